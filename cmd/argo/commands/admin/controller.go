@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// controllerPort is the port of the controller's health, synchronization and diagnostics endpoints
+const controllerPort = "6060"
+
+// controllerFlags select the controller pod, and authenticate to its diagnostics endpoints
+type controllerFlags struct {
+	pod      string // --pod
+	selector string // --selector
+	lease    string // --lease
+	token    string // --token
+}
+
+func (f *controllerFlags) addTo(command *cobra.Command) {
+	command.Flags().StringVar(&f.pod, "pod", "", "The controller pod. Defaults to the leader.")
+	command.Flags().StringVarP(&f.selector, "selector", "l", "app=workflow-controller", "The label selector of the controller pods, used if there is no leader.")
+	command.Flags().StringVar(&f.lease, "lease", "workflow-controller", "The name of the leader election lease, `workflow-controller-{instanceID}` if the controller has an instance ID.")
+	command.Flags().StringVar(&f.token, "token", os.Getenv("ARGO_DIAGNOSTICS_TOKEN"), "The controller's diagnostics token. Defaults to the ARGO_DIAGNOSTICS_TOKEN environment variable.")
+}
+
+// request returns a request to the controller pod's diagnostics endpoint, through the Kubernetes API's pod proxy
+func (f *controllerFlags) request(ctx context.Context, verb string, path ...string) (*restclient.Request, string, string, error) {
+	restConfig, err := client.GetConfig().ClientConfig()
+	if err != nil {
+		return nil, "", "", err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, "", "", err
+	}
+	namespace := client.Namespace()
+	pod := f.pod
+	if pod == "" {
+		pod, err = controllerPod(ctx, kubeClient, namespace, f.lease, f.selector)
+		if err != nil {
+			return nil, "", "", err
+		}
+	}
+	return kubeClient.CoreV1().RESTClient().Verb(verb).
+		Namespace(namespace).
+		Resource("pods").
+		Name(pod+":"+controllerPort).
+		SubResource("proxy").
+		Suffix(append([]string{"diagnostics"}, path...)...).
+		SetHeader(common.HeaderDiagnosticsToken, f.token), namespace, pod, nil
+}
+
+// controllerPod returns the name of the leading controller's pod, or, if there is no leader, of a running controller pod
+func controllerPod(ctx context.Context, kubeClient kubernetes.Interface, namespace, lease, selector string) (string, error) {
+	l, err := kubeClient.CoordinationV1().Leases(namespace).Get(ctx, lease, metav1.GetOptions{})
+	if err != nil && !apierr.IsNotFound(err) {
+		return "", err
+	}
+	if l != nil && l.Spec.HolderIdentity != nil && *l.Spec.HolderIdentity != "" {
+		return *l.Spec.HolderIdentity, nil
+	}
+	pods, err := kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "", err
+	}
+	for _, p := range pods.Items {
+		if p.Status.Phase == corev1.PodRunning {
+			return p.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no running controller pods in namespace %q with labels %q", namespace, selector)
+}
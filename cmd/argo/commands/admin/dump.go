@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/argoproj/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewDumpCommand() *cobra.Command {
+	var (
+		controller controllerFlags
+		output     string // --output
+	)
+	command := &cobra.Command{
+		Use:   "dump",
+		Short: "dump the work queues, last reconciliations, informer cache sizes and goroutines of the workflow controller",
+		Long: `Dump the diagnostics of the workflow controller into a gzipped tar, without restarting it.
+
+The controller must have ARGO_DIAGNOSTICS_TOKEN set, and you need permission to proxy to its pod.`,
+		Example: `# Dump the diagnostics of the leading controller in the "argo" namespace:
+
+  argo admin dump -n argo --token $ARGO_DIAGNOSTICS_TOKEN
+
+# Dump the diagnostics of a specific controller pod:
+
+  argo admin dump -n argo --pod workflow-controller-7f8d9c-abcde -o dump.tgz
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+			request, namespace, pod, err := controller.request(ctx, "GET", "dump")
+			errors.CheckError(err)
+			if output == "" {
+				output = fmt.Sprintf("argo-diagnostics-%s.tgz", time.Now().UTC().Format("20060102-150405"))
+			}
+			stream, err := request.Stream(ctx)
+			if err != nil {
+				errors.CheckError(fmt.Errorf("failed to dump the diagnostics of %s: %w", pod, err))
+			}
+			defer func() { _ = stream.Close() }()
+			errors.CheckError(writeFile(output, stream))
+			fmt.Printf("Dumped the diagnostics of %s/%s to %s\n", namespace, pod, output)
+		},
+	}
+	controller.addTo(command)
+	command.Flags().StringVarP(&output, "output", "o", "", "The file to write the dump to. Defaults to argo-diagnostics-{timestamp}.tgz.")
+	return command
+}
+
+func writeFile(name string, r io.Reader) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
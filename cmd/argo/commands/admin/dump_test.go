@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_controllerPod(t *testing.T) {
+	ctx := context.Background()
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pending", Namespace: "argo", Labels: map[string]string{"app": "workflow-controller"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "argo", Labels: map[string]string{"app": "workflow-controller"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	t.Run("Leader", func(t *testing.T) {
+		leader := "leader"
+		kubeClient := fake.NewSimpleClientset(running, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller", Namespace: "argo"},
+			Spec:       coordinationv1.LeaseSpec{HolderIdentity: &leader},
+		})
+		pod, err := controllerPod(ctx, kubeClient, "argo", "workflow-controller", "app=workflow-controller")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "leader", pod)
+		}
+	})
+	t.Run("NoLeader", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(pending, running)
+		pod, err := controllerPod(ctx, kubeClient, "argo", "workflow-controller", "app=workflow-controller")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "running", pod)
+		}
+	})
+	t.Run("NoPods", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(pending)
+		_, err := controllerPod(ctx, kubeClient, "argo", "workflow-controller", "app=workflow-controller")
+		assert.EqualError(t, err, `no running controller pods in namespace "argo" with labels "app=workflow-controller"`)
+	})
+}
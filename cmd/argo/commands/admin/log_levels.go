@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/argoproj/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func NewLogLevelsCommand() *cobra.Command {
+	var controller controllerFlags
+	command := &cobra.Command{
+		Use:   "log-levels [LEVELS]",
+		Short: "print or change the log levels of the workflow controller, without restarting it",
+		Long: `Print or change the log levels of the workflow controller, without restarting it.
+
+Levels are the default level, and the levels of components such as operator, artifact-gc and cron. They are reset
+when the controller restarts. The controller must have ARGO_DIAGNOSTICS_TOKEN set, and you need permission to proxy
+to its pod.`,
+		Example: `# Print the log levels of the leading controller in the "argo" namespace:
+
+  argo admin log-levels -n argo
+
+# Log the artifact garbage collection at debug level, and everything else at info level:
+
+  argo admin log-levels -n argo info,artifact-gc=debug
+`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+			verb, action := "GET", "get"
+			if len(args) == 1 {
+				verb, action = "PUT", "set"
+			}
+			request, _, _, err := controller.request(ctx, verb, "log-levels")
+			errors.CheckError(err)
+			if len(args) == 1 {
+				request = request.Body([]byte(args[0]))
+			}
+			data, err := request.DoRaw(ctx)
+			if err != nil {
+				errors.CheckError(fmt.Errorf("failed to %s the log levels: %w", action, err))
+			}
+			fmt.Println(string(data))
+		},
+	}
+	controller.addTo(command)
+	return command
+}
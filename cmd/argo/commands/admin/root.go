@@ -0,0 +1,19 @@
+package admin
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewAdminCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "admin",
+		Short: "administer the workflow controller, requires access to the Kubernetes API",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewDumpCommand())
+	command.AddCommand(NewLogLevelsCommand())
+	return command
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -36,7 +37,7 @@ func NewGetCommand() *cobra.Command {
 			printWorkflow(wf, output)
 		},
 	}
-	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide")
+	command.Flags().StringVarP(&output, "output", "o", "wide", "Output format. One of: json|yaml|wide|cost")
 	return command
 }
 
@@ -49,6 +50,8 @@ func printWorkflow(wf *wfv1.Workflow, output string) {
 			log.Fatal(err)
 		}
 		fmt.Println(string(output))
+	case "cost":
+		fmt.Print(common.PrintCostHelper(wf))
 	case "yaml":
 		output, err := yaml.Marshal(wf)
 		if err != nil {
@@ -82,6 +85,9 @@ func printWorkflow(wf *wfv1.Workflow, output string) {
 		if !wf.Status.StartedAt.IsZero() {
 			fmt.Printf(fmtStr, "Duration:", humanize.RelativeDuration(wf.Status.StartedAt.Time, wf.Status.FinishedAt.Time))
 		}
+		if wf.Status.Cost != nil {
+			fmt.Printf(fmtStr, "Cost:", wf.Status.Cost.Value)
+		}
 	}
 
 }
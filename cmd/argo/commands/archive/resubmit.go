@@ -16,11 +16,13 @@ import (
 )
 
 type resubmitOps struct {
-	priority      int32  // --priority
-	memoized      bool   // --memoized
-	namespace     string // --namespace
-	labelSelector string // --selector
-	fieldSelector string // --field-selector
+	priority             int32    // --priority
+	memoized             bool     // --memoized
+	namespace            string   // --namespace
+	labelSelector        string   // --selector
+	fieldSelector        string   // --field-selector
+	carryOverLabels      []string // --carry-over-labels
+	carryOverAnnotations []string // --carry-over-annotations
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -55,6 +57,10 @@ func NewResubmitCommand() *cobra.Command {
 
   argo archive resubmit --field-selector metadata.namespace=argo
 
+# Resubmit with a different parameter, only carrying over the labels and annotations of your team:
+
+  argo archive resubmit -p message=goodbye --carry-over-labels 'example.com/*' --carry-over-annotations 'example.com/*' uid
+
 # Resubmit and wait for completion:
 
   argo archive resubmit --wait uid
@@ -90,6 +96,8 @@ func NewResubmitCommand() *cobra.Command {
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&resubmitOpts.memoized, "memoized", false, "re-use successful steps & outputs from the previous run")
 	command.Flags().StringVarP(&resubmitOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	command.Flags().StringSliceVar(&resubmitOpts.carryOverLabels, "carry-over-labels", nil, "globs of the keys of the labels that carry over to the resubmitted workflow, e.g. 'example.com/*'. Defaults to all of them")
+	command.Flags().StringSliceVar(&resubmitOpts.carryOverAnnotations, "carry-over-annotations", nil, "globs of the keys of the annotations that carry over to the resubmitted workflow, e.g. 'example.com/*'. Defaults to all of them")
 	command.Flags().StringVar(&resubmitOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	return command
 }
@@ -128,11 +136,13 @@ func resubmitArchivedWorkflows(ctx context.Context, archiveServiceClient workflo
 		resubmittedUids[string(wf.UID)] = true
 
 		lastResubmitted, err = archiveServiceClient.ResubmitArchivedWorkflow(ctx, &workflowarchivepkg.ResubmitArchivedWorkflowRequest{
-			Uid:        string(wf.UID),
-			Namespace:  wf.Namespace,
-			Name:       wf.Name,
-			Memoized:   resubmitOpts.memoized,
-			Parameters: cliSubmitOpts.Parameters,
+			Uid:                  string(wf.UID),
+			Namespace:            wf.Namespace,
+			Name:                 wf.Name,
+			Memoized:             resubmitOpts.memoized,
+			Parameters:           cliSubmitOpts.Parameters,
+			CarryOverLabels:      resubmitOpts.carryOverLabels,
+			CarryOverAnnotations: resubmitOpts.carryOverAnnotations,
 		})
 		if err != nil {
 			return err
@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/term"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// bulkOps are the flags of commands that act on every workflow matched by a selector
+type bulkOps struct {
+	batchSize   int64 // --batch-size
+	parallelism int   // --parallelism
+}
+
+func (o *bulkOps) addFlags(command *cobra.Command) {
+	command.Flags().Int64Var(&o.batchSize, "batch-size", 500, "Number of workflows matched by a selector to list from the server at a time. Pass 0 to list them all at once.")
+	command.Flags().IntVar(&o.parallelism, "parallelism", 10, "Number of workflows to act on at a time")
+}
+
+// bulkAction acts on a workflow, and returns a message to print once it has
+type bulkAction func(ctx context.Context, wf wfv1.Workflow) (string, error)
+
+type bulkFailure struct {
+	wf  wfv1.Workflow
+	err error
+}
+
+// bulkOperation acts on many workflows, reporting progress as it goes
+type bulkOperation struct {
+	bulkOps
+	// verb is the past tense of the action, e.g. "stopped"
+	verb   string
+	action bulkAction
+	out    io.Writer
+	// progress is where to draw a progress bar, or nil to print each workflow's message to out instead
+	progress io.Writer
+	// allNamespaces is whether the workflows may be in different namespaces, so are told apart by namespace and name
+	allNamespaces bool
+
+	mu       sync.Mutex
+	done     int
+	total    int
+	failures []bulkFailure
+	seen     map[string]bool
+}
+
+func newBulkOperation(opts bulkOps, verb string, action bulkAction) *bulkOperation {
+	o := &bulkOperation{bulkOps: opts, verb: verb, action: action, out: os.Stdout, seen: map[string]bool{}}
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		o.progress = os.Stderr
+	}
+	return o
+}
+
+// run acts on the workflows matched by the selector in flags, if any, and then the named workflows. The matched
+// workflows are listed from the server a batch at a time, and each batch is acted on before the next is listed, so
+// that tens of thousands of workflows need not be listed up front. A workflow is only acted on once, even if it is
+// both matched and named. A failure does not stop the operation, instead the failures are summarised once it has
+// finished. It returns the number of workflows acted on.
+func (o *bulkOperation) run(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, flags *listFlags, named wfv1.Workflows) (int, error) {
+	defer o.endProgress()
+	if flags != nil {
+		listFlags := *flags
+		listFlags.chunkSize = o.batchSize
+		err := listWorkflowChunks(ctx, serviceClient, listFlags, func(batch wfv1.Workflows) error {
+			return o.runBatch(ctx, batch)
+		})
+		if err != nil {
+			return o.done, err
+		}
+	}
+	if err := o.runBatch(ctx, named); err != nil {
+		return o.done, err
+	}
+	return o.done, o.summarise()
+}
+
+func (o *bulkOperation) runBatch(ctx context.Context, batch wfv1.Workflows) error {
+	var todo wfv1.Workflows
+	for _, wf := range batch {
+		key := wf.Name
+		if o.allNamespaces {
+			key = wf.Namespace + "/" + wf.Name
+		}
+		if o.seen[key] {
+			// de-duplication in case there is an overlap between the selector and given workflow names
+			continue
+		}
+		o.seen[key] = true
+		todo = append(todo, wf)
+	}
+	o.total += len(todo)
+	o.drawProgress()
+	g := errgroup.Group{}
+	if o.parallelism > 0 {
+		g.SetLimit(o.parallelism)
+	} else {
+		g.SetLimit(1)
+	}
+	for _, wf := range todo {
+		wf := wf
+		g.Go(func() error {
+			message, err := o.action(ctx, wf)
+			o.mu.Lock()
+			defer o.mu.Unlock()
+			o.done++
+			if err != nil {
+				o.failures = append(o.failures, bulkFailure{wf, err})
+			} else if o.progress == nil && message != "" {
+				_, _ = fmt.Fprintln(o.out, strings.TrimSuffix(message, "\n"))
+			}
+			o.drawProgress()
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return ctx.Err()
+}
+
+func (o *bulkOperation) drawProgress() {
+	if o.progress == nil || o.total == 0 {
+		return
+	}
+	const width = 30
+	filled := width * o.done / o.total
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+	_, _ = fmt.Fprintf(o.progress, "\r[%s] %d/%d workflows %s, %d failed", bar, o.done, o.total, o.verb, len(o.failures))
+}
+
+func (o *bulkOperation) endProgress() {
+	if o.progress != nil && o.total > 0 {
+		_, _ = fmt.Fprintln(o.progress)
+	}
+}
+
+func (o *bulkOperation) summarise() error {
+	if len(o.failures) == 0 {
+		return nil
+	}
+	if len(o.failures) == 1 && o.done == 1 {
+		return o.failures[0].err
+	}
+	sort.Slice(o.failures, func(i, j int) bool {
+		return o.failures[i].wf.Name < o.failures[j].wf.Name
+	})
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%d of %d workflows could not be %s:", len(o.failures), o.done, o.verb)
+	for _, f := range o.failures {
+		_, _ = fmt.Fprintf(&b, "\n  %s: %v", f.wf.Name, f.err)
+	}
+	return errors.New(b.String())
+}
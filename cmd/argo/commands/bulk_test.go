@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_bulkOperation(t *testing.T) {
+	newClient := func() *workflowmocks.WorkflowServiceClient {
+		c := &workflowmocks.WorkflowServiceClient{}
+		c.On("ListWorkflows", mock.Anything, &workflowpkg.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "my-label=true", Limit: 2},
+			Fields:      defaultFields,
+		}).Return(&wfv1.WorkflowList{
+			ListMeta: metav1.ListMeta{Continue: "next"},
+			Items: wfv1.Workflows{
+				{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "argo"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "argo"}},
+			},
+		}, nil)
+		c.On("ListWorkflows", mock.Anything, &workflowpkg.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "my-label=true", Limit: 2, Continue: "next"},
+			Fields:      defaultFields,
+		}).Return(&wfv1.WorkflowList{
+			Items: wfv1.Workflows{
+				{ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: "argo"}},
+			},
+		}, nil)
+		return c
+	}
+	matched := &listFlags{namespace: "argo", labels: "my-label=true"}
+	named := wfv1.Workflows{
+		{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "argo"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "qux", Namespace: "argo"}},
+	}
+
+	t.Run("Batches", func(t *testing.T) {
+		c := newClient()
+		out := &bytes.Buffer{}
+		o := newBulkOperation(bulkOps{batchSize: 2}, "stopped", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
+			return fmt.Sprintf("workflow %s stopped", wf.Name), nil
+		})
+		o.out = out
+		o.progress = nil
+		done, err := o.run(context.Background(), c, matched, named)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 4, done)
+			assert.Equal(t, "workflow foo stopped\nworkflow bar stopped\nworkflow baz stopped\nworkflow qux stopped\n", out.String())
+			c.AssertNumberOfCalls(t, "ListWorkflows", 2)
+		}
+	})
+	t.Run("Failures", func(t *testing.T) {
+		o := newBulkOperation(bulkOps{batchSize: 2, parallelism: 3}, "stopped", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
+			if wf.Name == "bar" || wf.Name == "qux" {
+				return "", fmt.Errorf("mock error")
+			}
+			return "", nil
+		})
+		o.out = &bytes.Buffer{}
+		o.progress = nil
+		done, err := o.run(context.Background(), newClient(), matched, named)
+		assert.Equal(t, 4, done)
+		assert.EqualError(t, err, "2 of 4 workflows could not be stopped:\n  bar: mock error\n  qux: mock error")
+	})
+	t.Run("Progress", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		progress := &bytes.Buffer{}
+		o := newBulkOperation(bulkOps{}, "deleted", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
+			return "not printed", nil
+		})
+		o.out = out
+		o.progress = progress
+		_, err := o.run(context.Background(), &workflowmocks.WorkflowServiceClient{}, nil, named)
+		if assert.NoError(t, err) {
+			assert.Empty(t, out.String())
+			assert.Contains(t, progress.String(), "\r[==============================] 2/2 workflows deleted, 0 failed\n")
+		}
+	})
+	t.Run("AllNamespaces", func(t *testing.T) {
+		o := newBulkOperation(bulkOps{}, "deleted", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
+			return "", nil
+		})
+		o.allNamespaces = true
+		done, err := o.run(context.Background(), &workflowmocks.WorkflowServiceClient{}, nil, wfv1.Workflows{
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "argo"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "other"}},
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 2, done)
+		}
+	})
+}
@@ -25,15 +25,18 @@ var overrides = clientcmd.ConfigOverrides{}
 var (
 	explicitPath string
 	Offline      bool
+	OfflineFiles []string
 )
 
 func AddKubectlFlagsToCmd(cmd *cobra.Command) {
+	persistentFlags = cmd.PersistentFlags()
 	kflags := clientcmd.RecommendedConfigOverrideFlags("")
 	cmd.PersistentFlags().StringVar(&explicitPath, "kubeconfig", "", "Path to a kube config. Only required if out-of-cluster")
 	clientcmd.BindOverrideFlags(&overrides, cmd.PersistentFlags(), kflags)
 }
 
 func GetConfig() clientcmd.ClientConfig {
+	loadProfile()
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 	loadingRules.DefaultClientConfig = &clientcmd.DefaultClientConfig
 	loadingRules.ExplicitPath = explicitPath
@@ -41,6 +44,8 @@ func GetConfig() clientcmd.ClientConfig {
 }
 
 func AddAPIClientFlagsToCmd(cmd *cobra.Command) {
+	persistentFlags = cmd.PersistentFlags()
+	cmd.PersistentFlags().StringVar(&profileName, "profile", os.Getenv("ARGO_PROFILE"), "Name of the profile in the profiles file (ARGO_PROFILES_FILE, or ~/.argo/profiles.yaml) to connect with. Flags and environment variables take precedence over the profile. Defaults to the ARGO_PROFILE environment variable, or the file's default profile.")
 	cmd.PersistentFlags().StringVar(&instanceID, "instanceid", os.Getenv("ARGO_INSTANCEID"), "submit with a specific controller's instance id label. Default to the ARGO_INSTANCEID environment variable.")
 	// "-s" like kubectl
 	cmd.PersistentFlags().StringVarP(&ArgoServerOpts.URL, "argo-server", "s", os.Getenv("ARGO_SERVER"), "API server `host:port`. e.g. localhost:2746. Defaults to the ARGO_SERVER environment variable.")
@@ -54,6 +59,10 @@ func AddAPIClientFlagsToCmd(cmd *cobra.Command) {
 }
 
 func NewAPIClient(ctx context.Context) (context.Context, apiclient.Client) {
+	loadProfile()
+	// "--as" and "--as-group" are kubectl flags, used as they are by the Kubernetes API, and passed on by the Argo Server
+	ArgoServerOpts.ImpersonateUser = overrides.AuthInfo.Impersonate
+	ArgoServerOpts.ImpersonateGroups = overrides.AuthInfo.ImpersonateGroups
 	ctx, client, err := apiclient.NewClientFromOpts(
 		apiclient.Opts{
 			ArgoServerOpts: ArgoServerOpts,
@@ -63,6 +72,7 @@ func NewAPIClient(ctx context.Context) (context.Context, apiclient.Client) {
 			},
 			ClientConfigSupplier: func() clientcmd.ClientConfig { return GetConfig() },
 			Offline:              Offline,
+			OfflineFiles:         OfflineFiles,
 			Context:              ctx,
 		})
 	if err != nil {
@@ -75,6 +85,7 @@ func Namespace() string {
 	if Offline {
 		return ""
 	}
+	loadProfile()
 	if overrides.Context.Namespace != "" {
 		return overrides.Context.Namespace
 	}
@@ -90,10 +101,17 @@ func Namespace() string {
 }
 
 func GetAuthString() string {
+	loadProfile()
 	token, ok := os.LookupEnv("ARGO_TOKEN")
 	if ok {
 		return token
 	}
+	switch profile.AuthMode {
+	case AuthModeToken:
+		return profile.Token
+	case AuthModeNone:
+		return ""
+	}
 	restConfig, err := GetConfig().ClientConfig()
 	if err != nil {
 		log.Fatal(err)
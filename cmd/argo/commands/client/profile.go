@@ -0,0 +1,146 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AuthModeKubeConfig uses a token from the kube config, which is the default
+	AuthModeKubeConfig = "kubeconfig"
+	// AuthModeToken uses the token of the profile
+	AuthModeToken = "token"
+	// AuthModeNone does not send a token, e.g. for an Argo Server using the server auth mode
+	AuthModeNone = "none"
+)
+
+// Profile is a named set of options for connecting to Argo, so that they can be selected with `--profile` or
+// ARGO_PROFILE instead of setting many environment variables. Flags and environment variables take precedence over it.
+type Profile struct {
+	// ArgoServer is the Argo Server `host:port`. If empty, the Kubernetes API is used.
+	ArgoServer         string   `json:"argoServer,omitempty"`
+	BaseHRef           string   `json:"baseHRef,omitempty"`
+	HTTP1              *bool    `json:"http1,omitempty"`
+	Secure             *bool    `json:"secure,omitempty"`
+	InsecureSkipVerify *bool    `json:"insecureSkipVerify,omitempty"`
+	Headers            []string `json:"headers,omitempty"`
+	// AuthMode is one of "kubeconfig", "token" or "none"
+	AuthMode string `json:"authMode,omitempty"`
+	// Token is used with the "token" auth mode, e.g. "Bearer ..."
+	Token       string `json:"token,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	InstanceID  string `json:"instanceID,omitempty"`
+	KubeConfig  string `json:"kubeconfig,omitempty"`
+	KubeContext string `json:"kubeContext,omitempty"`
+}
+
+// Profiles is the content of the profiles file
+type Profiles struct {
+	// DefaultProfile is used when no profile is selected
+	DefaultProfile string             `json:"defaultProfile,omitempty"`
+	Profiles       map[string]Profile `json:"profiles,omitempty"`
+}
+
+var (
+	profileName string
+	profile     = Profile{}
+	profileOnce sync.Once
+	// the flags the profile must not override, because they were set explicitly
+	persistentFlags *pflag.FlagSet
+)
+
+// ProfilesPath returns the path of the profiles file, which is set by ARGO_PROFILES_FILE or is "~/.argo/profiles.yaml"
+func ProfilesPath() string {
+	if path, ok := os.LookupEnv("ARGO_PROFILES_FILE"); ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".argo", "profiles.yaml")
+}
+
+// ReadProfile returns the named profile from the profiles file, or the default profile if name is empty. It returns
+// nil if no profile is named and there is no default one.
+func ReadProfile(path, name string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && name == "" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles file: %w", err)
+	}
+	profiles := Profiles{}
+	if err := yaml.UnmarshalStrict(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles file %s: %w", path, err)
+	}
+	if name == "" {
+		name = profiles.DefaultProfile
+	}
+	if name == "" {
+		return nil, nil
+	}
+	p, ok := profiles.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+	switch p.AuthMode {
+	case "", AuthModeKubeConfig, AuthModeToken, AuthModeNone:
+	default:
+		return nil, fmt.Errorf("profile %q has invalid auth mode %q, must be one of: %s, %s, %s", name, p.AuthMode, AuthModeKubeConfig, AuthModeToken, AuthModeNone)
+	}
+	return &p, nil
+}
+
+// loadProfile applies the selected profile to the options that were not set by a flag or environment variable
+func loadProfile() {
+	profileOnce.Do(func() {
+		p, err := ReadProfile(ProfilesPath(), profileName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if p != nil {
+			p.apply(persistentFlags)
+			profile = *p
+		}
+	})
+}
+
+func (p Profile) apply(flags *pflag.FlagSet) {
+	isSet := func(flag, env string) bool {
+		if flags != nil && flags.Changed(flag) {
+			return true
+		}
+		_, ok := os.LookupEnv(env)
+		return env != "" && ok
+	}
+	setString := func(v *string, value, flag, env string) {
+		if value != "" && !isSet(flag, env) {
+			*v = value
+		}
+	}
+	setBool := func(v *bool, value *bool, flag, env string) {
+		if value != nil && !isSet(flag, env) {
+			*v = *value
+		}
+	}
+	setString(&ArgoServerOpts.URL, p.ArgoServer, "argo-server", "ARGO_SERVER")
+	setString(&ArgoServerOpts.Path, p.BaseHRef, "argo-base-href", "ARGO_BASE_HREF")
+	setBool(&ArgoServerOpts.HTTP1, p.HTTP1, "argo-http1", "ARGO_HTTP1")
+	setBool(&ArgoServerOpts.Secure, p.Secure, "secure", "ARGO_SECURE")
+	setBool(&ArgoServerOpts.InsecureSkipVerify, p.InsecureSkipVerify, "insecure-skip-verify", "ARGO_INSECURE_SKIP_VERIFY")
+	if len(p.Headers) > 0 && !isSet("header", "") {
+		ArgoServerOpts.Headers = p.Headers
+	}
+	setString(&overrides.Context.Namespace, p.Namespace, "namespace", "ARGO_NAMESPACE")
+	setString(&instanceID, p.InstanceID, "instanceid", "ARGO_INSTANCEID")
+	setString(&explicitPath, p.KubeConfig, "kubeconfig", "KUBECONFIG")
+	setString(&overrides.CurrentContext, p.KubeContext, "context", "")
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+)
+
+func TestReadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	t.Run("NoFile", func(t *testing.T) {
+		p, err := ReadProfile(path, "")
+		if assert.NoError(t, err) {
+			assert.Nil(t, p)
+		}
+		_, err = ReadProfile(path, "prod")
+		assert.Error(t, err)
+	})
+	err := os.WriteFile(path, []byte(`defaultProfile: dev
+profiles:
+  dev:
+    namespace: dev
+  prod:
+    argoServer: argo.example.com:443
+    secure: true
+    authMode: token
+    token: Bearer my-token
+  invalid:
+    authMode: password
+`), 0o600)
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Run("Default", func(t *testing.T) {
+		p, err := ReadProfile(path, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, &Profile{Namespace: "dev"}, p)
+		}
+	})
+	t.Run("Named", func(t *testing.T) {
+		p, err := ReadProfile(path, "prod")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "argo.example.com:443", p.ArgoServer)
+			assert.Equal(t, AuthModeToken, p.AuthMode)
+			assert.Equal(t, "Bearer my-token", p.Token)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadProfile(path, "missing")
+		assert.EqualError(t, err, `profile "missing" not found in `+path)
+	})
+	t.Run("InvalidAuthMode", func(t *testing.T) {
+		_, err := ReadProfile(path, "invalid")
+		assert.EqualError(t, err, `profile "invalid" has invalid auth mode "password", must be one of: kubeconfig, token, none`)
+	})
+}
+
+func TestProfile_apply(t *testing.T) {
+	defer func(opts apiclient.ArgoServerOpts, namespace string) {
+		ArgoServerOpts = opts
+		overrides.Context.Namespace = namespace
+	}(ArgoServerOpts, overrides.Context.Namespace)
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.StringVarP(&ArgoServerOpts.URL, "argo-server", "s", "", "")
+	flags.BoolVar(&ArgoServerOpts.Secure, "secure", true, "")
+	flags.BoolVar(&ArgoServerOpts.HTTP1, "argo-http1", false, "")
+	if !assert.NoError(t, flags.Parse([]string{"--argo-server", "localhost:2746"})) {
+		return
+	}
+	_ = os.Setenv("ARGO_NAMESPACE", "my-ns")
+	defer func() { _ = os.Unsetenv("ARGO_NAMESPACE") }()
+	no, yes := false, true
+	Profile{ArgoServer: "argo.example.com:443", Secure: &no, HTTP1: &yes, Namespace: "argo"}.apply(flags)
+	// the flag and environment variable take precedence
+	assert.Equal(t, "localhost:2746", ArgoServerOpts.URL)
+	assert.Empty(t, overrides.Context.Namespace)
+	assert.False(t, ArgoServerOpts.Secure)
+	assert.True(t, ArgoServerOpts.HTTP1)
+}
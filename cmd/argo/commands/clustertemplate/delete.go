@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 )
 
@@ -24,6 +25,7 @@ func NewDeleteCommand() *cobra.Command {
 	}
 
 	command.Flags().BoolVar(&all, "all", false, "Delete all cluster workflow templates")
+	command.ValidArgsFunction = common.CompleteClusterWorkflowTemplateNames()
 	return command
 }
 
@@ -10,12 +10,16 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func NewGetCommand() *cobra.Command {
-	var output string
+	var (
+		output   string
+		revision int64
+	)
 
 	command := &cobra.Command{
 		Use:   "get CLUSTER WORKFLOW_TEMPLATE...",
@@ -28,7 +32,8 @@ func NewGetCommand() *cobra.Command {
 			}
 			for _, name := range args {
 				wftmpl, err := serviceClient.GetClusterWorkflowTemplate(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest{
-					Name: name,
+					Name:     name,
+					Revision: revision,
 				})
 				if err != nil {
 					log.Fatal(err)
@@ -39,6 +44,8 @@ func NewGetCommand() *cobra.Command {
 	}
 
 	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml|wide")
+	command.Flags().Int64Var(&revision, "revision", 0, "Display a saved revision, rather than the latest")
+	command.ValidArgsFunction = common.CompleteClusterWorkflowTemplateNames()
 	return command
 }
 
@@ -63,4 +70,7 @@ func printClusterWorkflowTemplateHelper(wf *wfv1.ClusterWorkflowTemplate) {
 	const fmtStr = "%-20s %v\n"
 	fmt.Printf(fmtStr, "Name:", wf.ObjectMeta.Name)
 	fmt.Printf(fmtStr, "Created:", humanize.Timestamp(wf.ObjectMeta.CreationTimestamp.Time))
+	if wf.Generation > 0 {
+		fmt.Printf(fmtStr, "Revision:", wf.Generation)
+	}
 }
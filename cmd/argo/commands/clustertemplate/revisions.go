@@ -0,0 +1,50 @@
+package clustertemplate
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/argoproj/pkg/humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+)
+
+func NewRevisionsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "revisions CLUSTER_WORKFLOW_TEMPLATE",
+		Short: "list the saved revisions of a cluster workflow template",
+		Long:  "List the saved revisions of a cluster workflow template, oldest first. Revisions are only saved if the controller is configured to save them.",
+		Example: `# List the revisions of a cluster workflow template:
+
+  argo cluster-template revisions my-cwftmpl
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			revisions, err := serviceClient.ListClusterWorkflowTemplates(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateListRequest{
+				RevisionsOf: args[0],
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+			_, _ = fmt.Fprintln(w, "REVISION\tSAVED")
+			for _, revision := range revisions.Items {
+				_, _ = fmt.Fprintf(w, "%d\t%s\n", revision.Generation, humanize.RelativeDurationShort(revision.CreationTimestamp.Time, time.Now()))
+			}
+			_ = w.Flush()
+		},
+	}
+	command.ValidArgsFunction = common.CompleteClusterWorkflowTemplateNames()
+	return command
+}
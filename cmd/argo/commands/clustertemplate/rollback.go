@@ -0,0 +1,53 @@
+package clustertemplate
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+)
+
+func NewRollbackCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "rollback CLUSTER_WORKFLOW_TEMPLATE REVISION",
+		Short: "roll back a cluster workflow template to a previous revision",
+		Long:  "Roll back a cluster workflow template to a previous revision, by updating its spec to that of the revision. This saves a new revision, so it can also be undone.",
+		Example: `# Roll back a cluster workflow template to revision 3:
+
+  argo cluster-template rollback my-cwftmpl 3
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			revision, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil || revision <= 0 {
+				log.Fatalf("invalid revision %q", args[1])
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			latest, err := serviceClient.GetClusterWorkflowTemplate(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest{Name: args[0]})
+			if err != nil {
+				log.Fatal(err)
+			}
+			previous, err := serviceClient.GetClusterWorkflowTemplate(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest{Name: args[0], Revision: revision})
+			if err != nil {
+				log.Fatal(err)
+			}
+			latest.Spec = previous.Spec
+			updated, err := serviceClient.UpdateClusterWorkflowTemplate(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateUpdateRequest{Template: latest})
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("ClusterWorkflowTemplate '%s' rolled back to revision %d, as revision %d\n", updated.Name, revision, updated.Generation)
+		},
+	}
+	command.ValidArgsFunction = common.CompleteClusterWorkflowTemplateNames()
+	return command
+}
@@ -19,6 +19,9 @@ func NewClusterTemplateCommand() *cobra.Command {
 	command.AddCommand(NewCreateCommand())
 	command.AddCommand(NewDeleteCommand())
 	command.AddCommand(NewLintCommand())
+	command.AddCommand(NewRevisionsCommand())
+	command.AddCommand(NewDiffRevisionsCommand())
+	command.AddCommand(NewRollbackCommand())
 
 	return command
 }
@@ -0,0 +1,304 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// CompletionFunc completes the arguments, or the value of a flag, of a command
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// listNamesFunc lists the names of resources in the namespace
+type listNamesFunc func(ctx context.Context, apiClient apiclient.Client, namespace string) ([]string, error)
+
+// completeNames completes the arguments of a command with the names listed, other than those that are already arguments
+func completeNames(list listNamesFunc) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		ctx, apiClient := client.NewAPIClient(cmd.Context())
+		names, err := list(ctx, apiClient, client.Namespace())
+		if err != nil {
+			cobra.CompDebugln(err.Error(), true)
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return filterCompletions(names, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// filterCompletions returns the completions that start with toComplete, other than those that are already arguments
+func filterCompletions(completions []string, args []string, toComplete string) []string {
+	given := map[string]bool{}
+	for _, arg := range args {
+		given[arg] = true
+	}
+	var filtered []string
+	for _, completion := range completions {
+		if strings.HasPrefix(completion, toComplete) && !given[completion] {
+			filtered = append(filtered, completion)
+		}
+	}
+	return filtered
+}
+
+// CompleteWorkflowNames completes the names of the workflows in the namespace, that are in any of the phases, if given
+func CompleteWorkflowNames(phases ...wfv1.WorkflowPhase) CompletionFunc {
+	return completeNames(func(ctx context.Context, apiClient apiclient.Client, namespace string) ([]string, error) {
+		return listWorkflowNames(ctx, apiClient.NewWorkflowServiceClient(), namespace, phases...)
+	})
+}
+
+func listWorkflowNames(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, phases ...wfv1.WorkflowPhase) ([]string, error) {
+	listOpts := &metav1.ListOptions{}
+	if len(phases) > 0 {
+		var values []string
+		for _, phase := range phases {
+			values = append(values, string(phase))
+		}
+		req, err := labels.NewRequirement(common.LabelKeyPhase, selection.In, values)
+		if err != nil {
+			return nil, err
+		}
+		listOpts.LabelSelector = req.String()
+	}
+	list, err := serviceClient.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
+		Namespace:   namespace,
+		ListOptions: listOpts,
+		Fields:      "items.metadata.name",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, wf := range list.Items {
+		names = append(names, wf.Name)
+	}
+	return names, nil
+}
+
+// CompleteWorkflowTemplateNames completes the names of the workflow templates in the namespace
+func CompleteWorkflowTemplateNames() CompletionFunc {
+	return completeNames(listWorkflowTemplateNames)
+}
+
+func listWorkflowTemplateNames(ctx context.Context, apiClient apiclient.Client, namespace string) ([]string, error) {
+	serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := serviceClient.ListWorkflowTemplates(ctx, &workflowtemplatepkg.WorkflowTemplateListRequest{Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, wftmpl := range list.Items {
+		names = append(names, wftmpl.Name)
+	}
+	return names, nil
+}
+
+// CompleteClusterWorkflowTemplateNames completes the names of the cluster workflow templates
+func CompleteClusterWorkflowTemplateNames() CompletionFunc {
+	return completeNames(listClusterWorkflowTemplateNames)
+}
+
+func listClusterWorkflowTemplateNames(ctx context.Context, apiClient apiclient.Client, _ string) ([]string, error) {
+	serviceClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := serviceClient.ListClusterWorkflowTemplates(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, cwftmpl := range list.Items {
+		names = append(names, cwftmpl.Name)
+	}
+	return names, nil
+}
+
+// CompleteCronWorkflowNames completes the names of the cron workflows in the namespace
+func CompleteCronWorkflowNames() CompletionFunc {
+	return completeNames(listCronWorkflowNames)
+}
+
+func listCronWorkflowNames(ctx context.Context, apiClient apiclient.Client, namespace string) ([]string, error) {
+	serviceClient, err := apiClient.NewCronWorkflowServiceClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := serviceClient.ListCronWorkflows(ctx, &cronworkflowpkg.ListCronWorkflowsRequest{Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, cronWf := range list.Items {
+		names = append(names, cronWf.Name)
+	}
+	return names, nil
+}
+
+// the kinds of resource that can be submitted with `argo submit --from`, and how to list their names
+var submittableKinds = map[string]listNamesFunc{
+	workflow.WorkflowTemplateSingular:        listWorkflowTemplateNames,
+	workflow.ClusterWorkflowTemplateSingular: listClusterWorkflowTemplateNames,
+	workflow.CronWorkflowSingular:            listCronWorkflowNames,
+}
+
+// CompleteSubmitFrom completes the `kind/name` of the resource to submit a workflow from
+func CompleteSubmitFrom() CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		parts := strings.SplitN(toComplete, "/", 2)
+		if len(parts) != 2 {
+			var kinds []string
+			for kind := range submittableKinds {
+				kinds = append(kinds, kind+"/")
+			}
+			sort.Strings(kinds)
+			return filterCompletions(kinds, nil, toComplete), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+		}
+		list, ok := submittableKinds[normalizeKind(parts[0])]
+		if !ok {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names, directive := completeNames(list)(cmd, nil, parts[1])
+		var completions []string
+		for _, name := range names {
+			completions = append(completions, parts[0]+"/"+name)
+		}
+		return completions, directive
+	}
+}
+
+// normalizeKind returns the singular of any of the names of a kind that can be submitted
+func normalizeKind(kind string) string {
+	switch kind {
+	case workflow.WorkflowTemplateKind, workflow.WorkflowTemplateSingular, workflow.WorkflowTemplatePlural, workflow.WorkflowTemplateShortName:
+		return workflow.WorkflowTemplateSingular
+	case workflow.ClusterWorkflowTemplateKind, workflow.ClusterWorkflowTemplateSingular, workflow.ClusterWorkflowTemplatePlural, workflow.ClusterWorkflowTemplateShortName:
+		return workflow.ClusterWorkflowTemplateSingular
+	case workflow.CronWorkflowKind, workflow.CronWorkflowSingular, workflow.CronWorkflowPlural, workflow.CronWorkflowShortName:
+		return workflow.CronWorkflowSingular
+	}
+	return kind
+}
+
+// CompleteSubmitParameters completes `NAME=` for the parameters declared by the workflows to submit, which are either
+// the files given as arguments, or the resource given by the `--from` flag. If the parameter has an enum, then its
+// values are completed too.
+func CompleteSubmitParameters() CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var params []wfv1.Parameter
+		from, _ := cmd.Flags().GetString("from")
+		if from != "" {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			var err error
+			params, err = getSubmitFromParameters(ctx, apiClient, client.Namespace(), from)
+			if err != nil {
+				cobra.CompDebugln(err.Error(), true)
+				return nil, cobra.ShellCompDirectiveError
+			}
+		} else {
+			params = getFileParameters(args)
+		}
+		return parameterCompletions(params, toComplete)
+	}
+}
+
+func parameterCompletions(params []wfv1.Parameter, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var completions []string
+	if name, _, ok := strings.Cut(toComplete, "="); ok {
+		for _, param := range params {
+			if param.Name == name {
+				for _, value := range param.Enum {
+					completions = append(completions, fmt.Sprintf("%s=%s", name, value))
+				}
+			}
+		}
+		return filterCompletions(completions, nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	for _, param := range params {
+		completions = append(completions, param.Name+"=")
+	}
+	return filterCompletions(completions, nil, toComplete), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+// getFileParameters returns the parameters declared by the workflows in the files, ignoring any that cannot be read
+func getFileParameters(filePaths []string) []wfv1.Parameter {
+	var params []wfv1.Parameter
+	for _, filePath := range filePaths {
+		fileContents, err := util.ReadManifest(filePath)
+		if err != nil {
+			continue
+		}
+		for _, body := range fileContents {
+			wfs, err := common.SplitWorkflowYAMLFile(body, false)
+			if err != nil {
+				continue
+			}
+			for _, wf := range wfs {
+				params = append(params, wf.Spec.Arguments.Parameters...)
+			}
+		}
+	}
+	return params
+}
+
+// getSubmitFromParameters returns the parameters declared by the resource to submit a workflow from
+func getSubmitFromParameters(ctx context.Context, apiClient apiclient.Client, namespace, from string) ([]wfv1.Parameter, error) {
+	parts := strings.SplitN(from, "/", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("resource identifier '%s' is malformed. Should be `kind/name`, e.g. cronwf/hello-world-cwf", from)
+	}
+	name := parts[1]
+	switch normalizeKind(parts[0]) {
+	case workflow.WorkflowTemplateSingular:
+		serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+		if err != nil {
+			return nil, err
+		}
+		wftmpl, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: name, Namespace: namespace})
+		if err != nil {
+			return nil, err
+		}
+		return wftmpl.Spec.Arguments.Parameters, nil
+	case workflow.ClusterWorkflowTemplateSingular:
+		serviceClient, err := apiClient.NewClusterWorkflowTemplateServiceClient()
+		if err != nil {
+			return nil, err
+		}
+		cwftmpl, err := serviceClient.GetClusterWorkflowTemplate(ctx, &clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		return cwftmpl.Spec.Arguments.Parameters, nil
+	case workflow.CronWorkflowSingular:
+		serviceClient, err := apiClient.NewCronWorkflowServiceClient()
+		if err != nil {
+			return nil, err
+		}
+		cronWf, err := serviceClient.GetCronWorkflow(ctx, &cronworkflowpkg.GetCronWorkflowRequest{Name: name, Namespace: namespace})
+		if err != nil {
+			return nil, err
+		}
+		return cronWf.Spec.WorkflowSpec.Arguments.Parameters, nil
+	}
+	return nil, fmt.Errorf("resource kind '%s' is not supported for submitting", parts[0])
+}
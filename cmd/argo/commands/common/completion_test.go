@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_filterCompletions(t *testing.T) {
+	assert.Equal(t, []string{"my-wf-2"}, filterCompletions([]string{"my-wf-1", "my-wf-2", "other-wf"}, []string{"my-wf-1"}, "my-"))
+	assert.Empty(t, filterCompletions([]string{"my-wf-1"}, nil, "other-"))
+}
+
+func Test_listWorkflowNames(t *testing.T) {
+	c := &workflowmocks.WorkflowServiceClient{}
+	c.On("ListWorkflows", mock.Anything, &workflowpkg.WorkflowListRequest{
+		Namespace:   "argo",
+		ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/phase in (Error,Failed)"},
+		Fields:      "items.metadata.name",
+	}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+		{ObjectMeta: metav1.ObjectMeta{Name: "foo"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bar"}},
+	}}, nil)
+	names, err := listWorkflowNames(context.Background(), c, "argo", wfv1.WorkflowFailed, wfv1.WorkflowError)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"foo", "bar"}, names)
+	}
+}
+
+func Test_parameterCompletions(t *testing.T) {
+	params := []wfv1.Parameter{
+		{Name: "message"},
+		{Name: "mode", Enum: []wfv1.AnyString{"fast", "slow"}},
+		{Name: "other"},
+	}
+	t.Run("Names", func(t *testing.T) {
+		completions, directive := parameterCompletions(params, "m")
+		assert.Equal(t, []string{"message=", "mode="}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoSpace|cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+	t.Run("Enum", func(t *testing.T) {
+		completions, directive := parameterCompletions(params, "mode=f")
+		assert.Equal(t, []string{"mode=fast"}, completions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+	t.Run("NoEnum", func(t *testing.T) {
+		completions, _ := parameterCompletions(params, "message=")
+		assert.Empty(t, completions)
+	})
+}
+
+func Test_getFileParameters(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "wf.yaml")
+	err := os.WriteFile(file, []byte(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: my-wf-
+spec:
+  arguments:
+    parameters:
+      - name: message
+        value: hello
+`), 0o600)
+	if assert.NoError(t, err) {
+		params := getFileParameters([]string{file, "does-not-exist.yaml"})
+		if assert.Len(t, params, 1) {
+			assert.Equal(t, "message", params[0].Name)
+		}
+	}
+}
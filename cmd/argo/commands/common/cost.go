@@ -0,0 +1,53 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// PrintCostHelper prints the cost, and the estimated cost, of the workflow and each of its pods
+func PrintCostHelper(wf *wfv1.Workflow) string {
+	const fmtStr = "%-20s %v\n"
+	out := ""
+	out += fmt.Sprintf(fmtStr, "Name:", wf.ObjectMeta.Name)
+	out += fmt.Sprintf(fmtStr, "Namespace:", wf.ObjectMeta.Namespace)
+	if wf.Status.Cost == nil && wf.Status.EstimatedCost == nil {
+		out += "\nThis workflow has no cost. The workflow controller must be configured with pricing.\n"
+		return out
+	}
+	out += fmt.Sprintf(fmtStr, "Cost:", amountString(wf.Status.Cost))
+	out += fmt.Sprintf(fmtStr, "EstimatedCost:", amountString(wf.Status.EstimatedCost))
+
+	var nodes []wfv1.NodeStatus
+	for _, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if !nodes[i].StartedAt.Equal(&nodes[j].StartedAt) {
+			return nodes[i].StartedAt.Before(&nodes[j].StartedAt)
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	out += "\n"
+	writerBuffer := new(bytes.Buffer)
+	w := tabwriter.NewWriter(writerBuffer, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "STEP\tTEMPLATE\tPHASE\tRESOURCES DURATION\tESTIMATED COST\tCOST")
+	for _, node := range nodes {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", node.DisplayName, node.TemplateName, node.Phase, node.ResourcesDuration, amountString(node.EstimatedCost), amountString(node.Cost))
+	}
+	_ = w.Flush()
+	return out + writerBuffer.String()
+}
+
+func amountString(amount *wfv1.Amount) string {
+	if amount == nil {
+		return "-"
+	}
+	return string(amount.Value)
+}
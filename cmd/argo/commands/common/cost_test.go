@@ -0,0 +1,34 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestPrintCostHelper(t *testing.T) {
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}}
+	assert.Contains(t, PrintCostHelper(wf), "This workflow has no cost")
+
+	wf.Status = wfv1.WorkflowStatus{
+		Cost:          wfv1.NewAmount(0.5, 4),
+		EstimatedCost: wfv1.NewAmount(1.25, 4),
+		Nodes: wfv1.Nodes{
+			"my-wf":   {Name: "my-wf", DisplayName: "my-wf", Type: wfv1.NodeTypeDAG},
+			"my-wf-1": {Name: "my-wf.b", DisplayName: "b", Type: wfv1.NodeTypePod, TemplateName: "main", Phase: wfv1.NodeRunning, EstimatedCost: wfv1.NewAmount(0.75, 4)},
+			"my-wf-2": {Name: "my-wf.a", DisplayName: "a", Type: wfv1.NodeTypePod, TemplateName: "main", Phase: wfv1.NodeSucceeded, EstimatedCost: wfv1.NewAmount(0.4, 4), Cost: wfv1.NewAmount(0.5, 4)},
+		},
+	}
+	assert.Equal(t, `Name:                my-wf
+Namespace:           my-ns
+Cost:                0.5000
+EstimatedCost:       1.2500
+
+STEP  TEMPLATE  PHASE      RESOURCES DURATION  ESTIMATED COST  COST
+a     main      Succeeded                      0.4000          0.5000
+b     main      Running                        0.7500          -
+`, PrintCostHelper(wf))
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"encoding/json"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// fields of the metadata that are set by the cluster, rather than by a manifest
+var liveMetadataFields = []string{"creationTimestamp", "deletionGracePeriodSeconds", "deletionTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"}
+
+// labels and annotations that are set by the cluster, the Argo Server or kubectl, rather than by a manifest
+var liveLabels = []string{common.LabelKeyCreator, common.LabelKeyCreatorEmail, common.LabelKeyCreatorPreferredUsername}
+var liveAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration"}
+
+// Diff returns a unified diff of the live object, as it is in the cluster, and the local object, as it is in a
+// manifest, or "" if they are the same. The fields that are set by the cluster rather than by the manifest, such as
+// the status, are ignored, as are fields with default (i.e. empty) values. live is nil if the object does not exist.
+func Diff(liveName string, live interface{}, localName string, local interface{}) (string, error) {
+	liveText, err := diffText(live)
+	if err != nil {
+		return "", err
+	}
+	localText, err := diffText(local)
+	if err != nil {
+		return "", err
+	}
+	if liveText == localText {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveText),
+		B:        difflib.SplitLines(localText),
+		FromFile: liveName,
+		ToFile:   localName,
+		Context:  3,
+	})
+}
+
+// diffText returns the object as YAML, without the fields that are set by the cluster
+func diffText(obj interface{}) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	var x map[string]interface{}
+	if err := json.Unmarshal(data, &x); err != nil {
+		return "", err
+	}
+	if x == nil {
+		return "", nil
+	}
+	delete(x, "status")
+	if metadata, ok := x["metadata"].(map[string]interface{}); ok {
+		for _, field := range liveMetadataFields {
+			delete(metadata, field)
+		}
+		deleteKeys(metadata, "labels", liveLabels)
+		deleteKeys(metadata, "annotations", liveAnnotations)
+	}
+	data, err = yaml.Marshal(x)
+	return string(data), err
+}
+
+// deleteKeys deletes the keys from the map obj[field], and the map itself if it is then empty
+func deleteKeys(obj map[string]interface{}, field string, keys []string) {
+	m, ok := obj[field].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, key := range keys {
+		delete(m, key)
+	}
+	if len(m) == 0 {
+		delete(obj, field)
+	}
+}
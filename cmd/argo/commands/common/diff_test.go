@@ -0,0 +1,49 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestDiff(t *testing.T) {
+	local := &wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wftmpl", Namespace: "argo", Labels: map[string]string{"team": "a"}},
+		Spec:       wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Suspend: &wfv1.SuspendTemplate{}}}},
+	}
+	live := local.DeepCopy()
+	live.ResourceVersion = "123"
+	live.UID = "my-uid"
+	live.Generation = 2
+	live.CreationTimestamp = metav1.Now()
+	live.Labels[common.LabelKeyCreator] = "my-user"
+	live.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": "{}"}
+
+	t.Run("Same", func(t *testing.T) {
+		diff, err := Diff("live", live, "local", local)
+		if assert.NoError(t, err) {
+			assert.Empty(t, diff)
+		}
+	})
+	t.Run("Different", func(t *testing.T) {
+		changed := local.DeepCopy()
+		changed.Spec.Templates[0].Suspend.Duration = "10s"
+		diff, err := Diff("live", live, "local", changed)
+		if assert.NoError(t, err) {
+			assert.Contains(t, diff, "--- live\n+++ local\n")
+			assert.Contains(t, diff, "-    suspend: {}\n+    suspend:\n+      duration: 10s\n")
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var notFound *wfv1.WorkflowTemplate
+		diff, err := Diff("live", notFound, "local", local)
+		if assert.NoError(t, err) {
+			assert.Contains(t, diff, "+  name: my-wftmpl\n")
+			assert.NotContains(t, diff, "\n-")
+		}
+	})
+}
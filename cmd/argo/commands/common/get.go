@@ -93,6 +93,12 @@ func PrintWorkflowHelper(wf *wfv1.Workflow, getArgs GetFlags) string {
 	if !wf.Status.ResourcesDuration.IsZero() {
 		out += fmt.Sprintf(fmtStr, "ResourcesDuration:", wf.Status.ResourcesDuration)
 	}
+	if wf.Status.Cost != nil {
+		out += fmt.Sprintf(fmtStr, "Cost:", wf.Status.Cost.Value)
+	}
+	if wf.Status.Phase == wfv1.WorkflowRunning && wf.Status.EstimatedCost != nil {
+		out += fmt.Sprintf(fmtStr, "EstimatedCost:", wf.Status.EstimatedCost.Value)
+	}
 	if len(wf.GetExecSpec().Arguments.Parameters) > 0 {
 		out += fmt.Sprintf(fmtStr, "Parameters:", "")
 		for _, param := range wf.GetExecSpec().Arguments.Parameters {
@@ -225,7 +231,7 @@ func isNonBoundaryParentNode(node wfv1.NodeType) bool {
 }
 
 func isExecutionNode(node wfv1.NodeType) bool {
-	return (node == wfv1.NodeTypePod) || (node == wfv1.NodeTypeSkipped) || (node == wfv1.NodeTypeSuspend) || (node == wfv1.NodeTypeHTTP) || (node == wfv1.NodeTypePlugin)
+	return (node == wfv1.NodeTypePod) || (node == wfv1.NodeTypeSkipped) || (node == wfv1.NodeTypeSuspend) || (node == wfv1.NodeTypeHTTP) || (node == wfv1.NodeTypePlugin) || (node == wfv1.NodeTypeSQLQuery) || (node == wfv1.NodeTypeNotification)
 }
 
 func insertSorted(wf *wfv1.Workflow, sortedArray []renderNode, item renderNode) []renderNode {
@@ -2,8 +2,10 @@ package common
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/argoproj/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -12,15 +14,20 @@ import (
 )
 
 func LogWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, workflow, podName, grep, selector string, logOptions *corev1.PodLogOptions) {
-	// logs
-	stream, err := serviceClient.WorkflowLogs(ctx, &workflowpkg.WorkflowLogRequest{
+	LogWorkflowWithRequest(ctx, serviceClient, &workflowpkg.WorkflowLogRequest{
 		Name:       workflow,
 		Namespace:  namespace,
 		PodName:    podName,
 		LogOptions: logOptions,
 		Selector:   selector,
 		Grep:       grep,
-	})
+	}, "")
+}
+
+// LogWorkflowWithRequest prints the log lines of the workflow as text, or as JSON records if the output is "json"
+func LogWorkflowWithRequest(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, req *workflowpkg.WorkflowLogRequest, output string) {
+	// logs
+	stream, err := serviceClient.WorkflowLogs(ctx, req)
 	errors.CheckError(err)
 
 	// loop on log lines
@@ -30,6 +37,17 @@ func LogWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceC
 			return
 		}
 		errors.CheckError(err)
-		fmt.Println(ansiFormat(fmt.Sprintf("%s: %s", event.PodName, event.Content), ansiColorCode(event.PodName)))
+		printLogEntry(os.Stdout, event, output)
+	}
+}
+
+func printLogEntry(w io.Writer, event *workflowpkg.LogEntry, output string) {
+	switch output {
+	case "json":
+		data, err := json.Marshal(event)
+		errors.CheckError(err)
+		fmt.Fprintln(w, string(data))
+	default:
+		fmt.Fprintln(w, ansiFormat(fmt.Sprintf("%s: %s", event.PodName, event.Content), ansiColorCode(event.PodName)))
 	}
 }
@@ -0,0 +1,33 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+)
+
+func Test_printLogEntry(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+	event := &workflowpkg.LogEntry{
+		PodName:   "my-pod",
+		Content:   "hello",
+		Container: "main",
+		Timestamp: &metav1.MicroTime{Time: time.Date(2022, 10, 1, 9, 0, 0, 123456000, time.UTC)},
+	}
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		printLogEntry(&buf, event, "")
+		assert.Equal(t, "my-pod: hello\n", buf.String())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		printLogEntry(&buf, event, "json")
+		assert.Equal(t, `{"content":"hello","podName":"my-pod","container":"main","timestamp":"2022-10-01T09:00:00.123456Z"}`+"\n", buf.String())
+	})
+}
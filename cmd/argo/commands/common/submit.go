@@ -7,19 +7,26 @@ import (
 
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
 // cliSubmitOpts holds submission options specific to CLI submission (e.g. controlling output)
 type CliSubmitOpts struct {
-	Output        string // --output
-	Wait          bool   // --wait
-	Watch         bool   // --watch
-	Log           bool   // --log
-	Strict        bool   // --strict
-	Priority      *int32 // --priority
-	GetArgs       GetFlags
-	ScheduledTime string   // --scheduled-time
-	Parameters    []string // --parameter
+	Output          string // --output
+	Wait            bool   // --wait
+	Watch           bool   // --watch
+	Log             bool   // --log
+	Strict          bool   // --strict
+	Priority        *int32 // --priority
+	GetArgs         GetFlags
+	ScheduledTime   string   // --scheduled-time
+	Parameters      []string // --parameter
+	OutputArtifacts string   // --output-artifacts
+	SetValues       []string // --set
+	SetStringValues []string // --set-string
+	// ParameterValues are the values from --parameter-file, --parameter, --set and --set-string, to be merged into
+	// each workflow's arguments
+	ParameterValues util.ParameterValues
 }
 
 func WaitWatchOrLog(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflowNames []string, cliSubmitOpts CliSubmitOpts) {
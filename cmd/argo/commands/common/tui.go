@@ -0,0 +1,491 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/argoproj/pkg/humanize"
+	"golang.org/x/term"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+const (
+	tuiMaxLogLines = 1000
+	tuiHelp        = "↑/↓ select node  r retry  t terminate  q quit"
+
+	enterAltScreen = escape + "[?1049h" + escape + "[?25l"
+	leaveAltScreen = escape + "[?25h" + escape + "[?1049l"
+	cursorHome     = escape + "[H"
+	clearLine      = escape + "[K"
+	clearToEnd     = escape + "[J"
+)
+
+type tuiKey string
+
+const (
+	tuiKeyUp        tuiKey = "up"
+	tuiKeyDown      tuiKey = "down"
+	tuiKeyHome      tuiKey = "home"
+	tuiKeyEnd       tuiKey = "end"
+	tuiKeyRetry     tuiKey = "retry"
+	tuiKeyTerminate tuiKey = "terminate"
+	tuiKeyYes       tuiKey = "yes"
+	tuiKeyNo        tuiKey = "no"
+	tuiKeyQuit      tuiKey = "quit"
+)
+
+// parseTUIKeys converts the bytes read from a terminal in raw mode to keys, ignoring unknown keys
+func parseTUIKeys(b []byte) []tuiKey {
+	var keys []tuiKey
+	for i := 0; i < len(b); i++ {
+		if b[i] == 0x1b && i+2 < len(b) && (b[i+1] == '[' || b[i+1] == 'O') {
+			switch b[i+2] {
+			case 'A':
+				keys = append(keys, tuiKeyUp)
+			case 'B':
+				keys = append(keys, tuiKeyDown)
+			case 'H':
+				keys = append(keys, tuiKeyHome)
+			case 'F':
+				keys = append(keys, tuiKeyEnd)
+			}
+			i += 2
+			continue
+		}
+		switch b[i] {
+		case 'k':
+			keys = append(keys, tuiKeyUp)
+		case 'j':
+			keys = append(keys, tuiKeyDown)
+		case 'g':
+			keys = append(keys, tuiKeyHome)
+		case 'G':
+			keys = append(keys, tuiKeyEnd)
+		case 'r':
+			keys = append(keys, tuiKeyRetry)
+		case 't':
+			keys = append(keys, tuiKeyTerminate)
+		case 'y', 'Y':
+			keys = append(keys, tuiKeyYes)
+		case 'n', 'N', 0x1b:
+			keys = append(keys, tuiKeyNo)
+		case 'q', 0x03: // 0x03 is ctrl+c
+			keys = append(keys, tuiKeyQuit)
+		}
+	}
+	return keys
+}
+
+type tuiNode struct {
+	id    string
+	depth int
+}
+
+// tuiModel is the state of the TUI, independent of the terminal
+type tuiModel struct {
+	wf       *wfv1.Workflow
+	nodes    []tuiNode
+	selected string // the ID of the selected node, so the selection is kept as nodes are added
+	logsPod  string
+	logs     []string
+	message  string
+	confirm  tuiKey // the action waiting for confirmation, if any
+}
+
+func (m *tuiModel) setWorkflow(wf *wfv1.Workflow) {
+	m.wf = wf
+	m.nodes = flattenNodeTree(wf)
+	if m.selectedIndex() < 0 && len(m.nodes) > 0 {
+		m.selected = m.nodes[0].id
+	}
+}
+
+func (m *tuiModel) selectedIndex() int {
+	for i, n := range m.nodes {
+		if n.id == m.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *tuiModel) move(delta int) {
+	if len(m.nodes) == 0 {
+		return
+	}
+	i := m.selectedIndex() + delta
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(m.nodes) {
+		i = len(m.nodes) - 1
+	}
+	m.selected = m.nodes[i].id
+}
+
+// selectedPodName returns the pod of the selected node, or an empty string if it does not have one
+func (m *tuiModel) selectedPodName() string {
+	if m.wf == nil {
+		return ""
+	}
+	node, ok := m.wf.Status.Nodes[m.selected]
+	if !ok || node.Type != wfv1.NodeTypePod {
+		return ""
+	}
+	return util.PodName(m.wf.Name, node.Name, node.TemplateName, node.ID, util.GetWorkflowPodNameVersion(m.wf))
+}
+
+func (m *tuiModel) setLogsPod(podName string) {
+	m.logsPod = podName
+	m.logs = nil
+}
+
+func (m *tuiModel) appendLog(line string) {
+	m.logs = append(m.logs, strings.TrimRight(line, "\r\n"))
+	if len(m.logs) > tuiMaxLogLines {
+		m.logs = m.logs[len(m.logs)-tuiMaxLogLines:]
+	}
+}
+
+// render returns the screen as lines no wider, or more numerous, than the terminal
+func (m *tuiModel) render(width, height int) []string {
+	var lines []string
+	if m.wf == nil {
+		lines = append(lines, "Waiting for workflow...")
+	} else {
+		duration := humanize.RelativeDurationShort(m.wf.Status.StartedAt.Time, m.wf.Status.FinishedAt.Time)
+		lines = append(lines,
+			fmt.Sprintf("Name: %s  Namespace: %s", m.wf.Name, m.wf.Namespace),
+			fmt.Sprintf("Status: %s  Duration: %s  Progress: %s", workflowStatus(m.wf), duration, m.wf.Status.Progress),
+			"",
+		)
+	}
+
+	footer := tuiHelp
+	if m.confirm != "" {
+		footer = fmt.Sprintf("%s workflow %s? (y/n)", map[tuiKey]string{tuiKeyRetry: "Retry", tuiKeyTerminate: "Terminate"}[m.confirm], m.wf.Name)
+	} else if m.message != "" {
+		footer = m.message
+	}
+
+	available := height - len(lines) - 1
+	treeHeight := available
+	if m.logsPod != "" {
+		treeHeight = available / 2
+	}
+	lines = append(lines, m.renderTree(treeHeight)...)
+	if m.logsPod != "" {
+		logsHeight := available - treeHeight - 1
+		lines = append(lines, ansiFormat(fmt.Sprintf("Logs: %s", m.logsPod), Bold))
+		logs := m.logs
+		if len(logs) > logsHeight {
+			logs = logs[len(logs)-logsHeight:]
+		}
+		lines = append(lines, logs...)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, footer)
+	for i, line := range lines {
+		lines[i] = truncateANSI(line, width)
+	}
+	return lines
+}
+
+// renderTree renders a window of the node tree, scrolled to the selected node
+func (m *tuiModel) renderTree(height int) []string {
+	if height <= 0 {
+		return nil
+	}
+	start := 0
+	if i := m.selectedIndex(); i >= height {
+		start = i - height + 1
+	}
+	var lines []string
+	for i := start; i < len(m.nodes) && len(lines) < height; i++ {
+		node := m.wf.Status.Nodes[m.nodes[i].id]
+		prefix := "  "
+		if node.ID == m.selected {
+			prefix = "> "
+		}
+		icon := JobStatusIconMap[node.Phase]
+		if node.IsActiveSuspendNode() {
+			icon = NodeTypeIconMap[node.Type]
+		}
+		line := fmt.Sprintf("%s%s%s %s", prefix, strings.Repeat("  ", m.nodes[i].depth), icon, node.DisplayName)
+		if node.TemplateName != "" {
+			line += "  " + node.TemplateName
+		}
+		if !node.StartedAt.IsZero() {
+			line += "  " + humanize.RelativeDurationShort(node.StartedAt.Time, node.FinishedAt.Time)
+		}
+		if node.Message != "" {
+			line += "  " + node.Message
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func workflowStatus(wf *wfv1.Workflow) string {
+	if wf.Status.Phase == "" {
+		return string(wfv1.NodePending)
+	}
+	return string(wf.Status.Phase)
+}
+
+// flattenNodeTree orders the nodes depth first, children sorted by start time then name
+func flattenNodeTree(wf *wfv1.Workflow) []tuiNode {
+	isChild := map[string]bool{}
+	for _, node := range wf.Status.Nodes {
+		for _, child := range node.Children {
+			isChild[child] = true
+		}
+	}
+	sortIDs := func(ids []string) []string {
+		sorted := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if _, ok := wf.Status.Nodes[id]; ok {
+				sorted = append(sorted, id)
+			}
+		}
+		sort.SliceStable(sorted, func(i, j int) bool {
+			a, b := wf.Status.Nodes[sorted[i]], wf.Status.Nodes[sorted[j]]
+			if !a.StartedAt.Equal(&b.StartedAt) {
+				return a.StartedAt.Before(&b.StartedAt)
+			}
+			return a.DisplayName < b.DisplayName
+		})
+		return sorted
+	}
+	var roots []string
+	for id := range wf.Status.Nodes {
+		if !isChild[id] {
+			roots = append(roots, id)
+		}
+	}
+	var nodes []tuiNode
+	visited := map[string]bool{}
+	var visit func(id string, depth int)
+	visit = func(id string, depth int) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		nodes = append(nodes, tuiNode{id: id, depth: depth})
+		for _, child := range sortIDs(wf.Status.Nodes[id].Children) {
+			visit(child, depth+1)
+		}
+	}
+	for _, id := range sortIDs(roots) {
+		visit(id, 0)
+	}
+	return nodes
+}
+
+// truncateANSI truncates a string to a number of visible characters, ignoring ANSI escape sequences
+func truncateANSI(s string, width int) string {
+	visible := 0
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			if end := strings.IndexByte(s[i:], 'm'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		}
+		if visible == width {
+			if strings.Contains(s, escape) {
+				// make sure formatting does not continue past the end of the line
+				return s[:i] + escape + "[0m"
+			}
+			return s[:i]
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		visible++
+	}
+	return s
+}
+
+type tuiLogLine struct {
+	podName string
+	content string
+}
+
+// WatchWorkflowTUI watches a workflow in an interactive terminal UI until the user quits
+func WatchWorkflowTUI(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflow string) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("--tui requires a terminal")
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = term.Restore(fd, oldState) }()
+	fmt.Print(enterAltScreen)
+	defer fmt.Print(leaveAltScreen)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	wfChan, errChan := watchWorkflowEvents(ctx, serviceClient, namespace, workflow)
+	keyChan := readTUIKeys(os.Stdin)
+	logChan := make(chan tuiLogLine)
+	cancelLogs := func() {}
+	defer func() { cancelLogs() }()
+
+	m := &tuiModel{}
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case wf := <-wfChan:
+			if wf == nil {
+				return fmt.Errorf("workflow %s was deleted", workflow)
+			}
+			if err := packer.DecompressWorkflow(wf); err != nil {
+				return err
+			}
+			m.setWorkflow(wf)
+		case err := <-errChan:
+			return err
+		case keys, ok := <-keyChan:
+			if !ok {
+				return nil
+			}
+			for _, key := range keys {
+				if key == tuiKeyQuit {
+					return nil
+				}
+				handleTUIKey(ctx, serviceClient, m, key)
+			}
+		case line := <-logChan:
+			if line.podName == m.logsPod {
+				m.appendLog(line.content)
+			}
+		case <-ticker.C:
+			// refresh durations every second
+		case <-ctx.Done():
+			return nil
+		}
+
+		if podName := m.selectedPodName(); podName != m.logsPod {
+			cancelLogs()
+			m.setLogsPod(podName)
+			if podName != "" {
+				logsCtx, cancel := context.WithCancel(ctx)
+				cancelLogs = cancel
+				go streamTUILogs(logsCtx, serviceClient, m.wf, podName, logChan)
+			}
+		}
+
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			return err
+		}
+		fmt.Print(cursorHome + strings.Join(m.render(width, height), clearLine+"\r\n") + clearToEnd)
+	}
+}
+
+func handleTUIKey(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, m *tuiModel, key tuiKey) {
+	if m.confirm != "" {
+		action := m.confirm
+		m.confirm = ""
+		m.message = ""
+		if key != tuiKeyYes {
+			return
+		}
+		var err error
+		switch action {
+		case tuiKeyRetry:
+			_, err = serviceClient.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: m.wf.Name, Namespace: m.wf.Namespace})
+		case tuiKeyTerminate:
+			_, err = serviceClient.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{Name: m.wf.Name, Namespace: m.wf.Namespace})
+		}
+		if err != nil {
+			m.message = fmt.Sprintf("Failed to %s workflow: %v", action, err)
+		} else {
+			m.message = fmt.Sprintf("Requested %s of workflow %s", action, m.wf.Name)
+		}
+		return
+	}
+	switch key {
+	case tuiKeyUp:
+		m.move(-1)
+	case tuiKeyDown:
+		m.move(1)
+	case tuiKeyHome:
+		m.move(-len(m.nodes))
+	case tuiKeyEnd:
+		m.move(len(m.nodes))
+	case tuiKeyRetry, tuiKeyTerminate:
+		if m.wf != nil {
+			m.confirm = key
+		}
+	}
+}
+
+func readTUIKeys(r io.Reader) <-chan []tuiKey {
+	keyChan := make(chan []tuiKey)
+	go func() {
+		defer close(keyChan)
+		buf := make([]byte, 64)
+		for {
+			n, err := r.Read(buf)
+			if err != nil {
+				return
+			}
+			if keys := parseTUIKeys(buf[:n]); len(keys) > 0 {
+				keyChan <- keys
+			}
+		}
+	}()
+	return keyChan
+}
+
+func streamTUILogs(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, wf *wfv1.Workflow, podName string, logChan chan<- tuiLogLine) {
+	send := func(content string) bool {
+		select {
+		case logChan <- tuiLogLine{podName: podName, content: content}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	stream, err := serviceClient.WorkflowLogs(ctx, &workflowpkg.WorkflowLogRequest{
+		Name:       wf.Name,
+		Namespace:  wf.Namespace,
+		PodName:    podName,
+		LogOptions: &corev1.PodLogOptions{Container: "main", Follow: true, TailLines: pointer.Int64(tuiMaxLogLines)},
+	})
+	if err != nil {
+		send(fmt.Sprintf("failed to get logs: %v", err))
+		return
+	}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			send(fmt.Sprintf("failed to get logs: %v", err))
+			return
+		}
+		if !send(event.Content) {
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func newTUITestWorkflow() *wfv1.Workflow {
+	start := metav1.NewTime(time.Now().Add(-time.Minute))
+	later := metav1.NewTime(start.Add(time.Second))
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+		Status: wfv1.WorkflowStatus{
+			Phase:     wfv1.WorkflowRunning,
+			StartedAt: start,
+			Nodes: wfv1.Nodes{
+				"my-wf":         {ID: "my-wf", Name: "my-wf", DisplayName: "my-wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeRunning, StartedAt: start, Children: []string{"my-wf-2", "my-wf-1"}},
+				"my-wf-1":       {ID: "my-wf-1", Name: "my-wf[0].a", DisplayName: "a", TemplateName: "whalesay", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, StartedAt: start},
+				"my-wf-2":       {ID: "my-wf-2", Name: "my-wf[0].b", DisplayName: "b", TemplateName: "whalesay", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning, StartedAt: later, Message: "running"},
+				"my-wf-on-exit": {ID: "my-wf-on-exit", Name: "my-wf.onExit", DisplayName: "my-wf.onExit", Type: wfv1.NodeTypePod, Phase: wfv1.NodePending, StartedAt: later},
+			},
+		},
+	}
+}
+
+func Test_parseTUIKeys(t *testing.T) {
+	assert.Equal(t, []tuiKey{tuiKeyUp, tuiKeyDown, tuiKeyUp, tuiKeyDown, tuiKeyRetry, tuiKeyYes, tuiKeyQuit},
+		parseTUIKeys([]byte("\x1b[A\x1b[Bkjry\x03")))
+	assert.Equal(t, []tuiKey{tuiKeyNo, tuiKeyTerminate}, parseTUIKeys([]byte("\x1bxt")))
+	assert.Empty(t, parseTUIKeys([]byte("\x1b[C")))
+}
+
+func Test_flattenNodeTree(t *testing.T) {
+	assert.Equal(t, []tuiNode{
+		{id: "my-wf", depth: 0},
+		{id: "my-wf-1", depth: 1},
+		{id: "my-wf-2", depth: 1},
+		{id: "my-wf-on-exit", depth: 0},
+	}, flattenNodeTree(newTUITestWorkflow()))
+}
+
+func Test_truncateANSI(t *testing.T) {
+	assert.Equal(t, "abc", truncateANSI("abc", 5))
+	assert.Equal(t, "ab", truncateANSI("abc", 2))
+	assert.Equal(t, "\x1b[32m✔\x1b[0m a\x1b[0m", truncateANSI("\x1b[32m✔\x1b[0m abc", 3))
+}
+
+func Test_tuiModel(t *testing.T) {
+	m := &tuiModel{}
+	m.setWorkflow(newTUITestWorkflow())
+	assert.Equal(t, "my-wf", m.selected)
+	assert.Empty(t, m.selectedPodName())
+
+	m.move(-1)
+	assert.Equal(t, "my-wf", m.selected)
+	m.move(2)
+	assert.Equal(t, "my-wf-2", m.selected)
+	assert.Equal(t, util.PodName("my-wf", "my-wf[0].b", "whalesay", "my-wf-2", util.PodNameV2), m.selectedPodName())
+	m.move(10)
+	assert.Equal(t, "my-wf-on-exit", m.selected)
+
+	// the selection is kept when the workflow is updated
+	m.setWorkflow(newTUITestWorkflow())
+	assert.Equal(t, "my-wf-on-exit", m.selected)
+
+	m.move(-1)
+	m.setLogsPod(m.selectedPodName())
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		m.appendLog(line)
+	}
+	lines := m.render(100, 10)
+	assert.Len(t, lines, 10)
+	assert.Equal(t, "Name: my-wf  Namespace: my-ns", lines[0])
+	assert.Equal(t, "Status: Running  Duration: 1m  Progress: ", lines[1])
+	assert.Equal(t, "  "+JobStatusIconMap[wfv1.NodeRunning]+" my-wf  1m", lines[3])
+	assert.Equal(t, "    "+JobStatusIconMap[wfv1.NodeSucceeded]+" a  whalesay  1m", lines[4])
+	assert.Equal(t, ">   "+JobStatusIconMap[wfv1.NodeRunning]+" b  whalesay  59s  running", lines[5])
+	assert.Contains(t, lines[6], "Logs: "+m.logsPod)
+	assert.Equal(t, []string{"two", "three"}, lines[7:9])
+	assert.Equal(t, tuiHelp, lines[9])
+
+	assert.Equal(t, "Name: my-wf", m.render(11, 10)[0])
+}
+
+func Test_handleTUIKey(t *testing.T) {
+	ctx := context.Background()
+	c := &workflowmocks.WorkflowServiceClient{}
+	c.On("TerminateWorkflow", mock.Anything, &workflowpkg.WorkflowTerminateRequest{Name: "my-wf", Namespace: "my-ns"}).Return(&wfv1.Workflow{}, nil)
+
+	m := &tuiModel{}
+	m.setWorkflow(newTUITestWorkflow())
+
+	handleTUIKey(ctx, c, m, tuiKeyTerminate)
+	assert.Equal(t, tuiKeyTerminate, m.confirm)
+	assert.Contains(t, m.render(100, 10)[9], "Terminate workflow my-wf? (y/n)")
+	handleTUIKey(ctx, c, m, tuiKeyNo)
+	assert.Empty(t, m.confirm)
+	c.AssertNotCalled(t, "TerminateWorkflow", mock.Anything, mock.Anything)
+
+	handleTUIKey(ctx, c, m, tuiKeyTerminate)
+	handleTUIKey(ctx, c, m, tuiKeyYes)
+	c.AssertNumberOfCalls(t, "TerminateWorkflow", 1)
+	assert.Equal(t, "Requested terminate of workflow my-wf", m.message)
+
+	handleTUIKey(ctx, c, m, tuiKeyDown)
+	assert.Equal(t, "my-wf-1", m.selected)
+}
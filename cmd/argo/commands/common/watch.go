@@ -17,33 +17,7 @@ import (
 )
 
 func WatchWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflow string, getArgs GetFlags) {
-	req := &workflowpkg.WatchWorkflowsRequest{
-		Namespace: namespace,
-		ListOptions: &metav1.ListOptions{
-			FieldSelector:   util.GenerateFieldSelectorFromWorkflowName(workflow),
-			ResourceVersion: "0",
-		},
-	}
-	stream, err := serviceClient.WatchWorkflows(ctx, req)
-	errors.CheckError(err)
-
-	wfChan := make(chan *wfv1.Workflow)
-	go func() {
-		for {
-			event, err := stream.Recv()
-			if err == io.EOF {
-				log.Debug("Re-establishing workflow watch")
-				stream, err = serviceClient.WatchWorkflows(ctx, req)
-				errors.CheckError(err)
-				continue
-			}
-			errors.CheckError(err)
-			if event == nil {
-				continue
-			}
-			wfChan <- event.Object
-		}
-	}()
+	wfChan, errChan := watchWorkflowEvents(ctx, serviceClient, namespace, workflow)
 
 	var wf *wfv1.Workflow
 	ticker := time.NewTicker(time.Second)
@@ -55,6 +29,8 @@ func WatchWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 				return
 			}
 			wf = newWf
+		case err := <-errChan:
+			errors.CheckError(err)
 		case <-ticker.C:
 			// If we don't, refresh the workflow screen every second
 		case <-ctx.Done():
@@ -69,6 +45,53 @@ func WatchWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 	}
 }
 
+// watchWorkflowEvents streams updates of the workflow, re-establishing the watch when it ends.
+// The workflow channel receives nil if the workflow is deleted.
+func watchWorkflowEvents(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflow string) (<-chan *wfv1.Workflow, <-chan error) {
+	req := &workflowpkg.WatchWorkflowsRequest{
+		Namespace: namespace,
+		ListOptions: &metav1.ListOptions{
+			FieldSelector:   util.GenerateFieldSelectorFromWorkflowName(workflow),
+			ResourceVersion: "0",
+		},
+	}
+	wfChan := make(chan *wfv1.Workflow)
+	errChan := make(chan error, 1)
+	go func() {
+		stream, err := serviceClient.WatchWorkflows(ctx, req)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		var event *workflowpkg.WorkflowWatchEvent
+		for {
+			event, err = stream.Recv()
+			if err == io.EOF {
+				log.Debug("Re-establishing workflow watch")
+				stream, err = serviceClient.WatchWorkflows(ctx, req)
+				if err != nil {
+					errChan <- err
+					return
+				}
+				continue
+			}
+			if err != nil {
+				errChan <- err
+				return
+			}
+			if event == nil {
+				continue
+			}
+			select {
+			case wfChan <- event.Object:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return wfChan, errChan
+}
+
 func printWorkflowStatus(wf *wfv1.Workflow, getArgs GetFlags) {
 	if wf == nil {
 		return
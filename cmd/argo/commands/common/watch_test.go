@@ -0,0 +1,45 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type watchWorkflowsClient struct {
+	grpc.ClientStream
+	events []*workflowpkg.WorkflowWatchEvent
+}
+
+func (c *watchWorkflowsClient) Recv() (*workflowpkg.WorkflowWatchEvent, error) {
+	if len(c.events) == 0 {
+		return nil, io.EOF
+	}
+	event := c.events[0]
+	c.events = c.events[1:]
+	return event, nil
+}
+
+func Test_watchWorkflowEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wf := &wfv1.Workflow{}
+	serviceClient := &mocks.WorkflowServiceClient{}
+	serviceClient.On("WatchWorkflows", mock.Anything, mock.Anything).
+		Return(&watchWorkflowsClient{events: []*workflowpkg.WorkflowWatchEvent{{Object: wf}}}, nil).Once()
+	serviceClient.On("WatchWorkflows", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("connection refused")).Once()
+	wfChan, errChan := watchWorkflowEvents(ctx, serviceClient, "my-ns", "my-wf")
+	assert.Equal(t, wf, <-wfChan)
+	// the failure to re-establish the watch is reported, rather than receiving from a nil stream
+	assert.EqualError(t, <-errChan, "connection refused")
+}
@@ -2,23 +2,42 @@ package commands
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+	artifactpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/artifact"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
+type cpOps struct {
+	recursive   bool     // --recursive
+	include     []string // --include
+	exclude     []string // --exclude
+	parallelism int      // --parallelism
+	resume      bool     // --resume
+}
+
+// artifactDownload is a single file to download
+type artifactDownload struct {
+	url      string
+	fileName string
+	filePath string
+}
+
 func NewCpCommand() *cobra.Command {
 	var (
 		namespace    string // --namespace
@@ -26,6 +45,7 @@ func NewCpCommand() *cobra.Command {
 		templateName string // --template-name
 		artifactName string // --artifact-name
 		customPath   string // --path
+		cpArgs       cpOps
 	)
 	command := &cobra.Command{
 		Use:   "cp my-wf output-directory ...",
@@ -37,6 +57,14 @@ func NewCpCommand() *cobra.Command {
 # Copy artifacts from a specific node in a workflow to a local output directory:
 
   argo cp my-wf output-directory --node-id=my-wf-node-id-123
+
+# Copy the files of every artifact produced by a node and its children, file by file:
+
+  argo cp my-wf output-directory --node-id=my-wf-node-id-123 --recursive
+
+# Copy only CSV files, skipping any previously downloaded:
+
+  argo cp my-wf output-directory --recursive --include='*.csv' --resume
 `,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,6 +72,9 @@ func NewCpCommand() *cobra.Command {
 				cmd.HelpFunc()(cmd, args)
 				return fmt.Errorf("incorrect number of arguments")
 			}
+			if cpArgs.parallelism < 1 {
+				return fmt.Errorf("--parallelism must be greater than zero")
+			}
 			workflowName := args[0]
 			outputDir := args[1]
 
@@ -66,17 +97,21 @@ func NewCpCommand() *cobra.Command {
 				TemplateName: templateName,
 				NodeId:       nodeId,
 			}
+			var nodeIds map[string]bool
+			if cpArgs.recursive && nodeId != "" {
+				// search the whole sub-tree of the node
+				artifactSearchQuery.NodeId = ""
+				nodeIds = getDescendantNodeIds(workflow, nodeId)
+			}
 			artifactSearchResults := workflow.SearchArtifacts(&artifactSearchQuery)
 
-			c := &http.Client{
-				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						InsecureSkipVerify: client.ArgoServerOpts.InsecureSkipVerify,
-					},
-				},
-			}
+			c := newArtifactHTTPClient()
 
+			var downloads []artifactDownload
 			for _, artifact := range artifactSearchResults {
+				if nodeIds != nil && !nodeIds[artifact.NodeID] {
+					continue
+				}
 				customPath := filepath.Join(outputDir, customPath)
 				nodeInfo := workflow.Status.Nodes.Find(func(n v1alpha1.NodeStatus) bool { return n.ID == artifact.NodeID })
 				if nodeInfo == nil {
@@ -87,20 +122,14 @@ func NewCpCommand() *cobra.Command {
 				customPath = strings.Replace(customPath, "{workflowName}", workflowName, 1)
 				customPath = strings.Replace(customPath, "{nodeId}", artifact.NodeID, 1)
 				customPath = strings.Replace(customPath, "{artifactName}", artifact.Name, 1)
-				err = os.MkdirAll(customPath, os.ModePerm)
-				if err != nil {
-					return fmt.Errorf("failed to create folder path: %w", err)
-				}
-				key, err := artifact.GetKey()
-				if err != nil {
-					return fmt.Errorf("error getting key for artifact: %w", err)
-				}
-				err = getAndStoreArtifactData(namespace, workflowName, artifact.NodeID, artifact.Name, path.Base(key), customPath, c, client.ArgoServerOpts)
+				artifactDownloads, err := getArtifactDownloads(namespace, workflowName, artifact, customPath, c, client.ArgoServerOpts, cpArgs)
 				if err != nil {
-					return fmt.Errorf("failed to get and store artifact data: %w", err)
+					return fmt.Errorf("failed to list artifact %s: %w", artifact.Name, err)
 				}
+				downloads = append(downloads, artifactDownloads...)
 			}
-			return nil
+
+			return downloadArtifacts(downloads, c, cpArgs)
 		},
 	}
 	command.Flags().StringVarP(&namespace, "namespace", "n", "", "namespace of workflow")
@@ -108,33 +137,184 @@ func NewCpCommand() *cobra.Command {
 	command.Flags().StringVar(&templateName, "template-name", "", "name of template in workflow")
 	command.Flags().StringVar(&artifactName, "artifact-name", "", "name of output artifact in workflow")
 	command.Flags().StringVar(&customPath, "path", "{namespace}/{workflowName}/{nodeId}/outputs/{artifactName}", "use variables {workflowName}, {nodeId}, {templateName}, {artifactName}, and {namespace} to create a customized path to store the artifacts; example: {workflowName}/{templateName}/{artifactName}")
+	command.Flags().BoolVarP(&cpArgs.recursive, "recursive", "r", false, "copy the files of directory artifacts one by one, and include the artifacts of the children of --node-id")
+	command.Flags().StringArrayVar(&cpArgs.include, "include", nil, "only copy files matching this glob, e.g. '*.csv' or 'data/*.csv'; patterns without a '/' match the file name; may be repeated")
+	command.Flags().StringArrayVar(&cpArgs.exclude, "exclude", nil, "do not copy files matching this glob, takes precedence over --include; may be repeated")
+	command.Flags().IntVar(&cpArgs.parallelism, "parallelism", 4, "number of files to download in parallel")
+	command.Flags().BoolVar(&cpArgs.resume, "resume", false, "skip files that have already been downloaded")
 	return command
 }
 
-func getAndStoreArtifactData(namespace string, workflowName string, nodeId string, artifactName string, fileName string, customPath string, c *http.Client, argoServerOpts apiclient.ArgoServerOpts) error {
-	request, err := http.NewRequest("GET", fmt.Sprintf("%s/artifacts/%s/%s/%s/%s", argoServerOpts.GetURL(), namespace, workflowName, nodeId, artifactName), nil)
+func newArtifactHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: client.ArgoServerOpts.InsecureSkipVerify,
+			},
+		},
+	}
+}
+
+// downloadArtifacts downloads the files, cpArgs.parallelism at a time
+func downloadArtifacts(downloads []artifactDownload, c *http.Client, cpArgs cpOps) error {
+	g := errgroup.Group{}
+	g.SetLimit(cpArgs.parallelism)
+	for _, download := range downloads {
+		download := download
+		g.Go(func() error {
+			if cpArgs.resume {
+				if _, err := os.Stat(download.filePath); err == nil {
+					log.Printf("Skipped %q, already downloaded", download.fileName)
+					return nil
+				}
+			}
+			if err := getAndStoreArtifactData(download.url, download.filePath, c); err != nil {
+				return fmt.Errorf("failed to get and store artifact data: %w", err)
+			}
+			log.Printf("Created %q", download.fileName)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// getDescendantNodeIds returns the node and all of its descendants
+func getDescendantNodeIds(workflow *v1alpha1.Workflow, nodeId string) map[string]bool {
+	nodeIds := map[string]bool{}
+	queue := []string{nodeId}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if nodeIds[id] {
+			continue
+		}
+		nodeIds[id] = true
+		if node, ok := workflow.Status.Nodes[id]; ok {
+			queue = append(queue, node.Children...)
+		}
+	}
+	return nodeIds
+}
+
+// getArtifactDownloads returns the files of the artifact to download, taking the filters into account
+func getArtifactDownloads(namespace string, workflowName string, artifact v1alpha1.ArtifactSearchResult, customPath string, c *http.Client, argoServerOpts apiclient.ArgoServerOpts, cpArgs cpOps) ([]artifactDownload, error) {
+	var downloads []artifactDownload
+	if !cpArgs.recursive {
+		key, err := artifact.GetKey()
+		if err != nil {
+			return nil, fmt.Errorf("error getting key for artifact: %w", err)
+		}
+		fileName := path.Base(key)
+		if ok, err := matchesFilters(fileName, cpArgs.include, cpArgs.exclude); err != nil || !ok {
+			return nil, err
+		}
+		return append(downloads, artifactDownload{
+			url:      fmt.Sprintf("%s/artifacts/%s/%s/%s/%s", argoServerOpts.GetURL(), namespace, workflowName, artifact.NodeID, artifact.Name),
+			fileName: fileName,
+			filePath: filepath.Join(customPath, fileName),
+		}), nil
+	}
+	artifactURL := fmt.Sprintf("%s/artifact-files/%s/workflows/%s/%s/outputs/%s", argoServerOpts.GetURL(), namespace, workflowName, artifact.NodeID, url.PathEscape(artifact.Name))
+	listing, err := getArtifactFileListing(artifactURL, c)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range listing.Files {
+		if ok, err := matchesFilters(file, cpArgs.include, cpArgs.exclude); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		download := artifactDownload{url: artifactURL, fileName: file, filePath: filepath.Join(customPath, filepath.FromSlash(file))}
+		if listing.IsDirectory {
+			for _, part := range strings.Split(file, "/") {
+				download.url += "/" + url.PathEscape(part)
+			}
+		}
+		downloads = append(downloads, download)
+	}
+	return downloads, nil
+}
+
+func getArtifactFileListing(artifactURL string, c *http.Client) (*artifactpkg.FileListing, error) {
+	resp, err := doArtifactRequest(artifactURL+"?list=true", c)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	listing := &artifactpkg.FileListing{}
+	if err := json.NewDecoder(resp.Body).Decode(listing); err != nil {
+		return nil, fmt.Errorf("failed to decode artifact listing: %w", err)
+	}
+	return listing, nil
+}
+
+// matchesFilters returns whether the file matches any of the include globs, and none of the exclude globs.
+// Globs without a "/" are matched against the file's name, otherwise against its path.
+func matchesFilters(file string, include, exclude []string) (bool, error) {
+	matches := func(patterns []string) (bool, error) {
+		for _, pattern := range patterns {
+			name := file
+			if !strings.Contains(pattern, "/") {
+				name = path.Base(file)
+			}
+			if ok, err := path.Match(pattern, name); err != nil {
+				return false, fmt.Errorf("invalid glob %q: %w", pattern, err)
+			} else if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	if excluded, err := matches(exclude); err != nil || excluded {
+		return false, err
+	}
+	if len(include) == 0 {
+		return true, nil
+	}
+	return matches(include)
+}
+
+func doArtifactRequest(artifactURL string, c *http.Client) (*http.Response, error) {
+	request, err := http.NewRequest("GET", artifactURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	request.Header.Set("Authorization", client.GetAuthString())
 	resp, err := c.Do(request)
 	if err != nil {
-		return fmt.Errorf("request failed with: %w", err)
+		return nil, fmt.Errorf("request failed with: %w", err)
 	}
-	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("request failed %s", resp.Status)
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed %s", resp.Status)
 	}
-	artifactFilePath := filepath.Join(customPath, fileName)
-	fileWriter, err := os.Create(artifactFilePath)
+	return resp, nil
+}
+
+// getAndStoreArtifactData downloads to a temporary file first, so that an interrupted download is never mistaken for
+// a complete one when resuming
+func getAndStoreArtifactData(artifactURL string, artifactFilePath string, c *http.Client) error {
+	resp, err := doArtifactRequest(artifactURL, c)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	err = os.MkdirAll(filepath.Dir(artifactFilePath), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create folder path: %w", err)
+	}
+	partFilePath := artifactFilePath + ".part"
+	fileWriter, err := os.Create(partFilePath)
 	if err != nil {
 		return fmt.Errorf("creating file failed: %w", err)
 	}
-	defer fileWriter.Close()
 	_, err = io.Copy(fileWriter, resp.Body)
+	if closeErr := fileWriter.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return fmt.Errorf("copying file contents failed: %w", err)
 	}
-	log.Printf("Created %q", fileName)
-	return nil
+	return os.Rename(partFilePath, artifactFilePath)
 }
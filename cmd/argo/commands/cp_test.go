@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_matchesFilters(t *testing.T) {
+	for _, tt := range []struct {
+		file             string
+		include, exclude []string
+		matches          bool
+	}{
+		{file: "data/a.csv", matches: true},
+		{file: "data/a.csv", include: []string{"*.csv"}, matches: true},
+		{file: "data/a.csv", include: []string{"*.txt"}, matches: false},
+		{file: "data/a.csv", include: []string{"data/*"}, matches: true},
+		{file: "data/a.csv", include: []string{"other/*"}, matches: false},
+		{file: "data/a.csv", include: []string{"*.csv"}, exclude: []string{"a.*"}, matches: false},
+		{file: "data/a.csv", exclude: []string{"*.txt"}, matches: true},
+	} {
+		ok, err := matchesFilters(tt.file, tt.include, tt.exclude)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.matches, ok, "%s include=%v exclude=%v", tt.file, tt.include, tt.exclude)
+		}
+	}
+	_, err := matchesFilters("a", []string{"["}, nil)
+	assert.Error(t, err)
+}
+
+func Test_getDescendantNodeIds(t *testing.T) {
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+		"root":  {ID: "root", Children: []string{"a", "b"}},
+		"a":     {ID: "a", Children: []string{"a-1"}},
+		"a-1":   {ID: "a-1"},
+		"b":     {ID: "b", Children: []string{"a-1"}},
+		"other": {ID: "other"},
+	}}}
+	assert.Equal(t, map[string]bool{"a": true, "a-1": true}, getDescendantNodeIds(wf, "a"))
+	assert.Equal(t, map[string]bool{"root": true, "a": true, "a-1": true, "b": true}, getDescendantNodeIds(wf, "root"))
+}
+
+func Test_getArtifactDownloads(t *testing.T) {
+	t.Setenv("ARGO_TOKEN", "Bearer my-token")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		switch r.URL.String() {
+		case "/artifact-files/my-ns/workflows/my-wf/my-node/outputs/my-dir?list=true":
+			_, _ = w.Write([]byte(`{"isDirectory": true, "files": ["a.csv", "b.txt", "sub dir/c.csv"]}`))
+		case "/artifact-files/my-ns/workflows/my-wf/my-node/outputs/my-dir/sub%20dir/c.csv":
+			_, _ = w.Write([]byte("c"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	opts := apiclient.ArgoServerOpts{URL: server.Listener.Addr().String()}
+	artifact := wfv1.ArtifactSearchResult{Artifact: wfv1.Artifact{Name: "my-dir"}, NodeID: "my-node"}
+	dir := t.TempDir()
+
+	downloads, err := getArtifactDownloads("my-ns", "my-wf", artifact, dir, server.Client(), opts, cpOps{recursive: true, include: []string{"*.csv"}})
+	if assert.NoError(t, err) && assert.Len(t, downloads, 2) {
+		assert.Equal(t, artifactDownload{
+			url:      server.URL + "/artifact-files/my-ns/workflows/my-wf/my-node/outputs/my-dir/sub%20dir/c.csv",
+			fileName: "sub dir/c.csv",
+			filePath: filepath.Join(dir, "sub dir", "c.csv"),
+		}, downloads[1])
+
+		err = getAndStoreArtifactData(downloads[1].url, downloads[1].filePath, server.Client())
+		if assert.NoError(t, err) {
+			data, err := os.ReadFile(downloads[1].filePath)
+			assert.NoError(t, err)
+			assert.Equal(t, "c", string(data))
+		}
+
+		err = getAndStoreArtifactData(downloads[0].url, downloads[0].filePath, server.Client())
+		assert.EqualError(t, err, "request failed 404 Not Found")
+		assert.NoFileExists(t, downloads[0].filePath)
+	}
+}
@@ -0,0 +1,176 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/argoproj/pkg/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// how often to check whether backfilled workflows have completed, when the parallelism is limited
+var backfillPollInterval = 10 * time.Second
+
+type backfillOpts struct {
+	name        string
+	parallelism int
+	parameters  []string
+	dryRun      bool
+}
+
+// NewBackfillCommand returns a new instance of an `argo cron backfill` command
+func NewBackfillCommand() *cobra.Command {
+	var (
+		start string
+		end   string
+		opts  backfillOpts
+	)
+	command := &cobra.Command{
+		Use:   "backfill CRON_WORKFLOW",
+		Short: "submit the workflows a cron workflow would have run in a past time window",
+		Long: `Submit a workflow for each time the cron workflow was scheduled between the start and end times, inclusive.
+
+The scheduled time of each run is available to it as "{{workflow.scheduledTime}}". Each workflow is given the same name as the cron workflow would have given it, so runs that already exist are skipped, and is labeled with the name of the backfill.`,
+		Example: `# Run the workflows that were scheduled during the first week of October, three at a time:
+
+  argo cron backfill my-cron-wf --start 2022-10-01T00:00:00Z --end 2022-10-07T23:59:59Z --parallelism 3
+
+# List the times that would be backfilled, without submitting anything:
+
+  argo cron backfill my-cron-wf --start 2022-10-01T00:00:00Z --end 2022-10-07T23:59:59Z --dry-run
+
+# List the workflows of a backfill:
+
+  argo list -l workflows.argoproj.io/cron-workflow-backfill=my-backfill
+`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			startTime, err := time.Parse(time.RFC3339, start)
+			errors.CheckError(err)
+			endTime, err := time.Parse(time.RFC3339, end)
+			errors.CheckError(err)
+			if endTime.Before(startTime) {
+				errors.CheckError(fmt.Errorf("end time %s is before start time %s", end, start))
+			}
+			if opts.name == "" {
+				opts.name = fmt.Sprintf("backfill-%d", time.Now().Unix())
+			}
+			if errs := validation.IsValidLabelValue(opts.name); len(errs) > 0 {
+				errors.CheckError(fmt.Errorf("invalid backfill name %q: %s", opts.name, strings.Join(errs, ", ")))
+			}
+
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			cronServiceClient, err := apiClient.NewCronWorkflowServiceClient()
+			errors.CheckError(err)
+			namespace := client.Namespace()
+			cronWf, err := cronServiceClient.GetCronWorkflow(ctx, &cronworkflowpkg.GetCronWorkflowRequest{
+				Name:      args[0],
+				Namespace: namespace,
+			})
+			errors.CheckError(err)
+			scheduledTimes, err := GetScheduledTimes(cronWf, startTime, endTime)
+			errors.CheckError(err)
+			if len(scheduledTimes) == 0 {
+				fmt.Printf("CronWorkflow '%s' was not scheduled between %s and %s\n", cronWf.Name, start, end)
+				os.Exit(1)
+			}
+			err = backfillCronWorkflow(ctx, apiClient.NewWorkflowServiceClient(), cronWf, scheduledTimes, opts)
+			errors.CheckError(err)
+		},
+	}
+	command.Flags().StringVar(&start, "start", "", "Backfill the runs scheduled at or after this time (RFC3339)")
+	command.Flags().StringVar(&end, "end", "", "Backfill the runs scheduled at or before this time (RFC3339)")
+	command.Flags().StringVar(&opts.name, "name", "", "Name of the backfill, used to label its workflows. Defaults to \"backfill-\" followed by the current Unix time")
+	command.Flags().IntVar(&opts.parallelism, "parallelism", 0, "Maximum number of backfilled workflows to run at the same time. 0 means no limit")
+	command.Flags().StringArrayVarP(&opts.parameters, "parameter", "p", []string{}, "pass an input parameter to each workflow")
+	command.Flags().BoolVar(&opts.dryRun, "dry-run", false, "print the scheduled times to backfill, without submitting any workflows")
+	_ = command.MarkFlagRequired("start")
+	_ = command.MarkFlagRequired("end")
+	command.ValidArgsFunction = common.CompleteCronWorkflowNames()
+	return command
+}
+
+// backfillCronWorkflow submits a workflow from the cron workflow for each of the scheduled times, in order, waiting
+// for earlier workflows of the backfill to complete when there are more than opts.parallelism running
+func backfillCronWorkflow(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, cronWf *wfv1.CronWorkflow, scheduledTimes []time.Time, opts backfillOpts) error {
+	if opts.dryRun {
+		for _, scheduledTime := range scheduledTimes {
+			fmt.Printf("%s: %s\n", backfillWorkflowName(cronWf.Name, scheduledTime), scheduledTime.Format(time.RFC3339))
+		}
+		return nil
+	}
+	labels := fmt.Sprintf("%s=%s", wfcommon.LabelKeyCronWorkflowBackfill, opts.name)
+	submitted := 0
+	for _, scheduledTime := range scheduledTimes {
+		if opts.parallelism > 0 {
+			if err := waitForBackfillCapacity(ctx, serviceClient, cronWf.Namespace, labels, opts.parallelism); err != nil {
+				return err
+			}
+		}
+		name := backfillWorkflowName(cronWf.Name, scheduledTime)
+		_, err := serviceClient.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:    cronWf.Namespace,
+			ResourceKind: workflow.CronWorkflowKind,
+			ResourceName: cronWf.Name,
+			SubmitOptions: &wfv1.SubmitOpts{
+				Name:        name,
+				Labels:      labels,
+				Annotations: fmt.Sprintf("%s=%s", wfcommon.AnnotationKeyCronWfScheduledTime, scheduledTime.Format(time.RFC3339)),
+				Parameters:  opts.parameters,
+			},
+		})
+		if status.Code(err) == codes.AlreadyExists {
+			fmt.Printf("Workflow '%s' scheduled at %s already exists, skipping\n", name, scheduledTime.Format(time.RFC3339))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to submit workflow scheduled at %s: %w", scheduledTime.Format(time.RFC3339), err)
+		}
+		submitted++
+		fmt.Printf("Workflow '%s' scheduled at %s submitted\n", name, scheduledTime.Format(time.RFC3339))
+	}
+	fmt.Printf("Backfill '%s' submitted %d of %d workflows\n", opts.name, submitted, len(scheduledTimes))
+	return nil
+}
+
+// backfillWorkflowName returns the name the cron workflow controller gives to the workflow scheduled at the time
+func backfillWorkflowName(cronWorkflowName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", cronWorkflowName, scheduledTime.Unix())
+}
+
+// waitForBackfillCapacity blocks until fewer than parallelism workflows of the backfill are incomplete
+func waitForBackfillCapacity(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace, labels string, parallelism int) error {
+	for {
+		list, err := serviceClient.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{LabelSelector: fmt.Sprintf("%s,%s!=true", labels, wfcommon.LabelKeyCompleted)},
+			Fields:      "items.metadata.name",
+		})
+		if err != nil {
+			return err
+		}
+		if len(list.Items) < parallelism {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backfillPollInterval):
+		}
+	}
+}
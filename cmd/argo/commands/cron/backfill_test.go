@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestGetScheduledTimes(t *testing.T) {
+	cwf := &wfv1.CronWorkflow{Spec: wfv1.CronWorkflowSpec{Schedule: "0 */6 * * *"}}
+	start := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	scheduledTimes, err := GetScheduledTimes(cwf, start, start.Add(12*time.Hour))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []time.Time{start, start.Add(6 * time.Hour), start.Add(12 * time.Hour)}, scheduledTimes)
+	}
+
+	scheduledTimes, err = GetScheduledTimes(cwf, start.Add(time.Minute), start.Add(5*time.Hour))
+	if assert.NoError(t, err) {
+		assert.Empty(t, scheduledTimes)
+	}
+
+	_, err = GetScheduledTimes(&wfv1.CronWorkflow{Spec: wfv1.CronWorkflowSpec{Schedule: "invalid"}}, start, start)
+	assert.Error(t, err)
+
+	cwf = &wfv1.CronWorkflow{Spec: wfv1.CronWorkflowSpec{Schedules: []string{"0 */6 * * *", "0 1,6 * * *"}}}
+	scheduledTimes, err = GetScheduledTimes(cwf, start, start.Add(7*time.Hour))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []time.Time{start, start.Add(time.Hour), start.Add(6 * time.Hour)}, scheduledTimes)
+	}
+}
+
+func Test_backfillCronWorkflow(t *testing.T) {
+	cwf := &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf", Namespace: "argo"}}
+	first := time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+	submitRequest := func(name, scheduledTime string) *workflowpkg.WorkflowSubmitRequest {
+		return &workflowpkg.WorkflowSubmitRequest{
+			Namespace:    "argo",
+			ResourceKind: "CronWorkflow",
+			ResourceName: "my-cwf",
+			SubmitOptions: &wfv1.SubmitOpts{
+				Name:        name,
+				Labels:      "workflows.argoproj.io/cron-workflow-backfill=my-backfill",
+				Annotations: "workflows.argoproj.io/scheduled-time=" + scheduledTime,
+				Parameters:  []string{"message=hello"},
+			},
+		}
+	}
+
+	t.Run("Submit", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		c.On("SubmitWorkflow", mock.Anything, submitRequest("my-cwf-1664582400", "2022-10-01T00:00:00Z")).Return(&wfv1.Workflow{}, nil)
+		c.On("SubmitWorkflow", mock.Anything, submitRequest("my-cwf-1664586000", "2022-10-01T01:00:00Z")).Return(nil, status.Error(codes.AlreadyExists, "already exists"))
+		err := backfillCronWorkflow(context.Background(), c, cwf, []time.Time{first, second}, backfillOpts{name: "my-backfill", parameters: []string{"message=hello"}})
+		if assert.NoError(t, err) {
+			c.AssertNumberOfCalls(t, "SubmitWorkflow", 2)
+		}
+	})
+	t.Run("Error", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		c.On("SubmitWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.InvalidArgument, "invalid"))
+		err := backfillCronWorkflow(context.Background(), c, cwf, []time.Time{first, second}, backfillOpts{name: "my-backfill"})
+		assert.EqualError(t, err, "failed to submit workflow scheduled at 2022-10-01T00:00:00Z: rpc error: code = InvalidArgument desc = invalid")
+		c.AssertNumberOfCalls(t, "SubmitWorkflow", 1)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		err := backfillCronWorkflow(context.Background(), c, cwf, []time.Time{first, second}, backfillOpts{name: "my-backfill", dryRun: true})
+		if assert.NoError(t, err) {
+			c.AssertNotCalled(t, "SubmitWorkflow", mock.Anything, mock.Anything)
+		}
+	})
+	t.Run("Parallelism", func(t *testing.T) {
+		defer func(d time.Duration) { backfillPollInterval = d }(backfillPollInterval)
+		backfillPollInterval = time.Millisecond
+		c := &workflowmocks.WorkflowServiceClient{}
+		listRequest := &workflowpkg.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/cron-workflow-backfill=my-backfill,workflows.argoproj.io/completed!=true"},
+			Fields:      "items.metadata.name",
+		}
+		running := &wfv1.WorkflowList{Items: wfv1.Workflows{{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf-1664582400"}}}}
+		c.On("ListWorkflows", mock.Anything, listRequest).Return(&wfv1.WorkflowList{}, nil).Once()
+		c.On("ListWorkflows", mock.Anything, listRequest).Return(running, nil).Twice()
+		c.On("ListWorkflows", mock.Anything, listRequest).Return(&wfv1.WorkflowList{}, nil).Once()
+		c.On("SubmitWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+		err := backfillCronWorkflow(context.Background(), c, cwf, []time.Time{first, second}, backfillOpts{name: "my-backfill", parallelism: 1})
+		if assert.NoError(t, err) {
+			c.AssertNumberOfCalls(t, "ListWorkflows", 4)
+			c.AssertNumberOfCalls(t, "SubmitWorkflow", 2)
+		}
+	})
+}
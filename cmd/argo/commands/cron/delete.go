@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 )
 
@@ -39,5 +40,6 @@ func NewDeleteCommand() *cobra.Command {
 	}
 
 	command.Flags().BoolVar(&all, "all", false, "Delete all cron workflows")
+	command.ValidArgsFunction = common.CompleteCronWorkflowNames()
 	return command
 }
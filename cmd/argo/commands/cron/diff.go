@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func NewDiffCommand() *cobra.Command {
+	var strict bool
+	command := &cobra.Command{
+		Use:   "diff FILE1 FILE2...",
+		Short: "show the differences between cron workflows in files and those in the cluster",
+		Long:  "Show the differences between cron workflows in files and those in the cluster, ignoring the fields set by the cluster, such as the resource version, and fields with default values. Exits with 1 if there are differences.",
+		Example: `# Review changes to a cron workflow before updating it:
+
+  argo cron diff my-cron.yaml
+
+# Review changes to several cron workflows:
+
+  argo cron diff my-cron.yaml my-other-cron.yaml
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewCronWorkflowServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			different, err := diffCronWorkflows(ctx, serviceClient, client.Namespace(), args, strict)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if different {
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().BoolVar(&strict, "strict", true, "perform strict workflow validation")
+	return command
+}
+
+// diffCronWorkflows prints the differences between the cron workflows in the files and those in the
+// cluster, and returns whether there were any
+func diffCronWorkflows(ctx context.Context, serviceClient cronworkflowpkg.CronWorkflowServiceClient, namespace string, filePaths []string, strict bool) (bool, error) {
+	fileContents, err := util.ReadManifest(filePaths...)
+	if err != nil {
+		return false, err
+	}
+	var cronWfs []wfv1.CronWorkflow
+	for _, body := range fileContents {
+		cronWfs = append(cronWfs, unmarshalCronWorkflows(body, strict)...)
+	}
+	if len(cronWfs) == 0 {
+		return false, fmt.Errorf("no cron workflow found in given files")
+	}
+	different := false
+	for _, local := range cronWfs {
+		if local.Namespace == "" {
+			local.Namespace = namespace
+		}
+		var live *wfv1.CronWorkflow
+		if local.Name != "" {
+			live, err = serviceClient.GetCronWorkflow(ctx, &cronworkflowpkg.GetCronWorkflowRequest{
+				Name:      local.Name,
+				Namespace: local.Namespace,
+			})
+			if status.Code(err) == codes.NotFound {
+				live, err = nil, nil
+			}
+			if err != nil {
+				return false, err
+			}
+		}
+		if live != nil {
+			live.TypeMeta = local.TypeMeta
+		}
+		name := fmt.Sprintf("%s/%s", local.Namespace, local.Name)
+		diff, err := common.Diff("live/"+name, live, "local/"+name, &local)
+		if err != nil {
+			return false, err
+		}
+		if diff != "" {
+			fmt.Print(diff)
+			different = true
+		}
+	}
+	return different, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 )
 
@@ -31,5 +32,6 @@ func NewResumeCommand() *cobra.Command {
 		},
 	}
 
+	command.ValidArgsFunction = common.CompleteCronWorkflowNames()
 	return command
 }
@@ -1,19 +1,46 @@
 package cron
 
 import (
+	"sort"
 	"time"
 
-	"github.com/robfig/cron/v3"
-
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	cronutil "github.com/argoproj/argo-workflows/v3/util/cron"
 )
 
 // GetNextRuntime returns the next time the workflow should run in local time. It assumes the workflow-controller is in
 // UTC, but nevertheless returns the time in the local timezone.
 func GetNextRuntime(cwf *v1alpha1.CronWorkflow) (time.Time, error) {
-	cronSchedule, err := cron.ParseStandard(cwf.Spec.GetScheduleString())
+	cronSchedules, err := cronutil.ParseSchedules(cwf)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return cronSchedule.Next(time.Now().UTC()).Local(), nil
+	var next time.Time
+	now := time.Now().UTC()
+	for _, cronSchedule := range cronSchedules {
+		if t := cronSchedule.Next(now); next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next.Local(), nil
+}
+
+// GetScheduledTimes returns the times the workflow was scheduled to run between start and end, inclusive.
+func GetScheduledTimes(cwf *v1alpha1.CronWorkflow, start, end time.Time) ([]time.Time, error) {
+	cronSchedules, err := cronutil.ParseSchedules(cwf)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool)
+	var scheduledTimes []time.Time
+	for _, cronSchedule := range cronSchedules {
+		for t := cronSchedule.Next(start.Add(-time.Second)); !t.IsZero() && !t.After(end); t = cronSchedule.Next(t) {
+			if !seen[t.Unix()] {
+				seen[t.Unix()] = true
+				scheduledTimes = append(scheduledTimes, t)
+			}
+		}
+	}
+	sort.Slice(scheduledTimes, func(i, j int) bool { return scheduledTimes[i].Before(scheduledTimes[j]) })
+	return scheduledTimes, nil
 }
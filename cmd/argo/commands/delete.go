@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -11,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -23,6 +25,7 @@ func NewDeleteCommand() *cobra.Command {
 		allNamespaces bool
 		dryRun        bool
 		force         bool
+		bulk          bulkOps
 	)
 	command := &cobra.Command{
 		Use:   "delete [--dry-run] [WORKFLOW...|[--all] [--older] [--completed] [--resubmitted] [--prefix PREFIX] [--selector SELECTOR] [--force] ]",
@@ -34,6 +37,10 @@ func NewDeleteCommand() *cobra.Command {
 # Delete the latest workflow:
 
   argo delete @latest
+
+# Delete tens of thousands of completed workflows, listing 1000 at a time and deleting 20 at a time:
+
+  argo delete --completed -l workflows.argoproj.io/test=true --batch-size 1000 --parallelism 20
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && !(all || allNamespaces || flags.completed || flags.resubmitted || flags.prefix != "" || flags.labels != "" || flags.fields != "" || flags.finishedAfter != "") {
@@ -43,38 +50,38 @@ func NewDeleteCommand() *cobra.Command {
 
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
-			var workflows wfv1.Workflows
 			if !allNamespaces {
 				flags.namespace = client.Namespace()
 			}
+			var named wfv1.Workflows
 			for _, name := range args {
-				workflows = append(workflows, wfv1.Workflow{
+				named = append(named, wfv1.Workflow{
 					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: flags.namespace},
 				})
 			}
+			var matched *listFlags
 			if all || flags.completed || flags.resubmitted || flags.prefix != "" || flags.labels != "" || flags.fields != "" || flags.finishedAfter != "" {
-				listed, err := listWorkflows(ctx, serviceClient, flags)
-				errors.CheckError(err)
-				workflows = append(workflows, listed...)
-			}
-
-			if len(workflows) == 0 {
-				fmt.Printf("No resources found\n")
-				return
+				matched = &flags
 			}
 
-			for _, wf := range workflows {
-				if !dryRun {
-					_, err := serviceClient.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: wf.Name, Namespace: wf.Namespace, Force: force})
-					if err != nil && status.Code(err) == codes.NotFound {
-						fmt.Printf("Workflow '%s' not found\n", wf.Name)
-						continue
-					}
-					errors.CheckError(err)
-					fmt.Printf("Workflow '%s' deleted\n", wf.Name)
-				} else {
-					fmt.Printf("Workflow '%s' deleted (dry-run)\n", wf.Name)
+			deleteOp := newBulkOperation(bulk, "deleted", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
+				if dryRun {
+					return fmt.Sprintf("Workflow '%s' deleted (dry-run)", wf.Name), nil
+				}
+				_, err := serviceClient.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: wf.Name, Namespace: wf.Namespace, Force: force})
+				if err != nil && status.Code(err) == codes.NotFound {
+					return fmt.Sprintf("Workflow '%s' not found", wf.Name), nil
 				}
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("Workflow '%s' deleted", wf.Name), nil
+			})
+			deleteOp.allNamespaces = allNamespaces
+			deleted, err := deleteOp.run(ctx, serviceClient, matched, named)
+			errors.CheckError(err)
+			if deleted == 0 {
+				fmt.Printf("No resources found\n")
 			}
 		},
 	}
@@ -89,5 +96,7 @@ func NewDeleteCommand() *cobra.Command {
 	command.Flags().StringVar(&flags.fields, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	command.Flags().BoolVar(&dryRun, "dry-run", false, "Do not delete the workflow, only print what would happen")
 	command.Flags().BoolVar(&force, "force", false, "Force delete workflows by removing finalizers")
+	bulk.addFlags(command)
+	command.ValidArgsFunction = common.CompleteWorkflowNames()
 	return command
 }
@@ -28,6 +28,9 @@ func NewGetCommand() *cobra.Command {
 
 # Get the latest workflow:
   argo get @latest
+
+# Get the cost of each pod of a workflow:
+  argo get my-wf -o cost
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 {
@@ -48,27 +51,35 @@ func NewGetCommand() *cobra.Command {
 		},
 	}
 
-	command.Flags().StringVarP(&getArgs.Output, "output", "o", "", "Output format. One of: json|yaml|short|wide")
+	command.Flags().StringVarP(&getArgs.Output, "output", "o", "", "Output format. One of: json|yaml|short|wide|cost")
 	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
 	command.Flags().BoolVar(&common.NoUtf8, "no-utf8", false, "Use plain 7-bits ascii characters")
 	command.Flags().StringVar(&getArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error)")
 	command.Flags().StringVar(&getArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
+	command.ValidArgsFunction = common.CompleteWorkflowNames()
 	return command
 }
 
 func printWorkflow(wf *wfv1.Workflow, getArgs common.GetFlags) {
+	fmt.Print(sprintWorkflow(wf, getArgs))
+}
+
+func sprintWorkflow(wf *wfv1.Workflow, getArgs common.GetFlags) string {
 	switch getArgs.Output {
 	case "name":
-		fmt.Println(wf.ObjectMeta.Name)
+		return wf.ObjectMeta.Name + "\n"
 	case "json":
 		outBytes, _ := json.MarshalIndent(wf, "", "    ")
-		fmt.Println(string(outBytes))
+		return string(outBytes) + "\n"
 	case "yaml":
 		outBytes, _ := yaml.Marshal(wf)
-		fmt.Print(string(outBytes))
+		return string(outBytes)
+	case "cost":
+		return common.PrintCostHelper(wf)
 	case "short", "wide", "":
-		fmt.Print(common.PrintWorkflowHelper(wf, getArgs))
+		return common.PrintWorkflowHelper(wf, getArgs)
 	default:
 		log.Fatalf("Unknown output format: %s", getArgs.Output)
+		return ""
 	}
 }
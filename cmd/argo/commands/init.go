@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/argoproj/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+)
+
+type initOpts struct {
+	kind            string
+	name            string
+	image           string
+	dockerfile      string
+	command         []string
+	args            []string
+	workingDir      string
+	parameters      []string
+	inputArtifacts  []string
+	outputArtifacts []string
+}
+
+func NewInitCommand() *cobra.Command {
+	opts := initOpts{}
+	command := &cobra.Command{
+		Use:   "init [-- COMMAND [ARG...]]",
+		Short: "generate a workflow or workflow template manifest to get started with",
+		Long:  "Generate a commented workflow or workflow template manifest that runs a single container, and print it. Anything after \"--\" is the command the container runs.",
+		Example: `# Generate a hello world workflow:
+
+  argo init > hello-world.yaml
+
+# Generate a workflow that runs a Python script with a parameter, and submit it:
+
+  argo init --name my-wf --image python:3.10 -p message=hello -- python -c 'import sys; print(sys.argv[1])' '{{inputs.parameters.message}}' > my-wf.yaml
+  argo submit my-wf.yaml -p message=goodbye
+
+# Generate a workflow template that reads and writes artifacts:
+
+  argo init --kind WorkflowTemplate --name my-wftmpl --image my-image --input-artifact data=/tmp/data --output-artifact result=/tmp/result -- my-command
+
+# Generate a workflow that runs the entrypoint and command of an image built from a Dockerfile:
+
+  argo init --image my-image --dockerfile Dockerfile
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			opts.command = args
+			if opts.dockerfile != "" {
+				f, err := os.Open(opts.dockerfile)
+				errors.CheckError(err)
+				defer func() { _ = f.Close() }()
+				errors.CheckError(opts.applyDockerfile(f))
+			}
+			manifest, err := generateManifest(opts)
+			errors.CheckError(err)
+			fmt.Print(manifest)
+		},
+	}
+	command.Flags().StringVar(&opts.kind, "kind", workflow.WorkflowKind, "kind of the manifest, one of: Workflow, WorkflowTemplate")
+	command.Flags().StringVar(&opts.name, "name", "hello-world", "name of the workflow or workflow template")
+	command.Flags().StringVar(&opts.image, "image", "", "image the container runs. Defaults to a small image that prints \"hello world\"")
+	command.Flags().StringVar(&opts.dockerfile, "dockerfile", "", "Dockerfile of the image, to read the command, arguments and working directory from")
+	command.Flags().StringArrayVarP(&opts.parameters, "parameter", "p", []string{}, "input parameter, as NAME or NAME=DEFAULT")
+	command.Flags().StringArrayVar(&opts.inputArtifacts, "input-artifact", []string{}, "input artifact the container reads, as NAME=PATH")
+	command.Flags().StringArrayVar(&opts.outputArtifacts, "output-artifact", []string{}, "output artifact the container writes, as NAME=PATH")
+	return command
+}
+
+type initParameter struct {
+	Name    string
+	Default string
+}
+
+type initArtifact struct {
+	Name string
+	Path string
+}
+
+var manifestTemplate = template.Must(template.New("manifest").Funcs(template.FuncMap{"yaml": yamlScalar}).Parse(`# Generated by "argo init". Check it with "argo lint" and see
+# https://argoproj.github.io/argo-workflows/fields/ for all the fields you can set.
+apiVersion: argoproj.io/v1alpha1
+kind: {{.Kind}}
+metadata:
+{{- if eq .Kind "Workflow"}}
+  # each workflow is named with this prefix and a random suffix
+  generateName: {{yaml (print .Name "-")}}
+{{- else}}
+  name: {{yaml .Name}}
+{{- end}}
+spec:
+  # the template the workflow starts with
+  entrypoint: main
+{{- if or .Parameters .InputArtifacts}}
+  # the workflow's inputs, which are passed to the entrypoint template
+  arguments:
+{{- if .Parameters}}
+    parameters:
+{{- range .Parameters}}
+      # set with "argo submit -p {{.Name}}=VALUE"
+      - name: {{yaml .Name}}
+        value: {{yaml .Default}}
+{{- end}}
+{{- end}}
+{{- if .InputArtifacts}}
+    artifacts:
+{{- range .InputArtifacts}}
+      - name: {{yaml .Name}}
+        # replace with the location of the artifact, see
+        # https://argoproj.github.io/argo-workflows/walk-through/hardwired-artifacts/
+        http:
+          url: {{yaml (print "https://example.com/" .Name)}}
+{{- end}}
+{{- end}}
+{{- end}}
+  templates:
+    - name: main
+{{- if or .Parameters .InputArtifacts}}
+      inputs:
+{{- if .Parameters}}
+        # use as "{{"{{"}}inputs.parameters.NAME{{"}}"}}" in the container's command and arguments
+        parameters:
+{{- range .Parameters}}
+          - name: {{yaml .Name}}
+{{- end}}
+{{- end}}
+{{- if .InputArtifacts}}
+        # written to these paths in the container before it starts
+        artifacts:
+{{- range .InputArtifacts}}
+          - name: {{yaml .Name}}
+            path: {{yaml .Path}}
+{{- end}}
+{{- end}}
+{{- end}}
+      container:
+        image: {{yaml .Image}}
+{{- if .Command}}
+        command:
+{{- range .Command}}
+          - {{yaml .}}
+{{- end}}
+{{- else}}
+        # runs the image's entrypoint, set "command" to run something else
+{{- end}}
+{{- if .Args}}
+        args:
+{{- range .Args}}
+          - {{yaml .}}
+{{- end}}
+{{- end}}
+{{- if .WorkingDir}}
+        workingDir: {{yaml .WorkingDir}}
+{{- end}}
+        # requests help the scheduler find a node with enough room for the container
+        resources:
+          requests:
+            cpu: 100m
+            memory: 64Mi
+{{- if .OutputArtifacts}}
+      outputs:
+        # read from these paths in the container once it has finished, and saved to the artifact repository
+        artifacts:
+{{- range .OutputArtifacts}}
+          - name: {{yaml .Name}}
+            path: {{yaml .Path}}
+{{- end}}
+{{- end}}
+`))
+
+// generateManifest returns a commented manifest for a workflow or workflow template that runs a single container
+func generateManifest(opts initOpts) (string, error) {
+	if opts.kind != workflow.WorkflowKind && opts.kind != workflow.WorkflowTemplateKind {
+		return "", fmt.Errorf("kind must be one of: %s, %s", workflow.WorkflowKind, workflow.WorkflowTemplateKind)
+	}
+	if opts.name == "" {
+		return "", fmt.Errorf("name must not be empty")
+	}
+	data := struct {
+		Kind            string
+		Name            string
+		Image           string
+		Command         []string
+		Args            []string
+		WorkingDir      string
+		Parameters      []initParameter
+		InputArtifacts  []initArtifact
+		OutputArtifacts []initArtifact
+	}{
+		Kind:       opts.kind,
+		Name:       opts.name,
+		Image:      opts.image,
+		Command:    opts.command,
+		Args:       opts.args,
+		WorkingDir: opts.workingDir,
+	}
+	if data.Image == "" {
+		if len(data.Command) > 0 {
+			return "", fmt.Errorf("an image is required to run a command")
+		}
+		data.Image = "busybox"
+		data.Command = []string{"echo", "hello world"}
+	}
+	for _, p := range opts.parameters {
+		name, value, _ := strings.Cut(p, "=")
+		if name == "" {
+			return "", fmt.Errorf("expected parameter of the form: NAME or NAME=DEFAULT. Received: %s", p)
+		}
+		data.Parameters = append(data.Parameters, initParameter{Name: name, Default: value})
+	}
+	var err error
+	data.InputArtifacts, err = parseInitArtifacts(opts.inputArtifacts)
+	if err != nil {
+		return "", err
+	}
+	data.OutputArtifacts, err = parseInitArtifacts(opts.outputArtifacts)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := manifestTemplate.Execute(buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func parseInitArtifacts(values []string) ([]initArtifact, error) {
+	var artifacts []initArtifact
+	for _, a := range values {
+		name, path, ok := strings.Cut(a, "=")
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("expected artifact of the form: NAME=PATH. Received: %s", a)
+		}
+		artifacts = append(artifacts, initArtifact{Name: name, Path: path})
+	}
+	return artifacts, nil
+}
+
+// yamlScalar returns the string as a YAML scalar, quoting it if needed
+func yamlScalar(s string) (string, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+// applyDockerfile sets the command, arguments and working directory the image built from the Dockerfile runs,
+// unless a command was given
+func (o *initOpts) applyDockerfile(r io.Reader) error {
+	var entrypoint, cmd []string
+	workingDir := ""
+	shellForm := false
+	scanner := bufio.NewScanner(r)
+	line := ""
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if line == "" && strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasSuffix(text, "\\") {
+			line += strings.TrimSuffix(text, "\\") + " "
+			continue
+		}
+		line += text
+		instruction, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		value = strings.TrimSpace(value)
+		line = ""
+		switch strings.ToUpper(instruction) {
+		case "FROM":
+			// only the final stage is the image that runs
+			entrypoint, cmd, workingDir, shellForm = nil, nil, "", false
+		case "ENTRYPOINT":
+			entrypoint, shellForm = parseDockerfileCommand(value)
+			// setting the entrypoint resets the command
+			cmd = nil
+		case "CMD":
+			cmd, _ = parseDockerfileCommand(value)
+		case "WORKDIR":
+			if path.IsAbs(value) {
+				workingDir = value
+			} else {
+				workingDir = path.Join("/", workingDir, value)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(o.command) == 0 {
+		switch {
+		case len(entrypoint) > 0 && shellForm:
+			// the shell form of the entrypoint ignores the command
+			o.command = entrypoint
+		case len(entrypoint) > 0:
+			o.command, o.args = entrypoint, cmd
+		default:
+			o.command = cmd
+		}
+	}
+	if o.workingDir == "" {
+		o.workingDir = workingDir
+	}
+	return nil
+}
+
+// parseDockerfileCommand parses the exec form (a JSON array) or shell form of an ENTRYPOINT or CMD instruction
+func parseDockerfileCommand(value string) ([]string, bool) {
+	var command []string
+	if strings.HasPrefix(value, "[") && json.Unmarshal([]byte(value), &command) == nil {
+		return command, false
+	}
+	return []string{"/bin/sh", "-c", value}, true
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+func Test_generateManifest(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		manifest, err := generateManifest(initOpts{kind: "Workflow", name: "hello-world"})
+		if assert.NoError(t, err) {
+			wf := &wfv1.Workflow{}
+			if assert.NoError(t, yaml.UnmarshalStrict([]byte(manifest), wf)) {
+				assert.Equal(t, "hello-world-", wf.GenerateName)
+				assert.Equal(t, []string{"echo", "hello world"}, wf.Spec.Templates[0].Container.Command)
+				assert.NoError(t, validate.ValidateWorkflow(nil, nil, wf, validate.ValidateOpts{}))
+			}
+		}
+	})
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		manifest, err := generateManifest(initOpts{
+			kind:            "WorkflowTemplate",
+			name:            "my-wftmpl",
+			image:           "python:3.10",
+			command:         []string{"python", "-c", "print('{{inputs.parameters.message}}')"},
+			parameters:      []string{"message=hello: world", "other"},
+			inputArtifacts:  []string{"data=/tmp/data"},
+			outputArtifacts: []string{"result=/tmp/result"},
+		})
+		if assert.NoError(t, err) {
+			wftmpl := &wfv1.WorkflowTemplate{}
+			if assert.NoError(t, yaml.UnmarshalStrict([]byte(manifest), wftmpl)) {
+				assert.Equal(t, "my-wftmpl", wftmpl.Name)
+				assert.Equal(t, "hello: world", wftmpl.Spec.Arguments.GetParameterByName("message").Value.String())
+				assert.Equal(t, "", wftmpl.Spec.Arguments.GetParameterByName("other").Value.String())
+				tmpl := wftmpl.Spec.Templates[0]
+				assert.Equal(t, "python:3.10", tmpl.Container.Image)
+				assert.Equal(t, []string{"python", "-c", "print('{{inputs.parameters.message}}')"}, tmpl.Container.Command)
+				assert.Equal(t, "/tmp/data", tmpl.Inputs.GetArtifactByName("data").Path)
+				assert.Equal(t, "/tmp/result", tmpl.Outputs.GetArtifactByName("result").Path)
+				assert.NoError(t, validate.ValidateWorkflowTemplate(nil, nil, wftmpl, validate.ValidateOpts{}))
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := generateManifest(initOpts{kind: "Pod", name: "my-pod"})
+		assert.EqualError(t, err, "kind must be one of: Workflow, WorkflowTemplate")
+		_, err = generateManifest(initOpts{kind: "Workflow", name: "my-wf", command: []string{"echo"}})
+		assert.EqualError(t, err, "an image is required to run a command")
+		_, err = generateManifest(initOpts{kind: "Workflow", name: "my-wf", outputArtifacts: []string{"result"}})
+		assert.EqualError(t, err, "expected artifact of the form: NAME=PATH. Received: result")
+	})
+}
+
+func Test_applyDockerfile(t *testing.T) {
+	t.Run("ExecForm", func(t *testing.T) {
+		opts := &initOpts{}
+		err := opts.applyDockerfile(strings.NewReader(`FROM golang:1.18 AS builder
+ENTRYPOINT ["go"]
+WORKDIR /go/src
+
+FROM alpine:3.16
+# the app
+WORKDIR /app
+WORKDIR data
+ENTRYPOINT ["/app/server", \
+    "--port", "8080"]
+CMD ["--verbose"]
+`))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/app/server", "--port", "8080"}, opts.command)
+			assert.Equal(t, []string{"--verbose"}, opts.args)
+			assert.Equal(t, "/app/data", opts.workingDir)
+		}
+	})
+	t.Run("ShellForm", func(t *testing.T) {
+		opts := &initOpts{}
+		err := opts.applyDockerfile(strings.NewReader(`FROM alpine:3.16
+cmd echo hello
+`))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"/bin/sh", "-c", "echo hello"}, opts.command)
+			assert.Empty(t, opts.args)
+		}
+	})
+	t.Run("Command", func(t *testing.T) {
+		opts := &initOpts{command: []string{"my-command"}}
+		err := opts.applyDockerfile(strings.NewReader(`FROM alpine:3.16
+ENTRYPOINT ["/app/server"]
+`))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"my-command"}, opts.command)
+		}
+	})
+}
@@ -32,9 +32,14 @@ func NewLintCommand() *cobra.Command {
 
 # Lint only manifests of Workflows and CronWorkflows from stdin:
 
-  cat manifests.yaml | argo lint --kinds=workflows,cronworkflows -`,
+  cat manifests.yaml | argo lint --kinds=workflows,cronworkflows -
+
+# Lint without a cluster, resolving template references against the workflow templates in the linted files:
+
+  argo lint --offline ./manifests`,
 		Run: func(cmd *cobra.Command, args []string) {
 			client.Offline = offline
+			client.OfflineFiles = args
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			if len(args) == 0 {
 				cmd.HelpFunc()(cmd, args)
@@ -56,7 +61,7 @@ func NewLintCommand() *cobra.Command {
 	command.Flags().StringSliceVar(&lintKinds, "kinds", []string{"all"}, fmt.Sprintf("Which kinds will be linted. Can be: %s", strings.Join(allKinds, "|")))
 	command.Flags().StringVarP(&output, "output", "o", "pretty", "Linting results output format. One of: pretty|simple")
 	command.Flags().BoolVar(&strict, "strict", true, "Perform strict workflow validation")
-	command.Flags().BoolVar(&offline, "offline", false, "perform offline linting")
+	command.Flags().BoolVar(&offline, "offline", false, "perform offline linting, resolving template references against the (cluster) workflow templates in the linted files")
 
 	return command
 }
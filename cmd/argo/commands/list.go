@@ -10,6 +10,9 @@ import (
 	argotime "github.com/argoproj/pkg/time"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -95,6 +98,23 @@ func NewListCommand() *cobra.Command {
 }
 
 func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, flags listFlags) (wfv1.Workflows, error) {
+	var workflows wfv1.Workflows
+	err := listWorkflowChunks(ctx, serviceClient, flags, func(chunk wfv1.Workflows) error {
+		workflows = append(workflows, chunk...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(workflows)
+	return workflows, nil
+}
+
+// listWorkflowChunks lists the workflows flags.chunkSize at a time, and calls f with each chunk once it has been
+// filtered, so that the caller need not hold every workflow in memory. If the continue token expires part way through,
+// e.g. because f took longer than the API server keeps its snapshot, the list is restarted and the workflows already
+// passed to f are skipped.
+func listWorkflowChunks(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, flags listFlags, f func(wfv1.Workflows) error) error {
 	listOpts := &metav1.ListOptions{
 		Limit: flags.chunkSize,
 	}
@@ -123,7 +143,7 @@ func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 	}
 	listOpts.LabelSelector = labelSelector.String()
 	listOpts.FieldSelector = flags.fields
-	var workflows wfv1.Workflows
+	seen := map[string]bool{}
 	for {
 		log.WithField("listOpts", listOpts).Debug()
 		wfList, err := serviceClient.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
@@ -131,15 +151,39 @@ func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 			ListOptions: listOpts,
 			Fields:      flags.displayFields(),
 		})
+		if listOpts.Continue != "" && isExpired(err) {
+			log.WithError(err).Warn("The list's continue token has expired, restarting the list")
+			listOpts.Continue = ""
+			continue
+		}
 		if err != nil {
-			return nil, err
+			return err
+		}
+		chunk := wfList.Items.Filter(func(wf wfv1.Workflow) bool {
+			key := wf.Namespace + "/" + wf.Name
+			if seen[key] {
+				return false
+			}
+			seen[key] = true
+			return true
+		})
+		if err := f(filterWorkflows(chunk, flags)); err != nil {
+			return err
 		}
-		workflows = append(workflows, wfList.Items...)
 		if wfList.Continue == "" {
-			break
+			return nil
 		}
 		listOpts.Continue = wfList.Continue
 	}
+}
+
+// isExpired returns whether the error is because a list's continue token has expired, either from the API server, or
+// translated by the Argo Server
+func isExpired(err error) bool {
+	return apierr.IsResourceExpired(err) || apierr.IsGone(err) || status.Code(err) == codes.Aborted
+}
+
+func filterWorkflows(workflows wfv1.Workflows, flags listFlags) wfv1.Workflows {
 	workflows = workflows.
 		Filter(func(wf wfv1.Workflow) bool {
 			return strings.HasPrefix(wf.ObjectMeta.Name, flags.prefix)
@@ -162,6 +206,5 @@ func listWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 			workflows = workflows.Filter(wfv1.WorkflowFinishedBefore(*t))
 		}
 	}
-	sort.Sort(workflows)
-	return workflows, nil
+	return workflows
 }
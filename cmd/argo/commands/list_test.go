@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
@@ -88,6 +90,31 @@ func Test_listWorkflows(t *testing.T) {
 	})
 }
 
+func Test_listWorkflowChunks(t *testing.T) {
+	t.Run("ExpiredContinueToken", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		first := &wfv1.WorkflowList{Items: wfv1.Workflows{{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}}}
+		first.Continue = "my-continue"
+		c.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{ListOptions: &metav1.ListOptions{Limit: 1}, Fields: defaultFields}).
+			Return(first, nil).Once()
+		c.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{ListOptions: &metav1.ListOptions{Limit: 1, Continue: "my-continue"}, Fields: defaultFields}).
+			Return(nil, status.Error(codes.Aborted, "continue token expired")).Once()
+		c.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{ListOptions: &metav1.ListOptions{Limit: 1}, Fields: defaultFields}).
+			Return(&wfv1.WorkflowList{Items: wfv1.Workflows{{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}, {ObjectMeta: metav1.ObjectMeta{Name: "bar"}}}}, nil).Once()
+		var names []string
+		err := listWorkflowChunks(context.Background(), c, listFlags{chunkSize: 1}, func(chunk wfv1.Workflows) error {
+			for _, wf := range chunk {
+				names = append(names, wf.Name)
+			}
+			return nil
+		})
+		if assert.NoError(t, err) {
+			// the relisted workflow is not returned twice
+			assert.Equal(t, []string{"foo", "bar"}, names)
+		}
+	})
+}
+
 func list(listOptions *metav1.ListOptions, flags listFlags) (wfv1.Workflows, error) {
 	c := &workflowmocks.WorkflowServiceClient{}
 	c.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{ListOptions: listOptions, Fields: flags.displayFields()}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
@@ -0,0 +1,77 @@
+package lock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/argoproj/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
+)
+
+func NewListCommand() *cobra.Command {
+	var output string // --output
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "list the workflows and nodes holding or waiting for each lock",
+		Example: `# List the locks of the current namespace:
+
+  argo lock list
+
+# List the locks as JSON:
+
+  argo lock list -o json
+`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewSyncLockServiceClient()
+			errors.CheckError(err)
+			list, err := serviceClient.ListSyncLocks(ctx, &syncpkg.ListSyncLocksRequest{Namespace: client.Namespace()})
+			errors.CheckError(err)
+			switch output {
+			case "", "wide":
+				printLocks(os.Stdout, list.Items)
+			case "json":
+				data, err := json.MarshalIndent(list.Items, "", "  ")
+				errors.CheckError(err)
+				fmt.Println(string(data))
+			default:
+				errors.CheckError(fmt.Errorf("unknown output mode: %s", output))
+			}
+		},
+	}
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: wide|json")
+	return command
+}
+
+func printLocks(out io.Writer, locks []*syncpkg.LockStatus) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	_, _ = fmt.Fprintln(w, "LOCK\tTYPE\tSTATE\tWORKFLOW\tNODE\tWEIGHT\tDURATION")
+	for _, lock := range locks {
+		for _, users := range []struct {
+			state string
+			users []*syncpkg.LockUser
+		}{{"Holding", lock.Holders}, {"Waiting", lock.Waiting}} {
+			for _, user := range users.users {
+				weight := "-"
+				if user.Weight > 0 {
+					weight = strconv.FormatInt(user.Weight, 10)
+				}
+				node := user.NodeId
+				if node == "" {
+					node = "-"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", lock.Name, lock.Type, users.state, user.Workflow, node, weight, time.Duration(user.Duration)*time.Second)
+			}
+		}
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,38 @@
+package lock
+
+import (
+	"fmt"
+
+	"github.com/argoproj/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
+)
+
+func NewReleaseCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "release LOCK WORKFLOW",
+		Short: "force a workflow, and its nodes, to release a lock, e.g. when it is stuck holding it",
+		Long:  "The workflow controller releases the lock when it next reconciles the workflow. A workflow holding a workflow-level lock must acquire it again before it continues.",
+		Example: `# Force my-wf to release the my-config semaphore:
+
+  argo lock release argo/ConfigMap/my-config/workflow my-wf
+
+# Force my-wf to release the my-mutex mutex:
+
+  argo lock release argo/Mutex/my-mutex my-wf
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewSyncLockServiceClient()
+			errors.CheckError(err)
+			lock, workflow := args[0], args[1]
+			_, err = serviceClient.ReleaseSyncLock(ctx, &syncpkg.ReleaseSyncLockRequest{Namespace: client.Namespace(), Lock: lock, Workflow: workflow})
+			errors.CheckError(err)
+			fmt.Printf("Workflow %s will release %s\n", workflow, lock)
+		},
+	}
+	return command
+}
@@ -0,0 +1,19 @@
+package lock
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewLockCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "lock",
+		Short: "manage the semaphores and mutexes of workflows, requires the Argo Server",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.HelpFunc()(cmd, args)
+		},
+	}
+
+	command.AddCommand(NewListCommand())
+	command.AddCommand(NewReleaseCommand())
+	return command
+}
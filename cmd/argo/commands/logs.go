@@ -13,15 +13,18 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 )
 
 func NewLogsCommand() *cobra.Command {
 	var (
 		since     time.Duration
 		sinceTime string
+		untilTime string
 		tailLines int64
 		grep      string
 		selector  string
+		output    string
 	)
 	logOptions := &corev1.PodLogOptions{}
 	command := &cobra.Command{
@@ -53,6 +56,10 @@ func NewLogsCommand() *cobra.Command {
 
 # Print the logs of the latest workflow:
   argo logs @latest
+
+# Print the lines of a workflow's logs that match a regular expression, between two times, as JSON:
+
+  argo logs my-wf --grep 'error|warn' --since-time 2022-10-01T09:00:00Z --until-time 2022-10-01T10:00:00Z --output json
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			// parse all the args
@@ -85,6 +92,15 @@ func NewLogsCommand() *cobra.Command {
 				logOptions.SinceTime = &sinceTime
 			}
 
+			if untilTime != "" {
+				_, err := time.Parse(time.RFC3339, untilTime)
+				errors.CheckError(err)
+			}
+
+			if output != "" && output != "json" {
+				log.Fatalf("Unknown output format: %s", output)
+			}
+
 			if tailLines >= 0 {
 				logOptions.TailLines = pointer.Int64Ptr(tailLines)
 			}
@@ -94,7 +110,15 @@ func NewLogsCommand() *cobra.Command {
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
 
-			common.LogWorkflow(ctx, serviceClient, namespace, workflow, podName, grep, selector, logOptions)
+			common.LogWorkflowWithRequest(ctx, serviceClient, &workflowpkg.WorkflowLogRequest{
+				Name:       workflow,
+				Namespace:  namespace,
+				PodName:    podName,
+				LogOptions: logOptions,
+				Selector:   selector,
+				Grep:       grep,
+				UntilTime:  untilTime,
+			}, output)
 		},
 	}
 	command.Flags().StringVarP(&logOptions.Container, "container", "c", "main", "Print the logs of this container")
@@ -102,10 +126,12 @@ func NewLogsCommand() *cobra.Command {
 	command.Flags().BoolVarP(&logOptions.Previous, "previous", "p", false, "Specify if the previously terminated container logs should be returned.")
 	command.Flags().DurationVar(&since, "since", 0, "Only return logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs. Only one of since-time / since may be used.")
 	command.Flags().StringVar(&sinceTime, "since-time", "", "Only return logs after a specific date (RFC3339). Defaults to all logs. Only one of since-time / since may be used.")
+	command.Flags().StringVar(&untilTime, "until-time", "", "Only return logs up to and including a specific date (RFC3339). Defaults to all logs.")
 	command.Flags().Int64Var(&tailLines, "tail", -1, "If set, the number of lines from the end of the logs to show. If not specified, logs are shown from the creation of the container or sinceSeconds or sinceTime")
-	command.Flags().StringVar(&grep, "grep", "", "grep for lines")
+	command.Flags().StringVar(&grep, "grep", "", "Only return lines matching this regular expression")
 	command.Flags().StringVarP(&selector, "selector", "l", "", "log selector for some pod")
 	command.Flags().BoolVar(&logOptions.Timestamps, "timestamps", false, "Include timestamps on each line in the log output")
 	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
+	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json, which prints each line as a record with its pod, container and timestamp")
 	return command
 }
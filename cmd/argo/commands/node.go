@@ -11,15 +11,18 @@ import (
 	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 type setOps struct {
 	message           string   // --message
 	phase             string   // --phase
 	outputParameters  []string // --output-parameters
+	outputArtifacts   []string // --output-artifact
 	nodeFieldSelector string   // --node-field-selector
 }
 
@@ -33,6 +36,10 @@ func NewNodeCommand() *cobra.Command {
 
   argo node set my-wf --output-parameter parameter-name="Hello, world!" --node-field-selector displayName=approve
 
+# Set an output artifact of a node to an existing object in the artifact repository, and resume the workflow:
+
+  argo node set my-wf --output-artifact report='{"s3": {"key": "reports/report.pdf"}}' --phase Succeeded --node-field-selector displayName=approve
+
 # Set the message of a node within a workflow:
 
   argo node set my-wf --message "We did it!"" --node-field-selector displayName=approve
@@ -68,6 +75,27 @@ func NewNodeCommand() *cobra.Command {
 				outputParameters = string(res)
 			}
 
+			outputArtifacts := ""
+			if len(setArgs.outputArtifacts) > 0 {
+				outputArts := make(map[string]wfv1.ArtifactLocation)
+				for _, art := range setArgs.outputArtifacts {
+					parts := strings.SplitN(art, "=", 2)
+					if len(parts) != 2 {
+						log.Fatalf("expected artifact of the form: NAME=LOCATION. Received: %s", art)
+					}
+					location := wfv1.ArtifactLocation{}
+					if err := yaml.UnmarshalStrict([]byte(parts[1]), &location); err != nil {
+						log.Fatalf("unable to parse location of output artifact '%s': %s", parts[0], err)
+					}
+					outputArts[parts[0]] = location
+				}
+				res, err := json.Marshal(outputArts)
+				if err != nil {
+					log.Fatalf("unable to parse output artifact set request: %s", err)
+				}
+				outputArtifacts = string(res)
+			}
+
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
@@ -84,6 +112,7 @@ func NewNodeCommand() *cobra.Command {
 				Message:           setArgs.message,
 				Phase:             setArgs.phase,
 				OutputParameters:  outputParameters,
+				OutputArtifacts:   outputArtifacts,
 			})
 			errors.CheckError(err)
 			fmt.Printf("workflow values set\n")
@@ -92,6 +121,7 @@ func NewNodeCommand() *cobra.Command {
 	command.Flags().StringVar(&setArgs.nodeFieldSelector, "node-field-selector", "", "Selector of node to set, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
 	command.Flags().StringVar(&setArgs.phase, "phase", "", "Phase to set the node to, eg: --phase Succeeded")
 	command.Flags().StringArrayVarP(&setArgs.outputParameters, "output-parameter", "p", []string{}, "Set a \"supplied\" output parameter of node, eg: --output-parameter parameter-name=\"Hello, world!\"")
+	command.Flags().StringArrayVar(&setArgs.outputArtifacts, "output-artifact", []string{}, "Set an output artifact of node, that has no location, to a location in JSON or YAML, eg: --output-artifact report='{\"s3\": {\"key\": \"report.pdf\"}}'")
 	command.Flags().StringVarP(&setArgs.message, "message", "m", "", "Set the message of a node, eg: --message \"Hello, world!\"")
 	return command
 }
@@ -14,11 +14,13 @@ import (
 )
 
 type resubmitOps struct {
-	priority      int32  // --priority
-	memoized      bool   // --memoized
-	namespace     string // --namespace
-	labelSelector string // --selector
-	fieldSelector string // --field-selector
+	priority             int32    // --priority
+	memoized             bool     // --memoized
+	namespace            string   // --namespace
+	labelSelector        string   // --selector
+	fieldSelector        string   // --field-selector
+	carryOverLabels      []string // --carry-over-labels
+	carryOverAnnotations []string // --carry-over-annotations
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -53,6 +55,10 @@ func NewResubmitCommand() *cobra.Command {
 
   argo resubmit --field-selector metadata.namespace=argo
 
+# Resubmit with a different parameter, only carrying over the labels and annotations of your team:
+
+  argo resubmit -p message=goodbye --carry-over-labels 'example.com/*' --carry-over-annotations 'example.com/*' my-wf
+
 # Resubmit and wait for completion:
 
   argo resubmit --wait my-wf.yaml
@@ -90,7 +96,10 @@ func NewResubmitCommand() *cobra.Command {
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&resubmitOpts.memoized, "memoized", false, "re-use successful steps & outputs from the previous run")
 	command.Flags().StringVarP(&resubmitOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	command.Flags().StringSliceVar(&resubmitOpts.carryOverLabels, "carry-over-labels", nil, "globs of the keys of the labels that carry over to the resubmitted workflow, e.g. 'example.com/*'. Defaults to all of them")
+	command.Flags().StringSliceVar(&resubmitOpts.carryOverAnnotations, "carry-over-annotations", nil, "globs of the keys of the annotations that carry over to the resubmitted workflow, e.g. 'example.com/*'. Defaults to all of them")
 	command.Flags().StringVar(&resubmitOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
+	command.ValidArgsFunction = common.CompleteWorkflowNames()
 	return command
 }
 
@@ -131,10 +140,12 @@ func resubmitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowSe
 		resubmittedNames[wf.Name] = true
 
 		lastResubmitted, err = serviceClient.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{
-			Namespace:  wf.Namespace,
-			Name:       wf.Name,
-			Memoized:   resubmitOpts.memoized,
-			Parameters: cliSubmitOpts.Parameters,
+			Namespace:            wf.Namespace,
+			Name:                 wf.Name,
+			Memoized:             resubmitOpts.memoized,
+			Parameters:           cliSubmitOpts.Parameters,
+			CarryOverLabels:      resubmitOpts.carryOverLabels,
+			CarryOverAnnotations: resubmitOpts.carryOverAnnotations,
 		})
 		if err != nil {
 			return err
@@ -52,6 +52,29 @@ func Test_resubmitWorkflows(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Resubmit workflow with parameters and carry-over", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		resubmitOpts := resubmitOps{
+			namespace:            "argo",
+			carryOverLabels:      []string{"example.com/*"},
+			carryOverAnnotations: []string{"none"},
+		}
+		cliSubmitOpts := common.CliSubmitOpts{Parameters: []string{"message=goodbye"}}
+
+		c.On("ResubmitWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+
+		err := resubmitWorkflows(context.Background(), c, resubmitOpts, cliSubmitOpts, []string{"foo"})
+		c.AssertCalled(t, "ResubmitWorkflow", mock.Anything, &workflowpkg.WorkflowResubmitRequest{
+			Name:                 "foo",
+			Namespace:            "argo",
+			Parameters:           []string{"message=goodbye"},
+			CarryOverLabels:      []string{"example.com/*"},
+			CarryOverAnnotations: []string{"none"},
+		})
+
+		assert.NoError(t, err)
+	})
+
 	t.Run("Resubmit workflow by selector", func(t *testing.T) {
 		c := &workflowmocks.WorkflowServiceClient{}
 		resubmitOpts := resubmitOps{
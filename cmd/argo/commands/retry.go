@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
@@ -22,6 +23,7 @@ type retryOps struct {
 	namespace         string // --namespace
 	labelSelector     string // --selector
 	fieldSelector     string // --field-selector
+	bulkOps
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -56,6 +58,10 @@ func NewRetryCommand() *cobra.Command {
 
   argo retry --field-selector metadata.namespace=argo
 
+# Retry tens of thousands of failed workflows, listing 1000 at a time and retrying 20 at a time:
+
+  argo retry -l workflows.argoproj.io/phase=Failed --batch-size 1000 --parallelism 20
+
 # Retry and wait for completion:
 
   argo retry --wait my-wf.yaml
@@ -94,6 +100,8 @@ func NewRetryCommand() *cobra.Command {
 	command.Flags().StringVar(&retryOpts.nodeFieldSelector, "node-field-selector", "", "selector of nodes to reset, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
 	command.Flags().StringVarP(&retryOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&retryOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
+	retryOpts.bulkOps.addFlags(command)
+	command.ValidArgsFunction = common.CompleteWorkflowNames(wfv1.WorkflowFailed, wfv1.WorkflowError)
 	return command
 }
 
@@ -103,20 +111,18 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 	if err != nil {
 		return fmt.Errorf("unable to parse node field selector '%s': %s", retryOpts.nodeFieldSelector, err)
 	}
-	var wfs wfv1.Workflows
+	var matched *listFlags
 	if retryOpts.hasSelector() {
-		wfs, err = listWorkflows(ctx, serviceClient, listFlags{
+		matched = &listFlags{
 			namespace: retryOpts.namespace,
 			fields:    retryOpts.fieldSelector,
 			labels:    retryOpts.labelSelector,
-		})
-		if err != nil {
-			return err
 		}
 	}
 
+	var named wfv1.Workflows
 	for _, n := range args {
-		wfs = append(wfs, wfv1.Workflow{
+		named = append(named, wfv1.Workflow{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      n,
 				Namespace: retryOpts.namespace,
@@ -124,16 +130,12 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 		})
 	}
 
-	var lastRetried *wfv1.Workflow
-	retriedNames := make(map[string]bool)
-	for _, wf := range wfs {
-		if _, ok := retriedNames[wf.Name]; ok {
-			// de-duplication in case there is an overlap between the selector and given workflow names
-			continue
-		}
-		retriedNames[wf.Name] = true
-
-		lastRetried, err = serviceClient.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{
+	var (
+		lastRetried *wfv1.Workflow
+		mu          sync.Mutex
+	)
+	retried, err := newBulkOperation(retryOpts.bulkOps, "retried", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
+		retriedWf, err := serviceClient.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{
 			Name:              wf.Name,
 			Namespace:         wf.Namespace,
 			RestartSuccessful: retryOpts.restartSuccessful,
@@ -141,11 +143,17 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 			Parameters:        cliSubmitOpts.Parameters,
 		})
 		if err != nil {
-			return err
+			return "", err
 		}
-		printWorkflow(lastRetried, common.GetFlags{Output: cliSubmitOpts.Output})
+		mu.Lock()
+		lastRetried = retriedWf
+		mu.Unlock()
+		return sprintWorkflow(retriedWf, common.GetFlags{Output: cliSubmitOpts.Output}), nil
+	}).run(ctx, serviceClient, matched, named)
+	if err != nil {
+		return err
 	}
-	if len(retriedNames) == 1 {
+	if retried == 1 {
 		// watch or wait when there is only one workflow retried
 		common.WaitWatchOrLog(ctx, serviceClient, lastRetried.Namespace, []string{lastRetried.Name}, cliSubmitOpts)
 	}
@@ -1,17 +1,18 @@
 package commands
 
 import (
-	"github.com/argoproj/pkg/cli"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/admin"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/archive"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/auth"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/clustertemplate"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/cron"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/executorplugin"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/lock"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/template"
 	cmdutil "github.com/argoproj/argo-workflows/v3/util/cmd"
 )
@@ -82,6 +83,32 @@ Use the same configuration as GRPC mode, but also set:
 If your server is behind an ingress with a path (you'll be running "argo server --basehref /...) or "BASE_HREF=/... argo server"):
 
 	ARGO_BASE_HREF=/argo
+
+# Profiles
+
+Rather than setting these environment variables, you can name sets of them in the file ~/.argo/profiles.yaml (or ARGO_PROFILES_FILE) and choose one with "--profile" or ARGO_PROFILE. Flags and environment variables take precedence over the profile:
+
+	defaultProfile: dev
+	profiles:
+	  dev:
+	    namespace: argo
+	  prod:
+	    argoServer: argo.example.com:443  # ARGO_SERVER
+	    baseHRef: /argo                   # ARGO_BASE_HREF
+	    http1: false                      # ARGO_HTTP1
+	    secure: true                      # ARGO_SECURE
+	    insecureSkipVerify: false         # ARGO_INSECURE_SKIP_VERIFY
+	    headers: []                       # --header
+	    authMode: token                   # one of "kubeconfig" (default), "token" (uses "token") or "none"
+	    token: Bearer ******              # ARGO_TOKEN
+	    namespace: argo                   # ARGO_NAMESPACE
+	    instanceID: ""                    # ARGO_INSTANCEID
+	    kubeconfig: /home/me/.kube/prod   # --kubeconfig
+	    kubeContext: prod                 # --context
+
+# Impersonation
+
+To debug RBAC, use "--as" and "--as-group" to make requests as another user, like kubectl. This requires permission to impersonate them and, if using the Argo Server, that it runs with the client auth mode.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.HelpFunc()(cmd, args)
@@ -91,6 +118,7 @@ If your server is behind an ingress with a path (you'll be running "argo server
 	command.AddCommand(NewCompletionCommand())
 	command.AddCommand(NewDeleteCommand())
 	command.AddCommand(NewGetCommand())
+	command.AddCommand(NewInitCommand())
 	command.AddCommand(NewLintCommand())
 	command.AddCommand(NewListCommand())
 	command.AddCommand(NewLogsCommand())
@@ -113,6 +141,8 @@ If your server is behind an ingress with a path (you'll be running "argo server
 	command.AddCommand(cron.NewCronWorkflowCommand())
 	command.AddCommand(clustertemplate.NewClusterTemplateCommand())
 	command.AddCommand(executorplugin.NewRootCommand())
+	command.AddCommand(lock.NewLockCommand())
+	command.AddCommand(admin.NewAdminCommand())
 
 	client.AddKubectlFlagsToCmd(command)
 	client.AddAPIClientFlagsToCmd(command)
@@ -125,11 +155,11 @@ If your server is behind an ingress with a path (you'll be running "argo server
 			logLevel = "debug"
 			glogLevel = 6
 		}
-		cli.SetLogLevel(logLevel)
+		cmdutil.SetLogLevels(logLevel)
 		cmdutil.SetGLogLevel(glogLevel)
 		log.WithField("version", argo.GetVersion()).Debug("CLI version")
 	}
-	command.PersistentFlags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.PersistentFlags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error. Components may have their own levels, e.g. info,operator=debug")
 	command.PersistentFlags().IntVar(&glogLevel, "gloglevel", 0, "Set the glog logging level")
 	command.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enabled verbose logging, i.e. --loglevel debug")
 
@@ -2,6 +2,8 @@ package commands
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -46,9 +48,11 @@ func NewServerCommand() *cobra.Command {
 		baseHRef                 string
 		secure                   bool
 		tlsCertificateSecretName string
+		clientCAFile             string
 		htst                     bool
 		namespaced               bool   // --namespaced
 		managedNamespace         string // --managed-namespace
+		managedNamespaceSelector string // --managed-namespace-selector
 		enableOpenBrowser        bool
 		eventOperationQueueSize  int
 		eventWorkerCount         int
@@ -56,6 +60,7 @@ func NewServerCommand() *cobra.Command {
 		frameOptions             string
 		accessControlAllowOrigin string
 		apiRateLimit             uint64
+		apiRateLimitPerIdentity  uint64
 		allowedLinkProtocol      []string
 		logFormat                string // --log-format
 	)
@@ -98,6 +103,9 @@ See %s`, help.ArgoServer),
 			if namespaced && managedNamespace == "" {
 				managedNamespace = namespace
 			}
+			if namespaced && managedNamespaceSelector != "" {
+				return fmt.Errorf("--managed-namespace-selector cannot be used with --namespaced")
+			}
 
 			ssoNamespace := namespace
 			if managedNamespace != "" {
@@ -105,12 +113,13 @@ See %s`, help.ArgoServer),
 			}
 
 			log.WithFields(log.Fields{
-				"authModes":        authModes,
-				"namespace":        namespace,
-				"managedNamespace": managedNamespace,
-				"ssoNamespace":     ssoNamespace,
-				"baseHRef":         baseHRef,
-				"secure":           secure,
+				"authModes":                authModes,
+				"namespace":                namespace,
+				"managedNamespace":         managedNamespace,
+				"managedNamespaceSelector": managedNamespaceSelector,
+				"ssoNamespace":             ssoNamespace,
+				"baseHRef":                 baseHRef,
+				"secure":                   secure,
 			}).Info()
 
 			var tlsConfig *tls.Config
@@ -146,6 +155,25 @@ See %s`, help.ArgoServer),
 					return err
 				}
 			}
+			if modes[auth.ClientCert] {
+				if tlsConfig == nil {
+					return errors.New("the client-cert auth mode requires --secure")
+				}
+				if clientCAFile == "" {
+					return errors.New("the client-cert auth mode requires --client-ca-file")
+				}
+				data, err := os.ReadFile(clientCAFile)
+				if err != nil {
+					return err
+				}
+				clientCAs := x509.NewCertPool()
+				if !clientCAs.AppendCertsFromPEM(data) {
+					return fmt.Errorf("no certificates found in %s", clientCAFile)
+				}
+				tlsConfig.ClientCAs = clientCAs
+				// requests without a client certificate may use the other auth modes
+				tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+			}
 			if reflect.DeepEqual(modes, auth.Modes{auth.Server: true}) {
 				log.Warn("You are running without client authentication. Learn how to enable client authentication: https://argoproj.github.io/argo-workflows/argo-server-auth-mode/")
 			}
@@ -160,6 +188,7 @@ See %s`, help.ArgoServer),
 				RestConfig:               config,
 				AuthModes:                modes,
 				ManagedNamespace:         managedNamespace,
+				ManagedNamespaceSelector: managedNamespaceSelector,
 				SSONamespace:             ssoNamespace,
 				ConfigName:               configMap,
 				EventOperationQueueSize:  eventOperationQueueSize,
@@ -168,6 +197,7 @@ See %s`, help.ArgoServer),
 				XFrameOptions:            frameOptions,
 				AccessControlAllowOrigin: accessControlAllowOrigin,
 				APIRateLimit:             apiRateLimit,
+				APIRateLimitPerIdentity:  apiRateLimitPerIdentity,
 				AllowedLinkProtocol:      allowedLinkProtocol,
 			}
 			browserOpenFunc := func(url string) {}
@@ -218,10 +248,12 @@ See %s`, help.ArgoServer),
 	// "-e" for encrypt, like zip
 	command.Flags().BoolVarP(&secure, "secure", "e", true, "Whether or not we should listen on TLS.")
 	command.Flags().BoolVar(&htst, "hsts", true, "Whether or not we should add a HTTP Secure Transport Security header. This only has effect if secure is enabled.")
-	command.Flags().StringArrayVar(&authModes, "auth-mode", []string{"client"}, "API server authentication mode. Any 1 or more length permutation of: client,server,sso")
+	command.Flags().StringArrayVar(&authModes, "auth-mode", []string{"client"}, "API server authentication mode. Any 1 or more length permutation of: client,server,sso,client-cert")
+	command.Flags().StringVar(&clientCAFile, "client-ca-file", "", "Path to a PEM bundle of the certificate authorities that sign client certificates, for the client-cert auth mode")
 	command.Flags().StringVar(&configMap, "configmap", common.ConfigMapName, "Name of K8s configmap to retrieve workflow controller configuration")
 	command.Flags().BoolVar(&namespaced, "namespaced", false, "run as namespaced mode")
 	command.Flags().StringVar(&managedNamespace, "managed-namespace", "", "namespace that watches, default to the installation namespace")
+	command.Flags().StringVar(&managedNamespaceSelector, "managed-namespace-selector", "", "namespaces that the server serves, as a label selector, e.g. workflows.argoproj.io/managed=true. Cannot be used with --namespaced")
 	command.Flags().BoolVarP(&enableOpenBrowser, "browser", "b", false, "enable automatic launching of the browser [local mode]")
 	command.Flags().IntVar(&eventOperationQueueSize, "event-operation-queue-size", 16, "how many events operations that can be queued at once")
 	command.Flags().IntVar(&eventWorkerCount, "event-worker-count", 4, "how many event workers to run")
@@ -229,6 +261,7 @@ See %s`, help.ArgoServer),
 	command.Flags().StringVar(&frameOptions, "x-frame-options", "DENY", "Set X-Frame-Options header in HTTP responses.")
 	command.Flags().StringVar(&accessControlAllowOrigin, "access-control-allow-origin", "", "Set Access-Control-Allow-Origin header in HTTP responses.")
 	command.Flags().Uint64Var(&apiRateLimit, "api-rate-limit", 1000, "Set limit per IP for api ratelimiter")
+	command.Flags().Uint64Var(&apiRateLimitPerIdentity, "api-rate-limit-per-identity", 0, "Set limit per user (i.e. per SSO subject, service account or token) for api ratelimiter, 0 for no limit")
 	command.Flags().StringArrayVar(&allowedLinkProtocol, "allowed-link-protocol", defaultAllowedLinkProtocol, "Allowed link protocol in configMap. Used if the allowed configMap links protocol are different from http,https. Defaults to the environment variable ALLOWED_LINK_PROTOCOL")
 	command.Flags().StringVar(&logFormat, "log-format", "text", "The formatter to use for logs. One of: text|json")
 
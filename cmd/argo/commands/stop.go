@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -22,6 +23,8 @@ type stopOps struct {
 	labelSelector     string // --selector
 	fieldSelector     string // --field-selector
 	dryRun            bool   // --dry-run
+	strategy          string // --strategy
+	bulkOps
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -53,6 +56,14 @@ func NewStopCommand() *cobra.Command {
 # Stop multiple workflows by field selector
 
   argo stop --field-selector metadata.namespace=argo
+
+# Drain a workflow, not starting any new steps but letting running ones finish:
+
+  argo stop my-wf --strategy drain
+
+# Stop tens of thousands of workflows, listing 1000 at a time and stopping 20 at a time:
+
+  argo stop -l workflows.argoproj.io/test=true --batch-size 1000 --parallelism 20
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) == 0 && !stopArgs.hasSelector() {
@@ -72,6 +83,9 @@ func NewStopCommand() *cobra.Command {
 	command.Flags().StringVarP(&stopArgs.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&stopArgs.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	command.Flags().BoolVar(&stopArgs.dryRun, "dry-run", false, "If true, only stop the workflows that would be stopped, without stopping them.")
+	command.Flags().StringVar(&stopArgs.strategy, "strategy", "stop", "One of: stop|drain. stop terminates running pods, drain lets them finish. Neither starts new steps other than exit handlers.")
+	stopArgs.bulkOps.addFlags(command)
+	command.ValidArgsFunction = common.CompleteWorkflowNames(wfv1.WorkflowRunning, wfv1.WorkflowPending)
 	return command
 }
 
@@ -81,20 +95,26 @@ func stopWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 	if err != nil {
 		return fmt.Errorf("unable to parse node field selector '%s': %s", stopArgs.nodeFieldSelector, err)
 	}
-	var wfs wfv1.Workflows
+	var strategy wfv1.ShutdownStrategy
+	switch stopArgs.strategy {
+	case "", "stop":
+	case "drain":
+		strategy = wfv1.ShutdownStrategyDrain
+	default:
+		return fmt.Errorf("unknown strategy '%s', must be stop or drain", stopArgs.strategy)
+	}
+	var matched *listFlags
 	if stopArgs.hasSelector() {
-		wfs, err = listWorkflows(ctx, serviceClient, listFlags{
+		matched = &listFlags{
 			namespace: stopArgs.namespace,
 			fields:    stopArgs.fieldSelector,
 			labels:    stopArgs.labelSelector,
-		})
-		if err != nil {
-			return err
 		}
 	}
 
+	var named wfv1.Workflows
 	for _, n := range args {
-		wfs = append(wfs, wfv1.Workflow{
+		named = append(named, wfv1.Workflow{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      n,
 				Namespace: stopArgs.namespace,
@@ -102,28 +122,21 @@ func stopWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServic
 		})
 	}
 
-	stoppedNames := make(map[string]bool)
-	for _, wf := range wfs {
-		if _, ok := stoppedNames[wf.Name]; ok {
-			// de-duplication in case there is a overlap between the selector and given workflow names
-			continue
-		}
-		stoppedNames[wf.Name] = true
-
+	_, err = newBulkOperation(stopArgs.bulkOps, "stopped", func(ctx context.Context, wf wfv1.Workflow) (string, error) {
 		if stopArgs.dryRun {
-			fmt.Printf("workflow %s stopped (dry-run)\n", wf.Name)
-			continue
+			return fmt.Sprintf("workflow %s stopped (dry-run)", wf.Name), nil
 		}
-		wf, err := serviceClient.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{
+		_, err := serviceClient.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{
 			Name:              wf.Name,
 			Namespace:         wf.Namespace,
 			NodeFieldSelector: selector.String(),
 			Message:           stopArgs.message,
+			Strategy:          string(strategy),
 		})
 		if err != nil {
-			return err
+			return "", err
 		}
-		fmt.Printf("workflow %s stopped\n", wf.Name)
-	}
-	return nil
+		return fmt.Sprintf("workflow %s stopped", wf.Name), nil
+	}).run(ctx, serviceClient, matched, named)
+	return err
 }
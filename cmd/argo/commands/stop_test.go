@@ -41,6 +41,33 @@ func Test_stopWorkflows(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Drain workflow", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		stopArgs := stopOps{
+			namespace: "argo",
+			strategy:  "drain",
+		}
+
+		c.On("StopWorkflow", mock.Anything, &workflowpkg.WorkflowStopRequest{Name: "foo", Namespace: "argo", Strategy: "Drain"}).Return(&wfv1.Workflow{}, nil)
+
+		err := stopWorkflows(context.Background(), c, stopArgs, []string{"foo"})
+		c.AssertNumberOfCalls(t, "StopWorkflow", 1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Stop workflow with unknown strategy", func(t *testing.T) {
+		c := &workflowmocks.WorkflowServiceClient{}
+		stopArgs := stopOps{
+			strategy: "terminate",
+		}
+
+		err := stopWorkflows(context.Background(), c, stopArgs, []string{"foo"})
+		c.AssertNotCalled(t, "StopWorkflow")
+
+		assert.EqualError(t, err, "unknown strategy 'terminate', must be stop or drain")
+	})
+
 	t.Run("Stop workflow by selector", func(t *testing.T) {
 		c := &workflowmocks.WorkflowServiceClient{}
 		stopArgs := stopOps{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	common "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -28,6 +30,7 @@ func NewSubmitCommand() *cobra.Command {
 		cliSubmitOpts  common.CliSubmitOpts
 		priority       int32
 		from           string
+		idempotencyKey string
 	)
 	command := &cobra.Command{
 		Use:   "submit [FILE... | --from `kind/name]",
@@ -48,6 +51,23 @@ func NewSubmitCommand() *cobra.Command {
 
   argo submit --log my-wf.yaml
 
+# Submit, wait for completion, and download the output artifacts of the leaf nodes:
+
+  argo submit --wait --output-artifacts ./build my-wf.yaml
+
+# Submit with parameters from a file, overriding fields of a JSON object parameter:
+
+  argo submit my-wf.yaml --parameter-file values.yaml --set config.replicas=3 --set-string config.version=1.10
+
+# Submit a workflow that is validated and created, but does not start until it is resumed:
+
+  argo submit --start-paused my-wf.yaml
+  argo resume my-wf
+
+# Submit a workflow, or if a workflow was submitted with the same key recently, get that workflow instead:
+
+  argo submit --idempotency-key "$CI_PIPELINE_ID" my-wf.yaml
+
 # Submit a single workflow from an existing resource
 
   argo submit --from cronwf/my-cron-wf
@@ -57,13 +77,19 @@ func NewSubmitCommand() *cobra.Command {
 				cliSubmitOpts.Priority = &priority
 			}
 
+			if idempotencyKey != "" {
+				submitOpts.Labels = strings.TrimPrefix(submitOpts.Labels+","+wfcommon.LabelKeyIdempotencyKey+"="+idempotencyKey, ",")
+			}
+
 			if !cliSubmitOpts.Watch && len(cliSubmitOpts.GetArgs.Status) > 0 {
 				log.Warn("--status should only be used with --watch")
 			}
 
-			if parametersFile != "" {
-				err := util.ReadParametersFile(parametersFile, &submitOpts)
+			if parametersFile != "" || len(cliSubmitOpts.SetValues) > 0 || len(cliSubmitOpts.SetStringValues) > 0 {
+				values, err := readParameterValues(parametersFile, submitOpts.Parameters, cliSubmitOpts)
 				errors.CheckError(err)
+				cliSubmitOpts.ParameterValues = values
+				submitOpts.Parameters = nil
 			}
 
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
@@ -81,6 +107,8 @@ func NewSubmitCommand() *cobra.Command {
 		},
 	}
 	util.PopulateSubmitOpts(command, &submitOpts, &parametersFile, true)
+	command.Flags().BoolVar(&submitOpts.Suspend, "start-paused", false, "create the workflow suspended, so that no nodes run until it is resumed")
+	command.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "if a workflow was submitted with the same key recently, get that workflow rather than creating another, e.g. when retrying a CI job")
 	command.Flags().StringVarP(&cliSubmitOpts.Output, "output", "o", "", "Output format. One of: name|json|yaml|wide")
 	command.Flags().BoolVarP(&cliSubmitOpts.Wait, "wait", "w", false, "wait for the workflow to complete")
 	command.Flags().BoolVar(&cliSubmitOpts.Watch, "watch", false, "watch the workflow until it completes")
@@ -90,6 +118,9 @@ func NewSubmitCommand() *cobra.Command {
 	command.Flags().StringVar(&from, "from", "", "Submit from an existing `kind/name` E.g., --from=cronwf/hello-world-cwf")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error). Should only be used with --watch.")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
+	command.Flags().StringArrayVar(&cliSubmitOpts.SetValues, "set", []string{}, "set a parameter, or a field of a JSON object parameter, e.g. --set config.replicas=3; integers, booleans, null and lists such as {a,b} are typed; may be repeated")
+	command.Flags().StringArrayVar(&cliSubmitOpts.SetStringValues, "set-string", []string{}, "like --set, but the value is always a string, e.g. --set-string config.version=1.10")
+	command.Flags().StringVar(&cliSubmitOpts.OutputArtifacts, "output-artifacts", "", "once the workflow succeeds, download the output artifacts of its leaf and exit handler nodes into this directory. Must be used with --wait.")
 	command.Flags().StringVar(&cliSubmitOpts.ScheduledTime, "scheduled-time", "", "Override the workflow's scheduledTime parameter (useful for backfilling). The time must be RFC3339")
 
 	// Only complete files with appropriate extension.
@@ -97,6 +128,8 @@ func NewSubmitCommand() *cobra.Command {
 	if err != nil {
 		log.Fatal(err)
 	}
+	errors.CheckError(command.RegisterFlagCompletionFunc("from", common.CompleteSubmitFrom()))
+	errors.CheckError(command.RegisterFlagCompletionFunc("parameter", common.CompleteSubmitParameters()))
 	return command
 }
 
@@ -138,6 +171,10 @@ func validateOptions(workflows []wfv1.Workflow, submitOpts *wfv1.SubmitOpts, cli
 		}
 	}
 
+	if cliOpts.OutputArtifacts != "" && !cliOpts.Wait {
+		log.Fatalf("--output-artifacts must be combined with --wait")
+	}
+
 	if submitOpts.DryRun {
 		if cliOpts.Output == "" {
 			log.Fatalf("--dry-run should have an output option")
@@ -173,6 +210,12 @@ func submitWorkflowFromResource(ctx context.Context, serviceClient workflowpkg.W
 		submitOpts.Annotations = fmt.Sprintf("%s=%s", wfcommon.AnnotationKeyCronWfScheduledTime, cliOpts.ScheduledTime)
 	}
 
+	if cliOpts.ParameterValues != nil {
+		parameters, err := cliOpts.ParameterValues.Parameters(nil)
+		errors.CheckError(err)
+		submitOpts.Parameters = parameters
+	}
+
 	created, err := serviceClient.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
 		Namespace:     namespace,
 		ResourceKind:  kind,
@@ -186,6 +229,7 @@ func submitWorkflowFromResource(ctx context.Context, serviceClient workflowpkg.W
 	printWorkflow(created, common.GetFlags{Output: cliOpts.Output})
 
 	common.WaitWatchOrLog(ctx, serviceClient, namespace, []string{created.Name}, *cliOpts)
+	downloadOutputArtifacts(ctx, serviceClient, namespace, []string{created.Name}, cliOpts.OutputArtifacts)
 }
 
 func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflows []wfv1.Workflow, submitOpts *wfv1.SubmitOpts, cliOpts *common.CliSubmitOpts) {
@@ -203,6 +247,11 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 			// This is here to avoid passing an empty namespace when using --server-dry-run
 			wf.Namespace = namespace
 		}
+		if cliOpts.ParameterValues != nil {
+			parameters, err := cliOpts.ParameterValues.Parameters(wf.Spec.Arguments.Parameters)
+			errors.CheckError(err)
+			submitOpts.Parameters = parameters
+		}
 		err := util.ApplySubmitOpts(&wf, submitOpts)
 		errors.CheckError(err)
 		wf.Spec.Priority = cliOpts.Priority
@@ -225,6 +274,74 @@ func submitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServ
 	}
 
 	common.WaitWatchOrLog(ctx, serviceClient, namespace, workflowNames, *cliOpts)
+	downloadOutputArtifacts(ctx, serviceClient, namespace, workflowNames, cliOpts.OutputArtifacts)
+}
+
+// readParameterValues merges the parameter file, then the --parameter, --set and --set-string values, later ones taking
+// precedence
+func readParameterValues(parametersFile string, parameters []string, cliOpts common.CliSubmitOpts) (util.ParameterValues, error) {
+	values := util.ParameterValues{}
+	if parametersFile != "" {
+		if err := values.ReadFile(parametersFile); err != nil {
+			return nil, err
+		}
+	}
+	for _, parameter := range parameters {
+		if err := values.SetParameter(parameter); err != nil {
+			return nil, err
+		}
+	}
+	for _, value := range cliOpts.SetValues {
+		if err := values.Set(value); err != nil {
+			return nil, err
+		}
+	}
+	for _, value := range cliOpts.SetStringValues {
+		if err := values.SetString(value); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// downloadOutputArtifacts downloads the output artifacts of the workflows' leaf nodes into outputDir/{workflowName}
+func downloadOutputArtifacts(ctx context.Context, serviceClient workflowpkg.WorkflowServiceClient, namespace string, workflowNames []string, outputDir string) {
+	if outputDir == "" {
+		return
+	}
+	c := newArtifactHTTPClient()
+	for _, name := range workflowNames {
+		wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: name, Namespace: namespace})
+		errors.CheckError(err)
+		downloads, err := getOutputArtifactDownloads(wf, outputDir, client.ArgoServerOpts)
+		errors.CheckError(err)
+		err = downloadArtifacts(downloads, c, cpOps{parallelism: 4})
+		errors.CheckError(err)
+	}
+}
+
+// getOutputArtifactDownloads returns the output artifacts of the nodes without children, i.e. the last steps/tasks of the
+// workflow and of its exit handler
+func getOutputArtifactDownloads(wf *wfv1.Workflow, outputDir string, argoServerOpts apiclient.ArgoServerOpts) ([]artifactDownload, error) {
+	var downloads []artifactDownload
+	for _, node := range wf.Status.Nodes {
+		if len(node.Children) > 0 || node.Outputs == nil {
+			continue
+		}
+		for _, a := range node.Outputs.Artifacts {
+			if !a.HasLocationOrKey() {
+				continue
+			}
+			artifact := wfv1.ArtifactSearchResult{Artifact: a, NodeID: node.ID}
+			artifactPath := filepath.Join(outputDir, wf.Name, node.ID, a.Name)
+			artifactDownloads, err := getArtifactDownloads(wf.Namespace, wf.Name, artifact, artifactPath, nil, argoServerOpts, cpOps{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get download of artifact %s of node %s: %w", a.Name, node.ID, err)
+			}
+			downloads = append(downloads, artifactDownloads...)
+		}
+	}
+	return downloads, nil
 }
 
 // unmarshalWorkflows unmarshals the input bytes as either json or yaml
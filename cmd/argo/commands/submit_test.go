@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_getOutputArtifactDownloads(t *testing.T) {
+	s3Artifact := func(name, key string) wfv1.Artifact {
+		return wfv1.Artifact{Name: name, ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: key}}}
+	}
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+		Status: wfv1.WorkflowStatus{
+			Nodes: wfv1.Nodes{
+				"my-wf": {ID: "my-wf", Children: []string{"build"}},
+				"build": {ID: "build", Children: []string{"package"}, Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{s3Artifact("objects", "build/objects.tgz")}}},
+				"package": {ID: "package", Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{
+					s3Artifact("binary", "package/binary.tgz"),
+					{Name: "optional", Optional: true},
+				}}},
+				"exit": {ID: "exit", Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{s3Artifact("report", "exit/report.txt")}}},
+			},
+		},
+	}
+	dir := t.TempDir()
+
+	downloads, err := getOutputArtifactDownloads(wf, dir, apiclient.ArgoServerOpts{URL: "localhost:2746"})
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, []artifactDownload{
+			{
+				url:      "http://localhost:2746/artifacts/my-ns/my-wf/package/binary",
+				fileName: "binary.tgz",
+				filePath: filepath.Join(dir, "my-wf", "package", "binary", "binary.tgz"),
+			},
+			{
+				url:      "http://localhost:2746/artifacts/my-ns/my-wf/exit/report",
+				fileName: "report.txt",
+				filePath: filepath.Join(dir, "my-wf", "exit", "report", "report.txt"),
+			},
+		}, downloads)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func NewSuspendCommand() *cobra.Command {
@@ -37,5 +39,6 @@ func NewSuspendCommand() *cobra.Command {
 			}
 		},
 	}
+	command.ValidArgsFunction = common.CompleteWorkflowNames(wfv1.WorkflowRunning, wfv1.WorkflowPending)
 	return command
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 )
 
@@ -25,6 +26,7 @@ func NewDeleteCommand() *cobra.Command {
 	}
 
 	command.Flags().BoolVar(&all, "all", false, "Delete all workflow templates")
+	command.ValidArgsFunction = common.CompleteWorkflowTemplateNames()
 	return command
 }
 
@@ -0,0 +1,103 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func NewDiffCommand() *cobra.Command {
+	var strict bool
+	command := &cobra.Command{
+		Use:   "diff FILE1 FILE2...",
+		Short: "show the differences between workflow templates in files and those in the cluster",
+		Long:  "Show the differences between workflow templates in files and those in the cluster, ignoring the fields set by the cluster, such as the resource version, and fields with default values. Exits with 1 if there are differences.",
+		Example: `# Review changes to a workflow template before updating it:
+
+  argo template diff my-wftmpl.yaml
+
+# Review changes to several workflow templates:
+
+  argo template diff my-wftmpl.yaml my-other-wftmpl.yaml
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				cmd.HelpFunc()(cmd, args)
+				os.Exit(1)
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			different, err := diffWorkflowTemplates(ctx, serviceClient, client.Namespace(), args, strict)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if different {
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().BoolVar(&strict, "strict", true, "perform strict workflow validation")
+	return command
+}
+
+// diffWorkflowTemplates prints the differences between the workflow templates in the files and those in the
+// cluster, and returns whether there were any
+func diffWorkflowTemplates(ctx context.Context, serviceClient workflowtemplatepkg.WorkflowTemplateServiceClient, namespace string, filePaths []string, strict bool) (bool, error) {
+	fileContents, err := util.ReadManifest(filePaths...)
+	if err != nil {
+		return false, err
+	}
+	var workflowTemplates []wfv1.WorkflowTemplate
+	for _, body := range fileContents {
+		workflowTemplates = append(workflowTemplates, unmarshalWorkflowTemplates(body, strict)...)
+	}
+	if len(workflowTemplates) == 0 {
+		return false, fmt.Errorf("no workflow template found in given files")
+	}
+	different := false
+	for _, local := range workflowTemplates {
+		if local.Namespace == "" {
+			local.Namespace = namespace
+		}
+		var live *wfv1.WorkflowTemplate
+		if local.Name != "" {
+			live, err = serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{
+				Name:      local.Name,
+				Namespace: local.Namespace,
+			})
+			if status.Code(err) == codes.NotFound {
+				live, err = nil, nil
+			}
+			if err != nil {
+				return false, err
+			}
+		}
+		if live != nil {
+			live.TypeMeta = local.TypeMeta
+		}
+		name := fmt.Sprintf("%s/%s", local.Namespace, local.Name)
+		diff, err := common.Diff("live/"+name, live, "local/"+name, &local)
+		if err != nil {
+			return false, err
+		}
+		if diff != "" {
+			fmt.Print(diff)
+			different = true
+		}
+	}
+	return different, nil
+}
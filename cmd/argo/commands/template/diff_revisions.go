@@ -0,0 +1,74 @@
+package template
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func NewDiffRevisionsCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "diff-revisions WORKFLOW_TEMPLATE REVISION [REVISION]",
+		Short: "show the differences between revisions of a workflow template",
+		Long:  "Show the differences between two revisions of a workflow template, or between a revision and the latest revision. Exits with 1 if there are differences.",
+		Example: `# Review the changes made to a workflow template since revision 3:
+
+  argo template diff-revisions my-wftmpl 3
+
+# Review the changes made between revisions 3 and 5:
+
+  argo template diff-revisions my-wftmpl 3 5
+`,
+		Args: cobra.RangeArgs(2, 3),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			// the latest revision is the default
+			to := "0"
+			if len(args) == 3 {
+				to = args[2]
+			}
+			var revisions []*wfv1.WorkflowTemplate
+			for _, arg := range []string{args[1], to} {
+				revision, err := strconv.ParseInt(arg, 10, 64)
+				if err != nil {
+					log.Fatalf("invalid revision %q", arg)
+				}
+				wftmpl, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{
+					Name:      args[0],
+					Namespace: client.Namespace(),
+					Revision:  revision,
+				})
+				if err != nil {
+					log.Fatal(err)
+				}
+				revisions = append(revisions, wftmpl)
+			}
+			diff, err := common.Diff(revisionName(revisions[0]), revisions[0], revisionName(revisions[1]), revisions[1])
+			if err != nil {
+				log.Fatal(err)
+			}
+			if diff != "" {
+				fmt.Print(diff)
+				os.Exit(1)
+			}
+		},
+	}
+	command.ValidArgsFunction = common.CompleteWorkflowTemplateNames()
+	return command
+}
+
+func revisionName(wftmpl *wfv1.WorkflowTemplate) string {
+	return fmt.Sprintf("%s/%s@%d", wftmpl.Namespace, wftmpl.Name, wftmpl.Generation)
+}
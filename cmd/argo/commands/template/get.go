@@ -10,12 +10,16 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func NewGetCommand() *cobra.Command {
-	var output string
+	var (
+		output   string
+		revision int64
+	)
 
 	command := &cobra.Command{
 		Use:   "get WORKFLOW_TEMPLATE...",
@@ -31,6 +35,7 @@ func NewGetCommand() *cobra.Command {
 				wftmpl, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{
 					Name:      name,
 					Namespace: namespace,
+					Revision:  revision,
 				})
 				if err != nil {
 					log.Fatal(err)
@@ -41,6 +46,8 @@ func NewGetCommand() *cobra.Command {
 	}
 
 	command.Flags().StringVarP(&output, "output", "o", "", "Output format. One of: json|yaml|wide")
+	command.Flags().Int64Var(&revision, "revision", 0, "Display a saved revision, rather than the latest")
+	command.ValidArgsFunction = common.CompleteWorkflowTemplateNames()
 	return command
 }
 
@@ -66,4 +73,7 @@ func printWorkflowTemplateHelper(wf *wfv1.WorkflowTemplate) {
 	fmt.Printf(fmtStr, "Name:", wf.ObjectMeta.Name)
 	fmt.Printf(fmtStr, "Namespace:", wf.ObjectMeta.Namespace)
 	fmt.Printf(fmtStr, "Created:", humanize.Timestamp(wf.ObjectMeta.CreationTimestamp.Time))
+	if wf.Generation > 0 {
+		fmt.Printf(fmtStr, "Revision:", wf.Generation)
+	}
 }
@@ -0,0 +1,54 @@
+package template
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+)
+
+func NewRollbackCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "rollback WORKFLOW_TEMPLATE REVISION",
+		Short: "roll back a workflow template to a previous revision",
+		Long:  "Roll back a workflow template to a previous revision, by updating its spec to that of the revision. This saves a new revision, so it can also be undone.",
+		Example: `# Roll back a workflow template to revision 3:
+
+  argo template rollback my-wftmpl 3
+`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			revision, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil || revision <= 0 {
+				log.Fatalf("invalid revision %q", args[1])
+			}
+			ctx, apiClient := client.NewAPIClient(cmd.Context())
+			serviceClient, err := apiClient.NewWorkflowTemplateServiceClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			namespace := client.Namespace()
+			latest, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: args[0], Namespace: namespace})
+			if err != nil {
+				log.Fatal(err)
+			}
+			previous, err := serviceClient.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: args[0], Namespace: namespace, Revision: revision})
+			if err != nil {
+				log.Fatal(err)
+			}
+			latest.Spec = previous.Spec
+			updated, err := serviceClient.UpdateWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateUpdateRequest{Namespace: namespace, Template: latest})
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("WorkflowTemplate '%s' rolled back to revision %d, as revision %d\n", updated.Name, revision, updated.Generation)
+		},
+	}
+	command.ValidArgsFunction = common.CompleteWorkflowTemplateNames()
+	return command
+}
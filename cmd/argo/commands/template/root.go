@@ -18,6 +18,10 @@ func NewTemplateCommand() *cobra.Command {
 	command.AddCommand(NewCreateCommand())
 	command.AddCommand(NewDeleteCommand())
 	command.AddCommand(NewLintCommand())
+	command.AddCommand(NewDiffCommand())
+	command.AddCommand(NewRevisionsCommand())
+	command.AddCommand(NewDiffRevisionsCommand())
+	command.AddCommand(NewRollbackCommand())
 
 	return command
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func NewWaitCommand() *cobra.Command {
@@ -28,5 +29,6 @@ func NewWaitCommand() *cobra.Command {
 		},
 	}
 	command.Flags().BoolVar(&ignoreNotFound, "ignore-not-found", false, "Ignore the wait if the workflow is not found")
+	command.ValidArgsFunction = common.CompleteWorkflowNames(wfv1.WorkflowRunning, wfv1.WorkflowPending)
 	return command
 }
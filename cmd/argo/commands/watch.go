@@ -3,14 +3,19 @@ package commands
 import (
 	"os"
 
+	"github.com/argoproj/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func NewWatchCommand() *cobra.Command {
-	var getArgs common.GetFlags
+	var (
+		getArgs common.GetFlags
+		tui     bool
+	)
 
 	command := &cobra.Command{
 		Use:   "watch WORKFLOW",
@@ -22,6 +27,10 @@ func NewWatchCommand() *cobra.Command {
 # Watch the latest workflow:
 
   argo watch @latest
+
+# Watch a workflow in an interactive terminal UI, with the logs of the selected node:
+
+  argo watch my-wf --tui
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) != 1 {
@@ -31,10 +40,16 @@ func NewWatchCommand() *cobra.Command {
 			ctx, apiClient := client.NewAPIClient(cmd.Context())
 			serviceClient := apiClient.NewWorkflowServiceClient()
 			namespace := client.Namespace()
+			if tui {
+				errors.CheckError(common.WatchWorkflowTUI(ctx, serviceClient, namespace, args[0]))
+				return
+			}
 			common.WatchWorkflow(ctx, serviceClient, namespace, args[0], getArgs)
 		},
 	}
 	command.Flags().StringVar(&getArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error)")
 	command.Flags().StringVar(&getArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
+	command.Flags().BoolVar(&tui, "tui", false, "Watch in an interactive terminal UI: select nodes with the arrow keys to follow their logs, press 'r' to retry, 't' to terminate and 'q' to quit")
+	command.ValidArgsFunction = common.CompleteWorkflowNames(wfv1.WorkflowRunning, wfv1.WorkflowPending)
 	return command
 }
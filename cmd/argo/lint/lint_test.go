@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -13,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/lint/mocks"
+	"github.com/argoproj/argo-workflows/v3/pkg/apiclient"
 	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
 	wftemplatemocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate/mocks"
 	wf "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
@@ -338,3 +340,84 @@ func TestGetObjectName(t *testing.T) {
 		})
 	}
 }
+
+func TestLintOffline(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "templates.yaml"), []byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: foo
+spec:
+  templates:
+    - name: whalesay-template
+      inputs:
+        parameters:
+          - name: message
+      container:
+        image: docker/whalesay
+        args: ["{{=inputs.parameters.message + '!'}}"]
+`), 0o600)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "workflows.yaml"), []byte(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: ok
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: hello
+        templateRef: {name: foo, template: whalesay-template}
+        arguments:
+          parameters: [{name: message, value: "hello"}]
+---
+apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: missing-template
+spec:
+  schedule: "* * * * *"
+  workflowSpec:
+    entrypoint: main
+    templates:
+    - name: main
+      steps:
+      - - name: hello
+          templateRef: {name: bar, template: whalesay-template}
+---
+apiVersion: argoproj.io/v1alpha1
+kind: ClusterWorkflowTemplate
+metadata:
+  name: invalid-expression
+spec:
+  templates:
+    - name: main
+      container:
+        image: docker/whalesay
+        args: ["{{=1 +}}"]
+`), 0o600)
+	assert.NoError(t, err)
+
+	_, client, err := apiclient.NewClientFromOpts(apiclient.Opts{Offline: true, OfflineFiles: []string{dir}})
+	assert.NoError(t, err)
+	clients, err := getLintClients(client, []string{wf.WorkflowPlural, wf.WorkflowTemplatePlural, wf.CronWorkflowPlural, wf.ClusterWorkflowTemplatePlural})
+	assert.NoError(t, err)
+	fmtr, err := GetFormatter("simple")
+	assert.NoError(t, err)
+
+	res, err := Lint(context.Background(), &LintOptions{
+		Files:          []string{dir},
+		ServiceClients: clients,
+		Formatter:      fmtr,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.NotContains(t, res.msg, `"ok" (Workflow)`)
+	assert.NotContains(t, res.msg, `"foo" (WorkflowTemplate)`)
+	assert.Contains(t, res.msg, `in "missing-template" (CronWorkflow): cannot validate Workflow: templates.main.steps[0].hello workflow template "bar" not found in the linted files`)
+	assert.Contains(t, res.msg, `in "invalid-expression" (ClusterWorkflowTemplate): templates.main templates.main: invalid expression {{=1 +}}: unexpected token EOF (1:3)`)
+}
@@ -35,23 +35,32 @@ func NewAgentInitCommand() *cobra.Command {
 	return &cobra.Command{
 		Use: "init",
 		Run: func(cmd *cobra.Command, args []string) {
-			for _, name := range getPluginNames() {
-				filename := tokenFilename(name)
-				log.WithField("plugin", name).
-					WithField("filename", filename).
-					Info("creating token file for plugin")
-				if err := os.Mkdir(filepath.Dir(filename), 0o770); err != nil {
-					log.Fatal(err)
-				}
-				token := rand.String(32) // this could have 26^32 ~= 2 x 10^45  possible values, not guessable in reasonable time
-				if err := os.WriteFile(filename, []byte(token), 0o440); err != nil {
-					log.Fatal(err)
-				}
+			if err := createPluginTokens(getPluginNames()); err != nil {
+				log.Fatal(err)
 			}
 		},
 	}
 }
 
+// createPluginTokens creates the token file of each plugin, which is mounted into the plugin's sidecar so that it can
+// authenticate the calls of the executor
+func createPluginTokens(names []string) error {
+	for _, name := range names {
+		filename := tokenFilename(name)
+		log.WithField("plugin", name).
+			WithField("filename", filename).
+			Info("creating token file for plugin")
+		if err := os.Mkdir(filepath.Dir(filename), 0o770); err != nil {
+			return err
+		}
+		token := rand.String(32) // this could have 26^32 ~= 2 x 10^45  possible values, not guessable in reasonable time
+		if err := os.WriteFile(filename, []byte(token), 0o440); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func tokenFilename(name string) string {
 	return filepath.Join(common.VarRunArgoPath, name, "token")
 }
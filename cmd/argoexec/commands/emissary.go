@@ -71,24 +71,45 @@ func NewEmissaryCommand() *cobra.Command {
 				return fmt.Errorf("failed to unmarshal template: %w", err)
 			}
 
+			if containerName == common.MainContainerName {
+				if err := copyPluginArtifacts(); err != nil {
+					return err
+				}
+				restoreInitContainerParameters()
+			}
+
 			for _, x := range template.ContainerSet.GetGraph() {
 				if x.Name == containerName {
 					for _, y := range x.Dependencies {
 						logger.Infof("waiting for dependency %q", y)
 						for {
-							data, err := ioutil.ReadFile(filepath.Clean(varRunArgo + "/ctr/" + y + "/exitcode"))
-							if os.IsNotExist(err) {
-								time.Sleep(time.Second)
-								continue
+							exited, err := dependencyExited(y)
+							if err != nil {
+								return err
+							}
+							if exited {
+								break
+							}
+							time.Sleep(time.Second)
+						}
+					}
+					for _, y := range x.FileDependencies {
+						logger.Infof("waiting for file %q from dependency %q", y.Path, y.Container)
+						for {
+							if _, err := os.Stat(y.Path); err == nil {
+								break
+							} else if !os.IsNotExist(err) {
+								return fmt.Errorf("failed to check file %q from dependency %q: %w", y.Path, y.Container, err)
 							}
-							exitCode, err := strconv.Atoi(string(data))
+							exited, err := dependencyExited(y.Container)
 							if err != nil {
-								return fmt.Errorf("failed to read exit-code of dependency %q: %w", y, err)
+								return err
 							}
-							if exitCode != 0 {
-								return fmt.Errorf("dependency %q exited with non-zero code: %d", y, exitCode)
+							// check again, the file may have been written just before the dependency exited
+							if _, err := os.Stat(y.Path); exited && os.IsNotExist(err) {
+								return fmt.Errorf("dependency %q exited without producing file %q", y.Container, y.Path)
 							}
-							break
+							time.Sleep(time.Second)
 						}
 					}
 				}
@@ -118,11 +139,12 @@ func NewEmissaryCommand() *cobra.Command {
 			}
 
 			cmdErr := retry.OnError(backoff, func(error) bool { return true }, func() error {
-				command, stdout, combined, err := createCommand(name, args, template)
+				command, stdout, stderr, combined, err := createCommand(name, args, template)
 				if err != nil {
 					return fmt.Errorf("failed to create command: %w", err)
 				}
 				defer stdout.Close()
+				defer stderr.Close()
 				defer combined.Close()
 				signals := make(chan os.Signal, 1)
 				defer close(signals)
@@ -185,20 +207,9 @@ func NewEmissaryCommand() *cobra.Command {
 				}
 			}
 
-			if containerName == common.MainContainerName {
-				for _, x := range template.Outputs.Parameters {
-					if x.ValueFrom != nil && x.ValueFrom.Path != "" {
-						if err := saveParameter(x.ValueFrom.Path); err != nil {
-							return err
-						}
-					}
-				}
-				for _, x := range template.Outputs.Artifacts {
-					if x.Path != "" {
-						if err := saveArtifact(x.Path); err != nil {
-							return err
-						}
-					}
+			if containerName == common.MainContainerName || capturesOutputs(containerName) {
+				if err := saveOutputs(); err != nil {
+					return err
 				}
 			} else {
 				logger.Info("not saving outputs - not main container")
@@ -209,15 +220,95 @@ func NewEmissaryCommand() *cobra.Command {
 	}
 }
 
-func createCommand(name string, args []string, template *wfv1.Template) (*exec.Cmd, *os.File, *os.File, error) {
+// capturesOutputs returns whether the container is an init container that captures the outputs of the template
+func capturesOutputs(name string) bool {
+	for _, c := range template.InitContainers {
+		if c.Name == name {
+			return c.GetCaptureOutputs()
+		}
+	}
+	return false
+}
+
+func saveOutputs() error {
+	for _, x := range template.Outputs.Parameters {
+		if x.ValueFrom != nil && common.IsGlob(x.ValueFrom.Path) {
+			if err := saveGlobParameter(x.ValueFrom.Path); err != nil {
+				return err
+			}
+		} else if x.ValueFrom != nil && x.ValueFrom.Path != "" {
+			if err := saveParameter(x.ValueFrom.Path); err != nil {
+				return err
+			}
+		}
+	}
+	for _, x := range template.Outputs.Artifacts {
+		if x.Path != "" {
+			if err := saveArtifact(x.Path); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// restoreInitContainerParameters copies the output parameters captured from init containers to their paths, unless
+// the main container already has them, so that the main container can use them
+func restoreInitContainerParameters() {
+	for _, x := range template.Outputs.Parameters {
+		if x.ValueFrom == nil || x.ValueFrom.Path == "" || common.IsGlob(x.ValueFrom.Path) {
+			continue
+		}
+		srcPath := varRunArgo + "/outputs/parameters/" + x.ValueFrom.Path
+		if _, err := os.Stat(srcPath); err != nil {
+			continue
+		}
+		if _, err := os.Stat(x.ValueFrom.Path); !os.IsNotExist(err) {
+			continue
+		}
+		logger.Infof("%s -> %s", srcPath, x.ValueFrom.Path)
+		if err := copyPath(srcPath, x.ValueFrom.Path); err != nil {
+			logger.WithError(err).Warnf("cannot restore parameter %s", x.ValueFrom.Path)
+		}
+	}
+}
+
+// dependencyExited returns true if the dependency has exited successfully, and an error if it exited with a non-zero code
+func dependencyExited(name string) (bool, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(varRunArgo + "/ctr/" + name + "/exitcode"))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read exit-code of dependency %q: %w", name, err)
+	}
+	exitCode, err := strconv.Atoi(string(data))
+	if err != nil {
+		return false, fmt.Errorf("failed to read exit-code of dependency %q: %w", name, err)
+	}
+	if exitCode != 0 {
+		return false, fmt.Errorf("dependency %q exited with non-zero code: %d", name, exitCode)
+	}
+	return true, nil
+}
+
+func createCommand(name string, args []string, template *wfv1.Template) (*exec.Cmd, *os.File, *os.File, *os.File, error) {
 	command := exec.Command(name, args...)
 	command.Env = os.Environ()
+	if dir := template.WorkingDirOverride; dir != "" {
+		// created as the container's user, unlike the container's workingDir, which the runtime creates as root
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to create working directory %q: %w", dir, err)
+		}
+		command.Dir = dir
+	}
 	command.SysProcAttr = &syscall.SysProcAttr{}
 	osspecific.Setpgid(command.SysProcAttr)
 	command.Stdout = os.Stdout
 	command.Stderr = os.Stderr
 
 	var stdout *os.File
+	var stderr *os.File
 	var combined *os.File
 	var err error
 	// this may not be that important an optimisation, except for very long logs we don't want to capture
@@ -225,16 +316,101 @@ func createCommand(name string, args []string, template *wfv1.Template) (*exec.C
 		logger.Info("capturing logs")
 		stdout, err = os.OpenFile(varRunArgo+"/ctr/"+containerName+"/stdout", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to open stdout: %w", err)
+			return nil, nil, nil, nil, fmt.Errorf("failed to open stdout: %w", err)
 		}
 		combined, err = os.OpenFile(varRunArgo+"/ctr/"+containerName+"/combined", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 		if err != nil {
-			return nil, nil, nil, fmt.Errorf("failed to open combined: %w", err)
+			return nil, nil, nil, nil, fmt.Errorf("failed to open combined: %w", err)
 		}
 		command.Stdout = io.MultiWriter(os.Stdout, stdout, combined)
 		command.Stderr = io.MultiWriter(os.Stderr, combined)
+		if template.SaveLogStreamsAsArtifacts() {
+			stderr, err = os.OpenFile(varRunArgo+"/ctr/"+containerName+"/stderr", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("failed to open stderr: %w", err)
+			}
+			command.Stderr = io.MultiWriter(os.Stderr, stderr, combined)
+		}
+	}
+	return command, stdout, stderr, combined, nil
+}
+
+// copyPluginArtifacts waits for the wait container to load the input artifacts stored by artifact driver plugins, which
+// the init container cannot, and copies them to their paths
+func copyPluginArtifacts() error {
+	var arts []wfv1.Artifact
+	for _, art := range template.Inputs.Artifacts {
+		if common.IsPluginArtifact(art, template.ArchiveLocation) {
+			arts = append(arts, art)
+		}
+	}
+	if len(arts) == 0 {
+		return nil
+	}
+	logger.Info("waiting for input artifacts stored by artifact driver plugins")
+	var message []byte
+	for {
+		data, err := ioutil.ReadFile(common.PluginInputArtifactsLoadedPath)
+		if err == nil {
+			message = data
+			break
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to check input artifacts are loaded: %w", err)
+		}
+		time.Sleep(time.Second)
+	}
+	if len(message) > 0 {
+		return fmt.Errorf("failed to load input artifacts: %s", message)
+	}
+	for _, art := range arts {
+		srcPath := filepath.Join(common.PluginInputArtifactsPath, art.Name)
+		if _, err := os.Stat(srcPath); os.IsNotExist(err) { // an optional artifact that was not found
+			continue
+		}
+		logger.Infof("%s -> %s", srcPath, art.Path)
+		if err := copyPath(srcPath, art.Path); err != nil {
+			return fmt.Errorf("failed to copy input artifact %s: %w", art.Name, err)
+		}
+	}
+	return nil
+}
+
+// copyPath copies a file, or a directory and its contents
+func copyPath(srcPath, dstPath string) error {
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o755); err != nil { // chmod rwxr-xr-x
+		return err
 	}
-	return command, stdout, combined, nil
+	return filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dstPath, strings.TrimPrefix(path, srcPath))
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			src, err := os.Open(filepath.Clean(path))
+			if err != nil {
+				return err
+			}
+			defer func() { _ = src.Close() }()
+			dst, err := os.OpenFile(filepath.Clean(target), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(dst, src); err != nil {
+				_ = dst.Close()
+				return err
+			}
+			return dst.Close()
+		}
+	})
 }
 
 func saveArtifact(srcPath string) error {
@@ -266,6 +442,27 @@ func saveArtifact(srcPath string) error {
 	return nil
 }
 
+// saveGlobParameter saves each of the files matching the pattern as if it were the path of a parameter
+func saveGlobParameter(pattern string) error {
+	if common.FindOverlappingVolume(template, pattern) != nil {
+		logger.Infof("no need to save parameter - on overlapping volume: %s", pattern)
+		return nil
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+	for _, match := range matches {
+		if info, err := os.Stat(match); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if err := saveParameter(match); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func saveParameter(srcPath string) error {
 	if common.FindOverlappingVolume(template, srcPath) != nil {
 		logger.Infof("no need to save parameter - on overlapping volume: %s", srcPath)
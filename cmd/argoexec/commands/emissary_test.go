@@ -9,11 +9,75 @@ import (
 	"syscall"
 	"testing"
 
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/errors"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestDependencyExited(t *testing.T) {
+	varRunArgo = t.TempDir()
+	exited, err := dependencyExited("a")
+	assert.NoError(t, err)
+	assert.False(t, exited)
+
+	assert.NoError(t, os.MkdirAll(varRunArgo+"/ctr/a", 0o700))
+	assert.NoError(t, ioutil.WriteFile(varRunArgo+"/ctr/a/exitcode", []byte("0"), 0o600))
+	exited, err = dependencyExited("a")
+	assert.NoError(t, err)
+	assert.True(t, exited)
+
+	assert.NoError(t, ioutil.WriteFile(varRunArgo+"/ctr/a/exitcode", []byte("1"), 0o600))
+	_, err = dependencyExited("a")
+	assert.EqualError(t, err, `dependency "a" exited with non-zero code: 1`)
+}
+
+func TestCreateCommandWorkingDirOverride(t *testing.T) {
+	dir := t.TempDir() + "/work/dir"
+	command, _, _, _, err := createCommand("pwd", nil, &wfv1.Template{WorkingDirOverride: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, dir, command.Dir)
+	assert.DirExists(t, dir)
+}
+
+func TestCopyPath(t *testing.T) {
+	src := t.TempDir() + "/src"
+	assert.NoError(t, os.MkdirAll(src+"/dir", 0o700))
+	assert.NoError(t, ioutil.WriteFile(src+"/dir/file", []byte("my-data"), 0o600))
+	dst := t.TempDir() + "/some/dst"
+	assert.NoError(t, copyPath(src, dst))
+	data, err := ioutil.ReadFile(dst + "/dir/file")
+	assert.NoError(t, err)
+	assert.Equal(t, "my-data", string(data))
+
+	assert.NoError(t, copyPath(src+"/dir/file", dst+"-file"))
+	assert.FileExists(t, dst+"-file")
+}
+
+func TestInitContainerOutputs(t *testing.T) {
+	varRunArgo = t.TempDir()
+	path := t.TempDir() + "/token"
+	captureOutputs := true
+	template = &wfv1.Template{
+		InitContainers: []wfv1.UserContainer{{Container: apiv1.Container{Name: "init-foo"}, CaptureOutputs: &captureOutputs}},
+		Outputs:        wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "token", ValueFrom: &wfv1.ValueFrom{Path: path}}}},
+	}
+	defer func() { template = &wfv1.Template{} }()
+	assert.True(t, capturesOutputs("init-foo"))
+	assert.False(t, capturesOutputs("main"))
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("my-token"), 0o600))
+	assert.NoError(t, saveOutputs())
+	assert.NoError(t, os.Remove(path))
+
+	restoreInitContainerParameters()
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-token", string(data))
+}
+
 func TestEmissary(t *testing.T) {
 	tmp := t.TempDir()
 
@@ -2,10 +2,14 @@ package commands
 
 import (
 	"context"
+	"os"
 
 	"github.com/argoproj/pkg/stats"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func NewInitCommand() *cobra.Command {
@@ -27,18 +31,27 @@ func loadArtifacts(ctx context.Context) error {
 	wfExecutor := initExecutor()
 	defer wfExecutor.HandleError(ctx)
 	defer stats.LogStats()
+	defer tracing.Shutdown(tracing.Init(ctx, "argo-executor"))
+	ctx = tracingContext(ctx)
 
 	if err := wfExecutor.Init(); err != nil {
 		wfExecutor.AddError(err)
 		return err
 	}
+	// Create the tokens of the artifact driver plugins, which start after this container
+	if _, ok := os.LookupEnv(common.EnvVarPluginNames); ok {
+		if err := createPluginTokens(getPluginNames()); err != nil {
+			wfExecutor.AddError(err)
+			return err
+		}
+	}
 	// Download input artifacts
 	err := wfExecutor.StageFiles()
 	if err != nil {
 		wfExecutor.AddError(err)
 		return err
 	}
-	err = wfExecutor.LoadArtifacts(ctx)
+	err = traced(ctx, "loadArtifacts", wfExecutor.LoadArtifacts)
 	if err != nil {
 		wfExecutor.AddError(err)
 		return err
@@ -1,15 +1,16 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"time"
 
-	"github.com/argoproj/pkg/cli"
 	kubecli "github.com/argoproj/pkg/kube/cli"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -22,6 +23,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/cmd"
 	"github.com/argoproj/argo-workflows/v3/util/logs"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/executor"
 	"github.com/argoproj/argo-workflows/v3/workflow/executor/emissary"
@@ -45,7 +47,7 @@ func init() {
 
 func initConfig() {
 	cmd.SetLogFormatter(logFormat)
-	cli.SetLogLevel(logLevel)
+	cmd.SetLogLevels(logLevel)
 	cmd.SetGLogLevel(glogLevel)
 }
 
@@ -69,13 +71,26 @@ func NewRootCommand() *cobra.Command {
 	command.AddCommand(artifact.NewArtifactCommand())
 
 	clientConfig = kubecli.AddKubectlFlagsToCmd(&command)
-	command.PersistentFlags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.PersistentFlags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error. Components may have their own levels, e.g. info,operator=debug")
 	command.PersistentFlags().IntVar(&glogLevel, "gloglevel", 0, "Set the glog logging level")
 	command.PersistentFlags().StringVar(&logFormat, "log-format", "text", "The formatter to use for logs. One of: text|json")
 
 	return &command
 }
 
+// tracingContext returns a context whose spans are children of the span of the pod's node
+func tracingContext(ctx context.Context) context.Context {
+	return trace.ContextWithRemoteSpanContext(ctx, tracing.ParseTraceParent(os.Getenv(common.EnvVarTraceParent)))
+}
+
+// traced runs the step of the executor in a span
+func traced(ctx context.Context, name string, step func(ctx context.Context) error) error {
+	ctx, span := tracing.Tracer().Start(ctx, name)
+	err := step(ctx)
+	tracing.End(span, err)
+	return err
+}
+
 func initExecutor() *executor.WorkflowExecutor {
 	version := argo.GetVersion()
 	log.WithFields(log.Fields{"version": version.Version}).Info("Starting Workflow Executor")
@@ -9,6 +9,8 @@ import (
 	"github.com/argoproj/pkg/stats"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
 )
 
 func NewWaitCommand() *cobra.Command {
@@ -31,6 +33,13 @@ func waitContainer(ctx context.Context) error {
 	defer wfExecutor.HandleError(ctx) // Must be placed at the bottom of defers stack.
 	defer stats.LogStats()
 	stats.StartStatsTicker(5 * time.Minute)
+	defer tracing.Shutdown(tracing.Init(ctx, "argo-executor"))
+	ctx = tracingContext(ctx)
+
+	// Load the input artifacts that the init container could not, the main container waits for them
+	if err := traced(ctx, "loadPluginArtifacts", wfExecutor.LoadPluginArtifacts); err != nil {
+		wfExecutor.AddError(err)
+	}
 
 	// use a block to constrain the scope of ctx
 	{
@@ -39,7 +48,7 @@ func waitContainer(ctx context.Context) error {
 		defer cancel()
 
 		// Wait for main container to complete
-		err := wfExecutor.Wait(ctx)
+		err := traced(ctx, "wait", wfExecutor.Wait)
 		if err != nil {
 			wfExecutor.AddError(err)
 		}
@@ -51,16 +60,20 @@ func waitContainer(ctx context.Context) error {
 	}
 
 	// Saving output parameters
-	err = wfExecutor.SaveParameters(ctx)
+	err = traced(ctx, "saveParameters", wfExecutor.SaveParameters)
 	if err != nil {
 		wfExecutor.AddError(err)
 	}
 	// Saving output artifacts
-	err = wfExecutor.SaveArtifacts(ctx)
+	err = traced(ctx, "saveArtifacts", wfExecutor.SaveArtifacts)
 	if err != nil {
 		wfExecutor.AddError(err)
 	}
 
-	wfExecutor.SaveLogs(ctx)
+	_ = traced(ctx, "saveLogs", func(ctx context.Context) error {
+		wfExecutor.SaveLogs(ctx)
+		return nil
+	})
+	wfExecutor.ShutdownServiceMesh(ctx)
 	return wfExecutor.HasError()
 }
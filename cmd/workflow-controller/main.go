@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/argoproj/pkg/cli"
 	"github.com/argoproj/pkg/errors"
 	kubecli "github.com/argoproj/pkg/kube/cli"
 	"github.com/argoproj/pkg/stats"
@@ -62,6 +61,7 @@ func NewRootCommand() *cobra.Command {
 		qps                      float32
 		namespaced               bool   // --namespaced
 		managedNamespace         string // --managed-namespace
+		managedNamespaceSelector string // --managed-namespace-selector
 		executorPlugins          bool
 	)
 
@@ -71,7 +71,7 @@ func NewRootCommand() *cobra.Command {
 		RunE: func(c *cobra.Command, args []string) error {
 			defer runtimeutil.HandleCrash(runtimeutil.PanicHandlers...)
 
-			cli.SetLogLevel(logLevel)
+			cmdutil.SetLogLevels(logLevel)
 			cmdutil.SetGLogLevel(glogLevel)
 			cmdutil.SetLogFormatter(logFormat)
 			stats.RegisterStackDumper()
@@ -105,12 +105,15 @@ func NewRootCommand() *cobra.Command {
 			if namespaced && managedNamespace == "" {
 				managedNamespace = namespace
 			}
+			if namespaced && managedNamespaceSelector != "" {
+				return fmt.Errorf("--managed-namespace-selector cannot be used with --namespaced")
+			}
 
 			// start a controller on instances of our custom resource
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			wfController, err := controller.NewWorkflowController(ctx, config, kubeclientset, wfclientset, namespace, managedNamespace, executorImage, executorImagePullPolicy, logFormat, containerRuntimeExecutor, configMap, executorPlugins)
+			wfController, err := controller.NewWorkflowController(ctx, config, kubeclientset, wfclientset, namespace, managedNamespace, managedNamespaceSelector, executorImage, executorImagePullPolicy, logFormat, containerRuntimeExecutor, configMap, executorPlugins)
 			errors.CheckError(err)
 
 			leaderElectionOff := os.Getenv("LEADER_ELECTION_DISABLE")
@@ -154,6 +157,8 @@ func NewRootCommand() *cobra.Command {
 			}
 
 			http.HandleFunc("/healthz", wfController.Healthz)
+			http.HandleFunc("/synchronization", wfController.Synchronization)
+			http.HandleFunc("/diagnostics/", wfController.Diagnostics)
 
 			go func() {
 				log.Println(http.ListenAndServe(":6060", nil))
@@ -170,7 +175,7 @@ func NewRootCommand() *cobra.Command {
 	command.Flags().StringVar(&executorImage, "executor-image", "", "Executor image to use (overrides value in configmap)")
 	command.Flags().StringVar(&executorImagePullPolicy, "executor-image-pull-policy", "", "Executor imagePullPolicy to use (overrides value in configmap)")
 	command.Flags().StringVar(&containerRuntimeExecutor, "container-runtime-executor", "", "Container runtime executor to use (overrides value in configmap)")
-	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error")
+	command.Flags().StringVar(&logLevel, "loglevel", "info", "Set the logging level. One of: debug|info|warn|error. Components may have their own levels, e.g. info,operator=debug")
 	command.Flags().IntVar(&glogLevel, "gloglevel", 0, "Set the glog logging level")
 	command.Flags().StringVar(&logFormat, "log-format", "text", "The formatter to use for logs. One of: text|json")
 	command.Flags().IntVar(&workflowWorkers, "workflow-workers", 32, "Number of workflow workers")
@@ -180,6 +185,7 @@ func NewRootCommand() *cobra.Command {
 	command.Flags().Float32Var(&qps, "qps", 20.0, "Queries per second")
 	command.Flags().BoolVar(&namespaced, "namespaced", false, "run workflow-controller as namespaced mode")
 	command.Flags().StringVar(&managedNamespace, "managed-namespace", "", "namespace that workflow-controller watches, default to the installation namespace")
+	command.Flags().StringVar(&managedNamespaceSelector, "managed-namespace-selector", "", "namespaces that workflow-controller manages, as a label selector, e.g. workflows.argoproj.io/managed=true. Cannot be used with --namespaced")
 	command.Flags().BoolVar(&executorPlugins, "executor-plugins", false, "enable executor plugins")
 
 	viper.AutomaticEnv()
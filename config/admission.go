@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AdmissionFailurePolicyFail rejects workflows if a webhook cannot be called
+	AdmissionFailurePolicyFail = "Fail"
+	// AdmissionFailurePolicyIgnore admits workflows if a webhook cannot be called
+	AdmissionFailurePolicyIgnore = "Ignore"
+)
+
+// AdmissionConfig configures the policies and webhooks that must admit workflows before the Argo Server creates them
+type AdmissionConfig struct {
+	// Policies are evaluated first, in order
+	Policies []AdmissionPolicy `json:"policies,omitempty"`
+	// Webhooks are called after the policies, in order, and may mutate the workflow
+	Webhooks []AdmissionWebhook `json:"webhooks,omitempty"`
+}
+
+// AdmissionPolicy rejects workflows for which its expression is false
+type AdmissionPolicy struct {
+	Name string `json:"name"`
+	// Expression is evaluated with the workflow, with the spec of the template it references merged, as `workflow`,
+	// e.g. `"team" in workflow.metadata.labels`
+	Expression string `json:"expression"`
+	// Message explains why the workflow was rejected
+	Message string `json:"message,omitempty"`
+}
+
+// AdmissionWebhook is posted the workflow, and responds whether it is allowed and, optionally, a mutated workflow
+type AdmissionWebhook struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// Headers to add to the requests, e.g. for authentication
+	Headers map[string]string `json:"headers,omitempty"`
+	// Timeout of the requests. Defaults to 10s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// FailurePolicy is "Fail" (the default) or "Ignore", if the webhook cannot be called
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+func (w AdmissionWebhook) GetTimeout() time.Duration {
+	if w.Timeout != nil {
+		return w.Timeout.Duration
+	}
+	return 10 * time.Second
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArtifactRepositoryChecksConfig configures the controller to periodically check that it can connect to each
+// WorkflowArtifactRepository and ClusterWorkflowArtifactRepository, and record the result in its status
+type ArtifactRepositoryChecksConfig struct {
+	// Enabled checks the repositories
+	Enabled bool `json:"enabled,omitempty"`
+	// Period is how often the repositories are checked, defaults to 5m
+	Period *metav1.Duration `json:"period,omitempty"`
+	// Timeout of the check of each repository, defaults to 30s
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxPerNamespace is the maximum number of repositories checked in each namespace every period, defaults to 10.
+	// Those checked the longest time ago are checked first. ClusterWorkflowArtifactRepositories count as one namespace.
+	MaxPerNamespace int `json:"maxPerNamespace,omitempty"`
+}
+
+func (c *ArtifactRepositoryChecksConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *ArtifactRepositoryChecksConfig) GetPeriod() time.Duration {
+	if c == nil || c.Period == nil || c.Period.Duration <= 0 {
+		return 5 * time.Minute
+	}
+	return c.Period.Duration
+}
+
+func (c *ArtifactRepositoryChecksConfig) GetTimeout() time.Duration {
+	if c == nil || c.Timeout == nil || c.Timeout.Duration <= 0 {
+		return 30 * time.Second
+	}
+	return c.Timeout.Duration
+}
+
+func (c *ArtifactRepositoryChecksConfig) GetMaxPerNamespace() int {
+	if c == nil || c.MaxPerNamespace <= 0 {
+		return 10
+	}
+	return c.MaxPerNamespace
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AuditSinkStdout writes audit events as JSON lines to the Argo Server's stdout
+	AuditSinkStdout = "stdout"
+	// AuditSinkFile appends audit events as JSON lines to a file
+	AuditSinkFile = "file"
+	// AuditSinkWebhook posts each audit event as JSON to a URL
+	AuditSinkWebhook = "webhook"
+)
+
+// AuditConfig configures the Argo Server's audit log of mutating API calls
+type AuditConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Sinks the audit events are written to. Defaults to stdout.
+	Sinks []AuditSink `json:"sinks,omitempty"`
+}
+
+func (c *AuditConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *AuditConfig) GetSinks() []AuditSink {
+	if len(c.Sinks) == 0 {
+		return []AuditSink{{Type: AuditSinkStdout}}
+	}
+	return c.Sinks
+}
+
+type AuditSink struct {
+	// Type is one of "stdout", "file" or "webhook"
+	Type string `json:"type"`
+	// Path of the file, for the "file" type
+	Path string `json:"path,omitempty"`
+	// URL to post to, for the "webhook" type
+	URL string `json:"url,omitempty"`
+	// Headers to add to the webhook's requests, e.g. for authentication
+	Headers map[string]string `json:"headers,omitempty"`
+	// Timeout of the webhook's requests. Defaults to 10s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+func (s AuditSink) GetTimeout() time.Duration {
+	if s.Timeout != nil {
+		return s.Timeout.Duration
+	}
+	return 10 * time.Second
+}
@@ -0,0 +1,16 @@
+package config
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// ClusterConfig is another cluster that the controller runs the pods of templates on, while their workflows are kept
+// in the controller's own cluster
+type ClusterConfig struct {
+	// Name is the name of the cluster in the `cluster` field of templates
+	Name string `json:"name"`
+	// KubeConfig is a key of a secret, in the controller's namespace, containing a kubeconfig for the cluster.
+	// Its credentials must allow creating, watching, patching, deleting and exec-ing into pods, and watching
+	// workflow task results, in the namespaces of the workflows.
+	KubeConfig apiv1.SecretKeySelector `json:"kubeConfig"`
+}
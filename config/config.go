@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"strings"
 	"time"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -11,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
 )
 
 type ResourceRateLimit struct {
@@ -24,6 +26,9 @@ type Config struct {
 	// NodeEvents configures how node events are emitted
 	NodeEvents NodeEvents `json:"nodeEvents,omitempty"`
 
+	// Notifications configures publishing workflow and node phase changes to external systems, e.g. NATS, Kafka or HTTP
+	Notifications *NotificationsConfig `json:"notifications,omitempty"`
+
 	// Executor holds container customizations for the executor to use when running pods
 	Executor *apiv1.Container `json:"executor,omitempty"`
 
@@ -62,6 +67,38 @@ type Config struct {
 	// NamespaceParallelism limits the max workflows that can execute at the same time in a namespace
 	NamespaceParallelism int `json:"namespaceParallelism,omitempty"`
 
+	// Preemption allows higher-priority workflows to preempt lower-priority running workflows when the parallelism
+	// limit is reached
+	Preemption PreemptionConfig `json:"preemption,omitempty"`
+
+	// Quotas limit the running workflows and pods of the workflows in a namespace, or selected by labels. Workflows
+	// over a quota are held in Pending
+	Quotas []QuotaConfig `json:"quotas,omitempty"`
+
+	// Pricing prices the resources of pods, so the cost of nodes and workflows is estimated
+	Pricing *PricingConfig `json:"pricing,omitempty"`
+
+	// GPUVendors configure the device plugins of the GPU vendors that templates request GPUs from, overriding those of
+	// the "nvidia", "amd" and "intel" vendors, or adding vendors
+	GPUVendors map[wfv1.GPUVendor]GPUVendorConfig `json:"gpuVendors,omitempty"`
+
+	// RegistryImagePullSecrets are the names of the image pull secrets, by registry hostname, e.g. "ghcr.io", that are
+	// added to the pods with an image from the registry. The secrets must be in the namespace of the workflow
+	RegistryImagePullSecrets map[string]string `json:"registryImagePullSecrets,omitempty"`
+
+	// OffloadParameterSize is the size, in bytes, above which the value of a parameter, or result, of a node is
+	// offloaded into a ConfigMap, rather than stored in the workflow status. Defaults to 128Ki. A negative size disables
+	// offloading
+	OffloadParameterSize int `json:"offloadParameterSize,omitempty"`
+
+	// ExprFunctions are functions, by name, that are available in `when`, `depends` and parameter expressions, so
+	// common logic is not copied into every template
+	ExprFunctions map[string]exprenv.Function `json:"exprFunctions,omitempty"`
+
+	// ArtifactSaveParallelism is the number of output artifacts the wait container saves concurrently.
+	// Defaults to 1, i.e. artifacts are saved one at a time. Can be overridden by the workflow or template executor config.
+	ArtifactSaveParallelism int `json:"artifactSaveParallelism,omitempty"`
+
 	// ResourceRateLimit limits the rate at which pods are created
 	ResourceRateLimit *ResourceRateLimit `json:"resourceRateLimit,omitempty"`
 
@@ -104,6 +141,53 @@ type Config struct {
 
 	// SSO in settings for single-sign on
 	SSO SSOConfig `json:"sso,omitempty"`
+
+	// Audit configures the Argo Server's audit log of mutating API calls
+	Audit *AuditConfig `json:"audit,omitempty"`
+
+	// Admission configures the policies and webhooks that must admit workflows submitted to the Argo Server, and run by
+	// the controller
+	Admission *AdmissionConfig `json:"admission,omitempty"`
+
+	// Federation configures the Argo Server to serve the workflows of other clusters
+	Federation *FederationConfig `json:"federation,omitempty"`
+
+	// TemplateRevisions configures saving immutable revisions of WorkflowTemplates and ClusterWorkflowTemplates
+	TemplateRevisions *TemplateRevisionsConfig `json:"templateRevisions,omitempty"`
+
+	// ArtifactRepositoryChecks configures the periodic checks that the controller can connect to each
+	// WorkflowArtifactRepository and ClusterWorkflowArtifactRepository
+	ArtifactRepositoryChecks *ArtifactRepositoryChecksConfig `json:"artifactRepositoryChecks,omitempty"`
+
+	// ManagedNamespaceTemplate is the name of a config map, in the controller's namespace, of the manifests of resources
+	// to create in each namespace that starts to match the controller's --managed-namespace-selector, e.g. the role
+	// binding of the workflows' service account, or artifact repositories. "{{namespace}}" is replaced by the namespace.
+	ManagedNamespaceTemplate string `json:"managedNamespaceTemplate,omitempty"`
+
+	// Clusters are other clusters that templates can run their pods on. Changes require a restart of the controller.
+	Clusters []ClusterConfig `json:"clusters,omitempty"`
+
+	// WorkloadClasses are the scheduling constraints of the pods of templates, by the name of their `workloadClass`
+	WorkloadClasses map[string]WorkloadClass `json:"workloadClasses,omitempty"`
+
+	// Informers tunes the resync periods, list chunk sizes and label selectors of the informers of the controller
+	Informers InformersConfig `json:"informers,omitempty"`
+
+	// Compression configures how the nodes of large workflows and archived workflows are compressed
+	Compression CompressionConfig `json:"compression,omitempty"`
+
+	// MetadataPropagation is which of a workflow's labels and annotations are copied to its pods, agent pods, artifact
+	// GC pods and WorkflowArtifactGCTasks, e.g. cost allocation or tenancy labels
+	MetadataPropagation *MetadataPropagationConfig `json:"metadataPropagation,omitempty"`
+}
+
+// CompressionConfig configures the algorithm and level of compression. Content compressed with either algorithm can
+// always be read, so it can be changed at any time.
+type CompressionConfig struct {
+	// Algorithm is either "gzip", the default, or "zstd"
+	Algorithm string `json:"algorithm,omitempty"`
+	// Level is the level of compression, from 1 to 9 for gzip or 1 to 22 for zstd, defaults to the algorithm's default
+	Level int `json:"level,omitempty"`
 }
 
 func (c Config) GetExecutor() *apiv1.Container {
@@ -191,6 +275,30 @@ type PersistConfig struct {
 	PostgreSQL     *PostgreSQLConfig `json:"postgresql,omitempty"`
 	MySQL          *MySQLConfig      `json:"mysql,omitempty"`
 	SkipMigration  bool              `json:"skipMigration,omitempty"`
+	// Synchronization backs semaphores with the database, rather than the memory of the controller
+	Synchronization *SyncConfig `json:"synchronization,omitempty"`
+	// Memoization stores memoization caches in the database, rather than in config maps
+	Memoization bool `json:"memoization,omitempty"`
+	// ArchiveStorage stores archived workflows in object storage or ClickHouse, rather than in the database
+	ArchiveStorage *ArchiveStorageConfig `json:"archiveStorage,omitempty"`
+	// ArchiveRetention retains archived workflows by label. Each workflow is retained by the first rule that selects
+	// it, and the archiveTTL applies to workflows no rule selects.
+	ArchiveRetention []ArchiveRetentionRule `json:"archiveRetention,omitempty"`
+}
+
+type ArchiveRetentionRule struct {
+	// Name of the rule in metrics, defaults to the label selector
+	Name string `json:"name,omitempty"`
+	// LabelSelector selects the archived workflows this rule retains
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+	// TTL of the selected archived workflows, zero retains them forever
+	TTL TTL `json:"ttl,omitempty"`
+}
+
+// DatabaseConfigured returns whether a PostgreSQL or MySQL database is configured. Node status offloading,
+// synchronization and memoization need one, the workflow archive does not if archiveStorage is configured.
+func (c PersistConfig) DatabaseConfigured() bool {
+	return c.PostgreSQL != nil || c.MySQL != nil
 }
 
 func (c PersistConfig) GetArchiveLabelSelector() (labels.Selector, error) {
@@ -207,6 +315,89 @@ func (c PersistConfig) GetClusterName() string {
 	return "default"
 }
 
+// SyncConfig configures semaphores backed by the database, so their limits are honored across controller restarts and
+// across multiple controllers sharing the database.
+type SyncConfig struct {
+	// ControllerName identifies the holders of this controller in the database, defaults to the LEADER_ELECTION_IDENTITY
+	// environment variable, or the host name
+	ControllerName string `json:"controllerName,omitempty"`
+	// InactiveControllerTTL is how long a controller may stop heart-beating before its holders are released, defaults to 5m
+	InactiveControllerTTL TTL `json:"inactiveControllerTTL,omitempty"`
+	// PollInterval is how often workflows waiting for a semaphore are re-queued, so they may acquire units released
+	// by other controllers, defaults to 10s
+	PollInterval TTL `json:"pollInterval,omitempty"`
+}
+
+func (c SyncConfig) GetInactiveControllerTTL() time.Duration {
+	if c.InactiveControllerTTL > 0 {
+		return time.Duration(c.InactiveControllerTTL)
+	}
+	return 5 * time.Minute
+}
+
+func (c SyncConfig) GetPollInterval() time.Duration {
+	if c.PollInterval > 0 {
+		return time.Duration(c.PollInterval)
+	}
+	return 10 * time.Second
+}
+
+// ArchiveStorageConfig configures where archived workflows are stored, instead of the database. Only one of them may be
+// configured.
+type ArchiveStorageConfig struct {
+	// ObjectStorage stores archived workflows as compressed JSON in an S3, GCS, Azure or OSS bucket
+	ObjectStorage *ObjectStorageArchiveConfig `json:"objectStorage,omitempty"`
+	// ClickHouse stores archived workflows in ClickHouse, using its HTTP interface
+	ClickHouse *ClickHouseConfig `json:"clickhouse,omitempty"`
+}
+
+type ObjectStorageArchiveConfig struct {
+	// The bucket, configured in the same way as the artifact repository, e.g. `s3`
+	wfv1.ArtifactRepository `json:",inline"`
+	// KeyPrefix is the prefix of the keys of the archived workflows, defaults to "archived-workflows"
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+}
+
+func (c ObjectStorageArchiveConfig) GetKeyPrefix() string {
+	if c.KeyPrefix != "" {
+		return c.KeyPrefix
+	}
+	return "archived-workflows"
+}
+
+type ClickHouseConfig struct {
+	// Host, port (defaults to 8123, or 8443 if secure), database (defaults to "default"), table name (defaults to
+	// "argo_archived_workflows") and credentials
+	DatabaseConfig
+	// Secure connects to ClickHouse using HTTPS
+	Secure bool `json:"secure,omitempty"`
+}
+
+func (c ClickHouseConfig) GetURL() string {
+	scheme, port := "http", 8123
+	if c.Secure {
+		scheme, port = "https", 8443
+	}
+	if c.Port != 0 {
+		port = c.Port
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, c.Host, port)
+}
+
+func (c ClickHouseConfig) GetDatabase() string {
+	if c.Database != "" {
+		return c.Database
+	}
+	return "default"
+}
+
+func (c ClickHouseConfig) GetTableName() string {
+	if c.TableName != "" {
+		return c.TableName
+	}
+	return "argo_archived_workflows"
+}
+
 type ConnectionPool struct {
 	MaxIdleConns    int `json:"maxIdleConns,omitempty"`
 	MaxOpenConns    int `json:"maxOpenConns,omitempty"`
@@ -257,6 +448,194 @@ type MetricsConfig struct {
 	IgnoreErrors bool `json:"ignoreErrors,omitempty"`
 	// Secure is a flag that starts the metrics servers using TLS
 	Secure *bool `json:"secure,omitempty"`
+	// MaxCustomMetricSeries is the maximum number of series (i.e. distinct label values) of each custom metric.
+	// Series beyond this limit are not emitted, protecting the controller from unbounded label cardinality.
+	// Default is 0, which is unlimited
+	MaxCustomMetricSeries int `json:"maxCustomMetricSeries,omitempty"`
+	// DurationHistograms configures histograms of node durations by namespace and template reference, and
+	// percentiles of the latency of reconciling workflows. They are disabled by default
+	DurationHistograms DurationHistogramsConfig `json:"durationHistograms,omitempty"`
+}
+
+// DurationHistogramsConfig configures histograms of node durations. Namespaces and template references that are not
+// allowed are labelled "other", limiting the cardinality of the histograms
+type DurationHistogramsConfig struct {
+	// Enabled enables the histograms
+	Enabled bool `json:"enabled,omitempty"`
+	// Buckets are the upper bounds of the buckets in seconds. Default is from 1s to 4h
+	Buckets []float64 `json:"buckets,omitempty"`
+	// Namespaces are the namespaces allowed as labels. Default is any namespace
+	Namespaces []string `json:"namespaces,omitempty"`
+	// TemplateRefs are the template references allowed as labels, e.g. "my-workflow-template/my-template".
+	// Default is any template reference
+	TemplateRefs []string `json:"templateRefs,omitempty"`
+}
+
+// PreemptionConfig configures the preemption of running workflows by newly submitted workflows of a higher priority.
+// Preempted workflows pause at the next node boundary: running pods complete, but no new pods are started until the
+// workflow is processed again
+type PreemptionConfig struct {
+	// Enabled enables preemption
+	Enabled bool `json:"enabled,omitempty"`
+	// Namespaces are the namespaces whose workflows can preempt, and be preempted. Default is every namespace
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// Preemptible returns whether the namespace's workflows can preempt, and be preempted
+func (c PreemptionConfig) Preemptible(namespace string) bool {
+	if !c.Enabled {
+		return false
+	}
+	if len(c.Namespaces) == 0 {
+		return true
+	}
+	for _, n := range c.Namespaces {
+		if n == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// QuotaConfig limits the workflows running at the same time, and the pods they run, of the workflows it selects
+type QuotaConfig struct {
+	// Name of the quota in condition messages, defaults to the namespace and label selector
+	Name string `json:"name,omitempty"`
+	// Namespace of the selected workflows. Default is every namespace
+	Namespace string `json:"namespace,omitempty"`
+	// LabelSelector selects the workflows by their labels. Default is every workflow
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// MaxWorkflows is the max workflows that can run at the same time, zero is unlimited
+	MaxWorkflows int `json:"maxWorkflows,omitempty"`
+	// MaxPods is the max pending or running pods of the workflows, zero is unlimited
+	MaxPods int `json:"maxPods,omitempty"`
+}
+
+func (c QuotaConfig) GetName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	var parts []string
+	if c.Namespace != "" {
+		parts = append(parts, c.Namespace)
+	}
+	if c.LabelSelector != nil {
+		parts = append(parts, metav1.FormatLabelSelector(c.LabelSelector))
+	}
+	return strings.Join(parts, "/")
+}
+
+// Selects returns whether the quota applies to a workflow in the namespace with the labels
+func (c QuotaConfig) Selects(namespace string, workflowLabels map[string]string) (bool, error) {
+	if c.Namespace != "" && c.Namespace != namespace {
+		return false, nil
+	}
+	if c.LabelSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+	if err != nil {
+		return false, fmt.Errorf("quota %q has an invalid label selector: %w", c.GetName(), err)
+	}
+	return selector.Matches(labels.Set(workflowLabels)), nil
+}
+
+// PricingConfig prices the resources requested by pods by the hour. The cost of a pod is the price of the resources
+// duration of the pod, i.e. its resource requests, or limits, for the time its containers ran
+type PricingConfig struct {
+	Prices `json:",inline"`
+	// NodePrices override the prices of the pods whose node selectors select nodes with all of its node labels. The
+	// first one that applies is used
+	NodePrices []NodePrices `json:"nodePrices,omitempty"`
+}
+
+// Prices are the prices of resources by the hour
+type Prices struct {
+	// CPUHour is the price of one CPU for an hour
+	CPUHour float64 `json:"cpuHour,omitempty"`
+	// MemoryGiBHour is the price of 1Gi of memory for an hour
+	MemoryGiBHour float64 `json:"memoryGiBHour,omitempty"`
+	// GPUHour is the price of one GPU, any resource named "<vendor>/gpu", for an hour
+	GPUHour float64 `json:"gpuHour,omitempty"`
+}
+
+// NodePrices are the prices of resources on the nodes with all of the node labels
+type NodePrices struct {
+	NodeLabels map[string]string `json:"nodeLabels"`
+	Prices     `json:",inline"`
+}
+
+// GetPrices returns the prices of the resources of a pod with the node selector
+func (c PricingConfig) GetPrices(nodeSelector map[string]string) Prices {
+	for _, p := range c.NodePrices {
+		if labels.SelectorFromSet(p.NodeLabels).Matches(labels.Set(nodeSelector)) {
+			return p.Prices
+		}
+	}
+	return c.Prices
+}
+
+// Cost returns the price of the resources duration
+func (p Prices) Cost(resourcesDuration wfv1.ResourcesDuration) float64 {
+	cost := 0.0
+	for name, duration := range resourcesDuration {
+		hours := duration.Duration().Hours() * float64(wfv1.ResourceQuantityDenominator(name).Value())
+		switch {
+		case name == apiv1.ResourceCPU:
+			cost += hours * p.CPUHour
+		case name == apiv1.ResourceMemory:
+			cost += hours / (1 << 30) * p.MemoryGiBHour
+		case strings.HasSuffix(string(name), "/gpu"):
+			cost += hours * p.GPUHour
+		}
+	}
+	return cost
+}
+
+// GPUVendorConfig is the device plugin of a GPU vendor
+type GPUVendorConfig struct {
+	// Resource is the extended resource of a GPU, e.g. nvidia.com/gpu
+	Resource apiv1.ResourceName `json:"resource,omitempty"`
+	// MIGResourcePrefix prefixes a MIG profile to name the extended resource of a MIG device, e.g. nvidia.com/mig-
+	MIGResourcePrefix string `json:"migResourcePrefix,omitempty"`
+	// RuntimeClassName is the runtime class of the pods that request GPUs, if they need one
+	RuntimeClassName string `json:"runtimeClassName,omitempty"`
+}
+
+// defaultGPUVendors are the device plugins of the GPU vendors, unless configured
+var defaultGPUVendors = map[wfv1.GPUVendor]GPUVendorConfig{
+	wfv1.GPUVendorNVIDIA: {Resource: "nvidia.com/gpu", MIGResourcePrefix: "nvidia.com/mig-"},
+	wfv1.GPUVendorAMD:    {Resource: "amd.com/gpu"},
+	wfv1.GPUVendorIntel:  {Resource: "gpu.intel.com/i915"},
+}
+
+// GetGPUVendor returns the device plugin of the GPU vendor, the configured fields overriding the default ones, and
+// whether the vendor is known
+func (c Config) GetGPUVendor(vendor wfv1.GPUVendor) (GPUVendorConfig, bool) {
+	v := defaultGPUVendors[vendor]
+	if configured, ok := c.GPUVendors[vendor]; ok {
+		if configured.Resource != "" {
+			v.Resource = configured.Resource
+		}
+		if configured.MIGResourcePrefix != "" {
+			v.MIGResourcePrefix = configured.MIGResourcePrefix
+		}
+		if configured.RuntimeClassName != "" {
+			v.RuntimeClassName = configured.RuntimeClassName
+		}
+	}
+	return v, v.Resource != ""
+}
+
+// GetResource returns the extended resource of a GPU, or of a MIG device with the profile
+func (v GPUVendorConfig) GetResource(migProfile string) (apiv1.ResourceName, error) {
+	if migProfile == "" {
+		return v.Resource, nil
+	}
+	if v.MIGResourcePrefix == "" {
+		return "", fmt.Errorf("MIG devices are not configured")
+	}
+	return apiv1.ResourceName(v.MIGResourcePrefix + migProfile), nil
 }
 
 func (mc MetricsConfig) GetSecure(defaultValue bool) bool {
@@ -2,8 +2,11 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -13,6 +16,13 @@ func TestDatabaseConfig(t *testing.T) {
 	assert.Equal(t, "my-host:1234", DatabaseConfig{Host: "my-host", Port: 1234}.GetHostname())
 }
 
+func TestSyncConfig(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, SyncConfig{}.GetInactiveControllerTTL())
+	assert.Equal(t, time.Minute, SyncConfig{InactiveControllerTTL: TTL(time.Minute)}.GetInactiveControllerTTL())
+	assert.Equal(t, 10*time.Second, SyncConfig{}.GetPollInterval())
+	assert.Equal(t, time.Second, SyncConfig{PollInterval: TTL(time.Second)}.GetPollInterval())
+}
+
 func TestSanitize(t *testing.T) {
 	tests := []struct {
 		c   Config
@@ -31,3 +41,79 @@ func TestSanitize(t *testing.T) {
 		}
 	}
 }
+
+func TestPreemptionConfig(t *testing.T) {
+	assert.False(t, PreemptionConfig{}.Preemptible("my-ns"))
+	assert.True(t, PreemptionConfig{Enabled: true}.Preemptible("my-ns"))
+	assert.True(t, PreemptionConfig{Enabled: true, Namespaces: []string{"my-ns"}}.Preemptible("my-ns"))
+	assert.False(t, PreemptionConfig{Enabled: true, Namespaces: []string{"my-ns"}}.Preemptible("other-ns"))
+}
+
+func TestQuotaConfig(t *testing.T) {
+	assert.Equal(t, "my-quota", QuotaConfig{Name: "my-quota"}.GetName())
+	assert.Equal(t, "my-ns", QuotaConfig{Namespace: "my-ns"}.GetName())
+	assert.Equal(t, "team=ml", QuotaConfig{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml"}}}.GetName())
+	assert.Equal(t, "my-ns/team=ml", QuotaConfig{Namespace: "my-ns", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml"}}}.GetName())
+
+	for _, tt := range []struct {
+		quota     QuotaConfig
+		namespace string
+		labels    map[string]string
+		selects   bool
+	}{
+		{QuotaConfig{}, "my-ns", nil, true},
+		{QuotaConfig{Namespace: "my-ns"}, "my-ns", nil, true},
+		{QuotaConfig{Namespace: "my-ns"}, "other-ns", nil, false},
+		{QuotaConfig{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml"}}}, "my-ns", map[string]string{"team": "ml"}, true},
+		{QuotaConfig{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml"}}}, "my-ns", map[string]string{"team": "web"}, false},
+	} {
+		selects, err := tt.quota.Selects(tt.namespace, tt.labels)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tt.selects, selects, tt.quota.GetName())
+		}
+	}
+	_, err := QuotaConfig{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "!"}}}.Selects("my-ns", nil)
+	assert.Error(t, err)
+}
+
+func TestPricingConfig(t *testing.T) {
+	pricing := PricingConfig{
+		Prices: Prices{CPUHour: 0.04, MemoryGiBHour: 0.005, GPUHour: 3},
+		NodePrices: []NodePrices{
+			{NodeLabels: map[string]string{"node.kubernetes.io/lifecycle": "spot"}, Prices: Prices{CPUHour: 0.01}},
+		},
+	}
+	assert.Equal(t, pricing.Prices, pricing.GetPrices(nil))
+	assert.Equal(t, pricing.Prices, pricing.GetPrices(map[string]string{"kubernetes.io/os": "linux"}))
+	assert.Equal(t, Prices{CPUHour: 0.01}, pricing.GetPrices(map[string]string{"node.kubernetes.io/lifecycle": "spot", "kubernetes.io/os": "linux"}))
+
+	// memory is measured in 100Mi, so ten hours of 100Mi is one hour of 1000Mi
+	cost := pricing.Prices.Cost(wfv1.ResourcesDuration{
+		apiv1.ResourceCPU:    wfv1.NewResourceDuration(2 * time.Hour),
+		apiv1.ResourceMemory: wfv1.NewResourceDuration(1024 * time.Hour / 100),
+		"nvidia.com/gpu":     wfv1.NewResourceDuration(time.Hour),
+		"ephemeral-storage":  wfv1.NewResourceDuration(time.Hour),
+	})
+	assert.InDelta(t, 0.08+0.005+3, cost, 0.0001)
+}
+
+func TestGetGPUVendor(t *testing.T) {
+	c := Config{GPUVendors: map[wfv1.GPUVendor]GPUVendorConfig{
+		wfv1.GPUVendorNVIDIA: {RuntimeClassName: "nvidia"},
+		"habana":             {Resource: "habana.ai/gaudi"},
+	}}
+	nvidia, ok := c.GetGPUVendor(wfv1.GPUVendorNVIDIA)
+	assert.True(t, ok)
+	assert.Equal(t, GPUVendorConfig{Resource: "nvidia.com/gpu", MIGResourcePrefix: "nvidia.com/mig-", RuntimeClassName: "nvidia"}, nvidia)
+	habana, ok := c.GetGPUVendor("habana")
+	assert.True(t, ok)
+	assert.Equal(t, GPUVendorConfig{Resource: "habana.ai/gaudi"}, habana)
+	_, ok = c.GetGPUVendor("unknown")
+	assert.False(t, ok)
+
+	resource, err := nvidia.GetResource("1g.5gb")
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1.ResourceName("nvidia.com/mig-1g.5gb"), resource)
+	_, err = habana.GetResource("1g.5gb")
+	assert.EqualError(t, err, "MIG devices are not configured")
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// FederationConfig configures the Argo Server to serve the workflows of other clusters, as well as its own
+type FederationConfig struct {
+	// Cluster is the name of the cluster the Argo Server runs in, defaults to "local"
+	Cluster string `json:"cluster,omitempty"`
+	// Clusters are the other clusters
+	Clusters []FederatedCluster `json:"clusters,omitempty"`
+}
+
+func (c *FederationConfig) GetCluster() string {
+	if c == nil || c.Cluster == "" {
+		return "local"
+	}
+	return c.Cluster
+}
+
+// FederatedCluster is another cluster the Argo Server serves workflows from
+type FederatedCluster struct {
+	Name string `json:"name"`
+	// KubeConfig is a key of a secret, in the Argo Server's namespace, containing a kubeconfig for the cluster.
+	// Its credentials are used for all users, so should only allow reading workflows and pod logs.
+	KubeConfig apiv1.SecretKeySelector `json:"kubeConfig"`
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// InformersConfig tunes the informers that cache the workflows, pods and task results watched by the controller, so
+// that very large clusters can trade memory for staleness. Changes require a restart of the controller.
+type InformersConfig struct {
+	// Workflows tunes the informer of workflows
+	Workflows WorkflowInformerConfig `json:"workflows,omitempty"`
+	// Pods tunes the informer of the pods of workflows
+	Pods InformerConfig `json:"pods,omitempty"`
+	// TaskResults tunes the informer of the results of the executors of workflows. They only have the label of their
+	// workflow, and of the controller's instance ID.
+	TaskResults InformerConfig `json:"taskResults,omitempty"`
+}
+
+// InformerConfig tunes an informer
+type InformerConfig struct {
+	// ResyncPeriod is how often the informer re-processes all the objects it caches, e.g. "30m"
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+	// ChunkSize is the number of objects listed by each request when the informer lists all of its objects, rather
+	// than the default of 500. Smaller chunks reduce the memory of each request, at the cost of more requests.
+	ChunkSize int64 `json:"chunkSize,omitempty"`
+	// LabelSelector narrows the objects that the informer watches, in addition to the controller's own selector, e.g.
+	// "team in (a,b)". Objects that do not match it are neither cached nor processed.
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// GetResyncPeriod returns the resync period, or the default if it is not set
+func (c InformerConfig) GetResyncPeriod(defaultPeriod time.Duration) time.Duration {
+	if c.ResyncPeriod == nil {
+		return defaultPeriod
+	}
+	return c.ResyncPeriod.Duration
+}
+
+// LabelRequirements returns the requirements of the label selector
+func (c InformerConfig) LabelRequirements() (labels.Requirements, error) {
+	if c.LabelSelector == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(c.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid informer label selector %q: %w", c.LabelSelector, err)
+	}
+	requirements, _ := selector.Requirements()
+	return requirements, nil
+}
+
+// WorkflowInformerConfig tunes the informer of workflows
+type WorkflowInformerConfig struct {
+	InformerConfig `json:",inline"`
+	// NamespaceResyncPeriods override the resync period of the workflows in some namespaces, by namespace, e.g. to
+	// reconcile the workflows of a busy namespace less often
+	NamespaceResyncPeriods map[string]metav1.Duration `json:"namespaceResyncPeriods,omitempty"`
+}
+
+// GetNamespaceResyncPeriod returns the resync period of the workflows in the namespace, or the default if neither it
+// nor the resync period is set
+func (c WorkflowInformerConfig) GetNamespaceResyncPeriod(namespace string, defaultPeriod time.Duration) time.Duration {
+	if period, ok := c.NamespaceResyncPeriods[namespace]; ok {
+		return period.Duration
+	}
+	return c.GetResyncPeriod(defaultPeriod)
+}
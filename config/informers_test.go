@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestInformersConfig(t *testing.T) {
+	var c Config
+	err := yaml.Unmarshal([]byte(`
+informers:
+  workflows:
+    resyncPeriod: 30m
+    chunkSize: 100
+    labelSelector: team in (a,b)
+    namespaceResyncPeriods:
+      busy: 1h
+`), &c)
+	if !assert.NoError(t, err) {
+		return
+	}
+	workflows := c.Informers.Workflows
+	assert.Equal(t, int64(100), workflows.ChunkSize)
+	assert.Equal(t, 30*time.Minute, workflows.GetResyncPeriod(time.Minute))
+	assert.Equal(t, time.Hour, workflows.GetNamespaceResyncPeriod("busy", time.Minute))
+	assert.Equal(t, 30*time.Minute, workflows.GetNamespaceResyncPeriod("other", time.Minute))
+	assert.Equal(t, time.Minute, c.Informers.Pods.GetResyncPeriod(time.Minute))
+	assert.Equal(t, time.Duration(0), InformerConfig{ResyncPeriod: &metav1.Duration{}}.GetResyncPeriod(time.Minute))
+
+	requirements, err := workflows.LabelRequirements()
+	if assert.NoError(t, err) && assert.Len(t, requirements, 1) {
+		assert.Equal(t, "team in (a,b)", requirements[0].String())
+	}
+	requirements, err = c.Informers.Pods.LabelRequirements()
+	assert.NoError(t, err)
+	assert.Empty(t, requirements)
+	_, err = InformerConfig{LabelSelector: "!!"}.LabelRequirements()
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+)
+
+// MetadataPropagationConfig is which of a workflow's labels and annotations the controller copies to the resources it
+// creates for the workflow: its pods, agent pods, artifact GC pods and WorkflowArtifactGCTasks. Labels and
+// annotations in the workflows.argoproj.io domain are never copied.
+type MetadataPropagationConfig struct {
+	// Labels are the keys of the labels to copy
+	Labels KeyFilter `json:"labels,omitempty"`
+	// Annotations are the keys of the annotations to copy
+	Annotations KeyFilter `json:"annotations,omitempty"`
+}
+
+// KeyFilter selects keys of labels or annotations. A key ending in "*" matches any key with that prefix,
+// e.g. "cost.example.com/*", or "*" for all keys.
+type KeyFilter struct {
+	// Allow are the keys that are selected
+	Allow []string `json:"allow,omitempty"`
+	// Deny are the keys that are not selected, even if they are allowed
+	Deny []string `json:"deny,omitempty"`
+}
+
+// Matches returns whether the key is allowed, and not denied
+func (f KeyFilter) Matches(key string) bool {
+	return matchesAnyKey(f.Allow, key) && !matchesAnyKey(f.Deny, key)
+}
+
+func matchesAnyKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key || (strings.HasSuffix(k, "*") && strings.HasPrefix(key, strings.TrimSuffix(k, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// Propagate copies the labels and annotations of the workflow that the filters select to the resource, without
+// overwriting those the resource already has
+func (c *MetadataPropagationConfig) Propagate(wf, resource *metav1.ObjectMeta) {
+	if c == nil {
+		return
+	}
+	resource.Labels = propagate(c.Labels, wf.Labels, resource.Labels)
+	resource.Annotations = propagate(c.Annotations, wf.Annotations, resource.Annotations)
+}
+
+func propagate(filter KeyFilter, from, to map[string]string) map[string]string {
+	for k, v := range from {
+		if strings.HasPrefix(k, workflow.WorkflowFullName+"/") || !filter.Matches(k) {
+			continue
+		}
+		if _, ok := to[k]; ok {
+			continue
+		}
+		if to == nil {
+			to = map[string]string{}
+		}
+		to[k] = v
+	}
+	return to
+}
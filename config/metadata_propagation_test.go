@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKeyFilter(t *testing.T) {
+	f := KeyFilter{Allow: []string{"team", "cost.example.com/*"}, Deny: []string{"cost.example.com/secret"}}
+	assert.True(t, f.Matches("team"))
+	assert.True(t, f.Matches("cost.example.com/center"))
+	assert.False(t, f.Matches("cost.example.com/secret"))
+	assert.False(t, f.Matches("teams"))
+	assert.False(t, KeyFilter{}.Matches("team"), "nothing is allowed by default")
+	assert.True(t, KeyFilter{Allow: []string{"*"}}.Matches("team"))
+}
+
+func TestMetadataPropagationConfig_Propagate(t *testing.T) {
+	wf := &metav1.ObjectMeta{
+		Labels:      map[string]string{"team": "ml", "other": "x", "workflows.argoproj.io/phase": "Running"},
+		Annotations: map[string]string{"owner": "me"},
+	}
+	t.Run("Nil", func(t *testing.T) {
+		resource := &metav1.ObjectMeta{}
+		var c *MetadataPropagationConfig
+		c.Propagate(wf, resource)
+		assert.Nil(t, resource.Labels)
+	})
+	t.Run("Propagate", func(t *testing.T) {
+		resource := &metav1.ObjectMeta{Labels: map[string]string{"team": "web"}}
+		c := &MetadataPropagationConfig{Labels: KeyFilter{Allow: []string{"*"}}, Annotations: KeyFilter{Allow: []string{"owner"}}}
+		c.Propagate(wf, resource)
+		assert.Equal(t, map[string]string{"team": "web", "other": "x"}, resource.Labels)
+		assert.Equal(t, map[string]string{"owner": "me"}, resource.Annotations)
+	})
+}
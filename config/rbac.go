@@ -2,8 +2,36 @@ package config
 
 type RBACConfig struct {
 	Enabled bool `json:"enabled,omitempty"`
+	// Rules map the claims of SSO users to the namespaces and verbs they are allowed. If there are any, they are used
+	// instead of the rules annotated on service accounts.
+	Rules []RBACRule `json:"rules,omitempty"`
 }
 
 func (c *RBACConfig) IsEnabled() bool {
 	return c != nil && c.Enabled
 }
+
+func (c *RBACConfig) GetRules() []RBACRule {
+	if c == nil {
+		return nil
+	}
+	return c.Rules
+}
+
+// RBACRule allows the SSO users that are in one of its groups and have an email in one of its email domains to perform
+// its verbs in its namespaces
+type RBACRule struct {
+	// Name identifies the rule in the audit log and dry-run results
+	Name string `json:"name"`
+	// Groups the user must be in one of. Any group if empty.
+	Groups []string `json:"groups,omitempty"`
+	// EmailDomains the user's verified email must be in one of, e.g. "example.com". Any email if empty.
+	EmailDomains []string `json:"emailDomains,omitempty"`
+	// Namespaces the user is allowed to use, "*" for all namespaces, including cluster-scoped requests
+	Namespaces []string `json:"namespaces"`
+	// Verbs the user is allowed to perform, any of "get", "list", "watch", "create", "update" and "delete", or "*"
+	// for all
+	Verbs []string `json:"verbs"`
+	// ServiceAccountName is the service account, in the Argo Server's namespace, that performs the allowed requests
+	ServiceAccountName string `json:"serviceAccountName"`
+}
@@ -21,6 +21,8 @@ type SSOConfig struct {
 	CustomGroupClaimName string `json:"customGroupClaimName,omitempty"`
 	UserInfoPath         string `json:"userInfoPath,omitempty"`
 	InsecureSkipVerify   bool   `json:"insecureSkipVerify,omitempty"`
+	// APITokens allows users to create scoped, short-lived API tokens, e.g. for CI
+	APITokens *APITokensConfig `json:"apiTokens,omitempty"`
 }
 
 func (c SSOConfig) GetSessionExpiry() time.Duration {
@@ -29,3 +31,20 @@ func (c SSOConfig) GetSessionExpiry() time.Duration {
 	}
 	return 10 * time.Hour
 }
+
+type APITokensConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxExpiry is the longest a token may be valid for. Defaults to 24h.
+	MaxExpiry metav1.Duration `json:"maxExpiry,omitempty"`
+}
+
+func (c *APITokensConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *APITokensConfig) GetMaxExpiry() time.Duration {
+	if c != nil && c.MaxExpiry.Duration > 0 {
+		return c.MaxExpiry.Duration
+	}
+	return 24 * time.Hour
+}
@@ -0,0 +1,21 @@
+package config
+
+// TemplateRevisionsConfig configures the controller to save an immutable revision of WorkflowTemplates and
+// ClusterWorkflowTemplates every time their spec is changed, so workflows can pin a revision
+type TemplateRevisionsConfig struct {
+	// Enabled saves revisions
+	Enabled bool `json:"enabled,omitempty"`
+	// HistoryLimit is the number of revisions of each template to keep, defaults to 10
+	HistoryLimit int `json:"historyLimit,omitempty"`
+}
+
+func (c *TemplateRevisionsConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *TemplateRevisionsConfig) GetHistoryLimit() int {
+	if c == nil || c.HistoryLimit <= 0 {
+		return 10
+	}
+	return c.HistoryLimit
+}
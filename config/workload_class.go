@@ -0,0 +1,17 @@
+package config
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// WorkloadClass is a named bundle of scheduling constraints, e.g. "gpu" or "spot", that templates select with
+// `workloadClass`, so that portable templates need not embed the scheduling of a cluster. The template's own
+// scheduling constraints take precedence over those of its class, which take precedence over those of the workflow.
+type WorkloadClass struct {
+	// NodeSelector of the pods of the class
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity of the pods of the class
+	Affinity *apiv1.Affinity `json:"affinity,omitempty"`
+	// Tolerations of the pods of the class
+	Tolerations []apiv1.Toleration `json:"tolerations,omitempty"`
+}
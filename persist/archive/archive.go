@@ -0,0 +1,51 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"upper.io/db.v3/lib/sqlbuilder"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	artifacts "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+)
+
+// New returns the workflow archive of the persistence config: the archive storage if configured, or else the database
+// of the session. If migrate is true, the ClickHouse table is created if it does not exist.
+func New(ctx context.Context, kubeClient kubernetes.Interface, namespace string, persistence *config.PersistConfig, session sqlbuilder.Database, managedNamespace string, instanceIDService instanceid.Service, migrate bool) (sqldb.WorkflowArchive, error) {
+	storage := persistence.ArchiveStorage
+	switch {
+	case storage != nil && storage.ObjectStorage != nil && storage.ClickHouse != nil:
+		return nil, fmt.Errorf("only one of archiveStorage.objectStorage or archiveStorage.clickhouse may be configured")
+	case storage != nil && storage.ObjectStorage != nil:
+		return NewObjectStorageWorkflowArchive(*storage.ObjectStorage, persistence.GetClusterName(), managedNamespace, instanceIDService, &resources{kubeClient, namespace}, artifacts.NewDriver), nil
+	case storage != nil && storage.ClickHouse != nil:
+		cfg := storage.ClickHouse
+		var username, password []byte
+		if cfg.UsernameSecret.Name != "" {
+			var err error
+			username, err = util.GetSecrets(ctx, kubeClient, namespace, cfg.UsernameSecret.Name, cfg.UsernameSecret.Key)
+			if err != nil {
+				return nil, err
+			}
+			password, err = util.GetSecrets(ctx, kubeClient, namespace, cfg.PasswordSecret.Name, cfg.PasswordSecret.Key)
+			if err != nil {
+				return nil, err
+			}
+		}
+		r := NewClickHouseWorkflowArchive(*cfg, string(username), string(password), persistence.GetClusterName(), managedNamespace, instanceIDService)
+		if migrate {
+			if err := r.Migrate(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	case session != nil:
+		return sqldb.NewWorkflowArchive(session, persistence.GetClusterName(), managedNamespace, instanceIDService), nil
+	}
+	return nil, fmt.Errorf("workflow archiving needs a database or archiveStorage to be configured")
+}
@@ -0,0 +1,491 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+)
+
+// clickHouseTimeLayout is the layout of DateTime64(3) values in the JSONEachRow format
+const clickHouseTimeLayout = "2006-01-02 15:04:05.000"
+
+type clickHouseWorkflowRecord struct {
+	ClusterName       string            `json:"clustername"`
+	InstanceID        string            `json:"instanceid"`
+	UID               string            `json:"uid"`
+	Name              string            `json:"name"`
+	Namespace         string            `json:"namespace"`
+	Phase             string            `json:"phase"`
+	StartedAt         string            `json:"startedat"`
+	FinishedAt        string            `json:"finishedat"`
+	Duration          int64             `json:"duration"`
+	ProgressCompleted int64             `json:"progresscompleted"`
+	ProgressTotal     int64             `json:"progresstotal"`
+	Labels            map[string]string `json:"labels"`
+	Workflow          string            `json:"workflow,omitempty"`
+	SearchText        string            `json:"searchtext,omitempty"`
+	ArchivedAt        string            `json:"archivedat"`
+}
+
+// clickHouseWorkflowArchive stores archived workflows in a ClickHouse table, using the HTTP interface. Archiving a
+// workflow again inserts a new row, and the ReplacingMergeTree engine keeps the latest one.
+type clickHouseWorkflowArchive struct {
+	client            *http.Client
+	url               string
+	database          string
+	tableName         string
+	username          string
+	password          string
+	clusterName       string
+	managedNamespace  string
+	instanceIDService instanceid.Service
+}
+
+// NewClickHouseWorkflowArchive returns a workflow archive storing workflows in ClickHouse
+func NewClickHouseWorkflowArchive(cfg config.ClickHouseConfig, username, password, clusterName, managedNamespace string, instanceIDService instanceid.Service) *clickHouseWorkflowArchive {
+	return &clickHouseWorkflowArchive{
+		client:            &http.Client{Timeout: time.Minute},
+		url:               cfg.GetURL(),
+		database:          cfg.GetDatabase(),
+		tableName:         cfg.GetTableName(),
+		username:          username,
+		password:          password,
+		clusterName:       clusterName,
+		managedNamespace:  managedNamespace,
+		instanceIDService: instanceIDService,
+	}
+}
+
+func (r *clickHouseWorkflowArchive) IsEnabled() bool {
+	return true
+}
+
+// Migrate creates the table if it does not exist, and adds any columns missing from an existing table
+func (r *clickHouseWorkflowArchive) Migrate(ctx context.Context) error {
+	err := r.exec(ctx, fmt.Sprintf(`create table if not exists %s (
+    clustername String,
+    instanceid String,
+    uid String,
+    name String,
+    namespace String,
+    phase String,
+    startedat DateTime64(3, 'UTC'),
+    finishedat DateTime64(3, 'UTC'),
+    duration Int64,
+    progresscompleted Int64,
+    progresstotal Int64,
+    labels Map(String, String),
+    workflow String CODEC(ZSTD),
+    archivedat DateTime64(3, 'UTC'),
+    searchtext String CODEC(ZSTD)
+) engine = ReplacingMergeTree(archivedat)
+order by (clustername, instanceid, uid)`, r.table()), nil, nil)
+	if err != nil {
+		return err
+	}
+	return r.exec(ctx, fmt.Sprintf("alter table %s add column if not exists searchtext String CODEC(ZSTD)", r.table()), nil, nil)
+}
+
+func (r *clickHouseWorkflowArchive) table() string {
+	return "`" + strings.ReplaceAll(r.tableName, "`", "") + "`"
+}
+
+// exec executes the query, with its parameters, sending the body, e.g. the rows to insert
+func (r *clickHouseWorkflowArchive) exec(ctx context.Context, query string, params url.Values, body []byte) error {
+	rc, err := r.do(ctx, query, params, body)
+	if err != nil {
+		return err
+	}
+	return rc.Close()
+}
+
+// selectRows executes the query, with its parameters, decoding each row into a value returned by newRow
+func (r *clickHouseWorkflowArchive) selectRows(ctx context.Context, query string, params url.Values, newRow func() interface{}) error {
+	rc, err := r.do(ctx, query+" format JSONEachRow", params, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	dec := json.NewDecoder(rc)
+	for dec.More() {
+		if err := dec.Decode(newRow()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *clickHouseWorkflowArchive) do(ctx context.Context, query string, params url.Values, body []byte) (io.ReadCloser, error) {
+	values := url.Values{}
+	for k, v := range params {
+		values[k] = v
+	}
+	values.Set("database", r.database)
+	values.Set("output_format_json_quote_64bit_integers", "0")
+	if body == nil {
+		body = []byte(query)
+	} else {
+		values.Set("query", query)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+"/?"+values.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if r.username != "" {
+		req.Header.Set("X-ClickHouse-User", r.username)
+		req.Header.Set("X-ClickHouse-Key", r.password)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer func() { _ = resp.Body.Close() }()
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("ClickHouse query failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp.Body, nil
+}
+
+// clickHouseConds builds a where clause, passing values as query parameters
+type clickHouseConds struct {
+	conds  []string
+	params url.Values
+}
+
+func (c *clickHouseConds) param(typ string, value string) string {
+	name := fmt.Sprintf("p%d", len(c.params))
+	c.params.Set("param_"+name, value)
+	return "{" + name + ":" + typ + "}"
+}
+
+func (c *clickHouseConds) add(format string, args ...interface{}) {
+	c.conds = append(c.conds, fmt.Sprintf(format, args...))
+}
+
+func (c *clickHouseConds) String() string {
+	return strings.Join(c.conds, " and ")
+}
+
+func (r *clickHouseWorkflowArchive) managedConds() *clickHouseConds {
+	c := &clickHouseConds{params: url.Values{}}
+	c.add("clustername = %s", c.param("String", r.clusterName))
+	c.add("instanceid = %s", c.param("String", r.instanceIDService.InstanceID()))
+	if r.managedNamespace != "" {
+		c.add("namespace = %s", c.param("String", r.managedNamespace))
+	}
+	return c
+}
+
+func (r *clickHouseWorkflowArchive) filterConds(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string) (*clickHouseConds, error) {
+	c := r.managedConds()
+	if namespace != "" {
+		c.add("namespace = %s", c.param("String", namespace))
+	}
+	if name != "" {
+		c.add("name = %s", c.param("String", name))
+	}
+	if namePrefix != "" {
+		c.add("startsWith(name, %s)", c.param("String", namePrefix))
+	}
+	if !minStartedAt.IsZero() {
+		c.add("startedat > fromUnixTimestamp64Milli(%s, 'UTC')", c.param("Int64", strconv.FormatInt(minStartedAt.UnixMilli(), 10)))
+	}
+	if !maxStartedAt.IsZero() {
+		c.add("startedat < fromUnixTimestamp64Milli(%s, 'UTC')", c.param("Int64", strconv.FormatInt(maxStartedAt.UnixMilli(), 10)))
+	}
+	if len(phases) > 0 {
+		var in []string
+		for _, phase := range phases {
+			in = append(in, c.param("String", string(phase)))
+		}
+		c.add("phase in (%s)", strings.Join(in, ", "))
+	}
+	for _, word := range strings.Fields(search) {
+		c.add("positionCaseInsensitive(searchtext, %s) > 0", c.param("String", word))
+	}
+	for _, req := range labelRequirements {
+		if err := c.addLabelRequirement(req); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *clickHouseConds) addLabelRequirement(req labels.Requirement) error {
+	key := c.param("String", req.Key())
+	values := func() string {
+		var in []string
+		for _, v := range req.Values().List() {
+			in = append(in, c.param("String", v))
+		}
+		return strings.Join(in, ", ")
+	}
+	switch req.Operator() {
+	case selection.Exists:
+		c.add("mapContains(labels, %s)", key)
+	case selection.DoesNotExist:
+		c.add("not mapContains(labels, %s)", key)
+	case selection.Equals, selection.DoubleEquals, selection.In:
+		c.add("(mapContains(labels, %s) and labels[%s] in (%s))", key, key, values())
+	case selection.NotEquals, selection.NotIn:
+		c.add("not (mapContains(labels, %s) and labels[%s] in (%s))", key, key, values())
+	case selection.GreaterThan, selection.LessThan:
+		i, err := strconv.Atoi(req.Values().List()[0])
+		if err != nil {
+			return err
+		}
+		op := ">"
+		if req.Operator() == selection.LessThan {
+			op = "<"
+		}
+		c.add("toInt64OrNull(labels[%s]) %s %s", key, op, c.param("Int64", strconv.Itoa(i)))
+	default:
+		return fmt.Errorf("operation %v is not supported", req.Operator())
+	}
+	return nil
+}
+
+func (r *clickHouseWorkflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
+	logCtx := log.WithFields(log.Fields{"uid": wf.UID, "labels": wf.GetLabels()})
+	logCtx.Debug("Archiving workflow")
+	workflow, err := json.Marshal(wf)
+	if err != nil {
+		return err
+	}
+	var progressCompleted, progressTotal int64
+	if progress := wf.Status.Progress; strings.Contains(string(progress), "/") && progress.IsValid() {
+		progressCompleted, progressTotal = progress.N(), progress.M()
+	}
+	row, err := json.Marshal(&clickHouseWorkflowRecord{
+		ClusterName:       r.clusterName,
+		InstanceID:        r.instanceIDService.InstanceID(),
+		UID:               string(wf.UID),
+		Name:              wf.Name,
+		Namespace:         wf.Namespace,
+		Phase:             string(wf.Status.Phase),
+		StartedAt:         wf.Status.StartedAt.UTC().Format(clickHouseTimeLayout),
+		FinishedAt:        wf.Status.FinishedAt.UTC().Format(clickHouseTimeLayout),
+		Duration:          int64(wf.Status.GetDuration().Seconds()),
+		ProgressCompleted: progressCompleted,
+		ProgressTotal:     progressTotal,
+		Labels:            wf.GetLabels(),
+		Workflow:          string(workflow),
+		SearchText:        sqldb.SearchText(wf),
+		ArchivedAt:        time.Now().UTC().Format(clickHouseTimeLayout),
+	})
+	if err != nil {
+		return err
+	}
+	return r.exec(context.Background(), fmt.Sprintf("insert into %s format JSONEachRow", r.table()), nil, row)
+}
+
+func parseClickHouseTime(s string) metav1.Time {
+	t, err := time.ParseInLocation(clickHouseTimeLayout, s, time.UTC)
+	if err != nil {
+		return metav1.Time{}
+	}
+	return metav1.Time{Time: t}
+}
+
+func (r *clickHouseWorkflowArchive) ListWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string, sortBy string, limit, offset int) (wfv1.Workflows, error) {
+	orderBy := "startedat desc"
+	if sortBy != "" {
+		column, ok := sqldb.SortByFields[strings.TrimPrefix(sortBy, "-")]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort by %q", sortBy)
+		}
+		direction := "asc"
+		if strings.HasPrefix(sortBy, "-") {
+			direction = "desc"
+		}
+		orderBy = column + " " + direction + ", startedat desc"
+	}
+	c, err := r.filterConds(namespace, name, namePrefix, minStartedAt, maxStartedAt, labelRequirements, phases, search)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("select name, namespace, uid, phase, startedat, finishedat, progresscompleted, progresstotal from %s final where %s order by %s", r.table(), c, orderBy)
+	// a limit of 0 lists all the workflows, to match the behavior of the `List` operations in the Kubernetes API
+	if limit > 0 {
+		query += fmt.Sprintf(" limit %d offset %d", limit, offset)
+	}
+	var records []*clickHouseWorkflowRecord
+	err = r.selectRows(context.Background(), query, c.params, func() interface{} {
+		record := &clickHouseWorkflowRecord{}
+		records = append(records, record)
+		return record
+	})
+	if err != nil {
+		return nil, err
+	}
+	wfs := make(wfv1.Workflows, len(records))
+	for i, md := range records {
+		var progress wfv1.Progress
+		if md.ProgressTotal > 0 {
+			progress, _ = wfv1.NewProgress(md.ProgressCompleted, md.ProgressTotal)
+		}
+		startedAt := parseClickHouseTime(md.StartedAt)
+		wfs[i] = wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              md.Name,
+				Namespace:         md.Namespace,
+				UID:               types.UID(md.UID),
+				CreationTimestamp: startedAt,
+			},
+			Status: wfv1.WorkflowStatus{
+				Phase:      wfv1.WorkflowPhase(md.Phase),
+				StartedAt:  startedAt,
+				FinishedAt: parseClickHouseTime(md.FinishedAt),
+				Progress:   progress,
+			},
+		}
+	}
+	return wfs, nil
+}
+
+func (r *clickHouseWorkflowArchive) CountWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string) (int64, error) {
+	c, err := r.filterConds(namespace, name, namePrefix, minStartedAt, maxStartedAt, labelRequirements, phases, search)
+	if err != nil {
+		return 0, err
+	}
+	total := &struct {
+		Total int64 `json:"total"`
+	}{}
+	err = r.selectRows(context.Background(), fmt.Sprintf("select count() as total from %s final where %s", r.table(), c), c.params, func() interface{} { return total })
+	if err != nil {
+		return 0, err
+	}
+	return total.Total, nil
+}
+
+func (r *clickHouseWorkflowArchive) GetWorkflow(uid string) (*wfv1.Workflow, error) {
+	c := r.managedConds()
+	c.add("uid = %s", c.param("String", uid))
+	var record *clickHouseWorkflowRecord
+	err := r.selectRows(context.Background(), fmt.Sprintf("select workflow from %s final where %s limit 1", r.table(), c), c.params, func() interface{} {
+		record = &clickHouseWorkflowRecord{}
+		return record
+	})
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, nil
+	}
+	var wf *wfv1.Workflow
+	err = json.Unmarshal([]byte(record.Workflow), &wf)
+	if err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
+
+func (r *clickHouseWorkflowArchive) DeleteWorkflow(uid string) error {
+	c := r.managedConds()
+	c.add("uid = %s", c.param("String", uid))
+	err := r.exec(context.Background(), fmt.Sprintf("alter table %s delete where %s", r.table(), c), c.params, nil)
+	if err != nil {
+		return err
+	}
+	log.WithField("uid", uid).Debug("Deleted archived workflow")
+	return nil
+}
+
+func (r *clickHouseWorkflowArchive) DeleteExpiredWorkflows(ttl time.Duration, labelRequirements labels.Requirements, excluding []labels.Requirements) (int64, error) {
+	c := r.managedConds()
+	c.add("finishedat < now64(3) - toIntervalSecond(%s)", c.param("Int64", strconv.Itoa(int(ttl.Seconds()))))
+	for _, req := range labelRequirements {
+		if err := c.addLabelRequirement(req); err != nil {
+			return 0, err
+		}
+	}
+	for _, requirements := range excluding {
+		x := &clickHouseConds{params: c.params}
+		for _, req := range requirements {
+			if err := x.addLabelRequirement(req); err != nil {
+				return 0, err
+			}
+		}
+		if len(x.conds) > 0 {
+			c.add("not (%s)", x)
+		}
+	}
+	// mutations do not return the number of rows deleted, so count them first
+	total := &struct {
+		Total int64 `json:"total"`
+	}{}
+	err := r.selectRows(context.Background(), fmt.Sprintf("select count() as total from %s final where %s", r.table(), c), c.params, func() interface{} { return total })
+	if err != nil {
+		return 0, err
+	}
+	if total.Total == 0 {
+		return 0, nil
+	}
+	err = r.exec(context.Background(), fmt.Sprintf("alter table %s delete where %s", r.table(), c), c.params, nil)
+	if err != nil {
+		return 0, err
+	}
+	log.WithField("rowsDeleted", total.Total).Info("Deleted archived workflows")
+	return total.Total, nil
+}
+
+func (r *clickHouseWorkflowArchive) ListWorkflowsLabelKeys() (*wfv1.LabelKeys, error) {
+	c := r.managedConds()
+	labelKeys := &wfv1.LabelKeys{Items: []string{}}
+	err := r.selectRows(context.Background(), fmt.Sprintf("select distinct arrayJoin(mapKeys(labels)) as key from %s final where %s order by key", r.table(), c), c.params, func() interface{} {
+		return &labelRow{add: func(s string) { labelKeys.Items = append(labelKeys.Items, s) }}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labelKeys, nil
+}
+
+func (r *clickHouseWorkflowArchive) ListWorkflowsLabelValues(key string) (*wfv1.LabelValues, error) {
+	c := r.managedConds()
+	k := c.param("String", key)
+	c.add("mapContains(labels, %s)", k)
+	labelValues := &wfv1.LabelValues{Items: []string{}}
+	err := r.selectRows(context.Background(), fmt.Sprintf("select distinct labels[%s] as key from %s final where %s order by key", k, r.table(), c), c.params, func() interface{} {
+		return &labelRow{add: func(s string) { labelValues.Items = append(labelValues.Items, s) }}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return labelValues, nil
+}
+
+// labelRow decodes a row of label keys or values
+type labelRow struct {
+	add func(string)
+}
+
+func (l *labelRow) UnmarshalJSON(data []byte) error {
+	row := struct {
+		Key string `json:"key"`
+	}{}
+	if err := json.Unmarshal(data, &row); err != nil {
+		return err
+	}
+	l.add(row.Key)
+	return nil
+}
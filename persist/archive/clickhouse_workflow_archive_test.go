@@ -0,0 +1,119 @@
+package archive
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+)
+
+func TestClickHouseConds_addLabelRequirement(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{"a", "mapContains(labels, {p0:String})"},
+		{"!a", "not mapContains(labels, {p0:String})"},
+		{"a=b", "(mapContains(labels, {p0:String}) and labels[{p0:String}] in ({p1:String}))"},
+		{"a in (b,c)", "(mapContains(labels, {p0:String}) and labels[{p0:String}] in ({p1:String}, {p2:String}))"},
+		{"a!=b", "not (mapContains(labels, {p0:String}) and labels[{p0:String}] in ({p1:String}))"},
+		{"a>1", "toInt64OrNull(labels[{p0:String}]) > {p1:Int64}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			requirements, err := labels.ParseToRequirements(tt.selector)
+			if assert.NoError(t, err) {
+				c := &clickHouseConds{params: url.Values{}}
+				if assert.NoError(t, c.addLabelRequirement(requirements[0])) {
+					assert.Equal(t, tt.want, c.String())
+					assert.Equal(t, "a", c.params.Get("param_p0"))
+				}
+			}
+		})
+	}
+}
+
+func TestClickHouseWorkflowArchive(t *testing.T) {
+	var queries []string
+	var params url.Values
+	response := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "my-user", r.Header.Get("X-ClickHouse-User"))
+		params = r.URL.Query()
+		query := params.Get("query")
+		if query == "" {
+			data, _ := io.ReadAll(r.Body)
+			query = string(data)
+		}
+		queries = append(queries, query)
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+	r := NewClickHouseWorkflowArchive(config.ClickHouseConfig{DatabaseConfig: config.DatabaseConfig{Host: u.Hostname()}}, "my-user", "my-password", "default", "", instanceid.NewService(""))
+	r.url = server.URL
+
+	t.Run("ArchiveWorkflow", func(t *testing.T) {
+		err := r.ArchiveWorkflow(newArchivedWorkflow("a", "1", wfv1.WorkflowSucceeded, time.Now(), nil))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "insert into `argo_archived_workflows` format JSONEachRow", queries[len(queries)-1])
+		}
+	})
+	t.Run("ListWorkflows", func(t *testing.T) {
+		response = `{"name":"a","namespace":"my-ns","uid":"1","phase":"Succeeded","startedat":"2023-01-02 03:04:05.000","finishedat":"2023-01-02 03:05:05.000","progresscompleted":1,"progresstotal":2}` + "\n"
+		wfs, err := r.ListWorkflows("my-ns", "", "a", time.Time{}, time.Time{}, nil, []wfv1.WorkflowPhase{wfv1.WorkflowSucceeded}, "image-pull", "-duration", 10, 0)
+		if assert.NoError(t, err) && assert.Len(t, wfs, 1) {
+			assert.Equal(t, "select name, namespace, uid, phase, startedat, finishedat, progresscompleted, progresstotal from `argo_archived_workflows` final where clustername = {p0:String} and instanceid = {p1:String} and namespace = {p2:String} and startsWith(name, {p3:String}) and phase in ({p4:String}) and positionCaseInsensitive(searchtext, {p5:String}) > 0 order by duration desc, startedat desc limit 10 offset 0 format JSONEachRow", queries[len(queries)-1])
+			assert.Equal(t, "a", params.Get("param_p3"))
+			assert.Equal(t, "a", wfs[0].Name)
+			assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), wfs[0].Status.StartedAt.Time)
+			assert.Equal(t, wfv1.Progress("1/2"), wfs[0].Status.Progress)
+		}
+	})
+	t.Run("CountWorkflows", func(t *testing.T) {
+		response = `{"total":3}` + "\n"
+		count, err := r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(3), count)
+		}
+	})
+	t.Run("GetWorkflow", func(t *testing.T) {
+		response = ""
+		wf, err := r.GetWorkflow("1")
+		if assert.NoError(t, err) {
+			assert.Nil(t, wf)
+		}
+		response = `{"workflow":"{\"metadata\":{\"name\":\"a\"}}"}` + "\n"
+		wf, err = r.GetWorkflow("1")
+		if assert.NoError(t, err) && assert.NotNil(t, wf) {
+			assert.Equal(t, "a", wf.Name)
+		}
+	})
+	t.Run("ListWorkflowsLabelKeys", func(t *testing.T) {
+		response = `{"key":"a"}` + "\n" + `{"key":"b"}` + "\n"
+		keys, err := r.ListWorkflowsLabelKeys()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"a", "b"}, keys.Items)
+		}
+	})
+	t.Run("DeleteExpiredWorkflows", func(t *testing.T) {
+		response = `{"total":2}` + "\n"
+		team, err := labels.ParseToRequirements("team=payments")
+		assert.NoError(t, err)
+		deleted, err := r.DeleteExpiredWorkflows(time.Hour, nil, []labels.Requirements{team})
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(2), deleted)
+			assert.Equal(t, "alter table `argo_archived_workflows` delete where clustername = {p0:String} and instanceid = {p1:String} and finishedat < now64(3) - toIntervalSecond({p2:Int64}) and not ((mapContains(labels, {p3:String}) and labels[{p3:String}] in ({p4:String})))", queries[len(queries)-1])
+			assert.Equal(t, "3600", params.Get("param_p2"))
+		}
+	})
+}
@@ -0,0 +1,517 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	artifacts "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+const (
+	objectStorageWorkflowName = "workflow.json"
+	objectStorageIndexName    = "index.json"
+)
+
+// objectStorageWorkflowArchive stores each archived workflow as compressed JSON, with the key
+// `<keyPrefix>/<clusterName>/<uid>/workflow.json.gz`, or `workflow.json.zst` if it is compressed with zstd. Listing,
+// counting and deleting expired workflows read the index `<keyPrefix>/<clusterName>/index.json.gz` (or `.zst`) instead
+// of every archived workflow. Objects compressed with either algorithm are read, so it can be changed at any time.
+type objectStorageWorkflowArchive struct {
+	// indexLock serializes the updates of the index by this process
+	indexLock         sync.Mutex
+	repository        wfv1.ArtifactRepository
+	keyPrefix         string
+	clusterName       string
+	managedNamespace  string
+	instanceIDService instanceid.Service
+	resources         resource.Interface
+	newDriver         artifacts.NewDriverFunc
+}
+
+// objectStorageIndex is the index of the archived workflows of a cluster, by UID
+type objectStorageIndex struct {
+	Workflows map[string]objectStorageIndexEntry `json:"workflows"`
+}
+
+// objectStorageIndexEntry has the fields of an archived workflow used to list, filter and expire it
+type objectStorageIndexEntry struct {
+	Workflow   *wfv1.Workflow `json:"workflow"`
+	SearchText string         `json:"searchText,omitempty"`
+}
+
+func newObjectStorageIndexEntry(wf *wfv1.Workflow) objectStorageIndexEntry {
+	item := listItem(wf)
+	item.Labels = wf.Labels
+	return objectStorageIndexEntry{Workflow: &item, SearchText: sqldb.SearchText(wf)}
+}
+
+// NewObjectStorageWorkflowArchive returns a workflow archive storing workflows in the bucket of the config
+func NewObjectStorageWorkflowArchive(cfg config.ObjectStorageArchiveConfig, clusterName, managedNamespace string, instanceIDService instanceid.Service, resources resource.Interface, newDriver artifacts.NewDriverFunc) sqldb.WorkflowArchive {
+	return &objectStorageWorkflowArchive{
+		repository:        cfg.ArtifactRepository,
+		keyPrefix:         cfg.GetKeyPrefix(),
+		clusterName:       clusterName,
+		managedNamespace:  managedNamespace,
+		instanceIDService: instanceIDService,
+		resources:         resources,
+		newDriver:         newDriver,
+	}
+}
+
+func (r *objectStorageWorkflowArchive) IsEnabled() bool {
+	return true
+}
+
+func (r *objectStorageWorkflowArchive) clusterKey() string {
+	return path.Join(r.keyPrefix, r.clusterName)
+}
+
+// workflowKey returns the key of the workflow compressed with the current algorithm
+func (r *objectStorageWorkflowArchive) workflowKey(uid string) string {
+	return path.Join(r.clusterKey(), uid, objectStorageWorkflowName+file.CompressionExtension())
+}
+
+// indexKey returns the key of the index compressed with the current algorithm
+func (r *objectStorageWorkflowArchive) indexKey() string {
+	return path.Join(r.clusterKey(), objectStorageIndexName+file.CompressionExtension())
+}
+
+// isCompressedKey returns whether the key is of the JSON with the name, compressed with any algorithm
+func isCompressedKey(key, name string) bool {
+	for _, ext := range file.CompressionExtensions() {
+		if path.Base(key) == name+ext {
+			return true
+		}
+	}
+	return false
+}
+
+// preferKey returns the key if it is one of the keys, or else the first of them, or "" if there are none
+func preferKey(keys []string, key string) string {
+	for _, k := range keys {
+		if k == key {
+			return k
+		}
+	}
+	if len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+// workflowKeys returns the keys of the archived workflow, compressed with any algorithm. There is more than one if
+// it was archived again after the algorithm was changed.
+func (r *objectStorageWorkflowArchive) workflowKeys(driver artifactscommon.ArtifactDriver, art *wfv1.Artifact, uid string) ([]string, error) {
+	dir := path.Join(r.clusterKey(), uid)
+	if err := art.SetKey(dir); err != nil {
+		return nil, err
+	}
+	keys, err := driver.ListObjects(art)
+	if err != nil && !argoerrs.IsCode(argoerrs.CodeNotFound, err) {
+		return nil, err
+	}
+	var found []string
+	for _, key := range keys {
+		key = strings.TrimPrefix(key, "/")
+		if path.Dir(key) == dir && isCompressedKey(key, objectStorageWorkflowName) {
+			found = append(found, key)
+		}
+	}
+	return found, nil
+}
+
+func (r *objectStorageWorkflowArchive) driver(key string) (artifactscommon.ArtifactDriver, *wfv1.Artifact, error) {
+	l := r.repository.ToArtifactLocation()
+	if err := l.SetKey(key); err != nil {
+		return nil, nil, fmt.Errorf("archiveStorage.objectStorage does not configure a bucket: %w", err)
+	}
+	if !l.HasLocation() {
+		return nil, nil, fmt.Errorf("archiveStorage.objectStorage does not configure a bucket")
+	}
+	art := &wfv1.Artifact{ArtifactLocation: *l}
+	driver, err := r.newDriver(context.Background(), art, r.resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	return driver, art, nil
+}
+
+func (r *objectStorageWorkflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
+	logCtx := log.WithFields(log.Fields{"uid": wf.UID, "labels": wf.GetLabels()})
+	logCtx.Debug("Archiving workflow")
+	uid := string(wf.UID)
+	key := r.workflowKey(uid)
+	if err := r.save(key, wf); err != nil {
+		return err
+	}
+	if err := r.deleteWorkflowKeys(uid, key); err != nil {
+		return err
+	}
+	_, err := r.updateIndex(func(index *objectStorageIndex) {
+		index.Workflows[uid] = newObjectStorageIndexEntry(wf)
+	})
+	return err
+}
+
+// save stores the value as compressed JSON
+func (r *objectStorageWorkflowArchive) save(key string, v interface{}) error {
+	f, err := os.CreateTemp("", "archived-workflow-*.json"+file.CompressionExtension())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	w, err := file.NewCompressWriter(f)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	driver, art, err := r.driver(key)
+	if err != nil {
+		return err
+	}
+	return driver.Save(f.Name(), art)
+}
+
+func (r *objectStorageWorkflowArchive) load(driver artifactscommon.ArtifactDriver, art *wfv1.Artifact) (*wfv1.Workflow, error) {
+	var wf *wfv1.Workflow
+	if err := r.decode(driver, art, &wf); err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
+
+// decode reads the compressed JSON stored as the artifact into v
+func (r *objectStorageWorkflowArchive) decode(driver artifactscommon.ArtifactDriver, art *wfv1.Artifact, v interface{}) error {
+	rc, err := driver.OpenStream(art)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+	gz, err := file.NewDecompressReader(rc)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = gz.Close() }()
+	return json.NewDecoder(gz).Decode(v)
+}
+
+// managed returns whether the workflow is archived by this controller, as the cluster name is already in the key
+func (r *objectStorageWorkflowArchive) managed(wf *wfv1.Workflow) bool {
+	return (r.managedNamespace == "" || wf.Namespace == r.managedNamespace) && r.instanceIDService.Validate(wf) == nil
+}
+
+// updateIndex applies the update to the index and reconciles it with the archived workflows in the bucket, so it
+// includes the workflows archived without updating it (e.g. before it existed, or when a concurrent update of it by
+// another process was lost) and excludes the deleted ones. Only those workflows are read, and those that cannot be
+// read are skipped. The index is written if it changed.
+func (r *objectStorageWorkflowArchive) updateIndex(update func(index *objectStorageIndex)) (*objectStorageIndex, error) {
+	r.indexLock.Lock()
+	defer r.indexLock.Unlock()
+	driver, art, err := r.driver(r.clusterKey())
+	if err != nil {
+		return nil, err
+	}
+	keys, err := driver.ListObjects(art)
+	if err != nil && !argoerrs.IsCode(argoerrs.CodeNotFound, err) {
+		return nil, err
+	}
+	index := &objectStorageIndex{Workflows: map[string]objectStorageIndexEntry{}}
+	var indexKeys []string
+	for _, key := range keys {
+		key = strings.TrimPrefix(key, "/")
+		if path.Dir(key) == r.clusterKey() && isCompressedKey(key, objectStorageIndexName) {
+			indexKeys = append(indexKeys, key)
+		}
+	}
+	// the index is read from the object compressed with the current algorithm, if there is one
+	if indexKey := preferKey(indexKeys, r.indexKey()); indexKey != "" {
+		if err := art.SetKey(indexKey); err != nil {
+			return nil, err
+		}
+		if err := r.decode(driver, art, index); err != nil {
+			return nil, fmt.Errorf("failed to read archived workflows index %s: %w", indexKey, err)
+		}
+		if index.Workflows == nil {
+			index.Workflows = map[string]objectStorageIndexEntry{}
+		}
+	}
+	changed := update != nil
+	if update != nil {
+		update(index)
+	}
+	archived := map[string]bool{}
+	for _, key := range keys {
+		if !isCompressedKey(key, objectStorageWorkflowName) {
+			continue
+		}
+		uid := path.Base(path.Dir(key))
+		archived[uid] = true
+		if _, ok := index.Workflows[uid]; ok {
+			continue
+		}
+		if err := art.SetKey(key); err != nil {
+			return nil, err
+		}
+		wf, err := r.load(driver, art)
+		if err != nil {
+			log.WithError(err).WithField("key", key).Warn("Skipping archived workflow that cannot be read")
+			continue
+		}
+		index.Workflows[uid] = newObjectStorageIndexEntry(wf)
+		changed = true
+	}
+	for uid := range index.Workflows {
+		if !archived[uid] {
+			delete(index.Workflows, uid)
+			changed = true
+		}
+	}
+	if changed {
+		if err := r.save(r.indexKey(), index); err != nil {
+			return nil, fmt.Errorf("failed to write archived workflows index %s: %w", r.indexKey(), err)
+		}
+		// the index compressed with another algorithm is out of date
+		for _, key := range indexKeys {
+			if key == r.indexKey() {
+				continue
+			}
+			if err := art.SetKey(key); err != nil {
+				return nil, err
+			}
+			if err := driver.Delete(art); err != nil {
+				return nil, fmt.Errorf("failed to delete archived workflows index %s: %w", key, err)
+			}
+		}
+	}
+	return index, nil
+}
+
+// loadAll returns the index entries of all the archived workflows of this controller
+func (r *objectStorageWorkflowArchive) loadAll() ([]objectStorageIndexEntry, error) {
+	index, err := r.updateIndex(nil)
+	if err != nil {
+		return nil, err
+	}
+	var entries []objectStorageIndexEntry
+	for _, entry := range index.Workflows {
+		if r.managed(entry.Workflow) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (r *objectStorageWorkflowArchive) find(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string) ([]objectStorageIndexEntry, error) {
+	all, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	f := workflowFilter{namespace: namespace, name: name, namePrefix: namePrefix, minStartedAt: minStartedAt, maxStartedAt: maxStartedAt, labelRequirements: labelRequirements, phases: phases, search: search}
+	var found []objectStorageIndexEntry
+	for _, entry := range all {
+		if f.matches(entry.Workflow, entry.SearchText) {
+			found = append(found, entry)
+		}
+	}
+	return found, nil
+}
+
+func (r *objectStorageWorkflowArchive) ListWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string, sortBy string, limit, offset int) (wfv1.Workflows, error) {
+	less, err := lessFunc(sortBy)
+	if err != nil {
+		return nil, err
+	}
+	found, err := r.find(namespace, name, namePrefix, minStartedAt, maxStartedAt, labelRequirements, phases, search)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(found, func(i, j int) bool { return less(found[i].Workflow, found[j].Workflow) })
+	// a limit of 0 lists all the workflows, to match the behavior of the `List` operations in the Kubernetes API
+	if offset > len(found) {
+		offset = len(found)
+	}
+	found = found[offset:]
+	if limit > 0 && limit < len(found) {
+		found = found[:limit]
+	}
+	wfs := make(wfv1.Workflows, len(found))
+	for i, entry := range found {
+		wfs[i] = listItem(entry.Workflow)
+	}
+	return wfs, nil
+}
+
+func (r *objectStorageWorkflowArchive) CountWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string) (int64, error) {
+	found, err := r.find(namespace, name, namePrefix, minStartedAt, maxStartedAt, labelRequirements, phases, search)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(found)), nil
+}
+
+func (r *objectStorageWorkflowArchive) GetWorkflow(uid string) (*wfv1.Workflow, error) {
+	driver, art, err := r.driver(path.Join(r.clusterKey(), uid))
+	if err != nil {
+		return nil, err
+	}
+	keys, err := r.workflowKeys(driver, art, uid)
+	if err != nil {
+		return nil, err
+	}
+	key := preferKey(keys, r.workflowKey(uid))
+	if key == "" {
+		return nil, nil
+	}
+	if err := art.SetKey(key); err != nil {
+		return nil, err
+	}
+	wf, err := r.load(driver, art)
+	if err != nil {
+		return nil, err
+	}
+	if !r.managed(wf) {
+		return nil, nil
+	}
+	return wf, nil
+}
+
+func (r *objectStorageWorkflowArchive) DeleteWorkflow(uid string) error {
+	if err := r.deleteWorkflow(uid); err != nil {
+		return err
+	}
+	_, err := r.updateIndex(func(index *objectStorageIndex) { delete(index.Workflows, uid) })
+	return err
+}
+
+// deleteWorkflow deletes the archived workflow, without updating the index
+func (r *objectStorageWorkflowArchive) deleteWorkflow(uid string) error {
+	if err := r.deleteWorkflowKeys(uid, ""); err != nil {
+		return err
+	}
+	log.WithField("uid", uid).Debug("Deleted archived workflow")
+	return nil
+}
+
+// deleteWorkflowKeys deletes the objects of the archived workflow, but for the one with the key to keep
+func (r *objectStorageWorkflowArchive) deleteWorkflowKeys(uid, keep string) error {
+	driver, art, err := r.driver(path.Join(r.clusterKey(), uid))
+	if err != nil {
+		return err
+	}
+	keys, err := r.workflowKeys(driver, art, uid)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key == keep {
+			continue
+		}
+		if err := art.SetKey(key); err != nil {
+			return err
+		}
+		if err := driver.Delete(art); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *objectStorageWorkflowArchive) DeleteExpiredWorkflows(ttl time.Duration, labelRequirements labels.Requirements, excluding []labels.Requirements) (int64, error) {
+	entries, err := r.loadAll()
+	if err != nil {
+		return 0, err
+	}
+	expiredAt := time.Now().Add(-ttl)
+	filter := workflowFilter{labelRequirements: labelRequirements}
+	var deleted []string
+	defer func() {
+		if len(deleted) == 0 {
+			return
+		}
+		if _, err := r.updateIndex(func(index *objectStorageIndex) {
+			for _, uid := range deleted {
+				delete(index.Workflows, uid)
+			}
+		}); err != nil {
+			log.WithError(err).Warn("Failed to remove deleted archived workflows from the index")
+		}
+	}()
+	for _, entry := range entries {
+		wf := entry.Workflow
+		if !wf.Status.FinishedAt.Time.Before(expiredAt) || !filter.matches(wf, "") || matchesAny(wf, excluding) {
+			continue
+		}
+		if err := r.deleteWorkflow(string(wf.UID)); err != nil {
+			return int64(len(deleted)), err
+		}
+		deleted = append(deleted, string(wf.UID))
+	}
+	log.WithFields(log.Fields{"deleted": len(deleted)}).Info("Deleted archived workflows")
+	return int64(len(deleted)), nil
+}
+
+func (r *objectStorageWorkflowArchive) ListWorkflowsLabelKeys() (*wfv1.LabelKeys, error) {
+	entries, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	keys := map[string]bool{}
+	for _, entry := range entries {
+		for key := range entry.Workflow.Labels {
+			keys[key] = true
+		}
+	}
+	return &wfv1.LabelKeys{Items: sortedKeys(keys)}, nil
+}
+
+func (r *objectStorageWorkflowArchive) ListWorkflowsLabelValues(key string) (*wfv1.LabelValues, error) {
+	entries, err := r.loadAll()
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]bool{}
+	for _, entry := range entries {
+		if value, ok := entry.Workflow.Labels[key]; ok {
+			values[value] = true
+		}
+	}
+	return &wfv1.LabelValues{Items: sortedKeys(values)}, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+	items := make([]string, 0, len(m))
+	for item := range m {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return items
+}
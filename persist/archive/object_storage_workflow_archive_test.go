@@ -0,0 +1,268 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+// memoryDriver stores objects in memory, by S3 key
+type memoryDriver struct {
+	objects map[string][]byte
+	// opened are the keys of the objects read
+	opened []string
+}
+
+func (d *memoryDriver) Load(*wfv1.Artifact, string) error {
+	panic("not used")
+}
+
+func (d *memoryDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	d.opened = append(d.opened, a.S3.Key)
+	data, ok := d.objects[a.S3.Key]
+	if !ok {
+		return nil, argoerrs.New(argoerrs.CodeNotFound, a.S3.Key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (d *memoryDriver) Save(path string, a *wfv1.Artifact) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	d.objects[a.S3.Key] = data
+	return nil
+}
+
+func (d *memoryDriver) Delete(a *wfv1.Artifact) error {
+	delete(d.objects, a.S3.Key)
+	return nil
+}
+
+func (d *memoryDriver) ListObjects(a *wfv1.Artifact) ([]string, error) {
+	var keys []string
+	for key := range d.objects {
+		if strings.HasPrefix(key, a.S3.Key+"/") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, argoerrs.New(argoerrs.CodeNotFound, a.S3.Key)
+	}
+	return keys, nil
+}
+
+func (d *memoryDriver) IsDirectory(*wfv1.Artifact) (bool, error) {
+	return false, nil
+}
+
+func newTestObjectStorageWorkflowArchive(driver *memoryDriver) *objectStorageWorkflowArchive {
+	cfg := config.ObjectStorageArchiveConfig{ArtifactRepository: wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "my-endpoint", Bucket: "my-bucket"}}}}
+	newDriver := func(context.Context, *wfv1.Artifact, resource.Interface) (common.ArtifactDriver, error) {
+		return driver, nil
+	}
+	return NewObjectStorageWorkflowArchive(cfg, "default", "", instanceid.NewService(""), nil, newDriver).(*objectStorageWorkflowArchive)
+}
+
+func newArchivedWorkflow(name, uid string, phase wfv1.WorkflowPhase, startedAt time.Time, labels map[string]string) *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", UID: types.UID(uid), Labels: labels},
+		Status: wfv1.WorkflowStatus{
+			Phase:      phase,
+			StartedAt:  metav1.Time{Time: startedAt},
+			FinishedAt: metav1.Time{Time: startedAt.Add(time.Minute)},
+		},
+	}
+}
+
+func TestObjectStorageWorkflowArchive(t *testing.T) {
+	driver := &memoryDriver{objects: map[string][]byte{}}
+	r := newTestObjectStorageWorkflowArchive(driver)
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("Empty", func(t *testing.T) {
+		wfs, err := r.ListWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "", "", 0, 0)
+		if assert.NoError(t, err) {
+			assert.Empty(t, wfs)
+		}
+		wf, err := r.GetWorkflow("1")
+		if assert.NoError(t, err) {
+			assert.Nil(t, wf)
+		}
+	})
+
+	assert.NoError(t, r.ArchiveWorkflow(newArchivedWorkflow("a", "1", wfv1.WorkflowSucceeded, now.Add(-time.Hour), map[string]string{"team": "payments"})))
+	failed := newArchivedWorkflow("b", "2", wfv1.WorkflowFailed, now, nil)
+	failed.Status.Nodes = wfv1.Nodes{"b": {Message: "Back-off pulling image: ImagePullBackOff"}}
+	assert.NoError(t, r.ArchiveWorkflow(failed))
+	assert.Contains(t, driver.objects, "archived-workflows/default/1/workflow.json.gz")
+	assert.Contains(t, driver.objects, "archived-workflows/default/index.json.gz")
+
+	t.Run("ListWorkflows", func(t *testing.T) {
+		wfs, err := r.ListWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "", "", 0, 0)
+		if assert.NoError(t, err) && assert.Len(t, wfs, 2) {
+			assert.Equal(t, "b", wfs[0].Name)
+			assert.Equal(t, "a", wfs[1].Name)
+			assert.Nil(t, wfs[1].Labels)
+		}
+		wfs, err = r.ListWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "", "name", 1, 1)
+		if assert.NoError(t, err) && assert.Len(t, wfs, 1) {
+			assert.Equal(t, "b", wfs[0].Name)
+		}
+		_, err = r.ListWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "", "uid", 0, 0)
+		assert.Error(t, err)
+	})
+	t.Run("ReadsIndex", func(t *testing.T) {
+		driver.opened = nil
+		wfs, err := r.ListWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "", "", 0, 0)
+		if assert.NoError(t, err) {
+			assert.Len(t, wfs, 2)
+			assert.Equal(t, []string{"archived-workflows/default/index.json.gz"}, driver.opened)
+		}
+	})
+	t.Run("SkipsUnreadable", func(t *testing.T) {
+		driver.objects["archived-workflows/default/3/workflow.json.gz"] = []byte("not json")
+		defer delete(driver.objects, "archived-workflows/default/3/workflow.json.gz")
+		wfs, err := r.ListWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "", "", 0, 0)
+		if assert.NoError(t, err) {
+			assert.Len(t, wfs, 2)
+		}
+	})
+	t.Run("CountWorkflows", func(t *testing.T) {
+		requirements, err := labels.ParseToRequirements("team=payments")
+		assert.NoError(t, err)
+		count, err := r.CountWorkflows("my-ns", "", "", time.Time{}, time.Time{}, requirements, nil, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), count)
+		}
+		count, err = r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, []wfv1.WorkflowPhase{wfv1.WorkflowFailed, wfv1.WorkflowError}, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), count)
+		}
+		count, err = r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "imagepullbackoff back-off")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), count)
+		}
+		count, err = r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "ImagePullBackOff OOMKilled")
+		if assert.NoError(t, err) {
+			assert.Zero(t, count)
+		}
+	})
+	t.Run("GetWorkflow", func(t *testing.T) {
+		wf, err := r.GetWorkflow("1")
+		if assert.NoError(t, err) && assert.NotNil(t, wf) {
+			assert.Equal(t, "a", wf.Name)
+			assert.Equal(t, "payments", wf.Labels["team"])
+		}
+	})
+	t.Run("ListWorkflowsLabelKeys", func(t *testing.T) {
+		keys, err := r.ListWorkflowsLabelKeys()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"team"}, keys.Items)
+		}
+	})
+	t.Run("DeleteExpiredWorkflows", func(t *testing.T) {
+		payments, err := labels.ParseToRequirements("team=payments")
+		assert.NoError(t, err)
+		deleted, err := r.DeleteExpiredWorkflows(30*time.Minute, nil, []labels.Requirements{payments})
+		if assert.NoError(t, err) {
+			assert.Zero(t, deleted)
+		}
+		deleted, err = r.DeleteExpiredWorkflows(30*time.Minute, nil, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), deleted)
+		}
+		count, err := r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), count)
+		}
+	})
+}
+
+func TestObjectStorageWorkflowArchiveIndex(t *testing.T) {
+	driver := &memoryDriver{objects: map[string][]byte{}}
+	r := newTestObjectStorageWorkflowArchive(driver)
+	now := time.Now().Truncate(time.Second)
+	assert.NoError(t, r.ArchiveWorkflow(newArchivedWorkflow("a", "1", wfv1.WorkflowSucceeded, now, nil)))
+	assert.NoError(t, r.ArchiveWorkflow(newArchivedWorkflow("b", "2", wfv1.WorkflowSucceeded, now, nil)))
+
+	t.Run("Missing", func(t *testing.T) {
+		delete(driver.objects, "archived-workflows/default/index.json.gz")
+		count, err := r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(2), count)
+		}
+		assert.Contains(t, driver.objects, "archived-workflows/default/index.json.gz")
+	})
+	t.Run("Stale", func(t *testing.T) {
+		delete(driver.objects, "archived-workflows/default/2/workflow.json.gz")
+		count, err := r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), count)
+		}
+	})
+	t.Run("DeleteWorkflow", func(t *testing.T) {
+		assert.NoError(t, r.DeleteWorkflow("1"))
+		count, err := r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "")
+		if assert.NoError(t, err) {
+			assert.Zero(t, count)
+		}
+	})
+}
+
+func TestObjectStorageWorkflowArchiveCompression(t *testing.T) {
+	driver := &memoryDriver{objects: map[string][]byte{}}
+	r := newTestObjectStorageWorkflowArchive(driver)
+	now := time.Now().Truncate(time.Second)
+	assert.NoError(t, r.ArchiveWorkflow(newArchivedWorkflow("a", "1", wfv1.WorkflowSucceeded, now, nil)))
+
+	assert.NoError(t, file.SetCompression(file.CompressionZstd, 0))
+	defer func() { _ = file.SetCompression(file.CompressionGZip, 0) }()
+	assert.NoError(t, r.ArchiveWorkflow(newArchivedWorkflow("b", "2", wfv1.WorkflowSucceeded, now, nil)))
+	assert.Contains(t, driver.objects, "archived-workflows/default/1/workflow.json.gz")
+	assert.Contains(t, driver.objects, "archived-workflows/default/2/workflow.json.zst")
+	assert.Contains(t, driver.objects, "archived-workflows/default/index.json.zst")
+	assert.NotContains(t, driver.objects, "archived-workflows/default/index.json.gz")
+
+	for uid, name := range map[string]string{"1": "a", "2": "b"} {
+		wf, err := r.GetWorkflow(uid)
+		if assert.NoError(t, err) && assert.NotNil(t, wf) {
+			assert.Equal(t, name, wf.Name)
+		}
+	}
+	count, err := r.CountWorkflows("", "", "", time.Time{}, time.Time{}, nil, nil, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), count)
+	}
+
+	t.Run("Rearchived", func(t *testing.T) {
+		assert.NoError(t, r.ArchiveWorkflow(newArchivedWorkflow("c", "1", wfv1.WorkflowSucceeded, now, nil)))
+		assert.NotContains(t, driver.objects, "archived-workflows/default/1/workflow.json.gz")
+		wf, err := r.GetWorkflow("1")
+		if assert.NoError(t, err) && assert.NotNil(t, wf) {
+			assert.Equal(t, "c", wf.Name)
+		}
+	})
+	t.Run("DeleteWorkflow", func(t *testing.T) {
+		assert.NoError(t, r.DeleteWorkflow("2"))
+		assert.NotContains(t, driver.objects, "archived-workflows/default/2/workflow.json.zst")
+	})
+}
@@ -0,0 +1,161 @@
+package archive
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// workflowFilter filters archived workflows in memory, in the same way as the database archive
+type workflowFilter struct {
+	namespace         string
+	name              string
+	namePrefix        string
+	minStartedAt      time.Time
+	maxStartedAt      time.Time
+	labelRequirements labels.Requirements
+	phases            []wfv1.WorkflowPhase
+	// search matches workflows whose search text contains all of its words, ignoring case
+	search string
+}
+
+// matches returns whether the workflow, with the search text (see sqldb.SearchText), matches the filter
+func (f workflowFilter) matches(wf *wfv1.Workflow, searchText string) bool {
+	if f.namespace != "" && wf.Namespace != f.namespace {
+		return false
+	}
+	if f.name != "" && wf.Name != f.name {
+		return false
+	}
+	if !strings.HasPrefix(wf.Name, f.namePrefix) {
+		return false
+	}
+	startedAt := wf.Status.StartedAt.Time
+	if !f.minStartedAt.IsZero() && !startedAt.After(f.minStartedAt) {
+		return false
+	}
+	if !f.maxStartedAt.IsZero() && !startedAt.Before(f.maxStartedAt) {
+		return false
+	}
+	for _, r := range f.labelRequirements {
+		if !r.Matches(labels.Set(wf.Labels)) {
+			return false
+		}
+	}
+	if !f.matchesSearch(searchText) {
+		return false
+	}
+	if len(f.phases) == 0 {
+		return true
+	}
+	for _, phase := range f.phases {
+		if wf.Status.Phase == phase {
+			return true
+		}
+	}
+	return false
+}
+
+func (f workflowFilter) matchesSearch(searchText string) bool {
+	words := strings.Fields(strings.ToLower(f.search))
+	if len(words) == 0 {
+		return true
+	}
+	text := strings.ToLower(searchText)
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny returns whether the workflow meets all of any of the label requirements
+func matchesAny(wf *wfv1.Workflow, requirements []labels.Requirements) bool {
+	for _, r := range requirements {
+		if len(r) > 0 && (workflowFilter{labelRequirements: r}).matches(wf, "") {
+			return true
+		}
+	}
+	return false
+}
+
+func progressFraction(wf *wfv1.Workflow) float64 {
+	progress := wf.Status.Progress
+	if !strings.Contains(string(progress), "/") || !progress.IsValid() || progress.M() == 0 {
+		return 0
+	}
+	return float64(progress.N()) / float64(progress.M())
+}
+
+// lessFunc returns the order of sortBy (see sqldb.SortByFields), with the most recently started workflows first by
+// default and to break ties
+func lessFunc(sortBy string) (func(a, b *wfv1.Workflow) bool, error) {
+	mostRecentlyStarted := func(a, b *wfv1.Workflow) bool {
+		return a.Status.StartedAt.After(b.Status.StartedAt.Time)
+	}
+	if sortBy == "" {
+		return mostRecentlyStarted, nil
+	}
+	field := strings.TrimPrefix(sortBy, "-")
+	if _, ok := sqldb.SortByFields[field]; !ok {
+		return nil, fmt.Errorf("cannot sort by %q", sortBy)
+	}
+	compare := func(a, b *wfv1.Workflow) int {
+		var x, y float64
+		switch field {
+		case "name":
+			return strings.Compare(a.Name, b.Name)
+		case "startedAt":
+			x, y = float64(a.Status.StartedAt.Unix()), float64(b.Status.StartedAt.Unix())
+		case "finishedAt":
+			x, y = float64(a.Status.FinishedAt.Unix()), float64(b.Status.FinishedAt.Unix())
+		case "duration":
+			x, y = a.Status.GetDuration().Seconds(), b.Status.GetDuration().Seconds()
+		case "progress":
+			x, y = progressFraction(a), progressFraction(b)
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	descending := strings.HasPrefix(sortBy, "-")
+	return func(a, b *wfv1.Workflow) bool {
+		c := compare(a, b)
+		if descending {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return mostRecentlyStarted(a, b)
+	}, nil
+}
+
+// listItem returns the fields of the workflow returned by listing the archive
+func listItem(wf *wfv1.Workflow) wfv1.Workflow {
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              wf.Name,
+			Namespace:         wf.Namespace,
+			UID:               wf.UID,
+			CreationTimestamp: wf.Status.StartedAt,
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase:      wf.Status.Phase,
+			StartedAt:  wf.Status.StartedAt,
+			FinishedAt: wf.Status.FinishedAt,
+			Progress:   wf.Status.Progress,
+		},
+	}
+}
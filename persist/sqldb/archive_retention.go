@@ -0,0 +1,72 @@
+package sqldb
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+const defaultRetentionRuleName = "default"
+
+// RetentionRule retains the archived workflows that meet its label requirements for its TTL, or forever if the TTL is zero
+type RetentionRule struct {
+	// Name identifies the rule in logs and metrics
+	Name              string
+	LabelRequirements labels.Requirements
+	TTL               time.Duration
+}
+
+// NewRetentionRules returns the archive retention rules configured, followed by a rule named "default" that retains
+// the remaining workflows for the archive TTL
+func NewRetentionRules(persistence config.PersistConfig) ([]RetentionRule, error) {
+	var rules []RetentionRule
+	names := map[string]bool{defaultRetentionRuleName: true}
+	for i, r := range persistence.ArchiveRetention {
+		selector, err := metav1.LabelSelectorAsSelector(r.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("archive retention rule %d has an invalid label selector: %w", i, err)
+		}
+		requirements, _ := selector.Requirements()
+		if len(requirements) == 0 {
+			return nil, fmt.Errorf("archive retention rule %d must select workflows by label, use archiveTTL for all other workflows", i)
+		}
+		name := r.Name
+		if name == "" {
+			name = selector.String()
+		}
+		if names[name] {
+			return nil, fmt.Errorf("archive retention rule %d has a duplicate name %q", i, name)
+		}
+		names[name] = true
+		rules = append(rules, RetentionRule{Name: name, LabelRequirements: requirements, TTL: time.Duration(r.TTL)})
+	}
+	return append(rules, RetentionRule{Name: defaultRetentionRuleName, TTL: time.Duration(persistence.ArchiveTTL)}), nil
+}
+
+// PruneArchive deletes the expired archived workflows. Each workflow is retained by the first rule whose label
+// requirements it meets, so a rule without requirements retains every workflow not retained by an earlier rule.
+// It returns the number of workflows deleted by each rule.
+func PruneArchive(archive WorkflowArchive, rules []RetentionRule) (map[string]int64, error) {
+	deleted := map[string]int64{}
+	var earlier []labels.Requirements
+	for _, rule := range rules {
+		if rule.TTL > 0 {
+			rowsDeleted, err := archive.DeleteExpiredWorkflows(rule.TTL, rule.LabelRequirements, earlier)
+			if err != nil {
+				return deleted, fmt.Errorf("failed to prune archived workflows by retention rule %q: %w", rule.Name, err)
+			}
+			log.WithFields(log.Fields{"rule": rule.Name, "ttl": rule.TTL, "rowsDeleted": rowsDeleted}).Info("Pruned archived workflows")
+			deleted[rule.Name] = rowsDeleted
+		}
+		if len(rule.LabelRequirements) == 0 {
+			break
+		}
+		earlier = append(earlier, rule.LabelRequirements)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,80 @@
+package sqldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+func TestNewRetentionRules(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		rules, err := NewRetentionRules(config.PersistConfig{ArchiveTTL: config.TTL(time.Hour)})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []RetentionRule{{Name: "default", TTL: time.Hour}}, rules)
+		}
+	})
+	t.Run("Rules", func(t *testing.T) {
+		rules, err := NewRetentionRules(config.PersistConfig{ArchiveRetention: []config.ArchiveRetentionRule{
+			{Name: "payments", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}, TTL: config.TTL(2 * time.Hour)},
+			{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"keep": "true"}}},
+		}})
+		if assert.NoError(t, err) && assert.Len(t, rules, 3) {
+			assert.Equal(t, "payments", rules[0].Name)
+			assert.Equal(t, labels.Requirements(requirements("team=payments")), rules[0].LabelRequirements)
+			assert.Equal(t, 2*time.Hour, rules[0].TTL)
+			assert.Equal(t, "keep=true", rules[1].Name)
+			assert.Zero(t, rules[1].TTL)
+			assert.Equal(t, "default", rules[2].Name)
+		}
+	})
+	t.Run("NoLabelSelector", func(t *testing.T) {
+		_, err := NewRetentionRules(config.PersistConfig{ArchiveRetention: []config.ArchiveRetentionRule{{TTL: config.TTL(time.Hour)}}})
+		assert.EqualError(t, err, "archive retention rule 0 must select workflows by label, use archiveTTL for all other workflows")
+	})
+	t.Run("DuplicateName", func(t *testing.T) {
+		_, err := NewRetentionRules(config.PersistConfig{ArchiveRetention: []config.ArchiveRetentionRule{
+			{Name: "default", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}},
+		}})
+		assert.EqualError(t, err, `archive retention rule 0 has a duplicate name "default"`)
+	})
+}
+
+type deleteExpiredWorkflowsCall struct {
+	ttl               time.Duration
+	labelRequirements labels.Requirements
+	excluding         []labels.Requirements
+}
+
+type expiringWorkflowArchive struct {
+	WorkflowArchive
+	calls []deleteExpiredWorkflowsCall
+}
+
+func (r *expiringWorkflowArchive) DeleteExpiredWorkflows(ttl time.Duration, labelRequirements labels.Requirements, excluding []labels.Requirements) (int64, error) {
+	r.calls = append(r.calls, deleteExpiredWorkflowsCall{ttl, labelRequirements, excluding})
+	return int64(len(r.calls)), nil
+}
+
+func TestPruneArchive(t *testing.T) {
+	archive := &expiringWorkflowArchive{WorkflowArchive: NullWorkflowArchive}
+	payments := labels.Requirements(requirements("team=payments"))
+	keep := labels.Requirements(requirements("keep=true"))
+	deleted, err := PruneArchive(archive, []RetentionRule{
+		{Name: "payments", LabelRequirements: payments, TTL: 2 * time.Hour},
+		{Name: "keep", LabelRequirements: keep},
+		{Name: "default", TTL: time.Hour},
+		{Name: "unreachable", LabelRequirements: keep, TTL: time.Minute},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]int64{"payments": 1, "default": 2}, deleted)
+		assert.Equal(t, []deleteExpiredWorkflowsCall{
+			{2 * time.Hour, payments, nil},
+			{time.Hour, nil, []labels.Requirements{payments, keep}},
+		}, archive.calls)
+	}
+}
@@ -0,0 +1,102 @@
+package sqldb
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"upper.io/db.v3"
+	"upper.io/db.v3/lib/sqlbuilder"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// SearchText returns the text archived workflows are searched by: the parameters of the workflow's arguments, its
+// annotations, and the messages of the workflow and its nodes
+func SearchText(wf *wfv1.Workflow) string {
+	var lines []string
+	for _, p := range wf.Spec.Arguments.Parameters {
+		lines = append(lines, p.Name+"="+p.GetValue())
+	}
+	var annotations []string
+	for key, value := range wf.Annotations {
+		annotations = append(annotations, key+"="+value)
+	}
+	sort.Strings(annotations)
+	lines = append(lines, annotations...)
+	messages := map[string]bool{wf.Status.Message: true}
+	for _, node := range wf.Status.Nodes {
+		messages[node.Message] = true
+	}
+	delete(messages, "")
+	var sortedMessages []string
+	for message := range messages {
+		sortedMessages = append(sortedMessages, message)
+	}
+	sort.Strings(sortedMessages)
+	return strings.Join(append(lines, sortedMessages...), "\n")
+}
+
+// searchClause matches the archived workflows whose search text contains all of the words searched for
+func searchClause(t dbType, search string) db.Compound {
+	words := strings.Fields(search)
+	if len(words) == 0 {
+		return db.Cond{}
+	}
+	if t == MySQL {
+		// in boolean mode, "+" requires each word, which is quoted so that any operators in it are ignored
+		var terms []string
+		for _, word := range words {
+			terms = append(terms, `+"`+strings.ReplaceAll(word, `"`, "")+`"`)
+		}
+		return db.Raw("match(searchtext) against (? in boolean mode)", strings.Join(terms, " "))
+	}
+	return db.Raw("to_tsvector('simple', coalesce(searchtext, '')) @@ plainto_tsquery('simple', ?)", strings.Join(words, " "))
+}
+
+// backfillSearchText sets the search text of the workflows archived before it was added
+type backfillSearchText struct{}
+
+func (s backfillSearchText) String() string {
+	return "backfillSearchText{}"
+}
+
+func (s backfillSearchText) apply(session sqlbuilder.Database) (err error) {
+	log.Info("Backfill archived workflow search text")
+	rs, err := session.SelectFrom(archiveTableName).
+		Columns("uid", "workflow").
+		Where(db.Cond{"searchtext": nil}).
+		Query()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tmpErr := rs.Close()
+		if err == nil {
+			err = tmpErr
+		}
+	}()
+	for rs.Next() {
+		if err := rs.Err(); err != nil {
+			return err
+		}
+		var uid, workflow string
+		err := rs.Scan(&uid, &workflow)
+		if err != nil {
+			return err
+		}
+		wf, err := unmarshalArchivedWorkflow(workflow)
+		if err != nil {
+			return err
+		}
+		log.WithField("uid", uid).Debug("Back-filling search text")
+		_, err = session.Update(archiveTableName).
+			Set("searchtext", SearchText(wf)).
+			Where(db.Cond{"uid": uid}).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package sqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"upper.io/db.v3"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestSearchText(t *testing.T) {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"b": "2", "a": "1"}},
+		Spec:       wfv1.WorkflowSpec{Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "image", Value: wfv1.AnyStringPtr("nope:latest")}}}},
+		Status: wfv1.WorkflowStatus{
+			Message: "child 'pod' failed",
+			Nodes: wfv1.Nodes{
+				"wf":    {},
+				"pod":   {Message: "ImagePullBackOff"},
+				"retry": {Message: "child 'pod' failed"},
+			},
+		},
+	}
+	assert.Equal(t, "image=nope:latest\na=1\nb=2\nImagePullBackOff\nchild 'pod' failed", SearchText(wf))
+}
+
+func Test_searchClause(t *testing.T) {
+	assert.Equal(t, db.Cond{}, searchClause(Postgres, " "))
+	assert.Equal(t, db.Raw("to_tsvector('simple', coalesce(searchtext, '')) @@ plainto_tsquery('simple', ?)", "ImagePullBackOff last"), searchClause(Postgres, " ImagePullBackOff  last"))
+	assert.Equal(t, db.Raw("match(searchtext) against (? in boolean mode)", `+"ImagePullBackOff" +"-x"`), searchClause(MySQL, `ImagePullBackOff "-x"`))
+}
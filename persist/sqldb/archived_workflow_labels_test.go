@@ -45,3 +45,14 @@ func requirements(selector string) []labels.Requirement {
 	}
 	return requirements
 }
+
+func Test_notLabelsClause(t *testing.T) {
+	got, err := notLabelsClause(Postgres, requirements("foo,!bar"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, db.Raw("not (not exists (select 1 from argo_archived_workflows_labels where clustername = argo_archived_workflows.clustername and uid = argo_archived_workflows.uid and name = 'bar') and exists (select 1 from argo_archived_workflows_labels where clustername = argo_archived_workflows.clustername and uid = argo_archived_workflows.uid and name = 'foo'))"), got)
+	}
+	got, err = notLabelsClause(Postgres, requirements(""))
+	if assert.NoError(t, err) {
+		assert.Equal(t, db.Cond{}, got)
+	}
+}
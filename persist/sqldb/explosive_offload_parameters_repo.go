@@ -0,0 +1,32 @@
+package sqldb
+
+import (
+	"fmt"
+)
+
+var (
+	ExplosiveOffloadParametersRepo     OffloadParametersRepo = &explosiveOffloadParametersRepo{}
+	OffloadParametersNotSupportedError                       = fmt.Errorf("offloading parameters to the database is not supported")
+)
+
+type explosiveOffloadParametersRepo struct{}
+
+func (n *explosiveOffloadParametersRepo) IsEnabled() bool {
+	return false
+}
+
+func (n *explosiveOffloadParametersRepo) Save(string, string, string, map[string]string) error {
+	return OffloadParametersNotSupportedError
+}
+
+func (n *explosiveOffloadParametersRepo) Get(string, string) (map[string]string, error) {
+	return nil, OffloadParametersNotSupportedError
+}
+
+func (n *explosiveOffloadParametersRepo) ListOldOffloads(string) ([]string, error) {
+	return nil, OffloadParametersNotSupportedError
+}
+
+func (n *explosiveOffloadParametersRepo) Delete(string) error {
+	return OffloadParametersNotSupportedError
+}
@@ -258,6 +258,89 @@ func (m migrate) Exec(ctx context.Context) (err error) {
 		// add indexes for list archived workflow performance. #8836
 		ansiSQLChange(`create index argo_archived_workflows_i4 on argo_archived_workflows (startedat)`),
 		ansiSQLChange(`create index argo_archived_workflows_labels_i1 on argo_archived_workflows_labels (name,value)`),
+		// add columns and indexes to sort archived workflows by duration and progress, and filter them by phase
+		ansiSQLChange(`alter table argo_archived_workflows add column duration bigint not null default 0`),
+		ansiSQLChange(`alter table argo_archived_workflows add column progresscompleted bigint not null default 0`),
+		ansiSQLChange(`alter table argo_archived_workflows add column progresstotal bigint not null default 0`),
+		ternary(dbType == MySQL,
+			ansiSQLChange(`update argo_archived_workflows set duration = greatest(timestampdiff(second, startedat, finishedat), 0)`),
+			ansiSQLChange(`update argo_archived_workflows set duration = greatest(extract(epoch from finishedat - startedat), 0)`),
+		),
+		ternary(dbType == MySQL,
+			ansiSQLChange(`update argo_archived_workflows set progresscompleted = cast(substring_index(workflow->>'$.status.progress', '/', 1) as signed), progresstotal = cast(substring_index(workflow->>'$.status.progress', '/', -1) as signed) where workflow->>'$.status.progress' like '%/%'`),
+			ansiSQLChange(`update argo_archived_workflows set progresscompleted = cast(split_part(workflow->'status'->>'progress', '/', 1) as bigint), progresstotal = cast(split_part(workflow->'status'->>'progress', '/', 2) as bigint) where workflow->'status'->>'progress' like '%/%'`),
+		),
+		ansiSQLChange(`create index argo_archived_workflows_i5 on argo_archived_workflows (clustername,instanceid,duration)`),
+		ansiSQLChange(`create index argo_archived_workflows_i6 on argo_archived_workflows (clustername,instanceid,phase,startedat)`),
+		// tables to back semaphores with the database, the row of a lock in argo_sync_limits is locked to serialize
+		// acquiring it across controllers
+		ansiSQLChange(`create table if not exists argo_sync_limits (
+    clustername varchar(64) not null,
+    name varchar(256) not null,
+    sizelimit int not null,
+    primary key (clustername, name)
+)`),
+		ansiSQLChange(`create table if not exists argo_sync_holders (
+    clustername varchar(64) not null,
+    name varchar(256) not null,
+    holder varchar(384) not null,
+    weight int not null,
+    controller varchar(256) not null,
+    heartbeat timestamp not null default current_timestamp,
+    primary key (clustername, name, holder)
+)`),
+		ansiSQLChange(`create index argo_sync_holders_i1 on argo_sync_holders (clustername,controller)`),
+		// table to store memoization caches in the database, rather than in config maps limited to 1MB
+		ternary(dbType == MySQL,
+			ansiSQLChange(`create table if not exists argo_memoization_cache (
+    clustername varchar(64) not null,
+    namespace varchar(64) not null,
+    name varchar(256) not null,
+    cachekey varchar(256) not null,
+    entry longtext not null,
+    primary key (clustername, namespace, name, cachekey)
+)`),
+			ansiSQLChange(`create table if not exists argo_memoization_cache (
+    clustername varchar(64) not null,
+    namespace varchar(64) not null,
+    name varchar(256) not null,
+    cachekey varchar(256) not null,
+    entry text not null,
+    primary key (clustername, namespace, name, cachekey)
+)`),
+		),
+		// add a column and index to search archived workflows by their arguments, annotations and node messages
+		ternary(dbType == MySQL,
+			ansiSQLChange(`alter table argo_archived_workflows add column searchtext longtext`),
+			ansiSQLChange(`alter table argo_archived_workflows add column searchtext text`),
+		),
+		backfillSearchText{},
+		ternary(dbType == MySQL,
+			ansiSQLChange(`create fulltext index argo_archived_workflows_i7 on argo_archived_workflows (searchtext)`),
+			ansiSQLChange(`create index argo_archived_workflows_i7 on argo_archived_workflows using gin (to_tsvector('simple', coalesce(searchtext, '')))`),
+		),
+		// table to store the values of the parameters of nodes that are too large for a config map
+		ternary(dbType == MySQL,
+			ansiSQLChange(`create table if not exists argo_offloaded_parameters (
+    clustername varchar(64) not null,
+    uid varchar(128) not null,
+    nodeid varchar(256) not null,
+    namespace varchar(256) not null,
+    parameters longtext not null,
+    updatedat timestamp not null default current_timestamp,
+    primary key (clustername, uid, nodeid)
+)`),
+			ansiSQLChange(`create table if not exists argo_offloaded_parameters (
+    clustername varchar(64) not null,
+    uid varchar(128) not null,
+    nodeid varchar(256) not null,
+    namespace varchar(256) not null,
+    parameters text not null,
+    updatedat timestamp not null default current_timestamp,
+    primary key (clustername, uid, nodeid)
+)`),
+		),
+		ansiSQLChange(`create index argo_offloaded_parameters_i1 on argo_offloaded_parameters (clustername,namespace,updatedat)`),
 	} {
 		err := m.applyChange(ctx, changeSchemaVersion, change)
 		if err != nil {
@@ -30,20 +30,20 @@ func (_m *WorkflowArchive) ArchiveWorkflow(wf *v1alpha1.Workflow) error {
 	return r0
 }
 
-// CountWorkflows provides a mock function with given fields: namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements
-func (_m *WorkflowArchive) CountWorkflows(namespace string, name string, namePrefix string, minStartAt time.Time, maxStartAt time.Time, labelRequirements labels.Requirements) (int64, error) {
-	ret := _m.Called(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements)
+// CountWorkflows provides a mock function with given fields: namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search
+func (_m *WorkflowArchive) CountWorkflows(namespace string, name string, namePrefix string, minStartAt time.Time, maxStartAt time.Time, labelRequirements labels.Requirements, phases []v1alpha1.WorkflowPhase, search string) (int64, error) {
+	ret := _m.Called(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search)
 
 	var r0 int64
-	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, time.Time, labels.Requirements) int64); ok {
-		r0 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements)
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, time.Time, labels.Requirements, []v1alpha1.WorkflowPhase, string) int64); ok {
+		r0 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, time.Time, time.Time, labels.Requirements) error); ok {
-		r1 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements)
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Time, time.Time, labels.Requirements, []v1alpha1.WorkflowPhase, string) error); ok {
+		r1 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,18 +51,25 @@ func (_m *WorkflowArchive) CountWorkflows(namespace string, name string, namePre
 	return r0, r1
 }
 
-// DeleteExpiredWorkflows provides a mock function with given fields: ttl
-func (_m *WorkflowArchive) DeleteExpiredWorkflows(ttl time.Duration) error {
-	ret := _m.Called(ttl)
+// DeleteExpiredWorkflows provides a mock function with given fields: ttl, labelRequirements, excluding
+func (_m *WorkflowArchive) DeleteExpiredWorkflows(ttl time.Duration, labelRequirements labels.Requirements, excluding []labels.Requirements) (int64, error) {
+	ret := _m.Called(ttl, labelRequirements, excluding)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(time.Duration) error); ok {
-		r0 = rf(ttl)
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Duration, labels.Requirements, []labels.Requirements) int64); ok {
+		r0 = rf(ttl, labelRequirements, excluding)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int64)
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Duration, labels.Requirements, []labels.Requirements) error); ok {
+		r1 = rf(ttl, labelRequirements, excluding)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DeleteWorkflow provides a mock function with given fields: uid
@@ -116,13 +123,13 @@ func (_m *WorkflowArchive) IsEnabled() bool {
 	return r0
 }
 
-// ListWorkflows provides a mock function with given fields: namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, limit, offset
-func (_m *WorkflowArchive) ListWorkflows(namespace string, name string, namePrefix string, minStartAt time.Time, maxStartAt time.Time, labelRequirements labels.Requirements, limit int, offset int) (v1alpha1.Workflows, error) {
-	ret := _m.Called(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, limit, offset)
+// ListWorkflows provides a mock function with given fields: namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search, sortBy, limit, offset
+func (_m *WorkflowArchive) ListWorkflows(namespace string, name string, namePrefix string, minStartAt time.Time, maxStartAt time.Time, labelRequirements labels.Requirements, phases []v1alpha1.WorkflowPhase, search string, sortBy string, limit int, offset int) (v1alpha1.Workflows, error) {
+	ret := _m.Called(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search, sortBy, limit, offset)
 
 	var r0 v1alpha1.Workflows
-	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, time.Time, labels.Requirements, int, int) v1alpha1.Workflows); ok {
-		r0 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, limit, offset)
+	if rf, ok := ret.Get(0).(func(string, string, string, time.Time, time.Time, labels.Requirements, []v1alpha1.WorkflowPhase, string, string, int, int) v1alpha1.Workflows); ok {
+		r0 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search, sortBy, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(v1alpha1.Workflows)
@@ -130,8 +137,8 @@ func (_m *WorkflowArchive) ListWorkflows(namespace string, name string, namePref
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string, time.Time, time.Time, labels.Requirements, int, int) error); ok {
-		r1 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, limit, offset)
+	if rf, ok := ret.Get(1).(func(string, string, string, time.Time, time.Time, labels.Requirements, []v1alpha1.WorkflowPhase, string, string, int, int) error); ok {
+		r1 = rf(namespace, name, namePrefix, minStartAt, maxStartAt, labelRequirements, phases, search, sortBy, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
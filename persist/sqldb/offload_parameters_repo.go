@@ -0,0 +1,135 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"upper.io/db.v3"
+	"upper.io/db.v3/lib/sqlbuilder"
+
+	"github.com/argoproj/argo-workflows/v3/util/env"
+)
+
+const offloadedParametersTableName = "argo_offloaded_parameters"
+
+// OffloadParametersRepo stores the values of the parameters, and result, of the nodes of workflows that are too large
+// for a ConfigMap
+type OffloadParametersRepo interface {
+	// Save replaces the values of the node, by their key
+	Save(uid, namespace, nodeID string, values map[string]string) error
+	Get(uid, nodeID string) (map[string]string, error)
+	// ListOldOffloads returns the UIDs of the workflows whose values have not been saved within the TTL
+	ListOldOffloads(namespace string) ([]string, error)
+	// Delete deletes the values of all the nodes of the workflow
+	Delete(uid string) error
+	IsEnabled() bool
+}
+
+type parametersRecord struct {
+	ClusterName string `db:"clustername"`
+	UID         string `db:"uid"`
+	NodeID      string `db:"nodeid"`
+	Namespace   string `db:"namespace"`
+	Parameters  string `db:"parameters"`
+}
+
+type offloadParametersRepo struct {
+	session     sqlbuilder.Database
+	clusterName string
+	// time to live - at what ttl the values of a workflow that is not live may be deleted
+	ttl time.Duration
+}
+
+func NewOffloadParametersRepo(session sqlbuilder.Database, clusterName string) OffloadParametersRepo {
+	ttl := env.LookupEnvDurationOr("OFFLOAD_NODE_STATUS_TTL", 5*time.Minute)
+	return &offloadParametersRepo{session: session, clusterName: clusterName, ttl: ttl}
+}
+
+func (r *offloadParametersRepo) IsEnabled() bool {
+	return true
+}
+
+func (r *offloadParametersRepo) cond(uid, nodeID string) db.Cond {
+	return db.Cond{"clustername": r.clusterName, "uid": uid, "nodeid": nodeID}
+}
+
+func (r *offloadParametersRepo) Save(uid, namespace, nodeID string, values map[string]string) error {
+	marshalled, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"uid": uid, "nodeID": nodeID}).Debug("Offloading parameters")
+	return r.session.Tx(context.Background(), func(tx sqlbuilder.Tx) error {
+		if _, err := tx.DeleteFrom(offloadedParametersTableName).Where(r.cond(uid, nodeID)).Exec(); err != nil {
+			return err
+		}
+		_, err := tx.InsertInto(offloadedParametersTableName).
+			Values(&parametersRecord{
+				ClusterName: r.clusterName,
+				UID:         uid,
+				NodeID:      nodeID,
+				Namespace:   namespace,
+				Parameters:  string(marshalled),
+			}).
+			Exec()
+		return err
+	})
+}
+
+func (r *offloadParametersRepo) Get(uid, nodeID string) (map[string]string, error) {
+	record := &parametersRecord{}
+	err := r.session.
+		SelectFrom(offloadedParametersTableName).
+		Where(r.cond(uid, nodeID)).
+		One(record)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal([]byte(record.Parameters), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (r *offloadParametersRepo) ListOldOffloads(namespace string) ([]string, error) {
+	var records []parametersRecord
+	err := r.session.
+		Select("uid").
+		From(offloadedParametersTableName).
+		Where(db.Cond{"clustername": r.clusterName}).
+		And(namespaceEqual(namespace)).
+		And(fmt.Sprintf("updatedat < current_timestamp - interval '%d' second", int(r.ttl.Seconds()))).
+		GroupBy("uid").
+		All(&records)
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]string, len(records))
+	for i, record := range records {
+		uids[i] = record.UID
+	}
+	return uids, nil
+}
+
+func (r *offloadParametersRepo) Delete(uid string) error {
+	if uid == "" {
+		return fmt.Errorf("invalid uid")
+	}
+	rs, err := r.session.
+		DeleteFrom(offloadedParametersTableName).
+		Where(db.Cond{"clustername": r.clusterName, "uid": uid}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := rs.RowsAffected()
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"uid": uid, "rowsAffected": rowsAffected}).Debug("Deleted offloaded parameters")
+	return nil
+}
@@ -0,0 +1,178 @@
+package sqldb
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"upper.io/db.v3"
+	"upper.io/db.v3/lib/sqlbuilder"
+)
+
+const (
+	syncLimitsTableName  = "argo_sync_limits"
+	syncHoldersTableName = "argo_sync_holders"
+)
+
+// SyncLockRepo stores the holders of semaphores in the database, so that their limits are honored across controller
+// restarts and across multiple controllers sharing the database.
+type SyncLockRepo interface {
+	// Acquire acquires weight units of the lock for the holder, if they are available within the limit.
+	// It is idempotent, acquiring a lock already held by the holder renews it.
+	Acquire(lockName, holderKey string, weight int64, limit int) (bool, error)
+	Release(lockName, holderKey string) error
+	// Heartbeat renews the holders of this controller, and releases those of controllers that are inactive.
+	Heartbeat() error
+}
+
+type syncLimitRecord struct {
+	ClusterName string `db:"clustername"`
+	Name        string `db:"name"`
+	SizeLimit   int    `db:"sizelimit"`
+}
+
+type syncHolderRecord struct {
+	ClusterName string    `db:"clustername"`
+	Name        string    `db:"name"`
+	Holder      string    `db:"holder"`
+	Weight      int64     `db:"weight"`
+	Controller  string    `db:"controller"`
+	Heartbeat   time.Time `db:"heartbeat"`
+}
+
+type syncLockRepo struct {
+	session     sqlbuilder.Database
+	dbType      dbType
+	clusterName string
+	// controllerName identifies the holders of this controller
+	controllerName string
+	// inactiveTTL is how long a controller may stop heart-beating before its holders are released
+	inactiveTTL time.Duration
+}
+
+func NewSyncLockRepo(session sqlbuilder.Database, clusterName, controllerName string, inactiveTTL time.Duration) SyncLockRepo {
+	return &syncLockRepo{session: session, dbType: dbTypeFor(session), clusterName: clusterName, controllerName: controllerName, inactiveTTL: inactiveTTL}
+}
+
+func (r *syncLockRepo) Acquire(lockName, holderKey string, weight int64, limit int) (bool, error) {
+	logCtx := log.WithFields(log.Fields{"lockName": lockName, "holderKey": holderKey, "weight": weight, "limit": limit})
+	tx, err := r.session.NewTx(context.Background())
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// upserting the limit locks its row until the transaction ends, so only one controller at a time counts the
+	// holders of the lock
+	if err := r.lockLimit(tx, lockName, limit); err != nil {
+		return false, err
+	}
+	now := time.Now().UTC()
+	_, err = tx.
+		DeleteFrom(syncHoldersTableName).
+		Where(r.lockCond(lockName)).
+		And(db.Cond{"heartbeat <": now.Add(-r.inactiveTTL)}).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+	var holders []syncHolderRecord
+	err = tx.
+		SelectFrom(syncHoldersTableName).
+		Where(r.lockCond(lockName)).
+		All(&holders)
+	if err != nil {
+		return false, err
+	}
+	var used int64
+	for _, holder := range holders {
+		if holder.Holder == holderKey {
+			_, err := tx.
+				Update(syncHoldersTableName).
+				Set("weight", weight, "controller", r.controllerName, "heartbeat", now).
+				Where(r.lockCond(lockName)).
+				And(db.Cond{"holder": holderKey}).
+				Exec()
+			if err != nil {
+				return false, err
+			}
+			logCtx.Debug("Lock is already held")
+			return true, tx.Commit()
+		}
+		used += holder.Weight
+	}
+	if used+weight > int64(limit) {
+		logCtx.WithField("used", used).Debug("Lock is not available")
+		return false, nil
+	}
+	_, err = tx.
+		InsertInto(syncHoldersTableName).
+		Values(&syncHolderRecord{
+			ClusterName: r.clusterName,
+			Name:        lockName,
+			Holder:      holderKey,
+			Weight:      weight,
+			Controller:  r.controllerName,
+			Heartbeat:   now,
+		}).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+	logCtx.WithField("used", used+weight).Debug("Lock acquired")
+	return true, tx.Commit()
+}
+
+func (r *syncLockRepo) lockLimit(tx sqlbuilder.Tx, lockName string, limit int) error {
+	// the row is upserted in one statement, as MySQL reports no affected rows when it is updated to the same limit
+	query := "insert into " + syncLimitsTableName + " (clustername, name, sizelimit) values (?, ?, ?) "
+	if r.dbType == MySQL {
+		query += "on duplicate key update sizelimit = values(sizelimit)"
+	} else {
+		query += "on conflict (clustername, name) do update set sizelimit = excluded.sizelimit"
+	}
+	_, err := tx.Exec(query, r.clusterName, lockName, limit)
+	return err
+}
+
+func (r *syncLockRepo) Release(lockName, holderKey string) error {
+	_, err := r.session.
+		DeleteFrom(syncHoldersTableName).
+		Where(r.lockCond(lockName)).
+		And(db.Cond{"holder": holderKey}).
+		Exec()
+	return err
+}
+
+func (r *syncLockRepo) Heartbeat() error {
+	now := time.Now().UTC()
+	_, err := r.session.
+		Update(syncHoldersTableName).
+		Set("heartbeat", now).
+		Where(db.Cond{"clustername": r.clusterName}).
+		And(db.Cond{"controller": r.controllerName}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	rs, err := r.session.
+		DeleteFrom(syncHoldersTableName).
+		Where(db.Cond{"clustername": r.clusterName}).
+		And(db.Cond{"heartbeat <": now.Add(-r.inactiveTTL)}).
+		Exec()
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := rs.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected > 0 {
+		log.WithField("rowsAffected", rowsAffected).Info("Released the locks held by inactive controllers")
+	}
+	return nil
+}
+
+func (r *syncLockRepo) lockCond(lockName string) db.Cond {
+	return db.Cond{"clustername": r.clusterName, "name": lockName}
+}
@@ -0,0 +1,45 @@
+package sqldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"upper.io/db.v3/mysql"
+)
+
+func TestSyncLockRepoMySQL(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer sqlDB.Close()
+	mock.ExpectQuery("DATABASE\\(\\) AS name").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("argo"))
+	session, err := mysql.New(sqlDB)
+	require.NoError(t, err)
+	repo := &syncLockRepo{session: session, dbType: MySQL, clusterName: "default", controllerName: "my-controller", inactiveTTL: time.Minute}
+
+	// MySQL reports that no rows were affected when the limit is upserted to the same value
+	for i, rowsAffected := range []int64{1, 0} {
+		mock.ExpectBegin()
+		mock.ExpectExec("insert into argo_sync_limits \\(clustername, name, sizelimit\\) values \\(\\?, \\?, \\?\\) on duplicate key update sizelimit = values\\(sizelimit\\)").
+			WithArgs("default", "my-lock", 2).
+			WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+		mock.ExpectExec("DELETE FROM `argo_sync_holders`").WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"clustername", "name", "holder", "weight", "controller", "heartbeat"})
+		if i > 0 {
+			rows.AddRow("default", "my-lock", "my-holder-0", 1, "my-controller", time.Now())
+		}
+		mock.ExpectQuery("SELECT \\* FROM `argo_sync_holders`").WillReturnRows(rows)
+		mock.ExpectExec("INSERT INTO `argo_sync_holders`").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	for _, holderKey := range []string{"my-holder-0", "my-holder-1"} {
+		acquired, err := repo.Acquire("my-lock", holderKey, 1, 2)
+		if assert.NoError(t, err) {
+			assert.True(t, acquired)
+		}
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -14,6 +15,7 @@ import (
 	"upper.io/db.v3/lib/sqlbuilder"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 )
 
@@ -31,11 +33,16 @@ type archivedWorkflowMetadata struct {
 	Phase       wfv1.WorkflowPhase `db:"phase"`
 	StartedAt   time.Time          `db:"startedat"`
 	FinishedAt  time.Time          `db:"finishedat"`
+	// Duration in seconds
+	Duration          int64 `db:"duration"`
+	ProgressCompleted int64 `db:"progresscompleted"`
+	ProgressTotal     int64 `db:"progresstotal"`
 }
 
 type archivedWorkflowRecord struct {
 	archivedWorkflowMetadata
-	Workflow string `db:"workflow"`
+	Workflow   string `db:"workflow"`
+	SearchText string `db:"searchtext"`
 }
 
 type archivedWorkflowLabelRecord struct {
@@ -54,12 +61,16 @@ type archivedWorkflowCount struct {
 
 type WorkflowArchive interface {
 	ArchiveWorkflow(wf *wfv1.Workflow) error
-	// list workflows, with the most recently started workflows at the beginning (i.e. index 0 is the most recent)
-	ListWorkflows(namespace string, name string, namePrefix string, minStartAt, maxStartAt time.Time, labelRequirements labels.Requirements, limit, offset int) (wfv1.Workflows, error)
-	CountWorkflows(namespace string, name string, namePrefix string, minStartAt, maxStartAt time.Time, labelRequirements labels.Requirements) (int64, error)
+	// list workflows in any of the phases (or all phases if none), whose search text (see SearchText) contains all of
+	// the words searched for, ordered by sortBy (see SortByFields), which is by default with the most recently started
+	// workflows at the beginning (i.e. index 0 is the most recent)
+	ListWorkflows(namespace string, name string, namePrefix string, minStartAt, maxStartAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string, sortBy string, limit, offset int) (wfv1.Workflows, error)
+	CountWorkflows(namespace string, name string, namePrefix string, minStartAt, maxStartAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string) (int64, error)
 	GetWorkflow(uid string) (*wfv1.Workflow, error)
 	DeleteWorkflow(uid string) error
-	DeleteExpiredWorkflows(ttl time.Duration) error
+	// delete workflows that finished more than ttl ago and meet the label requirements, but not all of any of the
+	// (non-empty) excluded requirements, returning the number deleted
+	DeleteExpiredWorkflows(ttl time.Duration, labelRequirements labels.Requirements, excluding []labels.Requirements) (int64, error)
 	IsEnabled() bool
 	ListWorkflowsLabelKeys() (*wfv1.LabelKeys, error)
 	ListWorkflowsLabelValues(key string) (*wfv1.LabelValues, error)
@@ -85,10 +96,14 @@ func NewWorkflowArchive(session sqlbuilder.Database, clusterName, managedNamespa
 func (r *workflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
 	logCtx := log.WithFields(log.Fields{"uid": wf.UID, "labels": wf.GetLabels()})
 	logCtx.Debug("Archiving workflow")
-	workflow, err := json.Marshal(wf)
+	workflow, err := marshalArchivedWorkflow(wf)
 	if err != nil {
 		return err
 	}
+	var progressCompleted, progressTotal int64
+	if progress := wf.Status.Progress; strings.Contains(string(progress), "/") && progress.IsValid() {
+		progressCompleted, progressTotal = progress.N(), progress.M()
+	}
 	return r.session.Tx(context.Background(), func(sess sqlbuilder.Tx) error {
 		_, err := sess.
 			DeleteFrom(archiveTableName).
@@ -101,16 +116,20 @@ func (r *workflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
 		_, err = sess.Collection(archiveTableName).
 			Insert(&archivedWorkflowRecord{
 				archivedWorkflowMetadata: archivedWorkflowMetadata{
-					ClusterName: r.clusterName,
-					InstanceID:  r.instanceIDService.InstanceID(),
-					UID:         string(wf.UID),
-					Name:        wf.Name,
-					Namespace:   wf.Namespace,
-					Phase:       wf.Status.Phase,
-					StartedAt:   wf.Status.StartedAt.Time,
-					FinishedAt:  wf.Status.FinishedAt.Time,
+					ClusterName:       r.clusterName,
+					InstanceID:        r.instanceIDService.InstanceID(),
+					UID:               string(wf.UID),
+					Name:              wf.Name,
+					Namespace:         wf.Namespace,
+					Phase:             wf.Status.Phase,
+					StartedAt:         wf.Status.StartedAt.Time,
+					FinishedAt:        wf.Status.FinishedAt.Time,
+					Duration:          int64(wf.Status.GetDuration().Seconds()),
+					ProgressCompleted: progressCompleted,
+					ProgressTotal:     progressTotal,
 				},
-				Workflow: string(workflow),
+				Workflow:   string(workflow),
+				SearchText: SearchText(wf),
 			})
 		if err != nil {
 			return err
@@ -141,12 +160,16 @@ func (r *workflowArchive) ArchiveWorkflow(wf *wfv1.Workflow) error {
 	})
 }
 
-func (r *workflowArchive) ListWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, limit int, offset int) (wfv1.Workflows, error) {
+func (r *workflowArchive) ListWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string, sortBy string, limit int, offset int) (wfv1.Workflows, error) {
 	var archivedWfs []archivedWorkflowMetadata
 	clause, err := labelsClause(r.dbType, labelRequirements)
 	if err != nil {
 		return nil, err
 	}
+	orderBy, err := orderByClause(sortBy)
+	if err != nil {
+		return nil, err
+	}
 
 	// If we were passed 0 as the limit, then we should load all available archived workflows
 	// to match the behavior of the `List` operations in the Kubernetes API
@@ -156,15 +179,17 @@ func (r *workflowArchive) ListWorkflows(namespace string, name string, namePrefi
 	}
 
 	err = r.session.
-		Select("name", "namespace", "uid", "phase", "startedat", "finishedat").
+		Select("name", "namespace", "uid", "phase", "startedat", "finishedat", "progresscompleted", "progresstotal").
 		From(archiveTableName).
 		Where(r.clusterManagedNamespaceAndInstanceID()).
 		And(namespaceEqual(namespace)).
 		And(nameEqual(name)).
 		And(namePrefixClause(namePrefix)).
 		And(startedAtClause(minStartedAt, maxStartedAt)).
+		And(phasesClause(phases)).
+		And(searchClause(r.dbType, search)).
 		And(clause).
-		OrderBy("-startedat").
+		OrderBy(orderBy...).
 		Limit(limit).
 		Offset(offset).
 		All(&archivedWfs)
@@ -173,6 +198,10 @@ func (r *workflowArchive) ListWorkflows(namespace string, name string, namePrefi
 	}
 	wfs := make(wfv1.Workflows, len(archivedWfs))
 	for i, md := range archivedWfs {
+		var progress wfv1.Progress
+		if md.ProgressTotal > 0 {
+			progress, _ = wfv1.NewProgress(md.ProgressCompleted, md.ProgressTotal)
+		}
 		wfs[i] = wfv1.Workflow{
 			ObjectMeta: v1.ObjectMeta{
 				Name:              md.Name,
@@ -184,13 +213,14 @@ func (r *workflowArchive) ListWorkflows(namespace string, name string, namePrefi
 				Phase:      md.Phase,
 				StartedAt:  v1.Time{Time: md.StartedAt},
 				FinishedAt: v1.Time{Time: md.FinishedAt},
+				Progress:   progress,
 			},
 		}
 	}
 	return wfs, nil
 }
 
-func (r *workflowArchive) CountWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements) (int64, error) {
+func (r *workflowArchive) CountWorkflows(namespace string, name string, namePrefix string, minStartedAt, maxStartedAt time.Time, labelRequirements labels.Requirements, phases []wfv1.WorkflowPhase, search string) (int64, error) {
 	total := &archivedWorkflowCount{}
 	clause, err := labelsClause(r.dbType, labelRequirements)
 	if err != nil {
@@ -205,6 +235,8 @@ func (r *workflowArchive) CountWorkflows(namespace string, name string, namePref
 		And(nameEqual(name)).
 		And(namePrefixClause(namePrefix)).
 		And(startedAtClause(minStartedAt, maxStartedAt)).
+		And(phasesClause(phases)).
+		And(searchClause(r.dbType, search)).
 		And(clause).
 		One(total)
 	if err != nil {
@@ -233,6 +265,40 @@ func startedAtClause(from, to time.Time) db.Compound {
 	return db.And(conds...)
 }
 
+func phasesClause(phases []wfv1.WorkflowPhase) db.Cond {
+	if len(phases) == 0 {
+		return db.Cond{}
+	}
+	return db.Cond{"phase IN": phases}
+}
+
+// SortByFields are the fields archived workflows can be sorted by, prefixed with "-" for descending order
+var SortByFields = map[string]string{
+	"startedAt":  "startedat",
+	"finishedAt": "finishedat",
+	"duration":   "duration",
+	// the fraction of nodes completed, where workflows without progress have made none
+	"progress": "case when progresstotal = 0 then 0 else progresscompleted * 1.0 / progresstotal end",
+	"name":     "name",
+}
+
+// orderByClause returns the columns to order by for sortBy, with the most recently started workflows first by default
+// and to break ties
+func orderByClause(sortBy string) ([]interface{}, error) {
+	if sortBy == "" {
+		return []interface{}{"-startedat"}, nil
+	}
+	direction := "asc"
+	if strings.HasPrefix(sortBy, "-") {
+		direction = "desc"
+	}
+	column, ok := SortByFields[strings.TrimPrefix(sortBy, "-")]
+	if !ok {
+		return nil, fmt.Errorf("cannot sort by %q", sortBy)
+	}
+	return []interface{}{db.Raw(column + " " + direction), "-startedat"}, nil
+}
+
 func namespaceEqual(namespace string) db.Cond {
 	if namespace == "" {
 		return db.Cond{}
@@ -271,11 +337,35 @@ func (r *workflowArchive) GetWorkflow(uid string) (*wfv1.Workflow, error) {
 		}
 		return nil, err
 	}
-	var wf *wfv1.Workflow
-	err = json.Unmarshal([]byte(archivedWf.Workflow), &wf)
+	return unmarshalArchivedWorkflow(archivedWf.Workflow)
+}
+
+// marshalArchivedWorkflow returns the workflow as it is archived: its JSON, compressed with the configured algorithm and
+// base64 encoded, as a JSON string so that it is valid for the column's JSON type
+func marshalArchivedWorkflow(wf *wfv1.Workflow) ([]byte, error) {
+	data, err := json.Marshal(wf)
 	if err != nil {
 		return nil, err
 	}
+	return json.Marshal(file.CompressEncodeString(string(data)))
+}
+
+// unmarshalArchivedWorkflow returns the archived workflow, whether it is compressed, or is JSON as it was archived
+// before workflows were compressed
+func unmarshalArchivedWorkflow(workflow string) (*wfv1.Workflow, error) {
+	data := []byte(workflow)
+	var compressed string
+	if err := json.Unmarshal(data, &compressed); err == nil {
+		decompressed, err := file.DecodeDecompressString(compressed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress archived workflow: %w", err)
+		}
+		data = []byte(decompressed)
+	}
+	var wf *wfv1.Workflow
+	if err := json.Unmarshal(data, &wf); err != nil {
+		return nil, err
+	}
 	return wf, nil
 }
 
@@ -296,19 +386,32 @@ func (r *workflowArchive) DeleteWorkflow(uid string) error {
 	return nil
 }
 
-func (r *workflowArchive) DeleteExpiredWorkflows(ttl time.Duration) error {
+func (r *workflowArchive) DeleteExpiredWorkflows(ttl time.Duration, labelRequirements labels.Requirements, excluding []labels.Requirements) (int64, error) {
+	clause, err := labelsClause(r.dbType, labelRequirements)
+	if err != nil {
+		return 0, err
+	}
+	conds := []db.Compound{clause}
+	for _, requirements := range excluding {
+		c, err := notLabelsClause(r.dbType, requirements)
+		if err != nil {
+			return 0, err
+		}
+		conds = append(conds, c)
+	}
 	rs, err := r.session.
 		DeleteFrom(archiveTableName).
 		Where(r.clusterManagedNamespaceAndInstanceID()).
 		And(fmt.Sprintf("finishedat < current_timestamp - interval '%d' second", int(ttl.Seconds()))).
+		And(db.And(conds...)).
 		Exec()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	rowsAffected, err := rs.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	log.WithFields(log.Fields{"rowsAffected": rowsAffected}).Info("Deleted archived workflows")
-	return nil
+	return rowsAffected, nil
 }
@@ -0,0 +1,60 @@
+package sqldb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"upper.io/db.v3"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+)
+
+func Test_orderByClause(t *testing.T) {
+	tests := []struct {
+		name    string
+		sortBy  string
+		want    []interface{}
+		wantErr bool
+	}{
+		{"Default", "", []interface{}{"-startedat"}, false},
+		{"Ascending", "duration", []interface{}{db.Raw("duration asc"), "-startedat"}, false},
+		{"Descending", "-finishedAt", []interface{}{db.Raw("finishedat desc"), "-startedat"}, false},
+		{"Unknown", "-uid", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orderByClause(tt.sortBy)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_marshalArchivedWorkflow(t *testing.T) {
+	defer func() { _ = file.SetCompression(file.CompressionGZip, 0) }()
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}}
+	for _, algorithm := range []string{file.CompressionGZip, file.CompressionZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			assert.NoError(t, file.SetCompression(algorithm, 0))
+			data, err := marshalArchivedWorkflow(wf)
+			if assert.NoError(t, err) {
+				assert.Equal(t, byte('"'), data[0])
+				archived, err := unmarshalArchivedWorkflow(string(data))
+				if assert.NoError(t, err) {
+					assert.Equal(t, "my-wf", archived.Name)
+				}
+			}
+		})
+	}
+	t.Run("Uncompressed", func(t *testing.T) {
+		archived, err := unmarshalArchivedWorkflow(`{"metadata":{"name":"my-wf"}}`)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-wf", archived.Name)
+		}
+	})
+}
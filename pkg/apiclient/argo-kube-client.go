@@ -13,11 +13,14 @@ import (
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	workflow "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	clusterworkflowtmplserver "github.com/argoproj/argo-workflows/v3/server/clusterworkflowtemplate"
 	cronworkflowserver "github.com/argoproj/argo-workflows/v3/server/cronworkflow"
@@ -26,6 +29,8 @@ import (
 	workflowtemplateserver "github.com/argoproj/argo-workflows/v3/server/workflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/util/help"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 )
 
 var (
@@ -33,8 +38,16 @@ var (
 	NoArgoServerErr               = fmt.Errorf("this is impossible if you are not using the Argo Server, see " + help.CLI)
 )
 
+// argoKubeArtifactRepositories finds the artifact repositories of the workflows' namespaces, but not the default
+// artifact repository, as that is configured by the workflow controller
+func argoKubeArtifactRepositories(kubeClient kubernetes.Interface, wfClient workflow.Interface) artifactrepositories.Interface {
+	return artifactrepositories.New(kubeClient, wfClient, "", nil)
+}
+
 type argoKubeClient struct {
 	instanceIDService instanceid.Service
+	kubeClient        kubernetes.Interface
+	wfClient          workflow.Interface
 }
 
 var _ Client = &argoKubeClient{}
@@ -71,7 +84,7 @@ func newArgoKubeClient(ctx context.Context, clientConfig clientcmd.ClientConfig,
 		Sensor:      sensorInterface,
 		Workflow:    wfClient,
 	}
-	gatekeeper, err := auth.NewGatekeeper(auth.Modes{auth.Server: true}, clients, restConfig, nil, auth.DefaultClientForAuthorization, "unused", "unused", false, nil)
+	gatekeeper, err := auth.NewGatekeeper(auth.Modes{auth.Server: true}, clients, restConfig, nil, auth.DefaultClientForAuthorization, "unused", "unused", false, nil, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -79,11 +92,16 @@ func newArgoKubeClient(ctx context.Context, clientConfig clientcmd.ClientConfig,
 	if err != nil {
 		return nil, nil, err
 	}
-	return ctx, &argoKubeClient{instanceIDService}, nil
+	return ctx, &argoKubeClient{instanceIDService, kubeClient, wfClient}, nil
+}
+
+// hydrator hydrates workflows without a database, so it cannot hydrate nodes or parameters offloaded to it
+func (a *argoKubeClient) hydrator() hydrator.Interface {
+	return hydrator.New(argoKubeOffloadNodeStatusRepo, hydrator.NewParametersLoader(hydrator.KubeConfigMapGetter(a.kubeClient), sqldb.ExplosiveOffloadParametersRepo))
 }
 
 func (a *argoKubeClient) NewWorkflowServiceClient() workflowpkg.WorkflowServiceClient {
-	return &errorTranslatingWorkflowServiceClient{&argoKubeWorkflowServiceClient{workflowserver.NewWorkflowServer(a.instanceIDService, argoKubeOffloadNodeStatusRepo)}}
+	return &errorTranslatingWorkflowServiceClient{&argoKubeWorkflowServiceClient{workflowserver.NewWorkflowServer(a.instanceIDService, argoKubeOffloadNodeStatusRepo, a.hydrator(), admission.Null, argoKubeArtifactRepositories(a.kubeClient, a.wfClient))}}
 }
 
 func (a *argoKubeClient) NewCronWorkflowServiceClient() (cronworkflow.CronWorkflowServiceClient, error) {
@@ -102,6 +120,14 @@ func (a *argoKubeClient) NewInfoServiceClient() (infopkg.InfoServiceClient, erro
 	return nil, NoArgoServerErr
 }
 
+func (a *argoKubeClient) NewFederationServiceClient() (federationpkg.FederationServiceClient, error) {
+	return nil, NoArgoServerErr
+}
+
+func (a *argoKubeClient) NewSyncLockServiceClient() (syncpkg.SyncLockServiceClient, error) {
+	return nil, NoArgoServerErr
+}
+
 func (a *argoKubeClient) NewClusterWorkflowTemplateServiceClient() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
-	return &errorTranslatingWorkflowClusterTemplateServiceClient{&argoKubeWorkflowClusterTemplateServiceClient{clusterworkflowtmplserver.NewClusterWorkflowTemplateServer(a.instanceIDService)}}, nil
+	return &errorTranslatingWorkflowClusterTemplateServiceClient{&argoKubeWorkflowClusterTemplateServiceClient{clusterworkflowtmplserver.NewClusterWorkflowTemplateServer(a.instanceIDService, "")}}, nil
 }
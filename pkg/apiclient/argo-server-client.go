@@ -3,15 +3,19 @@ package apiclient
 import (
 	"context"
 	"crypto/tls"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	authenticationv1 "k8s.io/api/authentication/v1"
 
 	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
@@ -33,7 +37,7 @@ func newArgoServerClient(opts ArgoServerOpts, auth string) (context.Context, Cli
 	if err != nil {
 		return nil, nil, err
 	}
-	return newContext(auth), &argoServerClient{conn}, nil
+	return newContext(auth, opts), &argoServerClient{conn}, nil
 }
 
 func (a *argoServerClient) NewWorkflowServiceClient() workflowpkg.WorkflowServiceClient {
@@ -60,6 +64,14 @@ func (a *argoServerClient) NewInfoServiceClient() (infopkg.InfoServiceClient, er
 	return infopkg.NewInfoServiceClient(a.ClientConn), nil
 }
 
+func (a *argoServerClient) NewFederationServiceClient() (federationpkg.FederationServiceClient, error) {
+	return federationpkg.NewFederationServiceClient(a.ClientConn), nil
+}
+
+func (a *argoServerClient) NewSyncLockServiceClient() (syncpkg.SyncLockServiceClient, error) {
+	return syncpkg.NewSyncLockServiceClient(a.ClientConn), nil
+}
+
 func newClientConn(opts ArgoServerOpts) (*grpc.ClientConn, error) {
 	creds := grpc.WithTransportCredentials(insecure.NewCredentials())
 	if opts.Secure {
@@ -72,9 +84,19 @@ func newClientConn(opts ArgoServerOpts) (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
-func newContext(auth string) context.Context {
-	if auth == "" {
+func newContext(auth string, opts ArgoServerOpts) context.Context {
+	var kv []string
+	if auth != "" {
+		kv = append(kv, "authorization", auth)
+	}
+	if opts.ImpersonateUser != "" {
+		kv = append(kv, strings.ToLower(authenticationv1.ImpersonateUserHeader), opts.ImpersonateUser)
+	}
+	for _, group := range opts.ImpersonateGroups {
+		kv = append(kv, strings.ToLower(authenticationv1.ImpersonateGroupHeader), group)
+	}
+	if len(kv) == 0 {
 		return context.Background()
 	}
-	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", auth))
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs(kv...))
 }
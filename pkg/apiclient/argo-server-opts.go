@@ -2,6 +2,8 @@ package apiclient
 
 import (
 	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
 )
 
 type ArgoServerOpts struct {
@@ -14,6 +16,9 @@ type ArgoServerOpts struct {
 	// whether or not to use HTTP1
 	HTTP1   bool
 	Headers []string
+	// the user and groups to impersonate, which requires the Argo Server to use the client auth mode
+	ImpersonateUser   string
+	ImpersonateGroups []string
 }
 
 func (o ArgoServerOpts) GetURL() string {
@@ -23,6 +28,18 @@ func (o ArgoServerOpts) GetURL() string {
 	return "http://" + o.URL + o.Path
 }
 
+// GetHeaders returns the additional headers to send with each HTTP request, including the impersonation headers
+func (o ArgoServerOpts) GetHeaders() []string {
+	headers := append([]string{}, o.Headers...)
+	if o.ImpersonateUser != "" {
+		headers = append(headers, authenticationv1.ImpersonateUserHeader+":"+o.ImpersonateUser)
+	}
+	for _, group := range o.ImpersonateGroups {
+		headers = append(headers, authenticationv1.ImpersonateGroupHeader+":"+group)
+	}
+	return headers
+}
+
 func (o ArgoServerOpts) String() string {
 	return fmt.Sprintf("(url=%s,path=%s,secure=%v,insecureSkipVerify=%v,http=%v)", o.URL, o.Path, o.Secure, o.InsecureSkipVerify, o.HTTP1)
 }
@@ -17,3 +17,9 @@ func TestArgoServerOpts_GetURL(t *testing.T) {
 	assert.Equal(t, "http://my-url/my-path", ArgoServerOpts{URL: "my-url", Path: "/my-path"}.GetURL())
 	assert.Equal(t, "https://my-url/my-path", ArgoServerOpts{URL: "my-url", Path: "/my-path", Secure: true}.GetURL())
 }
+
+func TestArgoServerOpts_GetHeaders(t *testing.T) {
+	assert.Equal(t, []string{"foo:bar"}, ArgoServerOpts{Headers: []string{"foo:bar"}}.GetHeaders())
+	assert.Equal(t, []string{"foo:bar", "Impersonate-User:system:serviceaccount:argo:my-sa", "Impersonate-Group:my-group"},
+		ArgoServerOpts{Headers: []string{"foo:bar"}, ImpersonateUser: "system:serviceaccount:argo:my-sa", ImpersonateGroups: []string{"my-group"}}.GetHeaders())
+}
@@ -0,0 +1,10 @@
+package artifact
+
+// FileListing lists the files of an artifact, e.g. so that clients can download a directory file by file.
+// It is returned by the argo server's artifact-files endpoint when the "list=true" query parameter is set.
+type FileListing struct {
+	// IsDirectory is true if the artifact is a directory
+	IsDirectory bool `json:"isDirectory"`
+	// Files are the paths of every file in the directory relative to the artifact, or the name of the artifact's file
+	Files []string `json:"files"`
+}
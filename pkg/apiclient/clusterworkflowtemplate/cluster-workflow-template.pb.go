@@ -89,11 +89,13 @@ func (m *ClusterWorkflowTemplateCreateRequest) GetCreateOptions() *v1.CreateOpti
 }
 
 type ClusterWorkflowTemplateGetRequest struct {
-	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	GetOptions           *v1.GetOptions `protobuf:"bytes,2,opt,name=getOptions,proto3" json:"getOptions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Name       string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	GetOptions *v1.GetOptions `protobuf:"bytes,2,opt,name=getOptions,proto3" json:"getOptions,omitempty"`
+	// the revision to get, defaults to the latest
+	Revision             int64    `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ClusterWorkflowTemplateGetRequest) Reset()         { *m = ClusterWorkflowTemplateGetRequest{} }
@@ -143,11 +145,20 @@ func (m *ClusterWorkflowTemplateGetRequest) GetGetOptions() *v1.GetOptions {
 	return nil
 }
 
+func (m *ClusterWorkflowTemplateGetRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type ClusterWorkflowTemplateListRequest struct {
-	ListOptions          *v1.ListOptions `protobuf:"bytes,1,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	ListOptions *v1.ListOptions `protobuf:"bytes,1,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
+	// list the saved revisions of the named template, rather than the templates
+	RevisionsOf          string   `protobuf:"bytes,2,opt,name=revisionsOf,proto3" json:"revisionsOf,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ClusterWorkflowTemplateListRequest) Reset()         { *m = ClusterWorkflowTemplateListRequest{} }
@@ -190,6 +201,13 @@ func (m *ClusterWorkflowTemplateListRequest) GetListOptions() *v1.ListOptions {
 	return nil
 }
 
+func (m *ClusterWorkflowTemplateListRequest) GetRevisionsOf() string {
+	if m != nil {
+		return m.RevisionsOf
+	}
+	return ""
+}
+
 type ClusterWorkflowTemplateUpdateRequest struct {
 	// DEPRECATED: This field is ignored.
 	Name                 string                            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Deprecated: Do not use.
@@ -411,49 +429,51 @@ func init() {
 }
 
 var fileDescriptor_688d96b5f613e598 = []byte{
-	// 671 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0xcf, 0x6b, 0xd4, 0x40,
-	0x14, 0x66, 0x16, 0x11, 0x9d, 0xd2, 0xcb, 0x1c, 0xb4, 0xc4, 0x76, 0xd1, 0xa1, 0x52, 0x5d, 0xed,
-	0xc4, 0xb4, 0x3d, 0x48, 0x45, 0x0f, 0x6d, 0xa5, 0x1e, 0x2a, 0x4a, 0xea, 0x0f, 0x2a, 0x88, 0x4c,
-	0xd3, 0x31, 0x1b, 0x37, 0x9b, 0x89, 0x99, 0xd9, 0x94, 0x22, 0x5e, 0xbc, 0x79, 0xf2, 0x20, 0xfe,
-	0x29, 0xfe, 0x0f, 0x1e, 0x15, 0xff, 0x01, 0x59, 0x44, 0xf4, 0xe4, 0x4d, 0x3c, 0x4a, 0x26, 0x3f,
-	0x17, 0x9d, 0x36, 0xbb, 0x74, 0x7b, 0xf0, 0x16, 0x66, 0xe6, 0xbd, 0xf7, 0x7d, 0xef, 0x7d, 0xf3,
-	0x65, 0xe0, 0xcd, 0xb0, 0xe3, 0x9a, 0x34, 0xf4, 0x1c, 0xdf, 0x63, 0x81, 0x34, 0x1d, 0xbf, 0x27,
-	0x24, 0x8b, 0x76, 0x79, 0xd4, 0x79, 0xea, 0xf3, 0x5d, 0xc9, 0xba, 0xa1, 0x4f, 0x25, 0xcb, 0xd7,
-	0xe7, 0xf3, 0x8d, 0xf9, 0x7c, 0x87, 0x84, 0x11, 0x97, 0x1c, 0x9d, 0xd6, 0x04, 0x1a, 0xd3, 0x2e,
-	0xe7, 0xae, 0xcf, 0x92, 0x12, 0x26, 0x0d, 0x02, 0x2e, 0xa9, 0xf4, 0x78, 0x20, 0xd2, 0x30, 0x63,
-	0xa9, 0x73, 0x55, 0x10, 0x8f, 0x27, 0xbb, 0x5d, 0xea, 0xb4, 0xbd, 0x80, 0x45, 0x7b, 0x66, 0x86,
-	0x48, 0x98, 0x5d, 0x26, 0xa9, 0x19, 0x5b, 0xa6, 0xcb, 0x02, 0x16, 0x51, 0xc9, 0x76, 0xb2, 0xa8,
-	0xdb, 0xae, 0x27, 0xdb, 0xbd, 0x6d, 0xe2, 0xf0, 0xae, 0x49, 0x23, 0x97, 0x87, 0x11, 0x7f, 0xa6,
-	0x3e, 0x0a, 0x78, 0xa2, 0x4c, 0x92, 0x2f, 0x99, 0xb1, 0x45, 0xfd, 0xb0, 0x4d, 0xff, 0x4a, 0x87,
-	0x7f, 0x03, 0x38, 0xbb, 0x9a, 0xc2, 0x7f, 0x98, 0x1d, 0xbe, 0x97, 0xc1, 0x5f, 0x8d, 0x18, 0x95,
-	0xcc, 0x66, 0xcf, 0x7b, 0x4c, 0x48, 0xd4, 0x83, 0x27, 0x72, 0x5e, 0x53, 0xe0, 0x2c, 0xb8, 0x30,
-	0xb1, 0xb0, 0x45, 0x4a, 0x28, 0x24, 0x87, 0xa2, 0x3e, 0x9e, 0x14, 0x50, 0x48, 0xbc, 0x48, 0xc2,
-	0x8e, 0x4b, 0x12, 0x34, 0x24, 0x5f, 0x25, 0x39, 0x1a, 0xa2, 0xa9, 0x6c, 0x17, 0xa5, 0xd0, 0x16,
-	0x9c, 0x74, 0x14, 0x8e, 0x3b, 0xa1, 0xea, 0xdd, 0x54, 0x43, 0xd5, 0x5e, 0x24, 0x69, 0xf3, 0x48,
-	0xb5, 0x79, 0x65, 0xa5, 0xa4, 0x79, 0x24, 0xb6, 0xc8, 0x6a, 0x35, 0xd4, 0x1e, 0xcc, 0x84, 0x5f,
-	0x03, 0x78, 0x4e, 0x03, 0x60, 0x9d, 0xc9, 0x9c, 0x37, 0x82, 0xc7, 0x02, 0xda, 0x4d, 0x39, 0x9f,
-	0xb4, 0xd5, 0x37, 0xba, 0x0b, 0xa1, 0xcb, 0xe4, 0x20, 0xa2, 0x2b, 0xf5, 0x10, 0xad, 0x17, 0x71,
-	0x76, 0x25, 0x07, 0xde, 0x83, 0x58, 0x03, 0x65, 0xc3, 0x13, 0x05, 0x96, 0x4d, 0x38, 0xe1, 0x7b,
-	0xa2, 0x28, 0x9c, 0x8e, 0xc1, 0xaa, 0x57, 0x78, 0xa3, 0x0c, 0xb4, 0xab, 0x59, 0xf0, 0x7b, 0xbd,
-	0x02, 0xee, 0x87, 0x3b, 0x15, 0x05, 0x9c, 0xaa, 0x76, 0x62, 0xa5, 0x31, 0x05, 0xb2, 0x6e, 0x54,
-	0x95, 0xd1, 0x38, 0x32, 0x65, 0xe0, 0x77, 0x7a, 0xdc, 0x6b, 0xcc, 0x67, 0x25, 0xee, 0x7f, 0x4d,
-	0x70, 0x0b, 0x4e, 0xee, 0xa8, 0x43, 0x23, 0xc9, 0x6a, 0xad, 0x1a, 0x6a, 0x0f, 0x66, 0xc2, 0x73,
-	0xf0, 0xfc, 0x01, 0xb0, 0x44, 0xc8, 0x03, 0xc1, 0xf0, 0x2f, 0xb0, 0xcf, 0xd0, 0x03, 0xf9, 0xdf,
-	0x5e, 0xbc, 0x85, 0x37, 0x13, 0xb0, 0xa9, 0x01, 0xb0, 0xc9, 0xa2, 0xd8, 0x73, 0x18, 0xfa, 0x0e,
-	0xe0, 0x4c, 0x9a, 0x43, 0x73, 0x10, 0x5d, 0x27, 0x1a, 0xd7, 0x25, 0x75, 0xec, 0xcc, 0x18, 0x5f,
-	0x0f, 0xf1, 0xfc, 0xab, 0xcf, 0x5f, 0xdf, 0x36, 0xe6, 0x30, 0x56, 0xbe, 0x1f, 0x5b, 0xfa, 0xff,
-	0x87, 0x58, 0x06, 0x2d, 0xf4, 0x0d, 0x40, 0x63, 0x9d, 0x49, 0x1d, 0xcf, 0xe5, 0x61, 0x79, 0x96,
-	0xde, 0x35, 0x4e, 0x92, 0x96, 0x22, 0x79, 0x09, 0x5d, 0x3c, 0x98, 0xa4, 0xf9, 0x22, 0xb9, 0x72,
-	0x2f, 0x13, 0xa2, 0xd3, 0x89, 0x0b, 0x69, 0x52, 0x0a, 0x74, 0x6d, 0x58, 0xaa, 0x15, 0x6f, 0x34,
-	0x1e, 0x8f, 0x8d, 0x6b, 0x52, 0x05, 0xb7, 0x14, 0xdf, 0x59, 0x54, 0x63, 0xa8, 0xe8, 0x27, 0x80,
-	0x33, 0xa9, 0x75, 0x1e, 0x9a, 0x78, 0x07, 0x9c, 0x78, 0x9c, 0x73, 0x5d, 0x52, 0x3c, 0x89, 0x51,
-	0x7f, 0xae, 0x89, 0x86, 0x3f, 0x01, 0x38, 0x93, 0xba, 0xdb, 0xa1, 0x31, 0x1e, 0xf0, 0x70, 0xe3,
-	0xc6, 0xa8, 0xe1, 0x99, 0xd7, 0x66, 0x72, 0x6d, 0x0d, 0x21, 0xd7, 0x1f, 0x00, 0x9e, 0x49, 0x7c,
-	0x58, 0xc7, 0x68, 0x04, 0xb5, 0x06, 0x47, 0x71, 0x33, 0x17, 0x14, 0xd5, 0xcb, 0x78, 0xae, 0x06,
-	0x55, 0xdf, 0x0b, 0xe4, 0x32, 0x68, 0xad, 0x3c, 0xf8, 0xd0, 0x6f, 0x82, 0x8f, 0xfd, 0x26, 0xf8,
-	0xd2, 0x6f, 0x82, 0x47, 0xb7, 0xea, 0x3f, 0x31, 0xf7, 0x7f, 0x39, 0x6f, 0x1f, 0x57, 0x8f, 0xcc,
-	0xc5, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xe8, 0x0f, 0x8e, 0xa4, 0x69, 0x0b, 0x00, 0x00,
+	// 703 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x56, 0x4f, 0x4f, 0xd4, 0x4e,
+	0x18, 0xce, 0x2c, 0xbf, 0xfc, 0x02, 0xb3, 0xe1, 0x32, 0x07, 0xdd, 0x54, 0xd8, 0xe0, 0x04, 0x03,
+	0xae, 0x32, 0x75, 0x81, 0x83, 0xc1, 0xe8, 0x01, 0x30, 0x78, 0xc0, 0x60, 0x8a, 0x7f, 0x82, 0x89,
+	0x31, 0x65, 0x79, 0x29, 0x75, 0xbb, 0x9d, 0xda, 0x99, 0x2d, 0x31, 0xc6, 0x8b, 0x5f, 0xc0, 0x83,
+	0xf1, 0xe4, 0xd9, 0x8f, 0xe0, 0x77, 0xf0, 0xa8, 0xf1, 0x0b, 0x18, 0x62, 0x8c, 0x9e, 0xbc, 0x19,
+	0x8f, 0xa6, 0xd3, 0x3f, 0xdb, 0x8d, 0x19, 0x28, 0x1b, 0x96, 0x83, 0xb7, 0xe9, 0xcc, 0xbc, 0xef,
+	0xfb, 0x3c, 0xef, 0x3c, 0xf3, 0x74, 0xf0, 0xcd, 0xa0, 0xed, 0x98, 0x76, 0xe0, 0xb6, 0x3c, 0x17,
+	0x7c, 0x69, 0xb6, 0xbc, 0xae, 0x90, 0x10, 0xee, 0xf3, 0xb0, 0xbd, 0xeb, 0xf1, 0x7d, 0x09, 0x9d,
+	0xc0, 0xb3, 0x25, 0x64, 0xf3, 0x73, 0xd9, 0xc2, 0x5c, 0xb6, 0xc2, 0x82, 0x90, 0x4b, 0x4e, 0xce,
+	0x6a, 0x02, 0x8d, 0x09, 0x87, 0x73, 0xc7, 0x83, 0xb8, 0x84, 0x69, 0xfb, 0x3e, 0x97, 0xb6, 0x74,
+	0xb9, 0x2f, 0x92, 0x30, 0x63, 0xb1, 0x7d, 0x55, 0x30, 0x97, 0xc7, 0xab, 0x1d, 0xbb, 0xb5, 0xe7,
+	0xfa, 0x10, 0x3e, 0x33, 0x53, 0x44, 0xc2, 0xec, 0x80, 0xb4, 0xcd, 0xa8, 0x69, 0x3a, 0xe0, 0x43,
+	0x68, 0x4b, 0xd8, 0x49, 0xa3, 0x6e, 0x3b, 0xae, 0xdc, 0xeb, 0x6e, 0xb3, 0x16, 0xef, 0x98, 0x76,
+	0xe8, 0xf0, 0x20, 0xe4, 0x4f, 0xd4, 0x20, 0x87, 0x27, 0x7a, 0x49, 0xb2, 0x29, 0x33, 0x6a, 0xda,
+	0x5e, 0xb0, 0x67, 0xff, 0x95, 0x8e, 0xfe, 0x46, 0x78, 0x7a, 0x25, 0x81, 0xff, 0x20, 0xdd, 0x7c,
+	0x37, 0x85, 0xbf, 0x12, 0x82, 0x2d, 0xc1, 0x82, 0xa7, 0x5d, 0x10, 0x92, 0x74, 0xf1, 0x68, 0xc6,
+	0xab, 0x86, 0xa6, 0xd0, 0x6c, 0x75, 0x7e, 0x8b, 0xf5, 0xa0, 0xb0, 0x0c, 0x8a, 0x1a, 0x3c, 0xce,
+	0xa1, 0xb0, 0x68, 0x81, 0x05, 0x6d, 0x87, 0xc5, 0x68, 0x58, 0x36, 0xcb, 0x32, 0x34, 0x4c, 0x53,
+	0xd9, 0xca, 0x4b, 0x91, 0x2d, 0x3c, 0xde, 0x52, 0x38, 0x36, 0x02, 0xd5, 0xbb, 0x5a, 0x45, 0xd5,
+	0x5e, 0x60, 0x49, 0xf3, 0x58, 0xb1, 0x79, 0xbd, 0x4a, 0x71, 0xf3, 0x58, 0xd4, 0x64, 0x2b, 0xc5,
+	0x50, 0xab, 0x3f, 0x13, 0x7d, 0x87, 0xf0, 0x79, 0x0d, 0x80, 0x35, 0x90, 0x19, 0x6f, 0x82, 0xff,
+	0xf3, 0xed, 0x4e, 0xc2, 0x79, 0xcc, 0x52, 0x63, 0x72, 0x07, 0x63, 0x07, 0x64, 0x3f, 0xa2, 0x2b,
+	0xe5, 0x10, 0xad, 0xe5, 0x71, 0x56, 0x21, 0x07, 0x31, 0xf0, 0x68, 0x08, 0x91, 0x2b, 0x5c, 0xee,
+	0xd7, 0x46, 0xa6, 0xd0, 0xec, 0x88, 0x95, 0x7f, 0xd3, 0xb7, 0x08, 0x53, 0x0d, 0xce, 0x75, 0x57,
+	0xe4, 0x40, 0x37, 0x71, 0xd5, 0x73, 0x45, 0x8e, 0x2a, 0x39, 0xa3, 0x66, 0x39, 0x54, 0xeb, 0xbd,
+	0x40, 0xab, 0x98, 0x85, 0x4c, 0xe1, 0x6a, 0x86, 0x43, 0x6c, 0xec, 0x2a, 0xaa, 0x63, 0x56, 0x71,
+	0x8a, 0xbe, 0xd7, 0x0b, 0xe8, 0x5e, 0xb0, 0x53, 0x10, 0xd0, 0x99, 0x62, 0x23, 0x97, 0x2b, 0x35,
+	0x94, 0x36, 0xb3, 0x28, 0xac, 0xca, 0xa9, 0x09, 0x8b, 0xbe, 0xd1, 0xe3, 0x5e, 0x05, 0x0f, 0x24,
+	0x1c, 0x26, 0x80, 0x2d, 0x3c, 0xbe, 0xa3, 0x36, 0x0d, 0xa4, 0xca, 0xd5, 0x62, 0xa8, 0xd5, 0x9f,
+	0x89, 0xce, 0xe0, 0x0b, 0x47, 0xc0, 0x12, 0x01, 0xf7, 0x05, 0xd0, 0x5f, 0x87, 0xc9, 0xc2, 0x97,
+	0xff, 0xec, 0xbd, 0x9d, 0x7f, 0x55, 0xc5, 0x75, 0x0d, 0x80, 0x4d, 0x08, 0x23, 0xb7, 0x05, 0xe4,
+	0x3b, 0xc2, 0x93, 0x49, 0x0e, 0xcd, 0x46, 0x72, 0x9d, 0x69, 0x4c, 0x9b, 0x95, 0x71, 0x43, 0x63,
+	0x78, 0x3d, 0xa4, 0x73, 0x2f, 0x3f, 0x7f, 0x7d, 0x5d, 0x99, 0xa1, 0x54, 0xfd, 0x36, 0xa2, 0xa6,
+	0xfe, 0xf7, 0x23, 0x96, 0x50, 0x83, 0x7c, 0x43, 0xd8, 0x58, 0x03, 0xa9, 0xe3, 0xb9, 0x74, 0x5c,
+	0x9e, 0x3d, 0xeb, 0x1b, 0x26, 0xc9, 0xa6, 0x22, 0x79, 0x89, 0x5c, 0x3c, 0x9a, 0xa4, 0xf9, 0x3c,
+	0xbe, 0x72, 0x2f, 0x62, 0xa2, 0x13, 0xb1, 0x4f, 0x69, 0x52, 0x0a, 0x72, 0xed, 0xb8, 0x54, 0x0b,
+	0xee, 0x69, 0x3c, 0x1a, 0x1a, 0xd7, 0xb8, 0x0a, 0x6d, 0x28, 0xbe, 0xd3, 0xa4, 0xc4, 0xa1, 0x92,
+	0x9f, 0x08, 0x4f, 0x26, 0xd6, 0x79, 0x62, 0xe2, 0xed, 0x73, 0xe2, 0x61, 0x9e, 0xeb, 0xa2, 0xe2,
+	0xc9, 0x8c, 0xf2, 0xe7, 0x1a, 0x6b, 0xf8, 0x13, 0xc2, 0x93, 0x89, 0xbb, 0x9d, 0x18, 0xe3, 0x3e,
+	0x0f, 0x37, 0x6e, 0x0c, 0x1a, 0x9e, 0x7a, 0x6d, 0x2a, 0xd7, 0xc6, 0x31, 0xe4, 0xfa, 0x03, 0xe1,
+	0x73, 0xb1, 0x0f, 0xeb, 0x18, 0x0d, 0xa0, 0x56, 0xff, 0x34, 0x6e, 0xe6, 0xbc, 0xa2, 0x7a, 0x99,
+	0xce, 0x94, 0xa0, 0xea, 0xb9, 0xbe, 0x5c, 0x42, 0x8d, 0xe5, 0xfb, 0x1f, 0x0e, 0xea, 0xe8, 0xe3,
+	0x41, 0x1d, 0x7d, 0x39, 0xa8, 0xa3, 0x87, 0xb7, 0xca, 0xbf, 0x50, 0x0f, 0x7f, 0x78, 0x6f, 0xff,
+	0xaf, 0xde, 0xa8, 0x0b, 0x7f, 0x06, 0x00, 0xe5, 0x7e, 0xa8, 0x53, 0xa8, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -791,6 +811,11 @@ func (m *ClusterWorkflowTemplateGetRequest) MarshalToSizedBuffer(dAtA []byte) (i
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Revision != 0 {
+		i = encodeVarintClusterWorkflowTemplate(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.GetOptions != nil {
 		{
 			size, err := m.GetOptions.MarshalToSizedBuffer(dAtA[:i])
@@ -837,6 +862,13 @@ func (m *ClusterWorkflowTemplateListRequest) MarshalToSizedBuffer(dAtA []byte) (
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.RevisionsOf) > 0 {
+		i -= len(m.RevisionsOf)
+		copy(dAtA[i:], m.RevisionsOf)
+		i = encodeVarintClusterWorkflowTemplate(dAtA, i, uint64(len(m.RevisionsOf)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.ListOptions != nil {
 		{
 			size, err := m.ListOptions.MarshalToSizedBuffer(dAtA[:i])
@@ -1067,6 +1099,9 @@ func (m *ClusterWorkflowTemplateGetRequest) Size() (n int) {
 		l = m.GetOptions.Size()
 		n += 1 + l + sovClusterWorkflowTemplate(uint64(l))
 	}
+	if m.Revision != 0 {
+		n += 1 + sovClusterWorkflowTemplate(uint64(m.Revision))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1083,6 +1118,10 @@ func (m *ClusterWorkflowTemplateListRequest) Size() (n int) {
 		l = m.ListOptions.Size()
 		n += 1 + l + sovClusterWorkflowTemplate(uint64(l))
 	}
+	l = len(m.RevisionsOf)
+	if l > 0 {
+		n += 1 + l + sovClusterWorkflowTemplate(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1387,6 +1426,25 @@ func (m *ClusterWorkflowTemplateGetRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClusterWorkflowTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClusterWorkflowTemplate(dAtA[iNdEx:])
@@ -1474,6 +1532,38 @@ func (m *ClusterWorkflowTemplateListRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevisionsOf", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowClusterWorkflowTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthClusterWorkflowTemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthClusterWorkflowTemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RevisionsOf = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipClusterWorkflowTemplate(dAtA[iNdEx:])
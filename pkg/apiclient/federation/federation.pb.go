@@ -0,0 +1,1455 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pkg/apiclient/federation/federation.proto
+
+// Federation Service
+//
+// Federation Service serves the workflows of the Argo Server's own cluster, and of other clusters, as if they were in
+// one cluster. Each workflow is annotated with the name of its cluster.
+
+package federation
+
+import (
+	context "context"
+	fmt "fmt"
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	proto "github.com/gogo/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type FederatedWorkflowListRequest struct {
+	// The namespace, or empty for all namespaces.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Only the label and field selectors are used, as listing does not paginate.
+	ListOptions          *v1.ListOptions `protobuf:"bytes,2,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FederatedWorkflowListRequest) Reset()         { *m = FederatedWorkflowListRequest{} }
+func (m *FederatedWorkflowListRequest) String() string { return proto.CompactTextString(m) }
+func (*FederatedWorkflowListRequest) ProtoMessage()    {}
+func (*FederatedWorkflowListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_05afc6c7adae9427, []int{0}
+}
+func (m *FederatedWorkflowListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FederatedWorkflowListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FederatedWorkflowListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FederatedWorkflowListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedWorkflowListRequest.Merge(m, src)
+}
+func (m *FederatedWorkflowListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FederatedWorkflowListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedWorkflowListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedWorkflowListRequest proto.InternalMessageInfo
+
+func (m *FederatedWorkflowListRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowListRequest) GetListOptions() *v1.ListOptions {
+	if m != nil {
+		return m.ListOptions
+	}
+	return nil
+}
+
+type FederatedWorkflowGetRequest struct {
+	Cluster              string   `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederatedWorkflowGetRequest) Reset()         { *m = FederatedWorkflowGetRequest{} }
+func (m *FederatedWorkflowGetRequest) String() string { return proto.CompactTextString(m) }
+func (*FederatedWorkflowGetRequest) ProtoMessage()    {}
+func (*FederatedWorkflowGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_05afc6c7adae9427, []int{1}
+}
+func (m *FederatedWorkflowGetRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FederatedWorkflowGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FederatedWorkflowGetRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FederatedWorkflowGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedWorkflowGetRequest.Merge(m, src)
+}
+func (m *FederatedWorkflowGetRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FederatedWorkflowGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedWorkflowGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedWorkflowGetRequest proto.InternalMessageInfo
+
+func (m *FederatedWorkflowGetRequest) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowGetRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowGetRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type FederatedWorkflowLogRequest struct {
+	Cluster   string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PodName   string `protobuf:"bytes,4,opt,name=podName,proto3" json:"podName,omitempty"`
+	// The container, default "main".
+	Container            string   `protobuf:"bytes,5,opt,name=container,proto3" json:"container,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederatedWorkflowLogRequest) Reset()         { *m = FederatedWorkflowLogRequest{} }
+func (m *FederatedWorkflowLogRequest) String() string { return proto.CompactTextString(m) }
+func (*FederatedWorkflowLogRequest) ProtoMessage()    {}
+func (*FederatedWorkflowLogRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_05afc6c7adae9427, []int{2}
+}
+func (m *FederatedWorkflowLogRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FederatedWorkflowLogRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FederatedWorkflowLogRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FederatedWorkflowLogRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedWorkflowLogRequest.Merge(m, src)
+}
+func (m *FederatedWorkflowLogRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FederatedWorkflowLogRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedWorkflowLogRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedWorkflowLogRequest proto.InternalMessageInfo
+
+func (m *FederatedWorkflowLogRequest) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowLogRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowLogRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowLogRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *FederatedWorkflowLogRequest) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+type FederatedLogEntry struct {
+	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederatedLogEntry) Reset()         { *m = FederatedLogEntry{} }
+func (m *FederatedLogEntry) String() string { return proto.CompactTextString(m) }
+func (*FederatedLogEntry) ProtoMessage()    {}
+func (*FederatedLogEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_05afc6c7adae9427, []int{3}
+}
+func (m *FederatedLogEntry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FederatedLogEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FederatedLogEntry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FederatedLogEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedLogEntry.Merge(m, src)
+}
+func (m *FederatedLogEntry) XXX_Size() int {
+	return m.Size()
+}
+func (m *FederatedLogEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedLogEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedLogEntry proto.InternalMessageInfo
+
+func (m *FederatedLogEntry) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*FederatedWorkflowListRequest)(nil), "federation.FederatedWorkflowListRequest")
+	proto.RegisterType((*FederatedWorkflowGetRequest)(nil), "federation.FederatedWorkflowGetRequest")
+	proto.RegisterType((*FederatedWorkflowLogRequest)(nil), "federation.FederatedWorkflowLogRequest")
+	proto.RegisterType((*FederatedLogEntry)(nil), "federation.FederatedLogEntry")
+}
+
+func init() {
+	proto.RegisterFile("pkg/apiclient/federation/federation.proto", fileDescriptor_05afc6c7adae9427)
+}
+
+var fileDescriptor_05afc6c7adae9427 = []byte{
+	// 535 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0x4f, 0x8b, 0x13, 0x31,
+	0x1c, 0x25, 0xb5, 0x2a, 0x9b, 0x3d, 0x19, 0x54, 0xca, 0x58, 0xcb, 0x32, 0x17, 0xab, 0xb0, 0xc9,
+	0xce, 0xae, 0x07, 0x65, 0x11, 0x51, 0x58, 0x17, 0xa5, 0xae, 0xd0, 0x3d, 0x08, 0x5e, 0x24, 0x3b,
+	0xcd, 0xa6, 0xb1, 0xd3, 0x64, 0x9c, 0xa4, 0xb3, 0x2c, 0xd2, 0x8b, 0xdf, 0x40, 0xfc, 0x04, 0xde,
+	0xfc, 0x20, 0x1e, 0x3c, 0x89, 0x20, 0x78, 0x96, 0xe2, 0x07, 0x91, 0x64, 0xfe, 0xea, 0x94, 0x2a,
+	0xa2, 0xb7, 0xdf, 0xef, 0xcd, 0x2f, 0x2f, 0xef, 0x65, 0x5e, 0x02, 0xaf, 0xc7, 0x13, 0x4e, 0x68,
+	0x2c, 0xc2, 0x48, 0x30, 0x69, 0xc8, 0x31, 0x1b, 0xb1, 0x84, 0x1a, 0xa1, 0x64, 0xad, 0xc4, 0x71,
+	0xa2, 0x8c, 0x42, 0xb0, 0x42, 0xbc, 0x2e, 0x57, 0x8a, 0x47, 0xcc, 0xae, 0x24, 0x54, 0x4a, 0x65,
+	0x1c, 0xac, 0xb3, 0x49, 0xef, 0xe6, 0xe4, 0x96, 0xc6, 0x42, 0xd9, 0xaf, 0x53, 0x1a, 0x8e, 0x85,
+	0x64, 0xc9, 0x29, 0xc9, 0x37, 0xd2, 0x64, 0xca, 0x0c, 0x25, 0x69, 0x40, 0x38, 0x93, 0x96, 0x8e,
+	0x8d, 0xf2, 0x55, 0x8f, 0xb9, 0x30, 0xe3, 0xd9, 0x11, 0x0e, 0xd5, 0x94, 0xd0, 0x84, 0xab, 0x38,
+	0x51, 0x2f, 0x5c, 0xb1, 0x79, 0xa2, 0x92, 0xc9, 0x71, 0xa4, 0x4e, 0x74, 0x45, 0x52, 0x40, 0x24,
+	0x0d, 0x68, 0x14, 0x8f, 0x69, 0x83, 0xce, 0x7f, 0x03, 0x60, 0xf7, 0x41, 0xa6, 0x98, 0x8d, 0x9e,
+	0xe6, 0xe3, 0x03, 0xa1, 0xcd, 0x90, 0xbd, 0x9c, 0x31, 0x6d, 0x50, 0x17, 0xae, 0x49, 0x3a, 0x65,
+	0x3a, 0xa6, 0x21, 0xeb, 0x80, 0x0d, 0xd0, 0x5f, 0x1b, 0x56, 0x00, 0x3a, 0x84, 0xeb, 0x91, 0xd0,
+	0xe6, 0x49, 0xec, 0x8c, 0x75, 0x5a, 0x1b, 0xa0, 0xbf, 0xbe, 0x1d, 0xe0, 0xcc, 0x19, 0xae, 0x3b,
+	0xc3, 0xf1, 0x84, 0x5b, 0x40, 0x63, 0xeb, 0x0c, 0xa7, 0x01, 0x1e, 0x54, 0x0b, 0x87, 0x75, 0x16,
+	0x5f, 0xc0, 0x2b, 0x0d, 0x49, 0xfb, 0xac, 0x54, 0xd4, 0x81, 0xe7, 0xc3, 0x68, 0xa6, 0x0d, 0x4b,
+	0x72, 0x3d, 0x45, 0xfb, 0xb3, 0xd6, 0xd6, 0xaf, 0x5a, 0x11, 0x6c, 0xdb, 0xa6, 0x73, 0xc6, 0x7d,
+	0x70, 0xb5, 0xff, 0x0e, 0x2c, 0xd9, 0x6b, 0xa0, 0xf8, 0x7f, 0xd8, 0xcb, 0x72, 0xc5, 0x6a, 0x74,
+	0x60, 0xe1, 0x76, 0xc6, 0x95, 0xb7, 0x96, 0x2b, 0x54, 0xd2, 0x50, 0x7b, 0x50, 0x9d, 0xb3, 0x19,
+	0x57, 0x09, 0xf8, 0x9b, 0xf0, 0x42, 0x29, 0x71, 0xa0, 0xf8, 0x9e, 0x34, 0xc9, 0xa9, 0x13, 0xa6,
+	0xa4, 0x61, 0xd2, 0x94, 0xc2, 0xb2, 0x76, 0xfb, 0x6b, 0xbb, 0x9c, 0x17, 0x4a, 0x1e, 0xb2, 0x24,
+	0x15, 0x21, 0x43, 0x1f, 0x00, 0xbc, 0x6c, 0x0f, 0xbc, 0x61, 0x56, 0xa3, 0x3e, 0xae, 0x85, 0x78,
+	0x55, 0x16, 0xbc, 0x03, 0x5c, 0x85, 0x0f, 0x17, 0xe1, 0x73, 0xc5, 0xf3, 0x32, 0x7c, 0x38, 0xdd,
+	0xa9, 0x7e, 0x75, 0x81, 0xe2, 0x22, 0x7f, 0xb8, 0x4e, 0xeb, 0x6f, 0xbd, 0xfe, 0xf2, 0xfd, 0x6d,
+	0xeb, 0x06, 0xea, 0xbb, 0x1b, 0x92, 0x06, 0xf5, 0x8b, 0x55, 0xa5, 0xf9, 0x55, 0x79, 0xac, 0x73,
+	0xf4, 0x09, 0xc0, 0x8b, 0xfb, 0xac, 0xe9, 0x02, 0x5d, 0x5b, 0x69, 0xa2, 0x4a, 0x8f, 0xf7, 0xe8,
+	0xdf, 0x79, 0xf0, 0xef, 0x39, 0xfd, 0xbb, 0xe8, 0xf6, 0x6a, 0xfd, 0x79, 0x64, 0xe6, 0x75, 0x27,
+	0x59, 0x3d, 0x47, 0xef, 0x01, 0xbc, 0xb4, 0x2c, 0x80, 0xfa, 0x37, 0x8e, 0xaa, 0x8c, 0x7a, 0x57,
+	0x97, 0x0e, 0x16, 0x49, 0xf1, 0xf7, 0x9c, 0xc8, 0xbb, 0xe8, 0xce, 0x5f, 0x8b, 0x24, 0x91, 0xe2,
+	0x5b, 0xe0, 0xfe, 0xc3, 0x8f, 0x8b, 0x1e, 0xf8, 0xbc, 0xe8, 0x81, 0x6f, 0x8b, 0x1e, 0x78, 0xb6,
+	0xfb, 0xe7, 0xef, 0x50, 0xe3, 0xd5, 0x3c, 0x3a, 0xe7, 0x1e, 0x9f, 0x9d, 0x1f, 0x03, 0x00, 0x39,
+	0xcc, 0x54, 0x72, 0x58, 0x05, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FederationServiceClient is the client API for FederationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FederationServiceClient interface {
+	ListFederatedWorkflows(ctx context.Context, in *FederatedWorkflowListRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowList, error)
+	GetFederatedWorkflow(ctx context.Context, in *FederatedWorkflowGetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)
+	FederatedWorkflowLogs(ctx context.Context, in *FederatedWorkflowLogRequest, opts ...grpc.CallOption) (FederationService_FederatedWorkflowLogsClient, error)
+}
+
+type federationServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFederationServiceClient(cc *grpc.ClientConn) FederationServiceClient {
+	return &federationServiceClient{cc}
+}
+
+func (c *federationServiceClient) ListFederatedWorkflows(ctx context.Context, in *FederatedWorkflowListRequest, opts ...grpc.CallOption) (*v1alpha1.WorkflowList, error) {
+	out := new(v1alpha1.WorkflowList)
+	err := c.cc.Invoke(ctx, "/federation.FederationService/ListFederatedWorkflows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) GetFederatedWorkflow(ctx context.Context, in *FederatedWorkflowGetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	out := new(v1alpha1.Workflow)
+	err := c.cc.Invoke(ctx, "/federation.FederationService/GetFederatedWorkflow", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *federationServiceClient) FederatedWorkflowLogs(ctx context.Context, in *FederatedWorkflowLogRequest, opts ...grpc.CallOption) (FederationService_FederatedWorkflowLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FederationService_serviceDesc.Streams[0], "/federation.FederationService/FederatedWorkflowLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &federationServiceFederatedWorkflowLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FederationService_FederatedWorkflowLogsClient interface {
+	Recv() (*FederatedLogEntry, error)
+	grpc.ClientStream
+}
+
+type federationServiceFederatedWorkflowLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *federationServiceFederatedWorkflowLogsClient) Recv() (*FederatedLogEntry, error) {
+	m := new(FederatedLogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// FederationServiceServer is the server API for FederationService service.
+type FederationServiceServer interface {
+	ListFederatedWorkflows(context.Context, *FederatedWorkflowListRequest) (*v1alpha1.WorkflowList, error)
+	GetFederatedWorkflow(context.Context, *FederatedWorkflowGetRequest) (*v1alpha1.Workflow, error)
+	FederatedWorkflowLogs(*FederatedWorkflowLogRequest, FederationService_FederatedWorkflowLogsServer) error
+}
+
+// UnimplementedFederationServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedFederationServiceServer struct {
+}
+
+func (*UnimplementedFederationServiceServer) ListFederatedWorkflows(ctx context.Context, req *FederatedWorkflowListRequest) (*v1alpha1.WorkflowList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFederatedWorkflows not implemented")
+}
+func (*UnimplementedFederationServiceServer) GetFederatedWorkflow(ctx context.Context, req *FederatedWorkflowGetRequest) (*v1alpha1.Workflow, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFederatedWorkflow not implemented")
+}
+func (*UnimplementedFederationServiceServer) FederatedWorkflowLogs(req *FederatedWorkflowLogRequest, srv FederationService_FederatedWorkflowLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method FederatedWorkflowLogs not implemented")
+}
+
+func RegisterFederationServiceServer(s *grpc.Server, srv FederationServiceServer) {
+	s.RegisterService(&_FederationService_serviceDesc, srv)
+}
+
+func _FederationService_ListFederatedWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FederatedWorkflowListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).ListFederatedWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/federation.FederationService/ListFederatedWorkflows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).ListFederatedWorkflows(ctx, req.(*FederatedWorkflowListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_GetFederatedWorkflow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FederatedWorkflowGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FederationServiceServer).GetFederatedWorkflow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/federation.FederationService/GetFederatedWorkflow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FederationServiceServer).GetFederatedWorkflow(ctx, req.(*FederatedWorkflowGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FederationService_FederatedWorkflowLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FederatedWorkflowLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FederationServiceServer).FederatedWorkflowLogs(m, &federationServiceFederatedWorkflowLogsServer{stream})
+}
+
+type FederationService_FederatedWorkflowLogsServer interface {
+	Send(*FederatedLogEntry) error
+	grpc.ServerStream
+}
+
+type federationServiceFederatedWorkflowLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *federationServiceFederatedWorkflowLogsServer) Send(m *FederatedLogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _FederationService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "federation.FederationService",
+	HandlerType: (*FederationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListFederatedWorkflows",
+			Handler:    _FederationService_ListFederatedWorkflows_Handler,
+		},
+		{
+			MethodName: "GetFederatedWorkflow",
+			Handler:    _FederationService_GetFederatedWorkflow_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FederatedWorkflowLogs",
+			Handler:       _FederationService_FederatedWorkflowLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/apiclient/federation/federation.proto",
+}
+
+func (m *FederatedWorkflowListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FederatedWorkflowListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FederatedWorkflowListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ListOptions != nil {
+		{
+			size, err := m.ListOptions.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintFederation(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FederatedWorkflowGetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FederatedWorkflowGetRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FederatedWorkflowGetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Cluster) > 0 {
+		i -= len(m.Cluster)
+		copy(dAtA[i:], m.Cluster)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Cluster)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FederatedWorkflowLogRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FederatedWorkflowLogRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FederatedWorkflowLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Container) > 0 {
+		i -= len(m.Container)
+		copy(dAtA[i:], m.Container)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Container)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.PodName) > 0 {
+		i -= len(m.PodName)
+		copy(dAtA[i:], m.PodName)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.PodName)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Cluster) > 0 {
+		i -= len(m.Cluster)
+		copy(dAtA[i:], m.Cluster)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Cluster)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FederatedLogEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FederatedLogEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FederatedLogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Content) > 0 {
+		i -= len(m.Content)
+		copy(dAtA[i:], m.Content)
+		i = encodeVarintFederation(dAtA, i, uint64(len(m.Content)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFederation(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFederation(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *FederatedWorkflowListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	if m.ListOptions != nil {
+		l = m.ListOptions.Size()
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FederatedWorkflowGetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Cluster)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FederatedWorkflowLogRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Cluster)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	l = len(m.PodName)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	l = len(m.Container)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FederatedLogEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Content)
+	if l > 0 {
+		n += 1 + l + sovFederation(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovFederation(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFederation(x uint64) (n int) {
+	return sovFederation(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *FederatedWorkflowListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFederation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FederatedWorkflowListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FederatedWorkflowListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ListOptions == nil {
+				m.ListOptions = &v1.ListOptions{}
+			}
+			if err := m.ListOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFederation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FederatedWorkflowGetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFederation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FederatedWorkflowGetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FederatedWorkflowGetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cluster = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFederation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FederatedWorkflowLogRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFederation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FederatedWorkflowLogRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FederatedWorkflowLogRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cluster", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cluster = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PodName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PodName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Container", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Container = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFederation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FederatedLogEntry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFederation
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FederatedLogEntry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FederatedLogEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Content", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFederation
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Content = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFederation(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFederation
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipFederation(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowFederation
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFederation
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthFederation
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupFederation
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthFederation
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthFederation        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowFederation          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupFederation = fmt.Errorf("proto: unexpected end of group")
+)
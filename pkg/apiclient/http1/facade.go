@@ -163,11 +163,12 @@ func errFromResponse(r *http.Response) error {
 func parseHeaders(headerStrings []string) (http.Header, error) {
 	headers := http.Header{}
 	for _, kv := range headerStrings {
-		items := strings.Split(kv, ":")
-		if len(items)%2 == 1 {
+		// only split on the first colon, as values such as service account user names contain colons
+		items := strings.SplitN(kv, ":", 2)
+		if len(items) != 2 {
 			return nil, fmt.Errorf("additional headers must be colon(:)-separated: %s", kv)
 		}
-		headers.Add(items[0], items[1])
+		headers.Add(items[0], strings.TrimSpace(items[1]))
 	}
 	return headers, nil
 }
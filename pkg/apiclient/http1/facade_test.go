@@ -14,3 +14,13 @@ func TestFacade_do(t *testing.T) {
 		assert.Equal(t, "http://my-url/my-ns/?labels.foo=1", u.String())
 	}
 }
+
+func Test_parseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"foo: bar", "Impersonate-User:system:serviceaccount:argo:my-sa"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "bar", headers.Get("foo"))
+		assert.Equal(t, "system:serviceaccount:argo:my-sa", headers.Get("Impersonate-User"))
+	}
+	_, err = parseHeaders([]string{"foo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,12 @@
+package http1
+
+import (
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
+)
+
+type federatedLogsClient struct{ serverSentEventsClient }
+
+func (f *federatedLogsClient) Recv() (*federationpkg.FederatedLogEntry, error) {
+	v := &federationpkg.FederatedLogEntry{}
+	return v, f.RecvEvent(v)
+}
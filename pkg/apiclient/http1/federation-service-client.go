@@ -0,0 +1,30 @@
+package http1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type FederationServiceClient = Facade
+
+func (h FederationServiceClient) ListFederatedWorkflows(_ context.Context, in *federationpkg.FederatedWorkflowListRequest, _ ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	out := &wfv1.WorkflowList{}
+	return out, h.Get(in, out, "/api/v1/federation/workflows/{namespace}")
+}
+
+func (h FederationServiceClient) GetFederatedWorkflow(_ context.Context, in *federationpkg.FederatedWorkflowGetRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	out := &wfv1.Workflow{}
+	return out, h.Get(in, out, "/api/v1/federation/workflows/{cluster}/{namespace}/{name}")
+}
+
+func (h FederationServiceClient) FederatedWorkflowLogs(ctx context.Context, in *federationpkg.FederatedWorkflowLogRequest, _ ...grpc.CallOption) (federationpkg.FederationService_FederatedWorkflowLogsClient, error) {
+	reader, err := h.EventStreamReader(in, "/api/v1/federation/workflows/{cluster}/{namespace}/{name}/log")
+	if err != nil {
+		return nil, err
+	}
+	return &federatedLogsClient{serverSentEventsClient{ctx, reader}}, nil
+}
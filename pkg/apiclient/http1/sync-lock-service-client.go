@@ -0,0 +1,21 @@
+package http1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
+)
+
+type SyncLockServiceClient = Facade
+
+func (h SyncLockServiceClient) ListSyncLocks(_ context.Context, in *syncpkg.ListSyncLocksRequest, _ ...grpc.CallOption) (*syncpkg.LockList, error) {
+	out := &syncpkg.LockList{}
+	return out, h.Get(in, out, "/api/v1/sync-locks/{namespace}")
+}
+
+func (h SyncLockServiceClient) ReleaseSyncLock(_ context.Context, in *syncpkg.ReleaseSyncLockRequest, _ ...grpc.CallOption) (*syncpkg.ReleaseSyncLockResponse, error) {
+	out := &syncpkg.ReleaseSyncLockResponse{}
+	return out, h.Post(in, out, "/api/v1/sync-locks/{namespace}/release")
+}
@@ -3,35 +3,85 @@ package apiclient
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	"github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
 )
 
-type offlineClient struct{}
+type offlineClient struct {
+	wftmplGetter  templateresolution.WorkflowTemplateNamespacedGetter
+	cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter
+}
 
-var NotImplError error = fmt.Errorf("Not implemented for offline client, only valid for kind '--kinds=workflows'")
+var NotImplError error = fmt.Errorf("Not implemented for offline client")
 
 var _ Client = &offlineClient{}
 
-func newOfflineClient() (context.Context, Client, error) {
-	return context.Background(), &offlineClient{}, nil
+// newOfflineClient returns a client that validates without a cluster, resolving template references against the
+// (cluster) workflow templates in the given files and directories
+func newOfflineClient(paths []string) (context.Context, Client, error) {
+	wftmplGetter := offlineWorkflowTemplateNamespacedGetter{}
+	cwftmplGetter := offlineClusterWorkflowTemplateNamespacedGetter{}
+	for _, path := range paths {
+		if path == "-" {
+			continue // stdin can only be read once
+		}
+		err := filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !offlineFileExt[filepath.Ext(path)] {
+				return nil
+			}
+			data, err := ioutil.ReadFile(filepath.Clean(path))
+			if err != nil {
+				return err
+			}
+			for _, pr := range common.ParseObjects(data, false) {
+				switch v := pr.Object.(type) {
+				case *wfv1.ClusterWorkflowTemplate:
+					cwftmplGetter[v.Name] = v
+				case *wfv1.WorkflowTemplate:
+					wftmplGetter[v.Name] = v
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return context.Background(), &offlineClient{wftmplGetter: wftmplGetter, cwftmplGetter: cwftmplGetter}, nil
+}
+
+var offlineFileExt = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
 }
 
 func (a *offlineClient) NewWorkflowServiceClient() workflowpkg.WorkflowServiceClient {
-	return &errorTranslatingWorkflowServiceClient{OfflineWorkflowServiceClient{}}
+	return &errorTranslatingWorkflowServiceClient{OfflineWorkflowServiceClient{a.wftmplGetter, a.cwftmplGetter}}
 }
 
 func (a *offlineClient) NewCronWorkflowServiceClient() (cronworkflow.CronWorkflowServiceClient, error) {
-	return nil, NotImplError
+	return &errorTranslatingCronWorkflowServiceClient{&offlineCronWorkflowServiceClient{a.wftmplGetter, a.cwftmplGetter}}, nil
 }
 
 func (a *offlineClient) NewWorkflowTemplateServiceClient() (workflowtemplate.WorkflowTemplateServiceClient, error) {
-	return nil, NotImplError
+	return &errorTranslatingWorkflowTemplateServiceClient{&offlineWorkflowTemplateServiceClient{a.wftmplGetter, a.cwftmplGetter}}, nil
 }
 
 func (a *offlineClient) NewArchivedWorkflowServiceClient() (workflowarchivepkg.ArchivedWorkflowServiceClient, error) {
@@ -42,6 +92,34 @@ func (a *offlineClient) NewInfoServiceClient() (infopkg.InfoServiceClient, error
 	return nil, NotImplError
 }
 
-func (a *offlineClient) NewClusterWorkflowTemplateServiceClient() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
+func (a *offlineClient) NewFederationServiceClient() (federationpkg.FederationServiceClient, error) {
+	return nil, NotImplError
+}
+
+func (a *offlineClient) NewSyncLockServiceClient() (syncpkg.SyncLockServiceClient, error) {
 	return nil, NotImplError
 }
+
+func (a *offlineClient) NewClusterWorkflowTemplateServiceClient() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
+	return &errorTranslatingWorkflowClusterTemplateServiceClient{&offlineClusterWorkflowTemplateServiceClient{a.wftmplGetter, a.cwftmplGetter}}, nil
+}
+
+// offlineWorkflowTemplateNamespacedGetter gets the workflow templates by name, regardless of their namespace, as the
+// namespace is not known offline
+type offlineWorkflowTemplateNamespacedGetter map[string]*wfv1.WorkflowTemplate
+
+func (w offlineWorkflowTemplateNamespacedGetter) Get(name string) (*wfv1.WorkflowTemplate, error) {
+	if wftmpl, ok := w[name]; ok {
+		return wftmpl.DeepCopy(), nil
+	}
+	return nil, fmt.Errorf("workflow template %q not found in the linted files", name)
+}
+
+type offlineClusterWorkflowTemplateNamespacedGetter map[string]*wfv1.ClusterWorkflowTemplate
+
+func (o offlineClusterWorkflowTemplateNamespacedGetter) Get(name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	if cwftmpl, ok := o[name]; ok {
+		return cwftmpl.DeepCopy(), nil
+	}
+	return nil, fmt.Errorf("cluster workflow template %q not found in the linted files", name)
+}
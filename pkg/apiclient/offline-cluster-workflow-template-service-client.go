@@ -0,0 +1,47 @@
+package apiclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	clusterworkflowtmplpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+type offlineClusterWorkflowTemplateServiceClient struct {
+	wftmplGetter  templateresolution.WorkflowTemplateNamespacedGetter
+	cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter
+}
+
+var _ clusterworkflowtmplpkg.ClusterWorkflowTemplateServiceClient = &offlineClusterWorkflowTemplateServiceClient{}
+
+func (o *offlineClusterWorkflowTemplateServiceClient) CreateClusterWorkflowTemplate(context.Context, *clusterworkflowtmplpkg.ClusterWorkflowTemplateCreateRequest, ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineClusterWorkflowTemplateServiceClient) GetClusterWorkflowTemplate(context.Context, *clusterworkflowtmplpkg.ClusterWorkflowTemplateGetRequest, ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineClusterWorkflowTemplateServiceClient) ListClusterWorkflowTemplates(context.Context, *clusterworkflowtmplpkg.ClusterWorkflowTemplateListRequest, ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplateList, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineClusterWorkflowTemplateServiceClient) UpdateClusterWorkflowTemplate(context.Context, *clusterworkflowtmplpkg.ClusterWorkflowTemplateUpdateRequest, ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineClusterWorkflowTemplateServiceClient) DeleteClusterWorkflowTemplate(context.Context, *clusterworkflowtmplpkg.ClusterWorkflowTemplateDeleteRequest, ...grpc.CallOption) (*clusterworkflowtmplpkg.ClusterWorkflowTemplateDeleteResponse, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineClusterWorkflowTemplateServiceClient) LintClusterWorkflowTemplate(_ context.Context, req *clusterworkflowtmplpkg.ClusterWorkflowTemplateLintRequest, _ ...grpc.CallOption) (*v1alpha1.ClusterWorkflowTemplate, error) {
+	err := validate.ValidateClusterWorkflowTemplate(nil, o.cwftmplGetter, req.Template, validate.ValidateOpts{Lint: true})
+	if err != nil {
+		return nil, err
+	}
+	return req.Template, nil
+}
@@ -0,0 +1,55 @@
+package apiclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+type offlineCronWorkflowServiceClient struct {
+	wftmplGetter  templateresolution.WorkflowTemplateNamespacedGetter
+	cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter
+}
+
+var _ cronworkflowpkg.CronWorkflowServiceClient = &offlineCronWorkflowServiceClient{}
+
+func (o *offlineCronWorkflowServiceClient) LintCronWorkflow(_ context.Context, req *cronworkflowpkg.LintCronWorkflowRequest, _ ...grpc.CallOption) (*v1alpha1.CronWorkflow, error) {
+	err := validate.ValidateCronWorkflow(o.wftmplGetter, o.cwftmplGetter, req.CronWorkflow)
+	if err != nil {
+		return nil, err
+	}
+	return req.CronWorkflow, nil
+}
+
+func (o *offlineCronWorkflowServiceClient) CreateCronWorkflow(context.Context, *cronworkflowpkg.CreateCronWorkflowRequest, ...grpc.CallOption) (*v1alpha1.CronWorkflow, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineCronWorkflowServiceClient) ListCronWorkflows(context.Context, *cronworkflowpkg.ListCronWorkflowsRequest, ...grpc.CallOption) (*v1alpha1.CronWorkflowList, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineCronWorkflowServiceClient) GetCronWorkflow(context.Context, *cronworkflowpkg.GetCronWorkflowRequest, ...grpc.CallOption) (*v1alpha1.CronWorkflow, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineCronWorkflowServiceClient) UpdateCronWorkflow(context.Context, *cronworkflowpkg.UpdateCronWorkflowRequest, ...grpc.CallOption) (*v1alpha1.CronWorkflow, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineCronWorkflowServiceClient) DeleteCronWorkflow(context.Context, *cronworkflowpkg.DeleteCronWorkflowRequest, ...grpc.CallOption) (*cronworkflowpkg.CronWorkflowDeletedResponse, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineCronWorkflowServiceClient) ResumeCronWorkflow(context.Context, *cronworkflowpkg.CronWorkflowResumeRequest, ...grpc.CallOption) (*v1alpha1.CronWorkflow, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineCronWorkflowServiceClient) SuspendCronWorkflow(context.Context, *cronworkflowpkg.CronWorkflowSuspendRequest, ...grpc.CallOption) (*v1alpha1.CronWorkflow, error) {
+	return nil, OfflineErr
+}
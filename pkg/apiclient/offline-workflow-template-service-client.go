@@ -0,0 +1,47 @@
+package apiclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+type offlineWorkflowTemplateServiceClient struct {
+	wftmplGetter  templateresolution.WorkflowTemplateNamespacedGetter
+	cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter
+}
+
+var _ workflowtemplatepkg.WorkflowTemplateServiceClient = &offlineWorkflowTemplateServiceClient{}
+
+func (o *offlineWorkflowTemplateServiceClient) CreateWorkflowTemplate(context.Context, *workflowtemplatepkg.WorkflowTemplateCreateRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineWorkflowTemplateServiceClient) GetWorkflowTemplate(context.Context, *workflowtemplatepkg.WorkflowTemplateGetRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineWorkflowTemplateServiceClient) ListWorkflowTemplates(context.Context, *workflowtemplatepkg.WorkflowTemplateListRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplateList, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineWorkflowTemplateServiceClient) UpdateWorkflowTemplate(context.Context, *workflowtemplatepkg.WorkflowTemplateUpdateRequest, ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineWorkflowTemplateServiceClient) DeleteWorkflowTemplate(context.Context, *workflowtemplatepkg.WorkflowTemplateDeleteRequest, ...grpc.CallOption) (*workflowtemplatepkg.WorkflowTemplateDeleteResponse, error) {
+	return nil, OfflineErr
+}
+
+func (o *offlineWorkflowTemplateServiceClient) LintWorkflowTemplate(_ context.Context, req *workflowtemplatepkg.WorkflowTemplateLintRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowTemplate, error) {
+	err := validate.ValidateWorkflowTemplate(o.wftmplGetter, o.cwftmplGetter, req.Template, validate.ValidateOpts{Lint: true})
+	if err != nil {
+		return nil, err
+	}
+	return req.Template, nil
+}
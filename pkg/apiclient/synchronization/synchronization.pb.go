@@ -0,0 +1,1865 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: pkg/apiclient/synchronization/synchronization.proto
+
+// Sync Lock Service
+//
+// Sync Lock Service serves the status of the semaphores and mutexes of a namespace, built from the workflows holding or
+// waiting for them, and forced releases of them.
+
+package synchronization
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type ListSyncLocksRequest struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListSyncLocksRequest) Reset()         { *m = ListSyncLocksRequest{} }
+func (m *ListSyncLocksRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSyncLocksRequest) ProtoMessage()    {}
+func (*ListSyncLocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_167b15efdca09153, []int{0}
+}
+func (m *ListSyncLocksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListSyncLocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListSyncLocksRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ListSyncLocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSyncLocksRequest.Merge(m, src)
+}
+func (m *ListSyncLocksRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListSyncLocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSyncLocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListSyncLocksRequest proto.InternalMessageInfo
+
+func (m *ListSyncLocksRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+// LockUser is a workflow, or a node of a workflow, holding or waiting for a lock
+type LockUser struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Workflow  string `protobuf:"bytes,2,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// NodeID is empty for a workflow-level lock
+	NodeId string `protobuf:"bytes,3,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Weight int64  `protobuf:"varint,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Since is when the workflow or node started, i.e. started to wait for the lock
+	Since *v1.Time `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`
+	// Duration is how long, in seconds, the workflow or node has held, or waited for, the lock
+	Duration             int64    `protobuf:"varint,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockUser) Reset()         { *m = LockUser{} }
+func (m *LockUser) String() string { return proto.CompactTextString(m) }
+func (*LockUser) ProtoMessage()    {}
+func (*LockUser) Descriptor() ([]byte, []int) {
+	return fileDescriptor_167b15efdca09153, []int{1}
+}
+func (m *LockUser) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockUser) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockUser.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LockUser) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockUser.Merge(m, src)
+}
+func (m *LockUser) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockUser) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockUser.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockUser proto.InternalMessageInfo
+
+func (m *LockUser) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *LockUser) GetWorkflow() string {
+	if m != nil {
+		return m.Workflow
+	}
+	return ""
+}
+
+func (m *LockUser) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *LockUser) GetWeight() int64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *LockUser) GetSince() *v1.Time {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *LockUser) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+// LockStatus is the status of a semaphore or mutex
+type LockStatus struct {
+	// Name is the encoded name of the lock, e.g. `argo/ConfigMap/my-config/workflow` or `argo/Mutex/my-mutex`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Type is either "Semaphore" or "Mutex"
+	Type                 string      `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Holders              []*LockUser `protobuf:"bytes,3,rep,name=holders,proto3" json:"holders,omitempty"`
+	Waiting              []*LockUser `protobuf:"bytes,4,rep,name=waiting,proto3" json:"waiting,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *LockStatus) Reset()         { *m = LockStatus{} }
+func (m *LockStatus) String() string { return proto.CompactTextString(m) }
+func (*LockStatus) ProtoMessage()    {}
+func (*LockStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_167b15efdca09153, []int{2}
+}
+func (m *LockStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LockStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockStatus.Merge(m, src)
+}
+func (m *LockStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockStatus proto.InternalMessageInfo
+
+func (m *LockStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LockStatus) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LockStatus) GetHolders() []*LockUser {
+	if m != nil {
+		return m.Holders
+	}
+	return nil
+}
+
+func (m *LockStatus) GetWaiting() []*LockUser {
+	if m != nil {
+		return m.Waiting
+	}
+	return nil
+}
+
+type LockList struct {
+	Items                []*LockStatus `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *LockList) Reset()         { *m = LockList{} }
+func (m *LockList) String() string { return proto.CompactTextString(m) }
+func (*LockList) ProtoMessage()    {}
+func (*LockList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_167b15efdca09153, []int{3}
+}
+func (m *LockList) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LockList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LockList.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LockList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockList.Merge(m, src)
+}
+func (m *LockList) XXX_Size() int {
+	return m.Size()
+}
+func (m *LockList) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockList proto.InternalMessageInfo
+
+func (m *LockList) GetItems() []*LockStatus {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type ReleaseSyncLockRequest struct {
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Lock is the encoded name of the lock, e.g. `argo/ConfigMap/my-config/workflow`
+	Lock                 string   `protobuf:"bytes,2,opt,name=lock,proto3" json:"lock,omitempty"`
+	Workflow             string   `protobuf:"bytes,3,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseSyncLockRequest) Reset()         { *m = ReleaseSyncLockRequest{} }
+func (m *ReleaseSyncLockRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseSyncLockRequest) ProtoMessage()    {}
+func (*ReleaseSyncLockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_167b15efdca09153, []int{4}
+}
+func (m *ReleaseSyncLockRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseSyncLockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseSyncLockRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleaseSyncLockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseSyncLockRequest.Merge(m, src)
+}
+func (m *ReleaseSyncLockRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseSyncLockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseSyncLockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseSyncLockRequest proto.InternalMessageInfo
+
+func (m *ReleaseSyncLockRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ReleaseSyncLockRequest) GetLock() string {
+	if m != nil {
+		return m.Lock
+	}
+	return ""
+}
+
+func (m *ReleaseSyncLockRequest) GetWorkflow() string {
+	if m != nil {
+		return m.Workflow
+	}
+	return ""
+}
+
+type ReleaseSyncLockResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseSyncLockResponse) Reset()         { *m = ReleaseSyncLockResponse{} }
+func (m *ReleaseSyncLockResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseSyncLockResponse) ProtoMessage()    {}
+func (*ReleaseSyncLockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_167b15efdca09153, []int{5}
+}
+func (m *ReleaseSyncLockResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseSyncLockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseSyncLockResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleaseSyncLockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseSyncLockResponse.Merge(m, src)
+}
+func (m *ReleaseSyncLockResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseSyncLockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseSyncLockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseSyncLockResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*ListSyncLocksRequest)(nil), "synchronization.ListSyncLocksRequest")
+	proto.RegisterType((*LockUser)(nil), "synchronization.LockUser")
+	proto.RegisterType((*LockStatus)(nil), "synchronization.LockStatus")
+	proto.RegisterType((*LockList)(nil), "synchronization.LockList")
+	proto.RegisterType((*ReleaseSyncLockRequest)(nil), "synchronization.ReleaseSyncLockRequest")
+	proto.RegisterType((*ReleaseSyncLockResponse)(nil), "synchronization.ReleaseSyncLockResponse")
+}
+
+func init() {
+	proto.RegisterFile("pkg/apiclient/synchronization/synchronization.proto", fileDescriptor_167b15efdca09153)
+}
+
+var fileDescriptor_167b15efdca09153 = []byte{
+	// 553 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xd6, 0xe6, 0x8f, 0x76, 0x2b, 0x14, 0x69, 0x85, 0x8a, 0x1b, 0xaa, 0x28, 0xb2, 0x44, 0x89,
+	0x8a, 0x6a, 0x2b, 0x49, 0x0f, 0x08, 0x09, 0xa9, 0xe2, 0x86, 0x54, 0x71, 0x70, 0xe0, 0xc2, 0x6d,
+	0xeb, 0x4c, 0x9d, 0xc5, 0xf6, 0xae, 0xd9, 0xdd, 0x24, 0x0a, 0x88, 0x0b, 0xaf, 0xc0, 0x81, 0x1b,
+	0x47, 0x9e, 0x85, 0x23, 0x82, 0x17, 0x40, 0x11, 0x0f, 0x82, 0x76, 0x6d, 0x07, 0xf2, 0xa3, 0xb4,
+	0xb7, 0x99, 0x6f, 0xbf, 0x6f, 0x3d, 0xdf, 0xcc, 0x8e, 0xf1, 0x20, 0x8b, 0x23, 0x9f, 0x66, 0x2c,
+	0x4c, 0x18, 0x70, 0xed, 0xab, 0x39, 0x0f, 0xc7, 0x52, 0x70, 0xf6, 0x9e, 0x6a, 0x26, 0xf8, 0x7a,
+	0xee, 0x65, 0x52, 0x68, 0x41, 0x9a, 0x6b, 0x70, 0xeb, 0x38, 0x12, 0x22, 0x4a, 0xc0, 0x5c, 0xe4,
+	0x53, 0xce, 0x85, 0xb6, 0xb0, 0xca, 0xe9, 0xad, 0xf3, 0xf8, 0x89, 0xf2, 0x98, 0x30, 0xa7, 0x29,
+	0x0d, 0xc7, 0x8c, 0x83, 0x9c, 0xfb, 0xc5, 0x77, 0x95, 0x9f, 0x82, 0xa6, 0xfe, 0xb4, 0xe7, 0x47,
+	0xc0, 0x41, 0x52, 0x0d, 0xa3, 0x5c, 0xe5, 0x9e, 0xe3, 0x7b, 0x97, 0x4c, 0xe9, 0xe1, 0x9c, 0x87,
+	0x97, 0x22, 0x8c, 0x55, 0x00, 0xef, 0x26, 0xa0, 0x34, 0x39, 0xc6, 0xfb, 0x9c, 0xa6, 0xa0, 0x32,
+	0x1a, 0x82, 0x83, 0x3a, 0xa8, 0xbb, 0x1f, 0xfc, 0x03, 0xdc, 0x9f, 0x08, 0xef, 0x19, 0xfa, 0x6b,
+	0x05, 0x72, 0x37, 0x95, 0xb4, 0xf0, 0xde, 0x4c, 0xc8, 0xf8, 0x3a, 0x11, 0x33, 0xa7, 0x62, 0x0f,
+	0x97, 0x39, 0x39, 0xc4, 0x0d, 0x2e, 0x46, 0xf0, 0x62, 0xe4, 0x54, 0xed, 0x49, 0x91, 0x19, 0x7c,
+	0x06, 0x2c, 0x1a, 0x6b, 0xa7, 0xd6, 0x41, 0xdd, 0x6a, 0x50, 0x64, 0xe4, 0x02, 0xd7, 0x15, 0xe3,
+	0x21, 0x38, 0xf5, 0x0e, 0xea, 0x1e, 0xf4, 0x4f, 0xbd, 0xdc, 0xb2, 0xf7, 0xbf, 0x65, 0x2f, 0x8b,
+	0x23, 0x03, 0x28, 0xcf, 0x58, 0xf6, 0xa6, 0x3d, 0xef, 0x15, 0x4b, 0x21, 0xc8, 0x85, 0xa6, 0x9a,
+	0xd1, 0x44, 0xda, 0xbe, 0x39, 0x0d, 0x7b, 0xf7, 0x32, 0x77, 0xbf, 0x22, 0x8c, 0x8d, 0xa9, 0xa1,
+	0xa6, 0x7a, 0xa2, 0x08, 0xc1, 0x35, 0xe3, 0xa2, 0x70, 0x64, 0x63, 0x83, 0xe9, 0x79, 0x06, 0x85,
+	0x11, 0x1b, 0x93, 0x01, 0xbe, 0x33, 0x16, 0xc9, 0x08, 0xa4, 0x72, 0xaa, 0x9d, 0x6a, 0xf7, 0xa0,
+	0x7f, 0xe4, 0xad, 0xcf, 0xb3, 0x6c, 0x55, 0x50, 0x32, 0x8d, 0x68, 0x46, 0x99, 0x66, 0x3c, 0x72,
+	0x6a, 0x37, 0x8a, 0x0a, 0xa6, 0xfb, 0x2c, 0x6f, 0xba, 0x99, 0x17, 0xe9, 0xe1, 0x3a, 0xd3, 0x90,
+	0x2a, 0x07, 0x59, 0xf9, 0x83, 0xad, 0xf2, 0xdc, 0x49, 0x90, 0x33, 0xdd, 0x6b, 0x7c, 0x18, 0x40,
+	0x02, 0x54, 0x41, 0x39, 0xed, 0x5b, 0x0d, 0xdb, 0x98, 0x4e, 0x44, 0x18, 0x97, 0xa6, 0x4d, 0xbc,
+	0x32, 0xd5, 0xea, 0xea, 0x54, 0xdd, 0x23, 0x7c, 0x7f, 0xe3, 0x3b, 0x2a, 0x13, 0x5c, 0x41, 0xff,
+	0x5b, 0x05, 0x37, 0x4b, 0x70, 0x08, 0x72, 0xca, 0x42, 0x20, 0x73, 0x7c, 0x77, 0xe5, 0x05, 0x92,
+	0x87, 0x9b, 0x5e, 0xb6, 0xbc, 0xd0, 0xd6, 0xf6, 0x8e, 0x19, 0xaa, 0x7b, 0xf2, 0xe9, 0xd7, 0x9f,
+	0xcf, 0x95, 0x0e, 0x69, 0xdb, 0x55, 0x99, 0xf6, 0xec, 0x82, 0x9d, 0x99, 0xc2, 0x95, 0xff, 0x61,
+	0x69, 0xec, 0x23, 0xf9, 0x82, 0x70, 0x73, 0xad, 0x54, 0xf2, 0x68, 0xe3, 0xda, 0xed, 0x4d, 0x6b,
+	0x75, 0x6f, 0x26, 0xe6, 0xae, 0xdd, 0x9e, 0x2d, 0xe7, 0xb1, 0x7b, 0xb2, 0xbb, 0x1c, 0x5f, 0xe6,
+	0xfa, 0xa7, 0xe8, 0xf4, 0xf9, 0xcb, 0xef, 0x8b, 0x36, 0xfa, 0xb1, 0x68, 0xa3, 0xdf, 0x8b, 0x36,
+	0x7a, 0x73, 0x11, 0x31, 0x3d, 0x9e, 0x5c, 0x79, 0xa1, 0x48, 0x7d, 0x2a, 0x23, 0x91, 0x49, 0xf1,
+	0xd6, 0x06, 0x67, 0x65, 0xd3, 0x95, 0xbf, 0xf3, 0x0f, 0x73, 0xd5, 0xb0, 0xdb, 0x3e, 0xf8, 0x3b,
+	0x00, 0x18, 0x76, 0xe2, 0x4d, 0x89, 0x04, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SyncLockServiceClient is the client API for SyncLockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SyncLockServiceClient interface {
+	ListSyncLocks(ctx context.Context, in *ListSyncLocksRequest, opts ...grpc.CallOption) (*LockList, error)
+	// ReleaseSyncLock forces a workflow, and its nodes, to release a lock. The workflow controller releases the lock
+	// once it reconciles the workflow.
+	ReleaseSyncLock(ctx context.Context, in *ReleaseSyncLockRequest, opts ...grpc.CallOption) (*ReleaseSyncLockResponse, error)
+}
+
+type syncLockServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSyncLockServiceClient(cc *grpc.ClientConn) SyncLockServiceClient {
+	return &syncLockServiceClient{cc}
+}
+
+func (c *syncLockServiceClient) ListSyncLocks(ctx context.Context, in *ListSyncLocksRequest, opts ...grpc.CallOption) (*LockList, error) {
+	out := new(LockList)
+	err := c.cc.Invoke(ctx, "/synchronization.SyncLockService/ListSyncLocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *syncLockServiceClient) ReleaseSyncLock(ctx context.Context, in *ReleaseSyncLockRequest, opts ...grpc.CallOption) (*ReleaseSyncLockResponse, error) {
+	out := new(ReleaseSyncLockResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.SyncLockService/ReleaseSyncLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SyncLockServiceServer is the server API for SyncLockService service.
+type SyncLockServiceServer interface {
+	ListSyncLocks(context.Context, *ListSyncLocksRequest) (*LockList, error)
+	// ReleaseSyncLock forces a workflow, and its nodes, to release a lock. The workflow controller releases the lock
+	// once it reconciles the workflow.
+	ReleaseSyncLock(context.Context, *ReleaseSyncLockRequest) (*ReleaseSyncLockResponse, error)
+}
+
+// UnimplementedSyncLockServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSyncLockServiceServer struct {
+}
+
+func (*UnimplementedSyncLockServiceServer) ListSyncLocks(ctx context.Context, req *ListSyncLocksRequest) (*LockList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSyncLocks not implemented")
+}
+func (*UnimplementedSyncLockServiceServer) ReleaseSyncLock(ctx context.Context, req *ReleaseSyncLockRequest) (*ReleaseSyncLockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseSyncLock not implemented")
+}
+
+func RegisterSyncLockServiceServer(s *grpc.Server, srv SyncLockServiceServer) {
+	s.RegisterService(&_SyncLockService_serviceDesc, srv)
+}
+
+func _SyncLockService_ListSyncLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSyncLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncLockServiceServer).ListSyncLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.SyncLockService/ListSyncLocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncLockServiceServer).ListSyncLocks(ctx, req.(*ListSyncLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SyncLockService_ReleaseSyncLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSyncLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SyncLockServiceServer).ReleaseSyncLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.SyncLockService/ReleaseSyncLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SyncLockServiceServer).ReleaseSyncLock(ctx, req.(*ReleaseSyncLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SyncLockService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "synchronization.SyncLockService",
+	HandlerType: (*SyncLockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSyncLocks",
+			Handler:    _SyncLockService_ListSyncLocks_Handler,
+		},
+		{
+			MethodName: "ReleaseSyncLock",
+			Handler:    _SyncLockService_ReleaseSyncLock_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/apiclient/synchronization/synchronization.proto",
+}
+
+func (m *ListSyncLocksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSyncLocksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListSyncLocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockUser) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockUser) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockUser) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Duration != 0 {
+		i = encodeVarintSynchronization(dAtA, i, uint64(m.Duration))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Since != nil {
+		{
+			size, err := m.Since.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSynchronization(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Weight != 0 {
+		i = encodeVarintSynchronization(dAtA, i, uint64(m.Weight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.NodeId)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Workflow) > 0 {
+		i -= len(m.Workflow)
+		copy(dAtA[i:], m.Workflow)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Workflow)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Waiting) > 0 {
+		for iNdEx := len(m.Waiting) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Waiting[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSynchronization(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Holders) > 0 {
+		for iNdEx := len(m.Holders) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Holders[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSynchronization(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LockList) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LockList) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LockList) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSynchronization(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseSyncLockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseSyncLockRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseSyncLockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Workflow) > 0 {
+		i -= len(m.Workflow)
+		copy(dAtA[i:], m.Workflow)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Workflow)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Lock) > 0 {
+		i -= len(m.Lock)
+		copy(dAtA[i:], m.Lock)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Lock)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintSynchronization(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseSyncLockResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseSyncLockResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseSyncLockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSynchronization(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSynchronization(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ListSyncLocksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LockUser) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	l = len(m.Workflow)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	if m.Weight != 0 {
+		n += 1 + sovSynchronization(uint64(m.Weight))
+	}
+	if m.Since != nil {
+		l = m.Since.Size()
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	if m.Duration != 0 {
+		n += 1 + sovSynchronization(uint64(m.Duration))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LockStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	if len(m.Holders) > 0 {
+		for _, e := range m.Holders {
+			l = e.Size()
+			n += 1 + l + sovSynchronization(uint64(l))
+		}
+	}
+	if len(m.Waiting) > 0 {
+		for _, e := range m.Waiting {
+			l = e.Size()
+			n += 1 + l + sovSynchronization(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LockList) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovSynchronization(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReleaseSyncLockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	l = len(m.Lock)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	l = len(m.Workflow)
+	if l > 0 {
+		n += 1 + l + sovSynchronization(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReleaseSyncLockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovSynchronization(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSynchronization(x uint64) (n int) {
+	return sovSynchronization(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ListSyncLocksRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListSyncLocksRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListSyncLocksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSynchronization(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockUser) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockUser: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockUser: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workflow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Workflow = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			m.Weight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Weight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Since == nil {
+				m.Since = &v1.Time{}
+			}
+			if err := m.Since.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
+			}
+			m.Duration = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Duration |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSynchronization(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Holders", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Holders = append(m.Holders, &LockUser{})
+			if err := m.Holders[len(m.Holders)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Waiting", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Waiting = append(m.Waiting, &LockUser{})
+			if err := m.Waiting[len(m.Waiting)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSynchronization(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LockList) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LockList: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LockList: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &LockStatus{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSynchronization(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseSyncLockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseSyncLockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseSyncLockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lock", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lock = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workflow", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Workflow = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSynchronization(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseSyncLockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseSyncLockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseSyncLockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSynchronization(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSynchronization
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSynchronization(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSynchronization
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSynchronization
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSynchronization
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSynchronization
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSynchronization
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSynchronization        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSynchronization          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSynchronization = fmt.Errorf("proto: unexpected end of group")
+)
@@ -252,10 +252,14 @@ func (m *WorkflowListRequest) GetFields() string {
 }
 
 type WorkflowResubmitRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Memoized             bool     `protobuf:"varint,3,opt,name=memoized,proto3" json:"memoized,omitempty"`
-	Parameters           []string `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Name       string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Memoized   bool     `protobuf:"varint,3,opt,name=memoized,proto3" json:"memoized,omitempty"`
+	Parameters []string `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// carryOverLabels are globs of the keys of the labels that carry over, or all of them if there are none
+	CarryOverLabels []string `protobuf:"bytes,6,rep,name=carryOverLabels,proto3" json:"carryOverLabels,omitempty"`
+	// carryOverAnnotations are globs of the keys of the annotations that carry over, or all of them if there are none
+	CarryOverAnnotations []string `protobuf:"bytes,7,rep,name=carryOverAnnotations,proto3" json:"carryOverAnnotations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -322,6 +326,20 @@ func (m *WorkflowResubmitRequest) GetParameters() []string {
 	return nil
 }
 
+func (m *WorkflowResubmitRequest) GetCarryOverLabels() []string {
+	if m != nil {
+		return m.CarryOverLabels
+	}
+	return nil
+}
+
+func (m *WorkflowResubmitRequest) GetCarryOverAnnotations() []string {
+	if m != nil {
+		return m.CarryOverAnnotations
+	}
+	return nil
+}
+
 type WorkflowRetryRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
@@ -520,10 +538,12 @@ func (m *WorkflowTerminateRequest) GetNamespace() string {
 }
 
 type WorkflowStopRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	NodeFieldSelector    string   `protobuf:"bytes,3,opt,name=nodeFieldSelector,proto3" json:"nodeFieldSelector,omitempty"`
-	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Name              string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace         string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	NodeFieldSelector string `protobuf:"bytes,3,opt,name=nodeFieldSelector,proto3" json:"nodeFieldSelector,omitempty"`
+	Message           string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Strategy is the shutdown strategy, "Stop" (default) or "Drain", which lets running pods finish
+	Strategy             string   `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -590,6 +610,13 @@ func (m *WorkflowStopRequest) GetMessage() string {
 	return ""
 }
 
+func (m *WorkflowStopRequest) GetStrategy() string {
+	if m != nil {
+		return m.Strategy
+	}
+	return ""
+}
+
 type WorkflowSetRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
@@ -597,6 +624,7 @@ type WorkflowSetRequest struct {
 	Message              string   `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	Phase                string   `protobuf:"bytes,5,opt,name=phase,proto3" json:"phase,omitempty"`
 	OutputParameters     string   `protobuf:"bytes,6,opt,name=outputParameters,proto3" json:"outputParameters,omitempty"`
+	OutputArtifacts      string   `protobuf:"bytes,7,opt,name=outputArtifacts,proto3" json:"outputArtifacts,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -677,6 +705,13 @@ func (m *WorkflowSetRequest) GetOutputParameters() string {
 	return ""
 }
 
+func (m *WorkflowSetRequest) GetOutputArtifacts() string {
+	if m != nil {
+		return m.OutputArtifacts
+	}
+	return ""
+}
+
 type WorkflowSuspendRequest struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
@@ -733,15 +768,17 @@ func (m *WorkflowSuspendRequest) GetNamespace() string {
 }
 
 type WorkflowLogRequest struct {
-	Name                 string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace            string             `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	PodName              string             `protobuf:"bytes,3,opt,name=podName,proto3" json:"podName,omitempty"`
-	LogOptions           *v11.PodLogOptions `protobuf:"bytes,4,opt,name=logOptions,proto3" json:"logOptions,omitempty"`
-	Grep                 string             `protobuf:"bytes,5,opt,name=grep,proto3" json:"grep,omitempty"`
-	Selector             string             `protobuf:"bytes,6,opt,name=selector,proto3" json:"selector,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	Name       string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string             `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	PodName    string             `protobuf:"bytes,3,opt,name=podName,proto3" json:"podName,omitempty"`
+	LogOptions *v11.PodLogOptions `protobuf:"bytes,4,opt,name=logOptions,proto3" json:"logOptions,omitempty"`
+	Grep       string             `protobuf:"bytes,5,opt,name=grep,proto3" json:"grep,omitempty"`
+	Selector   string             `protobuf:"bytes,6,opt,name=selector,proto3" json:"selector,omitempty"`
+	// only return log lines up to and including this time (RFC3339)
+	UntilTime            string   `protobuf:"bytes,7,opt,name=untilTime,proto3" json:"untilTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *WorkflowLogRequest) Reset()         { *m = WorkflowLogRequest{} }
@@ -819,6 +856,13 @@ func (m *WorkflowLogRequest) GetSelector() string {
 	return ""
 }
 
+func (m *WorkflowLogRequest) GetUntilTime() string {
+	if m != nil {
+		return m.UntilTime
+	}
+	return ""
+}
+
 type WorkflowDeleteRequest struct {
 	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Namespace            string            `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
@@ -930,12 +974,14 @@ func (m *WorkflowDeleteResponse) XXX_DiscardUnknown() {
 var xxx_messageInfo_WorkflowDeleteResponse proto.InternalMessageInfo
 
 type WatchWorkflowsRequest struct {
-	Namespace            string          `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ListOptions          *v1.ListOptions `protobuf:"bytes,2,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
-	Fields               string          `protobuf:"bytes,3,opt,name=fields,proto3" json:"fields,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Namespace   string          `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ListOptions *v1.ListOptions `protobuf:"bytes,2,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
+	Fields      string          `protobuf:"bytes,3,opt,name=fields,proto3" json:"fields,omitempty"`
+	// Names restricts the watch to workflows with any of these names, so that many workflows can be watched with one request
+	Names                []string `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *WatchWorkflowsRequest) Reset()         { *m = WatchWorkflowsRequest{} }
@@ -992,6 +1038,13 @@ func (m *WatchWorkflowsRequest) GetFields() string {
 	return ""
 }
 
+func (m *WatchWorkflowsRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
 type WorkflowWatchEvent struct {
 	// the type of change
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
@@ -1105,11 +1158,13 @@ func (m *WatchEventsRequest) GetListOptions() *v1.ListOptions {
 }
 
 type LogEntry struct {
-	Content              string   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	PodName              string   `protobuf:"bytes,2,opt,name=podName,proto3" json:"podName,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Content              string        `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	PodName              string        `protobuf:"bytes,2,opt,name=podName,proto3" json:"podName,omitempty"`
+	Container            string        `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
+	Timestamp            *v1.MicroTime `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *LogEntry) Reset()         { *m = LogEntry{} }
@@ -1159,6 +1214,20 @@ func (m *LogEntry) GetPodName() string {
 	return ""
 }
 
+func (m *LogEntry) GetContainer() string {
+	if m != nil {
+		return m.Container
+	}
+	return ""
+}
+
+func (m *LogEntry) GetTimestamp() *v1.MicroTime {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 type WorkflowLintRequest struct {
 	Namespace            string             `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	Workflow             *v1alpha1.Workflow `protobuf:"bytes,2,opt,name=workflow,proto3" json:"workflow,omitempty"`
@@ -1312,98 +1381,105 @@ func init() {
 }
 
 var fileDescriptor_1f6bb75f9e833cb6 = []byte{
-	// 1442 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x98, 0xcd, 0x6f, 0x1c, 0xb5,
-	0x1b, 0xc7, 0xe5, 0xa4, 0x4d, 0x13, 0xe7, 0xa5, 0xad, 0x7f, 0x6d, 0x7f, 0xcb, 0xa8, 0x4d, 0x53,
-	0x97, 0x42, 0x9a, 0x36, 0x33, 0x79, 0x29, 0xd0, 0x22, 0x81, 0x44, 0x9b, 0x12, 0x51, 0x96, 0x52,
-	0xcd, 0x22, 0x21, 0xb8, 0xa0, 0xc9, 0xec, 0x93, 0xc9, 0x34, 0x3b, 0xe3, 0xc1, 0xf6, 0x6e, 0x15,
-	0x4a, 0x91, 0xe0, 0x02, 0x07, 0x24, 0x0e, 0x1c, 0xb9, 0x20, 0x24, 0x04, 0x07, 0x04, 0x08, 0x09,
-	0x09, 0x81, 0x84, 0x38, 0x70, 0xe0, 0x58, 0xa9, 0x57, 0x0e, 0xa8, 0xe2, 0x1f, 0xe0, 0x3f, 0x40,
-	0xf6, 0xbc, 0x67, 0xb7, 0xdb, 0x21, 0xd9, 0x42, 0x6f, 0x63, 0xcf, 0xd8, 0xcf, 0xc7, 0xdf, 0xc7,
-	0x7e, 0x9e, 0xc7, 0x83, 0x4f, 0x45, 0x9b, 0x9e, 0xe5, 0x44, 0xbe, 0xdb, 0xf2, 0x21, 0x94, 0xd6,
-	0x0d, 0xc6, 0x37, 0xd7, 0x5b, 0xec, 0x46, 0xf6, 0x60, 0x46, 0x9c, 0x49, 0x46, 0x46, 0xd3, 0xb6,
-	0x71, 0xd4, 0x63, 0xcc, 0x6b, 0x81, 0x1a, 0x63, 0x39, 0x61, 0xc8, 0xa4, 0x23, 0x7d, 0x16, 0x8a,
-	0xf8, 0x3b, 0xe3, 0xdc, 0xe6, 0x79, 0x61, 0xfa, 0x4c, 0xbd, 0x0d, 0x1c, 0x77, 0xc3, 0x0f, 0x81,
-	0x6f, 0x59, 0x89, 0x09, 0x61, 0x05, 0x20, 0x1d, 0xab, 0xb3, 0x68, 0x79, 0x10, 0x02, 0x77, 0x24,
-	0x34, 0x93, 0x51, 0x2f, 0x79, 0xbe, 0xdc, 0x68, 0xaf, 0x99, 0x2e, 0x0b, 0x2c, 0x87, 0x7b, 0x2c,
-	0xe2, 0xec, 0xba, 0x7e, 0x98, 0x4f, 0xcd, 0x8a, 0x7c, 0x92, 0x0c, 0xb1, 0xb3, 0xe8, 0xb4, 0xa2,
-	0x0d, 0xa7, 0x7b, 0x3a, 0x9a, 0x43, 0x58, 0x2e, 0xe3, 0xd0, 0xc3, 0x24, 0xfd, 0x65, 0x08, 0x1f,
-	0x7e, 0x35, 0x99, 0xe9, 0x12, 0x07, 0x47, 0x82, 0x0d, 0x6f, 0xb6, 0x41, 0x48, 0x72, 0x14, 0x8f,
-	0x85, 0x4e, 0x00, 0x22, 0x72, 0x5c, 0xa8, 0xa1, 0x19, 0x34, 0x3b, 0x66, 0xe7, 0x1d, 0x64, 0x1d,
-	0x67, 0x52, 0xd4, 0x86, 0x66, 0xd0, 0xec, 0xf8, 0xd2, 0x15, 0x33, 0xa7, 0x37, 0x53, 0x7a, 0xfd,
-	0xf0, 0x46, 0x46, 0x6f, 0x76, 0x96, 0xcd, 0x68, 0xd3, 0x33, 0xd5, 0x02, 0xcc, 0x4c, 0xda, 0x74,
-	0x01, 0x66, 0x0a, 0x62, 0x67, 0x73, 0x13, 0x8a, 0xb1, 0x1f, 0x0a, 0xe9, 0x84, 0x2e, 0xbc, 0xb0,
-	0x52, 0x1b, 0x56, 0x18, 0x17, 0x87, 0x6a, 0xc8, 0x2e, 0xf4, 0x12, 0x8a, 0x27, 0x04, 0xf0, 0x0e,
-	0xf0, 0x15, 0xbe, 0x65, 0xb7, 0xc3, 0xda, 0x9e, 0x19, 0x34, 0x3b, 0x6a, 0x97, 0xfa, 0xc8, 0x6b,
-	0x78, 0xd2, 0xd5, 0xcb, 0x7b, 0x39, 0xd2, 0x7e, 0xaa, 0xed, 0xd5, 0xd0, 0xcb, 0x66, 0xac, 0x91,
-	0x59, 0x74, 0x54, 0x8e, 0xa8, 0x1c, 0x65, 0x76, 0x16, 0xcd, 0x4b, 0xc5, 0xa1, 0x76, 0x79, 0x26,
-	0xfa, 0x2d, 0xc2, 0x24, 0x25, 0x5f, 0x05, 0x99, 0xea, 0x47, 0xf0, 0x1e, 0x25, 0x57, 0x22, 0x9d,
-	0x7e, 0x2e, 0x6b, 0x3a, 0xb4, 0x5d, 0xd3, 0x6b, 0x18, 0x7b, 0x20, 0x53, 0xc0, 0x61, 0x0d, 0xb8,
-	0x50, 0x0d, 0x70, 0x35, 0x1b, 0x67, 0x17, 0xe6, 0x20, 0x47, 0xf0, 0xc8, 0xba, 0x0f, 0xad, 0xa6,
-	0xd0, 0x9a, 0x8c, 0xd9, 0x49, 0x8b, 0x7e, 0x8a, 0xf0, 0xff, 0x52, 0xe4, 0xba, 0x2f, 0x64, 0x35,
-	0x9f, 0x37, 0xf0, 0x78, 0xcb, 0x17, 0x19, 0x60, 0xec, 0xf6, 0xc5, 0x6a, 0x80, 0xf5, 0x7c, 0xa0,
-	0x5d, 0x9c, 0xa5, 0x80, 0x38, 0x5c, 0x42, 0x7c, 0x1f, 0xe1, 0xff, 0x67, 0xfb, 0x01, 0x44, 0x7b,
-	0x2d, 0xf0, 0x77, 0x21, 0xad, 0x81, 0x47, 0x03, 0x08, 0x98, 0xff, 0x16, 0x34, 0xb5, 0x9d, 0x51,
-	0x3b, 0x6b, 0x93, 0x69, 0x8c, 0x23, 0x87, 0x3b, 0x01, 0x48, 0xe0, 0x6a, 0x5f, 0x0c, 0xcf, 0x8e,
-	0xd9, 0x85, 0x1e, 0xfa, 0x2b, 0xc2, 0x87, 0x72, 0x12, 0xc9, 0xb7, 0x76, 0x8e, 0x71, 0x16, 0x1f,
-	0xe4, 0x20, 0xa4, 0xc3, 0x65, 0xa3, 0xed, 0xba, 0x20, 0xc4, 0x7a, 0xbb, 0x95, 0xf0, 0x74, 0xbf,
-	0x50, 0x5f, 0x87, 0xac, 0x09, 0xcf, 0x2b, 0x41, 0x1a, 0xd0, 0x02, 0x57, 0x32, 0x9e, 0x38, 0xb2,
-	0xfb, 0xc5, 0x7d, 0x97, 0x71, 0x23, 0x3f, 0xe8, 0x4a, 0xcf, 0x00, 0x76, 0xb5, 0x8c, 0x6e, 0xb0,
-	0xe1, 0x7b, 0x80, 0xd1, 0x3a, 0xae, 0xa5, 0x86, 0x5f, 0x01, 0x1e, 0xf8, 0x61, 0x21, 0xc8, 0xfc,
-	0x63, 0xdb, 0xf4, 0xa3, 0xc2, 0xd6, 0x6d, 0x48, 0x16, 0xfd, 0x4b, 0xab, 0x20, 0x35, 0xbc, 0x2f,
-	0x00, 0x21, 0x1c, 0x0f, 0x12, 0x17, 0xa4, 0x4d, 0x7a, 0xbb, 0x70, 0xfe, 0x1b, 0xbb, 0x39, 0xff,
-	0x03, 0x02, 0x22, 0x87, 0xf0, 0xde, 0x68, 0xc3, 0x11, 0xa0, 0x63, 0xdc, 0x98, 0x1d, 0x37, 0xc8,
-	0x1c, 0x3e, 0xc0, 0xda, 0x32, 0x6a, 0xcb, 0x6b, 0xf9, 0x2e, 0x19, 0xd1, 0x1f, 0x74, 0xf5, 0xd3,
-	0x2b, 0xf8, 0x48, 0xb6, 0xa2, 0xb6, 0x88, 0x20, 0x6c, 0xee, 0xdc, 0x61, 0x77, 0x0a, 0xf2, 0xd4,
-	0x99, 0xb7, 0x73, 0x79, 0x6a, 0x78, 0x5f, 0xc4, 0x9a, 0x57, 0xd5, 0xa0, 0x58, 0x94, 0xb4, 0x49,
-	0x9e, 0xc3, 0xb8, 0xc5, 0xbc, 0x34, 0x2e, 0xed, 0xd1, 0x71, 0xe9, 0x44, 0x21, 0x2e, 0x99, 0x2a,
-	0xfb, 0xa9, 0x28, 0x74, 0x8d, 0x35, 0xeb, 0xd9, 0x87, 0x76, 0x61, 0x90, 0xc2, 0xf1, 0x38, 0x44,
-	0x89, 0x64, 0xfa, 0x59, 0x05, 0x0d, 0x91, 0xba, 0x21, 0x56, 0x2a, 0x6b, 0xd3, 0x1f, 0x51, 0x7e,
-	0x9c, 0x56, 0xa0, 0x05, 0xbb, 0xd8, 0xd2, 0x2a, 0x37, 0x35, 0xf5, 0x14, 0xe5, 0xd0, 0x5f, 0x31,
-	0x37, 0xad, 0x14, 0x87, 0xda, 0xe5, 0x99, 0xd4, 0x56, 0x58, 0x67, 0xdc, 0x85, 0x24, 0x27, 0xc6,
-	0x0d, 0x5a, 0xcb, 0xdd, 0x9b, 0xb2, 0x8b, 0x88, 0x85, 0x02, 0xe8, 0x67, 0x6a, 0x59, 0x8e, 0x74,
-	0x37, 0xd2, 0xf7, 0xe2, 0x21, 0x4c, 0x0d, 0x1f, 0x16, 0x76, 0x94, 0x86, 0xbd, 0xdc, 0x81, 0x50,
-	0x0b, 0x2f, 0xb7, 0xa2, 0x4c, 0x78, 0xf5, 0x4c, 0xd6, 0xf0, 0x08, 0x5b, 0xbb, 0x0e, 0xae, 0x7c,
-	0x00, 0x45, 0x4a, 0x32, 0xb3, 0xca, 0x54, 0x24, 0xc7, 0xf8, 0x0f, 0x05, 0xa3, 0xcf, 0xe2, 0xd1,
-	0x3a, 0xf3, 0x2e, 0x87, 0x92, 0x6f, 0xa9, 0xd3, 0xe2, 0xb2, 0x50, 0x42, 0x28, 0x13, 0xe3, 0x69,
-	0xb3, 0x78, 0x8e, 0x86, 0x4a, 0xe7, 0x88, 0x7e, 0x52, 0x2a, 0x0b, 0x42, 0xf9, 0x50, 0x95, 0x82,
-	0xf4, 0xaf, 0xc2, 0x91, 0x6b, 0x94, 0xea, 0x81, 0xfe, 0x7c, 0x14, 0x4f, 0x70, 0x10, 0xac, 0xcd,
-	0x5d, 0x78, 0xd1, 0x0f, 0x9b, 0xc9, 0xa2, 0x4b, 0x7d, 0xc5, 0x6f, 0x0a, 0x01, 0xa6, 0xd4, 0x47,
-	0x38, 0x9e, 0x8c, 0xcb, 0x90, 0x72, 0xa0, 0xa9, 0xef, 0x7e, 0xb1, 0x8d, 0x74, 0x5a, 0x61, 0x97,
-	0x4d, 0x2c, 0xfd, 0x7e, 0x18, 0xef, 0xcf, 0x73, 0x0b, 0xef, 0xf8, 0x2e, 0x90, 0x2f, 0x10, 0x9e,
-	0x8a, 0x0b, 0xd2, 0xf4, 0x0d, 0x39, 0x9e, 0x4f, 0xda, 0xb3, 0x98, 0x37, 0x06, 0xe8, 0x11, 0x3a,
-	0xfb, 0xde, 0x9d, 0x3f, 0x3f, 0x1e, 0xa2, 0xf4, 0x98, 0xbe, 0x58, 0x74, 0x16, 0xad, 0xfc, 0x72,
-	0x72, 0x33, 0x53, 0xfd, 0xd6, 0xd3, 0x68, 0x8e, 0x7c, 0x8e, 0xf0, 0xf8, 0x2a, 0xc8, 0x0c, 0xf3,
-	0x68, 0x37, 0x66, 0x5e, 0x30, 0x0f, 0x94, 0xf1, 0xac, 0x66, 0x7c, 0x8c, 0x3c, 0xda, 0x97, 0x31,
-	0x7e, 0xbe, 0xa5, 0x38, 0x27, 0xd5, 0xa1, 0xca, 0x82, 0x1e, 0x39, 0xd6, 0x4d, 0x5a, 0xa8, 0x93,
-	0x8d, 0xab, 0x83, 0x43, 0x55, 0xd3, 0xd2, 0x53, 0x1a, 0xf7, 0x38, 0xe9, 0x2f, 0x29, 0x79, 0x07,
-	0x4f, 0x95, 0x83, 0x73, 0xc9, 0xf1, 0xbd, 0xc2, 0xb6, 0xd1, 0x43, 0xf2, 0x3c, 0x56, 0xd1, 0x33,
-	0xda, 0xee, 0x29, 0x72, 0x72, 0xbb, 0xdd, 0x79, 0xd0, 0xb1, 0xac, 0x68, 0x7d, 0x01, 0x11, 0x81,
-	0xc7, 0x0b, 0x81, 0xae, 0xe4, 0xce, 0xae, 0xf8, 0x67, 0x3c, 0xd2, 0x2b, 0x01, 0xc7, 0x66, 0x4f,
-	0x6b, 0xb3, 0x27, 0xc9, 0x89, 0xd4, 0xac, 0x90, 0x1c, 0x9c, 0xc0, 0xea, 0x69, 0xf4, 0x5d, 0x84,
-	0xa7, 0xe2, 0x2c, 0xd5, 0x6f, 0xbb, 0x97, 0x72, 0xb0, 0x31, 0x73, 0xef, 0x0f, 0x92, 0x44, 0x97,
-	0x6c, 0x90, 0xb9, 0x6a, 0x1b, 0xe4, 0x3b, 0x84, 0x27, 0x75, 0xe9, 0x9f, 0x21, 0x4c, 0x77, 0x5b,
-	0x28, 0xde, 0x0d, 0x06, 0xba, 0x99, 0x9f, 0xd0, 0xac, 0x96, 0x31, 0x57, 0x85, 0xd5, 0xe2, 0x0a,
-	0x43, 0x9d, 0xbe, 0x9f, 0x10, 0x3e, 0x90, 0xde, 0x9c, 0x32, 0xee, 0x13, 0xbd, 0xb8, 0x4b, 0xb7,
-	0xab, 0x81, 0xa2, 0x9f, 0xd7, 0xe8, 0x4b, 0xc6, 0x7c, 0x45, 0xf4, 0x98, 0x44, 0xd1, 0x7f, 0x8f,
-	0xf0, 0x54, 0x7c, 0x4f, 0xe9, 0xe7, 0xf6, 0xd2, 0x4d, 0x66, 0xa0, 0xe4, 0x4f, 0x6a, 0xf2, 0x05,
-	0xe3, 0x4c, 0x65, 0xf2, 0x00, 0x14, 0xf7, 0x0f, 0x08, 0xef, 0x4f, 0x6a, 0xe6, 0x0c, 0xbc, 0xc7,
-	0x76, 0x2c, 0x97, 0xd5, 0x03, 0x25, 0x7f, 0x4a, 0x93, 0x2f, 0x1a, 0x67, 0x2b, 0x91, 0x8b, 0x18,
-	0x44, 0xa1, 0xff, 0x8c, 0xf0, 0xc1, 0xec, 0x86, 0x96, 0xc1, 0xd3, 0x6e, 0xf8, 0xed, 0xd7, 0xb8,
-	0x81, 0xe2, 0x5f, 0xd0, 0xf8, 0xcb, 0x86, 0x59, 0x09, 0x5f, 0xa6, 0x28, 0x6a, 0x01, 0xdf, 0x20,
-	0x3c, 0xa1, 0xee, 0x84, 0x19, 0x7b, 0x8f, 0x30, 0x5e, 0xb8, 0x33, 0x0e, 0x14, 0xfb, 0x9c, 0xc6,
-	0x36, 0x8d, 0xd3, 0xd5, 0x54, 0x97, 0x2c, 0x52, 0xc4, 0x5f, 0x21, 0x3c, 0xde, 0xe8, 0x9f, 0x21,
-	0x1b, 0x0f, 0x26, 0x43, 0x2e, 0x6b, 0xde, 0x79, 0x63, 0xb6, 0x1a, 0x2f, 0xe8, 0x43, 0xf9, 0x25,
-	0xc2, 0x13, 0xaa, 0x30, 0xec, 0x27, 0x70, 0xa1, 0x70, 0x1c, 0x28, 0xf0, 0xbc, 0x06, 0x7e, 0x9c,
-	0xd2, 0xfe, 0xc0, 0x2d, 0x3f, 0xd4, 0xa8, 0x6f, 0xe3, 0x7d, 0xf1, 0x6d, 0x4f, 0xf4, 0x12, 0x35,
-	0xbf, 0x88, 0x1a, 0x24, 0x7f, 0x9b, 0x16, 0xcf, 0xf4, 0x19, 0x6d, 0xeb, 0x1c, 0x59, 0xaa, 0x24,
-	0xce, 0xcd, 0xa4, 0x7e, 0xbe, 0x65, 0xb5, 0x98, 0xf7, 0xc1, 0x10, 0x5a, 0x40, 0x44, 0xe2, 0x89,
-	0x82, 0xa9, 0x9d, 0x20, 0x2c, 0x68, 0x84, 0x39, 0x52, 0xcd, 0x3f, 0x2d, 0xe6, 0x2d, 0x20, 0xf2,
-	0x35, 0xc2, 0x53, 0x8d, 0x72, 0xbc, 0x3f, 0xde, 0x2b, 0xf4, 0x3c, 0xa8, 0x68, 0x6f, 0x69, 0xe6,
-	0xd3, 0xf4, 0x3e, 0x49, 0x35, 0x0b, 0xf2, 0x17, 0x57, 0x7f, 0xbb, 0x3b, 0x8d, 0x6e, 0xdf, 0x9d,
-	0x46, 0x7f, 0xdc, 0x9d, 0x46, 0xaf, 0x5f, 0xa8, 0xfe, 0xfb, 0x7b, 0xdb, 0x6f, 0xfa, 0xb5, 0x11,
-	0xfd, 0x37, 0x7b, 0xf9, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6d, 0x5c, 0x66, 0x41, 0xc7, 0x17,
-	0x00, 0x00,
+	// 1558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x99, 0xc9, 0x6f, 0x1c, 0xc5,
+	0x1a, 0xc0, 0x55, 0x63, 0xc7, 0xf6, 0x94, 0x97, 0x24, 0xf5, 0x92, 0xbc, 0x79, 0x2d, 0xc7, 0x71,
+	0x2a, 0x2f, 0xef, 0x4d, 0x9c, 0xb8, 0xdb, 0x4b, 0x80, 0x04, 0x89, 0x43, 0x12, 0x07, 0x8b, 0x30,
+	0x59, 0xd4, 0x13, 0x09, 0xc1, 0x05, 0xb5, 0x7b, 0xca, 0xed, 0x8e, 0xbb, 0xbb, 0x9a, 0xaa, 0x9a,
+	0x89, 0x86, 0x10, 0x24, 0xb8, 0xc0, 0x81, 0x1b, 0x47, 0x2e, 0x5c, 0x10, 0x48, 0x20, 0x40, 0x48,
+	0x48, 0x11, 0x48, 0x88, 0x03, 0x07, 0x8e, 0x48, 0x5c, 0x39, 0xa0, 0x08, 0xfe, 0x80, 0x5c, 0x39,
+	0xa1, 0xaa, 0xde, 0x3d, 0x93, 0x49, 0xcb, 0x9e, 0x90, 0xdc, 0xba, 0xd6, 0xef, 0x57, 0xdf, 0x57,
+	0xf5, 0x2d, 0x6a, 0x78, 0x32, 0xdc, 0x76, 0x0c, 0x2b, 0x74, 0x6d, 0xcf, 0x25, 0x81, 0x30, 0x6e,
+	0x53, 0xb6, 0xbd, 0xe9, 0xd1, 0xdb, 0xe9, 0x87, 0x1e, 0x32, 0x2a, 0x28, 0x9a, 0x48, 0xda, 0xda,
+	0xac, 0x43, 0xa9, 0xe3, 0x11, 0xb9, 0xc6, 0xb0, 0x82, 0x80, 0x0a, 0x4b, 0xb8, 0x34, 0xe0, 0xd1,
+	0x3c, 0xed, 0xec, 0xf6, 0x39, 0xae, 0xbb, 0x54, 0x8e, 0xfa, 0x96, 0xbd, 0xe5, 0x06, 0x84, 0x75,
+	0x8d, 0x58, 0x04, 0x37, 0x7c, 0x22, 0x2c, 0xa3, 0xb3, 0x6c, 0x38, 0x24, 0x20, 0xcc, 0x12, 0xa4,
+	0x15, 0xaf, 0xba, 0xea, 0xb8, 0x62, 0xab, 0xbd, 0xa1, 0xdb, 0xd4, 0x37, 0x2c, 0xe6, 0xd0, 0x90,
+	0xd1, 0x5b, 0xea, 0x63, 0x31, 0x11, 0xcb, 0xb3, 0x4d, 0x52, 0xc4, 0xce, 0xb2, 0xe5, 0x85, 0x5b,
+	0x56, 0xef, 0x76, 0x38, 0x83, 0x30, 0x6c, 0xca, 0x48, 0x1f, 0x91, 0xf8, 0xc7, 0x0a, 0x3c, 0xfc,
+	0x4a, 0xbc, 0xd3, 0x25, 0x46, 0x2c, 0x41, 0x4c, 0xf2, 0x46, 0x9b, 0x70, 0x81, 0x66, 0x61, 0x35,
+	0xb0, 0x7c, 0xc2, 0x43, 0xcb, 0x26, 0x35, 0x30, 0x0f, 0xea, 0x55, 0x33, 0xeb, 0x40, 0x9b, 0x30,
+	0x55, 0x45, 0xad, 0x32, 0x0f, 0xea, 0x93, 0x2b, 0x57, 0xf4, 0x8c, 0x5e, 0x4f, 0xe8, 0xd5, 0xc7,
+	0xeb, 0x29, 0xbd, 0xde, 0x59, 0xd5, 0xc3, 0x6d, 0x47, 0x97, 0x07, 0xd0, 0x93, 0x5e, 0x3d, 0x39,
+	0x80, 0x9e, 0x80, 0x98, 0xe9, 0xde, 0x08, 0x43, 0xe8, 0x06, 0x5c, 0x58, 0x81, 0x4d, 0x5e, 0x5a,
+	0xab, 0x8d, 0x48, 0x8c, 0x8b, 0x95, 0x1a, 0x30, 0x73, 0xbd, 0x08, 0xc3, 0x29, 0x4e, 0x58, 0x87,
+	0xb0, 0x35, 0xd6, 0x35, 0xdb, 0x41, 0x6d, 0x74, 0x1e, 0xd4, 0x27, 0xcc, 0x42, 0x1f, 0x7a, 0x15,
+	0x4e, 0xdb, 0xea, 0x78, 0xd7, 0x43, 0x65, 0xa7, 0xda, 0x3e, 0x05, 0xbd, 0xaa, 0x47, 0x3a, 0xd2,
+	0xf3, 0x86, 0xca, 0x10, 0xa5, 0xa1, 0xf4, 0xce, 0xb2, 0x7e, 0x29, 0xbf, 0xd4, 0x2c, 0xee, 0x84,
+	0xbf, 0x06, 0x10, 0x25, 0xe4, 0xeb, 0x44, 0x24, 0xfa, 0x43, 0x70, 0x54, 0xaa, 0x2b, 0x56, 0x9d,
+	0xfa, 0x2e, 0xea, 0xb4, 0xb2, 0x53, 0xa7, 0x37, 0x20, 0x74, 0x88, 0x48, 0x00, 0x47, 0x14, 0xe0,
+	0x52, 0x39, 0xc0, 0xf5, 0x74, 0x9d, 0x99, 0xdb, 0x03, 0x1d, 0x81, 0x63, 0x9b, 0x2e, 0xf1, 0x5a,
+	0x5c, 0xe9, 0xa4, 0x6a, 0xc6, 0x2d, 0xfc, 0x31, 0x80, 0xff, 0x4a, 0x90, 0x1b, 0x2e, 0x17, 0xe5,
+	0x6c, 0xde, 0x84, 0x93, 0x9e, 0xcb, 0x53, 0xc0, 0xc8, 0xec, 0xcb, 0xe5, 0x00, 0x1b, 0xd9, 0x42,
+	0x33, 0xbf, 0x4b, 0x0e, 0x71, 0xa4, 0x80, 0xf8, 0x27, 0x80, 0xff, 0x4e, 0xef, 0x03, 0xe1, 0xed,
+	0x0d, 0xdf, 0xdd, 0x83, 0x6a, 0x35, 0x38, 0xe1, 0x13, 0x9f, 0xba, 0x6f, 0x92, 0x96, 0x92, 0x33,
+	0x61, 0xa6, 0x6d, 0x34, 0x07, 0x61, 0x68, 0x31, 0xcb, 0x27, 0x82, 0x30, 0x79, 0x2f, 0x46, 0xea,
+	0x55, 0x33, 0xd7, 0x83, 0xea, 0x70, 0xbf, 0x6d, 0x31, 0xd6, 0xbd, 0xde, 0x21, 0xac, 0x61, 0x6d,
+	0x10, 0x8f, 0xd7, 0xc6, 0xd4, 0xa4, 0x9d, 0xdd, 0x68, 0x05, 0x1e, 0x4a, 0xbb, 0x2e, 0x64, 0x3e,
+	0xa1, 0x36, 0xae, 0xa6, 0xf7, 0x1d, 0xc3, 0x3f, 0x01, 0x78, 0x28, 0x3b, 0xa7, 0x60, 0xdd, 0xdd,
+	0x1f, 0xf2, 0x0c, 0x3c, 0xc8, 0x08, 0x17, 0x16, 0x13, 0xcd, 0xb6, 0x6d, 0x13, 0xce, 0x37, 0xdb,
+	0x5e, 0x7c, 0xda, 0xde, 0x01, 0x39, 0x3b, 0xa0, 0x2d, 0xf2, 0xa2, 0x54, 0x77, 0x93, 0x78, 0xc4,
+	0x16, 0x94, 0xc5, 0xd7, 0xa4, 0x77, 0xe0, 0x51, 0x4a, 0xc2, 0xb7, 0xe1, 0xe1, 0xbc, 0xb5, 0x7c,
+	0xb2, 0xa7, 0x63, 0xf4, 0x82, 0x8d, 0x3c, 0x04, 0x0c, 0x37, 0x60, 0x2d, 0x11, 0x7c, 0x93, 0x30,
+	0xdf, 0x0d, 0x2c, 0xb1, 0x7b, 0xd9, 0xf8, 0xf3, 0xdc, 0xc3, 0x68, 0x0a, 0x1a, 0xfe, 0x43, 0xa7,
+	0x40, 0x35, 0x38, 0xee, 0x13, 0xce, 0x2d, 0x87, 0xc4, 0x26, 0x48, 0x9a, 0xf2, 0xe6, 0x72, 0x21,
+	0x3d, 0xb6, 0xd3, 0x55, 0x3e, 0xab, 0x6a, 0xa6, 0x6d, 0xfc, 0x57, 0xce, 0xf3, 0x34, 0x89, 0x78,
+	0xf2, 0xb0, 0x87, 0xe0, 0xbe, 0x70, 0xcb, 0xe2, 0x24, 0x26, 0x8d, 0x1a, 0x68, 0x01, 0x1e, 0xa0,
+	0x6d, 0x11, 0xb6, 0xc5, 0x8d, 0xec, 0x06, 0x8d, 0xa9, 0x09, 0x3d, 0xfd, 0xf2, 0xb1, 0x45, 0x7d,
+	0x17, 0x98, 0x70, 0x37, 0x2d, 0x5b, 0xc8, 0xd7, 0x23, 0xa7, 0xee, 0xec, 0xc6, 0x57, 0xe0, 0x91,
+	0xf4, 0xec, 0x6d, 0x1e, 0x92, 0xa0, 0xb5, 0x7b, 0xb3, 0x3f, 0xc8, 0x29, 0xb2, 0x41, 0x9d, 0xdd,
+	0x2b, 0xb2, 0x06, 0xc7, 0x43, 0xda, 0xba, 0x26, 0x17, 0x45, 0xea, 0x4b, 0x9a, 0xe8, 0x02, 0x84,
+	0x1e, 0x75, 0x12, 0xdf, 0x39, 0xaa, 0x7c, 0xe7, 0xf1, 0x9c, 0xef, 0xd4, 0x65, 0x84, 0x96, 0x9e,
+	0xf2, 0x06, 0x6d, 0x35, 0xd2, 0x89, 0x66, 0x6e, 0x91, 0xc4, 0x71, 0x18, 0x09, 0x63, 0xe5, 0xaa,
+	0x6f, 0x75, 0x3d, 0x12, 0x83, 0x8d, 0xc5, 0xd7, 0x23, 0xb1, 0xd3, 0x2c, 0xac, 0xb6, 0x03, 0xe1,
+	0x7a, 0x37, 0x5d, 0x9f, 0xc4, 0x5a, 0xcc, 0x3a, 0xf0, 0x77, 0x20, 0x7b, 0xb2, 0x6b, 0xc4, 0x23,
+	0x7b, 0x78, 0x36, 0x32, 0xba, 0xb6, 0xd4, 0x16, 0xc5, 0xe0, 0x55, 0x32, 0xba, 0xae, 0xe5, 0x97,
+	0x9a, 0xc5, 0x9d, 0xe4, 0x95, 0xda, 0xa4, 0xcc, 0x26, 0x71, 0x54, 0x8f, 0x1a, 0xb8, 0x96, 0x19,
+	0x3f, 0x61, 0xe7, 0x21, 0x0d, 0x38, 0xc1, 0xf7, 0xe4, 0xb1, 0x2c, 0x61, 0x6f, 0x25, 0xe3, 0xfc,
+	0xe9, 0x0b, 0x6e, 0xf2, 0x50, 0x4a, 0x72, 0x6d, 0x54, 0x39, 0xd2, 0xa8, 0x81, 0x3f, 0xc8, 0xdd,
+	0x42, 0x75, 0x84, 0xcb, 0x1d, 0x12, 0x28, 0x73, 0x88, 0x6e, 0x98, 0x9a, 0x43, 0x7e, 0xa3, 0x0d,
+	0x38, 0x46, 0x37, 0x6e, 0x11, 0x5b, 0x3c, 0x86, 0xe4, 0x2b, 0xde, 0x19, 0xbf, 0x27, 0x71, 0x52,
+	0x8c, 0x27, 0xa8, 0x46, 0xe9, 0x95, 0x27, 0x1a, 0xd4, 0xb9, 0x1c, 0x08, 0xd6, 0x95, 0x4f, 0xcc,
+	0xa6, 0x81, 0x20, 0x81, 0x88, 0xa5, 0x27, 0xcd, 0xfc, 0xe3, 0xab, 0x14, 0x1f, 0xdf, 0x2c, 0xac,
+	0xca, 0x49, 0x96, 0x14, 0x1c, 0x9b, 0x22, 0xeb, 0x40, 0x57, 0x61, 0x55, 0xb8, 0xbe, 0x0c, 0x90,
+	0x7e, 0x18, 0xbf, 0x4c, 0xa3, 0x1c, 0xf1, 0x55, 0xd7, 0x66, 0x54, 0xbe, 0x26, 0x33, 0xdb, 0x01,
+	0x7f, 0x54, 0x48, 0xae, 0x02, 0xf1, 0x54, 0x25, 0xd4, 0xf8, 0x41, 0xee, 0xd9, 0x37, 0x0b, 0x59,
+	0xd5, 0x60, 0x3e, 0x0c, 0xa7, 0x18, 0xe1, 0xb4, 0xcd, 0x6c, 0xf2, 0xb2, 0x1b, 0xb4, 0x62, 0x0d,
+	0x17, 0xfa, 0xf2, 0x73, 0x72, 0x2e, 0xb0, 0xd0, 0x87, 0x18, 0x9c, 0x8e, 0x92, 0xb9, 0xa2, 0x2b,
+	0x6c, 0xec, 0xfd, 0xb0, 0xcd, 0x64, 0x5b, 0x6e, 0x16, 0x45, 0xac, 0xfc, 0x76, 0x18, 0xee, 0xcf,
+	0xe2, 0x24, 0xeb, 0xb8, 0x36, 0x41, 0x9f, 0x02, 0x38, 0x13, 0xa5, 0xf5, 0xc9, 0x08, 0x3a, 0x96,
+	0x6d, 0xda, 0xb7, 0x24, 0xd2, 0x86, 0x68, 0x11, 0x5c, 0x7f, 0xf7, 0xd7, 0x3f, 0x3e, 0xac, 0x60,
+	0x7c, 0x54, 0x95, 0x67, 0x9d, 0x65, 0x23, 0x2b, 0xf1, 0xee, 0xa4, 0x5a, 0xbf, 0xfb, 0x3c, 0x58,
+	0x40, 0x9f, 0x00, 0x38, 0xb9, 0x4e, 0x44, 0x8a, 0x39, 0xdb, 0x8b, 0x99, 0x95, 0x1d, 0x43, 0x65,
+	0x3c, 0xa3, 0x18, 0xff, 0x87, 0xfe, 0x3b, 0x90, 0x31, 0xfa, 0xbe, 0x2b, 0x39, 0xa7, 0xe5, 0x13,
+	0x4e, 0x96, 0x73, 0x74, 0xb4, 0x97, 0x34, 0x57, 0x6d, 0x68, 0xd7, 0x86, 0x87, 0x2a, 0xb7, 0xc5,
+	0x27, 0x15, 0xee, 0x31, 0x34, 0x58, 0xa5, 0xe8, 0x6d, 0x38, 0x53, 0x0c, 0x10, 0x05, 0xc3, 0xf7,
+	0x0b, 0x1d, 0x5a, 0x1f, 0x95, 0x67, 0x9e, 0x11, 0x9f, 0x56, 0x72, 0x4f, 0xa2, 0x13, 0x3b, 0xe5,
+	0x2e, 0x12, 0x39, 0x5e, 0x90, 0xbe, 0x04, 0x10, 0x87, 0x93, 0xd9, 0x62, 0x5e, 0x30, 0x67, 0x8f,
+	0xb7, 0xd5, 0xfe, 0xd3, 0x2f, 0x45, 0x88, 0xc4, 0x9e, 0x52, 0x62, 0x4f, 0xa0, 0xe3, 0x89, 0x58,
+	0x2e, 0x18, 0xb1, 0x7c, 0xa3, 0xaf, 0xd0, 0x77, 0x00, 0x9c, 0x89, 0x22, 0xe5, 0xa0, 0xeb, 0x5e,
+	0xc8, 0x03, 0xb4, 0xf9, 0x87, 0x4f, 0x88, 0x83, 0x6d, 0x7c, 0x41, 0x16, 0xca, 0x5d, 0x90, 0x6f,
+	0x00, 0x9c, 0x56, 0x25, 0x4e, 0x8a, 0x30, 0xd7, 0x2b, 0x21, 0x5f, 0x03, 0x0d, 0xf5, 0x32, 0x3f,
+	0xa3, 0x58, 0x0d, 0x6d, 0xa1, 0x0c, 0xab, 0xc1, 0x24, 0x86, 0x7c, 0x7d, 0xdf, 0x03, 0x78, 0x20,
+	0xa9, 0x3f, 0x53, 0xee, 0xe3, 0xfd, 0xb8, 0x0b, 0x35, 0xea, 0x50, 0xd1, 0xcf, 0x29, 0xf4, 0x15,
+	0x6d, 0xb1, 0x24, 0x7a, 0x44, 0x22, 0xe9, 0xbf, 0x05, 0x70, 0x26, 0xaa, 0xc7, 0x06, 0x99, 0xbd,
+	0x50, 0xb1, 0x0d, 0x95, 0xfc, 0x59, 0x45, 0xbe, 0xa4, 0x9d, 0x2e, 0x4d, 0xee, 0x13, 0xc9, 0x7d,
+	0x0f, 0xc0, 0xfd, 0x71, 0x56, 0x9f, 0x82, 0xf7, 0xb9, 0x8e, 0xc5, 0xc4, 0x7f, 0xa8, 0xe4, 0xcf,
+	0x29, 0xf2, 0x65, 0xed, 0x4c, 0x29, 0x72, 0x1e, 0x81, 0x48, 0xf4, 0x1f, 0x00, 0x3c, 0x98, 0x56,
+	0xa2, 0x29, 0x3c, 0xee, 0x85, 0xdf, 0x59, 0xae, 0x0e, 0x15, 0xff, 0xbc, 0xc2, 0x5f, 0xd5, 0xf4,
+	0x52, 0xf8, 0x22, 0x41, 0x91, 0x07, 0xf8, 0x0a, 0xc0, 0x29, 0x59, 0xfb, 0xa6, 0xec, 0x7d, 0xdc,
+	0x78, 0xae, 0x36, 0x1e, 0x2a, 0xf6, 0x59, 0x85, 0xad, 0x6b, 0xa7, 0xca, 0x69, 0x5d, 0xd0, 0x50,
+	0x12, 0x7f, 0x01, 0xe0, 0x64, 0x73, 0x70, 0x84, 0x6c, 0x3e, 0x9e, 0x08, 0xb9, 0xaa, 0x78, 0x17,
+	0xb5, 0x7a, 0x39, 0x5e, 0xa2, 0x1e, 0xe5, 0x67, 0x00, 0x4e, 0xc9, 0xc4, 0x70, 0x90, 0x82, 0x73,
+	0x89, 0xe3, 0x50, 0x81, 0x17, 0x15, 0xf0, 0xff, 0x31, 0x1e, 0x0c, 0xec, 0xb9, 0x81, 0x42, 0x7d,
+	0x0b, 0x8e, 0x47, 0xf5, 0x28, 0xef, 0xa7, 0xd4, 0xac, 0x54, 0xd6, 0x50, 0x36, 0x9a, 0x64, 0xea,
+	0xf8, 0x05, 0x25, 0xeb, 0x2c, 0x5a, 0x29, 0xa5, 0x9c, 0x3b, 0x71, 0xb2, 0x7e, 0xd7, 0xf0, 0xa8,
+	0xf3, 0x7e, 0x05, 0x2c, 0x01, 0x24, 0xe0, 0x54, 0x4e, 0xd4, 0x6e, 0x10, 0x96, 0x14, 0xc2, 0x02,
+	0x2a, 0x67, 0x1f, 0x8f, 0x3a, 0x4b, 0x00, 0x7d, 0x09, 0xe0, 0x4c, 0xb3, 0xe8, 0xef, 0x8f, 0xf5,
+	0x73, 0x3d, 0x8f, 0xcb, 0xdb, 0x1b, 0x8a, 0xf9, 0x14, 0x7e, 0x44, 0x50, 0x4d, 0x9d, 0xfc, 0xc5,
+	0xf5, 0x9f, 0xef, 0xcf, 0x81, 0x5f, 0xee, 0xcf, 0x81, 0xdf, 0xef, 0xcf, 0x81, 0xd7, 0xce, 0x97,
+	0xff, 0x89, 0xb0, 0xe3, 0x67, 0xc7, 0xc6, 0x98, 0xfa, 0x27, 0xb0, 0xfa, 0xf7, 0x00, 0x17, 0x68,
+	0x83, 0xee, 0x0d, 0x19, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2386,6 +2462,24 @@ func (m *WorkflowResubmitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.CarryOverAnnotations) > 0 {
+		for iNdEx := len(m.CarryOverAnnotations) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CarryOverAnnotations[iNdEx])
+			copy(dAtA[i:], m.CarryOverAnnotations[iNdEx])
+			i = encodeVarintWorkflow(dAtA, i, uint64(len(m.CarryOverAnnotations[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.CarryOverLabels) > 0 {
+		for iNdEx := len(m.CarryOverLabels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CarryOverLabels[iNdEx])
+			copy(dAtA[i:], m.CarryOverLabels[iNdEx])
+			i = encodeVarintWorkflow(dAtA, i, uint64(len(m.CarryOverLabels[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Parameters) > 0 {
 		for iNdEx := len(m.Parameters) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Parameters[iNdEx])
@@ -2602,6 +2696,13 @@ func (m *WorkflowStopRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Strategy) > 0 {
+		i -= len(m.Strategy)
+		copy(dAtA[i:], m.Strategy)
+		i = encodeVarintWorkflow(dAtA, i, uint64(len(m.Strategy)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Message) > 0 {
 		i -= len(m.Message)
 		copy(dAtA[i:], m.Message)
@@ -2657,6 +2758,13 @@ func (m *WorkflowSetRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.OutputArtifacts) > 0 {
+		i -= len(m.OutputArtifacts)
+		copy(dAtA[i:], m.OutputArtifacts)
+		i = encodeVarintWorkflow(dAtA, i, uint64(len(m.OutputArtifacts)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.OutputParameters) > 0 {
 		i -= len(m.OutputParameters)
 		copy(dAtA[i:], m.OutputParameters)
@@ -2767,6 +2875,13 @@ func (m *WorkflowLogRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.UntilTime) > 0 {
+		i -= len(m.UntilTime)
+		copy(dAtA[i:], m.UntilTime)
+		i = encodeVarintWorkflow(dAtA, i, uint64(len(m.UntilTime)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.Selector) > 0 {
 		i -= len(m.Selector)
 		copy(dAtA[i:], m.Selector)
@@ -2931,6 +3046,15 @@ func (m *WatchWorkflowsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Names) > 0 {
+		for iNdEx := len(m.Names) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Names[iNdEx])
+			copy(dAtA[i:], m.Names[iNdEx])
+			i = encodeVarintWorkflow(dAtA, i, uint64(len(m.Names[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
 	if len(m.Fields) > 0 {
 		i -= len(m.Fields)
 		copy(dAtA[i:], m.Fields)
@@ -3076,6 +3200,25 @@ func (m *LogEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Timestamp != nil {
+		{
+			size, err := m.Timestamp.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWorkflow(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Container) > 0 {
+		i -= len(m.Container)
+		copy(dAtA[i:], m.Container)
+		i = encodeVarintWorkflow(dAtA, i, uint64(len(m.Container)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.PodName) > 0 {
 		i -= len(m.PodName)
 		copy(dAtA[i:], m.PodName)
@@ -3316,6 +3459,18 @@ func (m *WorkflowResubmitRequest) Size() (n int) {
 			n += 1 + l + sovWorkflow(uint64(l))
 		}
 	}
+	if len(m.CarryOverLabels) > 0 {
+		for _, s := range m.CarryOverLabels {
+			l = len(s)
+			n += 1 + l + sovWorkflow(uint64(l))
+		}
+	}
+	if len(m.CarryOverAnnotations) > 0 {
+		for _, s := range m.CarryOverAnnotations {
+			l = len(s)
+			n += 1 + l + sovWorkflow(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3421,6 +3576,10 @@ func (m *WorkflowStopRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflow(uint64(l))
 	}
+	l = len(m.Strategy)
+	if l > 0 {
+		n += 1 + l + sovWorkflow(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3457,6 +3616,10 @@ func (m *WorkflowSetRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflow(uint64(l))
 	}
+	l = len(m.OutputArtifacts)
+	if l > 0 {
+		n += 1 + l + sovWorkflow(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3513,6 +3676,10 @@ func (m *WorkflowLogRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflow(uint64(l))
 	}
+	l = len(m.UntilTime)
+	if l > 0 {
+		n += 1 + l + sovWorkflow(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3576,6 +3743,12 @@ func (m *WatchWorkflowsRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflow(uint64(l))
 	}
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			l = len(s)
+			n += 1 + l + sovWorkflow(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3636,6 +3809,14 @@ func (m *LogEntry) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflow(uint64(l))
 	}
+	l = len(m.Container)
+	if l > 0 {
+		n += 1 + l + sovWorkflow(uint64(l))
+	}
+	if m.Timestamp != nil {
+		l = m.Timestamp.Size()
+		n += 1 + l + sovWorkflow(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -4382,6 +4563,70 @@ func (m *WorkflowResubmitRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CarryOverLabels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CarryOverLabels = append(m.CarryOverLabels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CarryOverAnnotations", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CarryOverAnnotations = append(m.CarryOverAnnotations, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflow(dAtA[iNdEx:])
@@ -5022,6 +5267,38 @@ func (m *WorkflowStopRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Message = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Strategy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflow(dAtA[iNdEx:])
@@ -5265,6 +5542,38 @@ func (m *WorkflowSetRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.OutputParameters = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputArtifacts", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutputArtifacts = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflow(dAtA[iNdEx:])
@@ -5627,6 +5936,38 @@ func (m *WorkflowLogRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Selector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UntilTime", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.UntilTime = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflow(dAtA[iNdEx:])
@@ -6000,6 +6341,38 @@ func (m *WatchWorkflowsRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Fields = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflow(dAtA[iNdEx:])
@@ -6353,6 +6726,74 @@ func (m *LogEntry) Unmarshal(dAtA []byte) error {
 			}
 			m.PodName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Container", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Container = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflow
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflow
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Timestamp == nil {
+				m.Timestamp = &v1.MicroTime{}
+			}
+			if err := m.Timestamp.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflow(dAtA[iNdEx:])
@@ -30,11 +30,17 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type ListArchivedWorkflowsRequest struct {
-	ListOptions          *v1.ListOptions `protobuf:"bytes,1,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
-	NamePrefix           string          `protobuf:"bytes,2,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	ListOptions *v1.ListOptions `protobuf:"bytes,1,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
+	NamePrefix  string          `protobuf:"bytes,2,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
+	// SortBy is the field to sort by, one of "startedAt" (the default), "finishedAt", "duration", "progress" or "name", prefixed with "-" for descending order, e.g. "-duration"
+	SortBy string `protobuf:"bytes,3,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	// Fields to be included or excluded in the response. e.g. "items.spec,items.status.phase", "-items.status.nodes"
+	Fields string `protobuf:"bytes,4,opt,name=fields,proto3" json:"fields,omitempty"`
+	// Search matches workflows whose arguments, annotations or node messages contain all of the words, e.g. "ImagePullBackOff"
+	Search               string   `protobuf:"bytes,5,opt,name=search,proto3" json:"search,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListArchivedWorkflowsRequest) Reset()         { *m = ListArchivedWorkflowsRequest{} }
@@ -84,8 +90,31 @@ func (m *ListArchivedWorkflowsRequest) GetNamePrefix() string {
 	return ""
 }
 
+func (m *ListArchivedWorkflowsRequest) GetSortBy() string {
+	if m != nil {
+		return m.SortBy
+	}
+	return ""
+}
+
+func (m *ListArchivedWorkflowsRequest) GetFields() string {
+	if m != nil {
+		return m.Fields
+	}
+	return ""
+}
+
+func (m *ListArchivedWorkflowsRequest) GetSearch() string {
+	if m != nil {
+		return m.Search
+	}
+	return ""
+}
+
 type GetArchivedWorkflowRequest struct {
-	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Fields to be included or excluded in the response. e.g. "spec,status.phase", "-status.nodes"
+	Fields               string   `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -131,6 +160,13 @@ func (m *GetArchivedWorkflowRequest) GetUid() string {
 	return ""
 }
 
+func (m *GetArchivedWorkflowRequest) GetFields() string {
+	if m != nil {
+		return m.Fields
+	}
+	return ""
+}
+
 type DeleteArchivedWorkflowRequest struct {
 	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -393,11 +429,15 @@ func (m *RetryArchivedWorkflowRequest) GetParameters() []string {
 }
 
 type ResubmitArchivedWorkflowRequest struct {
-	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Memoized             bool     `protobuf:"varint,4,opt,name=memoized,proto3" json:"memoized,omitempty"`
-	Parameters           []string `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Uid        string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name       string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Memoized   bool     `protobuf:"varint,4,opt,name=memoized,proto3" json:"memoized,omitempty"`
+	Parameters []string `protobuf:"bytes,5,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// carryOverLabels are globs of the keys of the labels that carry over, or all of them if there are none
+	CarryOverLabels []string `protobuf:"bytes,6,rep,name=carryOverLabels,proto3" json:"carryOverLabels,omitempty"`
+	// carryOverAnnotations are globs of the keys of the annotations that carry over, or all of them if there are none
+	CarryOverAnnotations []string `protobuf:"bytes,7,rep,name=carryOverAnnotations,proto3" json:"carryOverAnnotations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -471,6 +511,20 @@ func (m *ResubmitArchivedWorkflowRequest) GetParameters() []string {
 	return nil
 }
 
+func (m *ResubmitArchivedWorkflowRequest) GetCarryOverLabels() []string {
+	if m != nil {
+		return m.CarryOverLabels
+	}
+	return nil
+}
+
+func (m *ResubmitArchivedWorkflowRequest) GetCarryOverAnnotations() []string {
+	if m != nil {
+		return m.CarryOverAnnotations
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListArchivedWorkflowsRequest)(nil), "workflowarchive.ListArchivedWorkflowsRequest")
 	proto.RegisterType((*GetArchivedWorkflowRequest)(nil), "workflowarchive.GetArchivedWorkflowRequest")
@@ -487,56 +541,60 @@ func init() {
 }
 
 var fileDescriptor_95ca9a2d33e8bb19 = []byte{
-	// 773 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x96, 0xdd, 0x6a, 0xe3, 0x46,
-	0x14, 0xc7, 0x99, 0x7c, 0x91, 0x4c, 0x2e, 0xda, 0x4e, 0x49, 0x6b, 0x84, 0xe3, 0xb8, 0xa2, 0x4d,
-	0x9c, 0xa4, 0x1e, 0xc5, 0x49, 0x4a, 0x4b, 0xae, 0xda, 0x52, 0x5a, 0x68, 0x3e, 0x5a, 0x64, 0x68,
-	0xa1, 0x37, 0x65, 0x2c, 0x9d, 0xd8, 0x53, 0x4b, 0x1a, 0x75, 0x66, 0xa4, 0x34, 0x2d, 0xb9, 0xe9,
-	0x3e, 0x42, 0x9e, 0x60, 0x61, 0x1f, 0x62, 0xd9, 0xfb, 0x85, 0xbd, 0x5a, 0x96, 0xdd, 0xbb, 0xbd,
-	0x58, 0x96, 0xb0, 0x0f, 0xb2, 0x48, 0xb6, 0xec, 0xac, 0x2d, 0x7f, 0xc0, 0x3a, 0x57, 0x1e, 0x9f,
-	0x39, 0x3a, 0xfa, 0xfd, 0x0f, 0xe7, 0xfc, 0x11, 0x3e, 0x0c, 0xdb, 0x4d, 0x8b, 0x85, 0xdc, 0xf1,
-	0x38, 0x04, 0xda, 0xba, 0x10, 0xb2, 0x7d, 0xee, 0x89, 0x0b, 0x26, 0x9d, 0x16, 0x8f, 0xa1, 0xf7,
-	0xbf, 0xda, 0x0d, 0xd0, 0x50, 0x0a, 0x2d, 0xc8, 0x07, 0x03, 0x79, 0x46, 0xb1, 0x29, 0x44, 0xd3,
-	0x83, 0xa4, 0x92, 0xc5, 0x82, 0x40, 0x68, 0xa6, 0xb9, 0x08, 0x54, 0x27, 0xdd, 0x38, 0x6c, 0x7f,
-	0xa3, 0x28, 0x17, 0xc9, 0xad, 0xcf, 0x9c, 0x16, 0x0f, 0x40, 0x5e, 0x5a, 0xdd, 0x17, 0x2b, 0xcb,
-	0x07, 0xcd, 0xac, 0xb8, 0x66, 0x35, 0x21, 0x00, 0xc9, 0x34, 0xb8, 0xdd, 0xa7, 0x4e, 0x9b, 0x5c,
-	0xb7, 0xa2, 0x06, 0x75, 0x84, 0x6f, 0x31, 0xd9, 0x14, 0xa1, 0x14, 0x7f, 0xa5, 0x87, 0x6a, 0xf6,
-	0x76, 0xd5, 0x2f, 0x92, 0x85, 0xac, 0xb8, 0xc6, 0xbc, 0xb0, 0xc5, 0x86, 0xca, 0x99, 0xd7, 0x08,
-	0x17, 0x4f, 0xb8, 0xd2, 0xdf, 0x75, 0x90, 0xdd, 0xdf, 0xb3, 0x22, 0x36, 0xfc, 0x1d, 0x81, 0xd2,
-	0xa4, 0x8e, 0x57, 0x3d, 0xae, 0xf4, 0x2f, 0x61, 0x8a, 0x5e, 0x40, 0x65, 0x54, 0x59, 0xdd, 0xaf,
-	0xd1, 0x0e, 0x3b, 0xbd, 0xcd, 0x4e, 0xc3, 0x76, 0x33, 0x09, 0x28, 0x9a, 0xb0, 0xd3, 0xb8, 0x46,
-	0x4f, 0xfa, 0x0f, 0xda, 0xb7, 0xab, 0x90, 0x12, 0xc6, 0x01, 0xf3, 0xe1, 0x57, 0x09, 0xe7, 0xfc,
-	0x9f, 0xc2, 0x5c, 0x19, 0x55, 0x56, 0xec, 0x5b, 0x11, 0x93, 0x62, 0xe3, 0x27, 0x18, 0x62, 0xca,
-	0x90, 0x3e, 0xc4, 0xf3, 0x11, 0x77, 0x53, 0x94, 0x15, 0x3b, 0x39, 0x9a, 0x35, 0xbc, 0xfe, 0x03,
-	0x78, 0xa0, 0x61, 0xfa, 0x47, 0x3e, 0xc3, 0x1b, 0x83, 0xc9, 0x9d, 0x12, 0xae, 0x0d, 0x2a, 0x14,
-	0x81, 0x02, 0x73, 0x13, 0x7f, 0x9e, 0xd7, 0x9a, 0x13, 0xd6, 0x00, 0xef, 0x18, 0x2e, 0xb3, 0x16,
-	0x99, 0x57, 0x78, 0x73, 0x64, 0xde, 0x6f, 0xcc, 0x8b, 0xe0, 0x4e, 0x9b, 0x69, 0xbe, 0x42, 0xb8,
-	0x68, 0x83, 0x96, 0x97, 0x53, 0x8b, 0x27, 0x04, 0x2f, 0x24, 0xdd, 0xee, 0x76, 0x3e, 0x3d, 0x93,
-	0x22, 0x5e, 0x49, 0x7e, 0x55, 0xc8, 0x1c, 0x28, 0xcc, 0xa7, 0x17, 0xfd, 0x00, 0xf9, 0x12, 0x7f,
-	0x24, 0x41, 0x69, 0x26, 0x75, 0x3d, 0x72, 0x1c, 0x50, 0xea, 0x3c, 0xf2, 0x0a, 0x0b, 0x65, 0x54,
-	0x59, 0xb6, 0x87, 0x2f, 0x92, 0xec, 0x40, 0xb8, 0xf0, 0x23, 0x07, 0xcf, 0xad, 0x83, 0x07, 0x8e,
-	0x16, 0xb2, 0xb0, 0x98, 0xd6, 0x1c, 0xbe, 0x48, 0xa6, 0x21, 0x64, 0x92, 0xf9, 0xa0, 0x41, 0xaa,
-	0xc2, 0x52, 0x79, 0x3e, 0x99, 0x86, 0x7e, 0xc4, 0xbc, 0x8f, 0xf0, 0x86, 0x0d, 0x2a, 0x6a, 0xf8,
-	0x5c, 0xdf, 0xa5, 0x46, 0x03, 0x2f, 0xfb, 0xe0, 0x0b, 0xfe, 0x2f, 0xb8, 0x5d, 0x69, 0xbd, 0xff,
-	0x03, 0x8c, 0x8b, 0x83, 0x8c, 0xfb, 0xf7, 0x56, 0xf1, 0xa7, 0x83, 0x6c, 0x75, 0x90, 0x31, 0x77,
-	0x80, 0x3c, 0x42, 0x78, 0x2d, 0x77, 0xc7, 0x48, 0x95, 0x0e, 0x58, 0x06, 0x1d, 0xb7, 0x8b, 0xc6,
-	0x19, 0xed, 0x2f, 0x3f, 0xcd, 0x96, 0x3f, 0x3d, 0xfc, 0xd9, 0x5b, 0x7e, 0x1a, 0x1f, 0xf4, 0x67,
-	0x27, 0x8b, 0xd2, 0x6c, 0xff, 0x69, 0x6f, 0x38, 0xb9, 0xd2, 0xa6, 0xf9, 0xff, 0x8b, 0x37, 0xd7,
-	0x73, 0x45, 0x62, 0xa4, 0x0e, 0x15, 0xd7, 0xac, 0x2e, 0x85, 0xdb, 0xf7, 0x12, 0xf2, 0x10, 0xe1,
-	0x8f, 0x73, 0x76, 0x91, 0xec, 0x0e, 0xa1, 0x8f, 0xde, 0x58, 0xe3, 0xe7, 0xd9, 0x81, 0x9b, 0x95,
-	0x14, 0xda, 0x24, 0xe5, 0xd1, 0xd0, 0xd6, 0x7f, 0x11, 0x77, 0xaf, 0xc8, 0x03, 0x84, 0x3f, 0xc9,
-	0xb7, 0x05, 0x42, 0x87, 0xe8, 0xc7, 0xfa, 0x87, 0xb1, 0x37, 0x94, 0x3f, 0xc9, 0x3c, 0xba, 0x98,
-	0x3b, 0x93, 0x31, 0x9f, 0x23, 0xbc, 0x3e, 0xd6, 0x67, 0xc8, 0x57, 0x53, 0x8d, 0xc9, 0xa0, 0x2f,
-	0x19, 0xc7, 0xef, 0xdf, 0xf5, 0x5e, 0x4d, 0xb3, 0x9a, 0xea, 0xd9, 0x22, 0x5f, 0x8c, 0xd6, 0x53,
-	0xf5, 0x92, 0xec, 0x6a, 0x3b, 0x41, 0x7e, 0x89, 0xf0, 0xc6, 0x04, 0x53, 0x24, 0x5f, 0x4f, 0x2f,
-	0xeb, 0x1d, 0x1b, 0x35, 0x4e, 0x67, 0x24, 0xac, 0x53, 0xd5, 0xb4, 0x52, 0x69, 0xdb, 0x64, 0x6b,
-	0xa2, 0xb4, 0xb8, 0x03, 0xfe, 0x18, 0xe1, 0xb5, 0x5c, 0xc7, 0xcd, 0x59, 0xe8, 0x71, 0xce, 0x3c,
-	0xd3, 0xbd, 0xa8, 0xa5, 0x2a, 0x76, 0x8d, 0xcd, 0x49, 0x03, 0x67, 0xc9, 0x04, 0xe9, 0x08, 0xed,
-	0x90, 0xa7, 0x08, 0x17, 0x46, 0x19, 0x2b, 0xd9, 0xcb, 0x91, 0x32, 0xd6, 0x83, 0x67, 0xaa, 0xe6,
-	0x30, 0x55, 0x43, 0x8d, 0xed, 0x29, 0xd4, 0x74, 0xa8, 0x8e, 0xd0, 0xce, 0xf7, 0x67, 0x4f, 0x6e,
-	0x4a, 0xe8, 0xd9, 0x4d, 0x09, 0xbd, 0xbe, 0x29, 0xa1, 0x3f, 0xbe, 0x9d, 0xfe, 0x53, 0x29, 0xff,
-	0x43, 0xaf, 0xb1, 0x94, 0x7e, 0x24, 0x1d, 0xbc, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x86, 0x56, 0x79,
-	0xf8, 0x10, 0x0a, 0x00, 0x00,
+	// 834 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x96, 0xdf, 0x6e, 0xdc, 0x44,
+	0x14, 0xc6, 0x35, 0x49, 0x1b, 0x9a, 0xc9, 0x45, 0x61, 0xa0, 0x65, 0x65, 0x6d, 0x93, 0x60, 0x41,
+	0x9a, 0xa6, 0xec, 0xb8, 0x9b, 0x06, 0x81, 0x7a, 0x45, 0x2b, 0x54, 0x24, 0x9a, 0xb6, 0xc8, 0x91,
+	0x40, 0xe2, 0x06, 0x4d, 0xec, 0x93, 0xcd, 0xb0, 0xb6, 0xc7, 0xcc, 0x8c, 0x5d, 0x16, 0xd4, 0x1b,
+	0x78, 0x04, 0x5e, 0x81, 0x87, 0x40, 0xdc, 0x23, 0x71, 0x85, 0x10, 0xdc, 0x81, 0x84, 0x50, 0xc4,
+	0x7b, 0x80, 0x66, 0x6c, 0xaf, 0x17, 0xaf, 0xf7, 0x8f, 0x44, 0x72, 0xb5, 0x33, 0x67, 0xce, 0x1e,
+	0x7f, 0xdf, 0x9c, 0xe3, 0x9f, 0x8c, 0x0f, 0xd2, 0xe1, 0xc0, 0x63, 0x29, 0x0f, 0x22, 0x0e, 0x89,
+	0xf6, 0x9e, 0x09, 0x39, 0x3c, 0x89, 0xc4, 0x33, 0x26, 0x83, 0x53, 0x9e, 0xc3, 0x78, 0xdf, 0x2b,
+	0x03, 0x34, 0x95, 0x42, 0x0b, 0x72, 0xb5, 0x91, 0xe7, 0x74, 0x07, 0x42, 0x0c, 0x22, 0x30, 0x95,
+	0x3c, 0x96, 0x24, 0x42, 0x33, 0xcd, 0x45, 0xa2, 0x8a, 0x74, 0xe7, 0x60, 0xf8, 0x8e, 0xa2, 0x5c,
+	0x98, 0xd3, 0x98, 0x05, 0xa7, 0x3c, 0x01, 0x39, 0xf2, 0xca, 0x07, 0x2b, 0x2f, 0x06, 0xcd, 0xbc,
+	0xbc, 0xef, 0x0d, 0x20, 0x01, 0xc9, 0x34, 0x84, 0xe5, 0xbf, 0x1e, 0x0f, 0xb8, 0x3e, 0xcd, 0x8e,
+	0x69, 0x20, 0x62, 0x8f, 0xc9, 0x81, 0x48, 0xa5, 0xf8, 0xcc, 0x2e, 0x7a, 0xd5, 0xd3, 0x55, 0x5d,
+	0xa4, 0x0a, 0x79, 0x79, 0x9f, 0x45, 0xe9, 0x29, 0x9b, 0x2a, 0xe7, 0xfe, 0x81, 0x70, 0xf7, 0x90,
+	0x2b, 0x7d, 0xbf, 0x90, 0x1c, 0x7e, 0x5c, 0x15, 0xf1, 0xe1, 0xf3, 0x0c, 0x94, 0x26, 0x47, 0x78,
+	0x23, 0xe2, 0x4a, 0x3f, 0x4d, 0xad, 0xf4, 0x0e, 0xda, 0x46, 0xbb, 0x1b, 0xfb, 0x7d, 0x5a, 0x68,
+	0xa7, 0x93, 0xda, 0x69, 0x3a, 0x1c, 0x98, 0x80, 0xa2, 0x46, 0x3b, 0xcd, 0xfb, 0xf4, 0xb0, 0xfe,
+	0xa3, 0x3f, 0x59, 0x85, 0x6c, 0x62, 0x9c, 0xb0, 0x18, 0x3e, 0x94, 0x70, 0xc2, 0xbf, 0xe8, 0xac,
+	0x6c, 0xa3, 0xdd, 0x75, 0x7f, 0x22, 0x42, 0xae, 0xe3, 0x35, 0x25, 0xa4, 0x7e, 0x30, 0xea, 0xac,
+	0xda, 0xb3, 0x72, 0x67, 0xe2, 0x27, 0x1c, 0xa2, 0x50, 0x75, 0x2e, 0x15, 0xf1, 0x62, 0x67, 0xf3,
+	0xc1, 0xdc, 0x7a, 0xe7, 0x72, 0x99, 0x6f, 0x77, 0xee, 0x43, 0xec, 0xbc, 0x0f, 0x53, 0xde, 0x2a,
+	0x6b, 0x2f, 0xe2, 0xd5, 0x8c, 0x87, 0xd6, 0xd2, 0xba, 0x6f, 0x96, 0x13, 0xf5, 0x57, 0x26, 0xeb,
+	0xbb, 0x7d, 0x7c, 0xe3, 0x3d, 0x88, 0x40, 0xc3, 0xd2, 0xa5, 0xdc, 0xd7, 0xf0, 0x56, 0x33, 0xb9,
+	0x28, 0x11, 0xfa, 0xa0, 0x52, 0x91, 0x28, 0x70, 0x77, 0xf0, 0xeb, 0x6d, 0x57, 0x7f, 0xc8, 0x8e,
+	0x21, 0x7a, 0x04, 0xa3, 0xaa, 0x05, 0xee, 0x73, 0xbc, 0x33, 0x33, 0xef, 0x23, 0x16, 0x65, 0x70,
+	0xa1, 0xcd, 0x72, 0xff, 0x44, 0xb8, 0xeb, 0x83, 0x96, 0xa3, 0xe5, 0xef, 0x91, 0xe0, 0x4b, 0xa6,
+	0x9b, 0xe5, 0x2d, 0xda, 0x35, 0xe9, 0xe2, 0x75, 0xf3, 0xab, 0x52, 0x16, 0x40, 0xd9, 0xd6, 0x3a,
+	0x40, 0xde, 0xc4, 0x2f, 0x49, 0x50, 0x9a, 0x49, 0x7d, 0x94, 0x05, 0x01, 0x28, 0x75, 0x92, 0x45,
+	0xb6, 0xc9, 0x57, 0xfc, 0xe9, 0x03, 0x93, 0x9d, 0x88, 0x10, 0x1e, 0x9a, 0xee, 0x1c, 0x41, 0x04,
+	0x81, 0x16, 0xb2, 0x6c, 0xfd, 0xf4, 0x81, 0x99, 0xb6, 0x94, 0x49, 0x16, 0x83, 0x06, 0xa9, 0x3a,
+	0x6b, 0xdb, 0xab, 0x66, 0xda, 0xea, 0x88, 0xfb, 0x0f, 0xc2, 0x5b, 0x3e, 0xa8, 0xec, 0x38, 0xe6,
+	0xfa, 0x22, 0x3d, 0x3a, 0xf8, 0x4a, 0x0c, 0xb1, 0xe0, 0x5f, 0x42, 0x58, 0x5a, 0x1b, 0xef, 0x1b,
+	0x1a, 0x2f, 0x37, 0x35, 0x92, 0x5d, 0x7c, 0x35, 0x60, 0x52, 0x8e, 0x9e, 0xe6, 0x20, 0x6d, 0xe3,
+	0x2b, 0x23, 0xcd, 0x30, 0xd9, 0xc7, 0xaf, 0x8c, 0x43, 0xf7, 0x6b, 0xe8, 0x74, 0x5e, 0xb0, 0xe9,
+	0xad, 0x67, 0xfb, 0xdf, 0x6c, 0xe0, 0x57, 0x9b, 0xce, 0x8f, 0x40, 0xe6, 0x3c, 0x00, 0xf2, 0x03,
+	0xc2, 0xd7, 0x5a, 0x09, 0x41, 0x7a, 0xb4, 0x01, 0x3c, 0x3a, 0x8f, 0x24, 0xce, 0x13, 0x5a, 0xa3,
+	0x8b, 0x56, 0xe8, 0xb2, 0x8b, 0x4f, 0xc7, 0xe8, 0xa2, 0xf9, 0xdd, 0x7a, 0x32, 0xab, 0x28, 0xad,
+	0xe8, 0x45, 0xc7, 0xa3, 0xcf, 0x95, 0x76, 0xdd, 0xaf, 0x7f, 0xfb, 0xfb, 0xdb, 0x95, 0x2e, 0x71,
+	0x2c, 0x5f, 0xf3, 0xbe, 0x57, 0xaa, 0x08, 0x6b, 0x12, 0x92, 0xef, 0x11, 0x7e, 0xb9, 0x85, 0x00,
+	0xe4, 0xf6, 0x94, 0xf4, 0xd9, 0x9c, 0x70, 0x3e, 0x38, 0x3f, 0xe1, 0xee, 0xae, 0x15, 0xed, 0x92,
+	0xed, 0xd9, 0xa2, 0xbd, 0xaf, 0x32, 0x1e, 0x3e, 0x27, 0xdf, 0x21, 0x7c, 0xbd, 0x1d, 0x3a, 0x84,
+	0x4e, 0xa9, 0x9f, 0x4b, 0x27, 0xe7, 0xce, 0x54, 0xfe, 0x22, 0x34, 0x95, 0x32, 0xf7, 0x16, 0xcb,
+	0xfc, 0x15, 0xe1, 0x1b, 0x73, 0x29, 0x46, 0xde, 0x5a, 0x6a, 0x4c, 0x9a, 0xd4, 0x73, 0x1e, 0xfd,
+	0xff, 0x5b, 0x1f, 0xd7, 0x74, 0x7b, 0xd6, 0xcf, 0x4d, 0xf2, 0xc6, 0x6c, 0x3f, 0xbd, 0xc8, 0x64,
+	0xf7, 0x86, 0x46, 0xf2, 0xef, 0x08, 0x6f, 0x2d, 0x40, 0x2e, 0x79, 0x7b, 0x79, 0x5b, 0xff, 0x81,
+	0xb4, 0xf3, 0xf8, 0x9c, 0x8c, 0x15, 0x55, 0x5d, 0xcf, 0x5a, 0xbb, 0x45, 0x6e, 0x2e, 0xb4, 0x96,
+	0x17, 0xc2, 0x7f, 0x44, 0xf8, 0x5a, 0x2b, 0xcf, 0x5b, 0x5e, 0xe8, 0x79, 0xdc, 0x3f, 0xd7, 0xf7,
+	0xa2, 0x6f, 0x5d, 0xdc, 0x76, 0x76, 0x16, 0x0d, 0x9c, 0x27, 0x8d, 0xa4, 0x7b, 0x68, 0x8f, 0xfc,
+	0x8c, 0x70, 0x67, 0x16, 0xb6, 0xc9, 0x9d, 0x16, 0x2b, 0x73, 0x09, 0x7f, 0xae, 0x6e, 0x0e, 0xac,
+	0x1b, 0xea, 0xdc, 0x5a, 0xc2, 0x4d, 0xa1, 0xea, 0x1e, 0xda, 0x7b, 0xf0, 0xe4, 0xa7, 0xb3, 0x4d,
+	0xf4, 0xcb, 0xd9, 0x26, 0xfa, 0xeb, 0x6c, 0x13, 0x7d, 0xf2, 0xee, 0xf2, 0x1f, 0x7a, 0xed, 0x9f,
+	0xa9, 0xc7, 0x6b, 0xf6, 0x13, 0xef, 0xee, 0xbf, 0x03, 0x00, 0xc9, 0xe2, 0x63, 0xe4, 0xce, 0x0a,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -859,6 +917,27 @@ func (m *ListArchivedWorkflowsRequest) MarshalToSizedBuffer(dAtA []byte) (int, e
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Search) > 0 {
+		i -= len(m.Search)
+		copy(dAtA[i:], m.Search)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Search)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Fields) > 0 {
+		i -= len(m.Fields)
+		copy(dAtA[i:], m.Fields)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Fields)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.SortBy) > 0 {
+		i -= len(m.SortBy)
+		copy(dAtA[i:], m.SortBy)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.SortBy)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.NamePrefix) > 0 {
 		i -= len(m.NamePrefix)
 		copy(dAtA[i:], m.NamePrefix)
@@ -905,6 +984,13 @@ func (m *GetArchivedWorkflowRequest) MarshalToSizedBuffer(dAtA []byte) (int, err
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Fields) > 0 {
+		i -= len(m.Fields)
+		copy(dAtA[i:], m.Fields)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Fields)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Uid) > 0 {
 		i -= len(m.Uid)
 		copy(dAtA[i:], m.Uid)
@@ -1140,6 +1226,24 @@ func (m *ResubmitArchivedWorkflowRequest) MarshalToSizedBuffer(dAtA []byte) (int
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.CarryOverAnnotations) > 0 {
+		for iNdEx := len(m.CarryOverAnnotations) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CarryOverAnnotations[iNdEx])
+			copy(dAtA[i:], m.CarryOverAnnotations[iNdEx])
+			i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.CarryOverAnnotations[iNdEx])))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.CarryOverLabels) > 0 {
+		for iNdEx := len(m.CarryOverLabels) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.CarryOverLabels[iNdEx])
+			copy(dAtA[i:], m.CarryOverLabels[iNdEx])
+			i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.CarryOverLabels[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Parameters) > 0 {
 		for iNdEx := len(m.Parameters) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Parameters[iNdEx])
@@ -1208,6 +1312,18 @@ func (m *ListArchivedWorkflowsRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
+	l = len(m.SortBy)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Search)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1224,6 +1340,10 @@ func (m *GetArchivedWorkflowRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
+	l = len(m.Fields)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1350,6 +1470,18 @@ func (m *ResubmitArchivedWorkflowRequest) Size() (n int) {
 			n += 1 + l + sovWorkflowArchive(uint64(l))
 		}
 	}
+	if len(m.CarryOverLabels) > 0 {
+		for _, s := range m.CarryOverLabels {
+			l = len(s)
+			n += 1 + l + sovWorkflowArchive(uint64(l))
+		}
+	}
+	if len(m.CarryOverAnnotations) > 0 {
+		for _, s := range m.CarryOverAnnotations {
+			l = len(s)
+			n += 1 + l + sovWorkflowArchive(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1459,6 +1591,102 @@ func (m *ListArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.NamePrefix = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SortBy", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SortBy = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Search", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Search = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
@@ -1542,6 +1770,38 @@ func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Uid = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
@@ -2244,6 +2504,70 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CarryOverLabels", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CarryOverLabels = append(m.CarryOverLabels, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CarryOverAnnotations", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CarryOverAnnotations = append(m.CarryOverAnnotations, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
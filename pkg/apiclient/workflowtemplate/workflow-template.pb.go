@@ -97,12 +97,14 @@ func (m *WorkflowTemplateCreateRequest) GetCreateOptions() *v1.CreateOptions {
 }
 
 type WorkflowTemplateGetRequest struct {
-	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace            string         `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	GetOptions           *v1.GetOptions `protobuf:"bytes,3,opt,name=getOptions,proto3" json:"getOptions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Name       string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string         `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	GetOptions *v1.GetOptions `protobuf:"bytes,3,opt,name=getOptions,proto3" json:"getOptions,omitempty"`
+	// the revision to get, defaults to the latest
+	Revision             int64    `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *WorkflowTemplateGetRequest) Reset()         { *m = WorkflowTemplateGetRequest{} }
@@ -159,12 +161,21 @@ func (m *WorkflowTemplateGetRequest) GetGetOptions() *v1.GetOptions {
 	return nil
 }
 
+func (m *WorkflowTemplateGetRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type WorkflowTemplateListRequest struct {
-	Namespace            string          `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	ListOptions          *v1.ListOptions `protobuf:"bytes,2,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+	Namespace   string          `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ListOptions *v1.ListOptions `protobuf:"bytes,2,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
+	// list the saved revisions of the named template, rather than the templates
+	RevisionsOf          string   `protobuf:"bytes,3,opt,name=revisionsOf,proto3" json:"revisionsOf,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *WorkflowTemplateListRequest) Reset()         { *m = WorkflowTemplateListRequest{} }
@@ -214,6 +225,13 @@ func (m *WorkflowTemplateListRequest) GetListOptions() *v1.ListOptions {
 	return nil
 }
 
+func (m *WorkflowTemplateListRequest) GetRevisionsOf() string {
+	if m != nil {
+		return m.RevisionsOf
+	}
+	return ""
+}
+
 type WorkflowTemplateUpdateRequest struct {
 	// DEPRECATED: This field is ignored.
 	Name                 string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // Deprecated: Do not use.
@@ -459,51 +477,52 @@ func init() {
 }
 
 var fileDescriptor_215375a0ab97a62a = []byte{
-	// 689 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0x4f, 0x4f, 0x14, 0x3f,
-	0x18, 0x4e, 0x97, 0x5f, 0x7e, 0x91, 0x12, 0x12, 0x53, 0x75, 0xdd, 0x8c, 0xb8, 0x21, 0x73, 0x30,
-	0x04, 0xdc, 0x96, 0x05, 0x25, 0x84, 0x9b, 0x40, 0xc2, 0x05, 0x83, 0x19, 0xfc, 0x13, 0xbc, 0x98,
-	0x32, 0xd4, 0x61, 0xdc, 0xd9, 0x76, 0x9c, 0x96, 0x21, 0xc6, 0x70, 0xf1, 0x60, 0x3c, 0x9a, 0xf8,
-	0x05, 0xfc, 0x00, 0x9e, 0xfc, 0x0e, 0x26, 0x9e, 0x0c, 0xc6, 0x83, 0x57, 0x43, 0xfc, 0x02, 0x7e,
-	0x03, 0x33, 0x9d, 0xff, 0xb3, 0x20, 0x03, 0x71, 0x4f, 0xde, 0x9a, 0x6e, 0xdf, 0xf7, 0x7d, 0x9e,
-	0xa7, 0xcf, 0x3e, 0x53, 0xb8, 0xe0, 0xf7, 0x1c, 0x42, 0x7d, 0xd7, 0xf6, 0x5c, 0xc6, 0x15, 0xd9,
-	0x17, 0x41, 0xef, 0xa9, 0x27, 0xf6, 0x15, 0xeb, 0xfb, 0x1e, 0x55, 0x2c, 0xdb, 0xe8, 0xa4, 0x3b,
-	0xd8, 0x0f, 0x84, 0x12, 0xe8, 0x62, 0xf5, 0xa4, 0x31, 0xe1, 0x08, 0xe1, 0x78, 0x2c, 0x6a, 0x46,
-	0x28, 0xe7, 0x42, 0x51, 0xe5, 0x0a, 0x2e, 0xe3, 0xf3, 0xc6, 0xad, 0xde, 0xa2, 0xc4, 0xae, 0x88,
-	0x7e, 0xed, 0x53, 0x7b, 0xd7, 0xe5, 0x2c, 0x78, 0x41, 0x92, 0xd9, 0x92, 0xf4, 0x99, 0xa2, 0x24,
-	0xec, 0x12, 0x87, 0x71, 0x16, 0x50, 0xc5, 0x76, 0x92, 0xaa, 0xbb, 0x8e, 0xab, 0x76, 0xf7, 0xb6,
-	0xb1, 0x2d, 0xfa, 0x84, 0x06, 0x8e, 0xf0, 0x03, 0xf1, 0x4c, 0x2f, 0x3a, 0xe9, 0x78, 0x99, 0x37,
-	0x49, 0xb7, 0x48, 0xd8, 0xa5, 0x9e, 0xbf, 0x4b, 0x07, 0xda, 0x99, 0x6f, 0x1a, 0xf0, 0xfa, 0xa3,
-	0xe4, 0xd4, 0xfd, 0x04, 0xf7, 0x4a, 0xc0, 0xa8, 0x62, 0x16, 0x7b, 0xbe, 0xc7, 0xa4, 0x42, 0x13,
-	0x70, 0x94, 0xd3, 0x3e, 0x93, 0x3e, 0xb5, 0x59, 0x0b, 0x4c, 0x82, 0xa9, 0x51, 0x2b, 0xdf, 0x40,
-	0x1c, 0x5e, 0x48, 0xe9, 0xb6, 0x1a, 0x93, 0x60, 0x6a, 0x6c, 0xce, 0xc2, 0x39, 0x42, 0x9c, 0x22,
-	0xd4, 0x8b, 0x27, 0x19, 0x42, 0x1c, 0xce, 0x63, 0xbf, 0xe7, 0xe0, 0x08, 0x24, 0x4e, 0x77, 0x71,
-	0x0a, 0x12, 0x57, 0x01, 0x59, 0xd9, 0x0c, 0xb4, 0x05, 0xc7, 0x6d, 0x0d, 0x6f, 0xc3, 0xd7, 0x5a,
-	0xb6, 0x46, 0xf4, 0xd0, 0x79, 0x1c, 0x8b, 0x89, 0x8b, 0x62, 0xe6, 0x23, 0x22, 0x31, 0x71, 0xd8,
-	0xc5, 0x2b, 0xc5, 0x52, 0xab, 0xdc, 0xc9, 0x7c, 0x0f, 0xa0, 0x51, 0x9d, 0xbc, 0xc6, 0x54, 0xaa,
-	0x03, 0x82, 0xff, 0x45, 0xb4, 0x13, 0x09, 0xf4, 0xba, 0xac, 0x4d, 0xa3, 0xaa, 0xcd, 0x3d, 0x08,
-	0x1d, 0xa6, 0xca, 0x40, 0x67, 0xeb, 0x01, 0x5d, 0xcb, 0xea, 0xac, 0x42, 0x0f, 0xf3, 0x2d, 0x80,
-	0xd7, 0xaa, 0x10, 0xd7, 0x5d, 0xa9, 0xea, 0xdd, 0xd5, 0x26, 0x1c, 0xf3, 0x5c, 0x99, 0x01, 0x8a,
-	0xaf, 0xab, 0x5b, 0x0f, 0xd0, 0x7a, 0x5e, 0x68, 0x15, 0xbb, 0x98, 0x9f, 0xc0, 0xa0, 0x81, 0x1e,
-	0xf8, 0x3b, 0x05, 0x03, 0x35, 0x8b, 0xc2, 0x2d, 0x37, 0x5a, 0xa0, 0x96, 0x78, 0x45, 0x63, 0x8d,
-	0x0c, 0xdf, 0x58, 0xe6, 0x87, 0x63, 0x78, 0xac, 0x32, 0x8f, 0xe5, 0x3c, 0xce, 0x6e, 0x80, 0x2d,
-	0x38, 0xbe, 0xa3, 0x5b, 0x9c, 0xcb, 0xac, 0xab, 0xc5, 0x52, 0xab, 0xdc, 0xc9, 0x9c, 0x84, 0xed,
-	0x93, 0xd0, 0x4a, 0x5f, 0x70, 0xc9, 0xcc, 0xd7, 0x8d, 0xe3, 0xbc, 0xc2, 0xd5, 0xbf, 0xf6, 0xbf,
-	0x9e, 0xfb, 0x35, 0x0a, 0xaf, 0x56, 0x27, 0x6f, 0xb2, 0x20, 0x74, 0x6d, 0x86, 0x0e, 0x01, 0x6c,
-	0xc6, 0xc5, 0xd5, 0x13, 0x88, 0xe0, 0x6a, 0x9e, 0xe3, 0x3f, 0x06, 0xa5, 0x31, 0x04, 0x81, 0xcc,
-	0xee, 0xab, 0x6f, 0x3f, 0xdf, 0x35, 0x66, 0xcc, 0x1b, 0xfa, 0x1b, 0x12, 0x76, 0x07, 0x3f, 0x3e,
-	0x92, 0xbc, 0xcc, 0x6e, 0xed, 0x60, 0x09, 0x4c, 0xa3, 0x2f, 0x00, 0x5e, 0x5a, 0x63, 0x6a, 0x80,
-	0xcf, 0xcd, 0xd3, 0xf9, 0xe4, 0x69, 0x37, 0x14, 0x32, 0xb7, 0x35, 0x19, 0x82, 0x3a, 0xf5, 0xc8,
-	0xc4, 0xeb, 0x83, 0x88, 0xd0, 0x95, 0x28, 0x7e, 0xaa, 0xfd, 0x24, 0xea, 0x9c, 0x4e, 0xa9, 0x90,
-	0x8e, 0xc6, 0xc3, 0xbf, 0xcf, 0x29, 0x6a, 0x6f, 0x62, 0xcd, 0x6b, 0x0a, 0xd5, 0xbc, 0x24, 0xf4,
-	0x1d, 0xc0, 0x66, 0x1c, 0x91, 0xe7, 0x31, 0x5d, 0x29, 0x5c, 0x87, 0x72, 0x4f, 0x8b, 0x9a, 0xcf,
-	0x9c, 0x71, 0xb6, 0x7b, 0x8a, 0xbc, 0xf7, 0x11, 0xc0, 0x66, 0x1c, 0x43, 0xe7, 0x61, 0x56, 0x8a,
-	0x5b, 0x63, 0xb6, 0x7e, 0x41, 0x92, 0x78, 0x89, 0xbf, 0xa6, 0xcf, 0xe8, 0xaf, 0xaf, 0x00, 0x5e,
-	0x8e, 0x82, 0x71, 0x00, 0x72, 0x2d, 0x7b, 0xf1, 0xa1, 0xfe, 0x65, 0x16, 0x34, 0xa5, 0x59, 0x73,
-	0xa6, 0x26, 0x25, 0xcf, 0xe5, 0x6a, 0x09, 0x4c, 0x2f, 0x6f, 0x7c, 0x3e, 0x6a, 0x83, 0xc3, 0xa3,
-	0x36, 0xf8, 0x71, 0xd4, 0x06, 0x8f, 0xef, 0xd4, 0x7f, 0x33, 0x9e, 0xf0, 0xe8, 0xdd, 0xfe, 0x5f,
-	0x3f, 0x17, 0xe7, 0x7f, 0x07, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x65, 0x92, 0x47, 0x1d, 0x0b, 0x00,
-	0x00,
+	// 713 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0xcf, 0x4f, 0x13, 0x41,
+	0x14, 0xce, 0x14, 0x62, 0xe8, 0x10, 0x12, 0x33, 0x6a, 0x6d, 0x56, 0x6c, 0x9a, 0x3d, 0x98, 0x06,
+	0xec, 0x2c, 0x05, 0x25, 0x84, 0x9b, 0x40, 0xc2, 0x05, 0x83, 0x59, 0xfc, 0x11, 0xbc, 0x98, 0xa5,
+	0x3c, 0x96, 0xb5, 0xdb, 0x99, 0x75, 0x67, 0x58, 0x62, 0x0c, 0x17, 0x0f, 0xc6, 0xbb, 0xff, 0x86,
+	0x27, 0xce, 0x5e, 0x4d, 0x3c, 0x19, 0x8c, 0x07, 0xaf, 0x86, 0xf8, 0x0f, 0xf8, 0x1f, 0x98, 0x9d,
+	0xfd, 0xd1, 0xed, 0x16, 0x64, 0x69, 0xec, 0xc9, 0xdb, 0xf0, 0x98, 0xf7, 0xde, 0xf7, 0x7d, 0xef,
+	0xeb, 0xdb, 0xc1, 0x8b, 0x5e, 0xc7, 0x36, 0x2c, 0xcf, 0x69, 0xbb, 0x0e, 0x30, 0x69, 0x1c, 0x72,
+	0xbf, 0xb3, 0xe7, 0xf2, 0x43, 0x09, 0x5d, 0xcf, 0xb5, 0x24, 0xa4, 0x81, 0x66, 0x12, 0xa1, 0x9e,
+	0xcf, 0x25, 0x27, 0x57, 0xf3, 0x37, 0xb5, 0x69, 0x9b, 0x73, 0xdb, 0x85, 0xb0, 0x98, 0x61, 0x31,
+	0xc6, 0xa5, 0x25, 0x1d, 0xce, 0x44, 0x74, 0x5f, 0xbb, 0xd7, 0x59, 0x12, 0xd4, 0xe1, 0xe1, 0x7f,
+	0xbb, 0x56, 0x7b, 0xdf, 0x61, 0xe0, 0xbf, 0x36, 0xe2, 0xde, 0xc2, 0xe8, 0x82, 0xb4, 0x8c, 0xa0,
+	0x65, 0xd8, 0xc0, 0xc0, 0xb7, 0x24, 0xec, 0xc6, 0x59, 0x0f, 0x6d, 0x47, 0xee, 0x1f, 0xec, 0xd0,
+	0x36, 0xef, 0x1a, 0x96, 0x6f, 0x73, 0xcf, 0xe7, 0x2f, 0xd5, 0xa1, 0x99, 0xb4, 0x17, 0xbd, 0x22,
+	0x49, 0xc8, 0x08, 0x5a, 0x96, 0xeb, 0xed, 0x5b, 0x03, 0xe5, 0xf4, 0xf7, 0x25, 0x7c, 0xfb, 0x59,
+	0x7c, 0xeb, 0x71, 0x8c, 0x7b, 0xd5, 0x07, 0x4b, 0x82, 0x09, 0xaf, 0x0e, 0x40, 0x48, 0x32, 0x8d,
+	0xcb, 0xcc, 0xea, 0x82, 0xf0, 0xac, 0x36, 0x54, 0x51, 0x1d, 0x35, 0xca, 0x66, 0x2f, 0x40, 0x18,
+	0x9e, 0x48, 0xe8, 0x56, 0x4b, 0x75, 0xd4, 0x98, 0x9c, 0x37, 0x69, 0x0f, 0x21, 0x4d, 0x10, 0xaa,
+	0xc3, 0x8b, 0x14, 0x21, 0x0d, 0x16, 0xa8, 0xd7, 0xb1, 0x69, 0x08, 0x92, 0x26, 0x51, 0x9a, 0x80,
+	0xa4, 0x79, 0x40, 0x66, 0xda, 0x83, 0x6c, 0xe3, 0xa9, 0xb6, 0x82, 0xb7, 0xe9, 0x29, 0x2d, 0xab,
+	0x63, 0xaa, 0xe9, 0x02, 0x8d, 0xc4, 0xa4, 0x59, 0x31, 0x7b, 0x2d, 0x42, 0x31, 0x69, 0xd0, 0xa2,
+	0xab, 0xd9, 0x54, 0xb3, 0xbf, 0x92, 0xfe, 0x09, 0x61, 0x2d, 0xdf, 0x79, 0x1d, 0x64, 0xa2, 0x03,
+	0xc1, 0xe3, 0x21, 0xed, 0x58, 0x02, 0x75, 0xee, 0xd7, 0xa6, 0x94, 0xd7, 0xe6, 0x11, 0xc6, 0x36,
+	0xc8, 0x7e, 0xa0, 0x73, 0xc5, 0x80, 0xae, 0xa7, 0x79, 0x66, 0xa6, 0x06, 0xd1, 0xf0, 0x84, 0x0f,
+	0x81, 0x23, 0x1c, 0xce, 0xaa, 0xe3, 0x75, 0xd4, 0x18, 0x33, 0xd3, 0xbf, 0xf5, 0x63, 0x84, 0x6f,
+	0xe5, 0xe1, 0x6f, 0x38, 0x42, 0x16, 0x9b, 0xe3, 0x16, 0x9e, 0x74, 0x1d, 0x91, 0x82, 0x8d, 0x46,
+	0xd9, 0x2a, 0x06, 0x76, 0xa3, 0x97, 0x68, 0x66, 0xab, 0x90, 0x3a, 0x9e, 0x4c, 0xe0, 0x89, 0xcd,
+	0x3d, 0xa5, 0x40, 0xd9, 0xcc, 0x86, 0xf4, 0xcf, 0x68, 0xd0, 0x7e, 0x4f, 0xbc, 0xdd, 0x8c, 0xfd,
+	0x2a, 0x59, 0xd9, 0x57, 0x4a, 0x55, 0x54, 0x48, 0xfa, 0xac, 0x2d, 0xc7, 0x46, 0x6f, 0x4b, 0xfd,
+	0xe3, 0x19, 0x3c, 0xd6, 0xc0, 0x05, 0x09, 0xc3, 0xdb, 0x67, 0x1b, 0x4f, 0xed, 0xaa, 0x12, 0x43,
+	0x59, 0x7d, 0x2d, 0x9b, 0x6a, 0xf6, 0x57, 0xd2, 0xeb, 0xb8, 0x76, 0x1e, 0x5a, 0xe1, 0x71, 0x26,
+	0x40, 0x7f, 0x57, 0x3a, 0xcb, 0x4d, 0x4c, 0xfe, 0x6f, 0x5b, 0x61, 0xfe, 0x77, 0x19, 0xdf, 0xcc,
+	0x77, 0xde, 0x02, 0x3f, 0x70, 0xda, 0x40, 0x4e, 0x10, 0xae, 0x44, 0xc9, 0xf9, 0x1b, 0xc4, 0xa0,
+	0xf9, 0xaf, 0x01, 0xfd, 0xeb, 0x9a, 0xd5, 0x46, 0x20, 0x90, 0xde, 0x7a, 0xfb, 0xfd, 0xd7, 0x87,
+	0xd2, 0xac, 0x7e, 0x47, 0x7d, 0x81, 0x82, 0xd6, 0xe0, 0xa7, 0x4b, 0x18, 0x6f, 0xd2, 0xa9, 0x1d,
+	0x2d, 0xa3, 0x19, 0xf2, 0x15, 0xe1, 0x6b, 0xeb, 0x20, 0x07, 0xf8, 0xdc, 0xbd, 0x98, 0x4f, 0x6f,
+	0x57, 0x8e, 0x84, 0xcc, 0x7d, 0x45, 0xc6, 0x20, 0xcd, 0x62, 0x64, 0xa2, 0xf3, 0x51, 0x48, 0xe8,
+	0x46, 0xb8, 0xa0, 0xf2, 0xf5, 0x04, 0x69, 0x5e, 0x4c, 0x29, 0xb3, 0x3f, 0xb5, 0xa7, 0xff, 0x9e,
+	0x53, 0x58, 0x5e, 0xa7, 0x8a, 0x57, 0x83, 0x14, 0x1c, 0x12, 0xf9, 0x81, 0x70, 0x25, 0x5a, 0x91,
+	0xc3, 0x98, 0xae, 0x6f, 0xb9, 0x8e, 0x64, 0x4e, 0x4b, 0x8a, 0xcf, 0xbc, 0x76, 0xb9, 0x39, 0x85,
+	0xde, 0x3b, 0x46, 0xb8, 0x12, 0xad, 0xa1, 0x61, 0x98, 0xf5, 0xad, 0x5b, 0x6d, 0xae, 0x78, 0x42,
+	0xbc, 0xf1, 0x62, 0x7f, 0xcd, 0x5c, 0xd2, 0x5f, 0xdf, 0x10, 0xbe, 0x1e, 0x2e, 0xc6, 0x01, 0xc8,
+	0x85, 0xec, 0xc5, 0x46, 0xfa, 0x93, 0x59, 0x54, 0x94, 0xe6, 0xf4, 0xd9, 0x82, 0x94, 0x5c, 0x87,
+	0xc9, 0x65, 0x34, 0xb3, 0xb2, 0xf9, 0xe5, 0xb4, 0x86, 0x4e, 0x4e, 0x6b, 0xe8, 0xe7, 0x69, 0x0d,
+	0x3d, 0x7f, 0x50, 0xfc, 0xc5, 0x79, 0xce, 0x93, 0x79, 0xe7, 0x8a, 0x7a, 0x6c, 0x2e, 0xfc, 0x19,
+	0x00, 0x3d, 0xea, 0x24, 0x58, 0x5b, 0x0b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -848,6 +867,11 @@ func (m *WorkflowTemplateGetRequest) MarshalToSizedBuffer(dAtA []byte) (int, err
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Revision != 0 {
+		i = encodeVarintWorkflowTemplate(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.GetOptions != nil {
 		{
 			size, err := m.GetOptions.MarshalToSizedBuffer(dAtA[:i])
@@ -901,6 +925,13 @@ func (m *WorkflowTemplateListRequest) MarshalToSizedBuffer(dAtA []byte) (int, er
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.RevisionsOf) > 0 {
+		i -= len(m.RevisionsOf)
+		copy(dAtA[i:], m.RevisionsOf)
+		i = encodeVarintWorkflowTemplate(dAtA, i, uint64(len(m.RevisionsOf)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.ListOptions != nil {
 		{
 			size, err := m.ListOptions.MarshalToSizedBuffer(dAtA[:i])
@@ -1167,6 +1198,9 @@ func (m *WorkflowTemplateGetRequest) Size() (n int) {
 		l = m.GetOptions.Size()
 		n += 1 + l + sovWorkflowTemplate(uint64(l))
 	}
+	if m.Revision != 0 {
+		n += 1 + sovWorkflowTemplate(uint64(m.Revision))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1187,6 +1221,10 @@ func (m *WorkflowTemplateListRequest) Size() (n int) {
 		l = m.ListOptions.Size()
 		n += 1 + l + sovWorkflowTemplate(uint64(l))
 	}
+	l = len(m.RevisionsOf)
+	if l > 0 {
+		n += 1 + l + sovWorkflowTemplate(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1567,6 +1605,25 @@ func (m *WorkflowTemplateGetRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowTemplate(dAtA[iNdEx:])
@@ -1686,6 +1743,38 @@ func (m *WorkflowTemplateListRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevisionsOf", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowTemplate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowTemplate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RevisionsOf = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowTemplate(dAtA[iNdEx:])
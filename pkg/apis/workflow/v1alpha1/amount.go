@@ -28,6 +28,11 @@ func (a Amount) OpenAPISchemaFormat() string {
 	return ""
 }
 
+// NewAmount returns the amount of the value, rounded to the number of decimal places
+func NewAmount(value float64, places int) *Amount {
+	return &Amount{Value: json.Number(strconv.FormatFloat(value, 'f', places, 64))}
+}
+
 func (a *Amount) Float64() (float64, error) {
 	return strconv.ParseFloat(string(a.Value), 64)
 }
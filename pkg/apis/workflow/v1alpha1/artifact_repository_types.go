@@ -3,6 +3,8 @@ package v1alpha1
 import (
 	"fmt"
 	"path"
+
+	apiv1 "k8s.io/api/core/v1"
 )
 
 var (
@@ -26,12 +28,27 @@ type ArtifactRepository struct {
 	GCS *GCSArtifactRepository `json:"gcs,omitempty" protobuf:"bytes,6,opt,name=gcs"`
 	// Azure stores artifact in an Azure Storage account
 	Azure *AzureArtifactRepository `json:"azure,omitempty" protobuf:"bytes,7,opt,name=azure"`
+	// MaxBytesPerSecond limits the bandwidth each pod uses to load and save artifacts to this repository
+	MaxBytesPerSecond *int64 `json:"maxBytesPerSecond,omitempty" protobuf:"varint,8,opt,name=maxBytesPerSecond"`
+	// SeparateLogStreams archives each container's stdout and stderr as separate artifacts, as well as the combined log
+	SeparateLogStreams *bool `json:"separateLogStreams,omitempty" protobuf:"varint,9,opt,name=separateLogStreams"`
 }
 
 func (a *ArtifactRepository) IsArchiveLogs() bool {
 	return a != nil && a.ArchiveLogs != nil && *a.ArchiveLogs
 }
 
+func (a *ArtifactRepository) IsSeparateLogStreams() bool {
+	return a != nil && a.SeparateLogStreams != nil && *a.SeparateLogStreams
+}
+
+func (a *ArtifactRepository) GetMaxBytesPerSecond() int64 {
+	if a == nil || a.MaxBytesPerSecond == nil {
+		return 0
+	}
+	return *a.MaxBytesPerSecond
+}
+
 type ArtifactRepositoryType interface {
 	IntoArtifactLocation(l *ArtifactLocation)
 }
@@ -55,13 +72,53 @@ func (a *ArtifactRepository) Get() ArtifactRepositoryType {
 	return nil
 }
 
+// SecretKeySelectors returns the secrets the repository's credentials are read from, keyed by their field path,
+// e.g. "s3.accessKeySecret"
+func (a *ArtifactRepository) SecretKeySelectors() map[string]*apiv1.SecretKeySelector {
+	selectors := make(map[string]*apiv1.SecretKeySelector)
+	add := func(field string, s *apiv1.SecretKeySelector) {
+		if s != nil {
+			selectors[field] = s
+		}
+	}
+	if a == nil {
+		return selectors
+	}
+	if r := a.S3; r != nil {
+		add("s3.accessKeySecret", r.AccessKeySecret)
+		add("s3.secretKeySecret", r.SecretKeySecret)
+		if r.EncryptionOptions != nil {
+			add("s3.encryptionOptions.serverSideCustomerKeySecret", r.EncryptionOptions.ServerSideCustomerKeySecret)
+		}
+	}
+	if r := a.Artifactory; r != nil {
+		add("artifactory.usernameSecret", r.UsernameSecret)
+		add("artifactory.passwordSecret", r.PasswordSecret)
+	}
+	if r := a.HDFS; r != nil {
+		add("hdfs.krbCCacheSecret", r.KrbCCacheSecret)
+		add("hdfs.krbKeytabSecret", r.KrbKeytabSecret)
+	}
+	if r := a.OSS; r != nil {
+		add("oss.accessKeySecret", r.AccessKeySecret)
+		add("oss.secretKeySecret", r.SecretKeySecret)
+	}
+	if r := a.GCS; r != nil {
+		add("gcs.serviceAccountKeySecret", r.ServiceAccountKeySecret)
+	}
+	if r := a.Azure; r != nil {
+		add("azure.accountKeySecret", r.AccountKeySecret)
+	}
+	return selectors
+}
+
 // ToArtifactLocation returns the artifact location set with default template key:
 // key = `{{workflow.name}}/{{pod.name}}`
 func (a *ArtifactRepository) ToArtifactLocation() *ArtifactLocation {
 	if a == nil {
 		return nil
 	}
-	l := &ArtifactLocation{ArchiveLogs: a.ArchiveLogs}
+	l := &ArtifactLocation{ArchiveLogs: a.ArchiveLogs, SeparateLogStreams: a.SeparateLogStreams}
 	v := a.Get()
 	if v != nil {
 		v.IntoArtifactLocation(l)
@@ -71,4 +71,6 @@ type SubmitOpts struct {
 	// Priority is used if controller is configured to process limited number of workflows in parallel, higher priority workflows
 	// are processed first.
 	Priority *int32 `json:"priority,omitempty" protobuf:"bytes,14,opt,name=priority"`
+	// Suspend creates the workflow suspended, so that it is validated and persisted but no nodes run until it is resumed
+	Suspend bool `json:"suspend,omitempty" protobuf:"varint,15,opt,name=suspend"`
 }
@@ -2,6 +2,8 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -77,7 +79,7 @@ func (in *ContainerSetTemplate) GetGraph() []ContainerNode {
 
 func (in *ContainerSetTemplate) HasSequencedContainers() bool {
 	for _, n := range in.GetGraph() {
-		if len(n.Dependencies) > 0 {
+		if len(n.GetDependencies()) > 0 {
 			return true
 		}
 	}
@@ -120,12 +122,23 @@ func (in *ContainerSetTemplate) Validate() error {
 				return fmt.Errorf("containers.%s dependency '%s' not defined", ctr.Name, depName)
 			}
 		}
+		for i, dep := range ctr.FileDependencies {
+			if _, ok := nameToContainer[dep.Container]; !ok {
+				return fmt.Errorf("containers.%s.fileDependencies[%d].container '%s' not defined", ctr.Name, i, dep.Container)
+			}
+			if !path.IsAbs(dep.Path) {
+				return fmt.Errorf("containers.%s.fileDependencies[%d].path '%s' must be absolute", ctr.Name, i, dep.Path)
+			}
+			if !in.isOnVolumeMount(dep.Path) {
+				return fmt.Errorf("containers.%s.fileDependencies[%d].path '%s' must be within one of the container set's volumeMounts", ctr.Name, i, dep.Path)
+			}
+		}
 	}
 
 	// Ensure there is no dependency cycle
 	depGraph := make(map[string][]string)
 	for _, ctr := range in.Containers {
-		depGraph[ctr.Name] = append(depGraph[ctr.Name], ctr.Dependencies...)
+		depGraph[ctr.Name] = append(depGraph[ctr.Name], ctr.GetDependencies()...)
 	}
 	err = validateNoCycles(depGraph)
 	if err != nil {
@@ -134,7 +147,44 @@ func (in *ContainerSetTemplate) Validate() error {
 	return nil
 }
 
+// isOnVolumeMount returns true if the path is within one of the volumes mounted in every container
+func (in *ContainerSetTemplate) isOnVolumeMount(p string) bool {
+	for _, m := range in.VolumeMounts {
+		if p == m.MountPath || strings.HasPrefix(p, strings.TrimSuffix(m.MountPath, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 type ContainerNode struct {
 	corev1.Container `json:",inline" protobuf:"bytes,1,opt,name=container"`
 	Dependencies     []string `json:"dependencies,omitempty" protobuf:"bytes,2,rep,name=dependencies"`
+	// FileDependencies are files, produced by other containers, that must exist before this container starts.
+	// Unlike dependencies, the other container does not need to have finished.
+	FileDependencies []ContainerFileDependency `json:"fileDependencies,omitempty" protobuf:"bytes,3,rep,name=fileDependencies"`
+}
+
+// GetDependencies returns the names of the containers this container depends on, either to finish or to produce a file
+func (n ContainerNode) GetDependencies() []string {
+	names := append([]string{}, n.Dependencies...)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		seen[name] = true
+	}
+	for _, dep := range n.FileDependencies {
+		if !seen[dep.Container] {
+			seen[dep.Container] = true
+			names = append(names, dep.Container)
+		}
+	}
+	return names
+}
+
+// ContainerFileDependency is a file produced by another container in the container set
+type ContainerFileDependency struct {
+	// Container is the name of the container that produces the file
+	Container string `json:"container" protobuf:"bytes,1,opt,name=container"`
+	// Path is the path of the file, which must be within one of the container set's volumeMounts
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
 }
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -79,6 +80,51 @@ func TestContainerSetTemplate(t *testing.T) {
 	})
 }
 
+func TestContainerNodeGetDependencies(t *testing.T) {
+	x := ContainerNode{
+		Dependencies:     []string{"a"},
+		FileDependencies: []ContainerFileDependency{{Container: "a", Path: "/workspace/a"}, {Container: "b", Path: "/workspace/b"}},
+	}
+	assert.Equal(t, []string{"a", "b"}, x.GetDependencies())
+	assert.True(t, (&ContainerSetTemplate{Containers: []ContainerNode{{FileDependencies: x.FileDependencies}}}).HasSequencedContainers())
+}
+
+func TestContainerSetFileDependencies(t *testing.T) {
+	containerSet := `
+volumeMounts:
+  - name: workspace
+    mountPath: /workspace
+containers:
+  - name: producer
+    image: argoproj/argosay:v2
+  - name: consumer
+    image: argoproj/argosay:v2
+    fileDependencies:
+      - container: %s
+        path: %s
+`
+	t.Run("Valid", func(t *testing.T) {
+		err := validateContainerSetTemplate(fmt.Sprintf(containerSet, "producer", "/workspace/ready"))
+		assert.NoError(t, err)
+	})
+	t.Run("UndefinedContainer", func(t *testing.T) {
+		err := validateContainerSetTemplate(fmt.Sprintf(containerSet, "missing", "/workspace/ready"))
+		assert.EqualError(t, err, "containers.consumer.fileDependencies[0].container 'missing' not defined")
+	})
+	t.Run("RelativePath", func(t *testing.T) {
+		err := validateContainerSetTemplate(fmt.Sprintf(containerSet, "producer", "workspace/ready"))
+		assert.EqualError(t, err, "containers.consumer.fileDependencies[0].path 'workspace/ready' must be absolute")
+	})
+	t.Run("NotOnVolumeMount", func(t *testing.T) {
+		err := validateContainerSetTemplate(fmt.Sprintf(containerSet, "producer", "/workspace2/ready"))
+		assert.EqualError(t, err, "containers.consumer.fileDependencies[0].path '/workspace2/ready' must be within one of the container set's volumeMounts")
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		err := validateContainerSetTemplate(fmt.Sprintf(containerSet, "consumer", "/workspace/ready"))
+		assert.Error(t, err)
+	})
+}
+
 func TestInvalidContainerSetEmpty(t *testing.T) {
 	invalidContainerSetEmpty := `
 volumeMounts:
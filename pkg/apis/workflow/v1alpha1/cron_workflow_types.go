@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -34,6 +36,21 @@ const (
 	AllowConcurrent   ConcurrencyPolicy = "Allow"
 	ForbidConcurrent  ConcurrencyPolicy = "Forbid"
 	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+	// ForbidIfPreviousFailedConcurrent is like Forbid, but also does not run while the previous Workflow did not
+	// succeed, until it is retried or resubmitted successfully, or deleted
+	ForbidIfPreviousFailedConcurrent ConcurrencyPolicy = "ForbidIfPreviousFailed"
+)
+
+// DSTPolicy is what to do with scheduled times that are ambiguous or skipped when the clocks change for daylight saving
+type DSTPolicy string
+
+const (
+	// DSTPolicySkip does not run at local times that happen twice, or not at all, when the clocks change
+	DSTPolicySkip DSTPolicy = "skip"
+	// DSTPolicyRunOnce runs once at local times that happen twice, and at the change for local times that do not happen
+	DSTPolicyRunOnce DSTPolicy = "runOnce"
+	// DSTPolicyRunTwice runs twice at local times that happen twice, and at the change for local times that do not happen
+	DSTPolicyRunTwice DSTPolicy = "runTwice"
 )
 
 const annotationKeyLatestSchedule = workflow.CronWorkflowFullName + "/last-used-schedule"
@@ -43,7 +60,7 @@ type CronWorkflowSpec struct {
 	// WorkflowSpec is the spec of the workflow to be run
 	WorkflowSpec WorkflowSpec `json:"workflowSpec" protobuf:"bytes,1,opt,name=workflowSpec,casttype=WorkflowSpec"`
 	// Schedule is a schedule to run the Workflow in Cron format
-	Schedule string `json:"schedule" protobuf:"bytes,2,opt,name=schedule"`
+	Schedule string `json:"schedule,omitempty" protobuf:"bytes,2,opt,name=schedule"`
 	// ConcurrencyPolicy is the K8s-style concurrency policy that will be used
 	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty" protobuf:"bytes,3,opt,name=concurrencyPolicy,casttype=ConcurrencyPolicy"`
 	// Suspend is a flag that will stop new CronWorkflows from running if set to true
@@ -59,6 +76,53 @@ type CronWorkflowSpec struct {
 	Timezone string `json:"timezone,omitempty" protobuf:"bytes,8,opt,name=timezone"`
 	// WorkflowMetadata contains some metadata of the workflow to be run
 	WorkflowMetadata *metav1.ObjectMeta `json:"workflowMetadata,omitempty" protobuf:"bytes,9,opt,name=workflowMeta"`
+	// Schedules is a list of schedules to run the Workflow in Cron format, in addition to Schedule. The Workflow is run
+	// whenever any of them are due.
+	Schedules []string `json:"schedules,omitempty" protobuf:"bytes,10,rep,name=schedules"`
+	// Exclusions are windows of time when the Workflow must not be run, even if a schedule is due, e.g. maintenance
+	// windows or holidays
+	Exclusions []CronExclusion `json:"exclusions,omitempty" protobuf:"bytes,11,rep,name=exclusions"`
+	// ScheduleJitter is the maximum time to delay each run by, e.g. "5m", to spread out CronWorkflows with the same
+	// schedule. The delay is the same for every run of a CronWorkflow, as it is derived from its namespace and name.
+	ScheduleJitter string `json:"scheduleJitter,omitempty" protobuf:"bytes,12,opt,name=scheduleJitter"`
+	// DSTPolicy is what to do with scheduled local times that happen twice ("skip", "runOnce" or "runTwice") when the
+	// clocks go back, and that do not happen when the clocks go forward ("skip", or run at the change for "runOnce" and
+	// "runTwice"). By default, such times run twice and are skipped respectively.
+	DSTPolicy DSTPolicy `json:"dstPolicy,omitempty" protobuf:"bytes,13,opt,name=dstPolicy,casttype=DSTPolicy"`
+	// Catchup runs the schedules that were missed, e.g. while the controller was down, rather than only the latest one
+	// within StartingDeadlineSeconds
+	Catchup *CronCatchup `json:"catchup,omitempty" protobuf:"bytes,14,opt,name=catchup"`
+}
+
+// CronCatchup is how a CronWorkflow runs the schedules it missed
+type CronCatchup struct {
+	// Enabled runs a Workflow for each missed schedule, oldest first, with its original scheduled time
+	Enabled bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
+	// Limit is the maximum number of missed schedules to run, the most recent ones, defaults to 10
+	Limit *int32 `json:"limit,omitempty" protobuf:"varint,2,opt,name=limit"`
+}
+
+func (c *CronCatchup) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *CronCatchup) GetLimit() int {
+	if c == nil || c.Limit == nil {
+		return 10
+	}
+	return int(*c.Limit)
+}
+
+// CronExclusion is a window of time when a CronWorkflow must not be run. Exactly one of Schedule or Calendar must be
+// specified. It is evaluated in the CronWorkflow's timezone.
+type CronExclusion struct {
+	// Schedule is when the window starts, in Cron format, e.g. "0 2 * * 0" for 2am on Sundays
+	Schedule string `json:"schedule,omitempty" protobuf:"bytes,1,opt,name=schedule"`
+	// Duration is how long the window lasts, e.g. "2h", required with Schedule
+	Duration string `json:"duration,omitempty" protobuf:"bytes,2,opt,name=duration"`
+	// Calendar is a key of a config map, in the CronWorkflow's namespace, listing dates (e.g. "2022-12-25"), one per line,
+	// on which the Workflow must not be run, e.g. holidays
+	Calendar *v1.ConfigMapKeySelector `json:"calendar,omitempty" protobuf:"bytes,3,opt,name=calendar"`
 }
 
 // CronWorkflowStatus is the status of a CronWorkflow
@@ -89,12 +153,35 @@ func (c *CronWorkflow) GetLatestSchedule() string {
 	return c.Annotations[annotationKeyLatestSchedule]
 }
 
+// GetScheduleString returns all the schedules, with the timezone, separated by commas
 func (c *CronWorkflowSpec) GetScheduleString() string {
-	scheduleString := c.Schedule
+	return strings.Join(c.GetSchedulesWithTimezone(), ",")
+}
+
+// GetSchedules returns Schedule, if specified, and Schedules
+func (c *CronWorkflowSpec) GetSchedules() []string {
+	var schedules []string
+	if c.Schedule != "" {
+		schedules = append(schedules, c.Schedule)
+	}
+	return append(schedules, c.Schedules...)
+}
+
+// GetSchedulesWithTimezone returns all the schedules, prefixed with the timezone if there is one
+func (c *CronWorkflowSpec) GetSchedulesWithTimezone() []string {
+	var schedules []string
+	for _, schedule := range c.GetSchedules() {
+		schedules = append(schedules, c.WithTimezone(schedule))
+	}
+	return schedules
+}
+
+// WithTimezone prefixes a cron schedule with the timezone, if there is one
+func (c *CronWorkflowSpec) WithTimezone(schedule string) string {
 	if c.Timezone != "" {
-		scheduleString = "CRON_TZ=" + c.Timezone + " " + scheduleString
+		return "CRON_TZ=" + c.Timezone + " " + schedule
 	}
-	return scheduleString
+	return schedule
 }
 
 func (c *CronWorkflowStatus) HasActiveUID(uid types.UID) bool {
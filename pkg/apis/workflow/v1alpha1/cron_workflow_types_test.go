@@ -27,3 +27,19 @@ func TestCronWorkflowSpec_GetScheduleString(t *testing.T) {
 	cwfSpec.Timezone = "America/Los_Angeles"
 	assert.Equal(t, "CRON_TZ=America/Los_Angeles * * * * *", cwfSpec.GetScheduleString())
 }
+
+func TestCronWorkflowSpec_GetSchedules(t *testing.T) {
+	cwfSpec := CronWorkflowSpec{
+		Schedule:  "0 * * * *",
+		Schedules: []string{"30 * * * *"},
+	}
+
+	assert.Equal(t, []string{"0 * * * *", "30 * * * *"}, cwfSpec.GetSchedules())
+	assert.Equal(t, "0 * * * *,30 * * * *", cwfSpec.GetScheduleString())
+
+	cwfSpec.Timezone = "America/Los_Angeles"
+	assert.Equal(t, []string{"CRON_TZ=America/Los_Angeles 0 * * * *", "CRON_TZ=America/Los_Angeles 30 * * * *"}, cwfSpec.GetSchedulesWithTimezone())
+
+	cwfSpec.Schedule = ""
+	assert.Equal(t, "CRON_TZ=America/Los_Angeles 30 * * * *", cwfSpec.GetScheduleString())
+}
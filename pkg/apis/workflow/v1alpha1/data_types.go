@@ -18,15 +18,63 @@ func (ds *DataSource) GetArtifactIfNeeded() (*Artifact, bool) {
 
 type Transformation []TransformationStep
 
+// TransformationStep is a single transformation. Only one of its fields may be set
 type TransformationStep struct {
 	// Expression defines an expr expression to apply
+	Expression string `json:"expression,omitempty" protobuf:"bytes,1,opt,name=expression"`
+
+	// Map is an expr expression applied to each item, available as `item`, with the results collected into a list
+	Map string `json:"map,omitempty" protobuf:"bytes,2,opt,name=map"`
+
+	// Flatten replaces any items that are lists with their items
+	Flatten bool `json:"flatten,omitempty" protobuf:"varint,3,opt,name=flatten"`
+
+	// Unique removes duplicate items, keeping the first of each
+	Unique bool `json:"unique,omitempty" protobuf:"varint,4,opt,name=unique"`
+
+	// Sort sorts the items
+	Sort *SortTransformation `json:"sort,omitempty" protobuf:"bytes,5,opt,name=sort"`
+
+	// Reduce combines the items into a single value
+	Reduce *ReduceTransformation `json:"reduce,omitempty" protobuf:"bytes,6,opt,name=reduce"`
+
+	// Join joins the items into a single string
+	Join *JoinTransformation `json:"join,omitempty" protobuf:"bytes,7,opt,name=join"`
+}
+
+// SortTransformation sorts a list of numbers or strings
+type SortTransformation struct {
+	// Key is an expr expression applied to each item, available as `item`, to get the value to sort by.
+	// Defaults to the item itself
+	Key string `json:"key,omitempty" protobuf:"bytes,1,opt,name=key"`
+
+	// Descending sorts the items in descending order
+	Descending bool `json:"descending,omitempty" protobuf:"varint,2,opt,name=descending"`
+}
+
+// ReduceTransformation combines a list into a single value
+type ReduceTransformation struct {
+	// Expression is an expr expression that combines the value so far, available as `acc`, with each item,
+	// available as `item`
 	Expression string `json:"expression" protobuf:"bytes,1,opt,name=expression"`
+
+	// Initial is an expr expression for the initial value, e.g. "0". Defaults to the first item
+	Initial string `json:"initial,omitempty" protobuf:"bytes,2,opt,name=initial"`
+}
+
+// JoinTransformation joins a list into a single string
+type JoinTransformation struct {
+	// Separator is placed between each item
+	Separator string `json:"separator,omitempty" protobuf:"bytes,1,opt,name=separator"`
 }
 
 // DataSource sources external data into a data template
 type DataSource struct {
 	// ArtifactPaths is a data transformation that collects a list of artifact paths
 	ArtifactPaths *ArtifactPaths `json:"artifactPaths,omitempty" protobuf:"bytes,1,opt,name=artifactPaths"`
+
+	// Parameter is a JSON value to transform, typically a previous step's output passed in as an input parameter
+	Parameter string `json:"parameter,omitempty" protobuf:"bytes,2,opt,name=parameter"`
 }
 
 // ArtifactPaths expands a step from a collection of artifacts
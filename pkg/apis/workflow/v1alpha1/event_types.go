@@ -29,11 +29,24 @@ type WorkflowEventBindingSpec struct {
 	Event Event `json:"event" protobuf:"bytes,1,opt,name=event"`
 	// Submit is the workflow template to submit
 	Submit *Submit `json:"submit,omitempty" protobuf:"bytes,2,opt,name=submit"`
+	// Submits are additional workflow templates to submit, each with its own arguments, so that a single event can trigger many workflows
+	Submits []Submit `json:"submits,omitempty" protobuf:"bytes,3,rep,name=submits"`
+}
+
+// GetSubmits returns all the workflow templates to submit
+func (s WorkflowEventBindingSpec) GetSubmits() []Submit {
+	var submits []Submit
+	if s.Submit != nil {
+		submits = append(submits, *s.Submit)
+	}
+	return append(submits, s.Submits...)
 }
 
 type Event struct {
 	// Selector (https://github.com/antonmedv/expr) that we must must match the event. E.g. `payload.message == "test"`
 	Selector string `json:"selector" protobuf:"bytes,1,opt,name=selector"`
+	// Transform (https://github.com/antonmedv/expr) reshapes the payload after it has been selected, but before the arguments are extracted from it. E.g. `{"sha": payload.after, "files": map(payload.commits, {#.id})}`
+	Transform string `json:"transform,omitempty" protobuf:"bytes,2,opt,name=transform"`
 }
 
 type Submit struct {
@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// GPUVendor is the vendor of the GPUs requested by a template
+type GPUVendor string
+
+const (
+	GPUVendorNVIDIA GPUVendor = "nvidia"
+	GPUVendorAMD    GPUVendor = "amd"
+	GPUVendorIntel  GPUVendor = "intel"
+)
+
+var migProfileRegex = regexp.MustCompile(`^[0-9]+g\.[0-9]+gb$`)
+
+// GPU requests GPUs for the main container of a template. The controller maps them to the extended resource, and
+// runtime class, of the vendor's device plugin.
+type GPU struct {
+	// Count is the number of GPUs, or of MIG devices if a MIG profile is specified
+	Count int32 `json:"count" protobuf:"varint,1,opt,name=count"`
+	// Vendor is the vendor of the GPUs, "nvidia" (default), "amd", "intel", or one configured in the controller
+	Vendor GPUVendor `json:"vendor,omitempty" protobuf:"bytes,2,opt,name=vendor,casttype=GPUVendor"`
+	// MIGProfile is the profile of NVIDIA multi-instance GPU devices, e.g. "1g.5gb"
+	MIGProfile string `json:"migProfile,omitempty" protobuf:"bytes,3,opt,name=migProfile"`
+}
+
+func (g *GPU) GetVendor() GPUVendor {
+	if g.Vendor != "" {
+		return g.Vendor
+	}
+	return GPUVendorNVIDIA
+}
+
+func (g *GPU) Validate() error {
+	if g.Count < 1 {
+		return fmt.Errorf("count must be at least 1")
+	}
+	if g.MIGProfile != "" {
+		if g.GetVendor() != GPUVendorNVIDIA {
+			return fmt.Errorf("migProfile is only supported by the %s vendor", GPUVendorNVIDIA)
+		}
+		if !migProfileRegex.MatchString(g.MIGProfile) {
+			return fmt.Errorf("migProfile %q must be like 1g.5gb", g.MIGProfile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGPU_Validate(t *testing.T) {
+	t.Run("NoCount", func(t *testing.T) {
+		assert.EqualError(t, (&GPU{}).Validate(), "count must be at least 1")
+	})
+	t.Run("MIGProfileVendor", func(t *testing.T) {
+		assert.EqualError(t, (&GPU{Count: 1, Vendor: GPUVendorAMD, MIGProfile: "1g.5gb"}).Validate(), "migProfile is only supported by the nvidia vendor")
+	})
+	t.Run("InvalidMIGProfile", func(t *testing.T) {
+		assert.EqualError(t, (&GPU{Count: 1, MIGProfile: "small"}).Validate(), `migProfile "small" must be like 1g.5gb`)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		assert.NoError(t, (&GPU{Count: 2}).Validate())
+		assert.NoError(t, (&GPU{Count: 1, MIGProfile: "3g.20gb"}).Validate())
+	})
+}
+
+func TestGPU_GetVendor(t *testing.T) {
+	assert.Equal(t, GPUVendorNVIDIA, (&GPU{}).GetVendor())
+	assert.Equal(t, GPUVendorIntel, (&GPU{Vendor: GPUVendorIntel}).GetVendor())
+}
@@ -1,9 +1,15 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"math"
 	"net/http"
+	"regexp"
+	"strconv"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type HTTPHeaderSource struct {
@@ -52,6 +58,15 @@ type HTTP struct {
 	BodyFrom *HTTPBodySource `json:"bodyFrom,omitempty" protobuf:"bytes,8,opt,name=bodyFrom"`
 	// InsecureSkipVerify is a bool when if set to true will skip TLS verification for the HTTP client
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" protobuf:"bytes,7,opt,name=insecureSkipVerify"`
+	// OutputArtifact is the name of an output artifact that the response body is streamed to, instead of being stored in the outputs result
+	OutputArtifact string `json:"outputArtifact,omitempty" protobuf:"bytes,9,opt,name=outputArtifact"`
+	// RetryOn is a list of response status codes (e.g. "429") or status classes (e.g. "5xx") that cause the request to be retried
+	RetryOn []string `json:"retryOn,omitempty" protobuf:"bytes,10,rep,name=retryOn"`
+	// RetryLimit is the maximum number of times the request is retried. Default is 3
+	RetryLimit *int32 `json:"retryLimit,omitempty" protobuf:"varint,11,opt,name=retryLimit"`
+	// RetryBackoff is the backoff between retries. Default is 1 second, doubling after each retry.
+	// A Retry-After response header takes precedence over the backoff, up to its maxDuration.
+	RetryBackoff *Backoff `json:"retryBackoff,omitempty" protobuf:"bytes,12,opt,name=retryBackoff"`
 }
 
 func (h *HTTP) GetBodyBytes() []byte {
@@ -60,3 +75,96 @@ func (h *HTTP) GetBodyBytes() []byte {
 	}
 	return nil
 }
+
+var httpStatusClass = regexp.MustCompile(`^[1-5]xx$`)
+
+// ValidateRetryOn checks that each entry is either a status code or a status class
+func (h *HTTP) ValidateRetryOn() error {
+	for _, x := range h.RetryOn {
+		if httpStatusClass.MatchString(x) {
+			continue
+		}
+		if code, err := strconv.Atoi(x); err != nil || code < 100 || code > 599 {
+			return fmt.Errorf("%q is not a valid status code or status class (e.g. \"429\" or \"5xx\")", x)
+		}
+	}
+	return nil
+}
+
+// ShouldRetry returns whether a response with the given status code should be retried
+func (h *HTTP) ShouldRetry(statusCode int) bool {
+	code := strconv.Itoa(statusCode)
+	for _, x := range h.RetryOn {
+		if x == code || (httpStatusClass.MatchString(x) && x[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *HTTP) GetRetryLimit() int {
+	if h.RetryLimit != nil {
+		return int(*h.RetryLimit)
+	}
+	return 3
+}
+
+// GetRetryDelay returns the delay before the given retry, starting at zero, which is at most the backoff's maxDuration
+func (h *HTTP) GetRetryDelay(retry int) (time.Duration, error) {
+	backoff := h.RetryBackoff
+	if backoff == nil {
+		backoff = &Backoff{Duration: "1s", Factor: intstr.ValueOrDefault(nil, intstr.FromInt(2))}
+	}
+	duration := time.Second
+	if backoff.Duration != "" {
+		d, err := parseBackoffDuration(backoff.Duration)
+		if err != nil {
+			return 0, err
+		}
+		duration = d
+	}
+	if duration < 0 {
+		duration = 0
+	}
+	maxDelay := time.Duration(math.MaxInt64)
+	if backoff.MaxDuration != "" {
+		d, err := parseBackoffDuration(backoff.MaxDuration)
+		if err != nil {
+			return 0, err
+		}
+		if d > 0 {
+			maxDelay = d
+		}
+	}
+	if duration > maxDelay {
+		return maxDelay, nil
+	}
+	factor := 1
+	if backoff.Factor != nil {
+		factor = backoff.Factor.IntValue()
+	}
+	for i := 0; i < retry && factor > 1; i++ {
+		// stop before the delay exceeds the maximum, or overflows
+		if duration > maxDelay/time.Duration(factor) {
+			return maxDelay, nil
+		}
+		duration *= time.Duration(factor)
+	}
+	return duration, nil
+}
+
+// GetRetryMaxDuration returns the maximum total time spent backing off, or zero if there is no limit
+func (h *HTTP) GetRetryMaxDuration() (time.Duration, error) {
+	if h.RetryBackoff == nil || h.RetryBackoff.MaxDuration == "" {
+		return 0, nil
+	}
+	return parseBackoffDuration(h.RetryBackoff.MaxDuration)
+}
+
+// parseBackoffDuration parses a duration where the default unit is seconds
+func parseBackoffDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(s); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(s)
+}
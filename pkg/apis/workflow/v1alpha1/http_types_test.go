@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestHTTP_ValidateRetryOn(t *testing.T) {
+	assert.NoError(t, (&HTTP{RetryOn: []string{"5xx", "429"}}).ValidateRetryOn())
+	assert.EqualError(t, (&HTTP{RetryOn: []string{"6xx"}}).ValidateRetryOn(), `"6xx" is not a valid status code or status class (e.g. "429" or "5xx")`)
+	assert.EqualError(t, (&HTTP{RetryOn: []string{"99"}}).ValidateRetryOn(), `"99" is not a valid status code or status class (e.g. "429" or "5xx")`)
+}
+
+func TestHTTP_ShouldRetry(t *testing.T) {
+	h := &HTTP{RetryOn: []string{"5xx", "429"}}
+	assert.True(t, h.ShouldRetry(503))
+	assert.True(t, h.ShouldRetry(429))
+	assert.False(t, h.ShouldRetry(404))
+	assert.False(t, h.ShouldRetry(200))
+	assert.False(t, (&HTTP{}).ShouldRetry(503))
+}
+
+func TestHTTP_GetRetryDelay(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		h := &HTTP{}
+		assert.Equal(t, 3, h.GetRetryLimit())
+		d, err := h.GetRetryDelay(0)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, d)
+		d, err = h.GetRetryDelay(2)
+		assert.NoError(t, err)
+		assert.Equal(t, 4*time.Second, d)
+	})
+	t.Run("Backoff", func(t *testing.T) {
+		factor := intstr.FromInt(3)
+		h := &HTTP{RetryBackoff: &Backoff{Duration: "2", Factor: &factor, MaxDuration: "1m"}}
+		d, err := h.GetRetryDelay(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 6*time.Second, d)
+		max, err := h.GetRetryMaxDuration()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, max)
+	})
+	t.Run("NoFactor", func(t *testing.T) {
+		h := &HTTP{RetryBackoff: &Backoff{Duration: "10ms"}}
+		d, err := h.GetRetryDelay(5)
+		assert.NoError(t, err)
+		assert.Equal(t, 10*time.Millisecond, d)
+	})
+	t.Run("MaxDuration", func(t *testing.T) {
+		h := &HTTP{RetryBackoff: &Backoff{Duration: "1s", Factor: intstr.ValueOrDefault(nil, intstr.FromInt(2)), MaxDuration: "1m"}}
+		d, err := h.GetRetryDelay(10)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, d)
+		h = &HTTP{RetryBackoff: &Backoff{Duration: "2m", MaxDuration: "1m"}}
+		d, err = h.GetRetryDelay(0)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, d)
+	})
+	t.Run("Overflow", func(t *testing.T) {
+		d, err := (&HTTP{}).GetRetryDelay(1000)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(math.MaxInt64), d)
+	})
+}
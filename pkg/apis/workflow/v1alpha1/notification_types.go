@@ -0,0 +1,162 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// WorkflowNotificationEvent is an event of a workflow that notifications are sent on
+type WorkflowNotificationEvent string
+
+const (
+	WorkflowNotificationOnSuccess WorkflowNotificationEvent = "onSuccess"
+	WorkflowNotificationOnFailure WorkflowNotificationEvent = "onFailure"
+)
+
+// defaultNotificationText is the text of Slack and email notifications, unless specified
+const defaultNotificationText = "Workflow {{workflow.namespace}}/{{workflow.name}} {{workflow.status}}"
+
+// Notification sends a message to Slack, by email or to an HTTP endpoint. Exactly one of them must be specified.
+type Notification struct {
+	// Slack posts the message to the incoming webhook of a Slack channel
+	Slack *SlackNotification `json:"slack,omitempty" protobuf:"bytes,1,opt,name=slack"`
+	// Email sends the message using an SMTP server
+	Email *EmailNotification `json:"email,omitempty" protobuf:"bytes,2,opt,name=email"`
+	// HTTP posts the message to an HTTP endpoint
+	HTTP *HTTPNotification `json:"http,omitempty" protobuf:"bytes,3,opt,name=http"`
+}
+
+// SlackNotification posts a message to the incoming webhook of a Slack channel
+type SlackNotification struct {
+	// WebhookURLSecret is the secret key containing the URL of the incoming webhook
+	WebhookURLSecret *apiv1.SecretKeySelector `json:"webhookURLSecret" protobuf:"bytes,1,opt,name=webhookURLSecret"`
+	// Text is the text of the message. Defaults to the name and status of the workflow.
+	Text string `json:"text,omitempty" protobuf:"bytes,2,opt,name=text"`
+}
+
+// EmailNotification sends an email using an SMTP server
+type EmailNotification struct {
+	// Host is the host of the SMTP server
+	Host string `json:"host" protobuf:"bytes,1,opt,name=host"`
+	// Port is the port of the SMTP server. Defaults to 587.
+	Port int32 `json:"port,omitempty" protobuf:"varint,2,opt,name=port"`
+	// UsernameSecret is the secret key containing the username to authenticate with, if any
+	UsernameSecret *apiv1.SecretKeySelector `json:"usernameSecret,omitempty" protobuf:"bytes,3,opt,name=usernameSecret"`
+	// PasswordSecret is the secret key containing the password to authenticate with, if any
+	PasswordSecret *apiv1.SecretKeySelector `json:"passwordSecret,omitempty" protobuf:"bytes,4,opt,name=passwordSecret"`
+	// From is the address the email is sent from
+	From string `json:"from" protobuf:"bytes,5,opt,name=from"`
+	// To are the addresses the email is sent to
+	To []string `json:"to" protobuf:"bytes,6,rep,name=to"`
+	// Subject is the subject of the email. Defaults to the name and status of the workflow.
+	Subject string `json:"subject,omitempty" protobuf:"bytes,7,opt,name=subject"`
+	// Body is the body of the email. Defaults to the name and status of the workflow.
+	Body string `json:"body,omitempty" protobuf:"bytes,8,opt,name=body"`
+}
+
+// HTTPNotification posts a message to an HTTP endpoint
+type HTTPNotification struct {
+	// URL of the endpoint
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Headers are the headers of the request, e.g. Content-Type or Authorization
+	Headers HTTPHeaders `json:"headers,omitempty" protobuf:"bytes,2,rep,name=headers"`
+	// Body is the body of the request
+	Body string `json:"body,omitempty" protobuf:"bytes,3,opt,name=body"`
+}
+
+// WorkflowNotification is a notification that is sent when the workflow completes, after its exit handler
+type WorkflowNotification struct {
+	// Name is the name of the notification, which is part of the name of its node
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Events are the events the notification is sent on, "onSuccess" and/or "onFailure". Defaults to both.
+	Events []WorkflowNotificationEvent `json:"events,omitempty" protobuf:"bytes,2,rep,name=events,casttype=WorkflowNotificationEvent"`
+
+	Notification `json:",inline" protobuf:"bytes,3,opt,name=notification"`
+}
+
+// GetPort returns the port of the SMTP server
+func (e *EmailNotification) GetPort() int32 {
+	if e.Port != 0 {
+		return e.Port
+	}
+	return 587
+}
+
+// WithDefaults returns the notification with the default text of Slack and email messages
+func (n Notification) WithDefaults() Notification {
+	if n.Slack != nil && n.Slack.Text == "" {
+		slack := *n.Slack
+		slack.Text = defaultNotificationText
+		n.Slack = &slack
+	}
+	if n.Email != nil && (n.Email.Subject == "" || n.Email.Body == "") {
+		email := *n.Email
+		if email.Subject == "" {
+			email.Subject = defaultNotificationText
+		}
+		if email.Body == "" {
+			email.Body = defaultNotificationText
+		}
+		n.Email = &email
+	}
+	return n
+}
+
+func (n *Notification) Validate() error {
+	numTargets := 0
+	if n.Slack != nil {
+		numTargets++
+		if n.Slack.WebhookURLSecret == nil || n.Slack.WebhookURLSecret.Name == "" || n.Slack.WebhookURLSecret.Key == "" {
+			return fmt.Errorf("slack.webhookURLSecret name and key must be specified")
+		}
+	}
+	if n.Email != nil {
+		numTargets++
+		if n.Email.Host == "" {
+			return fmt.Errorf("email.host must be specified")
+		}
+		if n.Email.From == "" || len(n.Email.To) == 0 {
+			return fmt.Errorf("email.from and email.to must be specified")
+		}
+	}
+	if n.HTTP != nil {
+		numTargets++
+		if n.HTTP.URL == "" {
+			return fmt.Errorf("http.url must be specified")
+		}
+	}
+	if numTargets != 1 {
+		return fmt.Errorf("exactly one of slack, email or http must be specified")
+	}
+	return nil
+}
+
+// SentOn returns whether the notification is sent when the workflow succeeded, or else failed
+func (n *WorkflowNotification) SentOn(succeeded bool) bool {
+	if len(n.Events) == 0 {
+		return true
+	}
+	event := WorkflowNotificationOnFailure
+	if succeeded {
+		event = WorkflowNotificationOnSuccess
+	}
+	for _, e := range n.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *WorkflowNotification) Validate() error {
+	if n.Name == "" {
+		return fmt.Errorf("name must be specified")
+	}
+	for _, e := range n.Events {
+		if e != WorkflowNotificationOnSuccess && e != WorkflowNotificationOnFailure {
+			return fmt.Errorf("events must be %s or %s", WorkflowNotificationOnSuccess, WorkflowNotificationOnFailure)
+		}
+	}
+	return n.Notification.Validate()
+}
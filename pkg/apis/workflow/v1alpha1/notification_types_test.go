@@ -0,0 +1,82 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestNotification_Validate(t *testing.T) {
+	secret := &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "slack"}, Key: "url"}
+	t.Run("NoTarget", func(t *testing.T) {
+		n := &Notification{}
+		assert.EqualError(t, n.Validate(), "exactly one of slack, email or http must be specified")
+	})
+	t.Run("MultipleTargets", func(t *testing.T) {
+		n := &Notification{Slack: &SlackNotification{WebhookURLSecret: secret}, HTTP: &HTTPNotification{URL: "http://example.com"}}
+		assert.EqualError(t, n.Validate(), "exactly one of slack, email or http must be specified")
+	})
+	t.Run("MissingWebhookURLSecret", func(t *testing.T) {
+		n := &Notification{Slack: &SlackNotification{}}
+		assert.EqualError(t, n.Validate(), "slack.webhookURLSecret name and key must be specified")
+	})
+	t.Run("MissingHost", func(t *testing.T) {
+		n := &Notification{Email: &EmailNotification{From: "argo@example.com", To: []string{"team@example.com"}}}
+		assert.EqualError(t, n.Validate(), "email.host must be specified")
+	})
+	t.Run("MissingTo", func(t *testing.T) {
+		n := &Notification{Email: &EmailNotification{Host: "smtp.example.com", From: "argo@example.com"}}
+		assert.EqualError(t, n.Validate(), "email.from and email.to must be specified")
+	})
+	t.Run("MissingURL", func(t *testing.T) {
+		n := &Notification{HTTP: &HTTPNotification{}}
+		assert.EqualError(t, n.Validate(), "http.url must be specified")
+	})
+	t.Run("Valid", func(t *testing.T) {
+		n := &Notification{Slack: &SlackNotification{WebhookURLSecret: secret}}
+		assert.NoError(t, n.Validate())
+	})
+}
+
+func TestNotification_WithDefaults(t *testing.T) {
+	n := Notification{
+		Slack: &SlackNotification{},
+		Email: &EmailNotification{Subject: "my-subject"},
+	}
+	d := n.WithDefaults()
+	assert.Equal(t, defaultNotificationText, d.Slack.Text)
+	assert.Equal(t, "my-subject", d.Email.Subject)
+	assert.Equal(t, defaultNotificationText, d.Email.Body)
+	assert.Empty(t, n.Slack.Text, "the original notification is unchanged")
+	assert.Empty(t, n.Email.Body, "the original notification is unchanged")
+}
+
+func TestEmailNotification_GetPort(t *testing.T) {
+	assert.Equal(t, int32(587), (&EmailNotification{}).GetPort())
+	assert.Equal(t, int32(25), (&EmailNotification{Port: 25}).GetPort())
+}
+
+func TestWorkflowNotification(t *testing.T) {
+	http := Notification{HTTP: &HTTPNotification{URL: "http://example.com"}}
+	t.Run("SentOn", func(t *testing.T) {
+		n := &WorkflowNotification{Name: "n", Notification: http}
+		assert.True(t, n.SentOn(true))
+		assert.True(t, n.SentOn(false))
+		n.Events = []WorkflowNotificationEvent{WorkflowNotificationOnFailure}
+		assert.False(t, n.SentOn(true))
+		assert.True(t, n.SentOn(false))
+	})
+	t.Run("MissingName", func(t *testing.T) {
+		n := &WorkflowNotification{Notification: http}
+		assert.EqualError(t, n.Validate(), "name must be specified")
+	})
+	t.Run("InvalidEvent", func(t *testing.T) {
+		n := &WorkflowNotification{Name: "n", Events: []WorkflowNotificationEvent{"onError"}, Notification: http}
+		assert.EqualError(t, n.Validate(), "events must be onSuccess or onFailure")
+	})
+	t.Run("Valid", func(t *testing.T) {
+		n := &WorkflowNotification{Name: "n", Events: []WorkflowNotificationEvent{WorkflowNotificationOnSuccess}, Notification: http}
+		assert.NoError(t, n.Validate())
+	})
+}
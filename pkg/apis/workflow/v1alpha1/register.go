@@ -50,6 +50,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&WorkflowTaskResultList{},
 		&WorkflowArtifactGCTask{},
 		&WorkflowArtifactGCTaskList{},
+		&WorkflowArtifactRepository{},
+		&WorkflowArtifactRepositoryList{},
+		&ClusterWorkflowArtifactRepository{},
+		&ClusterWorkflowArtifactRepositoryList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
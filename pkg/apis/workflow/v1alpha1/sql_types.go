@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// SQLDriver is the database driver used by a SQL query template
+type SQLDriver string
+
+const (
+	SQLDriverPostgres SQLDriver = "postgres"
+	SQLDriverMySQL    SQLDriver = "mysql"
+)
+
+// SQLRowCountParameter is the output parameter set to the number of rows returned by a SQL query
+const SQLRowCountParameter = "rowCount"
+
+// SQLQuery runs a parameterized SQL query against a database
+type SQLQuery struct {
+	// Driver is the database driver to use, either "postgres" or "mysql"
+	Driver SQLDriver `json:"driver" protobuf:"bytes,1,opt,name=driver,casttype=SQLDriver"`
+	// ConnectionSecret is the secret key containing the data source name (DSN) used to connect to the database
+	ConnectionSecret *apiv1.SecretKeySelector `json:"connectionSecret" protobuf:"bytes,2,opt,name=connectionSecret"`
+	// Query is the SQL statement to run. Arguments are bound using the driver's placeholders, e.g. $1 for postgres or ? for mysql
+	Query string `json:"query" protobuf:"bytes,3,opt,name=query"`
+	// Args are the positional arguments bound to the placeholders in the query
+	Args []string `json:"args,omitempty" protobuf:"bytes,4,rep,name=args"`
+	// TimeoutSeconds is the query timeout. Default is 30 seconds
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty" protobuf:"varint,5,opt,name=timeoutSeconds"`
+	// MaxRows is the maximum number of rows the query may return, the node fails if it returns more. Default is 1000
+	MaxRows *int64 `json:"maxRows,omitempty" protobuf:"varint,6,opt,name=maxRows"`
+	// OutputArtifact is the name of an output artifact that the rows are saved to as JSON, instead of being stored in the outputs result
+	OutputArtifact string `json:"outputArtifact,omitempty" protobuf:"bytes,7,opt,name=outputArtifact"`
+}
+
+func (s *SQLQuery) GetTimeout() time.Duration {
+	if s.TimeoutSeconds != nil {
+		return time.Duration(*s.TimeoutSeconds) * time.Second
+	}
+	return 30 * time.Second
+}
+
+func (s *SQLQuery) GetMaxRows() int64 {
+	if s.MaxRows != nil {
+		return *s.MaxRows
+	}
+	return 1000
+}
+
+func (s *SQLQuery) Validate() error {
+	switch s.Driver {
+	case SQLDriverPostgres, SQLDriverMySQL:
+	default:
+		return fmt.Errorf("driver must be one of: %s, %s", SQLDriverPostgres, SQLDriverMySQL)
+	}
+	if s.ConnectionSecret == nil || s.ConnectionSecret.Name == "" || s.ConnectionSecret.Key == "" {
+		return fmt.Errorf("connectionSecret name and key must be specified")
+	}
+	if s.Query == "" {
+		return fmt.Errorf("query must be specified")
+	}
+	if s.MaxRows != nil && *s.MaxRows <= 0 {
+		return fmt.Errorf("maxRows must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestSQLQuery_Validate(t *testing.T) {
+	secret := &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "db"}, Key: "dsn"}
+	t.Run("InvalidDriver", func(t *testing.T) {
+		q := &SQLQuery{Driver: "sqlite", ConnectionSecret: secret, Query: "SELECT 1"}
+		assert.EqualError(t, q.Validate(), "driver must be one of: postgres, mysql")
+	})
+	t.Run("MissingSecret", func(t *testing.T) {
+		q := &SQLQuery{Driver: SQLDriverPostgres, Query: "SELECT 1"}
+		assert.EqualError(t, q.Validate(), "connectionSecret name and key must be specified")
+	})
+	t.Run("MissingQuery", func(t *testing.T) {
+		q := &SQLQuery{Driver: SQLDriverMySQL, ConnectionSecret: secret}
+		assert.EqualError(t, q.Validate(), "query must be specified")
+	})
+	t.Run("InvalidMaxRows", func(t *testing.T) {
+		q := &SQLQuery{Driver: SQLDriverPostgres, ConnectionSecret: secret, Query: "SELECT 1", MaxRows: pointer.Int64(0)}
+		assert.EqualError(t, q.Validate(), "maxRows must be greater than zero")
+	})
+	t.Run("Valid", func(t *testing.T) {
+		q := &SQLQuery{Driver: SQLDriverPostgres, ConnectionSecret: secret, Query: "SELECT 1"}
+		assert.NoError(t, q.Validate())
+	})
+}
+
+func TestSQLQuery_GetTimeout(t *testing.T) {
+	assert.Equal(t, 30*time.Second, (&SQLQuery{}).GetTimeout())
+	assert.Equal(t, 5*time.Second, (&SQLQuery{TimeoutSeconds: pointer.Int64(5)}).GetTimeout())
+}
+
+func TestSQLQuery_GetMaxRows(t *testing.T) {
+	assert.Equal(t, int64(1000), (&SQLQuery{}).GetMaxRows())
+	assert.Equal(t, int64(5), (&SQLQuery{MaxRows: pointer.Int64(5)}).GetMaxRows())
+}
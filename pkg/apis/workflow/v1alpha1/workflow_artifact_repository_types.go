@@ -0,0 +1,54 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkflowArtifactRepository is an artifact repository that workflows in its namespace reference by name
+// +genclient
+// +kubebuilder:resource:shortName=wfar
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkflowArtifactRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              ArtifactRepository       `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status            ArtifactRepositoryStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// WorkflowArtifactRepositoryList is list of WorkflowArtifactRepository resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkflowArtifactRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []WorkflowArtifactRepository `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ClusterWorkflowArtifactRepository is an artifact repository that workflows in any namespace reference by name
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:scope=Cluster,shortName=cwfar
+// +kubebuilder:subresource:status
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterWorkflowArtifactRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              ArtifactRepository       `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status            ArtifactRepositoryStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ClusterWorkflowArtifactRepositoryList is list of ClusterWorkflowArtifactRepository resources
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ClusterWorkflowArtifactRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ClusterWorkflowArtifactRepository `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
+
+// ArtifactRepositoryStatus is the result of the controller's last check of an artifact repository
+type ArtifactRepositoryStatus struct {
+	// Conditions of the repository, e.g. whether the controller could connect to it
+	Conditions Conditions `json:"conditions,omitempty" protobuf:"bytes,1,rep,name=conditions"`
+	// CheckedAt is when the controller last checked the repository
+	CheckedAt metav1.Time `json:"checkedAt,omitempty" protobuf:"bytes,2,opt,name=checkedAt"`
+}
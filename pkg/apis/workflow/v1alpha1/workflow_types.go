@@ -41,6 +41,8 @@ const (
 	TemplateTypeData         TemplateType = "Data"
 	TemplateTypeHTTP         TemplateType = "HTTP"
 	TemplateTypePlugin       TemplateType = "Plugin"
+	TemplateTypeSQLQuery     TemplateType = "SQLQuery"
+	TemplateTypeNotification TemplateType = "Notification"
 	TemplateTypeUnknown      TemplateType = "Unknown"
 )
 
@@ -70,17 +72,19 @@ type NodeType string
 
 // Node types
 const (
-	NodeTypePod       NodeType = "Pod"
-	NodeTypeContainer NodeType = "Container"
-	NodeTypeSteps     NodeType = "Steps"
-	NodeTypeStepGroup NodeType = "StepGroup"
-	NodeTypeDAG       NodeType = "DAG"
-	NodeTypeTaskGroup NodeType = "TaskGroup"
-	NodeTypeRetry     NodeType = "Retry"
-	NodeTypeSkipped   NodeType = "Skipped"
-	NodeTypeSuspend   NodeType = "Suspend"
-	NodeTypeHTTP      NodeType = "HTTP"
-	NodeTypePlugin    NodeType = "Plugin"
+	NodeTypePod          NodeType = "Pod"
+	NodeTypeContainer    NodeType = "Container"
+	NodeTypeSteps        NodeType = "Steps"
+	NodeTypeStepGroup    NodeType = "StepGroup"
+	NodeTypeDAG          NodeType = "DAG"
+	NodeTypeTaskGroup    NodeType = "TaskGroup"
+	NodeTypeRetry        NodeType = "Retry"
+	NodeTypeSkipped      NodeType = "Skipped"
+	NodeTypeSuspend      NodeType = "Suspend"
+	NodeTypeHTTP         NodeType = "HTTP"
+	NodeTypePlugin       NodeType = "Plugin"
+	NodeTypeSQLQuery     NodeType = "SQLQuery"
+	NodeTypeNotification NodeType = "Notification"
 )
 
 // ArtifactGCStrategy is the strategy when to delete artifacts for GC.
@@ -129,6 +133,7 @@ type VolumeClaimGCStrategy string
 const (
 	VolumeClaimGCOnCompletion VolumeClaimGCStrategy = "OnWorkflowCompletion"
 	VolumeClaimGCOnSuccess    VolumeClaimGCStrategy = "OnWorkflowSuccess"
+	VolumeClaimGCOnDeletion   VolumeClaimGCStrategy = "OnWorkflowDeletion"
 )
 
 // Workflow is the definition of a workflow resource
@@ -222,6 +227,10 @@ func (w *Workflow) HasArtifactGC() bool {
 // return the ultimate ArtifactGCStrategy for the Artifact
 // (defined on the Workflow level but can be overridden on the Artifact level)
 func (w *Workflow) GetArtifactGCStrategy(a *Artifact) ArtifactGCStrategy {
+	// pipe artifacts are never in the artifact repository, they are deleted with the volume they are written to
+	if a.Pipe != nil {
+		return ArtifactGCNever
+	}
 	artifactStrategy := a.GetArtifactGC().GetStrategy()
 	wfStrategy := w.Spec.GetArtifactGC().GetStrategy()
 	strategy := wfStrategy
@@ -270,6 +279,10 @@ type TTLStrategy struct {
 	SecondsAfterSuccess *int32 `json:"secondsAfterSuccess,omitempty" protobuf:"bytes,2,opt,name=secondsAfterSuccess"`
 	// SecondsAfterFailure is the number of seconds to live after failure
 	SecondsAfterFailure *int32 `json:"secondsAfterFailure,omitempty" protobuf:"bytes,3,opt,name=secondsAfterFailure"`
+	// WaitForArchive delays deleting the workflow until it is archived, if it is to be archived
+	WaitForArchive bool `json:"waitForArchive,omitempty" protobuf:"varint,4,opt,name=waitForArchive"`
+	// WaitForArtifactGC delays deleting the workflow until the artifacts garbage collected on workflow completion are deleted
+	WaitForArtifactGC bool `json:"waitForArtifactGC,omitempty" protobuf:"varint,5,opt,name=waitForArtifactGC"`
 }
 
 // WorkflowSpec is the specification of a Workflow.
@@ -412,7 +425,7 @@ type WorkflowSpec struct {
 	// Metrics are a list of metrics emitted from this Workflow
 	Metrics *Metrics `json:"metrics,omitempty" protobuf:"bytes,32,opt,name=metrics"`
 
-	// Shutdown will shutdown the workflow according to its ShutdownStrategy
+	// Shutdown will shutdown the workflow according to its ShutdownStrategy: "Stop", "Terminate" or "Drain"
 	Shutdown ShutdownStrategy `json:"shutdown,omitempty" protobuf:"bytes,33,opt,name=shutdown,casttype=ShutdownStrategy"`
 
 	// WorkflowTemplateRef holds a reference to a WorkflowTemplate for execution
@@ -446,6 +459,164 @@ type WorkflowSpec struct {
 	// ArtifactGC describes the strategy to use when deleting artifacts from completed or deleted workflows (applies to all output Artifacts
 	// unless Artifact.ArtifactGC is specified, which overrides this)
 	ArtifactGC *ArtifactGC `json:"artifactGC,omitempty" protobuf:"bytes,43,opt,name=artifactGC"`
+
+	// PodEnv is a list of environment variables to set in the main containers of all pods in the workflow,
+	// unless the container already defines a variable of the same name
+	PodEnv []apiv1.EnvVar `json:"podEnv,omitempty" protobuf:"bytes,44,rep,name=podEnv"`
+
+	// TemplateImports imports the templates of other WorkflowTemplates or ClusterWorkflowTemplates, so that they can be
+	// called by name as if they were defined in this spec. Templates defined in this spec take precedence.
+	TemplateImports []TemplateImport `json:"templateImports,omitempty" protobuf:"bytes,45,rep,name=templateImports"`
+
+	// NamespaceRestrictions restricts the namespaces whose workflows may reference this template. It is only used by
+	// ClusterWorkflowTemplates.
+	NamespaceRestrictions *NamespaceRestrictions `json:"namespaceRestrictions,omitempty" protobuf:"bytes,46,opt,name=namespaceRestrictions"`
+
+	// RescheduleOnNodePreemption reschedules the pods whose node was preempted, shut down or lost, rather than failing
+	// them. Rescheduled pods do not count towards the limit of their retryStrategy.
+	RescheduleOnNodePreemption *bool `json:"rescheduleOnNodePreemption,omitempty" protobuf:"varint,47,opt,name=rescheduleOnNodePreemption"`
+
+	// TemplateResolutionStrictness is how strictly variables are resolved. "Strict" fails validation when simple tags
+	// or expressions reference undefined variables or outputs, or expressions use Sprig functions. Defaults to "Lenient".
+	TemplateResolutionStrictness TemplateResolutionStrictness `json:"templateResolutionStrictness,omitempty" protobuf:"bytes,48,opt,name=templateResolutionStrictness,casttype=TemplateResolutionStrictness"`
+
+	// Notifications are sent to Slack, by email or to HTTP endpoints by the agent when the workflow completes, after
+	// its exit handler
+	Notifications []WorkflowNotification `json:"notifications,omitempty" protobuf:"bytes,49,rep,name=notifications"`
+
+	// ServiceMesh is the service mesh whose sidecar proxy is injected into the workflow's pods, so that the pods wait
+	// for the proxy to start, and the proxy is shut down once the pod's outputs are saved, rather than running forever
+	ServiceMesh *ServiceMesh `json:"serviceMesh,omitempty" protobuf:"bytes,50,opt,name=serviceMesh"`
+
+	// PodLimit is the maximum number of pods the workflow may create, which protects the cluster from e.g. a
+	// `withParam` that expands to far more items than expected
+	PodLimit *PodLimit `json:"podLimit,omitempty" protobuf:"bytes,51,opt,name=podLimit"`
+}
+
+// ServiceMeshType is the type of a service mesh
+type ServiceMeshType string
+
+const (
+	ServiceMeshIstio   ServiceMeshType = "istio"
+	ServiceMeshLinkerd ServiceMeshType = "linkerd"
+)
+
+// ServiceMesh is a service mesh whose sidecar proxy is injected into pods
+type ServiceMesh struct {
+	// Type of the service mesh, "istio" or "linkerd"
+	Type ServiceMeshType `json:"type" protobuf:"bytes,1,opt,name=type,casttype=ServiceMeshType"`
+	// ShutdownURL is the URL the wait container POSTs to, to shut down the proxy. Defaults to the proxy's own
+	// endpoint, "http://localhost:15020/quitquitquit" for Istio and "http://localhost:4191/shutdown" for Linkerd.
+	ShutdownURL string `json:"shutdownURL,omitempty" protobuf:"bytes,2,opt,name=shutdownURL"`
+}
+
+// GetShutdownURL returns the URL to POST to, to shut down the proxy, or empty if the type is unknown
+func (m *ServiceMesh) GetShutdownURL() string {
+	switch {
+	case m == nil:
+		return ""
+	case m.ShutdownURL != "":
+		return m.ShutdownURL
+	case m.Type == ServiceMeshIstio:
+		return "http://localhost:15020/quitquitquit"
+	case m.Type == ServiceMeshLinkerd:
+		return "http://localhost:4191/shutdown"
+	}
+	return ""
+}
+
+// PodLimitAction is what the controller does when a workflow would create more pods than its limit
+type PodLimitAction string
+
+const (
+	// PodLimitActionFail terminates the workflow
+	PodLimitActionFail PodLimitAction = "Fail"
+	// PodLimitActionSuspend suspends the workflow, so that it can be resumed once its limit has been raised
+	PodLimitActionSuspend PodLimitAction = "Suspend"
+)
+
+// PodLimit is the maximum number of pods a workflow may create
+type PodLimit struct {
+	// Max is the maximum number of pods the workflow may create, including the pods of retries
+	Max int64 `json:"max" protobuf:"varint,1,opt,name=max"`
+	// Action is what the controller does when the workflow would create more pods, "Fail" or "Suspend".
+	// Defaults to "Fail"
+	Action PodLimitAction `json:"action,omitempty" protobuf:"bytes,2,opt,name=action,casttype=PodLimitAction"`
+}
+
+// TemplateResolutionStrictness is how strictly the variables of templates are resolved
+type TemplateResolutionStrictness string
+
+const (
+	// TemplateResolutionLenient ignores the tags it does not know
+	TemplateResolutionLenient TemplateResolutionStrictness = "Lenient"
+	// TemplateResolutionStrict fails validation on the tags and expressions that reference undefined variables
+	TemplateResolutionStrict TemplateResolutionStrictness = "Strict"
+)
+
+// NamespaceRestrictions restricts the namespaces whose workflows may reference a ClusterWorkflowTemplate. Namespaces
+// may be glob patterns, e.g. "team-*".
+type NamespaceRestrictions struct {
+	// Allow is the namespaces that may reference the template. Defaults to all of them.
+	Allow []string `json:"allow,omitempty" protobuf:"bytes,1,rep,name=allow"`
+	// Deny is the namespaces that may not reference the template. It takes precedence over Allow.
+	Deny []string `json:"deny,omitempty" protobuf:"bytes,2,rep,name=deny"`
+}
+
+// Allows returns true if workflows in the namespace may reference the template
+func (r *NamespaceRestrictions) Allows(namespace string) bool {
+	if r == nil {
+		return true
+	}
+	if matchesNamespace(r.Deny, namespace) {
+		return false
+	}
+	return len(r.Allow) == 0 || matchesNamespace(r.Allow, namespace)
+}
+
+func matchesNamespace(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateImport imports the templates of a WorkflowTemplate or ClusterWorkflowTemplate.
+type TemplateImport struct {
+	// Name is the resource name of the workflow template.
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// ClusterScope indicates the imported template is cluster scoped (i.e. a ClusterWorkflowTemplate).
+	ClusterScope bool `json:"clusterScope,omitempty" protobuf:"varint,2,opt,name=clusterScope"`
+	// Revision is the revision of the workflow template to import, i.e. its metadata.generation when it was saved.
+	// Defaults to the latest revision.
+	Revision int64 `json:"revision,omitempty" protobuf:"varint,3,opt,name=revision"`
+	// Templates are the names of the templates to import. Defaults to all of them.
+	Templates []string `json:"templates,omitempty" protobuf:"bytes,4,rep,name=templates"`
+}
+
+// Imports returns true if the template is imported
+func (i TemplateImport) Imports(template string) bool {
+	if len(i.Templates) == 0 {
+		return true
+	}
+	for _, name := range i.Templates {
+		if name == template {
+			return true
+		}
+	}
+	return false
+}
+
+// ToTemplateRef returns a reference to the imported template
+func (i TemplateImport) ToTemplateRef(template string) *TemplateRef {
+	return &TemplateRef{
+		Name:         i.Name,
+		ClusterScope: i.ClusterScope,
+		Template:     template,
+		Revision:     i.Revision,
+	}
 }
 
 type LabelValueFrom struct {
@@ -476,6 +647,16 @@ func (wfs *WorkflowSpec) HasExitHook() bool {
 	return (wfs.Hooks != nil && wfs.Hooks.HasExitHook()) || wfs.OnExit != ""
 }
 
+// ShouldRescheduleOnNodePreemption returns whether pods whose node was preempted are rescheduled
+func (wfs WorkflowSpec) ShouldRescheduleOnNodePreemption() bool {
+	return wfs.RescheduleOnNodePreemption != nil && *wfs.RescheduleOnNodePreemption
+}
+
+// IsTemplateResolutionStrict returns whether the variables of templates are resolved strictly
+func (wfs WorkflowSpec) IsTemplateResolutionStrict() bool {
+	return wfs.TemplateResolutionStrictness == TemplateResolutionStrict
+}
+
 // GetVolumeClaimGC returns the VolumeClaimGC that was defined in the workflow spec.  If none was provided, a default value is returned.
 func (wfs WorkflowSpec) GetVolumeClaimGC() *VolumeClaimGC {
 	// If no volumeClaimGC strategy was provided, we default to the equivalent of "OnSuccess"
@@ -545,7 +726,9 @@ type ShutdownStrategy string
 const (
 	ShutdownStrategyTerminate ShutdownStrategy = "Terminate"
 	ShutdownStrategyStop      ShutdownStrategy = "Stop"
-	ShutdownStrategyNone      ShutdownStrategy = ""
+	// ShutdownStrategyDrain does not start new nodes, other than those of exit handlers, but lets running pods finish
+	ShutdownStrategyDrain ShutdownStrategy = "Drain"
+	ShutdownStrategyNone  ShutdownStrategy = ""
 )
 
 func (s ShutdownStrategy) Enabled() bool {
@@ -556,13 +739,18 @@ func (s ShutdownStrategy) ShouldExecute(isOnExitPod bool) bool {
 	switch s {
 	case ShutdownStrategyTerminate:
 		return false
-	case ShutdownStrategyStop:
+	case ShutdownStrategyStop, ShutdownStrategyDrain:
 		return isOnExitPod
 	default:
 		return true
 	}
 }
 
+// ShouldTerminate returns whether running pods should be terminated
+func (s ShutdownStrategy) ShouldTerminate(isOnExitPod bool) bool {
+	return s != ShutdownStrategyDrain && !s.ShouldExecute(isOnExitPod)
+}
+
 // +kubebuilder:validation:Type=array
 type ParallelSteps struct {
 	Steps []WorkflowStep `json:"-" protobuf:"bytes,1,rep,name=steps"`
@@ -674,6 +862,12 @@ type Template struct {
 	// Plugin is a plugin template
 	Plugin *Plugin `json:"plugin,omitempty" protobuf:"bytes,43,opt,name=plugin"`
 
+	// SQLQuery runs a SQL query against a database
+	SQLQuery *SQLQuery `json:"sqlQuery,omitempty" protobuf:"bytes,44,opt,name=sqlQuery"`
+
+	// Notification sends a message to Slack, by email or to an HTTP endpoint
+	Notification *Notification `json:"notification,omitempty" protobuf:"bytes,46,opt,name=notification"`
+
 	// Volumes is a list of volumes that can be mounted by containers in a template.
 	// +patchStrategy=merge
 	// +patchMergeKey=name
@@ -766,6 +960,79 @@ type Template struct {
 	// Timeout allows to set the total node execution timeout duration counting from the node's start time.
 	// This duration also includes time in which the node spends in Pending state. This duration may not be applied to Step or DAG templates.
 	Timeout string `json:"timeout,omitempty" protobuf:"bytes,38,opt,name=timeout"`
+
+	// GPU requests GPUs for the main container, using the extended resource and runtime class of the vendor's device plugin
+	GPU *GPU `json:"gpu,omitempty" protobuf:"bytes,45,opt,name=gpu"`
+
+	// PodDisruptionBudget holds the number of concurrent disruptions that you allow for the pods of this template.
+	// It is created when the first pod of the template is, and deleted when the workflow completes.
+	PodDisruptionBudget *policyv1beta.PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty" protobuf:"bytes,47,opt,name=podDisruptionBudget"`
+
+	// DisruptionSensitive prevents the cluster autoscaler from evicting the pods of this template to scale down their
+	// nodes, e.g. for steps that run for hours
+	DisruptionSensitive *bool `json:"disruptionSensitive,omitempty" protobuf:"varint,48,opt,name=disruptionSensitive"`
+
+	// ImagePullSecrets are added to those of the workflow for the pods of this template, so secrets for the registries
+	// of only some templates' images need not be attached to every pod
+	// +patchStrategy=merge
+	// +patchMergeKey=name
+	ImagePullSecrets []apiv1.LocalObjectReference `json:"imagePullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"name" protobuf:"bytes,49,rep,name=imagePullSecrets"`
+
+	// WorkingDirOverride is the working directory of the main containers, overriding that of their images. Unlike the
+	// container's workingDir, the executor creates it if it is missing, as the container's user, e.g. in a mounted volume.
+	WorkingDirOverride string `json:"workingDirOverride,omitempty" protobuf:"bytes,50,opt,name=workingDirOverride"`
+
+	// RunAsUserOverride is the UID the main containers run as, overriding that of their images and security contexts
+	RunAsUserOverride *int64 `json:"runAsUserOverride,omitempty" protobuf:"varint,51,opt,name=runAsUserOverride"`
+
+	// Cluster is the name of the cluster, as configured in the controller's `clusters`, that the template's pod runs on.
+	// The state of the workflow is kept in this cluster. Defaults to this cluster.
+	Cluster string `json:"cluster,omitempty" protobuf:"bytes,52,opt,name=cluster"`
+
+	// WorkloadClass is the name of a workload class, as configured in the controller's `workloadClasses`, e.g. "gpu",
+	// whose node selector, affinity and tolerations the template's pod has, unless the template has its own
+	WorkloadClass string `json:"workloadClass,omitempty" protobuf:"bytes,53,opt,name=workloadClass"`
+
+	// Resources are the resource requests and limits of the main containers, which override those of the containers.
+	// Unlike the containers' own, they may be templated from parameters, e.g. "{{workflow.parameters.memory}}".
+	Resources *TemplateResources `json:"resources,omitempty" protobuf:"bytes,54,opt,name=resources"`
+}
+
+// TemplateResources are resource requests and limits whose quantities may be templated
+type TemplateResources struct {
+	// Limits are the maximum amount of compute resources allowed, e.g. `memory: "{{inputs.parameters.memory}}"`
+	Limits map[apiv1.ResourceName]string `json:"limits,omitempty" protobuf:"bytes,1,rep,name=limits,castkey=k8s.io/api/core/v1.ResourceName"`
+	// Requests are the minimum amount of compute resources required
+	Requests map[apiv1.ResourceName]string `json:"requests,omitempty" protobuf:"bytes,2,rep,name=requests,castkey=k8s.io/api/core/v1.ResourceName"`
+}
+
+// parseResourceList parses the quantities of a list of resources
+func parseResourceList(name string, quantities map[apiv1.ResourceName]string) (apiv1.ResourceList, error) {
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+	list := make(apiv1.ResourceList, len(quantities))
+	for resourceName, value := range quantities {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("resources.%s.%s %q is not a valid quantity: %w", name, resourceName, value, err)
+		}
+		list[resourceName] = q
+	}
+	return list, nil
+}
+
+// ResourceRequirements parses the quantities of the requests and limits, which must no longer be templated
+func (r *TemplateResources) ResourceRequirements() (apiv1.ResourceRequirements, error) {
+	limits, err := parseResourceList("limits", r.Limits)
+	if err != nil {
+		return apiv1.ResourceRequirements{}, err
+	}
+	requests, err := parseResourceList("requests", r.Requests)
+	if err != nil {
+		return apiv1.ResourceRequirements{}, err
+	}
+	return apiv1.ResourceRequirements{Limits: limits, Requests: requests}, nil
 }
 
 // SetType will set the template object based on template type.
@@ -817,6 +1084,10 @@ func (tmpl *Template) GetSidecarNames() []string {
 	return containerNames
 }
 
+func (tmpl *Template) IsDisruptionSensitive() bool {
+	return tmpl.DisruptionSensitive != nil && *tmpl.DisruptionSensitive
+}
+
 func (tmpl *Template) IsFailFast() bool {
 	return tmpl.FailFast != nil && *tmpl.FailFast
 }
@@ -890,11 +1161,46 @@ type Parameter struct {
 
 	// Description is the parameter description
 	Description *AnyString `json:"description,omitempty" protobuf:"bytes,7,opt,name=description"`
+
+	// Schema constrains the value of the parameter. Values passed to a WorkflowTemplate's parameters, e.g. on submission,
+	// must match the schema, and enum, of the WorkflowTemplate's parameter.
+	Schema *ParameterSchema `json:"schema,omitempty" protobuf:"bytes,8,opt,name=schema"`
+}
+
+// ParameterType is the type of the value of a parameter
+type ParameterType string
+
+const (
+	ParameterTypeString ParameterType = "string"
+	ParameterTypeInt    ParameterType = "int"
+	ParameterTypeBool   ParameterType = "bool"
+	ParameterTypeJSON   ParameterType = "json"
+)
+
+// ParameterSchema constrains the value of a parameter
+type ParameterSchema struct {
+	// Type of the value, one of "string" (default), "int", "bool" ("true" or "false") or "json"
+	Type ParameterType `json:"type,omitempty" protobuf:"bytes,1,opt,name=type,casttype=ParameterType"`
+	// Pattern is a regular expression (https://github.com/google/re2/wiki/Syntax) the value must match,
+	// e.g. "^[a-z]+$"
+	Pattern string `json:"pattern,omitempty" protobuf:"bytes,2,opt,name=pattern"`
+	// Minimum value of an int
+	Minimum *int64 `json:"minimum,omitempty" protobuf:"varint,3,opt,name=minimum"`
+	// Maximum value of an int
+	Maximum *int64 `json:"maximum,omitempty" protobuf:"varint,4,opt,name=maximum"`
+}
+
+func (s *ParameterSchema) GetType() ParameterType {
+	if s == nil || s.Type == "" {
+		return ParameterTypeString
+	}
+	return s.Type
 }
 
 // ValueFrom describes a location in which to obtain the value to a parameter
 type ValueFrom struct {
-	// Path in the container to retrieve an output parameter value from in container templates
+	// Path in the container to retrieve an output parameter value from in container templates. If it is a glob pattern,
+	// e.g. "/tmp/results/*.json", the value is a JSON object of the contents of the matching files, keyed by their paths
 	Path string `json:"path,omitempty" protobuf:"bytes,1,opt,name=path"`
 
 	// JSONPath of a resource to retrieve an output parameter value from in resource templates
@@ -982,6 +1288,65 @@ type Artifact struct {
 
 	// Has this been deleted?
 	Deleted bool `json:"deleted,omitempty" protobuf:"varint,13,opt,name=deleted"`
+
+	// Validation is checked by the init container after an input artifact is loaded, before the main container starts
+	Validation *ArtifactValidation `json:"validation,omitempty" protobuf:"bytes,14,opt,name=validation"`
+
+	// Digest is the hex-encoded SHA-256 digest of the content of the artifact, set by the executor when it saves the
+	// artifact. It can be referenced as `{{inputs.artifacts.<name>.digest}}`, e.g. in a memoization key
+	Digest string `json:"digest,omitempty" protobuf:"bytes,15,opt,name=digest"`
+
+	// Pipe streams the artifact through a shared volume instead of the artifact repository, so that a downstream
+	// DAG task can start reading it while it is still being written
+	Pipe *PipeArtifact `json:"pipe,omitempty" protobuf:"bytes,16,opt,name=pipe"`
+
+	// FromWorkflow takes the artifact from the output artifacts of another workflow in the same namespace, e.g. so a
+	// daily pipeline can consume the previous day's output without knowing the name of the workflow that produced it
+	FromWorkflow *ArtifactFromWorkflow `json:"fromWorkflow,omitempty" protobuf:"bytes,17,opt,name=fromWorkflow"`
+}
+
+// PipeArtifact is an output artifact written to a shared volume, which downstream tasks mount directly rather than
+// waiting for it to be uploaded to, and downloaded from, the artifact repository
+type PipeArtifact struct {
+	// Volume is the name of the workflow volume, or volume claim template, the artifact is written to.
+	// It must be mounted by the template's container at a path that contains the artifact's path.
+	Volume string `json:"volume" protobuf:"bytes,1,opt,name=volume"`
+
+	// SubPath is the path of the artifact within the volume, set by the controller
+	SubPath string `json:"subPath,omitempty" protobuf:"bytes,2,opt,name=subPath"`
+}
+
+// FromWorkflowStrategy picks one of the workflows an ArtifactFromWorkflow selects
+type FromWorkflowStrategy string
+
+const (
+	// FromWorkflowStrategyLatest picks the succeeded workflow that finished most recently
+	FromWorkflowStrategyLatest FromWorkflowStrategy = "Latest"
+)
+
+// ArtifactFromWorkflow is an output artifact of another workflow, found amongst both the live and archived workflows
+type ArtifactFromWorkflow struct {
+	// Selector selects the workflows to take the artifact from
+	Selector *metav1.LabelSelector `json:"selector" protobuf:"bytes,1,opt,name=selector"`
+
+	// ArtifactName is the name of the workflow output artifact, i.e. the `globalName` it was exported with
+	ArtifactName string `json:"artifactName" protobuf:"bytes,2,opt,name=artifactName"`
+
+	// Strategy picks one of the selected workflows. Defaults to "Latest"
+	Strategy FromWorkflowStrategy `json:"strategy,omitempty" protobuf:"bytes,3,opt,name=strategy,casttype=FromWorkflowStrategy"`
+}
+
+// ArtifactValidation describes the checks made on an input artifact once it has been loaded
+type ArtifactValidation struct {
+	// Required fails the node if the artifact does not exist or is empty, i.e. a zero-length file or an empty directory
+	Required bool `json:"required,omitempty" protobuf:"varint,1,opt,name=required"`
+
+	// MinSizeBytes is the minimum size of the artifact. The size of a directory is the total size of the files within it
+	MinSizeBytes *int64 `json:"minSizeBytes,omitempty" protobuf:"varint,2,opt,name=minSizeBytes"`
+
+	// MediaType is the expected media type of the artifact, e.g. "image/png", as detected from its content.
+	// Only valid for artifacts that are files
+	MediaType string `json:"mediaType,omitempty" protobuf:"bytes,3,opt,name=mediaType"`
 }
 
 // ArtifactGC returns the ArtifactGC that was defined by the artifact.  If none was provided, a default value is returned.
@@ -1030,6 +1395,21 @@ type PodGC struct {
 	Strategy PodGCStrategy `json:"strategy,omitempty" protobuf:"bytes,1,opt,name=strategy,casttype=PodGCStrategy"`
 	// LabelSelector is the label selector to check if the pods match the labels before being added to the pod GC queue.
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty" protobuf:"bytes,2,opt,name=labelSelector"`
+	// OlderThan is how long a pod is kept after it completes before it is deleted, e.g. "24h". Defaults to deleting it
+	// immediately
+	OlderThan *metav1.Duration `json:"olderThan,omitempty" protobuf:"bytes,3,opt,name=olderThan"`
+	// Rules override how long the pods they match are kept. The first rule that matches a pod applies
+	Rules []PodGCRule `json:"rules,omitempty" protobuf:"bytes,4,rep,name=rules"`
+}
+
+// PodGCRule overrides how long the completed pods in some phases, or matching a label selector, are kept
+type PodGCRule struct {
+	// Phases are the phases of the pods the rule matches, "Succeeded" or "Failed". Matches any phase if empty
+	Phases []apiv1.PodPhase `json:"phases,omitempty" protobuf:"bytes,1,rep,name=phases,casttype=k8s.io/api/core/v1.PodPhase"`
+	// LabelSelector selects the pods the rule matches. Matches any pod if empty
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty" protobuf:"bytes,2,opt,name=labelSelector"`
+	// OlderThan is how long the pods are kept after they complete before they are deleted. Zero deletes them immediately
+	OlderThan *metav1.Duration `json:"olderThan,omitempty" protobuf:"bytes,3,opt,name=olderThan"`
 }
 
 // GetLabelSelector gets the label selector from podGC.
@@ -1043,6 +1423,44 @@ func (podGC *PodGC) GetLabelSelector() (labels.Selector, error) {
 	return metav1.LabelSelectorAsSelector(podGC.LabelSelector)
 }
 
+func (r PodGCRule) matchesPhase(phase apiv1.PodPhase) bool {
+	for _, p := range r.Phases {
+		if p == phase {
+			return true
+		}
+	}
+	return len(r.Phases) == 0
+}
+
+// GetOlderThan returns how long a completed pod, in the phase and with the labels, is kept before it is deleted
+func (podGC *PodGC) GetOlderThan(phase apiv1.PodPhase, podLabels map[string]string) (time.Duration, error) {
+	if podGC == nil {
+		return 0, nil
+	}
+	for _, rule := range podGC.Rules {
+		if !rule.matchesPhase(phase) {
+			continue
+		}
+		if rule.LabelSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.LabelSelector)
+			if err != nil {
+				return 0, err
+			}
+			if !selector.Matches(labels.Set(podLabels)) {
+				continue
+			}
+		}
+		if rule.OlderThan == nil {
+			return 0, nil
+		}
+		return rule.OlderThan.Duration, nil
+	}
+	if podGC.OlderThan == nil {
+		return 0, nil
+	}
+	return podGC.OlderThan.Duration, nil
+}
+
 func (podGC *PodGC) GetStrategy() PodGCStrategy {
 	if podGC != nil {
 		return podGC.Strategy
@@ -1061,6 +1479,18 @@ type ArtifactGC struct {
 
 	// ServiceAccountName is an optional field for specifying the Service Account that should be assigned to the Pod doing the deletion
 	ServiceAccountName string `json:"serviceAccountName,omitempty" protobuf:"bytes,3,opt,name=serviceAccountName"`
+
+	// DNSPolicy is an optional field for specifying the DNS policy of the Pod doing the deletion, e.g.
+	// 'ClusterFirstWithHostNet' for a Pod on the host network
+	DNSPolicy *apiv1.DNSPolicy `json:"dnsPolicy,omitempty" protobuf:"bytes,4,opt,name=dnsPolicy"`
+
+	// DNSConfig is an optional field for specifying the DNS parameters of the Pod doing the deletion, in addition to
+	// those generated from DNSPolicy
+	DNSConfig *apiv1.PodDNSConfig `json:"dnsConfig,omitempty" protobuf:"bytes,5,opt,name=dnsConfig"`
+
+	// HostNetwork is an optional field for running the Pod doing the deletion on the host network, for artifact
+	// repositories that only the nodes can reach
+	HostNetwork *bool `json:"hostNetwork,omitempty" protobuf:"varint,6,opt,name=hostNetwork"`
 }
 
 // GetStrategy returns the VolumeClaimGCStrategy to use for the workflow
@@ -1073,8 +1503,16 @@ func (agc *ArtifactGC) GetStrategy() ArtifactGCStrategy {
 
 // VolumeClaimGC describes how to delete volumes from completed Workflows
 type VolumeClaimGC struct {
-	// Strategy is the strategy to use. One of "OnWorkflowCompletion", "OnWorkflowSuccess"
+	// Strategy is the strategy to use. One of "OnWorkflowCompletion", "OnWorkflowSuccess", "OnWorkflowDeletion".
+	// "OnWorkflowSuccess" retains the volumes of failed workflows for debugging, "OnWorkflowDeletion" retains them until
+	// the workflow is deleted
 	Strategy VolumeClaimGCStrategy `json:"strategy,omitempty" protobuf:"bytes,1,opt,name=strategy,casttype=VolumeClaimGCStrategy"`
+	// OlderThan is how long volumes are kept after the workflow completes before they are deleted, e.g. "1h". Defaults
+	// to deleting them immediately
+	OlderThan *metav1.Duration `json:"olderThan,omitempty" protobuf:"bytes,2,opt,name=olderThan"`
+	// ResizeOnRetry expands the retained volumes a retried workflow reuses to the storage their volume claim templates
+	// request, if it is larger, e.g. when retried with a larger parameter. The storage class must allow volume expansion
+	ResizeOnRetry bool `json:"resizeOnRetry,omitempty" protobuf:"varint,3,opt,name=resizeOnRetry"`
 }
 
 // GetStrategy returns the VolumeClaimGCStrategy to use for the workflow
@@ -1148,6 +1586,14 @@ type ArtifactLocation struct {
 
 	// Azure contains Azure Storage artifact location details
 	Azure *AzureArtifact `json:"azure,omitempty" protobuf:"bytes,10,opt,name=azure"`
+
+	// SeparateLogStreams indicates if the stdout and stderr of each container should be archived as separate artifacts,
+	// named `<container>-stdout-logs` and `<container>-stderr-logs`, as well as the combined log.
+	// Only used when logs are archived
+	SeparateLogStreams *bool `json:"separateLogStreams,omitempty" protobuf:"varint,11,opt,name=separateLogStreams"`
+
+	// Plugin contains the location details of an artifact stored by an artifact driver plugin
+	Plugin *PluginArtifact `json:"plugin,omitempty" protobuf:"bytes,12,opt,name=plugin"`
 }
 
 func (a *ArtifactLocation) Get() (ArtifactLocationType, error) {
@@ -1167,6 +1613,8 @@ func (a *ArtifactLocation) Get() (ArtifactLocationType, error) {
 		return a.HTTP, nil
 	} else if a.OSS != nil {
 		return a.OSS, nil
+	} else if a.Plugin != nil {
+		return a.Plugin, nil
 	} else if a.Raw != nil {
 		return a.Raw, nil
 	} else if a.S3 != nil {
@@ -1191,6 +1639,8 @@ func (a *ArtifactLocation) SetType(x ArtifactLocationType) error {
 		a.HTTP = &HTTPArtifact{}
 	case *OSSArtifact:
 		a.OSS = &OSSArtifact{}
+	case *PluginArtifact:
+		a.Plugin = &PluginArtifact{}
 	case *RawArtifact:
 		a.Raw = &RawArtifact{}
 	case *S3Artifact:
@@ -1256,6 +1706,10 @@ func (a *ArtifactLocation) IsArchiveLogs() bool {
 	return a != nil && a.ArchiveLogs != nil && *a.ArchiveLogs
 }
 
+func (a *ArtifactLocation) IsSeparateLogStreams() bool {
+	return a != nil && a.SeparateLogStreams != nil && *a.SeparateLogStreams
+}
+
 func (a *ArtifactLocation) GetKey() (string, error) {
 	v, err := a.Get()
 	if err != nil {
@@ -1270,6 +1724,11 @@ type ArtifactRepositoryRef struct {
 	ConfigMap string `json:"configMap,omitempty" protobuf:"bytes,1,opt,name=configMap"`
 	// The config map key. Defaults to the value of the "workflows.argoproj.io/default-artifact-repository" annotation.
 	Key string `json:"key,omitempty" protobuf:"bytes,2,opt,name=key"`
+	// Name of a WorkflowArtifactRepository in the workflow's namespace, or of a ClusterWorkflowArtifactRepository if
+	// clusterScope is true. Cannot be combined with configMap or key.
+	Name string `json:"name,omitempty" protobuf:"bytes,3,opt,name=name"`
+	// ClusterScope indicates the name refers to a ClusterWorkflowArtifactRepository.
+	ClusterScope bool `json:"clusterScope,omitempty" protobuf:"varint,4,opt,name=clusterScope"`
 }
 
 func (r *ArtifactRepositoryRef) GetConfigMapOr(configMap string) string {
@@ -1290,6 +1749,9 @@ func (r *ArtifactRepositoryRef) String() string {
 	if r == nil {
 		return "nil"
 	}
+	if r.Name != "" {
+		return r.Name
+	}
 	return fmt.Sprintf("%s#%s", r.ConfigMap, r.Key)
 }
 
@@ -1311,6 +1773,9 @@ func (r *ArtifactRepositoryRefStatus) String() string {
 	if r.Default {
 		return "default-artifact-repository"
 	}
+	if r.ClusterScope {
+		return r.ArtifactRepositoryRef.String()
+	}
 	return fmt.Sprintf("%s/%s", r.Namespace, r.ArtifactRepositoryRef.String())
 }
 
@@ -1612,6 +2077,9 @@ type TemplateRef struct {
 	Template string `json:"template,omitempty" protobuf:"bytes,2,opt,name=template"`
 	// ClusterScope indicates the referred template is cluster scoped (i.e. a ClusterWorkflowTemplate).
 	ClusterScope bool `json:"clusterScope,omitempty" protobuf:"varint,4,opt,name=clusterScope"`
+	// Revision is the revision of the template resource to use, i.e. its metadata.generation when it was saved. Defaults
+	// to the latest revision.
+	Revision int64 `json:"revision,omitempty" protobuf:"varint,5,opt,name=revision"`
 }
 
 // Synchronization holds synchronization lock configuration
@@ -1650,6 +2118,17 @@ func (s *Synchronization) GetType() SynchronizationType {
 type SemaphoreRef struct {
 	// ConfigMapKeyRef is configmap selector for Semaphore configuration
 	ConfigMapKeyRef *apiv1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty" protobuf:"bytes,1,opt,name=configMapKeyRef"`
+	// Weight is the number of units of the semaphore's limit the lock consumes, e.g. a step with a weight of 3 uses
+	// 3 units of a semaphore with a limit of 10. Defaults to 1.
+	Weight int32 `json:"weight,omitempty" protobuf:"varint,2,opt,name=weight"`
+}
+
+// GetWeight returns the number of units of the semaphore the lock consumes
+func (r *SemaphoreRef) GetWeight() int64 {
+	if r == nil || r.Weight < 1 {
+		return 1
+	}
+	return int64(r.Weight)
 }
 
 // Mutex holds Mutex configuration
@@ -1664,6 +2143,9 @@ type WorkflowTemplateRef struct {
 	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
 	// ClusterScope indicates the referred template is cluster scoped (i.e. a ClusterWorkflowTemplate).
 	ClusterScope bool `json:"clusterScope,omitempty" protobuf:"varint,2,opt,name=clusterScope"`
+	// Revision is the revision of the workflow template to use, i.e. its metadata.generation when it was saved.
+	// Defaults to the latest revision.
+	Revision int64 `json:"revision,omitempty" protobuf:"varint,3,opt,name=revision"`
 }
 
 func (ref *WorkflowTemplateRef) ToTemplateRef(entrypoint string) *TemplateRef {
@@ -1671,6 +2153,7 @@ func (ref *WorkflowTemplateRef) ToTemplateRef(entrypoint string) *TemplateRef {
 		Name:         ref.Name,
 		ClusterScope: ref.ClusterScope,
 		Template:     entrypoint,
+		Revision:     ref.Revision,
 	}
 }
 
@@ -1781,6 +2264,16 @@ type UserContainer struct {
 	// dind daemon to partially see the same filesystem as the main container in
 	// order to use features such as docker volume binding
 	MirrorVolumeMounts *bool `json:"mirrorVolumeMounts,omitempty" protobuf:"varint,2,opt,name=mirrorVolumeMounts"`
+
+	// CaptureOutputs captures the output parameters and artifacts of the template that an init container writes, before
+	// the main container starts. The output parameters are also available to the main container at the same paths.
+	// Only valid for init containers
+	CaptureOutputs *bool `json:"captureOutputs,omitempty" protobuf:"varint,3,opt,name=captureOutputs"`
+}
+
+// GetCaptureOutputs returns whether the outputs of the template are captured from the container
+func (c UserContainer) GetCaptureOutputs() bool {
+	return c.CaptureOutputs != nil && *c.CaptureOutputs
 }
 
 // WorkflowStatus contains overall status information about a workflow
@@ -1829,6 +2322,12 @@ type WorkflowStatus struct {
 	// ResourcesDuration is the total for the workflow
 	ResourcesDuration ResourcesDuration `json:"resourcesDuration,omitempty" protobuf:"bytes,12,opt,name=resourcesDuration"`
 
+	// Cost is the total cost of the completed pods of the workflow, priced by the controller's pricing config
+	Cost *Amount `json:"cost,omitempty" protobuf:"bytes,20,opt,name=cost"`
+
+	// EstimatedCost is the total of the cost of the completed pods, and the estimated cost of the other pods, of the workflow
+	EstimatedCost *Amount `json:"estimatedCost,omitempty" protobuf:"bytes,21,opt,name=estimatedCost"`
+
 	// StoredWorkflowSpec stores the WorkflowTemplate spec for future execution.
 	StoredWorkflowSpec *WorkflowSpec `json:"storedWorkflowTemplateSpec,omitempty" protobuf:"bytes,14,opt,name=storedWorkflowTemplateSpec"`
 
@@ -1877,8 +2376,27 @@ type Backoff struct {
 	Duration string `json:"duration,omitempty" protobuf:"varint,1,opt,name=duration"`
 	// Factor is a factor to multiply the base duration after each failed retry
 	Factor *intstr.IntOrString `json:"factor,omitempty" protobuf:"varint,2,opt,name=factor"`
-	// MaxDuration is the maximum amount of time allowed for the backoff strategy
+	// MaxDuration is the maximum amount of time allowed for the backoff strategy. It is cumulative across the retries,
+	// i.e. measured from when the first attempt started, not the time of each attempt.
 	MaxDuration string `json:"maxDuration,omitempty" protobuf:"varint,3,opt,name=maxDuration"`
+	// Jitter is the maximum fraction, between 0 and 1, of the backoff that is randomly added to it, e.g. 0.2 for up to
+	// 20%, so the retries of many nodes that failed together do not all happen at once
+	Jitter *Amount `json:"jitter,omitempty" protobuf:"bytes,4,opt,name=jitter"`
+}
+
+// GetJitter returns the jitter of the backoff, or 0 if it has none
+func (b *Backoff) GetJitter() (float64, error) {
+	if b == nil || b.Jitter == nil {
+		return 0, nil
+	}
+	jitter, err := b.Jitter.Float64()
+	if err != nil {
+		return 0, fmt.Errorf("invalid jitter %q: %w", b.Jitter.Value, err)
+	}
+	if jitter < 0 || jitter > 1 {
+		return 0, fmt.Errorf("jitter %v must be between 0 and 1", jitter)
+	}
+	return jitter, nil
 }
 
 // RetryNodeAntiAffinity is a placeholder for future expansion, only empty nodeAntiAffinity is allowed.
@@ -2034,6 +2552,18 @@ const (
 	ConditionTypeMetricsError ConditionType = "MetricsError"
 	//ConditionTypeArtifactGCError is an error on artifact garbage collection
 	ConditionTypeArtifactGCError ConditionType = "ArtifactGCError"
+	// ConditionTypeTTLWaiting signifies the workflow has expired, but is not deleted until it is archived or its artifacts are garbage collected
+	ConditionTypeTTLWaiting ConditionType = "TTLWaiting"
+	// ConditionTypePreempted signifies the workflow was preempted by a higher-priority workflow, and is paused until it is admitted again
+	ConditionTypePreempted ConditionType = "Preempted"
+	// ConditionTypeQuotaExceeded signifies the workflow is held in Pending because a quota does not allow it to start
+	ConditionTypeQuotaExceeded ConditionType = "QuotaExceeded"
+	// ConditionTypeConnected signifies whether the controller could connect to an artifact repository using its credentials
+	ConditionTypeConnected ConditionType = "Connected"
+	// ConditionTypePodLimitExceeded signifies the workflow would have created more pods than its limit
+	ConditionTypePodLimitExceeded ConditionType = "PodLimitExceeded"
+	// ConditionTypeVolumeClaimsRetained signifies the persistent volume claims of the completed workflow were not deleted
+	ConditionTypeVolumeClaimsRetained ConditionType = "VolumeClaimsRetained"
 )
 
 type Condition struct {
@@ -2098,6 +2628,12 @@ type NodeStatus struct {
 	// ResourcesDuration is indicative, but not accurate, resource duration. This is populated when the nodes completes.
 	ResourcesDuration ResourcesDuration `json:"resourcesDuration,omitempty" protobuf:"bytes,21,opt,name=resourcesDuration"`
 
+	// Cost is the cost of the resources duration of a pod, priced by the controller's pricing config. This is populated when the node completes.
+	Cost *Amount `json:"cost,omitempty" protobuf:"bytes,27,opt,name=cost"`
+
+	// EstimatedCost is the cost of the resources a pod requests for its estimated duration. This is populated when the pod is created.
+	EstimatedCost *Amount `json:"estimatedCost,omitempty" protobuf:"bytes,28,opt,name=estimatedCost"`
+
 	// PodIP captures the IP of the pod for daemoned steps
 	PodIP string `json:"podIP,omitempty" protobuf:"bytes,12,opt,name=podIP"`
 
@@ -2110,6 +2646,12 @@ type NodeStatus struct {
 	// Outputs captures output parameter values and artifact locations produced by this template invocation
 	Outputs *Outputs `json:"outputs,omitempty" protobuf:"bytes,15,opt,name=outputs"`
 
+	// ParametersConfigMap is the ConfigMap the values of the node's parameters, or result, that were too large for the workflow status were offloaded to
+	ParametersConfigMap string `json:"parametersConfigMap,omitempty" protobuf:"bytes,29,opt,name=parametersConfigMap"`
+
+	// ParametersDatabaseOffload is whether the values of the node's parameters, or result, that were too large for a ConfigMap were offloaded to the database
+	ParametersDatabaseOffload bool `json:"parametersDatabaseOffload,omitempty" protobuf:"varint,31,opt,name=parametersDatabaseOffload"`
+
 	// Children is a list of child node IDs
 	Children []string `json:"children,omitempty" protobuf:"bytes,16,rep,name=children"`
 
@@ -2135,6 +2677,9 @@ type NodeStatus struct {
 
 	// SynchronizationStatus is the synchronization status of the node
 	SynchronizationStatus *NodeSynchronizationStatus `json:"synchronizationStatus,omitempty" protobuf:"bytes,25,opt,name=synchronizationStatus"`
+
+	// Approval is the approval required to resume a suspend node, and the users that have approved it
+	Approval *NodeApproval `json:"approval,omitempty" protobuf:"bytes,30,opt,name=approval"`
 }
 
 func (n *NodeStatus) GetName() string {
@@ -2514,6 +3059,29 @@ func (a *AzureArtifact) HasLocation() bool {
 	return a != nil && a.Container != "" && a.Blob != ""
 }
 
+// PluginArtifact is the location of an artifact stored by an artifact driver plugin, which runs as a sidecar of the
+// pods that load, save or delete it
+type PluginArtifact struct {
+	// Name of the artifact driver plugin
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// Key is the path of the artifact in the plugin's storage
+	Key string `json:"key" protobuf:"bytes,2,opt,name=key"`
+}
+
+func (p *PluginArtifact) GetKey() (string, error) {
+	return p.Key, nil
+}
+
+func (p *PluginArtifact) SetKey(key string) error {
+	p.Key = key
+	return nil
+}
+
+func (p *PluginArtifact) HasLocation() bool {
+	return p != nil && p.Name != "" && p.Key != ""
+}
+
 // HDFSArtifact is the location of an HDFS artifact
 type HDFSArtifact struct {
 	HDFSConfig `json:",inline" protobuf:"bytes,1,opt,name=hDFSConfig"`
@@ -2765,6 +3333,9 @@ func (o *OSSArtifact) HasLocation() bool {
 type ExecutorConfig struct {
 	// ServiceAccountName specifies the service account name of the executor container.
 	ServiceAccountName string `json:"serviceAccountName,omitempty" protobuf:"bytes,1,opt,name=serviceAccountName"`
+	// ArtifactSaveParallelism is the number of output artifacts saved concurrently by the wait container.
+	// Overrides the controller's artifactSaveParallelism.
+	ArtifactSaveParallelism *int32 `json:"artifactSaveParallelism,omitempty" protobuf:"varint,2,opt,name=artifactSaveParallelism"`
 }
 
 // ScriptTemplate is a template subtype to enable scripting through code steps
@@ -2808,6 +3379,41 @@ type ResourceTemplate struct {
 	// 	"--validate=false"  # disable resource validation
 	// ]
 	Flags []string `json:"flags,omitempty" protobuf:"varint,7,opt,name=flags"`
+
+	// ServerSideApply uses server-side apply, rather than client-side apply, when the action is apply
+	ServerSideApply bool `json:"serverSideApply,omitempty" protobuf:"varint,9,opt,name=serverSideApply"`
+
+	// FieldManager is the name of the manager used to track field ownership with server-side apply.
+	// Defaults to "argo-workflows"
+	FieldManager string `json:"fieldManager,omitempty" protobuf:"bytes,10,opt,name=fieldManager"`
+
+	// SuccessExpression is an expression, evaluated against the live object as `resource`, which describes
+	// the conditions in which it is acceptable to proceed to the following step,
+	// e.g. `resource.status.phase == 'Running' && hasCondition('Ready', 'True')`
+	SuccessExpression string `json:"successExpression,omitempty" protobuf:"bytes,11,opt,name=successExpression"`
+
+	// FailureExpression is an expression, evaluated against the live object as `resource`, which describes
+	// the conditions in which the step is considered failed
+	FailureExpression string `json:"failureExpression,omitempty" protobuf:"bytes,12,opt,name=failureExpression"`
+
+	// WaitTimeout is the maximum time to wait for the success or failure conditions to be met, e.g. "10m".
+	// Defaults to waiting forever
+	WaitTimeout string `json:"waitTimeout,omitempty" protobuf:"bytes,13,opt,name=waitTimeout"`
+}
+
+// DefaultFieldManager is the field manager used for server-side apply if none is specified
+const DefaultFieldManager = "argo-workflows"
+
+func (r *ResourceTemplate) GetFieldManager() string {
+	if r.FieldManager != "" {
+		return r.FieldManager
+	}
+	return DefaultFieldManager
+}
+
+// HasWaitConditions returns true if the step must wait for the resource to meet a success or failure condition
+func (r *ResourceTemplate) HasWaitConditions() bool {
+	return r.SuccessCondition != "" || r.FailureCondition != "" || r.SuccessExpression != "" || r.FailureExpression != ""
 }
 
 type ManifestFrom struct {
@@ -2847,6 +3453,12 @@ func (tmpl *Template) GetType() TemplateType {
 	if tmpl.Plugin != nil {
 		return TemplateTypePlugin
 	}
+	if tmpl.SQLQuery != nil {
+		return TemplateTypeSQLQuery
+	}
+	if tmpl.Notification != nil {
+		return TemplateTypeNotification
+	}
 	return TemplateTypeUnknown
 }
 
@@ -2865,6 +3477,10 @@ func (tmpl *Template) GetNodeType() NodeType {
 		return NodeTypeSuspend
 	case TemplateTypePlugin:
 		return NodeTypePlugin
+	case TemplateTypeSQLQuery:
+		return NodeTypeSQLQuery
+	case TemplateTypeNotification:
+		return NodeTypeNotification
 	}
 	return ""
 }
@@ -2881,7 +3497,7 @@ func (tmpl *Template) IsPodType() bool {
 // IsLeaf returns whether or not the template is a leaf
 func (tmpl *Template) IsLeaf() bool {
 	switch tmpl.GetType() {
-	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData, TemplateTypeHTTP, TemplateTypePlugin:
+	case TemplateTypeContainer, TemplateTypeContainerSet, TemplateTypeScript, TemplateTypeResource, TemplateTypeData, TemplateTypeHTTP, TemplateTypePlugin, TemplateTypeSQLQuery, TemplateTypeNotification:
 		return true
 	}
 	return false
@@ -2925,7 +3541,7 @@ func (tmpl *Template) GetVolumeMounts() []apiv1.VolumeMount {
 
 // whether or not the template can and will have outputs (i.e. exit code and result)
 func (tmpl *Template) HasOutput() bool {
-	return tmpl.Container != nil || tmpl.ContainerSet.HasContainerNamed("main") || tmpl.Script != nil || tmpl.Data != nil || tmpl.HTTP != nil
+	return tmpl.Container != nil || tmpl.ContainerSet.HasContainerNamed("main") || tmpl.Script != nil || tmpl.Data != nil || tmpl.HTTP != nil || tmpl.SQLQuery != nil
 }
 
 func (t *Template) IsDaemon() bool {
@@ -2937,6 +3553,11 @@ func (tmpl *Template) SaveLogsAsArtifact() bool {
 	return tmpl != nil && tmpl.ArchiveLocation.IsArchiveLogs()
 }
 
+// if stdout and stderr should be saved as separate artifacts
+func (tmpl *Template) SaveLogStreamsAsArtifacts() bool {
+	return tmpl.SaveLogsAsArtifact() && tmpl.ArchiveLocation.IsSeparateLogStreams()
+}
+
 func (t *Template) GetRetryStrategy() (wait.Backoff, error) {
 	return t.ContainerSet.GetRetryStrategy()
 }
@@ -3063,6 +3684,77 @@ func (t *DAGTask) ShouldExpand() bool {
 type SuspendTemplate struct {
 	// Duration is the seconds to wait before automatically resuming a template
 	Duration string `json:"duration,omitempty" protobuf:"bytes,1,opt,name=duration"`
+
+	// Approval requires the node to be resumed by users in the approver groups, through the Argo Server
+	Approval *Approval `json:"approval,omitempty" protobuf:"bytes,2,opt,name=approval"`
+}
+
+// Approval describes who must approve a suspend node before it is resumed
+type Approval struct {
+	// Groups are the groups of the users that can approve the node. A user in any of them can approve it.
+	Groups []string `json:"groups" protobuf:"bytes,1,rep,name=groups"`
+
+	// MinApprovals is the number of different users that must approve the node. Defaults to 1.
+	MinApprovals int32 `json:"minApprovals,omitempty" protobuf:"varint,2,opt,name=minApprovals"`
+
+	// Expiry is the duration after which the node fails if it has not been approved, e.g. "24h"
+	Expiry string `json:"expiry,omitempty" protobuf:"bytes,3,opt,name=expiry"`
+}
+
+// GetMinApprovals returns the number of different users that must approve the node
+func (a *Approval) GetMinApprovals() int {
+	if a == nil || a.MinApprovals < 1 {
+		return 1
+	}
+	return int(a.MinApprovals)
+}
+
+// IsApprover returns whether a user in the groups can approve the node
+func (a *Approval) IsApprover(groups []string) bool {
+	for _, g := range groups {
+		if slice.ContainsString(a.Groups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeApproval is the approval required to resume a suspend node, and the users that have approved it
+type NodeApproval struct {
+	Approval `json:",inline" protobuf:"bytes,1,opt,name=approval"`
+
+	// Approvers are the users that have approved the node, in the order they approved it
+	Approvers []Approver `json:"approvers,omitempty" protobuf:"bytes,2,rep,name=approvers"`
+}
+
+// Approved returns whether the node has been approved by enough users to be resumed
+func (a *NodeApproval) Approved() bool {
+	return len(a.Approvers) >= a.GetMinApprovals()
+}
+
+// GetApprover returns the approval of the user with the subject, if they have approved the node
+func (a *NodeApproval) GetApprover(subject string) *Approver {
+	for i, approver := range a.Approvers {
+		if approver.Subject == subject {
+			return &a.Approvers[i]
+		}
+	}
+	return nil
+}
+
+// Approver is the identity of a user that approved a suspend node, as authenticated by the Argo Server
+type Approver struct {
+	// Subject is the subject of the user's claims
+	Subject string `json:"subject" protobuf:"bytes,1,opt,name=subject"`
+
+	// Email is the email of the user, if any
+	Email string `json:"email,omitempty" protobuf:"bytes,2,opt,name=email"`
+
+	// PreferredUsername is the preferred username of the user, if any
+	PreferredUsername string `json:"preferredUsername,omitempty" protobuf:"bytes,3,opt,name=preferredUsername"`
+
+	// ApprovedAt is the time the user approved the node
+	ApprovedAt metav1.Time `json:"approvedAt,omitempty" protobuf:"bytes,4,opt,name=approvedAt"`
 }
 
 // GetArtifactByName returns an input artifact by its name
@@ -3133,6 +3825,20 @@ func (out *Outputs) HasParameters() bool {
 	return out != nil && len(out.Parameters) > 0
 }
 
+// PipeArtifacts returns the output artifacts that are streamed to downstream tasks through a shared volume
+func (out *Outputs) PipeArtifacts() Artifacts {
+	if out == nil {
+		return nil
+	}
+	var arts Artifacts
+	for _, a := range out.Artifacts {
+		if a.Pipe != nil {
+			arts = append(arts, a)
+		}
+	}
+	return arts
+}
+
 const LogsSuffix = "-logs"
 
 func (out *Outputs) HasLogs() bool {
@@ -3268,7 +3974,12 @@ func resolveTemplateReference(callerScope ResourceScope, resourceName string, ca
 		if tmplRef.ClusterScope {
 			referenceScope = ResourceScopeCluster
 		}
-		return fmt.Sprintf("%s/%s/%s", referenceScope, tmplRef.Name, tmplRef.Template), true
+		name := tmplRef.Name
+		if tmplRef.Revision > 0 {
+			// different revisions of the same template may be referenced
+			name = fmt.Sprintf("%s@%d", name, tmplRef.Revision)
+		}
+		return fmt.Sprintf("%s/%s/%s", referenceScope, name, tmplRef.Template), true
 	} else if callerScope != ResourceScopeLocal {
 		// Either a WorkflowTemplate or a ClusterWorkflowTemplate is calling a template inside itself. Template storage is needed
 		return fmt.Sprintf("%s/%s/%s", callerScope, resourceName, caller.GetTemplateName()), true
@@ -3439,7 +4150,10 @@ type Histogram struct {
 	// Value is the value of the metric
 	Value string `json:"value" protobuf:"bytes,3,opt,name=value"`
 	// Buckets is a list of bucket divisors for the histogram
-	Buckets []Amount `json:"buckets" protobuf:"bytes,4,rep,name=buckets"`
+	Buckets []Amount `json:"buckets,omitempty" protobuf:"bytes,4,rep,name=buckets"`
+	// BucketsFrom computes the bucket divisors when the metric is emitted, e.g. "{{outputs.parameters.buckets}}".
+	// It must resolve to a JSON list of numbers, and is used instead of Buckets
+	BucketsFrom string `json:"bucketsFrom,omitempty" protobuf:"bytes,5,opt,name=bucketsFrom"`
 }
 
 func (in *Histogram) GetBuckets() []float64 {
@@ -3495,6 +4209,16 @@ type SemaphoreHolding struct {
 	// Holders stores the list of current holder names in the workflow.
 	// +listType=atomic
 	Holders []string `json:"holders,omitempty" protobuf:"bytes,2,opt,name=holders"`
+	// Weights stores the weight of the holders that consume more than one unit of the semaphore, by holder name.
+	Weights map[string]int32 `json:"weights,omitempty" protobuf:"bytes,3,rep,name=weights"`
+}
+
+// GetWeight returns the number of units of the semaphore the holder consumes
+func (sh SemaphoreHolding) GetWeight(holder string) int64 {
+	if weight, ok := sh.Weights[holder]; ok {
+		return int64(weight)
+	}
+	return 1
 }
 
 type SemaphoreStatus struct {
@@ -3553,6 +4277,20 @@ func (ss *SemaphoreStatus) LockAcquired(holderKey, lockKey string, currentHolder
 	return false
 }
 
+// LockWeighted records the weight of the holder of the semaphore, if it consumes more than one unit
+func (ss *SemaphoreStatus) LockWeighted(holderKey, lockKey string, weight int64) {
+	i, semaphoreHolding := ss.GetHolding(lockKey)
+	if i < 0 || weight == 1 {
+		return
+	}
+	items := strings.Split(holderKey, "/")
+	if semaphoreHolding.Weights == nil {
+		semaphoreHolding.Weights = map[string]int32{}
+	}
+	semaphoreHolding.Weights[items[len(items)-1]] = int32(weight)
+	ss.Holding[i] = semaphoreHolding
+}
+
 func (ss *SemaphoreStatus) LockReleased(holderKey, lockKey string) bool {
 	i, semaphoreHolding := ss.GetHolding(lockKey)
 	items := strings.Split(holderKey, "/")
@@ -3562,6 +4300,7 @@ func (ss *SemaphoreStatus) LockReleased(holderKey, lockKey string) bool {
 	holdingName := items[len(items)-1]
 	if i >= 0 {
 		semaphoreHolding.Holders = slice.RemoveString(semaphoreHolding.Holders, holdingName)
+		delete(semaphoreHolding.Weights, holdingName)
 		ss.Holding[i] = semaphoreHolding
 		return true
 	}
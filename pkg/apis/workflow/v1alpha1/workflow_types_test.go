@@ -787,6 +787,37 @@ func TestPodGC_GetLabelSelector(t *testing.T) {
 	})
 }
 
+func TestPodGC_GetOlderThan(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var podGC *PodGC
+		olderThan, err := podGC.GetOlderThan(corev1.PodFailed, nil)
+		assert.NoError(t, err)
+		assert.Zero(t, olderThan)
+	})
+	podGC := &PodGC{
+		OlderThan: &metav1.Duration{Duration: time.Hour},
+		Rules: []PodGCRule{
+			{Phases: []corev1.PodPhase{corev1.PodFailed}, OlderThan: &metav1.Duration{Duration: 24 * time.Hour}},
+			{LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"keep": "false"}}},
+		},
+	}
+	t.Run("Phase", func(t *testing.T) {
+		olderThan, err := podGC.GetOlderThan(corev1.PodFailed, map[string]string{"keep": "false"})
+		assert.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, olderThan)
+	})
+	t.Run("LabelSelector", func(t *testing.T) {
+		olderThan, err := podGC.GetOlderThan(corev1.PodSucceeded, map[string]string{"keep": "false"})
+		assert.NoError(t, err)
+		assert.Zero(t, olderThan)
+	})
+	t.Run("Default", func(t *testing.T) {
+		olderThan, err := podGC.GetOlderThan(corev1.PodSucceeded, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Hour, olderThan)
+	})
+}
+
 func TestNodes_FindByDisplayName(t *testing.T) {
 	assert.Nil(t, Nodes{}.FindByDisplayName(""))
 	assert.NotNil(t, Nodes{"": NodeStatus{DisplayName: "foo"}}.FindByDisplayName("foo"))
@@ -911,6 +942,16 @@ func TestShutdownStrategy_ShouldExecute(t *testing.T) {
 	assert.False(t, ShutdownStrategyTerminate.ShouldExecute(false))
 	assert.False(t, ShutdownStrategyStop.ShouldExecute(false))
 	assert.True(t, ShutdownStrategyStop.ShouldExecute(true))
+	assert.False(t, ShutdownStrategyDrain.ShouldExecute(false))
+	assert.True(t, ShutdownStrategyDrain.ShouldExecute(true))
+}
+
+func TestShutdownStrategy_ShouldTerminate(t *testing.T) {
+	assert.True(t, ShutdownStrategyTerminate.ShouldTerminate(true))
+	assert.True(t, ShutdownStrategyStop.ShouldTerminate(false))
+	assert.False(t, ShutdownStrategyStop.ShouldTerminate(true))
+	assert.False(t, ShutdownStrategyDrain.ShouldTerminate(false))
+	assert.False(t, ShutdownStrategyNone.ShouldTerminate(false))
 }
 
 func TestCronWorkflowConditions(t *testing.T) {
@@ -1619,3 +1660,16 @@ func TestGetExecSpec(t *testing.T) {
 
 	assert.Equal(t, wf.GetExecSpec().Templates[0].Name, "spec-template")
 }
+
+func TestNamespaceRestrictions_Allows(t *testing.T) {
+	var restrictions *NamespaceRestrictions
+	assert.True(t, restrictions.Allows("default"))
+	restrictions = &NamespaceRestrictions{Allow: []string{"team-*", "argo"}, Deny: []string{"team-x"}}
+	assert.True(t, restrictions.Allows("team-a"))
+	assert.True(t, restrictions.Allows("argo"))
+	assert.False(t, restrictions.Allows("team-x"), "deny takes precedence")
+	assert.False(t, restrictions.Allows("default"))
+	restrictions = &NamespaceRestrictions{Deny: []string{"default"}}
+	assert.True(t, restrictions.Allows("argo"))
+	assert.False(t, restrictions.Allows("default"))
+}
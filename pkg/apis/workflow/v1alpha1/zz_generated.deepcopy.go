@@ -31,6 +31,44 @@ func (in *Amount) DeepCopy() *Amount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approval) DeepCopyInto(out *Approval) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approval.
+func (in *Approval) DeepCopy() *Approval {
+	if in == nil {
+		return nil
+	}
+	out := new(Approval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Approver) DeepCopyInto(out *Approver) {
+	*out = *in
+	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Approver.
+func (in *Approver) DeepCopy() *Approver {
+	if in == nil {
+		return nil
+	}
+	out := new(Approver)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArchiveStrategy) DeepCopyInto(out *ArchiveStrategy) {
 	*out = *in
@@ -141,6 +179,21 @@ func (in *Artifact) DeepCopyInto(out *Artifact) {
 		*out = new(ArtifactGC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ArtifactValidation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pipe != nil {
+		in, out := &in.Pipe, &out.Pipe
+		*out = new(PipeArtifact)
+		**out = **in
+	}
+	if in.FromWorkflow != nil {
+		in, out := &in.FromWorkflow, &out.FromWorkflow
+		*out = new(ArtifactFromWorkflow)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -154,6 +207,27 @@ func (in *Artifact) DeepCopy() *Artifact {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactFromWorkflow) DeepCopyInto(out *ArtifactFromWorkflow) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactFromWorkflow.
+func (in *ArtifactFromWorkflow) DeepCopy() *ArtifactFromWorkflow {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactFromWorkflow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactGC) DeepCopyInto(out *ArtifactGC) {
 	*out = *in
@@ -162,6 +236,21 @@ func (in *ArtifactGC) DeepCopyInto(out *ArtifactGC) {
 		*out = new(Metadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DNSPolicy != nil {
+		in, out := &in.DNSPolicy, &out.DNSPolicy
+		*out = new(v1.DNSPolicy)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -274,6 +363,16 @@ func (in *ArtifactLocation) DeepCopyInto(out *ArtifactLocation) {
 		*out = new(AzureArtifact)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SeparateLogStreams != nil {
+		in, out := &in.SeparateLogStreams, &out.SeparateLogStreams
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(PluginArtifact)
+		**out = **in
+	}
 	return
 }
 
@@ -370,6 +469,16 @@ func (in *ArtifactRepository) DeepCopyInto(out *ArtifactRepository) {
 		*out = new(AzureArtifactRepository)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxBytesPerSecond != nil {
+		in, out := &in.MaxBytesPerSecond, &out.MaxBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SeparateLogStreams != nil {
+		in, out := &in.SeparateLogStreams, &out.SeparateLogStreams
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -421,6 +530,28 @@ func (in *ArtifactRepositoryRefStatus) DeepCopy() *ArtifactRepositoryRefStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactRepositoryStatus) DeepCopyInto(out *ArtifactRepositoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(Conditions, len(*in))
+		copy(*out, *in)
+	}
+	in.CheckedAt.DeepCopyInto(&out.CheckedAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactRepositoryStatus.
+func (in *ArtifactRepositoryStatus) DeepCopy() *ArtifactRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactResult) DeepCopyInto(out *ArtifactResult) {
 	*out = *in
@@ -539,6 +670,27 @@ func (in ArtifactSearchResults) DeepCopy() ArtifactSearchResults {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactValidation) DeepCopyInto(out *ArtifactValidation) {
+	*out = *in
+	if in.MinSizeBytes != nil {
+		in, out := &in.MinSizeBytes, &out.MinSizeBytes
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactValidation.
+func (in *ArtifactValidation) DeepCopy() *ArtifactValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactoryArtifact) DeepCopyInto(out *ArtifactoryArtifact) {
 	*out = *in
@@ -684,6 +836,11 @@ func (in *Backoff) DeepCopyInto(out *Backoff) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(Amount)
+		**out = **in
+	}
 	return
 }
 
@@ -770,6 +927,67 @@ func (in *ClientCertAuth) DeepCopy() *ClientCertAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWorkflowArtifactRepository) DeepCopyInto(out *ClusterWorkflowArtifactRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWorkflowArtifactRepository.
+func (in *ClusterWorkflowArtifactRepository) DeepCopy() *ClusterWorkflowArtifactRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWorkflowArtifactRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterWorkflowArtifactRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterWorkflowArtifactRepositoryList) DeepCopyInto(out *ClusterWorkflowArtifactRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterWorkflowArtifactRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterWorkflowArtifactRepositoryList.
+func (in *ClusterWorkflowArtifactRepositoryList) DeepCopy() *ClusterWorkflowArtifactRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterWorkflowArtifactRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterWorkflowArtifactRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterWorkflowTemplate) DeepCopyInto(out *ClusterWorkflowTemplate) {
 	*out = *in
@@ -888,6 +1106,22 @@ func (in Conditions) DeepCopy() Conditions {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerFileDependency) DeepCopyInto(out *ContainerFileDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFileDependency.
+func (in *ContainerFileDependency) DeepCopy() *ContainerFileDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerFileDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerNode) DeepCopyInto(out *ContainerNode) {
 	*out = *in
@@ -897,6 +1131,11 @@ func (in *ContainerNode) DeepCopyInto(out *ContainerNode) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FileDependencies != nil {
+		in, out := &in.FileDependencies, &out.FileDependencies
+		*out = make([]ContainerFileDependency, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1014,6 +1253,48 @@ func (in *CreateS3BucketOptions) DeepCopy() *CreateS3BucketOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronCatchup) DeepCopyInto(out *CronCatchup) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronCatchup.
+func (in *CronCatchup) DeepCopy() *CronCatchup {
+	if in == nil {
+		return nil
+	}
+	out := new(CronCatchup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronExclusion) DeepCopyInto(out *CronExclusion) {
+	*out = *in
+	if in.Calendar != nil {
+		in, out := &in.Calendar, &out.Calendar
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronExclusion.
+func (in *CronExclusion) DeepCopy() *CronExclusion {
+	if in == nil {
+		return nil
+	}
+	out := new(CronExclusion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CronWorkflow) DeepCopyInto(out *CronWorkflow) {
 	*out = *in
@@ -1099,6 +1380,23 @@ func (in *CronWorkflowSpec) DeepCopyInto(out *CronWorkflowSpec) {
 		*out = new(metav1.ObjectMeta)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = make([]CronExclusion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Catchup != nil {
+		in, out := &in.Catchup, &out.Catchup
+		*out = new(CronCatchup)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1233,7 +1531,9 @@ func (in *Data) DeepCopyInto(out *Data) {
 	if in.Transformation != nil {
 		in, out := &in.Transformation, &out.Transformation
 		*out = make(Transformation, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1269,6 +1569,37 @@ func (in *DataSource) DeepCopy() *DataSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotification) DeepCopyInto(out *EmailNotification) {
+	*out = *in
+	if in.UsernameSecret != nil {
+		in, out := &in.UsernameSecret, &out.UsernameSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotification.
+func (in *EmailNotification) DeepCopy() *EmailNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Event) DeepCopyInto(out *Event) {
 	*out = *in
@@ -1288,6 +1619,11 @@ func (in *Event) DeepCopy() *Event {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecutorConfig) DeepCopyInto(out *ExecutorConfig) {
 	*out = *in
+	if in.ArtifactSaveParallelism != nil {
+		in, out := &in.ArtifactSaveParallelism, &out.ArtifactSaveParallelism
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1356,6 +1692,22 @@ func (in *GCSBucket) DeepCopy() *GCSBucket {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPU) DeepCopyInto(out *GPU) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPU.
+func (in *GPU) DeepCopy() *GPU {
+	if in == nil {
+		return nil
+	}
+	out := new(GPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Gauge) DeepCopyInto(out *Gauge) {
 	*out = *in
@@ -1525,6 +1877,21 @@ func (in *HTTP) DeepCopyInto(out *HTTP) {
 		*out = new(HTTPBodySource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RetryLimit != nil {
+		in, out := &in.RetryLimit, &out.RetryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(Backoff)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1668,6 +2035,29 @@ func (in HTTPHeaders) DeepCopy() HTTPHeaders {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPNotification) DeepCopyInto(out *HTTPNotification) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(HTTPHeaders, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPNotification.
+func (in *HTTPNotification) DeepCopy() *HTTPNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Header) DeepCopyInto(out *Header) {
 	*out = *in
@@ -1745,6 +2135,22 @@ func (in *Item) DeepCopy() *Item {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinTransformation) DeepCopyInto(out *JoinTransformation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinTransformation.
+func (in *JoinTransformation) DeepCopy() *JoinTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LabelKeys) DeepCopyInto(out *LabelKeys) {
 	*out = *in
@@ -2052,6 +2458,56 @@ func (in *MutexStatus) DeepCopy() *MutexStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceRestrictions) DeepCopyInto(out *NamespaceRestrictions) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceRestrictions.
+func (in *NamespaceRestrictions) DeepCopy() *NamespaceRestrictions {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceRestrictions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeApproval) DeepCopyInto(out *NodeApproval) {
+	*out = *in
+	in.Approval.DeepCopyInto(&out.Approval)
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]Approver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeApproval.
+func (in *NodeApproval) DeepCopy() *NodeApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResult) DeepCopyInto(out *NodeResult) {
 	*out = *in
@@ -2090,6 +2546,16 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(Amount)
+		**out = **in
+	}
+	if in.EstimatedCost != nil {
+		in, out := &in.EstimatedCost, &out.EstimatedCost
+		*out = new(Amount)
+		**out = **in
+	}
 	if in.Daemoned != nil {
 		in, out := &in.Daemoned, &out.Daemoned
 		*out = new(bool)
@@ -2125,6 +2591,11 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 		*out = new(NodeSynchronizationStatus)
 		**out = **in
 	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(NodeApproval)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2192,6 +2663,37 @@ func (in *NoneStrategy) DeepCopy() *NoneStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notification) DeepCopyInto(out *Notification) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackNotification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailNotification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPNotification)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
+	if in == nil {
+		return nil
+	}
+	out := new(Notification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAuth2Auth) DeepCopyInto(out *OAuth2Auth) {
 	*out = *in
@@ -2442,6 +2944,11 @@ func (in *Parameter) DeepCopyInto(out *Parameter) {
 		*out = new(AnyString)
 		**out = **in
 	}
+	if in.Schema != nil {
+		in, out := &in.Schema, &out.Schema
+		*out = new(ParameterSchema)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2455,6 +2962,48 @@ func (in *Parameter) DeepCopy() *Parameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterSchema) DeepCopyInto(out *ParameterSchema) {
+	*out = *in
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterSchema.
+func (in *ParameterSchema) DeepCopy() *ParameterSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipeArtifact) DeepCopyInto(out *PipeArtifact) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipeArtifact.
+func (in *PipeArtifact) DeepCopy() *PipeArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(PipeArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
@@ -2472,6 +3021,22 @@ func (in *Plugin) DeepCopy() *Plugin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginArtifact) DeepCopyInto(out *PluginArtifact) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginArtifact.
+func (in *PluginArtifact) DeepCopy() *PluginArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodGC) DeepCopyInto(out *PodGC) {
 	*out = *in
@@ -2480,6 +3045,18 @@ func (in *PodGC) DeepCopyInto(out *PodGC) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OlderThan != nil {
+		in, out := &in.OlderThan, &out.OlderThan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PodGCRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2493,6 +3070,53 @@ func (in *PodGC) DeepCopy() *PodGC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodGCRule) DeepCopyInto(out *PodGCRule) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]v1.PodPhase, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OlderThan != nil {
+		in, out := &in.OlderThan, &out.OlderThan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodGCRule.
+func (in *PodGCRule) DeepCopy() *PodGCRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PodGCRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodLimit) DeepCopyInto(out *PodLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLimit.
+func (in *PodLimit) DeepCopy() *PodLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(PodLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Prometheus) DeepCopyInto(out *Prometheus) {
 	*out = *in
@@ -2530,23 +3154,39 @@ func (in *Prometheus) DeepCopy() *Prometheus {
 	if in == nil {
 		return nil
 	}
-	out := new(Prometheus)
+	out := new(Prometheus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawArtifact) DeepCopyInto(out *RawArtifact) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawArtifact.
+func (in *RawArtifact) DeepCopy() *RawArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(RawArtifact)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RawArtifact) DeepCopyInto(out *RawArtifact) {
+func (in *ReduceTransformation) DeepCopyInto(out *ReduceTransformation) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawArtifact.
-func (in *RawArtifact) DeepCopy() *RawArtifact {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReduceTransformation.
+func (in *ReduceTransformation) DeepCopy() *ReduceTransformation {
 	if in == nil {
 		return nil
 	}
-	out := new(RawArtifact)
+	out := new(ReduceTransformation)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2763,6 +3403,42 @@ func (in *S3EncryptionOptions) DeepCopy() *S3EncryptionOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLQuery) DeepCopyInto(out *SQLQuery) {
+	*out = *in
+	if in.ConnectionSecret != nil {
+		in, out := &in.ConnectionSecret, &out.ConnectionSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxRows != nil {
+		in, out := &in.MaxRows, &out.MaxRows
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLQuery.
+func (in *SQLQuery) DeepCopy() *SQLQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScriptTemplate) DeepCopyInto(out *ScriptTemplate) {
 	*out = *in
@@ -2788,6 +3464,13 @@ func (in *SemaphoreHolding) DeepCopyInto(out *SemaphoreHolding) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -2883,6 +3566,59 @@ func (in *Sequence) DeepCopy() *Sequence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMesh) DeepCopyInto(out *ServiceMesh) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMesh.
+func (in *ServiceMesh) DeepCopy() *ServiceMesh {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMesh)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackNotification) DeepCopyInto(out *SlackNotification) {
+	*out = *in
+	if in.WebhookURLSecret != nil {
+		in, out := &in.WebhookURLSecret, &out.WebhookURLSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackNotification.
+func (in *SlackNotification) DeepCopy() *SlackNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SortTransformation) DeepCopyInto(out *SortTransformation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SortTransformation.
+func (in *SortTransformation) DeepCopy() *SortTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(SortTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Submit) DeepCopyInto(out *Submit) {
 	*out = *in
@@ -2956,6 +3692,11 @@ func (in *SuppliedValueFrom) DeepCopy() *SuppliedValueFrom {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SuspendTemplate) DeepCopyInto(out *SuspendTemplate) {
 	*out = *in
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(Approval)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3131,7 +3872,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	if in.Suspend != nil {
 		in, out := &in.Suspend, &out.Suspend
 		*out = new(SuspendTemplate)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Data != nil {
 		in, out := &in.Data, &out.Data
@@ -3148,6 +3889,16 @@ func (in *Template) DeepCopyInto(out *Template) {
 		*out = new(Plugin)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SQLQuery != nil {
+		in, out := &in.SQLQuery, &out.SQLQuery
+		*out = new(SQLQuery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notification != nil {
+		in, out := &in.Notification, &out.Notification
+		*out = new(Notification)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]v1.Volume, len(*in))
@@ -3214,7 +3965,7 @@ func (in *Template) DeepCopyInto(out *Template) {
 	if in.Executor != nil {
 		in, out := &in.Executor, &out.Executor
 		*out = new(ExecutorConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.HostAliases != nil {
 		in, out := &in.HostAliases, &out.HostAliases
@@ -3243,6 +3994,36 @@ func (in *Template) DeepCopyInto(out *Template) {
 		*out = new(Memoize)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPU)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(v1beta1.PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisruptionSensitive != nil {
+		in, out := &in.DisruptionSensitive, &out.DisruptionSensitive
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RunAsUserOverride != nil {
+		in, out := &in.RunAsUserOverride, &out.RunAsUserOverride
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(TemplateResources)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3256,6 +4037,27 @@ func (in *Template) DeepCopy() *Template {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateImport) DeepCopyInto(out *TemplateImport) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateImport.
+func (in *TemplateImport) DeepCopy() *TemplateImport {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateRef) DeepCopyInto(out *TemplateRef) {
 	*out = *in
@@ -3272,12 +4074,44 @@ func (in *TemplateRef) DeepCopy() *TemplateRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateResources) DeepCopyInto(out *TemplateResources) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(map[v1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = make(map[v1.ResourceName]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateResources.
+func (in *TemplateResources) DeepCopy() *TemplateResources {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Transformation) DeepCopyInto(out *Transformation) {
 	{
 		in := &in
 		*out = make(Transformation, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -3295,6 +4129,21 @@ func (in Transformation) DeepCopy() Transformation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransformationStep) DeepCopyInto(out *TransformationStep) {
 	*out = *in
+	if in.Sort != nil {
+		in, out := &in.Sort, &out.Sort
+		*out = new(SortTransformation)
+		**out = **in
+	}
+	if in.Reduce != nil {
+		in, out := &in.Reduce, &out.Reduce
+		*out = new(ReduceTransformation)
+		**out = **in
+	}
+	if in.Join != nil {
+		in, out := &in.Join, &out.Join
+		*out = new(JoinTransformation)
+		**out = **in
+	}
 	return
 }
 
@@ -3317,6 +4166,11 @@ func (in *UserContainer) DeepCopyInto(out *UserContainer) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CaptureOutputs != nil {
+		in, out := &in.CaptureOutputs, &out.CaptureOutputs
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -3380,6 +4234,11 @@ func (in *Version) DeepCopy() *Version {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeClaimGC) DeepCopyInto(out *VolumeClaimGC) {
 	*out = *in
+	if in.OlderThan != nil {
+		in, out := &in.OlderThan, &out.OlderThan
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -3482,6 +4341,67 @@ func (in *WorkflowArtifactGCTaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowArtifactRepository) DeepCopyInto(out *WorkflowArtifactRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowArtifactRepository.
+func (in *WorkflowArtifactRepository) DeepCopy() *WorkflowArtifactRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowArtifactRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowArtifactRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowArtifactRepositoryList) DeepCopyInto(out *WorkflowArtifactRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkflowArtifactRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowArtifactRepositoryList.
+func (in *WorkflowArtifactRepositoryList) DeepCopy() *WorkflowArtifactRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowArtifactRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkflowArtifactRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowEventBinding) DeepCopyInto(out *WorkflowEventBinding) {
 	*out = *in
@@ -3551,6 +4471,13 @@ func (in *WorkflowEventBindingSpec) DeepCopyInto(out *WorkflowEventBindingSpec)
 		*out = new(Submit)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Submits != nil {
+		in, out := &in.Submits, &out.Submits
+		*out = make([]Submit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -3634,6 +4561,28 @@ func (in *WorkflowMetadata) DeepCopy() *WorkflowMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkflowNotification) DeepCopyInto(out *WorkflowNotification) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]WorkflowNotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	in.Notification.DeepCopyInto(&out.Notification)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowNotification.
+func (in *WorkflowNotification) DeepCopy() *WorkflowNotification {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkflowNotification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	*out = *in
@@ -3653,7 +4602,7 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	if in.Executor != nil {
 		in, out := &in.Executor, &out.Executor
 		*out = new(ExecutorConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
@@ -3783,7 +4732,7 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 	if in.VolumeClaimGC != nil {
 		in, out := &in.VolumeClaimGC, &out.VolumeClaimGC
 		*out = new(VolumeClaimGC)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RetryStrategy != nil {
 		in, out := &in.RetryStrategy, &out.RetryStrategy
@@ -3822,6 +4771,47 @@ func (in *WorkflowSpec) DeepCopyInto(out *WorkflowSpec) {
 		*out = new(ArtifactGC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodEnv != nil {
+		in, out := &in.PodEnv, &out.PodEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TemplateImports != nil {
+		in, out := &in.TemplateImports, &out.TemplateImports
+		*out = make([]TemplateImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NamespaceRestrictions != nil {
+		in, out := &in.NamespaceRestrictions, &out.NamespaceRestrictions
+		*out = new(NamespaceRestrictions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RescheduleOnNodePreemption != nil {
+		in, out := &in.RescheduleOnNodePreemption, &out.RescheduleOnNodePreemption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]WorkflowNotification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceMesh != nil {
+		in, out := &in.ServiceMesh, &out.ServiceMesh
+		*out = new(ServiceMesh)
+		**out = **in
+	}
+	if in.PodLimit != nil {
+		in, out := &in.PodLimit, &out.PodLimit
+		*out = new(PodLimit)
+		**out = **in
+	}
 	return
 }
 
@@ -3878,6 +4868,16 @@ func (in *WorkflowStatus) DeepCopyInto(out *WorkflowStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(Amount)
+		**out = **in
+	}
+	if in.EstimatedCost != nil {
+		in, out := &in.EstimatedCost, &out.EstimatedCost
+		*out = new(Amount)
+		**out = **in
+	}
 	if in.StoredWorkflowSpec != nil {
 		in, out := &in.StoredWorkflowSpec, &out.StoredWorkflowSpec
 		*out = new(WorkflowSpec)
@@ -0,0 +1,168 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	scheme "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterWorkflowArtifactRepositoriesGetter has a method to return a ClusterWorkflowArtifactRepositoryInterface.
+// A group's client should implement this interface.
+type ClusterWorkflowArtifactRepositoriesGetter interface {
+	ClusterWorkflowArtifactRepositories() ClusterWorkflowArtifactRepositoryInterface
+}
+
+// ClusterWorkflowArtifactRepositoryInterface has methods to work with ClusterWorkflowArtifactRepository resources.
+type ClusterWorkflowArtifactRepositoryInterface interface {
+	Create(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.CreateOptions) (*v1alpha1.ClusterWorkflowArtifactRepository, error)
+	Update(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.UpdateOptions) (*v1alpha1.ClusterWorkflowArtifactRepository, error)
+	UpdateStatus(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.UpdateOptions) (*v1alpha1.ClusterWorkflowArtifactRepository, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterWorkflowArtifactRepository, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterWorkflowArtifactRepositoryList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error)
+	ClusterWorkflowArtifactRepositoryExpansion
+}
+
+// clusterWorkflowArtifactRepositories implements ClusterWorkflowArtifactRepositoryInterface
+type clusterWorkflowArtifactRepositories struct {
+	client rest.Interface
+}
+
+// newClusterWorkflowArtifactRepositories returns a ClusterWorkflowArtifactRepositories
+func newClusterWorkflowArtifactRepositories(c *ArgoprojV1alpha1Client) *clusterWorkflowArtifactRepositories {
+	return &clusterWorkflowArtifactRepositories{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterWorkflowArtifactRepository, and returns the corresponding clusterWorkflowArtifactRepository object, and an error if there is any.
+func (c *clusterWorkflowArtifactRepositories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	result = &v1alpha1.ClusterWorkflowArtifactRepository{}
+	err = c.client.Get().
+		Resource("clusterworkflowartifactrepositories").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterWorkflowArtifactRepositories that match those selectors.
+func (c *clusterWorkflowArtifactRepositories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterWorkflowArtifactRepositoryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterWorkflowArtifactRepositoryList{}
+	err = c.client.Get().
+		Resource("clusterworkflowartifactrepositories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterWorkflowArtifactRepositories.
+func (c *clusterWorkflowArtifactRepositories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clusterworkflowartifactrepositories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterWorkflowArtifactRepository and creates it.  Returns the server's representation of the clusterWorkflowArtifactRepository, and an error, if there is any.
+func (c *clusterWorkflowArtifactRepositories) Create(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.CreateOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	result = &v1alpha1.ClusterWorkflowArtifactRepository{}
+	err = c.client.Post().
+		Resource("clusterworkflowartifactrepositories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterWorkflowArtifactRepository).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterWorkflowArtifactRepository and updates it. Returns the server's representation of the clusterWorkflowArtifactRepository, and an error, if there is any.
+func (c *clusterWorkflowArtifactRepositories) Update(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.UpdateOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	result = &v1alpha1.ClusterWorkflowArtifactRepository{}
+	err = c.client.Put().
+		Resource("clusterworkflowartifactrepositories").
+		Name(clusterWorkflowArtifactRepository.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterWorkflowArtifactRepository).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *clusterWorkflowArtifactRepositories) UpdateStatus(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.UpdateOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	result = &v1alpha1.ClusterWorkflowArtifactRepository{}
+	err = c.client.Put().
+		Resource("clusterworkflowartifactrepositories").
+		Name(clusterWorkflowArtifactRepository.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterWorkflowArtifactRepository).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterWorkflowArtifactRepository and deletes it. Returns an error if one occurs.
+func (c *clusterWorkflowArtifactRepositories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clusterworkflowartifactrepositories").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterWorkflowArtifactRepositories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clusterworkflowartifactrepositories").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterWorkflowArtifactRepository.
+func (c *clusterWorkflowArtifactRepositories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	result = &v1alpha1.ClusterWorkflowArtifactRepository{}
+	err = c.client.Patch(pt).
+		Resource("clusterworkflowartifactrepositories").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,117 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterWorkflowArtifactRepositories implements ClusterWorkflowArtifactRepositoryInterface
+type FakeClusterWorkflowArtifactRepositories struct {
+	Fake *FakeArgoprojV1alpha1
+}
+
+var clusterworkflowartifactrepositoriesResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "clusterworkflowartifactrepositories"}
+
+var clusterworkflowartifactrepositoriesKind = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "ClusterWorkflowArtifactRepository"}
+
+// Get takes name of the clusterWorkflowArtifactRepository, and returns the corresponding clusterWorkflowArtifactRepository object, and an error if there is any.
+func (c *FakeClusterWorkflowArtifactRepositories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clusterworkflowartifactrepositoriesResource, name), &v1alpha1.ClusterWorkflowArtifactRepository{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowArtifactRepository), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterWorkflowArtifactRepositories that match those selectors.
+func (c *FakeClusterWorkflowArtifactRepositories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterWorkflowArtifactRepositoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clusterworkflowartifactrepositoriesResource, clusterworkflowartifactrepositoriesKind, opts), &v1alpha1.ClusterWorkflowArtifactRepositoryList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterWorkflowArtifactRepositoryList{ListMeta: obj.(*v1alpha1.ClusterWorkflowArtifactRepositoryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterWorkflowArtifactRepositoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterWorkflowArtifactRepositories.
+func (c *FakeClusterWorkflowArtifactRepositories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clusterworkflowartifactrepositoriesResource, opts))
+}
+
+// Create takes the representation of a clusterWorkflowArtifactRepository and creates it.  Returns the server's representation of the clusterWorkflowArtifactRepository, and an error, if there is any.
+func (c *FakeClusterWorkflowArtifactRepositories) Create(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.CreateOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clusterworkflowartifactrepositoriesResource, clusterWorkflowArtifactRepository), &v1alpha1.ClusterWorkflowArtifactRepository{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowArtifactRepository), err
+}
+
+// Update takes the representation of a clusterWorkflowArtifactRepository and updates it. Returns the server's representation of the clusterWorkflowArtifactRepository, and an error, if there is any.
+func (c *FakeClusterWorkflowArtifactRepositories) Update(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.UpdateOptions) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clusterworkflowartifactrepositoriesResource, clusterWorkflowArtifactRepository), &v1alpha1.ClusterWorkflowArtifactRepository{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowArtifactRepository), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterWorkflowArtifactRepositories) UpdateStatus(ctx context.Context, clusterWorkflowArtifactRepository *v1alpha1.ClusterWorkflowArtifactRepository, opts v1.UpdateOptions) (*v1alpha1.ClusterWorkflowArtifactRepository, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(clusterworkflowartifactrepositoriesResource, "status", clusterWorkflowArtifactRepository), &v1alpha1.ClusterWorkflowArtifactRepository{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowArtifactRepository), err
+}
+
+// Delete takes name of the clusterWorkflowArtifactRepository and deletes it. Returns an error if one occurs.
+func (c *FakeClusterWorkflowArtifactRepositories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clusterworkflowartifactrepositoriesResource, name), &v1alpha1.ClusterWorkflowArtifactRepository{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterWorkflowArtifactRepositories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clusterworkflowartifactrepositoriesResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterWorkflowArtifactRepositoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterWorkflowArtifactRepository.
+func (c *FakeClusterWorkflowArtifactRepositories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clusterworkflowartifactrepositoriesResource, name, pt, data, subresources...), &v1alpha1.ClusterWorkflowArtifactRepository{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterWorkflowArtifactRepository), err
+}
@@ -12,6 +12,10 @@ type FakeArgoprojV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeArgoprojV1alpha1) ClusterWorkflowArtifactRepositories() v1alpha1.ClusterWorkflowArtifactRepositoryInterface {
+	return &FakeClusterWorkflowArtifactRepositories{c}
+}
+
 func (c *FakeArgoprojV1alpha1) ClusterWorkflowTemplates() v1alpha1.ClusterWorkflowTemplateInterface {
 	return &FakeClusterWorkflowTemplates{c}
 }
@@ -28,6 +32,10 @@ func (c *FakeArgoprojV1alpha1) WorkflowArtifactGCTasks(namespace string) v1alpha
 	return &FakeWorkflowArtifactGCTasks{c, namespace}
 }
 
+func (c *FakeArgoprojV1alpha1) WorkflowArtifactRepositories(namespace string) v1alpha1.WorkflowArtifactRepositoryInterface {
+	return &FakeWorkflowArtifactRepositories{c, namespace}
+}
+
 func (c *FakeArgoprojV1alpha1) WorkflowEventBindings(namespace string) v1alpha1.WorkflowEventBindingInterface {
 	return &FakeWorkflowEventBindings{c, namespace}
 }
@@ -2,6 +2,8 @@
 
 package v1alpha1
 
+type ClusterWorkflowArtifactRepositoryExpansion interface{}
+
 type ClusterWorkflowTemplateExpansion interface{}
 
 type CronWorkflowExpansion interface{}
@@ -10,6 +12,8 @@ type WorkflowExpansion interface{}
 
 type WorkflowArtifactGCTaskExpansion interface{}
 
+type WorkflowArtifactRepositoryExpansion interface{}
+
 type WorkflowEventBindingExpansion interface{}
 
 type WorkflowTaskResultExpansion interface{}
@@ -10,10 +10,12 @@ import (
 
 type ArgoprojV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ClusterWorkflowArtifactRepositoriesGetter
 	ClusterWorkflowTemplatesGetter
 	CronWorkflowsGetter
 	WorkflowsGetter
 	WorkflowArtifactGCTasksGetter
+	WorkflowArtifactRepositoriesGetter
 	WorkflowEventBindingsGetter
 	WorkflowTaskResultsGetter
 	WorkflowTaskSetsGetter
@@ -25,6 +27,10 @@ type ArgoprojV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ArgoprojV1alpha1Client) ClusterWorkflowArtifactRepositories() ClusterWorkflowArtifactRepositoryInterface {
+	return newClusterWorkflowArtifactRepositories(c)
+}
+
 func (c *ArgoprojV1alpha1Client) ClusterWorkflowTemplates() ClusterWorkflowTemplateInterface {
 	return newClusterWorkflowTemplates(c)
 }
@@ -41,6 +47,10 @@ func (c *ArgoprojV1alpha1Client) WorkflowArtifactGCTasks(namespace string) Workf
 	return newWorkflowArtifactGCTasks(c, namespace)
 }
 
+func (c *ArgoprojV1alpha1Client) WorkflowArtifactRepositories(namespace string) WorkflowArtifactRepositoryInterface {
+	return newWorkflowArtifactRepositories(c, namespace)
+}
+
 func (c *ArgoprojV1alpha1Client) WorkflowEventBindings(namespace string) WorkflowEventBindingInterface {
 	return newWorkflowEventBindings(c, namespace)
 }
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	workflowv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	versioned "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/listers/workflow/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterWorkflowArtifactRepositoryInformer provides access to a shared informer and lister for
+// ClusterWorkflowArtifactRepositories.
+type ClusterWorkflowArtifactRepositoryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterWorkflowArtifactRepositoryLister
+}
+
+type clusterWorkflowArtifactRepositoryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterWorkflowArtifactRepositoryInformer constructs a new informer for ClusterWorkflowArtifactRepository type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterWorkflowArtifactRepositoryInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterWorkflowArtifactRepositoryInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterWorkflowArtifactRepositoryInformer constructs a new informer for ClusterWorkflowArtifactRepository type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterWorkflowArtifactRepositoryInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ArgoprojV1alpha1().ClusterWorkflowArtifactRepositories().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.ArgoprojV1alpha1().ClusterWorkflowArtifactRepositories().Watch(context.TODO(), options)
+			},
+		},
+		&workflowv1alpha1.ClusterWorkflowArtifactRepository{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterWorkflowArtifactRepositoryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterWorkflowArtifactRepositoryInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterWorkflowArtifactRepositoryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&workflowv1alpha1.ClusterWorkflowArtifactRepository{}, f.defaultInformer)
+}
+
+func (f *clusterWorkflowArtifactRepositoryInformer) Lister() v1alpha1.ClusterWorkflowArtifactRepositoryLister {
+	return v1alpha1.NewClusterWorkflowArtifactRepositoryLister(f.Informer().GetIndexer())
+}
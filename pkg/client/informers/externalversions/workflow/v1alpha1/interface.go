@@ -8,6 +8,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterWorkflowArtifactRepositories returns a ClusterWorkflowArtifactRepositoryInformer.
+	ClusterWorkflowArtifactRepositories() ClusterWorkflowArtifactRepositoryInformer
 	// ClusterWorkflowTemplates returns a ClusterWorkflowTemplateInformer.
 	ClusterWorkflowTemplates() ClusterWorkflowTemplateInformer
 	// CronWorkflows returns a CronWorkflowInformer.
@@ -16,6 +18,8 @@ type Interface interface {
 	Workflows() WorkflowInformer
 	// WorkflowArtifactGCTasks returns a WorkflowArtifactGCTaskInformer.
 	WorkflowArtifactGCTasks() WorkflowArtifactGCTaskInformer
+	// WorkflowArtifactRepositories returns a WorkflowArtifactRepositoryInformer.
+	WorkflowArtifactRepositories() WorkflowArtifactRepositoryInformer
 	// WorkflowEventBindings returns a WorkflowEventBindingInformer.
 	WorkflowEventBindings() WorkflowEventBindingInformer
 	// WorkflowTaskResults returns a WorkflowTaskResultInformer.
@@ -37,6 +41,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterWorkflowArtifactRepositories returns a ClusterWorkflowArtifactRepositoryInformer.
+func (v *version) ClusterWorkflowArtifactRepositories() ClusterWorkflowArtifactRepositoryInformer {
+	return &clusterWorkflowArtifactRepositoryInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterWorkflowTemplates returns a ClusterWorkflowTemplateInformer.
 func (v *version) ClusterWorkflowTemplates() ClusterWorkflowTemplateInformer {
 	return &clusterWorkflowTemplateInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -57,6 +66,11 @@ func (v *version) WorkflowArtifactGCTasks() WorkflowArtifactGCTaskInformer {
 	return &workflowArtifactGCTaskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// WorkflowArtifactRepositories returns a WorkflowArtifactRepositoryInformer.
+func (v *version) WorkflowArtifactRepositories() WorkflowArtifactRepositoryInformer {
+	return &workflowArtifactRepositoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // WorkflowEventBindings returns a WorkflowEventBindingInformer.
 func (v *version) WorkflowEventBindings() WorkflowEventBindingInformer {
 	return &workflowEventBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
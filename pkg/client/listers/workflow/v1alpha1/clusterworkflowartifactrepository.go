@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterWorkflowArtifactRepositoryLister helps list ClusterWorkflowArtifactRepositories.
+// All objects returned here must be treated as read-only.
+type ClusterWorkflowArtifactRepositoryLister interface {
+	// List lists all ClusterWorkflowArtifactRepositories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ClusterWorkflowArtifactRepository, err error)
+	// Get retrieves the ClusterWorkflowArtifactRepository from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ClusterWorkflowArtifactRepository, error)
+	ClusterWorkflowArtifactRepositoryListerExpansion
+}
+
+// clusterWorkflowArtifactRepositoryLister implements the ClusterWorkflowArtifactRepositoryLister interface.
+type clusterWorkflowArtifactRepositoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterWorkflowArtifactRepositoryLister returns a new ClusterWorkflowArtifactRepositoryLister.
+func NewClusterWorkflowArtifactRepositoryLister(indexer cache.Indexer) ClusterWorkflowArtifactRepositoryLister {
+	return &clusterWorkflowArtifactRepositoryLister{indexer: indexer}
+}
+
+// List lists all ClusterWorkflowArtifactRepositories in the indexer.
+func (s *clusterWorkflowArtifactRepositoryLister) List(selector labels.Selector) (ret []*v1alpha1.ClusterWorkflowArtifactRepository, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ClusterWorkflowArtifactRepository))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterWorkflowArtifactRepository from the index for a given name.
+func (s *clusterWorkflowArtifactRepositoryLister) Get(name string) (*v1alpha1.ClusterWorkflowArtifactRepository, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("clusterworkflowartifactrepository"), name)
+	}
+	return obj.(*v1alpha1.ClusterWorkflowArtifactRepository), nil
+}
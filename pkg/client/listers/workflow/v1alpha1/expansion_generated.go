@@ -2,6 +2,10 @@
 
 package v1alpha1
 
+// ClusterWorkflowArtifactRepositoryListerExpansion allows custom methods to be added to
+// ClusterWorkflowArtifactRepositoryLister.
+type ClusterWorkflowArtifactRepositoryListerExpansion interface{}
+
 // ClusterWorkflowTemplateListerExpansion allows custom methods to be added to
 // ClusterWorkflowTemplateLister.
 type ClusterWorkflowTemplateListerExpansion interface{}
@@ -30,6 +34,14 @@ type WorkflowArtifactGCTaskListerExpansion interface{}
 // WorkflowArtifactGCTaskNamespaceLister.
 type WorkflowArtifactGCTaskNamespaceListerExpansion interface{}
 
+// WorkflowArtifactRepositoryListerExpansion allows custom methods to be added to
+// WorkflowArtifactRepositoryLister.
+type WorkflowArtifactRepositoryListerExpansion interface{}
+
+// WorkflowArtifactRepositoryNamespaceListerExpansion allows custom methods to be added to
+// WorkflowArtifactRepositoryNamespaceLister.
+type WorkflowArtifactRepositoryNamespaceListerExpansion interface{}
+
 // WorkflowEventBindingListerExpansion allows custom methods to be added to
 // WorkflowEventBindingLister.
 type WorkflowEventBindingListerExpansion interface{}
@@ -0,0 +1,72 @@
+// Package artifact is the API for an artifact driver plugin, which loads, saves and deletes artifacts with a
+// `plugin` location for the executor. Artifacts are exchanged as single files in a directory shared with the plugin,
+// directories are archived by the executor before they are saved and extracted after they are loaded.
+package artifact
+
+import (
+	"context"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type LoadArtifactArgs struct {
+	// Required: true
+	Artifact *wfv1.Artifact `json:"artifact"`
+	// Path of the file to load the artifact into
+	// Required: true
+	Path string `json:"path"`
+}
+
+type LoadArtifactReply struct {
+	// NotFound is whether the artifact does not exist, rather than the artifact failing to load
+	NotFound bool `json:"notFound,omitempty"`
+}
+
+type SaveArtifactArgs struct {
+	// Path of the file to save as the artifact
+	// Required: true
+	Path string `json:"path"`
+	// Required: true
+	Artifact *wfv1.Artifact `json:"artifact"`
+}
+
+type SaveArtifactReply struct{}
+
+type DeleteArtifactArgs struct {
+	// Required: true
+	Artifact *wfv1.Artifact `json:"artifact"`
+}
+
+type DeleteArtifactReply struct{}
+
+type ListObjectsArgs struct {
+	// Required: true
+	Artifact *wfv1.Artifact `json:"artifact"`
+}
+
+type ListObjectsReply struct {
+	// Objects are the keys of the objects under the artifact's key
+	Objects []string `json:"objects,omitempty"`
+}
+
+type IsDirectoryArgs struct {
+	// Required: true
+	Artifact *wfv1.Artifact `json:"artifact"`
+}
+
+type IsDirectoryReply struct {
+	IsDirectory bool `json:"isDirectory,omitempty"`
+}
+
+type ArtifactDriver interface {
+	// LoadArtifact is called as "artifact.load"
+	LoadArtifact(ctx context.Context, args LoadArtifactArgs, reply *LoadArtifactReply) error
+	// SaveArtifact is called as "artifact.save"
+	SaveArtifact(ctx context.Context, args SaveArtifactArgs, reply *SaveArtifactReply) error
+	// DeleteArtifact is called as "artifact.delete"
+	DeleteArtifact(ctx context.Context, args DeleteArtifactArgs, reply *DeleteArtifactReply) error
+	// ListObjects is called as "artifact.listObjects"
+	ListObjects(ctx context.Context, args ListObjectsArgs, reply *ListObjectsReply) error
+	// IsDirectory is called as "artifact.isDirectory"
+	IsDirectory(ctx context.Context, args IsDirectoryArgs, reply *IsDirectoryReply) error
+}
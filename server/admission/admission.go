@@ -0,0 +1,280 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/antonmedv/expr"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/expr/argoexpr"
+	jsonutil "github.com/argoproj/argo-workflows/v3/util/json"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// Interface admits workflows before they are created
+type Interface interface {
+	// Admit returns the workflow to create, which webhooks may have mutated, or a PermissionDenied error if it is
+	// rejected
+	Admit(ctx context.Context, wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) (*wfv1.Workflow, error)
+	// Check returns a PermissionDenied error if a workflow that has already been created is rejected. The controller
+	// checks every workflow before it runs it, as workflows may be created without the Argo Server, e.g. by kubectl,
+	// cron workflows or event bindings. Webhooks cannot mutate workflows that have already been created.
+	Check(ctx context.Context, wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) error
+}
+
+// Null admits all workflows unchanged
+var Null Interface = &admission{}
+
+// Request is posted to webhooks
+type Request struct {
+	// Workflow is the workflow that will be created
+	Workflow *wfv1.Workflow `json:"workflow"`
+	// RenderedWorkflow is the workflow with the spec of the template it references merged, e.g. to check its images
+	RenderedWorkflow *wfv1.Workflow `json:"renderedWorkflow"`
+}
+
+// Response is what webhooks respond with
+type Response struct {
+	Allowed bool `json:"allowed"`
+	// Message explains why the workflow was rejected
+	Message string `json:"message,omitempty"`
+	// Workflow, if set, replaces the workflow that will be created
+	Workflow *wfv1.Workflow `json:"workflow,omitempty"`
+}
+
+type admission struct {
+	policies []config.AdmissionPolicy
+	webhooks []webhook
+}
+
+type webhook struct {
+	config.AdmissionWebhook
+	client *http.Client
+}
+
+// New returns the admission for the config, which admits all workflows if there is none
+func New(c *config.AdmissionConfig) (Interface, error) {
+	if c == nil {
+		return Null, nil
+	}
+	a := &admission{policies: c.Policies}
+	for _, p := range c.Policies {
+		if p.Name == "" {
+			return nil, fmt.Errorf("admission policies must have a name")
+		}
+		if _, err := expr.Compile(p.Expression); err != nil {
+			return nil, fmt.Errorf("admission policy %q has an invalid expression: %w", p.Name, err)
+		}
+	}
+	for _, w := range c.Webhooks {
+		if w.Name == "" || w.URL == "" {
+			return nil, fmt.Errorf("admission webhooks must have a name and url")
+		}
+		switch w.FailurePolicy {
+		case "", config.AdmissionFailurePolicyFail, config.AdmissionFailurePolicyIgnore:
+		default:
+			return nil, fmt.Errorf("admission webhook %q has invalid failure policy %q, must be one of: %s, %s", w.Name, w.FailurePolicy, config.AdmissionFailurePolicyFail, config.AdmissionFailurePolicyIgnore)
+		}
+		a.webhooks = append(a.webhooks, webhook{AdmissionWebhook: w, client: &http.Client{Timeout: w.GetTimeout()}})
+	}
+	return a, nil
+}
+
+func (a *admission) Admit(ctx context.Context, wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) (*wfv1.Workflow, error) {
+	return a.admit(ctx, wf, wftmplGetter, cwftmplGetter, true)
+}
+
+func (a *admission) Check(ctx context.Context, wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) error {
+	_, err := a.admit(ctx, wf, wftmplGetter, cwftmplGetter, false)
+	return err
+}
+
+func (a *admission) admit(ctx context.Context, wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, mutate bool) (*wfv1.Workflow, error) {
+	if len(a.policies) == 0 && len(a.webhooks) == 0 {
+		return wf, nil
+	}
+	rendered, err := render(wf, wftmplGetter, cwftmplGetter)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.policies) > 0 {
+		m, err := jsonutil.Jsonify(rendered)
+		if err != nil {
+			return nil, err
+		}
+		env := map[string]interface{}{"workflow": m}
+		for _, p := range a.policies {
+			ok, err := argoexpr.EvalBool(p.Expression, env)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate admission policy %q: %w", p.Name, err)
+			}
+			if !ok {
+				return nil, rejected(p.Name, p.Message)
+			}
+		}
+	}
+	for _, w := range a.webhooks {
+		resp, err := w.call(ctx, Request{Workflow: wf, RenderedWorkflow: rendered})
+		if err != nil {
+			if w.FailurePolicy == config.AdmissionFailurePolicyIgnore {
+				log.WithError(err).WithField("webhook", w.Name).Warn("failed to call admission webhook, ignoring")
+				continue
+			}
+			return nil, status.Errorf(codes.Unavailable, "failed to call admission webhook %q: %v", w.Name, err)
+		}
+		if !resp.Allowed {
+			return nil, rejected(w.Name, resp.Message)
+		}
+		if mutate && resp.Workflow != nil {
+			if resp.Workflow.Namespace != wf.Namespace {
+				return nil, fmt.Errorf("admission webhook %q must not change the namespace of the workflow", w.Name)
+			}
+			wf = resp.Workflow
+			if rendered, err = render(wf, wftmplGetter, cwftmplGetter); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return wf, nil
+}
+
+func rejected(name, message string) error {
+	if message == "" {
+		return status.Errorf(codes.PermissionDenied, "workflow rejected by admission %q", name)
+	}
+	return status.Errorf(codes.PermissionDenied, "workflow rejected by admission %q: %s", name, message)
+}
+
+// render returns the workflow with the spec of the workflow template it references merged, and the templates that
+// its steps, DAG tasks and hooks reference appended to its templates, so that policies and webhooks can check them
+func render(wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) (*wfv1.Workflow, error) {
+	rendered := wf.DeepCopy()
+	if ref := wf.Spec.WorkflowTemplateRef; ref != nil {
+		var tmpl wfv1.WorkflowSpecHolder
+		var err error
+		if ref.ClusterScope {
+			tmpl, err = templateresolution.GetClusterWorkflowTemplate(cwftmplGetter, ref.Name, ref.Revision)
+		} else {
+			tmpl, err = templateresolution.GetWorkflowTemplate(wftmplGetter, ref.Name, ref.Revision)
+		}
+		if err != nil {
+			return nil, err
+		}
+		joined, err := util.JoinWorkflowSpec(&wf.Spec, tmpl.GetWorkflowSpec(), nil)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Spec = joined.Spec
+	}
+	// the context does not store the templates it resolves, as the workflow is nil
+	tmplCtx := templateresolution.NewContext(wftmplGetter, cwftmplGetter, rendered, nil)
+	r := &renderer{seen: map[string]bool{}}
+	for _, tmpl := range rendered.Spec.Templates {
+		r.seen[tmplCtx.GetTemplateScope()+"/"+tmpl.Name] = true
+	}
+	if err := r.resolveHooks(tmplCtx, rendered.Spec.Hooks); err != nil {
+		return nil, err
+	}
+	for i := range rendered.Spec.Templates {
+		if err := r.resolve(tmplCtx, &rendered.Spec.Templates[i]); err != nil {
+			return nil, err
+		}
+	}
+	rendered.Spec.Templates = append(rendered.Spec.Templates, r.templates...)
+	return rendered, nil
+}
+
+// renderer collects the templates that are referenced from other workflow templates, or imported
+type renderer struct {
+	seen      map[string]bool
+	templates []wfv1.Template
+}
+
+func (r *renderer) resolve(tmplCtx *templateresolution.Context, tmpl *wfv1.Template) error {
+	var holders []wfv1.TemplateReferenceHolder
+	for _, group := range tmpl.Steps {
+		for i := range group.Steps {
+			holders = append(holders, &group.Steps[i])
+			if err := r.resolveHooks(tmplCtx, group.Steps[i].Hooks); err != nil {
+				return err
+			}
+		}
+	}
+	if tmpl.DAG != nil {
+		for i := range tmpl.DAG.Tasks {
+			holders = append(holders, &tmpl.DAG.Tasks[i])
+			if err := r.resolveHooks(tmplCtx, tmpl.DAG.Tasks[i].Hooks); err != nil {
+				return err
+			}
+		}
+	}
+	for _, holder := range holders {
+		if err := r.resolveHolder(tmplCtx, holder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) resolveHooks(tmplCtx *templateresolution.Context, hooks wfv1.LifecycleHooks) error {
+	for _, hook := range hooks {
+		if err := r.resolveHolder(tmplCtx, &wfv1.WorkflowStep{Template: hook.Template, TemplateRef: hook.TemplateRef}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) resolveHolder(tmplCtx *templateresolution.Context, holder wfv1.TemplateReferenceHolder) error {
+	if inline := holder.GetTemplate(); inline != nil {
+		return r.resolve(tmplCtx, inline)
+	}
+	newCtx, tmpl, _, err := tmplCtx.ResolveTemplate(holder)
+	if err != nil {
+		return err
+	}
+	key := newCtx.GetTemplateScope() + "/" + tmpl.Name
+	if r.seen[key] {
+		return nil
+	}
+	r.seen[key] = true
+	r.templates = append(r.templates, *tmpl)
+	return r.resolve(newCtx, tmpl)
+}
+
+func (w webhook) call(ctx context.Context, req Request) (*Response, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range w.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpResp, err := w.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("responded with %s", httpResp.Status)
+	}
+	resp := &Response{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp, nil
+}
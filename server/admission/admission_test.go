@@ -0,0 +1,187 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+var imagePolicy = config.AdmissionPolicy{
+	Name:       "images",
+	Expression: `all(workflow.spec.templates, {.container == nil || .container.image startsWith "ghcr.io/my-org/"})`,
+	Message:    "images must be from ghcr.io/my-org",
+}
+
+func testWorkflow(image string) *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "main",
+			Templates:  []wfv1.Template{{Name: "main", Container: &corev1.Container{Image: image}}},
+		},
+	}
+}
+
+func admit(a Interface, wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	wfClient := fake.NewSimpleClientset(&wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wftmpl", Namespace: "my-ns"},
+		Spec:       testWorkflow("docker.io/busybox").Spec,
+	})
+	return a.Admit(context.Background(), wf,
+		templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates("my-ns")),
+		templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates()))
+}
+
+func TestNew(t *testing.T) {
+	a, err := New(nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, Null, a)
+	}
+	_, err = New(&config.AdmissionConfig{Policies: []config.AdmissionPolicy{{Name: "my-policy", Expression: "workflow."}}})
+	assert.ErrorContains(t, err, `admission policy "my-policy" has an invalid expression`)
+	_, err = New(&config.AdmissionConfig{Webhooks: []config.AdmissionWebhook{{Name: "my-webhook", URL: "http://localhost", FailurePolicy: "Retry"}}})
+	assert.EqualError(t, err, `admission webhook "my-webhook" has invalid failure policy "Retry", must be one of: Fail, Ignore`)
+}
+
+func TestAdmission_Policies(t *testing.T) {
+	a, err := New(&config.AdmissionConfig{Policies: []config.AdmissionPolicy{imagePolicy}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Run("Allowed", func(t *testing.T) {
+		wf, err := admit(a, testWorkflow("ghcr.io/my-org/my-image"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-wf", wf.Name)
+		}
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		_, err := admit(a, testWorkflow("docker.io/busybox"))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, `workflow rejected by admission "images": images must be from ghcr.io/my-org`, status.Convert(err).Message())
+	})
+	t.Run("RejectedWorkflowTemplateRef", func(t *testing.T) {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}, Spec: wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-wftmpl"}}}
+		_, err := admit(a, wf)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("RejectedTemplateRef", func(t *testing.T) {
+		wf := testWorkflow("ghcr.io/my-org/my-image")
+		wf.Spec.Templates = append(wf.Spec.Templates, wfv1.Template{Name: "steps", Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{
+			{Name: "local", Template: "main"},
+			{Name: "ref", TemplateRef: &wfv1.TemplateRef{Name: "my-wftmpl", Template: "main"}},
+		}}}})
+		wf.Spec.Entrypoint = "steps"
+		_, err := admit(a, wf)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
+
+func TestRender(t *testing.T) {
+	wfClient := fake.NewSimpleClientset(&wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wftmpl", Namespace: "my-ns"},
+		Spec: wfv1.WorkflowSpec{Templates: []wfv1.Template{
+			{Name: "main", DAG: &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{{Name: "a", Template: "leaf"}, {Name: "b", Template: "leaf"}}}},
+			{Name: "leaf", Container: &corev1.Container{Image: "docker.io/busybox"}},
+		}},
+	})
+	wf := testWorkflow("ghcr.io/my-org/my-image")
+	wf.Spec.Templates[0].Container = nil
+	wf.Spec.Templates[0].Steps = []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{
+		{Name: "a", TemplateRef: &wfv1.TemplateRef{Name: "my-wftmpl", Template: "main"}},
+		{Name: "b", TemplateRef: &wfv1.TemplateRef{Name: "my-wftmpl", Template: "main"}},
+	}}}
+	rendered, err := render(wf,
+		templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates("my-ns")),
+		templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates()))
+	if assert.NoError(t, err) {
+		var names []string
+		for _, tmpl := range rendered.Spec.Templates {
+			names = append(names, tmpl.Name)
+		}
+		assert.Equal(t, []string{"main", "main", "leaf"}, names)
+		assert.Len(t, wf.Spec.Templates, 1, "the workflow is not changed")
+	}
+}
+
+func TestAdmission_Webhooks(t *testing.T) {
+	var response Response
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		req := Request{}
+		if assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			assert.Equal(t, "my-wf", req.Workflow.Name)
+			assert.Len(t, req.RenderedWorkflow.Spec.Templates, 1)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+	a, err := New(&config.AdmissionConfig{Webhooks: []config.AdmissionWebhook{{Name: "my-webhook", URL: server.URL, Headers: map[string]string{"Authorization": "Bearer my-token"}}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	t.Run("Allowed", func(t *testing.T) {
+		response = Response{Allowed: true}
+		wf, err := admit(a, testWorkflow("my-image"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-image", wf.Spec.Templates[0].Container.Image)
+		}
+	})
+	t.Run("Mutated", func(t *testing.T) {
+		mutated := testWorkflow("my-image")
+		mutated.Labels = map[string]string{"team": "my-team"}
+		response = Response{Allowed: true, Workflow: mutated}
+		wf, err := admit(a, testWorkflow("my-image"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-team", wf.Labels["team"])
+		}
+	})
+	t.Run("CheckDoesNotMutate", func(t *testing.T) {
+		mutated := testWorkflow("my-image")
+		mutated.Namespace = "other-ns"
+		response = Response{Allowed: true, Workflow: mutated}
+		assert.NoError(t, a.Check(context.Background(), testWorkflow("my-image"), nil, nil))
+	})
+	t.Run("MutatedNamespace", func(t *testing.T) {
+		mutated := testWorkflow("my-image")
+		mutated.Namespace = "other-ns"
+		response = Response{Allowed: true, Workflow: mutated}
+		_, err := admit(a, testWorkflow("my-image"))
+		assert.EqualError(t, err, `admission webhook "my-webhook" must not change the namespace of the workflow`)
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		response = Response{Message: "the team label is required"}
+		_, err := admit(a, testWorkflow("my-image"))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Equal(t, `workflow rejected by admission "my-webhook": the team label is required`, status.Convert(err).Message())
+	})
+}
+
+func TestAdmission_FailurePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	a, err := New(&config.AdmissionConfig{Webhooks: []config.AdmissionWebhook{{Name: "my-webhook", URL: server.URL}}})
+	if assert.NoError(t, err) {
+		_, err := admit(a, testWorkflow("my-image"))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	a, err = New(&config.AdmissionConfig{Webhooks: []config.AdmissionWebhook{{Name: "my-webhook", URL: server.URL, FailurePolicy: config.AdmissionFailurePolicyIgnore}}})
+	if assert.NoError(t, err) {
+		_, err := admit(a, testWorkflow("my-image"))
+		assert.NoError(t, err)
+	}
+}
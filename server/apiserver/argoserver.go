@@ -13,9 +13,11 @@ import (
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"github.com/soheilhy/cmux"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -25,23 +27,32 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/env"
+	"upper.io/db.v3/lib/sqlbuilder"
 
 	"github.com/argoproj/argo-workflows/v3"
 	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/persist/archive"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	clusterwftemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
 	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
 	eventpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/event"
 	eventsourcepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/eventsource"
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
 	infopkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/info"
 	sensorpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/sensor"
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	"github.com/argoproj/argo-workflows/v3/server/apiserver/accesslog"
+	"github.com/argoproj/argo-workflows/v3/server/artifactrepository"
 	"github.com/argoproj/argo-workflows/v3/server/artifacts"
+	"github.com/argoproj/argo-workflows/v3/server/audit"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/apitoken"
+	"github.com/argoproj/argo-workflows/v3/server/auth/clientcert"
 	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
 	"github.com/argoproj/argo-workflows/v3/server/auth/webhook"
 	"github.com/argoproj/argo-workflows/v3/server/cache"
@@ -49,17 +60,25 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/cronworkflow"
 	"github.com/argoproj/argo-workflows/v3/server/event"
 	"github.com/argoproj/argo-workflows/v3/server/eventsource"
+	"github.com/argoproj/argo-workflows/v3/server/federation"
 	"github.com/argoproj/argo-workflows/v3/server/info"
+	"github.com/argoproj/argo-workflows/v3/server/report"
 	"github.com/argoproj/argo-workflows/v3/server/sensor"
 	"github.com/argoproj/argo-workflows/v3/server/static"
+	"github.com/argoproj/argo-workflows/v3/server/synchronization"
 	"github.com/argoproj/argo-workflows/v3/server/types"
 	"github.com/argoproj/argo-workflows/v3/server/workflow"
 	"github.com/argoproj/argo-workflows/v3/server/workflowarchive"
 	"github.com/argoproj/argo-workflows/v3/server/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/util/file"
 	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/json"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/namespaces"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 
@@ -77,6 +96,7 @@ type argoServer struct {
 	hsts                     bool
 	namespace                string
 	managedNamespace         string
+	managedNamespaces        *namespaces.Selector
 	clients                  *types.Clients
 	gatekeeper               auth.Gatekeeper
 	oAuth2Service            sso.Interface
@@ -88,8 +108,10 @@ type argoServer struct {
 	xframeOptions            string
 	accessControlAllowOrigin string
 	apiRateLimiter           limiter.Store
+	identityRateLimiter      limiter.Store
 	allowedLinkProtocol      []string
 	cache                    *cache.ResourceCache
+	apiTokens                *apitoken.Store
 }
 
 type ArgoServerOpts struct {
@@ -103,6 +125,7 @@ type ArgoServerOpts struct {
 	// config map name
 	ConfigName               string
 	ManagedNamespace         string
+	ManagedNamespaceSelector string
 	SSONamespace             string
 	HSTS                     bool
 	EventOperationQueueSize  int
@@ -111,6 +134,7 @@ type ArgoServerOpts struct {
 	XFrameOptions            string
 	AccessControlAllowOrigin string
 	APIRateLimit             uint64
+	APIRateLimitPerIdentity  uint64
 	AllowedLinkProtocol      []string
 }
 
@@ -132,6 +156,7 @@ func getResourceCacheNamespace(opts ArgoServerOpts) string {
 func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error) {
 	configController := config.NewController(opts.Namespace, opts.ConfigName, opts.Clients.Kubernetes)
 	var resourceCache *cache.ResourceCache = nil
+	var apiTokens *apitoken.Store
 	ssoIf := sso.NullSSO
 	if opts.AuthModes[auth.SSO] {
 		c, err := configController.Get(ctx)
@@ -144,11 +169,23 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 		}
 		resourceCache = cache.NewResourceCache(opts.Clients.Kubernetes, getResourceCacheNamespace(opts))
 		resourceCache.Run(ctx.Done())
+		apiTokens = apitoken.NewStore(opts.Clients.Kubernetes.CoreV1().Secrets(opts.Namespace))
+		if !apiTokens.Run(ctx.Done()) {
+			return nil, fmt.Errorf("timed out waiting for API tokens to sync")
+		}
 		log.Info("SSO enabled")
 	} else {
 		log.Info("SSO disabled")
 	}
-	gatekeeper, err := auth.NewGatekeeper(opts.AuthModes, opts.Clients, opts.RestConfig, ssoIf, auth.DefaultClientForAuthorization, opts.Namespace, opts.SSONamespace, opts.Namespaced, resourceCache)
+	var managedNamespaces *namespaces.Selector
+	if opts.ManagedNamespaceSelector != "" {
+		var err error
+		managedNamespaces, err = namespaces.NewSelector(opts.Clients.Kubernetes, opts.ManagedNamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+	gatekeeper, err := auth.NewGatekeeper(opts.AuthModes, opts.Clients, opts.RestConfig, ssoIf, auth.DefaultClientForAuthorization, opts.Namespace, opts.SSONamespace, opts.Namespaced, resourceCache, apiTokens)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +196,17 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 	if err != nil {
 		log.Fatal(err)
 	}
+	var identityStore limiter.Store
+	if opts.APIRateLimitPerIdentity > 0 {
+		identityStore, err = memorystore.New(&memorystore.Config{
+			Tokens:   opts.APIRateLimitPerIdentity,
+			Interval: time.Second,
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		identityStore = grpcutil.NewCountingStore(identityStore, "identity")
+	}
 
 	return &argoServer{
 		baseHRef:                 opts.BaseHRef,
@@ -166,6 +214,7 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 		hsts:                     opts.HSTS,
 		namespace:                opts.Namespace,
 		managedNamespace:         opts.ManagedNamespace,
+		managedNamespaces:        managedNamespaces,
 		clients:                  opts.Clients,
 		gatekeeper:               gatekeeper,
 		oAuth2Service:            ssoIf,
@@ -176,9 +225,11 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 		eventAsyncDispatch:       opts.EventAsyncDispatch,
 		xframeOptions:            opts.XFrameOptions,
 		accessControlAllowOrigin: opts.AccessControlAllowOrigin,
-		apiRateLimiter:           store,
+		apiRateLimiter:           grpcutil.NewCountingStore(store, "ip"),
+		identityRateLimiter:      identityStore,
 		allowedLinkProtocol:      opts.AllowedLinkProtocol,
 		cache:                    resourceCache,
+		apiTokens:                apiTokens,
 	}, nil
 }
 
@@ -198,32 +249,67 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	if err != nil {
 		log.Fatal(err)
 	}
+	// the same compression as the controller, so the archive in object storage is updated with the same keys
+	if err := file.SetCompression(config.Compression.Algorithm, config.Compression.Level); err != nil {
+		log.Fatal(err)
+	}
 	log.WithFields(log.Fields{"version": argo.GetVersion().Version, "instanceID": config.InstanceID}).Info("Starting Argo Server")
+	if !as.managedNamespaces.Run(as.stopCh) {
+		log.Fatal("Timed out waiting for managed namespaces to sync")
+	}
 	instanceIDService := instanceid.NewService(config.InstanceID)
 	offloadRepo := sqldb.ExplosiveOffloadNodeStatusRepo
+	offloadParametersRepo := sqldb.ExplosiveOffloadParametersRepo
 	wfArchive := sqldb.NullWorkflowArchive
 	persistence := config.Persistence
 	if persistence != nil {
-		session, tableName, err := sqldb.CreateDBSession(as.clients.Kubernetes, as.namespace, persistence)
-		if err != nil {
-			log.Fatal(err)
+		var session sqlbuilder.Database
+		if persistence.DatabaseConfigured() {
+			var tableName string
+			session, tableName, err = sqldb.CreateDBSession(as.clients.Kubernetes, as.namespace, persistence)
+			if err != nil {
+				log.Fatal(err)
+			}
+			// we always enable node offload, as this is read-only for the Argo Server, i.e. you can turn it off if you
+			// like and the controller won't offload newly created workflows, but you can still read them
+			offloadRepo, err = sqldb.NewOffloadNodeStatusRepo(session, persistence.GetClusterName(), tableName)
+			if err != nil {
+				log.Fatal(err)
+			}
+			offloadParametersRepo = sqldb.NewOffloadParametersRepo(session, persistence.GetClusterName())
 		}
-		// we always enable node offload, as this is read-only for the Argo Server, i.e. you can turn it off if you
-		// like and the controller won't offload newly created workflows, but you can still read them
-		offloadRepo, err = sqldb.NewOffloadNodeStatusRepo(session, persistence.GetClusterName(), tableName)
+		// we always enable the archive for the Argo Server, as the Argo Server does not write records, so you can
+		// disable the archiving - and still read old records
+		wfArchive, err = archive.New(ctx, as.clients.Kubernetes, as.namespace, persistence, session, as.managedNamespace, instanceIDService, false)
 		if err != nil {
 			log.Fatal(err)
 		}
-		// we always enable the archive for the Argo Server, as the Argo Server does not write records, so you can
-		// disable the archiving - and still read old records
-		wfArchive = sqldb.NewWorkflowArchive(session, persistence.GetClusterName(), as.managedNamespace, instanceIDService)
 	}
 	eventRecorderManager := events.NewEventRecorderManager(as.clients.Kubernetes)
-	artifactRepositories := artifactrepositories.New(as.clients.Kubernetes, as.managedNamespace, &config.ArtifactRepository)
-	artifactServer := artifacts.NewArtifactServer(as.gatekeeper, hydrator.New(offloadRepo), wfArchive, instanceIDService, artifactRepositories)
+	artifactRepositories := artifactrepositories.New(as.clients.Kubernetes, as.clients.Workflow, as.managedNamespace, &config.ArtifactRepository)
+	// offloaded parameters are read with the client of the caller, as their workflows are
+	wfHydrator := hydrator.New(offloadRepo, hydrator.NewParametersLoader(hydrator.ContextConfigMapGetter(auth.GetKubeClient), offloadParametersRepo))
+	artifactServer := artifacts.NewArtifactServer(as.gatekeeper, wfHydrator, wfArchive, instanceIDService, artifactRepositories)
+	reportServer := report.NewServer(as.gatekeeper, wfHydrator, instanceIDService, as.baseHRef, as.tlsConfig != nil)
 	eventServer := event.NewController(instanceIDService, eventRecorderManager, as.eventQueueSize, as.eventWorkerCount, as.eventAsyncDispatch)
-	grpcServer := as.newGRPCServer(instanceIDService, offloadRepo, wfArchive, eventServer, config.Links, config.NavColor)
-	httpServer := as.newHTTPServer(ctx, port, artifactServer)
+	auditLogger, err := audit.New(config.Audit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	wfAdmission, err := admission.New(config.Admission)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var federationServer *federation.Server
+	if config.Federation != nil {
+		federationServer, err = federation.New(ctx, instanceIDService, as.clients.Kubernetes, as.namespace, config.Federation)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	defer tracing.Shutdown(tracing.Init(ctx, "argo-server"))
+	grpcServer := as.newGRPCServer(instanceIDService, offloadRepo, wfHydrator, wfArchive, eventServer, auditLogger, wfAdmission, artifactRepositories, federationServer, config.Links, config.NavColor)
+	httpServer := as.newHTTPServer(ctx, port, artifactServer, reportServer)
 
 	// Start listener
 	var conn net.Listener
@@ -268,12 +354,17 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	<-as.stopCh
 }
 
-func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchive sqldb.WorkflowArchive, eventServer *event.Controller, links []*v1alpha1.Link, navColor string) *grpc.Server {
+func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfHydrator hydrator.Interface, wfArchive sqldb.WorkflowArchive, eventServer *event.Controller, auditLogger *audit.Logger, wfAdmission admission.Interface, artifactRepositories artifactrepositories.Interface, federationServer *federation.Server, links []*v1alpha1.Link, navColor string) *grpc.Server {
 	serverLog := log.NewEntry(log.StandardLogger())
 
 	// "Prometheus histograms are a great way to measure latency distributions of your RPCs. However, since it is bad practice to have metrics of high cardinality the latency monitoring metrics are disabled by default. To enable them please call the following in your server initialization code:"
 	grpc_prometheus.EnableHandlingTimeHistogram()
 
+	identityKey := func(context.Context) string { return "" }
+	if as.identityRateLimiter != nil {
+		identityKey = auth.GetIdentity
+	}
+
 	sOpts := []grpc.ServerOption{
 		// Set both the send and receive the bytes limit to be 100MB or GRPC_MESSAGE_SIZE
 		// The proper way to achieve high performance is to have pagination
@@ -285,38 +376,58 @@ func (as *argoServer) newGRPCServer(instanceIDService instanceid.Service, offloa
 			grpc_prometheus.UnaryServerInterceptor,
 			grpc_logrus.UnaryServerInterceptor(serverLog),
 			grpcutil.PanicLoggerUnaryServerInterceptor(serverLog),
+			otelgrpc.UnaryServerInterceptor(),
+			auditLogger.UnaryServerInterceptor(),
 			grpcutil.ErrorTranslationUnaryServerInterceptor,
 			as.gatekeeper.UnaryServerInterceptor(),
+			auditLogger.UserUnaryServerInterceptor(),
+			grpcutil.NamespaceUnaryServerInterceptor(as.managedNamespaces.Matches),
 			grpcutil.RatelimitUnaryServerInterceptor(as.apiRateLimiter),
+			grpcutil.KeyedRatelimitUnaryServerInterceptor(as.identityRateLimiter, identityKey),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
 			grpc_prometheus.StreamServerInterceptor,
 			grpc_logrus.StreamServerInterceptor(serverLog),
 			grpcutil.PanicLoggerStreamServerInterceptor(serverLog),
+			otelgrpc.StreamServerInterceptor(),
 			grpcutil.ErrorTranslationStreamServerInterceptor,
 			as.gatekeeper.StreamServerInterceptor(),
+			grpcutil.NamespaceStreamServerInterceptor(as.managedNamespaces.Matches),
 			grpcutil.RatelimitStreamServerInterceptor(as.apiRateLimiter),
+			grpcutil.KeyedRatelimitStreamServerInterceptor(as.identityRateLimiter, identityKey),
 		)),
 	}
 
+	if as.tlsConfig != nil {
+		// the listener performs the TLS handshake, but we need its client certificates
+		sOpts = append(sOpts, grpc.Creds(grpcutil.NewTLSListenerCredentials()))
+	}
+
 	grpcServer := grpc.NewServer(sOpts...)
 
 	infopkg.RegisterInfoServiceServer(grpcServer, info.NewInfoServer(as.managedNamespace, links, navColor))
 	eventpkg.RegisterEventServiceServer(grpcServer, eventServer)
 	eventsourcepkg.RegisterEventSourceServiceServer(grpcServer, eventsource.NewEventSourceServer())
 	sensorpkg.RegisterSensorServiceServer(grpcServer, sensor.NewSensorServer())
-	workflowpkg.RegisterWorkflowServiceServer(grpcServer, workflow.NewWorkflowServer(instanceIDService, offloadNodeStatusRepo))
+	workflowpkg.RegisterWorkflowServiceServer(grpcServer, workflow.NewWorkflowServer(instanceIDService, offloadNodeStatusRepo, wfHydrator, wfAdmission, artifactRepositories))
 	workflowtemplatepkg.RegisterWorkflowTemplateServiceServer(grpcServer, workflowtemplate.NewWorkflowTemplateServer(instanceIDService))
 	cronworkflowpkg.RegisterCronWorkflowServiceServer(grpcServer, cronworkflow.NewCronWorkflowServer(instanceIDService))
 	workflowarchivepkg.RegisterArchivedWorkflowServiceServer(grpcServer, workflowarchive.NewWorkflowArchiveServer(wfArchive))
-	clusterwftemplatepkg.RegisterClusterWorkflowTemplateServiceServer(grpcServer, clusterworkflowtemplate.NewClusterWorkflowTemplateServer(instanceIDService))
+	clusterwftemplatepkg.RegisterClusterWorkflowTemplateServiceServer(grpcServer, clusterworkflowtemplate.NewClusterWorkflowTemplateServer(instanceIDService, as.namespace))
+	syncpkg.RegisterSyncLockServiceServer(grpcServer, synchronization.NewSyncLockServer(wfHydrator, instanceIDService))
+	if federationServer != nil {
+		federationpkg.RegisterFederationServiceServer(grpcServer, federationServer)
+	}
 	grpc_prometheus.Register(grpcServer)
+	if err := grpcutil.RegisterRateLimitMetrics(prometheus.DefaultRegisterer); err != nil {
+		log.WithError(err).Error("failed to register the rate limit metrics")
+	}
 	return grpcServer
 }
 
 // newHTTPServer returns the HTTP server to serve HTTP/HTTPS requests. This is implemented
 // using grpc-gateway as a proxy to the gRPC server.
-func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServer *artifacts.ArtifactServer) *http.Server {
+func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServer *artifacts.ArtifactServer, reportServer *report.Server) *http.Server {
 	endpoint := fmt.Sprintf("localhost:%d", port)
 
 	ratelimit_middleware, err := httplimit.NewMiddleware(as.apiRateLimiter, httplimit.IPKeyFunc())
@@ -329,12 +440,17 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 		Addr:      endpoint,
 		Handler:   ratelimit_middleware.Handle(accesslog.Interceptor(mux)),
 		TLSConfig: as.tlsConfig,
+		// the listener performs the TLS handshake, but we need its client certificates
+		ConnContext: clientcert.ConnContext,
 	}
 	dialOpts := []grpc.DialOption{
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxGRPCMessageSize)),
 	}
 	if as.tlsConfig != nil {
-		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(as.tlsConfig)))
+		// the gateway must not present the server's certificate as a client certificate
+		dialTLSConfig := as.tlsConfig.Clone()
+		dialTLSConfig.Certificates = nil
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(dialTLSConfig)))
 	} else {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
@@ -361,10 +477,16 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 	mustRegisterGWHandler(cronworkflowpkg.RegisterCronWorkflowServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
 	mustRegisterGWHandler(workflowarchivepkg.RegisterArchivedWorkflowServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
 	mustRegisterGWHandler(clusterwftemplatepkg.RegisterClusterWorkflowTemplateServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
+	mustRegisterGWHandler(syncpkg.RegisterSyncLockServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
+	mustRegisterGWHandler(federationpkg.RegisterFederationServiceHandlerFromEndpoint, ctx, gwmux, endpoint, dialOpts)
 
 	mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
 		// we must delete this header for API request to prevent "stream terminated by RST_STREAM with error code: PROTOCOL_ERROR" error
 		r.Header.Del("Connection")
+		if err := clientcert.Forward(r); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		webhookInterceptor(w, r, gwmux)
 	})
 
@@ -378,6 +500,15 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 	}
 	mux.Handle("/oauth2/redirect", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleRedirect)))
 	mux.Handle("/oauth2/callback", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleCallback)))
+	mux.Handle("/workflow-reports/", reportServer)
+	mux.HandleFunc("/rbac/dry-run", auth.NewRBACDryRunHandler(as.oAuth2Service))
+	// called by the Kubernetes API server, which does not authenticate itself
+	mux.Handle("/webhooks/artifact-repositories", artifactrepository.ValidatingWebhook{})
+	apiTokensHandler := auth.NewAPITokensHandler(as.oAuth2Service, as.apiTokens)
+	mux.HandleFunc("/api-tokens", apiTokensHandler)
+	mux.HandleFunc("/api-tokens/", apiTokensHandler)
+	// protected by the same token as the controller's diagnostics
+	mux.HandleFunc("/diagnostics/log-levels", logging.LevelsHandler(common.HeaderDiagnosticsToken, os.Getenv("ARGO_DIAGNOSTICS_TOKEN")))
 	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
 		if os.Getenv("ARGO_SERVER_METRICS_AUTH") != "false" {
 			md := metadata.New(map[string]string{"authorization": r.Header.Get("Authorization")})
@@ -0,0 +1,91 @@
+package artifactrepository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// ValidatingWebhook is a Kubernetes validating admission webhook that rejects WorkflowArtifactRepositories and
+// ClusterWorkflowArtifactRepositories that do not configure exactly one repository, or whose credentials reference
+// secrets without a name or key
+type ValidatingWebhook struct{}
+
+func (ValidatingWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	review := &admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		http.Error(w, "request body must be an admission review with a request", http.StatusBadRequest)
+		return
+	}
+	resp := &admissionv1.AdmissionResponse{UID: review.Request.UID, Allowed: true}
+	if err := admit(review.Request); err != nil {
+		resp.Allowed = false
+		resp.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		}
+	}
+	review.Request = nil
+	review.Response = resp
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.WithError(err).Error("failed to write admission review response")
+	}
+}
+
+func admit(req *admissionv1.AdmissionRequest) error {
+	switch req.Kind.Kind {
+	case workflow.WorkflowArtifactRepositoryKind, workflow.ClusterWorkflowArtifactRepositoryKind:
+	default:
+		return fmt.Errorf("cannot validate kind %q", req.Kind.Kind)
+	}
+	if req.Operation == admissionv1.Delete {
+		return nil
+	}
+	obj := &struct {
+		Spec wfv1.ArtifactRepository `json:"spec"`
+	}{}
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return err
+	}
+	return validate(&obj.Spec)
+}
+
+func validate(repo *wfv1.ArtifactRepository) error {
+	n := 0
+	for _, configured := range []bool{repo.S3 != nil, repo.Artifactory != nil, repo.HDFS != nil, repo.OSS != nil, repo.GCS != nil, repo.Azure != nil} {
+		if configured {
+			n++
+		}
+	}
+	if n != 1 {
+		return fmt.Errorf("spec must configure exactly one of s3, artifactory, hdfs, oss, gcs or azure, not %d", n)
+	}
+	selectors := repo.SecretKeySelectors()
+	fields := make([]string, 0, len(selectors))
+	for field := range selectors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		s := selectors[field]
+		if s.Name == "" || s.Key == "" {
+			return fmt.Errorf("spec.%s must have a name and key", field)
+		}
+	}
+	return nil
+}
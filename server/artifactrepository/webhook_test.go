@@ -0,0 +1,75 @@
+package artifactrepository
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidatingWebhook(t *testing.T) {
+	review := func(t *testing.T, kind, object string) *admissionv1.AdmissionResponse {
+		req := &admissionv1.AdmissionRequest{
+			UID:       "my-uid",
+			Kind:      metav1.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: kind},
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(object)},
+		}
+		if object == "" {
+			req.Operation = admissionv1.Delete
+			req.Object = runtime.RawExtension{}
+		}
+		data, err := json.Marshal(&admissionv1.AdmissionReview{Request: req})
+		require.NoError(t, err)
+		w := httptest.NewRecorder()
+		ValidatingWebhook{}.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/artifact-repositories", bytes.NewReader(data)))
+		require.Equal(t, http.StatusOK, w.Code)
+		resp := &admissionv1.AdmissionReview{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(resp))
+		require.NotNil(t, resp.Response)
+		assert.Equal(t, "my-uid", string(resp.Response.UID))
+		return resp.Response
+	}
+	t.Run("Allowed", func(t *testing.T) {
+		resp := review(t, "WorkflowArtifactRepository", `{"spec":{"s3":{"bucket":"my-bucket","accessKeySecret":{"name":"my-secret","key":"accessKey"}}}}`)
+		assert.True(t, resp.Allowed)
+	})
+	t.Run("ClusterScope", func(t *testing.T) {
+		resp := review(t, "ClusterWorkflowArtifactRepository", `{"spec":{"gcs":{"bucket":"my-bucket"}}}`)
+		assert.True(t, resp.Allowed)
+	})
+	t.Run("NoRepository", func(t *testing.T) {
+		resp := review(t, "WorkflowArtifactRepository", `{"spec":{}}`)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "spec must configure exactly one of s3, artifactory, hdfs, oss, gcs or azure, not 0", resp.Result.Message)
+	})
+	t.Run("TwoRepositories", func(t *testing.T) {
+		resp := review(t, "WorkflowArtifactRepository", `{"spec":{"s3":{},"gcs":{}}}`)
+		assert.False(t, resp.Allowed)
+	})
+	t.Run("SecretWithoutKey", func(t *testing.T) {
+		resp := review(t, "WorkflowArtifactRepository", `{"spec":{"s3":{"accessKeySecret":{"name":"my-secret"}}}}`)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, "spec.s3.accessKeySecret must have a name and key", resp.Result.Message)
+	})
+	t.Run("OtherKind", func(t *testing.T) {
+		resp := review(t, "Workflow", `{}`)
+		assert.False(t, resp.Allowed)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		resp := review(t, "WorkflowArtifactRepository", ``)
+		assert.True(t, resp.Allowed)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ValidatingWebhook{}.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/artifact-repositories", bytes.NewReader([]byte(`{}`))))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
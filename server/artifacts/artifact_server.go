@@ -2,6 +2,7 @@ package artifacts
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -20,6 +21,7 @@ import (
 
 	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	artifactpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/artifact"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/server/types"
@@ -64,6 +66,8 @@ func (a *ArtifactServer) GetInputArtifact(w http.ResponseWriter, r *http.Request
 //	/artifact-files/{namespace}/[archived-workflows|workflows]/{id}/{nodeId}/outputs/{artifactName}/{fileDir}/.../{fileName}
 //
 // 'id' field represents 'uid' for archived workflows and 'name' for non-archived
+//
+// Adding the "list=true" query parameter returns an artifact.FileListing as JSON instead of the file or directory
 func (a *ArtifactServer) GetArtifactFile(w http.ResponseWriter, r *http.Request) {
 
 	const (
@@ -153,6 +157,11 @@ func (a *ArtifactServer) GetArtifactFile(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if r.URL.Query().Get("list") == "true" {
+		a.listArtifactFiles(w, artifact, driver, strings.HasSuffix(r.URL.Path, "/"))
+		return
+	}
+
 	isDir := strings.HasSuffix(r.URL.Path, "/")
 
 	if !isDir {
@@ -429,6 +438,43 @@ func (a *ArtifactServer) getArtifactAndDriver(ctx context.Context, nodeId, artif
 	return art, driver, nil
 }
 
+func (a *ArtifactServer) listArtifactFiles(w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver, isDir bool) {
+	key, _ := art.GetKey()
+	listing := artifactpkg.FileListing{Files: []string{}}
+	if !isDir {
+		var err error
+		isDir, err = driver.IsDirectory(art)
+		if err != nil && !argoerrors.IsCode(argoerrors.CodeNotImplemented, err) {
+			a.httpFromError(err, w)
+			return
+		}
+	}
+	if isDir {
+		objects, err := driver.ListObjects(art)
+		if err != nil {
+			a.httpFromError(err, w)
+			return
+		}
+		listing.IsDirectory = true
+		prefix := strings.TrimSuffix(key, "/") + "/"
+		for _, object := range objects {
+			// objects are prefixed by the key, and may include the directory itself
+			if file := strings.TrimPrefix(object, prefix); file != object && file != "" && !strings.HasSuffix(file, "/") {
+				listing.Files = append(listing.Files, file)
+			}
+		}
+	} else {
+		listing.Files = append(listing.Files, path.Base(key))
+	}
+	data, err := json.Marshal(listing)
+	if err != nil {
+		a.serverInternalError(err, w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
 func (a *ArtifactServer) returnArtifact(w http.ResponseWriter, art *wfv1.Artifact, driver common.ArtifactDriver) error {
 	stream, err := driver.OpenStream(art)
 	if err != nil {
@@ -469,7 +515,7 @@ func (a *ArtifactServer) getWorkflowAndValidate(ctx context.Context, namespace s
 	if err != nil {
 		return nil, err
 	}
-	err = a.hydrator.Hydrate(wf)
+	err = a.hydrator.Hydrate(ctx, wf)
 	if err != nil {
 		return nil, err
 	}
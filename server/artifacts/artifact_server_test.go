@@ -3,6 +3,7 @@ package artifacts
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -24,6 +25,7 @@ import (
 	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	sqldbmocks "github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
+	artifactpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/artifact"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	fakewfv1 "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
@@ -448,6 +450,44 @@ func TestArtifactServer_GetArtifactFile(t *testing.T) {
 	}
 }
 
+func TestArtifactServer_GetArtifactFileListing(t *testing.T) {
+	s := newServer()
+
+	tests := []struct {
+		path    string
+		listing artifactpkg.FileListing
+	}{
+		{
+			path:    "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory?list=true",
+			listing: artifactpkg.FileListing{IsDirectory: true, Files: []string{"a.txt", "index.html", "subdirectory/b.txt", "subdirectory/c.txt"}},
+		},
+		{
+			path:    "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-s3-artifact-directory/subdirectory/?list=true",
+			listing: artifactpkg.FileListing{IsDirectory: true, Files: []string{"b.txt", "c.txt"}},
+		},
+		{
+			path:    "/artifact-files/my-ns/workflows/my-wf/my-node-1/outputs/my-gcs-artifact-file/my-gcs-artifact.tgz?list=true",
+			listing: artifactpkg.FileListing{Files: []string{"my-gcs-artifact.tgz"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			r := &http.Request{}
+			r.URL = mustParse(tt.path)
+			recorder := httptest.NewRecorder()
+
+			s.GetArtifactFile(recorder, r)
+			if assert.Equal(t, 200, recorder.Result().StatusCode) {
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				var listing artifactpkg.FileListing
+				assert.NoError(t, json.NewDecoder(recorder.Result().Body).Decode(&listing))
+				assert.Equal(t, tt.listing, listing)
+			}
+		})
+	}
+}
+
 func TestArtifactServer_GetOutputArtifact(t *testing.T) {
 	s := newServer()
 
@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/rbac"
+)
+
+const (
+	ResultSuccess = "Success"
+	ResultFailure = "Failure"
+)
+
+// Event records a mutating API call: who made it, what they did to which resource, and the result
+type Event struct {
+	Time time.Time `json:"time"`
+	User User      `json:"user"`
+	// Verb is one of "create", "update" or "delete"
+	Verb string `json:"verb"`
+	// Method is the full gRPC method, e.g. "/workflow.WorkflowService/RetryWorkflow"
+	Method    string `json:"method"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Request is the body of the request, i.e. the change that was requested, with its string values redacted other than
+	// those that identify resources
+	Request json.RawMessage `json:"request,omitempty"`
+	Result  string          `json:"result"`
+	// Code is the gRPC code of the result, e.g. "OK" or "PermissionDenied"
+	Code  string `json:"code"`
+	Error string `json:"error,omitempty"`
+}
+
+type User struct {
+	Subject        string   `json:"subject,omitempty"`
+	Email          string   `json:"email,omitempty"`
+	Groups         []string `json:"groups,omitempty"`
+	ServiceAccount string   `json:"serviceAccount,omitempty"`
+}
+
+// Logger writes an audit event to each of its sinks for every mutating API call
+type Logger struct {
+	sinks []Sink
+}
+
+// New returns a logger for the config, which writes nothing if auditing is not enabled
+func New(c *config.AuditConfig) (*Logger, error) {
+	l := &Logger{}
+	if !c.IsEnabled() {
+		return l, nil
+	}
+	for _, sc := range c.GetSinks() {
+		s, err := NewSink(sc)
+		if err != nil {
+			return nil, err
+		}
+		l.sinks = append(l.sinks, s)
+	}
+	return l, nil
+}
+
+type userKey struct{}
+
+// UnaryServerInterceptor returns an interceptor that audits mutating calls. It must be before the gatekeeper's
+// interceptor, so that calls that fail to authenticate or are not allowed are audited too, and must be used with
+// UserUnaryServerInterceptor, which tells it who made the call.
+func (l *Logger) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		verb := mutatingVerb(info.FullMethod)
+		if len(l.sinks) == 0 || verb == "" {
+			return handler(ctx, req)
+		}
+		user := &User{}
+		resp, err := handler(context.WithValue(ctx, userKey{}, user), req)
+		l.write(ctx, newEvent(*user, info.FullMethod, verb, req, resp, err))
+		return resp, err
+	}
+}
+
+// UserUnaryServerInterceptor returns an interceptor that records who made the call for UnaryServerInterceptor. It must
+// be after the gatekeeper's interceptor.
+func (l *Logger) UserUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if user, ok := ctx.Value(userKey{}).(*User); ok {
+			if claims := auth.GetClaims(ctx); claims != nil {
+				*user = User{Subject: claims.Subject, Email: claims.Email, Groups: claims.Groups, ServiceAccount: claims.ServiceAccountName}
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (l *Logger) write(ctx context.Context, e Event) {
+	for _, s := range l.sinks {
+		if err := s.Write(ctx, e); err != nil {
+			// fallback so that the event is not lost
+			log.WithError(err).WithFields(log.Fields{"method": e.Method, "namespace": e.Namespace, "name": e.Name, "subject": e.User.Subject}).Error("failed to write audit event")
+		}
+	}
+}
+
+// mutatingVerb returns the verb of the method if it changes anything, otherwise ""
+func mutatingVerb(fullMethod string) string {
+	_, method := path.Split(fullMethod)
+	if strings.HasPrefix(method, "Lint") {
+		return ""
+	}
+	switch verb := rbac.VerbForMethod(fullMethod); verb {
+	case rbac.VerbCreate, rbac.VerbUpdate, rbac.VerbDelete:
+		return verb
+	}
+	return ""
+}
+
+func newEvent(user User, fullMethod, verb string, req, resp interface{}, err error) Event {
+	e := Event{
+		Time:   time.Now().UTC(),
+		User:   user,
+		Verb:   verb,
+		Method: fullMethod,
+		Kind:   kindForMethod(fullMethod),
+		Result: ResultSuccess,
+		Code:   status.Code(err).String(),
+	}
+	if err != nil {
+		e.Result = ResultFailure
+		e.Error = status.Convert(err).Message()
+	}
+	if r, ok := req.(interface{ GetNamespace() string }); ok {
+		e.Namespace = r.GetNamespace()
+	}
+	if r, ok := req.(interface{ GetName() string }); ok {
+		e.Name = r.GetName()
+	}
+	// the response has the name of created resources, including those with a generated name
+	if o, ok := resp.(metav1.Object); ok && o.GetName() != "" {
+		e.Name = o.GetName()
+		e.Namespace = o.GetNamespace()
+	}
+	if data, err := json.Marshal(req); err == nil {
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err == nil {
+			e.Request, _ = json.Marshal(redact("", v))
+		}
+	}
+	return e
+}
+
+// identifyingFields are the request fields whose values are not redacted, as they identify the resources the call was
+// for rather than being their content, which may include secrets such as parameters, environment variables or tokens
+var identifyingFields = map[string]bool{
+	"name":         true,
+	"namespace":    true,
+	"generateName": true,
+	"uid":          true,
+	"kind":         true,
+	"apiVersion":   true,
+	"resourceKind": true,
+	"resourceName": true,
+}
+
+const redacted = "[REDACTED]"
+
+// redact replaces the string values of v, other than those of identifying fields, so the audit log records the shape
+// of the change without its content. Numbers, booleans and nulls are kept, e.g. so flags such as "restartSuccessful" are
+// recorded.
+func redact(key string, v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, y := range x {
+			x[k] = redact(k, y)
+		}
+		return x
+	case []interface{}:
+		for i, y := range x {
+			x[i] = redact(key, y)
+		}
+		return x
+	case string:
+		if identifyingFields[key] {
+			return x
+		}
+		return redacted
+	default:
+		return x
+	}
+}
+
+// kindForMethod returns the kind of resource of the service of the method, e.g. "CronWorkflow" for
+// "/cronworkflow.CronWorkflowService/ResumeCronWorkflow"
+func kindForMethod(fullMethod string) string {
+	service := strings.TrimSuffix(path.Dir(fullMethod), "/")
+	service = service[strings.LastIndex(service, ".")+1:]
+	return strings.TrimSuffix(service, "Service")
+}
@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+type testSink struct{ events []Event }
+
+func (s *testSink) Write(_ context.Context, e Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	l, err := New(nil)
+	if assert.NoError(t, err) {
+		assert.Empty(t, l.sinks)
+	}
+	l, err = New(&config.AuditConfig{Enabled: true})
+	if assert.NoError(t, err) {
+		assert.Len(t, l.sinks, 1)
+	}
+	_, err = New(&config.AuditConfig{Enabled: true, Sinks: []config.AuditSink{{Type: "syslog"}}})
+	assert.EqualError(t, err, `unknown audit sink type "syslog", must be one of: stdout, file, webhook`)
+	_, err = New(&config.AuditConfig{Enabled: true, Sinks: []config.AuditSink{{Type: config.AuditSinkWebhook}}})
+	assert.EqualError(t, err, `audit sink of type "webhook" must have a url`)
+}
+
+func TestLogger_UnaryServerInterceptor(t *testing.T) {
+	sink := &testSink{}
+	l := &Logger{sinks: []Sink{sink}}
+	claims := &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}, Email: "me@example.com", ServiceAccountName: "my-sa"}
+	// like the gatekeeper, which is between the interceptors, adding the claims if the call is authenticated
+	intercept := func(method string, req interface{}, resp interface{}, err error) {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, _ = l.UnaryServerInterceptor()(context.Background(), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			if status.Code(err) == codes.Unauthenticated {
+				return nil, err
+			}
+			return l.UserUnaryServerInterceptor()(context.WithValue(ctx, auth.ClaimsKey, claims), req, info, func(context.Context, interface{}) (interface{}, error) {
+				return resp, err
+			})
+		})
+	}
+	t.Run("NotMutating", func(t *testing.T) {
+		intercept("/workflow.WorkflowService/ListWorkflows", &workflowpkg.WorkflowListRequest{Namespace: "my-ns"}, nil, nil)
+		intercept("/workflow.WorkflowService/LintWorkflow", &workflowpkg.WorkflowLintRequest{Namespace: "my-ns"}, nil, nil)
+		assert.Empty(t, sink.events)
+	})
+	t.Run("Success", func(t *testing.T) {
+		sink.events = nil
+		req := &workflowpkg.WorkflowCreateRequest{Namespace: "my-ns", Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: "my-wf-"}}}
+		intercept("/workflow.WorkflowService/CreateWorkflow", req, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf-abc", Namespace: "my-ns"}}, nil)
+		if assert.Len(t, sink.events, 1) {
+			e := sink.events[0]
+			assert.Equal(t, User{Subject: "my-sub", Email: "me@example.com", ServiceAccount: "my-sa"}, e.User)
+			assert.Equal(t, "create", e.Verb)
+			assert.Equal(t, "Workflow", e.Kind)
+			assert.Equal(t, "my-ns", e.Namespace)
+			assert.Equal(t, "my-wf-abc", e.Name)
+			assert.Equal(t, ResultSuccess, e.Result)
+			assert.Equal(t, "OK", e.Code)
+			assert.Contains(t, string(e.Request), `"generateName":"my-wf-"`)
+		}
+	})
+	t.Run("Redacted", func(t *testing.T) {
+		sink.events = nil
+		req := &workflowpkg.WorkflowResubmitRequest{Namespace: "my-ns", Name: "my-wf", Memoized: true, Parameters: []string{"password=my-password"}}
+		intercept("/workflow.WorkflowService/ResubmitWorkflow", req, nil, nil)
+		if assert.Len(t, sink.events, 1) {
+			assert.JSONEq(t, `{"namespace":"my-ns","name":"my-wf","memoized":true,"parameters":["[REDACTED]"]}`, string(sink.events[0].Request))
+		}
+	})
+	t.Run("Unauthenticated", func(t *testing.T) {
+		sink.events = nil
+		intercept("/workflow.WorkflowService/DeleteWorkflow", &workflowpkg.WorkflowDeleteRequest{Namespace: "my-ns", Name: "my-wf"}, nil, status.Error(codes.Unauthenticated, "token not valid"))
+		if assert.Len(t, sink.events, 1) {
+			e := sink.events[0]
+			assert.Empty(t, e.User)
+			assert.Equal(t, ResultFailure, e.Result)
+			assert.Equal(t, "Unauthenticated", e.Code)
+		}
+	})
+	t.Run("Failure", func(t *testing.T) {
+		sink.events = nil
+		intercept("/workflow.WorkflowService/DeleteWorkflow", &workflowpkg.WorkflowDeleteRequest{Namespace: "my-ns", Name: "my-wf"}, nil, status.Error(codes.NotFound, "not found"))
+		if assert.Len(t, sink.events, 1) {
+			e := sink.events[0]
+			assert.Equal(t, "delete", e.Verb)
+			assert.Equal(t, "my-wf", e.Name)
+			assert.Equal(t, ResultFailure, e.Result)
+			assert.Equal(t, "NotFound", e.Code)
+			assert.Equal(t, "not found", e.Error)
+		}
+	})
+}
+
+func TestNewSink(t *testing.T) {
+	e := Event{Verb: "update", Method: "/workflow.WorkflowService/RetryWorkflow", Result: ResultSuccess, Code: "OK"}
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		s, err := NewSink(config.AuditSink{Type: config.AuditSinkFile, Path: path})
+		if assert.NoError(t, err) {
+			assert.NoError(t, s.Write(context.Background(), e))
+			assert.NoError(t, s.Write(context.Background(), e))
+			data, err := os.ReadFile(path)
+			if assert.NoError(t, err) {
+				line := `{"time":"0001-01-01T00:00:00Z","user":{},"verb":"update","method":"/workflow.WorkflowService/RetryWorkflow","result":"Success","code":"OK"}` + "\n"
+				assert.Equal(t, line+line, string(data))
+			}
+		}
+	})
+	t.Run("Webhook", func(t *testing.T) {
+		received := make(chan Event, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+			data, _ := io.ReadAll(r.Body)
+			var e Event
+			_ = json.Unmarshal(data, &e)
+			received <- e
+		}))
+		defer server.Close()
+		s, err := NewSink(config.AuditSink{Type: config.AuditSinkWebhook, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer my-token"}})
+		if assert.NoError(t, err) {
+			// the event is posted in the background
+			assert.NoError(t, s.Write(context.Background(), e))
+			assert.Equal(t, e, <-received)
+		}
+	})
+	t.Run("WebhookQueueFull", func(t *testing.T) {
+		s := &webhookSink{events: make(chan Event, 1)}
+		assert.NoError(t, s.Write(context.Background(), e))
+		assert.EqualError(t, s.Write(context.Background(), e), "audit webhook queue is full")
+	})
+	t.Run("WebhookError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		s := &webhookSink{url: server.URL, client: http.DefaultClient}
+		assert.EqualError(t, s.post(e), "audit webhook responded with 500 Internal Server Error")
+	})
+}
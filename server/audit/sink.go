@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+// Sink is where audit events are written to
+type Sink interface {
+	Write(ctx context.Context, e Event) error
+}
+
+// NewSink returns the sink for the config
+func NewSink(c config.AuditSink) (Sink, error) {
+	switch c.Type {
+	case config.AuditSinkStdout:
+		return &writerSink{w: os.Stdout}, nil
+	case config.AuditSinkFile:
+		if c.Path == "" {
+			return nil, fmt.Errorf("audit sink of type %q must have a path", c.Type)
+		}
+		f, err := os.OpenFile(c.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log file: %w", err)
+		}
+		return &writerSink{w: f}, nil
+	case config.AuditSinkWebhook:
+		if c.URL == "" {
+			return nil, fmt.Errorf("audit sink of type %q must have a url", c.Type)
+		}
+		s := &webhookSink{url: c.URL, headers: c.Headers, client: &http.Client{Timeout: c.GetTimeout()}, events: make(chan Event, webhookQueueSize)}
+		go s.run()
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink type %q, must be one of: %s, %s, %s", c.Type, config.AuditSinkStdout, config.AuditSinkFile, config.AuditSinkWebhook)
+	}
+}
+
+// writerSink writes events as JSON lines
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *writerSink) Write(_ context.Context, e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// webhookQueueSize is the number of events that may be waiting to be posted, before events are dropped
+const webhookQueueSize = 1000
+
+// webhookSink posts each event as JSON. Events are queued and posted in the background, so that a slow webhook does not
+// slow down the API calls.
+type webhookSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+	events  chan Event
+}
+
+func (s *webhookSink) Write(_ context.Context, e Event) error {
+	select {
+	case s.events <- e:
+		return nil
+	default:
+		return fmt.Errorf("audit webhook queue is full")
+	}
+}
+
+func (s *webhookSink) run() {
+	for e := range s.events {
+		if err := s.post(e); err != nil {
+			// fallback so that the event is not lost
+			log.WithError(err).WithFields(log.Fields{"method": e.Method, "namespace": e.Namespace, "name": e.Name, "subject": e.User.Subject}).Error("failed to post audit event")
+		}
+	}
+}
+
+func (s *webhookSink) post(e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("audit webhook responded with %s", resp.Status)
+	}
+	return nil
+}
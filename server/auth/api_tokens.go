@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth/apitoken"
+	"github.com/argoproj/argo-workflows/v3/server/auth/rbac"
+	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	"github.com/argoproj/argo-workflows/v3/util/httputil"
+)
+
+// defaultAPITokenExpiry is how long API tokens are valid for, unless requested
+const defaultAPITokenExpiry = time.Hour
+
+// CreateAPITokenRequest is the request to create an API token
+type CreateAPITokenRequest struct {
+	// Name describes what the token is used for, e.g. "my-repo CI"
+	Name      string   `json:"name,omitempty"`
+	Namespace string   `json:"namespace"`
+	Verbs     []string `json:"verbs"`
+	// ExpiresIn is how long the token is valid for, e.g. "30m". Defaults to 1h.
+	ExpiresIn string `json:"expiresIn,omitempty"`
+}
+
+// CreateAPITokenResponse is the created token, and its authorization, which is never returned again
+type CreateAPITokenResponse struct {
+	// Authorization is the value of the Authorization header to use the token with, e.g. as ARGO_TOKEN
+	Authorization string `json:"authorization"`
+	apitoken.Token
+}
+
+// NewAPITokensHandler returns a handler that allows users with an SSO token to create, list and revoke their API tokens:
+//
+//	POST /api-tokens
+//	GET /api-tokens
+//	DELETE /api-tokens/{id}
+func NewAPITokensHandler(ssoIf sso.Interface, store *apitoken.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		config := ssoIf.APITokens()
+		if !config.IsEnabled() {
+			http.Error(w, "API tokens are not enabled", http.StatusNotFound)
+			return
+		}
+		claims, err := authorizeSSO(ssoIf, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api-tokens"), "/")
+		switch {
+		case r.Method == http.MethodPost && id == "":
+			req := CreateAPITokenRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+				return
+			}
+			token, err := newAPIToken(req, claims, config.GetMaxExpiry())
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if rules := ssoIf.RBACRules(); ssoIf.IsRBACEnabled() && len(rules) > 0 {
+				for _, verb := range token.Verbs {
+					if rbac.Authorize(rules, claims, token.Namespace, verb) == nil {
+						http.Error(w, fmt.Sprintf("no RBAC rule allows %q in namespace %q", verb, token.Namespace), http.StatusForbidden)
+						return
+					}
+				}
+			}
+			authorization, created, err := store.Create(r.Context(), *token)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			// important! write an audit entry (i.e. log entry) so we know which user created a token
+			log.WithFields(addClaimsLogFields(claims, log.Fields{"id": created.ID, "name": created.Name, "namespace": created.Namespace, "verbs": created.Verbs, "expiresAt": created.ExpiresAt})).Info("created API token")
+			httputil.WriteJSON(w, http.StatusCreated, CreateAPITokenResponse{Authorization: authorization, Token: *created})
+		case r.Method == http.MethodGet && id == "":
+			tokens, err := store.List(r.Context(), claims.Subject)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			httputil.WriteJSON(w, http.StatusOK, tokens)
+		case r.Method == http.MethodDelete && id != "":
+			if err := store.Revoke(r.Context(), claims.Subject, id); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			log.WithFields(addClaimsLogFields(claims, log.Fields{"id": id})).Info("revoked API token")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// newAPIToken returns the token the request is for, or an error if it is not narrowly scoped
+func newAPIToken(req CreateAPITokenRequest, claims *types.Claims, maxExpiry time.Duration) (*apitoken.Token, error) {
+	if req.Namespace == "" || req.Namespace == rbac.VerbAny {
+		return nil, fmt.Errorf("namespace must be specified")
+	}
+	if len(req.Verbs) == 0 {
+		return nil, fmt.Errorf("verbs must be specified")
+	}
+	for _, verb := range req.Verbs {
+		switch verb {
+		case rbac.VerbGet, rbac.VerbList, rbac.VerbWatch, rbac.VerbCreate, rbac.VerbUpdate, rbac.VerbDelete:
+		default:
+			return nil, fmt.Errorf("invalid verb %q", verb)
+		}
+	}
+	expiresIn := defaultAPITokenExpiry
+	if req.ExpiresIn != "" {
+		var err error
+		expiresIn, err = time.ParseDuration(req.ExpiresIn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiresIn: %w", err)
+		}
+	}
+	if expiresIn <= 0 || expiresIn > maxExpiry {
+		return nil, fmt.Errorf("expiresIn must be greater than 0s and at most %v", maxExpiry)
+	}
+	return &apitoken.Token{
+		Name:      req.Name,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		Groups:    claims.Groups,
+		Namespace: req.Namespace,
+		Verbs:     req.Verbs,
+		ExpiresAt: time.Now().Add(expiresIn).UTC(),
+	}, nil
+}
+
+// authorizeSSO returns the claims of the request's SSO token. Other tokens, including API tokens, are not accepted.
+func authorizeSSO(ssoIf sso.Interface, r *http.Request) (*types.Claims, error) {
+	md := metadata.New(map[string]string{"authorization": r.Header.Get("Authorization")})
+	if r.Header.Get("Authorization") == "" {
+		md = metadata.New(map[string]string{"cookie": r.Header.Get("Cookie")})
+	}
+	for _, token := range getAuthHeaders(md) {
+		if strings.HasPrefix(token, sso.Prefix) {
+			return ssoIf.Authorize(token)
+		}
+	}
+	return nil, fmt.Errorf("an SSO token is required")
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth/apitoken"
+	ssomocks "github.com/argoproj/argo-workflows/v3/server/auth/sso/mocks"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+func TestNewAPITokensHandler(t *testing.T) {
+	rules := []config.RBACRule{
+		{Name: "developers", Groups: []string{"my-group"}, Namespaces: []string{"my-ns"}, Verbs: []string{"get", "list", "create"}},
+	}
+	ssoIf := &ssomocks.Interface{}
+	ssoIf.On("APITokens").Return(&config.APITokensConfig{Enabled: true})
+	ssoIf.On("IsRBACEnabled").Return(true)
+	ssoIf.On("RBACRules").Return(rules)
+	ssoIf.On("Authorize", "Bearer v2:whatever").Return(&types.Claims{Claims: jwt.Claims{Subject: "my-sub"}, Groups: []string{"my-group"}}, nil)
+	store := apitoken.NewStore(kubefake.NewSimpleClientset().CoreV1().Secrets("argo"))
+	handler := NewAPITokensHandler(ssoIf, store)
+	request := func(method, target, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer v2:whatever")
+		handler(w, r)
+		return w
+	}
+	t.Run("Unauthenticated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api-tokens", nil)
+		r.Header.Set("Authorization", "Bearer api:whatever")
+		handler(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/api-tokens", `{"verbs":["create"]}`).Code)
+		assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/api-tokens", `{"namespace":"*","verbs":["create"]}`).Code)
+		assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/api-tokens", `{"namespace":"my-ns","verbs":["*"]}`).Code)
+		w := request(http.MethodPost, "/api-tokens", `{"namespace":"my-ns","verbs":["create"],"expiresIn":"48h"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "expiresIn must be greater than 0s and at most 24h0m0s\n", w.Body.String())
+	})
+	t.Run("NotAllowed", func(t *testing.T) {
+		w := request(http.MethodPost, "/api-tokens", `{"namespace":"my-ns","verbs":["delete"]}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Equal(t, http.StatusForbidden, request(http.MethodPost, "/api-tokens", `{"namespace":"other-ns","verbs":["get"]}`).Code)
+	})
+	var created CreateAPITokenResponse
+	t.Run("Create", func(t *testing.T) {
+		w := request(http.MethodPost, "/api-tokens", `{"name":"my-ci","namespace":"my-ns","verbs":["create"],"expiresIn":"30m"}`)
+		if assert.Equal(t, http.StatusCreated, w.Code) && assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created)) {
+			assert.True(t, strings.HasPrefix(created.Authorization, apitoken.Prefix))
+			assert.Equal(t, "my-sub", created.Subject)
+			assert.Equal(t, []string{"my-group"}, created.Groups)
+			assert.Equal(t, "my-ci", created.Name)
+		}
+	})
+	t.Run("List", func(t *testing.T) {
+		w := request(http.MethodGet, "/api-tokens", "")
+		var tokens []apitoken.Token
+		if assert.Equal(t, http.StatusOK, w.Code) && assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens)) && assert.Len(t, tokens, 1) {
+			assert.Equal(t, created.ID, tokens[0].ID)
+		}
+		assert.NotContains(t, w.Body.String(), created.Authorization)
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, request(http.MethodDelete, "/api-tokens/"+created.ID, "").Code)
+		assert.Equal(t, http.StatusNotFound, request(http.MethodDelete, "/api-tokens/"+created.ID, "").Code)
+		assert.JSONEq(t, `[]`, request(http.MethodGet, "/api-tokens", "").Body.String())
+	})
+	t.Run("NotEnabled", func(t *testing.T) {
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("APITokens").Return(nil)
+		w := httptest.NewRecorder()
+		NewAPITokensHandler(ssoIf, store)(w, httptest.NewRequest(http.MethodGet, "/api-tokens", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
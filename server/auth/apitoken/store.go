@@ -0,0 +1,278 @@
+package apitoken
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+const (
+	// Prefix is the prefix of the authorization of API tokens, e.g. "Bearer api:0123456789abcdef.<secret>"
+	Prefix     = "Bearer api:"
+	secretName = "argo-server-api-tokens" // where we store the tokens, keyed by their ID
+)
+
+// Token is an API token an SSO user created, which allows its verbs in its namespace until it expires. The user's
+// own permissions still apply, so it never allows more than they are allowed.
+type Token struct {
+	ID string `json:"id"`
+	// Name describes what the token is used for, e.g. "my-repo CI"
+	Name      string    `json:"name,omitempty"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email,omitempty"`
+	Groups    []string  `json:"groups,omitempty"`
+	Namespace string    `json:"namespace"`
+	Verbs     []string  `json:"verbs"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// record is how a token is stored. Only the hash of its secret is stored, so the token cannot be recovered.
+type record struct {
+	Token
+	SecretHash string `json:"secretHash"`
+}
+
+// Claims returns the claims of the user that created the token
+func (t *Token) Claims() *types.Claims {
+	return &types.Claims{Claims: jwt.Claims{Subject: t.Subject}, Email: t.Email, Groups: t.Groups}
+}
+
+// Expired returns whether the token has expired at the time
+func (t *Token) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// Allows returns whether the token allows the verb in the namespace. Requests without a verb, such as getting the
+// server's info, are allowed in any namespace.
+func (t *Token) Allows(namespace, verb string) bool {
+	if verb == "" {
+		return true
+	}
+	if namespace != t.Namespace {
+		return false
+	}
+	for _, v := range t.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// Store creates, lists, revokes and verifies API tokens, which it stores in a secret. Tokens are verified using an
+// informer of the secret, so that verifying the token of every request does not get the secret from the API server.
+type Store struct {
+	secrets  corev1.SecretInterface
+	informer cache.SharedIndexInformer
+	now      func() time.Time
+}
+
+func NewStore(secrets corev1.SecretInterface) *Store {
+	selector := fields.OneTermEqualSelector("metadata.name", secretName).String()
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = selector
+			return secrets.List(context.Background(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = selector
+			return secrets.Watch(context.Background(), options)
+		},
+	}, &apiv1.Secret{}, 0, cache.Indexers{})
+	return &Store{secrets: secrets, informer: informer, now: time.Now}
+}
+
+// Run runs the informer until the channel is closed, waiting for it to sync before returning
+func (s *Store) Run(stopCh <-chan struct{}) bool {
+	go s.informer.Run(stopCh)
+	return cache.WaitForCacheSync(stopCh, s.informer.HasSynced)
+}
+
+// Create stores the token, setting its ID and creation time, and returns its authorization, which is only ever
+// returned once. It also deletes any expired tokens.
+func (s *Store) Create(ctx context.Context, token Token) (string, *Token, error) {
+	id, err := randomHex(8)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", nil, err
+	}
+	token.ID = id
+	token.CreatedAt = s.now().UTC()
+	data, err := json.Marshal(record{Token: token, SecretHash: hash(secret)})
+	if err != nil {
+		return "", nil, err
+	}
+	err = s.update(ctx, func(tokens map[string][]byte) {
+		for k, v := range tokens {
+			r, err := unmarshal(v)
+			if err != nil || r.Expired(s.now()) {
+				delete(tokens, k)
+			}
+		}
+		tokens[token.ID] = data
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return Prefix + id + "." + secret, &token, nil
+}
+
+// List returns the unexpired tokens of the user with the subject, oldest first
+func (s *Store) List(ctx context.Context, subject string) ([]Token, error) {
+	tokens, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := []Token{}
+	for _, data := range tokens {
+		r, err := unmarshal(data)
+		if err != nil || r.Subject != subject || r.Expired(s.now()) {
+			continue
+		}
+		list = append(list, r.Token)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	return list, nil
+}
+
+// Revoke deletes the token with the ID, if it is one of the user with the subject
+func (s *Store) Revoke(ctx context.Context, subject, id string) error {
+	found := false
+	err := s.update(ctx, func(tokens map[string][]byte) {
+		r, err := unmarshal(tokens[id])
+		if err != nil || r.Subject != subject {
+			return
+		}
+		delete(tokens, id)
+		found = true
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("API token %q not found", id)
+	}
+	return nil
+}
+
+// Verify returns the token of the authorization, or an error if it is unknown, revoked or expired. Tokens that were
+// just created or revoked may not be known to the informer for a moment.
+func (s *Store) Verify(authorization string) (*Token, error) {
+	id, secret, ok := strings.Cut(strings.TrimPrefix(authorization, Prefix), ".")
+	if !strings.HasPrefix(authorization, Prefix) || !ok {
+		return nil, fmt.Errorf("invalid API token")
+	}
+	if !s.informer.HasSynced() {
+		return nil, fmt.Errorf("API tokens have not been synced")
+	}
+	tokens := map[string][]byte{}
+	for _, obj := range s.informer.GetStore().List() {
+		if secret, ok := obj.(*apiv1.Secret); ok && secret.Name == secretName {
+			tokens = secret.Data
+		}
+	}
+	data, ok := tokens[id]
+	if !ok {
+		return nil, fmt.Errorf("API token not found, it may have been revoked")
+	}
+	r, err := unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(r.SecretHash), []byte(hash(secret))) != 1 {
+		return nil, fmt.Errorf("invalid API token")
+	}
+	if r.Expired(s.now()) {
+		return nil, fmt.Errorf("API token expired at %s", r.ExpiresAt.Format(time.RFC3339))
+	}
+	return &r.Token, nil
+}
+
+func (s *Store) get(ctx context.Context) (map[string][]byte, error) {
+	secret, err := s.secrets.Get(ctx, secretName, metav1.GetOptions{})
+	if apierr.IsNotFound(err) {
+		return map[string][]byte{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API tokens: %w", err)
+	}
+	return secret.Data, nil
+}
+
+// update applies the change to the stored tokens, creating the secret if it does not exist, and retrying if another
+// replica of the server updated them first
+func (s *Store) update(ctx context.Context, change func(tokens map[string][]byte)) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := s.secrets.Get(ctx, secretName, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			tokens := map[string][]byte{}
+			change(tokens)
+			_, err = s.secrets.Create(ctx, &apiv1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName}, Data: tokens}, metav1.CreateOptions{})
+			if apierr.IsAlreadyExists(err) {
+				// another replica created it, so try again as a conflict
+				return apierr.NewConflict(apiv1.Resource("secrets"), secretName, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		change(secret.Data)
+		_, err = s.secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update API tokens: %w", err)
+	}
+	return nil
+}
+
+func unmarshal(data []byte) (*record, error) {
+	if data == nil {
+		return nil, fmt.Errorf("API token not found")
+	}
+	r := &record{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal API token: %w", err)
+	}
+	return r, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func hash(secret string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(secret)))
+}
@@ -0,0 +1,96 @@
+package apitoken
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	secrets := kubefake.NewSimpleClientset().CoreV1().Secrets("argo")
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewStore(secrets)
+	s.now = func() time.Time { return now }
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	if !assert.True(t, s.Run(stopCh)) {
+		return
+	}
+	authorization, token, err := s.Create(ctx, Token{Name: "my-ci", Subject: "my-sub", Namespace: "my-ns", Verbs: []string{"create"}, ExpiresAt: now.Add(time.Hour)})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, strings.HasPrefix(authorization, Prefix+token.ID+"."))
+	assert.Equal(t, now, token.CreatedAt)
+	t.Run("Stored", func(t *testing.T) {
+		secret, err := secrets.Get(ctx, secretName, metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			// only the hash of the secret is stored
+			assert.NotContains(t, string(secret.Data[token.ID]), strings.SplitN(authorization, ".", 2)[1])
+		}
+	})
+	t.Run("Verify", func(t *testing.T) {
+		// the informer sees the token in a moment
+		assert.Eventually(t, func() bool {
+			_, err := s.Verify(authorization)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		verified, err := s.Verify(authorization)
+		if assert.NoError(t, err) {
+			assert.Equal(t, token, verified)
+		}
+		_, err = s.Verify(authorization + "x")
+		assert.EqualError(t, err, "invalid API token")
+		_, err = s.Verify("Bearer api:unknown.secret")
+		assert.EqualError(t, err, "API token not found, it may have been revoked")
+	})
+	t.Run("List", func(t *testing.T) {
+		tokens, err := s.List(ctx, "my-sub")
+		if assert.NoError(t, err) && assert.Len(t, tokens, 1) {
+			assert.Equal(t, "my-ci", tokens[0].Name)
+		}
+		tokens, err = s.List(ctx, "other-sub")
+		if assert.NoError(t, err) {
+			assert.Empty(t, tokens)
+		}
+	})
+	t.Run("Expired", func(t *testing.T) {
+		s := &Store{secrets: secrets, informer: s.informer, now: func() time.Time { return now.Add(time.Hour) }}
+		_, err := s.Verify(authorization)
+		assert.EqualError(t, err, "API token expired at 2023-01-01T01:00:00Z")
+		tokens, err := s.List(ctx, "my-sub")
+		if assert.NoError(t, err) {
+			assert.Empty(t, tokens)
+		}
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		assert.EqualError(t, s.Revoke(ctx, "other-sub", token.ID), `API token "`+token.ID+`" not found`)
+		if assert.NoError(t, s.Revoke(ctx, "my-sub", token.ID)) {
+			assert.Eventually(t, func() bool {
+				_, err := s.Verify(authorization)
+				return err != nil
+			}, time.Second, 10*time.Millisecond)
+		}
+	})
+}
+
+func TestStore_NotSynced(t *testing.T) {
+	s := NewStore(kubefake.NewSimpleClientset().CoreV1().Secrets("argo"))
+	_, err := s.Verify(Prefix + "my-id.my-secret")
+	assert.EqualError(t, err, "API tokens have not been synced")
+}
+
+func TestToken_Allows(t *testing.T) {
+	token := &Token{Namespace: "my-ns", Verbs: []string{"get", "create"}}
+	assert.True(t, token.Allows("my-ns", "create"))
+	assert.True(t, token.Allows("", ""))
+	assert.False(t, token.Allows("my-ns", "delete"))
+	assert.False(t, token.Allows("other-ns", "get"))
+	assert.False(t, token.Allows("", "list"))
+}
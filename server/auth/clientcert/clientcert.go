@@ -0,0 +1,128 @@
+package clientcert
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
+)
+
+// Header is the HTTP header, and so gRPC metadata, the identity of the client certificate of an HTTP request is
+// forwarded to the gRPC API in
+const Header = "X-Argo-Client-Cert"
+
+// Identity is the Kubernetes user and groups of a verified client certificate, i.e. its common name and
+// organizations, as the Kubernetes API server does
+type Identity struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// the key forwarded identities are signed with, so that gRPC clients cannot forge them
+var key = func() []byte {
+	x := make([]byte, 32)
+	if _, err := rand.Read(x); err != nil {
+		panic(err)
+	}
+	return x
+}()
+
+// ForState returns the identity of the verified client certificate of the connection, or nil if there is none
+func ForState(state tls.ConnectionState) *Identity {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	subject := state.VerifiedChains[0][0].Subject
+	if subject.CommonName == "" {
+		return nil
+	}
+	return &Identity{User: subject.CommonName, Groups: subject.Organization}
+}
+
+type stateKey struct{}
+
+// ConnContext adds the TLS connection state to the context of an HTTP server's connection, which net/http only does for
+// connections it performed the TLS handshake of
+func ConnContext(ctx context.Context, conn net.Conn) context.Context {
+	if state := grpcutil.TLSConnectionState(conn); state != nil {
+		return context.WithValue(ctx, stateKey{}, state)
+	}
+	return ctx
+}
+
+// Forward sets the header of the request to the signed identity of its verified client certificate, if it has one,
+// so that the gRPC API can authenticate requests the HTTP API proxies
+func Forward(r *http.Request) error {
+	r.Header.Del(Header)
+	state := r.TLS
+	if state == nil {
+		state, _ = r.Context().Value(stateKey{}).(*tls.ConnectionState)
+	}
+	if state == nil {
+		return nil
+	}
+	identity := ForState(*state)
+	if identity == nil {
+		return nil
+	}
+	data, err := json.Marshal(identity)
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	r.Header.Set(Header, payload+"."+base64.RawURLEncoding.EncodeToString(sign(payload)))
+	return nil
+}
+
+// FromContext returns the identity of the verified client certificate of a gRPC request, either of its own connection
+// or forwarded by the HTTP API, or nil if there is none
+func FromContext(ctx context.Context) (*Identity, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if identity := ForState(info.State); identity != nil {
+				return identity, nil
+			}
+		}
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(Header) {
+		return verify(value)
+	}
+	return nil, nil
+}
+
+func verify(value string) (*Identity, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 2 {
+		return nil, errors.New("malformed client certificate identity")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, sign(parts[0])) {
+		return nil, errors.New("invalid client certificate identity signature")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	identity := &Identity{}
+	return identity, json.Unmarshal(data, identity)
+}
+
+func sign(payload string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,61 @@
+package clientcert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+var state = tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "my-user", Organization: []string{"my-group"}}}}}}
+
+func TestForState(t *testing.T) {
+	assert.Nil(t, ForState(tls.ConnectionState{}))
+	assert.Equal(t, &Identity{User: "my-user", Groups: []string{"my-group"}}, ForState(state))
+}
+
+func TestForward(t *testing.T) {
+	t.Run("NoClientCert", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{Header: []string{"forged"}}}
+		assert.NoError(t, Forward(r))
+		assert.Empty(t, r.Header.Get(Header))
+	})
+	t.Run("ClientCert", func(t *testing.T) {
+		r := &http.Request{Header: http.Header{}, TLS: &state}
+		assert.NoError(t, Forward(r))
+		identity, err := FromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, r.Header.Get(Header))))
+		if assert.NoError(t, err) {
+			assert.Equal(t, &Identity{User: "my-user", Groups: []string{"my-group"}}, identity)
+		}
+	})
+	t.Run("ConnContext", func(t *testing.T) {
+		r := (&http.Request{Header: http.Header{}}).WithContext(context.WithValue(context.Background(), stateKey{}, &state))
+		assert.NoError(t, Forward(r))
+		assert.NotEmpty(t, r.Header.Get(Header))
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		identity, err := FromContext(context.Background())
+		assert.NoError(t, err)
+		assert.Nil(t, identity)
+	})
+	t.Run("Peer", func(t *testing.T) {
+		identity, err := FromContext(peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}}))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-user", identity.User)
+		}
+	})
+	t.Run("Forged", func(t *testing.T) {
+		_, err := FromContext(metadata.NewIncomingContext(context.Background(), metadata.Pairs(Header, "eyJ1c2VyIjoiYWRtaW4ifQ.AAAA")))
+		assert.EqualError(t, err, "invalid client certificate identity signature")
+	})
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"net/http"
 	"os"
@@ -10,11 +11,13 @@ import (
 
 	eventsource "github.com/argoproj/argo-events/pkg/client/eventsource/clientset/versioned"
 	sensor "github.com/argoproj/argo-events/pkg/client/sensor/clientset/versioned"
+	"github.com/go-jose/go-jose/v3/jwt"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/dynamic"
@@ -22,6 +25,9 @@ import (
 	"k8s.io/client-go/rest"
 
 	workflow "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/server/auth/apitoken"
+	"github.com/argoproj/argo-workflows/v3/server/auth/clientcert"
+	"github.com/argoproj/argo-workflows/v3/server/auth/rbac"
 	"github.com/argoproj/argo-workflows/v3/server/auth/serviceaccount"
 	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
@@ -53,7 +59,7 @@ type Gatekeeper interface {
 	StreamServerInterceptor() grpc.StreamServerInterceptor
 }
 
-type ClientForAuthorization func(authorization string) (*rest.Config, *servertypes.Clients, error)
+type ClientForAuthorization func(authorization string, impersonate rest.ImpersonationConfig) (*rest.Config, *servertypes.Clients, error)
 
 type gatekeeper struct {
 	Modes Modes
@@ -67,9 +73,10 @@ type gatekeeper struct {
 	ssoNamespace string
 	namespaced   bool
 	cache        *cache.ResourceCache
+	apiTokens    *apitoken.Store
 }
 
-func NewGatekeeper(modes Modes, clients *servertypes.Clients, restConfig *rest.Config, ssoIf sso.Interface, clientForAuthorization ClientForAuthorization, namespace string, ssoNamespace string, namespaced bool, cache *cache.ResourceCache) (Gatekeeper, error) {
+func NewGatekeeper(modes Modes, clients *servertypes.Clients, restConfig *rest.Config, ssoIf sso.Interface, clientForAuthorization ClientForAuthorization, namespace string, ssoNamespace string, namespaced bool, cache *cache.ResourceCache, apiTokens *apitoken.Store) (Gatekeeper, error) {
 	if len(modes) == 0 {
 		return nil, fmt.Errorf("must specify at least one auth mode")
 	}
@@ -83,6 +90,7 @@ func NewGatekeeper(modes Modes, clients *servertypes.Clients, restConfig *rest.C
 		ssoNamespace,
 		namespaced,
 		cache,
+		apiTokens,
 	}, nil
 
 }
@@ -146,6 +154,24 @@ func GetClaims(ctx context.Context) *types.Claims {
 	return config
 }
 
+// GetIdentity returns a key that identifies the user of a request, e.g. to rate limit them. It is a hash of the subject
+// of their claims, or of their token if the claims have no subject. It returns "" for requests without a token, such as
+// those using the server auth mode.
+func GetIdentity(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authorization := range getAuthHeaders(md) {
+		if authorization == "" {
+			continue
+		}
+		identity := authorization
+		if claims := GetClaims(ctx); claims != nil && claims.Subject != "" {
+			identity = claims.Subject
+		}
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(identity)))
+	}
+	return ""
+}
+
 func getAuthHeaders(md metadata.MD) []string {
 	// looks for the HTTP header `Authorization: Bearer ...`
 	for _, t := range md.Get("authorization") {
@@ -187,42 +213,118 @@ func (s gatekeeper) getClients(ctx context.Context, req interface{}) (*servertyp
 			break
 		}
 	}
+	// a verified client certificate is used unless there is a valid token
+	var identity *clientcert.Identity
+	if s.Modes[ClientCert] && (!valid || mode == Server) {
+		var err error
+		identity, err = clientcert.FromContext(ctx)
+		if err != nil {
+			return nil, nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if identity != nil {
+			mode, valid = ClientCert, true
+		}
+	}
 	if !valid {
 		return nil, nil, status.Error(codes.Unauthenticated, "token not valid for running mode")
 	}
+	impersonate := getImpersonationConfig(md)
+	if mode != Client && (impersonate.UserName != "" || len(impersonate.Groups) > 0) {
+		return nil, nil, status.Error(codes.PermissionDenied, "impersonation is only supported with the client auth mode")
+	}
 	switch mode {
 	case Client:
-		restConfig, clients, err := s.clientForAuthorization(authorization)
+		restConfig, clients, err := s.clientForAuthorization(authorization, impersonate)
 		if err != nil {
 			return nil, nil, status.Error(codes.Unauthenticated, err.Error())
 		}
 		claims, _ := serviceaccount.ClaimSetFor(restConfig)
+		if impersonate.UserName != "" {
+			// important! write an audit entry (i.e. log entry) so we know which user impersonated another
+			fields := log.Fields{"impersonateUser": impersonate.UserName, "impersonateGroups": impersonate.Groups}
+			if claims != nil {
+				fields = addClaimsLogFields(claims, fields)
+			}
+			log.WithFields(fields).Info("impersonating user")
+		}
 		return clients, claims, nil
 	case Server:
 		claims, _ := serviceaccount.ClaimSetFor(s.restConfig)
 		return s.clients, claims, nil
+	case ClientCert:
+		// like the Kubernetes API server, the common name is the user, and the organizations are the groups
+		restConfig := rest.CopyConfig(s.restConfig)
+		restConfig.Impersonate = rest.ImpersonationConfig{UserName: identity.User, Groups: identity.Groups}
+		clients, err := clientsForRestConfig(restConfig)
+		if err != nil {
+			return nil, nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		claims := &types.Claims{Claims: jwt.Claims{Subject: identity.User}, Groups: identity.Groups}
+		// important! write an audit entry (i.e. log entry) so we know which user performed an operation
+		log.WithFields(addClaimsLogFields(claims, log.Fields{"groups": identity.Groups})).Info("using the client certificate's user")
+		return clients, claims, nil
 	case SSO:
 		claims, err := s.ssoIf.Authorize(authorization)
 		if err != nil {
 			return nil, nil, status.Error(codes.Unauthenticated, err.Error())
 		}
-		if s.ssoIf.IsRBACEnabled() {
-			clients, err := s.rbacAuthorization(ctx, claims, req)
-			if err != nil {
-				log.WithError(err).Error("failed to perform RBAC authorization")
-				return nil, nil, status.Error(codes.PermissionDenied, "not allowed")
-			}
-			return clients, claims, nil
-		} else {
-			// important! write an audit entry (i.e. log entry) so we know which user performed an operation
-			log.WithFields(addClaimsLogFields(claims, nil)).Info("using the default service account for user")
-			return s.clients, claims, nil
+		return s.ssoClients(ctx, claims, req)
+	case APIToken:
+		if s.apiTokens == nil || !s.ssoIf.APITokens().IsEnabled() {
+			return nil, nil, status.Error(codes.Unauthenticated, "API tokens are not enabled")
+		}
+		token, err := s.apiTokens.Verify(authorization)
+		if err != nil {
+			return nil, nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		method, _ := grpc.Method(ctx)
+		namespace, verb := getNamespace(req), rbac.VerbForMethod(method)
+		if !token.Allows(namespace, verb) {
+			// important! write an audit entry (i.e. log entry) so we know which token was used
+			log.WithFields(log.Fields{"apiToken": token.ID, "subject": token.Subject, "namespace": namespace, "verb": verb, "method": method}).Info("API token does not allow request")
+			return nil, nil, status.Errorf(codes.PermissionDenied, "API token does not allow %q in namespace %q", verb, namespace)
 		}
+		log.WithFields(log.Fields{"apiToken": token.ID, "subject": token.Subject, "namespace": namespace, "verb": verb}).Info("using API token")
+		// the user's own permissions still apply
+		return s.ssoClients(ctx, token.Claims(), req)
 	default:
 		panic("this should never happen")
 	}
 }
 
+// ssoClients returns the clients to perform the request of the SSO user with the claims
+func (s *gatekeeper) ssoClients(ctx context.Context, claims *types.Claims, req interface{}) (*servertypes.Clients, *types.Claims, error) {
+	if s.ssoIf.IsRBACEnabled() && len(s.ssoIf.RBACRules()) > 0 {
+		clients, err := s.ruleAuthorization(ctx, claims, req)
+		if err != nil {
+			log.WithError(err).Error("failed to perform RBAC rule authorization")
+			return nil, nil, status.Error(codes.PermissionDenied, "not allowed")
+		}
+		return clients, claims, nil
+	} else if s.ssoIf.IsRBACEnabled() {
+		clients, err := s.rbacAuthorization(ctx, claims, req)
+		if err != nil {
+			log.WithError(err).Error("failed to perform RBAC authorization")
+			return nil, nil, status.Error(codes.PermissionDenied, "not allowed")
+		}
+		return clients, claims, nil
+	} else {
+		// important! write an audit entry (i.e. log entry) so we know which user performed an operation
+		log.WithFields(addClaimsLogFields(claims, nil)).Info("using the default service account for user")
+		return s.clients, claims, nil
+	}
+}
+
+// getImpersonationConfig returns the user and groups to impersonate from the Kubernetes impersonation headers, which
+// the Kubernetes API authorizes against the user's own token
+func getImpersonationConfig(md metadata.MD) rest.ImpersonationConfig {
+	impersonate := rest.ImpersonationConfig{Groups: md.Get(authenticationv1.ImpersonateGroupHeader)}
+	for _, user := range md.Get(authenticationv1.ImpersonateUserHeader) {
+		impersonate.UserName = user
+	}
+	return impersonate
+}
+
 func getNamespace(req interface{}) string {
 	if req == nil {
 		return ""
@@ -284,7 +386,7 @@ func (s *gatekeeper) getClientsForServiceAccount(ctx context.Context, claims *ty
 	if err != nil {
 		return nil, err
 	}
-	_, clients, err := s.clientForAuthorization(authorization)
+	_, clients, err := s.clientForAuthorization(authorization, rest.ImpersonationConfig{})
 	if err != nil {
 		return nil, err
 	}
@@ -315,6 +417,31 @@ func (s *gatekeeper) rbacAuthorization(ctx context.Context, claims *types.Claims
 	return s.getClientsForServiceAccount(ctx, claims, delegatedAccount)
 }
 
+// ruleAuthorization returns the clients of the rule's service account if one of the RBAC rules allows the user to
+// perform the request
+func (s *gatekeeper) ruleAuthorization(ctx context.Context, claims *types.Claims, req interface{}) (*servertypes.Clients, error) {
+	method, _ := grpc.Method(ctx)
+	verb := rbac.VerbForMethod(method)
+	if verb == "" {
+		return s.clients, nil
+	}
+	namespace := getNamespace(req)
+	rule := rbac.Authorize(s.ssoIf.RBACRules(), claims, namespace, verb)
+	// important! write an audit entry (i.e. log entry) so we know which user performed an operation
+	fields := addClaimsLogFields(claims, log.Fields{"groups": claims.Groups, "namespace": namespace, "verb": verb, "method": method})
+	if rule == nil {
+		log.WithFields(fields).Info("no RBAC rule allows user")
+		return nil, fmt.Errorf("no RBAC rule allows %q in namespace %q", verb, namespace)
+	}
+	fields["rule"] = rule.Name
+	log.WithFields(fields).Info("RBAC rule allows user")
+	serviceAccount, err := s.cache.ServiceAccountLister.ServiceAccounts(s.ssoNamespace).Get(rule.ServiceAccountName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account of RBAC rule %q: %w", rule.Name, err)
+	}
+	return s.getClientsForServiceAccount(ctx, claims, serviceAccount)
+}
+
 func (s *gatekeeper) authorizationForServiceAccount(ctx context.Context, serviceAccount *corev1.ServiceAccount) (string, error) {
 	if len(serviceAccount.Secrets) == 0 {
 		return "", fmt.Errorf("expected at least one secret for SSO RBAC service account: %s", serviceAccount.GetName())
@@ -337,32 +464,41 @@ func addClaimsLogFields(claims *types.Claims, fields log.Fields) log.Fields {
 	return fields
 }
 
-func DefaultClientForAuthorization(authorization string) (*rest.Config, *servertypes.Clients, error) {
+func DefaultClientForAuthorization(authorization string, impersonate rest.ImpersonationConfig) (*rest.Config, *servertypes.Clients, error) {
 	restConfig, err := kubeconfig.GetRestConfig(authorization)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create REST config: %w", err)
 	}
+	restConfig.Impersonate = impersonate
+	clients, err := clientsForRestConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return restConfig, clients, nil
+}
+
+func clientsForRestConfig(restConfig *rest.Config) (*servertypes.Clients, error) {
 	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failure to create dynamic client: %w", err)
+		return nil, fmt.Errorf("failure to create dynamic client: %w", err)
 	}
 	wfClient, err := workflow.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failure to create workflow client: %w", err)
+		return nil, fmt.Errorf("failure to create workflow client: %w", err)
 	}
 	eventSourceClient, err := eventsource.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failure to create event source client: %w", err)
+		return nil, fmt.Errorf("failure to create event source client: %w", err)
 	}
 	sensorClient, err := sensor.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failure to create sensor client: %w", err)
+		return nil, fmt.Errorf("failure to create sensor client: %w", err)
 	}
 	kubeClient, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failure to create kubernetes client: %w", err)
+		return nil, fmt.Errorf("failure to create kubernetes client: %w", err)
 	}
-	return restConfig, &servertypes.Clients{
+	return &servertypes.Clients{
 		Dynamic:     dynamicClient,
 		Workflow:    wfClient,
 		Sensor:      sensorClient,
@@ -2,21 +2,32 @@ package auth
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	log "github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth/apitoken"
+	"github.com/argoproj/argo-workflows/v3/server/auth/clientcert"
 	ssomocks "github.com/argoproj/argo-workflows/v3/server/auth/sso/mocks"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 	"github.com/argoproj/argo-workflows/v3/server/cache"
@@ -107,30 +118,30 @@ func TestServer_GetWFClient(t *testing.T) {
 	)
 	resourceCache := cache.NewResourceCache(kubeClient, corev1.NamespaceAll)
 	resourceCache.Run(context.TODO().Done())
-	var clientForAuthorization ClientForAuthorization = func(authorization string) (*rest.Config, *servertypes.Clients, error) {
+	var clientForAuthorization ClientForAuthorization = func(authorization string, impersonate rest.ImpersonationConfig) (*rest.Config, *servertypes.Clients, error) {
 		return &rest.Config{}, &servertypes.Clients{Workflow: &fakewfclientset.Clientset{}, Kubernetes: &kubefake.Clientset{}}, nil
 	}
 	clients := &servertypes.Clients{Workflow: wfClient, Kubernetes: kubeClient}
 	t.Run("None", func(t *testing.T) {
-		_, err := NewGatekeeper(Modes{}, clients, nil, nil, clientForAuthorization, "", "", true, resourceCache)
+		_, err := NewGatekeeper(Modes{}, clients, nil, nil, clientForAuthorization, "", "", true, resourceCache, nil)
 		assert.Error(t, err)
 	})
 	t.Run("Invalid", func(t *testing.T) {
-		g, err := NewGatekeeper(Modes{Client: true}, clients, nil, nil, clientForAuthorization, "", "", true, resourceCache)
+		g, err := NewGatekeeper(Modes{Client: true}, clients, nil, nil, clientForAuthorization, "", "", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			_, err := g.Context(x("invalid"))
 			assert.Error(t, err)
 		}
 	})
 	t.Run("NotAllowed", func(t *testing.T) {
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, nil, clientForAuthorization, "", "", true, resourceCache)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, nil, clientForAuthorization, "", "", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			_, err := g.Context(x("Bearer "))
 			assert.Error(t, err)
 		}
 	})
 	t.Run("Client", func(t *testing.T) {
-		g, err := NewGatekeeper(Modes{Client: true}, clients, &rest.Config{Username: "my-username"}, nil, clientForAuthorization, "", "", true, resourceCache)
+		g, err := NewGatekeeper(Modes{Client: true}, clients, &rest.Config{Username: "my-username"}, nil, clientForAuthorization, "", "", true, resourceCache, nil)
 		assert.NoError(t, err)
 		ctx, err := g.Context(x("Bearer "))
 		if assert.NoError(t, err) {
@@ -139,8 +150,27 @@ func TestServer_GetWFClient(t *testing.T) {
 			assert.Nil(t, GetClaims(ctx))
 		}
 	})
+	t.Run("ClientImpersonate", func(t *testing.T) {
+		var impersonated rest.ImpersonationConfig
+		g, err := NewGatekeeper(Modes{Client: true}, clients, nil, nil, func(authorization string, impersonate rest.ImpersonationConfig) (*rest.Config, *servertypes.Clients, error) {
+			impersonated = impersonate
+			return clientForAuthorization(authorization, impersonate)
+		}, "", "", true, resourceCache, nil)
+		assert.NoError(t, err)
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer ", "impersonate-user", "my-user", "impersonate-group", "my-group", "impersonate-group", "my-other-group"))
+		_, err = g.Context(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, rest.ImpersonationConfig{UserName: "my-user", Groups: []string{"my-group", "my-other-group"}}, impersonated)
+		}
+	})
+	t.Run("ServerImpersonate", func(t *testing.T) {
+		g, err := NewGatekeeper(Modes{Server: true}, clients, &rest.Config{Username: "my-username"}, nil, clientForAuthorization, "", "", true, resourceCache, nil)
+		assert.NoError(t, err)
+		_, err = g.Context(metadata.NewIncomingContext(context.Background(), metadata.Pairs("impersonate-user", "my-user")))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
 	t.Run("Server", func(t *testing.T) {
-		g, err := NewGatekeeper(Modes{Server: true}, clients, &rest.Config{Username: "my-username"}, nil, clientForAuthorization, "", "", true, resourceCache)
+		g, err := NewGatekeeper(Modes{Server: true}, clients, &rest.Config{Username: "my-username"}, nil, clientForAuthorization, "", "", true, resourceCache, nil)
 		assert.NoError(t, err)
 		ctx, err := g.Context(x(""))
 		if assert.NoError(t, err) {
@@ -149,11 +179,29 @@ func TestServer_GetWFClient(t *testing.T) {
 			assert.NotNil(t, GetClaims(ctx))
 		}
 	})
+	t.Run("ClientCert", func(t *testing.T) {
+		g, err := NewGatekeeper(Modes{Server: true, ClientCert: true}, clients, &rest.Config{Username: "my-username"}, nil, clientForAuthorization, "", "", true, resourceCache, nil)
+		assert.NoError(t, err)
+		r := &http.Request{Header: http.Header{}, TLS: &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "my-user", Organization: []string{"my-group"}}}}}}}
+		assert.NoError(t, clientcert.Forward(r))
+		ctx, err := g.Context(metadata.NewIncomingContext(context.Background(), metadata.Pairs(clientcert.Header, r.Header.Get(clientcert.Header))))
+		if assert.NoError(t, err) {
+			assert.NotEqual(t, wfClient, GetWfClient(ctx))
+			assert.Equal(t, "my-user", GetClaims(ctx).Subject)
+			assert.Equal(t, []string{"my-group"}, GetClaims(ctx).Groups)
+		}
+		_, err = g.Context(metadata.NewIncomingContext(context.Background(), metadata.Pairs(clientcert.Header, "forged.identity")))
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		ctx, err = g.Context(x(""))
+		if assert.NoError(t, err) {
+			assert.Equal(t, wfClient, GetWfClient(ctx))
+		}
+	})
 	t.Run("SSO", func(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Claims: jwt.Claims{Subject: "my-sub"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(false)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.Context(x("Bearer v2:whatever"))
 			if assert.NoError(t, err) {
@@ -172,7 +220,8 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"my-group", "other-group"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.Context(x("Bearer v2:whatever"))
 			if assert.NoError(t, err) {
@@ -193,7 +242,8 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"my-group", "other-group"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", false, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", false, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.ContextWithRequest(x("Bearer v2:whatever"), servertypes.NamespaceHolder("user1-ns"))
 			if assert.NoError(t, err) {
@@ -214,7 +264,8 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"my-group", "other-group"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.ContextWithRequest(x("Bearer v2:whatever"), servertypes.NamespaceHolder("user1-ns"))
 			if assert.NoError(t, err) {
@@ -235,7 +286,8 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"my-group", "other-group"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", false, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", false, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.ContextWithRequest(x("Bearer v2:whatever"), servertypes.NamespaceHolder("user2-ns"))
 			if assert.NoError(t, err) {
@@ -257,7 +309,8 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"my-group", "other-group"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", false, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", false, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.ContextWithRequest(x("Bearer v2:whatever"), servertypes.NamespaceHolder("user3-ns"))
 			if assert.NoError(t, err) {
@@ -278,7 +331,8 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"other-group"}}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			ctx, err := g.Context(x("Bearer v2:whatever"))
 			if assert.NoError(t, err) {
@@ -291,14 +345,136 @@ func TestServer_GetWFClient(t *testing.T) {
 		ssoIf := &ssomocks.Interface{}
 		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{}, nil)
 		ssoIf.On("IsRBACEnabled").Return(true)
-		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache)
+		ssoIf.On("RBACRules").Return(nil)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
 		if assert.NoError(t, err) {
 			_, err := g.Context(x("Bearer v2:whatever"))
 			assert.EqualError(t, err, "rpc error: code = PermissionDenied desc = not allowed")
 		}
 	})
+	rules := []config.RBACRule{
+		{Name: "readers", Groups: []string{"other-group"}, Namespaces: []string{"my-ns"}, Verbs: []string{"get", "list"}, ServiceAccountName: "my-other-sa"},
+		{Name: "admins", Groups: []string{"my-group"}, Namespaces: []string{"*"}, Verbs: []string{"*"}, ServiceAccountName: "my-sa"},
+	}
+	rulesCtx := func(method string) context.Context {
+		return grpc.NewContextWithServerTransportStream(x("Bearer v2:whatever"), &transportStream{method: method})
+	}
+	t.Run("SSO+RBACRules,allowed", func(t *testing.T) {
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"other-group"}}, nil)
+		ssoIf.On("IsRBACEnabled").Return(true)
+		ssoIf.On("RBACRules").Return(rules)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
+		if assert.NoError(t, err) {
+			ctx, err := g.ContextWithRequest(rulesCtx("/workflow.WorkflowService/ListWorkflows"), servertypes.NamespaceHolder("my-ns"))
+			if assert.NoError(t, err) {
+				assert.NotEqual(t, wfClient, GetWfClient(ctx))
+				assert.Equal(t, "my-other-sa", GetClaims(ctx).ServiceAccountName)
+			}
+		}
+	})
+	t.Run("SSO+RBACRules,denied", func(t *testing.T) {
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"other-group"}}, nil)
+		ssoIf.On("IsRBACEnabled").Return(true)
+		ssoIf.On("RBACRules").Return(rules)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
+		if assert.NoError(t, err) {
+			_, err := g.ContextWithRequest(rulesCtx("/workflow.WorkflowService/DeleteWorkflow"), servertypes.NamespaceHolder("my-ns"))
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = g.ContextWithRequest(rulesCtx("/workflow.WorkflowService/ListWorkflows"), servertypes.NamespaceHolder("other-ns"))
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+	})
+	t.Run("SSO+RBACRules,info", func(t *testing.T) {
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{}, nil)
+		ssoIf.On("IsRBACEnabled").Return(true)
+		ssoIf.On("RBACRules").Return(rules)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
+		if assert.NoError(t, err) {
+			_, err := g.Context(rulesCtx("/info.InfoService/GetUserInfo"))
+			assert.NoError(t, err)
+		}
+	})
+	t.Run("SSO+RBACRules,serviceAccount", func(t *testing.T) {
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("Authorize", mock.Anything, mock.Anything).Return(&types.Claims{Groups: []string{"my-group"}}, nil)
+		ssoIf.On("IsRBACEnabled").Return(true)
+		ssoIf.On("RBACRules").Return(rules)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, nil)
+		if assert.NoError(t, err) {
+			ctx, err := g.ContextWithRequest(rulesCtx("/workflow.WorkflowService/DeleteWorkflow"), servertypes.NamespaceHolder("other-ns"))
+			if assert.NoError(t, err) {
+				assert.NotEqual(t, wfClient, GetWfClient(ctx))
+				assert.Equal(t, "my-sa", GetClaims(ctx).ServiceAccountName)
+			}
+		}
+	})
+	t.Run("SSO+APIToken", func(t *testing.T) {
+		store := apitoken.NewStore(kubeClient.CoreV1().Secrets("my-ns"))
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		store.Run(stopCh)
+		authorization, token, err := store.Create(context.Background(), apitoken.Token{Subject: "my-sub", Groups: []string{"other-group"}, Namespace: "my-ns", Verbs: []string{"list"}, ExpiresAt: time.Now().Add(time.Hour)})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Eventually(t, func() bool {
+			_, err := store.Verify(authorization)
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+		tokenCtx := func(method string) context.Context {
+			return grpc.NewContextWithServerTransportStream(x(authorization), &transportStream{method: method})
+		}
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("APITokens").Return(&config.APITokensConfig{Enabled: true})
+		ssoIf.On("IsRBACEnabled").Return(true)
+		ssoIf.On("RBACRules").Return(rules)
+		g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, store)
+		if assert.NoError(t, err) {
+			ctx, err := g.ContextWithRequest(tokenCtx("/workflow.WorkflowService/ListWorkflows"), servertypes.NamespaceHolder("my-ns"))
+			if assert.NoError(t, err) {
+				assert.NotEqual(t, wfClient, GetWfClient(ctx))
+				assert.Equal(t, "my-sub", GetClaims(ctx).Subject)
+			}
+			_, err = g.ContextWithRequest(tokenCtx("/workflow.WorkflowService/DeleteWorkflow"), servertypes.NamespaceHolder("my-ns"))
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = g.ContextWithRequest(tokenCtx("/workflow.WorkflowService/ListWorkflows"), servertypes.NamespaceHolder("other-ns"))
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			_, err = g.ContextWithRequest(tokenCtx("/workflow.WorkflowService/ListWorkflows"), servertypes.NamespaceHolder("my-ns"))
+			assert.NoError(t, err)
+			_, err = g.Context(x(authorization + "x"))
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+		t.Run("Revoked", func(t *testing.T) {
+			if assert.NoError(t, store.Revoke(context.Background(), "my-sub", token.ID)) {
+				assert.Eventually(t, func() bool {
+					_, err := g.ContextWithRequest(tokenCtx("/workflow.WorkflowService/ListWorkflows"), servertypes.NamespaceHolder("my-ns"))
+					return status.Code(err) == codes.Unauthenticated
+				}, time.Second, 10*time.Millisecond)
+			}
+		})
+		t.Run("Disabled", func(t *testing.T) {
+			ssoIf := &ssomocks.Interface{}
+			ssoIf.On("APITokens").Return(nil)
+			g, err := NewGatekeeper(Modes{SSO: true}, clients, nil, ssoIf, clientForAuthorization, "my-ns", "my-ns", true, resourceCache, store)
+			if assert.NoError(t, err) {
+				_, err := g.Context(x(authorization))
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			}
+		})
+	})
+}
+
+// transportStream sets the method of a context like the gRPC server does
+type transportStream struct {
+	grpc.ServerTransportStream
+	method string
 }
 
+func (s *transportStream) Method() string { return s.method }
+
 func x(authorization string) context.Context {
 	return metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{"authorization": authorization}))
 }
@@ -307,3 +483,15 @@ func TestGetClaimSet(t *testing.T) {
 	// we should be able to get nil claim set
 	assert.Nil(t, GetClaims(context.TODO()))
 }
+
+func TestGetIdentity(t *testing.T) {
+	assert.Empty(t, GetIdentity(context.Background()))
+	assert.Empty(t, GetIdentity(x("")))
+	token := GetIdentity(x("Bearer my-token"))
+	assert.Len(t, token, 64)
+	assert.NotEqual(t, token, GetIdentity(x("Bearer other-token")))
+	// the subject identifies the user, even if their token changes
+	subject := GetIdentity(context.WithValue(x("Bearer v2:my-token"), ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}}))
+	assert.Equal(t, subject, GetIdentity(context.WithValue(x("Bearer v2:other-token"), ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}})))
+	assert.NotEqual(t, token, subject)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/argoproj/argo-workflows/v3/server/auth/apitoken"
 	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
 )
 
@@ -15,11 +16,15 @@ const (
 	Client Mode = "client"
 	Server Mode = "server"
 	SSO    Mode = "sso"
+	// ClientCert authenticates requests with a verified TLS client certificate
+	ClientCert Mode = "client-cert"
+	// APIToken authenticates requests with an API token an SSO user created. It cannot be added, but is enabled by SSO.
+	APIToken Mode = "api-token"
 )
 
 func (m Modes) Add(value string) error {
 	switch value {
-	case "client", "server", "sso":
+	case "client", "server", "sso", "client-cert":
 		m[Mode(value)] = true
 	case "hybrid":
 		m[Client] = true
@@ -31,6 +36,9 @@ func (m Modes) Add(value string) error {
 }
 
 func (m Modes) GetMode(authorisation string) (Mode, bool) {
+	if m[SSO] && strings.HasPrefix(authorisation, apitoken.Prefix) {
+		return APIToken, true
+	}
 	if m[SSO] && strings.HasPrefix(authorisation, sso.Prefix) {
 		return SSO, true
 	}
@@ -16,6 +16,12 @@ func TestModes_Add(t *testing.T) {
 			assert.Contains(t, m, Client)
 		}
 	})
+	t.Run("ClientCert", func(t *testing.T) {
+		m := Modes{}
+		if assert.NoError(t, m.Add("client-cert")) {
+			assert.Contains(t, m, ClientCert)
+		}
+	})
 	t.Run("Hybrid", func(t *testing.T) {
 		m := Modes{}
 		if assert.NoError(t, m.Add("hybrid")) {
@@ -61,6 +67,12 @@ func TestModes_GetMode(t *testing.T) {
 			assert.Equal(t, SSO, mode)
 		}
 	})
+	t.Run("APIToken", func(t *testing.T) {
+		mode, valid := m.GetMode("Bearer api:")
+		if assert.True(t, valid) {
+			assert.Equal(t, APIToken, mode)
+		}
+	})
 
 	m = Modes{
 		Client: false,
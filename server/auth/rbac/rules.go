@@ -0,0 +1,159 @@
+package rbac
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+const (
+	VerbGet    = "get"
+	VerbList   = "list"
+	VerbWatch  = "watch"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+	// VerbAny matches all verbs or namespaces in a rule
+	VerbAny = "*"
+)
+
+// verbPrefixes maps the prefixes of the API's method names to the verb they perform
+var verbPrefixes = []struct {
+	prefix string
+	verb   string
+}{
+	{"Get", VerbGet},
+	{"List", VerbList},
+	{"Watch", VerbWatch},
+	{"Create", VerbCreate},
+	{"Submit", VerbCreate},
+	{"Resubmit", VerbCreate},
+	{"Lint", VerbCreate},
+	{"Receive", VerbCreate},
+	{"Update", VerbUpdate},
+	{"Set", VerbUpdate},
+	{"Retry", VerbUpdate},
+	{"Resume", VerbUpdate},
+	{"Suspend", VerbUpdate},
+	{"Stop", VerbUpdate},
+	{"Terminate", VerbUpdate},
+	{"Delete", VerbDelete},
+}
+
+// ValidateRules returns an error if a rule does not have a name, namespaces, valid verbs or a service account
+func ValidateRules(rules []config.RBACRule) error {
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d must have a name", i)
+		}
+		if len(rule.Namespaces) == 0 {
+			return fmt.Errorf("rule %q must have namespaces", rule.Name)
+		}
+		if len(rule.Verbs) == 0 {
+			return fmt.Errorf("rule %q must have verbs", rule.Name)
+		}
+		for _, verb := range rule.Verbs {
+			switch verb {
+			case VerbGet, VerbList, VerbWatch, VerbCreate, VerbUpdate, VerbDelete, VerbAny:
+			default:
+				return fmt.Errorf("rule %q has invalid verb %q", rule.Name, verb)
+			}
+		}
+		if rule.ServiceAccountName == "" {
+			return fmt.Errorf("rule %q must have a serviceAccountName", rule.Name)
+		}
+	}
+	return nil
+}
+
+// VerbForMethod returns the verb a gRPC method (e.g. "/workflow.WorkflowService/ListWorkflows") performs. It returns
+// "" for methods that any user may call, such as getting the server's info, and the lower-cased method name for methods
+// it does not know, which only rules allowing all verbs match. Requests that are not gRPC, such as for artifacts, get.
+func VerbForMethod(fullMethod string) string {
+	if fullMethod == "" {
+		return VerbGet
+	}
+	service, method := path.Split(fullMethod)
+	if strings.HasSuffix(strings.TrimSuffix(service, "/"), ".InfoService") {
+		return ""
+	}
+	if strings.HasSuffix(method, "Logs") {
+		return VerbGet
+	}
+	for _, p := range verbPrefixes {
+		if strings.HasPrefix(method, p.prefix) {
+			return p.verb
+		}
+	}
+	return strings.ToLower(method)
+}
+
+// Matches returns whether the rule applies to the user with the claims. Rules with email domains only apply to users
+// whose email the identity provider has verified, as otherwise anyone could claim an email in the domain.
+func Matches(rule config.RBACRule, claims *types.Claims) bool {
+	if claims == nil {
+		return false
+	}
+	if len(rule.Groups) > 0 && !containsAny(rule.Groups, claims.Groups) {
+		return false
+	}
+	if len(rule.EmailDomains) > 0 {
+		if !claims.EmailVerified {
+			return false
+		}
+		_, domain, ok := strings.Cut(claims.Email, "@")
+		if !ok || !containsFold(rule.EmailDomains, domain) {
+			return false
+		}
+	}
+	return true
+}
+
+// Allows returns whether the rule allows the verb in the namespace, where "" is a cluster-scoped request or one for all
+// namespaces
+func Allows(rule config.RBACRule, namespace, verb string) bool {
+	return allows(rule.Namespaces, namespace) && allows(rule.Verbs, verb)
+}
+
+// Authorize returns the first of the rules that applies to the user with the claims and allows the verb in the
+// namespace, or nil if there is none
+func Authorize(rules []config.RBACRule, claims *types.Claims, namespace, verb string) *config.RBACRule {
+	for i, rule := range rules {
+		if Matches(rule, claims) && Allows(rule, namespace, verb) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func allows(allowed []string, value string) bool {
+	for _, a := range allowed {
+		if a == VerbAny || (value != "" && a == value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(values, others []string) bool {
+	for _, v := range values {
+		for _, o := range others {
+			if v == o {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+func TestValidateRules(t *testing.T) {
+	assert.NoError(t, ValidateRules(nil))
+	assert.NoError(t, ValidateRules([]config.RBACRule{{Name: "my-rule", Namespaces: []string{"*"}, Verbs: []string{"get", "*"}, ServiceAccountName: "my-sa"}}))
+	assert.EqualError(t, ValidateRules([]config.RBACRule{{}}), "rule 0 must have a name")
+	assert.EqualError(t, ValidateRules([]config.RBACRule{{Name: "my-rule", Verbs: []string{"get"}}}), `rule "my-rule" must have namespaces`)
+	assert.EqualError(t, ValidateRules([]config.RBACRule{{Name: "my-rule", Namespaces: []string{"*"}}}), `rule "my-rule" must have verbs`)
+	assert.EqualError(t, ValidateRules([]config.RBACRule{{Name: "my-rule", Namespaces: []string{"*"}, Verbs: []string{"patch"}}}), `rule "my-rule" has invalid verb "patch"`)
+	assert.EqualError(t, ValidateRules([]config.RBACRule{{Name: "my-rule", Namespaces: []string{"*"}, Verbs: []string{"get"}}}), `rule "my-rule" must have a serviceAccountName`)
+}
+
+func TestVerbForMethod(t *testing.T) {
+	for method, verb := range map[string]string{
+		"":                                      VerbGet,
+		"/info.InfoService/GetUserInfo":         "",
+		"/workflow.WorkflowService/GetWorkflow": VerbGet,
+		"/workflow.WorkflowService/ListWorkflows":                    VerbList,
+		"/workflow.WorkflowService/WatchWorkflows":                   VerbWatch,
+		"/workflow.WorkflowService/WorkflowLogs":                     VerbGet,
+		"/workflow.WorkflowService/SubmitWorkflow":                   VerbCreate,
+		"/workflow.WorkflowService/RetryWorkflow":                    VerbUpdate,
+		"/workflow.WorkflowService/SetWorkflow":                      VerbUpdate,
+		"/workflow.WorkflowService/DeleteWorkflow":                   VerbDelete,
+		"/cronworkflow.CronWorkflowService/ResumeCronWorkflow":       VerbUpdate,
+		"/event.EventService/ReceiveEvent":                           VerbCreate,
+		"/workflowtemplate.WorkflowTemplateService/SomethingUnknown": "somethingunknown",
+	} {
+		assert.Equal(t, verb, VerbForMethod(method), method)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	claims := &types.Claims{Email: "me@Example.com", EmailVerified: true, Groups: []string{"my-group"}}
+	assert.True(t, Matches(config.RBACRule{}, claims))
+	assert.False(t, Matches(config.RBACRule{}, nil))
+	assert.True(t, Matches(config.RBACRule{Groups: []string{"other-group", "my-group"}}, claims))
+	assert.False(t, Matches(config.RBACRule{Groups: []string{"other-group"}}, claims))
+	assert.True(t, Matches(config.RBACRule{EmailDomains: []string{"example.com"}}, claims))
+	assert.False(t, Matches(config.RBACRule{EmailDomains: []string{"other.com"}}, claims))
+	assert.False(t, Matches(config.RBACRule{Groups: []string{"my-group"}, EmailDomains: []string{"other.com"}}, claims))
+	assert.False(t, Matches(config.RBACRule{EmailDomains: []string{"example.com"}}, &types.Claims{}))
+	assert.False(t, Matches(config.RBACRule{EmailDomains: []string{"example.com"}}, &types.Claims{Email: "me@example.com"}))
+}
+
+func TestAllows(t *testing.T) {
+	rule := config.RBACRule{Namespaces: []string{"my-ns"}, Verbs: []string{VerbGet, VerbList}}
+	assert.True(t, Allows(rule, "my-ns", VerbGet))
+	assert.False(t, Allows(rule, "my-ns", VerbDelete))
+	assert.False(t, Allows(rule, "other-ns", VerbGet))
+	assert.False(t, Allows(rule, "", VerbList))
+	all := config.RBACRule{Namespaces: []string{VerbAny}, Verbs: []string{VerbAny}}
+	assert.True(t, Allows(all, "", VerbList))
+	assert.True(t, Allows(all, "other-ns", "somethingunknown"))
+}
+
+func TestAuthorize(t *testing.T) {
+	rules := []config.RBACRule{
+		{Name: "readers", Namespaces: []string{"*"}, Verbs: []string{VerbGet, VerbList}},
+		{Name: "admins", Groups: []string{"admins"}, Namespaces: []string{"*"}, Verbs: []string{VerbAny}},
+	}
+	assert.Nil(t, Authorize(rules, &types.Claims{}, "my-ns", VerbDelete))
+	if rule := Authorize(rules, &types.Claims{Groups: []string{"admins"}}, "my-ns", VerbGet); assert.NotNil(t, rule) {
+		assert.Equal(t, "readers", rule.Name)
+	}
+	if rule := Authorize(rules, &types.Claims{Groups: []string{"admins"}}, "my-ns", VerbDelete); assert.NotNil(t, rule) {
+		assert.Equal(t, "admins", rule.Name)
+	}
+}
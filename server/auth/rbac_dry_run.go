@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth/rbac"
+	"github.com/argoproj/argo-workflows/v3/server/auth/sso"
+)
+
+// RBACDryRunResult is what the RBAC rules allow the user of a token to do
+type RBACDryRunResult struct {
+	Subject string   `json:"subject,omitempty"`
+	Email   string   `json:"email,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+	// Rules are the names of the rules that apply to the user
+	Rules     []string `json:"rules"`
+	Namespace string   `json:"namespace,omitempty"`
+	Verb      string   `json:"verb,omitempty"`
+	// Allowed is whether the verb is allowed in the namespace, and is only set if a verb is requested
+	Allowed *bool `json:"allowed,omitempty"`
+	// Rule is the name of the rule that allows the verb in the namespace
+	Rule string `json:"rule,omitempty"`
+}
+
+// NewRBACDryRunHandler returns a handler that tests what the SSO RBAC rules allow the user of the request's token to do,
+// e.g. `GET /rbac/dry-run?namespace=argo&verb=delete`. It does not perform any request on their behalf.
+func NewRBACDryRunHandler(ssoIf sso.Interface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rules := ssoIf.RBACRules()
+		if !ssoIf.IsRBACEnabled() || len(rules) == 0 {
+			http.Error(w, "RBAC rules are not configured", http.StatusNotFound)
+			return
+		}
+		claims, err := authorizeSSO(ssoIf, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		result := RBACDryRunResult{
+			Subject:   claims.Subject,
+			Email:     claims.Email,
+			Groups:    claims.Groups,
+			Rules:     []string{},
+			Namespace: r.URL.Query().Get("namespace"),
+			Verb:      r.URL.Query().Get("verb"),
+		}
+		for _, rule := range rules {
+			if rbac.Matches(rule, claims) {
+				result.Rules = append(result.Rules, rule.Name)
+			}
+		}
+		if result.Verb != "" {
+			rule := rbac.Authorize(rules, claims, result.Namespace, result.Verb)
+			allowed := rule != nil
+			result.Allowed = &allowed
+			if rule != nil {
+				result.Rule = rule.Name
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			log.WithError(err).Error("failed to write RBAC dry-run result")
+		}
+	}
+}
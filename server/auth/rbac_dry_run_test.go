@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	ssomocks "github.com/argoproj/argo-workflows/v3/server/auth/sso/mocks"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+func TestNewRBACDryRunHandler(t *testing.T) {
+	rules := []config.RBACRule{
+		{Name: "readers", Namespaces: []string{"*"}, Verbs: []string{"get", "list"}},
+		{Name: "admins", Groups: []string{"admins"}, Namespaces: []string{"*"}, Verbs: []string{"*"}},
+	}
+	ssoIf := &ssomocks.Interface{}
+	ssoIf.On("IsRBACEnabled").Return(true)
+	ssoIf.On("RBACRules").Return(rules)
+	ssoIf.On("Authorize", "Bearer v2:whatever").Return(&types.Claims{Email: "me@example.com", Groups: []string{"my-group"}}, nil)
+	handler := NewRBACDryRunHandler(ssoIf)
+	t.Run("Unauthenticated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/rbac/dry-run", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("Rules", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/rbac/dry-run", nil)
+		r.Header.Set("Authorization", "Bearer v2:whatever")
+		handler(w, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"email":"me@example.com","groups":["my-group"],"rules":["readers"]}`, w.Body.String())
+	})
+	t.Run("Allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/rbac/dry-run?namespace=argo&verb=list", nil)
+		r.AddCookie(&http.Cookie{Name: "authorization", Value: "Bearer v2:whatever"})
+		handler(w, r)
+		assert.JSONEq(t, `{"email":"me@example.com","groups":["my-group"],"rules":["readers"],"namespace":"argo","verb":"list","allowed":true,"rule":"readers"}`, w.Body.String())
+	})
+	t.Run("Denied", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/rbac/dry-run?namespace=argo&verb=delete", nil)
+		r.Header.Set("Authorization", "Bearer v2:whatever")
+		handler(w, r)
+		assert.JSONEq(t, `{"email":"me@example.com","groups":["my-group"],"rules":["readers"],"namespace":"argo","verb":"delete","allowed":false}`, w.Body.String())
+	})
+	t.Run("NotConfigured", func(t *testing.T) {
+		ssoIf := &ssomocks.Interface{}
+		ssoIf.On("IsRBACEnabled").Return(true)
+		ssoIf.On("RBACRules").Return(nil)
+		w := httptest.NewRecorder()
+		NewRBACDryRunHandler(ssoIf)(w, httptest.NewRequest(http.MethodGet, "/rbac/dry-run", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
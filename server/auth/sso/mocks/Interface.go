@@ -7,6 +7,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	config "github.com/argoproj/argo-workflows/v3/config"
+
 	types "github.com/argoproj/argo-workflows/v3/server/auth/types"
 )
 
@@ -15,6 +17,22 @@ type Interface struct {
 	mock.Mock
 }
 
+// APITokens provides a mock function with given fields:
+func (_m *Interface) APITokens() *config.APITokensConfig {
+	ret := _m.Called()
+
+	var r0 *config.APITokensConfig
+	if rf, ok := ret.Get(0).(func() *config.APITokensConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*config.APITokensConfig)
+		}
+	}
+
+	return r0
+}
+
 // Authorize provides a mock function with given fields: authorization
 func (_m *Interface) Authorize(authorization string) (*types.Claims, error) {
 	ret := _m.Called(authorization)
@@ -61,3 +79,19 @@ func (_m *Interface) IsRBACEnabled() bool {
 
 	return r0
 }
+
+// RBACRules provides a mock function with given fields:
+func (_m *Interface) RBACRules() []config.RBACRule {
+	ret := _m.Called()
+
+	var r0 []config.RBACRule
+	if rf, ok := ret.Get(0).(func() []config.RBACRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]config.RBACRule)
+		}
+	}
+
+	return r0
+}
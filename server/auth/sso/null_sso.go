@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 )
 
@@ -15,6 +16,14 @@ func (n nullService) IsRBACEnabled() bool {
 	return false
 }
 
+func (n nullService) RBACRules() []config.RBACRule {
+	return nil
+}
+
+func (n nullService) APITokens() *config.APITokensConfig {
+	return nil
+}
+
 func (n nullService) Authorize(string) (*types.Claims, error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
+	"github.com/argoproj/argo-workflows/v3/server/auth/rbac"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 )
 
@@ -42,6 +43,8 @@ type Interface interface {
 	HandleRedirect(writer http.ResponseWriter, request *http.Request)
 	HandleCallback(writer http.ResponseWriter, request *http.Request)
 	IsRBACEnabled() bool
+	RBACRules() []config.RBACRule
+	APITokens() *config.APITokensConfig
 }
 
 var _ Interface = &sso{}
@@ -58,6 +61,7 @@ type sso struct {
 	privateKey      crypto.PrivateKey
 	encrypter       jose.Encrypter
 	rbacConfig      *config.RBACConfig
+	apiTokensConfig *config.APITokensConfig
 	expiry          time.Duration
 	customClaimName string
 	userInfoPath    string
@@ -67,6 +71,14 @@ func (s *sso) IsRBACEnabled() bool {
 	return s.rbacConfig.IsEnabled()
 }
 
+func (s *sso) RBACRules() []config.RBACRule {
+	return s.rbacConfig.GetRules()
+}
+
+func (s *sso) APITokens() *config.APITokensConfig {
+	return s.apiTokensConfig
+}
+
 // Abstract methods of oidc.Provider that our code uses into an interface. That
 // will allow us to implement a stub for unit testing.  If you start using more
 // oidc.Provider methods in this file, add them here and provide a stub
@@ -96,6 +108,9 @@ func newSso(
 	if c.Issuer == "" {
 		return nil, fmt.Errorf("issuer empty")
 	}
+	if err := rbac.ValidateRules(c.RBAC.GetRules()); err != nil {
+		return nil, fmt.Errorf("invalid RBAC rules: %w", err)
+	}
 	if c.ClientID.Name == "" || c.ClientID.Key == "" {
 		return nil, fmt.Errorf("clientID empty")
 	}
@@ -188,6 +203,7 @@ func newSso(
 		privateKey:      privateKey,
 		encrypter:       encrypter,
 		rbacConfig:      c.RBAC,
+		apiTokensConfig: c.APITokens,
 		expiry:          c.GetSessionExpiry(),
 		customClaimName: c.CustomGroupClaimName,
 		userInfoPath:    c.UserInfoPath,
@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// Azure DevOps service hooks do not sign requests, instead they use basic authentication, so the secret is "username:password"
+func azuredevopsMatch(client *webhookClient, r *http.Request) bool {
+	username, password, ok := r.BasicAuth()
+	if !ok || client.Secret == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(username+":"+password), []byte(client.Secret)) == 1
+}
@@ -6,8 +6,8 @@ import (
 	bitbucketserver "gopkg.in/go-playground/webhooks.v5/bitbucket-server"
 )
 
-func bitbucketserverMatch(secret string, r *http.Request) bool {
-	hook, err := bitbucketserver.New(bitbucketserver.Options.Secret(secret))
+func bitbucketserverMatch(client *webhookClient, r *http.Request) bool {
+	hook, err := bitbucketserver.New(bitbucketserver.Options.Secret(client.Secret))
 	if err != nil {
 		return false
 	}
@@ -31,6 +31,7 @@ func bitbucketserverMatch(secret string, r *http.Request) bool {
 		bitbucketserver.PullRequestCommentAddedEvent,
 		bitbucketserver.PullRequestCommentEditedEvent,
 		bitbucketserver.PullRequestCommentDeletedEvent,
+		bitbucketserver.DiagnosticsPingEvent,
 	)
 	return err == nil
 }
@@ -6,8 +6,8 @@ import (
 	"gopkg.in/go-playground/webhooks.v5/github"
 )
 
-func githubMatch(secret string, r *http.Request) bool {
-	hook, err := github.New(github.Options.Secret(secret))
+func githubMatch(client *webhookClient, r *http.Request) bool {
+	hook, err := github.New(github.Options.Secret(client.Secret))
 	if err != nil {
 		return false
 	}
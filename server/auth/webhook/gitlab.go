@@ -1,13 +1,18 @@
 package webhook
 
 import (
+	"crypto/subtle"
 	"net/http"
 
 	"gopkg.in/go-playground/webhooks.v5/gitlab"
 )
 
-func gitlabMatch(secret string, r *http.Request) bool {
-	hook, err := gitlab.New(gitlab.Options.Secret(secret))
+func gitlabMatch(client *webhookClient, r *http.Request) bool {
+	// system hooks are sent for many events the parser does not know about (e.g. "project_create"), so we only verify the token
+	if r.Header.Get("X-Gitlab-Event") == string(gitlab.SystemHookEvents) {
+		return client.Secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Gitlab-Token")), []byte(client.Secret)) == 1
+	}
+	hook, err := gitlab.New(gitlab.Options.Secret(client.Secret))
 	if err != nil {
 		return false
 	}
@@ -22,7 +27,6 @@ func gitlabMatch(secret string, r *http.Request) bool {
 		gitlab.PipelineEvents,
 		gitlab.BuildEvents,
 		gitlab.JobEvents,
-		gitlab.SystemHookEvents,
 	)
 	return err == nil
 }
@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+var hmacAlgorithms = map[string]func() hash.Hash{
+	"":       sha256.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// hmacMatch matches any client that signs the request body using HMAC, and puts the signature in a header
+func hmacMatch(client *webhookClient, r *http.Request) bool {
+	newHash, ok := hmacAlgorithms[client.Algorithm]
+	if !ok || client.Header == "" || client.Secret == "" {
+		return false
+	}
+	value := r.Header.Get(client.Header)
+	if !strings.HasPrefix(value, client.Prefix) {
+		return false
+	}
+	var signature []byte
+	var err error
+	switch client.Encoding {
+	case "", "hex":
+		signature, err = hex.DecodeString(strings.TrimPrefix(value, client.Prefix))
+	case "base64":
+		signature, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(value, client.Prefix))
+	default:
+		return false
+	}
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(newHash, []byte(client.Secret))
+	mac.Write(body)
+	return hmac.Equal(signature, mac.Sum(nil))
+}
@@ -18,16 +18,28 @@ type webhookClient struct {
 	Type string `json:"type"`
 	// e.g. "shh!"
 	Secret string `json:"secret"`
+	// Discriminator, if specified, restricts the client to only this discriminator, and therefore only the workflow event bindings that select it
+	Discriminator string `json:"discriminator,omitempty"`
+	// Header is the header containing the signature, only for "hmac", e.g. "X-Signature"
+	Header string `json:"header,omitempty"`
+	// Algorithm is the hash algorithm of the signature, only for "hmac", one of "sha1", "sha256" (default) or "sha512"
+	Algorithm string `json:"algorithm,omitempty"`
+	// Prefix is the prefix of the signature, only for "hmac", e.g. "sha256="
+	Prefix string `json:"prefix,omitempty"`
+	// Encoding is the encoding of the signature, only for "hmac", one of "hex" (default) or "base64"
+	Encoding string `json:"encoding,omitempty"`
 }
 
-type matcher = func(secret string, r *http.Request) bool
+type matcher = func(client *webhookClient, r *http.Request) bool
 
 // parser for each types, these should be fast, i.e. no database or API interactions
 var webhookParsers = map[string]matcher{
+	"azuredevops":     azuredevopsMatch,
 	"bitbucket":       bitbucketMatch,
 	"bitbucketserver": bitbucketserverMatch,
 	"github":          githubMatch,
 	"gitlab":          gitlabMatch,
+	"hmac":            hmacMatch,
 }
 
 const pathPrefix = "/api/v1/events/"
@@ -49,14 +61,15 @@ func Interceptor(client kubernetes.Interface) func(w http.ResponseWriter, r *htt
 
 func addWebhookAuthorization(r *http.Request, kube kubernetes.Interface) error {
 	// try and exit quickly before we do anything API calls
-	if r.Method != "POST" || len(r.Header["Authorization"]) > 0 || !strings.HasPrefix(r.URL.Path, pathPrefix) {
+	// Azure DevOps authenticates using basic auth, so we cannot ignore those requests
+	if r.Method != "POST" || (len(r.Header["Authorization"]) > 0 && !strings.HasPrefix(r.Header.Get("Authorization"), "Basic ")) || !strings.HasPrefix(r.URL.Path, pathPrefix) {
 		return nil
 	}
 	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, pathPrefix), "/", 2)
 	if len(parts) != 2 {
 		return nil
 	}
-	namespace := parts[0]
+	namespace, discriminator := parts[0], parts[1]
 	secretsInterface := kube.CoreV1().Secrets(namespace)
 	ctx := r.Context()
 
@@ -76,8 +89,15 @@ func addWebhookAuthorization(r *http.Request, kube kubernetes.Interface) error {
 		if err != nil {
 			return fmt.Errorf("failed to unmarshal webhook client \"%s\": %w", serviceAccountName, err)
 		}
+		if client.Discriminator != "" && client.Discriminator != discriminator {
+			continue
+		}
+		match, ok := webhookParsers[client.Type]
+		if !ok {
+			return fmt.Errorf("webhook client \"%s\" has unknown type \"%s\"", serviceAccountName, client.Type)
+		}
 		log.WithFields(log.Fields{"serviceAccountName": serviceAccountName, "webhookType": client.Type}).Debug("Attempting to match webhook request")
-		ok := webhookParsers[client.Type](client.Secret, r)
+		ok = match(client, r)
 		if ok {
 			log.WithField("serviceAccountName", serviceAccountName).Debug("Matched webhook request")
 			serviceAccount, err := serviceAccountInterface.Get(ctx, serviceAccountName, metav1.GetOptions{})
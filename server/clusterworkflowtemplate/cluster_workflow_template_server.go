@@ -13,15 +13,22 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/templaterevision"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
 )
 
+// errRevisionsNamespaceUnknown is returned for revisions when the server does not know the controller's namespace, e.g.
+// when the CLI uses the Kubernetes API directly
+var errRevisionsNamespaceUnknown = fmt.Errorf("revisions of cluster workflow templates can only be retrieved from the Argo Server")
+
 type ClusterWorkflowTemplateServer struct {
 	instanceIDService instanceid.Service
+	// namespace is where the controller saves the revisions of cluster workflow templates, or empty if it is not known
+	namespace string
 }
 
-func NewClusterWorkflowTemplateServer(instanceID instanceid.Service) clusterwftmplpkg.ClusterWorkflowTemplateServiceServer {
-	return &ClusterWorkflowTemplateServer{instanceID}
+func NewClusterWorkflowTemplateServer(instanceID instanceid.Service, namespace string) clusterwftmplpkg.ClusterWorkflowTemplateServiceServer {
+	return &ClusterWorkflowTemplateServer{instanceID, namespace}
 }
 
 func (cwts *ClusterWorkflowTemplateServer) CreateClusterWorkflowTemplate(ctx context.Context, req *clusterwftmplpkg.ClusterWorkflowTemplateCreateRequest) (*v1alpha1.ClusterWorkflowTemplate, error) {
@@ -44,6 +51,12 @@ func (cwts *ClusterWorkflowTemplateServer) GetClusterWorkflowTemplate(ctx contex
 	if err != nil {
 		return nil, err
 	}
+	if req.Revision != 0 && req.Revision != wfTmpl.Generation {
+		if cwts.namespace == "" {
+			return nil, errRevisionsNamespaceUnknown
+		}
+		return templaterevision.GetClusterWorkflowTemplate(ctx, auth.GetKubeClient(ctx), cwts.namespace, wfTmpl, req.Revision)
+	}
 	return wfTmpl, nil
 }
 
@@ -61,6 +74,20 @@ func (cwts *ClusterWorkflowTemplateServer) getTemplateAndValidate(ctx context.Co
 }
 
 func (cwts *ClusterWorkflowTemplateServer) ListClusterWorkflowTemplates(ctx context.Context, req *clusterwftmplpkg.ClusterWorkflowTemplateListRequest) (*v1alpha1.ClusterWorkflowTemplateList, error) {
+	if req.RevisionsOf != "" {
+		cwftmpl, err := cwts.getTemplateAndValidate(ctx, req.RevisionsOf)
+		if err != nil {
+			return nil, err
+		}
+		if cwts.namespace == "" {
+			return nil, errRevisionsNamespaceUnknown
+		}
+		revisions, err := templaterevision.ListClusterWorkflowTemplate(ctx, auth.GetKubeClient(ctx), cwts.namespace, cwftmpl)
+		if err != nil {
+			return nil, err
+		}
+		return &v1alpha1.ClusterWorkflowTemplateList{Items: revisions}, nil
+	}
 	wfClient := auth.GetWfClient(ctx)
 	options := &v1.ListOptions{}
 	if req.ListOptions != nil {
@@ -11,6 +11,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 
@@ -58,10 +59,7 @@ func (o *Operation) Dispatch(ctx context.Context) error {
 
 	var errs []error
 	for _, event := range o.events {
-		err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
-			_, err := o.dispatch(ctx, event)
-			return !errorsutil.IsTransientErr(err), err
-		})
+		_, err := o.dispatch(ctx, event)
 		if err != nil {
 			log.WithError(err).WithFields(log.Fields{"namespace": event.Namespace, "event": event.Name}).Error("failed to dispatch from event")
 			o.eventRecorder.Event(&event, corev1.EventTypeWarning, "WorkflowEventBindingError", "failed to dispatch event: "+err.Error())
@@ -74,81 +72,121 @@ func (o *Operation) Dispatch(ctx context.Context) error {
 	return nil
 }
 
-func (o *Operation) dispatch(ctx context.Context, wfeb wfv1.WorkflowEventBinding) (*wfv1.Workflow, error) {
+func (o *Operation) dispatch(ctx context.Context, wfeb wfv1.WorkflowEventBinding) ([]*wfv1.Workflow, error) {
 	selector := wfeb.Spec.Event.Selector
 	matched, err := argoexpr.EvalBool(selector, o.env)
 	if err != nil {
 		return nil, fmt.Errorf("failed to evaluate workflow template expression: %w", err)
 	}
 	log.WithFields(log.Fields{"namespace": wfeb.Namespace, "event": wfeb.Name, "selector": selector, "matched": matched}).Debug("Selector evaluation")
-	submit := wfeb.Spec.Submit
-	if matched && submit != nil {
-		client := auth.GetWfClient(o.ctx)
-		ref := wfeb.Spec.Submit.WorkflowTemplateRef
-		var tmpl wfv1.WorkflowSpecHolder
-		var err error
-		if ref.ClusterScope {
-			tmpl, err = client.ArgoprojV1alpha1().ClusterWorkflowTemplates().Get(ctx, ref.Name, metav1.GetOptions{})
-		} else {
-			tmpl, err = client.ArgoprojV1alpha1().WorkflowTemplates(wfeb.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to get workflow template: %w", err)
-		}
-		err = o.instanceIDService.Validate(tmpl)
-		if err != nil {
-			return nil, fmt.Errorf("failed to validate workflow template instanceid: %w", err)
-		}
-		wf := common.NewWorkflowFromWorkflowTemplate(tmpl.GetName(), ref.ClusterScope)
-		o.instanceIDService.Label(wf)
-		err = o.populateWorkflowMetadata(wf, &submit.ObjectMeta)
+	submits := wfeb.Spec.GetSubmits()
+	if !matched || len(submits) == 0 {
+		return nil, nil
+	}
+	env, err := o.transform(wfeb.Spec.Event.Transform)
+	if err != nil {
+		return nil, err
+	}
+	var wfs []*wfv1.Workflow
+	var errs []error
+	// each workflow is submitted (and retried) independently, so that one failure does not prevent, or duplicate, the others
+	for _, submit := range submits {
+		err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+			wf, err := o.submit(ctx, wfeb, submit, env)
+			if err == nil {
+				wfs = append(wfs, wf)
+			}
+			return !errorsutil.IsTransientErr(err), err
+		})
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
 		}
+	}
+	return wfs, utilerrors.NewAggregate(errs)
+}
 
-		if wf.Name == "" {
-			wf.SetName(wf.GetGenerateName() + util.RandSuffix())
-		}
+// transform returns the environment with the payload reshaped by the transform expression, if there is one
+func (o *Operation) transform(transform string) (map[string]interface{}, error) {
+	if transform == "" {
+		return o.env, nil
+	}
+	result, err := expr.Eval(transform, exprenv.GetFuncMap(o.env))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate event transform expression: %w", err)
+	}
+	env := make(map[string]interface{}, len(o.env))
+	for k, v := range o.env {
+		env[k] = v
+	}
+	env["payload"] = result
+	return jsonutil.Jsonify(env)
+}
+
+func (o *Operation) submit(ctx context.Context, wfeb wfv1.WorkflowEventBinding, submit wfv1.Submit, env map[string]interface{}) (*wfv1.Workflow, error) {
+	client := auth.GetWfClient(o.ctx)
+	ref := submit.WorkflowTemplateRef
+	var tmpl wfv1.WorkflowSpecHolder
+	var err error
+	if ref.ClusterScope {
+		tmpl, err = client.ArgoprojV1alpha1().ClusterWorkflowTemplates().Get(ctx, ref.Name, metav1.GetOptions{})
+	} else {
+		tmpl, err = client.ArgoprojV1alpha1().WorkflowTemplates(wfeb.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow template: %w", err)
+	}
+	err = o.instanceIDService.Validate(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate workflow template instanceid: %w", err)
+	}
+	wf := common.NewWorkflowFromWorkflowTemplate(tmpl.GetName(), ref.ClusterScope)
+	o.instanceIDService.Label(wf)
+	err = o.populateWorkflowMetadata(wf, &submit.ObjectMeta, env)
+	if err != nil {
+		return nil, err
+	}
 
-		// users will always want to know why a workflow was submitted,
-		// so we label with creator (which is a standard) and the name of the triggering event
-		creator.Label(o.ctx, wf)
-		labels.Label(wf, common.LabelKeyWorkflowEventBinding, wfeb.Name)
-		if submit.Arguments != nil {
-			for _, p := range submit.Arguments.Parameters {
-				if p.ValueFrom == nil {
-					return nil, fmt.Errorf("malformed workflow template parameter \"%s\": valueFrom is nil", p.Name)
-				}
-				result, err := expr.Eval(p.ValueFrom.Event, o.env)
-				if err != nil {
-					return nil, fmt.Errorf("failed to evaluate workflow template parameter \"%s\" expression: %w", p.Name, err)
-				}
-				data, err := json.Marshal(result)
-				if err != nil {
-					return nil, fmt.Errorf("failed to convert result to JSON \"%s\" expression: %w", p.Name, err)
-				}
-				wf.Spec.Arguments.Parameters = append(wf.Spec.Arguments.Parameters, wfv1.Parameter{Name: p.Name, Value: wfv1.AnyStringPtr(wfv1.Item{Value: data})})
+	if wf.Name == "" {
+		wf.SetName(wf.GetGenerateName() + util.RandSuffix())
+	}
+
+	// users will always want to know why a workflow was submitted,
+	// so we label with creator (which is a standard) and the name of the triggering event
+	creator.Label(o.ctx, wf)
+	labels.Label(wf, common.LabelKeyWorkflowEventBinding, wfeb.Name)
+	if submit.Arguments != nil {
+		for _, p := range submit.Arguments.Parameters {
+			if p.ValueFrom == nil {
+				return nil, fmt.Errorf("malformed workflow template parameter \"%s\": valueFrom is nil", p.Name)
 			}
+			result, err := expr.Eval(p.ValueFrom.Event, env)
+			if err != nil {
+				return nil, fmt.Errorf("failed to evaluate workflow template parameter \"%s\" expression: %w", p.Name, err)
+			}
+			data, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert result to JSON \"%s\" expression: %w", p.Name, err)
+			}
+			wf.Spec.Arguments.Parameters = append(wf.Spec.Arguments.Parameters, wfv1.Parameter{Name: p.Name, Value: wfv1.AnyStringPtr(wfv1.Item{Value: data})})
 		}
-		wf, err = client.ArgoprojV1alpha1().Workflows(wfeb.Namespace).Create(ctx, wf, metav1.CreateOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create workflow: %w", err)
-		}
-		return wf, nil
 	}
-	return nil, nil
+	wf, err = client.ArgoprojV1alpha1().Workflows(wfeb.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %w", err)
+	}
+	return wf, nil
 }
 
-func (o *Operation) populateWorkflowMetadata(wf *wfv1.Workflow, metadata *metav1.ObjectMeta) error {
+func (o *Operation) populateWorkflowMetadata(wf *wfv1.Workflow, metadata *metav1.ObjectMeta, env map[string]interface{}) error {
 	if len(metadata.Name) > 0 {
-		evalName, err := o.evaluateStringExpression(metadata.Name, "name")
+		evalName, err := evaluateStringExpression(metadata.Name, "name", env)
 		if err != nil {
 			return err
 		}
 		wf.SetName(evalName)
 	}
 	for labelKey, labelValue := range metadata.Labels {
-		evalLabel, err := o.evaluateStringExpression(labelValue, fmt.Sprintf("label \"%s\"", labelKey))
+		evalLabel, err := evaluateStringExpression(labelValue, fmt.Sprintf("label \"%s\"", labelKey), env)
 		if err != nil {
 			return err
 		}
@@ -160,7 +198,7 @@ func (o *Operation) populateWorkflowMetadata(wf *wfv1.Workflow, metadata *metav1
 		wf.Labels[labelKey] = evalLabel
 	}
 	for annotationKey, annotationValue := range metadata.Annotations {
-		evalAnnotation, err := o.evaluateStringExpression(annotationValue, fmt.Sprintf("annotation \"%s\"", annotationKey))
+		evalAnnotation, err := evaluateStringExpression(annotationValue, fmt.Sprintf("annotation \"%s\"", annotationKey), env)
 		if err != nil {
 			return err
 		}
@@ -173,8 +211,8 @@ func (o *Operation) populateWorkflowMetadata(wf *wfv1.Workflow, metadata *metav1
 	return nil
 }
 
-func (o *Operation) evaluateStringExpression(statement string, errorInfo string) (string, error) {
-	result, err := expr.Eval(statement, exprenv.GetFuncMap(o.env))
+func evaluateStringExpression(statement string, errorInfo string, env map[string]interface{}) (string, error) {
+	result, err := expr.Eval(statement, exprenv.GetFuncMap(env))
 	if err != nil {
 		return "", fmt.Errorf("failed to evaluate workflow %s expression: %w", errorInfo, err)
 	}
@@ -384,6 +384,75 @@ func Test_populateWorkflowMetadata(t *testing.T) {
 	assert.Equal(t, "Warning WorkflowEventBindingError failed to dispatch event: workflow name expression must evaluate to a string, not a <nil>", <-recorder.Events)
 }
 
+func TestOperationTransformAndSubmits(t *testing.T) {
+	// set-up
+	client := fake.NewSimpleClientset(
+		&wfv1.WorkflowTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wft", Namespace: "my-ns", Labels: map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"}},
+		},
+		&wfv1.WorkflowTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wft-2", Namespace: "my-ns", Labels: map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"}},
+		},
+	)
+	ctx := context.WithValue(context.WithValue(context.Background(), auth.WfKey, client), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}})
+	recorder := record.NewFakeRecorder(2)
+
+	// act
+	operation, err := NewOperation(ctx, instanceid.NewService("my-instanceid"), recorder, []wfv1.WorkflowEventBinding{
+		// test a binding that transforms the payload and submits many templates
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wfeb-1", Namespace: "my-ns"},
+			Spec: wfv1.WorkflowEventBindingSpec{
+				Event: wfv1.Event{
+					Selector:  "payload.ref == 'main'",
+					Transform: `{"sha": payload.after, "ids": map(payload.commits, {#.id})}`,
+				},
+				Submit: &wfv1.Submit{
+					ObjectMeta:          metav1.ObjectMeta{Name: `"my-wf-" + payload.sha`},
+					WorkflowTemplateRef: wfv1.WorkflowTemplateRef{Name: "my-wft"},
+					Arguments:           &wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "sha", ValueFrom: &wfv1.ValueFrom{Event: "payload.sha"}}}},
+				},
+				Submits: []wfv1.Submit{
+					{
+						ObjectMeta:          metav1.ObjectMeta{Name: `"my-wf-2-" + payload.sha`},
+						WorkflowTemplateRef: wfv1.WorkflowTemplateRef{Name: "my-wft-2"},
+						Arguments:           &wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "ids", ValueFrom: &wfv1.ValueFrom{Event: "payload.ids"}}}},
+					},
+					// test that one failed submission does not prevent the others
+					{
+						WorkflowTemplateRef: wfv1.WorkflowTemplateRef{Name: "not-found"},
+					},
+				},
+			},
+		},
+		// test a binding with an invalid transform
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wfeb-2", Namespace: "my-ns"},
+			Spec: wfv1.WorkflowEventBindingSpec{
+				Event: wfv1.Event{Selector: "true", Transform: "rubbish!!!"},
+				Submit: &wfv1.Submit{
+					WorkflowTemplateRef: wfv1.WorkflowTemplateRef{Name: "my-wft"},
+				},
+			},
+		},
+	}, "my-ns", "my-discriminator", &wfv1.Item{Value: json.RawMessage(`{"ref": "main", "after": "abc", "commits": [{"id": "a"}, {"id": "b"}]}`)})
+	assert.NoError(t, err)
+	err = operation.Dispatch(ctx)
+	assert.Error(t, err)
+
+	// assert
+	wf, err := client.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf-abc", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, `abc`, wf.Spec.Arguments.Parameters[0].Value.String())
+	}
+	wf, err = client.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf-2-abc", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, `["a","b"]`, wf.Spec.Arguments.Parameters[0].Value.String())
+	}
+	assert.Equal(t, "Warning WorkflowEventBindingError failed to dispatch event: failed to get workflow template: workflowtemplates.argoproj.io \"not-found\" not found", <-recorder.Events)
+	assert.Equal(t, "Warning WorkflowEventBindingError failed to dispatch event: failed to evaluate event transform expression: unexpected token Operator(\"!\") (1:8)\n | rubbish!!!\n | .......^", <-recorder.Events)
+}
+
 func Test_expressionEnvironment(t *testing.T) {
 	env, err := expressionEnvironment(context.TODO(), "my-ns", "my-d", &wfv1.Item{Value: []byte(`{"foo":"bar"}`)})
 	if assert.NoError(t, err) {
@@ -0,0 +1,200 @@
+package federation
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+type cluster struct {
+	wfClient   versioned.Interface
+	kubeClient kubernetes.Interface
+}
+
+// Server serves the workflows of the Argo Server's own cluster, and of other clusters, as if they were in one cluster.
+// Users access their own cluster with their own credentials, and the other clusters with the configured credentials,
+// but only if they would be allowed to do the same in their own cluster.
+type Server struct {
+	instanceIDService instanceid.Service
+	// the name of the Argo Server's own cluster
+	local    string
+	clusters map[string]cluster
+}
+
+// New creates clients for each of the federated clusters using the kubeconfig in their secret
+func New(ctx context.Context, instanceIDService instanceid.Service, kubeClient kubernetes.Interface, namespace string, cfg *config.FederationConfig) (*Server, error) {
+	clusters := make(map[string]cluster)
+	for _, c := range cfg.Clusters {
+		if c.Name == cfg.GetCluster() {
+			return nil, fmt.Errorf("federated cluster %q has the same name as this cluster", c.Name)
+		}
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, c.KubeConfig.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kubeconfig for federated cluster %q: %w", c.Name, err)
+		}
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[c.KubeConfig.Key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig for federated cluster %q: %w", c.Name, err)
+		}
+		wfClient, err := versioned.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		clusterKubeClient, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		clusters[c.Name] = cluster{wfClient: wfClient, kubeClient: clusterKubeClient}
+		log.WithField("cluster", c.Name).Info("Federated cluster")
+	}
+	return newServer(instanceIDService, cfg.GetCluster(), clusters), nil
+}
+
+func newServer(instanceIDService instanceid.Service, local string, clusters map[string]cluster) *Server {
+	return &Server{instanceIDService: instanceIDService, local: local, clusters: clusters}
+}
+
+var _ federationpkg.FederationServiceServer = &Server{}
+
+// ListFederatedWorkflows lists the workflows of all clusters, or all namespaces if the namespace is empty
+func (s *Server) ListFederatedWorkflows(ctx context.Context, req *federationpkg.FederatedWorkflowListRequest) (*wfv1.WorkflowList, error) {
+	listOptions := &metav1.ListOptions{}
+	if req.ListOptions != nil {
+		listOptions.LabelSelector = req.ListOptions.LabelSelector
+		listOptions.FieldSelector = req.ListOptions.FieldSelector
+	}
+	s.instanceIDService.With(listOptions)
+	list, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(req.Namespace).List(ctx, *listOptions)
+	if err != nil {
+		return nil, clusterError(s.local, err)
+	}
+	items := withCluster(list.Items, s.local)
+	if len(s.clusters) > 0 {
+		if err := s.canI(ctx, "list", req.Namespace, ""); err != nil {
+			return nil, clusterError(s.local, err)
+		}
+		for name, c := range s.clusters {
+			list, err := c.wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).List(ctx, *listOptions)
+			if err != nil {
+				return nil, clusterError(name, err)
+			}
+			items = append(items, withCluster(list.Items, name)...)
+		}
+	}
+	sort.Sort(items)
+	return &wfv1.WorkflowList{Items: items}, nil
+}
+
+func (s *Server) GetFederatedWorkflow(ctx context.Context, req *federationpkg.FederatedWorkflowGetRequest) (*wfv1.Workflow, error) {
+	wfClient, _, err := s.clients(ctx, req.Cluster, req.Namespace, req.Name)
+	if err != nil {
+		return nil, clusterError(req.Cluster, err)
+	}
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, clusterError(req.Cluster, err)
+	}
+	if err := s.instanceIDService.Validate(wf); err != nil {
+		return nil, clusterError(req.Cluster, apierr.NewNotFound(wfv1.Resource(workflow.WorkflowPlural), req.Name))
+	}
+	return &withCluster(wfv1.Workflows{*wf}, req.Cluster)[0], nil
+}
+
+// FederatedWorkflowLogs streams the logs of one of the workflow's pods
+func (s *Server) FederatedWorkflowLogs(req *federationpkg.FederatedWorkflowLogRequest, stream federationpkg.FederationService_FederatedWorkflowLogsServer) error {
+	ctx := stream.Context()
+	container := req.Container
+	if container == "" {
+		container = common.MainContainerName
+	}
+	_, kubeClient, err := s.clients(ctx, req.Cluster, req.Namespace, req.Name)
+	if err != nil {
+		return clusterError(req.Cluster, err)
+	}
+	pod, err := kubeClient.CoreV1().Pods(req.Namespace).Get(ctx, req.PodName, metav1.GetOptions{})
+	if err != nil {
+		return clusterError(req.Cluster, err)
+	}
+	// the user may only be allowed to get the workflow, so they must not be able to get the logs of any other pod
+	if pod.Labels[common.LabelKeyWorkflow] != req.Name {
+		return clusterError(req.Cluster, apierr.NewNotFound(corev1.Resource("pods"), req.PodName))
+	}
+	logs, err := kubeClient.CoreV1().Pods(req.Namespace).GetLogs(req.PodName, &corev1.PodLogOptions{Container: container}).Stream(ctx)
+	if err != nil {
+		return clusterError(req.Cluster, err)
+	}
+	defer func() { _ = logs.Close() }()
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		if err := stream.Send(&federationpkg.FederatedLogEntry{Content: scanner.Text()}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// clients returns the clients for the cluster, as long as the user can get the workflow in their own cluster
+func (s *Server) clients(ctx context.Context, clusterName, namespace, name string) (versioned.Interface, kubernetes.Interface, error) {
+	if clusterName == s.local {
+		return auth.GetWfClient(ctx), auth.GetKubeClient(ctx), nil
+	}
+	c, ok := s.clusters[clusterName]
+	if !ok {
+		return nil, nil, apierr.NewNotFound(schema.GroupResource{Resource: "clusters"}, clusterName)
+	}
+	if err := s.canI(ctx, "get", namespace, name); err != nil {
+		return nil, nil, err
+	}
+	return c.wfClient, c.kubeClient, nil
+}
+
+func (s *Server) canI(ctx context.Context, verb, namespace, name string) error {
+	allowed, err := auth.CanI(ctx, verb, workflow.WorkflowPlural, namespace, name)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierr.NewForbidden(wfv1.Resource(workflow.WorkflowPlural), name, errors.New("not allowed in this cluster"))
+	}
+	return nil
+}
+
+func withCluster(items wfv1.Workflows, clusterName string) wfv1.Workflows {
+	for i := range items {
+		if items[i].Annotations == nil {
+			items[i].Annotations = map[string]string{}
+		}
+		items[i].Annotations[common.AnnotationKeyCluster] = clusterName
+	}
+	return items
+}
+
+// clusterError prefixes the message of the error with the name of the cluster it came from, keeping its status
+func clusterError(clusterName string, err error) error {
+	e := &apierr.StatusError{}
+	if errors.As(err, &e) {
+		status := e.Status()
+		status.Message = fmt.Sprintf("cluster %q: %s", clusterName, status.Message)
+		return &apierr.StatusError{ErrStatus: status}
+	}
+	return fmt.Errorf("cluster %q: %w", clusterName, err)
+}
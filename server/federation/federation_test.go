@@ -0,0 +1,102 @@
+package federation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	federationpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/federation"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newTestServer(allowed bool) (context.Context, *Server) {
+	wf := func(name string) *wfv1.Workflow {
+		return &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns"}}
+	}
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+	})
+	ctx := context.WithValue(context.WithValue(context.Background(), auth.KubeKey, kubeClient), auth.WfKey, fake.NewSimpleClientset(wf("local-wf")))
+	remoteKubeClient := kubefake.NewSimpleClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "remote-pod", Namespace: "my-ns", Labels: map[string]string{common.LabelKeyWorkflow: "remote-wf"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-pod", Namespace: "my-ns"}},
+	)
+	return ctx, newServer(instanceid.NewService(""), "local", map[string]cluster{
+		"remote": {wfClient: fake.NewSimpleClientset(wf("remote-wf")), kubeClient: remoteKubeClient},
+	})
+}
+
+type logsServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	entries []*federationpkg.FederatedLogEntry
+}
+
+func (s *logsServer) Context() context.Context { return s.ctx }
+
+func (s *logsServer) Send(entry *federationpkg.FederatedLogEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func getWorkflow(ctx context.Context, s *Server, cluster, name string) error {
+	_, err := s.GetFederatedWorkflow(ctx, &federationpkg.FederatedWorkflowGetRequest{Cluster: cluster, Namespace: "my-ns", Name: name})
+	return err
+}
+
+func TestServer(t *testing.T) {
+	ctx, s := newTestServer(true)
+	t.Run("ListFederatedWorkflows", func(t *testing.T) {
+		list, err := s.ListFederatedWorkflows(ctx, &federationpkg.FederatedWorkflowListRequest{Namespace: "my-ns"})
+		if assert.NoError(t, err) {
+			clusters := map[string]string{}
+			for _, wf := range list.Items {
+				clusters[wf.Name] = wf.Annotations[common.AnnotationKeyCluster]
+			}
+			assert.Equal(t, map[string]string{"local-wf": "local", "remote-wf": "remote"}, clusters)
+		}
+	})
+	t.Run("GetFederatedWorkflow", func(t *testing.T) {
+		wf, err := s.GetFederatedWorkflow(ctx, &federationpkg.FederatedWorkflowGetRequest{Cluster: "remote", Namespace: "my-ns", Name: "remote-wf"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "remote", wf.Annotations[common.AnnotationKeyCluster])
+		}
+		assert.NoError(t, getWorkflow(ctx, s, "local", "local-wf"))
+		assert.True(t, apierr.IsNotFound(getWorkflow(ctx, s, "remote", "local-wf")))
+		err = getWorkflow(ctx, s, "unknown", "remote-wf")
+		assert.True(t, apierr.IsNotFound(err))
+		assert.Contains(t, err.Error(), `cluster "unknown": `)
+	})
+	t.Run("FederatedWorkflowLogs", func(t *testing.T) {
+		stream := &logsServer{ctx: ctx}
+		err := s.FederatedWorkflowLogs(&federationpkg.FederatedWorkflowLogRequest{Cluster: "remote", Namespace: "my-ns", Name: "remote-wf", PodName: "remote-pod"}, stream)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []*federationpkg.FederatedLogEntry{{Content: "fake logs"}}, stream.entries)
+		}
+		err = s.FederatedWorkflowLogs(&federationpkg.FederatedWorkflowLogRequest{Cluster: "remote", Namespace: "my-ns", Name: "remote-wf", PodName: "other-pod"}, &logsServer{ctx: ctx})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+}
+
+func TestServerForbidden(t *testing.T) {
+	ctx, s := newTestServer(false)
+	_, err := s.ListFederatedWorkflows(ctx, &federationpkg.FederatedWorkflowListRequest{Namespace: "my-ns"})
+	assert.True(t, apierr.IsForbidden(err))
+	assert.True(t, apierr.IsForbidden(getWorkflow(ctx, s, "remote", "remote-wf")))
+	// the user's own credentials are used for their own cluster
+	assert.NoError(t, getWorkflow(ctx, s, "local", "local-wf"))
+}
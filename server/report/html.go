@@ -0,0 +1,109 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// the report must be self-contained, so it has no external scripts, styles or images
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"time": func(t metav1.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	},
+	"resources": func(r wfv1.ResourcesDuration) string {
+		return r.String()
+	},
+	"yaml": func(v interface{}) (string, error) {
+		data, err := yaml.Marshal(v)
+		return string(data), err
+	},
+	"sortedKeys": func(m map[string]string) []string {
+		var keys []string
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return keys
+	},
+	"warning": func(t string) bool {
+		return t == corev1.EventTypeWarning
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Namespace}}/{{.Name}} run report</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+pre { background: #f6f6f6; padding: 1em; overflow: auto; }
+.Succeeded { color: #18be94; } .Failed, .Error, .warning { color: #e96d76; } .Running, .Pending { color: #0dadea; }
+</style>
+</head>
+<body>
+<h1>{{.Namespace}}/{{.Name}}</h1>
+<table>
+<tr><th>UID</th><td>{{.UID}}</td></tr>
+<tr><th>Phase</th><td class="{{.Phase}}">{{.Phase}}</td></tr>
+{{- if .Message}}<tr><th>Message</th><td>{{.Message}}</td></tr>{{end}}
+<tr><th>Started</th><td>{{time .StartedAt}}</td></tr>
+<tr><th>Finished</th><td>{{time .FinishedAt}}</td></tr>
+<tr><th>Duration</th><td>{{.Duration}}</td></tr>
+<tr><th>Resources duration</th><td>{{resources .ResourcesDuration}}</td></tr>
+<tr><th>Report generated</th><td>{{time .GeneratedAt}}</td></tr>
+</table>
+{{- if .Labels}}
+<h2>Labels</h2>
+<table>
+{{- range $k := sortedKeys .Labels}}
+<tr><th>{{$k}}</th><td>{{index $.Labels $k}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Parameters}}
+<h2>Parameters</h2>
+<table>
+{{- range .Parameters}}
+<tr><th>{{.Name}}</th><td>{{if .Value}}{{.Value}}{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<h2>Nodes</h2>
+<table>
+<tr><th>Name</th><th>Type</th><th>Template</th><th>Pod</th><th>Phase</th><th>Started</th><th>Finished</th><th>Duration</th><th>Resources duration</th><th>Artifacts</th><th>Message</th></tr>
+{{- range .Nodes}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.TemplateName}}</td><td>{{.PodName}}</td><td class="{{.Phase}}">{{.Phase}}</td><td>{{time .StartedAt}}</td><td>{{time .FinishedAt}}</td><td>{{.Duration}}</td><td>{{resources .ResourcesDuration}}</td><td>{{range .Artifacts}}<a href="{{.URL}}">{{.Name}}</a> {{end}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- if .Events}}
+<h2>Events</h2>
+<table>
+<tr><th>Time</th><th>Type</th><th>Reason</th><th>Message</th></tr>
+{{- range .Events}}
+<tr{{if warning .Type}} class="warning"{{end}}><td>{{time .Time}}</td><td>{{.Type}}</td><td>{{.Reason}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<h2>Spec</h2>
+<pre>{{yaml .Spec}}</pre>
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a self-contained HTML file
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// Report is a complete record of a workflow run, for attaching to tickets and compliance archives
+type Report struct {
+	GeneratedAt       metav1.Time            `json:"generatedAt"`
+	Name              string                 `json:"name"`
+	Namespace         string                 `json:"namespace"`
+	UID               string                 `json:"uid"`
+	Labels            map[string]string      `json:"labels,omitempty"`
+	Phase             wfv1.WorkflowPhase     `json:"phase,omitempty"`
+	Message           string                 `json:"message,omitempty"`
+	StartedAt         metav1.Time            `json:"startedAt,omitempty"`
+	FinishedAt        metav1.Time            `json:"finishedAt,omitempty"`
+	Duration          string                 `json:"duration,omitempty"`
+	ResourcesDuration wfv1.ResourcesDuration `json:"resourcesDuration,omitempty"`
+	Parameters        []wfv1.Parameter       `json:"parameters,omitempty"`
+	Spec              wfv1.WorkflowSpec      `json:"spec"`
+	Nodes             []Node                 `json:"nodes,omitempty"`
+	Events            []Event                `json:"events,omitempty"`
+	Conditions        wfv1.Conditions        `json:"conditions,omitempty"`
+	Outputs           *wfv1.Outputs          `json:"outputs,omitempty"`
+}
+
+// Node is a record of a node of the workflow
+type Node struct {
+	ID                string                 `json:"id"`
+	Name              string                 `json:"name"`
+	DisplayName       string                 `json:"displayName"`
+	Type              wfv1.NodeType          `json:"type"`
+	TemplateName      string                 `json:"templateName,omitempty"`
+	PodName           string                 `json:"podName,omitempty"`
+	Phase             wfv1.NodePhase         `json:"phase,omitempty"`
+	Message           string                 `json:"message,omitempty"`
+	StartedAt         metav1.Time            `json:"startedAt,omitempty"`
+	FinishedAt        metav1.Time            `json:"finishedAt,omitempty"`
+	Duration          string                 `json:"duration,omitempty"`
+	ResourcesDuration wfv1.ResourcesDuration `json:"resourcesDuration,omitempty"`
+	Artifacts         []Artifact             `json:"artifacts,omitempty"`
+}
+
+// Artifact is an output artifact of a node, with a link to download it from the Argo Server
+type Artifact struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Event is a Kubernetes event about the workflow
+type Event struct {
+	Time    metav1.Time `json:"time"`
+	Type    string      `json:"type"`
+	Reason  string      `json:"reason"`
+	Message string      `json:"message"`
+}
+
+// New creates the report of the workflow, with links to download artifacts relative to the Argo Server's URL, e.g.
+// "https://localhost:2746/"
+func New(wf *wfv1.Workflow, events []corev1.Event, serverURL string, now time.Time) *Report {
+	spec := wf.GetExecSpec()
+	r := &Report{
+		GeneratedAt:       metav1.NewTime(now),
+		Name:              wf.Name,
+		Namespace:         wf.Namespace,
+		UID:               string(wf.UID),
+		Labels:            wf.Labels,
+		Phase:             wf.Status.Phase,
+		Message:           wf.Status.Message,
+		StartedAt:         wf.Status.StartedAt,
+		FinishedAt:        wf.Status.FinishedAt,
+		Duration:          formatDuration(wf.Status.GetDuration()),
+		ResourcesDuration: wf.Status.ResourcesDuration,
+		Parameters:        spec.Arguments.Parameters,
+		Spec:              *spec,
+		Conditions:        wf.Status.Conditions,
+		Outputs:           wf.Status.Outputs,
+	}
+	podNameVersion := util.GetWorkflowPodNameVersion(wf)
+	for _, n := range wf.Status.Nodes {
+		node := Node{
+			ID:                n.ID,
+			Name:              n.Name,
+			DisplayName:       n.DisplayName,
+			Type:              n.Type,
+			TemplateName:      n.TemplateName,
+			Phase:             n.Phase,
+			Message:           n.Message,
+			StartedAt:         n.StartedAt,
+			FinishedAt:        n.FinishedAt,
+			Duration:          formatDuration(n.GetDuration()),
+			ResourcesDuration: n.ResourcesDuration,
+		}
+		if n.Type == wfv1.NodeTypePod {
+			node.PodName = util.PodName(wf.Name, n.Name, n.TemplateName, n.ID, podNameVersion)
+		}
+		if n.Outputs != nil {
+			for _, a := range n.Outputs.Artifacts {
+				node.Artifacts = append(node.Artifacts, Artifact{
+					Name: a.Name,
+					URL:  fmt.Sprintf("%sartifact-files/%s/workflows/%s/%s/outputs/%s", serverURL, url.PathEscape(wf.Namespace), url.PathEscape(wf.Name), url.PathEscape(n.ID), url.PathEscape(a.Name)),
+				})
+			}
+		}
+		r.Nodes = append(r.Nodes, node)
+	}
+	sort.Slice(r.Nodes, func(i, j int) bool {
+		x, y := r.Nodes[i], r.Nodes[j]
+		if !x.StartedAt.Equal(&y.StartedAt) {
+			return x.StartedAt.Before(&y.StartedAt)
+		}
+		return x.ID < y.ID
+	})
+	for _, e := range events {
+		t := e.LastTimestamp
+		if t.IsZero() {
+			t = metav1.NewTime(e.EventTime.Time)
+		}
+		r.Events = append(r.Events, Event{Time: t, Type: e.Type, Reason: e.Reason, Message: e.Message})
+	}
+	sort.SliceStable(r.Events, func(i, j int) bool { return r.Events[i].Time.Before(&r.Events[j].Time) })
+	return r
+}
+
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.Truncate(time.Second).String()
+}
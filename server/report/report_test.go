@@ -0,0 +1,94 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var testWorkflow = wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+  uid: my-uid
+  labels:
+    team: a
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: message
+        value: hello
+  templates:
+    - name: main
+      container:
+        image: argoproj/argosay:v2
+status:
+  phase: Succeeded
+  startedAt: "2022-01-01T00:00:00Z"
+  finishedAt: "2022-01-01T00:01:30Z"
+  resourcesDuration:
+    cpu: 5
+  nodes:
+    my-wf:
+      id: my-wf
+      name: my-wf
+      displayName: my-wf
+      type: Steps
+      templateName: steps
+      phase: Succeeded
+      startedAt: "2022-01-01T00:00:00Z"
+      finishedAt: "2022-01-01T00:01:30Z"
+    my-wf-1:
+      id: my-wf-1
+      name: my-wf[0].main
+      displayName: main
+      type: Pod
+      templateName: main
+      phase: Succeeded
+      startedAt: "2022-01-01T00:00:10Z"
+      finishedAt: "2022-01-01T00:01:20Z"
+      outputs:
+        artifacts:
+          - name: my-art
+            s3:
+              key: my-key
+`)
+
+var testEvents = []corev1.Event{{Type: corev1.EventTypeWarning, Reason: "WorkflowNodeFailed", Message: "<failed>", LastTimestamp: metav1.NewTime(time.Date(2022, 1, 1, 0, 1, 0, 0, time.UTC))}}
+
+func TestNew(t *testing.T) {
+	r := New(testWorkflow, testEvents, "https://localhost:2746/", time.Now())
+	assert.Equal(t, "my-uid", r.UID)
+	assert.Equal(t, "1m30s", r.Duration)
+	assert.Equal(t, "hello", r.Parameters[0].Value.String())
+	if assert.Len(t, r.Nodes, 2) {
+		assert.Equal(t, "my-wf", r.Nodes[0].ID)
+		assert.Empty(t, r.Nodes[0].PodName)
+		node := r.Nodes[1]
+		assert.Equal(t, "1m10s", node.Duration)
+		assert.Equal(t, "my-wf-main-2424314658", node.PodName)
+		assert.Equal(t, []Artifact{{Name: "my-art", URL: "https://localhost:2746/artifact-files/my-ns/workflows/my-wf/my-wf-1/outputs/my-art"}}, node.Artifacts)
+	}
+	if assert.Len(t, r.Events, 1) {
+		assert.Equal(t, "WorkflowNodeFailed", r.Events[0].Reason)
+	}
+}
+
+func TestReport_WriteHTML(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if assert.NoError(t, New(testWorkflow, testEvents, "https://localhost:2746/", time.Now()).WriteHTML(buf)) {
+		html := buf.String()
+		assert.Contains(t, html, "<h1>my-ns/my-wf</h1>")
+		assert.Contains(t, html, "<tr><th>message</th><td>hello</td></tr>")
+		assert.Contains(t, html, `<a href="https://localhost:2746/artifact-files/my-ns/workflows/my-wf/my-wf-1/outputs/my-art">my-art</a>`)
+		assert.Contains(t, html, "&lt;failed&gt;")
+		assert.Contains(t, html, "image: argoproj/argosay:v2")
+	}
+}
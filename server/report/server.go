@@ -0,0 +1,118 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/types"
+	"github.com/argoproj/argo-workflows/v3/util/httputil"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+)
+
+// Server serves run reports of workflows
+type Server struct {
+	gatekeeper        auth.Gatekeeper
+	hydrator          hydrator.Interface
+	instanceIDService instanceid.Service
+	baseHRef          string
+	secure            bool
+}
+
+func NewServer(gatekeeper auth.Gatekeeper, hydrator hydrator.Interface, instanceIDService instanceid.Service, baseHRef string, secure bool) *Server {
+	return &Server{gatekeeper: gatekeeper, hydrator: hydrator, instanceIDService: instanceIDService, baseHRef: baseHRef, secure: secure}
+}
+
+// ServeHTTP serves `GET /workflow-reports/{namespace}/{name}?format=html|json`, as a file to download
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	requestPath := strings.Split(strings.TrimPrefix(r.URL.Path, "/workflow-reports/"), "/")
+	if r.Method != http.MethodGet || len(requestPath) != 2 {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	namespace, name := requestPath[0], requestPath[1]
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "html"
+	}
+	if format != "html" && format != "json" {
+		http.Error(w, "format must be html or json", http.StatusBadRequest)
+		return
+	}
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		if cookie, err := r.Cookie("authorization"); err == nil {
+			token = cookie.Value
+		}
+	}
+	ctx, err := s.gatekeeper.ContextWithRequest(metadata.NewIncomingContext(r.Context(), metadata.MD{"authorization": []string{token}}), types.NamespaceHolder(namespace))
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	wf, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		httputil.Error(w, err)
+		return
+	}
+	if err := s.instanceIDService.Validate(wf); err != nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	if err := s.hydrator.Hydrate(ctx, wf); err != nil {
+		httputil.Error(w, err)
+		return
+	}
+	var events []corev1.Event
+	list, err := auth.GetKubeClient(ctx).CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(wf.UID)).String(),
+	})
+	if apierr.IsForbidden(err) {
+		// the user may be allowed to get workflows, but not events, in which case the report has no events
+		log.WithError(err).Debug("Not allowed to list workflow events for report")
+	} else if err != nil {
+		httputil.Error(w, err)
+		return
+	} else {
+		events = list.Items
+	}
+	report := New(wf, events, s.serverURL(r), time.Now())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-report.%s"`, wf.Name, format))
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(report)
+	} else {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = report.WriteHTML(w)
+	}
+	if err != nil {
+		log.WithError(err).Error("failed to write workflow report")
+	}
+}
+
+// serverURL is the URL of the Argo Server, as the user sees it, so that the links in reports work when downloaded
+func (s *Server) serverURL(r *http.Request) string {
+	scheme := r.Header.Get("X-Forwarded-Proto")
+	if scheme == "" {
+		scheme = "http"
+		if s.secure {
+			scheme = "https"
+		}
+	}
+	host := r.Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = r.Host
+	}
+	return scheme + "://" + host + s.baseHRef
+}
@@ -0,0 +1,57 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	authmocks "github.com/argoproj/argo-workflows/v3/server/auth/mocks"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+)
+
+func TestServer(t *testing.T) {
+	ctx := context.WithValue(context.WithValue(context.Background(), auth.KubeKey, kubefake.NewSimpleClientset()), auth.WfKey, fake.NewSimpleClientset(testWorkflow))
+	gatekeeper := &authmocks.Gatekeeper{}
+	gatekeeper.On("ContextWithRequest", mock.Anything, mock.Anything).Return(ctx, nil)
+	s := NewServer(gatekeeper, hydratorfake.Noop, instanceid.NewService(""), "/", true)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Host = "localhost:2746"
+		s.ServeHTTP(w, r)
+		return w
+	}
+	t.Run("HTML", func(t *testing.T) {
+		w := get("/workflow-reports/my-ns/my-wf")
+		if assert.Equal(t, http.StatusOK, w.Code) {
+			assert.Equal(t, `attachment; filename="my-wf-report.html"`, w.Header().Get("Content-Disposition"))
+			assert.Contains(t, w.Body.String(), "https://localhost:2746/artifact-files/my-ns/workflows/my-wf/my-wf-1/outputs/my-art")
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		w := get("/workflow-reports/my-ns/my-wf?format=json")
+		if assert.Equal(t, http.StatusOK, w.Code) {
+			r := &Report{}
+			if assert.NoError(t, json.Unmarshal(w.Body.Bytes(), r)) {
+				assert.Equal(t, "my-wf", r.Name)
+				assert.Len(t, r.Nodes, 2)
+			}
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("/workflow-reports/my-ns/not-found").Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, get("/workflow-reports/my-ns/my-wf?format=pdf").Code)
+		assert.Equal(t, http.StatusBadRequest, get("/workflow-reports/my-ns").Code)
+	})
+}
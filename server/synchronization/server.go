@@ -0,0 +1,115 @@
+package synchronization
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/sync"
+)
+
+// syncLockServer serves the status of the semaphores and mutexes of a namespace, built from the workflows holding or waiting
+// for them, and forced releases of them
+type syncLockServer struct {
+	hydrator          hydrator.Interface
+	instanceIDService instanceid.Service
+}
+
+func NewSyncLockServer(hydrator hydrator.Interface, instanceIDService instanceid.Service) syncpkg.SyncLockServiceServer {
+	return &syncLockServer{hydrator: hydrator, instanceIDService: instanceIDService}
+}
+
+func (s *syncLockServer) ListSyncLocks(ctx context.Context, req *syncpkg.ListSyncLocksRequest) (*syncpkg.LockList, error) {
+	listOptions := metav1.ListOptions{}
+	s.instanceIDService.With(&listOptions)
+	list, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(req.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	var wfs []wfv1.Workflow
+	for _, wf := range list.Items {
+		if wf.Status.Synchronization == nil || wf.Status.Fulfilled() {
+			continue
+		}
+		if err := s.hydrator.Hydrate(ctx, &wf); err != nil {
+			return nil, err
+		}
+		wfs = append(wfs, wf)
+	}
+	return &syncpkg.LockList{Items: lockStatuses(sync.GetLockStatuses(wfs, time.Now()))}, nil
+}
+
+func (s *syncLockServer) ReleaseSyncLock(ctx context.Context, req *syncpkg.ReleaseSyncLockRequest) (*syncpkg.ReleaseSyncLockResponse, error) {
+	if req.Lock == "" || req.Workflow == "" {
+		return nil, status.Error(codes.InvalidArgument, "lock and workflow are required")
+	}
+	workflows := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(req.Namespace)
+	wf, err := workflows.Get(ctx, req.Workflow, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.instanceIDService.Validate(wf); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if !holds(wf, req.Lock) {
+		return nil, status.Error(codes.NotFound, "workflow does not hold the lock")
+	}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{common.AnnotationKeyReleaseLock: req.Lock},
+		},
+	})
+	if _, err := workflows.Patch(ctx, req.Workflow, apitypes.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{"namespace": req.Namespace, "workflow": req.Workflow, "lock": req.Lock}).Info("Forced release of lock requested")
+	// the controller releases the lock once it reconciles the workflow
+	return &syncpkg.ReleaseSyncLockResponse{}, nil
+}
+
+// holds returns whether the workflow, or any of its nodes, holds the lock
+func holds(wf *wfv1.Workflow, lockName string) bool {
+	if wf.Status.Synchronization == nil {
+		return false
+	}
+	if semaphore := wf.Status.Synchronization.Semaphore; semaphore != nil {
+		if i, holding := semaphore.GetHolding(lockName); i >= 0 && len(holding.Holders) > 0 {
+			return true
+		}
+	}
+	if mutex := wf.Status.Synchronization.Mutex; mutex != nil {
+		if i, holding := mutex.GetHolding(lockName); i >= 0 && holding.Holder != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func lockStatuses(in []sync.LockStatus) []*syncpkg.LockStatus {
+	var out []*syncpkg.LockStatus
+	for _, lock := range in {
+		out = append(out, &syncpkg.LockStatus{Name: lock.Name, Type: string(lock.Type), Holders: lockUsers(lock.Holders), Waiting: lockUsers(lock.Waiting)})
+	}
+	return out
+}
+
+func lockUsers(in []sync.LockUser) []*syncpkg.LockUser {
+	var out []*syncpkg.LockUser
+	for _, user := range in {
+		since := user.Since
+		out = append(out, &syncpkg.LockUser{Namespace: user.Namespace, Workflow: user.Workflow, NodeId: user.NodeID, Weight: user.Weight, Since: &since, Duration: user.Duration})
+	}
+	return out
+}
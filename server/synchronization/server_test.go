@@ -0,0 +1,63 @@
+package synchronization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	syncpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/synchronization"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+)
+
+var testWorkflow = &wfv1.Workflow{
+	ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wf"},
+	Status: wfv1.WorkflowStatus{
+		Phase: wfv1.WorkflowRunning,
+		Synchronization: &wfv1.SynchronizationStatus{
+			Mutex: &wfv1.MutexStatus{Holding: []wfv1.MutexHolding{{Mutex: "my-ns/Mutex/my-mutex", Holder: "my-wf"}}},
+		},
+	},
+}
+
+func TestServer(t *testing.T) {
+	wfClient := fake.NewSimpleClientset(testWorkflow)
+	ctx := context.WithValue(context.Background(), auth.WfKey, wfClient)
+	s := NewSyncLockServer(hydratorfake.Noop, instanceid.NewService(""))
+	t.Run("ListSyncLocks", func(t *testing.T) {
+		list, err := s.ListSyncLocks(ctx, &syncpkg.ListSyncLocksRequest{Namespace: "my-ns"})
+		if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+			assert.Equal(t, "my-ns/Mutex/my-mutex", list.Items[0].Name)
+			assert.Equal(t, "Mutex", list.Items[0].Type)
+			assert.Equal(t, "my-wf", list.Items[0].Holders[0].Workflow)
+		}
+	})
+	t.Run("ReleaseSyncLock", func(t *testing.T) {
+		_, err := s.ReleaseSyncLock(ctx, &syncpkg.ReleaseSyncLockRequest{Namespace: "my-ns", Lock: "my-ns/Mutex/my-mutex", Workflow: "my-wf"})
+		if assert.NoError(t, err) {
+			wf, err := wfClient.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf", metav1.GetOptions{})
+			if assert.NoError(t, err) {
+				assert.Equal(t, "my-ns/Mutex/my-mutex", wf.Annotations[common.AnnotationKeyReleaseLock])
+			}
+		}
+	})
+	t.Run("ReleaseNotHeld", func(t *testing.T) {
+		_, err := s.ReleaseSyncLock(ctx, &syncpkg.ReleaseSyncLockRequest{Namespace: "my-ns", Lock: "my-ns/Mutex/other", Workflow: "my-wf"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = s.ReleaseSyncLock(ctx, &syncpkg.ReleaseSyncLockRequest{Namespace: "my-ns", Lock: "my-ns/Mutex/my-mutex", Workflow: "not-found"})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+	t.Run("InvalidArgument", func(t *testing.T) {
+		_, err := s.ReleaseSyncLock(ctx, &syncpkg.ReleaseSyncLockRequest{Namespace: "my-ns"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/logs"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// archivedLogs finds the `<container>-logs` artifacts saved by `archiveLogs`
+type archivedLogs struct {
+	hydrator             hydrator.Interface
+	artifactRepositories artifactrepositories.Interface
+	newDriver            artifact.NewDriverFunc
+}
+
+var _ logs.ArchivedLogs = &archivedLogs{}
+
+func (a *archivedLogs) Find(ctx context.Context, wf *wfv1.Workflow, container string) (map[string]logs.ArchivedLog, error) {
+	if err := a.hydrator.Hydrate(ctx, wf); err != nil {
+		return nil, err
+	}
+	version := util.GetWorkflowPodNameVersion(wf)
+	found := make(map[string]logs.ArchivedLog)
+	for _, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		art := node.Outputs.GetArtifactByName(container + "-logs")
+		if art == nil {
+			continue
+		}
+		node, logsArt := node, *art
+		found[util.PodName(wf.Name, node.Name, node.TemplateName, node.ID, version)] = logs.ArchivedLog{
+			StartedAt: node.StartedAt.Time,
+			Open:      func() (io.ReadCloser, error) { return a.open(ctx, wf, node, &logsArt) },
+		}
+	}
+	return found, nil
+}
+
+// open opens the artifact, which is in the archive location of the node's template or the workflow's artifact repository
+func (a *archivedLogs) open(ctx context.Context, wf *wfv1.Workflow, node wfv1.NodeStatus, art *wfv1.Artifact) (io.ReadCloser, error) {
+	var archiveLocation *wfv1.ArtifactLocation
+	if tmpl := wf.GetTemplateByName(util.GetTemplateFromNode(node)); tmpl != nil {
+		archiveLocation = tmpl.ArchiveLocation
+	}
+	if !archiveLocation.HasLocation() {
+		ref := wf.Status.ArtifactRepositoryRef
+		if ref == nil {
+			ref = &wfv1.ArtifactRepositoryRefStatus{Default: true}
+		}
+		ar, err := a.artifactRepositories.Get(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		archiveLocation = ar.ToArtifactLocation()
+	}
+	if err := art.Relocate(archiveLocation); err != nil {
+		return nil, fmt.Errorf("failed to locate archived logs %s of node %s: %w", art.Name, node.ID, err)
+	}
+	driver, err := a.newDriver(ctx, art, resources{auth.GetKubeClient(ctx), wf.Namespace})
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(log.Fields{"nodeId": node.ID, "artifactName": art.Name}).Debug("Opening archived logs")
+	return driver.OpenStream(art)
+}
+
+type resources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r resources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r resources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
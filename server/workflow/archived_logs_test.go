@@ -0,0 +1,152 @@
+package workflow
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	fakewfv1 "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/logs"
+	armocks "github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories/mocks"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+type fakeLogsDriver struct {
+	artifactscommon.ArtifactDriver
+	logs map[string]string
+}
+
+func (d *fakeLogsDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(d.logs[a.S3.Key])), nil
+}
+
+type collectingLogsServer struct {
+	entries []*workflowpkg.LogEntry
+}
+
+func (s *collectingLogsServer) Send(entry *workflowpkg.LogEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func newArchivedLogsWorkflow() *wfv1.Workflow {
+	started := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	logsArtifact := func(key string) *wfv1.Outputs {
+		return &wfv1.Outputs{Artifacts: wfv1.Artifacts{{Name: "main-logs", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: key}}}}}
+	}
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wf"},
+		Spec:       wfv1.WorkflowSpec{Templates: []wfv1.Template{{Name: "my-tmpl"}}},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowSucceeded,
+			Nodes: wfv1.Nodes{
+				"my-wf": {ID: "my-wf", Name: "my-wf", Type: wfv1.NodeTypeSteps},
+				"my-wf-1": {ID: "my-wf-1", Name: "my-wf[0].deleted", TemplateName: "my-tmpl", Type: wfv1.NodeTypePod, StartedAt: started,
+					Outputs: logsArtifact("deleted/main.log")},
+				"my-wf-2": {ID: "my-wf-2", Name: "my-wf[1].live", TemplateName: "my-tmpl", Type: wfv1.NodeTypePod, StartedAt: metav1.NewTime(started.Add(time.Minute)),
+					Outputs: logsArtifact("live/main.log")},
+				"my-wf-3": {ID: "my-wf-3", Name: "my-wf[2].unarchived", TemplateName: "my-tmpl", Type: wfv1.NodeTypePod, StartedAt: started},
+			},
+		},
+	}
+}
+
+func newArchivedLogs(driver *fakeLogsDriver) *archivedLogs {
+	return &archivedLogs{
+		hydrator: hydratorfake.Noop,
+		artifactRepositories: armocks.DummyArtifactRepositories(&wfv1.ArtifactRepository{
+			S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Endpoint: "my-endpoint", Bucket: "my-bucket"}},
+		}),
+		newDriver: func(_ context.Context, _ *wfv1.Artifact, _ resource.Interface) (artifactscommon.ArtifactDriver, error) {
+			return driver, nil
+		},
+	}
+}
+
+func podName(wf *wfv1.Workflow, nodeID string) string {
+	node := wf.Status.Nodes[nodeID]
+	return util.PodName(wf.Name, node.Name, node.TemplateName, node.ID, util.GetWorkflowPodNameVersion(wf))
+}
+
+func TestArchivedLogs_Find(t *testing.T) {
+	wf := newArchivedLogsWorkflow()
+	a := newArchivedLogs(&fakeLogsDriver{logs: map[string]string{"deleted/main.log": "my-log"}})
+	ctx := context.WithValue(context.Background(), auth.KubeKey, fake.NewSimpleClientset())
+
+	found, err := a.Find(ctx, wf, "main")
+	if assert.NoError(t, err) && assert.Len(t, found, 2) {
+		archivedLog, ok := found[podName(wf, "my-wf-1")]
+		if assert.True(t, ok) {
+			assert.Equal(t, wf.Status.Nodes["my-wf-1"].StartedAt.Time, archivedLog.StartedAt)
+			rc, err := archivedLog.Open()
+			if assert.NoError(t, err) {
+				data, _ := ioutil.ReadAll(rc)
+				assert.Equal(t, "my-log", string(data))
+			}
+		}
+	}
+
+	found, err = a.Find(ctx, wf, "wait")
+	if assert.NoError(t, err) {
+		assert.Empty(t, found)
+	}
+}
+
+func TestWorkflowLogs_ArchivedLogs(t *testing.T) {
+	wf := newArchivedLogsWorkflow()
+	livePod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: podName(wf, "my-wf-2"), Labels: map[string]string{common.LabelKeyWorkflow: "my-wf"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}
+	kubeClient := fake.NewSimpleClientset(livePod)
+	ctx := context.WithValue(context.Background(), auth.KubeKey, kubeClient)
+	a := newArchivedLogs(&fakeLogsDriver{logs: map[string]string{
+		"deleted/main.log": "first\nsecond\nthird",
+		"live/main.log":    "archived",
+	}})
+
+	t.Run("Merged", func(t *testing.T) {
+		s := &collectingLogsServer{}
+		err := logs.WorkflowLogs(ctx, fakewfv1.NewSimpleClientset(wf), kubeClient, a, &workflowpkg.WorkflowLogRequest{Namespace: "my-ns", Name: "my-wf", LogOptions: &corev1.PodLogOptions{}}, s)
+		if assert.NoError(t, err) && assert.Len(t, s.entries, 4) {
+			for i, content := range []string{"first", "second", "third"} {
+				assert.Equal(t, podName(wf, "my-wf-1"), s.entries[i].PodName)
+				assert.Equal(t, content, s.entries[i].Content)
+			}
+			// the live pod's logs are streamed instead of its archived logs
+			assert.Equal(t, livePod.Name, s.entries[3].PodName)
+			assert.NotEqual(t, "archived", s.entries[3].Content)
+		}
+	})
+	t.Run("PodName", func(t *testing.T) {
+		s := &collectingLogsServer{}
+		err := logs.WorkflowLogs(ctx, fakewfv1.NewSimpleClientset(wf), kubeClient, a, &workflowpkg.WorkflowLogRequest{Namespace: "my-ns", Name: "my-wf", PodName: podName(wf, "my-wf-1"), Grep: "^s|d$", LogOptions: &corev1.PodLogOptions{TailLines: pointer.Int64Ptr(2)}}, s)
+		if assert.NoError(t, err) && assert.Len(t, s.entries, 2) {
+			assert.Equal(t, "second", s.entries[0].Content)
+			assert.Equal(t, "third", s.entries[1].Content)
+		}
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		s := &collectingLogsServer{}
+		err := logs.WorkflowLogs(ctx, fakewfv1.NewSimpleClientset(wf), kubeClient, nil, &workflowpkg.WorkflowLogRequest{Namespace: "my-ns", Name: "my-wf", LogOptions: &corev1.PodLogOptions{}}, s)
+		if assert.NoError(t, err) && assert.Len(t, s.entries, 1) {
+			assert.Equal(t, livePod.Name, s.entries[0].PodName)
+		}
+	})
+}
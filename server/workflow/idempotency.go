@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// idempotencyKeyTTL is how long a workflow created with an idempotency key is returned by requests to create another
+// workflow with the same key, rather than the other workflow being created
+var idempotencyKeyTTL = env.LookupEnvDurationOr("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+
+// maxIdempotentNamePrefixLength is the longest prefix of the generated name of a workflow created with an idempotency
+// key, so that the name, including the hash of the key, is no longer than a name generated by Kubernetes
+const maxIdempotentNamePrefixLength = 53
+
+// labelIdempotencyKey labels the workflow with the request's `Idempotency-Key` header, unless it already has a key
+func labelIdempotencyKey(ctx context.Context, wf *wfv1.Workflow) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get("idempotency-key")
+	if len(keys) == 0 || keys[0] == "" || wf.GetLabels()[common.LabelKeyIdempotencyKey] != "" {
+		return
+	}
+	if wf.Labels == nil {
+		wf.Labels = map[string]string{}
+	}
+	wf.Labels[common.LabelKeyIdempotencyKey] = keys[0]
+}
+
+// idempotentName returns the name of a workflow created with the idempotency key, so that only one of the requests made
+// at the same time with the same key can create it
+func idempotentName(generateName, key string) string {
+	hash := sha256.Sum256([]byte(key))
+	if len(generateName) > maxIdempotentNamePrefixLength {
+		generateName = generateName[:maxIdempotentNamePrefixLength]
+	}
+	return generateName + hex.EncodeToString(hash[:])[:10]
+}
+
+// createIdempotentWorkflow creates the workflow, unless a workflow was created with the same idempotency key within the
+// TTL, in which case that workflow is returned
+func (s *workflowServer) createIdempotentWorkflow(ctx context.Context, wfClient versioned.Interface, wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	existing, err := s.getIdempotentWorkflow(ctx, wfClient, wf)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+	workflows := wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace)
+	key := wf.GetLabels()[common.LabelKeyIdempotencyKey]
+	if key == "" {
+		return workflows.Create(ctx, wf, metav1.CreateOptions{})
+	}
+	named := wf.DeepCopy()
+	if named.Name == "" {
+		named.Name = idempotentName(wf.GenerateName, key)
+	}
+	created, err := workflows.Create(ctx, named, metav1.CreateOptions{})
+	if !apierr.IsAlreadyExists(err) {
+		return created, err
+	}
+	// another request with the same key created the workflow after we listed them, or the workflow with the name is
+	// older than the TTL or was not created with the key
+	existing, getErr := workflows.Get(ctx, named.Name, metav1.GetOptions{})
+	if getErr != nil {
+		return nil, getErr
+	}
+	if existing.GetLabels()[common.LabelKeyIdempotencyKey] == key && s.instanceIDService.Validate(existing) == nil && time.Since(existing.CreationTimestamp.Time) <= idempotencyKeyTTL {
+		if err := s.hydrator.Hydrate(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+	if wf.Name != "" {
+		return nil, err
+	}
+	return workflows.Create(ctx, wf, metav1.CreateOptions{})
+}
+
+// getIdempotentWorkflow returns the most recently created workflow with the same idempotency key as the workflow, if it
+// was created within the TTL, or nil if the workflow should be created
+func (s *workflowServer) getIdempotentWorkflow(ctx context.Context, wfClient versioned.Interface, wf *wfv1.Workflow) (*wfv1.Workflow, error) {
+	key := wf.GetLabels()[common.LabelKeyIdempotencyKey]
+	if key == "" {
+		return nil, nil
+	}
+	if errs := validation.IsValidLabelValue(key); len(errs) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency key %q is invalid: %s", key, strings.Join(errs, ", "))
+	}
+	opts := &metav1.ListOptions{LabelSelector: common.LabelKeyIdempotencyKey + "=" + key}
+	s.instanceIDService.With(opts)
+	wfList, err := wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).List(ctx, *opts)
+	if err != nil {
+		return nil, err
+	}
+	var existing *wfv1.Workflow
+	for i, item := range wfList.Items {
+		if time.Since(item.CreationTimestamp.Time) > idempotencyKeyTTL {
+			continue
+		}
+		if existing == nil || item.CreationTimestamp.After(existing.CreationTimestamp.Time) {
+			existing = &wfList.Items[i]
+		}
+	}
+	if existing == nil {
+		return nil, nil
+	}
+	if err := s.hydrator.Hydrate(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
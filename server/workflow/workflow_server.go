@@ -7,8 +7,11 @@ import (
 	"sort"
 
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,11 +23,15 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	argoutil "github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/fields"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/logs"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
@@ -37,13 +44,20 @@ type workflowServer struct {
 	instanceIDService     instanceid.Service
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
 	hydrator              hydrator.Interface
+	admission             admission.Interface
+	archivedLogs          logs.ArchivedLogs
 }
 
 const latestAlias = "@latest"
 
-// NewWorkflowServer returns a new workflowServer
-func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo) workflowpkg.WorkflowServiceServer {
-	return &workflowServer{instanceIDService, offloadNodeStatusRepo, hydrator.New(offloadNodeStatusRepo)}
+// NewWorkflowServer returns a new workflowServer. The logs of deleted pods are streamed from their archived logs
+// unless artifactRepositories is nil.
+func NewWorkflowServer(instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, hydrator hydrator.Interface, admission admission.Interface, artifactRepositories artifactrepositories.Interface) workflowpkg.WorkflowServiceServer {
+	s := &workflowServer{instanceIDService: instanceIDService, offloadNodeStatusRepo: offloadNodeStatusRepo, hydrator: hydrator, admission: admission}
+	if artifactRepositories != nil {
+		s.archivedLogs = &archivedLogs{s.hydrator, artifactRepositories, artifact.NewDriver}
+	}
+	return s
 }
 
 func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.WorkflowCreateRequest) (*wfv1.Workflow, error) {
@@ -59,11 +73,19 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 
 	s.instanceIDService.Label(req.Workflow)
 	creator.Label(ctx, req.Workflow)
+	annotateTraceParent(ctx, req.Workflow)
+	labelIdempotencyKey(ctx, req.Workflow)
 
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace))
-	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates()), req.Namespace)
+
+	wf, err := s.admission.Admit(ctx, req.Workflow, wftmplGetter, cwftmplGetter)
+	if err != nil {
+		return nil, err
+	}
+	req.Workflow = wf
 
-	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{})
+	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{})
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +98,7 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return util.CreateServerDryRun(ctx, req.Workflow, wfClient)
 	}
 
-	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, req.Workflow, metav1.CreateOptions{})
+	wf, err = s.createIdempotentWorkflow(ctx, wfClient, req.Workflow)
 	if err != nil {
 		if apierr.IsServerTimeout(err) && req.Workflow.GenerateName != "" && req.Workflow.Name != "" {
 			errWithHint := fmt.Errorf(`create request failed due to timeout, but it's possible that workflow "%s" already exists. Original error: %w`, req.Workflow.Name, err)
@@ -90,6 +112,18 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 	return wf, nil
 }
 
+// annotateTraceParent makes the workflow's span a child of the request's span, unless it already has a parent
+func annotateTraceParent(ctx context.Context, wf *wfv1.Workflow) {
+	traceParent := tracing.TraceParent(trace.SpanContextFromContext(ctx))
+	if traceParent == "" || wf.GetAnnotations()[common.AnnotationKeyTraceParent] != "" {
+		return
+	}
+	if wf.Annotations == nil {
+		wf.Annotations = map[string]string{}
+	}
+	wf.Annotations[common.AnnotationKeyTraceParent] = traceParent
+}
+
 func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.WorkflowGetRequest) (*wfv1.Workflow, error) {
 	wfGetOption := metav1.GetOptions{}
 	if req.GetOptions != nil {
@@ -106,7 +140,7 @@ func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.Workf
 	}
 	cleaner := fields.NewCleaner(req.Fields)
 	if !cleaner.WillExclude("status.nodes") {
-		if err := s.hydrator.Hydrate(wf); err != nil {
+		if err := s.hydrator.Hydrate(ctx, wf); err != nil {
 			return nil, err
 		}
 	}
@@ -186,6 +220,11 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 	}
 	defer watch.Stop()
 	cleaner := fields.NewCleaner(req.Fields).WithoutPrefix("result.object.")
+	// a field selector can only select one name, so we filter any others ourselves
+	names := make(map[string]bool, len(req.Names))
+	for _, name := range req.Names {
+		names[name] = true
+	}
 
 	clean := func(x *wfv1.Workflow) (*wfv1.Workflow, error) {
 		y := &wfv1.Workflow{}
@@ -223,9 +262,12 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 				// object is probably metav1.Status, `FromObject` can deal with anything
 				return apierr.FromObject(event.Object)
 			}
+			if len(names) > 0 && !names[wf.Name] {
+				continue
+			}
 			logCtx := log.WithFields(log.Fields{"workflow": wf.Name, "type": event.Type, "phase": wf.Status.Phase})
 			if !cleaner.WillExclude("status.nodes") {
-				if err := s.hydrator.Hydrate(wf); err != nil {
+				if err := s.hydrator.Hydrate(ctx, wf); err != nil {
 					return err
 				}
 			}
@@ -326,7 +368,7 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 		return nil, err
 	}
 
-	err = s.hydrator.Hydrate(wf)
+	err = s.hydrator.Hydrate(ctx, wf)
 	if err != nil {
 		return nil, err
 	}
@@ -344,7 +386,7 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 		}
 	}
 
-	err = s.hydrator.Dehydrate(wf)
+	err = s.hydrator.Dehydrate(ctx, wf)
 	if err != nil {
 		return nil, err
 	}
@@ -369,7 +411,15 @@ func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.
 		return nil, err
 	}
 
-	newWF, err := util.FormulateResubmitWorkflow(wf, req.Memoized, req.Parameters)
+	newWF, err := util.FormulateResubmitWorkflow(wf, req.Memoized, req.Parameters, util.MetadataCarryOver{Labels: req.CarryOverLabels, Annotations: req.CarryOverAnnotations})
+	if err != nil {
+		return nil, err
+	}
+
+	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace))
+	cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates()), req.Namespace)
+	newWF.Namespace = req.Namespace
+	newWF, err = s.admission.Admit(ctx, newWF, wftmplGetter, cwftmplGetter)
 	if err != nil {
 		return nil, err
 	}
@@ -393,10 +443,10 @@ func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return nil, err
 	}
 
-	err = util.ResumeWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector)
+	err = util.ResumeWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, getApprover(ctx))
 	if err != nil {
 		log.Warnf("Failed to resume %s: %+v", wf.Name, err)
-		return nil, err
+		return nil, approvalError(err)
 	}
 
 	wf, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, wf.Name, metav1.GetOptions{})
@@ -468,7 +518,17 @@ func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.Work
 	if err != nil {
 		return nil, err
 	}
-	err = util.StopWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, req.Message)
+	switch wfv1.ShutdownStrategy(req.Strategy) {
+	case wfv1.ShutdownStrategyNone, wfv1.ShutdownStrategyStop:
+		err = util.StopWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, req.Message)
+	case wfv1.ShutdownStrategyDrain:
+		if req.NodeFieldSelector != "" {
+			return nil, errors.Errorf(errors.CodeBadRequest, "nodes cannot be drained, only workflows")
+		}
+		err = util.DrainWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wf.Name)
+	default:
+		return nil, errors.Errorf(errors.CodeBadRequest, "unknown strategy '%s', must be %s or %s", req.Strategy, wfv1.ShutdownStrategyStop, wfv1.ShutdownStrategyDrain)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -480,6 +540,26 @@ func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.Work
 	return wf, nil
 }
 
+// getApprover returns the authenticated user of the request, who approves the suspend nodes they resume
+func getApprover(ctx context.Context) *util.Approver {
+	claims := auth.GetClaims(ctx)
+	if claims == nil {
+		return nil
+	}
+	return &util.Approver{
+		Approver: wfv1.Approver{Subject: claims.Subject, Email: claims.Email, PreferredUsername: claims.PreferredUsername},
+		Groups:   claims.Groups,
+	}
+}
+
+// approvalError returns the error as permission denied if the user was not allowed to approve a node
+func approvalError(err error) error {
+	if errors.IsCode(errors.CodeForbidden, err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return err
+}
+
 func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.WorkflowSetRequest) (*wfv1.Workflow, error) {
 	wfClient := auth.GetWfClient(ctx)
 	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
@@ -507,15 +587,25 @@ func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.Workf
 		}
 	}
 
+	outputArtifacts := make(map[string]wfv1.ArtifactLocation)
+	if req.OutputArtifacts != "" {
+		err = json.Unmarshal([]byte(req.OutputArtifacts), &outputArtifacts)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse output artifact set request: %s", err)
+		}
+	}
+
 	operation := util.SetOperationValues{
 		Phase:            phaseToSet,
 		Message:          req.Message,
 		OutputParameters: outputParams,
+		OutputArtifacts:  outputArtifacts,
+		Approver:         getApprover(ctx),
 	}
 
 	err = util.SetWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), s.hydrator, wf.Name, req.NodeFieldSelector, operation)
 	if err != nil {
-		return nil, err
+		return nil, approvalError(err)
 	}
 
 	wf, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, wf.Name, metav1.GetOptions{})
@@ -531,9 +621,10 @@ func (s *workflowServer) LintWorkflow(ctx context.Context, req *workflowpkg.Work
 	}
 	wfClient := auth.GetWfClient(ctx)
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace))
-	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates()), req.Namespace)
 	s.instanceIDService.Label(req.Workflow)
 	creator.Label(ctx, req.Workflow)
+	annotateTraceParent(ctx, req.Workflow)
 
 	err := validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, req.Workflow, validate.ValidateOpts{Lint: true})
 	if err != nil {
@@ -563,7 +654,7 @@ func (s *workflowServer) PodLogs(req *workflowpkg.WorkflowLogRequest, ws workflo
 		return err
 	}
 
-	return logs.WorkflowLogs(ctx, wfClient, kubeClient, req, ws)
+	return logs.WorkflowLogs(ctx, wfClient, kubeClient, s.archivedLogs, req, ws)
 }
 
 func (s *workflowServer) WorkflowLogs(req *workflowpkg.WorkflowLogRequest, ws workflowpkg.WorkflowService_WorkflowLogsServer) error {
@@ -627,17 +718,25 @@ func (s *workflowServer) SubmitWorkflow(ctx context.Context, req *workflowpkg.Wo
 
 	s.instanceIDService.Label(wf)
 	creator.Label(ctx, wf)
+	annotateTraceParent(ctx, wf)
+	labelIdempotencyKey(ctx, wf)
 	err := util.ApplySubmitOpts(wf, req.SubmitOptions)
 	if err != nil {
 		return nil, err
 	}
 
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().WorkflowTemplates(req.Namespace))
-	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WrapClusterWorkflowTemplateInterface(wfClient.ArgoprojV1alpha1().ClusterWorkflowTemplates()), req.Namespace)
+
+	wf.Namespace = req.Namespace
+	wf, err = s.admission.Admit(ctx, wf, wftmplGetter, cwftmplGetter)
+	if err != nil {
+		return nil, err
+	}
 
 	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, validate.ValidateOpts{Submit: true})
 	if err != nil {
 		return nil, err
 	}
-	return wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+	return s.createIdempotentWorkflow(ctx, wfClient, wf)
 }
@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,17 +20,20 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	v1alpha "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 	"github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 )
 
 const unlabelled = `{
@@ -584,7 +592,7 @@ func getWorkflowServer() (workflowpkg.WorkflowServiceServer, context.Context) {
 	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
 	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(true)
 	offloadNodeStatusRepo.On("List", mock.Anything).Return(map[sqldb.UUIDVersion]v1alpha1.Nodes{}, nil)
-	server := NewWorkflowServer(instanceid.NewService("my-instanceid"), offloadNodeStatusRepo)
+	server := NewWorkflowServer(instanceid.NewService("my-instanceid"), offloadNodeStatusRepo, hydrator.New(offloadNodeStatusRepo, nil), admission.Null, nil)
 	kubeClientSet := fake.NewSimpleClientset()
 	wfClientset := v1alpha.NewSimpleClientset(&unlabelledObj, &wfObj1, &wfObj2, &wfObj3, &wfObj4, &wfObj5, &failedWfObj, &wftmpl, &cronwfObj, &cwfTmpl)
 	wfClientset.PrependReactor("create", "workflows", generateNameReactor)
@@ -622,6 +630,63 @@ func TestCreateWorkflow(t *testing.T) {
 	}
 }
 
+func TestCreateWorkflowIdempotencyKey(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	wfClient := auth.GetWfClient(ctx)
+	_, err := wfClient.ArgoprojV1alpha1().Workflows("default").Create(ctx, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{
+		Name:              "my-wf",
+		CreationTimestamp: metav1.Now(),
+		Labels:            map[string]string{common.LabelKeyIdempotencyKey: "my-key", common.LabelKeyControllerInstanceID: "my-instanceid"},
+	}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	create := func(ctx context.Context) *v1alpha1.Workflow {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		return wf
+	}
+	t.Run("SameKey", func(t *testing.T) {
+		wf := create(metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "my-key")))
+		assert.Equal(t, "my-wf", wf.Name)
+	})
+	t.Run("OtherKey", func(t *testing.T) {
+		wf := create(metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "my-other-key")))
+		assert.Equal(t, idempotentName("hello-world-", "my-other-key"), wf.Name)
+		assert.Equal(t, "my-other-key", wf.Labels[common.LabelKeyIdempotencyKey])
+	})
+	t.Run("CreatedConcurrently", func(t *testing.T) {
+		created := create(metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "my-concurrent-key")))
+		// the fake client does not set the creation timestamp
+		created.CreationTimestamp = metav1.Now()
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("default").Update(ctx, created, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		// the other request lists the workflows before this one is created
+		wfClient.(*v1alpha.Clientset).PrependReactor("list", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+			return true, &v1alpha1.WorkflowList{}, nil
+		})
+		defer func() { wfClient.(*v1alpha.Clientset).ReactionChain = wfClient.(*v1alpha.Clientset).ReactionChain[1:] }()
+		wf := create(metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "my-concurrent-key")))
+		assert.Equal(t, created.Name, wf.Name)
+	})
+	t.Run("Expired", func(t *testing.T) {
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("default").Create(ctx, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Name:              idempotentName("hello-world-", "my-expired-key"),
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * idempotencyKeyTTL)),
+			Labels:            map[string]string{common.LabelKeyIdempotencyKey: "my-expired-key", common.LabelKeyControllerInstanceID: "my-instanceid"},
+		}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		wf := create(metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "my-expired-key")))
+		assert.NotEqual(t, idempotentName("hello-world-", "my-expired-key"), wf.Name)
+	})
+	t.Run("InvalidKey", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		_, err := server.CreateWorkflow(metadata.NewIncomingContext(ctx, metadata.Pairs("idempotency-key", "my key")), &req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
 type testWatchWorkflowServer struct {
 	testServerStream
 }
@@ -644,6 +709,41 @@ func TestWatchWorkflows(t *testing.T) {
 	cancel()
 }
 
+type namesWatchWorkflowServer struct {
+	testServerStream
+	headers chan struct{}
+	events  chan *workflowpkg.WorkflowWatchEvent
+}
+
+func (t namesWatchWorkflowServer) SendHeader(metadata.MD) error {
+	close(t.headers)
+	return nil
+}
+
+func (t namesWatchWorkflowServer) Send(e *workflowpkg.WorkflowWatchEvent) error {
+	t.events <- e
+	return nil
+}
+
+func TestWatchWorkflowsNames(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ws := namesWatchWorkflowServer{testServerStream{ctx}, make(chan struct{}), make(chan *workflowpkg.WorkflowWatchEvent, 2)}
+	go func() {
+		err := server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{Namespace: "workflows", Names: []string{"my-wf", "my-other-wf"}}, ws)
+		assert.NoError(t, err)
+	}()
+	<-ws.headers
+	wfClient := auth.GetWfClient(ctx)
+	for _, name := range []string{"not-my-wf", "my-wf", "my-other-wf"} {
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("workflows").Create(ctx, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, "my-wf", (<-ws.events).Object.Name)
+	assert.Equal(t, "my-other-wf", (<-ws.events).Object.Name)
+}
+
 func TestWatchLatestWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer()
 	wf := &v1alpha1.Workflow{
@@ -823,6 +923,24 @@ func TestStopWorkflow(t *testing.T) {
 	}
 }
 
+func TestDrainWorkflow(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	t.Run("Drain", func(t *testing.T) {
+		wf, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Strategy: "Drain"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, v1alpha1.ShutdownStrategyDrain, wf.Spec.Shutdown)
+		}
+	})
+	t.Run("NodeFieldSelector", func(t *testing.T) {
+		_, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Strategy: "Drain", NodeFieldSelector: "displayName=a"})
+		assert.EqualError(t, err, "nodes cannot be drained, only workflows")
+	})
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		_, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Strategy: "Terminate"})
+		assert.EqualError(t, err, "unknown strategy 'Terminate', must be Stop or Drain")
+	})
+}
+
 func TestResubmitWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer()
 	t.Run("Labelled", func(t *testing.T) {
@@ -930,3 +1048,51 @@ func TestSubmitWorkflowFromResource(t *testing.T) {
 		}
 	})
 }
+
+func TestSubmitWorkflowAdmission(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	a, err := admission.New(&config.AdmissionConfig{Policies: []config.AdmissionPolicy{{Name: "team", Expression: `"team" in workflow.metadata.labels`}}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	server.(*workflowServer).admission = a
+	_, err = server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+		Namespace:     "workflows",
+		ResourceKind:  "workflowtemplate",
+		ResourceName:  "workflow-template-whalesay-template",
+		SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+		Namespace:     "workflows",
+		ResourceKind:  "workflowtemplate",
+		ResourceName:  "workflow-template-whalesay-template",
+		SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}, Labels: "team=my-team"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "my-team", wf.Labels["team"])
+	}
+}
+
+func TestSubmitWorkflowSuspended(t *testing.T) {
+	server, ctx := getWorkflowServer()
+	// marshal the request as the gRPC client does, so that fields missing from the protobuf are lost
+	data, err := (&workflowpkg.WorkflowSubmitRequest{
+		Namespace:     "workflows",
+		ResourceKind:  "workflowtemplate",
+		ResourceName:  "workflow-template-whalesay-template",
+		SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}, Suspend: true},
+	}).Marshal()
+	if !assert.NoError(t, err) {
+		return
+	}
+	req := &workflowpkg.WorkflowSubmitRequest{}
+	if !assert.NoError(t, req.Unmarshal(data)) {
+		return
+	}
+	assert.True(t, req.SubmitOptions.Suspend)
+	wf, err := server.SubmitWorkflow(ctx, req)
+	if assert.NoError(t, err) && assert.NotNil(t, wf.Spec.Suspend) {
+		assert.True(t, *wf.Spec.Suspend)
+	}
+}
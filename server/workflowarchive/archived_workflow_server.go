@@ -21,6 +21,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/fields"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
 
@@ -57,6 +58,7 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 	minStartedAt := time.Time{}
 	maxStartedAt := time.Time{}
 	showRemainingItemCount := false
+	var phases []wfv1.WorkflowPhase
 	for _, selector := range strings.Split(options.FieldSelector, ",") {
 		if len(selector) == 0 {
 			continue
@@ -75,6 +77,9 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 			if err != nil {
 				return nil, err
 			}
+		} else if strings.HasPrefix(selector, "status.phase=") {
+			// may be repeated to match any of the phases, e.g. "status.phase=Failed,status.phase=Error"
+			phases = append(phases, wfv1.WorkflowPhase(strings.TrimPrefix(selector, "status.phase=")))
 		} else if strings.HasPrefix(selector, "ext.showRemainingItemCount") {
 			showRemainingItemCount, err = strconv.ParseBool(strings.TrimPrefix(selector, "ext.showRemainingItemCount="))
 			if err != nil {
@@ -88,6 +93,9 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := sqldb.SortByFields[strings.TrimPrefix(req.SortBy, "-")]; req.SortBy != "" && !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "sortBy must be one of startedAt, finishedAt, duration, progress or name, optionally prefixed with \"-\", not %q", req.SortBy)
+	}
 
 	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, namespace, "")
 	if err != nil {
@@ -108,7 +116,7 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 		limitWithMore = limit + 1
 	}
 
-	items, err := w.wfArchive.ListWorkflows(namespace, name, namePrefix, minStartedAt, maxStartedAt, requirements, limitWithMore, offset)
+	items, err := w.wfArchive.ListWorkflows(namespace, name, namePrefix, minStartedAt, maxStartedAt, requirements, phases, req.Search, req.SortBy, limitWithMore, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -116,7 +124,7 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 	meta := metav1.ListMeta{}
 
 	if showRemainingItemCount && !loadAll {
-		total, err := w.wfArchive.CountWorkflows(namespace, name, namePrefix, minStartedAt, maxStartedAt, requirements)
+		total, err := w.wfArchive.CountWorkflows(namespace, name, namePrefix, minStartedAt, maxStartedAt, requirements, phases, req.Search)
 		if err != nil {
 			return nil, err
 		}
@@ -135,8 +143,17 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 		meta.Continue = fmt.Sprintf("%v", offset+limit)
 	}
 
-	sort.Sort(items)
-	return &wfv1.WorkflowList{ListMeta: meta, Items: items}, nil
+	if req.SortBy == "" {
+		sort.Sort(items)
+	}
+	res := &wfv1.WorkflowList{ListMeta: meta, Items: items}
+	newRes := &wfv1.WorkflowList{}
+	if ok, err := fields.NewCleaner(req.Fields).Clean(res, &newRes); err != nil {
+		return nil, fmt.Errorf("unable to CleanFields in request: %w", err)
+	} else if ok {
+		return newRes, nil
+	}
+	return res, nil
 }
 
 func (w *archivedWorkflowServer) GetArchivedWorkflow(ctx context.Context, req *workflowarchivepkg.GetArchivedWorkflowRequest) (*wfv1.Workflow, error) {
@@ -154,7 +171,13 @@ func (w *archivedWorkflowServer) GetArchivedWorkflow(ctx context.Context, req *w
 	if !allowed {
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
-	return wf, err
+	newWf := &wfv1.Workflow{}
+	if ok, err := fields.NewCleaner(req.Fields).Clean(wf, &newWf); err != nil {
+		return nil, fmt.Errorf("unable to CleanFields in request: %w", err)
+	} else if ok {
+		return newWf, nil
+	}
+	return wf, nil
 }
 
 func (w *archivedWorkflowServer) DeleteArchivedWorkflow(ctx context.Context, req *workflowarchivepkg.DeleteArchivedWorkflowRequest) (*workflowarchivepkg.ArchivedWorkflowDeletedResponse, error) {
@@ -221,7 +244,7 @@ func (w *archivedWorkflowServer) ResubmitArchivedWorkflow(ctx context.Context, r
 		return nil, err
 	}
 
-	newWF, err := util.FormulateResubmitWorkflow(wf, req.Memoized, nil)
+	newWF, err := util.FormulateResubmitWorkflow(wf, req.Memoized, req.Parameters, util.MetadataCarryOver{Labels: req.CarryOverLabels, Annotations: req.CarryOverAnnotations})
 	if err != nil {
 		return nil, err
 	}
@@ -47,17 +47,19 @@ func Test_archivedWorkflowServer(t *testing.T) {
 		}, nil
 	})
 	// two pages of results for limit 1
-	repo.On("ListWorkflows", "", "", "", time.Time{}, time.Time{}, labels.Requirements(nil), 2, 0).Return(wfv1.Workflows{{}, {}}, nil)
-	repo.On("ListWorkflows", "", "", "", time.Time{}, time.Time{}, labels.Requirements(nil), 2, 1).Return(wfv1.Workflows{{}}, nil)
+	repo.On("ListWorkflows", "", "", "", time.Time{}, time.Time{}, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "", "", 2, 0).Return(wfv1.Workflows{{}, {}}, nil)
+	repo.On("ListWorkflows", "", "", "", time.Time{}, time.Time{}, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "", "", 2, 1).Return(wfv1.Workflows{{}}, nil)
 	minStartAt, _ := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
 	maxStartAt, _ := time.Parse(time.RFC3339, "2020-01-02T00:00:00Z")
 	createdTime := metav1.Time{Time: time.Now().UTC()}
 	finishedTime := metav1.Time{Time: createdTime.Add(time.Second * 2)}
-	repo.On("ListWorkflows", "", "", "", minStartAt, maxStartAt, labels.Requirements(nil), 2, 0).Return(wfv1.Workflows{{}}, nil)
-	repo.On("ListWorkflows", "", "my-name", "", minStartAt, maxStartAt, labels.Requirements(nil), 2, 0).Return(wfv1.Workflows{{}}, nil)
-	repo.On("ListWorkflows", "", "", "my-", minStartAt, maxStartAt, labels.Requirements(nil), 2, 0).Return(wfv1.Workflows{{}}, nil)
-	repo.On("ListWorkflows", "", "my-name", "my-", minStartAt, maxStartAt, labels.Requirements(nil), 2, 0).Return(wfv1.Workflows{{}}, nil)
-	repo.On("CountWorkflows", "", "my-name", "my-", minStartAt, maxStartAt, labels.Requirements(nil)).Return(int64(5), nil)
+	repo.On("ListWorkflows", "", "", "", minStartAt, maxStartAt, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "", "", 2, 0).Return(wfv1.Workflows{{}}, nil)
+	repo.On("ListWorkflows", "", "my-name", "", minStartAt, maxStartAt, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "", "", 2, 0).Return(wfv1.Workflows{{}}, nil)
+	repo.On("ListWorkflows", "", "", "my-", minStartAt, maxStartAt, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "", "", 2, 0).Return(wfv1.Workflows{{}}, nil)
+	repo.On("ListWorkflows", "", "my-name", "my-", minStartAt, maxStartAt, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "", "", 2, 0).Return(wfv1.Workflows{{}}, nil)
+	repo.On("CountWorkflows", "", "my-name", "my-", minStartAt, maxStartAt, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "").Return(int64(5), nil)
+	repo.On("ListWorkflows", "", "", "", time.Time{}, time.Time{}, labels.Requirements(nil), []wfv1.WorkflowPhase{wfv1.WorkflowFailed, wfv1.WorkflowError}, "", "-duration", 2, 0).Return(wfv1.Workflows{{}}, nil)
+	repo.On("ListWorkflows", "", "", "", time.Time{}, time.Time{}, labels.Requirements(nil), []wfv1.WorkflowPhase(nil), "ImagePullBackOff", "", 2, 0).Return(wfv1.Workflows{{}}, nil)
 	repo.On("GetWorkflow", "").Return(nil, nil)
 	repo.On("GetWorkflow", "my-uid").Return(&wfv1.Workflow{
 		ObjectMeta: metav1.ObjectMeta{Name: "my-name"},
@@ -161,6 +163,22 @@ func Test_archivedWorkflowServer(t *testing.T) {
 			assert.Equal(t, *resp.ListMeta.RemainingItemCount, int64(4))
 			assert.Empty(t, resp.Continue)
 		}
+		resp, err = w.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{ListOptions: &metav1.ListOptions{FieldSelector: "status.phase=Failed,status.phase=Error", Limit: 1}, SortBy: "-duration"})
+		if assert.NoError(t, err) {
+			assert.Len(t, resp.Items, 1)
+			assert.Empty(t, resp.Continue)
+		}
+		resp, err = w.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{ListOptions: &metav1.ListOptions{Limit: 1}, Search: "ImagePullBackOff"})
+		if assert.NoError(t, err) {
+			assert.Len(t, resp.Items, 1)
+		}
+		resp, err = w.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{ListOptions: &metav1.ListOptions{Limit: 1}, Fields: "metadata.continue"})
+		if assert.NoError(t, err) {
+			assert.Empty(t, resp.Items)
+			assert.Equal(t, "1", resp.Continue)
+		}
+		_, err = w.ListArchivedWorkflows(ctx, &workflowarchivepkg.ListArchivedWorkflowsRequest{ListOptions: &metav1.ListOptions{Limit: 1}, SortBy: "-uid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 	t.Run("GetArchivedWorkflow", func(t *testing.T) {
 		allowed = false
@@ -172,6 +190,11 @@ func Test_archivedWorkflowServer(t *testing.T) {
 		wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "my-uid"})
 		assert.NoError(t, err)
 		assert.NotNil(t, wf)
+		wf, err = w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "my-uid", Fields: "metadata.name"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-name", wf.Name)
+			assert.Empty(t, wf.Spec.Entrypoint)
+		}
 	})
 	t.Run("DeleteArchivedWorkflow", func(t *testing.T) {
 		allowed = false
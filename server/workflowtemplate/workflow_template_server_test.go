@@ -16,6 +16,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templaterevision"
 )
 
 const unlabelled = `{
@@ -78,6 +79,7 @@ const wftStr2 = `{
   "metadata": {
     "name": "workflow-template-whalesay-template2",
     "namespace": "default",
+    "uid": "my-uid",
 	"labels": {
 		"workflows.argoproj.io/controller-instanceid": "my-instanceid"
   	}
@@ -236,6 +238,32 @@ func TestWorkflowTemplateServer_ListWorkflowTemplates(t *testing.T) {
 	}
 }
 
+func TestWorkflowTemplateServer_Revisions(t *testing.T) {
+	server, ctx := getWorkflowTemplateServer()
+	var revision v1alpha1.WorkflowTemplate
+	v1alpha1.MustUnmarshal(wftStr2, &revision)
+	revision.Generation = 1
+	revision.Spec.Entrypoint = "old"
+	assert.NoError(t, templaterevision.SaveWorkflowTemplate(ctx, auth.GetKubeClient(ctx), &revision, 10))
+	t.Run("Get", func(t *testing.T) {
+		wftRsp, err := server.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: "workflow-template-whalesay-template2", Namespace: "default", Revision: 1})
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), wftRsp.Generation)
+			assert.Equal(t, "old", wftRsp.Spec.Entrypoint)
+		}
+		_, err = server.GetWorkflowTemplate(ctx, &workflowtemplatepkg.WorkflowTemplateGetRequest{Name: "workflow-template-whalesay-template2", Namespace: "default", Revision: 2})
+		assert.Error(t, err)
+	})
+	t.Run("List", func(t *testing.T) {
+		wftRsp, err := server.ListWorkflowTemplates(ctx, &workflowtemplatepkg.WorkflowTemplateListRequest{Namespace: "default", RevisionsOf: "workflow-template-whalesay-template2"})
+		if assert.NoError(t, err) && assert.Len(t, wftRsp.Items, 1) {
+			assert.Equal(t, "old", wftRsp.Items[0].Spec.Entrypoint)
+		}
+		_, err = server.ListWorkflowTemplates(ctx, &workflowtemplatepkg.WorkflowTemplateListRequest{Namespace: "default", RevisionsOf: "unlabelled"})
+		assert.Error(t, err)
+	})
+}
+
 func TestWorkflowTemplateServer_DeleteWorkflowTemplate(t *testing.T) {
 	server, ctx := getWorkflowTemplateServer()
 	t.Run("Labelled", func(t *testing.T) {
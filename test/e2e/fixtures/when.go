@@ -358,7 +358,7 @@ func (w *When) WaitForWorkflowDeletion() *When {
 
 func (w *When) hydrateWorkflow(wf *wfv1.Workflow) {
 	w.t.Helper()
-	err := w.hydrator.Hydrate(wf)
+	err := w.hydrator.Hydrate(context.Background(), wf)
 	if err != nil {
 		w.t.Fatal(err)
 	}
@@ -23,6 +23,7 @@ interface State {
     namespace: string;
     name: string;
     namePrefix: string;
+    search: string;
     selectedPhases: string[];
     selectedLabels: string[];
     minStartedAt?: Date;
@@ -52,6 +53,7 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
             namespace: Utils.getNamespace(this.props.match.params.namespace) || '',
             name: this.queryParams('name').toString() || '',
             namePrefix: this.queryParams('namePrefix').toString() || '',
+            search: this.queryParams('search').toString() || '',
             selectedPhases: phaseQueryParam.length > 0 ? phaseQueryParam : savedOptions.selectedPhases,
             selectedLabels: labelQueryParam.length > 0 ? labelQueryParam : savedOptions.selectedLabels,
             minStartedAt: this.parseTime(this.queryParam('minStartedAt')) || this.lastMonth(),
@@ -65,6 +67,7 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
             this.state.namespace,
             this.state.name,
             this.state.namePrefix,
+            this.state.search,
             this.state.selectedPhases,
             this.state.selectedLabels,
             this.state.minStartedAt,
@@ -100,13 +103,14 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
                                 namespace={this.state.namespace}
                                 name={this.state.name}
                                 namePrefix={this.state.namePrefix}
+                                search={this.state.search}
                                 phaseItems={Object.values([models.NODE_PHASE.SUCCEEDED, models.NODE_PHASE.FAILED, models.NODE_PHASE.ERROR])}
                                 selectedPhases={this.state.selectedPhases}
                                 selectedLabels={this.state.selectedLabels}
                                 minStartedAt={this.state.minStartedAt}
                                 maxStartedAt={this.state.maxStartedAt}
-                                onChange={(namespace, name, namePrefix, selectedPhases, selectedLabels, minStartedAt, maxStartedAt) =>
-                                    this.changeFilters(namespace, name, namePrefix, selectedPhases, selectedLabels, minStartedAt, maxStartedAt, {
+                                onChange={(namespace, name, namePrefix, search, selectedPhases, selectedLabels, minStartedAt, maxStartedAt) =>
+                                    this.changeFilters(namespace, name, namePrefix, search, selectedPhases, selectedLabels, minStartedAt, maxStartedAt, {
                                         limit: this.state.pagination.limit
                                     })
                                 }
@@ -143,13 +147,14 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
         namespace: string,
         name: string,
         namePrefix: string,
+        search: string,
         selectedPhases: string[],
         selectedLabels: string[],
         minStartedAt: Date,
         maxStartedAt: Date,
         pagination: Pagination
     ) {
-        this.fetchArchivedWorkflows(namespace, name, namePrefix, selectedPhases, selectedLabels, minStartedAt, maxStartedAt, pagination);
+        this.fetchArchivedWorkflows(namespace, name, namePrefix, search, selectedPhases, selectedLabels, minStartedAt, maxStartedAt, pagination);
     }
 
     private get filterParams() {
@@ -170,6 +175,9 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
         if (this.state.namePrefix) {
             params.append('namePrefix', this.state.namePrefix);
         }
+        if (this.state.search) {
+            params.append('search', this.state.search);
+        }
         params.append('minStartedAt', this.state.minStartedAt.toISOString());
         params.append('maxStartedAt', this.state.maxStartedAt.toISOString());
         if (this.state.pagination.offset) {
@@ -192,6 +200,7 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
         namespace: string,
         name: string,
         namePrefix: string,
+        search: string,
         selectedPhases: string[],
         selectedLabels: string[],
         minStartedAt: Date,
@@ -199,7 +208,7 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
         pagination: Pagination
     ): void {
         services.archivedWorkflows
-            .list(namespace, name, namePrefix, selectedPhases, selectedLabels, minStartedAt, maxStartedAt, pagination)
+            .list(namespace, name, namePrefix, search, selectedPhases, selectedLabels, minStartedAt, maxStartedAt, pagination)
             .then(list => {
                 this.setState(
                     {
@@ -207,6 +216,7 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
                         namespace,
                         name,
                         namePrefix,
+                        search,
                         workflows: list.items || [],
                         selectedPhases,
                         selectedLabels,
@@ -275,6 +285,7 @@ export class ArchivedWorkflowList extends BasePage<RouteComponentProps<any>, Sta
                             this.state.namespace,
                             this.state.name,
                             this.state.namePrefix,
+                            this.state.search,
                             this.state.selectedPhases,
                             this.state.selectedLabels,
                             this.state.minStartedAt,
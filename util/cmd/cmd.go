@@ -12,6 +12,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
 )
 
 // NewVersionCmd returns a new `version` command to be used as a sub-command to root
@@ -107,3 +108,12 @@ func SetLogFormatter(logFormat string) {
 		log.Fatalf("Unknown log format '%s'", logFormat)
 	}
 }
+
+// SetLogLevels sets the levels of logrus's logs, e.g. `info` or `info,operator=debug,artifact-gc=warn`
+func SetLogLevels(logLevels string) {
+	levels, err := logging.ParseLevels(logLevels)
+	if err != nil {
+		log.Fatalf("Unknown log levels '%s': %v", logLevels, err)
+	}
+	logging.SetLevels(levels)
+}
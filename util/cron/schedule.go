@@ -0,0 +1,162 @@
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// ParseSchedules parses the schedules of a CronWorkflow, such that the times they return are delayed by its jitter and
+// follow its daylight saving policy
+func ParseSchedules(cronWf *wfv1.CronWorkflow) ([]cron.Schedule, error) {
+	jitter, err := Jitter(cronWf)
+	if err != nil {
+		return nil, err
+	}
+	var schedules []cron.Schedule
+	for _, scheduleString := range cronWf.Spec.GetSchedulesWithTimezone() {
+		schedule, err := cron.ParseStandard(scheduleString)
+		if err != nil {
+			return nil, fmt.Errorf("unable to form timezone schedule '%s': %s", scheduleString, err)
+		}
+		// only schedules with fields can have local times that are skipped or repeated, not e.g. "@every 1h"
+		if spec, ok := schedule.(*cron.SpecSchedule); ok && cronWf.Spec.DSTPolicy != "" {
+			schedule = &dstSchedule{SpecSchedule: spec, policy: cronWf.Spec.DSTPolicy}
+		}
+		if jitter > 0 {
+			schedule = &jitterSchedule{Schedule: schedule, delay: jitter}
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// Jitter returns how long every run of the CronWorkflow is delayed by. This is derived from its namespace and name, so
+// that CronWorkflows with the same schedule are spread out, but each one still runs at regular times.
+func Jitter(cronWf *wfv1.CronWorkflow) (time.Duration, error) {
+	if cronWf.Spec.ScheduleJitter == "" {
+		return 0, nil
+	}
+	maxJitter, err := time.ParseDuration(cronWf.Spec.ScheduleJitter)
+	if err != nil {
+		return 0, fmt.Errorf("malformed schedule jitter '%s': %s", cronWf.Spec.ScheduleJitter, err)
+	}
+	seconds := uint64(maxJitter / time.Second)
+	if seconds == 0 {
+		return 0, nil
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cronWf.Namespace + "/" + cronWf.Name))
+	return time.Duration(uint64(h.Sum32())%seconds) * time.Second, nil
+}
+
+type jitterSchedule struct {
+	cron.Schedule
+	delay time.Duration
+}
+
+func (s *jitterSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t.Add(-s.delay))
+	if next.IsZero() {
+		return next
+	}
+	return next.Add(s.delay)
+}
+
+type dstSchedule struct {
+	*cron.SpecSchedule
+	policy wfv1.DSTPolicy
+}
+
+func (s *dstSchedule) Next(t time.Time) time.Time {
+	loc := s.Location
+	// like cron.SpecSchedule, schedules without a timezone are local to the time provided
+	if loc == time.Local {
+		loc = t.Location()
+	}
+	next := s.SpecSchedule.Next(t)
+	for !next.IsZero() {
+		occurrence := occurrence(next, loc)
+		if occurrence == once || s.policy == wfv1.DSTPolicyRunTwice || (s.policy == wfv1.DSTPolicyRunOnce && occurrence == first) {
+			break
+		}
+		next = s.SpecSchedule.Next(next)
+	}
+	if s.policy != wfv1.DSTPolicySkip {
+		if change := s.skippedChange(t, next, loc); !change.IsZero() {
+			return change.In(t.Location())
+		}
+	}
+	return next
+}
+
+// skippedChange returns the first time after t, and no later than next, that the clocks go forward past a local time
+// of the schedule, or zero if there is no such time
+func (s *dstSchedule) skippedChange(t, next time.Time, loc *time.Location) time.Time {
+	end := next
+	if end.IsZero() {
+		// cron.SpecSchedule gives up after five years too
+		end = t.AddDate(5, 0, 0)
+	}
+	for from := t.Unix(); from < end.Unix(); from += 24 * 60 * 60 {
+		to := from + 24*60*60
+		if to > end.Unix() {
+			to = end.Unix()
+		}
+		_, before := time.Unix(from, 0).In(loc).Zone()
+		_, after := time.Unix(to, 0).In(loc).Zone()
+		if after <= before {
+			continue
+		}
+		// clock changes are on whole seconds, so find the first second of the new offset
+		lo, hi := from, to
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			if _, offset := time.Unix(mid, 0).In(loc).Zone(); offset == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		change := time.Unix(hi, 0)
+		// the skipped local times are those from the change, for the change in offset, in the old offset
+		spec := *s.SpecSchedule
+		spec.Location = time.FixedZone("", before)
+		if skipped := spec.Next(change.Add(-time.Second)); !skipped.IsZero() && skipped.Before(change.Add(time.Duration(after-before)*time.Second)) {
+			return change
+		}
+	}
+	return time.Time{}
+}
+
+const (
+	once = iota
+	first
+	second
+)
+
+// occurrence returns whether the local time of t happens once, or if it is the first or second of two times when the
+// clocks go back
+func occurrence(t time.Time, loc *time.Location) int {
+	t = t.In(loc)
+	_, offset := t.Zone()
+	for _, d := range []time.Duration{-12 * time.Hour, 12 * time.Hour} {
+		_, other := t.Add(d).Zone()
+		shift := time.Duration(offset-other) * time.Second
+		if shift == 0 {
+			continue
+		}
+		const layout = "2006-01-02T15:04:05"
+		if t.Add(shift).In(loc).Format(layout) == t.Format(layout) {
+			if shift > 0 {
+				return first
+			}
+			return second
+		}
+	}
+	return once
+}
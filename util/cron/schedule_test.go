@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestJitter(t *testing.T) {
+	cronWf := &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-cwf"}}
+	jitter, err := Jitter(cronWf)
+	if assert.NoError(t, err) {
+		assert.Zero(t, jitter)
+	}
+	cronWf.Spec.ScheduleJitter = "5m"
+	jitter, err = Jitter(cronWf)
+	if assert.NoError(t, err) {
+		assert.True(t, jitter >= 0 && jitter < 5*time.Minute)
+		assert.Zero(t, jitter%time.Second)
+		again, _ := Jitter(cronWf)
+		assert.Equal(t, jitter, again, "the jitter is the same every time")
+	}
+	cronWf.Spec.ScheduleJitter = "foo"
+	_, err = Jitter(cronWf)
+	assert.EqualError(t, err, `malformed schedule jitter 'foo': time: invalid duration "foo"`)
+}
+
+func TestParseSchedulesJitter(t *testing.T) {
+	cronWf := &wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-cwf"},
+		Spec:       wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Timezone: "UTC", ScheduleJitter: "30m"},
+	}
+	jitter, err := Jitter(cronWf)
+	assert.NoError(t, err)
+	schedules, err := ParseSchedules(cronWf)
+	if assert.NoError(t, err) && assert.Len(t, schedules, 1) {
+		start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		first := schedules[0].Next(start)
+		assert.Equal(t, start.Add(jitter), first)
+		assert.Equal(t, start.Add(time.Hour+jitter), schedules[0].Next(first))
+	}
+}
+
+func TestParseSchedulesDSTPolicy(t *testing.T) {
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if !assert.NoError(t, err) {
+		return
+	}
+	next := func(policy wfv1.DSTPolicy, schedule string, start time.Time, n int) []string {
+		schedules, err := ParseSchedules(&wfv1.CronWorkflow{Spec: wfv1.CronWorkflowSpec{Schedule: schedule, Timezone: "America/Los_Angeles", DSTPolicy: policy}})
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		var times []string
+		for t := start; len(times) < n; {
+			t = schedules[0].Next(t)
+			times = append(times, t.In(loc).Format("2006-01-02 15:04 MST"))
+		}
+		return times
+	}
+	// the clocks go back at 2020-11-01 02:00 PDT, so 01:30 happens twice
+	back := time.Date(2020, 10, 31, 12, 0, 0, 0, loc)
+	t.Run("Back", func(t *testing.T) {
+		assert.Equal(t, []string{"2020-11-01 01:30 PDT", "2020-11-01 01:30 PST", "2020-11-02 01:30 PST"}, next("", "30 1 * * *", back, 3))
+		assert.Equal(t, []string{"2020-11-01 01:30 PDT", "2020-11-01 01:30 PST", "2020-11-02 01:30 PST"}, next(wfv1.DSTPolicyRunTwice, "30 1 * * *", back, 3))
+		assert.Equal(t, []string{"2020-11-01 01:30 PDT", "2020-11-02 01:30 PST"}, next(wfv1.DSTPolicyRunOnce, "30 1 * * *", back, 2))
+		assert.Equal(t, []string{"2020-11-02 01:30 PST"}, next(wfv1.DSTPolicySkip, "30 1 * * *", back, 1))
+		assert.Equal(t, []string{"2020-11-01 03:30 PST"}, next(wfv1.DSTPolicySkip, "30 1,3 * * *", back, 1))
+	})
+	// the clocks go forward at 2020-03-08 02:00 PST, so 02:30 does not happen
+	forward := time.Date(2020, 3, 7, 12, 0, 0, 0, loc)
+	t.Run("Forward", func(t *testing.T) {
+		assert.Equal(t, []string{"2020-03-09 02:30 PDT"}, next("", "30 2 * * *", forward, 1))
+		assert.Equal(t, []string{"2020-03-09 02:30 PDT"}, next(wfv1.DSTPolicySkip, "30 2 * * *", forward, 1))
+		assert.Equal(t, []string{"2020-03-08 03:00 PDT", "2020-03-09 02:30 PDT"}, next(wfv1.DSTPolicyRunOnce, "30 2 * * *", forward, 2))
+		assert.Equal(t, []string{"2020-03-08 03:00 PDT", "2020-03-09 02:30 PDT"}, next(wfv1.DSTPolicyRunTwice, "30 2 * * *", forward, 2))
+		assert.Equal(t, []string{"2020-03-08 01:30 PST", "2020-03-09 01:30 PDT"}, next(wfv1.DSTPolicyRunOnce, "30 1 * * *", forward, 2))
+	})
+	t.Run("Every", func(t *testing.T) {
+		assert.Equal(t, []string{"2020-11-01 00:00 PDT", "2020-11-01 01:00 PDT", "2020-11-01 01:00 PST", "2020-11-01 02:00 PST"}, next(wfv1.DSTPolicySkip, "@every 1h", time.Date(2020, 10, 31, 23, 0, 0, 0, loc), 4))
+	})
+}
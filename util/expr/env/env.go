@@ -17,7 +17,16 @@ func init() {
 }
 
 func GetFuncMap(m map[string]interface{}) map[string]interface{} {
-	env := expand.Expand(m)
+	env := addBuiltins(expand.Expand(m))
+	functionsMutex.RLock()
+	defer functionsMutex.RUnlock()
+	for k, v := range functions {
+		env[k] = v
+	}
+	return env
+}
+
+func addBuiltins(env map[string]interface{}) map[string]interface{} {
 	for k, v := range exprpkg.GetExprEnvFunctionMap() {
 		env[k] = v
 	}
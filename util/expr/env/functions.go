@@ -0,0 +1,82 @@
+package env
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
+)
+
+// Function is a function that operators define with an expression of its parameters
+type Function struct {
+	// Params are the names of the parameters of the function, which are variables of the expression
+	Params []string `json:"params,omitempty"`
+	// Expression is the expression that computes the result of the function
+	Expression string `json:"expression"`
+}
+
+// Func is the type of the functions, both expr and govaluate can call them
+type Func = func(args ...interface{}) (interface{}, error)
+
+var (
+	functionsMutex sync.RWMutex
+	functions      = map[string]Func{}
+)
+
+// SetFunctions replaces the functions that are available to expressions. The expressions of the functions can use
+// the built-in functions, but not each other.
+func SetFunctions(defs map[string]Function) error {
+	builtins := addBuiltins(map[string]interface{}{})
+	compiled := make(map[string]Func, len(defs))
+	for name, def := range defs {
+		if _, ok := builtins[name]; ok {
+			return fmt.Errorf("function %q is a built-in function", name)
+		}
+		f, err := newFunc(name, def, builtins)
+		if err != nil {
+			return err
+		}
+		compiled[name] = f
+	}
+	functionsMutex.Lock()
+	defer functionsMutex.Unlock()
+	functions = compiled
+	return nil
+}
+
+// GetFunctions returns the functions that operators defined, by name
+func GetFunctions() map[string]Func {
+	functionsMutex.RLock()
+	defer functionsMutex.RUnlock()
+	copied := make(map[string]Func, len(functions))
+	for name, f := range functions {
+		copied[name] = f
+	}
+	return copied
+}
+
+func newFunc(name string, def Function, builtins map[string]interface{}) (Func, error) {
+	// the types of the arguments are only known when the function is called
+	program, err := expr.Compile(def.Expression, expr.Env(builtins), expr.AllowUndefinedVariables())
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", name, err)
+	}
+	return func(args ...interface{}) (interface{}, error) {
+		if len(args) != len(def.Params) {
+			return nil, fmt.Errorf("function %q expects %d arguments, got %d", name, len(def.Params), len(args))
+		}
+		return run(program, builtins, def.Params, args)
+	}, nil
+}
+
+func run(program *vm.Program, builtins map[string]interface{}, params []string, args []interface{}) (interface{}, error) {
+	env := make(map[string]interface{}, len(builtins)+len(params))
+	for k, v := range builtins {
+		env[k] = v
+	}
+	for i, p := range params {
+		env[p] = args[i]
+	}
+	return expr.Run(program, env)
+}
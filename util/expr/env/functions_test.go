@@ -0,0 +1,33 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/antonmedv/expr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFunctions(t *testing.T) {
+	defer func() { _ = SetFunctions(nil) }()
+	t.Run("BuiltIn", func(t *testing.T) {
+		assert.EqualError(t, SetFunctions(map[string]Function{"toJson": {Expression: "1"}}), `function "toJson" is a built-in function`)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Error(t, SetFunctions(map[string]Function{"f": {Params: []string{"x"}, Expression: "x +"}}))
+	})
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, SetFunctions(map[string]Function{
+			"semverGte": {Params: []string{"a", "b"}, Expression: "sprig.semverCompare('>=' + b, a)"},
+			"bucket":    {Params: []string{"url"}, Expression: "sprig.splitList('/', sprig.trimPrefix('s3://', url))[0]"},
+		}))
+		result, err := expr.Eval("semverGte(inputs.parameters.version, '1.2.0')", GetFuncMap(map[string]interface{}{"inputs.parameters.version": "1.10.0"}))
+		require.NoError(t, err)
+		assert.Equal(t, true, result)
+		result, err = expr.Eval("bucket('s3://my-bucket/my-key')", GetFuncMap(nil))
+		require.NoError(t, err)
+		assert.Equal(t, "my-bucket", result)
+		_, err = expr.Eval("semverGte('1.0.0')", GetFuncMap(nil))
+		assert.EqualError(t, err, `function "semverGte" expects 2 arguments, got 1`)
+	})
+}
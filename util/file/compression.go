@@ -0,0 +1,145 @@
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/klauspost/pgzip"
+)
+
+const (
+	// CompressionGZip compresses with gzip, using the implementation of `GZipImplEnvVarKey`
+	CompressionGZip = "gzip"
+	// CompressionZstd compresses with Zstandard, which is faster and smaller than gzip for workflows' JSON
+	CompressionZstd = "zstd"
+)
+
+// zstdMagic starts every Zstandard frame, so that content compressed by either algorithm can be read
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// extensions are the file extensions of content compressed with each algorithm
+var extensions = map[string]string{
+	CompressionGZip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// compression is the algorithm and level that content is compressed with. It is replaced, rather than modified, by
+// SetCompression, so it can be read while content is compressed concurrently.
+type compression struct {
+	algorithm string
+	level     int
+	// encoder compresses content with zstd, and is shared as creating one is expensive. It is nil for gzip.
+	encoder *zstd.Encoder
+}
+
+var current atomic.Value
+
+func getCompression() compression {
+	if c, ok := current.Load().(compression); ok {
+		return c
+	}
+	return compression{algorithm: CompressionGZip}
+}
+
+// SetCompression sets the algorithm and level that content is compressed with. A level of 0 is the algorithm's
+// default, otherwise it is the gzip level from 1 to 9, or the zstd level from 1 to 22.
+func SetCompression(algorithm string, level int) error {
+	switch algorithm {
+	case "":
+		algorithm = CompressionGZip
+	case CompressionGZip, CompressionZstd:
+	default:
+		return fmt.Errorf("unknown compression algorithm %q, must be one of %q or %q", algorithm, CompressionGZip, CompressionZstd)
+	}
+	if level < 0 || (algorithm == CompressionGZip && level > gzip.BestCompression) || (algorithm == CompressionZstd && level > 22) {
+		return fmt.Errorf("invalid %s compression level %d", algorithm, level)
+	}
+	c := compression{algorithm: algorithm, level: level}
+	if algorithm == CompressionZstd {
+		encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(c.zstdLevel()))
+		if err != nil {
+			return err
+		}
+		c.encoder = encoder
+	}
+	current.Store(c)
+	return nil
+}
+
+// CompressionExtension returns the file extension of content compressed with the algorithm set by SetCompression,
+// e.g. ".gz"
+func CompressionExtension() string {
+	return extensions[getCompression().algorithm]
+}
+
+// CompressionExtensions returns the file extensions of content compressed with any algorithm
+func CompressionExtensions() []string {
+	return []string{extensions[CompressionGZip], extensions[CompressionZstd]}
+}
+
+func (c compression) zstdLevel() zstd.EncoderLevel {
+	if c.level > 0 {
+		return zstd.EncoderLevelFromZstd(c.level)
+	}
+	return zstd.SpeedDefault
+}
+
+// NewCompressWriter returns a writer that compresses to the writer with the algorithm set by SetCompression
+func NewCompressWriter(w io.Writer) (io.WriteCloser, error) {
+	return getCompression().newWriter(w)
+}
+
+func (c compression) newWriter(w io.Writer) (io.WriteCloser, error) {
+	switch c.algorithm {
+	case CompressionZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(c.zstdLevel()))
+	default:
+		level := gzip.DefaultCompression
+		if c.level > 0 {
+			level = c.level
+		}
+		if gzipImpl == GZIP {
+			return gzip.NewWriterLevel(w, level)
+		}
+		return pgzip.NewWriterLevel(w, level)
+	}
+}
+
+// compress compresses the content in one go, which for zstd uses the shared encoder
+func (c compression) compress(content []byte) ([]byte, error) {
+	if c.encoder != nil {
+		return c.encoder.EncodeAll(content, nil), nil
+	}
+	var buf bytes.Buffer
+	w, err := c.newWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(content); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// NewDecompressReader returns a reader that decompresses the reader, whether it was compressed with gzip or zstd
+func NewDecompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(len(zstdMagic))
+	if bytes.Equal(magic, zstdMagic) {
+		d, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return d.IOReadCloser(), nil
+	}
+	return GetGzipReader(br)
+}
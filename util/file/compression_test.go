@@ -0,0 +1,77 @@
+package file_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/util/file"
+)
+
+func TestSetCompression(t *testing.T) {
+	defer func() { _ = file.SetCompression(file.CompressionGZip, 0) }()
+	assert.NoError(t, file.SetCompression("", 0))
+	assert.NoError(t, file.SetCompression(file.CompressionZstd, 19))
+	assert.EqualError(t, file.SetCompression("lz4", 0), `unknown compression algorithm "lz4", must be one of "gzip" or "zstd"`)
+	assert.EqualError(t, file.SetCompression(file.CompressionGZip, 10), "invalid gzip compression level 10")
+	assert.EqualError(t, file.SetCompression(file.CompressionZstd, -1), "invalid zstd compression level -1")
+}
+
+func TestCompression(t *testing.T) {
+	defer func() { _ = file.SetCompression(file.CompressionGZip, 0) }()
+	content := `{"my-wf":{"id":"my-wf","phase":"Succeeded"}}`
+	compressed := map[string]string{}
+	for _, algorithm := range []string{file.CompressionGZip, file.CompressionZstd} {
+		for _, level := range []int{0, 1, 9} {
+			assert.NoError(t, file.SetCompression(algorithm, level))
+			s := file.CompressEncodeString(content)
+			compressed[algorithm] = s
+			decompressed, err := file.DecodeDecompressString(s)
+			if assert.NoError(t, err) {
+				assert.Equal(t, content, decompressed)
+			}
+		}
+	}
+	assert.NotEqual(t, compressed[file.CompressionGZip], compressed[file.CompressionZstd])
+
+	t.Run("EitherAlgorithm", func(t *testing.T) {
+		assert.NoError(t, file.SetCompression(file.CompressionGZip, 0))
+		decompressed, err := file.DecodeDecompressString(compressed[file.CompressionZstd])
+		if assert.NoError(t, err) {
+			assert.Equal(t, content, decompressed)
+		}
+	})
+}
+
+func TestCompressionExtension(t *testing.T) {
+	defer func() { _ = file.SetCompression(file.CompressionGZip, 0) }()
+	assert.Equal(t, ".gz", file.CompressionExtension())
+	assert.NoError(t, file.SetCompression(file.CompressionZstd, 0))
+	assert.Equal(t, ".zst", file.CompressionExtension())
+	assert.Equal(t, []string{".gz", ".zst"}, file.CompressionExtensions())
+}
+
+// TestCompressionConcurrently checks that content can be compressed while the compression is changed, e.g. when the
+// configuration of the controller is updated
+func TestCompressionConcurrently(t *testing.T) {
+	defer func() { _ = file.SetCompression(file.CompressionGZip, 0) }()
+	content := `{"my-wf":{"id":"my-wf","phase":"Succeeded"}}`
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				decompressed, err := file.DecodeDecompressString(file.CompressEncodeString(content))
+				if assert.NoError(t, err) {
+					assert.Equal(t, content, decompressed)
+				}
+			}
+		}()
+	}
+	for _, algorithm := range []string{file.CompressionZstd, file.CompressionGZip, file.CompressionZstd} {
+		assert.NoError(t, file.SetCompression(algorithm, 0))
+	}
+	wg.Wait()
+}
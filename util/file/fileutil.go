@@ -90,32 +90,23 @@ func DecodeDecompressString(content string) (string, error) {
 	return string(dBuf), nil
 }
 
-// CompressContent will compress the byte array using zip writer
+// CompressContent will compress the byte array with the algorithm set by SetCompression
 func CompressContent(content []byte) []byte {
-	var buf bytes.Buffer
-	var gzipWriter io.WriteCloser
-	switch gzipImpl {
-	case GZIP:
-		gzipWriter = gzip.NewWriter(&buf)
-	default:
-		gzipWriter = pgzip.NewWriter(&buf)
-	}
-
-	_, err := gzipWriter.Write(content)
+	compressed, err := getCompression().compress(content)
 	if err != nil {
 		log.Warnf("Error in compressing: %v", err)
+		return nil
 	}
-	close(gzipWriter)
-	return buf.Bytes()
+	return compressed
 }
 
-// DecompressContent will return the uncompressed content
+// DecompressContent will return the uncompressed content, whether it was compressed with gzip or zstd
 func DecompressContent(content []byte) ([]byte, error) {
 	buf := bytes.NewReader(content)
-	gzipReader, err := GetGzipReader(buf)
+	r, err := NewDecompressReader(buf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decompress: %w", err)
 	}
-	defer close(gzipReader)
-	return ioutil.ReadAll(gzipReader)
+	defer close(r)
+	return ioutil.ReadAll(r)
 }
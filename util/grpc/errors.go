@@ -27,6 +27,9 @@ func TranslateError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case apierr.IsForbidden(err):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case apierr.IsResourceExpired(err) || apierr.IsGone(err):
+		// e.g. a list's continue token has expired, and the list must be restarted
+		return status.Error(codes.Aborted, err.Error())
 	case apierr.IsTimeout(err):
 		return status.Error(codes.DeadlineExceeded, err.Error())
 	case apierr.IsInternalError(err):
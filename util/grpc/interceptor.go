@@ -53,37 +53,52 @@ var (
 
 // RatelimitUnaryServerInterceptor returns a new unary server interceptor that performs request rate limiting.
 func RatelimitUnaryServerInterceptor(ratelimiter limiter.Store) grpc.UnaryServerInterceptor {
+	return KeyedRatelimitUnaryServerInterceptor(ratelimiter, getClientIP)
+}
+
+// RatelimitStreamServerInterceptor returns a new stream server interceptor that performs rate limiting on the request.
+func RatelimitStreamServerInterceptor(ratelimiter limiter.Store) grpc.StreamServerInterceptor {
+	return KeyedRatelimitStreamServerInterceptor(ratelimiter, getClientIP)
+}
+
+// KeyedRatelimitUnaryServerInterceptor returns a new unary server interceptor that rate limits requests with the same
+// key, e.g. the identity of the user. Requests without a key are not limited.
+func KeyedRatelimitUnaryServerInterceptor(ratelimiter limiter.Store, key func(ctx context.Context) string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		ip := getClientIP(ctx)
-		_, _, _, ok, err := ratelimiter.Take(ctx, ip)
-		if err != nil {
-			log.Warnf("Internal Server Error: %s", err)
-			return nil, status.Errorf(codes.Internal, "%s: grpc_ratelimit middleware internal error", info.FullMethod)
-		}
-		if !ok {
-			return nil, status.Errorf(codes.ResourceExhausted, "%s is rejected by grpc_ratelimit middleware, please retry later.", info.FullMethod)
+		if err := takeRatelimitToken(ctx, ratelimiter, key(ctx), info.FullMethod); err != nil {
+			return nil, err
 		}
 		return handler(ctx, req)
 	}
 }
 
-// RatelimitStreamServerInterceptor returns a new stream server interceptor that performs rate limiting on the request.
-func RatelimitStreamServerInterceptor(ratelimiter limiter.Store) grpc.StreamServerInterceptor {
+// KeyedRatelimitStreamServerInterceptor returns a new stream server interceptor that rate limits requests with the same
+// key, e.g. the identity of the user. Requests without a key are not limited.
+func KeyedRatelimitStreamServerInterceptor(ratelimiter limiter.Store, key func(ctx context.Context) string) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		ctx := stream.Context()
-		ip := getClientIP(ctx)
-		_, _, _, ok, err := ratelimiter.Take(ctx, ip)
-		if err != nil {
-			log.Warnf("Internal Server Error: %s", err)
-			return status.Errorf(codes.Internal, "%s: grpc_ratelimit middleware internal error", info.FullMethod)
-		}
-		if !ok {
-			return status.Errorf(codes.ResourceExhausted, "%s is rejected by grpc_ratelimit middleware, please retry later.", info.FullMethod)
+		if err := takeRatelimitToken(ctx, ratelimiter, key(ctx), info.FullMethod); err != nil {
+			return err
 		}
 		return handler(srv, stream)
 	}
 }
 
+func takeRatelimitToken(ctx context.Context, ratelimiter limiter.Store, key, fullMethod string) error {
+	if key == "" {
+		return nil
+	}
+	_, _, _, ok, err := ratelimiter.Take(ctx, key)
+	if err != nil {
+		log.Warnf("Internal Server Error: %s", err)
+		return status.Errorf(codes.Internal, "%s: grpc_ratelimit middleware internal error", fullMethod)
+	}
+	if !ok {
+		return status.Errorf(codes.ResourceExhausted, "%s is rejected by grpc_ratelimit middleware, please retry later.", fullMethod)
+	}
+	return nil
+}
+
 // GetClientIP inspects the context to retrieve the ip address of the client
 func getClientIP(ctx context.Context) string {
 	p, ok := peer.FromContext(ctx)
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type namespacedRequest interface {
+	GetNamespace() string
+}
+
+// NamespaceUnaryServerInterceptor denies requests for a namespace that is not managed. Requests that are not for a
+// namespace are allowed.
+func NamespaceUnaryServerInterceptor(managed func(namespace string) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkNamespace(req, managed); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NamespaceStreamServerInterceptor denies the requests of streams for a namespace that is not managed
+func NamespaceStreamServerInterceptor(managed func(namespace string) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &namespaceCheckingServerStream{ServerStream: stream, managed: managed})
+	}
+}
+
+type namespaceCheckingServerStream struct {
+	grpc.ServerStream
+	managed func(namespace string) bool
+}
+
+func (s *namespaceCheckingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return checkNamespace(m, s.managed)
+}
+
+func checkNamespace(req interface{}, managed func(namespace string) bool) error {
+	r, ok := req.(namespacedRequest)
+	if !ok || r.GetNamespace() == "" || managed(r.GetNamespace()) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "namespace %q is not managed", r.GetNamespace())
+}
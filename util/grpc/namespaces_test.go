@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type namespaceHolder string
+
+func (n namespaceHolder) GetNamespace() string { return string(n) }
+
+func TestNamespaceUnaryServerInterceptor(t *testing.T) {
+	interceptor := NamespaceUnaryServerInterceptor(func(namespace string) bool { return namespace == "tenant" })
+	call := func(req interface{}) error {
+		_, err := interceptor(context.Background(), req, &grpc.UnaryServerInfo{}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	assert.NoError(t, call(namespaceHolder("tenant")))
+	assert.NoError(t, call(namespaceHolder("")))
+	assert.NoError(t, call(nil))
+	err := call(namespaceHolder("kube-system"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.EqualError(t, err, `rpc error: code = PermissionDenied desc = namespace "kube-system" is not managed`)
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	limiter "github.com/sethvargo/go-limiter"
+)
+
+// RateLimitedRequests counts the API requests rejected by a rate limiter, by the limit (e.g. "ip" or "identity")
+var RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "argo",
+	Subsystem: "server",
+	Name:      "api_rate_limited_requests_total",
+	Help:      "Total number of API requests rejected by a rate limit",
+}, []string{"limit"})
+
+// RegisterRateLimitMetrics registers RateLimitedRequests. It may be called more than once, e.g. by each server created
+// in the same process.
+func RegisterRateLimitMetrics(registerer prometheus.Registerer) error {
+	err := registerer.Register(RateLimitedRequests)
+	if errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil
+	}
+	return err
+}
+
+type countingStore struct {
+	limiter.Store
+	limit string
+}
+
+// NewCountingStore returns a store that counts the requests it rejects in RateLimitedRequests
+func NewCountingStore(store limiter.Store, limit string) limiter.Store {
+	return &countingStore{Store: store, limit: limit}
+}
+
+func (s *countingStore) Take(ctx context.Context, key string) (tokens, remaining, reset uint64, ok bool, err error) {
+	tokens, remaining, reset, ok, err = s.Store.Take(ctx, key)
+	if err == nil && !ok {
+		RateLimitedRequests.WithLabelValues(s.limit).Inc()
+	}
+	return tokens, remaining, reset, ok, err
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sethvargo/go-limiter/memorystore"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type keyKey struct{}
+
+func TestKeyedRatelimitUnaryServerInterceptor(t *testing.T) {
+	store, err := memorystore.New(&memorystore.Config{Tokens: 1, Interval: time.Hour})
+	if !assert.NoError(t, err) {
+		return
+	}
+	interceptor := KeyedRatelimitUnaryServerInterceptor(NewCountingStore(store, "test"), func(ctx context.Context) string {
+		key, _ := ctx.Value(keyKey{}).(string)
+		return key
+	})
+	call := func(key string) error {
+		ctx := context.WithValue(context.Background(), keyKey{}, key)
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/my.Service/List"}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	assert.NoError(t, call("my-user"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(call("my-user")))
+	assert.NoError(t, call("other-user"))
+	// requests without a key are not limited
+	assert.NoError(t, call(""))
+	assert.NoError(t, call(""))
+	assert.Equal(t, float64(1), testutil.ToFloat64(RateLimitedRequests.WithLabelValues("test")))
+}
+
+func TestRegisterRateLimitMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, RegisterRateLimitMetrics(registry))
+	// e.g. a second server in the same process
+	assert.NoError(t, RegisterRateLimitMetrics(registry))
+}
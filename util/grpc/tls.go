@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+
+	"github.com/soheilhy/cmux"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsListenerCredentials are the transport credentials of a server whose listener has already performed the TLS
+// handshake, so that the TLS connection state (e.g. client certificates) is available as the peer's auth info
+type tlsListenerCredentials struct{}
+
+func NewTLSListenerCredentials() credentials.TransportCredentials {
+	return tlsListenerCredentials{}
+}
+
+func (c tlsListenerCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("TLS listener credentials are only for servers")
+}
+
+func (c tlsListenerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if state := TLSConnectionState(conn); state != nil {
+		return conn, credentials.TLSInfo{State: *state, CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity}}, nil
+	}
+	return conn, nil, nil
+}
+
+// TLSConnectionState returns the state of a TLS connection, even if cmux has wrapped it, or nil if it is not TLS
+func TLSConnectionState(conn net.Conn) *tls.ConnectionState {
+	if m, ok := conn.(*cmux.MuxConn); ok {
+		conn = m.Conn
+	}
+	if t, ok := conn.(*tls.Conn); ok {
+		state := t.ConnectionState()
+		return &state
+	}
+	return nil
+}
+
+func (c tlsListenerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c tlsListenerCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (c tlsListenerCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,37 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+)
+
+// Error writes the HTTP status of the error, either a Kubernetes API error or an Argo error, defaulting to an
+// internal server error. Internal errors are logged, rather than returned to the user.
+func Error(w http.ResponseWriter, err error) {
+	statusCode := http.StatusInternalServerError
+	e := &apierr.StatusError{}
+	if errors.As(err, &e) {
+		statusCode = int(e.Status().Code)
+	} else if argoerr, ok := err.(argoerrors.ArgoError); ok {
+		statusCode = argoerr.HTTPCode()
+	}
+	if statusCode == http.StatusInternalServerError {
+		log.WithError(err).Error("HTTP request returned internal error")
+	}
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
+
+// WriteJSON writes the value as a JSON response with the status code
+func WriteJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("failed to write JSON response")
+	}
+}
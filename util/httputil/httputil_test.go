@@ -0,0 +1,42 @@
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	argoerrors "github.com/argoproj/argo-workflows/v3/errors"
+)
+
+func TestError(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		err        error
+		statusCode int
+	}{
+		{"Kubernetes", apierr.NewForbidden(schema.GroupResource{}, "my-wf", fmt.Errorf("no")), http.StatusForbidden},
+		{"WrappedKubernetes", fmt.Errorf("wrapped: %w", apierr.NewNotFound(schema.GroupResource{}, "my-wf")), http.StatusNotFound},
+		{"Argo", argoerrors.New(argoerrors.CodeBadRequest, "bad"), http.StatusBadRequest},
+		{"Other", fmt.Errorf("secret detail"), http.StatusInternalServerError},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Error(w, tt.err)
+			assert.Equal(t, tt.statusCode, w.Code)
+			assert.Equal(t, http.StatusText(tt.statusCode)+"\n", w.Body.String())
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteJSON(w, http.StatusCreated, map[string]string{"foo": "bar"})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"foo":"bar"}`, w.Body.String())
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"crypto/subtle"
+	"io"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// LevelsHandler returns a handler of the levels, see ServeLevels. Requests must have the token in the header, and the
+// levels are not found if the token is empty.
+func LevelsHandler(header, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			http.NotFound(w, r)
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get(header)), []byte(token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		ServeLevels(w, r)
+	}
+}
+
+// ServeLevels returns the levels for `GET`, and sets them to the request's body for `PUT`, e.g. `info,operator=debug`
+func ServeLevels(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		_, _ = w.Write([]byte(GetLevels().String()))
+	case http.MethodPut:
+		data, err := io.ReadAll(io.LimitReader(r.Body, 4096))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		levels, err := ParseLevels(string(data))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.WithFields(log.Fields{"old": GetLevels().String(), "new": levels.String()}).Info("Setting log levels")
+		SetLevels(levels)
+		_, _ = w.Write([]byte(levels.String()))
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
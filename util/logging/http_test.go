@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelsHandler(t *testing.T) {
+	defer SetLevels(Levels{Default: log.InfoLevel})
+	serve := func(handler http.HandlerFunc, method, token, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/diagnostics/log-levels", strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("X-Token", token)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+	handler := LevelsHandler("X-Token", "my-token")
+
+	assert.Equal(t, http.StatusNotFound, serve(LevelsHandler("X-Token", ""), http.MethodGet, "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, "other-token", "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, http.MethodPost, "my-token", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(handler, http.MethodPut, "my-token", "operator=verbose").Code)
+	w := serve(handler, http.MethodPut, "my-token", "warn,operator=debug")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "warning,operator=debug", w.Body.String())
+	assert.Equal(t, "warning,operator=debug", serve(handler, http.MethodGet, "my-token", "").Body.String())
+}
@@ -0,0 +1,142 @@
+// Package logging configures the level of each component's logs
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FieldComponent is the field of log entries that names the component that logged them, e.g. `operator`
+const FieldComponent = "component"
+
+// Component returns a log entry for the component's logs, which are logged at the component's level
+func Component(name string) *log.Entry {
+	return log.NewEntry(componentLogger(name)).WithField(FieldComponent, name)
+}
+
+// WithComponent returns the entry, with its fields, as one of the component's logs
+func WithComponent(entry *log.Entry, name string) *log.Entry {
+	return Component(name).WithFields(entry.Data).WithField(FieldComponent, name)
+}
+
+// Levels are the levels of the logs of each component, and of the logs of any other component
+type Levels struct {
+	Default    log.Level
+	Components map[string]log.Level
+}
+
+// ParseLevels parses levels such as `info,operator=debug,artifact-gc=warn`. The level without a component is the
+// default level, which is `info` if it is not given.
+func ParseLevels(s string) (Levels, error) {
+	levels := Levels{Default: log.InfoLevel, Components: map[string]log.Level{}}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		component, level, ok := strings.Cut(part, "=")
+		if !ok {
+			component, level = "", component
+		}
+		l, err := log.ParseLevel(strings.TrimSpace(level))
+		if err != nil {
+			return Levels{}, err
+		}
+		component = strings.TrimSpace(component)
+		if ok && component == "" {
+			return Levels{}, fmt.Errorf("missing component in %q", part)
+		}
+		if component == "" {
+			levels.Default = l
+		} else {
+			levels.Components[component] = l
+		}
+	}
+	return levels, nil
+}
+
+// String formats the levels as they are parsed
+func (l Levels) String() string {
+	parts := []string{l.Default.String()}
+	components := make([]string, 0, len(l.Components))
+	for component := range l.Components {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		parts = append(parts, component+"="+l.Components[component].String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// level returns the level of the component's logs
+func (l Levels) level(component string) log.Level {
+	if level, ok := l.Components[component]; ok {
+		return level
+	}
+	return l.Default
+}
+
+var levels atomic.Value
+
+func init() {
+	levels.Store(Levels{Default: log.InfoLevel})
+}
+
+// GetLevels returns the current levels
+func GetLevels() Levels {
+	return levels.Load().(Levels)
+}
+
+// SetLevels sets the levels, which may be changed at any time. Logrus's level is set to the default level, and each
+// component's logger to the component's level.
+func SetLevels(l Levels) {
+	levels.Store(l)
+	log.SetLevel(l.Default)
+	componentLoggers.Range(func(name, logger interface{}) bool {
+		logger.(*log.Logger).SetLevel(l.level(name.(string)))
+		return true
+	})
+}
+
+// componentLoggers are the loggers of the components by name, created when they first log
+var componentLoggers sync.Map
+
+// componentLogger returns the logger of the component, which logs at the component's level but is otherwise the same as
+// logrus's standard logger: it writes to its output, with its formatter, and fires its hooks, including those added
+// later with log.AddHook
+func componentLogger(name string) *log.Logger {
+	if logger, ok := componentLoggers.Load(name); ok {
+		return logger.(*log.Logger)
+	}
+	std := log.StandardLogger()
+	logger, _ := componentLoggers.LoadOrStore(name, &log.Logger{
+		Out:          standardOutput{},
+		Formatter:    standardFormatter{},
+		Hooks:        std.Hooks,
+		ExitFunc:     std.ExitFunc,
+		ReportCaller: std.ReportCaller,
+	})
+	// set after it is stored, so it is not missed by a concurrent SetLevels
+	logger.(*log.Logger).SetLevel(GetLevels().level(name))
+	return logger.(*log.Logger)
+}
+
+// standardOutput writes to the output of logrus's standard logger
+type standardOutput struct{}
+
+func (standardOutput) Write(p []byte) (int, error) {
+	return log.StandardLogger().Out.Write(p)
+}
+
+// standardFormatter formats with the formatter of logrus's standard logger
+type standardFormatter struct{}
+
+func (standardFormatter) Format(entry *log.Entry) ([]byte, error) {
+	return log.StandardLogger().Formatter.Format(entry)
+}
@@ -0,0 +1,97 @@
+package logging
+
+import (
+	"bytes"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevels(t *testing.T) {
+	for s, expected := range map[string]string{
+		"":                                     "info",
+		"debug":                                "debug",
+		"info,operator=debug":                  "info,operator=debug",
+		" warn , artifact-gc=debug,cron=error": "warning,artifact-gc=debug,cron=error",
+		"operator=debug":                       "info,operator=debug",
+	} {
+		levels, err := ParseLevels(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, expected, levels.String(), s)
+		}
+	}
+	for _, s := range []string{"verbose", "operator=verbose", "=debug"} {
+		_, err := ParseLevels(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSetLevels(t *testing.T) {
+	logger := log.StandardLogger()
+	out, formatter, level := logger.Out, logger.Formatter, logger.Level
+	defer func() {
+		logger.SetOutput(out)
+		logger.SetFormatter(formatter)
+		logger.SetLevel(level)
+		SetLevels(Levels{Default: log.InfoLevel})
+	}()
+	buf := &bytes.Buffer{}
+	logger.SetOutput(buf)
+	logger.SetFormatter(&log.JSONFormatter{})
+
+	levels, _ := ParseLevels("info,operator=debug,artifact-gc=error")
+	SetLevels(levels)
+	assert.Equal(t, log.InfoLevel, log.GetLevel())
+
+	log.Debug("default-debug")
+	log.Info("default-info")
+	Component("operator").Debug("operator-debug")
+	Component("artifact-gc").Warn("artifact-gc-warn")
+	Component("artifact-gc").Error("artifact-gc-error")
+	WithComponent(Component("operator").WithField("workflow", "my-wf"), "artifact-gc").Error("artifact-gc-workflow-error")
+
+	assert.NotContains(t, buf.String(), "default-debug")
+	assert.Contains(t, buf.String(), "default-info")
+	assert.Contains(t, buf.String(), `"component":"operator"`)
+	assert.Contains(t, buf.String(), "operator-debug")
+	assert.NotContains(t, buf.String(), "artifact-gc-warn")
+	assert.Contains(t, buf.String(), "artifact-gc-error")
+	assert.Contains(t, buf.String(), `"workflow":"my-wf"`)
+
+	t.Run("Changed", func(t *testing.T) {
+		buf.Reset()
+		SetLevels(Levels{Default: log.InfoLevel})
+		Component("operator").Debug("operator-debug")
+		Component("artifact-gc").Warn("artifact-gc-warn")
+		assert.NotContains(t, buf.String(), "operator-debug")
+		assert.Contains(t, buf.String(), "artifact-gc-warn")
+	})
+	t.Run("Hooks", func(t *testing.T) {
+		hook := &countingHook{}
+		logger.AddHook(hook)
+		// the hooks are shared with the components' loggers, so it is removed rather than replaced
+		defer func() {
+			for _, level := range hook.Levels() {
+				logger.Hooks[level] = logger.Hooks[level][:len(logger.Hooks[level])-1]
+			}
+		}()
+		Component("operator").Debug("operator-debug")
+		Component("operator").Info("operator-info")
+		assert.Equal(t, 1, hook.fired)
+	})
+}
+
+// countingHook counts the entries it is fired for
+type countingHook struct {
+	fired int
+}
+
+func (h *countingHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+func (h *countingHook) Fire(*log.Entry) error {
+	h.fired++
+	return nil
+}
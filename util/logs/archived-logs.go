@@ -0,0 +1,41 @@
+package logs
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// ArchivedLog is a container's log archived as an artifact by `archiveLogs`
+type ArchivedLog struct {
+	// StartedAt is when the pod's node started, the archived lines have no timestamps of their own
+	StartedAt time.Time
+	Open      func() (io.ReadCloser, error)
+}
+
+// ArchivedLogs finds the archived logs of a workflow, so they can be streamed once the pods are deleted
+type ArchivedLogs interface {
+	// Find returns the archived logs of the container of the workflow's pods, by pod name
+	Find(ctx context.Context, wf *wfv1.Workflow, container string) (map[string]ArchivedLog, error)
+}
+
+// readArchivedLog returns the lines of the archived log, or the last tailLines of them if that is not nil
+func readArchivedLog(archivedLog ArchivedLog, tailLines *int64) ([]string, error) {
+	rc, err := archivedLog.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+	var lines []string
+	scanner := bufio.NewScanner(rc)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+		if tailLines != nil && int64(len(lines)) > *tailLines {
+			lines = lines[1:]
+		}
+	}
+	return lines, scanner.Err()
+}
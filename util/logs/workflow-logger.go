@@ -35,15 +35,18 @@ type request interface {
 	GetLogOptions() *corev1.PodLogOptions
 	GetGrep() string
 	GetSelector() string
+	GetUntilTime() string
 }
 
 type sender interface {
 	Send(entry *workflowpkg.LogEntry) error
 }
 
-func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient kubernetes.Interface, req request, sender sender) error {
+// WorkflowLogs streams the logs of the workflow's pods. The archived logs of pods that no longer exist are streamed
+// too, unless archivedLogs is nil or the pods are selected by label.
+func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient kubernetes.Interface, archivedLogs ArchivedLogs, req request, sender sender) error {
 	wfInterface := wfClient.ArgoprojV1alpha1().Workflows(req.GetNamespace())
-	_, err := wfInterface.Get(ctx, req.GetName(), metav1.GetOptions{})
+	wf, err := wfInterface.Get(ctx, req.GetName(), metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -53,6 +56,15 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 		return fmt.Errorf("failed to compile %q: %w", req.GetGrep(), err)
 	}
 
+	var untilTime *time.Time
+	if req.GetUntilTime() != "" {
+		t, err := time.Parse(time.RFC3339, req.GetUntilTime())
+		if err != nil {
+			return fmt.Errorf("failed to parse until time %q: %w", req.GetUntilTime(), err)
+		}
+		untilTime = &t
+	}
+
 	podInterface := kubeClient.CoreV1().Pods(req.GetNamespace())
 
 	logCtx := log.WithFields(log.Fields{"workflow": req.GetName(), "namespace": req.GetNamespace()})
@@ -133,9 +145,14 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 						if req.GetLogOptions().Timestamps {
 							content = line
 						}
+						// the lines of a pod's logs are in time order, so there will be no more before the until time
+						if untilTime != nil && timestamp.After(*untilTime) {
+							logCtx.Debug("Reached the until time")
+							return
+						}
 						if rx.MatchString(content) { // this means we filter the lines in the server, but will still incur the cost of retrieving them from Kubernetes
 							logCtx.WithFields(log.Fields{"timestamp": timestamp, "content": content}).Debug("Log line")
-							unsortedEntries <- logEntry{podName: podName, content: content, timestamp: timestamp}
+							unsortedEntries <- logEntry{podName: podName, container: podLogStreamOptions.Container, content: content, timestamp: timestamp}
 						}
 					}
 				}
@@ -163,10 +180,55 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 		return list.Items[i].Status.StartTime.Before(list.Items[j].Status.StartTime)
 	})
 
+	livePods := make(map[string]bool)
 	for _, pod := range list.Items {
+		livePods[pod.Name] = true
 		ensureWeAreStreaming(&pod)
 	}
 
+	// the archived logs have no pod labels, so we cannot tell which of them the selector would select
+	if archivedLogs != nil && req.GetSelector() == "" {
+		container := podLogStreamOptions.Container
+		if container == "" {
+			container = common.MainContainerName
+		}
+		found, err := archivedLogs.Find(ctx, wf, container)
+		if err != nil {
+			return err
+		}
+		for podName, archivedLog := range found {
+			if livePods[podName] || (req.GetPodName() != "" && podName != req.GetPodName()) {
+				continue
+			}
+			wg.Add(1)
+			go func(podName string, archivedLog ArchivedLog) {
+				defer wg.Done()
+				logCtx := logCtx.WithField("podName", podName)
+				logCtx.Debug("Streaming archived pod logs")
+				lines, err := readArchivedLog(archivedLog, logOptions.TailLines)
+				if err != nil {
+					logCtx.WithError(err).Error("failed to read archived pod logs")
+					return
+				}
+				for i, content := range lines {
+					// the lines are timestamped in order from the start of the node, so they sort before the logs of
+					// any later pod
+					timestamp := archivedLog.StartedAt.Add(time.Duration(i))
+					if untilTime != nil && timestamp.After(*untilTime) {
+						return
+					}
+					if rx.MatchString(content) {
+						select {
+						case <-ctx.Done():
+							return
+						case unsortedEntries <- logEntry{podName: podName, container: container, content: content, timestamp: timestamp}:
+						}
+					}
+				}
+			}(podName, archivedLog)
+		}
+	}
+
 	if logOptions.Follow {
 		wfListOptions := metav1.ListOptions{FieldSelector: "metadata.name=" + req.GetName(), ResourceVersion: "0"}
 		wfWatch, err := wfInterface.Watch(ctx, wfListOptions)
@@ -268,7 +330,7 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 				var e logEntry
 				e, entries = entries[0], entries[1:]
 				logCtx.WithFields(log.Fields{"timestamp": e.timestamp, "content": e.content}).Debug("Sending entry")
-				err := sender.Send(&workflowpkg.LogEntry{Content: e.content, PodName: e.podName})
+				err := sender.Send(&workflowpkg.LogEntry{Content: e.content, PodName: e.podName, Container: e.container, Timestamp: &metav1.MicroTime{Time: e.timestamp}})
 				if err != nil {
 					return err
 				}
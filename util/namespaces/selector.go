@@ -0,0 +1,77 @@
+package namespaces
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const resyncPeriod = 20 * time.Minute
+
+// Selector tracks the namespaces that match a label selector as they are created and labelled, e.g. the tenant
+// namespaces that are managed. A nil selector matches all namespaces.
+type Selector struct {
+	selector labels.Selector
+	informer cache.SharedIndexInformer
+}
+
+// NewSelector returns a selector of the namespaces that match the label selector, e.g. "workflows.argoproj.io/managed=true"
+func NewSelector(kubeclientset kubernetes.Interface, selector string) (*Selector, error) {
+	s, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector %q: %w", selector, err)
+	}
+	if s.Empty() {
+		return nil, fmt.Errorf("namespace selector must not be empty")
+	}
+	informer := coreinformers.NewFilteredNamespaceInformer(kubeclientset, resyncPeriod, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.LabelSelector = s.String()
+	})
+	return &Selector{selector: s, informer: informer}, nil
+}
+
+// Run runs the selector until the channel is closed, waiting for it to sync before returning
+func (s *Selector) Run(stopCh <-chan struct{}) bool {
+	if s == nil {
+		return true
+	}
+	go s.informer.Run(stopCh)
+	return cache.WaitForCacheSync(stopCh, s.informer.HasSynced)
+}
+
+// Matches returns whether the namespace currently matches the selector
+func (s *Selector) Matches(namespace string) bool {
+	if s == nil {
+		return true
+	}
+	_, exists, _ := s.informer.GetStore().GetByKey(namespace)
+	return exists
+}
+
+// OnMatch calls the function with the name of each namespace that starts to match the selector, including those that
+// match when it starts to run
+func (s *Selector) OnMatch(f func(namespace string)) {
+	if s == nil {
+		return
+	}
+	s.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if ns, ok := obj.(*apiv1.Namespace); ok {
+				f(ns.Name)
+			}
+		},
+	})
+}
+
+func (s *Selector) String() string {
+	if s == nil {
+		return ""
+	}
+	return s.selector.String()
+}
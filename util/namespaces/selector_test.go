@@ -0,0 +1,61 @@
+package namespaces
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSelector(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var s *Selector
+		assert.True(t, s.Run(nil))
+		assert.True(t, s.Matches("any"))
+		assert.Empty(t, s.String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewSelector(fake.NewSimpleClientset(), "!!")
+		assert.Error(t, err)
+		_, err = NewSelector(fake.NewSimpleClientset(), "")
+		assert.EqualError(t, err, "namespace selector must not be empty")
+	})
+	t.Run("Matches", func(t *testing.T) {
+		kube := fake.NewSimpleClientset(
+			&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"tenant": "true"}}},
+			&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b", Labels: map[string]string{"tenant": "true"}}},
+			&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		)
+		s, err := NewSelector(kube, "tenant=true")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "tenant=true", s.String())
+		var mu sync.Mutex
+		var matched []string
+		s.OnMatch(func(namespace string) {
+			mu.Lock()
+			defer mu.Unlock()
+			matched = append(matched, namespace)
+		})
+		stopCh := make(chan struct{})
+		defer close(stopCh)
+		if assert.True(t, s.Run(stopCh)) {
+			assert.True(t, s.Matches("tenant-a"))
+			assert.False(t, s.Matches("kube-system"))
+			assert.False(t, s.Matches("unknown"))
+			// handlers are notified asynchronously
+			assert.Eventually(t, func() bool {
+				mu.Lock()
+				defer mu.Unlock()
+				sort.Strings(matched)
+				return assert.ObjectsAreEqual([]string{"tenant-a", "tenant-b"}, matched)
+			}, 5*time.Second, 10*time.Millisecond)
+		}
+	})
+}
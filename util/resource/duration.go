@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 
@@ -8,8 +10,26 @@ import (
 )
 
 func DurationForPod(pod *corev1.Pod) wfv1.ResourcesDuration {
+	summaries := summariesForContainers(append(pod.Spec.InitContainers, pod.Spec.Containers...))
+	for _, c := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		summaries[c.Name] = Summary{ResourceList: summaries[c.Name].ResourceList, ContainerState: c.State}
+	}
+	return summaries.Duration()
+}
+
+// EstimatedDurationForPod returns the resources duration of the pod if its containers, but not its init containers,
+// run for the duration
+func EstimatedDurationForPod(pod *corev1.Pod, duration time.Duration) wfv1.ResourcesDuration {
+	d := wfv1.ResourcesDuration{}
+	for _, s := range summariesForContainers(pod.Spec.Containers) {
+		d = d.Add(s.duration(duration))
+	}
+	return d
+}
+
+func summariesForContainers(containers []corev1.Container) Summaries {
 	summaries := Summaries{}
-	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+	for _, c := range containers {
 		// Initialize summaries with default limits for CPU and memory.
 		summaries[c.Name] = Summary{ResourceList: map[corev1.ResourceName]resource.Quantity{
 			// https://medium.com/@betz.mark/understanding-resource-limits-in-kubernetes-cpu-time-9eff74d3161b
@@ -25,8 +45,5 @@ func DurationForPod(pod *corev1.Pod) wfv1.ResourcesDuration {
 			summaries[c.Name].ResourceList[name] = quantity
 		}
 	}
-	for _, c := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
-		summaries[c.Name] = Summary{ResourceList: summaries[c.Name].ResourceList, ContainerState: c.State}
-	}
-	return summaries.Duration()
+	return summaries
 }
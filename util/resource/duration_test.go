@@ -79,3 +79,19 @@ func TestDurationForPod(t *testing.T) {
 		})
 	}
 }
+
+func TestEstimatedDurationForPod(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "init"}},
+		Containers: []corev1.Container{
+			{Name: "wait"},
+			{Name: "main", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}},
+		},
+	}}
+	assert.Equal(t, wfv1.ResourcesDuration{
+		corev1.ResourceCPU:    wfv1.NewResourceDuration(3 * time.Minute),
+		corev1.ResourceMemory: wfv1.NewResourceDuration(2 * time.Minute),
+	}, EstimatedDurationForPod(pod, time.Minute))
+}
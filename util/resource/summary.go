@@ -21,6 +21,16 @@ func (s Summary) age() time.Duration {
 	}
 }
 
+// duration returns the resources duration of the resource list for the age
+func (s Summary) duration(age time.Duration) wfv1.ResourcesDuration {
+	d := wfv1.ResourcesDuration{}
+	seconds := int64(age.Seconds())
+	for n, q := range s.ResourceList {
+		d = d.Add(wfv1.ResourcesDuration{n: wfv1.NewResourceDuration(time.Duration(q.Value() * seconds / wfv1.ResourceQuantityDenominator(n).Value() * int64(time.Second)))})
+	}
+	return d
+}
+
 // map[containerName]Summary
 type Summaries map[string]Summary
 
@@ -28,10 +38,7 @@ func (ss Summaries) Duration() wfv1.ResourcesDuration {
 	// Add container states.
 	d := wfv1.ResourcesDuration{}
 	for _, s := range ss {
-		age := int64(s.age().Seconds())
-		for n, q := range s.ResourceList {
-			d = d.Add(wfv1.ResourcesDuration{n: wfv1.NewResourceDuration(time.Duration(q.Value() * age / wfv1.ResourceQuantityDenominator(n).Value() * int64(time.Second)))})
-		}
+		d = d.Add(s.duration(s.age()))
 	}
 	return d
 }
@@ -1,25 +1,147 @@
 package template
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
+	"strings"
 
+	"github.com/antonmedv/expr/ast"
+	"github.com/antonmedv/expr/parser"
 	"github.com/valyala/fasttemplate"
+	"golang.org/x/exp/maps"
+
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
 )
 
 func Validate(s string, validator func(tag string) error) error {
+	return validate(s, validator, validateExpression)
+}
+
+// ValidateStrict validates the template like Validate, and also validates the variables that expressions use with the
+// variable validator, e.g. inputs.parameters.message for {{=inputs.parameters['message']}}
+func ValidateStrict(s string, validator func(tag string) error, variableValidator func(variable string) error) error {
+	return validate(s, validator, func(expression string) error {
+		identifiers, err := parseIdentifiers(expression)
+		if err != nil || identifiers == nil {
+			return err
+		}
+		variables := maps.Keys(identifiers.variables)
+		sort.Strings(variables)
+		for i, variable := range variables {
+			// only validate the whole path, e.g. inputs.parameters.message rather than inputs
+			if i+1 < len(variables) && strings.HasPrefix(variables[i+1], variable+".") {
+				continue
+			}
+			if err := variableValidator(variable); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ValidateSprigFree validates that the expressions of the template do not use Sprig functions, as they do not raise
+// errors
+func ValidateSprigFree(s string) error {
+	return validate(s, func(string) error { return nil }, func(expression string) error {
+		identifiers, err := parseIdentifiers(expression)
+		if err != nil || identifiers == nil {
+			return err
+		}
+		if identifiers.functions["sprig"] {
+			return fmt.Errorf("expression {{=%s}} cannot use Sprig functions", identifiers.expression)
+		}
+		return nil
+	})
+}
+
+func validate(s string, validator func(tag string) error, expressionValidator func(expression string) error) error {
 	t, err := fasttemplate.NewTemplate(s, prefix, suffix)
 	if err != nil {
 		return err
 	}
 	_, err = t.ExecuteFunc(ioutil.Discard, func(w io.Writer, tag string) (int, error) {
-		kind, _ := parseTag(tag)
+		kind, expression := parseTag(tag)
 		switch kind {
 		case kindExpression:
-			return 0, nil // we do not validate expression templates
+			return 0, expressionValidator(expression)
 		default:
 			return 0, validator(tag)
 		}
 	})
 	return err
 }
+
+// validateExpression only validates the syntax of the expression, as the variables it uses are not known until runtime
+func validateExpression(expression string) error {
+	_, err := parseIdentifiers(expression)
+	return err
+}
+
+// identifiers are the variables and functions an expression uses. Variables are paths, e.g. inputs.parameters.message
+// for both inputs.parameters.message and inputs.parameters['message'], as well as their prefixes.
+type identifiers struct {
+	expression string
+	functions  map[string]bool
+	variables  map[string]bool
+}
+
+// parseIdentifiers parses the identifiers of the expression, or returns nil if the expression is not from a
+// JSON-marshaled template
+func parseIdentifiers(expression string) (*identifiers, error) {
+	// The template is JSON-marshaled. This JSON-unmarshals the expression to undo any character escapes.
+	var unmarshalledExpression string
+	if err := json.Unmarshal([]byte(fmt.Sprintf(`"%s"`, expression)), &unmarshalledExpression); err != nil {
+		return nil, nil // not from a JSON-marshaled template, so we cannot tell what the expression is
+	}
+	tree, err := parser.Parse(unmarshalledExpression)
+	if err != nil {
+		// the error also quotes the expression on the following lines, which we already have
+		return nil, fmt.Errorf("invalid expression {{=%s}}: %s", unmarshalledExpression, strings.SplitN(err.Error(), "\n", 2)[0])
+	}
+	v := &identifierVisitor{
+		funcMap:     exprenv.GetFuncMap(nil),
+		identifiers: &identifiers{expression: unmarshalledExpression, functions: map[string]bool{}, variables: map[string]bool{}},
+	}
+	ast.Walk(&tree.Node, v)
+	return v.identifiers, nil
+}
+
+type identifierVisitor struct {
+	funcMap     map[string]interface{}
+	identifiers *identifiers
+}
+
+func (v *identifierVisitor) Enter(*ast.Node) {}
+
+func (v *identifierVisitor) Exit(node *ast.Node) {
+	path, ok := variablePath(*node)
+	if !ok {
+		return
+	}
+	root := strings.SplitN(path, ".", 2)[0]
+	if _, ok := v.funcMap[root]; ok {
+		v.identifiers.functions[root] = true
+	} else {
+		v.identifiers.variables[path] = true
+	}
+}
+
+func variablePath(node ast.Node) (string, bool) {
+	switch n := node.(type) {
+	case *ast.IdentifierNode:
+		return n.Value, true
+	case *ast.PropertyNode:
+		path, ok := variablePath(n.Node)
+		return path + "." + n.Property, ok
+	case *ast.IndexNode:
+		if index, ok := n.Index.(*ast.StringNode); ok {
+			path, ok := variablePath(n.Node)
+			return path + "." + index.Value, ok
+		}
+	}
+	return "", false
+}
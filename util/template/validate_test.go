@@ -24,4 +24,46 @@ func Test_Validate(t *testing.T) {
 		err := Validate("{{=foo}}", func(tag string) error { return fmt.Errorf(tag) })
 		assert.NoError(t, err)
 	})
+	t.Run("EscapedExpression", func(t *testing.T) {
+		err := Validate(`{{=foo == \"bar\"}}`, func(tag string) error { return fmt.Errorf(tag) })
+		assert.NoError(t, err)
+	})
+	t.Run("InvalidExpression", func(t *testing.T) {
+		err := Validate("{{=foo ==}}", func(tag string) error { return nil })
+		assert.ErrorContains(t, err, "invalid expression {{=foo ==}}: unexpected token EOF")
+	})
+}
+
+func Test_ValidateStrict(t *testing.T) {
+	var variables []string
+	validator := func(variable string) error {
+		variables = append(variables, variable)
+		if variable == "typo" {
+			return fmt.Errorf("failed to resolve %s", variable)
+		}
+		return nil
+	}
+	t.Run("Simple", func(t *testing.T) {
+		err := ValidateStrict("{{foo}}", func(tag string) error { return fmt.Errorf(tag) }, validator)
+		assert.EqualError(t, err, "foo")
+	})
+	t.Run("Variables", func(t *testing.T) {
+		variables = nil
+		err := ValidateStrict(`{{=asInt(inputs.parameters['my-param']) + len(filter(steps.a.outputs.result, {# > 1}))}}`, nil, validator)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"inputs.parameters.my-param", "steps.a.outputs.result"}, variables)
+	})
+	t.Run("UndefinedVariable", func(t *testing.T) {
+		err := ValidateStrict("{{=typo}}", nil, validator)
+		assert.EqualError(t, err, "failed to resolve typo")
+	})
+	t.Run("InvalidExpression", func(t *testing.T) {
+		err := ValidateStrict("{{=foo ==}}", nil, validator)
+		assert.ErrorContains(t, err, "invalid expression {{=foo ==}}: unexpected token EOF")
+	})
+}
+
+func Test_ValidateSprigFree(t *testing.T) {
+	assert.NoError(t, ValidateSprigFree("{{sprig}} {{=toJson(sprig_value)}}"))
+	assert.EqualError(t, ValidateSprigFree("{{=sprig.trim(inputs.parameters.message)}}"), "expression {{=sprig.trim(inputs.parameters.message)}} cannot use Sprig functions")
 }
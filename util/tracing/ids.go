@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RootSpanContext returns the span context of the root span of a trace identified by a UUID, e.g. a workflow's UID,
+// or of a random trace if the UUID is not valid
+func RootSpanContext(uuid, key string) trace.SpanContext {
+	var traceID trace.TraceID
+	if b, err := hex.DecodeString(strings.ReplaceAll(uuid, "-", "")); err == nil && len(b) == len(traceID) {
+		copy(traceID[:], b)
+	} else {
+		_, _ = rand.Read(traceID[:])
+	}
+	return ChildSpanContext(trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, TraceFlags: trace.FlagsSampled}), key)
+}
+
+// ChildSpanContext returns the span context of a child span identified by key. The same key always returns the same
+// span ID, so components can refer to a span, e.g. of a node, without the span ID being stored.
+func ChildSpanContext(parent trace.SpanContext, key string) trace.SpanContext {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", parent.TraceID(), parent.SpanID(), key)))
+	var spanID trace.SpanID
+	copy(spanID[:], sum[:])
+	return trace.NewSpanContext(trace.SpanContextConfig{TraceID: parent.TraceID(), SpanID: spanID, TraceFlags: trace.FlagsSampled})
+}
+
+type spanContextKey struct{}
+
+// StartWithSpanContext starts a span with the IDs of the span context, rather than random ones, as a child of the
+// context's span. The tracer's provider must use IDGenerator. Unlike trace.Tracer.Start, it does not return a context,
+// as the spans started from it would have the same IDs.
+func StartWithSpanContext(ctx context.Context, tracer trace.Tracer, sc trace.SpanContext, name string, opts ...trace.SpanStartOption) trace.Span {
+	_, span := tracer.Start(context.WithValue(ctx, spanContextKey{}, sc), name, opts...)
+	return span
+}
+
+type idGenerator struct{}
+
+// IDGenerator returns a generator of random IDs, except for the spans started by StartWithSpanContext
+func IDGenerator() sdktrace.IDGenerator {
+	return idGenerator{}
+}
+
+func (idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if sc, ok := ctx.Value(spanContextKey{}).(trace.SpanContext); ok && sc.IsValid() {
+		return sc.TraceID(), sc.SpanID()
+	}
+	var traceID trace.TraceID
+	_, _ = rand.Read(traceID[:])
+	return traceID, newSpanID()
+}
+
+func (idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	if sc, ok := ctx.Value(spanContextKey{}).(trace.SpanContext); ok && sc.IsValid() && sc.TraceID() == traceID {
+		return sc.SpanID()
+	}
+	return newSpanID()
+}
+
+func newSpanID() trace.SpanID {
+	var spanID trace.SpanID
+	_, _ = rand.Read(spanID[:])
+	return spanID
+}
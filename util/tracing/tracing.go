@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EnvVarEndpoint is the base URL of the OTLP/HTTP collector, spans are exported to `<endpoint>/v1/traces`
+	EnvVarEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// EnvVarTracesEndpoint is the URL spans are exported to, overriding EnvVarEndpoint
+	EnvVarTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+)
+
+// InstrumentationName is the name of the tracer of Argo's spans
+const InstrumentationName = "github.com/argoproj/argo-workflows"
+
+// New returns a tracer provider that exports spans to the OTLP/HTTP collector configured by the standard OpenTelemetry
+// environment variables, or nil if no endpoint is configured. Its spans can have known IDs, see StartWithSpanContext.
+func New(ctx context.Context, serviceName string) (*sdktrace.TracerProvider, error) {
+	if os.Getenv(EnvVarEndpoint) == "" && os.Getenv(EnvVarTracesEndpoint) == "" {
+		return nil, nil
+	}
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the service name
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(serviceName)), resource.WithFromEnv(), resource.WithTelemetrySDK())
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res), sdktrace.WithIDGenerator(IDGenerator())), nil
+}
+
+// Init sets the global propagator to W3C trace context, and the global tracer provider to New's, if spans are exported.
+// It returns the tracer provider, which is nil if spans are not exported.
+func Init(ctx context.Context, serviceName string) *sdktrace.TracerProvider {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tp, err := New(ctx, serviceName)
+	if err != nil {
+		log.WithError(err).Warn("Failed to create the tracer provider, spans are not exported")
+		return nil
+	}
+	if tp == nil {
+		return nil
+	}
+	log.WithField("serviceName", serviceName).Info("Exporting traces")
+	otel.SetTracerProvider(tp)
+	return tp
+}
+
+// Shutdown exports the spans of the tracer provider not yet exported, if any
+func Shutdown(tp *sdktrace.TracerProvider) {
+	if tp == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := tp.Shutdown(ctx); err != nil {
+		log.WithError(err).Warn("Failed to export spans")
+	}
+}
+
+// Tracer returns the tracer of Argo's spans of the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// End ends the span, failed if err is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent formats the span context as a W3C `traceparent`, or returns an empty string if it is not valid
+func TraceParent(sc trace.SpanContext) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	return carrier.Get("traceparent")
+}
+
+// ParseTraceParent parses a W3C `traceparent`, the span context is not valid if it cannot be parsed
+func ParseTraceParent(traceParent string) trace.SpanContext {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceParent})
+	return trace.SpanContextFromContext(ctx)
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceParent = "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+func TestParseTraceParent(t *testing.T) {
+	sc := ParseTraceParent(traceParent)
+	assert.True(t, sc.IsValid())
+	assert.Equal(t, traceParent, TraceParent(sc))
+	for _, s := range []string{"", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331", "00-xyz-b7ad6b7169203331-01", "00-00000000000000000000000000000000-b7ad6b7169203331-01"} {
+		assert.False(t, ParseTraceParent(s).IsValid(), s)
+	}
+	assert.Empty(t, TraceParent(trace.SpanContext{}))
+}
+
+func TestChildSpanContext(t *testing.T) {
+	sc := ParseTraceParent(traceParent)
+	assert.Equal(t, ChildSpanContext(sc, "a"), ChildSpanContext(sc, "a"))
+	assert.NotEqual(t, ChildSpanContext(sc, "a"), ChildSpanContext(sc, "b"))
+	assert.Equal(t, sc.TraceID(), ChildSpanContext(sc, "a").TraceID())
+	assert.True(t, ChildSpanContext(sc, "a").IsValid())
+}
+
+func TestRootSpanContext(t *testing.T) {
+	sc := RootSpanContext("0af76519-16cd-43dd-8448-eb211c80319c", "a")
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", sc.TraceID().String())
+	assert.Equal(t, sc, RootSpanContext("0af76519-16cd-43dd-8448-eb211c80319c", "a"))
+	assert.True(t, RootSpanContext("", "a").IsValid())
+}
+
+func TestStartWithSpanContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithIDGenerator(IDGenerator())).Tracer(InstrumentationName)
+	root := RootSpanContext("0af76519-16cd-43dd-8448-eb211c80319c", "root")
+	child := ChildSpanContext(root, "child")
+
+	StartWithSpanContext(context.Background(), tracer, root, "root").End()
+	End(StartWithSpanContext(trace.ContextWithRemoteSpanContext(context.Background(), root), tracer, child, "child"), errors.New("my-error"))
+	// spans started from the context of the span with known IDs have random IDs
+	ctx, span := tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), child), "random")
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, root.SpanID(), spans[0].SpanContext().SpanID())
+	assert.Equal(t, root.TraceID(), spans[0].SpanContext().TraceID())
+	assert.False(t, spans[0].Parent().IsValid())
+	assert.Equal(t, child.SpanID(), spans[1].SpanContext().SpanID())
+	assert.Equal(t, root.SpanID(), spans[1].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "my-error", spans[1].Status().Description)
+	assert.Equal(t, child.SpanID(), spans[2].Parent().SpanID())
+	assert.NotEqual(t, child.SpanID(), trace.SpanContextFromContext(ctx).SpanID())
+}
+
+type collector struct {
+	mutex    sync.Mutex
+	requests int
+	headers  http.Header
+}
+
+func (c *collector) ServeHTTP(_ http.ResponseWriter, r *http.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if r.URL.Path == "/v1/traces" {
+		c.requests++
+		c.headers = r.Header
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		t.Setenv(EnvVarEndpoint, "")
+		t.Setenv(EnvVarTracesEndpoint, "")
+		tp, err := New(context.Background(), "my-service")
+		assert.NoError(t, err)
+		assert.Nil(t, tp)
+		Shutdown(tp)
+	})
+	t.Run("Export", func(t *testing.T) {
+		c := &collector{}
+		server := httptest.NewServer(c)
+		defer server.Close()
+		t.Setenv(EnvVarEndpoint, server.URL)
+		t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "authorization=Bearer my-token")
+		tp, err := New(context.Background(), "my-service")
+		require.NoError(t, err)
+		require.NotNil(t, tp)
+		_, span := tp.Tracer(InstrumentationName).Start(context.Background(), "my-span")
+		span.End()
+		Shutdown(tp)
+
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		assert.Equal(t, 1, c.requests)
+		assert.Equal(t, "Bearer my-token", c.headers.Get("Authorization"))
+	})
+}
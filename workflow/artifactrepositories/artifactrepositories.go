@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
 	"github.com/argoproj/argo-workflows/v3/util/retry"
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
@@ -27,17 +28,31 @@ type Interface interface {
 	Get(ctx context.Context, ref *wfv1.ArtifactRepositoryRefStatus) (*wfv1.ArtifactRepository, error)
 }
 
-func New(kubernetesInterface kubernetes.Interface, namespace string, defaultArtifactRepository *wfv1.ArtifactRepository) Interface {
-	return &artifactRepositories{kubernetesInterface, namespace, defaultArtifactRepository}
+func New(kubernetesInterface kubernetes.Interface, wfClientset versioned.Interface, namespace string, defaultArtifactRepository *wfv1.ArtifactRepository) Interface {
+	return &artifactRepositories{kubernetesInterface, wfClientset, namespace, defaultArtifactRepository}
 }
 
 type artifactRepositories struct {
 	kubernetesInterface       kubernetes.Interface
+	wfClientset               versioned.Interface
 	namespace                 string
 	defaultArtifactRepository *wfv1.ArtifactRepository
 }
 
 func (s *artifactRepositories) Resolve(ctx context.Context, ref *wfv1.ArtifactRepositoryRef, workflowNamespace string) (*wfv1.ArtifactRepositoryRefStatus, error) {
+	if ref != nil && ref.Name != "" {
+		// a repository referenced by name must exist, there is no fallback
+		r := &wfv1.ArtifactRepositoryRefStatus{ArtifactRepositoryRef: wfv1.ArtifactRepositoryRef{Name: ref.Name, ClusterScope: ref.ClusterScope}}
+		if !ref.ClusterScope {
+			r.Namespace = workflowNamespace
+		}
+		resolvedRef, err := s.get(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf(`error getting artifact repository "%v": %w`, r, err)
+		}
+		log.WithField("artifactRepositoryRef", r).Info("resolved artifact repository")
+		return resolvedRef, nil
+	}
 	var refs []*wfv1.ArtifactRepositoryRefStatus
 	if ref != nil {
 		refs = []*wfv1.ArtifactRepositoryRefStatus{
@@ -84,6 +99,9 @@ func (s *artifactRepositories) get(ctx context.Context, ref *wfv1.ArtifactReposi
 			ArtifactRepository:    s.defaultArtifactRepository,
 		}, nil
 	}
+	if ref.Name != "" {
+		return s.getByName(ctx, ref)
+	}
 	var cm *v1.ConfigMap
 	namespace := ref.Namespace
 	configMap := ref.GetConfigMapOr("artifact-repositories")
@@ -113,3 +131,30 @@ func (s *artifactRepositories) get(ctx context.Context, ref *wfv1.ArtifactReposi
 		ArtifactRepository:    repo,
 	}, nil
 }
+
+// getByName gets a WorkflowArtifactRepository, or a ClusterWorkflowArtifactRepository if the ref is cluster scoped
+func (s *artifactRepositories) getByName(ctx context.Context, ref *wfv1.ArtifactRepositoryRefStatus) (*wfv1.ArtifactRepositoryRefStatus, error) {
+	var repo *wfv1.ArtifactRepository
+	err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		if ref.ClusterScope {
+			r, err := s.wfClientset.ArgoprojV1alpha1().ClusterWorkflowArtifactRepositories().Get(ctx, ref.Name, metav1.GetOptions{})
+			if err == nil {
+				repo = &r.Spec
+			}
+			return !errorsutil.IsTransientErr(err), err
+		}
+		r, err := s.wfClientset.ArgoprojV1alpha1().WorkflowArtifactRepositories(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err == nil {
+			repo = &r.Spec
+		}
+		return !errorsutil.IsTransientErr(err), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &wfv1.ArtifactRepositoryRefStatus{
+		Namespace:             ref.Namespace,
+		ArtifactRepositoryRef: wfv1.ArtifactRepositoryRef{Name: ref.Name, ClusterScope: ref.ClusterScope},
+		ArtifactRepository:    repo,
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wffake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 )
 
 func TestArtifactRepositories(t *testing.T) {
@@ -21,7 +22,8 @@ func TestArtifactRepositories(t *testing.T) {
 		ArtifactRepository: defaultArtifactRepository,
 	}
 	k := kubefake.NewSimpleClientset()
-	i := New(k, "my-ctrl-ns", defaultArtifactRepository)
+	wf := wffake.NewSimpleClientset()
+	i := New(k, wf, "my-ctrl-ns", defaultArtifactRepository)
 	t.Run("Explicit.WorkflowNamespace", func(t *testing.T) {
 		ctx := context.Background()
 		_, err := k.CoreV1().ConfigMaps("my-wf-ns").Create(ctx, &corev1.ConfigMap{
@@ -92,6 +94,50 @@ s3:
 		err = k.CoreV1().ConfigMaps("my-ns").Delete(ctx, "artifact-repositories", metav1.DeleteOptions{})
 		assert.NoError(t, err)
 	})
+	t.Run("Name.WorkflowNamespace", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := wf.ArgoprojV1alpha1().WorkflowArtifactRepositories("my-wf-ns").Create(ctx, &wfv1.WorkflowArtifactRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-repo"},
+			Spec:       wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "bar"}},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		ref, err := i.Resolve(ctx, &wfv1.ArtifactRepositoryRef{Name: "my-repo"}, "my-wf-ns")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-wf-ns", ref.Namespace)
+			assert.Equal(t, "my-repo", ref.Name)
+			assert.False(t, ref.ClusterScope)
+			assert.Equal(t, "my-wf-ns/my-repo", ref.String())
+		}
+		repo, err := i.Get(ctx, &wfv1.ArtifactRepositoryRefStatus{Namespace: "my-wf-ns", ArtifactRepositoryRef: wfv1.ArtifactRepositoryRef{Name: "my-repo"}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "bar"}}, repo)
+		}
+
+		_, err = i.Resolve(ctx, &wfv1.ArtifactRepositoryRef{Name: "my-repo"}, "other-ns")
+		assert.Error(t, err, "there is no fallback to another namespace")
+	})
+	t.Run("Name.ClusterScope", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := wf.ArgoprojV1alpha1().ClusterWorkflowArtifactRepositories().Create(ctx, &wfv1.ClusterWorkflowArtifactRepository{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-cluster-repo"},
+			Spec:       wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "baz"}},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		ref, err := i.Resolve(ctx, &wfv1.ArtifactRepositoryRef{Name: "my-cluster-repo", ClusterScope: true}, "my-wf-ns")
+		if assert.NoError(t, err) {
+			assert.Empty(t, ref.Namespace)
+			assert.True(t, ref.ClusterScope)
+			assert.Equal(t, "my-cluster-repo", ref.String())
+			assert.Equal(t, &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{KeyFormat: "baz"}}, ref.ArtifactRepository)
+		}
+	})
+	t.Run("Name.NotFound", func(t *testing.T) {
+		ctx := context.Background()
+		_, err := i.Resolve(ctx, &wfv1.ArtifactRepositoryRef{Name: "not-found"}, "my-wf-ns")
+		assert.Error(t, err)
+	})
 	t.Run("WorkflowNamespaceDefault", func(t *testing.T) {
 		ctx := context.Background()
 		_, err := k.CoreV1().ConfigMaps("my-wf-ns").Create(ctx, &corev1.ConfigMap{
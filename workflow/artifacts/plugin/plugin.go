@@ -0,0 +1,160 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactplugins "github.com/argoproj/argo-workflows/v3/pkg/plugins/artifact"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	rpc "github.com/argoproj/argo-workflows/v3/workflow/util/plugins"
+)
+
+// exchangeDir is the directory shared with the plugins, that artifacts are copied to and from
+var exchangeDir = common.ArtifactPluginsPath
+
+// ArtifactDriver loads, saves and deletes artifacts by calling an artifact driver plugin running as a sidecar
+type ArtifactDriver struct {
+	Name   string
+	client rpc.Client
+}
+
+var _ artifactscommon.ArtifactDriver = &ArtifactDriver{}
+
+// NewDriver returns the driver of the named plugin, which must be one of the plugins the controller added to the pod
+func NewDriver(name string) (*ArtifactDriver, error) {
+	var names, addresses []string
+	_ = json.Unmarshal([]byte(os.Getenv(common.EnvVarPluginNames)), &names)
+	_ = json.Unmarshal([]byte(os.Getenv(common.EnvVarPluginAddresses)), &addresses)
+	for i, x := range names {
+		if x != name || i >= len(addresses) {
+			continue
+		}
+		token, err := os.ReadFile(filepath.Join(common.VarRunArgoPath, name, "token"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the token of artifact driver plugin %q: %w", name, err)
+		}
+		return newDriver(name, addresses[i], string(token)), nil
+	}
+	return nil, fmt.Errorf("artifact driver plugin %q is not available", name)
+}
+
+func newDriver(name, address, token string) *ArtifactDriver {
+	// artifacts can take any time to load or save, so there is no timeout, but the plugin may still be starting
+	return &ArtifactDriver{Name: name, client: rpc.New(address, token, 0, wait.Backoff{
+		Duration: time.Second,
+		Jitter:   0.2,
+		Factor:   2,
+		Steps:    5,
+	})}
+}
+
+func (d *ArtifactDriver) call(method string, args interface{}, reply interface{}) error {
+	if err := d.client.Call(context.Background(), method, args, reply); err != nil {
+		return fmt.Errorf("artifact driver plugin %q failed to %s: %w", d.Name, method, err)
+	}
+	if !d.client.Implements(method) {
+		return fmt.Errorf("artifact driver plugin %q does not implement %s", d.Name, method)
+	}
+	return nil
+}
+
+// exchangeFile returns the path of a new file in the directory shared with the plugin
+func exchangeFile() string {
+	return filepath.Join(exchangeDir, rand.String(16))
+}
+
+func (d *ArtifactDriver) Load(inputArtifact *wfv1.Artifact, path string) error {
+	file := exchangeFile()
+	defer func() { _ = os.Remove(file) }()
+	reply := &artifactplugins.LoadArtifactReply{}
+	if err := d.call("artifact.load", artifactplugins.LoadArtifactArgs{Artifact: inputArtifact, Path: file}, reply); err != nil {
+		return err
+	}
+	if reply.NotFound {
+		return errors.Errorf(errors.CodeNotFound, "artifact %s not found by artifact driver plugin %q", inputArtifact.Name, d.Name)
+	}
+	return copyFile(file, path)
+}
+
+func (d *ArtifactDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	file := exchangeFile()
+	if err := d.Load(a, file); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		_ = os.Remove(file)
+		return nil, err
+	}
+	return &removeOnClose{File: f}, nil
+}
+
+func (d *ArtifactDriver) Save(path string, outputArtifact *wfv1.Artifact) error {
+	file := exchangeFile()
+	defer func() { _ = os.Remove(file) }()
+	if err := copyFile(path, file); err != nil {
+		return err
+	}
+	return d.call("artifact.save", artifactplugins.SaveArtifactArgs{Path: file, Artifact: outputArtifact}, &artifactplugins.SaveArtifactReply{})
+}
+
+func (d *ArtifactDriver) Delete(artifact *wfv1.Artifact) error {
+	err := d.client.Call(context.Background(), "artifact.delete", artifactplugins.DeleteArtifactArgs{Artifact: artifact}, &artifactplugins.DeleteArtifactReply{})
+	if err != nil {
+		return fmt.Errorf("artifact driver plugin %q failed to artifact.delete: %w", d.Name, err)
+	}
+	if !d.client.Implements("artifact.delete") {
+		return artifactscommon.ErrDeleteNotSupported
+	}
+	return nil
+}
+
+func (d *ArtifactDriver) ListObjects(artifact *wfv1.Artifact) ([]string, error) {
+	reply := &artifactplugins.ListObjectsReply{}
+	err := d.call("artifact.listObjects", artifactplugins.ListObjectsArgs{Artifact: artifact}, reply)
+	return reply.Objects, err
+}
+
+func (d *ArtifactDriver) IsDirectory(artifact *wfv1.Artifact) (bool, error) {
+	reply := &artifactplugins.IsDirectoryReply{}
+	err := d.call("artifact.isDirectory", artifactplugins.IsDirectoryArgs{Artifact: artifact}, reply)
+	return reply.IsDirectory, err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = in.Close() }()
+	out, err := os.Create(filepath.Clean(dst))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// removeOnClose removes a file loaded to be streamed once the stream is closed
+type removeOnClose struct {
+	*os.File
+}
+
+func (r *removeOnClose) Close() error {
+	defer func() { _ = os.Remove(r.Name()) }()
+	return r.File.Close()
+}
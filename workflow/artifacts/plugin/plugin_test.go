@@ -0,0 +1,94 @@
+package plugin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifactplugins "github.com/argoproj/argo-workflows/v3/pkg/plugins/artifact"
+	artifactscommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+func TestArtifactDriver(t *testing.T) {
+	exchangeDir = t.TempDir()
+	stored := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var reply interface{}
+		switch r.URL.Path {
+		case "/api/v1/artifact.save":
+			args := artifactplugins.SaveArtifactArgs{}
+			_ = json.NewDecoder(r.Body).Decode(&args)
+			stored[args.Artifact.Plugin.Key], _ = os.ReadFile(args.Path)
+			reply = artifactplugins.SaveArtifactReply{}
+		case "/api/v1/artifact.load":
+			args := artifactplugins.LoadArtifactArgs{}
+			_ = json.NewDecoder(r.Body).Decode(&args)
+			data, ok := stored[args.Artifact.Plugin.Key]
+			_ = os.WriteFile(args.Path, data, 0o600)
+			reply = artifactplugins.LoadArtifactReply{NotFound: !ok}
+		case "/api/v1/artifact.listObjects":
+			reply = artifactplugins.ListObjectsReply{Objects: []string{"my-key"}}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(reply)
+	}))
+	defer server.Close()
+	driver := newDriver("my-plugin", server.URL, "my-token")
+	art := &wfv1.Artifact{Name: "my-art", ArtifactLocation: wfv1.ArtifactLocation{Plugin: &wfv1.PluginArtifact{Name: "my-plugin", Key: "my-key"}}}
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "src")
+	assert.NoError(t, os.WriteFile(src, []byte("my-data"), 0o600))
+	assert.NoError(t, driver.Save(src, art))
+
+	dst := filepath.Join(dir, "dst")
+	if assert.NoError(t, driver.Load(art, dst)) {
+		data, err := os.ReadFile(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-data", string(data))
+	}
+	stream, err := driver.OpenStream(art)
+	if assert.NoError(t, err) {
+		data, err := io.ReadAll(stream)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-data", string(data))
+		assert.NoError(t, stream.Close())
+	}
+	entries, err := os.ReadDir(exchangeDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "exchanged files are removed")
+
+	objects, err := driver.ListObjects(art)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"my-key"}, objects)
+
+	t.Run("NotFound", func(t *testing.T) {
+		missing := art.DeepCopy()
+		missing.Plugin.Key = "missing"
+		err := driver.Load(missing, dst)
+		assert.True(t, errors.IsCode(errors.CodeNotFound, err))
+	})
+	t.Run("NotImplemented", func(t *testing.T) {
+		_, err := driver.IsDirectory(art)
+		assert.EqualError(t, err, `artifact driver plugin "my-plugin" does not implement artifact.isDirectory`)
+		assert.Equal(t, artifactscommon.ErrDeleteNotSupported, driver.Delete(art))
+	})
+	t.Run("NotAvailable", func(t *testing.T) {
+		_, err := NewDriver("other-plugin")
+		assert.EqualError(t, err, `artifact driver plugin "other-plugin" is not available`)
+	})
+}
@@ -0,0 +1,144 @@
+package throttle
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+)
+
+// driver limits the bandwidth used to load and save artifacts.
+//
+// Artifacts are loaded by streaming them through the limiter, so the limit applies on the wire. Drivers cannot stream
+// uploads or directories, so these are paced instead: the transfer waits until the limiter allows its size, keeping the
+// average rate within the limit.
+type driver struct {
+	common.ArtifactDriver
+	limiter Limiter
+}
+
+// Limiter limits the number of bytes transferred, e.g. a *rate.Limiter
+type Limiter interface {
+	// Burst is the most bytes that can be waited for at once
+	Burst() int
+	// WaitN waits until n bytes are allowed
+	WaitN(ctx context.Context, n int) error
+}
+
+// NewLimiter returns a limiter allowing bytesPerSecond, or nil if bytesPerSecond is not positive.
+// Drivers sharing a limiter share its bandwidth.
+func NewLimiter(bytesPerSecond int64) Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), int(bytesPerSecond))
+}
+
+// New returns a driver limited by limiter, or the driver itself if limiter is nil
+func New(d common.ArtifactDriver, limiter Limiter) common.ArtifactDriver {
+	if limiter == nil {
+		return d
+	}
+	return &driver{d, limiter}
+}
+
+func (d driver) Load(a *wfv1.Artifact, path string) error {
+	isDir, err := d.ArtifactDriver.IsDirectory(a)
+	if err != nil || isDir {
+		return d.paced(path, func() error { return d.ArtifactDriver.Load(a, path) })
+	}
+	rc, err := d.ArtifactDriver.OpenStream(a)
+	if err != nil {
+		log.WithField("artifactName", a.Name).WithError(err).Debug("Cannot stream artifact, pacing load instead")
+		return d.paced(path, func() error { return d.ArtifactDriver.Load(a, path) })
+	}
+	defer func() { _ = rc.Close() }()
+	f, err := os.Create(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, d.reader(rc)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (d driver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	rc, err := d.ArtifactDriver.OpenStream(a)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{d.reader(rc), rc}, nil
+}
+
+func (d driver) Save(path string, a *wfv1.Artifact) error {
+	if err := d.wait(size(path)); err != nil {
+		return err
+	}
+	return d.ArtifactDriver.Save(path, a)
+}
+
+// paced runs the transfer, and then waits until the limiter allows the number of bytes written to path
+func (d driver) paced(path string, transfer func() error) error {
+	if err := transfer(); err != nil {
+		return err
+	}
+	return d.wait(size(path))
+}
+
+func (d driver) reader(r io.Reader) io.Reader {
+	return &reader{r, d}
+}
+
+// wait waits until the limiter allows n bytes, in chunks no larger than the limiter's burst
+func (d driver) wait(n int64) error {
+	for n > 0 {
+		chunk := int64(d.limiter.Burst())
+		if n < chunk {
+			chunk = n
+		}
+		if err := d.limiter.WaitN(context.Background(), int(chunk)); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+type reader struct {
+	io.Reader
+	driver driver
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if burst := r.driver.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := r.Reader.Read(p)
+	if waitErr := r.driver.wait(int64(n)); waitErr != nil {
+		return n, waitErr
+	}
+	return n, err
+}
+
+// size returns the total size of the files at path
+func size(path string) int64 {
+	var total int64
+	_ = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
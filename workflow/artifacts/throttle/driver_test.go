@@ -0,0 +1,132 @@
+package throttle
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/raw"
+)
+
+type streamingDriver struct {
+	raw.ArtifactDriver
+	saved string
+}
+
+func (d *streamingDriver) OpenStream(a *wfv1.Artifact) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(a.Raw.Data)), nil
+}
+
+func (d *streamingDriver) IsDirectory(*wfv1.Artifact) (bool, error) {
+	return false, nil
+}
+
+func (d *streamingDriver) Save(path string, _ *wfv1.Artifact) error {
+	d.saved = path
+	return nil
+}
+
+var _ common.ArtifactDriver = &streamingDriver{}
+
+// countingLimiter records the bytes waited for, rather than waiting
+type countingLimiter struct {
+	burst  int
+	waited []int
+}
+
+func (l *countingLimiter) Burst() int {
+	return l.burst
+}
+
+func (l *countingLimiter) WaitN(_ context.Context, n int) error {
+	l.waited = append(l.waited, n)
+	return nil
+}
+
+// total returns the bytes waited for, and checks none of the waits exceeded the burst
+func (l *countingLimiter) total(t *testing.T) int {
+	total := 0
+	for _, n := range l.waited {
+		assert.LessOrEqual(t, n, l.burst)
+		total += n
+	}
+	return total
+}
+
+func TestNew(t *testing.T) {
+	d := &raw.ArtifactDriver{}
+	assert.Equal(t, d, New(d, NewLimiter(0)))
+	assert.IsType(t, &driver{}, New(d, NewLimiter(1)))
+}
+
+func TestDriver(t *testing.T) {
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: strings.Repeat("x", 300)}}}
+	dir, err := ioutil.TempDir("", "throttle")
+	assert.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	t.Run("OpenStream", func(t *testing.T) {
+		limiter := &countingLimiter{burst: 100}
+		d := New(&streamingDriver{}, limiter)
+		rc, err := d.OpenStream(art)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+		assert.Len(t, data, 300)
+		assert.Equal(t, 300, limiter.total(t))
+	})
+	t.Run("LoadStream", func(t *testing.T) {
+		limiter := &countingLimiter{burst: 100}
+		d := New(&streamingDriver{}, limiter)
+		path := filepath.Join(dir, "stream")
+		assert.NoError(t, d.Load(art, path))
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Len(t, data, 300)
+		assert.Equal(t, 300, limiter.total(t))
+	})
+	t.Run("LoadPaced", func(t *testing.T) {
+		limiter := &countingLimiter{burst: 100}
+		d := New(&raw.ArtifactDriver{}, limiter)
+		path := filepath.Join(dir, "paced")
+		assert.NoError(t, d.Load(art, path))
+		data, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Len(t, data, 300)
+		assert.Equal(t, []int{100, 100, 100}, limiter.waited)
+	})
+	t.Run("Save", func(t *testing.T) {
+		limiter := &countingLimiter{burst: 100}
+		s := &streamingDriver{}
+		d := New(s, limiter)
+		path := filepath.Join(dir, "stream")
+		assert.NoError(t, d.Save(path, art))
+		assert.Equal(t, path, s.saved)
+		assert.Equal(t, []int{100, 100, 100}, limiter.waited)
+	})
+}
+
+func TestSharedLimiter(t *testing.T) {
+	art := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: strings.Repeat("x", 100)}}}
+	limiter := &countingLimiter{burst: 100}
+	a := New(&streamingDriver{}, limiter)
+	b := New(&streamingDriver{}, limiter)
+	for _, d := range []common.ArtifactDriver{a, b, a} {
+		rc, err := d.OpenStream(art)
+		assert.NoError(t, err)
+		_, err = ioutil.ReadAll(rc)
+		assert.NoError(t, err)
+		assert.NoError(t, rc.Close())
+	}
+	// the drivers share the bandwidth, so they all wait for the same limiter
+	assert.Equal(t, 300, limiter.total(t))
+}
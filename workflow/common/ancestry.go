@@ -27,6 +27,7 @@ const (
 	TaskResultDaemoned     TaskResult = "Daemoned"
 	TaskResultAnySucceeded TaskResult = "AnySucceeded"
 	TaskResultAllFailed    TaskResult = "AllFailed"
+	TaskResultStreaming    TaskResult = "Streaming"
 )
 
 var (
@@ -64,6 +65,10 @@ func GetTaskDependencies(task *wfv1.DAGTask, ctx DagContext) (map[string]Depende
 				dependencies[split[0]] = DependencyTypeTask
 			}
 		} else if matchGroup[4] != -1 {
+			// a name followed by arguments is a call of an expression function, rather than a task
+			if strings.HasPrefix(strings.TrimLeft(depends[matchGroup[5]:], " "), "(") {
+				continue
+			}
 			match := depends[matchGroup[4]:matchGroup[5]]
 			dependencies[match] = DependencyTypeTask
 			expansionMatches = append(expansionMatches, expansionMatch{taskName: match, start: matchGroup[4], end: matchGroup[5]})
@@ -97,7 +102,7 @@ func ValidateTaskResults(dagTask *wfv1.DAGTask) error {
 		split := strings.Split(matchGroup[1], ".")
 		taskName, taskResult := split[0], TaskResult(split[1])
 		switch taskResult {
-		case TaskResultSucceeded, TaskResultFailed, TaskResultSkipped, TaskResultOmitted, TaskResultErrored, TaskResultDaemoned, TaskResultAnySucceeded, TaskResultAllFailed:
+		case TaskResultSucceeded, TaskResultFailed, TaskResultSkipped, TaskResultOmitted, TaskResultErrored, TaskResultDaemoned, TaskResultAnySucceeded, TaskResultAllFailed, TaskResultStreaming:
 			// Do nothing
 		default:
 			return fmt.Errorf("task result '%s' for task '%s' is invalid", taskResult, taskName)
@@ -106,6 +111,16 @@ func ValidateTaskResults(dagTask *wfv1.DAGTask) error {
 	return nil
 }
 
+// DependsOnTaskResult returns whether the depends logic refers to the given result of the given task
+func DependsOnTaskResult(depends string, taskName string, result TaskResult) bool {
+	for _, match := range taskResultRegex.FindAllString(depends, -1) {
+		if match == taskName+"."+string(result) {
+			return true
+		}
+	}
+	return false
+}
+
 func getTaskDependsLogic(dagTask *wfv1.DAGTask, ctx DagContext) string {
 	if dagTask.Depends != "" {
 		return dagTask.Depends
@@ -60,6 +60,11 @@ func TestGetTaskDependenciesFromDepends(t *testing.T) {
 	assert.Equal(t, map[string]DependencyType{"task-1": DependencyTypeItems}, deps)
 	assert.Equal(t, "task-1.Succeeded && task-1.AnySucceeded", logic)
 
+	task = &wfv1.DAGTask{Depends: "either (task-1, task-2.Failed)"}
+	deps, logic = GetTaskDependencies(task, ctx)
+	assert.Equal(t, map[string]DependencyType{"task-1": DependencyTypeTask, "task-2": DependencyTypeTask}, deps)
+	assert.Equal(t, "either ((task-1.Succeeded || task-1.Skipped || task-1.Daemoned), task-2.Failed)", logic)
+
 	ctx.testTasks[0].ContinueOn = &wfv1.ContinueOn{Failed: true}
 	task = &wfv1.DAGTask{Depends: "task-1"}
 	deps, logic = GetTaskDependencies(task, ctx)
@@ -92,11 +97,22 @@ func TestValidateTaskResults(t *testing.T) {
 	err = ValidateTaskResults(task)
 	assert.NoError(t, err)
 
+	task = &wfv1.DAGTask{Depends: "task-1.Streaming || task-1.Succeeded"}
+	err = ValidateTaskResults(task)
+	assert.NoError(t, err)
+
 	task = &wfv1.DAGTask{Depends: "(task-1.DoeNotExist || task-2.Succeeded)"}
 	err = ValidateTaskResults(task)
 	assert.Error(t, err, "task result 'DoeNotExist' for task 'task-1' is invalid")
 }
 
+func TestDependsOnTaskResult(t *testing.T) {
+	assert.True(t, DependsOnTaskResult("task-1.Streaming && task-2", "task-1", TaskResultStreaming))
+	assert.False(t, DependsOnTaskResult("task-1.Streaming && task-2", "task-2", TaskResultStreaming))
+	assert.False(t, DependsOnTaskResult("my-task-1.Streaming", "task-1", TaskResultStreaming))
+	assert.False(t, DependsOnTaskResult("task-1.Succeeded", "task-1", TaskResultStreaming))
+}
+
 func TestGetTaskDependsLogic(t *testing.T) {
 	testTasks := []*wfv1.DAGTask{
 		{
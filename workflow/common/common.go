@@ -30,6 +30,13 @@ const (
 	AnnotationKeyRBACRule           = workflow.WorkflowFullName + "/rbac-rule"
 	AnnotationKeyRBACRulePrecedence = workflow.WorkflowFullName + "/rbac-rule-precedence"
 
+	// AnnotationKeyCluster is the name of the cluster a workflow is in, set on workflows served by a federated Argo Server
+	AnnotationKeyCluster = workflow.WorkflowFullName + "/cluster"
+
+	// AnnotationKeyReleaseLock is the name of a semaphore or mutex the workflow is forced to release, it is removed once
+	// the controller has released it
+	AnnotationKeyReleaseLock = workflow.WorkflowFullName + "/release-lock"
+
 	// AnnotationKeyOutputs is the pod metadata annotation key containing the container outputs
 	AnnotationKeyOutputs = workflow.WorkflowFullName + "/outputs"
 	// AnnotationKeyCronWfScheduledTime is the workflow metadata annotation key containing the time when the workflow
@@ -41,9 +48,16 @@ const (
 	// AnnotationKeyWorkflowUID is the uid of the workflow
 	AnnotationKeyWorkflowUID = workflow.WorkflowFullName + "/workflow-uid"
 
+	// AnnotationKeyTraceParent is the W3C traceparent of the span the workflow's span is a child of, e.g. the span of
+	// the request that submitted the workflow
+	AnnotationKeyTraceParent = workflow.WorkflowFullName + "/traceparent"
+
 	// AnnotationKeyPodNameVersion stores the pod naming convention version
 	AnnotationKeyPodNameVersion = workflow.WorkflowFullName + "/pod-name-format"
 
+	// AnnotationKeySafeToEvict is the annotation the cluster autoscaler checks before evicting a pod to scale down its node
+	AnnotationKeySafeToEvict = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
 	// AnnotationKeyProgress is N/M progress for the node
 	AnnotationKeyProgress = workflow.WorkflowFullName + "/progress"
 
@@ -62,6 +76,11 @@ const (
 	// Workflows and pods with a completed=true label will be ignored by the controller.
 	// See also `LabelKeyWorkflowArchivingStatus`.
 	LabelKeyCompleted = workflow.WorkflowFullName + "/completed"
+	// LabelKeyCluster is the metadata label applied on workflow pods that run on another cluster, the name of the cluster
+	LabelKeyCluster = workflow.WorkflowFullName + "/cluster"
+	// LabelKeyDeleteAfter is the metadata label applied on completed workflow pods kept by pod GC, the Unix time after
+	// which they are deleted
+	LabelKeyDeleteAfter = workflow.WorkflowFullName + "/delete-after"
 	// LabelKeyWorkflowArchivingStatus indicates if a workflow needs archiving or not:
 	// * `` - does not need archiving ... yet
 	// * `Pending` - pending archiving
@@ -79,16 +98,26 @@ const (
 	LabelKeyPreviousWorkflowName = workflow.WorkflowFullName + "/resubmitted-from-workflow"
 	// LabelKeyCronWorkflow is a label applied to Workflows that are started by a CronWorkflow
 	LabelKeyCronWorkflow = workflow.WorkflowFullName + "/cron-workflow"
+	// LabelKeyCronWorkflowBackfill is a label applied to Workflows that are submitted by `argo cron backfill`, naming the backfill they are part of
+	LabelKeyCronWorkflowBackfill = workflow.WorkflowFullName + "/cron-workflow-backfill"
 	// LabelKeyWorkflowTemplate is a label applied to Workflows that are submitted from Workflowtemplate
 	LabelKeyWorkflowTemplate = workflow.WorkflowFullName + "/workflow-template"
 	// LabelKeyWorkflowEventBinding is a label applied to Workflows that are submitted from a WorkflowEventBinding
 	LabelKeyWorkflowEventBinding = workflow.WorkflowFullName + "/workflow-event-binding"
 	// LabelKeyWorkflowTemplate is a label applied to Workflows that are submitted from ClusterWorkflowtemplate
 	LabelKeyClusterWorkflowTemplate = workflow.WorkflowFullName + "/cluster-workflow-template"
+	// LabelKeyTemplateRevision is a label applied to the config maps of WorkflowTemplate and ClusterWorkflowTemplate revisions, with the revision
+	LabelKeyTemplateRevision = workflow.WorkflowFullName + "/template-revision"
 	// LabelKeyOnExit is a label applied to Pods that are run from onExit nodes, so that they are not shut down when stopping a Workflow
 	LabelKeyOnExit = workflow.WorkflowFullName + "/on-exit"
 	// LabelKeyArtifactGCPodHash is a label applied to WorkflowTaskSets used by the Artifact Garbage Collection Pod
 	LabelKeyArtifactGCPodHash = workflow.WorkflowFullName + "/artifact-gc-pod"
+	// LabelKeyIdempotencyKey is a label applied to Workflows to identify submissions of the same workflow, so that
+	// retried submissions return the existing workflow rather than creating a duplicate
+	LabelKeyIdempotencyKey = workflow.WorkflowFullName + "/idempotency-key"
+	// LabelKeyPodDisruptionBudget is a label applied to the Pods of templates with a PodDisruptionBudget, and to the
+	// PodDisruptionBudget, with the name of the template
+	LabelKeyPodDisruptionBudget = workflow.WorkflowFullName + "/pod-disruption-budget"
 
 	// ExecutorArtifactBaseDir is the base directory in the init container in which artifacts will be copied to.
 	// Each artifact will be named according to its input name (e.g: /argo/inputs/artifacts/CODE)
@@ -100,6 +129,10 @@ const (
 	// as well as artifact collection by the wait container.
 	ExecutorMainFilesystemDir = "/mainctrfs"
 
+	// PipeArtifactDoneFile is the file the wait container writes into the directory of a pipe artifact once the main
+	// container has exited, so that the tasks reading it know it is complete
+	PipeArtifactDoneFile = ".argo-done"
+
 	// ExecutorStagingEmptyDir is the path of the emptydir which is used as a staging area to transfer a file between init/main container for script/resource templates
 	ExecutorStagingEmptyDir = "/argo/staging"
 	// ExecutorScriptSourcePath is the path which init will write the script source file to for script templates
@@ -135,6 +168,12 @@ const (
 	EnvVarIncludeScriptOutput = "ARGO_INCLUDE_SCRIPT_OUTPUT"
 	// EnvVarTemplate is the template
 	EnvVarTemplate = "ARGO_TEMPLATE"
+	// EnvVarTraceParent is the W3C traceparent of the span of the pod's node, the executor's spans are its children
+	EnvVarTraceParent = "ARGO_TRACEPARENT"
+
+	// HeaderDiagnosticsToken is the header of requests to the controller's diagnostics endpoints that must contain
+	// the controller's ARGO_DIAGNOSTICS_TOKEN
+	HeaderDiagnosticsToken = "X-Argo-Diagnostics-Token"
 	// EnvVarArgoTrace is used enable tracing statements in Argo components
 	EnvVarArgoTrace = "ARGO_TRACE"
 	// EnvVarProgressPatchTickDuration sets the tick duration for patching pod annotations upon progress changes.
@@ -147,6 +186,15 @@ const (
 	EnvVarProgressFile = "ARGO_PROGRESS_FILE"
 	// EnvVarDefaultRequeueTime is the default requeue time for Workflow Informers. For more info, see rate_limiters.go
 	EnvVarDefaultRequeueTime = "DEFAULT_REQUEUE_TIME"
+	// EnvVarArtifactMaxBytesPerSec limits the bandwidth used by the executor to load and save artifacts
+	EnvVarArtifactMaxBytesPerSec = "ARGO_ARTIFACT_MAX_BYTES_PER_SEC"
+	// EnvVarArtifactRepositoryMaxBytesPerSec limits the bandwidth used by the executor to load and save the artifacts in the
+	// artifact repository of the workflow
+	EnvVarArtifactRepositoryMaxBytesPerSec = "ARGO_ARTIFACT_REPOSITORY_MAX_BYTES_PER_SEC"
+	// EnvVarArtifactSaveParallelism is the number of output artifacts the wait container saves concurrently
+	EnvVarArtifactSaveParallelism = "ARGO_ARTIFACT_SAVE_PARALLELISM"
+	// EnvVarServiceMeshShutdownURL is the URL the wait container POSTs to, to shut down the service mesh proxy
+	EnvVarServiceMeshShutdownURL = "ARGO_SERVICE_MESH_SHUTDOWN_URL"
 	// EnvAgentTaskWorkers is the number of task workers for the agent pod
 	EnvAgentTaskWorkers = "ARGO_AGENT_TASK_WORKERS"
 	// EnvAgentPatchRate is the rate that the Argo Agent will patch the Workflow TaskSet
@@ -173,6 +221,8 @@ const (
 	GlobalVarWorkflowPriority = "workflow.priority"
 	// GlobalVarWorkflowFailures is a global variable of a JSON map referencing the workflow's failed nodes
 	GlobalVarWorkflowFailures = "workflow.failures"
+	// GlobalVarWorkflowFailedNodes is a global variable of the JSON list of the workflow's failed nodes, e.g. for withParam
+	GlobalVarWorkflowFailedNodes = "workflow.failedNodes"
 	// GlobalVarWorkflowDuration is the current duration of this workflow
 	GlobalVarWorkflowDuration = "workflow.duration"
 	// GlobalVarWorkflowAnnotations is a JSON string containing all workflow annotations
@@ -184,14 +234,37 @@ const (
 	// GlobalVarWorkflowCronScheduleTime is the scheduled timestamp of a Workflow started by a CronWorkflow
 	GlobalVarWorkflowCronScheduleTime = "workflow.scheduledTime"
 
+	// CronWorkflowVarName is the name of the CronWorkflow, only available in the arguments of its workflowSpec
+	CronWorkflowVarName = "cronworkflow.name"
+	// CronWorkflowVarNamespace is the namespace of the CronWorkflow, only available in the arguments of its workflowSpec
+	CronWorkflowVarNamespace = "cronworkflow.namespace"
+	// CronWorkflowVarScheduledTime is the scheduled time of the run, in the CronWorkflow's timezone, formatted in RFC 3339
+	CronWorkflowVarScheduledTime = "cronworkflow.scheduledTime"
+	// CronWorkflowVarLastScheduledTime is the scheduled time of the previous run, formatted in RFC 3339, or empty
+	CronWorkflowVarLastScheduledTime = "cronworkflow.lastScheduledTime"
+	// CronWorkflowVarScheduleIndex is the index of the schedule that was due, of `schedule` followed by `schedules`
+	CronWorkflowVarScheduleIndex = "cronworkflow.scheduleIndex"
+	// CronWorkflowVarSchedule is the cron expression of the schedule that was due
+	CronWorkflowVarSchedule = "cronworkflow.schedule"
+
 	// LabelKeyConfigMapType is the label key for the type of configmap.
 	LabelKeyConfigMapType = "workflows.argoproj.io/configmap-type"
 	// LabelValueTypeConfigMapCache is a key for configmaps that are memoization cache.
 	LabelValueTypeConfigMapCache = "Cache"
 	// LabelValueTypeConfigMapParameter is a key for configmaps that contains parameter values.
 	LabelValueTypeConfigMapParameter = "Parameter"
+	// LabelValueTypeConfigMapOffloadedParameters is a key for configmaps that contain the values of the parameters of a node that were too large for the workflow status.
+	LabelValueTypeConfigMapOffloadedParameters = "OffloadedParameters"
 	// LabelValueTypeConfigMapExecutorPlugin is a key for configmaps that contains an executor plugin.
 	LabelValueTypeConfigMapExecutorPlugin = "ExecutorPlugin"
+	// LabelValueTypeConfigMapArtifactDriverPlugin is a key for configmaps that contains an artifact driver plugin.
+	LabelValueTypeConfigMapArtifactDriverPlugin = "ArtifactDriverPlugin"
+	// LabelValueTypeConfigMapTemplateRevision is a key for configmaps that contain a revision of a WorkflowTemplate or ClusterWorkflowTemplate.
+	LabelValueTypeConfigMapTemplateRevision = "TemplateRevision"
+	// LabelValueTypeConfigMapWorkflowDefaults is a key for configmaps that contain the workflow defaults of their namespace.
+	LabelValueTypeConfigMapWorkflowDefaults = "WorkflowDefaults"
+	// ConfigMapKeyWorkflowDefaults is the key of the workflow defaults in a workflow defaults configmap.
+	ConfigMapKeyWorkflowDefaults = "workflowDefaults"
 
 	// LocalVarPodName is a step level variable that references the name of the pod
 	LocalVarPodName = "pod.name"
@@ -203,6 +276,8 @@ const (
 	LocalVarStatus = "status"
 	// LocalVarResourcesDuration is a step level variable (currently only available in metric emission) that tracks the resources duration of the step
 	LocalVarResourcesDuration = "resourcesDuration"
+	// LocalVarCost is a step level variable (currently only available in metric emission) that tracks the cost of the step
+	LocalVarCost = "cost"
 	// LocalVarExitCode is a step level variable (currently only available in metric emission) that tracks the step's exit code
 	LocalVarExitCode = "exitCode"
 
@@ -228,6 +303,17 @@ const (
 	// ArgoProgressPath defines the path to a file used for self reporting progress
 	ArgoProgressPath = VarRunArgoPath + "/progress"
 
+	// ArtifactPluginsVolumeName is the name of the volume shared with artifact driver plugins to exchange artifacts
+	ArtifactPluginsVolumeName = "artifact-plugins"
+	// ArtifactPluginsPath is the path of the volume shared with artifact driver plugins
+	ArtifactPluginsPath = "/argo/artifact-plugins"
+	// PluginInputArtifactsPath is the path the wait container loads the input artifacts stored by artifact driver
+	// plugins into, as the plugins only run once the init container has completed
+	PluginInputArtifactsPath = VarRunArgoPath + "/inputs/artifacts"
+	// PluginInputArtifactsLoadedPath is the path of the file the wait container writes once it has loaded the input
+	// artifacts stored by artifact driver plugins, containing the error if they failed to load
+	PluginInputArtifactsLoadedPath = VarRunArgoPath + "/inputs/loaded"
+
 	// ErrDeadlineExceeded is the pod status reason when exceed deadline
 	ErrDeadlineExceeded = "DeadlineExceeded"
 
@@ -3,10 +3,13 @@ package common
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os/exec"
+	"path"
 	"runtime"
 	"sort"
 	"strings"
@@ -46,6 +49,35 @@ func FindOverlappingVolume(tmpl *wfv1.Template, path string) *apiv1.VolumeMount
 	return nil
 }
 
+// PipeArtifactSubPath returns the path of a pipe artifact within the volume it is written to, which must be mounted
+// by the template's container at a path that contains the artifact's path
+func PipeArtifactSubPath(tmpl *wfv1.Template, art wfv1.Artifact) (string, error) {
+	for _, mnt := range tmpl.GetVolumeMounts() {
+		if mnt.Name != art.Pipe.Volume {
+			continue
+		}
+		normalizedMountPath := strings.TrimRight(mnt.MountPath, "/")
+		if art.Path == normalizedMountPath || isSubPath(art.Path, normalizedMountPath) {
+			return strings.TrimPrefix(path.Join(mnt.SubPath, strings.TrimPrefix(art.Path, normalizedMountPath)), "/"), nil
+		}
+	}
+	return "", errors.Errorf(errors.CodeBadRequest, "path '%s' is not within a mount of volume '%s'", art.Path, art.Pipe.Volume)
+}
+
+// IsGlob returns whether the path is a glob pattern, matching many files, rather than the path of a single file
+func IsGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// IsPluginArtifact returns whether an artifact is stored by an artifact driver plugin, either in its own location or,
+// when it only has a key, in the archive location it is relocated to
+func IsPluginArtifact(art wfv1.Artifact, archiveLocation *wfv1.ArtifactLocation) bool {
+	if art.Plugin != nil {
+		return true
+	}
+	return art.HasKey() && !art.HasLocation() && archiveLocation != nil && archiveLocation.Plugin != nil
+}
+
 func isSubPath(path string, normalizedMountPath string) bool {
 	return strings.HasPrefix(path, normalizedMountPath+"/")
 }
@@ -175,12 +207,12 @@ func ProcessArgs(tmpl *wfv1.Template, args wfv1.ArgumentsProvider, globalParams,
 
 		argArt := args.GetArtifactByName(inArt.Name)
 
-		if !inArt.Optional && !inArt.HasLocationOrKey() {
+		if !inArt.Optional && !inArt.HasLocationOrKey() && inArt.FromWorkflow == nil {
 			// artifact must be supplied
 			if argArt == nil {
 				return nil, errors.Errorf(errors.CodeBadRequest, "inputs.artifacts.%s was not supplied", inArt.Name)
 			}
-			if (argArt.From == "" || argArt.FromExpression == "") && !argArt.HasLocationOrKey() && !validateOnly {
+			if (argArt.From == "" || argArt.FromExpression == "") && !argArt.HasLocationOrKey() && argArt.Pipe == nil && argArt.FromWorkflow == nil && !validateOnly {
 				return nil, errors.Errorf(errors.CodeBadRequest, "inputs.artifacts.%s missing location information", inArt.Name)
 			}
 		}
@@ -195,6 +227,19 @@ func ProcessArgs(tmpl *wfv1.Template, args wfv1.ArgumentsProvider, globalParams,
 	return SubstituteParams(newTmpl, globalParams, localParams)
 }
 
+// getArtifactDigest returns the digest of the artifact set by the executor that saved it, or computed from the data of
+// a raw artifact
+func getArtifactDigest(art wfv1.Artifact) string {
+	if art.Digest != "" {
+		return art.Digest
+	}
+	if art.Raw != nil {
+		sum := sha256.Sum256([]byte(art.Raw.Data))
+		return hex.EncodeToString(sum[:])
+	}
+	return ""
+}
+
 // substituteConfigMapKeyRefParams check if ConfigMapKeyRef's key is a param and perform the substitution.
 func substituteConfigMapKeyRefParam(in string, globalParams Parameters) (string, error) {
 	if strings.HasPrefix(in, "{{") && strings.HasSuffix(in, "}}") {
@@ -248,6 +293,9 @@ func SubstituteParams(tmpl *wfv1.Template, globalParams, localParams Parameters)
 		if inArt.Path != "" {
 			replaceMap["inputs.artifacts."+inArt.Name+".path"] = inArt.Path
 		}
+		if digest := getArtifactDigest(inArt); digest != "" {
+			replaceMap["inputs.artifacts."+inArt.Name+".digest"] = digest
+		}
 	}
 	for _, outArt := range globalReplacedTmpl.Outputs.Artifacts {
 		if outArt.Path != "" {
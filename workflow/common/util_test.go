@@ -140,6 +140,45 @@ func TestFindOverlappingVolume(t *testing.T) {
 	assert.Nil(t, FindOverlappingVolume(templateWithVolMount, "/user-mount-coincidental-prefix/"))
 }
 
+func TestPipeArtifactSubPath(t *testing.T) {
+	tmpl := &wfv1.Template{
+		Container: &corev1.Container{
+			VolumeMounts: []corev1.VolumeMount{
+				{Name: "workdir", MountPath: "/work/"},
+				{Name: "data", MountPath: "/data", SubPath: "pipes"},
+			},
+		},
+	}
+	pipe := func(volume, path string) wfv1.Artifact {
+		return wfv1.Artifact{Name: "out", Path: path, Pipe: &wfv1.PipeArtifact{Volume: volume}}
+	}
+
+	subPath, err := PipeArtifactSubPath(tmpl, pipe("workdir", "/work/out"))
+	assert.NoError(t, err)
+	assert.Equal(t, "out", subPath)
+
+	subPath, err = PipeArtifactSubPath(tmpl, pipe("data", "/data/a/out"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pipes/a/out", subPath)
+
+	subPath, err = PipeArtifactSubPath(tmpl, pipe("data", "/data"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pipes", subPath)
+
+	_, err = PipeArtifactSubPath(tmpl, pipe("workdir", "/data/out"))
+	assert.EqualError(t, err, "path '/data/out' is not within a mount of volume 'workdir'")
+
+	_, err = PipeArtifactSubPath(tmpl, pipe("missing", "/work/out"))
+	assert.Error(t, err)
+}
+
+func TestIsGlob(t *testing.T) {
+	assert.False(t, IsGlob("/tmp/result.json"))
+	assert.True(t, IsGlob("/tmp/*.json"))
+	assert.True(t, IsGlob("/tmp/result-?.json"))
+	assert.True(t, IsGlob("/tmp/result-[0-9].json"))
+}
+
 func TestUnknownFieldEnforcerForWorkflowStep(t *testing.T) {
 	_, err := SplitWorkflowYAMLFile([]byte(validWf), false)
 	assert.NoError(t, err)
@@ -290,3 +329,34 @@ func TestOverridableDefaultInputArts(t *testing.T) {
 	assert.NotNil(t, newTmpl)
 	assert.Equal(t, newTmpl.Inputs.Artifacts[0].Raw.Data, inputRawArt.Data)
 }
+
+func TestInputArtifactDigest(t *testing.T) {
+	tmpl := &wfv1.Template{
+		Name: "memoized",
+		Inputs: wfv1.Inputs{
+			Artifacts: []wfv1.Artifact{{Name: "data"}, {Name: "raw"}},
+		},
+		Memoize: &wfv1.Memoize{Key: "{{inputs.artifacts.data.digest}}-{{inputs.artifacts.raw.digest}}"},
+	}
+	args := &wfv1.Inputs{
+		Artifacts: []wfv1.Artifact{
+			{Name: "data", ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "data.tgz"}}, Digest: "abc123"},
+			{Name: "raw", ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: "hello"}}},
+		},
+	}
+	newTmpl, err := ProcessArgs(tmpl, args, map[string]string{}, map[string]string{}, false, "", nil)
+	if assert.NoError(t, err) {
+		// sha256 of "hello"
+		assert.Equal(t, "abc123-2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", newTmpl.Memoize.Key)
+	}
+}
+
+func TestIsPluginArtifact(t *testing.T) {
+	pluginLocation := &wfv1.ArtifactLocation{Plugin: &wfv1.PluginArtifact{Name: "my-plugin", Key: "my-key"}}
+	assert.True(t, IsPluginArtifact(wfv1.Artifact{ArtifactLocation: *pluginLocation}, nil))
+	keyOnly := wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+	assert.True(t, IsPluginArtifact(keyOnly, pluginLocation))
+	assert.False(t, IsPluginArtifact(keyOnly, nil))
+	assert.False(t, IsPluginArtifact(wfv1.Artifact{}, pluginLocation), "not supplied")
+	assert.False(t, IsPluginArtifact(wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: "my-data"}}}, pluginLocation))
+}
@@ -241,6 +241,7 @@ func (woc *wfOperationCtx) createAgentPod(ctx context.Context) (*apiv1.Pod, erro
 	if woc.controller.Config.InstanceID != "" {
 		pod.ObjectMeta.Labels[common.LabelKeyControllerInstanceID] = woc.controller.Config.InstanceID
 	}
+	woc.controller.Config.MetadataPropagation.Propagate(&woc.wf.ObjectMeta, &pod.ObjectMeta)
 
 	log.Debug("Creating Agent pod")
 
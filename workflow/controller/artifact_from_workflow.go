@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// resolveFromWorkflowArtifacts sets the location of the template's input artifacts that are taken from another workflow
+func (woc *wfOperationCtx) resolveFromWorkflowArtifacts(tmpl *wfv1.Template, node *wfv1.NodeStatus) error {
+	for i, art := range tmpl.Inputs.Artifacts {
+		if art.FromWorkflow == nil || art.HasLocationOrKey() {
+			continue
+		}
+		// once the node exists, keep the artifact it was created with, so that all its children and retries use the
+		// same one, even if another selected workflow has since succeeded
+		var resolved *wfv1.Artifact
+		if node != nil {
+			resolved = node.Inputs.GetArtifactByName(art.Name)
+		}
+		if resolved == nil || !resolved.HasLocationOrKey() {
+			var err error
+			resolved, err = woc.controller.fromWorkflowArtifact(woc.wf.Namespace, art.FromWorkflow)
+			if err != nil {
+				if art.Optional {
+					woc.log.WithError(err).Warnf("Optional artifact '%s' was not found; it won't be available as an input", art.Name)
+					continue
+				}
+				return fmt.Errorf("unable to resolve inputs.artifacts.%s.fromWorkflow: %w", art.Name, err)
+			}
+		}
+		tmpl.Inputs.Artifacts[i].ArtifactLocation = resolved.ArtifactLocation
+		tmpl.Inputs.Artifacts[i].Digest = resolved.Digest
+	}
+	return nil
+}
+
+// fromWorkflowArtifact returns the output artifact of the workflow the strategy picks from those the selector selects
+func (wfc *WorkflowController) fromWorkflowArtifact(namespace string, from *wfv1.ArtifactFromWorkflow) (*wfv1.Artifact, error) {
+	selector, err := metav1.LabelSelectorAsSelector(from.Selector)
+	if err != nil {
+		return nil, err
+	}
+	// "Latest" is the only strategy
+	wf, err := wfc.latestSucceededWorkflow(namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	if wf == nil {
+		return nil, fmt.Errorf("no succeeded workflow matches selector %q", selector)
+	}
+	var art *wfv1.Artifact
+	if wf.Status.Outputs != nil {
+		art = wf.Status.Outputs.GetArtifactByName(from.ArtifactName)
+	}
+	if art == nil || art.Deleted {
+		return nil, fmt.Errorf("workflow %q does not have output artifact %q", wf.Name, from.ArtifactName)
+	}
+	return art, nil
+}
+
+// latestSucceededWorkflow returns the selected workflow that finished most recently, either live or archived
+func (wfc *WorkflowController) latestSucceededWorkflow(namespace string, selector labels.Selector) (*wfv1.Workflow, error) {
+	objs, err := wfc.wfInformer.GetIndexer().ByIndex(indexes.WorkflowPhaseIndex, string(wfv1.WorkflowSucceeded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list succeeded workflows: %w", err)
+	}
+	var latest *wfv1.Workflow
+	for _, obj := range objs {
+		un, ok := obj.(*unstructured.Unstructured)
+		if !ok || un.GetNamespace() != namespace || !selector.Matches(labels.Set(un.GetLabels())) {
+			continue
+		}
+		wf, err := util.FromUnstructured(un)
+		if err != nil {
+			return nil, fmt.Errorf("failed convert unstructured to workflow: %w", err)
+		}
+		if latest == nil || wf.Status.FinishedAt.After(latest.Status.FinishedAt.Time) {
+			latest = wf
+		}
+	}
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		return latest, nil
+	}
+	archived, err := wfc.wfArchive.ListWorkflows(namespace, "", "", time.Time{}, time.Time{}, requirements, []wfv1.WorkflowPhase{wfv1.WorkflowSucceeded}, "", "-finishedAt", 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived workflows: %w", err)
+	}
+	if len(archived) > 0 && (latest == nil || archived[0].Status.FinishedAt.After(latest.Status.FinishedAt.Time)) {
+		// only the archived workflow's metadata is listed, so we get it to read its outputs
+		return wfc.wfArchive.GetWorkflow(string(archived[0].UID))
+	}
+	return latest, nil
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func succeededDailyWorkflow(name, finishedAt, key string) *wfv1.Workflow {
+	return wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: ` + name + `
+  namespace: my-ns
+  labels:
+    pipeline: daily
+    workflows.argoproj.io/phase: Succeeded
+status:
+  phase: Succeeded
+  finishedAt: "` + finishedAt + `"
+  outputs:
+    artifacts:
+      - name: report
+        s3:
+          key: ` + key + `
+`)
+}
+
+const fromWorkflowArtifactWorkflow = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      inputs:
+        artifacts:
+          - name: report
+            path: /tmp/report
+            fromWorkflow:
+              selector:
+                matchLabels:
+                  pipeline: daily
+              artifactName: %s
+      container:
+        image: my-image
+`
+
+func TestFromWorkflowArtifact(t *testing.T) {
+	cancel, controller := newController(
+		succeededDailyWorkflow("yesterday", "2023-01-02T00:00:00Z", "yesterday/report.tgz"),
+		succeededDailyWorkflow("day-before-yesterday", "2023-01-01T00:00:00Z", "day-before-yesterday/report.tgz"),
+	)
+	defer cancel()
+	ctx := context.Background()
+
+	t.Run("Latest", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(fromWorkflowArtifactWorkflow, "report"))
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		node := woc.wf.Status.Nodes.FindByDisplayName("my-wf")
+		require.NotNil(t, node)
+		art := node.Inputs.GetArtifactByName("report")
+		require.NotNil(t, art)
+		assert.Equal(t, "yesterday/report.tgz", art.S3.Key)
+	})
+	t.Run("MissingArtifact", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(fmt.Sprintf(fromWorkflowArtifactWorkflow, "missing"))
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, `workflow "yesterday" does not have output artifact "missing"`)
+	})
+}
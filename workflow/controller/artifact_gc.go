@@ -2,10 +2,13 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"hash/fnv"
 	"sort"
+	"strconv"
 
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	apiv1 "k8s.io/api/core/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
 	"github.com/argoproj/argo-workflows/v3/util/slice"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
@@ -24,6 +28,11 @@ import (
 
 const artifactGCComponent = "artifact-gc"
 
+// artGCLog returns the log entry of the workflow's artifact garbage collection, logged at the `artifact-gc` level
+func (woc *wfOperationCtx) artGCLog() *log.Entry {
+	return logging.WithComponent(woc.log, artifactGCComponent)
+}
+
 // artifactGCEnabled is a feature flag to globally disabled artifact GC in case of emergency
 var artifactGCEnabled, _ = env.GetBool("ARGO_ARTIFACT_GC_ENABLED", true)
 
@@ -44,7 +53,7 @@ func (woc *wfOperationCtx) garbageCollectArtifacts(ctx context.Context) error {
 			return nil // we already verified it's not required for this workflow
 		}
 		if woc.execWf.HasArtifactGC() {
-			woc.log.Info("adding artifact GC finalizer")
+			woc.artGCLog().Info("adding artifact GC finalizer")
 			finalizers := append(woc.wf.GetFinalizers(), common.FinalizerArtifactGC)
 			woc.wf.SetFinalizers(finalizers)
 			woc.wf.Status.ArtifactGCStatus.NotSpecified = false
@@ -57,7 +66,7 @@ func (woc *wfOperationCtx) garbageCollectArtifacts(ctx context.Context) error {
 	// based on current state of Workflow, which Artifact GC Strategies can be processed now?
 	strategies := woc.artifactGCStrategiesReady()
 	for strategy := range strategies {
-		woc.log.Debugf("processing Artifact GC Strategy %s", strategy)
+		woc.artGCLog().Debugf("processing Artifact GC Strategy %s", strategy)
 		err := woc.processArtifactGCStrategy(ctx, strategy)
 		if err != nil {
 			return err
@@ -101,7 +110,7 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 
 	var err error
 
-	woc.log.Debugf("processing Artifact GC Strategy %s", strategy)
+	woc.artGCLog().Debugf("processing Artifact GC Strategy %s", strategy)
 
 	// Search for artifacts
 	artifactSearchResults := woc.wf.SearchArtifacts(&wfv1.ArtifactSearchQuery{
@@ -110,7 +119,7 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 		NodeTypes:            map[wfv1.NodeType]bool{wfv1.NodeTypePod: true},
 	})
 	if len(artifactSearchResults) == 0 {
-		woc.log.Debugf("No Artifact Search Results returned from strategy %s", strategy)
+		woc.artGCLog().Debugf("No Artifact Search Results returned from strategy %s", strategy)
 		return nil
 	}
 
@@ -208,10 +217,13 @@ func (woc *wfOperationCtx) processArtifactGCStrategy(ctx context.Context, strate
 type podInfo struct {
 	serviceAccount string
 	podMetadata    wfv1.Metadata
+	dnsPolicy      *corev1.DNSPolicy
+	dnsConfig      *corev1.PodDNSConfig
+	hostNetwork    *bool
 }
 
 // get Pod name
-// (we have a unique Pod for each Artifact GC Strategy and Service Account/Metadata requirement)
+// (we have a unique Pod for each Artifact GC Strategy and Service Account/Metadata/network requirement)
 func (woc *wfOperationCtx) artGCPodName(strategy wfv1.ArtifactGCStrategy, podAccessInfo podInfo) (string, error) {
 	h := fnv.New32a()
 	_, _ = h.Write([]byte(podAccessInfo.serviceAccount))
@@ -233,6 +245,21 @@ func (woc *wfOperationCtx) artGCPodName(strategy wfv1.ArtifactGCStrategy, podAcc
 		_, _ = h.Write([]byte(annotationValue))
 	}
 
+	// only hash the network settings that are specified, so the names of other Pods do not change
+	if podAccessInfo.dnsPolicy != nil {
+		_, _ = h.Write([]byte(*podAccessInfo.dnsPolicy))
+	}
+	if podAccessInfo.dnsConfig != nil {
+		dnsConfig, err := json.Marshal(podAccessInfo.dnsConfig)
+		if err != nil {
+			return "", err
+		}
+		_, _ = h.Write(dnsConfig)
+	}
+	if podAccessInfo.hostNetwork != nil {
+		_, _ = h.Write([]byte(strconv.FormatBool(*podAccessInfo.hostNetwork)))
+	}
+
 	abbreviatedName := ""
 	switch strategy {
 	case wfv1.ArtifactGCOnWorkflowCompletion:
@@ -286,6 +313,7 @@ func (woc *wfOperationCtx) addTemplateArtifactsToTasks(podName string, tasks *[]
 				ArtifactsByNode: make(map[string]wfv1.ArtifactNodeSpec),
 			},
 		}
+		woc.controller.Config.MetadataPropagation.Propagate(&woc.wf.ObjectMeta, &currentTask.ObjectMeta)
 		*tasks = append(*tasks, currentTask)
 	} /*else if hitting 1 MB on CRD { //todo: handle multiple WorkflowArtifactGCTasks
 		// add a new WorkflowArtifactGCTask to *tasks
@@ -315,7 +343,7 @@ func (woc *wfOperationCtx) addTemplateArtifactsToTasks(podName string, tasks *[]
 		artifactNodeSpec.Artifacts[artifactSearchResult.Name] = artifactSearchResult.Artifact
 
 	}
-	woc.log.Debugf("list of artifacts pertaining to template %s to WorkflowArtifactGCTask '%s': %+v", template.Name, currentTask.Name, artifactsByNode)
+	woc.artGCLog().Debugf("list of artifacts pertaining to template %s to WorkflowArtifactGCTask '%s': %+v", template.Name, currentTask.Name, artifactsByNode)
 
 }
 
@@ -341,9 +369,9 @@ func (woc *wfOperationCtx) createWorkflowArtifactGCTask(ctx context.Context, tas
 		return nil, err
 	}
 	if foundTask != nil {
-		woc.log.Debugf("Artifact GC Task %s already exists", task.Name)
+		woc.artGCLog().Debugf("Artifact GC Task %s already exists", task.Name)
 	} else {
-		woc.log.Infof("Creating Artifact GC Task %s", task.Name)
+		woc.artGCLog().Infof("Creating Artifact GC Task %s", task.Name)
 
 		task, err = woc.controller.wfclientset.ArgoprojV1alpha1().WorkflowArtifactGCTasks(woc.wf.Namespace).Create(ctx, task, metav1.CreateOptions{})
 		if err != nil {
@@ -357,7 +385,7 @@ func (woc *wfOperationCtx) createWorkflowArtifactGCTask(ctx context.Context, tas
 func (woc *wfOperationCtx) createArtifactGCPod(ctx context.Context, strategy wfv1.ArtifactGCStrategy, tasks []*wfv1.WorkflowArtifactGCTask,
 	podAccessInfo podInfo, podName string, templatesToArtList templatesToArtifacts, templatesByName map[string]*wfv1.Template) (*corev1.Pod, error) {
 
-	woc.log.
+	woc.artGCLog().
 		WithField("strategy", strategy).
 		Infof("creating pod to delete artifacts: %s", podName)
 
@@ -453,16 +481,29 @@ func (woc *wfOperationCtx) createArtifactGCPod(ctx context.Context, strategy wfv
 	for annotation, annotationVal := range podAccessInfo.podMetadata.Annotations {
 		pod.ObjectMeta.Annotations[annotation] = annotationVal
 	}
+	// some artifact repositories are only reachable with custom DNS or from the nodes' network
+	if podAccessInfo.dnsPolicy != nil {
+		pod.Spec.DNSPolicy = *podAccessInfo.dnsPolicy
+	}
+	pod.Spec.DNSConfig = podAccessInfo.dnsConfig
+	if podAccessInfo.hostNetwork != nil {
+		pod.Spec.HostNetwork = *podAccessInfo.hostNetwork
+	}
 
 	if v := woc.controller.Config.InstanceID; v != "" {
 		pod.Labels[common.EnvVarInstanceID] = v
 	}
+	woc.controller.Config.MetadataPropagation.Propagate(&woc.wf.ObjectMeta, &pod.ObjectMeta)
+
+	if err := woc.addArtifactGCPlugins(ctx, pod, artifactLocations); err != nil {
+		return nil, err
+	}
 
 	_, err := woc.controller.kubeclientset.CoreV1().Pods(woc.wf.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 
 	if err != nil {
 		if apierr.IsAlreadyExists(err) {
-			woc.log.Warningf("Artifact GC Pod %s already exists?", pod.Name)
+			woc.artGCLog().Warningf("Artifact GC Pod %s already exists?", pod.Name)
 		} else {
 			return nil, fmt.Errorf("failed to create pod: %w", err)
 		}
@@ -493,9 +534,14 @@ func (woc *wfOperationCtx) processArtifactGCCompletion(ctx context.Context) erro
 
 		phase := pod.Status.Phase
 
+		// the sidecars of artifact driver plugins keep running after the artifacts have been deleted
+		if phase == corev1.PodRunning && len(pod.Spec.Containers) > 1 && getExitCode(pod) != nil {
+			woc.controller.queuePodForCleanup(pod.Namespace, pod.Name, terminateContainers)
+		}
+
 		// if Pod is done process the results
 		if phase == corev1.PodSucceeded || phase == corev1.PodFailed {
-			woc.log.WithField("pod", pod.Name).
+			woc.artGCLog().WithField("pod", pod.Name).
 				WithField("phase", phase).
 				WithField("message", pod.Status.Message).
 				Info("reconciling artifact-gc pod")
@@ -515,7 +561,7 @@ func (woc *wfOperationCtx) processArtifactGCCompletion(ctx context.Context) erro
 	if anyPodSuccess {
 		// check if all artifacts have been deleted and if so remove Finalizer
 		if woc.allArtifactsDeleted() {
-			woc.log.Info("no remaining artifacts to GC, removing artifact GC finalizer")
+			woc.artGCLog().Info("no remaining artifacts to GC, removing artifact GC finalizer")
 			woc.wf.Finalizers = slice.RemoveString(woc.wf.Finalizers, common.FinalizerArtifactGC)
 			woc.updated = true
 		}
@@ -539,7 +585,7 @@ func (woc *wfOperationCtx) allArtifactsDeleted() bool {
 }
 
 func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, pod *corev1.Pod) error {
-	woc.log.Infof("processing completed Artifact GC Pod '%s'", pod.Name)
+	woc.artGCLog().Infof("processing completed Artifact GC Pod '%s'", pod.Name)
 
 	// get associated WorkflowArtifactGCTasks
 	labelSelector := fmt.Sprintf("%s = %s", common.LabelKeyArtifactGCPodHash, woc.artifactGCPodLabel(pod.Name))
@@ -566,7 +612,7 @@ func (woc *wfOperationCtx) processCompletedArtifactGCPod(ctx context.Context, po
 // process the Status in the WorkflowArtifactGCTask which was completed and reflect it in Workflow Status; then delete the Task CRD Object
 // return first found error message if GC failed
 func (woc *wfOperationCtx) processCompletedWorkflowArtifactGCTask(ctx context.Context, artifactGCTask *wfv1.WorkflowArtifactGCTask, strategy wfv1.ArtifactGCStrategy) error {
-	woc.log.Debugf("processing WorkflowArtifactGCTask %s", artifactGCTask.Name)
+	woc.artGCLog().Debugf("processing WorkflowArtifactGCTask %s", artifactGCTask.Name)
 
 	foundGCFailure := false
 	for nodeName, nodeResult := range artifactGCTask.Status.ArtifactResultsByNode {
@@ -607,10 +653,10 @@ func (woc *wfOperationCtx) processCompletedWorkflowArtifactGCTask(ctx context.Co
 
 	// now we can delete it, if it succeeded (otherwise we leave it up to be inspected)
 	if !foundGCFailure {
-		woc.log.Debugf("deleting WorkflowArtifactGCTask: %s", artifactGCTask.Name)
+		woc.artGCLog().Debugf("deleting WorkflowArtifactGCTask: %s", artifactGCTask.Name)
 		err := woc.controller.wfclientset.ArgoprojV1alpha1().WorkflowArtifactGCTasks(woc.wf.Namespace).Delete(ctx, artifactGCTask.Name, metav1.DeleteOptions{})
 		if err != nil {
-			woc.log.Errorf("error deleting WorkflowArtifactGCTask: %s: %v", artifactGCTask.Name, err)
+			woc.artGCLog().Errorf("error deleting WorkflowArtifactGCTask: %s: %v", artifactGCTask.Name, err)
 		}
 	}
 	return nil
@@ -634,6 +680,15 @@ func (woc *wfOperationCtx) updateArtifactGCPodInfo(artifactGC *wfv1.ArtifactGC,
 	if artifactGC.ServiceAccountName != "" {
 		podAccessInfo.serviceAccount = artifactGC.ServiceAccountName
 	}
+	if artifactGC.DNSPolicy != nil {
+		podAccessInfo.dnsPolicy = artifactGC.DNSPolicy
+	}
+	if artifactGC.DNSConfig != nil {
+		podAccessInfo.dnsConfig = artifactGC.DNSConfig
+	}
+	if artifactGC.HostNetwork != nil {
+		podAccessInfo.hostNetwork = artifactGC.HostNetwork
+	}
 	if artifactGC.PodMetadata != nil {
 		if len(artifactGC.PodMetadata.Labels) > 0 && podAccessInfo.podMetadata.Labels == nil {
 			podAccessInfo.podMetadata.Labels = make(map[string]string)
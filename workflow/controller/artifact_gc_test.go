@@ -9,6 +9,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -448,6 +449,37 @@ func TestProcessArtifactGCStrategy(t *testing.T) {
 
 }
 
+func TestArtifactGCPodNetwork(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artgcWorkflow)
+	dnsPolicy := corev1.DNSClusterFirstWithHostNet
+	wf.Spec.ArtifactGC.DNSPolicy = &dnsPolicy
+	wf.Spec.ArtifactGC.DNSConfig = &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}}
+	wf.Spec.ArtifactGC.HostNetwork = pointer.Bool(true)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.wf.Status.ArtifactGCStatus = &wfv1.ArtGCStatus{}
+
+	err := woc.processArtifactGCStrategy(ctx, wfv1.ArtifactGCOnWorkflowCompletion)
+	assert.NoError(t, err)
+
+	pods, err := woc.controller.kubeclientset.CoreV1().Pods(woc.wf.GetNamespace()).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, pods.Items, 2) {
+		for _, pod := range pods.Items {
+			// the network settings are part of the name, so these pods are not mistaken for those without them
+			assert.NotContains(t, []string{"two-artgc-8tcvt-artgc-wfcomp-592587874", "two-artgc-8tcvt-artgc-wfcomp-3953780960"}, pod.Name)
+			assert.Equal(t, corev1.DNSClusterFirstWithHostNet, pod.Spec.DNSPolicy)
+			if assert.NotNil(t, pod.Spec.DNSConfig) {
+				assert.Equal(t, []string{"10.0.0.10"}, pod.Spec.DNSConfig.Nameservers)
+			}
+			assert.True(t, pod.Spec.HostNetwork)
+		}
+	}
+}
+
 var artgcTask = `apiVersion: argoproj.io/v1alpha1
 kind: WorkflowArtifactGCTask
 metadata:
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/plugins/spec"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var (
+	// the executor and the artifact driver plugins exchange the artifacts they load and save through this volume
+	volumeArtifactPlugins = apiv1.Volume{
+		Name: common.ArtifactPluginsVolumeName,
+		VolumeSource: apiv1.VolumeSource{
+			EmptyDir: &apiv1.EmptyDirVolumeSource{},
+		},
+	}
+	volumeMountArtifactPlugins = apiv1.VolumeMount{
+		Name:      volumeArtifactPlugins.Name,
+		MountPath: common.ArtifactPluginsPath,
+	}
+)
+
+// artifactPluginNames returns the names of the artifact driver plugins that store the template's artifacts, including
+// those that only have a key and are stored in its archive location
+func artifactPluginNames(tmpl *wfv1.Template) []string {
+	locations := []*wfv1.ArtifactLocation{tmpl.ArchiveLocation}
+	for i := range tmpl.Inputs.Artifacts {
+		locations = append(locations, &tmpl.Inputs.Artifacts[i].ArtifactLocation)
+	}
+	for i := range tmpl.Outputs.Artifacts {
+		locations = append(locations, &tmpl.Outputs.Artifacts[i].ArtifactLocation)
+	}
+	return pluginNames(locations)
+}
+
+// pluginNames returns the sorted names of the artifact driver plugins of the locations
+func pluginNames(locations []*wfv1.ArtifactLocation) []string {
+	names := map[string]bool{}
+	for _, l := range locations {
+		if l != nil && l.Plugin != nil {
+			names[l.Plugin.Name] = true
+		}
+	}
+	var sorted []string
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// findArtifactDriverPlugin returns the named artifact driver plugin, preferring the one in the workflow's namespace to
+// the one in the controller's namespace
+func (woc *wfOperationCtx) findArtifactDriverPlugin(name string) *spec.Plugin {
+	for _, namespace := range []string{woc.wf.Namespace, woc.controller.namespace} {
+		for _, plug := range woc.controller.artifactDriverPlugins[namespace] {
+			if plug.Name == name {
+				return plug
+			}
+		}
+	}
+	return nil
+}
+
+func (woc *wfOperationCtx) getArtifactDriverPlugins(ctx context.Context, names []string) ([]apiv1.Container, []apiv1.Volume, error) {
+	var sidecars []apiv1.Container
+	var volumes []apiv1.Volume
+	for _, name := range names {
+		plug := woc.findArtifactDriverPlugin(name)
+		if plug == nil {
+			return nil, nil, fmt.Errorf("artifact driver plugin %q not found", name)
+		}
+		s := plug.Spec.Sidecar
+		c := s.Container.DeepCopy()
+		if c.Name == "" {
+			c.Name = plug.Name
+		}
+		c.VolumeMounts = append(c.VolumeMounts,
+			apiv1.VolumeMount{
+				Name:      volumeMountVarArgo.Name,
+				MountPath: volumeMountVarArgo.MountPath,
+				ReadOnly:  true,
+				// only mount the token for this plugin, not others
+				SubPath: plug.Name,
+			},
+			volumeMountArtifactPlugins,
+		)
+		if s.AutomountServiceAccountToken {
+			volume, volumeMount, err := woc.getServiceAccountTokenVolume(ctx, plug.Name+"-artifact-driver-plugin")
+			if err != nil {
+				return nil, nil, err
+			}
+			volumes = append(volumes, *volume)
+			c.VolumeMounts = append(c.VolumeMounts, *volumeMount)
+		}
+		sidecars = append(sidecars, *c)
+	}
+	return sidecars, volumes, nil
+}
+
+// addArtifactDriverPlugins adds the sidecars of the artifact driver plugins that store the template's artifacts, and
+// tells the init and wait containers how to call them
+func (woc *wfOperationCtx) addArtifactDriverPlugins(ctx context.Context, pod *apiv1.Pod, tmpl *wfv1.Template) error {
+	names := artifactPluginNames(tmpl)
+	if len(names) == 0 {
+		return nil
+	}
+	sidecars, volumes, err := woc.getArtifactDriverPlugins(ctx, names)
+	if err != nil {
+		return err
+	}
+	env := []apiv1.EnvVar{
+		{Name: common.EnvVarPluginNames, Value: wfv1.MustMarshallJSON(names)},
+		{Name: common.EnvVarPluginAddresses, Value: wfv1.MustMarshallJSON(addresses(sidecars))},
+	}
+	for i, c := range pod.Spec.InitContainers {
+		if c.Name == common.InitContainerName {
+			c.Env = append(c.Env, env...)
+			pod.Spec.InitContainers[i] = c
+		}
+	}
+	for i, c := range pod.Spec.Containers {
+		if c.Name == common.WaitContainerName {
+			c.Env = append(c.Env, env...)
+			c.VolumeMounts = append(c.VolumeMounts, volumeMountArtifactPlugins)
+			pod.Spec.Containers[i] = c
+		}
+	}
+	pod.Spec.Containers = append(pod.Spec.Containers, sidecars...)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumeArtifactPlugins)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+	return nil
+}
+
+// addArtifactGCPlugins adds the sidecars of the artifact driver plugins that store the artifacts to an artifact GC pod,
+// with an init container to create their tokens
+func (woc *wfOperationCtx) addArtifactGCPlugins(ctx context.Context, pod *apiv1.Pod, artifactLocations []*wfv1.ArtifactLocation) error {
+	names := pluginNames(artifactLocations)
+	if len(names) == 0 {
+		return nil
+	}
+	sidecars, volumes, err := woc.getArtifactDriverPlugins(ctx, names)
+	if err != nil {
+		return err
+	}
+	main := &pod.Spec.Containers[0]
+	main.Env = append(main.Env,
+		apiv1.EnvVar{Name: common.EnvVarPluginNames, Value: wfv1.MustMarshallJSON(names)},
+		apiv1.EnvVar{Name: common.EnvVarPluginAddresses, Value: wfv1.MustMarshallJSON(addresses(sidecars))},
+	)
+	main.VolumeMounts = append(main.VolumeMounts, volumeMountVarArgo, volumeMountArtifactPlugins)
+	// the `init` container creates the tokens of the plugins
+	initCtr := main.DeepCopy()
+	initCtr.Name = common.InitContainerName
+	initCtr.Args = []string{"agent", "init", "--loglevel", getExecutorLogLevel()}
+	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initCtr)
+	pod.Spec.Containers = append(pod.Spec.Containers, sidecars...)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumeVarArgo, volumeArtifactPlugins)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+	return nil
+}
+
+// isArtifactDriverPlugin returns whether the container is the sidecar of an artifact driver plugin
+func isArtifactDriverPlugin(pod *apiv1.Pod, containerName string) bool {
+	if containerName == common.WaitContainerName || containerName == common.MainContainerName {
+		return false
+	}
+	for _, c := range pod.Spec.Containers {
+		if c.Name != containerName {
+			continue
+		}
+		for _, m := range c.VolumeMounts {
+			if m.Name == volumeArtifactPlugins.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/plugins/spec"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+var artifactPluginWf = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      inputs:
+        artifacts:
+          - name: my-input
+            path: /my-input
+            plugin:
+              name: my-plugin
+              key: my-input
+      outputs:
+        artifacts:
+          - name: my-output
+            path: /my-output
+            plugin:
+              name: my-plugin
+              key: my-output
+      container:
+        image: my-image
+        command: [my-command]
+`
+
+func withArtifactDriverPlugin(x *WorkflowController) {
+	x.artifactDriverPlugins = map[string]map[string]*spec.Plugin{
+		"my-ns": {
+			"my-plugin-artifact-driver-plugin": {
+				ObjectMeta: metav1.ObjectMeta{Name: "my-plugin"},
+				Spec: spec.PluginSpec{Sidecar: spec.Sidecar{Container: apiv1.Container{
+					Name:  "my-plugin",
+					Image: "my-plugin-image",
+					Ports: []apiv1.ContainerPort{{ContainerPort: 1234}},
+				}}},
+			},
+		},
+	}
+}
+
+func TestArtifactDriverPlugin(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artifactPluginWf)
+	cancel, controller := newController(wf, withArtifactDriverPlugin)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+		pod := pods.Items[0]
+		assert.Contains(t, pod.Spec.Volumes, volumeArtifactPlugins)
+		names := map[string]bool{}
+		for _, c := range pod.Spec.Containers {
+			names[c.Name] = true
+			switch c.Name {
+			case "my-plugin":
+				assert.Equal(t, "my-plugin-image", c.Image)
+				assert.Contains(t, c.VolumeMounts, apiv1.VolumeMount{Name: "var-run-argo", MountPath: common.VarRunArgoPath, ReadOnly: true, SubPath: "my-plugin"})
+				assert.Contains(t, c.VolumeMounts, volumeMountArtifactPlugins)
+				assert.Empty(t, c.Command, "the emissary does not run the plugin")
+				assert.True(t, isArtifactDriverPlugin(&pod, c.Name))
+			case common.WaitContainerName:
+				assert.Contains(t, c.Env, apiv1.EnvVar{Name: common.EnvVarPluginNames, Value: `["my-plugin"]`})
+				assert.Contains(t, c.Env, apiv1.EnvVar{Name: common.EnvVarPluginAddresses, Value: `["http://localhost:1234"]`})
+				assert.Contains(t, c.VolumeMounts, volumeMountArtifactPlugins)
+				assert.False(t, isArtifactDriverPlugin(&pod, c.Name))
+			case common.MainContainerName:
+				for _, m := range c.VolumeMounts {
+					assert.NotEqual(t, "/my-input", m.MountPath, "the main container copies the input itself")
+				}
+			}
+		}
+		assert.Equal(t, map[string]bool{common.WaitContainerName: true, common.MainContainerName: true, "my-plugin": true}, names)
+		assert.Contains(t, pod.Spec.InitContainers[0].Env, apiv1.EnvVar{Name: common.EnvVarPluginNames, Value: `["my-plugin"]`})
+	}
+}
+
+func TestArtifactDriverPluginNotFound(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(artifactPluginWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	node := woc.wf.Status.Nodes.FindByDisplayName("my-wf")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, wfv1.NodeError, node.Phase)
+		assert.Equal(t, `artifact driver plugin "my-plugin" not found`, node.Message)
+	}
+}
+
+func TestArtifactPluginNames(t *testing.T) {
+	assert.Empty(t, artifactPluginNames(&wfv1.Template{}))
+	assert.Equal(t, []string{"a", "b"}, artifactPluginNames(&wfv1.Template{
+		ArchiveLocation: &wfv1.ArtifactLocation{Plugin: &wfv1.PluginArtifact{Name: "b"}},
+		Inputs:          wfv1.Inputs{Artifacts: wfv1.Artifacts{{ArtifactLocation: wfv1.ArtifactLocation{Plugin: &wfv1.PluginArtifact{Name: "b"}}}}},
+		Outputs:         wfv1.Outputs{Artifacts: wfv1.Artifacts{{ArtifactLocation: wfv1.ArtifactLocation{Plugin: &wfv1.PluginArtifact{Name: "a"}}}}},
+	}))
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	artifacts "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
+)
+
+// runArtifactRepositoryChecks checks the artifact repositories every period, if the checks are enabled. The config is
+// read every period, so the checks can be enabled, disabled or tuned without restarting the controller.
+func (wfc *WorkflowController) runArtifactRepositoryChecks(ctx context.Context) {
+	for {
+		checks := wfc.Config.ArtifactRepositoryChecks
+		if checks.IsEnabled() {
+			wfc.checkArtifactRepositories(ctx, checks, artifacts.NewDriver)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(checks.GetPeriod()):
+		}
+	}
+}
+
+// checkArtifactRepositories updates the status of each WorkflowArtifactRepository and ClusterWorkflowArtifactRepository
+// with whether the controller could connect to it. At most the configured number of repositories of each namespace are
+// checked, those checked the longest time ago first, so a namespace with many repositories cannot delay the others.
+func (wfc *WorkflowController) checkArtifactRepositories(ctx context.Context, checks *config.ArtifactRepositoryChecksConfig, newDriver artifacts.NewDriverFunc) {
+	client := wfc.wfclientset.ArgoprojV1alpha1()
+	repos, err := client.WorkflowArtifactRepositories(wfc.GetManagedNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.WithError(err).Error("Failed to list workflow artifact repositories")
+	} else {
+		items := repos.Items
+		sort.SliceStable(items, func(i, j int) bool { return items[i].Status.CheckedAt.Before(&items[j].Status.CheckedAt) })
+		checked := make(map[string]int)
+		for _, r := range items {
+			if checked[r.Namespace] >= checks.GetMaxPerNamespace() {
+				continue
+			}
+			checked[r.Namespace]++
+			r.Status = checkArtifactRepository(ctx, wfc.kubeclientset, &r.Spec, r.Namespace, newDriver, checks.GetTimeout())
+			if _, err := client.WorkflowArtifactRepositories(r.Namespace).UpdateStatus(ctx, &r, metav1.UpdateOptions{}); err != nil {
+				log.WithError(err).WithFields(log.Fields{"namespace": r.Namespace, "name": r.Name}).Error("Failed to update the status of workflow artifact repository")
+			}
+		}
+	}
+	if wfc.GetManagedNamespace() != "" {
+		// namespaced installations cannot read cluster scoped resources
+		return
+	}
+	clusterRepos, err := client.ClusterWorkflowArtifactRepositories().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.WithError(err).Error("Failed to list cluster workflow artifact repositories")
+		return
+	}
+	items := clusterRepos.Items
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Status.CheckedAt.Before(&items[j].Status.CheckedAt) })
+	for i, r := range items {
+		if i >= checks.GetMaxPerNamespace() {
+			break
+		}
+		// the credentials of cluster scoped repositories are read from each workflow's namespace, we check them with
+		// those in the controller's namespace
+		r.Status = checkArtifactRepository(ctx, wfc.kubeclientset, &r.Spec, wfc.namespace, newDriver, checks.GetTimeout())
+		if _, err := client.ClusterWorkflowArtifactRepositories().UpdateStatus(ctx, &r, metav1.UpdateOptions{}); err != nil {
+			log.WithError(err).WithField("name", r.Name).Error("Failed to update the status of cluster workflow artifact repository")
+		}
+	}
+}
+
+// checkArtifactRepository checks the secrets the repository references exist in the namespace, and that it can
+// connect to the repository using them within the timeout
+func checkArtifactRepository(ctx context.Context, kubeClient kubernetes.Interface, repo *wfv1.ArtifactRepository, namespace string, newDriver artifacts.NewDriverFunc, timeout time.Duration) wfv1.ArtifactRepositoryStatus {
+	status := wfv1.ArtifactRepositoryStatus{CheckedAt: metav1.Now()}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	// not every driver stops when the context is done, so we stop waiting for it
+	errs := make(chan error, 1)
+	go func() { errs <- connectArtifactRepository(ctx, kubeClient, repo, namespace, newDriver) }()
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out connecting to the artifact repository after %v", timeout)
+	}
+	if err != nil {
+		status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeConnected, Status: metav1.ConditionFalse, Message: err.Error()})
+	} else {
+		status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeConnected, Status: metav1.ConditionTrue})
+	}
+	return status
+}
+
+func connectArtifactRepository(ctx context.Context, kubeClient kubernetes.Interface, repo *wfv1.ArtifactRepository, namespace string, newDriver artifacts.NewDriverFunc) error {
+	if repo.Get() == nil {
+		return fmt.Errorf("no artifact repository is configured")
+	}
+	selectors := repo.SecretKeySelectors()
+	fields := make([]string, 0, len(selectors))
+	for field := range selectors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		s := selectors[field]
+		secret, err := kubeClient.CoreV1().Secrets(namespace).Get(ctx, s.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("%s: %w", field, err)
+		}
+		if _, ok := secret.Data[s.Key]; !ok {
+			return fmt.Errorf("%s: secret %q does not have key %q", field, s.Name, s.Key)
+		}
+	}
+	art := &wfv1.Artifact{ArtifactLocation: *repo.ToArtifactLocation()}
+	key, err := art.GetKey()
+	if err != nil {
+		return err
+	}
+	// only the part of the key before the first variable is known until a workflow runs
+	if err := art.SetKey(strings.SplitN(key, "{{", 2)[0]); err != nil {
+		return err
+	}
+	driver, err := newDriver(ctx, art, artifactRepositoryResources{kubeClient, namespace})
+	if err != nil {
+		return err
+	}
+	_, err = driver.IsDirectory(art)
+	return err
+}
+
+// artifactRepositoryResources gets the credentials of an artifact repository from a namespace
+type artifactRepositoryResources struct {
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+func (r artifactRepositoryResources) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := r.kubeClient.CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return string(secret.Data[key]), nil
+}
+
+func (r artifactRepositoryResources) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := r.kubeClient.CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	return configMap.Data[key], nil
+}
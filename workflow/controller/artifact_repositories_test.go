@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/resource"
+)
+
+type connectivityDriver struct {
+	common.ArtifactDriver
+	key     string
+	err     error
+	blocked chan struct{}
+}
+
+func (d *connectivityDriver) IsDirectory(a *wfv1.Artifact) (bool, error) {
+	if d.blocked != nil {
+		<-d.blocked
+	}
+	d.key = a.S3.Key
+	return false, d.err
+}
+
+func TestCheckArtifactRepository(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := kubefake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-s3-credentials", Namespace: "my-ns"},
+		Data:       map[string][]byte{"accessKey": []byte("a"), "secretKey": []byte("s")},
+	})
+	repo := func(secretKey string) *wfv1.ArtifactRepository {
+		return &wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{
+			S3Bucket: wfv1.S3Bucket{
+				Bucket:          "my-bucket",
+				AccessKeySecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-s3-credentials"}, Key: "accessKey"},
+				SecretKeySecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-s3-credentials"}, Key: secretKey},
+			},
+			KeyFormat: "my-prefix/{{workflow.name}}/{{pod.name}}",
+		}}
+	}
+	newDriver := func(d *connectivityDriver) func(context.Context, *wfv1.Artifact, resource.Interface) (common.ArtifactDriver, error) {
+		return func(context.Context, *wfv1.Artifact, resource.Interface) (common.ArtifactDriver, error) {
+			return d, nil
+		}
+	}
+	connected := func(status wfv1.ArtifactRepositoryStatus) wfv1.Condition {
+		assert.False(t, status.CheckedAt.IsZero())
+		if assert.Len(t, status.Conditions, 1) {
+			return status.Conditions[0]
+		}
+		return wfv1.Condition{}
+	}
+	t.Run("Connected", func(t *testing.T) {
+		d := &connectivityDriver{}
+		c := connected(checkArtifactRepository(ctx, kubeClient, repo("secretKey"), "my-ns", newDriver(d), time.Minute))
+		assert.Equal(t, wfv1.ConditionTypeConnected, c.Type)
+		assert.Equal(t, metav1.ConditionTrue, c.Status)
+		assert.Equal(t, "my-prefix/", d.key)
+	})
+	t.Run("ConnectionFailed", func(t *testing.T) {
+		d := &connectivityDriver{err: fmt.Errorf("access denied")}
+		c := connected(checkArtifactRepository(ctx, kubeClient, repo("secretKey"), "my-ns", newDriver(d), time.Minute))
+		assert.Equal(t, metav1.ConditionFalse, c.Status)
+		assert.Equal(t, "access denied", c.Message)
+	})
+	t.Run("MissingSecretKey", func(t *testing.T) {
+		c := connected(checkArtifactRepository(ctx, kubeClient, repo("missing"), "my-ns", newDriver(&connectivityDriver{}), time.Minute))
+		assert.Equal(t, metav1.ConditionFalse, c.Status)
+		assert.Equal(t, `s3.secretKeySecret: secret "my-s3-credentials" does not have key "missing"`, c.Message)
+	})
+	t.Run("MissingSecret", func(t *testing.T) {
+		c := connected(checkArtifactRepository(ctx, kubeClient, repo("secretKey"), "other-ns", newDriver(&connectivityDriver{}), time.Minute))
+		assert.Equal(t, metav1.ConditionFalse, c.Status)
+		assert.Contains(t, c.Message, "s3.accessKeySecret: ")
+	})
+	t.Run("TimedOut", func(t *testing.T) {
+		d := &connectivityDriver{blocked: make(chan struct{})}
+		defer close(d.blocked)
+		c := connected(checkArtifactRepository(ctx, kubeClient, repo("secretKey"), "my-ns", newDriver(d), 10*time.Millisecond))
+		assert.Equal(t, metav1.ConditionFalse, c.Status)
+		assert.Equal(t, "timed out connecting to the artifact repository after 10ms", c.Message)
+	})
+	t.Run("NotConfigured", func(t *testing.T) {
+		c := connected(checkArtifactRepository(ctx, kubeClient, &wfv1.ArtifactRepository{}, "my-ns", newDriver(&connectivityDriver{}), time.Minute))
+		assert.Equal(t, metav1.ConditionFalse, c.Status)
+		assert.Equal(t, "no artifact repository is configured", c.Message)
+	})
+}
+
+func TestCheckArtifactRepositories(t *testing.T) {
+	repo := func(namespace, name string, checkedAt time.Time) *wfv1.WorkflowArtifactRepository {
+		return &wfv1.WorkflowArtifactRepository{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       wfv1.ArtifactRepository{S3: &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "my-bucket"}}},
+			Status:     wfv1.ArtifactRepositoryStatus{CheckedAt: metav1.NewTime(checkedAt)},
+		}
+	}
+	now := time.Now()
+	cancel, controller := newController(
+		repo("my-ns", "checked-recently", now.Add(-time.Minute)),
+		repo("my-ns", "checked-long-ago", now.Add(-time.Hour)),
+		repo("my-ns", "never-checked", time.Time{}),
+		repo("other-ns", "other", now.Add(-time.Minute)),
+	)
+	defer cancel()
+	ctx := context.Background()
+	controller.checkArtifactRepositories(ctx, &config.ArtifactRepositoryChecksConfig{Enabled: true, MaxPerNamespace: 2}, func(context.Context, *wfv1.Artifact, resource.Interface) (common.ArtifactDriver, error) {
+		return &connectivityDriver{}, nil
+	})
+
+	checked := func(namespace, name string) bool {
+		r, err := controller.wfclientset.ArgoprojV1alpha1().WorkflowArtifactRepositories(namespace).Get(ctx, name, metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			return len(r.Status.Conditions) == 1
+		}
+		return false
+	}
+	assert.True(t, checked("my-ns", "never-checked"))
+	assert.True(t, checked("my-ns", "checked-long-ago"))
+	assert.False(t, checked("my-ns", "checked-recently"))
+	assert.True(t, checked("other-ns", "other"))
+}
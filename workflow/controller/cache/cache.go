@@ -7,6 +7,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"upper.io/db.v3/lib/sqlbuilder"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -51,6 +52,9 @@ type cacheFactory struct {
 	caches     map[string]MemoizationCache
 	kubeclient kubernetes.Interface
 	namespace  string
+	// session is set if the caches are stored in the database, rather than in config maps
+	session     sqlbuilder.Database
+	clusterName string
 }
 
 type Factory interface {
@@ -59,16 +63,26 @@ type Factory interface {
 
 func NewCacheFactory(ki kubernetes.Interface, ns string) Factory {
 	return &cacheFactory{
-		make(map[string]MemoizationCache),
-		ki,
-		ns,
+		caches:     make(map[string]MemoizationCache),
+		kubeclient: ki,
+		namespace:  ns,
+	}
+}
+
+// NewDatabaseCacheFactory returns a factory of caches stored in the database, named after the config maps they replace
+func NewDatabaseCacheFactory(session sqlbuilder.Database, clusterName string, ns string) Factory {
+	return &cacheFactory{
+		caches:      make(map[string]MemoizationCache),
+		namespace:   ns,
+		session:     session,
+		clusterName: clusterName,
 	}
 }
 
 type CacheType string
 
 const (
-	// Only config maps are currently supported for caching
+	// Config maps are the only type of cache, but may be stored in the database
 	ConfigMapCache CacheType = "ConfigMapCache"
 )
 
@@ -80,7 +94,12 @@ func (cf *cacheFactory) GetCache(ct CacheType, name string) MemoizationCache {
 	}
 	switch ct {
 	case ConfigMapCache:
-		c := NewConfigMapCache(cf.namespace, cf.kubeclient, name)
+		var c MemoizationCache
+		if cf.session != nil {
+			c = NewDatabaseCache(cf.session, cf.clusterName, cf.namespace, name)
+		} else {
+			c = NewConfigMapCache(cf.namespace, cf.kubeclient, name)
+		}
 		cf.caches[idx] = c
 		return c
 	default:
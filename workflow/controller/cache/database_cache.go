@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"upper.io/db.v3"
+	"upper.io/db.v3/lib/sqlbuilder"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const databaseCacheTableName = "argo_memoization_cache"
+
+type databaseCacheRecord struct {
+	ClusterName string `db:"clustername"`
+	Namespace   string `db:"namespace"`
+	Name        string `db:"name"`
+	Key         string `db:"cachekey"`
+	Entry       string `db:"entry"`
+}
+
+// databaseCache stores the entries of a cache in the database, so they are not limited by the 1MB size of a config map
+type databaseCache struct {
+	session     sqlbuilder.Database
+	clusterName string
+	namespace   string
+	name        string
+	lock        sync.Mutex
+}
+
+func NewDatabaseCache(session sqlbuilder.Database, clusterName, ns, n string) MemoizationCache {
+	return &databaseCache{
+		session:     session,
+		clusterName: clusterName,
+		namespace:   ns,
+		name:        n,
+	}
+}
+
+func (c *databaseCache) logFields(fields log.Fields) *log.Entry {
+	return log.WithFields(log.Fields{"namespace": c.namespace, "name": c.name}).WithFields(fields)
+}
+
+func (c *databaseCache) cond(key string) db.Cond {
+	return db.Cond{"clustername": c.clusterName, "namespace": c.namespace, "name": c.name, "cachekey": key}
+}
+
+func (c *databaseCache) Load(ctx context.Context, key string) (*Entry, error) {
+	if !cacheKeyRegex.MatchString(key) {
+		return nil, fmt.Errorf("invalid cache key: %s", key)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	record := &databaseCacheRecord{}
+	err := c.session.
+		SelectFrom(databaseCacheTableName).
+		Where(c.cond(key)).
+		One(record)
+	if err == db.ErrNoMoreRows {
+		c.logFields(log.Fields{"key": key}).Info("database cache miss: entry does not exist")
+		return nil, nil
+	}
+	if err != nil {
+		c.logFields(log.Fields{"key": key}).WithError(err).Debug("Error loading database cache")
+		return nil, fmt.Errorf("could not load database cache: %w", err)
+	}
+
+	var entry Entry
+	err = json.Unmarshal([]byte(record.Entry), &entry)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cache entry: could not unmarshal JSON; unable to parse: %w", err)
+	}
+
+	entry.LastHitTimestamp = metav1.Time{Time: time.Now()}
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal cache entry with last hit timestamp: %w", err)
+	}
+	_, err = c.session.
+		Update(databaseCacheTableName).
+		Set("entry", string(entryJSON)).
+		Where(c.cond(key)).
+		Exec()
+	if err != nil {
+		c.logFields(log.Fields{"key": key}).WithError(err).Debug("Error updating last hit timestamp on cache")
+		return nil, fmt.Errorf("error updating last hit timestamp on cache: %w", err)
+	}
+	return &entry, nil
+}
+
+func (c *databaseCache) Save(ctx context.Context, key string, nodeId string, value *wfv1.Outputs) error {
+	if !cacheKeyRegex.MatchString(key) {
+		return fmt.Errorf("invalid cache key: %s", key)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.logFields(log.Fields{"key": key, "nodeId": nodeId}).Info("Saving database cache entry")
+
+	creationTime := time.Now()
+	entryJSON, err := json.Marshal(Entry{
+		NodeID:            nodeId,
+		Outputs:           value,
+		CreationTimestamp: metav1.Time{Time: creationTime},
+		LastHitTimestamp:  metav1.Time{Time: creationTime},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to marshal cache entry: %w", err)
+	}
+
+	// replace any existing entry
+	return c.session.Tx(ctx, func(tx sqlbuilder.Tx) error {
+		_, err := tx.
+			DeleteFrom(databaseCacheTableName).
+			Where(c.cond(key)).
+			Exec()
+		if err != nil {
+			return err
+		}
+		_, err = tx.
+			InsertInto(databaseCacheTableName).
+			Values(&databaseCacheRecord{
+				ClusterName: c.clusterName,
+				Namespace:   c.namespace,
+				Name:        c.name,
+				Key:         key,
+				Entry:       string(entryJSON),
+			}).
+			Exec()
+		if err != nil {
+			return fmt.Errorf("error creating cache entry: %w", err)
+		}
+		return nil
+	})
+}
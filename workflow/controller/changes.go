@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"sort"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// setHydratedOrig takes the snapshot of the workflow that significantlyChanged compares it with. It must be called once
+// the workflow is hydrated, before it is changed.
+func (woc *wfOperationCtx) setHydratedOrig() {
+	woc.hydratedOrig = woc.wf.DeepCopy()
+	normalizeWorkflow(woc.hydratedOrig)
+}
+
+// significantlyChanged returns whether the workflow differs from the original in a way that needs to be persisted.
+// Reconciliations often mark the workflow as updated without changing it, e.g. re-setting a condition, so
+// persisting it would only write the same object to etcd with a new resource version. The workflow must be hydrated,
+// i.e. its parameters must not have been offloaded yet. Without a snapshot of the original, it is always persisted.
+func (woc *wfOperationCtx) significantlyChanged() bool {
+	if woc.hydratedOrig == nil {
+		return true
+	}
+	wf := woc.wf.DeepCopy()
+	normalizeWorkflow(wf)
+	return !apiequality.Semantic.DeepEqual(woc.hydratedOrig, wf)
+}
+
+// normalizeWorkflow sorts the lists of the workflow that have no meaningful order
+func normalizeWorkflow(wf *wfv1.Workflow) {
+	sort.SliceStable(wf.Status.Conditions, func(i, j int) bool {
+		return wf.Status.Conditions[i].Type < wf.Status.Conditions[j].Type
+	})
+	for id, node := range wf.Status.Nodes {
+		sort.Strings(node.Children)
+		sort.Strings(node.OutboundNodes)
+		wf.Status.Nodes[id] = node
+	}
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+)
+
+var changesWf = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+  resourceVersion: "1"
+spec:
+  entrypoint: main
+status:
+  phase: Running
+  conditions:
+    - type: PodRunning
+      status: "True"
+    - type: SpecWarning
+      status: "True"
+  nodes:
+    my-wf:
+      id: my-wf
+      phase: Running
+      children: [a, b]
+`
+
+func TestSignificantlyChanged(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	change := func(f func(wf *wfv1.Workflow)) bool {
+		orig := wfv1.MustUnmarshalWorkflow(changesWf)
+		woc := newWorkflowOperationCtx(orig, controller)
+		woc.setHydratedOrig()
+		f(woc.wf)
+		return woc.significantlyChanged()
+	}
+
+	assert.False(t, change(func(*wfv1.Workflow) {}))
+	t.Run("NoSnapshot", func(t *testing.T) {
+		woc := newWorkflowOperationCtx(wfv1.MustUnmarshalWorkflow(changesWf), controller)
+		assert.True(t, woc.significantlyChanged())
+	})
+	t.Run("ReorderedConditions", func(t *testing.T) {
+		assert.False(t, change(func(wf *wfv1.Workflow) {
+			c := wf.Status.Conditions
+			c[0], c[1] = c[1], c[0]
+		}))
+	})
+	t.Run("ReorderedChildren", func(t *testing.T) {
+		assert.False(t, change(func(wf *wfv1.Workflow) {
+			node := wf.Status.Nodes["my-wf"]
+			node.Children = []string{"b", "a"}
+			wf.Status.Nodes["my-wf"] = node
+		}))
+	})
+	t.Run("EmptyMaps", func(t *testing.T) {
+		assert.False(t, change(func(wf *wfv1.Workflow) {
+			wf.Status.StoredTemplates = map[string]wfv1.Template{}
+			wf.Labels = map[string]string{}
+		}))
+	})
+	t.Run("Phase", func(t *testing.T) {
+		assert.True(t, change(func(wf *wfv1.Workflow) {
+			wf.Status.Phase = wfv1.WorkflowSucceeded
+		}))
+	})
+	t.Run("Node", func(t *testing.T) {
+		assert.True(t, change(func(wf *wfv1.Workflow) {
+			wf.Status.Nodes["my-wf"] = wfv1.NodeStatus{ID: "my-wf", Phase: wfv1.NodeSucceeded, Children: []string{"a", "b"}}
+		}))
+	})
+	t.Run("Condition", func(t *testing.T) {
+		assert.True(t, change(func(wf *wfv1.Workflow) {
+			wf.Status.Conditions[0].Status = metav1.ConditionFalse
+		}))
+	})
+	t.Run("OffloadedNodes", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		_, controller.hydrator = getMockDBCtx(nil, true)
+		orig := wfv1.MustUnmarshalWorkflow(changesWf)
+		orig.Status.Nodes = nil
+		orig.Status.OffloadNodeStatusVersion = "my-version"
+		woc := newWorkflowOperationCtx(orig, controller)
+		assert.NoError(t, controller.hydrator.Hydrate(ctx, woc.wf))
+		woc.setHydratedOrig()
+		assert.False(t, woc.significantlyChanged())
+		woc.wf.Status.Nodes = wfv1.Nodes{"my-node": {Phase: wfv1.NodeSucceeded}}
+		assert.True(t, woc.significantlyChanged())
+	})
+	t.Run("OffloadedParameters", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		controller.hydrator = hydrator.New(sqldb.ExplosiveOffloadNodeStatusRepo, hydrator.NewParametersLoader(controller.getParametersConfigMap, controller.offloadParametersRepo))
+		_, err := controller.kubeclientset.CoreV1().ConfigMaps("my-ns").Create(ctx, &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf-parameters", Namespace: "my-ns"},
+			Data:       map[string]string{"outputs.result": "my-result"},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		orig := wfv1.MustUnmarshalWorkflow(changesWf)
+		node := orig.Status.Nodes["my-wf"]
+		node.ParametersConfigMap = "my-wf-parameters"
+		node.Outputs = &wfv1.Outputs{}
+		orig.Status.Nodes["my-wf"] = node
+		woc := newWorkflowOperationCtx(orig, controller)
+		assert.NoError(t, controller.hydrator.Hydrate(ctx, woc.wf))
+		woc.setHydratedOrig()
+		assert.False(t, woc.significantlyChanged())
+		woc.wf.Status.Nodes["my-wf"].Outputs.Result = pointer.String("my-other-result")
+		assert.True(t, woc.significantlyChanged())
+	})
+}
+
+func TestPersistUpdatesWithoutSignificantChanges(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	wfcset := controller.wfclientset.ArgoprojV1alpha1().Workflows("my-ns")
+	wf, err := wfcset.Create(ctx, wfv1.MustUnmarshalWorkflow(changesWf), metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.setHydratedOrig()
+	woc.wf.Status.Conditions[0], woc.wf.Status.Conditions[1] = woc.wf.Status.Conditions[1], woc.wf.Status.Conditions[0]
+	woc.updated = true
+	woc.persistUpdates(ctx)
+	assert.Equal(t, wf.ResourceVersion, woc.wf.ResourceVersion, "the workflow is not updated")
+
+	woc = newWorkflowOperationCtx(wf, controller)
+	woc.setHydratedOrig()
+	woc.wf.Status.Message = "my-message"
+	woc.updated = true
+	woc.persistUpdates(ctx)
+	persisted, err := wfcset.Get(ctx, wf.Name, metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "my-message", persisted.Status.Message)
+	}
+}
+
+func TestPersistUpdatesHydratesOnce(t *testing.T) {
+	orig := wfv1.MustUnmarshalWorkflow(changesWf)
+	orig.Status.Nodes = nil
+	orig.Status.OffloadNodeStatusVersion = "my-version"
+	var repo *mocks.OffloadNodeStatusRepo
+	cancel, controller := newController(orig, func(controller *WorkflowController) {
+		repo, controller.hydrator = getMockDBCtx(nil, true)
+		controller.offloadNodeStatusRepo = repo
+	})
+	defer cancel()
+
+	assert.True(t, controller.processNextItem(context.Background()))
+	repo.AssertNumberOfCalls(t, "Get", 1)
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+
+	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// cluster is another cluster that the pods of templates run on. Its pods and the task results of their executors are
+// watched like those of the controller's own cluster, while their workflows are kept in the controller's own cluster.
+type cluster struct {
+	restConfig         *rest.Config
+	kubeclientset      kubernetes.Interface
+	podInformer        cache.SharedIndexInformer
+	taskResultInformer cache.SharedIndexInformer
+}
+
+// newClusters creates clients and informers for each of the configured clusters using the kubeconfig in their secret
+func (wfc *WorkflowController) newClusters(ctx context.Context) (map[string]*cluster, error) {
+	clusters := make(map[string]*cluster)
+	for _, c := range wfc.Config.Clusters {
+		if c.Name == "" || strings.ContainsAny(c.Name, ":/") {
+			return nil, fmt.Errorf("invalid cluster name %q", c.Name)
+		}
+		if _, ok := clusters[c.Name]; ok {
+			return nil, fmt.Errorf("duplicate cluster %q", c.Name)
+		}
+		secret, err := wfc.kubeclientset.CoreV1().Secrets(wfc.namespace).Get(ctx, c.KubeConfig.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kubeconfig for cluster %q: %w", c.Name, err)
+		}
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[c.KubeConfig.Key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig for cluster %q: %w", c.Name, err)
+		}
+		kubeclientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		wfClient, err := wfclientset.NewForConfig(restConfig)
+		if err != nil {
+			return nil, err
+		}
+		clusters[c.Name] = &cluster{
+			restConfig:         restConfig,
+			kubeclientset:      kubeclientset,
+			podInformer:        wfc.newPodInformer(ctx, kubeclientset),
+			taskResultInformer: wfc.newWorkflowTaskResultInformer(wfClient),
+		}
+		log.WithField("cluster", c.Name).Info("Cluster")
+	}
+	return clusters, nil
+}
+
+// runClusters runs the informers of the clusters, and returns the functions that return whether they have synced
+func (wfc *WorkflowController) runClusters(stopCh <-chan struct{}) []cache.InformerSynced {
+	var synced []cache.InformerSynced
+	for _, c := range wfc.clusters {
+		go c.podInformer.Run(stopCh)
+		go c.taskResultInformer.Run(stopCh)
+		synced = append(synced, c.podInformer.HasSynced, c.taskResultInformer.HasSynced)
+	}
+	return synced
+}
+
+// clusterClients returns the clients of the cluster, or of the controller's own cluster if the name is empty
+func (wfc *WorkflowController) clusterClients(name string) (kubernetes.Interface, *rest.Config, error) {
+	if name == "" {
+		return wfc.kubeclientset, wfc.restConfig, nil
+	}
+	c, ok := wfc.clusters[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("cluster %q is not configured", name)
+	}
+	return c.kubeclientset, c.restConfig, nil
+}
+
+// clusterPodInformer returns the pod informer of the cluster, or of the controller's own cluster if the name is empty
+func (wfc *WorkflowController) clusterPodInformer(name string) (cache.SharedIndexInformer, error) {
+	if name == "" {
+		return wfc.podInformer, nil
+	}
+	c, ok := wfc.clusters[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not configured", name)
+	}
+	return c.podInformer, nil
+}
+
+// podsByIndex returns the pods of all the clusters in the index
+func (wfc *WorkflowController) podsByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	objs, err := wfc.podInformer.GetIndexer().ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range wfc.clusters {
+		clusterObjs, err := c.podInformer.GetIndexer().ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, clusterObjs...)
+	}
+	return objs, nil
+}
+
+// taskResultsByIndex returns the task results of all the clusters in the index
+func (wfc *WorkflowController) taskResultsByIndex(indexName, indexedValue string) ([]interface{}, error) {
+	objs, err := wfc.taskResultInformer.GetIndexer().ByIndex(indexName, indexedValue)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range wfc.clusters {
+		clusterObjs, err := c.taskResultInformer.GetIndexer().ByIndex(indexName, indexedValue)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, clusterObjs...)
+	}
+	return objs, nil
+}
+
+// podCluster returns the name of the other cluster that a watched pod runs on, or empty if it runs on the controller's
+// own cluster
+func (wfc *WorkflowController) podCluster(namespace, podName string) string {
+	for name, c := range wfc.clusters {
+		if _, exists, _ := c.podInformer.GetStore().GetByKey(namespace + "/" + podName); exists {
+			return name
+		}
+	}
+	return ""
+}
+
+// deleteClusterPods deletes the pods of a deleted workflow from the other clusters, as they are not garbage collected
+// by its owner reference
+func (wfc *WorkflowController) deleteClusterPods(ctx context.Context, namespace, workflowName string) {
+	workflowNameReq, err := labels.NewRequirement(common.LabelKeyWorkflow, selection.Equals, []string{workflowName})
+	if err != nil {
+		return
+	}
+	labelSelector := labels.NewSelector().
+		Add(*workflowNameReq).
+		Add(wfc.instanceIDReq())
+	for name, c := range wfc.clusters {
+		err := c.kubeclientset.CoreV1().Pods(namespace).DeleteCollection(ctx, metav1.DeleteOptions{}, metav1.ListOptions{LabelSelector: labelSelector.String()})
+		if err != nil {
+			log.WithFields(log.Fields{"cluster": name, "namespace": namespace, "workflow": workflowName}).WithError(err).Warn("Failed to delete the pods of a deleted workflow")
+		}
+	}
+}
+
+// clusterNamespace qualifies the namespace of a pod of another cluster with the name of its cluster, e.g.
+// "my-cluster:my-ns", so that it can be cleaned up
+func clusterNamespace(cluster, namespace string) string {
+	if cluster == "" {
+		return namespace
+	}
+	return cluster + ":" + namespace
+}
+
+// splitClusterNamespace returns the cluster and the namespace of a namespace returned by clusterNamespace
+func splitClusterNamespace(namespace string) (string, string) {
+	if i := strings.Index(namespace, ":"); i >= 0 {
+		return namespace[:i], namespace[i+1:]
+	}
+	return "", namespace
+}
+
+// forCluster changes a pod to run on another cluster, where its workflow cannot be its owner
+func forCluster(pod *apiv1.Pod, cluster string) {
+	pod.OwnerReferences = nil
+	pod.Labels[common.LabelKeyCluster] = cluster
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/workqueue"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+)
+
+// withCluster adds another cluster named "remote" to the controller, and returns its client
+func withCluster(ctx context.Context, controller *WorkflowController) *fake.Clientset {
+	kube := fake.NewSimpleClientset()
+	controller.clusters = map[string]*cluster{"remote": {
+		restConfig:         &rest.Config{},
+		kubeclientset:      kube,
+		podInformer:        controller.newPodInformer(ctx, kube),
+		taskResultInformer: controller.newWorkflowTaskResultInformer(fakewfclientset.NewSimpleClientset()),
+	}}
+	for _, synced := range controller.runClusters(ctx.Done()) {
+		for !synced() {
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	return kube
+}
+
+var clusterWf = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      cluster: remote
+      container:
+        image: my-image
+`
+
+func TestCluster(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(clusterWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx, cancelClusters := context.WithCancel(context.Background())
+	defer cancelClusters()
+	remote := withCluster(ctx, controller)
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	pods, err := listPods(woc)
+	assert.NoError(t, err)
+	assert.Empty(t, pods.Items, "the pod does not run on the controller's cluster")
+	pods, err = remote.CoreV1().Pods("my-ns").List(ctx, metav1.ListOptions{})
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+		pod := pods.Items[0]
+		assert.Equal(t, "remote", pod.Labels[common.LabelKeyCluster])
+		assert.Empty(t, pod.OwnerReferences)
+
+		pod.Status.Phase = apiv1.PodSucceeded
+		_, err = remote.CoreV1().Pods("my-ns").UpdateStatus(ctx, &pod, metav1.UpdateOptions{})
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			p, err := controller.getPod("my-ns", pod.Name)
+			return err == nil && p != nil && p.Status.Phase == apiv1.PodSucceeded
+		}, 5*time.Second, 10*time.Millisecond)
+
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+
+		t.Run("Cleanup", func(t *testing.T) {
+			controller.podCleanupQueue = newRecordingQueue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+			controller.queuePodForCleanup("my-ns", pod.Name, deletePod)
+			assert.Contains(t, controller.podCleanupQueue.(*recordingQueue).items, newPodCleanupKey("remote:my-ns", pod.Name, deletePod))
+			assert.True(t, controller.processNextPodCleanupItem(ctx))
+			pods, err := remote.CoreV1().Pods("my-ns").List(ctx, metav1.ListOptions{})
+			assert.NoError(t, err)
+			assert.Empty(t, pods.Items)
+		})
+	}
+}
+
+func TestClusterNotConfigured(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(clusterWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+	node := woc.wf.Status.Nodes.FindByDisplayName("my-wf")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, `cluster "remote" is not configured`, node.Message)
+	}
+}
+
+func TestClusterNamespace(t *testing.T) {
+	assert.Equal(t, "my-ns", clusterNamespace("", "my-ns"))
+	cluster, namespace := splitClusterNamespace(clusterNamespace("remote", "my-ns"))
+	assert.Equal(t, "remote", cluster)
+	assert.Equal(t, "my-ns", namespace)
+	cluster, namespace = splitClusterNamespace("my-ns")
+	assert.Empty(t, cluster)
+	assert.Equal(t, "my-ns", namespace)
+}
+
+func TestPodsByIndex(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx, cancelClusters := context.WithCancel(context.Background())
+	defer cancelClusters()
+	remote := withCluster(ctx, controller)
+	_, err := remote.CoreV1().Pods("my-ns").Create(ctx, &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:   "my-pod",
+		Labels: map[string]string{common.LabelKeyWorkflow: "my-wf", common.LabelKeyCompleted: "false"},
+	}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		objs, err := controller.podsByIndex(indexes.WorkflowIndex, indexes.WorkflowIndexValue("my-ns", "my-wf"))
+		return err == nil && len(objs) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "remote", controller.podCluster("my-ns", "my-pod"))
+	assert.Empty(t, controller.podCluster("my-ns", "other-pod"))
+}
@@ -3,20 +3,50 @@ package controller
 import (
 	"context"
 	"fmt"
+	"os"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/time/rate"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/yaml"
+	"upper.io/db.v3/lib/sqlbuilder"
 
 	"github.com/argoproj/argo-workflows/v3"
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/persist/archive"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
+	"github.com/argoproj/argo-workflows/v3/util/file"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
+	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/notification"
 )
 
+// setWorkflowArchive sets the workflow archive, in the archive storage or else the database of the session, if
+// archiving is enabled
+func (wfc *WorkflowController) setWorkflowArchive(session sqlbuilder.Database, persistence *config.PersistConfig) error {
+	if !persistence.Archive {
+		log.Info("Workflow archiving is disabled")
+		return nil
+	}
+	var err error
+	wfc.archiveLabelSelector, err = persistence.GetArchiveLabelSelector()
+	if err != nil {
+		return err
+	}
+	instanceIDService := instanceid.NewService(wfc.Config.InstanceID)
+	wfc.wfArchive, err = archive.New(context.Background(), wfc.kubeclientset, wfc.namespace, persistence, session, wfc.managedNamespace, instanceIDService, !persistence.SkipMigration)
+	if err != nil {
+		return err
+	}
+	log.Info("Workflow archiving is enabled")
+	return nil
+}
+
 func (wfc *WorkflowController) updateConfig() error {
 	bytes, err := yaml.Marshal(wfc.Config)
 	if err != nil {
@@ -24,12 +54,23 @@ func (wfc *WorkflowController) updateConfig() error {
 	}
 	log.Info("Configuration:\n" + string(bytes))
 	wfc.session = nil
-	wfc.artifactRepositories = artifactrepositories.New(wfc.kubeclientset, wfc.namespace, &wfc.Config.ArtifactRepository)
+	wfc.artifactRepositories = artifactrepositories.New(wfc.kubeclientset, wfc.wfclientset, wfc.namespace, &wfc.Config.ArtifactRepository)
 	wfc.offloadNodeStatusRepo = sqldb.ExplosiveOffloadNodeStatusRepo
+	wfc.offloadParametersRepo = sqldb.ExplosiveOffloadParametersRepo
 	wfc.wfArchive = sqldb.NullWorkflowArchive
+	wfc.syncLockRepo = nil
+	wfc.cacheFactory = controllercache.NewCacheFactory(wfc.kubeclientset, wfc.namespace)
 	wfc.archiveLabelSelector = labels.Everything()
 	persistence := wfc.Config.Persistence
-	if persistence != nil {
+	if persistence != nil && !persistence.DatabaseConfigured() {
+		log.Info("Persistence configuration enabled without a database")
+		if persistence.NodeStatusOffload || persistence.Synchronization != nil || persistence.Memoization {
+			return fmt.Errorf("node status offloading, synchronization and memoization need a postgresql or mysql database to be configured")
+		}
+		if err := wfc.setWorkflowArchive(nil, persistence); err != nil {
+			return err
+		}
+	} else if persistence != nil {
 		log.Info("Persistence configuration enabled")
 		session, tableName, err := sqldb.CreateDBSession(wfc.kubeclientset, wfc.namespace, persistence)
 		if err != nil {
@@ -51,28 +92,59 @@ func (wfc *WorkflowController) updateConfig() error {
 			if err != nil {
 				return err
 			}
+			wfc.offloadParametersRepo = sqldb.NewOffloadParametersRepo(session, persistence.GetClusterName())
 			log.Info("Node status offloading is enabled")
 		} else {
 			log.Info("Node status offloading is disabled")
 		}
-		if persistence.Archive {
-			instanceIDService := instanceid.NewService(wfc.Config.InstanceID)
-
-			wfc.archiveLabelSelector, err = persistence.GetArchiveLabelSelector()
-			if err != nil {
-				return err
+		if err := wfc.setWorkflowArchive(session, persistence); err != nil {
+			return err
+		}
+		if sync := persistence.Synchronization; sync != nil {
+			controllerName := sync.ControllerName
+			if controllerName == "" {
+				controllerName = wfc.controllerIdentity()
 			}
-			wfc.wfArchive = sqldb.NewWorkflowArchive(session, persistence.GetClusterName(), wfc.managedNamespace, instanceIDService)
-			log.Info("Workflow archiving is enabled")
+			wfc.syncLockRepo = sqldb.NewSyncLockRepo(session, persistence.GetClusterName(), controllerName, sync.GetInactiveControllerTTL())
+			log.WithField("controllerName", controllerName).Info("Database synchronization is enabled")
 		} else {
-			log.Info("Workflow archiving is disabled")
+			log.Info("Database synchronization is disabled")
+		}
+		if persistence.Memoization {
+			wfc.cacheFactory = controllercache.NewDatabaseCacheFactory(session, persistence.GetClusterName(), wfc.namespace)
+			log.Info("Database memoization is enabled")
+		} else {
+			log.Info("Database memoization is disabled")
 		}
 	} else {
 		log.Info("Persistence configuration disabled")
 	}
-	wfc.hydrator = hydrator.New(wfc.offloadNodeStatusRepo)
+	wfc.hydrator = hydrator.New(wfc.offloadNodeStatusRepo, hydrator.NewParametersLoader(wfc.getParametersConfigMap, wfc.offloadParametersRepo))
 	wfc.updateEstimatorFactory()
 	wfc.rateLimiter = wfc.newRateLimiter()
+	notifier, err := notification.New(wfc.Config.Notifications)
+	if err != nil {
+		return err
+	}
+	if wfc.notifier != nil {
+		wfc.notifier.Close()
+	}
+	wfc.notifier = notifier
+	wfc.admission, err = admission.New(wfc.Config.Admission)
+	if err != nil {
+		return err
+	}
+	if err := exprenv.SetFunctions(wfc.Config.ExprFunctions); err != nil {
+		return fmt.Errorf("invalid expression functions: %w", err)
+	}
+	if err := file.SetCompression(wfc.Config.Compression.Algorithm, wfc.Config.Compression.Level); err != nil {
+		return fmt.Errorf("invalid compression: %w", err)
+	}
+	for _, c := range []config.InformerConfig{wfc.Config.Informers.Workflows.InformerConfig, wfc.Config.Informers.Pods, wfc.Config.Informers.TaskResults} {
+		if _, err := c.LabelRequirements(); err != nil {
+			return err
+		}
+	}
 
 	log.WithField("executorImage", wfc.executorImage()).
 		WithField("executorImagePullPolicy", wfc.executorImagePullPolicy()).
@@ -81,6 +153,15 @@ func (wfc *WorkflowController) updateConfig() error {
 	return nil
 }
 
+// controllerIdentity returns the identity used to elect a leader, or the host name
+func (wfc *WorkflowController) controllerIdentity() string {
+	if identity, ok := os.LookupEnv("LEADER_ELECTION_IDENTITY"); ok && identity != "" {
+		return identity
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
 func (wfc *WorkflowController) newRateLimiter() *rate.Limiter {
 	return rate.NewLimiter(rate.Limit(wfc.Config.GetResourceRateLimit().Limit), wfc.Config.GetResourceRateLimit().Burst)
 }
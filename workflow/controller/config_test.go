@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
 )
 
 func TestUpdateConfig(t *testing.T) {
@@ -16,3 +18,14 @@ func TestUpdateConfig(t *testing.T) {
 	assert.NotNil(t, controller.wfArchive)
 	assert.NotNil(t, controller.offloadNodeStatusRepo)
 }
+
+func TestUpdateConfigExprFunctions(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	defer func() { _ = exprenv.SetFunctions(nil) }()
+	controller.Config.ExprFunctions = map[string]exprenv.Function{"toJson": {Expression: "1"}}
+	assert.EqualError(t, controller.updateConfig(), `invalid expression functions: function "toJson" is a built-in function`)
+	controller.Config.ExprFunctions = map[string]exprenv.Function{"double": {Params: []string{"x"}, Expression: "x * 2"}}
+	assert.NoError(t, controller.updateConfig())
+	assert.Contains(t, exprenv.GetFunctions(), "double")
+}
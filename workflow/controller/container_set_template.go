@@ -37,10 +37,11 @@ func (woc *wfOperationCtx) executeContainerSet(ctx context.Context, nodeName str
 	}
 	for _, c := range tmpl.ContainerSet.GetGraph() {
 		ctrNodeName := fmt.Sprintf("%s.%s", nodeName, c.Name)
-		if len(c.Dependencies) == 0 {
+		dependencies := c.GetDependencies()
+		if len(dependencies) == 0 {
 			woc.addChildNode(nodeName, ctrNodeName)
 		}
-		for _, v := range c.Dependencies {
+		for _, v := range dependencies {
 			woc.addChildNode(fmt.Sprintf("%s.%s", nodeName, v), ctrNodeName)
 		}
 	}
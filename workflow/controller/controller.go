@@ -11,6 +11,7 @@ import (
 	"github.com/argoproj/pkg/errors"
 	syncpkg "github.com/argoproj/pkg/sync"
 	log "github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"golang.org/x/exp/slices"
 	"golang.org/x/time/rate"
 	apiv1 "k8s.io/api/core/v1"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	apiwatch "k8s.io/client-go/tools/watch"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/pointer"
 	"upper.io/db.v3/lib/sqlbuilder"
 
 	"github.com/argoproj/argo-workflows/v3"
@@ -43,10 +45,13 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions"
 	wfextvv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/plugins/spec"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	authutil "github.com/argoproj/argo-workflows/v3/util/auth"
 	"github.com/argoproj/argo-workflows/v3/util/diff"
 	"github.com/argoproj/argo-workflows/v3/util/env"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/namespaces"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
@@ -60,6 +65,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/gccontroller"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v3/workflow/notification"
 	"github.com/argoproj/argo-workflows/v3/workflow/signal"
 	"github.com/argoproj/argo-workflows/v3/workflow/sync"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
@@ -71,6 +77,8 @@ type WorkflowController struct {
 	// namespace of the workflow controller
 	namespace        string
 	managedNamespace string
+	// managedNamespaces are the namespaces that match the --managed-namespace-selector, or nil if there is none
+	managedNamespaces *namespaces.Selector
 
 	configController config.Controller
 	// Config is the workflow controller's configuration
@@ -107,20 +115,28 @@ type WorkflowController struct {
 	wfQueue               workqueue.RateLimitingInterface
 	podCleanupQueue       workqueue.RateLimitingInterface // pods to be deleted or labelled depend on GC strategy
 	throttler             sync.Throttler
-	workflowKeyLock       syncpkg.KeyLock // used to lock workflows for exclusive modification or access
+	reconciliations       *reconciliations // the last reconciliation of each workflow, for diagnostics
+	preemptedPods         *preemptedPods   // the pods whose node preemption was handled
+	workflowKeyLock       syncpkg.KeyLock  // used to lock workflows for exclusive modification or access
 	session               sqlbuilder.Database
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
+	offloadParametersRepo sqldb.OffloadParametersRepo
 	hydrator              hydrator.Interface
 	wfArchive             sqldb.WorkflowArchive
+	syncLockRepo          sqldb.SyncLockRepo
 	estimatorFactory      estimation.EstimatorFactory
 	syncManager           *sync.Manager
 	metrics               *metrics.Metrics
+	tracerProvider        *sdktrace.TracerProvider
 	eventRecorderManager  events.EventRecorderManager
+	notifier              notification.Notifier
+	admission             admission.Interface
 	archiveLabelSelector  labels.Selector
 	cacheFactory          controllercache.Factory
 	wfTaskSetInformer     wfextvv1alpha1.WorkflowTaskSetInformer
 	artGCTaskInformer     wfextvv1alpha1.WorkflowArtifactGCTaskInformer
 	taskResultInformer    cache.SharedIndexInformer
+	clusters              map[string]*cluster // other clusters that the pods of templates run on, by name
 
 	// progressPatchTickDuration defines how often the executor will patch pod annotations if an updated progress is found.
 	// Default is 1m and can be configured using the env var ARGO_PROGRESS_PATCH_TICK_DURATION.
@@ -129,6 +145,8 @@ type WorkflowController struct {
 	// Default is 3s and can be configured using the env var ARGO_PROGRESS_FILE_TICK_DURATION
 	progressFileTickDuration time.Duration
 	executorPlugins          map[string]map[string]*spec.Plugin // namespace -> name -> plugin
+	artifactDriverPlugins    map[string]map[string]*spec.Plugin // namespace -> name -> plugin
+	informerWatchers         map[string]*informerWatcher        // name -> watcher
 }
 
 const (
@@ -151,6 +169,9 @@ var (
 	// believe it cannot run. By delaying for 1s, we would have finished the semaphore counter
 	// updates, and the next workflow will see the updated availability.
 	semaphoreNotifyDelay = env.LookupEnvDurationOr("SEMAPHORE_NOTIFY_DELAY", time.Second)
+
+	// podGCSweepPeriod is how often the completed pods kept by pod GC are checked, to delete those that are old enough
+	podGCSweepPeriod = env.LookupEnvDurationOr("POD_GC_SWEEP_PERIOD", time.Minute)
 )
 
 func init() {
@@ -160,7 +181,7 @@ func init() {
 }
 
 // NewWorkflowController instantiates a new WorkflowController
-func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubeclientset kubernetes.Interface, wfclientset wfclientset.Interface, namespace, managedNamespace, executorImage, executorImagePullPolicy, executorLogFormat, containerRuntimeExecutor, configMap string, executorPlugins bool) (*WorkflowController, error) {
+func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubeclientset kubernetes.Interface, wfclientset wfclientset.Interface, namespace, managedNamespace, managedNamespaceSelector, executorImage, executorImagePullPolicy, executorLogFormat, containerRuntimeExecutor, configMap string, executorPlugins bool) (*WorkflowController, error) {
 	dynamicInterface, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
@@ -187,26 +208,41 @@ func NewWorkflowController(ctx context.Context, restConfig *rest.Config, kubecli
 
 	if executorPlugins {
 		wfc.executorPlugins = map[string]map[string]*spec.Plugin{}
+		wfc.artifactDriverPlugins = map[string]map[string]*spec.Plugin{}
+	}
+
+	if managedNamespaceSelector != "" {
+		wfc.managedNamespaces, err = namespaces.NewSelector(kubeclientset, managedNamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	wfc.UpdateConfig(ctx)
 
 	wfc.metrics = metrics.New(wfc.getMetricsServerConfig())
+	wfc.tracerProvider = tracing.Init(ctx, "workflow-controller")
 	wfc.entrypoint = entrypoint.New(kubeclientset, wfc.Config.Images)
 
 	workqueue.SetProvider(wfc.metrics) // must execute SetProvider before we created the queues
-	wfc.wfQueue = wfc.metrics.RateLimiterWithBusyWorkers(&fixedItemIntervalRateLimiter{}, "workflow_queue")
+	wfc.wfQueue = newRecordingQueue(wfc.metrics.RateLimiterWithBusyWorkers(&fixedItemIntervalRateLimiter{}, "workflow_queue"))
 	wfc.throttler = wfc.newThrottler()
-	wfc.podCleanupQueue = wfc.metrics.RateLimiterWithBusyWorkers(workqueue.DefaultControllerRateLimiter(), "pod_cleanup_queue")
+	wfc.podCleanupQueue = newRecordingQueue(wfc.metrics.RateLimiterWithBusyWorkers(workqueue.DefaultControllerRateLimiter(), "pod_cleanup_queue"))
+	wfc.reconciliations = newReconciliations()
+	wfc.preemptedPods = newPreemptedPods()
 
 	return &wfc, nil
 }
 
 func (wfc *WorkflowController) newThrottler() sync.Throttler {
 	f := func(key string) { wfc.wfQueue.AddRateLimited(key) }
+	var preemptible sync.PreemptibleFunc
+	if wfc.Config.Preemption.Enabled {
+		preemptible = func(key string) bool { return wfc.Config.Preemption.Preemptible(sync.NamespaceBucket(key)) }
+	}
 	return sync.ChainThrottler{
-		sync.NewThrottler(wfc.Config.Parallelism, sync.SingleBucket, f),
-		sync.NewThrottler(wfc.Config.NamespaceParallelism, sync.NamespaceBucket, f),
+		sync.NewPreemptingThrottler(wfc.Config.Parallelism, sync.SingleBucket, f, preemptible),
+		sync.NewPreemptingThrottler(wfc.Config.NamespaceParallelism, sync.NamespaceBucket, f, preemptible),
 	}
 }
 
@@ -224,7 +260,7 @@ func (wfc *WorkflowController) runGCcontroller(ctx context.Context, workflowTTLW
 func (wfc *WorkflowController) runCronController(ctx context.Context) {
 	defer runtimeutil.HandleCrash(runtimeutil.PanicHandlers...)
 
-	cronController := cron.NewCronController(wfc.wfclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), wfc.Config.InstanceID, wfc.metrics, wfc.eventRecorderManager)
+	cronController := cron.NewCronController(wfc.kubeclientset, wfc.wfclientset, wfc.dynamicInterface, wfc.namespace, wfc.GetManagedNamespace(), wfc.managedNamespaces, wfc.Config.InstanceID, wfc.metrics, wfc.eventRecorderManager)
 	cronController.Run(ctx)
 }
 
@@ -245,6 +281,8 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	defer tracing.Shutdown(wfc.tracerProvider)
+
 	defer wfc.wfQueue.ShutDown()
 	defer wfc.podCleanupQueue.ShutDown()
 
@@ -256,17 +294,30 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 		WithField("podCleanup", podCleanupWorkers).
 		Info("Current Worker Numbers")
 
-	wfc.wfInformer = util.NewWorkflowInformer(wfc.dynamicInterface, wfc.GetManagedNamespace(), workflowResyncPeriod, wfc.tweakListOptions, indexers)
+	wfc.wfInformer = util.NewWorkflowInformer(wfc.dynamicInterface, wfc.GetManagedNamespace(), wfc.Config.Informers.Workflows.GetResyncPeriod(workflowResyncPeriod), wfc.tweakListOptions, indexers)
 	wfc.wftmplInformer = informer.NewTolerantWorkflowTemplateInformer(wfc.dynamicInterface, workflowTemplateResyncPeriod, wfc.managedNamespace)
 	wfc.wfTaskSetInformer = wfc.newWorkflowTaskSetInformer()
 	wfc.artGCTaskInformer = wfc.newArtGCTaskInformer()
-	wfc.taskResultInformer = wfc.newWorkflowTaskResultInformer()
+	wfc.taskResultInformer = wfc.newWorkflowTaskResultInformer(wfc.wfclientset)
 
 	wfc.addWorkflowInformerHandlers(ctx)
-	wfc.podInformer = wfc.newPodInformer(ctx)
+	wfc.addWorkflowTemplateInformerHandlers(ctx)
+	wfc.podInformer = wfc.newPodInformer(ctx, wfc.kubeclientset)
+	clusters, err := wfc.newClusters(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	wfc.clusters = clusters
 	wfc.updateEstimatorFactory()
 
 	wfc.configMapInformer = wfc.newConfigMapInformer()
+	wfc.watchInformers()
+
+	// the managed namespaces must be known before workflows are processed
+	wfc.managedNamespaces.OnMatch(func(namespace string) { wfc.onManagedNamespace(ctx, namespace) })
+	if !wfc.managedNamespaces.Run(ctx.Done()) {
+		log.Fatal("Timed out waiting for managed namespaces to sync")
+	}
 
 	// Create Synchronization Manager
 	wfc.createSynchronizationManager(ctx)
@@ -283,18 +334,21 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	go wfc.wfTaskSetInformer.Informer().Run(ctx.Done())
 	go wfc.artGCTaskInformer.Informer().Run(ctx.Done())
 	go wfc.taskResultInformer.Run(ctx.Done())
+	clustersSynced := wfc.runClusters(ctx.Done())
 	wfc.createClusterWorkflowTemplateInformer(ctx)
 
 	// Wait for all involved caches to be synced, before processing items from the queue is started
 	if !cache.WaitForCacheSync(
 		ctx.Done(),
-		wfc.wfInformer.HasSynced,
-		wfc.wftmplInformer.Informer().HasSynced,
-		wfc.podInformer.HasSynced,
-		wfc.configMapInformer.HasSynced,
-		wfc.wfTaskSetInformer.Informer().HasSynced,
-		wfc.artGCTaskInformer.Informer().HasSynced,
-		wfc.taskResultInformer.HasSynced,
+		append([]cache.InformerSynced{
+			wfc.wfInformer.HasSynced,
+			wfc.wftmplInformer.Informer().HasSynced,
+			wfc.podInformer.HasSynced,
+			wfc.configMapInformer.HasSynced,
+			wfc.wfTaskSetInformer.Informer().HasSynced,
+			wfc.artGCTaskInformer.Informer().HasSynced,
+			wfc.taskResultInformer.HasSynced,
+		}, clustersSynced...)...,
 	) {
 		log.Fatal("Timed out waiting for caches to sync")
 	}
@@ -305,15 +359,24 @@ func (wfc *WorkflowController) Run(ctx context.Context, wfWorkers, workflowTTLWo
 	for i := 0; i < podCleanupWorkers; i++ {
 		go wait.UntilWithContext(ctx, wfc.runPodCleanup, time.Second)
 	}
-	go wfc.workflowGarbageCollector(ctx.Done())
+	go wait.UntilWithContext(ctx, wfc.podGCSweep, podGCSweepPeriod)
+	go wait.UntilWithContext(ctx, wfc.pvcGCSweep, podGCSweepPeriod)
+	go wfc.workflowGarbageCollector(ctx)
 	go wfc.archivedWorkflowGarbageCollector(ctx.Done())
 
 	go wfc.runGCcontroller(ctx, workflowTTLWorkers)
 	go wfc.runCronController(ctx)
 	go wait.Until(wfc.syncWorkflowPhaseMetrics, 15*time.Second, ctx.Done())
 	go wait.Until(wfc.syncPodPhaseMetrics, 15*time.Second, ctx.Done())
+	go wait.Until(wfc.syncInformerMetrics, 15*time.Second, ctx.Done())
 
 	go wait.Until(wfc.syncManager.CheckWorkflowExistence, workflowExistenceCheckPeriod, ctx.Done())
+	go wfc.runArtifactRepositoryChecks(ctx)
+	if wfc.syncLockRepo != nil {
+		sync := wfc.Config.Persistence.Synchronization
+		go wait.Until(wfc.syncLockHeartbeat, sync.GetInactiveControllerTTL()/3, ctx.Done())
+		go wait.Until(wfc.syncManager.NotifyStoredWaiters, sync.GetPollInterval(), ctx.Done())
+	}
 
 	for i := 0; i < wfWorkers; i++ {
 		go wait.Until(wfc.runWorker, time.Second, ctx.Done())
@@ -356,7 +419,22 @@ func (wfc *WorkflowController) createSynchronizationManager(ctx context.Context)
 		return exists
 	}
 
-	wfc.syncManager = sync.NewLockManager(getSyncLimit, nextWorkflow, isWFDeleted)
+	if wfc.syncLockRepo != nil {
+		wfc.syncManager = sync.NewStoredLockManager(getSyncLimit, nextWorkflow, isWFDeleted, wfc.syncLockRepo)
+	} else {
+		wfc.syncManager = sync.NewLockManager(getSyncLimit, nextWorkflow, isWFDeleted)
+	}
+}
+
+// syncLockHeartbeat renews the semaphore holders of this controller in the database
+func (wfc *WorkflowController) syncLockHeartbeat() {
+	// the repository is reset if the configuration changes
+	if wfc.syncLockRepo == nil {
+		return
+	}
+	if err := wfc.syncLockRepo.Heartbeat(); err != nil {
+		log.WithError(err).Error("Failed to renew the semaphore holders of this controller")
+	}
 }
 
 // list all running workflows to initialize throttler and syncManager
@@ -439,6 +517,7 @@ func (wfc *WorkflowController) createClusterWorkflowTemplateInformer(ctx context
 
 	if cwftGetAllowed && cwftListAllowed && cwftWatchAllowed {
 		wfc.cwftmplInformer = informer.NewTolerantClusterWorkflowTemplateInformer(wfc.dynamicInterface, clusterWorkflowTemplateResyncPeriod)
+		wfc.addClusterWorkflowTemplateInformerHandlers(ctx)
 		go wfc.cwftmplInformer.Informer().Run(ctx.Done())
 
 		// since the above call is asynchronous, make sure we populate our cache before we try to use it later
@@ -466,11 +545,24 @@ func (wfc *WorkflowController) UpdateConfig(ctx context.Context) {
 }
 
 func (wfc *WorkflowController) queuePodForCleanup(namespace string, podName string, action podCleanupAction) {
-	wfc.podCleanupQueue.AddRateLimited(newPodCleanupKey(namespace, podName, action))
+	wfc.podCleanupQueue.AddRateLimited(newPodCleanupKey(wfc.podCleanupNamespace(namespace, podName), podName, action))
 }
 
 func (wfc *WorkflowController) queuePodForCleanupAfter(namespace string, podName string, action podCleanupAction, duration time.Duration) {
-	wfc.podCleanupQueue.AddAfter(newPodCleanupKey(namespace, podName, action), duration)
+	wfc.podCleanupQueue.AddAfter(newPodCleanupKey(wfc.podCleanupNamespace(namespace, podName), podName, action), duration)
+}
+
+func (wfc *WorkflowController) queuePodForDeletionAfter(namespace string, podName string, deleteAfter time.Time) {
+	wfc.podCleanupQueue.AddRateLimited(newPodDeleteAfterKey(wfc.podCleanupNamespace(namespace, podName), podName, deleteAfter))
+}
+
+// podCleanupNamespace qualifies the namespace of a pod with the name of its cluster, unless it already is, as the pod
+// may no longer be watched by the time it is cleaned up
+func (wfc *WorkflowController) podCleanupNamespace(namespace string, podName string) string {
+	if cluster, _ := splitClusterNamespace(namespace); cluster != "" {
+		return namespace
+	}
+	return clusterNamespace(wfc.podCluster(namespace, podName), namespace)
 }
 
 func (wfc *WorkflowController) runPodCleanup(ctx context.Context) {
@@ -494,7 +586,12 @@ func (wfc *WorkflowController) processNextPodCleanupItem(ctx context.Context) bo
 	logCtx := log.WithFields(log.Fields{"key": key, "action": action})
 	logCtx.Info("cleaning up pod")
 	err := func() error {
-		pods := wfc.kubeclientset.CoreV1().Pods(namespace)
+		cluster, podNamespace := splitClusterNamespace(namespace)
+		kubeclientset, _, err := wfc.clusterClients(cluster)
+		if err != nil {
+			return err
+		}
+		pods := kubeclientset.CoreV1().Pods(podNamespace)
 		switch action {
 		case terminateContainers:
 			if terminationGracePeriod, err := wfc.signalContainers(namespace, podName, syscall.SIGTERM); err != nil {
@@ -517,6 +614,22 @@ func (wfc *WorkflowController) processNextPodCleanupItem(ctx context.Context) bo
 			if err != nil {
 				return err
 			}
+		case labelPodDeleteAfter:
+			deleteAfter, err := parsePodDeleteAfter(key.(podCleanupKey))
+			if err != nil {
+				return err
+			}
+			// the pod is no longer watched, and is deleted by the pod GC sweep
+			_, err = pods.Patch(
+				ctx,
+				podName,
+				types.MergePatchType,
+				[]byte(fmt.Sprintf(`{"metadata": {"labels": {"%s": "true", "%s": "%d"}}}`, common.LabelKeyCompleted, common.LabelKeyDeleteAfter, deleteAfter)),
+				metav1.PatchOptions{},
+			)
+			if err != nil {
+				return err
+			}
 		case deletePod:
 			propagation := metav1.DeletePropagationBackground
 			err := pods.Delete(ctx, podName, metav1.DeleteOptions{
@@ -526,6 +639,12 @@ func (wfc *WorkflowController) processNextPodCleanupItem(ctx context.Context) bo
 			if err != nil && !apierr.IsNotFound(err) {
 				return err
 			}
+		case forceDeletePod:
+			// the pod's node may be gone, so it is not waited for
+			err := pods.Delete(ctx, podName, metav1.DeleteOptions{GracePeriodSeconds: pointer.Int64Ptr(0)})
+			if err != nil && !apierr.IsNotFound(err) {
+				return err
+			}
 		}
 		return nil
 	}()
@@ -538,8 +657,47 @@ func (wfc *WorkflowController) processNextPodCleanupItem(ctx context.Context) bo
 	return true
 }
 
+// podGCSweep queues the deletion of the completed pods kept by pod GC, once they are old enough
+func (wfc *WorkflowController) podGCSweep(ctx context.Context) {
+	labelSelector := labels.NewSelector().
+		Add(*workflowReq).
+		Add(*deleteAfterReq).
+		Add(wfc.instanceIDReq())
+	clients := map[string]kubernetes.Interface{"": wfc.kubeclientset}
+	for name, c := range wfc.clusters {
+		clients[name] = c.kubeclientset
+	}
+	for cluster, kubeclientset := range clients {
+		list, err := kubeclientset.CoreV1().Pods(wfc.GetManagedNamespace()).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+		if err != nil {
+			log.WithError(err).WithField("cluster", cluster).Error("Failed to list the pods kept by pod GC")
+			continue
+		}
+		now := time.Now().Unix()
+		for _, pod := range list.Items {
+			deleteAfter, err := strconv.ParseInt(pod.Labels[common.LabelKeyDeleteAfter], 10, 64)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{"namespace": pod.Namespace, "podName": pod.Name}).Warn("Invalid pod delete after label")
+				continue
+			}
+			if deleteAfter <= now {
+				wfc.queuePodForCleanup(clusterNamespace(cluster, pod.Namespace), pod.Name, deletePod)
+			}
+		}
+	}
+}
+
+// getPod returns a watched pod of any cluster, the namespace may be qualified with the name of its cluster
 func (wfc *WorkflowController) getPod(namespace string, podName string) (*apiv1.Pod, error) {
-	obj, exists, err := wfc.podInformer.GetStore().GetByKey(namespace + "/" + podName)
+	cluster, namespace := splitClusterNamespace(namespace)
+	if cluster == "" {
+		cluster = wfc.podCluster(namespace, podName)
+	}
+	informer, err := wfc.clusterPodInformer(cluster)
+	if err != nil {
+		return nil, err
+	}
+	obj, exists, err := informer.GetStore().GetByKey(namespace + "/" + podName)
 	if err != nil {
 		return nil, err
 	}
@@ -558,13 +716,28 @@ func (wfc *WorkflowController) signalContainers(namespace string, podName string
 	if pod == nil || err != nil {
 		return 0, err
 	}
+	cluster, _ := splitClusterNamespace(namespace)
+	_, restConfig, err := wfc.clusterClients(cluster)
+	if err != nil {
+		return 0, err
+	}
 
+	waitRunning := false
+	for _, c := range pod.Status.ContainerStatuses {
+		if c.Name == common.WaitContainerName && c.State.Running != nil {
+			waitRunning = true
+		}
+	}
 	for _, c := range pod.Status.ContainerStatuses {
 		if c.State.Running == nil {
 			continue
 		}
+		// the wait container needs the artifact driver plugins to save the outputs, they are signaled once it has
+		if waitRunning && isArtifactDriverPlugin(pod, c.Name) {
+			continue
+		}
 		// problems are already logged at info level, so we just ignore errors here
-		_ = signal.SignalContainer(wfc.restConfig, pod, c.Name, sig)
+		_ = signal.SignalContainer(restConfig, pod, c.Name, sig)
 	}
 	if pod.Spec.TerminationGracePeriodSeconds == nil {
 		return 30 * time.Second, nil
@@ -572,7 +745,7 @@ func (wfc *WorkflowController) signalContainers(namespace string, podName string
 	return time.Duration(*pod.Spec.TerminationGracePeriodSeconds) * time.Second, nil
 }
 
-func (wfc *WorkflowController) workflowGarbageCollector(stopCh <-chan struct{}) {
+func (wfc *WorkflowController) workflowGarbageCollector(ctx context.Context) {
 	defer runtimeutil.HandleCrash(runtimeutil.PanicHandlers...)
 
 	periodicity := env.LookupEnvDurationOr("WORKFLOW_GC_PERIOD", 5*time.Minute)
@@ -580,7 +753,7 @@ func (wfc *WorkflowController) workflowGarbageCollector(stopCh <-chan struct{})
 	ticker := time.NewTicker(periodicity)
 	for {
 		select {
-		case <-stopCh:
+		case <-ctx.Done():
 			ticker.Stop()
 			return
 		case <-ticker.C:
@@ -593,17 +766,42 @@ func (wfc *WorkflowController) workflowGarbageCollector(stopCh <-chan struct{})
 				}
 				log.WithField("len_wfs", len(oldRecords)).Info("Deleting old offloads that are not live")
 				for uid, versions := range oldRecords {
-					if err := wfc.deleteOffloadedNodesForWorkflow(uid, versions); err != nil {
+					if err := wfc.deleteOffloadedNodesForWorkflow(ctx, uid, versions); err != nil {
 						log.WithError(err).WithField("uid", uid).Error("Failed to delete old offloaded nodes")
 					}
 				}
 				log.Info("Workflow GC finished")
 			}
+			if wfc.offloadParametersRepo.IsEnabled() {
+				wfc.deleteOldOffloadedParameters()
+			}
+		}
+	}
+}
+
+// deleteOldOffloadedParameters deletes the parameters offloaded to the database of workflows that are not live
+func (wfc *WorkflowController) deleteOldOffloadedParameters() {
+	uids, err := wfc.offloadParametersRepo.ListOldOffloads(wfc.GetManagedNamespace())
+	if err != nil {
+		log.WithError(err).Error("Failed to list old offloaded parameters")
+		return
+	}
+	for _, uid := range uids {
+		workflows, err := wfc.wfInformer.GetIndexer().ByIndex(indexes.UIDIndex, uid)
+		if err != nil {
+			log.WithError(err).WithField("uid", uid).Error("Failed to get workflow of offloaded parameters")
+			continue
+		}
+		if len(workflows) > 0 {
+			continue
+		}
+		if err := wfc.offloadParametersRepo.Delete(uid); err != nil {
+			log.WithError(err).WithField("uid", uid).Error("Failed to delete old offloaded parameters")
 		}
 	}
 }
 
-func (wfc *WorkflowController) deleteOffloadedNodesForWorkflow(uid string, versions []string) error {
+func (wfc *WorkflowController) deleteOffloadedNodesForWorkflow(ctx context.Context, uid string, versions []string) error {
 	workflows, err := wfc.wfInformer.GetIndexer().ByIndex(indexes.UIDIndex, uid)
 	if err != nil {
 		return err
@@ -624,7 +822,7 @@ func (wfc *WorkflowController) deleteOffloadedNodesForWorkflow(uid string, versi
 		// workflow might still be hydrated
 		if wfc.hydrator.IsHydrated(wf) {
 			log.WithField("uid", wf.UID).Info("Hydrated workflow encountered")
-			err = wfc.hydrator.Dehydrate(wf)
+			err = wfc.hydrator.Dehydrate(ctx, wf)
 			if err != nil {
 				return err
 			}
@@ -656,12 +854,16 @@ func (wfc *WorkflowController) archivedWorkflowGarbageCollector(stopCh <-chan st
 		log.Info("Archive disabled - so archived workflow GC disabled - you must restart the controller if you enable this")
 		return
 	}
-	ttl := wfc.Config.Persistence.ArchiveTTL
-	if ttl == config.TTL(0) {
+	rules, err := sqldb.NewRetentionRules(*wfc.Config.Persistence)
+	if err != nil {
+		log.WithError(err).Error("Invalid archive retention - so archived workflow GC disabled - you must restart the controller if you fix this")
+		return
+	}
+	if len(rules) == 1 && rules[0].TTL == 0 {
 		log.Info("Archived workflows TTL zero - so archived workflow GC disabled - you must restart the controller if you enable this")
 		return
 	}
-	log.WithFields(log.Fields{"ttl": ttl, "periodicity": periodicity}).Info("Performing archived workflow GC")
+	log.WithFields(log.Fields{"ttl": wfc.Config.Persistence.ArchiveTTL, "rules": len(rules) - 1, "periodicity": periodicity}).Info("Performing archived workflow GC")
 	ticker := time.NewTicker(periodicity)
 	defer ticker.Stop()
 	for {
@@ -670,7 +872,10 @@ func (wfc *WorkflowController) archivedWorkflowGarbageCollector(stopCh <-chan st
 			return
 		case <-ticker.C:
 			log.Info("Performing archived workflow GC")
-			err := wfc.wfArchive.DeleteExpiredWorkflows(time.Duration(ttl))
+			deleted, err := sqldb.PruneArchive(wfc.wfArchive, rules)
+			for rule, rowsDeleted := range deleted {
+				metrics.ArchivePrunedMetric.WithLabelValues(rule).Add(float64(rowsDeleted))
+			}
 			if err != nil {
 				log.WithField("err", err).Error("Failed to delete archived workflows")
 			}
@@ -721,6 +926,11 @@ func (wfc *WorkflowController) processNextItem(ctx context.Context) bool {
 		return true
 	}
 
+	if !wfc.managedNamespaces.Matches(un.GetNamespace()) {
+		log.WithFields(log.Fields{"key": key}).Debug("Won't process Workflow since its namespace is not managed")
+		return true
+	}
+
 	wf, err := util.FromUnstructured(un)
 	if err != nil {
 		log.WithFields(log.Fields{"key": key, "error": err}).Warn("Failed to unmarshal key to workflow object")
@@ -730,18 +940,48 @@ func (wfc *WorkflowController) processNextItem(ctx context.Context) bool {
 		return true
 	}
 
-	// this will ensure we process every incomplete workflow once every 20m
-	wfc.wfQueue.AddAfter(key, workflowResyncPeriod)
+	// this will ensure we process every incomplete workflow once every 20m, or its namespace's resync period
+	if period := wfc.Config.Informers.Workflows.GetNamespaceResyncPeriod(un.GetNamespace(), workflowResyncPeriod); period > 0 {
+		wfc.wfQueue.AddAfter(key, period)
+	}
 
 	woc := newWorkflowOperationCtx(wf, wfc)
 
+	// hydrate before anything is persisted, so an offloaded or compressed workflow is never persisted without its nodes
+	err = wfc.hydrator.Hydrate(ctx, woc.wf)
+	if err != nil {
+		woc.log.Errorf("hydration failed: %v", err)
+		woc.markWorkflowError(ctx, err)
+		woc.persistUpdates(ctx)
+		return true
+	}
+	woc.setHydratedOrig()
+
+	if woc.wf.Status.Phase == wfv1.WorkflowUnknown || woc.wf.Status.Phase == wfv1.WorkflowPending {
+		if message := wfc.workflowQuotaExceeded(woc.wf); message != "" {
+			woc.holdForQuota(ctx, message)
+			wfc.wfQueue.AddAfter(key, quotaRequeueDelay)
+			return true
+		}
+	}
+	woc.releaseQuota()
+
 	if !wfc.throttler.Admit(key.(string)) {
 		log.WithField("key", key).Info("Workflow processing has been postponed due to max parallelism limit")
-		if woc.wf.Status.Phase == wfv1.WorkflowUnknown {
+		switch woc.wf.Status.Phase {
+		case wfv1.WorkflowUnknown:
 			woc.markWorkflowPhase(ctx, wfv1.WorkflowPending, "Workflow processing has been postponed because too many workflows are already running")
 			woc.persistUpdates(ctx)
+			return true
+		case wfv1.WorkflowRunning:
+			// a preempted workflow is still operated, so its pods are reconciled and it can be stopped, terminated or
+			// time out, but it does not start new nodes
+			woc.markPreempted()
+		default:
+			return true
 		}
-		return true
+	} else {
+		woc.resumePreempted()
 	}
 
 	// make sure this is removed from the throttler is complete
@@ -752,16 +992,10 @@ func (wfc *WorkflowController) processNextItem(ctx context.Context) bool {
 		}
 	}()
 
-	err = wfc.hydrator.Hydrate(woc.wf)
-	if err != nil {
-		woc.log.Errorf("hydration failed: %v", err)
-		woc.markWorkflowError(ctx, err)
-		woc.persistUpdates(ctx)
-		return true
-	}
 	startTime := time.Now()
 	woc.operate(ctx)
 	wfc.metrics.OperationCompleted(time.Since(startTime).Seconds())
+	wfc.reconciliations.record(key.(string), startTime, time.Since(startTime))
 	if woc.wf.Status.Fulfilled() {
 		err := woc.completeTaskSet(ctx)
 		if err != nil {
@@ -802,7 +1036,7 @@ func (wfc *WorkflowController) enqueueWfFromPodLabel(obj interface{}) error {
 func (wfc *WorkflowController) tweakListOptions(options *metav1.ListOptions) {
 	labelSelector := labels.NewSelector().
 		Add(util.InstanceIDRequirement(wfc.Config.InstanceID))
-	options.LabelSelector = labelSelector.String()
+	informerListOptions(wfc.Config.Informers.Workflows.InformerConfig, labelSelector)(options)
 }
 
 func getWfPriority(obj interface{}) (int32, time.Time) {
@@ -825,7 +1059,8 @@ func (wfc *WorkflowController) addWorkflowInformerHandlers(ctx context.Context)
 	wfc.wfInformer.AddEventHandler(
 		cache.FilteringResourceEventHandler{
 			FilterFunc: func(obj interface{}) bool {
-				return reconciliationNeeded(obj.(*unstructured.Unstructured))
+				un := obj.(*unstructured.Unstructured)
+				return reconciliationNeeded(un) && wfc.managedNamespaces.Matches(un.GetNamespace())
 			},
 			Handler: cache.ResourceEventHandlerFuncs{
 				AddFunc: func(obj interface{}) {
@@ -858,6 +1093,7 @@ func (wfc *WorkflowController) addWorkflowInformerHandlers(ctx context.Context)
 						wfc.releaseAllWorkflowLocks(obj)
 						// no need to add to the queue - this workflow is done
 						wfc.throttler.Remove(key)
+						wfc.reconciliations.remove(key)
 					}
 				},
 			},
@@ -884,6 +1120,9 @@ func (wfc *WorkflowController) addWorkflowInformerHandlers(ctx context.Context)
 			wf, ok := obj.(*unstructured.Unstructured)
 			if ok { // maybe cache.DeletedFinalStateUnknown
 				wfc.metrics.StopRealtimeMetricsForKey(string(wf.GetUID()))
+				if len(wfc.clusters) > 0 {
+					go wfc.deleteClusterPods(ctx, wf.GetNamespace(), wf.GetName())
+				}
 			}
 		},
 	})
@@ -912,7 +1151,7 @@ func (wfc *WorkflowController) archiveWorkflowAux(ctx context.Context, obj inter
 	if err != nil {
 		return fmt.Errorf("failed to convert to workflow from unstructured: %w", err)
 	}
-	err = wfc.hydrator.Hydrate(wf)
+	err = wfc.hydrator.Hydrate(ctx, wf)
 	if err != nil {
 		return fmt.Errorf("failed to hydrate workflow: %w", err)
 	}
@@ -950,37 +1189,40 @@ func (wfc *WorkflowController) archiveWorkflowAux(ctx context.Context, obj inter
 }
 
 var (
-	incompleteReq, _ = labels.NewRequirement(common.LabelKeyCompleted, selection.Equals, []string{"false"})
-	workflowReq, _   = labels.NewRequirement(common.LabelKeyWorkflow, selection.Exists, nil)
+	incompleteReq, _  = labels.NewRequirement(common.LabelKeyCompleted, selection.Equals, []string{"false"})
+	workflowReq, _    = labels.NewRequirement(common.LabelKeyWorkflow, selection.Exists, nil)
+	deleteAfterReq, _ = labels.NewRequirement(common.LabelKeyDeleteAfter, selection.Exists, nil)
 )
 
 func (wfc *WorkflowController) instanceIDReq() labels.Requirement {
 	return util.InstanceIDRequirement(wfc.Config.InstanceID)
 }
 
-func (wfc *WorkflowController) newWorkflowPodWatch(ctx context.Context) *cache.ListWatch {
-	c := wfc.kubeclientset.CoreV1().Pods(wfc.GetManagedNamespace())
+func (wfc *WorkflowController) newWorkflowPodWatch(ctx context.Context, kubeclientset kubernetes.Interface) *cache.ListWatch {
+	c := kubeclientset.CoreV1().Pods(wfc.GetManagedNamespace())
 	// completed=false
 	labelSelector := labels.NewSelector().
 		Add(*workflowReq).
 		Add(*incompleteReq).
 		Add(wfc.instanceIDReq())
 
+	tweakListOptions := informerListOptions(wfc.Config.Informers.Pods, labelSelector)
+
 	listFunc := func(options metav1.ListOptions) (runtime.Object, error) {
-		options.LabelSelector = labelSelector.String()
+		tweakListOptions(&options)
 		return c.List(ctx, options)
 	}
 	watchFunc := func(options metav1.ListOptions) (watch.Interface, error) {
 		options.Watch = true
-		options.LabelSelector = labelSelector.String()
+		tweakListOptions(&options)
 		return c.Watch(ctx, options)
 	}
 	return &cache.ListWatch{ListFunc: listFunc, WatchFunc: watchFunc}
 }
 
-func (wfc *WorkflowController) newPodInformer(ctx context.Context) cache.SharedIndexInformer {
-	source := wfc.newWorkflowPodWatch(ctx)
-	informer := cache.NewSharedIndexInformer(source, &apiv1.Pod{}, podResyncPeriod, cache.Indexers{
+func (wfc *WorkflowController) newPodInformer(ctx context.Context, kubeclientset kubernetes.Interface) cache.SharedIndexInformer {
+	source := wfc.newWorkflowPodWatch(ctx, kubeclientset)
+	informer := cache.NewSharedIndexInformer(source, &apiv1.Pod{}, wfc.Config.Informers.Pods.GetResyncPeriod(podResyncPeriod), cache.Indexers{
 		indexes.WorkflowIndex: indexes.MetaWorkflowIndexFunc,
 		indexes.NodeIDIndex:   indexes.MetaNodeIDIndexFunc,
 		indexes.PodPhaseIndex: indexes.PodPhaseIndexFunc,
@@ -1020,6 +1262,9 @@ func (wfc *WorkflowController) newPodInformer(ctx context.Context) cache.SharedI
 
 				// Enqueue the workflow for deleted pod
 				_ = wfc.enqueueWfFromPodLabel(obj)
+				if pod, ok := obj.(*apiv1.Pod); ok {
+					wfc.preemptedPods.remove(pod.UID)
+				}
 			},
 		},
 	)
@@ -1034,58 +1279,66 @@ func (wfc *WorkflowController) newConfigMapInformer() cache.SharedIndexInformer
 	})
 	log.WithField("executorPlugins", wfc.executorPlugins != nil).Info("Plugins")
 	if wfc.executorPlugins != nil {
-		indexInformer.AddEventHandler(cache.FilteringResourceEventHandler{
-			FilterFunc: func(obj interface{}) bool {
-				cm, err := meta.Accessor(obj)
+		indexInformer.AddEventHandler(newPluginEventHandler(common.LabelValueTypeConfigMapExecutorPlugin, wfc.executorPlugins))
+		indexInformer.AddEventHandler(newPluginEventHandler(common.LabelValueTypeConfigMapArtifactDriverPlugin, wfc.artifactDriverPlugins))
+	}
+	return indexInformer
+}
+
+// newPluginEventHandler returns a handler that keeps the plugins of the kind up to date with their configmaps
+func newPluginEventHandler(kind string, plugins map[string]map[string]*spec.Plugin) cache.ResourceEventHandler {
+	return cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			cm, err := meta.Accessor(obj)
+			if err != nil {
+				return false
+			}
+			return cm.GetLabels()[common.LabelKeyConfigMapType] == kind
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				cm := obj.(*apiv1.ConfigMap)
+				p, err := plugin.FromConfigMap(cm)
 				if err != nil {
-					return false
-				}
-				return cm.GetLabels()[common.LabelKeyConfigMapType] == common.LabelValueTypeConfigMapExecutorPlugin
-			},
-			Handler: cache.ResourceEventHandlerFuncs{
-				AddFunc: func(obj interface{}) {
-					cm := obj.(*apiv1.ConfigMap)
-					p, err := plugin.FromConfigMap(cm)
-					if err != nil {
-						log.WithField("namespace", cm.GetNamespace()).
-							WithField("name", cm.GetName()).
-							WithError(err).
-							Error("failed to convert configmap to plugin")
-						return
-					}
-					if _, ok := wfc.executorPlugins[cm.GetNamespace()]; !ok {
-						wfc.executorPlugins[cm.GetNamespace()] = map[string]*spec.Plugin{}
-					}
-					wfc.executorPlugins[cm.GetNamespace()][cm.GetName()] = p
 					log.WithField("namespace", cm.GetNamespace()).
 						WithField("name", cm.GetName()).
-						Info("Executor plugin added")
-				},
-				UpdateFunc: func(_, obj interface{}) {
-					cm := obj.(*apiv1.ConfigMap)
-					p, err := plugin.FromConfigMap(cm)
-					if err != nil {
-						log.WithField("namespace", cm.GetNamespace()).
-							WithField("name", cm.GetName()).
-							WithError(err).
-							Error("failed to convert configmap to plugin")
-						return
-					}
-					wfc.executorPlugins[cm.GetNamespace()][cm.GetName()] = p
+						WithError(err).
+						Error("failed to convert configmap to plugin")
+					return
+				}
+				if _, ok := plugins[cm.GetNamespace()]; !ok {
+					plugins[cm.GetNamespace()] = map[string]*spec.Plugin{}
+				}
+				plugins[cm.GetNamespace()][cm.GetName()] = p
+				log.WithField("namespace", cm.GetNamespace()).
+					WithField("name", cm.GetName()).
+					WithField("kind", kind).
+					Info("Plugin added")
+			},
+			UpdateFunc: func(_, obj interface{}) {
+				cm := obj.(*apiv1.ConfigMap)
+				p, err := plugin.FromConfigMap(cm)
+				if err != nil {
 					log.WithField("namespace", cm.GetNamespace()).
 						WithField("name", cm.GetName()).
-						Info("Executor plugin updated")
-				},
-				DeleteFunc: func(obj interface{}) {
-					key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
-					namespace, name, _ := cache.SplitMetaNamespaceKey(key)
-					delete(wfc.executorPlugins[namespace], name)
-					log.WithField("namespace", namespace).WithField("name", name).Info("Executor plugin removed")
-				},
+						WithError(err).
+						Error("failed to convert configmap to plugin")
+					return
+				}
+				plugins[cm.GetNamespace()][cm.GetName()] = p
+				log.WithField("namespace", cm.GetNamespace()).
+					WithField("name", cm.GetName()).
+					WithField("kind", kind).
+					Info("Plugin updated")
 			},
-		})
+			DeleteFunc: func(obj interface{}) {
+				key, _ := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+				namespace, name, _ := cache.SplitMetaNamespaceKey(key)
+				delete(plugins[namespace], name)
+				log.WithField("namespace", namespace).WithField("name", name).WithField("kind", kind).Info("Plugin removed")
+			},
+		},
 	}
-	return indexInformer
 }
 
 // call this func whenever the configuration changes, or when the workflow informer changes
@@ -1095,15 +1348,14 @@ func (wfc *WorkflowController) updateEstimatorFactory() {
 
 // setWorkflowDefaults sets values in the workflow.Spec with defaults from the
 // workflowController. Values in the workflow will be given the upper hand over the defaults.
-// The defaults for the workflow controller are set in the workflow-controller config map
+// The defaults for the workflow controller are set in the workflow-controller config map, and may be overridden by
+// the workflow defaults config maps of the workflow's namespace.
 func (wfc *WorkflowController) setWorkflowDefaults(wf *wfv1.Workflow) error {
-	if wfc.Config.WorkflowDefaults != nil {
-		err := util.MergeTo(wfc.Config.WorkflowDefaults, wf)
-		if err != nil {
-			return err
-		}
+	defaults, err := wfc.getWorkflowDefaults(wf.Namespace)
+	if err != nil {
+		return err
 	}
-	return nil
+	return util.MergeTo(defaults, wf)
 }
 
 func (wfc *WorkflowController) GetManagedNamespace() string {
@@ -1131,7 +1383,14 @@ func (wfc *WorkflowController) getMetricsServerConfig() (metrics.ServerConfig, m
 		TTL:          time.Duration(wfc.Config.MetricsConfig.MetricsTTL),
 		IgnoreErrors: wfc.Config.MetricsConfig.IgnoreErrors,
 		// Default to false until v3.5
-		Secure: wfc.Config.MetricsConfig.GetSecure(false),
+		Secure:                wfc.Config.MetricsConfig.GetSecure(false),
+		MaxCustomMetricSeries: wfc.Config.MetricsConfig.MaxCustomMetricSeries,
+		DurationHistograms: metrics.DurationHistogramsConfig{
+			Enabled:      wfc.Config.MetricsConfig.DurationHistograms.Enabled,
+			Buckets:      wfc.Config.MetricsConfig.DurationHistograms.Buckets,
+			Namespaces:   wfc.Config.MetricsConfig.DurationHistograms.Namespaces,
+			TemplateRefs: wfc.Config.MetricsConfig.DurationHistograms.TemplateRefs,
+		},
 	}
 
 	// Telemetry config
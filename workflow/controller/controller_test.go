@@ -28,6 +28,7 @@ import (
 	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/scheme"
 	wfextv "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	envutil "github.com/argoproj/argo-workflows/v3/util/env"
 	armocks "github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories/mocks"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
@@ -192,7 +193,9 @@ func newController(options ...interface{}) (context.CancelFunc, *WorkflowControl
 		wfclientset:               wfclientset,
 		workflowKeyLock:           sync.NewKeyLock(),
 		wfArchive:                 sqldb.NullWorkflowArchive,
+		offloadParametersRepo:     sqldb.ExplosiveOffloadParametersRepo,
 		hydrator:                  hydratorfake.Noop,
+		admission:                 admission.Null,
 		estimatorFactory:          estimation.DummyEstimatorFactory,
 		eventRecorderManager:      &testEventRecorderManager{eventRecorder: record.NewFakeRecorder(64)},
 		archiveLabelSelector:      labels.Everything(),
@@ -213,9 +216,11 @@ func newController(options ...interface{}) (context.CancelFunc, *WorkflowControl
 	{
 		wfc.metrics = metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{})
 		wfc.entrypoint = entrypoint.New(kube, wfc.Config.Images)
-		wfc.wfQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		wfc.wfQueue = newRecordingQueue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
 		wfc.throttler = wfc.newThrottler()
-		wfc.podCleanupQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		wfc.podCleanupQueue = newRecordingQueue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+		wfc.reconciliations = newReconciliations()
+		wfc.preemptedPods = newPreemptedPods()
 		wfc.rateLimiter = wfc.newRateLimiter()
 	}
 
@@ -224,11 +229,12 @@ func newController(options ...interface{}) (context.CancelFunc, *WorkflowControl
 		wfc.wfInformer = util.NewWorkflowInformer(dynamicClient, "", 0, wfc.tweakListOptions, indexers)
 		wfc.wfTaskSetInformer = informerFactory.Argoproj().V1alpha1().WorkflowTaskSets()
 		wfc.artGCTaskInformer = informerFactory.Argoproj().V1alpha1().WorkflowArtifactGCTasks()
-		wfc.taskResultInformer = wfc.newWorkflowTaskResultInformer()
+		wfc.taskResultInformer = wfc.newWorkflowTaskResultInformer(wfclientset)
 		wfc.wftmplInformer = informerFactory.Argoproj().V1alpha1().WorkflowTemplates()
 		wfc.addWorkflowInformerHandlers(ctx)
-		wfc.podInformer = wfc.newPodInformer(ctx)
+		wfc.podInformer = wfc.newPodInformer(ctx, kube)
 		wfc.configMapInformer = wfc.newConfigMapInformer()
+		wfc.watchInformers()
 		wfc.createSynchronizationManager(ctx)
 		_ = wfc.initManagers(ctx)
 
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/resource"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+// costPlaces is the number of decimal places of costs
+const costPlaces = 4
+
+// priceNode prices the node of the pod, if pricing is configured. Its estimated cost is the price of the resources the
+// pod requests for the estimated duration of the node, and its cost is the price of its resources duration, once it
+// is fulfilled.
+func (woc *wfOperationCtx) priceNode(pod *apiv1.Pod, node *wfv1.NodeStatus) {
+	pricing := woc.controller.Config.Pricing
+	if pricing == nil {
+		return
+	}
+	prices := pricing.GetPrices(pod.Spec.NodeSelector)
+	if node.EstimatedCost == nil && node.EstimatedDuration > 0 {
+		node.EstimatedCost = wfv1.NewAmount(prices.Cost(resource.EstimatedDurationForPod(pod, node.EstimatedDuration.ToDuration())), costPlaces)
+	}
+	if node.Cost == nil && node.Fulfilled() && node.ResourcesDuration != nil {
+		node.Cost = wfv1.NewAmount(prices.Cost(node.ResourcesDuration), costPlaces)
+	}
+}
+
+// updateWorkflowCost totals the cost, and the estimated cost, of the pod nodes of the workflow, if pricing is
+// configured. The estimated cost uses the cost of the nodes that have one.
+func (woc *wfOperationCtx) updateWorkflowCost() {
+	if woc.controller.Config.Pricing == nil {
+		return
+	}
+	cost, estimatedCost := 0.0, 0.0
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		if node.Cost != nil {
+			x, _ := node.Cost.Float64()
+			cost += x
+			estimatedCost += x
+		} else if node.EstimatedCost != nil {
+			x, _ := node.EstimatedCost.Float64()
+			estimatedCost += x
+		}
+	}
+	woc.wf.Status.Cost = wfv1.NewAmount(cost, costPlaces)
+	woc.wf.Status.EstimatedCost = wfv1.NewAmount(estimatedCost, costPlaces)
+}
+
+// observeNodeCosts counts the costs of the nodes that were priced during this execution of the operator loop
+func (woc *wfOperationCtx) observeNodeCosts(old wfv1.Nodes, new wfv1.Nodes) {
+	for nodeID, node := range new {
+		if node.Cost == nil || old[nodeID].Cost != nil {
+			continue
+		}
+		if x, err := node.Cost.Float64(); err == nil {
+			metrics.CostMetric.WithLabelValues(woc.wf.Namespace).Add(x)
+		}
+	}
+}
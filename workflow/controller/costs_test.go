@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+var pricing = &config.PricingConfig{
+	Prices: config.Prices{CPUHour: 0.5},
+	NodePrices: []config.NodePrices{
+		{NodeLabels: map[string]string{"node.kubernetes.io/lifecycle": "spot"}, Prices: config.Prices{CPUHour: 0.1}},
+	},
+}
+
+func TestPriceNode(t *testing.T) {
+	cancel, controller := newController(func(x *WorkflowController) { x.Config.Pricing = pricing })
+	defer cancel()
+	woc := newWorkflowOperationCtx(&wfv1.Workflow{}, controller)
+	pod := &apiv1.Pod{Spec: apiv1.PodSpec{Containers: []apiv1.Container{{
+		Name:      common.MainContainerName,
+		Resources: apiv1.ResourceRequirements{Requests: apiv1.ResourceList{apiv1.ResourceCPU: resource.MustParse("2")}},
+	}}}}
+
+	node := &wfv1.NodeStatus{Phase: wfv1.NodeRunning}
+	woc.priceNode(pod, node)
+	assert.Nil(t, node.EstimatedCost, "has no estimated duration")
+	assert.Nil(t, node.Cost)
+
+	node.EstimatedDuration = wfv1.NewEstimatedDuration(time.Hour)
+	woc.priceNode(pod, node)
+	assert.Equal(t, wfv1.NewAmount(1, 4), node.EstimatedCost)
+	assert.Nil(t, node.Cost, "is not fulfilled")
+
+	node.Phase = wfv1.NodeSucceeded
+	node.ResourcesDuration = wfv1.ResourcesDuration{apiv1.ResourceCPU: wfv1.NewResourceDuration(4 * time.Hour)}
+	woc.priceNode(pod, node)
+	assert.Equal(t, wfv1.NewAmount(2, 4), node.Cost)
+
+	pod.Spec.NodeSelector = map[string]string{"node.kubernetes.io/lifecycle": "spot"}
+	node = &wfv1.NodeStatus{Phase: wfv1.NodeSucceeded, EstimatedDuration: wfv1.NewEstimatedDuration(time.Hour)}
+	woc.priceNode(pod, node)
+	assert.Equal(t, wfv1.NewAmount(0.2, 4), node.EstimatedCost)
+}
+
+func TestWorkflowCost(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: cost-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+        resources:
+          requests:
+            cpu: "2"
+`)
+	cancel, controller := newController(wf, func(x *WorkflowController) { x.Config.Pricing = pricing })
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.NewAmount(0, 4), woc.wf.Status.Cost)
+
+	now := time.Now()
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded, func(pod *apiv1.Pod) {
+		pod.Status.ContainerStatuses = []apiv1.ContainerStatus{{
+			Name: common.MainContainerName,
+			State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{
+				StartedAt:  metav1.NewTime(now.Add(-time.Hour)),
+				FinishedAt: metav1.NewTime(now),
+			}},
+		}}
+	})
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+	assert.Equal(t, wfv1.NewAmount(1, 4), woc.wf.Status.Nodes[wf.Name].Cost)
+	assert.Equal(t, wfv1.NewAmount(1, 4), woc.wf.Status.Cost)
+	assert.Equal(t, wfv1.NewAmount(1, 4), woc.wf.Status.EstimatedCost)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.CostMetric.WithLabelValues("cost-ns")))
+}
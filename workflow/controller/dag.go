@@ -13,6 +13,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/expr/argoexpr"
+	"github.com/argoproj/argo-workflows/v3/util/expr/env"
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
@@ -750,6 +751,7 @@ type TaskResults struct {
 	Daemoned     bool `json:"Daemoned"`
 	AnySucceeded bool `json:"AnySucceeded"`
 	AllFailed    bool `json:"AllFailed"`
+	Streaming    bool `json:"Streaming"`
 }
 
 // evaluateDependsLogic returns whether a node should execute and proceed. proceed means that all of its dependencies are
@@ -761,12 +763,17 @@ func (d *dagContext) evaluateDependsLogic(taskName string) (bool, bool, error) {
 	}
 
 	evalScope := make(map[string]TaskResults)
+	dependsLogic := d.GetTaskDependsLogic(taskName)
 
 	for _, taskName := range d.GetTaskDependencies(taskName) {
 
-		// If the task is still running, we should not proceed.
+		// If the task is still running, we should not proceed, unless we read the pipe artifacts it is streaming
 		depNode := d.getTaskNode(taskName)
-		if depNode == nil || !depNode.Fulfilled() {
+		if depNode == nil {
+			return false, false, nil
+		}
+		streaming := isStreaming(depNode)
+		if !depNode.Fulfilled() && !(streaming && common.DependsOnTaskResult(dependsLogic, taskName, common.TaskResultStreaming)) {
 			return false, false, nil
 		}
 
@@ -805,13 +812,28 @@ func (d *dagContext) evaluateDependsLogic(taskName string) (bool, bool, error) {
 			Daemoned:     depNode.IsDaemoned() && depNode.Phase != wfv1.NodePending,
 			AnySucceeded: anySucceeded,
 			AllFailed:    allFailed,
+			Streaming:    streaming,
 		}
 	}
 
-	evalLogic := strings.Replace(d.GetTaskDependsLogic(taskName), "-", "_", -1)
-	execute, err := argoexpr.EvalBool(evalLogic, evalScope)
+	evalLogic := strings.Replace(dependsLogic, "-", "_", -1)
+	// the results of tasks take precedence over functions of the same name
+	evalEnv := map[string]interface{}{}
+	for name, f := range env.GetFunctions() {
+		evalEnv[name] = f
+	}
+	for name, results := range evalScope {
+		evalEnv[name] = results
+	}
+	execute, err := argoexpr.EvalBool(evalLogic, evalEnv)
 	if err != nil {
 		return false, false, fmt.Errorf("unable to evaluate expression '%s': %s", evalLogic, err)
 	}
 	return execute, true, nil
 }
+
+// isStreaming returns whether the pipe artifacts of a task can be read, i.e. the task has started writing them, or
+// has finished successfully
+func isStreaming(node *wfv1.NodeStatus) bool {
+	return (node.Phase == wfv1.NodeRunning || node.Phase == wfv1.NodeSucceeded) && len(node.Outputs.PipeArtifacts()) > 0
+}
@@ -11,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
@@ -462,6 +463,73 @@ func TestEvaluateDependsLogicWhenDaemonFailed(t *testing.T) {
 	assert.True(t, execute)
 }
 
+func TestEvaluateDependsLogicWhenStreaming(t *testing.T) {
+	testTasks := []wfv1.DAGTask{
+		{
+			Name: "A",
+		},
+		{
+			Name:    "B",
+			Depends: "A.Streaming",
+		},
+		{
+			Name:    "C",
+			Depends: "A",
+		},
+	}
+
+	d := &dagContext{
+		boundaryName: "test",
+		tasks:        testTasks,
+		wf:           &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "test-wf"}},
+		dependencies: make(map[string][]string),
+		dependsLogic: make(map[string]string),
+	}
+
+	// Task A is running, but is not yet streaming any pipe artifacts
+	d.wf = &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-wf"},
+		Status: wfv1.WorkflowStatus{
+			Nodes: map[string]wfv1.NodeStatus{
+				d.taskNodeID("A"): {Phase: wfv1.NodeRunning},
+			},
+		},
+	}
+
+	execute, proceed, err := d.evaluateDependsLogic("B")
+	assert.NoError(t, err)
+	assert.False(t, proceed)
+	assert.False(t, execute)
+
+	// Task A is streaming a pipe artifact
+	outputs := &wfv1.Outputs{Artifacts: wfv1.Artifacts{{Name: "out", Path: "/work/out", Pipe: &wfv1.PipeArtifact{Volume: "workdir", SubPath: "out"}}}}
+	d.wf.Status.Nodes[d.taskNodeID("A")] = wfv1.NodeStatus{Phase: wfv1.NodeRunning, Outputs: outputs}
+
+	// Task B should proceed and execute, task C must still wait for task A to complete
+	execute, proceed, err = d.evaluateDependsLogic("B")
+	assert.NoError(t, err)
+	assert.True(t, proceed)
+	assert.True(t, execute)
+	execute, proceed, err = d.evaluateDependsLogic("C")
+	assert.NoError(t, err)
+	assert.False(t, proceed)
+	assert.False(t, execute)
+
+	// Task A succeeded, so its pipe artifacts can still be read
+	d.wf.Status.Nodes[d.taskNodeID("A")] = wfv1.NodeStatus{Phase: wfv1.NodeSucceeded, Outputs: outputs}
+	execute, proceed, err = d.evaluateDependsLogic("B")
+	assert.NoError(t, err)
+	assert.True(t, proceed)
+	assert.True(t, execute)
+
+	// Task A failed
+	d.wf.Status.Nodes[d.taskNodeID("A")] = wfv1.NodeStatus{Phase: wfv1.NodeFailed, Outputs: outputs}
+	execute, proceed, err = d.evaluateDependsLogic("B")
+	assert.NoError(t, err)
+	assert.True(t, proceed)
+	assert.False(t, execute)
+}
+
 func TestEvaluateDependsLogicWhenTaskOmitted(t *testing.T) {
 	testTasks := []wfv1.DAGTask{
 		{
@@ -498,6 +566,30 @@ func TestEvaluateDependsLogicWhenTaskOmitted(t *testing.T) {
 	assert.True(t, execute)
 }
 
+func TestEvaluateDependsLogicWithFunctions(t *testing.T) {
+	assert.NoError(t, exprenv.SetFunctions(map[string]exprenv.Function{
+		"either": {Params: []string{"a", "b"}, Expression: "a || b"},
+		"A":      {Expression: "false"},
+	}))
+	defer func() { _ = exprenv.SetFunctions(nil) }()
+	d := &dagContext{
+		boundaryName: "test",
+		tasks: []wfv1.DAGTask{
+			{Name: "A"},
+			{Name: "B", Depends: "either(A.Failed, A.Skipped) && !A.Succeeded"},
+		},
+		wf:           &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "test-wf"}},
+		dependencies: make(map[string][]string),
+		dependsLogic: make(map[string]string),
+	}
+	d.wf.Status.Nodes = map[string]wfv1.NodeStatus{d.taskNodeID("A"): {Phase: wfv1.NodeSkipped}}
+
+	execute, proceed, err := d.evaluateDependsLogic("B")
+	assert.NoError(t, err)
+	assert.True(t, proceed)
+	assert.True(t, execute)
+}
+
 func TestAllEvaluateDependsLogic(t *testing.T) {
 	statusMap := map[common.TaskResult]wfv1.NodePhase{
 		common.TaskResultSucceeded: wfv1.NodeSucceeded,
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	httppprof "net/http/pprof"
+	"os"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// diagnosticsToken must be sent in the common.HeaderDiagnosticsToken header of requests to the diagnostics endpoints,
+// which are disabled if it is empty
+var diagnosticsToken = os.Getenv("ARGO_DIAGNOSTICS_TOKEN")
+
+// recordingQueue is a work queue that records the keys waiting in it, so they can be dumped
+type recordingQueue struct {
+	workqueue.RateLimitingInterface
+	mutex sync.Mutex
+	items map[interface{}]QueuedItem
+}
+
+// QueuedItem is a key waiting in a work queue
+type QueuedItem struct {
+	Key     string    `json:"key"`
+	AddedAt time.Time `json:"addedAt"`
+	// ReadyAt is when the key can be taken from the queue, keys added rate limited are assumed to be ready immediately
+	ReadyAt  time.Time `json:"readyAt"`
+	Requeues int       `json:"requeues"`
+}
+
+func newRecordingQueue(queue workqueue.RateLimitingInterface) *recordingQueue {
+	return &recordingQueue{RateLimitingInterface: queue, items: map[interface{}]QueuedItem{}}
+}
+
+func (q *recordingQueue) record(item interface{}, delay time.Duration) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	now := time.Now()
+	readyAt := now.Add(delay)
+	if existing, ok := q.items[item]; ok {
+		// like the queue itself, the earliest time the key is ready wins
+		if readyAt.After(existing.ReadyAt) {
+			readyAt = existing.ReadyAt
+		}
+		now = existing.AddedAt
+	}
+	q.items[item] = QueuedItem{Key: fmt.Sprint(item), AddedAt: now, ReadyAt: readyAt}
+}
+
+func (q *recordingQueue) Add(item interface{}) {
+	q.record(item, 0)
+	q.RateLimitingInterface.Add(item)
+}
+
+func (q *recordingQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.record(item, duration)
+	q.RateLimitingInterface.AddAfter(item, duration)
+}
+
+func (q *recordingQueue) AddRateLimited(item interface{}) {
+	q.record(item, 0)
+	q.RateLimitingInterface.AddRateLimited(item)
+}
+
+func (q *recordingQueue) Get() (interface{}, bool) {
+	item, shutdown := q.RateLimitingInterface.Get()
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	delete(q.items, item)
+	return item, shutdown
+}
+
+// Items returns the keys waiting in the queue, ordered by when they are ready
+func (q *recordingQueue) Items() []QueuedItem {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	items := make([]QueuedItem, 0, len(q.items))
+	for item, queued := range q.items {
+		queued.Requeues = q.NumRequeues(item)
+		items = append(items, queued)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ReadyAt.Before(items[j].ReadyAt) })
+	return items
+}
+
+// Reconciliation is the last reconciliation of a workflow
+type Reconciliation struct {
+	Key       string    `json:"key"`
+	StartedAt time.Time `json:"startedAt"`
+	Seconds   float64   `json:"seconds"`
+}
+
+// reconciliations records the last reconciliation of each workflow, until the workflow is deleted
+type reconciliations struct {
+	mutex sync.Mutex
+	items map[string]Reconciliation
+}
+
+func newReconciliations() *reconciliations {
+	return &reconciliations{items: map[string]Reconciliation{}}
+}
+
+func (r *reconciliations) record(key string, startedAt time.Time, duration time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.items[key] = Reconciliation{Key: key, StartedAt: startedAt, Seconds: duration.Seconds()}
+}
+
+func (r *reconciliations) remove(key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.items, key)
+}
+
+// slowest returns the reconciliations, slowest first
+func (r *reconciliations) slowest() []Reconciliation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	items := make([]Reconciliation, 0, len(r.items))
+	for _, item := range r.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Seconds > items[j].Seconds })
+	return items
+}
+
+// Diagnostics serves `GET /diagnostics/dump`, a gzipped tar of the controller's work queues, last reconciliations,
+// informer cache sizes and goroutines, the `pprof` profiles at `GET /diagnostics/pprof/{profile}`, and the log levels
+// at `GET /diagnostics/log-levels`, which are changed by `PUT /diagnostics/log-levels`.
+func (wfc *WorkflowController) Diagnostics(w http.ResponseWriter, r *http.Request) {
+	if diagnosticsToken == "" {
+		http.Error(w, "diagnostics are disabled, set ARGO_DIAGNOSTICS_TOKEN to enable them", http.StatusNotFound)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(common.HeaderDiagnosticsToken)), []byte(diagnosticsToken)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/diagnostics/")
+	if path == "log-levels" {
+		logging.ServeLevels(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	switch {
+	case path == "dump":
+		log.Info("Dumping diagnostics")
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "argo-diagnostics.tgz"))
+		if err := wfc.dumpDiagnostics(w); err != nil {
+			log.WithError(err).Error("Failed to dump diagnostics")
+		}
+	case path == "pprof/profile":
+		httppprof.Profile(w, r)
+	case path == "pprof/trace":
+		httppprof.Trace(w, r)
+	case strings.HasPrefix(path, "pprof/") && pprof.Lookup(strings.TrimPrefix(path, "pprof/")) != nil:
+		httppprof.Handler(strings.TrimPrefix(path, "pprof/")).ServeHTTP(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// dumpDiagnostics writes the diagnostics bundle as a gzipped tar
+func (wfc *WorkflowController) dumpDiagnostics(out io.Writer) error {
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	now := time.Now()
+	write := func(name string, data []byte) error {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: now}); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(data)
+		return err
+	}
+	writeJSON := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return write(name, data)
+	}
+	if err := writeJSON("queues.json", map[string]interface{}{
+		"workflow_queue":    queueDiagnostics(wfc.wfQueue),
+		"pod_cleanup_queue": queueDiagnostics(wfc.podCleanupQueue),
+	}); err != nil {
+		return err
+	}
+	if err := writeJSON("reconciliations.json", wfc.reconciliations.slowest()); err != nil {
+		return err
+	}
+	if err := writeJSON("informers.json", wfc.informerCacheSizes()); err != nil {
+		return err
+	}
+	for name, debug := range map[string]int{"goroutines.txt": 2, "goroutine.pb.gz": 0} {
+		buf := &bytes.Buffer{}
+		if err := pprof.Lookup("goroutine").WriteTo(buf, debug); err != nil {
+			return err
+		}
+		if err := write(name, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+func queueDiagnostics(queue workqueue.RateLimitingInterface) map[string]interface{} {
+	diagnostics := map[string]interface{}{"length": queue.Len()}
+	if q, ok := queue.(*recordingQueue); ok {
+		diagnostics["items"] = q.Items()
+	}
+	return diagnostics
+}
+
+// informerCacheSizes returns the number of objects in each informer's cache
+func (wfc *WorkflowController) informerCacheSizes() map[string]int {
+	sizes := map[string]int{}
+	for name, informer := range map[string]cache.SharedIndexInformer{
+		"workflows":           wfc.wfInformer,
+		"pods":                wfc.podInformer,
+		"configmaps":          wfc.configMapInformer,
+		"workflowtaskresults": wfc.taskResultInformer,
+	} {
+		if informer != nil {
+			sizes[name] = len(informer.GetStore().ListKeys())
+		}
+	}
+	if wfc.wftmplInformer != nil {
+		sizes["workflowtemplates"] = len(wfc.wftmplInformer.Informer().GetStore().ListKeys())
+	}
+	if wfc.cwftmplInformer != nil {
+		sizes["clusterworkflowtemplates"] = len(wfc.cwftmplInformer.Informer().GetStore().ListKeys())
+	}
+	if wfc.wfTaskSetInformer != nil {
+		sizes["workflowtasksets"] = len(wfc.wfTaskSetInformer.Informer().GetStore().ListKeys())
+	}
+	if wfc.artGCTaskInformer != nil {
+		sizes["workflowartifactgctasks"] = len(wfc.artGCTaskInformer.Informer().GetStore().ListKeys())
+	}
+	return sizes
+}
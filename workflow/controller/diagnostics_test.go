@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestRecordingQueue(t *testing.T) {
+	q := newRecordingQueue(workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()))
+	defer q.ShutDown()
+	q.AddAfter("my-ns/later", time.Hour)
+	q.Add("my-ns/now")
+	q.AddAfter("my-ns/now", time.Hour)
+	items := q.Items()
+	require.Len(t, items, 2)
+	assert.Equal(t, "my-ns/now", items[0].Key)
+	assert.Equal(t, items[0].AddedAt, items[0].ReadyAt)
+	assert.Equal(t, "my-ns/later", items[1].Key)
+	assert.True(t, items[1].ReadyAt.After(items[1].AddedAt))
+
+	key, _ := q.Get()
+	assert.Equal(t, "my-ns/now", key)
+	q.Done(key)
+	items = q.Items()
+	require.Len(t, items, 1)
+	assert.Equal(t, "my-ns/later", items[0].Key)
+}
+
+func TestReconciliations(t *testing.T) {
+	r := newReconciliations()
+	now := time.Now()
+	r.record("my-ns/fast", now, time.Second)
+	r.record("my-ns/slow", now, time.Minute)
+	r.record("my-ns/deleted", now, time.Hour)
+	r.remove("my-ns/deleted")
+	slowest := r.slowest()
+	require.Len(t, slowest, 2)
+	assert.Equal(t, "my-ns/slow", slowest[0].Key)
+	assert.Equal(t, 60.0, slowest[0].Seconds)
+	assert.Equal(t, "my-ns/fast", slowest[1].Key)
+}
+
+func TestDiagnostics(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	controller.wfQueue.Add("my-ns/my-wf")
+	controller.reconciliations.record("my-ns/my-wf", time.Now(), time.Second)
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			r.Header.Set(common.HeaderDiagnosticsToken, token)
+		}
+		w := httptest.NewRecorder()
+		controller.Diagnostics(w, r)
+		return w
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("/diagnostics/dump", "").Code)
+	})
+
+	diagnosticsToken = "my-token"
+	defer func() { diagnosticsToken = "" }()
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, get("/diagnostics/dump", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, get("/diagnostics/dump", "not-my-token").Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, get("/diagnostics/pprof/not-a-profile", "my-token").Code)
+	})
+	t.Run("PProf", func(t *testing.T) {
+		w := get("/diagnostics/pprof/goroutine?debug=1", "my-token")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "goroutine profile")
+	})
+	t.Run("LogLevels", func(t *testing.T) {
+		defer logging.SetLevels(logging.GetLevels())
+		put := func(body string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodPut, "/diagnostics/log-levels", strings.NewReader(body))
+			r.Header.Set(common.HeaderDiagnosticsToken, "my-token")
+			w := httptest.NewRecorder()
+			controller.Diagnostics(w, r)
+			return w
+		}
+		assert.Equal(t, http.StatusBadRequest, put("operator=verbose").Code)
+		w := put("warn,artifact-gc=debug")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "warning,artifact-gc=debug", w.Body.String())
+		assert.Equal(t, "warning,artifact-gc=debug", get("/diagnostics/log-levels", "my-token").Body.String())
+		assert.Equal(t, "warning,artifact-gc=debug", getExecutorLogLevel())
+	})
+	t.Run("Dump", func(t *testing.T) {
+		w := get("/diagnostics/dump", "my-token")
+		require.Equal(t, http.StatusOK, w.Code)
+		gzipReader, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		files := map[string][]byte{}
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			files[header.Name], err = io.ReadAll(tarReader)
+			require.NoError(t, err)
+		}
+		assert.Len(t, files, 5)
+		assert.Contains(t, string(files["goroutines.txt"]), "goroutine")
+		assert.NotEmpty(t, files["goroutine.pb.gz"])
+
+		var queues map[string]struct {
+			Length int          `json:"length"`
+			Items  []QueuedItem `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(files["queues.json"], &queues))
+		assert.Equal(t, 1, queues["workflow_queue"].Length)
+		if assert.Len(t, queues["workflow_queue"].Items, 1) {
+			assert.Equal(t, "my-ns/my-wf", queues["workflow_queue"].Items[0].Key)
+		}
+		assert.Equal(t, 0, queues["pod_cleanup_queue"].Length)
+
+		var reconciliations []Reconciliation
+		require.NoError(t, json.Unmarshal(files["reconciliations.json"], &reconciliations))
+		if assert.Len(t, reconciliations, 1) {
+			assert.Equal(t, "my-ns/my-wf", reconciliations[0].Key)
+		}
+
+		var informers map[string]int
+		require.NoError(t, json.Unmarshal(files["informers.json"], &informers))
+		assert.Contains(t, informers, "workflows")
+		assert.Contains(t, informers, "pods")
+	})
+}
@@ -35,10 +35,11 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 	case apiv1.PodPending, apiv1.PodRunning:
 		// Check if we are currently shutting down
 		if woc.GetShutdownStrategy().Enabled() {
-			// Only delete pods that are not part of an onExit handler if we are "Stopping" or all pods if we are "Terminating"
+			// Only delete pods that are not part of an onExit handler if we are "Stopping" or all pods if we are "Terminating",
+			// but no pods if we are "Draining"
 			_, onExitPod := pod.Labels[common.LabelKeyOnExit]
 
-			if !woc.GetShutdownStrategy().ShouldExecute(onExitPod) {
+			if woc.GetShutdownStrategy().ShouldTerminate(onExitPod) {
 				woc.log.WithField("podName", pod.Name).
 					WithField("shutdownStrategy", woc.GetShutdownStrategy()).
 					Info("Terminating pod as part of workflow shutdown")
@@ -64,7 +65,7 @@ func (woc *wfOperationCtx) applyExecutionControl(pod *apiv1.Pod, wfNodesLock *sy
 		}
 	}
 	if woc.GetShutdownStrategy().Enabled() {
-		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; !woc.GetShutdownStrategy().ShouldExecute(onExitPod) {
+		if _, onExitPod := pod.Labels[common.LabelKeyOnExit]; woc.GetShutdownStrategy().ShouldTerminate(onExitPod) {
 			woc.log.WithField("podName", pod.Name).
 				Info("Terminating on-exit pod")
 			woc.controller.queuePodForCleanup(woc.wf.Namespace, pod.Name, terminateContainers)
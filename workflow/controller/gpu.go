@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// addGPUs requests the GPUs of the template for the main container of the pod, using the extended resource of the
+// vendor's device plugin, and sets the runtime class of the vendor, unless the pod has one
+func (woc *wfOperationCtx) addGPUs(pod *apiv1.Pod, tmpl *wfv1.Template) error {
+	gpu := tmpl.GPU
+	if gpu == nil {
+		return nil
+	}
+	vendor, ok := woc.controller.Config.GetGPUVendor(gpu.GetVendor())
+	if !ok {
+		return fmt.Errorf("unknown GPU vendor %q", gpu.GetVendor())
+	}
+	name, err := vendor.GetResource(gpu.MIGProfile)
+	if err != nil {
+		return fmt.Errorf("GPU vendor %q: %w", gpu.GetVendor(), err)
+	}
+	count := *resource.NewQuantity(int64(gpu.Count), resource.DecimalSI)
+	for i, c := range pod.Spec.Containers {
+		if c.Name != common.MainContainerName {
+			continue
+		}
+		// extended resources cannot be overcommitted, so the request, if any, must equal the limit
+		if c.Resources.Limits == nil {
+			c.Resources.Limits = apiv1.ResourceList{}
+		}
+		c.Resources.Limits[name] = count
+		if c.Resources.Requests != nil {
+			c.Resources.Requests[name] = count
+		}
+		pod.Spec.Containers[i] = c
+	}
+	if vendor.RuntimeClassName != "" && pod.Spec.RuntimeClassName == nil {
+		pod.Spec.RuntimeClassName = &vendor.RuntimeClassName
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const gpuWorkflow = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: nvidia/cuda
+        command: [nvidia-smi]
+        resources:
+          requests:
+            cpu: "1"
+`
+
+func TestAddGPUs(t *testing.T) {
+	ctx := context.Background()
+	mainContainer := func(t *testing.T, woc *wfOperationCtx) apiv1.Container {
+		pods, err := listPods(woc)
+		assert.NoError(t, err)
+		if assert.Len(t, pods.Items, 1) {
+			for _, c := range pods.Items[0].Spec.Containers {
+				if c.Name == "main" {
+					return c
+				}
+			}
+		}
+		return apiv1.Container{}
+	}
+	t.Run("GPUs", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(gpuWorkflow)
+		wf.Spec.Templates[0].GPU = &wfv1.GPU{Count: 2}
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		c := mainContainer(t, woc)
+		assert.Equal(t, resource.MustParse("2"), c.Resources.Limits["nvidia.com/gpu"])
+		assert.Equal(t, resource.MustParse("2"), c.Resources.Requests["nvidia.com/gpu"])
+		assert.Equal(t, resource.MustParse("1"), c.Resources.Requests[apiv1.ResourceCPU])
+	})
+	t.Run("MIGProfile", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(gpuWorkflow)
+		wf.Spec.Templates[0].GPU = &wfv1.GPU{Count: 1, MIGProfile: "1g.5gb"}
+		cancel, controller := newController(wf, func(x *WorkflowController) {
+			x.Config.GPUVendors = map[wfv1.GPUVendor]config.GPUVendorConfig{wfv1.GPUVendorNVIDIA: {RuntimeClassName: "nvidia"}}
+		})
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		c := mainContainer(t, woc)
+		assert.Equal(t, resource.MustParse("1"), c.Resources.Limits["nvidia.com/mig-1g.5gb"])
+		pods, err := listPods(woc)
+		if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) && assert.NotNil(t, pods.Items[0].Spec.RuntimeClassName) {
+			assert.Equal(t, "nvidia", *pods.Items[0].Spec.RuntimeClassName)
+		}
+	})
+	t.Run("UnknownVendor", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(gpuWorkflow)
+		wf.Spec.Templates[0].GPU = &wfv1.GPU{Count: 1, Vendor: "habana"}
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Nodes[wf.Name].Message, `unknown GPU vendor "habana"`)
+	})
+}
@@ -1,14 +1,49 @@
 package controller
 
 import (
+	"encoding/json"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/template"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
-func (woc *wfOperationCtx) executeHTTPTemplate(nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) *wfv1.NodeStatus {
+func (woc *wfOperationCtx) executeHTTPTemplate(nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) (*wfv1.NodeStatus, error) {
 	node := woc.wf.GetNodeByName(nodeName)
 	if node == nil {
 		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeHTTP, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending)
+		if tmpl.HTTP.OutputArtifact != "" {
+			if err := woc.addAgentArchiveLocation(tmpl, node.ID); err != nil {
+				return node, err
+			}
+		}
 		woc.taskSet[node.ID] = *tmpl
 	}
-	return node
+	return node, nil
+}
+
+// addAgentArchiveLocation adds the archive location to a template executed by the agent. The agent does not create a
+// pod per node, so the node ID is used in place of the pod name in the key.
+func (woc *wfOperationCtx) addAgentArchiveLocation(tmpl *wfv1.Template, nodeID string) error {
+	woc.addArchiveLocation(tmpl)
+	if tmpl.ArchiveLocation == nil {
+		return nil
+	}
+	params := woc.globalParams.DeepCopy()
+	params[common.LocalVarPodName] = nodeID
+	data, err := json.Marshal(tmpl.ArchiveLocation)
+	if err != nil {
+		return errors.InternalWrapError(err)
+	}
+	data2, err := template.Replace(string(data), params, true)
+	if err != nil {
+		return err
+	}
+	location := &wfv1.ArtifactLocation{}
+	if err := json.Unmarshal([]byte(data2), location); err != nil {
+		return errors.InternalWrapError(err)
+	}
+	tmpl.ArchiveLocation = location
+	return nil
 }
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/google/go-containerregistry/pkg/name"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// addImagePullSecrets adds the image pull secrets of the template, and those configured for the registries of the
+// images of the pod's containers, to the pod, unless it already has them
+func (woc *wfOperationCtx) addImagePullSecrets(pod *apiv1.Pod, tmpl *wfv1.Template) {
+	add := func(secret apiv1.LocalObjectReference) {
+		for _, s := range pod.Spec.ImagePullSecrets {
+			if s.Name == secret.Name {
+				return
+			}
+		}
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, secret)
+	}
+	for _, s := range tmpl.ImagePullSecrets {
+		add(s)
+	}
+	registrySecrets := woc.controller.Config.RegistryImagePullSecrets
+	if len(registrySecrets) == 0 {
+		return
+	}
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if secret, ok := registrySecrets[imageRegistry(c.Image)]; ok {
+			add(apiv1.LocalObjectReference{Name: secret})
+		}
+	}
+}
+
+// imageRegistry returns the hostname of the registry of the image, "docker.io" for Docker Hub, or "" if the image
+// cannot be parsed
+func imageRegistry(image string) string {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return ""
+	}
+	registry := ref.Context().RegistryStr()
+	if registry == name.DefaultRegistry {
+		return "docker.io"
+	}
+	return registry
+}
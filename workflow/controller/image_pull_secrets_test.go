@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const imagePullSecretsWorkflow = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  imagePullSecrets:
+    - name: wf-secret
+  templates:
+    - name: main
+      imagePullSecrets:
+        - name: wf-secret
+        - name: tmpl-secret
+      container:
+        image: ghcr.io/my-org/my-image:v1
+        command: [main]
+      sidecars:
+        - name: proxy
+          image: quay.io/my-org/proxy:v1
+          command: [proxy]
+`
+
+func TestAddImagePullSecrets(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(imagePullSecretsWorkflow)
+	cancel, controller := newController(wf, func(x *WorkflowController) {
+		x.Config.RegistryImagePullSecrets = map[string]string{"ghcr.io": "ghcr-secret", "quay.io": "quay-secret", "gcr.io": "gcr-secret"}
+	})
+	defer cancel()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	assert.NoError(t, err)
+	if assert.Len(t, pods.Items, 1) {
+		assert.Equal(t, []apiv1.LocalObjectReference{{Name: "wf-secret"}, {Name: "tmpl-secret"}, {Name: "quay-secret"}, {Name: "ghcr-secret"}}, pods.Items[0].Spec.ImagePullSecrets)
+	}
+}
+
+func TestImageRegistry(t *testing.T) {
+	assert.Equal(t, "docker.io", imageRegistry("argoproj/argosay:v2"))
+	assert.Equal(t, "docker.io", imageRegistry("docker.io/library/alpine"))
+	assert.Equal(t, "ghcr.io", imageRegistry("ghcr.io/my-org/my-image:v1"))
+	assert.Equal(t, "localhost:5000", imageRegistry("localhost:5000/my-image"))
+	assert.Equal(t, "", imageRegistry("{{inputs.parameters.image}}"))
+}
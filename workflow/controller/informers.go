@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+// informerListOptions returns a func that narrows the list and watch requests of an informer to its configured label
+// selector, in addition to the controller's own, and lists its objects in chunks of its configured size
+func informerListOptions(c config.InformerConfig, selector labels.Selector) func(options *metav1.ListOptions) {
+	requirements, _ := c.LabelRequirements() // already validated by updateConfig
+	selector = selector.Add(requirements...)
+	return func(options *metav1.ListOptions) {
+		options.LabelSelector = selector.String()
+		// a zero limit means the whole list is wanted, e.g. because a chunked list expired
+		if c.ChunkSize > 0 && options.Limit > 0 {
+			options.Limit = c.ChunkSize
+		}
+	}
+}
+
+// informerWatcher records when an informer last received a change from its watch, rather than a resync, to report
+// how stale its cache may be
+type informerWatcher struct {
+	informer   cache.SharedIndexInformer
+	lastChange int64 // unix nanoseconds, accessed atomically
+}
+
+func newInformerWatcher(informer cache.SharedIndexInformer) *informerWatcher {
+	w := &informerWatcher{informer: informer, lastChange: time.Now().UnixNano()}
+	changed := func() { atomic.StoreInt64(&w.lastChange, time.Now().UnixNano()) }
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { changed() },
+		UpdateFunc: func(old, new interface{}) {
+			oldObj, oldErr := meta.Accessor(old)
+			newObj, newErr := meta.Accessor(new)
+			if oldErr == nil && newErr == nil && oldObj.GetResourceVersion() == newObj.GetResourceVersion() {
+				return // a resync
+			}
+			changed()
+		},
+		DeleteFunc: func(interface{}) { changed() },
+	})
+	return w
+}
+
+func (w *informerWatcher) lag() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&w.lastChange)))
+}
+
+func (wfc *WorkflowController) watchInformers() {
+	wfc.informerWatchers = map[string]*informerWatcher{
+		"workflows":    newInformerWatcher(wfc.wfInformer),
+		"pods":         newInformerWatcher(wfc.podInformer),
+		"task_results": newInformerWatcher(wfc.taskResultInformer),
+	}
+}
+
+func (wfc *WorkflowController) syncInformerMetrics() {
+	defer runtimeutil.HandleCrash(runtimeutil.PanicHandlers...)
+
+	for name, w := range wfc.informerWatchers {
+		metrics.InformerCacheSizeMetric.WithLabelValues(name).Set(float64(len(w.informer.GetStore().ListKeys())))
+		metrics.InformerLagMetric.WithLabelValues(name).Set(w.lag().Seconds())
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+func TestInformerListOptions(t *testing.T) {
+	selector, err := labels.Parse("a=b")
+	assert.NoError(t, err)
+	tweak := informerListOptions(config.InformerConfig{ChunkSize: 100, LabelSelector: "tenant in (x,y)"}, selector)
+
+	options := &metav1.ListOptions{Limit: 500}
+	tweak(options)
+	assert.Equal(t, "a=b,tenant in (x,y)", options.LabelSelector)
+	assert.Equal(t, int64(100), options.Limit)
+
+	t.Run("FullList", func(t *testing.T) {
+		options := &metav1.ListOptions{}
+		tweak(options)
+		assert.Zero(t, options.Limit)
+	})
+	t.Run("Default", func(t *testing.T) {
+		options := &metav1.ListOptions{Limit: 500}
+		informerListOptions(config.InformerConfig{}, selector)(options)
+		assert.Equal(t, "a=b", options.LabelSelector)
+		assert.Equal(t, int64(500), options.Limit)
+	})
+}
+
+func TestSyncInformerMetrics(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+
+	_, err := controller.kubeclientset.CoreV1().Pods("my-ns").Create(ctx, &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:   "my-pod",
+		Labels: map[string]string{common.LabelKeyWorkflow: "my-wf", common.LabelKeyCompleted: "false"},
+	}}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(controller.podInformer.GetStore().ListKeys()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	controller.syncInformerMetrics()
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.InformerCacheSizeMetric.WithLabelValues("pods")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.InformerCacheSizeMetric.WithLabelValues("workflows")))
+	assert.Less(t, testutil.ToFloat64(metrics.InformerLagMetric.WithLabelValues("pods")), 5.0)
+}
+
+func TestUpdateConfigInvalidInformerLabelSelector(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	controller.Config.Informers.Pods.LabelSelector = "!!"
+	assert.ErrorContains(t, controller.updateConfig(), "invalid informer label selector")
+}
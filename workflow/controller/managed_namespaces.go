@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/util/template"
+)
+
+// onManagedNamespace creates the resources of the managed namespace template in a namespace that starts to match the
+// managed namespace selector, and processes its workflows
+func (wfc *WorkflowController) onManagedNamespace(ctx context.Context, namespace string) {
+	logCtx := log.WithField("namespace", namespace)
+	logCtx.Info("Managing namespace")
+	if err := wfc.createManagedNamespaceResources(ctx, namespace); err != nil {
+		logCtx.WithError(err).Error("Failed to create the resources of the managed namespace template")
+	}
+	if wfc.wfInformer == nil {
+		return
+	}
+	_ = cache.ListAllByNamespace(wfc.wfInformer.GetIndexer(), namespace, labels.Everything(), func(obj interface{}) {
+		un, ok := obj.(*unstructured.Unstructured)
+		if !ok || !reconciliationNeeded(un) {
+			return
+		}
+		key, err := cache.MetaNamespaceKeyFunc(un)
+		if err == nil {
+			wfc.wfQueue.Add(key)
+			priority, creation := getWfPriority(un)
+			wfc.throttler.Add(key, priority, creation)
+		}
+	})
+}
+
+// createManagedNamespaceResources creates the resources of the managed namespace template in the namespace, unless they
+// already exist, so that they can be changed for each namespace
+func (wfc *WorkflowController) createManagedNamespaceResources(ctx context.Context, namespace string) error {
+	name := wfc.Config.ManagedNamespaceTemplate
+	if name == "" {
+		return nil
+	}
+	cm, err := wfc.kubeclientset.CoreV1().ConfigMaps(wfc.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get managed namespace template %q: %w", name, err)
+	}
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data, err := yaml.YAMLToJSON([]byte(cm.Data[key]))
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal %q: %w", key, err)
+		}
+		// other variables, e.g. those of the key format of an artifact repository, are left for workflows
+		manifest, err := template.Replace(string(data), map[string]string{"namespace": namespace}, true)
+		if err != nil {
+			return fmt.Errorf("failed to replace variables of %q: %w", key, err)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal([]byte(manifest), &obj.Object); err != nil {
+			return fmt.Errorf("failed to unmarshal %q: %w", key, err)
+		}
+		obj.SetNamespace(namespace)
+		resource, err := wfc.resourceFor(obj.GroupVersionKind())
+		if err != nil {
+			return fmt.Errorf("failed to create %q: %w", key, err)
+		}
+		_, err = wfc.dynamicInterface.Resource(resource).Namespace(namespace).Create(ctx, obj, metav1.CreateOptions{})
+		if apierr.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create %q: %w", key, err)
+		}
+		log.WithFields(log.Fields{"namespace": namespace, "kind": obj.GetKind(), "name": obj.GetName()}).Info("Created resource of managed namespace template")
+	}
+	return nil
+}
+
+// resourceFor returns the namespaced resource of the kind
+func (wfc *WorkflowController) resourceFor(gvk schema.GroupVersionKind) (schema.GroupVersionResource, error) {
+	list, err := wfc.kubeclientset.Discovery().ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	for _, r := range list.APIResources {
+		// ignore sub-resources, e.g. "pods/log"
+		if r.Kind == gvk.Kind && r.Namespaced && !strings.Contains(r.Name, "/") {
+			return gvk.GroupVersion().WithResource(r.Name), nil
+		}
+	}
+	return schema.GroupVersionResource{}, fmt.Errorf("%s is not a namespaced kind", gvk)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/namespaces"
+)
+
+func TestUnmanagedNamespace(t *testing.T) {
+	cancel, controller := newController(wfv1.MustUnmarshalWorkflow(helloWorldWf))
+	defer cancel()
+	ctx, cancelSelector := context.WithCancel(context.Background())
+	defer cancelSelector()
+	selector, err := namespaces.NewSelector(controller.kubeclientset, "tenant=true")
+	assert.NoError(t, err)
+	assert.True(t, selector.Run(ctx.Done()))
+	controller.managedNamespaces = selector
+
+	assert.True(t, controller.processNextItem(ctx))
+	expectWorkflow(ctx, controller, "hello-world", func(wf *wfv1.Workflow) {
+		if assert.NotNil(t, wf) {
+			assert.Empty(t, wf.Status.Phase)
+		}
+	})
+}
+
+func TestCreateManagedNamespaceResources(t *testing.T) {
+	cancel, controller := newController(func(x *WorkflowController) {
+		x.namespace = "argo"
+		x.Config.ManagedNamespaceTemplate = "tenant-namespace-template"
+	})
+	defer cancel()
+	ctx := context.Background()
+	kube := controller.kubeclientset.(*fake.Clientset)
+	kube.Fake.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "serviceaccounts", Namespaced: true, Kind: "ServiceAccount"},
+			{Name: "serviceaccounts/token", Namespaced: true, Kind: "TokenRequest"},
+		},
+	}}
+	_, err := kube.CoreV1().ConfigMaps("argo").Create(ctx, &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-namespace-template"},
+		Data: map[string]string{
+			"service-account": `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: executor
+  annotations:
+    tenant: "{{namespace}}"
+    untouched: "{{workflow.name}}"
+`,
+		},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	assert.NoError(t, controller.createManagedNamespaceResources(ctx, "my-tenant"))
+	// existing resources are left alone
+	assert.NoError(t, controller.createManagedNamespaceResources(ctx, "my-tenant"))
+
+	sa, err := controller.dynamicInterface.Resource(schema.GroupVersionResource{Version: "v1", Resource: "serviceaccounts"}).Namespace("my-tenant").Get(ctx, "executor", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"tenant": "my-tenant", "untouched": "{{workflow.name}}"}, sa.GetAnnotations())
+	}
+
+	t.Run("NotNamespaced", func(t *testing.T) {
+		_, err := controller.resourceFor(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"})
+		assert.EqualError(t, err, "/v1, Kind=Namespace is not a namespaced kind")
+	})
+	t.Run("NoTemplate", func(t *testing.T) {
+		controller.Config.ManagedNamespaceTemplate = ""
+		assert.NoError(t, controller.createManagedNamespaceResources(ctx, "other-tenant"))
+	})
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+// podConditionDisruptionTarget is added to pods that are about to be terminated because of a disruption, such as the
+// shut down of their node. It is only set by Kubernetes v1.26 and after.
+const podConditionDisruptionTarget apiv1.PodConditionType = "DisruptionTarget"
+
+// nodePreemptionReasons are the reasons of the DisruptionTarget condition, or of failed pods, for a pod whose node
+// was preempted, shut down or lost, rather than a failure of the application
+var nodePreemptionReasons = map[string]bool{
+	"DeletionByTaintManager": true, // the node is not ready, unreachable or out of service
+	"DeletionByPodGC":        true, // the node was deleted
+	"TerminationByKubelet":   true, // the node is shutting down
+	"PreemptionByScheduler":  true,
+	"NodeShutdown":           true,
+	"Shutdown":               true,
+	"NodeLost":               true,
+}
+
+// nodePreemption returns the reason and message of a pod whose node was preempted, shut down or lost, or empty strings
+func nodePreemption(pod *apiv1.Pod) (reason string, message string) {
+	if pod.Status.Phase == apiv1.PodSucceeded {
+		return "", ""
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type == podConditionDisruptionTarget && c.Status == apiv1.ConditionTrue && nodePreemptionReasons[c.Reason] {
+			return c.Reason, c.Message
+		}
+	}
+	if nodePreemptionReasons[pod.Status.Reason] {
+		return pod.Status.Reason, pod.Status.Message
+	}
+	// graceful node shutdown fails pods with the generic reason "Terminated"
+	if pod.Status.Phase == apiv1.PodFailed && pod.Status.Reason == "Terminated" && pod.Status.Message == "Pod was terminated in response to imminent node shutdown." {
+		return "NodeShutdown", pod.Status.Message
+	}
+	return "", ""
+}
+
+// nodePreemptionMessage is the message of a node whose pod's node was preempted
+func nodePreemptionMessage(reason, message string, rescheduled bool) string {
+	if rescheduled {
+		return fmt.Sprintf("Rescheduled because the pod's node was preempted (%s): %s", reason, message)
+	}
+	return fmt.Sprintf("The pod's node was preempted (%s): %s", reason, message)
+}
+
+// handleNodePreemption handles a pod of the node whose node was preempted, rather than the application failing. It
+// returns whether it did, and the new node status, if it changed. If the workflow reschedules such pods, the node is
+// Pending again, and the pod is deleted, so that it is recreated. This does not count towards the limit of a retry
+// strategy, as the node does not fail. Otherwise, the node errors.
+func (woc *wfOperationCtx) handleNodePreemption(pod *apiv1.Pod, node wfv1.NodeStatus) (*wfv1.NodeStatus, bool) {
+	reason, message := nodePreemption(pod)
+	if reason == "" || node.Fulfilled() {
+		return nil, false
+	}
+	reschedule := woc.execWf.Spec.ShouldRescheduleOnNodePreemption()
+	if woc.controller.preemptedPods.add(pod.UID) {
+		woc.log.WithField("podName", pod.Name).WithField("reason", reason).WithField("reschedule", reschedule).Info("Pod's node was preempted")
+		metrics.NodePreemptionMetric.WithLabelValues(woc.wf.Namespace, reason, strconv.FormatBool(reschedule)).Inc()
+		if reschedule {
+			woc.controller.queuePodForCleanup(pod.Namespace, pod.Name, forceDeletePod)
+		}
+	}
+	new := node.DeepCopy()
+	new.Daemoned = nil
+	if reschedule {
+		// the pod is recreated once the informer no longer has it
+		new.Phase = wfv1.NodePending
+	} else {
+		new.Phase = wfv1.NodeError
+		new.FinishedAt = getLatestFinishedAt(pod)
+		if new.FinishedAt.IsZero() {
+			new.FinishedAt = metav1.Now()
+		}
+	}
+	new.Message = nodePreemptionMessage(reason, message, reschedule)
+	if new.Phase == node.Phase && new.Message == node.Message {
+		return nil, true
+	}
+	return new, true
+}
+
+// preemptedPods are the UIDs of the pods whose node preemption was handled, until they are deleted
+type preemptedPods struct {
+	mutex sync.Mutex
+	uids  map[types.UID]bool
+}
+
+func newPreemptedPods() *preemptedPods {
+	return &preemptedPods{uids: map[types.UID]bool{}}
+}
+
+// add returns whether the pod was not already added
+func (p *preemptedPods) add(uid types.UID) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.uids[uid] {
+		return false
+	}
+	p.uids[uid] = true
+	return true
+}
+
+func (p *preemptedPods) remove(uid types.UID) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.uids, uid)
+}
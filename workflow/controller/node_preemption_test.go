@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+)
+
+const nodeShutdownMessage = "Pod was terminated in response to imminent node shutdown."
+
+func TestNodePreemption(t *testing.T) {
+	t.Run("Succeeded", func(t *testing.T) {
+		reason, _ := nodePreemption(&apiv1.Pod{Status: apiv1.PodStatus{Phase: apiv1.PodSucceeded, Reason: "NodeLost"}})
+		assert.Empty(t, reason)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		reason, _ := nodePreemption(&apiv1.Pod{Status: apiv1.PodStatus{Phase: apiv1.PodFailed, Message: "Pod failed"}})
+		assert.Empty(t, reason)
+	})
+	t.Run("DisruptionTarget", func(t *testing.T) {
+		reason, message := nodePreemption(&apiv1.Pod{Status: apiv1.PodStatus{
+			Phase:      apiv1.PodRunning,
+			Conditions: []apiv1.PodCondition{{Type: podConditionDisruptionTarget, Status: apiv1.ConditionTrue, Reason: "DeletionByTaintManager", Message: "Taint manager: deleting due to NoExecute taint"}},
+		}})
+		assert.Equal(t, "DeletionByTaintManager", reason)
+		assert.Equal(t, "Taint manager: deleting due to NoExecute taint", message)
+	})
+	t.Run("NodeLost", func(t *testing.T) {
+		reason, _ := nodePreemption(&apiv1.Pod{Status: apiv1.PodStatus{Phase: apiv1.PodFailed, Reason: "NodeLost"}})
+		assert.Equal(t, "NodeLost", reason)
+	})
+	t.Run("NodeShutdown", func(t *testing.T) {
+		reason, message := nodePreemption(&apiv1.Pod{Status: apiv1.PodStatus{Phase: apiv1.PodFailed, Reason: "Terminated", Message: nodeShutdownMessage}})
+		assert.Equal(t, "NodeShutdown", reason)
+		assert.Equal(t, nodeShutdownMessage, message)
+	})
+}
+
+const preemptedWorkflow = `
+metadata:
+  name: my-wf
+  namespace: preempt-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+`
+
+func withNodeShutdown(pod *apiv1.Pod) {
+	pod.Status.Reason = "Terminated"
+	pod.Status.Message = nodeShutdownMessage
+}
+
+func TestRescheduleOnNodePreemption(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(preemptedWorkflow)
+	wf.Spec.RescheduleOnNodePreemption = pointer.BoolPtr(true)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withNodeShutdown)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	node := woc.wf.Status.Nodes[wf.Name]
+	assert.Equal(t, wfv1.NodePending, node.Phase)
+	assert.Equal(t, "Rescheduled because the pod's node was preempted (NodeShutdown): "+nodeShutdownMessage, node.Message)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NodePreemptionMetric.WithLabelValues("preempt-ns", "NodeShutdown", "true")))
+	assert.Contains(t, controller.podCleanupQueue.(*recordingQueue).items, newPodCleanupKey("preempt-ns", wf.Name, forceDeletePod))
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.NodePending, woc.wf.Status.Nodes[wf.Name].Phase, "the stale pod is not assessed")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NodePreemptionMetric.WithLabelValues("preempt-ns", "NodeShutdown", "true")), "is counted once")
+}
+
+func TestErrorOnNodePreemption(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(preemptedWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodFailed, withNodeShutdown)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+	node := woc.wf.Status.Nodes[wf.Name]
+	assert.Equal(t, wfv1.NodeError, node.Phase)
+	assert.Equal(t, "The pod's node was preempted (NodeShutdown): "+nodeShutdownMessage, node.Message)
+	assert.False(t, node.FinishedAt.IsZero())
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.NodePreemptionMetric.WithLabelValues("preempt-ns", "NodeShutdown", "false")))
+}
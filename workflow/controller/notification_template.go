@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+func (woc *wfOperationCtx) executeNotificationTemplate(nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) *wfv1.NodeStatus {
+	node := woc.wf.GetNodeByName(nodeName)
+	if node == nil {
+		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeNotification, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending)
+	}
+	if !node.Fulfilled() {
+		woc.taskSet[node.ID] = *tmpl
+	}
+	return node
+}
+
+// executeWorkflowNotifications sends the notifications of the workflow for the status it completed with, and returns
+// whether all of them have been sent. Notifications that fail to be sent do not change the status of the workflow.
+func (woc *wfOperationCtx) executeWorkflowNotifications(ctx context.Context, tmplCtx *templateresolution.Context, workflowStatus wfv1.WorkflowPhase, onExitNode *wfv1.NodeStatus) (bool, error) {
+	succeeded := workflowStatus == wfv1.WorkflowSucceeded && (onExitNode == nil || !onExitNode.FailedOrError())
+	if workflowStatus == wfv1.WorkflowSucceeded && !succeeded {
+		// the workflow is unsuccessful because its exit handler was
+		if onExitNode.Phase == wfv1.NodeFailed {
+			woc.globalParams[common.GlobalVarWorkflowStatus] = string(wfv1.WorkflowFailed)
+		} else {
+			woc.globalParams[common.GlobalVarWorkflowStatus] = string(wfv1.WorkflowError)
+		}
+	}
+	fulfilled := true
+	requiresTaskSetReconciliation := false
+	for _, n := range woc.execWf.Spec.Notifications {
+		if !n.SentOn(succeeded) {
+			continue
+		}
+		notification := n.Notification.WithDefaults()
+		nodeName := fmt.Sprintf("%s.notifications.%s", woc.wf.Name, n.Name)
+		node, err := woc.executeTemplate(ctx, nodeName, &wfv1.WorkflowStep{Inline: &wfv1.Template{Name: n.Name, Notification: &notification}}, tmplCtx, wfv1.Arguments{}, &executeTemplateOpts{onExitTemplate: true})
+		if err != nil {
+			return false, fmt.Errorf("error in notification %s: %w", n.Name, err)
+		}
+		if node == nil || !node.Fulfilled() {
+			fulfilled = false
+			requiresTaskSetReconciliation = true
+		}
+	}
+	if requiresTaskSetReconciliation {
+		woc.taskSetReconciliation(ctx)
+	}
+	return fulfilled, nil
+}
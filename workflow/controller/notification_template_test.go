@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var workflowWithNotifications = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: notifications
+  namespace: default
+spec:
+  entrypoint: main
+  notifications:
+  - name: on-failure
+    events: [onFailure]
+    slack:
+      webhookURLSecret:
+        name: slack
+        key: url
+  - name: webhook
+    http:
+      url: http://example.com
+      body: "{{workflow.name}} {{workflow.status}}"
+  templates:
+  - name: main
+    container:
+      image: alpine
+      command: [echo, hello]
+`
+
+func TestWorkflowNotifications(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(workflowWithNotifications)
+	cancel, controller := newController(wf, defaultServiceAccount)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase, "the workflow waits for its notifications")
+	assert.Nil(t, woc.wf.GetNodeByName("notifications.notifications.on-failure"), "the workflow succeeded")
+	node := woc.wf.GetNodeByName("notifications.notifications.webhook")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, wfv1.NodeTypeNotification, node.Type)
+		ts, err := controller.wfclientset.ArgoprojV1alpha1().WorkflowTaskSets("default").Get(ctx, "notifications", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			if assert.Contains(t, ts.Spec.Tasks, node.ID) {
+				assert.Equal(t, "notifications Succeeded", ts.Spec.Tasks[node.ID].Notification.HTTP.Body)
+			}
+		}
+
+		node.Phase = wfv1.NodeSucceeded
+		woc.wf.Status.Nodes[node.ID] = *node
+		woc = newWorkflowOperationCtx(woc.wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+	}
+}
+
+func TestWorkflowNotificationsFailed(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(workflowWithNotifications)
+	cancel, controller := newController(wf, defaultServiceAccount)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodFailed)
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	for _, name := range []string{"notifications.notifications.on-failure", "notifications.notifications.webhook"} {
+		node := woc.wf.GetNodeByName(name)
+		if assert.NotNil(t, node, name) {
+			node.Phase = wfv1.NodeFailed
+			woc.wf.Status.Nodes[node.ID] = *node
+		}
+	}
+
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase, "notifications that fail to be sent do not change the phase")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"math"
 	"os"
 	"reflect"
@@ -21,6 +22,10 @@ import (
 	"github.com/argoproj/pkg/strftime"
 	jsonpatch "github.com/evanphx/json-patch"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	apiv1 "k8s.io/api/core/v1"
 	policyv1beta "k8s.io/api/policy/v1beta1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -44,6 +49,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/expr/argoexpr"
 	"github.com/argoproj/argo-workflows/v3/util/expr/env"
 	"github.com/argoproj/argo-workflows/v3/util/intstr"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
 	"github.com/argoproj/argo-workflows/v3/util/resource"
 	"github.com/argoproj/argo-workflows/v3/util/retry"
 	argoruntime "github.com/argoproj/argo-workflows/v3/util/runtime"
@@ -55,6 +61,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/estimation"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v3/workflow/notification"
 	"github.com/argoproj/argo-workflows/v3/workflow/progress"
 	argosync "github.com/argoproj/argo-workflows/v3/workflow/sync"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
@@ -68,6 +75,8 @@ type wfOperationCtx struct {
 	wf *wfv1.Workflow
 	// orig is the original workflow object for purposes of creating a patch
 	orig *wfv1.Workflow
+	// hydratedOrig is the original workflow once hydrated, normalized to tell whether the workflow significantly changed
+	hydratedOrig *wfv1.Workflow
 	// updated indicates whether or not the workflow object itself was updated
 	// and needs to be persisted back to kubernetes
 	updated bool
@@ -93,6 +102,8 @@ type wfOperationCtx struct {
 	// activePods tracks the number of active (Running/Pending) pods for controlling
 	// parallelism
 	activePods int64
+	// quotaPods tracks the number of active pods of the workflows of each pod quota of this workflow
+	quotaPods map[string]int
 	// workflowDeadline is the deadline which the workflow is expected to complete before we
 	// terminate the workflow.
 	workflowDeadline *time.Time
@@ -134,6 +145,11 @@ type failedNodeStatus struct {
 	Phase        string      `json:"phase"`
 	PodName      string      `json:"podName"`
 	FinishedAt   metav1.Time `json:"finishedAt"`
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	StartedAt    metav1.Time `json:"startedAt"`
+	// Duration is the duration of the node in seconds
+	Duration int64 `json:"duration"`
 }
 
 // newWorkflowOperationCtx creates and initializes a new wfOperationCtx object.
@@ -148,7 +164,7 @@ func newWorkflowOperationCtx(wf *wfv1.Workflow, wfc *WorkflowController) *wfOper
 		orig:    wf,
 		execWf:  wfCopy,
 		updated: false,
-		log: log.WithFields(log.Fields{
+		log: logging.Component("operator").WithFields(log.Fields{
 			"workflow":  wf.ObjectMeta.Name,
 			"namespace": wf.ObjectMeta.Namespace,
 		}),
@@ -159,6 +175,7 @@ func newWorkflowOperationCtx(wf *wfv1.Workflow, wfc *WorkflowController) *wfOper
 		eventRecorder:          wfc.eventRecorderManager.Get(wf.Namespace),
 		preExecutionNodePhases: make(map[string]wfv1.NodePhase),
 		taskSet:                make(map[string]wfv1.Template),
+		quotaPods:              make(map[string]int),
 	}
 
 	if woc.wf.Status.Nodes == nil {
@@ -179,6 +196,16 @@ func newWorkflowOperationCtx(wf *wfv1.Workflow, wfc *WorkflowController) *wfOper
 func (woc *wfOperationCtx) operate(ctx context.Context) {
 	defer argoruntime.RecoverFromPanic(woc.log)
 
+	if tracer := woc.controller.tracer(); tracer != nil {
+		_, workflowSpan := woc.workflowSpanContext()
+		var span trace.Span
+		ctx, span = tracer.Start(trace.ContextWithRemoteSpanContext(ctx, workflowSpan), "reconcile", trace.WithAttributes(
+			attribute.String("argo.workflow.name", woc.wf.Name),
+			attribute.String("argo.workflow.namespace", woc.wf.Namespace),
+		))
+		defer span.End()
+	}
+
 	defer func() {
 		if woc.wf.Status.Fulfilled() {
 			woc.killDaemonedChildren("")
@@ -234,6 +261,14 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		return
 	}
 
+	if lockKey, ok := woc.wf.Annotations[common.AnnotationKeyReleaseLock]; ok {
+		if !woc.controller.syncManager.ReleaseLock(woc.wf, lockKey) {
+			woc.log.WithField("lock", lockKey).Warn("Workflow does not hold the lock it was forced to release")
+		}
+		delete(woc.wf.Annotations, common.AnnotationKeyReleaseLock)
+		woc.updated = true
+	}
+
 	// Workflow Level Synchronization lock
 	if woc.execWf.Spec.Synchronization != nil {
 		acquired, wfUpdate, msg, err := woc.controller.syncManager.TryAcquire(woc.wf, "", woc.execWf.Spec.Synchronization)
@@ -304,6 +339,10 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		woc.log.Info("workflow suspended")
 		return
 	}
+	if woc.pausedByPreemption() {
+		woc.log.Info("workflow preempted")
+		return
+	}
 	if woc.execWf.Spec.Parallelism != nil {
 		woc.activePods = woc.getActivePods("")
 	}
@@ -381,9 +420,20 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 					Phase:        string(node.Phase),
 					PodName:      node.ID,
 					FinishedAt:   node.FinishedAt,
+					Name:         node.Name,
+					Type:         string(node.Type),
+					StartedAt:    node.StartedAt,
+					Duration:     int64(node.GetDuration().Seconds()),
 				})
 		}
 	}
+	// in the order the nodes failed, so exit handlers can tell the first failure from those that it caused
+	sort.Slice(failures, func(i, j int) bool {
+		if !failures[i].FinishedAt.Equal(&failures[j].FinishedAt) {
+			return failures[i].FinishedAt.Before(&failures[j].FinishedAt)
+		}
+		return failures[i].Name < failures[j].Name
+	})
 	failedNodeBytes, err := json.Marshal(failures)
 	if err != nil {
 		woc.log.Errorf("Error marshalling failed nodes list: %+v", err)
@@ -391,6 +441,11 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 	}
 	// This strconv.Quote is necessary so that the escaped quotes are not removed during parameter substitution
 	woc.globalParams[common.GlobalVarWorkflowFailures] = strconv.Quote(string(failedNodeBytes))
+	// unlike workflow.failures, this is the JSON list itself, so it can be passed to a parameter or withParam
+	if failures == nil {
+		failedNodeBytes = []byte("[]")
+	}
+	woc.globalParams[common.GlobalVarWorkflowFailedNodes] = string(failedNodeBytes)
 
 	err = woc.executeWfLifeCycleHook(ctx, tmplCtx)
 	if err != nil {
@@ -435,6 +490,14 @@ func (woc *wfOperationCtx) operate(ctx context.Context) {
 		}
 	}
 
+	if len(woc.execWf.Spec.Notifications) > 0 && woc.GetShutdownStrategy().ShouldExecute(true) {
+		if fulfilled, err := woc.executeWorkflowNotifications(ctx, tmplCtx, workflowStatus, onExitNode); err != nil {
+			woc.log.WithError(err).Error("error in notification execution")
+		} else if !fulfilled {
+			return
+		}
+	}
+
 	var workflowMessage string
 	if node.FailedOrError() && woc.GetShutdownStrategy().Enabled() {
 		workflowMessage = fmt.Sprintf("Stopped with strategy '%s'", woc.GetShutdownStrategy())
@@ -652,6 +715,7 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 	diff.LogChanges(woc.orig, woc.wf)
 
 	resource.UpdateResourceDurations(woc.wf)
+	woc.updateWorkflowCost()
 	progress.UpdateProgress(woc.wf)
 	// You MUST not call `persistUpdates` twice.
 	// * Fails the `reapplyUpdate` cannot work unless resource versions are different.
@@ -660,9 +724,16 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 		woc.log.Panic("cannot persist updates with mismatched resource versions")
 	}
 	wfClient := woc.controller.wfclientset.ArgoprojV1alpha1().Workflows(woc.wf.ObjectMeta.Namespace)
-	// try and compress nodes if needed
+	if !woc.significantlyChanged() {
+		woc.log.Debug("Workflow has no significant changes, skipping update")
+		woc.queuePodsForCleanup()
+		return
+	}
+	// offload the large values of parameters, and try and compress nodes if needed
 	nodes := woc.wf.Status.Nodes
-	err := woc.controller.hydrator.Dehydrate(woc.wf)
+	offloaded := woc.offloadParameters(ctx, nodes)
+	woc.wf.Status.Nodes = offloaded
+	err := woc.controller.hydrator.Dehydrate(ctx, woc.wf)
 	if err != nil {
 		woc.log.Warnf("Failed to dehydrate: %v", err)
 		woc.markWorkflowError(ctx, err)
@@ -686,7 +757,7 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 			return
 		}
 		woc.log.Info("Re-applying updates on latest version and retrying update")
-		wf, err := woc.reapplyUpdate(ctx, wfClient, nodes)
+		wf, err := woc.reapplyUpdate(ctx, wfClient, offloaded)
 		if err != nil {
 			woc.log.WithError(err).Info("Failed to re-apply update")
 			return
@@ -696,6 +767,8 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 		woc.wf = wf
 		woc.controller.hydrator.HydrateWithNodes(woc.wf, nodes)
 	}
+	// the values of parameters are only offloaded in the persisted workflow
+	woc.wf.Status.Nodes = nodes
 
 	// The workflow returned from wfClient.Update doesn't have a TypeMeta associated
 	// with it, so copy from the original workflow.
@@ -703,6 +776,10 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 
 	// Create WorkflowNode* events for nodes that have changed phase
 	woc.recordNodePhaseChangeEvents(woc.orig.Status.Nodes, woc.wf.Status.Nodes)
+	woc.observeNodeDurations(woc.orig.Status.Nodes, woc.wf.Status.Nodes)
+	woc.observeNodeCosts(woc.orig.Status.Nodes, woc.wf.Status.Nodes)
+	woc.recordSpans(ctx, &woc.orig.Status, &woc.wf.Status)
+	woc.notifyPhaseChanges()
 
 	if !woc.controller.hydrator.IsHydrated(woc.wf) {
 		panic("workflow should be hydrated")
@@ -781,7 +858,7 @@ func (woc *wfOperationCtx) reapplyUpdate(ctx context.Context, wfClient v1alpha1.
 	if woc.orig.ResourceVersion != woc.wf.ResourceVersion {
 		woc.log.Panic("cannot re-apply update with mismatched resource versions")
 	}
-	err := woc.controller.hydrator.Hydrate(woc.orig)
+	err := woc.controller.hydrator.Hydrate(ctx, woc.orig)
 	if err != nil {
 		return nil, err
 	}
@@ -813,7 +890,7 @@ func (woc *wfOperationCtx) reapplyUpdate(ctx context.Context, wfClient v1alpha1.
 		if currWf.Status.Fulfilled() {
 			return nil, fmt.Errorf("must never update completed workflows")
 		}
-		err = woc.controller.hydrator.Hydrate(currWf)
+		err = woc.controller.hydrator.Hydrate(ctx, currWf)
 		if err != nil {
 			return nil, err
 		}
@@ -836,7 +913,7 @@ func (woc *wfOperationCtx) reapplyUpdate(ctx context.Context, wfClient v1alpha1.
 		if err != nil {
 			return nil, err
 		}
-		err = woc.controller.hydrator.Dehydrate(&newWf)
+		err = woc.controller.hydrator.Dehydrate(ctx, &newWf)
 		if err != nil {
 			return nil, err
 		}
@@ -865,6 +942,15 @@ func (woc *wfOperationCtx) requeue() {
 	woc.controller.wfQueue.AddRateLimited(key)
 }
 
+// retryJitterFraction returns a fraction in [0, 1) to jitter the backoff of the retry of the node by. It is derived from
+// the node and the retry, rather than random, so the backoff is the same each time the node is reconciled, yet differs
+// between the nodes of a fan-out.
+func retryJitterFraction(nodeID string, retry int) float64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s/%d", nodeID, retry)))
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
+
 // processNodeRetries updates the retry node state based on the child node state and the retry strategy and returns the node.
 func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrategy wfv1.RetryStrategy, opts *executeTemplateOpts) (*wfv1.NodeStatus, bool, error) {
 	if node.Fulfilled() {
@@ -947,6 +1033,14 @@ func (woc *wfOperationCtx) processNodeRetries(node *wfv1.NodeStatus, retryStrate
 			// Note that timeToWait should equal to duration for the first retry attempt.
 			timeToWait = baseDuration * time.Duration(math.Pow(float64(*retryStrategyBackoffFactor), float64(len(node.Children)-1)))
 		}
+		jitter, err := retryStrategy.Backoff.GetJitter()
+		if err != nil {
+			return nil, false, err
+		}
+		if jitter > 0 {
+			// Formula: timeToWait = timeToWait * (1 + jitter * fraction), where fraction is in [0, 1)
+			timeToWait += time.Duration(jitter * retryJitterFraction(node.ID, len(node.Children)) * float64(timeToWait))
+		}
 		waitingDeadline := lastChildNode.FinishedAt.Add(timeToWait)
 
 		// If the waiting deadline is after the max duration deadline, then it's futile to wait until then. Stop early
@@ -1041,6 +1135,13 @@ func (woc *wfOperationCtx) podReconciliation(ctx context.Context) error {
 		wfNodesLock.Lock()
 		defer wfNodesLock.Unlock()
 		if node, ok := woc.wf.Status.Nodes[nodeID]; ok {
+			if newState, preempted := woc.handleNodePreemption(pod, node); preempted {
+				if newState != nil {
+					woc.wf.Status.Nodes[nodeID] = *newState
+					woc.updated = true
+				}
+				return
+			}
 			if newState := woc.assessNodeStatus(pod, &node); newState != nil {
 				woc.addOutputsToGlobalScope(newState.Outputs)
 				if newState.MemoizationStatus != nil {
@@ -1166,7 +1267,7 @@ func (woc *wfOperationCtx) failSuspendedAndPendingNodesAfterDeadlineOrShutdown()
 
 // getAllWorkflowPods returns all pods related to the current workflow
 func (woc *wfOperationCtx) getAllWorkflowPods() ([]*apiv1.Pod, error) {
-	objs, err := woc.controller.podInformer.GetIndexer().ByIndex(indexes.WorkflowIndex, indexes.WorkflowIndexValue(woc.wf.Namespace, woc.wf.Name))
+	objs, err := woc.controller.podsByIndex(indexes.WorkflowIndex, indexes.WorkflowIndexValue(woc.wf.Namespace, woc.wf.Name))
 	if err != nil {
 		return nil, err
 	}
@@ -1245,6 +1346,17 @@ func (woc *wfOperationCtx) assessNodeStatus(pod *apiv1.Pod, old *wfv1.NodeStatus
 		} else {
 			new.Phase = wfv1.NodeRunning
 		}
+		// pipe artifacts can be read by downstream tasks as soon as the pod is running, so they are output straight away
+		if tmpl != nil && len(tmpl.Outputs.PipeArtifacts()) > 0 && len(new.Outputs.PipeArtifacts()) == 0 {
+			if podTmpl, err := getPodTemplate(pod); err != nil {
+				woc.log.WithError(err).WithField("nodeID", old.ID).Warn("failed to get the pipe artifacts of the pod")
+			} else {
+				if new.Outputs == nil {
+					new.Outputs = &wfv1.Outputs{}
+				}
+				new.Outputs.Artifacts = append(new.Outputs.Artifacts, podTmpl.Outputs.PipeArtifacts()...)
+			}
+		}
 		if tmpl != nil {
 			woc.cleanUpPod(pod, *tmpl)
 		}
@@ -1338,6 +1450,7 @@ func (woc *wfOperationCtx) assessNodeStatus(pod *apiv1.Pod, old *wfv1.NodeStatus
 		new.FinishedAt = getLatestFinishedAt(pod)
 		new.ResourcesDuration = resource.DurationForPod(pod)
 	}
+	woc.priceNode(pod, new)
 
 	if !reflect.DeepEqual(old, new) {
 		woc.log.WithField("nodeID", old.ID).
@@ -1543,6 +1656,10 @@ func (woc *wfOperationCtx) createPVCs(ctx context.Context) error {
 			if !hasOwnerReference {
 				return errors.Errorf(errors.CodeForbidden, "%s pvc already exists with different ownerreference", pvcTmpl.Name)
 			}
+			pvc, err = woc.keepPVC(ctx, pvc)
+			if err == nil && woc.execWf.Spec.GetVolumeClaimGC().ResizeOnRetry {
+				pvc, err = woc.resizePVC(ctx, pvc, pvcTmpl)
+			}
 		}
 
 		// continue
@@ -1565,15 +1682,20 @@ func (woc *wfOperationCtx) createPVCs(ctx context.Context) error {
 }
 
 func (woc *wfOperationCtx) deletePVCs(ctx context.Context) error {
-	gcStrategy := woc.execWf.Spec.GetVolumeClaimGC().GetStrategy()
+	volumeClaimGC := woc.execWf.Spec.GetVolumeClaimGC()
+	gcStrategy := volumeClaimGC.GetStrategy()
 
 	switch gcStrategy {
 	case wfv1.VolumeClaimGCOnSuccess:
 		if woc.wf.Status.Phase == wfv1.WorkflowError || woc.wf.Status.Phase == wfv1.WorkflowFailed {
 			// Skip deleting PVCs to reuse them for retried failed/error workflows.
 			// PVCs are automatically deleted when corresponded owner workflows get deleted.
+			woc.retainPVCs(" of the unsuccessful workflow")
 			return nil
 		}
+	case wfv1.VolumeClaimGCOnDeletion:
+		woc.retainPVCs(" until the workflow is deleted")
+		return nil
 	case wfv1.VolumeClaimGCOnCompletion:
 	default:
 		return fmt.Errorf("unknown volume gc strategy: %s", gcStrategy)
@@ -1584,6 +1706,9 @@ func (woc *wfOperationCtx) deletePVCs(ctx context.Context) error {
 		// PVC list already empty. nothing to do
 		return nil
 	}
+	if volumeClaimGC.OlderThan != nil && volumeClaimGC.OlderThan.Duration > 0 {
+		return woc.deletePVCsAfter(ctx, time.Now().Add(volumeClaimGC.OlderThan.Duration))
+	}
 	pvcClient := woc.controller.kubeclientset.CoreV1().PersistentVolumeClaims(woc.wf.ObjectMeta.Namespace)
 	newPVClist := make([]apiv1.Volume, 0)
 	// Attempt to delete all PVCs. Record first error encountered
@@ -1737,9 +1862,18 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 	if err != nil {
 		return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, err), err
 	}
+	err = woc.resolveFromWorkflowArtifacts(processedTmpl, node)
+	if err != nil {
+		return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, err), err
+	}
 
 	// If memoization is on, check if node output exists in cache
 	if node == nil && processedTmpl.Memoize != nil {
+		if strings.Contains(processedTmpl.Memoize.Key, "{{inputs.artifacts.") {
+			// digests are only known for artifacts saved by another node, and raw artifacts
+			err := fmt.Errorf("unable to resolve memoization key %s: input artifact digest is unknown", processedTmpl.Memoize.Key)
+			return woc.initializeNodeOrMarkError(node, nodeName, templateScope, orgTmpl, opts.boundaryID, err), err
+		}
 		memoizationCache := woc.controller.cacheFactory.GetCache(controllercache.ConfigMapCache, processedTmpl.Memoize.Cache.ConfigMap.Name)
 		if memoizationCache == nil {
 			err := fmt.Errorf("cache could not be found or created")
@@ -1775,6 +1909,11 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 			Key:       processedTmpl.Memoize.Key,
 			CacheName: processedTmpl.Memoize.Cache.ConfigMap.Name,
 		}
+		result := "miss"
+		if hit {
+			result = "hit"
+		}
+		metrics.MemoizationCacheMetric.WithLabelValues(memoizationStatus.CacheName, processedTmpl.Name, result).Inc()
 		if hit {
 			node = woc.initializeCacheHitNode(nodeName, processedTmpl, templateScope, orgTmpl, opts.boundaryID, outputs, memoizationStatus)
 		} else {
@@ -1947,9 +2086,13 @@ func (woc *wfOperationCtx) executeTemplate(ctx context.Context, nodeName string,
 	case wfv1.TemplateTypeData:
 		node, err = woc.executeData(ctx, nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypeHTTP:
-		node = woc.executeHTTPTemplate(nodeName, templateScope, processedTmpl, orgTmpl, opts)
+		node, err = woc.executeHTTPTemplate(nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	case wfv1.TemplateTypePlugin:
 		node = woc.executePluginTemplate(nodeName, templateScope, processedTmpl, orgTmpl, opts)
+	case wfv1.TemplateTypeSQLQuery:
+		node, err = woc.executeSQLQueryTemplate(nodeName, templateScope, processedTmpl, orgTmpl, opts)
+	case wfv1.TemplateTypeNotification:
+		node = woc.executeNotificationTemplate(nodeName, templateScope, processedTmpl, orgTmpl, opts)
 	default:
 		err = errors.Errorf(errors.CodeBadRequest, "Template '%s' missing specification", processedTmpl.Name)
 		return woc.initializeNode(nodeName, wfv1.NodeTypeSkipped, templateScope, orgTmpl, opts.boundaryID, wfv1.NodeError, err.Error()), err
@@ -2112,6 +2255,9 @@ func (woc *wfOperationCtx) markWorkflowPhase(ctx context.Context, phase wfv1.Wor
 			woc.wf.ObjectMeta.Labels[common.LabelKeyCompleted] = "true"
 			woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Status: metav1.ConditionTrue, Type: wfv1.ConditionTypeCompleted})
 			err := woc.deletePDBResource(ctx)
+			if err == nil {
+				err = woc.deleteTemplatePDBResources(ctx)
+			}
 			if err != nil {
 				woc.wf.Status.Phase = wfv1.WorkflowError
 				woc.wf.ObjectMeta.Labels[common.LabelKeyPhase] = string(wfv1.NodeError)
@@ -2205,6 +2351,9 @@ func (woc *wfOperationCtx) initializeExecutableNode(nodeName string, nodeType wf
 
 	if nodeType == wfv1.NodeTypeSuspend {
 		node = addRawOutputFields(node, executeTmpl)
+		if approval := executeTmpl.Suspend.Approval; approval != nil {
+			node.Approval = &wfv1.NodeApproval{Approval: *approval.DeepCopy()}
+		}
 	}
 
 	if len(messages) > 0 {
@@ -2244,6 +2393,10 @@ func (woc *wfOperationCtx) initializeCacheHitNode(nodeName string, resolvedTmpl
 	node := woc.initializeCacheNode(nodeName, resolvedTmpl, templateScope, orgTmpl, boundaryID, memStat, messages...)
 	node.Phase = wfv1.NodeSucceeded
 	node.Outputs = outputs
+	// the artifacts are referenced from the memoized node, so they are not garbage collected with this workflow
+	for i := range node.Outputs.GetArtifacts() {
+		node.Outputs.Artifacts[i].ArtifactGC = &wfv1.ArtifactGC{Strategy: wfv1.ArtifactGCNever}
+	}
 	node.FinishedAt = metav1.Time{Time: time.Now().UTC()}
 	return node
 }
@@ -2406,6 +2559,50 @@ func (woc *wfOperationCtx) recordNodePhaseChangeEvents(old wfv1.Nodes, new wfv1.
 	}
 }
 
+// observeNodeDurations observes the durations of the pod, steps and DAG nodes that completed during this execution of
+// the operator loop
+func (woc *wfOperationCtx) observeNodeDurations(old wfv1.Nodes, new wfv1.Nodes) {
+	for nodeID, node := range new {
+		if !node.Completed() || old[nodeID].Completed() || node.StartedAt.IsZero() || node.FinishedAt.IsZero() {
+			continue
+		}
+		switch node.Type {
+		case wfv1.NodeTypePod, wfv1.NodeTypeSteps, wfv1.NodeTypeDAG:
+			woc.controller.metrics.NodeCompleted(woc.wf.Namespace, woc.templateRefLabel(node), node.Phase, node.FinishedAt.Sub(node.StartedAt.Time))
+		}
+	}
+}
+
+// templateRefLabel returns "<template name>/<template>" for a node whose template is referenced, either by the node
+// or by the workflow's workflowTemplateRef, and otherwise an empty string
+func (woc *wfOperationCtx) templateRefLabel(node wfv1.NodeStatus) string {
+	if node.TemplateRef != nil {
+		return node.TemplateRef.Name + "/" + node.TemplateRef.Template
+	}
+	if ref := woc.wf.Spec.WorkflowTemplateRef; ref != nil && node.TemplateName != "" {
+		return ref.Name + "/" + node.TemplateName
+	}
+	return ""
+}
+
+// notifyPhaseChanges publishes notifications for the workflow and each node that has changed phase during this
+// execution of the operator loop. It is only called once the changes have been persisted.
+func (woc *wfOperationCtx) notifyPhaseChanges() {
+	notifier := woc.controller.notifier
+	if notifier == nil {
+		return
+	}
+	now := time.Now()
+	for nodeName, node := range woc.wf.Status.Nodes {
+		if old, exists := woc.orig.Status.Nodes[nodeName]; !exists || old.Phase != node.Phase {
+			notifier.Notify(notification.ForNode(woc.wf, node, now))
+		}
+	}
+	if woc.orig.Status.Phase != woc.wf.Status.Phase {
+		notifier.Notify(notification.ForWorkflow(woc.wf, now))
+	}
+}
+
 // markNodeError is a convenience method to mark a node with an error and set the message from the error
 func (woc *wfOperationCtx) markNodeError(nodeName string, err error) *wfv1.NodeStatus {
 	woc.log.WithError(err).WithField("nodeName", nodeName).Error("Mark error node")
@@ -2528,7 +2725,7 @@ func (woc *wfOperationCtx) executeContainer(ctx context.Context, nodeName string
 func (woc *wfOperationCtx) getOutboundNodes(nodeID string) []string {
 	node := woc.wf.Status.Nodes[nodeID]
 	switch node.Type {
-	case wfv1.NodeTypeSkipped, wfv1.NodeTypeSuspend, wfv1.NodeTypeHTTP, wfv1.NodeTypePlugin:
+	case wfv1.NodeTypeSkipped, wfv1.NodeTypeSuspend, wfv1.NodeTypeHTTP, wfv1.NodeTypePlugin, wfv1.NodeTypeSQLQuery, wfv1.NodeTypeNotification:
 		return []string{node.ID}
 	case wfv1.NodeTypePod:
 
@@ -2731,7 +2928,7 @@ func (woc *wfOperationCtx) executeScript(ctx context.Context, nodeName string, t
 }
 
 func (woc *wfOperationCtx) requeueIfTransientErr(err error, nodeName string) (*wfv1.NodeStatus, error) {
-	if errorsutil.IsTransientErr(err) || err == ErrResourceRateLimitReached {
+	if errorsutil.IsTransientErr(err) || err == ErrResourceRateLimitReached || isPodQuotaReached(err) {
 		// Our error was most likely caused by a lack of resources.
 		woc.requeue()
 		return woc.markNodePending(nodeName, err), nil
@@ -3052,6 +3249,23 @@ func (woc *wfOperationCtx) executeSuspend(nodeName string, templateScope string,
 		}
 	}
 
+	if approval := tmpl.Suspend.Approval; approval != nil && approval.Expiry != "" {
+		node := woc.wf.GetNodeByName(nodeName)
+		expiry, err := parseStringToDuration(approval.Expiry)
+		if err != nil {
+			return node, err
+		}
+		approvalDeadline := node.StartedAt.Add(expiry)
+		if time.Now().UTC().After(approvalDeadline) {
+			woc.log.Infof("approval of node %s expired", nodeName)
+			_ = woc.markNodePhase(nodeName, wfv1.NodeFailed, "approval expired")
+			return node, nil
+		}
+		if requeueTime == nil || approvalDeadline.Before(*requeueTime) {
+			requeueTime = &approvalDeadline
+		}
+	}
+
 	// workflowDeadline is the time when the workflow will be timed out, if any
 	if workflowDeadline := woc.getWorkflowDeadline(); workflowDeadline != nil {
 		// There is an active workflow deadline. If this node is suspended with a duration, choose the earlier time
@@ -3108,6 +3322,15 @@ func addRawOutputFields(node *wfv1.NodeStatus, tmpl *wfv1.Template) *wfv1.NodeSt
 			node.Outputs.Parameters = append(node.Outputs.Parameters, param)
 		}
 	}
+	// artifacts without a location are supplied when the node is set, e.g. `argo node set --output-artifact`
+	for _, art := range tmpl.Outputs.Artifacts {
+		if !art.HasLocationOrKey() {
+			if node.Outputs == nil {
+				node.Outputs = &wfv1.Outputs{}
+			}
+			node.Outputs.Artifacts = append(node.Outputs.Artifacts, art)
+		}
+	}
 	return node
 }
 
@@ -3274,11 +3497,12 @@ func (woc *wfOperationCtx) substituteParamsInVolumes(params map[string]string) e
 func (woc *wfOperationCtx) createTemplateContext(scope wfv1.ResourceScope, resourceName string) (*templateresolution.Context, error) {
 	var clusterWorkflowTemplateGetter templateresolution.ClusterWorkflowTemplateGetter
 	if woc.controller.cwftmplInformer != nil {
-		clusterWorkflowTemplateGetter = woc.controller.cwftmplInformer.Lister()
+		clusterWorkflowTemplateGetter = templateresolution.WithNamespaceRestrictions(templateresolution.WithClusterWorkflowTemplateRevisions(woc.controller.cwftmplInformer.Lister(), woc.controller.kubeclientset, woc.controller.namespace), woc.wf.Namespace)
 	} else {
 		clusterWorkflowTemplateGetter = &templateresolution.NullClusterWorkflowTemplateGetter{}
 	}
-	ctx := templateresolution.NewContext(woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace), clusterWorkflowTemplateGetter, woc.execWf, woc.wf)
+	workflowTemplateGetter := templateresolution.WithWorkflowTemplateRevisions(woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace), woc.controller.kubeclientset, woc.wf.Namespace)
+	ctx := templateresolution.NewContext(workflowTemplateGetter, clusterWorkflowTemplateGetter, woc.execWf, woc.wf)
 
 	switch scope {
 	case wfv1.ResourceScopeNamespaced:
@@ -3389,6 +3613,23 @@ func (woc *wfOperationCtx) computeMetrics(metricList []*wfv1.Prometheus, localSc
 
 			metricSpec.SetValueString(replacedStringJson)
 
+			// Buckets computed from the outputs of the node are resolved at emission time, different buckets result
+			// in a different metric
+			if metricSpec.Histogram != nil && metricSpec.Histogram.BucketsFrom != "" {
+				bucketsFrom, err := substituteMetricString(metricSpec.Histogram.BucketsFrom, localScope)
+				if err != nil {
+					woc.reportMetricEmissionError(fmt.Sprintf("unable to substitute buckets for metric '%s': %s", metricSpec.Name, err))
+					continue
+				}
+				buckets, err := metrics.ParseHistogramBuckets(bucketsFrom)
+				if err != nil {
+					woc.reportMetricEmissionError(fmt.Sprintf("invalid buckets for metric '%s': %s", metricSpec.Name, err))
+					continue
+				}
+				metricSpec.Histogram.Buckets = buckets
+				metricSpec.Histogram.BucketsFrom = ""
+			}
+
 			metric := woc.controller.metrics.GetCustomMetric(metricSpec.GetDesc())
 			// It is valid to pass a nil metric to ConstructOrUpdateMetric, in that case the metric will be created for us
 			updatedMetric, err := metrics.ConstructOrUpdateMetric(metric, metricSpec)
@@ -3406,6 +3647,21 @@ func (woc *wfOperationCtx) computeMetrics(metricList []*wfv1.Prometheus, localSc
 	}
 }
 
+// substituteMetricString substitutes parameters in a metric field, escaping them as they would be in the metric's JSON
+func substituteMetricString(value string, localScope map[string]string) (string, error) {
+	valueJson, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	replacedJson, err := template.Replace(string(valueJson), localScope, false)
+	if err != nil {
+		return "", err
+	}
+	var replaced string
+	err = json.Unmarshal([]byte(replacedJson), &replaced)
+	return replaced, err
+}
+
 func (woc *wfOperationCtx) reportMetricEmissionError(errorString string) {
 	woc.wf.Status.Conditions.UpsertConditionMessage(
 		wfv1.Condition{
@@ -3479,6 +3735,93 @@ func (woc *wfOperationCtx) deletePDBResource(ctx context.Context) error {
 	return nil
 }
 
+// templatePDBKey returns the value of the PDB label of the pods of the template, as template names are neither valid
+// label values nor object names
+func templatePDBKey(tmpl *wfv1.Template) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tmpl.Name))
+	return fmt.Sprintf("%v", h.Sum32())
+}
+
+// createTemplatePDBResource creates the PDB of the pods of the template, unless it already exists
+func (woc *wfOperationCtx) createTemplatePDBResource(ctx context.Context, tmpl *wfv1.Template) error {
+	if tmpl.PodDisruptionBudget == nil {
+		return nil
+	}
+	key := templatePDBKey(tmpl)
+	labels := map[string]string{common.LabelKeyWorkflow: woc.wf.Name, common.LabelKeyPodDisruptionBudget: key}
+	pdbSpec := *tmpl.PodDisruptionBudget
+	if pdbSpec.Selector == nil {
+		pdbSpec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	}
+	newPDB := policyv1beta.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   woc.wf.Name + "-" + key,
+			Labels: labels,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(woc.wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind)),
+			},
+		},
+		Spec: pdbSpec,
+	}
+	_, err := woc.controller.kubeclientset.PolicyV1beta1().PodDisruptionBudgets(woc.wf.Namespace).Create(ctx, &newPDB, metav1.CreateOptions{})
+	if apierr.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	woc.log.WithField("template", tmpl.Name).Info("Created PDB resource for template.")
+	return nil
+}
+
+// hasTemplatePDBs returns whether any template of the workflow, including the templates it references, has a PDB
+func (woc *wfOperationCtx) hasTemplatePDBs() bool {
+	if woc.execWf.Spec.TemplateDefaults != nil && woc.execWf.Spec.TemplateDefaults.PodDisruptionBudget != nil {
+		return true
+	}
+	for _, tmpl := range woc.execWf.Spec.Templates {
+		if tmpl.PodDisruptionBudget != nil {
+			return true
+		}
+	}
+	for _, tmpl := range woc.wf.Status.StoredTemplates {
+		if tmpl.PodDisruptionBudget != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteTemplatePDBResources deletes the PDBs of the templates of the workflow. Any that are missed, e.g. those of
+// inline templates, are deleted with the workflow.
+func (woc *wfOperationCtx) deleteTemplatePDBResources(ctx context.Context) error {
+	if !woc.hasTemplatePDBs() {
+		return nil
+	}
+	pdbs := woc.controller.kubeclientset.PolicyV1beta1().PodDisruptionBudgets(woc.wf.Namespace)
+	selector := fmt.Sprintf("%s=%s,%s", common.LabelKeyWorkflow, woc.wf.Name, common.LabelKeyPodDisruptionBudget)
+	err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
+		list, err := pdbs.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return !errorsutil.IsTransientErr(err), err
+		}
+		for _, pdb := range list.Items {
+			err := pdbs.Delete(ctx, pdb.Name, metav1.DeleteOptions{})
+			if err != nil && !apierr.IsNotFound(err) {
+				return !errorsutil.IsTransientErr(err), err
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		woc.log.WithField("err", err).Error("Unable to delete PDB resources for templates.")
+		return err
+	}
+	woc.log.Info("Deleted PDB resources for templates.")
+	return nil
+}
+
 // Check if the output of this node is referenced elsewhere in the Workflow. If so, make sure to include it during
 // execution.
 func (woc *wfOperationCtx) includeScriptOutput(nodeName, boundaryID string) (bool, error) {
@@ -3515,9 +3858,11 @@ func (woc *wfOperationCtx) fetchWorkflowSpec() (wfv1.WorkflowSpecHolder, error)
 			woc.log.WithError(err).Error("clusterWorkflowTemplate RBAC is missing")
 			return nil, fmt.Errorf("cannot get resource clusterWorkflowTemplate at cluster scope")
 		}
-		specHolder, err = woc.controller.cwftmplInformer.Lister().Get(woc.wf.Spec.WorkflowTemplateRef.Name) // not-woc-misuse
+		getter := templateresolution.WithNamespaceRestrictions(templateresolution.WithClusterWorkflowTemplateRevisions(woc.controller.cwftmplInformer.Lister(), woc.controller.kubeclientset, woc.controller.namespace), woc.wf.Namespace)
+		specHolder, err = templateresolution.GetClusterWorkflowTemplate(getter, woc.wf.Spec.WorkflowTemplateRef.Name, woc.wf.Spec.WorkflowTemplateRef.Revision) // not-woc-misuse
 	} else {
-		specHolder, err = woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace).Get(woc.wf.Spec.WorkflowTemplateRef.Name) // not-woc-misuse
+		getter := templateresolution.WithWorkflowTemplateRevisions(woc.controller.wftmplInformer.Lister().WorkflowTemplates(woc.wf.Namespace), woc.controller.kubeclientset, woc.wf.Namespace)
+		specHolder, err = templateresolution.GetWorkflowTemplate(getter, woc.wf.Spec.WorkflowTemplateRef.Name, woc.wf.Spec.WorkflowTemplateRef.Revision) // not-woc-misuse
 	}
 	if err != nil {
 		return nil, err
@@ -3557,8 +3902,8 @@ func (woc *wfOperationCtx) setExecWorkflow(ctx context.Context) error {
 	// Perform one-time workflow validation
 	if woc.wf.Status.Phase == wfv1.WorkflowUnknown {
 		validateOpts := validate.ValidateOpts{}
-		wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(woc.controller.wfclientset.ArgoprojV1alpha1().WorkflowTemplates(woc.wf.Namespace))
-		cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(woc.controller.wfclientset.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+		wftmplGetter := templateresolution.WithWorkflowTemplateRevisions(templateresolution.WrapWorkflowTemplateInterface(woc.controller.wfclientset.ArgoprojV1alpha1().WorkflowTemplates(woc.wf.Namespace)), woc.controller.kubeclientset, woc.wf.Namespace)
+		cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WithClusterWorkflowTemplateRevisions(templateresolution.WrapClusterWorkflowTemplateInterface(woc.controller.wfclientset.ArgoprojV1alpha1().ClusterWorkflowTemplates()), woc.controller.kubeclientset, woc.controller.namespace), woc.wf.Namespace)
 
 		// Validate the execution wfSpec
 		err := waitutil.Backoff(retry.DefaultRetry,
@@ -3574,6 +3919,18 @@ func (woc *wfOperationCtx) setExecWorkflow(ctx context.Context) error {
 			woc.markWorkflowFailed(ctx, msg)
 			return err
 		}
+
+		// Check the workflow with the admission policies and webhooks, as it may not have been created by the Argo
+		// Server, e.g. by kubectl, a cron workflow or an event binding
+		err = waitutil.Backoff(retry.DefaultRetry,
+			func() (bool, error) {
+				admissionErr := woc.controller.admission.Check(ctx, woc.wf, wftmplGetter, cwftmplGetter)
+				return status.Code(admissionErr) != codes.Unavailable, admissionErr
+			})
+		if err != nil {
+			woc.markWorkflowFailed(ctx, status.Convert(err).Message())
+			return err
+		}
 	}
 	err := woc.setGlobalParameters(woc.execWf.Spec.Arguments)
 	if err != nil {
@@ -3627,7 +3984,10 @@ func (woc *wfOperationCtx) needsStoredWfSpecUpdate() bool {
 }
 
 func (woc *wfOperationCtx) setStoredWfSpec() error {
-	wfDefault := woc.controller.Config.WorkflowDefaults
+	wfDefault, err := woc.controller.getWorkflowDefaults(woc.wf.Namespace)
+	if err != nil {
+		return err
+	}
 	if wfDefault == nil {
 		wfDefault = &wfv1.Workflow{}
 	}
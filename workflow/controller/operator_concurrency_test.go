@@ -13,6 +13,7 @@ import (
 
 	argoErr "github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/sync"
 )
 
@@ -833,3 +834,50 @@ func TestSynchronizationWithStepRetry(t *testing.T) {
 	})
 
 }
+
+func TestReleaseLockAnnotation(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	controller.syncManager = sync.NewLockManager(GetSyncLimitFunc(ctx, controller.kubeclientset), func(key string) {
+	}, workflowExistenceFunc)
+	var cm v1.ConfigMap
+	wfv1.MustUnmarshal([]byte(configMap), &cm)
+	_, err := controller.kubeclientset.CoreV1().ConfigMaps("default").Create(ctx, &cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	wf := wfv1.MustUnmarshalWorkflow(wfWithSemaphore)
+	wf, err = controller.wfclientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	if assert.NotNil(t, woc.wf.Status.Synchronization) && assert.NotNil(t, woc.wf.Status.Synchronization.Semaphore) {
+		assert.Len(t, woc.wf.Status.Synchronization.Semaphore.Holding, 1)
+	}
+
+	// a node that finished without releasing its lock
+	for id, node := range woc.wf.Status.Nodes {
+		node.Phase = wfv1.NodeSucceeded
+		woc.wf.Status.Nodes[id] = node
+	}
+	woc.wf.Annotations = map[string]string{common.AnnotationKeyReleaseLock: "default/ConfigMap/my-config/template"}
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.NotContains(t, woc.wf.Annotations, common.AnnotationKeyReleaseLock)
+	if woc.wf.Status.Synchronization != nil && woc.wf.Status.Synchronization.Semaphore != nil {
+		for _, holding := range woc.wf.Status.Synchronization.Semaphore.Holding {
+			assert.Empty(t, holding.Holders)
+		}
+	}
+
+	// the lock is available to another workflow
+	wfTwo := wfv1.MustUnmarshalWorkflow(wfWithSemaphore)
+	wfTwo.Name = "two"
+	wfTwo, err = controller.wfclientset.ArgoprojV1alpha1().Workflows(wfTwo.Namespace).Create(ctx, wfTwo, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	wocTwo := newWorkflowOperationCtx(wfTwo, controller)
+	wocTwo.operate(ctx)
+	if assert.NotNil(t, wocTwo.wf.Status.Synchronization) && assert.NotNil(t, wocTwo.wf.Status.Synchronization.Semaphore) {
+		assert.Len(t, wocTwo.wf.Status.Synchronization.Semaphore.Holding, 1)
+	}
+}
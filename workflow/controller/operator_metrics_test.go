@@ -862,3 +862,55 @@ func TestRuntimeMetrics(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, metricString, `Succeeded`)
 }
+
+var histogramBucketsFromMetrics = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: histogram-buckets-from
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: docker/whalesay:latest
+    outputs:
+      parameters:
+      - name: buckets
+        valueFrom:
+          path: /tmp/buckets
+      - name: size
+        valueFrom:
+          path: /tmp/size
+    metrics:
+      prometheus:
+      - name: output_size
+        help: Size of the output
+        histogram:
+          bucketsFrom: "{{outputs.parameters.buckets}}"
+          value: "{{outputs.parameters.size}}"
+`
+
+func TestHistogramBucketsFrom(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	wfcset := controller.wfclientset.ArgoprojV1alpha1().Workflows("")
+	wf := v1alpha1.MustUnmarshalWorkflow(histogramBucketsFromMetrics)
+	_, err := wfcset.Create(ctx, wf, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	woc := newWorkflowOperationCtx(wf, controller)
+
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded, withOutputs(`{"parameters": [{"name": "buckets", "value": "[100, 10]"}, {"name": "size", "value": "42"}]}`))
+	woc.operate(ctx)
+
+	metricSpec := woc.wf.GetTemplateByName("main").Metrics.Prometheus[0].DeepCopy()
+	metricSpec.Histogram.Buckets = []v1alpha1.Amount{{Value: "10"}, {Value: "100"}}
+	metric := controller.metrics.GetCustomMetric(metricSpec.GetDesc())
+	if assert.NotNil(t, metric) {
+		metricString, err := getMetricStringValue(metric)
+		assert.NoError(t, err)
+		assert.Contains(t, metricString, `histogram:<sample_count:1 sample_sum:42 bucket:<cumulative_count:0 upper_bound:10 > bucket:<cumulative_count:1 upper_bound:100 >`)
+	}
+}
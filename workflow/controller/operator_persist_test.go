@@ -21,7 +21,7 @@ func getMockDBCtx(expectedError error, largeWfSupport bool) (*mocks.OffloadNodeS
 	mockDBRepo.On("Save", mock.Anything, mock.Anything, mock.Anything).Return("my-offloaded-version", expectedError)
 	mockDBRepo.On("Get", mock.Anything, mock.Anything).Return(wfv1.Nodes{"my-node": wfv1.NodeStatus{}}, nil)
 	mockDBRepo.On("IsEnabled").Return(largeWfSupport)
-	return mockDBRepo, hydrator.New(mockDBRepo)
+	return mockDBRepo, hydrator.New(mockDBRepo, nil)
 }
 
 var helloWorldWfPersist = `
@@ -522,3 +522,47 @@ func TestTemplateClusterScope(t *testing.T) {
 		assert.Equal(t, "namespaced/test-template-scope-2", node.TemplateScope)
 	}
 }
+
+var testTemplateImportsWorkflowYaml = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: test-template-imports
+  namespace: default
+spec:
+  entrypoint: entry
+  templateImports:
+  - name: test-template-scope-2
+  templates:
+  - name: entry
+    steps:
+      - - name: step
+          template: steps
+`
+
+func TestTemplateImportsScope(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(testTemplateImportsWorkflowYaml)
+	wftmpl := wfv1.MustUnmarshalWorkflowTemplate(testTemplateScopeWorkflowTemplateYaml2)
+
+	cancel, controller := newController(wf, wftmpl)
+	defer cancel()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	ctx := context.Background()
+	woc.operate(ctx)
+
+	wf = woc.wf
+	assert.Equal(t, wfv1.WorkflowRunning, wf.Status.Phase)
+
+	node := findNodeByName(wf.Status.Nodes, "test-template-imports[0].step")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, wfv1.NodeTypeSteps, node.Type)
+		assert.Equal(t, "local/test-template-imports", node.TemplateScope)
+	}
+
+	node = findNodeByName(wf.Status.Nodes, "test-template-imports[0].step[0].hello")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, wfv1.NodeTypePod, node.Type)
+		assert.Equal(t, "namespaced/test-template-scope-2", node.TemplateScope)
+	}
+}
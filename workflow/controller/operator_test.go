@@ -12,8 +12,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/argoproj/pkg/humanize"
 	"github.com/argoproj/pkg/strftime"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -31,11 +33,14 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/config"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/admission"
 	intstrutil "github.com/argoproj/argo-workflows/v3/util/intstr"
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
 	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
+	"github.com/argoproj/argo-workflows/v3/workflow/notification"
 	"github.com/argoproj/argo-workflows/v3/workflow/sync"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
@@ -73,7 +78,7 @@ func Test_wfOperationCtx_reapplyUpdate(t *testing.T) {
 		woc := newWorkflowOperationCtx(wf, controller)
 
 		// fake the behaviour woc.operate()
-		assert.NoError(t, controller.hydrator.Hydrate(wf))
+		assert.NoError(t, controller.hydrator.Hydrate(ctx, wf))
 		nodes := wfv1.Nodes{"foo": wfv1.NodeStatus{Name: "my-foo", Phase: wfv1.NodeSucceeded}}
 
 		// now force a re-apply update
@@ -1104,6 +1109,43 @@ func TestBackoffMessage(t *testing.T) {
 	assert.Equal(t, "", newRetryNode.Message)
 }
 
+func TestBackoffJitter(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	wf := wfv1.MustUnmarshalWorkflow(backoffMessage)
+	retryStrategy := *wf.Spec.Templates[0].RetryStrategy
+	retryStrategy.Backoff.Duration = "60"
+	retryStrategy.Backoff.Jitter = &wfv1.Amount{Value: "0.5"}
+	woc := newWorkflowOperationCtx(wf, controller)
+	retryNode := woc.wf.GetNodeByName("retry-backoff-s69z6")
+
+	firstNode := getChildNodeIndex(retryNode, woc.wf.Status.Nodes, 0)
+	firstNode.StartedAt = metav1.Time{Time: time.Now().Add(-8 * time.Second)}
+	woc.wf.Status.Nodes[firstNode.ID] = *firstNode
+	lastNode := getChildNodeIndex(retryNode, woc.wf.Status.Nodes, -1)
+	lastNode.FinishedAt = metav1.Time{Time: time.Now().Add(-1 * time.Second)}
+	woc.wf.Status.Nodes[lastNode.ID] = *lastNode
+	retryStrategy.Backoff.MaxDuration = "1h"
+
+	// the second retry backs off 60s * 2, plus up to half of that
+	fraction := retryJitterFraction(retryNode.ID, 2)
+	assert.True(t, fraction >= 0 && fraction < 1)
+	expected := fmt.Sprintf("Backoff for %s", humanize.Duration(time.Duration(float64(2*time.Minute)*(1+0.5*fraction))))
+	for i := 0; i < 2; i++ {
+		// the jitter is the same each time the node is reconciled
+		newRetryNode, proceed, err := woc.processNodeRetries(retryNode, retryStrategy, &executeTemplateOpts{})
+		assert.NoError(t, err)
+		assert.False(t, proceed)
+		assert.Equal(t, expected, newRetryNode.Message)
+	}
+	assert.NotEqual(t, fraction, retryJitterFraction(retryNode.ID, 3))
+	assert.NotEqual(t, fraction, retryJitterFraction("another-node", 2))
+
+	retryStrategy.Backoff.Jitter = &wfv1.Amount{Value: "2"}
+	_, _, err := woc.processNodeRetries(retryNode, retryStrategy, &executeTemplateOpts{})
+	assert.EqualError(t, err, "jitter 2 must be between 0 and 1")
+}
+
 var retriesVariableTemplate = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -1366,18 +1408,6 @@ func TestAssessNodeStatus(t *testing.T) {
 	}
 }
 
-func getPodTemplate(pod *apiv1.Pod) (*wfv1.Template, error) {
-	tmpl := &wfv1.Template{}
-	for _, c := range pod.Spec.Containers {
-		for _, e := range c.Env {
-			if e.Name == common.EnvVarTemplate {
-				return tmpl, json.Unmarshal([]byte(e.Value), tmpl)
-			}
-		}
-	}
-	return nil, fmt.Errorf("not found")
-}
-
 func getPodDeadline(pod *apiv1.Pod) (time.Time, error) {
 	for _, c := range pod.Spec.Containers {
 		for _, e := range c.Env {
@@ -1899,7 +1929,7 @@ func TestSuspendResume(t *testing.T) {
 	assert.Equal(t, 0, len(pods.Items))
 
 	// resume the workflow and operate again. two pods should be able to be scheduled
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "", nil)
 	assert.NoError(t, err)
 	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -1957,6 +1987,49 @@ func TestSuspendWithDeadline(t *testing.T) {
 	assert.True(t, found)
 }
 
+var suspendTemplateWithApproval = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: suspend-template
+spec:
+  entrypoint: suspend
+  templates:
+  - name: suspend
+    suspend:
+      approval:
+        groups: [approvers]
+        minApprovals: 2
+        expiry: 1h
+`
+
+func TestSuspendWithApproval(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(suspendTemplateWithApproval)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes.FindByDisplayName("suspend-template")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, wfv1.NodeRunning, node.Phase)
+		assert.Equal(t, &wfv1.NodeApproval{Approval: wfv1.Approval{Groups: []string{"approvers"}, MinApprovals: 2, Expiry: "1h"}}, node.Approval)
+	}
+
+	// the node fails if it is not approved before the approval expires
+	node.StartedAt = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	woc.wf.Status.Nodes[node.ID] = *node
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	node = woc.wf.Status.Nodes.FindByDisplayName("suspend-template")
+	if assert.NotNil(t, node) {
+		assert.Equal(t, wfv1.NodeFailed, node.Phase)
+		assert.Equal(t, "approval expired", node.Message)
+	}
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+}
+
 var suspendTemplateInputResolution = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -2272,7 +2345,7 @@ func TestSuspendTemplate(t *testing.T) {
 	assert.Equal(t, 0, len(pods.Items))
 
 	// resume the workflow. verify resume workflow edits nodestatus correctly
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "", nil)
 	assert.NoError(t, err)
 	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -2349,7 +2422,7 @@ func TestSuspendTemplateWithFilteredResume(t *testing.T) {
 	assert.Equal(t, 0, len(pods.Items))
 
 	// resume the workflow, but with non-matching selector
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.paramaters.param1.value=value2")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.paramaters.param1.value=value2", nil)
 	assert.Error(t, err)
 
 	// operate the workflow. nothing should have happened
@@ -2361,7 +2434,7 @@ func TestSuspendTemplateWithFilteredResume(t *testing.T) {
 	assert.True(t, util.IsWorkflowSuspended(wf))
 
 	// resume the workflow, but with matching selector
-	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.parameters.param1.value=value1")
+	err = util.ResumeWorkflow(ctx, wfcset, controller.hydrator, wf.ObjectMeta.Name, "inputs.parameters.param1.value=value1", nil)
 	assert.NoError(t, err)
 	wf, err = wfcset.Get(ctx, wf.ObjectMeta.Name, metav1.GetOptions{})
 	assert.NoError(t, err)
@@ -3370,6 +3443,91 @@ func TestStepsOnExitFailures(t *testing.T) {
 	assert.Contains(t, woc.globalParams[common.GlobalVarWorkflowFailures], `[{\"displayName\":\"exit-handlers\",\"message\":\"Pod failed\",\"templateName\":\"intentional-fail\",\"phase\":\"Failed\",\"podName\":\"exit-handlers\"`)
 }
 
+var onExitFailedNodes = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: exit-handlers
+spec:
+  entrypoint: intentional-fail
+  onExit: exit-handler
+  templates:
+  - name: intentional-fail
+    container:
+      image: alpine:latest
+      command: [sh, -c]
+      args: ["echo intentional failure; exit 1"]
+  - name: exit-handler
+    dag:
+      tasks:
+      - name: summarize
+        template: summarize
+        arguments:
+          parameters:
+          - name: failures
+            value: "{{workflow.failedNodes}}"
+      - name: report
+        template: report
+        arguments:
+          parameters:
+          - name: name
+            value: "{{item.name}}"
+          - name: message
+            value: "{{item.message}}"
+        withParam: "{{workflow.failedNodes}}"
+  - name: summarize
+    inputs:
+      parameters:
+      - name: failures
+    container:
+      image: alpine:latest
+      command: [echo, "{{inputs.parameters.failures}}"]
+  - name: report
+    inputs:
+      parameters:
+      - name: name
+      - name: message
+    container:
+      image: alpine:latest
+      command: [echo, "{{inputs.parameters.name}}: {{inputs.parameters.message}}"]
+`
+
+func TestOnExitFailedNodes(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(onExitFailedNodes)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodFailed)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	var failures []failedNodeStatus
+	if assert.NoError(t, json.Unmarshal([]byte(woc.globalParams[common.GlobalVarWorkflowFailedNodes]), &failures)) && assert.Len(t, failures, 1) {
+		assert.Equal(t, "exit-handlers", failures[0].Name)
+		assert.Equal(t, "Pod failed", failures[0].Message)
+		assert.Equal(t, "Pod", failures[0].Type)
+	}
+	summarize := woc.wf.Status.Nodes.FindByDisplayName("summarize")
+	if assert.NotNil(t, summarize) {
+		var failures []failedNodeStatus
+		assert.NoError(t, json.Unmarshal([]byte(summarize.Inputs.Parameters[0].Value.String()), &failures))
+		assert.Len(t, failures, 1)
+	}
+	var reports []wfv1.NodeStatus
+	for _, node := range woc.wf.Status.Nodes {
+		if node.TemplateName == "report" && node.Type == wfv1.NodeTypePod {
+			reports = append(reports, node)
+		}
+	}
+	if assert.Len(t, reports, 1) {
+		assert.Equal(t, "exit-handlers", reports[0].Inputs.Parameters[0].Value.String())
+		assert.Equal(t, "Pod failed", reports[0].Inputs.Parameters[1].Value.String())
+	}
+}
+
 var onExitTimeout = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3512,6 +3670,97 @@ spec:
 	}
 }
 
+type recordingNotifier struct {
+	notifications []string
+}
+
+func (n *recordingNotifier) Notify(x notification.Notification) {
+	name := x.Name
+	if x.Node != nil {
+		name = x.Node.Name
+	}
+	n.notifications = append(n.notifications, fmt.Sprintf("%s %s %s", x.Type, x.Phase, name))
+}
+
+func (n *recordingNotifier) Close() {}
+
+func TestNotifyPhaseChanges(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: notify
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: whalesay
+    - name: whalesay
+      container:
+        image: docker/whalesay:latest
+`)
+	ctx := context.Background()
+	cancel, controller := newController(wf)
+	defer cancel()
+	notifier := &recordingNotifier{}
+	controller.notifier = notifier
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.ElementsMatch(t, []string{
+		"Workflow Running notify",
+		"Node Running notify",
+		"Node Pending notify.a",
+	}, notifier.notifications)
+	notifier.notifications = nil
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.ElementsMatch(t, []string{
+		"Node Succeeded notify.a",
+		"Node Succeeded notify",
+		"Workflow Succeeded notify",
+	}, notifier.notifications)
+}
+
+func TestObserveNodeDurations(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: durations
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: whalesay
+    - name: whalesay
+      container:
+        image: docker/whalesay:latest
+`)
+	ctx := context.Background()
+	cancel, controller := newController(wf)
+	defer cancel()
+	controller.metrics = metrics.New(metrics.ServerConfig{DurationHistograms: metrics.DurationHistogramsConfig{Enabled: true}}, metrics.ServerConfig{})
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, 0, testutil.CollectAndCount(controller.metrics, "argo_workflows_node_duration_seconds"))
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	// the pod and DAG nodes have the same labels
+	assert.Equal(t, 1, testutil.CollectAndCount(controller.metrics, "argo_workflows_node_duration_seconds"))
+}
+
+func TestTemplateRefLabel(t *testing.T) {
+	woc := &wfOperationCtx{wf: &wfv1.Workflow{}}
+	assert.Empty(t, woc.templateRefLabel(wfv1.NodeStatus{TemplateName: "my-tmpl"}))
+	assert.Equal(t, "my-wftmpl/my-tmpl", woc.templateRefLabel(wfv1.NodeStatus{TemplateRef: &wfv1.TemplateRef{Name: "my-wftmpl", Template: "my-tmpl"}}))
+	woc.wf.Spec.WorkflowTemplateRef = &wfv1.WorkflowTemplateRef{Name: "my-wftmpl"}
+	assert.Equal(t, "my-wftmpl/main", woc.templateRefLabel(wfv1.NodeStatus{TemplateName: "main"}))
+}
+
 func TestEventNodeEventsAsPod(t *testing.T) {
 	for manifest, want := range map[string][]string{
 		// Invalid spec
@@ -3705,6 +3954,54 @@ func TestPDBCreationRaceDelete(t *testing.T) {
 	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
 }
 
+var templatePDBWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-template-pdb-wf
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    podDisruptionBudget:
+      maxUnavailable: 0
+    disruptionSensitive: true
+    container:
+      image: docker/whalesay:latest
+      command: [cowsay]
+`
+
+func TestTemplatePDBCreation(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(templatePDBWorkflow)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+		pod := pods.Items[0]
+		assert.Equal(t, "false", pod.Annotations[common.AnnotationKeySafeToEvict])
+		key := pod.Labels[common.LabelKeyPodDisruptionBudget]
+		assert.NotEmpty(t, key)
+		pdb, err := controller.kubeclientset.PolicyV1beta1().PodDisruptionBudgets("").Get(ctx, wf.Name+"-"+key, metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{common.LabelKeyWorkflow: wf.Name, common.LabelKeyPodDisruptionBudget: key}, pdb.Spec.Selector.MatchLabels)
+			assert.Equal(t, int32(0), pdb.Spec.MaxUnavailable.IntVal)
+		}
+	}
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowSucceeded, woc.wf.Status.Phase)
+	pdbs, err := controller.kubeclientset.PolicyV1beta1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	if assert.NoError(t, err) {
+		assert.Empty(t, pdbs.Items)
+	}
+}
+
 func TestStatusConditions(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(pdbwf)
 	cancel, controller := newController(wf)
@@ -4305,6 +4602,24 @@ func TestUnsuppliedArgValue(t *testing.T) {
 	assert.Equal(t, woc.wf.Status.Message, "invalid spec: spec.arguments.missing.value is required")
 }
 
+func TestAdmission(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	cancel, controller := newController(wf, func(controller *WorkflowController) {
+		controller.admission, _ = admission.New(&config.AdmissionConfig{Policies: []config.AdmissionPolicy{{
+			Name:       "team",
+			Expression: `"team" in workflow.metadata.labels`,
+			Message:    "workflows must have a team label",
+		}}})
+	})
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	assert.Equal(t, `workflow rejected by admission "team": workflows must have a team label`, woc.wf.Status.Message)
+}
+
 var suppliedArgValue = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -5003,6 +5318,7 @@ func TestConfigMapCacheLoadOperate(t *testing.T) {
 	_, err = controller.kubeclientset.CoreV1().ConfigMaps("default").Create(ctx, &sampleConfigMapCacheEntry, metav1.CreateOptions{})
 	assert.NoError(t, err)
 
+	hits := testutil.ToFloat64(metrics.MemoizationCacheMetric.WithLabelValues("whalesay-cache", "whalesay", "hit"))
 	woc := newWorkflowOperationCtx(wf, controller)
 	woc.operate(ctx)
 
@@ -5012,8 +5328,12 @@ func TestConfigMapCacheLoadOperate(t *testing.T) {
 			assert.Equal(t, "hello", node.Outputs.Parameters[0].Name)
 			assert.Equal(t, "foobar", node.Outputs.Parameters[0].Value.String())
 			assert.Equal(t, wfv1.NodeSucceeded, node.Phase)
+			if assert.Len(t, node.Outputs.Artifacts, 1) {
+				assert.Equal(t, wfv1.ArtifactGCNever, node.Outputs.Artifacts[0].GetArtifactGC().GetStrategy())
+			}
 		}
 	}
+	assert.Equal(t, hits+1, testutil.ToFloat64(metrics.MemoizationCacheMetric.WithLabelValues("whalesay-cache", "whalesay", "hit")))
 }
 
 var workflowCachedMaxAge = `
@@ -5321,7 +5641,7 @@ status:
       name: my-wf
       phase: Failed
 `)
-	wf, err := util.FormulateResubmitWorkflow(wf, true, nil)
+	wf, err := util.FormulateResubmitWorkflow(wf, true, nil, util.MetadataCarryOver{})
 	if assert.NoError(t, err) {
 		cancel, controller := newController(wf)
 		defer cancel()
@@ -5370,7 +5690,7 @@ status:
       name: my-wf
       phase: Failed
 `)
-	wf, err := util.FormulateResubmitWorkflow(wf, true, []string{"message=modified"})
+	wf, err := util.FormulateResubmitWorkflow(wf, true, []string{"message=modified"}, util.MetadataCarryOver{})
 	if assert.NoError(t, err) {
 		cancel, controller := newController(wf)
 		defer cancel()
@@ -5724,6 +6044,64 @@ func TestFailSuspendedAndPendingNodesAfterShutdown(t *testing.T) {
 	})
 }
 
+var drainWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: drain
+spec:
+  entrypoint: main
+  onExit: exit
+  templates:
+  - name: main
+    steps:
+    - - name: a
+        template: container
+    - - name: b
+        template: container
+  - name: container
+    container:
+      image: argoproj/argosay:v2
+      command: [/argosay]
+  - name: exit
+    container:
+      image: argoproj/argosay:v2
+      command: [/argosay]
+`
+
+func TestDrainShutdownStrategy(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(drainWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+
+	ctx := context.Background()
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodRunning)
+
+	woc.wf.Spec.Shutdown = wfv1.ShutdownStrategyDrain
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	assert.Equal(t, wfv1.NodeRunning, woc.wf.Status.Nodes.FindByDisplayName("a").Phase, "running pods are not terminated")
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.NodeSucceeded, woc.wf.Status.Nodes.FindByDisplayName("a").Phase)
+	assert.Equal(t, wfv1.NodeFailed, woc.wf.Status.Nodes.FindByDisplayName("b").Phase, "new nodes are not started")
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) {
+		assert.Len(t, pods.Items, 2, "the exit handler is run")
+	}
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, wfv1.WorkflowFailed, woc.wf.Status.Phase)
+	assert.Equal(t, "Stopped with strategy 'Drain'", woc.wf.Status.Message)
+}
+
 func Test_processItem(t *testing.T) {
 	task := wfv1.DAGTask{
 		WithParam: `[{"number": 2, "string": "foo", "list": [0, "1"], "json": {"number": 2, "string": "foo", "list": [0, "1"]}}]`,
@@ -11,6 +11,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util"
+	"github.com/argoproj/argo-workflows/v3/workflow/templaterevision"
 )
 
 func TestWorkflowTemplateRef(t *testing.T) {
@@ -108,6 +109,37 @@ func TestWorkflowTemplateRefWithWorkflowTemplateArgs(t *testing.T) {
 	})
 }
 
+func TestWorkflowTemplateRefRevision(t *testing.T) {
+	ctx := context.Background()
+	wftmpl := wfv1.MustUnmarshalWorkflowTemplate(wfTmpl)
+	wftmpl.Generation = 2
+	wftmpl.UID = "my-uid"
+	revision := wftmpl.DeepCopy()
+	revision.Generation = 1
+	revision.Spec.ServiceAccountName = "old-sa"
+	t.Run("Pinned", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wf.Spec.WorkflowTemplateRef.Revision = 1
+		cancel, controller := newController(wf, wftmpl)
+		defer cancel()
+		assert.NoError(t, templaterevision.SaveWorkflowTemplate(ctx, controller.kubeclientset, revision, 10))
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+		assert.Equal(t, "old-sa", woc.globalParams["workflow.serviceAccountName"])
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(wfWithTmplRef)
+		wf.Spec.WorkflowTemplateRef.Revision = 1
+		cancel, controller := newController(wf, wftmpl)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Equal(t, wfv1.WorkflowError, woc.wf.Status.Phase)
+		assert.Contains(t, woc.wf.Status.Message, "workflow-template-whalesay-template@1")
+	})
+}
+
 const invalidWF = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
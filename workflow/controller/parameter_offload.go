@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+)
+
+// defaultOffloadParameterSize is the size above which the values of parameters are offloaded, unless configured
+const defaultOffloadParameterSize = 128 * 1024
+
+// maxParametersConfigMapSize is the size above which the values of a node are offloaded to the database, rather than
+// a ConfigMap, leaving room for the metadata within the 1MiB size limit of a ConfigMap
+const maxParametersConfigMapSize = 1000 * 1024
+
+func (wfc *WorkflowController) offloadParameterSize() int {
+	if wfc.Config.OffloadParameterSize != 0 {
+		return wfc.Config.OffloadParameterSize
+	}
+	return defaultOffloadParameterSize
+}
+
+// parametersConfigMapName is the name of the ConfigMap the large values of the node are offloaded to
+func parametersConfigMapName(nodeID string) string {
+	return nodeID + "-parameters"
+}
+
+// largeParameters returns the values of the parameters, and result, of the node larger than the size, by their key
+func largeParameters(node wfv1.NodeStatus, size int) map[string]string {
+	data := map[string]string{}
+	for k, v := range hydrator.ParameterValues(node) {
+		if len(v) > size {
+			data[k] = v
+		}
+	}
+	return data
+}
+
+func dataSize(data map[string]string) int {
+	size := 0
+	for k, v := range data {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// offloadParameters returns the nodes with the values of parameters, and results, larger than the offload size
+// replaced by a reference to a ConfigMap of their node, or to the database if they are too large for a ConfigMap and
+// node status offloading is enabled, so large outputs, e.g. aggregated ones, do not exceed the maximum size of the
+// workflow. The nodes are not modified. Values are kept if they cannot be written.
+func (woc *wfOperationCtx) offloadParameters(ctx context.Context, nodes wfv1.Nodes) wfv1.Nodes {
+	size := woc.controller.offloadParameterSize()
+	if size < 0 {
+		return nodes
+	}
+	var offloaded wfv1.Nodes
+	for id, node := range nodes {
+		data := largeParameters(node, size)
+		if len(data) == 0 {
+			continue
+		}
+		n := node.DeepCopy()
+		if dataSize(data) > maxParametersConfigMapSize {
+			if !woc.controller.offloadParametersRepo.IsEnabled() {
+				woc.log.WithField("nodeID", id).Warn("Parameters are too large for a ConfigMap, and node status offloading is not enabled, so they are not offloaded")
+				continue
+			}
+			if err := woc.writeOffloadedParameters(node, data); err != nil {
+				woc.log.WithError(err).WithField("nodeID", id).Warn("Failed to offload parameters to the database")
+				continue
+			}
+			n.ParametersConfigMap = ""
+			n.ParametersDatabaseOffload = true
+		} else {
+			name := parametersConfigMapName(node.ID)
+			if err := woc.writeParametersConfigMap(ctx, name, data); err != nil {
+				woc.log.WithError(err).WithField("nodeID", id).Warn("Failed to offload parameters")
+				continue
+			}
+			n.ParametersConfigMap = name
+			n.ParametersDatabaseOffload = false
+		}
+		if offloaded == nil {
+			offloaded = make(wfv1.Nodes, len(nodes))
+			for id, node := range nodes {
+				offloaded[id] = node
+			}
+		}
+		removed := map[string]*string{}
+		for k := range data {
+			removed[k] = nil
+		}
+		hydrator.SetParameterValues(n, removed)
+		offloaded[id] = *n
+	}
+	if offloaded == nil {
+		return nodes
+	}
+	return offloaded
+}
+
+// writeOffloadedParameters saves the data of the node to the database, unless it already has it
+func (woc *wfOperationCtx) writeOffloadedParameters(node wfv1.NodeStatus, data map[string]string) error {
+	repo := woc.controller.offloadParametersRepo
+	if node.ParametersDatabaseOffload {
+		if saved, err := repo.Get(string(woc.wf.UID), node.ID); err == nil && reflect.DeepEqual(saved, data) {
+			return nil
+		}
+	}
+	return repo.Save(string(woc.wf.UID), woc.wf.Namespace, node.ID, data)
+}
+
+// writeParametersConfigMap creates the ConfigMap with the data, or adds the data to the existing one, unless it
+// already has it
+func (woc *wfOperationCtx) writeParametersConfigMap(ctx context.Context, name string, data map[string]string) error {
+	configMaps := woc.controller.kubeclientset.CoreV1().ConfigMaps(woc.wf.Namespace)
+	cm, err := woc.controller.getParametersConfigMap(ctx, woc.wf.Namespace, name)
+	if apierr.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapOffloadedParameters,
+					common.LabelKeyWorkflow:      woc.wf.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(woc.wf, wfv1.SchemeGroupVersion.WithKind(workflow.WorkflowKind)),
+				},
+			},
+			Data: data,
+		}, metav1.CreateOptions{})
+		if !apierr.IsAlreadyExists(err) {
+			return err
+		}
+		// the informer does not have it yet
+		cm, err = configMaps.Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	changed := false
+	cm = cm.DeepCopy()
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	for k, v := range data {
+		if cm.Data[k] != v {
+			cm.Data[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+// getParametersConfigMap gets the ConfigMap from the informer, or the API if the informer does not have it yet
+func (wfc *WorkflowController) getParametersConfigMap(ctx context.Context, namespace, name string) (*apiv1.ConfigMap, error) {
+	if wfc.configMapInformer != nil {
+		obj, exists, err := wfc.configMapInformer.GetIndexer().GetByKey(namespace + "/" + name)
+		if err != nil {
+			return nil, err
+		}
+		if cm, ok := obj.(*apiv1.ConfigMap); exists && ok {
+			return cm, nil
+		}
+	}
+	return wfc.kubeclientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+}
@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+)
+
+func TestOffloadParameters(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      steps:
+        - - name: gen
+            template: gen
+        - - name: print
+            template: print
+            arguments:
+              parameters:
+                - name: items
+                  value: "{{steps.gen.outputs.result}}"
+    - name: gen
+      script:
+        image: python
+        command: [python]
+        source: print("[]")
+    - name: print
+      inputs:
+        parameters:
+          - name: items
+      container:
+        image: my-image
+        command: [echo, "{{inputs.parameters.items}}"]
+`)
+	cancel, controller := newController(wf, func(x *WorkflowController) { x.Config.OffloadParameterSize = 10 })
+	defer cancel()
+	ctx := context.Background()
+	result := "[" + strings.Repeat(`"item",`, 10) + `"item"]`
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded, withOutputs(wfv1.Outputs{Result: &result}))
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	gen := woc.wf.Status.Nodes.FindByDisplayName("gen")
+	if assert.NotNil(t, gen) && assert.NotNil(t, gen.Outputs) {
+		assert.Equal(t, result, *gen.Outputs.Result, "the values are kept in memory")
+	}
+	print := woc.wf.Status.Nodes.FindByDisplayName("print")
+	if assert.NotNil(t, print) && assert.NotNil(t, print.Inputs) {
+		assert.Equal(t, result, print.Inputs.Parameters[0].Value.String())
+	}
+
+	persisted, err := controller.wfclientset.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		node := persisted.Status.Nodes[gen.ID]
+		assert.Nil(t, node.Outputs.Result)
+		assert.Equal(t, gen.ID+"-parameters", node.ParametersConfigMap)
+		node = persisted.Status.Nodes[print.ID]
+		assert.Nil(t, node.Inputs.Parameters[0].Value)
+		assert.Equal(t, print.ID+"-parameters", node.ParametersConfigMap)
+
+		cm, err := controller.kubeclientset.CoreV1().ConfigMaps("my-ns").Get(ctx, gen.ID+"-parameters", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"outputs.result": result}, cm.Data)
+			assert.Equal(t, common.LabelValueTypeConfigMapOffloadedParameters, cm.Labels[common.LabelKeyConfigMapType])
+		}
+
+		h := hydrator.New(sqldb.ExplosiveOffloadNodeStatusRepo, hydrator.NewParametersLoader(controller.getParametersConfigMap, controller.offloadParametersRepo))
+		assert.NoError(t, h.Hydrate(ctx, persisted))
+		assert.Equal(t, result, *persisted.Status.Nodes[gen.ID].Outputs.Result)
+		assert.Equal(t, result, persisted.Status.Nodes[print.ID].Inputs.Parameters[0].Value.String())
+	}
+}
+
+func TestOffloadParametersDisabled(t *testing.T) {
+	cancel, controller := newController(func(x *WorkflowController) { x.Config.OffloadParameterSize = 10 })
+	defer cancel()
+	woc := newWorkflowOperationCtx(&wfv1.Workflow{}, controller)
+	result := "large"
+	nodes := wfv1.Nodes{"my-node": {ID: "my-node", Outputs: &wfv1.Outputs{Result: &result}}}
+	assert.Equal(t, nodes, woc.offloadParameters(context.Background(), nodes))
+}
+
+// memoryOffloadParametersRepo stores offloaded parameters in memory, by UID and node ID
+type memoryOffloadParametersRepo struct {
+	sqldb.OffloadParametersRepo
+	values map[string]map[string]string
+}
+
+func (r *memoryOffloadParametersRepo) IsEnabled() bool {
+	return true
+}
+
+func (r *memoryOffloadParametersRepo) Save(uid, _, nodeID string, values map[string]string) error {
+	r.values[uid+"/"+nodeID] = values
+	return nil
+}
+
+func (r *memoryOffloadParametersRepo) Get(uid, nodeID string) (map[string]string, error) {
+	return r.values[uid+"/"+nodeID], nil
+}
+
+func TestOffloadParametersDatabase(t *testing.T) {
+	repo := &memoryOffloadParametersRepo{values: map[string]map[string]string{}}
+	result := strings.Repeat("x", maxParametersConfigMapSize)
+	nodes := wfv1.Nodes{"my-node": {ID: "my-node", Outputs: &wfv1.Outputs{Result: &result}}}
+
+	t.Run("Enabled", func(t *testing.T) {
+		cancel, controller := newController(func(x *WorkflowController) { x.offloadParametersRepo = repo })
+		defer cancel()
+		woc := newWorkflowOperationCtx(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{UID: "my-uid", Namespace: "my-ns"}}, controller)
+		node := woc.offloadParameters(context.Background(), nodes)["my-node"]
+		assert.True(t, node.ParametersDatabaseOffload)
+		assert.Empty(t, node.ParametersConfigMap)
+		assert.Nil(t, node.Outputs.Result)
+		assert.Equal(t, map[string]string{"outputs.result": result}, repo.values["my-uid/my-node"])
+		assert.Equal(t, result, *nodes["my-node"].Outputs.Result, "the nodes are not modified")
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		woc := newWorkflowOperationCtx(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{UID: "my-uid", Namespace: "my-ns"}}, controller)
+		assert.Equal(t, nodes, woc.offloadParameters(context.Background(), nodes))
+	})
+}
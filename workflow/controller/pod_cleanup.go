@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"time"
+
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -16,7 +18,7 @@ func (woc *wfOperationCtx) queuePodsForCleanup() {
 	strategy := podGC.GetStrategy()
 	selector, _ := podGC.GetLabelSelector()
 	workflowPhase := woc.wf.Status.Phase
-	objs, _ := woc.controller.podInformer.GetIndexer().ByIndex(indexes.WorkflowIndex, woc.wf.Namespace+"/"+woc.wf.Name)
+	objs, _ := woc.controller.podsByIndex(indexes.WorkflowIndex, woc.wf.Namespace+"/"+woc.wf.Name)
 	for _, obj := range objs {
 		pod := obj.(*apiv1.Pod)
 		if _, ok := pod.Labels[common.LabelKeyComponent]; ok { // for these types we don't want to do PodGC
@@ -28,7 +30,17 @@ func (woc *wfOperationCtx) queuePodsForCleanup() {
 		}
 		switch determinePodCleanupAction(selector, pod.Labels, strategy, workflowPhase, pod.Status.Phase) {
 		case deletePod:
-			woc.controller.queuePodForCleanupAfter(pod.Namespace, pod.Name, deletePod, delay)
+			olderThan, err := podGC.GetOlderThan(pod.Status.Phase, pod.Labels)
+			if err != nil {
+				woc.log.WithError(err).WithField("podName", pod.Name).Warn("Invalid pod GC rule, keeping the pod")
+				woc.controller.queuePodForCleanup(pod.Namespace, pod.Name, labelPodCompleted)
+				continue
+			}
+			if deleteAfter := podFinishedAt(pod).Add(olderThan); time.Until(deleteAfter) > delay {
+				woc.controller.queuePodForDeletionAfter(pod.Namespace, pod.Name, deleteAfter)
+			} else {
+				woc.controller.queuePodForCleanupAfter(pod.Namespace, pod.Name, deletePod, delay)
+			}
 		case labelPodCompleted:
 			woc.controller.queuePodForCleanup(pod.Namespace, pod.Name, labelPodCompleted)
 		}
@@ -62,3 +74,16 @@ func determinePodCleanupAction(
 	}
 	return ""
 }
+
+// podFinishedAt returns when the last container of the pod finished, or when the pod was created if none did
+func podFinishedAt(pod *apiv1.Pod) time.Time {
+	finishedAt := pod.CreationTimestamp.Time
+	for _, statuses := range [][]apiv1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if t := status.State.Terminated; t != nil && t.FinishedAt.After(finishedAt) {
+				finishedAt = t.FinishedAt.Time
+			}
+		}
+	}
+	return finishedAt
+}
@@ -2,7 +2,9 @@ package controller
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Should I use "clean-up" or "cleanup"?
@@ -16,19 +18,38 @@ type (
 
 const (
 	deletePod           podCleanupAction = "deletePod"
+	forceDeletePod      podCleanupAction = "forceDeletePod"
 	labelPodCompleted   podCleanupAction = "labelPodCompleted"
 	terminateContainers podCleanupAction = "terminateContainers"
 	killContainers      podCleanupAction = "killContainers"
+	labelPodDeleteAfter podCleanupAction = "labelPodDeleteAfter"
 )
 
 func newPodCleanupKey(namespace string, podName string, action podCleanupAction) podCleanupKey {
 	return fmt.Sprintf("%s/%s/%v", namespace, podName, action)
 }
 
+// newPodDeleteAfterKey returns the key to label a completed pod with the time after which it is deleted
+func newPodDeleteAfterKey(namespace string, podName string, deleteAfter time.Time) podCleanupKey {
+	return fmt.Sprintf("%s/%s/%v/%d", namespace, podName, labelPodDeleteAfter, deleteAfter.Unix())
+}
+
 func parsePodCleanupKey(k podCleanupKey) (namespace string, podName string, action podCleanupAction) {
 	parts := strings.Split(k, "/")
+	if len(parts) == 4 && parts[2] == labelPodDeleteAfter {
+		return parts[0], parts[1], parts[2]
+	}
 	if len(parts) != 3 {
 		return "", "", ""
 	}
 	return parts[0], parts[1], parts[2]
 }
+
+// parsePodDeleteAfter returns the Unix time after which the pod is deleted, from a key returned by newPodDeleteAfterKey
+func parsePodDeleteAfter(k podCleanupKey) (int64, error) {
+	parts := strings.Split(k, "/")
+	if len(parts) != 4 {
+		return 0, fmt.Errorf("invalid pod delete after key %q", k)
+	}
+	return strconv.ParseInt(parts[3], 10, 64)
+}
@@ -1,13 +1,18 @@
 package controller
 
 import (
+	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 func Test_determinePodCleanupAction(t *testing.T) {
@@ -70,3 +75,46 @@ func Test_determinePodCleanupAction(t *testing.T) {
 		})
 	}
 }
+
+func Test_podDeleteAfterKey(t *testing.T) {
+	deleteAfter := time.Unix(1700000000, 0)
+	key := newPodDeleteAfterKey("my-ns", "my-pod", deleteAfter)
+	namespace, podName, action := parsePodCleanupKey(key)
+	assert.Equal(t, "my-ns", namespace)
+	assert.Equal(t, "my-pod", podName)
+	assert.Equal(t, labelPodDeleteAfter, action)
+	unix, err := parsePodDeleteAfter(key)
+	if assert.NoError(t, err) {
+		assert.Equal(t, deleteAfter.Unix(), unix)
+	}
+}
+
+func Test_podGCSweep(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	for name, deleteAfter := range map[string]time.Time{
+		"old": time.Now().Add(-time.Minute),
+		"new": time.Now().Add(time.Hour),
+	} {
+		_, err := controller.kubeclientset.CoreV1().Pods("default").Create(ctx, &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					common.LabelKeyWorkflow:    "my-wf",
+					common.LabelKeyCompleted:   "true",
+					common.LabelKeyDeleteAfter: strconv.FormatInt(deleteAfter.Unix(), 10),
+				},
+			},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	controller.podGCSweep(ctx)
+
+	// the pod is queued after the rate limiter's delay
+	if assert.Eventually(t, func() bool { return controller.podCleanupQueue.Len() == 1 }, time.Second, 10*time.Millisecond) {
+		key, _ := controller.podCleanupQueue.Get()
+		assert.Equal(t, newPodCleanupKey("default", "old", deletePod), key)
+	}
+}
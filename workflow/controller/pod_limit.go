@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// podLimitExceeded returns whether creating the pod of the node would exceed the workflow's pod limit. If it would, the
+// workflow is stopped or suspended, depending on the limit's action, and the pod must not be created. The pods of exit
+// handlers are not limited, so that they can report the failure.
+func (woc *wfOperationCtx) podLimitExceeded(nodeName string, onExitPod bool) bool {
+	limit := woc.execWf.Spec.PodLimit
+	if limit == nil || onExitPod {
+		return false
+	}
+	// the node of the pod has already been initialized, so is counted
+	var pods int64
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod {
+			pods++
+		}
+	}
+	if pods <= limit.Max {
+		return false
+	}
+	message := fmt.Sprintf("Workflow would create more than its limit of %d pods", limit.Max)
+	if limit.Action == wfv1.PodLimitActionSuspend {
+		woc.markNodePhase(nodeName, wfv1.NodePending, message)
+		if !woc.ShouldSuspend() {
+			woc.log.Info(message + ", suspending")
+			woc.wf.Spec.Suspend = pointer.Bool(true)
+			woc.execWf.Spec.Suspend = pointer.Bool(true)
+			woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowPodLimitExceeded", message)
+		}
+	} else {
+		woc.markNodePhase(nodeName, wfv1.NodeFailed, message)
+		if !woc.GetShutdownStrategy().Enabled() {
+			woc.log.Info(message + ", stopping")
+			woc.wf.Spec.Shutdown = wfv1.ShutdownStrategyStop
+			woc.execWf.Spec.Shutdown = wfv1.ShutdownStrategyStop
+			woc.eventRecorder.Event(woc.wf, apiv1.EventTypeWarning, "WorkflowPodLimitExceeded", message)
+		}
+	}
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypePodLimitExceeded, Status: metav1.ConditionTrue, Message: message})
+	woc.updated = true
+	return true
+}
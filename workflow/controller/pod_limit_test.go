@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const podLimitWorkflow = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  podLimit:
+    max: 2
+    action: %s
+  templates:
+    - name: main
+      steps:
+        - - name: fan-out
+            template: echo
+            withItems: [a, b, c]
+    - name: echo
+      container:
+        image: my-image
+`
+
+func TestPodLimit(t *testing.T) {
+	ctx := context.Background()
+	condition := wfv1.Condition{Type: wfv1.ConditionTypePodLimitExceeded, Status: metav1.ConditionTrue, Message: "Workflow would create more than its limit of 2 pods"}
+	t.Run("Fail", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		woc := newWorkflowOperationCtx(wfv1.MustUnmarshalWorkflow(fmt.Sprintf(podLimitWorkflow, "Fail")), controller)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, 2)
+		assert.Equal(t, wfv1.ShutdownStrategyStop, woc.wf.Spec.Shutdown)
+		assert.Contains(t, woc.wf.Status.Conditions, condition)
+		node := woc.wf.Status.Nodes.FindByDisplayName("fan-out(2:c)")
+		require.NotNil(t, node)
+		assert.Equal(t, wfv1.NodeFailed, node.Phase)
+	})
+	t.Run("Suspend", func(t *testing.T) {
+		cancel, controller := newController()
+		defer cancel()
+		woc := newWorkflowOperationCtx(wfv1.MustUnmarshalWorkflow(fmt.Sprintf(podLimitWorkflow, "Suspend")), controller)
+		woc.operate(ctx)
+		pods, err := listPods(woc)
+		require.NoError(t, err)
+		assert.Len(t, pods.Items, 2)
+		assert.True(t, woc.ShouldSuspend())
+		assert.Contains(t, woc.wf.Status.Conditions, condition)
+		node := woc.wf.Status.Nodes.FindByDisplayName("fan-out(2:c)")
+		require.NotNil(t, node)
+		assert.Equal(t, wfv1.NodePending, node.Phase)
+	})
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const preemptedMessage = "Workflow was preempted by a higher-priority workflow, no new pods are started until it is resumed"
+
+// markPreempted records that the running workflow, which the throttler no longer admits, was preempted. It is paused
+// at the next node boundary, as it does not start new nodes until it is admitted again.
+func (woc *wfOperationCtx) markPreempted() {
+	if woc.preempted() {
+		return
+	}
+	woc.log.Info("Workflow has been preempted by a higher-priority workflow")
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypePreempted, Status: metav1.ConditionTrue, Message: preemptedMessage})
+	woc.updated = true
+	woc.eventRecorder.Event(woc.wf, apiv1.EventTypeNormal, "WorkflowPreempted", preemptedMessage)
+}
+
+// resumePreempted removes the condition of a preempted workflow that the throttler admitted again
+func (woc *wfOperationCtx) resumePreempted() {
+	if !woc.preempted() {
+		return
+	}
+	woc.log.Info("Workflow has been resumed after being preempted")
+	woc.wf.Status.Conditions.RemoveCondition(wfv1.ConditionTypePreempted)
+	woc.updated = true
+	woc.eventRecorder.Event(woc.wf, apiv1.EventTypeNormal, "WorkflowResumed", "Workflow resumed after being preempted")
+}
+
+func (woc *wfOperationCtx) preempted() bool {
+	for _, c := range woc.wf.Status.Conditions {
+		if c.Type == wfv1.ConditionTypePreempted {
+			return true
+		}
+	}
+	return false
+}
+
+// pausedByPreemption returns whether the workflow must not start new nodes because it was preempted. It still runs to
+// completion when it is stopped, terminated or exceeds its deadline.
+func (woc *wfOperationCtx) pausedByPreemption() bool {
+	deadlineExceeded := woc.workflowDeadline != nil && time.Now().UTC().After(*woc.workflowDeadline)
+	return woc.preempted() && !woc.GetShutdownStrategy().Enabled() && !deadlineExceeded
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/sync/mocks"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+func TestPreemptingThrottler(t *testing.T) {
+	cancel, controller := newController(func(x *WorkflowController) {
+		x.Config.NamespaceParallelism = 1
+		x.Config.Preemption = config.PreemptionConfig{Enabled: true, Namespaces: []string{"my-ns"}}
+	})
+	defer cancel()
+	throttler := controller.newThrottler()
+
+	throttler.Add("my-ns/low", 1, time.Now())
+	throttler.Add("my-ns/high", 2, time.Now())
+	assert.True(t, throttler.Admit("my-ns/high"))
+	assert.False(t, throttler.Admit("my-ns/low"))
+
+	throttler.Add("other-ns/low", 1, time.Now())
+	throttler.Add("other-ns/high", 2, time.Now())
+	assert.True(t, throttler.Admit("other-ns/low"), "namespace is not preemptible")
+	assert.False(t, throttler.Admit("other-ns/high"))
+}
+
+func TestMarkPreempted(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+status:
+  phase: Running
+`)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.markPreempted()
+	woc.persistUpdates(ctx)
+	wfs := controller.wfclientset.ArgoprojV1alpha1().Workflows("my-ns")
+	wf, err := wfs.Get(ctx, "my-wf", metav1.GetOptions{})
+	if assert.NoError(t, err) && assert.Len(t, wf.Status.Conditions, 1) {
+		assert.Equal(t, wfv1.Condition{Type: wfv1.ConditionTypePreempted, Status: metav1.ConditionTrue, Message: preemptedMessage}, wf.Status.Conditions[0])
+	}
+	assert.Equal(t, []string{"Normal WorkflowPreempted " + preemptedMessage}, getEvents(controller, 1))
+
+	woc = newWorkflowOperationCtx(wf, controller)
+	woc.markPreempted()
+	assert.False(t, woc.updated, "is already preempted")
+
+	woc.resumePreempted()
+	assert.True(t, woc.updated)
+	assert.Empty(t, woc.wf.Status.Conditions)
+}
+
+// preemptWorkflows makes the throttler of the controller no longer admit any workflow
+func preemptWorkflows(controller *WorkflowController) {
+	throttler := &mocks.Throttler{}
+	throttler.On("Admit", mock.Anything).Return(false)
+	throttler.On("Remove", mock.Anything).Return()
+	controller.throttler = throttler
+}
+
+func expectPreemptedWorkflow(ctx context.Context, controller *WorkflowController, test func(wf *wfv1.Workflow)) {
+	wf, err := controller.wfclientset.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf", metav1.GetOptions{})
+	if err != nil {
+		panic(err)
+	}
+	test(wf)
+}
+
+func TestPreemptedWorkflow(t *testing.T) {
+	t.Run("DoesNotStartNodes", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+status:
+  phase: Running
+`)
+		cancel, controller := newController(wf)
+		defer cancel()
+		preemptWorkflows(controller)
+		ctx := context.Background()
+
+		assert.True(t, controller.processNextItem(ctx))
+		expectPreemptedWorkflow(ctx, controller, func(wf *wfv1.Workflow) {
+			assert.Equal(t, wfv1.WorkflowRunning, wf.Status.Phase)
+			assert.Contains(t, wf.Status.Conditions, wfv1.Condition{Type: wfv1.ConditionTypePreempted, Status: metav1.ConditionTrue, Message: preemptedMessage})
+			assert.Empty(t, wf.Status.Nodes)
+		})
+		pods, err := controller.kubeclientset.CoreV1().Pods("my-ns").List(ctx, metav1.ListOptions{})
+		if assert.NoError(t, err) {
+			assert.Empty(t, pods.Items)
+		}
+	})
+	t.Run("OffloadedNodes", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+status:
+  phase: Running
+  offloadNodeStatusVersion: my-offloaded-version
+`)
+		cancel, controller := newController(wf, func(controller *WorkflowController) {
+			controller.offloadNodeStatusRepo, controller.hydrator = getMockDBCtx(nil, true)
+		})
+		defer cancel()
+		preemptWorkflows(controller)
+		ctx := context.Background()
+
+		assert.True(t, controller.processNextItem(ctx))
+		obj, exists, err := controller.wfInformer.GetStore().GetByKey("my-ns/my-wf")
+		if assert.NoError(t, err) && assert.True(t, exists) {
+			wf, err := util.FromUnstructured(obj.(*unstructured.Unstructured))
+			if assert.NoError(t, err) {
+				assert.Contains(t, wf.Status.Conditions, wfv1.Condition{Type: wfv1.ConditionTypePreempted, Status: metav1.ConditionTrue, Message: preemptedMessage})
+				assert.Contains(t, wf.Status.Nodes, "my-node", "the nodes were not lost")
+			}
+		}
+	})
+	t.Run("Stopped", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  shutdown: Stop
+  templates:
+    - name: main
+      container:
+        image: my-image
+status:
+  phase: Running
+  conditions:
+    - type: Preempted
+      status: "True"
+`)
+		cancel, controller := newController(wf)
+		defer cancel()
+		preemptWorkflows(controller)
+		ctx := context.Background()
+
+		assert.True(t, controller.processNextItem(ctx))
+		expectPreemptedWorkflow(ctx, controller, func(wf *wfv1.Workflow) {
+			assert.True(t, wf.Status.Phase.Completed())
+		})
+	})
+	t.Run("DeadlineExceeded", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  activeDeadlineSeconds: 1
+  templates:
+    - name: main
+      container:
+        image: my-image
+status:
+  phase: Running
+  startedAt: "2021-01-01T00:00:00Z"
+  nodes:
+    my-wf:
+      id: my-wf
+      name: my-wf
+      type: Pod
+      templateName: main
+      phase: Pending
+  conditions:
+    - type: Preempted
+      status: "True"
+`)
+		cancel, controller := newController(wf)
+		defer cancel()
+		preemptWorkflows(controller)
+		ctx := context.Background()
+
+		assert.True(t, controller.processNextItem(ctx))
+		expectPreemptedWorkflow(ctx, controller, func(wf *wfv1.Workflow) {
+			assert.Equal(t, wfv1.WorkflowFailed, wf.Status.Phase)
+		})
+	})
+}
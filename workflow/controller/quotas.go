@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+)
+
+// quotaRequeueDelay is how long a workflow held in Pending by a quota waits before it is processed again
+const quotaRequeueDelay = 10 * time.Second
+
+// podQuotaReachedError is returned when creating a pod would exceed a pod quota of its workflow
+type podQuotaReachedError struct {
+	quota   string
+	maxPods int
+}
+
+func (e podQuotaReachedError) Error() string {
+	return fmt.Sprintf("pod quota %q reached, the pod is created once fewer than %d pods of its workflows are pending or running", e.quota, e.maxPods)
+}
+
+func isPodQuotaReached(err error) bool {
+	_, ok := err.(podQuotaReachedError)
+	return ok
+}
+
+// quotas returns the quotas that select the workflow in the namespace with the labels
+func (wfc *WorkflowController) quotas(namespace string, workflowLabels map[string]string) []config.QuotaConfig {
+	var quotas []config.QuotaConfig
+	for _, quota := range wfc.Config.Quotas {
+		selects, err := quota.Selects(namespace, workflowLabels)
+		if err != nil {
+			log.WithError(err).Warn("Ignoring quota")
+			continue
+		}
+		if selects {
+			quotas = append(quotas, quota)
+		}
+	}
+	return quotas
+}
+
+// workflowQuotaExceeded returns why a quota does not allow the workflow to start, or an empty string if all do
+func (wfc *WorkflowController) workflowQuotaExceeded(wf *wfv1.Workflow) string {
+	for _, quota := range wfc.quotas(wf.Namespace, wf.Labels) {
+		if quota.MaxWorkflows <= 0 {
+			continue
+		}
+		objs, err := wfc.wfInformer.GetIndexer().ByIndex(indexes.WorkflowPhaseIndex, string(wfv1.WorkflowRunning))
+		if err != nil {
+			log.WithError(err).Error("failed to list running workflows")
+			continue
+		}
+		running := 0
+		for _, obj := range objs {
+			un, ok := obj.(*unstructured.Unstructured)
+			if !ok || (un.GetNamespace() == wf.Namespace && un.GetName() == wf.Name) {
+				continue
+			}
+			if selects, _ := quota.Selects(un.GetNamespace(), un.GetLabels()); selects {
+				running++
+			}
+		}
+		if running >= quota.MaxWorkflows {
+			return fmt.Sprintf("Workflow is held in Pending because quota %q allows %d running workflows", quota.GetName(), quota.MaxWorkflows)
+		}
+	}
+	return ""
+}
+
+// activeQuotaPods returns the number of pending or running pods of the workflows the quota selects
+func (wfc *WorkflowController) activeQuotaPods(quota config.QuotaConfig) int {
+	active := 0
+	for _, phase := range []apiv1.PodPhase{apiv1.PodPending, apiv1.PodRunning} {
+		objs, err := wfc.podsByIndex(indexes.PodPhaseIndex, string(phase))
+		if err != nil {
+			log.WithError(err).Error("failed to list active pods")
+			continue
+		}
+		for _, obj := range objs {
+			pod, ok := obj.(*apiv1.Pod)
+			if !ok {
+				continue
+			}
+			workflowName, ok := pod.Labels[common.LabelKeyWorkflow]
+			if !ok {
+				continue
+			}
+			wf, exists, err := wfc.wfInformer.GetIndexer().GetByKey(pod.Namespace + "/" + workflowName)
+			if err != nil || !exists {
+				continue
+			}
+			un, ok := wf.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			if selects, _ := quota.Selects(un.GetNamespace(), un.GetLabels()); selects {
+				active++
+			}
+		}
+	}
+	return active
+}
+
+// checkPodQuotas returns a podQuotaReachedError if creating another pod would exceed a pod quota of the workflow.
+// The active pods of each quota are counted once per operation, as the informer does not yet have the pods it creates.
+func (woc *wfOperationCtx) checkPodQuotas() error {
+	for _, quota := range woc.controller.quotas(woc.wf.Namespace, woc.wf.Labels) {
+		if quota.MaxPods <= 0 {
+			continue
+		}
+		name := quota.GetName()
+		if _, ok := woc.quotaPods[name]; !ok {
+			woc.quotaPods[name] = woc.controller.activeQuotaPods(quota)
+		}
+		if woc.quotaPods[name] >= quota.MaxPods {
+			woc.log.WithField("quota", name).Infof("Pod quota reached %d/%d", woc.quotaPods[name], quota.MaxPods)
+			return podQuotaReachedError{quota: name, maxPods: quota.MaxPods}
+		}
+	}
+	return nil
+}
+
+// podCreated counts a created pod against the pod quotas of the workflow
+func (woc *wfOperationCtx) podCreated() {
+	for name := range woc.quotaPods {
+		woc.quotaPods[name]++
+	}
+}
+
+// holdForQuota keeps the workflow, which a quota does not allow to start, in Pending
+func (woc *wfOperationCtx) holdForQuota(ctx context.Context, message string) {
+	woc.log.Info(message)
+	woc.markWorkflowPhase(ctx, wfv1.WorkflowPending, message)
+	condition := wfv1.Condition{Type: wfv1.ConditionTypeQuotaExceeded, Status: metav1.ConditionTrue, Message: message}
+	if !woc.hasCondition(condition) {
+		woc.wf.Status.Conditions.UpsertCondition(condition)
+		woc.updated = true
+	}
+	woc.persistUpdates(ctx)
+}
+
+// releaseQuota removes the condition of a workflow that was held in Pending by a quota
+func (woc *wfOperationCtx) releaseQuota() {
+	for _, c := range woc.wf.Status.Conditions {
+		if c.Type == wfv1.ConditionTypeQuotaExceeded {
+			woc.wf.Status.Conditions.RemoveCondition(wfv1.ConditionTypeQuotaExceeded)
+			woc.updated = true
+			return
+		}
+	}
+}
+
+func (woc *wfOperationCtx) hasCondition(condition wfv1.Condition) bool {
+	for _, c := range woc.wf.Status.Conditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var mlQuota = config.QuotaConfig{Name: "ml", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml"}}}
+
+const runningMLWorkflow = `
+metadata:
+  name: running
+  namespace: my-ns
+  labels:
+    team: ml
+    workflows.argoproj.io/phase: Running
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+status:
+  phase: Running
+`
+
+func TestWorkflowQuota(t *testing.T) {
+	quota := mlQuota
+	quota.MaxWorkflows = 1
+	cancel, controller := newController(wfv1.MustUnmarshalWorkflow(runningMLWorkflow), func(x *WorkflowController) {
+		x.Config.Quotas = []config.QuotaConfig{quota}
+	})
+	defer cancel()
+	ctx := context.Background()
+
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+  labels:
+    team: ml
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      container:
+        image: my-image
+`)
+	message := controller.workflowQuotaExceeded(wf)
+	assert.Equal(t, `Workflow is held in Pending because quota "ml" allows 1 running workflows`, message)
+	assert.Empty(t, controller.workflowQuotaExceeded(wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+  labels:
+    team: web
+`)), "is not selected by the quota")
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.holdForQuota(ctx, message)
+	assert.Equal(t, wfv1.WorkflowPending, woc.wf.Status.Phase)
+	assert.Equal(t, message, woc.wf.Status.Message)
+	assert.Equal(t, wfv1.Conditions{{Type: wfv1.ConditionTypeQuotaExceeded, Status: metav1.ConditionTrue, Message: message}}, woc.wf.Status.Conditions)
+
+	woc.releaseQuota()
+	assert.True(t, woc.updated)
+	assert.Empty(t, woc.wf.Status.Conditions)
+}
+
+func TestPodQuota(t *testing.T) {
+	quota := mlQuota
+	quota.MaxPods = 2
+	pod := &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running-pod", Namespace: "my-ns", Labels: map[string]string{"workflows.argoproj.io/workflow": "running"}},
+		Status:     apiv1.PodStatus{Phase: apiv1.PodRunning},
+	}
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: my-wf
+  namespace: my-ns
+  labels:
+    team: ml
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: pod
+          - name: b
+            template: pod
+    - name: pod
+      container:
+        image: my-image
+`)
+	cancel, controller := newController(wfv1.MustUnmarshalWorkflow(runningMLWorkflow), wf, func(x *WorkflowController) {
+		x.Config.Quotas = []config.QuotaConfig{quota}
+	})
+	defer cancel()
+	ctx := context.Background()
+	assert.NoError(t, controller.podInformer.GetIndexer().Add(pod))
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) {
+		assert.Len(t, pods.Items, 1, "only one pod of the workflow")
+	}
+	assert.Equal(t, wfv1.WorkflowRunning, woc.wf.Status.Phase)
+	pending := 0
+	for _, node := range woc.wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod && node.Phase == wfv1.NodePending && node.Message != "" {
+			pending++
+			assert.Equal(t, `pod quota "ml" reached, the pod is created once fewer than 2 pods of its workflows are pending or running`, node.Message)
+		}
+	}
+	assert.Equal(t, 1, pending)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func (woc *wfOperationCtx) executeSQLQueryTemplate(nodeName string, templateScope string, tmpl *wfv1.Template, orgTmpl wfv1.TemplateReferenceHolder, opts *executeTemplateOpts) (*wfv1.NodeStatus, error) {
+	node := woc.wf.GetNodeByName(nodeName)
+	if node == nil {
+		node = woc.initializeExecutableNode(nodeName, wfv1.NodeTypeSQLQuery, templateScope, tmpl, orgTmpl, opts.boundaryID, wfv1.NodePending)
+	}
+	if !node.Fulfilled() {
+		if tmpl.SQLQuery.OutputArtifact != "" {
+			if err := woc.addAgentArchiveLocation(tmpl, node.ID); err != nil {
+				return node, err
+			}
+		}
+		woc.taskSet[node.ID] = *tmpl
+	}
+	return node, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/expr/env"
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	controllercache "github.com/argoproj/argo-workflows/v3/workflow/controller/cache"
@@ -314,7 +315,11 @@ func shouldExecute(when string) (bool, error) {
 	if when == "" {
 		return true, nil
 	}
-	expression, err := govaluate.NewEvaluableExpression(when)
+	functions := map[string]govaluate.ExpressionFunction{}
+	for name, f := range env.GetFunctions() {
+		functions[name] = f
+	}
+	expression, err := govaluate.NewEvaluableExpressionWithFunctions(when, functions)
 	if err != nil {
 		if strings.Contains(err.Error(), "Invalid token") {
 			return false, errors.Errorf(errors.CodeBadRequest, "Invalid 'when' expression '%s': %v (hint: try wrapping the affected expression in quotes (\"))", when, err)
@@ -510,6 +515,7 @@ func (woc *wfOperationCtx) prepareDefaultMetricScope() (map[string]string, map[s
 	localScope[common.LocalVarStatus] = string(wfv1.NodePending)
 	localScope[durationCPU] = "0"
 	localScope[durationMem] = "0"
+	localScope[common.LocalVarCost] = "0"
 
 	var realTimeScope = map[string]func() float64{
 		common.GlobalVarWorkflowDuration: func() float64 {
@@ -575,5 +581,9 @@ func (woc *wfOperationCtx) prepareMetricScope(node *wfv1.NodeStatus) (map[string
 		}
 	}
 
+	if node.Cost != nil {
+		localScope[common.LocalVarCost] = string(node.Cost.Value)
+	}
+
 	return localScope, realTimeScope
 }
@@ -0,0 +1,18 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Synchronization writes the state of the semaphores and mutexes, i.e. their holders and the queue of those waiting for
+// them, for debugging.
+func (wfc *WorkflowController) Synchronization(w http.ResponseWriter, r *http.Request) {
+	if wfc.syncManager == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("synchronization manager is not running, this controller may not be the leader"))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"locks": wfc.syncManager.GetLockStates()})
+}
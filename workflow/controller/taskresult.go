@@ -5,37 +5,34 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	wfextvv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/client/informers/externalversions/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
 )
 
-func (wfc *WorkflowController) newWorkflowTaskResultInformer() cache.SharedIndexInformer {
+func (wfc *WorkflowController) newWorkflowTaskResultInformer(wfclientset wfclientset.Interface) cache.SharedIndexInformer {
 	labelSelector := labels.NewSelector().
 		Add(*workflowReq).
-		Add(wfc.instanceIDReq()).
-		String()
-	log.WithField("labelSelector", labelSelector).
+		Add(wfc.instanceIDReq())
+	log.WithField("labelSelector", labelSelector.String()).
 		Info("Watching task results")
 	return wfextvv1alpha1.NewFilteredWorkflowTaskResultInformer(
-		wfc.wfclientset,
+		wfclientset,
 		wfc.GetManagedNamespace(),
-		20*time.Minute,
+		wfc.Config.Informers.TaskResults.GetResyncPeriod(20*time.Minute),
 		cache.Indexers{
 			indexes.WorkflowIndex: indexes.MetaWorkflowIndexFunc,
 		},
-		func(options *metav1.ListOptions) {
-			options.LabelSelector = labelSelector
-		},
+		informerListOptions(wfc.Config.Informers.TaskResults, labelSelector),
 	)
 }
 
 func (woc *wfOperationCtx) taskResultReconciliation() {
-	objs, _ := woc.controller.taskResultInformer.GetIndexer().ByIndex(indexes.WorkflowIndex, woc.wf.Namespace+"/"+woc.wf.Name)
+	objs, _ := woc.controller.taskResultsByIndex(indexes.WorkflowIndex, woc.wf.Namespace+"/"+woc.wf.Name)
 	woc.log.WithField("numObjs", len(objs)).Info("Task-result reconciliation")
 	for _, obj := range objs {
 		result := obj.(*wfv1.WorkflowTaskResult)
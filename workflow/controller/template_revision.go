@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/templaterevision"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// addWorkflowTemplateInformerHandlers saves a revision of WorkflowTemplates when they are created or their spec changes.
+// Only the generations the informer observes are saved: updates made while the controller is down, or coalesced by
+// the informer, leave gaps in the revisions.
+func (wfc *WorkflowController) addWorkflowTemplateInformerHandlers(ctx context.Context) {
+	wfc.wftmplInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			wfc.saveWorkflowTemplateRevision(ctx, obj)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if old.(*unstructured.Unstructured).GetGeneration() != new.(*unstructured.Unstructured).GetGeneration() {
+				wfc.saveWorkflowTemplateRevision(ctx, new)
+			}
+		},
+	})
+}
+
+func (wfc *WorkflowController) saveWorkflowTemplateRevision(ctx context.Context, obj interface{}) {
+	if !wfc.Config.TemplateRevisions.IsEnabled() {
+		return
+	}
+	wftmpl := &wfv1.WorkflowTemplate{}
+	if err := util.FromUnstructuredObj(obj.(*unstructured.Unstructured), wftmpl); err != nil {
+		log.WithError(err).Warn("Failed to convert workflow template")
+		return
+	}
+	if err := templaterevision.SaveWorkflowTemplate(ctx, wfc.kubeclientset, wftmpl, wfc.Config.TemplateRevisions.GetHistoryLimit()); err != nil {
+		log.WithError(err).WithFields(log.Fields{"namespace": wftmpl.Namespace, "name": wftmpl.Name}).Error("Failed to save workflow template revision")
+	}
+}
+
+// addClusterWorkflowTemplateInformerHandlers saves a revision of ClusterWorkflowTemplates, in the controller's
+// namespace, when they are created or their spec changes
+func (wfc *WorkflowController) addClusterWorkflowTemplateInformerHandlers(ctx context.Context) {
+	wfc.cwftmplInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			wfc.saveClusterWorkflowTemplateRevision(ctx, obj)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			if old.(*unstructured.Unstructured).GetGeneration() != new.(*unstructured.Unstructured).GetGeneration() {
+				wfc.saveClusterWorkflowTemplateRevision(ctx, new)
+			}
+		},
+	})
+}
+
+func (wfc *WorkflowController) saveClusterWorkflowTemplateRevision(ctx context.Context, obj interface{}) {
+	if !wfc.Config.TemplateRevisions.IsEnabled() {
+		return
+	}
+	cwftmpl := &wfv1.ClusterWorkflowTemplate{}
+	if err := util.FromUnstructuredObj(obj.(*unstructured.Unstructured), cwftmpl); err != nil {
+		log.WithError(err).Warn("Failed to convert cluster workflow template")
+		return
+	}
+	if err := templaterevision.SaveClusterWorkflowTemplate(ctx, wfc.kubeclientset, wfc.namespace, cwftmpl, wfc.Config.TemplateRevisions.GetHistoryLimit()); err != nil {
+		log.WithError(err).WithField("name", cwftmpl.Name).Error("Failed to save cluster workflow template revision")
+	}
+}
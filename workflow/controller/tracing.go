@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// tracer returns the tracer of the controller's spans, or nil if spans are not exported
+func (wfc *WorkflowController) tracer() trace.Tracer {
+	if wfc.tracerProvider == nil {
+		return nil
+	}
+	return wfc.tracerProvider.Tracer(tracing.InstrumentationName)
+}
+
+// workflowSpanContext returns the span context of the workflow's span, and of its parent. The parent is the span in
+// the workflow's traceparent annotation, if any. Otherwise, the workflow's span is the root of a trace identified by
+// the workflow's UID.
+func (woc *wfOperationCtx) workflowSpanContext() (parent trace.SpanContext, span trace.SpanContext) {
+	parent = tracing.ParseTraceParent(woc.wf.GetAnnotations()[common.AnnotationKeyTraceParent])
+	if !parent.IsValid() {
+		return parent, tracing.RootSpanContext(string(woc.wf.UID), "workflow")
+	}
+	return parent, tracing.ChildSpanContext(parent, "workflow/"+string(woc.wf.UID))
+}
+
+// nodeSpanContext returns the span context of the node's span, which is the parent of the spans of the node's pod
+func nodeSpanContext(workflowSpan trace.SpanContext, nodeID string) trace.SpanContext {
+	return tracing.ChildSpanContext(workflowSpan, "node/"+nodeID)
+}
+
+// recordSpans records the spans of the workflow and of the nodes that completed during this execution of the
+// operator loop. The spans of nodes are children of the span of their boundary node, or of the workflow.
+func (woc *wfOperationCtx) recordSpans(ctx context.Context, old, new *wfv1.WorkflowStatus) {
+	tracer := woc.controller.tracer()
+	if tracer == nil {
+		return
+	}
+	parent, workflowSpan := woc.workflowSpanContext()
+	for nodeID, node := range new.Nodes {
+		if !node.Completed() || old.Nodes[nodeID].Completed() || node.StartedAt.IsZero() || node.FinishedAt.IsZero() {
+			continue
+		}
+		nodeParent := workflowSpan
+		if node.BoundaryID != "" {
+			nodeParent = nodeSpanContext(workflowSpan, node.BoundaryID)
+		}
+		span := tracing.StartWithSpanContext(trace.ContextWithRemoteSpanContext(ctx, nodeParent), tracer, nodeSpanContext(workflowSpan, nodeID), string(node.Type),
+			trace.WithTimestamp(node.StartedAt.Time),
+			trace.WithAttributes(
+				attribute.String("argo.node.name", node.Name),
+				attribute.String("argo.node.id", nodeID),
+				attribute.String("argo.node.template", node.TemplateName),
+				attribute.String("argo.node.phase", string(node.Phase)),
+			),
+		)
+		if node.Phase.FailedOrError() {
+			span.SetStatus(codes.Error, errorMessage(node.Message, string(node.Phase)))
+		}
+		span.End(trace.WithTimestamp(node.FinishedAt.Time))
+	}
+	if new.Phase.Completed() && !old.Phase.Completed() {
+		span := tracing.StartWithSpanContext(trace.ContextWithRemoteSpanContext(ctx, parent), tracer, workflowSpan, "workflow",
+			trace.WithTimestamp(new.StartedAt.Time),
+			trace.WithAttributes(
+				attribute.String("argo.workflow.name", woc.wf.Name),
+				attribute.String("argo.workflow.namespace", woc.wf.Namespace),
+				attribute.String("argo.workflow.phase", string(new.Phase)),
+			),
+		)
+		if new.Phase != wfv1.WorkflowSucceeded {
+			span.SetStatus(codes.Error, errorMessage(new.Message, string(new.Phase)))
+		}
+		span.End(trace.WithTimestamp(new.FinishedAt.Time))
+	}
+}
+
+func errorMessage(message, phase string) string {
+	if message == "" {
+		return phase
+	}
+	return message
+}
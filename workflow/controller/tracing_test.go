@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestRecordSpans(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`
+metadata:
+  name: tracing
+  uid: 0af76519-16cd-43dd-8448-eb211c80319c
+  annotations:
+    workflows.argoproj.io/traceparent: 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01
+spec:
+  entrypoint: main
+  templates:
+    - name: main
+      dag:
+        tasks:
+          - name: a
+            template: whalesay
+    - name: whalesay
+      container:
+        image: docker/whalesay:latest
+`)
+	ctx := context.Background()
+	cancel, controller := newController(wf)
+	defer cancel()
+	recorder := tracetest.NewSpanRecorder()
+	controller.tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder), sdktrace.WithIDGenerator(tracing.IDGenerator()))
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	parent, workflowSpan := woc.workflowSpanContext()
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", tracing.TraceParent(parent))
+	node := woc.wf.Status.Nodes.FindByDisplayName("a")
+	require.NotNil(t, node)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	var traceParents []string
+	for _, c := range pods.Items[0].Spec.Containers {
+		for _, e := range c.Env {
+			if e.Name == common.EnvVarTraceParent {
+				traceParents = append(traceParents, e.Value)
+			}
+		}
+	}
+	assert.Contains(t, traceParents, tracing.TraceParent(nodeSpanContext(workflowSpan, node.ID)))
+
+	makePodsPhase(ctx, woc, apiv1.PodSucceeded, func(pod *apiv1.Pod) {
+		pod.Status.ContainerStatuses = []apiv1.ContainerStatus{{
+			Name:  common.MainContainerName,
+			State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{FinishedAt: metav1.Now()}},
+		}}
+	})
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	var names []string
+	parents := map[trace.SpanID]trace.SpanID{}
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+		parents[span.SpanContext().SpanID()] = span.Parent().SpanID()
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"DAG", "Pod", "reconcile", "reconcile", "workflow"}, names)
+	dagSpan := nodeSpanContext(workflowSpan, woc.wf.NodeID("tracing"))
+	assert.Equal(t, parent.SpanID(), parents[workflowSpan.SpanID()])
+	assert.Equal(t, dagSpan.SpanID(), parents[nodeSpanContext(workflowSpan, node.ID).SpanID()])
+	assert.Equal(t, workflowSpan.SpanID(), parents[dagSpan.SpanID()])
+}
+
+func TestWorkflowSpanContext(t *testing.T) {
+	woc := &wfOperationCtx{wf: &wfv1.Workflow{}}
+	woc.wf.UID = "0af76519-16cd-43dd-8448-eb211c80319c"
+	parent, span := woc.workflowSpanContext()
+	assert.False(t, parent.IsValid())
+	assert.True(t, span.IsValid())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.TraceID().String())
+	_, again := woc.workflowSpanContext()
+	assert.Equal(t, span, again)
+}
@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/slice"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// retainPVCs records which of the workflow's PVCs are retained, rather than deleted, now it has completed
+func (woc *wfOperationCtx) retainPVCs(suffix string) {
+	if len(woc.wf.Status.PersistentVolumeClaims) == 0 {
+		return
+	}
+	var claimNames []string
+	for _, pvc := range woc.wf.Status.PersistentVolumeClaims {
+		claimNames = append(claimNames, pvc.PersistentVolumeClaim.ClaimName)
+	}
+	message := fmt.Sprintf("Retained persistent volume claims %s%s", strings.Join(claimNames, ", "), suffix)
+	woc.log.Info(message)
+	woc.wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeVolumeClaimsRetained, Status: metav1.ConditionTrue, Message: message})
+	woc.updated = true
+}
+
+// deletePVCsAfter labels the workflow's PVCs with the time after which they are deleted by the PVC GC sweep
+func (woc *wfOperationCtx) deletePVCsAfter(ctx context.Context, deleteAfter time.Time) error {
+	pvcLabels := map[string]string{common.LabelKeyDeleteAfter: strconv.FormatInt(deleteAfter.Unix(), 10)}
+	if woc.controller.Config.InstanceID != "" {
+		pvcLabels[common.LabelKeyControllerInstanceID] = woc.controller.Config.InstanceID
+	}
+	data, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"labels": pvcLabels}})
+	if err != nil {
+		return err
+	}
+	pvcClient := woc.controller.kubeclientset.CoreV1().PersistentVolumeClaims(woc.wf.ObjectMeta.Namespace)
+	for _, pvc := range woc.wf.Status.PersistentVolumeClaims {
+		_, err := pvcClient.Patch(ctx, pvc.PersistentVolumeClaim.ClaimName, types.MergePatchType, data, metav1.PatchOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	woc.retainPVCs(fmt.Sprintf(" until %s", deleteAfter.UTC().Format(time.RFC3339)))
+	return nil
+}
+
+// keepPVC stops the reused PVC from being deleted by the PVC GC sweep, as the workflow was retried
+func (woc *wfOperationCtx) keepPVC(ctx context.Context, pvc *apiv1.PersistentVolumeClaim) (*apiv1.PersistentVolumeClaim, error) {
+	if _, ok := pvc.Labels[common.LabelKeyDeleteAfter]; !ok {
+		return pvc, nil
+	}
+	pvc = pvc.DeepCopy()
+	delete(pvc.Labels, common.LabelKeyDeleteAfter)
+	return woc.controller.kubeclientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metav1.UpdateOptions{})
+}
+
+// resizePVC expands the reused PVC to the storage requested by its volume claim template, if it is larger
+func (woc *wfOperationCtx) resizePVC(ctx context.Context, pvc *apiv1.PersistentVolumeClaim, pvcTmpl apiv1.PersistentVolumeClaim) (*apiv1.PersistentVolumeClaim, error) {
+	requested, ok := pvcTmpl.Spec.Resources.Requests[apiv1.ResourceStorage]
+	if !ok {
+		return pvc, nil
+	}
+	current := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]
+	if requested.Cmp(current) <= 0 {
+		return pvc, nil
+	}
+	woc.log.WithFields(log.Fields{"pvc": pvc.Name, "from": current.String(), "to": requested.String()}).Info("Resizing pvc")
+	pvc = pvc.DeepCopy()
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = apiv1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[apiv1.ResourceStorage] = requested
+	return woc.controller.kubeclientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metav1.UpdateOptions{})
+}
+
+// pvcGCSweep deletes the PVCs of completed workflows kept by volume claim GC, once they are old enough
+func (wfc *WorkflowController) pvcGCSweep(ctx context.Context) {
+	labelSelector := labels.NewSelector().
+		Add(*workflowReq).
+		Add(*deleteAfterReq).
+		Add(wfc.instanceIDReq())
+	list, err := wfc.kubeclientset.CoreV1().PersistentVolumeClaims(wfc.GetManagedNamespace()).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		log.WithError(err).Error("Failed to list the PVCs kept by volume claim GC")
+		return
+	}
+	now := time.Now().Unix()
+	for i := range list.Items {
+		pvc := &list.Items[i]
+		logCtx := log.WithFields(log.Fields{"namespace": pvc.Namespace, "claimName": pvc.Name})
+		deleteAfter, err := strconv.ParseInt(pvc.Labels[common.LabelKeyDeleteAfter], 10, 64)
+		if err != nil {
+			logCtx.WithError(err).Warn("Invalid PVC delete after label")
+			continue
+		}
+		if deleteAfter > now {
+			continue
+		}
+		logCtx.Info("Deleting PVC")
+		pvcClient := wfc.kubeclientset.CoreV1().PersistentVolumeClaims(pvc.Namespace)
+		err = pvcClient.Delete(ctx, pvc.Name, metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			logCtx.WithError(err).Warn("Failed to delete PVC")
+			continue
+		}
+		if os.Getenv("ARGO_REMOVE_PVC_PROTECTION_FINALIZER") != "false" && slice.ContainsString(pvc.Finalizers, "kubernetes.io/pvc-protection") {
+			pvc.Finalizers = slice.RemoveString(pvc.Finalizers, "kubernetes.io/pvc-protection")
+			_, err = pvcClient.Update(ctx, pvc, metav1.UpdateOptions{})
+			if err != nil && !apierr.IsNotFound(err) {
+				logCtx.WithError(err).Warn("Failed to remove PVC \"kubernetes.io/pvc-protection\" finalizer")
+			}
+		}
+	}
+}
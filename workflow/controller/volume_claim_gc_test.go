@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newPVC(name string, storage string, labels map[string]string) *apiv1.PersistentVolumeClaim {
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{{Name: "wf-with-pvc"}},
+		},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			Resources: apiv1.ResourceRequirements{Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse(storage)}},
+		},
+	}
+}
+
+func TestVolumeClaimGC(t *testing.T) {
+	ctx := context.Background()
+	t.Run("RetainedOnFailure", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(workflowWithPVCAndFailingStep)
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Len(t, woc.wf.Status.PersistentVolumeClaims, 1)
+		assert.Contains(t, woc.wf.Status.Conditions, wfv1.Condition{
+			Type:    wfv1.ConditionTypeVolumeClaimsRetained,
+			Status:  metav1.ConditionTrue,
+			Message: "Retained persistent volume claims wf-with-pvc-data of the unsuccessful workflow",
+		})
+	})
+	t.Run("OnWorkflowDeletion", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(workflowWithPVCAndFailingStep)
+		wf.Spec.VolumeClaimGC = &wfv1.VolumeClaimGC{Strategy: wfv1.VolumeClaimGCOnDeletion}
+		cancel, controller := newController(wf)
+		defer cancel()
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		assert.Len(t, woc.wf.Status.PersistentVolumeClaims, 1)
+		assert.Equal(t, "Retained persistent volume claims wf-with-pvc-data until the workflow is deleted", woc.wf.Status.Conditions[len(woc.wf.Status.Conditions)-1].Message)
+	})
+	t.Run("OlderThan", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(workflowWithPVCAndFailingStep)
+		wf.Spec.VolumeClaimGC = &wfv1.VolumeClaimGC{Strategy: wfv1.VolumeClaimGCOnCompletion, OlderThan: &metav1.Duration{Duration: time.Hour}}
+		cancel, controller := newController(wf)
+		defer cancel()
+		pvcClient := controller.kubeclientset.CoreV1().PersistentVolumeClaims(wf.Namespace)
+		_, err := pvcClient.Create(ctx, newPVC("wf-with-pvc-data", "1Gi", map[string]string{common.LabelKeyWorkflow: "wf-with-pvc"}), metav1.CreateOptions{})
+		require.NoError(t, err)
+		woc := newWorkflowOperationCtx(wf, controller)
+		woc.operate(ctx)
+		pvc, err := pvcClient.Get(ctx, "wf-with-pvc-data", metav1.GetOptions{})
+		require.NoError(t, err)
+		deleteAfter, err := strconv.ParseInt(pvc.Labels[common.LabelKeyDeleteAfter], 10, 64)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Now().Add(time.Hour).Unix(), deleteAfter, 60)
+		assert.Len(t, woc.wf.Status.PersistentVolumeClaims, 1)
+		assert.Equal(t, wfv1.ConditionTypeVolumeClaimsRetained, woc.wf.Status.Conditions[len(woc.wf.Status.Conditions)-1].Type)
+	})
+	t.Run("ResizeOnRetry", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(workflowWithPVCAndFailingStep)
+		wf.Status = wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning}
+		wf.Spec.VolumeClaimTemplates[0].Spec.Resources.Requests[apiv1.ResourceStorage] = resource.MustParse("2Gi")
+		wf.Spec.VolumeClaimGC = &wfv1.VolumeClaimGC{ResizeOnRetry: true}
+		cancel, controller := newController(wf)
+		defer cancel()
+		pvcClient := controller.kubeclientset.CoreV1().PersistentVolumeClaims(wf.Namespace)
+		_, err := pvcClient.Create(ctx, newPVC("wf-with-pvc-data", "1Gi", map[string]string{common.LabelKeyWorkflow: "wf-with-pvc", common.LabelKeyDeleteAfter: "1"}), metav1.CreateOptions{})
+		require.NoError(t, err)
+		woc := newWorkflowOperationCtx(wf, controller)
+		require.NoError(t, woc.createPVCs(ctx))
+		pvc, err := pvcClient.Get(ctx, "wf-with-pvc-data", metav1.GetOptions{})
+		require.NoError(t, err)
+		storage := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]
+		assert.Equal(t, "2Gi", storage.String())
+		assert.NotContains(t, pvc.Labels, common.LabelKeyDeleteAfter)
+		assert.Len(t, woc.wf.Status.PersistentVolumeClaims, 1)
+	})
+}
+
+func Test_pvcGCSweep(t *testing.T) {
+	cancel, controller := newController()
+	defer cancel()
+	ctx := context.Background()
+	pvcClient := controller.kubeclientset.CoreV1().PersistentVolumeClaims("default")
+	for name, deleteAfter := range map[string]time.Time{
+		"old": time.Now().Add(-time.Minute),
+		"new": time.Now().Add(time.Hour),
+	} {
+		_, err := pvcClient.Create(ctx, newPVC(name, "1Gi", map[string]string{
+			common.LabelKeyWorkflow:    "my-wf",
+			common.LabelKeyDeleteAfter: strconv.FormatInt(deleteAfter.Unix(), 10),
+		}), metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	controller.pvcGCSweep(ctx)
+
+	_, err := pvcClient.Get(ctx, "old", metav1.GetOptions{})
+	assert.True(t, apierr.IsNotFound(err))
+	_, err = pvcClient.Get(ctx, "new", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
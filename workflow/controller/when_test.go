@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
 )
 
 func TestShouldExecute(t *testing.T) {
@@ -51,3 +53,16 @@ func TestShouldExecute(t *testing.T) {
 		assert.False(t, res)
 	}
 }
+
+func TestShouldExecuteWithFunctions(t *testing.T) {
+	assert.NoError(t, exprenv.SetFunctions(map[string]exprenv.Function{
+		"semverGte": {Params: []string{"a", "b"}, Expression: "sprig.semverCompare('>=' + b, a)"},
+	}))
+	defer func() { _ = exprenv.SetFunctions(nil) }()
+	res, err := shouldExecute("semverGte('1.10.0', '1.2.0')")
+	assert.NoError(t, err)
+	assert.True(t, res)
+	res, err = shouldExecute("semverGte('1.1.0', '1.2.0') || foo == bar")
+	assert.NoError(t, err)
+	assert.False(t, res)
+}
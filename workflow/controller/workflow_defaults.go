@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// getWorkflowDefaults returns the defaults of workflows in the namespace, i.e. the workflowDefaults of the controller
+// config, overridden by those of the namespace's workflow defaults config maps. If there are several config maps, they
+// are applied in name order, so the last one takes precedence.
+func (wfc *WorkflowController) getWorkflowDefaults(namespace string) (*wfv1.Workflow, error) {
+	defaults := wfc.Config.WorkflowDefaults
+	if wfc.configMapInformer == nil {
+		return defaults, nil
+	}
+	objs, err := wfc.configMapInformer.GetIndexer().ByIndex(indexes.ConfigMapLabelsIndex, common.LabelValueTypeConfigMapWorkflowDefaults)
+	if err != nil {
+		return nil, err
+	}
+	var cms []*apiv1.ConfigMap
+	for _, obj := range objs {
+		cm, ok := obj.(*apiv1.ConfigMap)
+		if ok && cm.Namespace == namespace {
+			cms = append(cms, cm)
+		}
+	}
+	sort.Slice(cms, func(i, j int) bool {
+		return cms[i].Name < cms[j].Name
+	})
+	for _, cm := range cms {
+		namespaceDefaults := &wfv1.Workflow{}
+		if err := yaml.Unmarshal([]byte(cm.Data[common.ConfigMapKeyWorkflowDefaults]), namespaceDefaults); err != nil {
+			return nil, fmt.Errorf("malformed workflow defaults config map %s/%s: %w", cm.Namespace, cm.Name, err)
+		}
+		if err := util.MergeTo(defaults, namespaceDefaults); err != nil {
+			return nil, err
+		}
+		defaults = namespaceDefaults
+	}
+	return defaults, nil
+}
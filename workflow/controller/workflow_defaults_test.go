@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newWorkflowDefaultsConfigMap(namespace, name, workflowDefaults string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapWorkflowDefaults},
+		},
+		Data: map[string]string{common.ConfigMapKeyWorkflowDefaults: workflowDefaults},
+	}
+}
+
+func TestNamespaceWorkflowDefaults(t *testing.T) {
+	cancel, controller := newControllerWithComplexDefaults()
+	defer cancel()
+	indexer := controller.configMapInformer.GetIndexer()
+	assert.NoError(t, indexer.Add(newWorkflowDefaultsConfigMap("my-ns", "a", `
+spec:
+  serviceAccountName: a
+  podGC:
+    strategy: OnPodSuccess
+`)))
+	assert.NoError(t, indexer.Add(newWorkflowDefaultsConfigMap("my-ns", "b", `
+spec:
+  serviceAccountName: b
+`)))
+	t.Run("Namespace", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(testDefaultWf)
+		wf.Namespace = "my-ns"
+		wf.Spec.ServiceAccountName = ""
+		assert.NoError(t, controller.setWorkflowDefaults(wf))
+		assert.Equal(t, "b", wf.Spec.ServiceAccountName, "the last config map takes precedence")
+		assert.Equal(t, wfv1.PodGCOnPodSuccess, wf.Spec.PodGC.Strategy)
+		assert.Equal(t, int32(10), *wf.Spec.TTLStrategy.SecondsAfterFailure, "controller defaults apply")
+		assert.Contains(t, wf.Labels, "label")
+	})
+	t.Run("OtherNamespace", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(testDefaultWf)
+		wf.Namespace = "other-ns"
+		wf.Spec.ServiceAccountName = ""
+		assert.NoError(t, controller.setWorkflowDefaults(wf))
+		assert.Equal(t, "my_service_account", wf.Spec.ServiceAccountName)
+		assert.Nil(t, wf.Spec.PodGC)
+	})
+	t.Run("Malformed", func(t *testing.T) {
+		assert.NoError(t, indexer.Add(newWorkflowDefaultsConfigMap("bad-ns", "a", "spec: [")))
+		wf := wfv1.MustUnmarshalWorkflow(testDefaultWf)
+		wf.Namespace = "bad-ns"
+		assert.ErrorContains(t, controller.setWorkflowDefaults(wf), "malformed workflow defaults config map bad-ns/a")
+	})
+}
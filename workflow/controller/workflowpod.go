@@ -15,12 +15,15 @@ import (
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/utils/pointer"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
 	"github.com/argoproj/argo-workflows/v3/util/intstr"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
 	"github.com/argoproj/argo-workflows/v3/util/template"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/entrypoint"
 	"github.com/argoproj/argo-workflows/v3/workflow/controller/indexes"
@@ -96,7 +99,16 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 
 	if !woc.GetShutdownStrategy().ShouldExecute(opts.onExitPod) {
 		// Do not create pods if we are shutting down
-		woc.markNodePhase(nodeName, wfv1.NodeSkipped, fmt.Sprintf("workflow shutdown with strategy: %s", woc.GetShutdownStrategy()))
+		phase := wfv1.NodeSkipped
+		if woc.GetShutdownStrategy() == wfv1.ShutdownStrategyDrain {
+			// the running pods may all succeed, but the workflow must not
+			phase = wfv1.NodeFailed
+		}
+		woc.markNodePhase(nodeName, phase, fmt.Sprintf("workflow shutdown with strategy: %s", woc.GetShutdownStrategy()))
+		return nil, nil
+	}
+
+	if woc.podLimitExceeded(nodeName, opts.onExitPod) {
 		return nil, nil
 	}
 
@@ -125,6 +137,12 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 				return nil, err
 			}
 		}
+		// Workflow-level environment variables do not override the container's own
+		for _, env := range wfSpec.PodEnv {
+			if !hasEnvVar(c.Env, env.Name) {
+				c.Env = append(c.Env, env)
+			}
+		}
 
 		mainCtrs[i] = c
 	}
@@ -186,6 +204,14 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.ObjectMeta.Labels[common.LabelKeyOnExit] = "true"
 	}
 
+	kubeclientset, _, err := woc.controller.clusterClients(tmpl.Cluster)
+	if err != nil {
+		return nil, errors.New(errors.CodeBadRequest, err.Error())
+	}
+	if tmpl.Cluster != "" {
+		forCluster(pod, tmpl.Cluster)
+	}
+
 	if woc.execWf.Spec.HostNetwork != nil {
 		pod.Spec.HostNetwork = *woc.execWf.Spec.HostNetwork
 	}
@@ -222,6 +248,14 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	// container's PID and root filesystem.
 	pod.Spec.Containers = append(pod.Spec.Containers, mainCtrs...)
 
+	if err := addResourcesOverride(pod, tmpl); err != nil {
+		return nil, err
+	}
+	if err := woc.addGPUs(pod, tmpl); err != nil {
+		return nil, err
+	}
+	addRunAsUserOverride(pod, tmpl)
+
 	// Configuring default container to be used with commands like "kubectl exec/logs".
 	// Select "main" container if it's available. In other case use the last container (can happent when pod created from ContainerSet).
 	defaultContainer := pod.Spec.Containers[len(pod.Spec.Containers)-1].Name
@@ -238,8 +272,20 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	initCtr := woc.newInitContainer(tmpl)
 	pod.Spec.InitContainers = []apiv1.Container{initCtr}
 
-	addSchedulingConstraints(pod, wfSpec, tmpl)
+	workloadClass, err := woc.getWorkloadClass(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	addSchedulingConstraints(pod, wfSpec, tmpl, workloadClass)
 	woc.addMetadata(pod, tmpl)
+	addServiceMeshAnnotations(pod, wfSpec.ServiceMesh)
+
+	if tmpl.PodDisruptionBudget != nil {
+		pod.ObjectMeta.Labels[common.LabelKeyPodDisruptionBudget] = templatePDBKey(tmpl)
+	}
+	if tmpl.IsDisruptionSensitive() {
+		pod.ObjectMeta.Annotations[common.AnnotationKeySafeToEvict] = "false"
+	}
 
 	err = addVolumeReferences(pod, woc.volumes, tmpl, woc.wf.Status.PersistentVolumeClaims)
 	if err != nil {
@@ -266,6 +312,11 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.InitContainers[i] = c
 	}
 
+	err = setPipeArtifactSubPaths(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
 	envVarTemplateValue := wfv1.MustMarshallJSON(tmpl)
 
 	// Add standard environment variables, making pod spec larger
@@ -277,6 +328,11 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		{Name: common.EnvVarProgressFile, Value: common.ArgoProgressPath},
 	}
 
+	if woc.controller.tracer() != nil {
+		_, workflowSpan := woc.workflowSpanContext()
+		envVars = append(envVars, apiv1.EnvVar{Name: common.EnvVarTraceParent, Value: tracing.TraceParent(nodeSpanContext(workflowSpan, nodeID))})
+	}
+
 	// only set tick durations if progress is enabled. The EnvVarProgressFile is always set (user convenience) but the
 	// progress is only monitored if the tick durations are >0.
 	if woc.controller.progressPatchTickDuration != 0 && woc.controller.progressFileTickDuration != 0 {
@@ -370,24 +426,27 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		}
 	}
 
+	woc.addImagePullSecrets(pod, tmpl)
+
+	// init containers that capture outputs run the emissary, which saves their outputs once they exit
+	for _, ctr := range tmpl.InitContainers {
+		if !ctr.GetCaptureOutputs() {
+			continue
+		}
+		for i := range pod.Spec.InitContainers {
+			if pod.Spec.InitContainers[i].Name == ctr.Name {
+				if err := woc.addEmissaryCommand(ctx, pod, &pod.Spec.InitContainers[i]); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
 	for i, c := range pod.Spec.Containers {
 		if c.Name != common.WaitContainerName {
-			// https://kubernetes.io/docs/tasks/inject-data-application/define-command-argument-container/#notes
-			if len(c.Command) == 0 {
-				x, err := woc.controller.entrypoint.Lookup(ctx, c.Image, entrypoint.Options{
-					Namespace: woc.wf.Namespace, ServiceAccountName: woc.execWf.Spec.ServiceAccountName, ImagePullSecrets: woc.execWf.Spec.ImagePullSecrets,
-				})
-				if err != nil {
-					return nil, fmt.Errorf("failed to look-up entrypoint/cmd for image %q, you must either explicitly specify the command, or list the image's command in the index: https://argoproj.github.io/argo-workflows/workflow-executors/#emissary-emissary: %w", c.Image, err)
-				}
-				c.Command = x.Entrypoint
-				if c.Args == nil { // check nil rather than length, as zero-length is valid args
-					c.Args = x.Cmd
-				}
+			if err := woc.addEmissaryCommand(ctx, pod, &c); err != nil {
+				return nil, err
 			}
-			c.Command = append([]string{common.VarRunArgoPath + "/argoexec", "emissary",
-				"--loglevel", getExecutorLogLevel(), "--log-format", woc.controller.executorLogFormat(),
-				"--"}, c.Command...)
 		}
 		if c.Image == woc.controller.executorImage() {
 			// mount tmp dir to wait container
@@ -404,6 +463,11 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.Containers[i] = c
 	}
 
+	// the sidecars of artifact driver plugins are added last, as they do not run the emissary
+	if err := woc.addArtifactDriverPlugins(ctx, pod, tmpl); err != nil {
+		return nil, err
+	}
+
 	// Check if the template has exceeded its timeout duration. If it hasn't set the applicable activeDeadlineSeconds
 	node := woc.wf.GetNodeByName(nodeName)
 	templateDeadline, err := woc.checkTemplateTimeout(tmpl, node)
@@ -424,13 +488,21 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 		pod.Spec.ActiveDeadlineSeconds = &newActiveDeadlineSeconds
 	}
 
+	if err := woc.checkPodQuotas(); err != nil {
+		return nil, err
+	}
+
 	if !woc.controller.rateLimiter.Allow() {
 		return nil, ErrResourceRateLimitReached
 	}
 
+	if err := woc.createTemplatePDBResource(ctx, tmpl); err != nil {
+		return nil, err
+	}
+
 	woc.log.Debugf("Creating Pod: %s (%s)", nodeName, pod.Name)
 
-	created, err := woc.controller.kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	created, err := kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Create(ctx, pod, metav1.CreateOptions{})
 	if err != nil {
 		if apierr.IsAlreadyExists(err) {
 			// workflow pod names are deterministic. We can get here if the
@@ -446,11 +518,12 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 	}
 	woc.log.Infof("Created pod: %s (%s)", nodeName, created.Name)
 	woc.activePods++
+	woc.podCreated()
 	return created, nil
 }
 
 func (woc *wfOperationCtx) podExists(nodeID string) (existing *apiv1.Pod, exists bool, err error) {
-	objs, err := woc.controller.podInformer.GetIndexer().ByIndex(indexes.NodeIDIndex, woc.wf.Namespace+"/"+nodeID)
+	objs, err := woc.controller.podsByIndex(indexes.NodeIDIndex, woc.wf.Namespace+"/"+nodeID)
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to get pod from informer store: %w", err)
 	}
@@ -515,11 +588,14 @@ func (woc *wfOperationCtx) newInitContainer(tmpl *wfv1.Template) apiv1.Container
 func (woc *wfOperationCtx) newWaitContainer(tmpl *wfv1.Template) *apiv1.Container {
 	ctr := woc.newExecContainer(common.WaitContainerName, tmpl)
 	ctr.Command = []string{"argoexec", "wait", "--loglevel", getExecutorLogLevel(), "--log-format", woc.controller.executorLogFormat()}
+	if url := woc.execWf.Spec.ServiceMesh.GetShutdownURL(); url != "" {
+		ctr.Env = append(ctr.Env, apiv1.EnvVar{Name: common.EnvVarServiceMeshShutdownURL, Value: url})
+	}
 	return ctr
 }
 
 func getExecutorLogLevel() string {
-	return log.GetLevel().String()
+	return logging.GetLevels().String()
 }
 
 func (woc *wfOperationCtx) createEnvVars() []apiv1.EnvVar {
@@ -560,12 +636,26 @@ func (woc *wfOperationCtx) createEnvVars() []apiv1.EnvVar {
 			apiv1.EnvVar{Name: common.EnvVarInstanceID, Value: v},
 		)
 	}
+	if v := woc.artifactRepository.GetMaxBytesPerSecond(); v > 0 {
+		execEnvVars = append(execEnvVars,
+			apiv1.EnvVar{Name: common.EnvVarArtifactRepositoryMaxBytesPerSec, Value: strconv.FormatInt(v, 10)},
+		)
+	}
 	if woc.controller.Config.Executor != nil {
 		execEnvVars = append(execEnvVars, woc.controller.Config.Executor.Env...)
 	}
 	return execEnvVars
 }
 
+func hasEnvVar(envs []apiv1.EnvVar, name string) bool {
+	for _, env := range envs {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (woc *wfOperationCtx) createVolumes(tmpl *wfv1.Template) []apiv1.Volume {
 	var volumes []apiv1.Volume
 	if woc.controller.Config.KubeConfig != nil {
@@ -642,11 +732,27 @@ func (woc *wfOperationCtx) newExecContainer(name string, tmpl *wfv1.Template) *a
 			ReadOnly:  true,
 		})
 	}
+	if v := woc.getArtifactSaveParallelism(tmpl); v > 0 {
+		exec.Env = append(exec.Env, apiv1.EnvVar{Name: common.EnvVarArtifactSaveParallelism, Value: strconv.Itoa(v)})
+	}
 	return &exec
 }
 
-// addMetadata applies metadata specified in the template
+// getArtifactSaveParallelism returns the number of output artifacts to save concurrently, preferring the
+// template's executor config, then the workflow's, then the controller's
+func (woc *wfOperationCtx) getArtifactSaveParallelism(tmpl *wfv1.Template) int {
+	if tmpl.Executor != nil && tmpl.Executor.ArtifactSaveParallelism != nil {
+		return int(*tmpl.Executor.ArtifactSaveParallelism)
+	} else if woc.execWf.Spec.Executor != nil && woc.execWf.Spec.Executor.ArtifactSaveParallelism != nil {
+		return int(*woc.execWf.Spec.Executor.ArtifactSaveParallelism)
+	}
+	return woc.controller.Config.ArtifactSaveParallelism
+}
+
+// addMetadata applies metadata propagated from the workflow, and specified in the workflow and template
 func (woc *wfOperationCtx) addMetadata(pod *apiv1.Pod, tmpl *wfv1.Template) {
+	woc.controller.Config.MetadataPropagation.Propagate(&woc.wf.ObjectMeta, &pod.ObjectMeta)
+
 	if woc.execWf.Spec.PodMetadata != nil {
 		// add workflow-level pod annotations and labels
 		for k, v := range woc.execWf.Spec.PodMetadata.Annotations {
@@ -665,23 +771,121 @@ func (woc *wfOperationCtx) addMetadata(pod *apiv1.Pod, tmpl *wfv1.Template) {
 	}
 }
 
-// addSchedulingConstraints applies any node selectors or affinity rules to the pod, either set in the workflow or the template
-func addSchedulingConstraints(pod *apiv1.Pod, wfSpec *wfv1.WorkflowSpec, tmpl *wfv1.Template) {
+// addRunAsUserOverride runs the main containers of the pod as the template's runAsUserOverride, if any. The emissary
+// applies the workingDirOverride, as it must create the directory as the container's user.
+func addRunAsUserOverride(pod *apiv1.Pod, tmpl *wfv1.Template) {
+	if tmpl.RunAsUserOverride == nil {
+		return
+	}
+	for i, c := range pod.Spec.Containers {
+		if !tmpl.IsMainContainerName(c.Name) {
+			continue
+		}
+		// the security context may be shared with the template's container
+		c.SecurityContext = c.SecurityContext.DeepCopy()
+		if c.SecurityContext == nil {
+			c.SecurityContext = &apiv1.SecurityContext{}
+		}
+		c.SecurityContext.RunAsUser = pointer.Int64(*tmpl.RunAsUserOverride)
+		pod.Spec.Containers[i] = c
+	}
+}
+
+// addResourcesOverride sets the requests and limits of the main containers of the pod to the template's resources, if
+// any, whose parameters have been substituted by now
+func addResourcesOverride(pod *apiv1.Pod, tmpl *wfv1.Template) error {
+	if tmpl.Resources == nil {
+		return nil
+	}
+	resources, err := tmpl.Resources.ResourceRequirements()
+	if err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s", tmpl.Name, err)
+	}
+	for i, c := range pod.Spec.Containers {
+		if !tmpl.IsMainContainerName(c.Name) {
+			continue
+		}
+		// the resources may be shared with the template's container
+		c.Resources = *c.Resources.DeepCopy()
+		for name, q := range resources.Limits {
+			if c.Resources.Limits == nil {
+				c.Resources.Limits = apiv1.ResourceList{}
+			}
+			c.Resources.Limits[name] = q
+		}
+		for name, q := range resources.Requests {
+			if c.Resources.Requests == nil {
+				c.Resources.Requests = apiv1.ResourceList{}
+			}
+			c.Resources.Requests[name] = q
+		}
+		pod.Spec.Containers[i] = c
+	}
+	return nil
+}
+
+// getWorkloadClass returns the workload class of the template, or nil if it has none
+func (woc *wfOperationCtx) getWorkloadClass(tmpl *wfv1.Template) (*config.WorkloadClass, error) {
+	if tmpl.WorkloadClass == "" {
+		return nil, nil
+	}
+	workloadClass, ok := woc.controller.Config.WorkloadClasses[tmpl.WorkloadClass]
+	if !ok {
+		return nil, errors.Errorf(errors.CodeBadRequest, "workload class %q is not configured", tmpl.WorkloadClass)
+	}
+	return &workloadClass, nil
+}
+
+// addServiceMeshAnnotations annotates the pod so that the service mesh's proxy is started before the pod's containers,
+// and can be shut down by the wait container. Annotations set by the user are not overwritten.
+func addServiceMeshAnnotations(pod *apiv1.Pod, serviceMesh *wfv1.ServiceMesh) {
+	if serviceMesh == nil {
+		return
+	}
+	var annotations map[string]string
+	switch serviceMesh.Type {
+	case wfv1.ServiceMeshIstio:
+		annotations = map[string]string{"proxy.istio.io/config": `{"holdApplicationUntilProxyStarts": true}`}
+	case wfv1.ServiceMeshLinkerd:
+		annotations = map[string]string{
+			"config.linkerd.io/proxy-await":          "enabled",
+			"config.linkerd.io/proxy-admin-shutdown": "enabled",
+		}
+	}
+	for k, v := range annotations {
+		if _, ok := pod.ObjectMeta.Annotations[k]; !ok {
+			pod.ObjectMeta.Annotations[k] = v
+		}
+	}
+}
+
+// addSchedulingConstraints applies any node selectors or affinity rules to the pod, either set in the workflow, the
+// template's workload class, or the template
+func addSchedulingConstraints(pod *apiv1.Pod, wfSpec *wfv1.WorkflowSpec, tmpl *wfv1.Template, workloadClass *config.WorkloadClass) {
+	if workloadClass == nil {
+		workloadClass = &config.WorkloadClass{}
+	}
 	// Set nodeSelector (if specified)
 	if len(tmpl.NodeSelector) > 0 {
 		pod.Spec.NodeSelector = tmpl.NodeSelector
+	} else if len(workloadClass.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = workloadClass.NodeSelector
 	} else if len(wfSpec.NodeSelector) > 0 {
 		pod.Spec.NodeSelector = wfSpec.NodeSelector
 	}
 	// Set affinity (if specified)
 	if tmpl.Affinity != nil {
 		pod.Spec.Affinity = tmpl.Affinity
+	} else if workloadClass.Affinity != nil {
+		pod.Spec.Affinity = workloadClass.Affinity
 	} else if wfSpec.Affinity != nil {
 		pod.Spec.Affinity = wfSpec.Affinity
 	}
 	// Set tolerations (if specified)
 	if len(tmpl.Tolerations) > 0 {
 		pod.Spec.Tolerations = tmpl.Tolerations
+	} else if len(workloadClass.Tolerations) > 0 {
+		pod.Spec.Tolerations = workloadClass.Tolerations
 	} else if len(wfSpec.Tolerations) > 0 {
 		pod.Spec.Tolerations = wfSpec.Tolerations
 	}
@@ -777,6 +981,18 @@ func addVolumeReferences(pod *apiv1.Pod, vols []apiv1.Volume, tmpl *wfv1.Templat
 		return err
 	}
 
+	// pipe input artifacts are mounted from the volume the upstream task writes them to
+	var pipeMounts []apiv1.VolumeMount
+	for _, art := range tmpl.Inputs.Artifacts {
+		if art.Pipe != nil {
+			pipeMounts = append(pipeMounts, apiv1.VolumeMount{Name: art.Pipe.Volume})
+		}
+	}
+	err = addVolumeRef(pipeMounts)
+	if err != nil {
+		return err
+	}
+
 	for _, container := range tmpl.InitContainers {
 		err := addVolumeRef(container.VolumeMounts)
 		if err != nil {
@@ -878,11 +1094,26 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.T
 			if err != nil {
 				return errors.Errorf(errors.CodeBadRequest, "error in inputs.artifacts.%s: %s", art.Name, err.Error())
 			}
+			if art.Pipe != nil {
+				// the artifact is read directly from the volume it is being written to
+				c.VolumeMounts = append(c.VolumeMounts, apiv1.VolumeMount{
+					Name:      art.Pipe.Volume,
+					MountPath: art.Path,
+					SubPath:   art.Pipe.SubPath,
+					ReadOnly:  true,
+				})
+				continue
+			}
 			if !art.HasLocationOrKey() && art.Optional {
 				woc.log.Infof("skip volume mount of %s (%s): optional artifact was not provided",
 					art.Name, art.Path)
 				continue
 			}
+			if common.IsPluginArtifact(art, tmpl.ArchiveLocation) {
+				// the main container copies the artifact to its path once the wait container has loaded it
+				woc.log.Debugf("skip volume mount of %s (%s): stored by an artifact driver plugin", art.Name, art.Path)
+				continue
+			}
 			overlap := common.FindOverlappingVolume(tmpl, art.Path)
 			if overlap != nil {
 				// artifact path overlaps with a mounted volume. do not mount the
@@ -904,6 +1135,35 @@ func (woc *wfOperationCtx) addInputArtifactsVolumes(pod *apiv1.Pod, tmpl *wfv1.T
 	return nil
 }
 
+// setPipeArtifactSubPaths sets the path of each pipe output artifact within the volume it is written to, so that the
+// tasks that read it can mount it
+func setPipeArtifactSubPaths(tmpl *wfv1.Template) error {
+	for i, art := range tmpl.Outputs.Artifacts {
+		if art.Pipe == nil {
+			continue
+		}
+		subPath, err := common.PipeArtifactSubPath(tmpl, art)
+		if err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "error in outputs.artifacts.%s.pipe: %s", art.Name, err.Error())
+		}
+		tmpl.Outputs.Artifacts[i].Pipe.SubPath = subPath
+	}
+	return nil
+}
+
+// getPodTemplate returns the template the pod was created from, as passed to the executor
+func getPodTemplate(pod *apiv1.Pod) (*wfv1.Template, error) {
+	tmpl := &wfv1.Template{}
+	for _, c := range pod.Spec.Containers {
+		for _, e := range c.Env {
+			if e.Name == common.EnvVarTemplate {
+				return tmpl, json.Unmarshal([]byte(e.Value), tmpl)
+			}
+		}
+	}
+	return nil, fmt.Errorf("not found")
+}
+
 // addOutputArtifactsVolumes mirrors any volume mounts in the main container to the wait sidecar.
 // For any output artifacts that were produced in mounted volumes (e.g. PVCs, emptyDirs), the
 // wait container will collect the artifacts directly from volumeMount instead of `docker cp`-ing
@@ -958,8 +1218,15 @@ func (woc *wfOperationCtx) addArchiveLocation(tmpl *wfv1.Template) {
 	if !needLocation {
 		return
 	}
+	var separateLogStreams *bool
+	if tmpl.ArchiveLocation != nil && tmpl.ArchiveLocation.SeparateLogStreams != nil {
+		separateLogStreams = tmpl.ArchiveLocation.SeparateLogStreams
+	}
 	tmpl.ArchiveLocation = woc.artifactRepository.ToArtifactLocation()
 	tmpl.ArchiveLocation.ArchiveLogs = &archiveLogs
+	if separateLogStreams != nil {
+		tmpl.ArchiveLocation.SeparateLogStreams = separateLogStreams
+	}
 }
 
 // IsArchiveLogs determines if container should archive logs
@@ -1061,6 +1328,27 @@ func addScriptStagingVolume(pod *apiv1.Pod) {
 	}
 }
 
+// addEmissaryCommand runs the container's command using the emissary
+func (woc *wfOperationCtx) addEmissaryCommand(ctx context.Context, pod *apiv1.Pod, c *apiv1.Container) error {
+	// https://kubernetes.io/docs/tasks/inject-data-application/define-command-argument-container/#notes
+	if len(c.Command) == 0 {
+		x, err := woc.controller.entrypoint.Lookup(ctx, c.Image, entrypoint.Options{
+			Namespace: woc.wf.Namespace, ServiceAccountName: woc.execWf.Spec.ServiceAccountName, ImagePullSecrets: pod.Spec.ImagePullSecrets,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to look-up entrypoint/cmd for image %q, you must either explicitly specify the command, or list the image's command in the index: https://argoproj.github.io/argo-workflows/workflow-executors/#emissary-emissary: %w", c.Image, err)
+		}
+		c.Command = x.Entrypoint
+		if c.Args == nil { // check nil rather than length, as zero-length is valid args
+			c.Args = x.Cmd
+		}
+	}
+	c.Command = append([]string{common.VarRunArgoPath + "/argoexec", "emissary",
+		"--loglevel", getExecutorLogLevel(), "--log-format", woc.controller.executorLogFormat(),
+		"--"}, c.Command...)
+	return nil
+}
+
 // addInitContainers adds all init containers to the pod spec of the step
 // Optionally volume mounts from the main container to the init containers
 func addInitContainers(pod *apiv1.Pod, tmpl *wfv1.Template) {
@@ -525,6 +525,32 @@ func TestConditionalAddArchiveLocationArchiveLogs(t *testing.T) {
 	assert.NotNil(t, tmpl.ArchiveLocation)
 }
 
+func TestAddArchiveLocationSeparateLogStreams(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		repository *bool
+		template   *bool
+		expected   bool
+	}{
+		{"Default", nil, nil, false},
+		{"Repository", pointer.BoolPtr(true), nil, true},
+		{"Template", nil, pointer.BoolPtr(true), true},
+		{"TemplateOverridesRepository", pointer.BoolPtr(true), pointer.BoolPtr(false), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			woc := newWoc()
+			woc.artifactRepository = &wfv1.ArtifactRepository{
+				S3:                 &wfv1.S3ArtifactRepository{S3Bucket: wfv1.S3Bucket{Bucket: "foo"}},
+				ArchiveLogs:        pointer.BoolPtr(true),
+				SeparateLogStreams: tt.repository,
+			}
+			tmpl := &wfv1.Template{ArchiveLocation: &wfv1.ArtifactLocation{SeparateLogStreams: tt.template}}
+			woc.addArchiveLocation(tmpl)
+			assert.Equal(t, tt.expected, tmpl.SaveLogStreamsAsArtifacts())
+		})
+	}
+}
+
 // TestConditionalNoAddArchiveLocation verifies we add archive location when it is needed
 func TestConditionalArchiveLocation(t *testing.T) {
 	ctx := context.Background()
@@ -1558,6 +1584,98 @@ func TestPodMetadata(t *testing.T) {
 	assert.Equal(t, "world", pod.ObjectMeta.Labels["template-level-pod-label"])
 }
 
+func TestPodMetadataPropagation(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(wfWithPodMetadataAndTemplateMetadata)
+	wf.Labels = map[string]string{
+		"cost.example.com/team":    "ml",
+		"cost.example.com/secret":  "x",
+		"other":                    "y",
+		"workflow-level-pod-label": "overridden",
+		common.LabelKeyCompleted:   "true",
+		common.LabelKeyWorkflow:    "other-wf",
+	}
+	ctx := context.Background()
+	woc := newWoc(*wf)
+	woc.controller.Config.MetadataPropagation = &config.MetadataPropagationConfig{
+		Labels: config.KeyFilter{Allow: []string{"cost.example.com/*", "workflow-level-pod-label", "workflows.argoproj.io/*"}, Deny: []string{"cost.example.com/secret"}},
+	}
+	mainCtr := woc.execWf.Spec.Templates[0].Container
+	pod, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*mainCtr}, &wf.Spec.Templates[0], &createWorkflowPodOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, "ml", pod.Labels["cost.example.com/team"])
+	assert.NotContains(t, pod.Labels, "cost.example.com/secret")
+	assert.NotContains(t, pod.Labels, "other")
+	assert.Equal(t, "buzz", pod.Labels["workflow-level-pod-label"], "pod metadata takes precedence")
+	assert.Equal(t, "false", pod.Labels[common.LabelKeyCompleted], "labels of the controller are never propagated")
+	assert.Equal(t, wf.Name, pod.Labels[common.LabelKeyWorkflow])
+}
+
+func TestInitContainerCaptureOutputs(t *testing.T) {
+	ctx := context.Background()
+	woc := newWoc()
+	woc.execWf.Spec.Templates[0].InitContainers = []wfv1.UserContainer{
+		{Container: apiv1.Container{Name: "init-foo", Image: "busybox", Command: []string{"init"}}, CaptureOutputs: pointer.Bool(true)},
+		{Container: apiv1.Container{Name: "init-bar", Image: "busybox", Command: []string{"init"}}},
+	}
+	tmplCtx, err := woc.createTemplateContext(wfv1.ResourceScopeLocal, "")
+	require.NoError(t, err)
+	_, err = woc.executeContainer(ctx, woc.execWf.Spec.Entrypoint, tmplCtx.GetTemplateScope(), &woc.execWf.Spec.Templates[0], &wfv1.WorkflowStep{}, &executeTemplateOpts{})
+	require.NoError(t, err)
+	pods, err := listPods(woc)
+	require.NoError(t, err)
+	require.Len(t, pods.Items, 1)
+	initContainers := pods.Items[0].Spec.InitContainers
+	require.Len(t, initContainers, 3)
+	assert.Equal(t, []string{"/var/run/argo/argoexec", "emissary", "--loglevel", "info", "--log-format", "text", "--", "init"}, initContainers[1].Command)
+	assert.Equal(t, []string{"init"}, initContainers[2].Command)
+}
+
+var wfWithPodEnv = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: hello-world
+spec:
+  entrypoint: whalesay
+  podEnv:
+  - name: HTTP_PROXY
+    value: http://proxy:3128
+  - name: TENANT_ID
+    valueFrom:
+      configMapKeyRef:
+        name: tenant
+        key: id
+  templates:
+  - name: whalesay
+    container:
+      image: docker/whalesay:latest
+      env:
+      - name: HTTP_PROXY
+        value: http://other-proxy:3128
+`
+
+func TestPodEnv(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(wfWithPodEnv)
+	ctx := context.Background()
+	woc := newWoc(*wf)
+	mainCtr := woc.execWf.Spec.Templates[0].Container
+	pod, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*mainCtr}, &wf.Spec.Templates[0], &createWorkflowPodOpts{})
+	if assert.NoError(t, err) {
+		var env []apiv1.EnvVar
+		for _, c := range pod.Spec.Containers {
+			if c.Name == common.MainContainerName {
+				env = c.Env
+			}
+		}
+		assert.Contains(t, env, apiv1.EnvVar{Name: "HTTP_PROXY", Value: "http://other-proxy:3128"})
+		assert.NotContains(t, env, apiv1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy:3128"})
+		assert.Contains(t, env, wf.Spec.PodEnv[1])
+		for _, c := range pod.Spec.InitContainers {
+			assert.False(t, hasEnvVar(c.Env, "TENANT_ID"))
+		}
+	}
+}
+
 var wfWithContainerSet = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -1596,6 +1714,109 @@ func TestPodDefaultContainer(t *testing.T) {
 	assert.Equal(t, "b", pod.ObjectMeta.Annotations[common.AnnotationKeyDefaultContainer])
 }
 
+func TestWorkloadClass(t *testing.T) {
+	ctx := context.Background()
+	gpu := config.WorkloadClass{
+		NodeSelector: map[string]string{"accelerator": "nvidia"},
+		Tolerations:  []apiv1.Toleration{{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule}},
+	}
+	createPod := func(modify func(wf *wfv1.Workflow)) (*apiv1.Pod, error) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Spec.NodeSelector = map[string]string{"pool": "default"}
+		wf.Spec.Templates[0].WorkloadClass = "gpu"
+		modify(wf)
+		woc := newWoc(*wf)
+		woc.controller.Config.WorkloadClasses = map[string]config.WorkloadClass{"gpu": gpu}
+		template := woc.execWf.Spec.Templates[0]
+		return woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*template.Container}, &template, &createWorkflowPodOpts{})
+	}
+	t.Run("Class", func(t *testing.T) {
+		pod, err := createPod(func(*wfv1.Workflow) {})
+		if assert.NoError(t, err) {
+			assert.Equal(t, gpu.NodeSelector, pod.Spec.NodeSelector)
+			assert.Equal(t, gpu.Tolerations, pod.Spec.Tolerations)
+			assert.Nil(t, pod.Spec.Affinity)
+		}
+	})
+	t.Run("TemplateOverridesClass", func(t *testing.T) {
+		pod, err := createPod(func(wf *wfv1.Workflow) {
+			wf.Spec.Templates[0].NodeSelector = map[string]string{"accelerator": "amd"}
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"accelerator": "amd"}, pod.Spec.NodeSelector)
+			assert.Equal(t, gpu.Tolerations, pod.Spec.Tolerations)
+		}
+	})
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, err := createPod(func(wf *wfv1.Workflow) {
+			wf.Spec.Templates[0].WorkloadClass = "highmem"
+		})
+		assert.EqualError(t, err, `workload class "highmem" is not configured`)
+	})
+}
+
+func TestServiceMesh(t *testing.T) {
+	ctx := context.Background()
+	createPod := func(serviceMesh *wfv1.ServiceMesh, annotations map[string]string) *apiv1.Pod {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Spec.ServiceMesh = serviceMesh
+		wf.Spec.Templates[0].Metadata.Annotations = annotations
+		woc := newWoc(*wf)
+		template := woc.execWf.Spec.Templates[0]
+		pod, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*template.Container}, &template, &createWorkflowPodOpts{})
+		assert.NoError(t, err)
+		return pod
+	}
+	shutdownURL := func(pod *apiv1.Pod) string {
+		for _, c := range pod.Spec.Containers {
+			if c.Name == common.WaitContainerName {
+				for _, e := range c.Env {
+					if e.Name == common.EnvVarServiceMeshShutdownURL {
+						return e.Value
+					}
+				}
+			}
+		}
+		return ""
+	}
+	t.Run("None", func(t *testing.T) {
+		pod := createPod(nil, nil)
+		assert.NotContains(t, pod.Annotations, "proxy.istio.io/config")
+		assert.Empty(t, shutdownURL(pod))
+	})
+	t.Run("Istio", func(t *testing.T) {
+		pod := createPod(&wfv1.ServiceMesh{Type: wfv1.ServiceMeshIstio}, nil)
+		assert.Equal(t, `{"holdApplicationUntilProxyStarts": true}`, pod.Annotations["proxy.istio.io/config"])
+		assert.Equal(t, "http://localhost:15020/quitquitquit", shutdownURL(pod))
+	})
+	t.Run("Linkerd", func(t *testing.T) {
+		pod := createPod(&wfv1.ServiceMesh{Type: wfv1.ServiceMeshLinkerd, ShutdownURL: "http://localhost:4191/custom"}, map[string]string{"config.linkerd.io/proxy-await": "disabled"})
+		assert.Equal(t, "disabled", pod.Annotations["config.linkerd.io/proxy-await"])
+		assert.Equal(t, "enabled", pod.Annotations["config.linkerd.io/proxy-admin-shutdown"])
+		assert.Equal(t, "http://localhost:4191/custom", shutdownURL(pod))
+	})
+}
+
+func TestRunAsUserOverride(t *testing.T) {
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow(wfWithContainerSet)
+	wf.Spec.Templates[0].RunAsUserOverride = pointer.Int64(1000)
+	woc := newWoc(*wf)
+	template := woc.execWf.Spec.Templates[0]
+	pod, err := woc.createWorkflowPod(ctx, wf.Name, template.ContainerSet.GetContainers(), &template, &createWorkflowPodOpts{})
+	if assert.NoError(t, err) {
+		for _, c := range pod.Spec.Containers {
+			if template.IsMainContainerName(c.Name) {
+				if assert.NotNil(t, c.SecurityContext) && assert.NotNil(t, c.SecurityContext.RunAsUser) {
+					assert.Equal(t, int64(1000), *c.SecurityContext.RunAsUser)
+				}
+			} else if c.SecurityContext != nil {
+				assert.Nil(t, c.SecurityContext.RunAsUser)
+			}
+		}
+	}
+}
+
 func TestGetDeadline(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
 	ctx := context.Background()
@@ -1793,3 +2014,172 @@ func TestProgressEnvVars(t *testing.T) {
 		})
 	})
 }
+
+func TestCreateEnvVarsArtifactMaxBytesPerSec(t *testing.T) {
+	woc := newWoc()
+	assert.NotContains(t, woc.createEnvVars(), apiv1.EnvVar{Name: common.EnvVarArtifactRepositoryMaxBytesPerSec, Value: "1024"})
+	woc.artifactRepository = &wfv1.ArtifactRepository{MaxBytesPerSecond: pointer.Int64(1024)}
+	assert.Contains(t, woc.createEnvVars(), apiv1.EnvVar{Name: common.EnvVarArtifactRepositoryMaxBytesPerSec, Value: "1024"})
+}
+
+func TestNewExecContainerArtifactSaveParallelism(t *testing.T) {
+	env := apiv1.EnvVar{Name: common.EnvVarArtifactSaveParallelism}
+	woc := newWoc()
+	tmpl := &wfv1.Template{}
+	for _, e := range woc.newExecContainer(common.WaitContainerName, tmpl).Env {
+		assert.NotEqual(t, env.Name, e.Name)
+	}
+	woc.controller.Config.ArtifactSaveParallelism = 2
+	env.Value = "2"
+	assert.Contains(t, woc.newExecContainer(common.WaitContainerName, tmpl).Env, env)
+	woc.execWf.Spec.Executor = &wfv1.ExecutorConfig{ArtifactSaveParallelism: pointer.Int32(3)}
+	env.Value = "3"
+	assert.Contains(t, woc.newExecContainer(common.WaitContainerName, tmpl).Env, env)
+	tmpl.Executor = &wfv1.ExecutorConfig{ArtifactSaveParallelism: pointer.Int32(4)}
+	env.Value = "4"
+	assert.Contains(t, woc.newExecContainer(common.WaitContainerName, tmpl).Env, env)
+}
+
+var templateResourcesWf = `
+metadata:
+  name: my-wf
+  namespace: my-ns
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: memory
+        value: 2Gi
+  templates:
+    - name: main
+      inputs:
+        parameters:
+          - name: cpu
+            value: 500m
+      resources:
+        limits:
+          memory: "{{workflow.parameters.memory}}"
+        requests:
+          cpu: "{{inputs.parameters.cpu}}"
+      container:
+        image: my-image
+        resources:
+          requests:
+            memory: 1Gi
+`
+
+func TestTemplateResources(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(templateResourcesWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+		main := pods.Items[0].Spec.Containers[1]
+		assert.Equal(t, common.MainContainerName, main.Name)
+		assert.Equal(t, resource.MustParse("2Gi"), main.Resources.Limits[apiv1.ResourceMemory])
+		assert.Equal(t, resource.MustParse("500m"), main.Resources.Requests[apiv1.ResourceCPU])
+		assert.Equal(t, resource.MustParse("1Gi"), main.Resources.Requests[apiv1.ResourceMemory])
+	}
+	assert.Equal(t, resource.MustParse("1Gi"), wf.Spec.Templates[0].Container.Resources.Requests[apiv1.ResourceMemory])
+	assert.NotContains(t, wf.Spec.Templates[0].Container.Resources.Requests, apiv1.ResourceCPU)
+
+	t.Run("InvalidQuantity", func(t *testing.T) {
+		wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+		wf.Spec.Templates[0].Resources = &wfv1.TemplateResources{Limits: map[apiv1.ResourceName]string{apiv1.ResourceMemory: "lots"}}
+		woc := newWoc(*wf)
+		template := woc.execWf.Spec.Templates[0]
+		_, err := woc.createWorkflowPod(ctx, wf.Name, []apiv1.Container{*template.Container}, &template, &createWorkflowPodOpts{})
+		assert.ErrorContains(t, err, `templates.whalesay.resources.limits.memory "lots" is not a valid quantity`)
+	})
+}
+
+var pipeArtifactsWf = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: pipe-artifacts
+spec:
+  entrypoint: main
+  volumes:
+  - name: workdir
+    persistentVolumeClaim:
+      claimName: shared
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: produce
+        template: produce
+      - name: consume
+        template: consume
+        depends: produce.Streaming
+        arguments:
+          artifacts:
+          - name: in
+            from: "{{tasks.produce.outputs.artifacts.out}}"
+  - name: produce
+    container:
+      image: argoproj/argosay:v2
+      volumeMounts:
+      - name: workdir
+        mountPath: /work
+    outputs:
+      artifacts:
+      - name: out
+        path: /work/out
+        pipe:
+          volume: workdir
+  - name: consume
+    inputs:
+      artifacts:
+      - name: in
+        path: /in
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestPipeArtifacts(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(pipeArtifactsWf)
+	cancel, controller := newController(wf)
+	defer cancel()
+	ctx := context.Background()
+
+	woc := newWorkflowOperationCtx(wf, controller)
+	woc.operate(ctx)
+
+	pods, err := listPods(woc)
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 1) {
+		tmpl, err := getPodTemplate(&pods.Items[0])
+		if assert.NoError(t, err) {
+			assert.Equal(t, &wfv1.PipeArtifact{Volume: "workdir", SubPath: "out"}, tmpl.Outputs.Artifacts[0].Pipe)
+		}
+	}
+
+	// once the producer is running, the consumer reads the artifact from the volume it is written to
+	makePodsPhase(ctx, woc, apiv1.PodRunning)
+	woc = newWorkflowOperationCtx(woc.wf, controller)
+	woc.operate(ctx)
+
+	produce := woc.wf.Status.Nodes.FindByDisplayName("produce")
+	if assert.NotNil(t, produce) {
+		assert.Equal(t, wfv1.NodeRunning, produce.Phase)
+		assert.Len(t, produce.Outputs.PipeArtifacts(), 1)
+	}
+	pods, err = listPods(woc)
+	if assert.NoError(t, err) && assert.Len(t, pods.Items, 2) {
+		for _, pod := range pods.Items {
+			if pod.Annotations[common.AnnotationKeyNodeID] == produce.ID {
+				continue
+			}
+			assert.Contains(t, pod.Spec.Volumes, apiv1.Volume{Name: "workdir", VolumeSource: apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "shared"}}})
+			main := pod.Spec.Containers[1]
+			assert.Equal(t, common.MainContainerName, main.Name)
+			assert.Contains(t, main.VolumeMounts, apiv1.VolumeMount{Name: "workdir", MountPath: "/in", SubPath: "out", ReadOnly: true})
+		}
+	}
+}
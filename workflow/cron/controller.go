@@ -21,13 +21,16 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	cronutil "github.com/argoproj/argo-workflows/v3/util/cron"
 	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/util/namespaces"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
@@ -38,9 +41,11 @@ import (
 type Controller struct {
 	namespace            string
 	managedNamespace     string
+	managedNamespaces    *namespaces.Selector
 	instanceId           string
 	cron                 *cronFacade
 	keyLock              sync.KeyLock
+	kubeClient           kubernetes.Interface
 	wfClientset          versioned.Interface
 	wfLister             util.WorkflowLister
 	cronWfInformer       informers.GenericInformer
@@ -68,11 +73,13 @@ func init() {
 	log.WithField("cronSyncPeriod", cronSyncPeriod).Info("cron config")
 }
 
-func NewCronController(wfclientset versioned.Interface, dynamicInterface dynamic.Interface, namespace string, managedNamespace string, instanceId string, metrics *metrics.Metrics, eventRecorderManager events.EventRecorderManager) *Controller {
+func NewCronController(kubeclientset kubernetes.Interface, wfclientset versioned.Interface, dynamicInterface dynamic.Interface, namespace string, managedNamespace string, managedNamespaces *namespaces.Selector, instanceId string, metrics *metrics.Metrics, eventRecorderManager events.EventRecorderManager) *Controller {
 	return &Controller{
+		kubeClient:           kubeclientset,
 		wfClientset:          wfclientset,
 		namespace:            namespace,
 		managedNamespace:     managedNamespace,
+		managedNamespaces:    managedNamespaces,
 		instanceId:           instanceId,
 		cron:                 newCronFacade(),
 		keyLock:              sync.NewKeyLock(),
@@ -95,6 +102,7 @@ func (cc *Controller) Run(ctx context.Context) {
 		cronWfInformerListOptionsFunc(options, cc.instanceId)
 	}).ForResource(schema.GroupVersionResource{Group: workflow.Group, Version: workflow.Version, Resource: workflow.CronWorkflowPlural})
 	cc.addCronWorkflowInformerHandler()
+	cc.managedNamespaces.OnMatch(cc.enqueueNamespace)
 
 	wfInformer := util.NewWorkflowInformer(cc.dynamicInterface, cc.managedNamespace, cronWorkflowResyncPeriod, func(options *v1.ListOptions) {
 		wfInformerListOptionsFunc(options, cc.instanceId)
@@ -154,6 +162,11 @@ func (cc *Controller) processNextCronItem(ctx context.Context) bool {
 		logCtx.Errorf("malformed cluster workflow template: expected *unstructured.Unstructured, got %s", reflect.TypeOf(obj).Name())
 		return true
 	}
+	if !cc.managedNamespaces.Matches(un.GetNamespace()) {
+		logCtx.Info("Not scheduling as its namespace is not managed")
+		cc.cron.Delete(key.(string))
+		return true
+	}
 	cronWf := &v1alpha1.CronWorkflow{}
 	err = util.FromUnstructuredObj(un, cronWf)
 	if err != nil {
@@ -162,7 +175,7 @@ func (cc *Controller) processNextCronItem(ctx context.Context) bool {
 		return true
 	}
 
-	cronWorkflowOperationCtx := newCronWfOperationCtx(cronWf, cc.wfClientset, cc.metrics)
+	cronWorkflowOperationCtx := newCronWfOperationCtx(cronWf, cc.kubeClient, cc.wfClientset, cc.metrics)
 
 	err = cronWorkflowOperationCtx.validateCronWorkflow()
 	if err != nil {
@@ -182,13 +195,13 @@ func (cc *Controller) processNextCronItem(ctx context.Context) bool {
 	// The job is currently scheduled, remove it and re add it.
 	cc.cron.Delete(key.(string))
 
-	lastScheduledTimeFunc, err := cc.cron.AddJob(key.(string), cronWf.Spec.GetScheduleString(), cronWorkflowOperationCtx)
+	schedules, err := cronutil.ParseSchedules(cronWf)
 	if err != nil {
 		logCtx.WithError(err).Error("could not schedule CronWorkflow")
 		return true
 	}
 
-	cronWorkflowOperationCtx.scheduledTimeFunc = lastScheduledTimeFunc
+	cronWorkflowOperationCtx.scheduledTimeFunc = cc.cron.AddJob(key.(string), schedules, cronWorkflowOperationCtx)
 
 	logCtx.Infof("CronWorkflow %s added", key.(string))
 
@@ -218,6 +231,16 @@ func (cc *Controller) addCronWorkflowInformerHandler() {
 	})
 }
 
+// enqueueNamespace enqueues the cron workflows of a namespace that starts to be managed
+func (cc *Controller) enqueueNamespace(namespace string) {
+	_ = cache.ListAllByNamespace(cc.cronWfInformer.Informer().GetIndexer(), namespace, labels.Everything(), func(obj interface{}) {
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err == nil {
+			cc.cronWfQueue.Add(key)
+		}
+	})
+}
+
 func (cc *Controller) syncAll(ctx context.Context) {
 	defer runtimeutil.HandleCrash(runtimeutil.PanicHandlers...)
 
@@ -236,6 +259,9 @@ func (cc *Controller) syncAll(ctx context.Context) {
 			log.Error("Unable to convert object to unstructured when syncing CronWorkflows")
 			continue
 		}
+		if !cc.managedNamespaces.Matches(un.GetNamespace()) {
+			continue
+		}
 		cronWf := &v1alpha1.CronWorkflow{}
 		err := util.FromUnstructuredObj(un, cronWf)
 		if err != nil {
@@ -256,7 +282,7 @@ func (cc *Controller) syncCronWorkflow(ctx context.Context, cronWf *v1alpha1.Cro
 	cc.keyLock.Lock(key)
 	defer cc.keyLock.Unlock(key)
 
-	cwoc := newCronWfOperationCtx(cronWf, cc.wfClientset, cc.metrics)
+	cwoc := newCronWfOperationCtx(cronWf, cc.kubeClient, cc.wfClientset, cc.metrics)
 	err := cwoc.enforceHistoryLimit(ctx, workflows)
 	if err != nil {
 		return err
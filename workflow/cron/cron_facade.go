@@ -14,7 +14,7 @@ import (
 type cronFacade struct {
 	mu       sync.Mutex
 	cron     *cron.Cron
-	entryIDs map[string]cron.EntryID
+	entryIDs map[string][]cron.EntryID
 }
 
 type ScheduledTimeFunc func() time.Time
@@ -22,7 +22,7 @@ type ScheduledTimeFunc func() time.Time
 func newCronFacade() *cronFacade {
 	return &cronFacade{
 		cron:     cron.New(),
-		entryIDs: make(map[string]cron.EntryID),
+		entryIDs: make(map[string][]cron.EntryID),
 	}
 }
 
@@ -37,37 +37,42 @@ func (f *cronFacade) Stop() {
 func (f *cronFacade) Delete(key string) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	entryID, ok := f.entryIDs[key]
-	if !ok {
-		return
+	for _, entryID := range f.entryIDs[key] {
+		f.cron.Remove(entryID)
 	}
-	f.cron.Remove(entryID)
 	delete(f.entryIDs, key)
 }
 
-func (f *cronFacade) AddJob(key, schedule string, cwoc *cronWfOperationCtx) (ScheduledTimeFunc, error) {
+// AddJob adds an entry for each schedule, all of which run the same job
+func (f *cronFacade) AddJob(key string, schedules []cron.Schedule, cwoc *cronWfOperationCtx) ScheduledTimeFunc {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	entryID, err := f.cron.AddJob(schedule, cwoc)
-	if err != nil {
-		return nil, err
+	var entryIDs []cron.EntryID
+	for _, schedule := range schedules {
+		entryIDs = append(entryIDs, f.cron.Schedule(schedule, cwoc))
 	}
-	f.entryIDs[key] = entryID
+	f.entryIDs[key] = entryIDs
 
-	// Return a function to return the last scheduled time
+	// Return a function to return the last scheduled time, i.e. that of the entry that ran most recently
 	return func() time.Time {
-		return f.cron.Entry(entryID).Prev
-	}, nil
+		var prev time.Time
+		for _, entryID := range entryIDs {
+			if t := f.cron.Entry(entryID).Prev; t.After(prev) {
+				prev = t
+			}
+		}
+		return prev
+	}
 }
 
 func (f *cronFacade) Load(key string) (*cronWfOperationCtx, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	entryID, ok := f.entryIDs[key]
-	if !ok {
+	entryIDs, ok := f.entryIDs[key]
+	if !ok || len(entryIDs) == 0 {
 		return nil, fmt.Errorf("entry ID for %s not found", key)
 	}
-	entry := f.cron.Entry(entryID).Job
+	entry := f.cron.Entry(entryIDs[0]).Job
 	cwoc, ok := entry.(*cronWfOperationCtx)
 	if !ok {
 		return nil, fmt.Errorf("job entry ID for %s was not a *cronWfOperationCtx, was %v", key, reflect.TypeOf(entry))
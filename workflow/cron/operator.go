@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -13,12 +15,16 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	typed "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
+	cronutil "github.com/argoproj/argo-workflows/v3/util/cron"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/template"
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
@@ -31,6 +37,7 @@ type cronWfOperationCtx struct {
 	// CronWorkflow is the CronWorkflow to be run
 	name        string
 	cronWf      *v1alpha1.CronWorkflow
+	kubeClient  kubernetes.Interface
 	wfClientset versioned.Interface
 	wfClient    typed.WorkflowInterface
 	cronWfIf    typed.CronWorkflowInterface
@@ -40,14 +47,15 @@ type cronWfOperationCtx struct {
 	scheduledTimeFunc ScheduledTimeFunc
 }
 
-func newCronWfOperationCtx(cronWorkflow *v1alpha1.CronWorkflow, wfClientset versioned.Interface, metrics *metrics.Metrics) *cronWfOperationCtx {
+func newCronWfOperationCtx(cronWorkflow *v1alpha1.CronWorkflow, kubeClient kubernetes.Interface, wfClientset versioned.Interface, metrics *metrics.Metrics) *cronWfOperationCtx {
 	return &cronWfOperationCtx{
 		name:        cronWorkflow.ObjectMeta.Name,
 		cronWf:      cronWorkflow,
+		kubeClient:  kubeClient,
 		wfClientset: wfClientset,
 		wfClient:    wfClientset.ArgoprojV1alpha1().Workflows(cronWorkflow.Namespace),
 		cronWfIf:    wfClientset.ArgoprojV1alpha1().CronWorkflows(cronWorkflow.Namespace),
-		log: log.WithFields(log.Fields{
+		log: logging.Component("cron").WithFields(log.Fields{
 			"workflow":  cronWorkflow.ObjectMeta.Name,
 			"namespace": cronWorkflow.ObjectMeta.Namespace,
 		}),
@@ -91,7 +99,21 @@ func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Ti
 		return
 	}
 
+	excluded, err := woc.isExcluded(ctx, scheduledRuntime)
+	if err != nil {
+		woc.reportCronWorkflowError(v1alpha1.ConditionTypeSubmissionError, fmt.Sprintf("Exclusion error: %s", err))
+		return
+	} else if excluded {
+		woc.log.Infof("%s is excluded at %s, skipping execution", woc.name, scheduledRuntime)
+		return
+	}
+
 	wf := common.ConvertCronWorkflowToWorkflowWithProperties(woc.cronWf, getChildWorkflowName(woc.cronWf.Name, scheduledRuntime), scheduledRuntime)
+	err = woc.templateArguments(wf, scheduledRuntime)
+	if err != nil {
+		woc.reportCronWorkflowError(v1alpha1.ConditionTypeSubmissionError, fmt.Sprintf("Failed to template arguments: %s", err))
+		return
+	}
 
 	runWf, err := util.SubmitWorkflow(ctx, woc.wfClient, woc.wfClientset, woc.cronWf.Namespace, wf, &v1alpha1.SubmitOpts{})
 	if err != nil {
@@ -110,7 +132,7 @@ func (woc *cronWfOperationCtx) run(ctx context.Context, scheduledRuntime time.Ti
 
 func (woc *cronWfOperationCtx) validateCronWorkflow() error {
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(woc.wfClientset.ArgoprojV1alpha1().WorkflowTemplates(woc.cronWf.Namespace))
-	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(woc.wfClientset.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WrapClusterWorkflowTemplateInterface(woc.wfClientset.ArgoprojV1alpha1().ClusterWorkflowTemplates()), woc.cronWf.Namespace)
 	err := validate.ValidateCronWorkflow(wftmplGetter, cwftmplGetter, woc.cronWf)
 	if err != nil {
 		woc.reportCronWorkflowError(v1alpha1.ConditionTypeSpecError, fmt.Sprint(err))
@@ -176,6 +198,19 @@ func (woc *cronWfOperationCtx) enforceRuntimePolicy(ctx context.Context) (bool,
 				woc.log.Infof("%s has 'ConcurrencyPolicy: Forbid' and has an active Workflow so it was not run", woc.name)
 				return false, nil
 			}
+		case v1alpha1.ForbidIfPreviousFailedConcurrent:
+			if len(woc.cronWf.Status.Active) > 0 {
+				woc.log.Infof("%s has 'ConcurrencyPolicy: ForbidIfPreviousFailed' and has an active Workflow so it was not run", woc.name)
+				return false, nil
+			}
+			previousWf, err := woc.getPreviousWorkflow(ctx)
+			if err != nil {
+				return false, err
+			}
+			if previousWf != nil && previousWf.Status.Fulfilled() && !previousWf.Status.Successful() {
+				woc.log.Infof("%s has 'ConcurrencyPolicy: ForbidIfPreviousFailed' and its previous Workflow '%s' did not succeed so it was not run, retry, resubmit or delete it to resume", woc.name, previousWf.Name)
+				return false, nil
+			}
 		case v1alpha1.ReplaceConcurrent:
 			if len(woc.cronWf.Status.Active) > 0 {
 				woc.log.Infof("%s has 'ConcurrencyPolicy: Replace' and has active Workflows", woc.name)
@@ -191,6 +226,148 @@ func (woc *cronWfOperationCtx) enforceRuntimePolicy(ctx context.Context) (bool,
 	return true, nil
 }
 
+// getPreviousWorkflow returns the most recently created Workflow of the CronWorkflow, or nil if there are none
+func (woc *cronWfOperationCtx) getPreviousWorkflow(ctx context.Context) (*v1alpha1.Workflow, error) {
+	wfList, err := woc.wfClient.List(ctx, v1.ListOptions{LabelSelector: common.LabelKeyCronWorkflow + "=" + woc.cronWf.Name})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Workflows of CronWorkflow '%s': %w", woc.cronWf.Name, err)
+	}
+	var previousWf *v1alpha1.Workflow
+	for i, wf := range wfList.Items {
+		if previousWf == nil || previousWf.CreationTimestamp.Before(&wf.CreationTimestamp) || (previousWf.CreationTimestamp.Equal(&wf.CreationTimestamp) && previousWf.Name < wf.Name) {
+			previousWf = &wfList.Items[i]
+		}
+	}
+	return previousWf, nil
+}
+
+// templateArguments replaces the variables describing the run, e.g. "{{cronworkflow.scheduledTime}}", in the workflow's
+// arguments, so that each run can be parameterized by when it was scheduled
+func (woc *cronWfOperationCtx) templateArguments(wf *v1alpha1.Workflow, scheduledTime time.Time) error {
+	loc, err := woc.getLocation()
+	if err != nil {
+		return err
+	}
+	scheduleIndex, err := woc.getScheduleIndex(scheduledTime)
+	if err != nil {
+		return err
+	}
+	replaceMap := map[string]string{
+		common.CronWorkflowVarName:              woc.cronWf.Name,
+		common.CronWorkflowVarNamespace:         woc.cronWf.Namespace,
+		common.CronWorkflowVarScheduledTime:     scheduledTime.In(loc).Format(time.RFC3339),
+		common.CronWorkflowVarLastScheduledTime: "",
+		common.CronWorkflowVarScheduleIndex:     strconv.Itoa(scheduleIndex),
+		common.CronWorkflowVarSchedule:          woc.cronWf.Spec.GetSchedules()[scheduleIndex],
+	}
+	if woc.cronWf.Status.LastScheduledTime != nil {
+		replaceMap[common.CronWorkflowVarLastScheduledTime] = woc.cronWf.Status.LastScheduledTime.In(loc).Format(time.RFC3339)
+	}
+	data, err := json.Marshal(wf.Spec.Arguments)
+	if err != nil {
+		return err
+	}
+	// other variables, e.g. "{{workflow.name}}", are left for the workflow controller to replace
+	replaced, err := template.Replace(string(data), replaceMap, true)
+	if err != nil {
+		return err
+	}
+	// unmarshal into new arguments, as the workflow's share their parameters with the CronWorkflow's
+	arguments := v1alpha1.Arguments{}
+	if err := json.Unmarshal([]byte(replaced), &arguments); err != nil {
+		return err
+	}
+	wf.Spec.Arguments = arguments
+	return nil
+}
+
+// getScheduleIndex returns the index of the first of the CronWorkflow's schedules that is due at the scheduled time, or
+// zero if none are, e.g. if the time was inferred
+func (woc *cronWfOperationCtx) getScheduleIndex(scheduledTime time.Time) (int, error) {
+	schedules, err := cronutil.ParseSchedules(woc.cronWf)
+	if err != nil {
+		return 0, err
+	}
+	for i, schedule := range schedules {
+		if schedule.Next(scheduledTime.Add(-time.Second)).Equal(scheduledTime) {
+			return i, nil
+		}
+	}
+	return 0, nil
+}
+
+// getLocation returns the CronWorkflow's timezone, or the machine's if it does not have one
+func (woc *cronWfOperationCtx) getLocation() (*time.Location, error) {
+	if woc.cronWf.Spec.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(woc.cronWf.Spec.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone '%s': %s", woc.cronWf.Spec.Timezone, err)
+	}
+	return loc, nil
+}
+
+// isExcluded returns true if the scheduled time is within any of the CronWorkflow's exclusion windows
+func (woc *cronWfOperationCtx) isExcluded(ctx context.Context, scheduledTime time.Time) (bool, error) {
+	loc, err := woc.getLocation()
+	if err != nil {
+		return false, err
+	}
+	scheduledTime = scheduledTime.In(loc)
+	for _, exclusion := range woc.cronWf.Spec.Exclusions {
+		if exclusion.Schedule != "" {
+			schedule, err := cron.ParseStandard(woc.cronWf.Spec.WithTimezone(exclusion.Schedule))
+			if err != nil {
+				return false, fmt.Errorf("malformed exclusion schedule '%s': %s", exclusion.Schedule, err)
+			}
+			duration, err := time.ParseDuration(exclusion.Duration)
+			if err != nil {
+				return false, fmt.Errorf("malformed exclusion duration '%s': %s", exclusion.Duration, err)
+			}
+			// the window that could contain the scheduled time is the first one to start after the scheduled time, less the duration
+			if start := schedule.Next(scheduledTime.Add(-duration)); !start.After(scheduledTime) {
+				return true, nil
+			}
+		}
+		if exclusion.Calendar != nil {
+			dates, err := woc.getCalendar(ctx, exclusion.Calendar)
+			if err != nil {
+				return false, err
+			}
+			if dates[scheduledTime.Format("2006-01-02")] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// getCalendar returns the dates listed in a config map key, one per line, ignoring blank lines and comments
+func (woc *cronWfOperationCtx) getCalendar(ctx context.Context, selector *corev1.ConfigMapKeySelector) (map[string]bool, error) {
+	cm, err := woc.kubeClient.CoreV1().ConfigMaps(woc.cronWf.Namespace).Get(ctx, selector.Name, v1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exclusion calendar '%s': %w", selector.Name, err)
+	}
+	data, ok := cm.Data[selector.Key]
+	if !ok {
+		return nil, fmt.Errorf("exclusion calendar '%s' does not have key '%s'", selector.Name, selector.Key)
+	}
+	dates := make(map[string]bool)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", line)
+		if err != nil {
+			return nil, fmt.Errorf("exclusion calendar '%s' has malformed date '%s'", selector.Name, line)
+		}
+		dates[date.Format("2006-01-02")] = true
+	}
+	return dates, nil
+}
+
 func (woc *cronWfOperationCtx) terminateOutstandingWorkflows(ctx context.Context) error {
 	for _, wfObjectRef := range woc.cronWf.Status.Active {
 		woc.log.Infof("stopping '%s'", wfObjectRef.Name)
@@ -207,6 +384,18 @@ func (woc *cronWfOperationCtx) terminateOutstandingWorkflows(ctx context.Context
 }
 
 func (woc *cronWfOperationCtx) runOutstandingWorkflows(ctx context.Context) (bool, error) {
+	if woc.cronWf.Spec.Catchup.IsEnabled() {
+		missedExecutionTimes, err := woc.getCatchupTimes()
+		if err != nil {
+			return false, err
+		}
+		lastScheduledTime := woc.cronWf.Status.LastScheduledTime
+		for _, missedExecutionTime := range missedExecutionTimes {
+			woc.run(ctx, missedExecutionTime)
+		}
+		// runs may have been skipped, e.g. by the concurrency policy, in which case the CronWorkflow is not requeued
+		return !woc.cronWf.Status.LastScheduledTime.Equal(lastScheduledTime), nil
+	}
 	missedExecutionTime, err := woc.shouldOutstandingWorkflowsBeRun()
 	if err != nil {
 		return false, err
@@ -218,54 +407,86 @@ func (woc *cronWfOperationCtx) runOutstandingWorkflows(ctx context.Context) (boo
 	return false, nil
 }
 
+// getCatchupTimes returns the missed execution times to catch up on, oldest first, limited to the most recent ones
+func (woc *cronWfOperationCtx) getCatchupTimes() ([]time.Time, error) {
+	now := time.Now()
+	missedExecutionTimes, err := woc.getMissedExecutionTimes(now)
+	if err != nil {
+		return nil, err
+	}
+	var catchupTimes []time.Time
+	for _, missedExecutionTime := range missedExecutionTimes {
+		if woc.cronWf.Spec.StartingDeadlineSeconds == nil || now.Before(missedExecutionTime.Add(time.Duration(*woc.cronWf.Spec.StartingDeadlineSeconds)*time.Second)) {
+			catchupTimes = append(catchupTimes, missedExecutionTime)
+		}
+	}
+	if limit := woc.cronWf.Spec.Catchup.GetLimit(); len(catchupTimes) > limit {
+		woc.log.Infof("%s missed %d executions, only catching up on the most recent %d", woc.cronWf.Name, len(catchupTimes), limit)
+		catchupTimes = catchupTimes[len(catchupTimes)-limit:]
+	}
+	if len(catchupTimes) > 0 {
+		woc.log.Infof("%s is catching up on %d missed executions", woc.cronWf.Name, len(catchupTimes))
+	}
+	return catchupTimes, nil
+}
+
 func (woc *cronWfOperationCtx) shouldOutstandingWorkflowsBeRun() (time.Time, error) {
+	var now time.Time
+	if woc.cronWf.Spec.Timezone != "" {
+		loc, err := time.LoadLocation(woc.cronWf.Spec.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone '%s': %s", woc.cronWf.Spec.Timezone, err)
+		}
+		now = time.Now().In(loc)
+	} else {
+		now = time.Now()
+	}
+	missedExecutionTimes, err := woc.getMissedExecutionTimes(now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// We missed the latest execution time
+	if len(missedExecutionTimes) > 0 {
+		missedExecutionTime := missedExecutionTimes[len(missedExecutionTimes)-1]
+		// if missedExecutionTime is within StartDeadlineSeconds, We are still within the deadline window, run the Workflow
+		if woc.cronWf.Spec.StartingDeadlineSeconds != nil && now.Before(missedExecutionTime.Add(time.Duration(*woc.cronWf.Spec.StartingDeadlineSeconds)*time.Second)) {
+			woc.log.Infof("%s missed an execution at %s and is within StartingDeadline", woc.cronWf.Name, missedExecutionTime.Format("Mon Jan _2 15:04:05 2006"))
+			return missedExecutionTime, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+// getMissedExecutionTimes returns the times the CronWorkflow was scheduled after its last scheduled time and before
+// now, oldest first
+func (woc *cronWfOperationCtx) getMissedExecutionTimes(now time.Time) ([]time.Time, error) {
 	// If the CronWorkflow schedule was just updated, then do not run any outstanding workflows.
 	if woc.cronWf.IsUsingNewSchedule() {
-		return time.Time{}, nil
+		return nil, nil
 	}
 	// If this CronWorkflow has been run before, check if we have missed any scheduled executions
-	if woc.cronWf.Status.LastScheduledTime != nil {
-		var now time.Time
-		var cronSchedule cron.Schedule
-		if woc.cronWf.Spec.Timezone != "" {
-			loc, err := time.LoadLocation(woc.cronWf.Spec.Timezone)
-			if err != nil {
-				return time.Time{}, fmt.Errorf("invalid timezone '%s': %s", woc.cronWf.Spec.Timezone, err)
-			}
-			now = time.Now().In(loc)
-
-			cronScheduleString := woc.cronWf.Spec.GetScheduleString()
-			cronSchedule, err = cron.ParseStandard(cronScheduleString)
-			if err != nil {
-				return time.Time{}, fmt.Errorf("unable to form timezone schedule '%s': %s", cronScheduleString, err)
-			}
-		} else {
-			var err error
-			now = time.Now()
-			cronSchedule, err = cron.ParseStandard(woc.cronWf.Spec.Schedule)
-			if err != nil {
-				return time.Time{}, err
-			}
-		}
-
-		var missedExecutionTime time.Time
+	if woc.cronWf.Status.LastScheduledTime == nil {
+		return nil, nil
+	}
+	cronSchedules, err := cronutil.ParseSchedules(woc.cronWf)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[int64]bool)
+	var missedExecutionTimes []time.Time
+	for _, cronSchedule := range cronSchedules {
 		nextScheduledRunTime := cronSchedule.Next(woc.cronWf.Status.LastScheduledTime.Time)
 		// Workflow should have ran
-		for nextScheduledRunTime.Before(now) {
-			missedExecutionTime = nextScheduledRunTime
-			nextScheduledRunTime = cronSchedule.Next(missedExecutionTime)
-		}
-
-		// We missed the latest execution time
-		if !missedExecutionTime.IsZero() {
-			// if missedExecutionTime is within StartDeadlineSeconds, We are still within the deadline window, run the Workflow
-			if woc.cronWf.Spec.StartingDeadlineSeconds != nil && now.Before(missedExecutionTime.Add(time.Duration(*woc.cronWf.Spec.StartingDeadlineSeconds)*time.Second)) {
-				woc.log.Infof("%s missed an execution at %s and is within StartingDeadline", woc.cronWf.Name, missedExecutionTime.Format("Mon Jan _2 15:04:05 2006"))
-				return missedExecutionTime, nil
+		for !nextScheduledRunTime.IsZero() && nextScheduledRunTime.Before(now) {
+			if !seen[nextScheduledRunTime.Unix()] {
+				seen[nextScheduledRunTime.Unix()] = true
+				missedExecutionTimes = append(missedExecutionTimes, nextScheduledRunTime)
 			}
+			nextScheduledRunTime = cronSchedule.Next(nextScheduledRunTime)
 		}
 	}
-	return time.Time{}, nil
+	sort.Slice(missedExecutionTimes, func(i, j int) bool { return missedExecutionTimes[i].Before(missedExecutionTimes[j]) })
+	return missedExecutionTimes, nil
 }
 
 func (woc *cronWfOperationCtx) reconcileActiveWfs(ctx context.Context, workflows []v1alpha1.Workflow) error {
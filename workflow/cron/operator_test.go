@@ -8,10 +8,13 @@ import (
 	"github.com/argoproj/pkg/humanize"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	cronutil "github.com/argoproj/argo-workflows/v3/util/cron"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
@@ -396,3 +399,245 @@ func TestMissedScheduleAfterCronScheduleWithForbid(t *testing.T) {
 		assert.True(t, missedExecutionTime.IsZero())
 	})
 }
+
+func TestIsExcluded(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "holidays", Namespace: "argo"},
+		Data:       map[string]string{"dates": "# christmas\n2022-12-25\n\n2022-12-26\n", "malformed": "christmas"},
+	})
+	newWoc := func(exclusions ...v1alpha1.CronExclusion) *cronWfOperationCtx {
+		return &cronWfOperationCtx{
+			kubeClient: kubeClient,
+			cronWf: &v1alpha1.CronWorkflow{
+				ObjectMeta: v1.ObjectMeta{Name: "my-cwf", Namespace: "argo"},
+				Spec:       v1alpha1.CronWorkflowSpec{Schedule: "0 * * * *", Timezone: "America/Los_Angeles", Exclusions: exclusions},
+			},
+			log: logrus.WithFields(logrus.Fields{}),
+		}
+	}
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx := context.Background()
+	t.Run("Window", func(t *testing.T) {
+		// Sundays 2am to 4am
+		woc := newWoc(v1alpha1.CronExclusion{Schedule: "0 2 * * 0", Duration: "2h"})
+		for scheduledTime, excluded := range map[time.Time]bool{
+			time.Date(2022, 10, 2, 1, 0, 0, 0, loc): false,
+			time.Date(2022, 10, 2, 2, 0, 0, 0, loc): true,
+			time.Date(2022, 10, 2, 3, 0, 0, 0, loc): true,
+			time.Date(2022, 10, 2, 4, 0, 0, 0, loc): false,
+			time.Date(2022, 10, 3, 3, 0, 0, 0, loc): false,
+			// the same time in UTC
+			time.Date(2022, 10, 2, 10, 0, 0, 0, time.UTC): true,
+		} {
+			actual, err := woc.isExcluded(ctx, scheduledTime)
+			if assert.NoError(t, err) {
+				assert.Equal(t, excluded, actual, scheduledTime.String())
+			}
+		}
+	})
+	t.Run("Calendar", func(t *testing.T) {
+		woc := newWoc(v1alpha1.CronExclusion{Calendar: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "holidays"}, Key: "dates"}})
+		excluded, err := woc.isExcluded(ctx, time.Date(2022, 12, 25, 23, 0, 0, 0, loc))
+		if assert.NoError(t, err) {
+			assert.True(t, excluded)
+		}
+		// this is Christmas day in UTC, but not in Los Angeles
+		excluded, err = woc.isExcluded(ctx, time.Date(2022, 12, 25, 1, 0, 0, 0, time.UTC))
+		if assert.NoError(t, err) {
+			assert.False(t, excluded)
+		}
+	})
+	t.Run("MalformedCalendar", func(t *testing.T) {
+		woc := newWoc(v1alpha1.CronExclusion{Calendar: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "holidays"}, Key: "malformed"}})
+		_, err := woc.isExcluded(ctx, time.Now())
+		assert.EqualError(t, err, "exclusion calendar 'holidays' has malformed date 'christmas'")
+	})
+	t.Run("MissingCalendar", func(t *testing.T) {
+		woc := newWoc(v1alpha1.CronExclusion{Calendar: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "not-found"}, Key: "dates"}})
+		_, err := woc.isExcluded(ctx, time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestRunExcluded(t *testing.T) {
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(scheduledWf), &cronWf)
+	cronWf.Spec.Exclusions = []v1alpha1.CronExclusion{{Schedule: "* * * * *", Duration: "1m"}}
+	cs := fake.NewSimpleClientset(&cronWf)
+	woc := &cronWfOperationCtx{
+		wfClientset: cs,
+		wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+		cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:      &cronWf,
+		log:         logrus.WithFields(logrus.Fields{}),
+		metrics:     metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{}),
+	}
+	woc.run(context.Background(), inferScheduledTime())
+	wfs, err := cs.ArgoprojV1alpha1().Workflows("argo").List(context.Background(), v1.ListOptions{})
+	if assert.NoError(t, err) {
+		assert.Empty(t, wfs.Items)
+	}
+}
+
+func TestShouldOutstandingWorkflowsBeRunMultipleSchedules(t *testing.T) {
+	startingDeadlineSeconds := int64(3600)
+	cronWf := &v1alpha1.CronWorkflow{
+		Spec: v1alpha1.CronWorkflowSpec{
+			// one of these will never be due, the other was due at most one minute ago
+			Schedules:               []string{"0 0 1 1 *", "* * * * *"},
+			StartingDeadlineSeconds: &startingDeadlineSeconds,
+		},
+		Status: v1alpha1.CronWorkflowStatus{LastScheduledTime: &v1.Time{Time: time.Now().Add(-10 * time.Minute)}},
+	}
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleString())
+	woc := &cronWfOperationCtx{cronWf: cronWf, log: logrus.WithFields(logrus.Fields{})}
+	missedExecutionTime, err := woc.shouldOutstandingWorkflowsBeRun()
+	if assert.NoError(t, err) {
+		assert.WithinDuration(t, time.Now(), missedExecutionTime, time.Minute)
+	}
+}
+
+func TestShouldOutstandingWorkflowsBeRunJitter(t *testing.T) {
+	startingDeadlineSeconds := int64(3600)
+	cronWf := &v1alpha1.CronWorkflow{
+		ObjectMeta: v1.ObjectMeta{Namespace: "my-ns", Name: "my-cwf"},
+		Spec: v1alpha1.CronWorkflowSpec{
+			Schedule:                "*/10 * * * *",
+			ScheduleJitter:          "10m",
+			StartingDeadlineSeconds: &startingDeadlineSeconds,
+		},
+		Status: v1alpha1.CronWorkflowStatus{LastScheduledTime: &v1.Time{Time: time.Now().Add(-30 * time.Minute)}},
+	}
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleString())
+	jitter, err := cronutil.Jitter(cronWf)
+	assert.NoError(t, err)
+	woc := &cronWfOperationCtx{cronWf: cronWf, log: logrus.WithFields(logrus.Fields{})}
+	missedExecutionTime, err := woc.shouldOutstandingWorkflowsBeRun()
+	if assert.NoError(t, err) {
+		// the missed execution is the jittered time, not the time of the schedule
+		assert.Equal(t, jitter, missedExecutionTime.Sub(missedExecutionTime.Truncate(10*time.Minute)))
+	}
+}
+
+func TestTemplateArguments(t *testing.T) {
+	cronWf := &v1alpha1.CronWorkflow{
+		ObjectMeta: v1.ObjectMeta{Namespace: "my-ns", Name: "my-cwf"},
+		Spec: v1alpha1.CronWorkflowSpec{
+			Schedule:  "0 2 * * *",
+			Schedules: []string{"30 * * * *"},
+			Timezone:  "America/Los_Angeles",
+			WorkflowSpec: v1alpha1.WorkflowSpec{Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{
+				{Name: "name", Value: v1alpha1.AnyStringPtr("{{cronworkflow.namespace}}/{{cronworkflow.name}}")},
+				{Name: "scheduled-time", Value: v1alpha1.AnyStringPtr("{{cronworkflow.scheduledTime}}")},
+				{Name: "last-scheduled-time", Value: v1alpha1.AnyStringPtr("{{cronworkflow.lastScheduledTime}}")},
+				{Name: "schedule", Value: v1alpha1.AnyStringPtr("{{cronworkflow.scheduleIndex}}: {{cronworkflow.schedule}}")},
+				{Name: "partition", Value: v1alpha1.AnyStringPtr("{{=sprig.dateInZone('2006-01-02', sprig.toDate('2006-01-02T15:04:05Z07:00', cronworkflow.scheduledTime), 'UTC')}}")},
+				{Name: "workflow", Value: v1alpha1.AnyStringPtr("{{workflow.name}}")},
+			}}},
+		},
+		Status: v1alpha1.CronWorkflowStatus{LastScheduledTime: &v1.Time{Time: time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)}},
+	}
+	woc := &cronWfOperationCtx{cronWf: cronWf, log: logrus.WithFields(logrus.Fields{})}
+	scheduledTime := time.Date(2022, 1, 1, 10, 30, 0, 0, time.UTC)
+	wf := common.ConvertCronWorkflowToWorkflowWithProperties(cronWf, "my-wf", scheduledTime)
+	if assert.NoError(t, woc.templateArguments(wf, scheduledTime)) {
+		parameters := wf.Spec.Arguments.Parameters
+		assert.Equal(t, "my-ns/my-cwf", parameters[0].Value.String())
+		assert.Equal(t, "2022-01-01T02:30:00-08:00", parameters[1].Value.String())
+		assert.Equal(t, "2022-01-01T02:00:00-08:00", parameters[2].Value.String())
+		assert.Equal(t, "1: 30 * * * *", parameters[3].Value.String())
+		assert.Equal(t, "2022-01-01", parameters[4].Value.String())
+		assert.Equal(t, "{{workflow.name}}", parameters[5].Value.String())
+	}
+	// the CronWorkflow's own spec is not changed
+	assert.Equal(t, "{{cronworkflow.scheduledTime}}", cronWf.Spec.WorkflowSpec.Arguments.Parameters[1].Value.String())
+}
+
+func TestRunForbidIfPreviousFailed(t *testing.T) {
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(scheduledWf), &cronWf)
+	cronWf.Spec.ConcurrencyPolicy = v1alpha1.ForbidIfPreviousFailedConcurrent
+	newWf := func(name string, creationTimestamp time.Time, phase v1alpha1.WorkflowPhase) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{
+			ObjectMeta: v1.ObjectMeta{Name: name, Namespace: "argo", CreationTimestamp: v1.Time{Time: creationTimestamp}, Labels: map[string]string{common.LabelKeyCronWorkflow: cronWf.Name}},
+			Status:     v1alpha1.WorkflowStatus{Phase: phase},
+		}
+	}
+	run := func(t *testing.T, wfs ...*v1alpha1.Workflow) int {
+		cs := fake.NewSimpleClientset(cronWf.DeepCopy())
+		for _, wf := range wfs {
+			_, err := cs.ArgoprojV1alpha1().Workflows("argo").Create(context.Background(), wf, v1.CreateOptions{})
+			assert.NoError(t, err)
+		}
+		woc := &cronWfOperationCtx{
+			wfClientset: cs,
+			wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+			cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+			cronWf:      cronWf.DeepCopy(),
+			log:         logrus.WithFields(logrus.Fields{}),
+			metrics:     metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{}),
+		}
+		woc.run(context.Background(), inferScheduledTime())
+		list, err := cs.ArgoprojV1alpha1().Workflows("argo").List(context.Background(), v1.ListOptions{})
+		assert.NoError(t, err)
+		return len(list.Items) - len(wfs)
+	}
+	now := time.Now()
+	t.Run("NoPreviousWorkflow", func(t *testing.T) {
+		assert.Equal(t, 1, run(t))
+	})
+	t.Run("PreviousSucceeded", func(t *testing.T) {
+		assert.Equal(t, 1, run(t, newWf("a", now.Add(-2*time.Hour), v1alpha1.WorkflowFailed), newWf("b", now.Add(-time.Hour), v1alpha1.WorkflowSucceeded)))
+	})
+	t.Run("PreviousFailed", func(t *testing.T) {
+		assert.Equal(t, 0, run(t, newWf("a", now.Add(-2*time.Hour), v1alpha1.WorkflowSucceeded), newWf("b", now.Add(-time.Hour), v1alpha1.WorkflowFailed)))
+	})
+	t.Run("PreviousErrored", func(t *testing.T) {
+		assert.Equal(t, 0, run(t, newWf("a", now.Add(-time.Hour), v1alpha1.WorkflowError)))
+	})
+}
+
+func TestRunOutstandingWorkflowsCatchup(t *testing.T) {
+	var cronWf v1alpha1.CronWorkflow
+	v1alpha1.MustUnmarshal([]byte(scheduledWf), &cronWf)
+	cronWf.Spec.Schedule = "* * * * *"
+	cronWf.Spec.Timezone = ""
+	cronWf.Spec.ConcurrencyPolicy = v1alpha1.AllowConcurrent
+	limit := int32(3)
+	cronWf.Spec.Catchup = &v1alpha1.CronCatchup{Enabled: true, Limit: &limit}
+	cronWf.Spec.StartingDeadlineSeconds = nil
+	lastScheduledTime := time.Now().Truncate(time.Minute).Add(-5 * time.Minute)
+	cronWf.Status.LastScheduledTime = &v1.Time{Time: lastScheduledTime}
+	cronWf.SetSchedule(cronWf.Spec.GetScheduleString())
+	cs := fake.NewSimpleClientset(&cronWf)
+	woc := &cronWfOperationCtx{
+		wfClientset: cs,
+		wfClient:    cs.ArgoprojV1alpha1().Workflows("argo"),
+		cronWfIf:    cs.ArgoprojV1alpha1().CronWorkflows("argo"),
+		cronWf:      &cronWf,
+		log:         logrus.WithFields(logrus.Fields{}),
+		metrics:     metrics.New(metrics.ServerConfig{}, metrics.ServerConfig{}),
+	}
+	wasRun, err := woc.runOutstandingWorkflows(context.Background())
+	if assert.NoError(t, err) {
+		assert.True(t, wasRun)
+	}
+	wfs, err := cs.ArgoprojV1alpha1().Workflows("argo").List(context.Background(), v1.ListOptions{})
+	if assert.NoError(t, err) {
+		var names []string
+		for _, wf := range wfs.Items {
+			names = append(names, wf.Name)
+		}
+		// at least 5 minutes since the last scheduled time are missed, of which the most recent 3 are caught up on
+		latest := woc.cronWf.Status.LastScheduledTime.Time
+		assert.True(t, latest.Sub(lastScheduledTime) >= 5*time.Minute)
+		assert.ElementsMatch(t, []string{
+			getChildWorkflowName(cronWf.Name, latest.Add(-2*time.Minute)),
+			getChildWorkflowName(cronWf.Name, latest.Add(-time.Minute)),
+			getChildWorkflowName(cronWf.Name, latest),
+		}, names)
+	}
+}
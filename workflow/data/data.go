@@ -1,7 +1,11 @@
 package data
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/antonmedv/expr"
 
@@ -20,6 +24,31 @@ func ProcessData(data *wfv1.Data, processor wfv1.DataSourceProcessor) (interface
 	return transformedData, nil
 }
 
+// Validate checks the data template has a single source, and that each transformation step has a single operation
+func Validate(data *wfv1.Data) error {
+	if data.Source.ArtifactPaths != nil && data.Source.Parameter != "" {
+		return fmt.Errorf("source must have only one of artifactPaths or parameter")
+	}
+	for i, step := range data.Transformation {
+		n := 0
+		for _, set := range []bool{step.Expression != "", step.Map != "", step.Flatten, step.Unique, step.Sort != nil, step.Reduce != nil, step.Join != nil} {
+			if set {
+				n++
+			}
+		}
+		if n == 0 {
+			return fmt.Errorf("transformation[%d] must have one of expression, map, flatten, unique, sort, reduce or join", i)
+		}
+		if n > 1 {
+			return fmt.Errorf("transformation[%d] must have only one of expression, map, flatten, unique, sort, reduce or join", i)
+		}
+		if step.Reduce != nil && step.Reduce.Expression == "" {
+			return fmt.Errorf("transformation[%d].reduce.expression is required", i)
+		}
+	}
+	return nil
+}
+
 func processSource(source wfv1.DataSource, processor wfv1.DataSourceProcessor) (interface{}, error) {
 	var data interface{}
 	var err error
@@ -29,6 +58,11 @@ func processSource(source wfv1.DataSource, processor wfv1.DataSourceProcessor) (
 		if err != nil {
 			return nil, fmt.Errorf("unable to source artifact paths: %w", err)
 		}
+	case source.Parameter != "":
+		err = json.Unmarshal([]byte(source.Parameter), &data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to source parameter, it must be valid JSON: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("no valid source is used for data template")
 	}
@@ -46,6 +80,18 @@ func processTransformation(data interface{}, transformation *wfv1.Transformation
 		switch {
 		case step.Expression != "":
 			data, err = processExpression(step.Expression, data)
+		case step.Map != "":
+			data, err = processMap(step.Map, data)
+		case step.Flatten:
+			data, err = processFlatten(data)
+		case step.Unique:
+			data, err = processUnique(data)
+		case step.Sort != nil:
+			data, err = processSort(step.Sort, data)
+		case step.Reduce != nil:
+			data, err = processReduce(step.Reduce, data)
+		case step.Join != nil:
+			data, err = processJoin(step.Join, data)
 		}
 		if err != nil {
 			return nil, fmt.Errorf("error processing data step %d: %w", i, err)
@@ -58,3 +104,187 @@ func processTransformation(data interface{}, transformation *wfv1.Transformation
 func processExpression(expression string, data interface{}) (interface{}, error) {
 	return expr.Eval(expression, map[string]interface{}{"data": data})
 }
+
+func processMap(expression string, data interface{}) (interface{}, error) {
+	items, err := toList(data)
+	if err != nil {
+		return nil, err
+	}
+	program, err := expr.Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, len(items))
+	for i, item := range items {
+		result[i], err = expr.Run(program, map[string]interface{}{"item": item})
+		if err != nil {
+			return nil, fmt.Errorf("unable to map item %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+func processFlatten(data interface{}) (interface{}, error) {
+	items, err := toList(data)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if nested, err := toList(item); err == nil {
+			result = append(result, nested...)
+		} else {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func processUnique(data interface{}) (interface{}, error) {
+	items, err := toList(data)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		key, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[string(key)] {
+			seen[string(key)] = true
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func processSort(s *wfv1.SortTransformation, data interface{}) (interface{}, error) {
+	items, err := toList(data)
+	if err != nil {
+		return nil, err
+	}
+	keys := items
+	if s.Key != "" {
+		mapped, err := processMap(s.Key, items)
+		if err != nil {
+			return nil, err
+		}
+		keys = mapped.([]interface{})
+	}
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	var sortErr error
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := keys[indexes[i]], keys[indexes[j]]
+		if s.Descending {
+			a, b = b, a
+		}
+		less, err := lessThan(a, b)
+		if err != nil && sortErr == nil {
+			sortErr = err
+		}
+		return less
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	result := make([]interface{}, len(items))
+	for i, index := range indexes {
+		result[i] = items[index]
+	}
+	return result, nil
+}
+
+func processReduce(r *wfv1.ReduceTransformation, data interface{}) (interface{}, error) {
+	items, err := toList(data)
+	if err != nil {
+		return nil, err
+	}
+	var acc interface{}
+	if r.Initial != "" {
+		acc, err = expr.Eval(r.Initial, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to evaluate initial value: %w", err)
+		}
+	} else if len(items) > 0 {
+		acc, items = items[0], items[1:]
+	}
+	program, err := expr.Compile(r.Expression)
+	if err != nil {
+		return nil, err
+	}
+	for i, item := range items {
+		acc, err = expr.Run(program, map[string]interface{}{"acc": acc, "item": item})
+		if err != nil {
+			return nil, fmt.Errorf("unable to reduce item %d: %w", i, err)
+		}
+	}
+	return acc, nil
+}
+
+func processJoin(j *wfv1.JoinTransformation, data interface{}) (interface{}, error) {
+	items, err := toList(data)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(items))
+	for i, item := range items {
+		if s, ok := item.(string); ok {
+			values[i] = s
+			continue
+		}
+		value, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = string(value)
+	}
+	return strings.Join(values, j.Separator), nil
+}
+
+// toList converts any slice, such as a []string, to a []interface{}
+func toList(data interface{}) ([]interface{}, error) {
+	if items, ok := data.([]interface{}); ok {
+		return items, nil
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("data must be a list, got %T", data)
+	}
+	items := make([]interface{}, v.Len())
+	for i := range items {
+		items[i] = v.Index(i).Interface()
+	}
+	return items, nil
+}
+
+// lessThan compares two numbers or two strings
+func lessThan(a, b interface{}) (bool, error) {
+	if x, ok := a.(string); ok {
+		if y, ok := b.(string); ok {
+			return x < y, nil
+		}
+	}
+	x, xOk := toFloat(a)
+	y, yOk := toFloat(b)
+	if xOk && yOk {
+		return x < y, nil
+	}
+	return false, fmt.Errorf("unable to compare %v (%T) and %v (%T), only numbers or strings can be sorted", a, a, b, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch x := reflect.ValueOf(v); x.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(x.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(x.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return x.Float(), true
+	}
+	return 0, false
+}
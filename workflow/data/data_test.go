@@ -101,3 +101,75 @@ func TestProcessTransformation(t *testing.T) {
 	_, err = processTransformation(files, filterFiles)
 	assert.Error(t, err)
 }
+
+func TestProcessParameterSource(t *testing.T) {
+	data, err := processSource(v1alpha1.DataSource{Parameter: `[1, "a"]`}, &nullTestDataSourceProcessor{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []interface{}{float64(1), "a"}, data)
+	}
+
+	_, err = processSource(v1alpha1.DataSource{Parameter: `[`}, &nullTestDataSourceProcessor{})
+	assert.Error(t, err)
+}
+
+func TestProcessCollectionTransformations(t *testing.T) {
+	files := []string{"foo.py", "bar.pdf", "goo/foo.py", "moo/bar.pdf"}
+
+	t.Run("Map", func(t *testing.T) {
+		mapped, err := processTransformation(files, &v1alpha1.Transformation{{Map: `item + '.processed'`}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []interface{}{"foo.py.processed", "bar.pdf.processed", "goo/foo.py.processed", "moo/bar.pdf.processed"}, mapped)
+		}
+	})
+	t.Run("Flatten", func(t *testing.T) {
+		flattened, err := processTransformation([]interface{}{[]interface{}{"a", "b"}, "c", []interface{}{}}, &v1alpha1.Transformation{{Flatten: true}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []interface{}{"a", "b", "c"}, flattened)
+		}
+	})
+	t.Run("Unique", func(t *testing.T) {
+		unique, err := processTransformation(files, &v1alpha1.Transformation{{Map: `item endsWith '.py'`}, {Unique: true}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []interface{}{true, false}, unique)
+		}
+	})
+	t.Run("Sort", func(t *testing.T) {
+		sorted, err := processTransformation(files, &v1alpha1.Transformation{{Sort: &v1alpha1.SortTransformation{}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []interface{}{"bar.pdf", "foo.py", "goo/foo.py", "moo/bar.pdf"}, sorted)
+		}
+		sorted, err = processTransformation(files, &v1alpha1.Transformation{{Sort: &v1alpha1.SortTransformation{Key: `len(item)`, Descending: true}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []interface{}{"moo/bar.pdf", "goo/foo.py", "bar.pdf", "foo.py"}, sorted)
+		}
+		_, err = processTransformation([]interface{}{"a", 1}, &v1alpha1.Transformation{{Sort: &v1alpha1.SortTransformation{}}})
+		assert.Error(t, err)
+	})
+	t.Run("Reduce", func(t *testing.T) {
+		total, err := processTransformation([]interface{}{1, 2, 3}, &v1alpha1.Transformation{{Reduce: &v1alpha1.ReduceTransformation{Expression: `acc + item`}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 6, total)
+		}
+		total, err = processTransformation(files, &v1alpha1.Transformation{{Reduce: &v1alpha1.ReduceTransformation{Expression: `acc + len(item)`, Initial: `0`}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, 34, total)
+		}
+	})
+	t.Run("Join", func(t *testing.T) {
+		joined, err := processTransformation([]interface{}{"a", 1, true}, &v1alpha1.Transformation{{Join: &v1alpha1.JoinTransformation{Separator: ","}}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "a,1,true", joined)
+		}
+	})
+	t.Run("NotList", func(t *testing.T) {
+		_, err := processTransformation("foo", &v1alpha1.Transformation{{Unique: true}})
+		assert.EqualError(t, err, "error processing data step 0: data must be a list, got string")
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(&v1alpha1.Data{Source: v1alpha1.DataSource{Parameter: "[]"}, Transformation: v1alpha1.Transformation{{Map: "item"}, {Unique: true}}}))
+	assert.EqualError(t, Validate(&v1alpha1.Data{Source: v1alpha1.DataSource{Parameter: "[]", ArtifactPaths: &v1alpha1.ArtifactPaths{}}}), "source must have only one of artifactPaths or parameter")
+	assert.EqualError(t, Validate(&v1alpha1.Data{Transformation: v1alpha1.Transformation{{Map: "item", Unique: true}}}), "transformation[0] must have only one of expression, map, flatten, unique, sort, reduce or join")
+	assert.EqualError(t, Validate(&v1alpha1.Data{Transformation: v1alpha1.Transformation{{Reduce: &v1alpha1.ReduceTransformation{}}}}), "transformation[0].reduce.expression is required")
+}
@@ -6,8 +6,12 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path"
+	"strconv"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -30,6 +34,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/env"
 	"github.com/argoproj/argo-workflows/v3/util/errors"
 	"github.com/argoproj/argo-workflows/v3/util/expr/argoexpr"
+	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
@@ -226,6 +231,10 @@ func (ae *AgentExecutor) processTask(ctx context.Context, tmpl wfv1.Template) (*
 		executeTemplate = ae.executeHTTPTemplate
 	case tmpl.Plugin != nil:
 		executeTemplate = ae.executePluginTemplate
+	case tmpl.SQLQuery != nil:
+		executeTemplate = ae.executeSQLQueryTemplate
+	case tmpl.Notification != nil:
+		executeTemplate = ae.executeNotificationTemplate
 	default:
 		return nil, 0, fmt.Errorf("agent cannot execute: unknown task type: %v", tmpl.GetType())
 	}
@@ -243,18 +252,32 @@ func (ae *AgentExecutor) executeHTTPTemplate(ctx context.Context, tmpl wfv1.Temp
 		return 0, nil
 	}
 
-	response, err := ae.executeHTTPTemplateRequest(ctx, tmpl.HTTP)
+	response, err := ae.executeHTTPTemplateRequestWithRetry(ctx, tmpl.HTTP)
 	if err != nil {
 		return 0, err
 	}
 	defer response.Body.Close()
 
-	bodyBytes, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return 0, err
+	var bodyBytes []byte
+	outputs := wfv1.Outputs{}
+	if tmpl.HTTP.OutputArtifact != "" {
+		// the body of an error response is not the artifact, so it is not saved
+		if response.StatusCode < 200 || response.StatusCode >= 300 {
+			return 0, fmt.Errorf("received non-2xx response code: %d", response.StatusCode)
+		}
+		art, err := ae.saveOutputArtifact(ctx, tmpl, tmpl.HTTP.OutputArtifact, response.Body)
+		if err != nil {
+			return 0, err
+		}
+		outputs.Artifacts = wfv1.Artifacts{*art}
+	} else {
+		bodyBytes, err = ioutil.ReadAll(response.Body)
+		if err != nil {
+			return 0, err
+		}
+		outputs.Result = pointer.StringPtr(string(bodyBytes))
 	}
 
-	outputs := wfv1.Outputs{Result: pointer.StringPtr(string(bodyBytes))}
 	phase := wfv1.NodeSucceeded
 	message := ""
 	if tmpl.HTTP.SuccessCondition == "" {
@@ -306,6 +329,130 @@ var httpClients = map[bool]*http.Client{
 	true:  httpClientSkip,
 }
 
+// executeHTTPTemplateRequestWithRetry retries requests that receive one of the template's retryOn status codes
+func (ae *AgentExecutor) executeHTTPTemplateRequestWithRetry(ctx context.Context, httpTemplate *wfv1.HTTP) (*http.Response, error) {
+	maxDuration, err := httpTemplate.GetRetryMaxDuration()
+	if err != nil {
+		return nil, err
+	}
+	var waited time.Duration
+	for retry := 0; ; retry++ {
+		response, err := ae.executeHTTPTemplateRequest(ctx, httpTemplate)
+		if err != nil || retry >= httpTemplate.GetRetryLimit() || !httpTemplate.ShouldRetry(response.StatusCode) {
+			return response, err
+		}
+		delay, err := httpTemplate.GetRetryDelay(retry)
+		if err != nil {
+			_ = response.Body.Close()
+			return nil, err
+		}
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			delay = retryAfter
+			// the server may ask for a longer delay than we are willing to wait in total
+			if maxDuration > 0 && delay > maxDuration {
+				delay = maxDuration
+			}
+		}
+		if maxDuration > 0 && waited+delay > maxDuration {
+			return response, nil
+		}
+		_ = response.Body.Close()
+		ae.log.WithFields(log.Fields{"statusCode": response.StatusCode, "retry": retry + 1, "delay": delay}).Info("Retrying HTTP request")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		waited += delay
+	}
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or a HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// saveOutputArtifact streams the reader to the template's output artifact with the name
+func (ae *AgentExecutor) saveOutputArtifact(ctx context.Context, tmpl wfv1.Template, name string, body io.Reader) (*wfv1.Artifact, error) {
+	art := tmpl.Outputs.GetArtifactByName(name)
+	if art == nil {
+		return nil, fmt.Errorf("output artifact %q not found", name)
+	}
+	art = art.DeepCopy()
+
+	file, err := ioutil.TempFile("", "output-artifact-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if _, err := io.Copy(file, body); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	if !art.HasKey() {
+		key, err := tmpl.ArchiveLocation.GetKey()
+		if err != nil {
+			return nil, err
+		}
+		location, err := tmpl.ArchiveLocation.Get()
+		if err != nil {
+			return nil, err
+		}
+		if err := art.SetType(location); err != nil {
+			return nil, err
+		}
+		if err := art.SetKey(path.Join(key, art.Name)); err != nil {
+			return nil, err
+		}
+	}
+	driverArt := art.DeepCopy()
+	if err := driverArt.Relocate(tmpl.ArchiveLocation); err != nil {
+		return nil, err
+	}
+	driver, err := artifact.NewDriver(ctx, driverArt, ae)
+	if err != nil {
+		return nil, err
+	}
+	if err := driver.Save(file.Name(), driverArt); err != nil {
+		return nil, err
+	}
+	ae.log.WithField("artifact", art.Name).Info("Saved output artifact")
+	return art, nil
+}
+
+func (ae *AgentExecutor) GetSecret(ctx context.Context, name, key string) (string, error) {
+	secret, err := util.GetSecrets(ctx, ae.ClientSet, ae.Namespace, name, key)
+	return string(secret), err
+}
+
+func (ae *AgentExecutor) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
+	configMap, err := ae.ClientSet.CoreV1().ConfigMaps(ae.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	value, ok := configMap.Data[key]
+	if !ok {
+		return "", fmt.Errorf("config map %q does not have the key %q", name, key)
+	}
+	return value, nil
+}
+
 func (ae *AgentExecutor) executeHTTPTemplateRequest(ctx context.Context, httpTemplate *wfv1.HTTP) (*http.Response, error) {
 	var (
 		request *http.Request
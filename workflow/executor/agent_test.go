@@ -2,9 +2,18 @@ package executor
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
 
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -31,3 +40,182 @@ func TestUnsupportedTemplateTaskWorker(t *testing.T) {
 	assert.Equal(t, v1alpha1.NodeError, response.Result.Phase)
 	assert.Contains(t, response.Result.Message, "agent cannot execute: unknown task type")
 }
+
+func TestExecuteHTTPTemplateRequestWithRetry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	ae := &AgentExecutor{log: log.WithField("workflow", "my-wf")}
+
+	t.Run("Retried", func(t *testing.T) {
+		requests = 0
+		response, err := ae.executeHTTPTemplateRequestWithRetry(context.Background(), &v1alpha1.HTTP{
+			URL:          server.URL,
+			RetryOn:      []string{"5xx", "429"},
+			RetryBackoff: &v1alpha1.Backoff{Duration: "1ms"},
+		})
+		if assert.NoError(t, err) {
+			defer response.Body.Close()
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, 3, requests)
+		}
+	})
+	t.Run("RetryLimit", func(t *testing.T) {
+		requests = 0
+		response, err := ae.executeHTTPTemplateRequestWithRetry(context.Background(), &v1alpha1.HTTP{
+			URL:          server.URL,
+			RetryOn:      []string{"5xx", "429"},
+			RetryLimit:   pointer.Int32(1),
+			RetryBackoff: &v1alpha1.Backoff{Duration: "1ms"},
+		})
+		if assert.NoError(t, err) {
+			defer response.Body.Close()
+			assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			assert.Equal(t, 2, requests)
+		}
+	})
+	t.Run("NotRetried", func(t *testing.T) {
+		requests = 0
+		response, err := ae.executeHTTPTemplateRequestWithRetry(context.Background(), &v1alpha1.HTTP{URL: server.URL})
+		if assert.NoError(t, err) {
+			defer response.Body.Close()
+			assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+			assert.Equal(t, 1, requests)
+		}
+	})
+}
+
+func TestExecuteHTTPTemplateRequestWithRetryAfter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ae := &AgentExecutor{log: log.WithField("workflow", "my-wf")}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	response, err := ae.executeHTTPTemplateRequestWithRetry(ctx, &v1alpha1.HTTP{
+		URL:          server.URL,
+		RetryOn:      []string{"429"},
+		RetryBackoff: &v1alpha1.Backoff{Duration: "1ms", MaxDuration: "10ms"},
+	})
+	if assert.NoError(t, err) {
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, requests)
+	}
+}
+
+func TestExecuteHTTPTemplateOutputArtifactErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("my-error"))
+	}))
+	defer server.Close()
+	ae := &AgentExecutor{log: log.WithField("workflow", "my-wf")}
+	result := &v1alpha1.NodeResult{}
+	_, err := ae.executeHTTPTemplate(context.Background(), v1alpha1.Template{
+		Outputs: v1alpha1.Outputs{Artifacts: v1alpha1.Artifacts{{Name: "body"}}},
+		HTTP:    &v1alpha1.HTTP{URL: server.URL, OutputArtifact: "body"},
+	}, result)
+	assert.EqualError(t, err, "received non-2xx response code: 500")
+	assert.Nil(t, result.Outputs)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("")
+	assert.False(t, ok)
+	assert.Zero(t, d)
+	d, ok = parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, d)
+	d, ok = parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Zero(t, d)
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestExecuteNotificationTemplate(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		body = string(data)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	ae := &AgentExecutor{
+		log:       log.WithField("workflow", "my-wf"),
+		Namespace: "my-ns",
+		ClientSet: fake.NewSimpleClientset(&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "my-ns"},
+			Data:       map[string][]byte{"url": []byte(server.URL)},
+		}),
+	}
+
+	t.Run("Slack", func(t *testing.T) {
+		result := &v1alpha1.NodeResult{}
+		_, err := ae.executeNotificationTemplate(context.Background(), v1alpha1.Template{Notification: &v1alpha1.Notification{
+			Slack: &v1alpha1.SlackNotification{
+				WebhookURLSecret: &apiv1.SecretKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "slack"}, Key: "url"},
+				Text:             "my-wf Failed",
+			},
+		}}, result)
+		if assert.NoError(t, err) {
+			assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase)
+			assert.JSONEq(t, `{"text": "my-wf Failed"}`, body)
+		}
+	})
+	t.Run("HTTP", func(t *testing.T) {
+		result := &v1alpha1.NodeResult{}
+		_, err := ae.executeNotificationTemplate(context.Background(), v1alpha1.Template{Notification: &v1alpha1.Notification{
+			HTTP: &v1alpha1.HTTPNotification{URL: server.URL, Body: "my-wf Succeeded"},
+		}}, result)
+		if assert.NoError(t, err) {
+			assert.Equal(t, v1alpha1.NodeSucceeded, result.Phase)
+			assert.Equal(t, "my-wf Succeeded", body)
+		}
+	})
+	t.Run("HTTPFailed", func(t *testing.T) {
+		result := &v1alpha1.NodeResult{}
+		_, err := ae.executeNotificationTemplate(context.Background(), v1alpha1.Template{Notification: &v1alpha1.Notification{
+			HTTP: &v1alpha1.HTTPNotification{URL: server.URL + "/fail"},
+		}}, result)
+		assert.EqualError(t, err, "received non-2xx response code: 500")
+	})
+}
+
+func TestNewEmailMessage(t *testing.T) {
+	msg, err := newEmailMessage(&v1alpha1.EmailNotification{
+		From:    "argo@example.com",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "my-wf Failed",
+		Body:    "line 1\nline 2",
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "From: argo@example.com\r\nTo: a@example.com, b@example.com\r\nSubject: my-wf Failed\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\nline 1\r\nline 2", string(msg))
+	}
+	_, err = newEmailMessage(&v1alpha1.EmailNotification{From: "argo@example.com", To: []string{"a@example.com"}, Subject: "my-wf\r\nBcc: c@example.com"})
+	assert.EqualError(t, err, "email subject must not contain a carriage return or line feed")
+	_, err = newEmailMessage(&v1alpha1.EmailNotification{From: "argo@example.com", To: []string{"a@example.com\nc@example.com"}})
+	assert.EqualError(t, err, "email to must not contain a carriage return or line feed")
+}
@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	argofile "github.com/argoproj/pkg/file"
+
+	"github.com/argoproj/argo-workflows/v3/util/file"
+)
+
+// digestArtifact returns the hex-encoded SHA-256 digest of the content of an artifact staged at localArtPath.
+// The archives created when staging the artifact are digested by the names and contents of their files, rather than
+// their bytes, so that the digest does not change with the modification times of the files.
+func digestArtifact(localArtPath string, archived bool) (string, error) {
+	h := sha256.New()
+	isDir, err := argofile.IsDirectory(localArtPath)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case isDir:
+		err = digestDirectory(h, localArtPath)
+	case archived && filepath.Ext(localArtPath) == ".zip":
+		err = digestZip(h, localArtPath)
+	case archived:
+		err = digestTarGz(h, localArtPath)
+	default:
+		err = digestFile(h, localArtPath)
+	}
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func digestEntry(h hash.Hash, name string, r io.Reader) error {
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, err := io.Copy(h, r)
+	return err
+}
+
+func digestFile(h hash.Hash, path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(h, f)
+	return err
+}
+
+func digestDirectory(h hash.Hash, dir string) error {
+	// the files are walked in lexical order
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return digestEntry(h, filepath.ToSlash(name), f)
+	})
+}
+
+func digestTarGz(h hash.Hash, path string) error {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gzr, err := file.GetGzipReader(f)
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := digestEntry(h, filepath.ToSlash(filepath.Clean(header.Name)), tr); err != nil {
+			return err
+		}
+	}
+}
+
+func digestZip(h hash.Hash, path string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = digestEntry(h, filepath.ToSlash(filepath.Clean(f.Name)), rc)
+		_ = rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
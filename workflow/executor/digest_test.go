@@ -0,0 +1,58 @@
+package executor
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argoproj/argo-workflows/v3/util/archive"
+)
+
+func TestDigestArtifact(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	assert.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("hello"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("world"), 0o600))
+
+	t.Run("File", func(t *testing.T) {
+		digest, err := digestArtifact(filepath.Join(src, "a.txt"), false)
+		if assert.NoError(t, err) {
+			// sha256 of "hello"
+			assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", digest)
+		}
+	})
+	t.Run("Directory", func(t *testing.T) {
+		digest, err := digestArtifact(src, false)
+		if assert.NoError(t, err) {
+			assert.Len(t, digest, 64)
+		}
+	})
+	t.Run("TarGz", func(t *testing.T) {
+		tarGz := func(name string) string {
+			path := filepath.Join(dir, name)
+			f, err := os.Create(path)
+			assert.NoError(t, err)
+			defer f.Close()
+			assert.NoError(t, archive.TarGzToWriter(src, gzip.DefaultCompression, f))
+			return path
+		}
+		first, err := digestArtifact(tarGz("first.tgz"), true)
+		assert.NoError(t, err)
+		// the digest does not change with the modification times of the files
+		later := time.Now().Add(time.Hour)
+		assert.NoError(t, os.Chtimes(filepath.Join(src, "a.txt"), later, later))
+		second, err := digestArtifact(tarGz("second.tgz"), true)
+		assert.NoError(t, err)
+		assert.Equal(t, first, second)
+
+		assert.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("changed"), 0o600))
+		third, err := digestArtifact(tarGz("third.tgz"), true)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, third)
+	})
+}
@@ -34,6 +34,7 @@ In the main container, the emissary creates these files:
 * `/var/run/argo/ctr/${containerName}/exitcode` The container exit code.
 * `/var/run/argo/ctr/${containerName}/combined` A copy of stdout+stderr (if needed).
 * `/var/run/argo/ctr/${containerName}/stdout`  A copy of stdout (if needed).
+* `/var/run/argo/ctr/${containerName}/stderr`  A copy of stderr (if needed).
 
 If the container is named `main` it also copies base-layer artifacts to the shared volume:
 
@@ -74,6 +75,18 @@ func (e emissary) GetFileContents(_ string, sourcePath string) (string, error) {
 	return string(data), err
 }
 
+func (e emissary) Glob(_ string, pattern string) ([]string, error) {
+	dir := filepath.Join(common.VarRunArgoPath, "outputs", "parameters")
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+	for i, match := range matches {
+		matches[i] = strings.TrimPrefix(match, dir)
+	}
+	return matches, nil
+}
+
 func (e emissary) CopyFile(_ string, sourcePath string, destPath string, _ int) error {
 	// this implementation is very different, because we expect the emissary binary has already compressed the file
 	// so no compression can or needs to be implemented here
@@ -110,6 +123,10 @@ func (e emissary) GetOutputStream(_ context.Context, containerName string, combi
 	return os.Open(filepath.Clean(filepath.Join(common.VarRunArgoPath, "ctr", containerName, name)))
 }
 
+func (e emissary) GetErrorStream(_ context.Context, containerName string) (io.ReadCloser, error) {
+	return os.Open(filepath.Clean(filepath.Join(common.VarRunArgoPath, "ctr", containerName, "stderr")))
+}
+
 func (e emissary) Wait(ctx context.Context, containerNames []string) error {
 	for {
 		select {
@@ -12,18 +12,25 @@ import (
 	"io"
 	"io/fs"
 	"io/ioutil"
+	"mime"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/argoproj/argo-workflows/v3/util/file"
 
 	argofile "github.com/argoproj/pkg/file"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
 	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,11 +45,14 @@ import (
 	argoprojv1 "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/archive"
+	"github.com/argoproj/argo-workflows/v3/util/env"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
 	"github.com/argoproj/argo-workflows/v3/util/retry"
+	"github.com/argoproj/argo-workflows/v3/util/tracing"
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	artifact "github.com/argoproj/argo-workflows/v3/workflow/artifacts"
 	artifactcommon "github.com/argoproj/argo-workflows/v3/workflow/artifacts/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/throttle"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	executorretry "github.com/argoproj/argo-workflows/v3/workflow/executor/retry"
 )
@@ -50,6 +60,9 @@ import (
 const (
 	// This directory temporarily stores the tarballs of the artifacts before uploading
 	tempOutArtDir = "/tmp/argo/outputs/artifacts"
+	// maxGlobParameterSize is the maximum size of an output parameter of the files matching a glob, as parameters are
+	// stored in the workflow
+	maxGlobParameterSize = 256 * 1024
 )
 
 // WorkflowExecutor is program which runs as the init/wait container
@@ -67,10 +80,16 @@ type WorkflowExecutor struct {
 	Namespace           string
 	RuntimeExecutor     ContainerRuntimeExecutor
 
+	// guards memoizedConfigMaps and memoizedSecrets, which are used by artifacts saved in parallel
+	memoizedLock sync.Mutex
 	// memoized configmaps
 	memoizedConfigMaps map[string]string
 	// memoized secrets
 	memoizedSecrets map[string][]byte
+	// limits the bandwidth of all the artifacts loaded and saved by this executor, nil if unlimited
+	artifactLimiter throttle.Limiter
+	// limits the bandwidth of the artifacts in the artifact repository of the template, nil if unlimited
+	artifactRepositoryLimiter throttle.Limiter
 	// list of errors that occurred during execution.
 	// the first of these is used as the overall message of the node
 	errors []error
@@ -93,6 +112,9 @@ type ContainerRuntimeExecutor interface {
 	// GetFileContents returns the file contents of a file in a container as a string
 	GetFileContents(containerName string, sourcePath string) (string, error)
 
+	// Glob returns the paths of the files in a container matching a pattern, whose contents can be got with GetFileContents
+	Glob(containerName string, pattern string) ([]string, error)
+
 	// CopyFile copies a source file in a container to a local path
 	CopyFile(containerName, sourcePath, destPath string, compressionLevel int) error
 
@@ -100,6 +122,10 @@ type ContainerRuntimeExecutor interface {
 	// Used to capture script results as an output parameter, and to archive container logs
 	GetOutputStream(ctx context.Context, containerName string, combinedOutput bool) (io.ReadCloser, error)
 
+	// GetErrorStream returns the entirety of the container's stderr as a io.Reader
+	// Used to archive container logs when stdout and stderr are archived separately
+	GetErrorStream(ctx context.Context, containerName string) (io.ReadCloser, error)
+
 	// Wait waits for the container to complete.
 	Wait(ctx context.Context, containerNames []string) error
 
@@ -137,6 +163,8 @@ func NewExecutor(
 		Deadline:                     deadline,
 		memoizedConfigMaps:           map[string]string{},
 		memoizedSecrets:              map[string][]byte{},
+		artifactLimiter:              throttle.NewLimiter(int64(env.LookupEnvIntOr(common.EnvVarArtifactMaxBytesPerSec, 0))),
+		artifactRepositoryLimiter:    throttle.NewLimiter(int64(env.LookupEnvIntOr(common.EnvVarArtifactRepositoryMaxBytesPerSec, 0))),
 		errors:                       []error{},
 		annotationPatchTickDuration:  annotationPatchTickDuration,
 		readProgressFileTickDuration: readProgressFileTickDuration,
@@ -159,6 +187,14 @@ func (we *WorkflowExecutor) HandleError(ctx context.Context) {
 func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 	log.Infof("Start loading input artifacts...")
 	for _, art := range we.Template.Inputs.Artifacts {
+		if common.IsPluginArtifact(art, we.Template.ArchiveLocation) {
+			log.Infof("Artifact %s is stored by an artifact driver plugin, it is loaded by the wait container", art.Name)
+			continue
+		}
+		if art.Pipe != nil {
+			log.Infof("Artifact %s is a pipe artifact, it is mounted from volume %s", art.Name, art.Pipe.Volume)
+			continue
+		}
 
 		log.Infof("Downloading artifact: %s", art.Name)
 
@@ -174,14 +210,6 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		driverArt, err := we.newDriverArt(&art)
-		if err != nil {
-			return fmt.Errorf("failed to load artifact '%s': %w", art.Name, err)
-		}
-		artDriver, err := we.InitDriver(ctx, driverArt)
-		if err != nil {
-			return err
-		}
 		// Determine the file path of where to load the artifact
 		var artPath string
 		mnt := common.FindOverlappingVolume(&we.Template, art.Path)
@@ -197,64 +225,203 @@ func (we *WorkflowExecutor) LoadArtifacts(ctx context.Context) error {
 			artPath = path.Join(common.ExecutorMainFilesystemDir, art.Path)
 		}
 
-		// The artifact is downloaded to a temporary location, after which we determine if
-		// the file is a tarball or not. If it is, it is first extracted then renamed to
-		// the desired location. If not, it is simply renamed to the location.
-		tempArtPath := artPath + ".tmp"
-		err = artDriver.Load(driverArt, tempArtPath)
-		if err != nil {
-			if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
-				log.Infof("Skipping optional input artifact that was not found: %s", art.Name)
-				continue
+		if err := we.loadArtifact(ctx, art, artPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadPluginArtifacts loads the input artifacts stored by artifact driver plugins, which the init container cannot load
+// as the plugins only start with the pod's other containers. They are loaded into a directory shared with the main
+// container, which waits for them and copies them to their paths before it runs its command.
+func (we *WorkflowExecutor) LoadPluginArtifacts(ctx context.Context) error {
+	var arts []wfv1.Artifact
+	for _, art := range we.Template.Inputs.Artifacts {
+		if common.IsPluginArtifact(art, we.Template.ArchiveLocation) {
+			arts = append(arts, art)
+		}
+	}
+	if len(arts) == 0 {
+		return nil
+	}
+	err := func() error {
+		if err := os.MkdirAll(common.PluginInputArtifactsPath, 0o755); err != nil { // chmod rwxr-xr-x
+			return err
+		}
+		for _, art := range arts {
+			log.Infof("Downloading artifact: %s", art.Name)
+			if err := art.CleanPath(); err != nil {
+				return err
 			}
-			return fmt.Errorf("artifact %s failed to load: %w", art.Name, err)
-		}
-
-		isTar := false
-		isZip := false
-		if art.GetArchive().None != nil {
-			// explicitly not a tar
-			isTar = false
-			isZip = false
-		} else if art.GetArchive().Tar != nil {
-			// explicitly a tar
-			isTar = true
-		} else if art.GetArchive().Zip != nil {
-			// explicitly a zip
-			isZip = true
-		} else {
-			// auto-detect if tarball
-			// (don't try to autodetect zip files for backwards compatibility)
-			isTar, err = isTarball(tempArtPath)
-			if err != nil {
+			if err := we.loadArtifact(ctx, art, filepath.Join(common.PluginInputArtifactsPath, art.Name)); err != nil {
 				return err
 			}
 		}
+		return nil
+	}()
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	// the file is renamed into place so the main container never reads it partially written
+	tmp := common.PluginInputArtifactsLoadedPath + ".tmp"
+	if err := os.WriteFile(tmp, []byte(message), 0o644); err != nil { // chmod rw-r--r--
+		return err
+	}
+	if err := os.Rename(tmp, common.PluginInputArtifactsLoadedPath); err != nil {
+		return err
+	}
+	return err
+}
 
-		if isTar {
-			err = untar(tempArtPath, artPath)
-			_ = os.Remove(tempArtPath)
-		} else if isZip {
-			err = unzip(tempArtPath, artPath)
-			_ = os.Remove(tempArtPath)
-		} else {
-			err = os.Rename(tempArtPath, artPath)
+// loadArtifact loads an input artifact to the path, extracting it if it is an archive
+func (we *WorkflowExecutor) loadArtifact(ctx context.Context, art wfv1.Artifact, artPath string) error {
+	driverArt, err := we.newDriverArt(&art)
+	if err != nil {
+		return fmt.Errorf("failed to load artifact '%s': %w", art.Name, err)
+	}
+	artDriver, err := we.InitDriver(ctx, driverArt)
+	if err != nil {
+		return err
+	}
+	// The artifact is downloaded to a temporary location, after which we determine if
+	// the file is a tarball or not. If it is, it is first extracted then renamed to
+	// the desired location. If not, it is simply renamed to the location.
+	tempArtPath := artPath + ".tmp"
+	err = artDriver.Load(driverArt, tempArtPath)
+	if err != nil {
+		if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
+			log.Infof("Skipping optional input artifact that was not found: %s", art.Name)
+			return nil
 		}
+		return fmt.Errorf("artifact %s failed to load: %w", art.Name, err)
+	}
+
+	isTar := false
+	isZip := false
+	if art.GetArchive().None != nil {
+		// explicitly not a tar
+		isTar = false
+		isZip = false
+	} else if art.GetArchive().Tar != nil {
+		// explicitly a tar
+		isTar = true
+	} else if art.GetArchive().Zip != nil {
+		// explicitly a zip
+		isZip = true
+	} else {
+		// auto-detect if tarball
+		// (don't try to autodetect zip files for backwards compatibility)
+		isTar, err = isTarball(tempArtPath)
 		if err != nil {
 			return err
 		}
+	}
+
+	if isTar {
+		err = untar(tempArtPath, artPath)
+		_ = os.Remove(tempArtPath)
+	} else if isZip {
+		err = unzip(tempArtPath, artPath)
+		_ = os.Remove(tempArtPath)
+	} else {
+		err = os.Rename(tempArtPath, artPath)
+	}
+	if err != nil {
+		return err
+	}
 
-		log.Infof("Successfully download file: %s", artPath)
-		if art.Mode != nil {
-			err = chmod(artPath, *art.Mode, art.RecurseMode)
+	log.Infof("Successfully download file: %s", artPath)
+	if art.Mode != nil {
+		err = chmod(artPath, *art.Mode, art.RecurseMode)
+		if err != nil {
+			return err
+		}
+	}
+	if art.Validation != nil {
+		if err := validateArtifact(art.Validation, artPath); err != nil {
+			return argoerrs.Errorf(argoerrs.CodeBadRequest, "input artifact '%s' failed validation: %v", art.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateArtifact checks that the artifact loaded to artPath meets its validation rules
+func validateArtifact(validation *wfv1.ArtifactValidation, artPath string) error {
+	info, err := os.Stat(artPath)
+	if os.IsNotExist(err) {
+		if validation.Required {
+			return fmt.Errorf("artifact does not exist")
+		}
+		// an optional artifact that was not loaded has nothing to validate
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var size int64
+	var empty bool
+	if info.IsDir() {
+		entries, err := os.ReadDir(artPath)
+		if err != nil {
+			return err
+		}
+		empty = len(entries) == 0
+		err = filepath.Walk(artPath, func(_ string, info fs.FileInfo, err error) error {
 			if err != nil {
 				return err
 			}
+			if info.Mode().IsRegular() {
+				size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		size = info.Size()
+		empty = size == 0
+	}
+	if validation.Required && empty {
+		return fmt.Errorf("artifact is empty")
+	}
+	if min := validation.MinSizeBytes; min != nil && size < *min {
+		return fmt.Errorf("artifact is %d bytes, less than the minimum of %d bytes", size, *min)
+	}
+	if validation.MediaType != "" {
+		if info.IsDir() {
+			return fmt.Errorf("mediaType can only be validated for files, but artifact is a directory")
+		}
+		mediaType, err := detectMediaType(artPath)
+		if err != nil {
+			return err
+		}
+		if mediaType != validation.MediaType {
+			return fmt.Errorf("artifact media type is %q, expected %q", mediaType, validation.MediaType)
 		}
 	}
 	return nil
 }
 
+// detectMediaType sniffs the media type of a file from its content, without any parameters such as the charset
+func detectMediaType(filePath string) (string, error) {
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	// http.DetectContentType considers at most the first 512 bytes
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(f, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buf[:n]))
+	return mediaType, err
+}
+
 // StageFiles will create any files required by script/resource templates
 func (we *WorkflowExecutor) StageFiles() error {
 	var filePath string
@@ -294,12 +461,40 @@ func (we *WorkflowExecutor) SaveArtifacts(ctx context.Context) error {
 		return argoerrs.InternalWrapError(err)
 	}
 
-	for i, art := range we.Template.Outputs.Artifacts {
-		err := we.saveArtifact(ctx, common.MainContainerName, &art)
+	parallelism := env.LookupEnvIntOr(common.EnvVarArtifactSaveParallelism, 1)
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	// each artifact is written back to its own index, and errors are reported in artifact order, so the
+	// outputs are the same regardless of the order in which the saves complete
+	errs := make([]error, len(we.Template.Outputs.Artifacts))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallelism)
+	for i := range we.Template.Outputs.Artifacts {
+		i := i
+		art := we.Template.Outputs.Artifacts[i]
+		g.Go(func() error {
+			if gctx.Err() != nil {
+				// a previous artifact failed, do not start any more saves
+				return nil
+			}
+			ctx, span := tracing.Tracer().Start(gctx, "saveArtifact", trace.WithAttributes(attribute.String("argo.artifact.name", art.Name)))
+			err := we.saveArtifact(ctx, common.MainContainerName, &art)
+			tracing.End(span, err)
+			if err != nil {
+				errs[i] = err
+				return err
+			}
+			we.Template.Outputs.Artifacts[i] = art
+			return nil
+		})
+	}
+	_ = g.Wait()
+	for _, err := range errs {
 		if err != nil {
 			return err
 		}
-		we.Template.Outputs.Artifacts[i] = art
 	}
 	return nil
 }
@@ -310,6 +505,9 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 	if err != nil {
 		return err
 	}
+	if art.Pipe != nil {
+		return savePipeArtifact(art)
+	}
 	fileName, localArtPath, err := we.stageArchiveFile(containerName, art)
 	if err != nil {
 		if art.Optional && argoerrs.IsCode(argoerrs.CodeNotFound, err) {
@@ -318,9 +516,28 @@ func (we *WorkflowExecutor) saveArtifact(ctx context.Context, containerName stri
 		}
 		return err
 	}
+	// the strategy defaults to tar
+	archived := art.Archive == nil || art.Archive.None == nil
+	if art.Digest, err = digestArtifact(localArtPath, archived); err != nil {
+		log.WithError(err).Warnf("Failed to digest artifact '%s'", art.Name)
+	}
 	return we.saveArtifactFromFile(ctx, art, fileName, localArtPath)
 }
 
+// savePipeArtifact marks a pipe artifact as complete, rather than uploading it. Its readers have been reading it from
+// the shared volume while it was written, and wait for the marker to know that there is nothing more to come.
+func savePipeArtifact(art *wfv1.Artifact) error {
+	dir := filepath.Join(common.ExecutorMainFilesystemDir, art.Path)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	log.Infof("Marking pipe artifact %s complete", art.Name)
+	if err := os.WriteFile(filepath.Join(dir, common.PipeArtifactDoneFile), nil, 0o644); err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	return nil
+}
+
 // fileBase is probably path.Base(filePath), but can be something else
 func (we *WorkflowExecutor) saveArtifactFromFile(ctx context.Context, art *wfv1.Artifact, fileName, localArtPath string) error {
 	if !art.HasKey() {
@@ -473,7 +690,13 @@ func (we *WorkflowExecutor) stageArchiveFile(containerName string, art *wfv1.Art
 		// If we are uploading a single file, we need to preserve original filename so that
 		// 1. minio client can infer its mime-type, based on file extension
 		// 2. the original filename is incorporated into the final path
-		localArtPath = path.Join(tempOutArtDir, fileName)
+		// Artifacts may be saved concurrently, so stage each file in its own directory in case two
+		// artifacts share the same filename
+		stagingDir := path.Join(tempOutArtDir, art.Name+".d")
+		if err := os.MkdirAll(stagingDir, os.ModePerm); err != nil {
+			return "", "", argoerrs.InternalWrapError(err)
+		}
+		localArtPath = path.Join(stagingDir, fileName)
 		err = os.Rename(unarchivedArtPath, localArtPath)
 		if err != nil {
 			return "", "", argoerrs.InternalWrapError(err)
@@ -540,7 +763,19 @@ func (we *WorkflowExecutor) SaveParameters(ctx context.Context) error {
 		}
 
 		var output *wfv1.AnyString
-		if we.isBaseImagePath(param.ValueFrom.Path) {
+		if common.IsGlob(param.ValueFrom.Path) {
+			data, err := we.getGlobParameter(param.ValueFrom.Path)
+			if err != nil {
+				// We have a default value to use instead of returning an error
+				if param.ValueFrom.Default != nil {
+					output = param.ValueFrom.Default
+				} else {
+					return fmt.Errorf("failed to save output parameter %s: %w", param.Name, err)
+				}
+			} else {
+				output = wfv1.AnyStringPtr(data)
+			}
+		} else if we.isBaseImagePath(param.ValueFrom.Path) {
 			log.Infof("Copying %s from base image layer", param.ValueFrom.Path)
 			fileContents, err := we.RuntimeExecutor.GetFileContents(common.MainContainerName, param.ValueFrom.Path)
 			if err != nil {
@@ -577,6 +812,57 @@ func (we *WorkflowExecutor) SaveParameters(ctx context.Context) error {
 	return nil
 }
 
+// getGlobParameter returns a JSON object of the contents of the files matching the pattern, keyed by their paths, so that
+// a step can output many small files, e.g. the results of a fan-out, without them being aggregated into one
+func (we *WorkflowExecutor) getGlobParameter(pattern string) (string, error) {
+	contents := make(map[string]string)
+	size := 0
+	add := func(path, data string) error {
+		size += len(path) + len(data)
+		if size > maxGlobParameterSize {
+			return fmt.Errorf("files matching %s exceed %d bytes", pattern, maxGlobParameterSize)
+		}
+		contents[path] = strings.TrimSuffix(data, "\n")
+		return nil
+	}
+	if we.isBaseImagePath(pattern) {
+		log.Infof("Copying %s from base image layer", pattern)
+		matches, err := we.RuntimeExecutor.Glob(common.MainContainerName, pattern)
+		if err != nil {
+			return "", err
+		}
+		for _, path := range matches {
+			data, err := we.RuntimeExecutor.GetFileContents(common.MainContainerName, path)
+			if err != nil {
+				return "", err
+			}
+			if err := add(path, data); err != nil {
+				return "", err
+			}
+		}
+	} else {
+		log.Infof("Copying %s from volume mount", pattern)
+		matches, err := filepath.Glob(filepath.Join(common.ExecutorMainFilesystemDir, pattern))
+		if err != nil {
+			return "", err
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			data, err := ioutil.ReadFile(filepath.Clean(match))
+			if err != nil {
+				return "", err
+			}
+			if err := add(strings.TrimPrefix(match, common.ExecutorMainFilesystemDir), string(data)); err != nil {
+				return "", err
+			}
+		}
+	}
+	data, err := json.Marshal(contents)
+	return string(data), err
+}
+
 func (we *WorkflowExecutor) SaveLogs(ctx context.Context) {
 	var logArtifacts []wfv1.Artifact
 	tempLogsDir := "/tmp/argo/outputs/logs"
@@ -598,6 +884,13 @@ func (we *WorkflowExecutor) SaveLogs(ctx context.Context) {
 			} else {
 				logArtifacts = append(logArtifacts, *art)
 			}
+			if we.Template.SaveLogStreamsAsArtifacts() {
+				arts, err := we.saveContainerLogStreams(ctx, tempLogsDir, containerName)
+				if err != nil {
+					we.AddError(err)
+				}
+				logArtifacts = append(logArtifacts, arts...)
+			}
 		}
 	}
 
@@ -626,6 +919,37 @@ func (we *WorkflowExecutor) saveContainerLogs(ctx context.Context, tempLogsDir,
 	return art, nil
 }
 
+// saveContainerLogStreams saves a single container's stdout and stderr into separate files
+func (we *WorkflowExecutor) saveContainerLogStreams(ctx context.Context, tempLogsDir, containerName string) ([]wfv1.Artifact, error) {
+	streams := []struct {
+		name string
+		open func() (io.ReadCloser, error)
+	}{
+		{"stdout", func() (io.ReadCloser, error) { return we.RuntimeExecutor.GetOutputStream(ctx, containerName, false) }},
+		{"stderr", func() (io.ReadCloser, error) { return we.RuntimeExecutor.GetErrorStream(ctx, containerName) }},
+	}
+	var arts []wfv1.Artifact
+	for _, stream := range streams {
+		fileName := fmt.Sprintf("%s.%s.log", containerName, stream.name)
+		filePath := path.Join(tempLogsDir, fileName)
+		reader, err := stream.open()
+		if err != nil {
+			return arts, err
+		}
+		err = copyToFile(reader, filePath)
+		if err != nil {
+			return arts, err
+		}
+		art := wfv1.Artifact{Name: fmt.Sprintf("%s-%s-logs", containerName, stream.name)}
+		err = we.saveArtifactFromFile(ctx, &art, fileName, filePath)
+		if err != nil {
+			return arts, err
+		}
+		arts = append(arts, art)
+	}
+	return arts, nil
+}
+
 // GetSecret will retrieve the Secrets from VolumeMount
 func (we *WorkflowExecutor) GetSecret(ctx context.Context, accessKeyName string, accessKey string) (string, error) {
 	file, err := ioutil.ReadFile(filepath.Clean(filepath.Join(common.SecretVolMountPath, accessKeyName, accessKey)))
@@ -637,16 +961,21 @@ func (we *WorkflowExecutor) GetSecret(ctx context.Context, accessKeyName string,
 
 // saveLogToFile saves the entire log output of a container to a local file
 func (we *WorkflowExecutor) saveLogToFile(ctx context.Context, containerName, path string) error {
-	outFile, err := os.Create(path)
-	if err != nil {
-		return argoerrs.InternalWrapError(err)
-	}
-	defer func() { _ = outFile.Close() }()
 	reader, err := we.RuntimeExecutor.GetOutputStream(ctx, containerName, true)
 	if err != nil {
 		return err
 	}
+	return copyToFile(reader, path)
+}
+
+// copyToFile copies the reader to a local file, closing the reader
+func copyToFile(reader io.ReadCloser, path string) error {
 	defer func() { _ = reader.Close() }()
+	outFile, err := os.Create(path)
+	if err != nil {
+		return argoerrs.InternalWrapError(err)
+	}
+	defer func() { _ = outFile.Close() }()
 	_, err = io.Copy(outFile, reader)
 	if err != nil {
 		return argoerrs.InternalWrapError(err)
@@ -666,13 +995,49 @@ func (we *WorkflowExecutor) InitDriver(ctx context.Context, art *wfv1.Artifact)
 	if err == artifact.ErrUnsupportedDriver {
 		return nil, argoerrs.Errorf(argoerrs.CodeBadRequest, "Unsupported artifact driver for %s", art.Name)
 	}
-	return driver, err
+	if err != nil {
+		return nil, err
+	}
+	return throttle.New(driver, we.getArtifactLimiter(art)), nil
+}
+
+// getArtifactLimiter returns the limiter of the artifact, which is the executor's if it has one, or else its artifact
+// repository's if the artifact is in it
+func (we *WorkflowExecutor) getArtifactLimiter(art *wfv1.Artifact) throttle.Limiter {
+	if we.artifactLimiter != nil {
+		return we.artifactLimiter
+	}
+	if we.artifactRepositoryLimiter != nil && isInArtifactRepository(we.Template.ArchiveLocation, art) {
+		return we.artifactRepositoryLimiter
+	}
+	return nil
+}
+
+// isInArtifactRepository returns whether the artifact is in the repository of the archive location, i.e. it has no
+// location of its own, or its location is the archive location's but for the key
+func isInArtifactRepository(archiveLocation *wfv1.ArtifactLocation, art *wfv1.Artifact) bool {
+	if archiveLocation == nil {
+		return false
+	}
+	if !art.HasLocation() {
+		return true
+	}
+	repo := archiveLocation.DeepCopy()
+	location := art.ArtifactLocation.DeepCopy()
+	repo.ArchiveLogs = nil
+	location.ArchiveLogs = nil
+	if repo.SetKey("") != nil || location.SetKey("") != nil {
+		return false
+	}
+	return reflect.DeepEqual(repo, location)
 }
 
 // GetConfigMapKey retrieves a configmap value and memoizes the result
 func (we *WorkflowExecutor) GetConfigMapKey(ctx context.Context, name, key string) (string, error) {
 	namespace := we.Namespace
 	cachedKey := fmt.Sprintf("%s/%s/%s", namespace, name, key)
+	we.memoizedLock.Lock()
+	defer we.memoizedLock.Unlock()
 	if val, ok := we.memoizedConfigMaps[cachedKey]; ok {
 		return val, nil
 	}
@@ -701,6 +1066,8 @@ func (we *WorkflowExecutor) GetConfigMapKey(ctx context.Context, name, key strin
 // GetSecrets retrieves a secret value and memoizes the result
 func (we *WorkflowExecutor) GetSecrets(ctx context.Context, namespace, name, key string) ([]byte, error) {
 	cachedKey := fmt.Sprintf("%s/%s/%s", namespace, name, key)
+	we.memoizedLock.Lock()
+	defer we.memoizedLock.Unlock()
 	if val, ok := we.memoizedSecrets[cachedKey]; ok {
 		return val, nil
 	}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,8 +19,10 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/pointer"
 
+	argoerrs "github.com/argoproj/argo-workflows/v3/errors"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argofake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/throttle"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/executor/mocks"
 )
@@ -113,6 +117,48 @@ func TestSaveParameters(t *testing.T) {
 	assert.Equal(t, "has a newline", we.Template.Outputs.Parameters[0].Value.String())
 }
 
+func TestSaveGlobParameters(t *testing.T) {
+	newExecutor := func() (*WorkflowExecutor, *mocks.ContainerRuntimeExecutor) {
+		mockRuntimeExecutor := &mocks.ContainerRuntimeExecutor{}
+		return &WorkflowExecutor{
+			PodName:   fakePodName,
+			ClientSet: fake.NewSimpleClientset(),
+			Namespace: fakeNamespace,
+			Template: wfv1.Template{
+				Outputs: wfv1.Outputs{
+					Parameters: []wfv1.Parameter{{Name: "my-out", ValueFrom: &wfv1.ValueFrom{Path: "/results/*.json"}}},
+				},
+			},
+			RuntimeExecutor: mockRuntimeExecutor,
+		}, mockRuntimeExecutor
+	}
+	ctx := context.Background()
+	t.Run("Matches", func(t *testing.T) {
+		we, mockRuntimeExecutor := newExecutor()
+		mockRuntimeExecutor.On("Glob", fakeContainerName, "/results/*.json").Return([]string{"/results/a.json", "/results/b.json"}, nil)
+		mockRuntimeExecutor.On("GetFileContents", fakeContainerName, "/results/a.json").Return("1\n", nil)
+		mockRuntimeExecutor.On("GetFileContents", fakeContainerName, "/results/b.json").Return(`{"b":2}`, nil)
+		assert.NoError(t, we.SaveParameters(ctx))
+		assert.Equal(t, `{"/results/a.json":"1","/results/b.json":"{\"b\":2}"}`, we.Template.Outputs.Parameters[0].Value.String())
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		we, mockRuntimeExecutor := newExecutor()
+		mockRuntimeExecutor.On("Glob", fakeContainerName, "/results/*.json").Return(nil, nil)
+		assert.NoError(t, we.SaveParameters(ctx))
+		assert.Equal(t, `{}`, we.Template.Outputs.Parameters[0].Value.String())
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		we, mockRuntimeExecutor := newExecutor()
+		mockRuntimeExecutor.On("Glob", fakeContainerName, "/results/*.json").Return([]string{"/results/a.json"}, nil)
+		mockRuntimeExecutor.On("GetFileContents", fakeContainerName, "/results/a.json").Return(strings.Repeat("x", maxGlobParameterSize), nil)
+		assert.EqualError(t, we.SaveParameters(ctx), "failed to save output parameter my-out: files matching /results/*.json exceed 262144 bytes")
+
+		we.Template.Outputs.Parameters[0].ValueFrom.Default = wfv1.AnyStringPtr("{}")
+		assert.NoError(t, we.SaveParameters(ctx))
+		assert.Equal(t, `{}`, we.Template.Outputs.Parameters[0].Value.String())
+	})
+}
+
 // TestIsBaseImagePath tests logic of isBaseImagePath which determines if a path is coming from a
 // base image layer versus a shared volumeMount.
 func TestIsBaseImagePath(t *testing.T) {
@@ -444,7 +490,8 @@ func TestSaveArtifacts(t *testing.T) {
 		},
 	}
 
-	for _, tt := range tests {
+	for i := range tests {
+		tt := &tests[i]
 		ctx := context.Background()
 		err := tt.workflowExecutor.SaveArtifacts(ctx)
 		if err != nil {
@@ -455,6 +502,114 @@ func TestSaveArtifacts(t *testing.T) {
 	}
 }
 
+func TestSaveArtifactsParallelism(t *testing.T) {
+	t.Setenv(common.EnvVarArtifactSaveParallelism, "3")
+	mockRuntimeExecutor := mocks.ContainerRuntimeExecutor{}
+	mockRuntimeExecutor.On("CopyFile", common.MainContainerName, mock.Anything, mock.Anything, mock.Anything).
+		Return(argoerrs.Errorf(argoerrs.CodeNotFound, "no such file or directory"))
+	newTemplate := func(optional bool) wfv1.Template {
+		tmpl := wfv1.Template{}
+		for _, name := range []string{"a", "b", "c", "d"} {
+			tmpl.Outputs.Artifacts = append(tmpl.Outputs.Artifacts, wfv1.Artifact{Name: name, Path: "/" + name, Optional: optional})
+		}
+		return tmpl
+	}
+	t.Run("Optional", func(t *testing.T) {
+		we := WorkflowExecutor{Template: newTemplate(true), RuntimeExecutor: &mockRuntimeExecutor}
+		assert.NoError(t, we.SaveArtifacts(context.Background()))
+		for i, name := range []string{"a", "b", "c", "d"} {
+			assert.Equal(t, name, we.Template.Outputs.Artifacts[i].Name)
+		}
+	})
+	t.Run("Required", func(t *testing.T) {
+		we := WorkflowExecutor{Template: newTemplate(false), RuntimeExecutor: &mockRuntimeExecutor}
+		assert.Error(t, we.SaveArtifacts(context.Background()))
+	})
+}
+
+// run with -race to check that artifacts saved in parallel can share the memoized secrets and configmaps
+func TestGetSecretsConcurrently(t *testing.T) {
+	fakeClientset := fake.NewSimpleClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: fakeNamespace}, Data: map[string][]byte{"key": []byte("secret-value")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-configmap", Namespace: fakeNamespace}, Data: map[string]string{"key": "configmap-value"}},
+	)
+	we := NewExecutor(fakeClientset, nil, nil, fakePodName, fakePodUID, fakeWorkflow, fakeNodeID, fakeNamespace, &mocks.ContainerRuntimeExecutor{}, wfv1.Template{}, false, time.Now(), time.Second, time.Second)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			secret, err := we.GetSecrets(ctx, fakeNamespace, "my-secret", "key")
+			assert.NoError(t, err)
+			assert.Equal(t, "secret-value", string(secret))
+			configmap, err := we.GetConfigMapKey(ctx, "my-configmap", "key")
+			assert.NoError(t, err)
+			assert.Equal(t, "configmap-value", configmap)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestGetArtifactLimiter(t *testing.T) {
+	archiveLocation := &wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Endpoint: "s3.amazonaws.com", Bucket: "my-bucket"}, Key: "my-wf/my-pod"}}
+	inRepo := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Endpoint: "s3.amazonaws.com", Bucket: "my-bucket"}, Key: "my-key"}}}
+	keyOnly := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-key"}}}
+	elsewhere := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{S3Bucket: wfv1.S3Bucket{Endpoint: "s3.amazonaws.com", Bucket: "other-bucket"}, Key: "my-key"}}}
+	httpArt := &wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: "https://example.com"}}}
+
+	t.Run("Repository", func(t *testing.T) {
+		we := &WorkflowExecutor{Template: wfv1.Template{ArchiveLocation: archiveLocation}, artifactRepositoryLimiter: throttle.NewLimiter(1)}
+		assert.NotNil(t, we.getArtifactLimiter(inRepo))
+		assert.NotNil(t, we.getArtifactLimiter(keyOnly))
+		assert.Nil(t, we.getArtifactLimiter(elsewhere))
+		assert.Nil(t, we.getArtifactLimiter(httpArt))
+	})
+	t.Run("Executor", func(t *testing.T) {
+		limiter := throttle.NewLimiter(1)
+		we := &WorkflowExecutor{Template: wfv1.Template{ArchiveLocation: archiveLocation}, artifactLimiter: limiter, artifactRepositoryLimiter: throttle.NewLimiter(2)}
+		assert.Equal(t, limiter, we.getArtifactLimiter(inRepo))
+		assert.Equal(t, limiter, we.getArtifactLimiter(httpArt))
+	})
+	t.Run("Unlimited", func(t *testing.T) {
+		we := &WorkflowExecutor{Template: wfv1.Template{ArchiveLocation: archiveLocation}}
+		assert.Nil(t, we.getArtifactLimiter(inRepo))
+	})
+}
+
+func TestValidateArtifact(t *testing.T) {
+	dir := t.TempDir()
+	emptyFile := filepath.Join(dir, "empty")
+	assert.NoError(t, os.WriteFile(emptyFile, nil, 0o600))
+	textFile := filepath.Join(dir, "text")
+	assert.NoError(t, os.WriteFile(textFile, []byte("hello"), 0o600))
+	emptyDir := filepath.Join(dir, "empty-dir")
+	assert.NoError(t, os.Mkdir(emptyDir, 0o700))
+
+	t.Run("Required", func(t *testing.T) {
+		validation := &wfv1.ArtifactValidation{Required: true}
+		assert.EqualError(t, validateArtifact(validation, filepath.Join(dir, "missing")), "artifact does not exist")
+		assert.EqualError(t, validateArtifact(validation, emptyFile), "artifact is empty")
+		assert.EqualError(t, validateArtifact(validation, emptyDir), "artifact is empty")
+		assert.NoError(t, validateArtifact(validation, textFile))
+		assert.NoError(t, validateArtifact(validation, dir))
+	})
+	t.Run("Optional", func(t *testing.T) {
+		validation := &wfv1.ArtifactValidation{MinSizeBytes: pointer.Int64(1), MediaType: "text/plain"}
+		assert.NoError(t, validateArtifact(validation, filepath.Join(dir, "missing")))
+	})
+	t.Run("MinSizeBytes", func(t *testing.T) {
+		assert.NoError(t, validateArtifact(&wfv1.ArtifactValidation{MinSizeBytes: pointer.Int64(5)}, textFile))
+		assert.NoError(t, validateArtifact(&wfv1.ArtifactValidation{MinSizeBytes: pointer.Int64(5)}, dir))
+		assert.EqualError(t, validateArtifact(&wfv1.ArtifactValidation{MinSizeBytes: pointer.Int64(6)}, textFile), "artifact is 5 bytes, less than the minimum of 6 bytes")
+	})
+	t.Run("MediaType", func(t *testing.T) {
+		assert.NoError(t, validateArtifact(&wfv1.ArtifactValidation{MediaType: "text/plain"}, textFile))
+		assert.EqualError(t, validateArtifact(&wfv1.ArtifactValidation{MediaType: "image/png"}, textFile), `artifact media type is "text/plain", expected "image/png"`)
+		assert.Error(t, validateArtifact(&wfv1.ArtifactValidation{MediaType: "text/plain"}, dir))
+	})
+}
+
 func TestMonitorProgress(t *testing.T) {
 	ctx := context.Background()
 
@@ -520,4 +675,25 @@ func TestSaveLogs(t *testing.T) {
 		we.SaveLogs(ctx)
 		assert.EqualError(t, we.errors[0], artStorageError)
 	})
+	t.Run("Separate log streams", func(t *testing.T) {
+		mockRuntimeExecutor.On("GetOutputStream", mock.Anything, common.MainContainerName, false).Return(io.NopCloser(strings.NewReader("hello")), nil)
+		mockRuntimeExecutor.On("GetErrorStream", mock.Anything, common.MainContainerName).Return(io.NopCloser(strings.NewReader("world")), nil)
+		we := WorkflowExecutor{
+			Template: wfv1.Template{
+				ArchiveLocation: &wfv1.ArtifactLocation{
+					ArchiveLogs:        pointer.BoolPtr(true),
+					SeparateLogStreams: pointer.BoolPtr(true),
+				},
+			},
+			RuntimeExecutor: &mockRuntimeExecutor,
+		}
+
+		ctx := context.Background()
+		we.SaveLogs(ctx)
+		if assert.Len(t, we.errors, 2) {
+			assert.EqualError(t, we.errors[0], artStorageError)
+			assert.EqualError(t, we.errors[1], artStorageError)
+		}
+		mockRuntimeExecutor.AssertCalled(t, "GetOutputStream", mock.Anything, common.MainContainerName, false)
+	})
 }
@@ -31,6 +31,29 @@ func (_m *ContainerRuntimeExecutor) CopyFile(containerName string, sourcePath st
 	return r0
 }
 
+// GetErrorStream provides a mock function with given fields: ctx, containerName
+func (_m *ContainerRuntimeExecutor) GetErrorStream(ctx context.Context, containerName string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, containerName)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, containerName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, containerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFileContents provides a mock function with given fields: containerName, sourcePath
 func (_m *ContainerRuntimeExecutor) GetFileContents(containerName string, sourcePath string) (string, error) {
 	ret := _m.Called(containerName, sourcePath)
@@ -75,6 +98,29 @@ func (_m *ContainerRuntimeExecutor) GetOutputStream(ctx context.Context, contain
 	return r0, r1
 }
 
+// Glob provides a mock function with given fields: containerName, pattern
+func (_m *ContainerRuntimeExecutor) Glob(containerName string, pattern string) ([]string, error) {
+	ret := _m.Called(containerName, pattern)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(containerName, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(containerName, pattern)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Kill provides a mock function with given fields: ctx, containerNames, terminationGracePeriodDuration
 func (_m *ContainerRuntimeExecutor) Kill(ctx context.Context, containerNames []string, terminationGracePeriodDuration time.Duration) error {
 	ret := _m.Called(ctx, containerNames, terminationGracePeriodDuration)
@@ -0,0 +1,140 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util"
+)
+
+func (ae *AgentExecutor) executeNotificationTemplate(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
+	notification := tmpl.Notification
+	if notification == nil {
+		return 0, nil
+	}
+	if err := notification.Validate(); err != nil {
+		return 0, err
+	}
+	var err error
+	switch {
+	case notification.Slack != nil:
+		err = ae.sendSlackNotification(ctx, notification.Slack)
+	case notification.Email != nil:
+		err = ae.sendEmailNotification(ctx, notification.Email)
+	case notification.HTTP != nil:
+		err = ae.sendHTTPNotification(ctx, notification.HTTP)
+	}
+	if err != nil {
+		return 0, err
+	}
+	result.Phase = wfv1.NodeSucceeded
+	return 0, nil
+}
+
+func (ae *AgentExecutor) sendSlackNotification(ctx context.Context, slack *wfv1.SlackNotification) error {
+	url, err := ae.getNotificationSecret(ctx, slack.WebhookURLSecret)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"text": slack.Text})
+	if err != nil {
+		return err
+	}
+	return ae.postNotification(ctx, &wfv1.HTTP{
+		Method:  http.MethodPost,
+		URL:     url,
+		Headers: wfv1.HTTPHeaders{{Name: "Content-Type", Value: "application/json"}},
+		Body:    string(body),
+	})
+}
+
+func (ae *AgentExecutor) sendEmailNotification(ctx context.Context, email *wfv1.EmailNotification) error {
+	var auth smtp.Auth
+	if email.UsernameSecret != nil || email.PasswordSecret != nil {
+		username, err := ae.getNotificationSecret(ctx, email.UsernameSecret)
+		if err != nil {
+			return err
+		}
+		password, err := ae.getNotificationSecret(ctx, email.PasswordSecret)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", username, password, email.Host)
+	}
+	addr := net.JoinHostPort(email.Host, strconv.Itoa(int(email.GetPort())))
+	msg, err := newEmailMessage(email)
+	if err != nil {
+		return err
+	}
+	if err := smtp.SendMail(addr, auth, email.From, email.To, msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// newEmailMessage returns the RFC 822 message of the email. Headers that contain a carriage return or line feed are
+// rejected, as they could add headers, or recipients, to the message.
+func newEmailMessage(email *wfv1.EmailNotification) ([]byte, error) {
+	headers := map[string]string{"From": email.From, "Subject": email.Subject}
+	for _, to := range email.To {
+		headers["To"] += to
+	}
+	for name, value := range headers {
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("email %s must not contain a carriage return or line feed", strings.ToLower(name))
+		}
+	}
+	var msg strings.Builder
+	msg.WriteString("From: " + email.From + "\r\n")
+	msg.WriteString("To: " + strings.Join(email.To, ", ") + "\r\n")
+	msg.WriteString("Subject: " + email.Subject + "\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(email.Body, "\n", "\r\n"))
+	return []byte(msg.String()), nil
+}
+
+func (ae *AgentExecutor) sendHTTPNotification(ctx context.Context, notification *wfv1.HTTPNotification) error {
+	return ae.postNotification(ctx, &wfv1.HTTP{
+		Method:  http.MethodPost,
+		URL:     notification.URL,
+		Headers: notification.Headers,
+		Body:    notification.Body,
+	})
+}
+
+func (ae *AgentExecutor) postNotification(ctx context.Context, httpTemplate *wfv1.HTTP) error {
+	response, err := ae.executeHTTPTemplateRequestWithRetry(ctx, httpTemplate)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("received non-2xx response code: %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (ae *AgentExecutor) getNotificationSecret(ctx context.Context, selector *apiv1.SecretKeySelector) (string, error) {
+	if selector == nil {
+		return "", nil
+	}
+	value, err := util.GetSecrets(ctx, ae.ClientSet, ae.Namespace, selector.Name, selector.Key)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
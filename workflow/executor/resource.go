@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/antonmedv/expr"
+	"github.com/antonmedv/expr/vm"
 	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -139,6 +141,11 @@ func (we *WorkflowExecutor) getKubectlArguments(action string, manifestPath stri
 		args = append(args, string(buff))
 	}
 
+	if action == "apply" && we.Template.Resource.ServerSideApply {
+		args = append(args, "--server-side")
+		args = append(args, "--field-manager="+we.Template.Resource.GetFieldManager())
+	}
+
 	if len(flags) != 0 {
 		args = append(args, flags...)
 	}
@@ -172,7 +179,7 @@ func (g gjsonLabels) Get(label string) string {
 
 // WaitResource waits for a specific resource to satisfy either the success or failure condition
 func (we *WorkflowExecutor) WaitResource(ctx context.Context, resourceNamespace, resourceName, selfLink string) error {
-	if we.Template.Resource.SuccessCondition == "" && we.Template.Resource.FailureCondition == "" {
+	if !we.Template.Resource.HasWaitConditions() {
 		return nil
 	}
 	var successReqs labels.Requirements
@@ -194,9 +201,32 @@ func (we *WorkflowExecutor) WaitResource(ctx context.Context, resourceNamespace,
 		log.Infof("Failing for conditions: %s", failSelector)
 		failReqs, _ = failSelector.Requirements()
 	}
-	err := wait.PollImmediateInfinite(envutil.LookupEnvDurationOr("RESOURCE_STATE_CHECK_INTERVAL", time.Second*5),
+	successProgram, err := compileExpression("success expression", we.Template.Resource.SuccessExpression)
+	if err != nil {
+		return err
+	}
+	failureProgram, err := compileExpression("failure expression", we.Template.Resource.FailureExpression)
+	if err != nil {
+		return err
+	}
+	poll := wait.PollImmediateInfinite
+	if we.Template.Resource.WaitTimeout != "" {
+		timeout, err := time.ParseDuration(we.Template.Resource.WaitTimeout)
+		if err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "wait timeout '%s' failed to parse: %v", we.Template.Resource.WaitTimeout, err)
+		}
+		log.Infof("Waiting for at most %v", timeout)
+		poll = func(interval time.Duration, condition wait.ConditionFunc) error {
+			err := wait.PollImmediate(interval, timeout, condition)
+			if err == wait.ErrWaitTimeout {
+				return errors.Errorf(errors.CodeTimeout, "timed out after %v waiting for resource %s to meet its success or failure conditions", timeout, resourceName)
+			}
+			return err
+		}
+	}
+	err = poll(envutil.LookupEnvDurationOr("RESOURCE_STATE_CHECK_INTERVAL", time.Second*5),
 		func() (bool, error) {
-			isErrRetryable, err := we.checkResourceState(ctx, selfLink, successReqs, failReqs)
+			isErrRetryable, err := we.checkResourceState(ctx, selfLink, successReqs, failReqs, successProgram, failureProgram)
 			if err == nil {
 				log.Infof("Returning from successful wait for resource %s in namespace %s", resourceName, resourceNamespace)
 				return true, nil
@@ -222,7 +252,7 @@ func (we *WorkflowExecutor) WaitResource(ctx context.Context, resourceNamespace,
 
 // checkResourceState performs resource status checking and then waiting on json reading.
 // The returning boolean indicates whether we should retry.
-func (we *WorkflowExecutor) checkResourceState(ctx context.Context, selfLink string, successReqs labels.Requirements, failReqs labels.Requirements) (bool, error) {
+func (we *WorkflowExecutor) checkResourceState(ctx context.Context, selfLink string, successReqs labels.Requirements, failReqs labels.Requirements, success, failure *vm.Program) (bool, error) {
 	request := we.RESTClient.Get().RequestURI(selfLink)
 	stream, err := request.Stream(ctx)
 
@@ -244,7 +274,93 @@ func (we *WorkflowExecutor) checkResourceState(ctx context.Context, selfLink str
 	if !gjson.Valid(jsonString) {
 		return false, errors.Errorf(errors.CodeNotFound, "Encountered invalid JSON response when checking resource status. Will not be retried: %q", jsonString)
 	}
-	return matchConditions(jsonBytes, successReqs, failReqs)
+	conditionsRetry, conditionsErr := matchConditions(jsonBytes, successReqs, failReqs)
+	if conditionsErr != nil && !conditionsRetry {
+		return false, conditionsErr
+	}
+	expressionsRetry, expressionsErr := matchExpressions(jsonBytes, success, failure)
+	if expressionsErr != nil && !expressionsRetry {
+		return false, expressionsErr
+	}
+	if conditionsErr != nil {
+		return conditionsRetry, conditionsErr
+	}
+	return expressionsRetry, expressionsErr
+}
+
+// compileExpression compiles a success or failure expression, returning nil if there is none.
+func compileExpression(field, expression string) (*vm.Program, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	program, err := expr.Compile(expression)
+	if err != nil {
+		return nil, errors.Errorf(errors.CodeBadRequest, "%s '%s' failed to compile: %v", field, expression, err)
+	}
+	return program, nil
+}
+
+// evalExpression evaluates a compiled expression against the environment, it must return a boolean.
+func evalExpression(program *vm.Program, env map[string]interface{}) (bool, error) {
+	result, err := expr.Run(program, env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean result, got %v", result)
+	}
+	return b, nil
+}
+
+// matchExpressions checks whether the returned JSON bytes match the success or failure expressions.
+// Expressions that fail to evaluate are not retried, use `?.` to refer to fields that may not have been set yet.
+func matchExpressions(jsonBytes []byte, success, failure *vm.Program) (bool, error) {
+	if success == nil && failure == nil {
+		return false, nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &obj); err != nil {
+		return false, err
+	}
+	env := map[string]interface{}{
+		"resource":     obj,
+		"hasCondition": func(conditionType, status string) bool { return hasCondition(obj, conditionType, status) },
+	}
+	if failure != nil {
+		failed, err := evalExpression(failure, env)
+		if err != nil {
+			return false, errors.Errorf(errors.CodeBadRequest, "failure expression '%s' failed to evaluate: %v", failure.Source.Content(), err)
+		}
+		msg := fmt.Sprintf("failure expression '%s' evaluated %v", failure.Source.Content(), failed)
+		log.Info(msg)
+		if failed {
+			return false, errors.Errorf(errors.CodeBadRequest, msg)
+		}
+	}
+	if success != nil {
+		succeeded, err := evalExpression(success, env)
+		if err != nil {
+			return false, errors.Errorf(errors.CodeBadRequest, "success expression '%s' failed to evaluate: %v", success.Source.Content(), err)
+		}
+		log.Infof("success expression '%s' evaluated %v", success.Source.Content(), succeeded)
+		if !succeeded {
+			return true, errors.Errorf(errors.CodeNotFound, "Neither success expression nor the failure expression has been matched. Retrying...")
+		}
+	}
+	return false, nil
+}
+
+// hasCondition returns true if the object has a status condition of the given type and status, e.g. "Ready" and "True"
+func hasCondition(obj map[string]interface{}, conditionType, status string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == conditionType && condition["status"] == status {
+			return true
+		}
+	}
+	return false
 }
 
 // matchConditions checks whether the returned JSON bytes match success or failure conditions.
@@ -149,6 +149,79 @@ func TestResourceConditionsMatching(t *testing.T) {
 	assert.True(t, finished)
 }
 
+// TestResourceServerSideApplyFlags tests whether server-side apply flags
+// are properly passed to `kubectl apply` command
+func TestResourceServerSideApplyFlags(t *testing.T) {
+	manifestPath := "../../examples/hello-world.yaml"
+	we := WorkflowExecutor{
+		Template: wfv1.Template{
+			Resource: &wfv1.ResourceTemplate{
+				Action:          "apply",
+				ServerSideApply: true,
+			},
+		},
+	}
+	args, err := we.getKubectlArguments("apply", manifestPath, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"apply", "--server-side", "--field-manager=argo-workflows", "-f", manifestPath, "-o", "json"}, args)
+
+	we.Template.Resource.FieldManager = "my-manager"
+	args, err = we.getKubectlArguments("apply", manifestPath, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, args, "--field-manager=my-manager")
+}
+
+// TestResourceExpressionsMatching tests whether the JSON response match
+// with either success or failure expressions.
+func TestResourceExpressionsMatching(t *testing.T) {
+	success, err := compileExpression("success expression", `resource.status?.phase == 'Running' && hasCondition('Ready', 'True')`)
+	assert.NoError(t, err)
+	failure, err := compileExpression("failure expression", `resource.status?.phase == 'Failed'`)
+	assert.NoError(t, err)
+
+	jsonBytes := []byte(`{"status":{"phase":"Failed"}}`)
+	retry, err := matchExpressions(jsonBytes, success, failure)
+	assert.EqualError(t, err, `failure expression 'resource.status?.phase == 'Failed'' evaluated true`)
+	assert.False(t, retry)
+
+	jsonBytes = []byte(`{"status":{"phase":"Running","conditions":[{"type":"Ready","status":"True"}]}}`)
+	retry, err = matchExpressions(jsonBytes, success, failure)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	jsonBytes = []byte(`{"status":{"phase":"Running","conditions":[{"type":"Ready","status":"False"}]}}`)
+	retry, err = matchExpressions(jsonBytes, success, failure)
+	assert.EqualError(t, err, "Neither success expression nor the failure expression has been matched. Retrying...")
+	assert.True(t, retry)
+
+	retry, err = matchExpressions([]byte(`{}`), success, failure)
+	assert.Error(t, err)
+	assert.True(t, retry)
+
+	retry, err = matchExpressions(jsonBytes, nil, nil)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	t.Run("CompileError", func(t *testing.T) {
+		_, err := compileExpression("success expression", `resource.status.phase ==`)
+		assert.Error(t, err)
+	})
+	t.Run("EvaluationError", func(t *testing.T) {
+		success, err := compileExpression("success expression", `resource.status.phase == 'Running'`)
+		assert.NoError(t, err)
+		retry, err := matchExpressions([]byte(`{}`), success, nil)
+		assert.Error(t, err)
+		assert.False(t, retry)
+	})
+	t.Run("NonBoolean", func(t *testing.T) {
+		failure, err := compileExpression("failure expression", `resource.status`)
+		assert.NoError(t, err)
+		retry, err := matchExpressions([]byte(`{"status":{}}`), nil, failure)
+		assert.Error(t, err)
+		assert.False(t, retry)
+	})
+}
+
 // TestInferSelfLink tests whether the inferred self link for k8s objects are correct.
 func TestInferSelfLink(t *testing.T) {
 	obj := unstructured.Unstructured{}
@@ -0,0 +1,43 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// ShutdownServiceMesh shuts down the sidecar proxy of the workflow's service mesh, if any, so that the pod completes
+// rather than the proxy running forever. It is called once the outputs are saved, as saving them may need the proxy.
+// Failing to shut down the proxy is not an error of the step, it is left to be killed with the pod's other sidecars.
+func (we *WorkflowExecutor) ShutdownServiceMesh(ctx context.Context) {
+	url := os.Getenv(common.EnvVarServiceMeshShutdownURL)
+	if url == "" {
+		return
+	}
+	if err := shutdownServiceMesh(ctx, url); err != nil {
+		log.WithField("url", url).WithError(err).Warn("failed to shut down the service mesh proxy")
+		return
+	}
+	log.WithField("url", url).Info("shut down the service mesh proxy")
+}
+
+func shutdownServiceMesh(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownServiceMesh(t *testing.T) {
+	var method string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		if r.URL.Path != "/quitquitquit" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	assert.NoError(t, shutdownServiceMesh(ctx, server.URL+"/quitquitquit"))
+	assert.Equal(t, http.MethodPost, method)
+	assert.EqualError(t, shutdownServiceMesh(ctx, server.URL+"/shutdown"), "404 Not Found")
+}
@@ -0,0 +1,118 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	// database drivers available to SQL query templates
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util"
+)
+
+// maxSQLResultSize is the maximum size of the JSON result of a SQL query, as it is stored in the node status
+const maxSQLResultSize = 256 * 1024
+
+func (ae *AgentExecutor) executeSQLQueryTemplate(ctx context.Context, tmpl wfv1.Template, result *wfv1.NodeResult) (time.Duration, error) {
+	query := tmpl.SQLQuery
+	if query == nil {
+		return 0, nil
+	}
+	if err := query.Validate(); err != nil {
+		return 0, err
+	}
+
+	dsn, err := util.GetSecrets(ctx, ae.ClientSet, ae.Namespace, query.ConnectionSecret.Name, query.ConnectionSecret.Key)
+	if err != nil {
+		return 0, err
+	}
+	db, err := sql.Open(string(query.Driver), string(dsn))
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	records, err := executeSQLQuery(ctx, db, query)
+	if err != nil {
+		return 0, err
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return 0, err
+	}
+
+	outputs := wfv1.Outputs{
+		Parameters: []wfv1.Parameter{{Name: wfv1.SQLRowCountParameter, Value: wfv1.AnyStringPtr(len(records))}},
+	}
+	if query.OutputArtifact != "" {
+		art, err := ae.saveOutputArtifact(ctx, tmpl, query.OutputArtifact, bytes.NewReader(data))
+		if err != nil {
+			return 0, err
+		}
+		outputs.Artifacts = wfv1.Artifacts{*art}
+	} else {
+		if len(data) > maxSQLResultSize {
+			return 0, fmt.Errorf("query result of %d bytes exceeds the maximum of %d bytes, select fewer rows or columns, or set outputArtifact", len(data), maxSQLResultSize)
+		}
+		outputs.Result = pointer.StringPtr(string(data))
+	}
+	result.Phase = wfv1.NodeSucceeded
+	result.Outputs = &outputs
+	return 0, nil
+}
+
+// executeSQLQuery runs the query against the database, and returns its rows
+func executeSQLQuery(ctx context.Context, db *sql.DB, query *wfv1.SQLQuery) ([]map[string]interface{}, error) {
+	ctx, cancel := context.WithTimeout(ctx, query.GetTimeout())
+	defer cancel()
+
+	args := make([]interface{}, len(query.Args))
+	for i, arg := range query.Args {
+		args[i] = arg
+	}
+	rows, err := db.QueryContext(ctx, query.Query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+	return scanSQLRows(rows, query.GetMaxRows())
+}
+
+// scanSQLRows reads every row into a map of column name to value, failing if there are more than maxRows rows
+func scanSQLRows(rows *sql.Rows, maxRows int64) ([]map[string]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		if int64(len(records)) >= maxRows {
+			return nil, fmt.Errorf("query returned more than %d rows, set maxRows to allow more", maxRows)
+		}
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				record[column] = string(b)
+			} else {
+				record[column] = values[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records, rows.Err()
+}
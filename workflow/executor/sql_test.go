@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestExecuteSQLQuery(t *testing.T) {
+	created := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		query   wfv1.SQLQuery
+		expect  func(mock sqlmock.Sqlmock)
+		records []map[string]interface{}
+		err     string
+	}{
+		{
+			name:  "Types",
+			query: wfv1.SQLQuery{Query: "SELECT * FROM jobs WHERE owner = $1 AND priority > $2", Args: []string{"my-owner", "1"}},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT * FROM jobs WHERE owner = $1 AND priority > $2").
+					WithArgs("my-owner", "1").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score", "active", "created"}).
+						AddRow(int64(1), []byte("my-job"), 1.5, true, created))
+			},
+			records: []map[string]interface{}{{"id": int64(1), "name": "my-job", "score": 1.5, "active": true, "created": created}},
+		},
+		{
+			name:  "Nulls",
+			query: wfv1.SQLQuery{Query: "SELECT id, name FROM jobs"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, name FROM jobs").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(1), nil))
+			},
+			records: []map[string]interface{}{{"id": int64(1), "name": nil}},
+		},
+		{
+			name:  "NoRows",
+			query: wfv1.SQLQuery{Query: "SELECT id FROM jobs"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM jobs").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			records: []map[string]interface{}{},
+		},
+		{
+			name:  "MaxRows",
+			query: wfv1.SQLQuery{Query: "SELECT id FROM jobs", MaxRows: pointer.Int64Ptr(2)},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM jobs").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)))
+			},
+			records: []map[string]interface{}{{"id": int64(1)}, {"id": int64(2)}},
+		},
+		{
+			name:  "MaxRowsExceeded",
+			query: wfv1.SQLQuery{Query: "SELECT id FROM jobs", MaxRows: pointer.Int64Ptr(1)},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM jobs").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)))
+			},
+			err: "query returned more than 1 rows, set maxRows to allow more",
+		},
+		{
+			name:  "QueryError",
+			query: wfv1.SQLQuery{Query: "SELECT id FROM jobs"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM jobs").WillReturnError(errors.New("my-error"))
+			},
+			err: "failed to execute query: my-error",
+		},
+		{
+			name:  "DriverError",
+			query: wfv1.SQLQuery{Query: "SELECT id FROM jobs"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM jobs").WillReturnError(&pq.Error{Code: "42P01", Message: "relation \"jobs\" does not exist"})
+			},
+			err: "failed to execute query: pq: relation \"jobs\" does not exist",
+		},
+		{
+			name:  "RowError",
+			query: wfv1.SQLQuery{Query: "SELECT id FROM jobs"},
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id FROM jobs").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)).AddRow(int64(2)).RowError(1, errors.New("my-error")))
+			},
+			err: "my-error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			require.NoError(t, err)
+			defer db.Close()
+			tt.expect(mock)
+
+			records, err := executeSQLQuery(context.Background(), db, &tt.query)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.records, records)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
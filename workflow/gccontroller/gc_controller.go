@@ -3,19 +3,23 @@ package gccontroller
 import (
 	"container/heap"
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/pointer"
 
 	"github.com/argoproj/argo-workflows/v3/config"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
@@ -205,9 +209,21 @@ func (c *Controller) deleteWorkflow(ctx context.Context, key string) error {
 	// It should be impossible for a workflow to have been queue without a valid key.
 	namespace, name, _ := cache.SplitMetaNamespaceKey(key)
 
-	// Any workflow that was queued must need deleting, therefore we do not check the expiry again.
+	// Any workflow that was queued must need deleting, therefore we do not check the expiry again. But it may need to
+	// wait to be archived, or for its artifacts to be garbage collected. It is queued again when it is updated.
+	obj, exists, err := c.wfInformer.GetStore().GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if un, ok := obj.(*unstructured.Unstructured); exists && ok {
+		if waitingFor := ttlWaitingFor(un); waitingFor != "" {
+			log.Infof("Not deleting garbage collected workflow '%s', waiting for %s", key, waitingFor)
+			return c.setTTLWaitingCondition(ctx, un, waitingFor)
+		}
+	}
+
 	log.Infof("Deleting garbage collected workflow '%s'", key)
-	err := c.wfclientset.ArgoprojV1alpha1().Workflows(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: commonutil.GetDeletePropagation()})
+	err = c.wfclientset.ArgoprojV1alpha1().Workflows(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: commonutil.GetDeletePropagation()})
 	if err != nil {
 		if apierr.IsNotFound(err) {
 			log.Infof("Workflow already deleted '%s'", key)
@@ -246,3 +262,62 @@ func ttl(wf *wfv1.Workflow) (ttl time.Duration, ok bool) {
 	}
 	return 0, false
 }
+
+// ttlWaitingFor returns what the deletion of the workflow waits for, if anything, according to its TTL strategy
+func ttlWaitingFor(un *unstructured.Unstructured) string {
+	wf, err := util.FromUnstructured(un)
+	if err != nil {
+		log.Warnf("Failed to unmarshal workflow %s/%s: %v", un.GetNamespace(), un.GetName(), err)
+		return ""
+	}
+	ttlStrategy := wf.GetTTLStrategy()
+	if ttlStrategy == nil {
+		return ""
+	}
+	if ttlStrategy.WaitForArchive && wf.Labels[common.LabelKeyWorkflowArchivingStatus] == "Pending" {
+		return "the workflow to be archived"
+	}
+	if ttlStrategy.WaitForArtifactGC && artifactGCPending(wf) {
+		return "the artifacts to be garbage collected"
+	}
+	return ""
+}
+
+// artifactGCPending returns whether the artifacts garbage collected on workflow completion are not all deleted yet
+func artifactGCPending(wf *wfv1.Workflow) bool {
+	if !slices.Contains(wf.Finalizers, common.FinalizerArtifactGC) {
+		return false
+	}
+	if wf.Status.ArtifactGCStatus == nil || !wf.Status.ArtifactGCStatus.IsArtifactGCStrategyProcessed(wfv1.ArtifactGCOnWorkflowCompletion) {
+		return true
+	}
+	return len(wf.SearchArtifacts(&wfv1.ArtifactSearchQuery{
+		ArtifactGCStrategies: map[wfv1.ArtifactGCStrategy]bool{wfv1.ArtifactGCOnWorkflowCompletion: true},
+		Deleted:              pointer.BoolPtr(false),
+		NodeTypes:            map[wfv1.NodeType]bool{wfv1.NodeTypePod: true},
+	})) > 0
+}
+
+// setTTLWaitingCondition records what the deletion of the workflow waits for in its conditions
+func (c *Controller) setTTLWaitingCondition(ctx context.Context, un *unstructured.Unstructured, waitingFor string) error {
+	wf, err := util.FromUnstructured(un)
+	if err != nil {
+		return err
+	}
+	message := "Waiting for " + waitingFor + " before deleting the workflow"
+	for _, condition := range wf.Status.Conditions {
+		if condition.Type == wfv1.ConditionTypeTTLWaiting && condition.Message == message {
+			return nil
+		}
+	}
+	wf.Status.Conditions.UpsertCondition(wfv1.Condition{Type: wfv1.ConditionTypeTTLWaiting, Status: metav1.ConditionTrue, Message: message})
+	data, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"conditions": wf.Status.Conditions}})
+	if err != nil {
+		return err
+	}
+	_, err = c.wfclientset.ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, data, metav1.PatchOptions{})
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
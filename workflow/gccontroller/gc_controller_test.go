@@ -14,6 +14,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 )
@@ -567,3 +568,58 @@ func TestGetTTLStrategy(t *testing.T) {
 		assert.Nil(t, ttl)
 	})
 }
+
+func TestTTLWaitingFor(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow([]byte(succeededWf))
+	wf.Labels[common.LabelKeyWorkflowArchivingStatus] = "Pending"
+	wf.Finalizers = []string{common.FinalizerArtifactGC}
+	t.Run("NoWait", func(t *testing.T) {
+		un, err := util.ToUnstructured(wf)
+		assert.NoError(t, err)
+		assert.Empty(t, ttlWaitingFor(un))
+	})
+	t.Run("WaitForArchive", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.Spec.TTLStrategy = &wfv1.TTLStrategy{WaitForArchive: true}
+		un, err := util.ToUnstructured(wf)
+		assert.NoError(t, err)
+		assert.Equal(t, "the workflow to be archived", ttlWaitingFor(un))
+		wf.Labels[common.LabelKeyWorkflowArchivingStatus] = "Archived"
+		un, err = util.ToUnstructured(wf)
+		assert.NoError(t, err)
+		assert.Empty(t, ttlWaitingFor(un))
+	})
+	t.Run("WaitForArtifactGC", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.Spec.TTLStrategy = &wfv1.TTLStrategy{WaitForArtifactGC: true}
+		un, err := util.ToUnstructured(wf)
+		assert.NoError(t, err)
+		assert.Equal(t, "the artifacts to be garbage collected", ttlWaitingFor(un))
+		wf.Status.ArtifactGCStatus = &wfv1.ArtGCStatus{}
+		wf.Status.ArtifactGCStatus.SetArtifactGCStrategyProcessed(wfv1.ArtifactGCOnWorkflowCompletion, true)
+		un, err = util.ToUnstructured(wf)
+		assert.NoError(t, err)
+		assert.Empty(t, ttlWaitingFor(un))
+	})
+}
+
+func TestDeleteWorkflowWaiting(t *testing.T) {
+	controller := newTTLController()
+	ctx := context.Background()
+	wf := wfv1.MustUnmarshalWorkflow([]byte(succeededWf))
+	wf.Labels[common.LabelKeyWorkflowArchivingStatus] = "Pending"
+	wf.Spec.TTLStrategy = &wfv1.TTLStrategy{WaitForArchive: true}
+	_, err := controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Create(ctx, wf, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	un, err := util.ToUnstructured(wf)
+	assert.NoError(t, err)
+	assert.NoError(t, controller.wfInformer.GetStore().Add(un))
+
+	assert.NoError(t, controller.deleteWorkflow(ctx, "default/hello-world-nrgbf"))
+
+	wf, err = controller.wfclientset.ArgoprojV1alpha1().Workflows("default").Get(ctx, "hello-world-nrgbf", metav1.GetOptions{})
+	if assert.NoError(t, err) && assert.Len(t, wf.Status.Conditions, 1) {
+		assert.Equal(t, wfv1.ConditionTypeTTLWaiting, wf.Status.Conditions[0].Type)
+		assert.Equal(t, "Waiting for the workflow to be archived before deleting the workflow", wf.Status.Conditions[0].Message)
+	}
+}
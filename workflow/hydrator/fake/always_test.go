@@ -1,6 +1,7 @@
 package fake
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,24 +10,25 @@ import (
 )
 
 func TestAlways(t *testing.T) {
+	ctx := context.Background()
 	h := Always
 	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{}}}}
 	t.Run("Dehydrate", func(t *testing.T) {
-		err := h.Dehydrate(wf)
+		err := h.Dehydrate(ctx, wf)
 		assert.NoError(t, err)
 		assert.False(t, h.IsHydrated(wf))
 		assert.Empty(t, wf.Status.Nodes)
 		assert.NotEmpty(t, wf.Status.OffloadNodeStatusVersion)
 	})
 	t.Run("Hydrate", func(t *testing.T) {
-		err := h.Hydrate(wf)
+		err := h.Hydrate(ctx, wf)
 		assert.NoError(t, err)
 		assert.True(t, h.IsHydrated(wf))
 		assert.NotEmpty(t, wf.Status.Nodes)
 		assert.Empty(t, wf.Status.OffloadNodeStatusVersion)
 	})
 	t.Run("HydrateWithNodes", func(t *testing.T) {
-		err := h.Dehydrate(wf)
+		err := h.Dehydrate(ctx, wf)
 		assert.NoError(t, err)
 		h.HydrateWithNodes(wf, wfv1.Nodes{"foo": wfv1.NodeStatus{}})
 		assert.NotEmpty(t, wf.Status.Nodes)
@@ -1,6 +1,8 @@
 package fake
 
 import (
+	"context"
+
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
 )
@@ -12,11 +14,11 @@ func (i noop) IsHydrated(wf *wfv1.Workflow) bool {
 	return true
 }
 
-func (i noop) Hydrate(wf *wfv1.Workflow) error {
+func (i noop) Hydrate(_ context.Context, wf *wfv1.Workflow) error {
 	return nil
 }
 
-func (i noop) Dehydrate(wf *wfv1.Workflow) error {
+func (i noop) Dehydrate(_ context.Context, wf *wfv1.Workflow) error {
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package hydrator
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -19,15 +20,17 @@ type Interface interface {
 	// whether or not the workflow in hydrated
 	IsHydrated(wf *wfv1.Workflow) bool
 	// hydrate the workflow - doing nothing if it is already hydrated
-	Hydrate(wf *wfv1.Workflow) error
+	Hydrate(ctx context.Context, wf *wfv1.Workflow) error
 	// dehydrate the workflow - doing nothing if already dehydrated
-	Dehydrate(wf *wfv1.Workflow) error
+	Dehydrate(ctx context.Context, wf *wfv1.Workflow) error
 	// hydrate the workflow using the provided nodes
 	HydrateWithNodes(wf *wfv1.Workflow, nodes wfv1.Nodes)
 }
 
-func New(offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo) Interface {
-	return &hydrator{offloadNodeStatusRepo}
+// New returns a hydrator of the nodes offloaded with the repository, and of the values of their parameters offloaded
+// by the controller loaded with the loader, which may be nil if they cannot be loaded
+func New(offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, parameters ParametersLoader) Interface {
+	return &hydrator{offloadNodeStatusRepo, parameters}
 }
 
 var alwaysOffloadNodeStatus = os.Getenv("ALWAYS_OFFLOAD_NODE_STATUS") == "true"
@@ -38,6 +41,7 @@ func init() {
 
 type hydrator struct {
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
+	parameters            ParametersLoader
 }
 
 func (h hydrator) IsHydrated(wf *wfv1.Workflow) bool {
@@ -69,7 +73,7 @@ var readRetry = wait.Backoff{Steps: 5, Duration: 100 * time.Millisecond, Factor:
 // 5	31.00
 var writeRetry = wait.Backoff{Steps: 5, Duration: 1 * time.Second, Factor: 2}
 
-func (h hydrator) Hydrate(wf *wfv1.Workflow) error {
+func (h hydrator) Hydrate(ctx context.Context, wf *wfv1.Workflow) error {
 	err := packer.DecompressWorkflow(wf)
 	if err != nil {
 		return err
@@ -87,13 +91,16 @@ func (h hydrator) Hydrate(wf *wfv1.Workflow) error {
 		log.WithField("Workflow Size", wf.Size()).Info("Workflow hydrated")
 	}
 
-	return nil
+	return h.hydrateParameters(ctx, wf)
 }
 
-func (h hydrator) Dehydrate(wf *wfv1.Workflow) error {
+func (h hydrator) Dehydrate(ctx context.Context, wf *wfv1.Workflow) error {
 	if !h.IsHydrated(wf) {
 		return nil
 	}
+	if err := h.dehydrateParameters(ctx, wf); err != nil {
+		return err
+	}
 	var err error
 	log.WithField("Workflow Size", wf.Size()).Info("Workflow to be dehydrated")
 	if !alwaysOffloadNodeStatus {
@@ -1,6 +1,7 @@
 package hydrator
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,28 +16,29 @@ import (
 
 func TestHydrator(t *testing.T) {
 	defer packer.SetMaxWorkflowSize(260)()
+	ctx := context.Background()
 
 	t.Run("Dehydrate", func(t *testing.T) {
 		t.Run("Packed", func(t *testing.T) {
-			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{})
+			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{}, nil)
 			wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{CompressedNodes: "foo"}}
-			err := hydrator.Dehydrate(wf)
+			err := hydrator.Dehydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.NotEmpty(t, wf.Status.CompressedNodes)
 			}
 		})
 		t.Run("Offloaded", func(t *testing.T) {
-			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{})
+			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{}, nil)
 			wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{OffloadNodeStatusVersion: "foo"}}
-			err := hydrator.Dehydrate(wf)
+			err := hydrator.Dehydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.True(t, wf.Status.IsOffloadNodeStatus())
 			}
 		})
 		t.Run("Noop", func(t *testing.T) {
-			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{})
+			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{}, nil)
 			wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{}}}}
-			err := hydrator.Dehydrate(wf)
+			err := hydrator.Dehydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.NotEmpty(t, wf.Status.Nodes)
 				assert.Empty(t, wf.Status.CompressedNodes)
@@ -44,9 +46,9 @@ func TestHydrator(t *testing.T) {
 			}
 		})
 		t.Run("Pack", func(t *testing.T) {
-			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{})
+			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{}, nil)
 			wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{}, "bar": wfv1.NodeStatus{}}}}
-			err := hydrator.Dehydrate(wf)
+			err := hydrator.Dehydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.Empty(t, wf.Status.Nodes)
 				assert.NotEmpty(t, wf.Status.CompressedNodes)
@@ -56,13 +58,13 @@ func TestHydrator(t *testing.T) {
 		t.Run("Offload", func(t *testing.T) {
 			offloadNodeStatusRepo := &sqldbmocks.OffloadNodeStatusRepo{}
 			offloadNodeStatusRepo.On("Save", "my-uid", "my-ns", mock.Anything).Return("my-offload-version", nil)
-			hydrator := New(offloadNodeStatusRepo)
+			hydrator := New(offloadNodeStatusRepo, nil)
 			wf := &wfv1.Workflow{
 				ObjectMeta: metav1.ObjectMeta{UID: "my-uid", Namespace: "my-ns"},
 				Spec:       wfv1.WorkflowSpec{Entrypoint: "main"},
 				Status:     wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{}, "bar": wfv1.NodeStatus{}, "baz": wfv1.NodeStatus{}, "qux": wfv1.NodeStatus{}}},
 			}
-			err := hydrator.Dehydrate(wf)
+			err := hydrator.Dehydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.Empty(t, wf.Status.Nodes)
 				assert.Empty(t, wf.Status.CompressedNodes)
@@ -73,13 +75,13 @@ func TestHydrator(t *testing.T) {
 		t.Run("WorkflowTooLargeButOffloadNotSupported", func(t *testing.T) {
 			offloadNodeStatusRepo := &sqldbmocks.OffloadNodeStatusRepo{}
 			offloadNodeStatusRepo.On("Save", "my-uid", "my-ns", mock.Anything).Return("my-offload-version", sqldb.OffloadNotSupportedError)
-			hydrator := New(offloadNodeStatusRepo)
+			hydrator := New(offloadNodeStatusRepo, nil)
 			wf := &wfv1.Workflow{
 				ObjectMeta: metav1.ObjectMeta{UID: "my-uid", Namespace: "my-ns"},
 				Spec:       wfv1.WorkflowSpec{Entrypoint: "main"},
 				Status:     wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{}, "bar": wfv1.NodeStatus{}, "baz": wfv1.NodeStatus{}, "qux": wfv1.NodeStatus{}}},
 			}
-			err := hydrator.Dehydrate(wf)
+			err := hydrator.Dehydrate(ctx, wf)
 			assert.Error(t, err)
 		})
 	})
@@ -87,12 +89,12 @@ func TestHydrator(t *testing.T) {
 		t.Run("Offloaded", func(t *testing.T) {
 			offloadNodeStatusRepo := &sqldbmocks.OffloadNodeStatusRepo{}
 			offloadNodeStatusRepo.On("Get", "my-uid", "my-offload-version").Return(wfv1.Nodes{"foo": wfv1.NodeStatus{}}, nil)
-			hydrator := New(offloadNodeStatusRepo)
+			hydrator := New(offloadNodeStatusRepo, nil)
 			wf := &wfv1.Workflow{
 				ObjectMeta: metav1.ObjectMeta{UID: "my-uid"},
 				Status:     wfv1.WorkflowStatus{OffloadNodeStatusVersion: "my-offload-version"},
 			}
-			err := hydrator.Hydrate(wf)
+			err := hydrator.Hydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.NotEmpty(t, wf.Status.Nodes)
 				assert.Empty(t, wf.Status.CompressedNodes)
@@ -102,21 +104,21 @@ func TestHydrator(t *testing.T) {
 		t.Run("OffloadingDisabled", func(t *testing.T) {
 			offloadNodeStatusRepo := &sqldbmocks.OffloadNodeStatusRepo{}
 			offloadNodeStatusRepo.On("Get", "my-uid", "my-offload-version").Return(nil, sqldb.OffloadNotSupportedError)
-			hydrator := New(offloadNodeStatusRepo)
+			hydrator := New(offloadNodeStatusRepo, nil)
 			wf := &wfv1.Workflow{
 				ObjectMeta: metav1.ObjectMeta{UID: "my-uid"},
 				Status:     wfv1.WorkflowStatus{OffloadNodeStatusVersion: "my-offload-version"},
 			}
-			err := hydrator.Hydrate(wf)
+			err := hydrator.Hydrate(ctx, wf)
 			assert.Error(t, err)
 		})
 		t.Run("Packed", func(t *testing.T) {
-			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{})
+			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{}, nil)
 			wf := &wfv1.Workflow{
 				ObjectMeta: metav1.ObjectMeta{UID: "my-uid"},
 				Status:     wfv1.WorkflowStatus{CompressedNodes: "H4sIAAAAAAAA/6pWSkosUrKqVspMUbJSUtJRykvMTYWwUjKLC3ISK/3gAiWVBVBWcUliUUlqimOJklVeaU6OjlJaZl5mcQZCpFZHKS0/nwbm1gICAAD//8SSRamxAAAA"},
 			}
-			err := hydrator.Hydrate(wf)
+			err := hydrator.Hydrate(ctx, wf)
 			if assert.NoError(t, err) {
 				assert.NotEmpty(t, wf.Status.Nodes)
 				assert.Empty(t, wf.Status.CompressedNodes)
@@ -124,10 +126,51 @@ func TestHydrator(t *testing.T) {
 			}
 		})
 		t.Run("Hydrated", func(t *testing.T) {
-			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{})
+			hydrator := New(&sqldbmocks.OffloadNodeStatusRepo{}, nil)
 			wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{}}
-			err := hydrator.Hydrate(wf)
+			err := hydrator.Hydrate(ctx, wf)
 			assert.NoError(t, err)
 		})
 	})
 }
+
+func TestHydratorParameters(t *testing.T) {
+	ctx := context.Background()
+	result := "large"
+	parameters := func(_ context.Context, wf *wfv1.Workflow, node wfv1.NodeStatus) (map[string]string, error) {
+		assert.Equal(t, "my-cm", node.ParametersConfigMap)
+		return map[string]string{"outputs.result": result}, nil
+	}
+	newWorkflow := func(value *string) *wfv1.Workflow {
+		return &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"my-node": {ID: "my-node", ParametersConfigMap: "my-cm", Outputs: &wfv1.Outputs{Result: value}},
+			"other":   {ID: "other", Outputs: &wfv1.Outputs{Result: &result}},
+		}}}
+	}
+
+	t.Run("Hydrate", func(t *testing.T) {
+		wf := newWorkflow(nil)
+		if assert.NoError(t, New(sqldb.ExplosiveOffloadNodeStatusRepo, parameters).Hydrate(ctx, wf)) {
+			assert.Equal(t, result, *wf.Status.Nodes["my-node"].Outputs.Result)
+		}
+	})
+	t.Run("HydrateNotSupported", func(t *testing.T) {
+		assert.Error(t, New(sqldb.ExplosiveOffloadNodeStatusRepo, nil).Hydrate(ctx, newWorkflow(nil)))
+	})
+	t.Run("Dehydrate", func(t *testing.T) {
+		wf := newWorkflow(&result)
+		nodes := wf.Status.Nodes
+		if assert.NoError(t, New(sqldb.ExplosiveOffloadNodeStatusRepo, parameters).Dehydrate(ctx, wf)) {
+			assert.Nil(t, wf.Status.Nodes["my-node"].Outputs.Result)
+			assert.Equal(t, result, *wf.Status.Nodes["other"].Outputs.Result)
+			assert.Equal(t, result, *nodes["my-node"].Outputs.Result, "the nodes are not modified")
+		}
+	})
+	t.Run("DehydrateChanged", func(t *testing.T) {
+		changed := "changed"
+		wf := newWorkflow(&changed)
+		if assert.NoError(t, New(sqldb.ExplosiveOffloadNodeStatusRepo, parameters).Dehydrate(ctx, wf)) {
+			assert.Equal(t, changed, *wf.Status.Nodes["my-node"].Outputs.Result)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package hydrator
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const (
+	inputParameterKeyPrefix  = "inputs.parameters."
+	outputParameterKeyPrefix = "outputs.parameters."
+	outputResultKey          = "outputs.result"
+)
+
+// ParametersLoader loads the values of the parameters, and result, of the node that were offloaded, by their key (see
+// ParameterValues)
+type ParametersLoader func(ctx context.Context, wf *wfv1.Workflow, node wfv1.NodeStatus) (map[string]string, error)
+
+// ConfigMapGetter gets the ConfigMap
+type ConfigMapGetter func(ctx context.Context, namespace, name string) (*apiv1.ConfigMap, error)
+
+// NewParametersLoader returns a loader of the values offloaded into the ConfigMap of their node, or the database
+func NewParametersLoader(getConfigMap ConfigMapGetter, offloadParametersRepo sqldb.OffloadParametersRepo) ParametersLoader {
+	return func(ctx context.Context, wf *wfv1.Workflow, node wfv1.NodeStatus) (map[string]string, error) {
+		if node.ParametersDatabaseOffload {
+			return offloadParametersRepo.Get(string(wf.UID), node.ID)
+		}
+		cm, err := getConfigMap(ctx, wf.Namespace, node.ParametersConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		return cm.Data, nil
+	}
+}
+
+// KubeConfigMapGetter gets ConfigMaps from the Kubernetes API
+func KubeConfigMapGetter(kubeClient kubernetes.Interface) ConfigMapGetter {
+	return ContextConfigMapGetter(func(context.Context) kubernetes.Interface { return kubeClient })
+}
+
+// ContextConfigMapGetter gets ConfigMaps from the Kubernetes API with the client of the context, e.g. the client of the
+// caller of the Argo Server
+func ContextConfigMapGetter(getKubeClient func(ctx context.Context) kubernetes.Interface) ConfigMapGetter {
+	return func(ctx context.Context, namespace, name string) (*apiv1.ConfigMap, error) {
+		return getKubeClient(ctx).CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+}
+
+// hasOffloadedParameters returns whether values of the parameters, or result, of the node were offloaded
+func hasOffloadedParameters(node wfv1.NodeStatus) bool {
+	return node.ParametersConfigMap != "" || node.ParametersDatabaseOffload
+}
+
+// ParameterValues returns the values of the parameters, and result, of the node by their key, e.g.
+// `outputs.parameters.<name>`
+func ParameterValues(node wfv1.NodeStatus) map[string]string {
+	values := map[string]string{}
+	if node.Inputs != nil {
+		for _, p := range node.Inputs.Parameters {
+			if p.Value != nil {
+				values[inputParameterKeyPrefix+p.Name] = p.Value.String()
+			}
+		}
+	}
+	if node.Outputs != nil {
+		for _, p := range node.Outputs.Parameters {
+			if p.Value != nil {
+				values[outputParameterKeyPrefix+p.Name] = p.Value.String()
+			}
+		}
+		if node.Outputs.Result != nil {
+			values[outputResultKey] = *node.Outputs.Result
+		}
+	}
+	return values
+}
+
+// SetParameterValues sets the values of the parameters, and result, of the node with the keys. A nil value removes it.
+func SetParameterValues(node *wfv1.NodeStatus, values map[string]*string) {
+	if node.Inputs != nil {
+		for i, p := range node.Inputs.Parameters {
+			if v, ok := values[inputParameterKeyPrefix+p.Name]; ok {
+				node.Inputs.Parameters[i].Value = anyStringPtr(v)
+			}
+		}
+	}
+	if node.Outputs != nil {
+		for i, p := range node.Outputs.Parameters {
+			if v, ok := values[outputParameterKeyPrefix+p.Name]; ok {
+				node.Outputs.Parameters[i].Value = anyStringPtr(v)
+			}
+		}
+		if v, ok := values[outputResultKey]; ok {
+			node.Outputs.Result = v
+		}
+	}
+}
+
+func anyStringPtr(v *string) *wfv1.AnyString {
+	if v == nil {
+		return nil
+	}
+	return wfv1.AnyStringPtr(*v)
+}
+
+// hydrateParameters sets the values of the parameters, and results, of the nodes that were offloaded
+func (h hydrator) hydrateParameters(ctx context.Context, wf *wfv1.Workflow) error {
+	for id, node := range wf.Status.Nodes {
+		if !hasOffloadedParameters(node) {
+			continue
+		}
+		if h.parameters == nil {
+			return fmt.Errorf("node %s has offloaded parameters, but loading them is not supported", id)
+		}
+		offloaded, err := h.parameters(ctx, wf, node)
+		if err != nil {
+			return fmt.Errorf("failed to get the offloaded parameters of node %s: %w", id, err)
+		}
+		values := map[string]*string{}
+		for k, v := range offloaded {
+			v := v
+			values[k] = &v
+		}
+		n := node.DeepCopy()
+		SetParameterValues(n, values)
+		wf.Status.Nodes[id] = *n
+	}
+	return nil
+}
+
+// dehydrateParameters removes the values of the parameters, and results, of the nodes that were offloaded, so that a
+// hydrated workflow is not updated with them. The nodes are copied rather than modified.
+func (h hydrator) dehydrateParameters(ctx context.Context, wf *wfv1.Workflow) error {
+	var nodes wfv1.Nodes
+	for id, node := range wf.Status.Nodes {
+		if !hasOffloadedParameters(node) || h.parameters == nil {
+			continue
+		}
+		offloaded, err := h.parameters(ctx, wf, node)
+		if err != nil {
+			return fmt.Errorf("failed to get the offloaded parameters of node %s: %w", id, err)
+		}
+		removed := map[string]*string{}
+		for k, v := range ParameterValues(node) {
+			if value, ok := offloaded[k]; ok && value == v {
+				removed[k] = nil
+			}
+		}
+		if len(removed) == 0 {
+			continue
+		}
+		if nodes == nil {
+			nodes = make(wfv1.Nodes, len(wf.Status.Nodes))
+			for id, node := range wf.Status.Nodes {
+				nodes[id] = node
+			}
+		}
+		n := node.DeepCopy()
+		SetParameterValues(n, removed)
+		nodes[id] = *n
+	}
+	if nodes != nil {
+		wf.Status.Nodes = nodes
+	}
+	return nil
+}
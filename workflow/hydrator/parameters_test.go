@@ -0,0 +1,64 @@
+package hydrator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type offloadParametersRepo struct {
+	sqldb.OffloadParametersRepo
+}
+
+func (r offloadParametersRepo) Get(uid, nodeID string) (map[string]string, error) {
+	return map[string]string{"outputs.result": uid + "/" + nodeID}, nil
+}
+
+func TestNewParametersLoader(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "my-ns"},
+		Data:       map[string]string{"outputs.result": "my-value"},
+	})
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", UID: "my-uid"}}
+	load := NewParametersLoader(KubeConfigMapGetter(kubeClient), offloadParametersRepo{})
+
+	t.Run("ConfigMap", func(t *testing.T) {
+		values, err := load(ctx, wf, wfv1.NodeStatus{ID: "my-node", ParametersConfigMap: "my-cm"})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"outputs.result": "my-value"}, values)
+		}
+	})
+	t.Run("ConfigMapNotFound", func(t *testing.T) {
+		_, err := load(ctx, wf, wfv1.NodeStatus{ID: "my-node", ParametersConfigMap: "not-found"})
+		assert.Error(t, err)
+	})
+	t.Run("Database", func(t *testing.T) {
+		values, err := load(ctx, wf, wfv1.NodeStatus{ID: "my-node", ParametersDatabaseOffload: true})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"outputs.result": "my-uid/my-node"}, values)
+		}
+	})
+	t.Run("DatabaseNotSupported", func(t *testing.T) {
+		_, err := NewParametersLoader(KubeConfigMapGetter(kubeClient), sqldb.ExplosiveOffloadParametersRepo)(ctx, wf, wfv1.NodeStatus{ID: "my-node", ParametersDatabaseOffload: true})
+		assert.Equal(t, sqldb.OffloadParametersNotSupportedError, err)
+	})
+}
+
+func TestContextConfigMapGetter(t *testing.T) {
+	type key struct{}
+	kubeClient := fake.NewSimpleClientset(&apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "my-ns"}})
+	get := ContextConfigMapGetter(func(ctx context.Context) kubernetes.Interface { return ctx.Value(key{}).(kubernetes.Interface) })
+	cm, err := get(context.WithValue(context.Background(), key{}, kubernetes.Interface(kubeClient)), "my-ns", "my-cm")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "my-cm", cm.Name)
+	}
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var ArchivePrunedMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "archive_pruned_total",
+		Help:      "Number of archived workflows deleted by pruning. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_archive_pruned_total",
+	},
+	[]string{"rule"},
+)
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var CostMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "cost_total",
+		Help:      "Total estimated cost of the completed pods of workflows, priced by the pricing config. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_cost_total",
+	},
+	[]string{"namespace"},
+)
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// otherLabelValue replaces the label values that are not allowed, to limit the cardinality of the duration histograms
+const otherLabelValue = "other"
+
+var defaultNodeDurationBuckets = []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600, 7200, 14400}
+
+// DurationHistogramsConfig configures the opt-in node duration histograms and reconciliation latency percentiles
+type DurationHistogramsConfig struct {
+	Enabled bool
+	// Buckets are the upper bounds of the node duration buckets in seconds, nil is the default buckets
+	Buckets []float64
+	// Namespaces are the namespaces that label node durations, any other is labelled "other". Empty allows any.
+	Namespaces []string
+	// TemplateRefs are the template references that label node durations, any other is labelled "other". Empty allows any.
+	TemplateRefs []string
+}
+
+type durationHistograms struct {
+	nodeDurations      *prometheus.HistogramVec
+	reconcileDurations prometheus.Summary
+	namespaces         map[string]bool
+	templateRefs       map[string]bool
+}
+
+func newDurationHistograms(config DurationHistogramsConfig) *durationHistograms {
+	if !config.Enabled {
+		return nil
+	}
+	buckets := config.Buckets
+	if len(buckets) == 0 {
+		buckets = defaultNodeDurationBuckets
+	}
+	return &durationHistograms{
+		nodeDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: argoNamespace,
+			Subsystem: workflowsSubsystem,
+			Name:      "node_duration_seconds",
+			Help:      "Histogram of durations of completed nodes. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_node_duration_seconds",
+			Buckets:   buckets,
+		}, []string{"namespace", "template_ref", "phase"}),
+		reconcileDurations: prometheus.NewSummary(prometheus.SummaryOpts{
+			Namespace:  argoNamespace,
+			Subsystem:  workflowsSubsystem,
+			Name:       "reconcile_duration_seconds",
+			Help:       "Percentiles of durations of workflow reconciliations. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_reconcile_duration_seconds",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		namespaces:   allowList(config.Namespaces),
+		templateRefs: allowList(config.TemplateRefs),
+	}
+}
+
+func allowList(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(values))
+	for _, v := range values {
+		allowed[v] = true
+	}
+	return allowed
+}
+
+func allowed(allowList map[string]bool, value string) string {
+	if allowList != nil && !allowList[value] {
+		return otherLabelValue
+	}
+	return value
+}
+
+// NodeCompleted observes the duration of a completed node, if the duration histograms are enabled. The template ref is
+// "<template name>/<template>", or empty for templates defined in the workflow.
+func (m *Metrics) NodeCompleted(namespace, templateRef string, phase v1alpha1.NodePhase, duration time.Duration) {
+	if m.durationHistograms == nil {
+		return
+	}
+	h := m.durationHistograms
+	h.nodeDurations.WithLabelValues(allowed(h.namespaces, namespace), allowed(h.templateRefs, templateRef), string(phase)).Observe(duration.Seconds())
+}
+
+func (h *durationHistograms) Describe(ch chan<- *prometheus.Desc) {
+	if h == nil {
+		return
+	}
+	h.nodeDurations.Describe(ch)
+	h.reconcileDurations.Describe(ch)
+}
+
+func (h *durationHistograms) Collect(ch chan<- prometheus.Metric) {
+	if h == nil {
+		return
+	}
+	h.nodeDurations.Collect(ch)
+	h.reconcileDurations.Collect(ch)
+}
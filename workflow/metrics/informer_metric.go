@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var InformerCacheSizeMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "informer_cache_size",
+		Help:      "Number of objects cached by the informer. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_informer_cache_size",
+	},
+	[]string{"informer"},
+)
+
+var InformerLagMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "informer_lag_seconds",
+		Help:      "Seconds since the informer last received a change from its watch. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_informer_lag_seconds",
+	},
+	[]string{"informer"},
+)
@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var MemoizationCacheMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "memoization_cache_total",
+		Help:      "Number of memoization cache lookups. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_memoization_cache_total",
+	},
+	[]string{"cache_name", "template_name", "result"},
+)
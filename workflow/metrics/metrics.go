@@ -27,6 +27,9 @@ type ServerConfig struct {
 	TTL          time.Duration
 	IgnoreErrors bool
 	Secure       bool
+	// MaxCustomMetricSeries limits the number of series of each custom metric, zero is unlimited
+	MaxCustomMetricSeries int
+	DurationHistograms    DurationHistogramsConfig
 }
 
 func (s ServerConfig) SameServerAs(other ServerConfig) bool {
@@ -35,6 +38,7 @@ func (s ServerConfig) SameServerAs(other ServerConfig) bool {
 
 type metric struct {
 	metric      prometheus.Metric
+	name        string
 	lastUpdated time.Time
 }
 
@@ -53,6 +57,7 @@ type Metrics struct {
 	customMetrics      map[string]metric
 	workqueueMetrics   map[string]prometheus.Metric
 	workersBusy        map[string]prometheus.Gauge
+	durationHistograms *durationHistograms
 
 	// Used to quickly check if a metric desc is already used by the system
 	defaultMetricDescs map[string]bool
@@ -84,6 +89,7 @@ func New(metricsConfig, telemetryConfig ServerConfig) *Metrics {
 		customMetrics:      make(map[string]metric),
 		workqueueMetrics:   make(map[string]prometheus.Metric),
 		workersBusy:        make(map[string]prometheus.Gauge),
+		durationHistograms: newDurationHistograms(metricsConfig.DurationHistograms),
 		defaultMetricDescs: make(map[string]bool),
 		metricNameHelps:    make(map[string]string),
 		logMetric: prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -155,6 +161,9 @@ func (m *Metrics) OperationCompleted(durationSeconds float64) {
 	defer m.mutex.Unlock()
 
 	m.operationDurations.Observe(durationSeconds)
+	if m.durationHistograms != nil {
+		m.durationHistograms.reconcileDurations.Observe(durationSeconds)
+	}
 }
 
 func (m *Metrics) GetCustomMetric(key string) prometheus.Metric {
@@ -179,7 +188,12 @@ func (m *Metrics) UpsertCustomMetric(key string, ownerKey string, newMetric prom
 	} else {
 		m.metricNameHelps[name] = help
 	}
-	m.customMetrics[key] = metric{metric: newMetric, lastUpdated: time.Now()}
+	if _, exists := m.customMetrics[key]; !exists && m.metricsConfig.MaxCustomMetricSeries > 0 {
+		if series := m.customMetricSeries(name); series >= m.metricsConfig.MaxCustomMetricSeries {
+			return fmt.Errorf("metric '%s' already has %d series, which is the maximum allowed: use fewer distinct label values", name, series)
+		}
+	}
+	m.customMetrics[key] = metric{metric: newMetric, name: name, lastUpdated: time.Now()}
 
 	// If this is a realtime metric, track it
 	if realtime {
@@ -189,6 +203,17 @@ func (m *Metrics) UpsertCustomMetric(key string, ownerKey string, newMetric prom
 	return nil
 }
 
+// customMetricSeries returns the number of custom metrics with the given name, i.e. its label cardinality
+func (m *Metrics) customMetricSeries(name string) int {
+	series := 0
+	for _, metric := range m.customMetrics {
+		if metric.name == name {
+			series++
+		}
+	}
+	return series
+}
+
 func (m *Metrics) SetWorkflowPhaseGauge(phase v1alpha1.NodePhase, num int) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -175,3 +175,56 @@ func TestRealTimeMetricDeletion(t *testing.T) {
 	assert.Empty(t, m.workflows["456"])
 	assert.Len(t, m.customMetrics, 1)
 }
+
+func TestMaxCustomMetricSeries(t *testing.T) {
+	config := ServerConfig{
+		Enabled:               true,
+		Path:                  DefaultMetricsServerPath,
+		Port:                  DefaultMetricsServerPort,
+		MaxCustomMetricSeries: 2,
+	}
+	m := New(config, config)
+
+	for _, value := range []string{"a", "b"} {
+		err := m.UpsertCustomMetric("metric-"+value, "", newCounter("series", "series", map[string]string{"label": value}), false)
+		assert.NoError(t, err)
+	}
+	err := m.UpsertCustomMetric("metric-c", "", newCounter("series", "series", map[string]string{"label": "c"}), false)
+	assert.EqualError(t, err, "metric 'series' already has 2 series, which is the maximum allowed: use fewer distinct label values")
+	assert.Nil(t, m.GetCustomMetric("metric-c"))
+
+	// existing series can still be updated, and other metrics are not affected
+	err = m.UpsertCustomMetric("metric-a", "", newCounter("series", "series", map[string]string{"label": "a"}), false)
+	assert.NoError(t, err)
+	err = m.UpsertCustomMetric("other", "", newCounter("other", "other", nil), false)
+	assert.NoError(t, err)
+}
+
+func TestDurationHistograms(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m := New(ServerConfig{}, ServerConfig{})
+		m.NodeCompleted("my-ns", "my-wftmpl/my-tmpl", v1alpha1.NodeSucceeded, time.Minute)
+		m.OperationCompleted(0.05)
+		assert.Nil(t, m.durationHistograms)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		m := New(ServerConfig{DurationHistograms: DurationHistogramsConfig{
+			Enabled:      true,
+			Buckets:      []float64{10, 100},
+			Namespaces:   []string{"my-ns"},
+			TemplateRefs: []string{"my-wftmpl/my-tmpl"},
+		}}, ServerConfig{})
+		m.NodeCompleted("my-ns", "my-wftmpl/my-tmpl", v1alpha1.NodeSucceeded, time.Minute)
+		m.NodeCompleted("your-ns", "your-wftmpl/your-tmpl", v1alpha1.NodeFailed, time.Second)
+		m.OperationCompleted(0.05)
+
+		h := m.durationHistograms
+		allowed := write(h.nodeDurations.WithLabelValues("my-ns", "my-wftmpl/my-tmpl", "Succeeded").(prometheus.Metric))
+		assert.Equal(t, uint64(1), allowed.Histogram.GetSampleCount())
+		assert.Equal(t, uint64(0), allowed.Histogram.Bucket[0].GetCumulativeCount())
+		assert.Equal(t, uint64(1), allowed.Histogram.Bucket[1].GetCumulativeCount())
+		other := write(h.nodeDurations.WithLabelValues("other", "other", "Failed").(prometheus.Metric))
+		assert.Equal(t, uint64(1), other.Histogram.GetSampleCount())
+		assert.Equal(t, uint64(1), write(h.reconcileDurations).Summary.GetSampleCount())
+	})
+}
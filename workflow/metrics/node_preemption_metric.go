@@ -0,0 +1,15 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var NodePreemptionMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: argoNamespace,
+		Subsystem: workflowsSubsystem,
+		Name:      "node_preemptions_total",
+		Help:      "Number of workflow pods whose node was preempted, shut down or lost. https://argoproj.github.io/argo-workflows/metrics/#argo_workflows_node_preemptions_total",
+	},
+	[]string{"namespace", "reason", "rescheduled"},
+)
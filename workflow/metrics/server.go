@@ -97,7 +97,14 @@ func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 		ch <- metric.Desc()
 	}
 	m.logMetric.Describe(ch)
+	m.durationHistograms.Describe(ch)
+	ArchivePrunedMetric.Describe(ch)
+	CostMetric.Describe(ch)
+	InformerCacheSizeMetric.Describe(ch)
+	InformerLagMetric.Describe(ch)
+	NodePreemptionMetric.Describe(ch)
 	K8sRequestTotalMetric.Describe(ch)
+	MemoizationCacheMetric.Describe(ch)
 	PodMissingMetric.Describe(ch)
 	WorkflowConditionMetric.Describe(ch)
 }
@@ -107,7 +114,14 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 		ch <- metric
 	}
 	m.logMetric.Collect(ch)
+	m.durationHistograms.Collect(ch)
+	ArchivePrunedMetric.Collect(ch)
+	CostMetric.Collect(ch)
+	InformerCacheSizeMetric.Collect(ch)
+	InformerLagMetric.Collect(ch)
+	NodePreemptionMetric.Collect(ch)
 	K8sRequestTotalMetric.Collect(ch)
+	MemoizationCacheMetric.Collect(ch)
 	PodMissingMetric.Collect(ch)
 	WorkflowConditionMetric.Collect(ch)
 }
@@ -1,9 +1,11 @@
 package metrics
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -236,12 +238,46 @@ func ValidateMetricValues(metric *wfv1.Prometheus) error {
 	if metric.Counter != nil && metric.Counter.Value == "" {
 		return errors.New("missing counter.value")
 	}
-	if metric.Histogram != nil && metric.Histogram.Value == "" {
-		return errors.New("missing histogram.value")
+	if metric.Histogram != nil {
+		if metric.Histogram.Value == "" {
+			return errors.New("missing histogram.value")
+		}
+		if len(metric.Histogram.Buckets) == 0 && metric.Histogram.BucketsFrom == "" {
+			return errors.New("histogram must have one of buckets or bucketsFrom")
+		}
+		if len(metric.Histogram.Buckets) > 0 && metric.Histogram.BucketsFrom != "" {
+			return errors.New("histogram must have only one of buckets or bucketsFrom")
+		}
+		if metric.Histogram.BucketsFrom != "" && !strings.Contains(metric.Histogram.BucketsFrom, "{{") {
+			if _, err := ParseHistogramBuckets(metric.Histogram.BucketsFrom); err != nil {
+				return fmt.Errorf("invalid histogram.bucketsFrom: %w", err)
+			}
+		}
 	}
 	return nil
 }
 
+// ParseHistogramBuckets parses a JSON list of numbers, such as the value of histogram.bucketsFrom, into sorted and
+// de-duplicated buckets
+func ParseHistogramBuckets(value string) ([]wfv1.Amount, error) {
+	var values []float64
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return nil, fmt.Errorf("buckets must be a JSON list of numbers: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, errors.New("buckets must not be empty")
+	}
+	sort.Float64s(values)
+	buckets := make([]wfv1.Amount, 0, len(values))
+	for i, v := range values {
+		if i > 0 && v == values[i-1] {
+			continue
+		}
+		buckets = append(buckets, wfv1.Amount{Value: json.Number(strconv.FormatFloat(v, 'f', -1, 64))})
+	}
+	return buckets, nil
+}
+
 func ValidateMetricLabels(metrics map[string]string) error {
 	for name := range metrics {
 		if !IsValidMetricName(name) {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 func TestRecoverMetric(t *testing.T) {
@@ -25,3 +27,26 @@ func TestRecoverMetric(t *testing.T) {
 		})
 	}
 }
+
+func TestParseHistogramBuckets(t *testing.T) {
+	buckets, err := ParseHistogramBuckets("[5, 0.5, 1, 5]")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []wfv1.Amount{{Value: "0.5"}, {Value: "1"}, {Value: "5"}}, buckets)
+	}
+	_, err = ParseHistogramBuckets("[]")
+	assert.EqualError(t, err, "buckets must not be empty")
+	_, err = ParseHistogramBuckets(`["a"]`)
+	assert.Error(t, err)
+}
+
+func TestValidateHistogramValues(t *testing.T) {
+	histogram := func(h *wfv1.Histogram) *wfv1.Prometheus {
+		return &wfv1.Prometheus{Name: "name", Help: "help", Histogram: h}
+	}
+	assert.NoError(t, ValidateMetricValues(histogram(&wfv1.Histogram{Value: "1", Buckets: []wfv1.Amount{{Value: "1"}}})))
+	assert.NoError(t, ValidateMetricValues(histogram(&wfv1.Histogram{Value: "1", BucketsFrom: "{{outputs.parameters.buckets}}"})))
+	assert.NoError(t, ValidateMetricValues(histogram(&wfv1.Histogram{Value: "1", BucketsFrom: "[1, 2]"})))
+	assert.EqualError(t, ValidateMetricValues(histogram(&wfv1.Histogram{Value: "1"})), "histogram must have one of buckets or bucketsFrom")
+	assert.EqualError(t, ValidateMetricValues(histogram(&wfv1.Histogram{Value: "1", Buckets: []wfv1.Amount{{Value: "1"}}, BucketsFrom: "[1]"})), "histogram must have only one of buckets or bucketsFrom")
+	assert.Error(t, ValidateMetricValues(histogram(&wfv1.Histogram{Value: "1", BucketsFrom: "1, 2"})))
+}
@@ -0,0 +1,47 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+type httpPublisher struct {
+	url     string
+	headers map[string]string
+}
+
+func newHTTPPublisher(c *config.HTTPNotificationSink) publisher {
+	return &httpPublisher{url: c.URL, headers: c.Headers}
+}
+
+func (p *httpPublisher) publish(ctx context.Context, _ Notification, data []byte) error {
+	return post(ctx, p.url, "application/json", p.headers, data)
+}
+
+func post(ctx context.Context, url, contentType string, headers map[string]string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be re-used
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
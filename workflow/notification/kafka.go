@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+// kafkaPublisher publishes to Kafka using the Kafka REST Proxy v2 API
+// https://docs.confluent.io/platform/current/kafka-rest/api.html#records-v2
+type kafkaPublisher struct {
+	url     string
+	headers map[string]string
+}
+
+func newKafkaPublisher(c *config.KafkaNotificationSink) publisher {
+	return &kafkaPublisher{
+		url:     fmt.Sprintf("%s/topics/%s", strings.TrimSuffix(c.URL, "/"), url.PathEscape(c.Topic)),
+		headers: c.Headers,
+	}
+}
+
+type kafkaRecord struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+func (p *kafkaPublisher) publish(ctx context.Context, n Notification, data []byte) error {
+	// keyed by the workflow's UID, so all the notifications about a workflow are on the same partition, and therefore in order
+	body, err := json.Marshal(map[string][]kafkaRecord{"records": {{Key: []byte(n.UID), Value: data}}})
+	if err != nil {
+		return err
+	}
+	return post(ctx, p.url, "application/vnd.kafka.binary.v2+json", p.headers, body)
+}
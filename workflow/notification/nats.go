@@ -0,0 +1,130 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+// natsPublisher publishes using the NATS client protocol, https://docs.nats.io/reference/reference-protocols/nats-protocol
+// It is only used by a single sink goroutine, so it is not safe for concurrent use.
+type natsPublisher struct {
+	address string
+	subject string
+	connect []byte
+	conn    net.Conn
+	reader  *bufio.Reader
+}
+
+func newNATSPublisher(c *config.NATSNotificationSink) (publisher, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("NATS URL scheme must be \"nats\"")
+	}
+	if c.Subject == "" || strings.ContainsAny(c.Subject, " \t\r\n") {
+		return nil, fmt.Errorf("NATS subject must not be empty or contain whitespace")
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "4222")
+	}
+	options := map[string]interface{}{"verbose": false, "pedantic": false, "name": "workflow-controller", "lang": "go"}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			options["user"] = u.User.Username()
+			options["pass"] = password
+		} else {
+			options["auth_token"] = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	return &natsPublisher{address: address, subject: c.Subject, connect: connect}, nil
+}
+
+func (p *natsPublisher) publish(ctx context.Context, _ Notification, data []byte) error {
+	err := p.publishOnce(ctx, data)
+	if err != nil && p.conn != nil {
+		// the connection may be broken, so we start again with a new one next time
+		_ = p.conn.Close()
+		p.conn = nil
+	}
+	return err
+}
+
+func (p *natsPublisher) publishOnce(ctx context.Context, data []byte) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(publishTimeout)
+	}
+	if p.conn == nil {
+		if err := p.dial(ctx, deadline); err != nil {
+			return err
+		}
+	}
+	if err := p.conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	// the PING means the server replies, so we know if it accepted the message
+	if _, err := fmt.Fprintf(p.conn, "PUB %s %d\r\n%s\r\nPING\r\n", p.subject, len(data), data); err != nil {
+		return err
+	}
+	return p.awaitPong()
+}
+
+func (p *natsPublisher) dial(ctx context.Context, deadline time.Time) error {
+	conn, err := (&net.Dialer{Deadline: deadline}).DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return err
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected NATS server greeting: %s", strings.TrimSpace(line))
+	}
+	if strings.Contains(line, `"tls_required":true`) {
+		return fmt.Errorf("NATS server requires TLS, which is not supported")
+	}
+	_, err = fmt.Fprintf(conn, "CONNECT %s\r\n", p.connect)
+	return err
+}
+
+func (p *natsPublisher) awaitPong() error {
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := fmt.Fprint(p.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		// ignore anything else, e.g. "+OK" or "INFO"
+	}
+}
@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+type Type string
+
+const (
+	TypeWorkflow Type = "Workflow"
+	TypeNode     Type = "Node"
+)
+
+// Notification is a change of phase of a workflow or one of its nodes
+type Notification struct {
+	Type      Type              `json:"type"`
+	Time      metav1.Time       `json:"time"`
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	UID       string            `json:"uid"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	// Phase is the phase of the workflow, or the node if this is a node notification
+	Phase string `json:"phase"`
+	// Message is the message of the workflow, or the node if this is a node notification
+	Message string `json:"message,omitempty"`
+	Node    *Node  `json:"node,omitempty"`
+}
+
+type Node struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	Type         string `json:"type"`
+	TemplateName string `json:"templateName,omitempty"`
+}
+
+func ForWorkflow(wf *wfv1.Workflow, now time.Time) Notification {
+	return Notification{
+		Type:      TypeWorkflow,
+		Time:      metav1.NewTime(now),
+		Namespace: wf.Namespace,
+		Name:      wf.Name,
+		UID:       string(wf.UID),
+		Labels:    wf.Labels,
+		Phase:     string(wf.Status.Phase),
+		Message:   wf.Status.Message,
+	}
+}
+
+func ForNode(wf *wfv1.Workflow, node wfv1.NodeStatus, now time.Time) Notification {
+	n := ForWorkflow(wf, now)
+	n.Type = TypeNode
+	n.Phase = string(node.Phase)
+	n.Message = node.Message
+	n.Node = &Node{
+		ID:           node.ID,
+		Name:         node.Name,
+		DisplayName:  node.DisplayName,
+		Type:         string(node.Type),
+		TemplateName: node.TemplateName,
+	}
+	return n
+}
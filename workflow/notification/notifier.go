@@ -0,0 +1,168 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	sprig "github.com/Masterminds/sprig/v3"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+)
+
+const publishTimeout = 10 * time.Second
+
+// Notifier publishes notifications to the configured sinks, asynchronously, so that it never blocks the caller
+type Notifier interface {
+	Notify(n Notification)
+	// Close stops publishing, any queued notifications are dropped
+	Close()
+}
+
+type publisher interface {
+	publish(ctx context.Context, n Notification, data []byte) error
+}
+
+type sink struct {
+	name      string
+	types     map[string]bool
+	phases    map[string]bool
+	payload   *template.Template
+	publisher publisher
+	queue     chan Notification
+}
+
+type notifier struct {
+	sinks []*sink
+	done  chan struct{}
+}
+
+// Null is a notifier that publishes nothing
+var Null Notifier = &notifier{done: make(chan struct{})}
+
+// New creates a notifier and starts publishing
+func New(c *config.NotificationsConfig) (Notifier, error) {
+	if c == nil || len(c.Sinks) == 0 {
+		return Null, nil
+	}
+	n := &notifier{done: make(chan struct{})}
+	for _, s := range c.Sinks {
+		x, err := newSink(s, c.GetQueueSize())
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification sink \"%s\": %w", s.Name, err)
+		}
+		n.sinks = append(n.sinks, x)
+	}
+	for _, s := range n.sinks {
+		go s.run(n.done)
+	}
+	return n, nil
+}
+
+func newSink(c config.NotificationSink, queueSize int) (*sink, error) {
+	s := &sink{name: c.Name, types: toSet(c.Types), phases: toSet(c.Phases), queue: make(chan Notification, queueSize)}
+	for t := range s.types {
+		if t != string(TypeWorkflow) && t != string(TypeNode) {
+			return nil, fmt.Errorf("unknown type \"%s\", must be \"%s\" or \"%s\"", t, TypeWorkflow, TypeNode)
+		}
+	}
+	if c.Payload != "" {
+		funcs := sprig.TxtFuncMap()
+		delete(funcs, "env")
+		delete(funcs, "expandenv")
+		t, err := template.New(c.Name).Funcs(funcs).Parse(c.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse payload: %w", err)
+		}
+		s.payload = t
+	}
+	var publishers []publisher
+	if c.HTTP != nil {
+		publishers = append(publishers, newHTTPPublisher(c.HTTP))
+	}
+	if c.NATS != nil {
+		p, err := newNATSPublisher(c.NATS)
+		if err != nil {
+			return nil, err
+		}
+		publishers = append(publishers, p)
+	}
+	if c.Kafka != nil {
+		publishers = append(publishers, newKafkaPublisher(c.Kafka))
+	}
+	if len(publishers) != 1 {
+		return nil, fmt.Errorf("exactly one of http, nats or kafka must be specified")
+	}
+	s.publisher = publishers[0]
+	return s, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func (n *notifier) Notify(x Notification) {
+	for _, s := range n.sinks {
+		if !s.matches(x) {
+			continue
+		}
+		select {
+		case <-n.done:
+			return
+		case s.queue <- x:
+		default:
+			log.WithFields(log.Fields{"sink": s.name, "namespace": x.Namespace, "workflow": x.Name}).Warn("Notification queue is full, dropping notification")
+		}
+	}
+}
+
+func (n *notifier) Close() {
+	if n != Null {
+		close(n.done)
+	}
+}
+
+func (s *sink) matches(n Notification) bool {
+	return (len(s.types) == 0 || s.types[string(n.Type)]) && (len(s.phases) == 0 || s.phases[n.Phase])
+}
+
+func (s *sink) run(done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case n := <-s.queue:
+			logCtx := log.WithFields(log.Fields{"sink": s.name, "namespace": n.Namespace, "workflow": n.Name, "type": n.Type, "phase": n.Phase})
+			data, err := s.render(n)
+			if err != nil {
+				logCtx.WithError(err).Error("Failed to render notification")
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+			err = s.publisher.publish(ctx, n, data)
+			cancel()
+			if err != nil {
+				logCtx.WithError(err).Warn("Failed to publish notification")
+			} else {
+				logCtx.Debug("Published notification")
+			}
+		}
+	}
+}
+
+func (s *sink) render(n Notification) ([]byte, error) {
+	if s.payload == nil {
+		return json.Marshal(n)
+	}
+	buf := &bytes.Buffer{}
+	err := s.payload.Execute(buf, n)
+	return buf.Bytes(), err
+}
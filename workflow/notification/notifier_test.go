@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+var wf = &wfv1.Workflow{
+	ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wf", UID: "my-uid"},
+	Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowFailed, Message: "my-message"},
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Null", func(t *testing.T) {
+		n, err := New(nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, Null, n)
+			n.Notify(ForWorkflow(wf, time.Now()))
+			n.Close()
+		}
+	})
+	t.Run("NoPublisher", func(t *testing.T) {
+		_, err := New(&config.NotificationsConfig{Sinks: []config.NotificationSink{{Name: "my-sink"}}})
+		assert.EqualError(t, err, "invalid notification sink \"my-sink\": exactly one of http, nats or kafka must be specified")
+	})
+	t.Run("UnknownType", func(t *testing.T) {
+		_, err := New(&config.NotificationsConfig{Sinks: []config.NotificationSink{{Name: "my-sink", Types: []string{"Pod"}, HTTP: &config.HTTPNotificationSink{}}}})
+		assert.EqualError(t, err, "invalid notification sink \"my-sink\": unknown type \"Pod\", must be \"Workflow\" or \"Node\"")
+	})
+	t.Run("InvalidPayload", func(t *testing.T) {
+		_, err := New(&config.NotificationsConfig{Sinks: []config.NotificationSink{{Name: "my-sink", Payload: "{{", HTTP: &config.HTTPNotificationSink{}}}})
+		assert.Error(t, err)
+	})
+	t.Run("InvalidNATSURL", func(t *testing.T) {
+		_, err := New(&config.NotificationsConfig{Sinks: []config.NotificationSink{{Name: "my-sink", NATS: &config.NATSNotificationSink{URL: "http://nats", Subject: "my-subject"}}}})
+		assert.EqualError(t, err, "invalid notification sink \"my-sink\": NATS URL scheme must be \"nats\"")
+	})
+}
+
+func TestSinkMatches(t *testing.T) {
+	s, err := newSink(config.NotificationSink{Types: []string{"Workflow"}, Phases: []string{"Failed"}, HTTP: &config.HTTPNotificationSink{}}, 1)
+	if assert.NoError(t, err) {
+		assert.True(t, s.matches(ForWorkflow(wf, time.Now())))
+		assert.False(t, s.matches(ForNode(wf, wfv1.NodeStatus{Phase: wfv1.NodeFailed}, time.Now())))
+		assert.False(t, s.matches(Notification{Type: TypeWorkflow, Phase: "Succeeded"}))
+	}
+}
+
+func TestSinkRender(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Run("Default", func(t *testing.T) {
+		s, _ := newSink(config.NotificationSink{HTTP: &config.HTTPNotificationSink{}}, 1)
+		data, err := s.render(ForNode(wf, wfv1.NodeStatus{ID: "my-id", Name: "my-wf.a", DisplayName: "a", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded}, now))
+		if assert.NoError(t, err) {
+			assert.JSONEq(t, `{"type":"Node","time":"2022-01-02T03:04:05Z","namespace":"my-ns","name":"my-wf","uid":"my-uid","phase":"Succeeded","node":{"id":"my-id","name":"my-wf.a","displayName":"a","type":"Pod"}}`, string(data))
+		}
+	})
+	t.Run("Template", func(t *testing.T) {
+		s, _ := newSink(config.NotificationSink{Payload: `{"text": {{printf "%s/%s is %s" .Namespace .Name .Phase | toJson}}}`, HTTP: &config.HTTPNotificationSink{}}, 1)
+		data, err := s.render(ForWorkflow(wf, now))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `{"text": "my-ns/my-wf is Failed"}`, string(data))
+		}
+	})
+}
+
+func TestHTTPPublisher(t *testing.T) {
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		requests <- r
+		bodies <- string(data)
+	}))
+	defer server.Close()
+	n, err := New(&config.NotificationsConfig{Sinks: []config.NotificationSink{{Name: "my-sink", HTTP: &config.HTTPNotificationSink{URL: server.URL + "/my-path", Headers: map[string]string{"X-My-Header": "my-value"}}}}})
+	if assert.NoError(t, err) {
+		defer n.Close()
+		n.Notify(ForWorkflow(wf, time.Now()))
+		r := <-requests
+		assert.Equal(t, "/my-path", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "my-value", r.Header.Get("X-My-Header"))
+		assert.Contains(t, <-bodies, `"name":"my-wf"`)
+	}
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics/my-topic" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "application/vnd.kafka.binary.v2+json", r.Header.Get("Content-Type"))
+		body := map[string][]kafkaRecord{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if assert.Len(t, body["records"], 1) {
+			assert.Equal(t, "my-uid", string(body["records"][0].Key))
+			assert.Equal(t, "my-data", string(body["records"][0].Value))
+		}
+	}))
+	defer server.Close()
+	p := newKafkaPublisher(&config.KafkaNotificationSink{URL: server.URL + "/", Topic: "my-topic"})
+	assert.NoError(t, p.publish(context.Background(), ForWorkflow(wf, time.Now()), []byte("my-data")))
+	p = newKafkaPublisher(&config.KafkaNotificationSink{URL: server.URL + "/not-found", Topic: "my-topic"})
+	assert.Error(t, p.publish(context.Background(), ForWorkflow(wf, time.Now()), []byte("my-data")))
+}
+
+func TestNATSPublisher(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer listener.Close()
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte("INFO {\"server_id\":\"my-server\"}\r\n"))
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			lines <- line
+			if line == "PING" {
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			}
+		}
+	}()
+	p, err := newNATSPublisher(&config.NATSNotificationSink{URL: "nats://my-user:my-password@" + listener.Addr().String(), Subject: "my-subject"})
+	if assert.NoError(t, err) {
+		assert.NoError(t, p.publish(context.Background(), ForWorkflow(wf, time.Now()), []byte("my-data")))
+		assert.NoError(t, p.publish(context.Background(), ForWorkflow(wf, time.Now()), []byte("more-data")))
+		assert.Equal(t, `CONNECT {"lang":"go","name":"workflow-controller","pass":"my-password","pedantic":false,"user":"my-user","verbose":false}`, <-lines)
+		assert.Equal(t, "PUB my-subject 7", <-lines)
+		assert.Equal(t, "my-data", <-lines)
+		assert.Equal(t, "PING", <-lines)
+		assert.Equal(t, "PUB my-subject 9", <-lines)
+	}
+}
@@ -18,10 +18,11 @@ func SignalContainer(restConfig *rest.Config, pod *corev1.Pod, container string,
 	command := []string{"/bin/sh", "-c", "kill -%d 1"}
 
 	// If the container has the /var/run/argo volume mounted, this it will have access to `argoexec`.
+	// Plugins only mount their own sub-path of it, which does not have `argoexec`.
 	for _, c := range pod.Spec.Containers {
 		if c.Name == container {
 			for _, m := range c.VolumeMounts {
-				if m.MountPath == common.VarRunArgoPath {
+				if m.MountPath == common.VarRunArgoPath && m.SubPath == "" {
 					command = []string{filepath.Join(common.VarRunArgoPath, "argoexec"), "kill", "%d", "1"}
 				}
 			}
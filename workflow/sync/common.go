@@ -2,9 +2,17 @@ package sync
 
 import "time"
 
+// LockStore stores the holders of semaphores outside the controller, e.g. in the database, so that their limits are
+// honored across controller restarts and across multiple controllers.
+type LockStore interface {
+	// Acquire acquires weight units of the lock for the holder, if they are available within the limit
+	Acquire(lockName, holderKey string, weight int64, limit int) (bool, error)
+	Release(lockName, holderKey string) error
+}
+
 type Semaphore interface {
-	acquire(holderKey string) bool
-	tryAcquire(holderKey string) (bool, string)
+	acquire(holderKey string, weight int64) bool
+	tryAcquire(holderKey string, weight int64) (bool, string)
 	release(key string) bool
 	addToQueue(holderKey string, priority int32, creationTime time.Time)
 	removeFromQueue(holderKey string)
@@ -13,4 +21,31 @@ type Semaphore interface {
 	getName() string
 	getLimit() int
 	resize(n int) bool
+	getState() LockState
+}
+
+// LockState is the state of a lock, for debugging
+type LockState struct {
+	Name  string `json:"name"`
+	Limit int    `json:"limit"`
+	// Used is the number of units of the lock consumed by its holders
+	Used    int                 `json:"used"`
+	Holders []LockHolder        `json:"holders,omitempty"`
+	Pending []PendingLockHolder `json:"pending,omitempty"`
+}
+
+type LockHolder struct {
+	Key    string `json:"key"`
+	Weight int64  `json:"weight"`
+}
+
+// PendingLockHolder is a holder waiting for a lock, in the order they will acquire it
+type PendingLockHolder struct {
+	Key      string `json:"key"`
+	Weight   int64  `json:"weight"`
+	Priority int32  `json:"priority"`
+	// EffectivePriority is the priority raised by aging
+	EffectivePriority int64     `json:"effectivePriority"`
+	CreationTime      time.Time `json:"creationTime"`
+	QueueTime         time.Time `json:"queueTime"`
 }
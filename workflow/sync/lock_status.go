@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// LockStatus is the status of a semaphore or mutex, built from the status of the workflows holding or waiting for it
+type LockStatus struct {
+	// Name is the encoded name of the lock, e.g. `argo/ConfigMap/my-config/workflow` or `argo/Mutex/my-mutex`
+	Name    string                   `json:"name"`
+	Type    wfv1.SynchronizationType `json:"type"`
+	Holders []LockUser               `json:"holders,omitempty"`
+	Waiting []LockUser               `json:"waiting,omitempty"`
+}
+
+// LockUser is a workflow, or a node of a workflow, holding or waiting for a lock
+type LockUser struct {
+	Namespace string `json:"namespace"`
+	Workflow  string `json:"workflow"`
+	// NodeID is empty for a workflow-level lock
+	NodeID string `json:"nodeId,omitempty"`
+	Weight int64  `json:"weight,omitempty"`
+	// Since is when the workflow or node started, i.e. started to wait for the lock
+	Since metav1.Time `json:"since"`
+	// Duration is how long, in seconds, the workflow or node has held, or waited for, the lock
+	Duration int64 `json:"duration"`
+}
+
+// GetLockStatuses returns the status of the locks held or waited for by the workflows, ordered by name
+func GetLockStatuses(wfs []wfv1.Workflow, now time.Time) []LockStatus {
+	locks := map[string]*LockStatus{}
+	lockStatus := func(name string, lockType wfv1.SynchronizationType) *LockStatus {
+		if _, ok := locks[name]; !ok {
+			locks[name] = &LockStatus{Name: name, Type: lockType}
+		}
+		return locks[name]
+	}
+	for _, wf := range wfs {
+		sync := wf.Status.Synchronization
+		if sync == nil || wf.Status.Fulfilled() {
+			continue
+		}
+		newUser := func(holder string, weight int64) LockUser {
+			user := LockUser{Namespace: wf.Namespace, Workflow: wf.Name, Weight: weight, Since: wf.Status.StartedAt}
+			if user.Since.IsZero() {
+				user.Since = wf.CreationTimestamp
+			}
+			if holder != wf.Name {
+				user.NodeID = holder
+				if node, ok := wf.Status.Nodes[holder]; ok && !node.StartedAt.IsZero() {
+					user.Since = node.StartedAt
+				}
+			}
+			user.Duration = int64(now.Sub(user.Since.Time).Seconds())
+			return user
+		}
+		held := map[string]bool{}
+		if sync.Semaphore != nil {
+			for _, holding := range sync.Semaphore.Holding {
+				lock := lockStatus(holding.Semaphore, wfv1.SynchronizationTypeSemaphore)
+				for _, holder := range holding.Holders {
+					lock.Holders = append(lock.Holders, newUser(holder, holding.GetWeight(holder)))
+					held[holding.Semaphore] = held[holding.Semaphore] || holder == wf.Name
+				}
+			}
+		}
+		if sync.Mutex != nil {
+			for _, holding := range sync.Mutex.Holding {
+				lock := lockStatus(holding.Mutex, wfv1.SynchronizationTypeMutex)
+				lock.Holders = append(lock.Holders, newUser(holding.Holder, 1))
+				held[holding.Mutex] = held[holding.Mutex] || holding.Holder == wf.Name
+			}
+		}
+		// nodes record the lock they are waiting for, while the workflow records the locks it has waited for, a
+		// workflow-level lock keeps the workflow pending
+		waitingNodes := map[string]bool{}
+		for _, node := range wf.Status.Nodes {
+			if node.SynchronizationStatus == nil || node.SynchronizationStatus.Waiting == "" {
+				continue
+			}
+			lockName := node.SynchronizationStatus.Waiting
+			waitingNodes[lockName] = true
+			lockType := wfv1.SynchronizationTypeSemaphore
+			if name, err := DecodeLockName(lockName); err == nil && name.Kind == LockKindMutex {
+				lockType = wfv1.SynchronizationTypeMutex
+			}
+			lock := lockStatus(lockName, lockType)
+			lock.Waiting = append(lock.Waiting, newUser(node.ID, 0))
+		}
+		waitingWorkflow := func(lockName string, lockType wfv1.SynchronizationType) {
+			pending := wf.Status.Phase == wfv1.WorkflowUnknown || wf.Status.Phase == wfv1.WorkflowPending
+			if pending && !waitingNodes[lockName] && !held[lockName] {
+				lock := lockStatus(lockName, lockType)
+				lock.Waiting = append(lock.Waiting, newUser(wf.Name, 0))
+			}
+		}
+		if sync.Semaphore != nil {
+			for _, waiting := range sync.Semaphore.Waiting {
+				waitingWorkflow(waiting.Semaphore, wfv1.SynchronizationTypeSemaphore)
+			}
+		}
+		if sync.Mutex != nil {
+			for _, waiting := range sync.Mutex.Waiting {
+				waitingWorkflow(waiting.Mutex, wfv1.SynchronizationTypeMutex)
+			}
+		}
+	}
+	var statuses []LockStatus
+	for _, lock := range locks {
+		for _, users := range [][]LockUser{lock.Holders, lock.Waiting} {
+			sort.Slice(users, func(i, j int) bool {
+				if !users[i].Since.Equal(&users[j].Since) {
+					return users[i].Since.Before(&users[j].Since)
+				}
+				return users[i].Workflow+"/"+users[i].NodeID < users[j].Workflow+"/"+users[j].NodeID
+			})
+		}
+		statuses = append(statuses, *lock)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
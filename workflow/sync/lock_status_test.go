@@ -0,0 +1,81 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestGetLockStatuses(t *testing.T) {
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	started := metav1.NewTime(now.Add(-time.Hour))
+	nodeStarted := metav1.NewTime(now.Add(-time.Minute))
+	holding := wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "holding"},
+		Status: wfv1.WorkflowStatus{
+			Phase:     wfv1.WorkflowRunning,
+			StartedAt: started,
+			Nodes:     wfv1.Nodes{"holding-1": {ID: "holding-1", StartedAt: nodeStarted}},
+			Synchronization: &wfv1.SynchronizationStatus{
+				Semaphore: &wfv1.SemaphoreStatus{
+					Holding: []wfv1.SemaphoreHolding{{Semaphore: "argo/ConfigMap/my-config/template", Holders: []string{"holding-1"}, Weights: map[string]int32{"holding-1": 2}}},
+				},
+				Mutex: &wfv1.MutexStatus{
+					Holding: []wfv1.MutexHolding{{Mutex: "argo/Mutex/my-mutex", Holder: "holding"}},
+				},
+			},
+		},
+	}
+	waiting := wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "waiting"},
+		Status: wfv1.WorkflowStatus{
+			Phase:     wfv1.WorkflowPending,
+			StartedAt: started,
+			Synchronization: &wfv1.SynchronizationStatus{
+				Mutex: &wfv1.MutexStatus{
+					Waiting: []wfv1.MutexHolding{{Mutex: "argo/Mutex/my-mutex", Holder: "argo/holding"}},
+				},
+			},
+		},
+	}
+	waitingNode := wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "waiting-node"},
+		Status: wfv1.WorkflowStatus{
+			Phase:     wfv1.WorkflowRunning,
+			StartedAt: started,
+			Nodes: wfv1.Nodes{"waiting-node-1": {
+				ID:                    "waiting-node-1",
+				StartedAt:             nodeStarted,
+				SynchronizationStatus: &wfv1.NodeSynchronizationStatus{Waiting: "argo/ConfigMap/my-config/template"},
+			}},
+			Synchronization: &wfv1.SynchronizationStatus{
+				Semaphore: &wfv1.SemaphoreStatus{
+					Waiting: []wfv1.SemaphoreHolding{{Semaphore: "argo/ConfigMap/my-config/template", Holders: []string{"argo/holding/holding-1"}}},
+				},
+			},
+		},
+	}
+	completed := waitingNode.DeepCopy()
+	completed.Name = "completed"
+	completed.Status.Phase = wfv1.WorkflowSucceeded
+
+	locks := GetLockStatuses([]wfv1.Workflow{holding, waiting, waitingNode, *completed}, now)
+	assert.Equal(t, []LockStatus{
+		{
+			Name:    "argo/ConfigMap/my-config/template",
+			Type:    wfv1.SynchronizationTypeSemaphore,
+			Holders: []LockUser{{Namespace: "argo", Workflow: "holding", NodeID: "holding-1", Weight: 2, Since: nodeStarted, Duration: 60}},
+			Waiting: []LockUser{{Namespace: "argo", Workflow: "waiting-node", NodeID: "waiting-node-1", Since: nodeStarted, Duration: 60}},
+		},
+		{
+			Name:    "argo/Mutex/my-mutex",
+			Type:    wfv1.SynchronizationTypeMutex,
+			Holders: []LockUser{{Namespace: "argo", Workflow: "holding", Weight: 1, Since: started, Duration: 3600}},
+			Waiting: []LockUser{{Namespace: "argo", Workflow: "waiting", Since: started, Duration: 3600}},
+		},
+	}, locks)
+}
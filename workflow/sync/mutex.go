@@ -50,10 +50,11 @@ func (m *PriorityMutex) release(key string) bool {
 	return m.mutex.release(key)
 }
 
-func (m *PriorityMutex) acquire(holderKey string) bool {
+// acquire acquires the mutex, which is not weighted, so the weight is ignored
+func (m *PriorityMutex) acquire(holderKey string, _ int64) bool {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	return m.mutex.acquire(holderKey)
+	return m.mutex.acquire(holderKey, 1)
 }
 
 func (m *PriorityMutex) addToQueue(holderKey string, priority int32, creationTime time.Time) {
@@ -68,8 +69,12 @@ func (m *PriorityMutex) removeFromQueue(holderKey string) {
 	m.mutex.removeFromQueue(holderKey)
 }
 
-func (m *PriorityMutex) tryAcquire(holderKey string) (bool, string) {
+func (m *PriorityMutex) tryAcquire(holderKey string, _ int64) (bool, string) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
-	return m.mutex.tryAcquire(holderKey)
+	return m.mutex.tryAcquire(holderKey, 1)
+}
+
+func (m *PriorityMutex) getState() LockState {
+	return m.mutex.getState()
 }
@@ -2,23 +2,34 @@ package sync
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	sema "golang.org/x/sync/semaphore"
+
+	"github.com/argoproj/argo-workflows/v3/util/env"
 )
 
+// priorityAgingInterval is how long a holder waits for a lock before its priority is raised by one, so that holders
+// with a low priority are not starved by a constant stream of holders with a higher priority. Zero disables aging.
+var priorityAgingInterval = env.LookupEnvDurationOr("SEMAPHORE_PRIORITY_AGING_INTERVAL", 0)
+
 type PrioritySemaphore struct {
-	name         string
-	limit        int
-	pending      *priorityQueue
-	semaphore    *sema.Weighted
-	lockHolder   map[string]bool
+	name  string
+	limit int
+	// pending is the queue of holders waiting for the lock
+	pending   *priorityQueue
+	semaphore *sema.Weighted
+	// lockHolder is the weight of each holder of the lock
+	lockHolder   map[string]int64
 	lock         *sync.Mutex
 	nextWorkflow NextWorkflow
 	log          *log.Entry
+	// store, if not nil, also stores the holders, e.g. so that the limit is honored across controllers
+	store LockStore
 }
 
 var _ Semaphore = &PrioritySemaphore{}
@@ -29,7 +40,7 @@ func NewSemaphore(name string, limit int, nextWorkflow NextWorkflow, lockType st
 		limit:        limit,
 		pending:      &priorityQueue{itemByKey: make(map[string]*item)},
 		semaphore:    sema.NewWeighted(int64(limit)),
-		lockHolder:   make(map[string]bool),
+		lockHolder:   make(map[string]int64),
 		lock:         &sync.Mutex{},
 		nextWorkflow: nextWorkflow,
 		log: log.WithFields(log.Fields{
@@ -38,6 +49,13 @@ func NewSemaphore(name string, limit int, nextWorkflow NextWorkflow, lockType st
 	}
 }
 
+// NewStoredSemaphore creates a semaphore whose holders are also stored in the store
+func NewStoredSemaphore(name string, limit int, nextWorkflow NextWorkflow, store LockStore) *PrioritySemaphore {
+	s := NewSemaphore(name, limit, nextWorkflow, "semaphore")
+	s.store = store
+	return s
+}
+
 func (s *PrioritySemaphore) getName() string {
 	return s.name
 }
@@ -46,9 +64,10 @@ func (s *PrioritySemaphore) getLimit() int {
 	return s.limit
 }
 
+// getCurrentPending returns the keys of the holders waiting for the lock, in the order they will acquire it
 func (s *PrioritySemaphore) getCurrentPending() []string {
 	var keys []string
-	for _, item := range s.pending.items {
+	for _, item := range s.sortedPending() {
 		keys = append(keys, item.key)
 	}
 	return keys
@@ -62,11 +81,53 @@ func (s *PrioritySemaphore) getCurrentHolders() []string {
 	return keys
 }
 
+func (s *PrioritySemaphore) getState() LockState {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	state := LockState{Name: s.name, Limit: s.limit, Used: s.used()}
+	for key, weight := range s.lockHolder {
+		state.Holders = append(state.Holders, LockHolder{Key: key, Weight: weight})
+	}
+	sort.Slice(state.Holders, func(i, j int) bool {
+		return state.Holders[i].Key < state.Holders[j].Key
+	})
+	for _, item := range s.sortedPending() {
+		state.Pending = append(state.Pending, PendingLockHolder{
+			Key:               item.key,
+			Weight:            item.getWeight(),
+			Priority:          item.priority,
+			EffectivePriority: item.effectivePriority(),
+			CreationTime:      item.creationTime,
+			QueueTime:         item.queueTime,
+		})
+	}
+	return state
+}
+
+// used returns the number of units of the semaphore consumed by its holders
+func (s *PrioritySemaphore) used() int {
+	used := 0
+	for _, weight := range s.lockHolder {
+		used += int(weight)
+	}
+	return used
+}
+
+// acquired returns the number of units acquired from the underlying semaphore, which is less than the number used
+// when the semaphore has been resized downward
+func (s *PrioritySemaphore) acquired() int {
+	used := s.used()
+	if used > s.limit {
+		return s.limit
+	}
+	return used
+}
+
 func (s *PrioritySemaphore) resize(n int) bool {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	cur := len(s.lockHolder)
+	cur := s.used()
 	// downward case, acquired n locks
 	if cur > n {
 		cur = n
@@ -86,15 +147,19 @@ func (s *PrioritySemaphore) release(key string) bool {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	if _, ok := s.lockHolder[key]; ok {
+		acquired := s.acquired()
 		delete(s.lockHolder, key)
-		// When semaphore resized downward
-		// Remove the excess holders from map once the done.
-		if len(s.lockHolder) >= s.limit {
-			return true
+		if s.store != nil {
+			if err := s.store.Release(s.name, key); err != nil {
+				s.log.WithError(err).Errorf("Failed to release the stored lock of %s", key)
+			}
 		}
-
-		s.semaphore.Release(1)
-		availableLocks := s.limit - len(s.lockHolder)
+		// When semaphore resized downward, the excess holders are removed from the map without releasing the
+		// underlying semaphore, until the holders fit within the new limit.
+		if released := acquired - s.acquired(); released > 0 {
+			s.semaphore.Release(int64(released))
+		}
+		availableLocks := s.limit - s.used()
 		s.log.Infof("Lock has been released by %s. Available locks: %d", key, availableLocks)
 		if s.pending.Len() > 0 {
 			s.notifyWaiters()
@@ -103,15 +168,16 @@ func (s *PrioritySemaphore) release(key string) bool {
 	return true
 }
 
-// notifyWaiters enqueues the next N workflows who are waiting for the semaphore to the workqueue,
-// where N is the availability of the semaphore. If semaphore is out of capacity, this does nothing.
+// notifyWaiters enqueues the next workflows who are waiting for the semaphore to the workqueue, in the order they
+// will acquire it, while their weights fit within the availability of the semaphore. If semaphore is out of capacity,
+// this does nothing.
 func (s *PrioritySemaphore) notifyWaiters() {
-	triggerCount := s.limit - len(s.lockHolder)
-	if s.pending.Len() < triggerCount {
-		triggerCount = s.pending.Len()
-	}
-	for idx := 0; idx < triggerCount; idx++ {
-		item := s.pending.items[idx]
+	available := int64(s.limit - s.used())
+	for _, item := range s.sortedPending() {
+		if item.getWeight() > available {
+			break
+		}
+		available -= item.getWeight()
 		wfKey := workflowKey(item)
 		s.log.Debugf("Enqueue the workflow %s", wfKey)
 		s.nextWorkflow(wfKey)
@@ -129,6 +195,28 @@ func workflowKey(i *item) string {
 	return i.key
 }
 
+// sortedPending returns the pending holders in the order they will acquire the lock, i.e. by their effective priority,
+// and then first come, first served.
+func (s *PrioritySemaphore) sortedPending() []*item {
+	items := make([]*item, len(s.pending.items))
+	copy(items, s.pending.items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].before(items[j])
+	})
+	return items
+}
+
+// next returns the pending holder that will acquire the lock next
+func (s *PrioritySemaphore) next() *item {
+	next := s.pending.items[0]
+	for _, item := range s.pending.items[1:] {
+		if item.before(next) {
+			next = item
+		}
+	}
+	return next
+}
+
 // addToQueue adds the holderkey into priority queue that maintains the priority order to acquire the lock.
 func (s *PrioritySemaphore) addToQueue(holderKey string, priority int32, creationTime time.Time) {
 	s.lock.Lock()
@@ -151,12 +239,33 @@ func (s *PrioritySemaphore) removeFromQueue(holderKey string) {
 	s.log.Debugf("Removed from queue: %s", holderKey)
 }
 
-func (s *PrioritySemaphore) acquire(holderKey string) bool {
-	if s.semaphore.TryAcquire(1) {
-		s.lockHolder[holderKey] = true
-		return true
+func (s *PrioritySemaphore) acquire(holderKey string, weight int64) bool {
+	if !s.semaphore.TryAcquire(weight) {
+		return false
+	}
+	if s.store != nil {
+		// other controllers may hold the units available to this one
+		acquired, err := s.store.Acquire(s.name, holderKey, weight, s.limit)
+		if err != nil {
+			s.log.WithError(err).Errorf("Failed to acquire the stored lock for %s", holderKey)
+		}
+		if !acquired {
+			s.semaphore.Release(weight)
+			return false
+		}
+	}
+	s.lockHolder[holderKey] = weight
+	return true
+}
+
+// notifyNext enqueues the workflow at the front of the queue, so it may try to acquire units released by other
+// controllers sharing the store
+func (s *PrioritySemaphore) notifyNext() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.pending.Len() > 0 {
+		s.nextWorkflow(workflowKey(s.next()))
 	}
-	return false
 }
 
 func isSameWorkflowNodeKeys(firstKey, secondKey string) bool {
@@ -170,7 +279,7 @@ func isSameWorkflowNodeKeys(firstKey, secondKey string) bool {
 	return firstItems[1] == secondItems[1]
 }
 
-func (s *PrioritySemaphore) tryAcquire(holderKey string) (bool, string) {
+func (s *PrioritySemaphore) tryAcquire(holderKey string, weight int64) (bool, string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -178,27 +287,33 @@ func (s *PrioritySemaphore) tryAcquire(holderKey string) (bool, string) {
 		s.log.Debugf("%s is already holding a lock", holderKey)
 		return true, ""
 	}
-	var nextKey string
+	if item, ok := s.pending.itemByKey[holderKey]; ok {
+		item.weight = weight
+	}
 
-	waitingMsg := fmt.Sprintf("Waiting for %s lock. Lock status: %d/%d", s.name, s.limit-len(s.lockHolder), s.limit)
+	waitingMsg := fmt.Sprintf("Waiting for %s lock. Lock status: %d/%d", s.name, s.limit-s.used(), s.limit)
+	if weight > 1 {
+		waitingMsg = fmt.Sprintf("Waiting for %d units of %s lock. Lock status: %d/%d", weight, s.name, s.limit-s.used(), s.limit)
+	}
 
 	// Check whether requested holdkey is in front of priority queue.
 	// If it is in front position, it will allow to acquire lock.
-	// If it is not a front key, it needs to wait for its turn.
+	// If it is not a front key, it needs to wait for its turn, even if there are enough units available for it, so
+	// that holders with a larger weight are not starved by those with a smaller one.
 	if s.pending.Len() > 0 {
-		item := s.pending.peek()
-		if holderKey != nextKey && !isSameWorkflowNodeKeys(holderKey, item.key) {
+		item := s.next()
+		if !isSameWorkflowNodeKeys(holderKey, item.key) {
 			// Enqueue the front workflow if lock is available
-			if len(s.lockHolder) < s.limit {
+			if int64(s.limit-s.used()) >= item.getWeight() {
 				s.nextWorkflow(workflowKey(item))
 			}
 			return false, waitingMsg
 		}
 	}
 
-	if s.acquire(holderKey) {
-		s.pending.pop()
-		s.log.Infof("%s acquired by %s. Lock availability: %d/%d", s.name, holderKey, s.limit-len(s.lockHolder), s.limit)
+	if s.acquire(holderKey, weight) {
+		s.pending.remove(holderKey)
+		s.log.Infof("%s acquired by %s. Lock availability: %d/%d", s.name, holderKey, s.limit-s.used(), s.limit)
 		s.notifyWaiters()
 		return true, ""
 	}
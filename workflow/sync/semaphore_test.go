@@ -34,20 +34,20 @@ func TestTryAcquire(t *testing.T) {
 
 	// verify only the first in line is allowed to acquired the semaphore
 	var acquired bool
-	acquired, _ = s.tryAcquire("default/wf-04")
+	acquired, _ = s.tryAcquire("default/wf-04", 1)
 	assert.False(t, acquired)
-	acquired, _ = s.tryAcquire("default/wf-03")
+	acquired, _ = s.tryAcquire("default/wf-03", 1)
 	assert.False(t, acquired)
-	acquired, _ = s.tryAcquire("default/wf-02")
+	acquired, _ = s.tryAcquire("default/wf-02", 1)
 	assert.False(t, acquired)
-	acquired, _ = s.tryAcquire("default/wf-01")
+	acquired, _ = s.tryAcquire("default/wf-01", 1)
 	assert.True(t, acquired)
 	// now that wf-01 obtained it, wf-02 can
-	acquired, _ = s.tryAcquire("default/wf-02")
+	acquired, _ = s.tryAcquire("default/wf-02", 1)
 	assert.True(t, acquired)
-	acquired, _ = s.tryAcquire("default/wf-03")
+	acquired, _ = s.tryAcquire("default/wf-03", 1)
 	assert.False(t, acquired)
-	acquired, _ = s.tryAcquire("default/wf-04")
+	acquired, _ = s.tryAcquire("default/wf-04", 1)
 	assert.False(t, acquired)
 }
 
@@ -66,7 +66,7 @@ func TestNotifyWaitersAcquire(t *testing.T) {
 	s.addToQueue("default/wf-05", 0, now.Add(4*time.Second))
 	s.addToQueue("default/wf-03", 0, now.Add(2*time.Second))
 
-	acquired, _ := s.tryAcquire("default/wf-01")
+	acquired, _ := s.tryAcquire("default/wf-01", 1)
 	assert.True(t, acquired)
 
 	assert.Len(t, notified, 2)
@@ -98,9 +98,85 @@ func TestNotifyWorkflowFromTemplateSemaphore(t *testing.T) {
 	s.addToQueue("default/wf-01/nodeid-123", 0, now)
 	s.addToQueue("default/wf-02/nodeid-456", 0, now.Add(time.Second))
 
-	acquired, _ := s.tryAcquire("default/wf-01/nodeid-123")
+	acquired, _ := s.tryAcquire("default/wf-01/nodeid-123", 1)
 	assert.True(t, acquired)
 
 	assert.Len(t, notified, 1)
 	assert.True(t, notified["default/wf-02"])
 }
+
+func TestTryAcquireWeighted(t *testing.T) {
+	notified := make(map[string]bool)
+	nextWorkflow := func(key string) {
+		notified[key] = true
+	}
+
+	s := NewSemaphore("foo", 10, nextWorkflow, "semaphore")
+	now := time.Now()
+	s.addToQueue("default/wf-01", 0, now)
+	s.addToQueue("default/wf-02", 0, now.Add(time.Second))
+	s.addToQueue("default/wf-03", 0, now.Add(2*time.Second))
+
+	acquired, _ := s.tryAcquire("default/wf-01", 8)
+	assert.True(t, acquired)
+	assert.Equal(t, 8, s.used())
+
+	// wf-02 does not fit, and wf-03 must wait for it even though it would fit
+	acquired, msg := s.tryAcquire("default/wf-02", 3)
+	assert.False(t, acquired)
+	assert.Equal(t, "Waiting for 3 units of foo lock. Lock status: 2/10", msg)
+	acquired, _ = s.tryAcquire("default/wf-03", 1)
+	assert.False(t, acquired)
+
+	notified = make(map[string]bool)
+	assert.True(t, s.release("default/wf-01"))
+	assert.True(t, notified["default/wf-02"])
+	assert.True(t, notified["default/wf-03"])
+
+	acquired, _ = s.tryAcquire("default/wf-02", 3)
+	assert.True(t, acquired)
+	acquired, _ = s.tryAcquire("default/wf-03", 1)
+	assert.True(t, acquired)
+	assert.Equal(t, 4, s.used())
+}
+
+func TestResizeWeighted(t *testing.T) {
+	s := NewSemaphore("foo", 10, func(string) {}, "semaphore")
+	assert.True(t, s.acquire("default/wf-01", 4))
+	assert.True(t, s.acquire("default/wf-02", 4))
+
+	assert.True(t, s.resize(5))
+	assert.False(t, s.acquire("default/wf-03", 1))
+
+	// releasing wf-01 leaves 4 units used of 5
+	assert.True(t, s.release("default/wf-01"))
+	assert.True(t, s.acquire("default/wf-03", 1))
+	assert.False(t, s.acquire("default/wf-04", 1))
+}
+
+func TestPriorityAging(t *testing.T) {
+	defer func(interval time.Duration) { priorityAgingInterval = interval }(priorityAgingInterval)
+	priorityAgingInterval = time.Minute
+
+	s := NewSemaphore("foo", 1, func(string) {}, "semaphore")
+	now := time.Now()
+	s.addToQueue("default/wf-01", 0, now)
+	s.addToQueue("default/wf-02", 2, now.Add(time.Second))
+	assert.Equal(t, []string{"default/wf-02", "default/wf-01"}, s.getCurrentPending())
+
+	// wf-01 has waited long enough to overtake wf-02
+	s.pending.itemByKey["default/wf-01"].queueTime = now.Add(-3 * time.Minute)
+	assert.Equal(t, []string{"default/wf-01", "default/wf-02"}, s.getCurrentPending())
+	acquired, _ := s.tryAcquire("default/wf-02", 1)
+	assert.False(t, acquired)
+	acquired, _ = s.tryAcquire("default/wf-01", 1)
+	assert.True(t, acquired)
+
+	state := s.getState()
+	assert.Equal(t, 1, state.Used)
+	assert.Equal(t, []LockHolder{{Key: "default/wf-01", Weight: 1}}, state.Holders)
+	if assert.Len(t, state.Pending, 1) {
+		assert.Equal(t, "default/wf-02", state.Pending[0].Key)
+		assert.Equal(t, int32(2), state.Pending[0].Priority)
+	}
+}
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -23,6 +24,8 @@ type Manager struct {
 	nextWorkflow NextWorkflow
 	getSyncLimit GetSyncLimit
 	isWFDeleted  IsWorkflowDeleted
+	// store, if not nil, also stores the holders of semaphores
+	store LockStore
 }
 
 func NewLockManager(getSyncLimit GetSyncLimit, nextWorkflow NextWorkflow, isWFDeleted IsWorkflowDeleted) *Manager {
@@ -35,6 +38,13 @@ func NewLockManager(getSyncLimit GetSyncLimit, nextWorkflow NextWorkflow, isWFDe
 	}
 }
 
+// NewStoredLockManager creates a manager whose semaphores also store their holders in the store
+func NewStoredLockManager(getSyncLimit GetSyncLimit, nextWorkflow NextWorkflow, isWFDeleted IsWorkflowDeleted, store LockStore) *Manager {
+	cm := NewLockManager(getSyncLimit, nextWorkflow, isWFDeleted)
+	cm.store = store
+	return cm
+}
+
 func (cm *Manager) getWorkflowKey(key string) (string, error) {
 	if key == "" {
 		return "", fmt.Errorf("holderkey is empty")
@@ -66,6 +76,18 @@ func (cm *Manager) CheckWorkflowExistence() {
 	}
 }
 
+// NotifyStoredWaiters enqueues the workflows at the front of the queues of the stored semaphores, so they may acquire
+// units released by other controllers
+func (cm *Manager) NotifyStoredWaiters() {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	for _, lock := range cm.syncLockMap {
+		if semaphore, ok := lock.(*PrioritySemaphore); ok && semaphore.store != nil {
+			semaphore.notifyNext()
+		}
+	}
+}
+
 func (cm *Manager) Initialize(wfs []wfv1.Workflow) {
 	for _, wf := range wfs {
 		if wf.Status.Synchronization == nil {
@@ -77,7 +99,8 @@ func (cm *Manager) Initialize(wfs []wfv1.Workflow) {
 
 				semaphore := cm.syncLockMap[holding.Semaphore]
 				if semaphore == nil {
-					semaphore, err := cm.initializeSemaphore(holding.Semaphore)
+					var err error
+					semaphore, err = cm.initializeSemaphore(holding.Semaphore)
 					if err != nil {
 						log.Warnf("cannot initialize semaphore '%s': %v", holding.Semaphore, err)
 						continue
@@ -87,7 +110,7 @@ func (cm *Manager) Initialize(wfs []wfv1.Workflow) {
 
 				for _, holders := range holding.Holders {
 					resourceKey := getResourceKey(wf.Namespace, wf.Name, holders)
-					if semaphore != nil && semaphore.acquire(resourceKey) {
+					if semaphore != nil && semaphore.acquire(resourceKey, holding.GetWeight(holders)) {
 						log.Infof("Lock acquired by %s from %s", resourceKey, holding.Semaphore)
 					}
 				}
@@ -102,7 +125,7 @@ func (cm *Manager) Initialize(wfs []wfv1.Workflow) {
 					mutex := cm.initializeMutex(holding.Mutex)
 					if holding.Holder != "" {
 						resourceKey := getResourceKey(wf.Namespace, wf.Name, holding.Holder)
-						mutex.acquire(resourceKey)
+						mutex.acquire(resourceKey, 1)
 					}
 					cm.syncLockMap[holding.Mutex] = mutex
 				}
@@ -144,11 +167,16 @@ func (cm *Manager) TryAcquire(wf *wfv1.Workflow, nodeName string, syncLockRef *w
 		cm.syncLockMap[lockKey] = lock
 	}
 
+	var weight int64 = 1
 	if syncLockRef.GetType() == wfv1.SynchronizationTypeSemaphore {
 		err := cm.checkAndUpdateSemaphoreSize(lock)
 		if err != nil {
 			return false, false, "", err
 		}
+		weight = syncLockRef.Semaphore.GetWeight()
+		if weight > int64(lock.getLimit()) {
+			return false, false, "", fmt.Errorf("semaphore weight %d exceeds the limit %d of %s", weight, lock.getLimit(), lockKey)
+		}
 	}
 
 	holderKey := getHolderKey(wf, nodeName)
@@ -163,9 +191,12 @@ func (cm *Manager) TryAcquire(wf *wfv1.Workflow, nodeName string, syncLockRef *w
 
 	ensureInit(wf, syncLockRef.GetType())
 	currentHolders := cm.getCurrentLockHolders(lockKey)
-	acquired, msg := lock.tryAcquire(holderKey)
+	acquired, msg := lock.tryAcquire(holderKey, weight)
 	if acquired {
 		updated := wf.Status.Synchronization.GetStatus(syncLockRef.GetType()).LockAcquired(holderKey, lockKey, currentHolders)
+		if syncLockRef.GetType() == wfv1.SynchronizationTypeSemaphore {
+			wf.Status.Synchronization.Semaphore.LockWeighted(holderKey, lockKey, weight)
+		}
 		return true, updated, "", nil
 	}
 
@@ -198,6 +229,43 @@ func (cm *Manager) Release(wf *wfv1.Workflow, nodeName string, syncRef *wfv1.Syn
 	}
 }
 
+// ReleaseLock forcibly releases the lock held by the workflow, or any of its nodes, e.g. when a holder is stuck.
+// It returns whether the workflow held the lock.
+func (cm *Manager) ReleaseLock(wf *wfv1.Workflow, lockKey string) bool {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+
+	if wf.Status.Synchronization == nil {
+		return false
+	}
+	syncLockHolder := cm.syncLockMap[lockKey]
+	release := func(holder string) {
+		resourceKey := getResourceKey(wf.Namespace, wf.Name, holder)
+		if syncLockHolder != nil {
+			syncLockHolder.release(resourceKey)
+		}
+		log.Infof("%s was forced to release a lock from %s", resourceKey, lockKey)
+	}
+	released := false
+	if semaphore := wf.Status.Synchronization.Semaphore; semaphore != nil {
+		if i, holding := semaphore.GetHolding(lockKey); i >= 0 {
+			for _, holder := range append([]string{}, holding.Holders...) {
+				release(holder)
+				semaphore.LockReleased(holder, lockKey)
+				released = true
+			}
+		}
+	}
+	if mutex := wf.Status.Synchronization.Mutex; mutex != nil {
+		if i, holding := mutex.GetHolding(lockKey); i >= 0 && holding.Holder != "" {
+			release(holding.Holder)
+			mutex.LockReleased(holding.Holder, lockKey)
+			released = true
+		}
+	}
+	return released
+}
+
 func (cm *Manager) ReleaseAll(wf *wfv1.Workflow) bool {
 	cm.lock.Lock()
 	defer cm.lock.Unlock()
@@ -273,6 +341,21 @@ func (cm *Manager) ReleaseAll(wf *wfv1.Workflow) bool {
 	return true
 }
 
+// GetLockStates returns the state of the locks, ordered by name, for debugging
+func (cm *Manager) GetLockStates() []LockState {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+
+	var states []LockState
+	for _, lock := range cm.syncLockMap {
+		states = append(states, lock.getState())
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
 func ensureInit(wf *wfv1.Workflow, lockType wfv1.SynchronizationType) {
 	if wf.Status.Synchronization == nil {
 		wf.Status.Synchronization = &wfv1.SynchronizationStatus{}
@@ -316,6 +399,9 @@ func (cm *Manager) initializeSemaphore(semaphoreName string) (Semaphore, error)
 	if err != nil {
 		return nil, err
 	}
+	if cm.store != nil {
+		return NewStoredSemaphore(semaphoreName, limit, cm.nextWorkflow, cm.store), nil
+	}
 	return NewSemaphore(semaphoreName, limit, cm.nextWorkflow, "semaphore"), nil
 }
 
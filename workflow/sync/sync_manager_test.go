@@ -3,6 +3,7 @@ package sync
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -871,3 +872,188 @@ status:
 	})
 
 }
+
+func TestSemaphoreWeight(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+	var cm v1.ConfigMap
+	wfv1.MustUnmarshal([]byte(configMap), &cm)
+	cm.Data["workflow"] = "3"
+	ctx := context.Background()
+	_, err := kube.CoreV1().ConfigMaps("default").Create(ctx, &cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	concurrenyMgr := NewLockManager(GetSyncLimitFunc(kube), func(key string) {
+	}, WorkflowExistenceFunc)
+	wf := wfv1.MustUnmarshalWorkflow(wfWithSemaphore)
+	wf.Spec.Synchronization.Semaphore.Weight = 2
+	wf1 := wf.DeepCopy()
+	wf1.Name = "two"
+
+	status, _, _, err := concurrenyMgr.TryAcquire(wf, "", wf.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.True(t, status)
+	assert.Equal(t, int64(2), wf.Status.Synchronization.Semaphore.Holding[0].GetWeight(wf.Name))
+
+	status, _, msg, err := concurrenyMgr.TryAcquire(wf1, "", wf1.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.False(t, status)
+	assert.Equal(t, "Waiting for 2 units of default/ConfigMap/my-config/workflow lock. Lock status: 1/3", msg)
+
+	states := concurrenyMgr.GetLockStates()
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, 2, states[0].Used)
+		assert.Equal(t, []LockHolder{{Key: "default/" + wf.Name, Weight: 2}}, states[0].Holders)
+		if assert.Len(t, states[0].Pending, 1) {
+			assert.Equal(t, "default/two", states[0].Pending[0].Key)
+			assert.Equal(t, int64(2), states[0].Pending[0].Weight)
+		}
+	}
+
+	t.Run("Initialize", func(t *testing.T) {
+		concurrenyMgr := NewLockManager(GetSyncLimitFunc(kube), func(key string) {
+		}, WorkflowExistenceFunc)
+		concurrenyMgr.syncLockMap["default/ConfigMap/my-config/workflow"] = NewSemaphore("default/ConfigMap/my-config/workflow", 3, func(string) {}, "semaphore")
+		concurrenyMgr.Initialize([]wfv1.Workflow{*wf})
+		states := concurrenyMgr.GetLockStates()
+		if assert.Len(t, states, 1) {
+			assert.Equal(t, 2, states[0].Used)
+		}
+	})
+
+	t.Run("ExceedsLimit", func(t *testing.T) {
+		wf2 := wf.DeepCopy()
+		wf2.Name = "three"
+		wf2.Status = wfv1.WorkflowStatus{}
+		wf2.Spec.Synchronization.Semaphore.Weight = 4
+		_, _, _, err := concurrenyMgr.TryAcquire(wf2, "", wf2.Spec.Synchronization)
+		assert.EqualError(t, err, "semaphore weight 4 exceeds the limit 3 of default/ConfigMap/my-config/workflow")
+	})
+}
+
+// memoryLockStore is a LockStore shared by managers, like a database shared by controllers
+type memoryLockStore struct {
+	holders map[string]map[string]int64
+}
+
+func (s *memoryLockStore) Acquire(lockName, holderKey string, weight int64, limit int) (bool, error) {
+	holders := s.holders[lockName]
+	if _, ok := holders[holderKey]; ok {
+		return true, nil
+	}
+	var used int64
+	for _, w := range holders {
+		used += w
+	}
+	if used+weight > int64(limit) {
+		return false, nil
+	}
+	if holders == nil {
+		holders = map[string]int64{}
+		s.holders[lockName] = holders
+	}
+	holders[holderKey] = weight
+	return true, nil
+}
+
+func (s *memoryLockStore) Release(lockName, holderKey string) error {
+	delete(s.holders[lockName], holderKey)
+	return nil
+}
+
+func TestStoredSemaphore(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+	var cm v1.ConfigMap
+	wfv1.MustUnmarshal([]byte(configMap), &cm)
+	cm.Data["workflow"] = "2"
+	ctx := context.Background()
+	_, err := kube.CoreV1().ConfigMaps("default").Create(ctx, &cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	store := &memoryLockStore{holders: map[string]map[string]int64{}}
+	var nextKey string
+	newManager := func() *Manager {
+		return NewStoredLockManager(GetSyncLimitFunc(kube), func(key string) {
+			nextKey = key
+		}, WorkflowExistenceFunc, store)
+	}
+	wf := wfv1.MustUnmarshalWorkflow(wfWithSemaphore)
+	wf1 := wf.DeepCopy()
+	wf1.Name = "two"
+	wf2 := wf.DeepCopy()
+	wf2.Name = "three"
+
+	// two controllers share the limit of 2
+	mgr1 := newManager()
+	mgr2 := newManager()
+	status, _, _, err := mgr1.TryAcquire(wf, "", wf.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.True(t, status)
+	status, _, _, err = mgr2.TryAcquire(wf1, "", wf1.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.True(t, status)
+	status, _, msg, err := mgr2.TryAcquire(wf2, "", wf2.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.False(t, status)
+	assert.NotEmpty(t, msg)
+
+	// a release by the first controller is noticed by the second when it notifies its waiters
+	mgr1.Release(wf, "", wf.Spec.Synchronization)
+	nextKey = ""
+	mgr2.NotifyStoredWaiters()
+	assert.Equal(t, "default/three", nextKey)
+	status, _, _, err = mgr2.TryAcquire(wf2, "", wf2.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.True(t, status)
+
+	t.Run("Restart", func(t *testing.T) {
+		// the restarted controller re-acquires its holders, and still honors the limit
+		mgr := newManager()
+		mgr.Initialize([]wfv1.Workflow{*wf1})
+		wf3 := wf.DeepCopy()
+		wf3.Name = "four"
+		wf3.Status = wfv1.WorkflowStatus{}
+		status, _, _, err := mgr.TryAcquire(wf3, "", wf3.Spec.Synchronization)
+		assert.NoError(t, err)
+		assert.False(t, status)
+		assert.Equal(t, []string{"default/three", "default/two"}, func() []string {
+			var keys []string
+			for key := range store.holders["default/ConfigMap/my-config/workflow"] {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			return keys
+		}())
+	})
+}
+
+func TestReleaseLock(t *testing.T) {
+	kube := fake.NewSimpleClientset()
+	var cm v1.ConfigMap
+	wfv1.MustUnmarshal([]byte(configMap), &cm)
+	ctx := context.Background()
+	_, err := kube.CoreV1().ConfigMaps("default").Create(ctx, &cm, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	var nextKey string
+	concurrenyMgr := NewLockManager(GetSyncLimitFunc(kube), func(key string) {
+		nextKey = key
+	}, WorkflowExistenceFunc)
+	wf := wfv1.MustUnmarshalWorkflow(wfWithSemaphore)
+	wf1 := wf.DeepCopy()
+	wf1.Name = "two"
+	status, _, _, err := concurrenyMgr.TryAcquire(wf, "", wf.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.True(t, status)
+	status, _, _, err = concurrenyMgr.TryAcquire(wf1, "", wf1.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.False(t, status)
+
+	lockKey := "default/ConfigMap/my-config/workflow"
+	assert.False(t, concurrenyMgr.ReleaseLock(wf1, lockKey))
+	assert.True(t, concurrenyMgr.ReleaseLock(wf, lockKey))
+	assert.Empty(t, wf.Status.Synchronization.Semaphore.Holding[0].Holders)
+	assert.Equal(t, "default/two", nextKey)
+	status, _, _, err = concurrenyMgr.TryAcquire(wf1, "", wf1.Spec.Synchronization)
+	assert.NoError(t, err)
+	assert.True(t, status)
+}
@@ -14,7 +14,7 @@ import (
 
 // Throttler allows the controller to limit number of items it is processing in parallel.
 // Items are processed in priority order, and one processing starts, other items (including higher-priority items)
-// will be kept pending until the processing is complete.
+// will be kept pending until the processing is complete, unless the throttler preempts the lower-priority items.
 // Implementations should be idempotent.
 type Throttler interface {
 	Init(wfs []wfv1.Workflow) error
@@ -40,21 +40,36 @@ var NamespaceBucket BucketFunc = func(key Key) BucketKey {
 type throttler struct {
 	queue       QueueFunc
 	bucketFunc  BucketFunc
+	preemptible PreemptibleFunc
 	inProgress  buckets
 	pending     map[BucketKey]*priorityQueue
 	lock        *sync.Mutex
 	parallelism int
 }
 
-type bucket map[Key]bool
+// PreemptibleFunc returns whether the item can preempt, and be preempted by, items of other priorities
+type PreemptibleFunc func(Key) bool
+
+// bucket are the items in progress, by key
+type bucket map[Key]*item
 type buckets map[BucketKey]bucket
 
 // NewThrottler returns a throttle that only runs `parallelism` items at once. When an item may need processing,
 // `queue` is invoked.
 func NewThrottler(parallelism int, bucketFunc BucketFunc, queue QueueFunc) Throttler {
+	return NewPreemptingThrottler(parallelism, bucketFunc, queue, nil)
+}
+
+// NewPreemptingThrottler returns a throttle like NewThrottler, except that when `parallelism` items are in progress,
+// a newly added item preempts the lowest-priority item in progress, if that has a lower priority. The preempted item
+// is pending until it is the highest-priority item when processing of another item completes. Only preemptible
+// items preempt, or are preempted, and items are not preempted if `preemptible` is nil. `queue` is invoked for both
+// items, so the preempted item can pause.
+func NewPreemptingThrottler(parallelism int, bucketFunc BucketFunc, queue QueueFunc, preemptible PreemptibleFunc) Throttler {
 	return &throttler{
 		queue:       queue,
 		bucketFunc:  bucketFunc,
+		preemptible: preemptible,
 		inProgress:  make(buckets),
 		pending:     make(map[BucketKey]*priorityQueue),
 		lock:        &sync.Mutex{},
@@ -79,7 +94,11 @@ func (t *throttler) Init(wfs []wfv1.Workflow) error {
 			if _, ok := t.inProgress[bucketKey]; !ok {
 				t.inProgress[bucketKey] = make(bucket)
 			}
-			t.inProgress[bucketKey][key] = true
+			var priority int32
+			if wf.Spec.Priority != nil {
+				priority = *wf.Spec.Priority
+			}
+			t.inProgress[bucketKey][key] = &item{key: key, priority: priority, creationTime: wf.CreationTimestamp.Time}
 		}
 	}
 	return nil
@@ -92,6 +111,10 @@ func (t *throttler) Add(key Key, priority int32, creationTime time.Time) {
 		return
 	}
 	bucketKey := t.bucketFunc(key)
+	if x, ok := t.inProgress[bucketKey][key]; ok {
+		x.priority = priority
+		return
+	}
 	if _, ok := t.pending[bucketKey]; !ok {
 		t.pending[bucketKey] = &priorityQueue{itemByKey: make(map[string]*item)}
 	}
@@ -106,7 +129,7 @@ func (t *throttler) Admit(key Key) bool {
 		return true
 	}
 	bucketKey := t.bucketFunc(key)
-	if x, ok := t.inProgress[bucketKey]; ok && x[key] {
+	if x, ok := t.inProgress[bucketKey]; ok && x[key] != nil {
 		return true
 	}
 	t.queueThrottled(bucketKey)
@@ -133,17 +156,79 @@ func (t *throttler) queueThrottled(bucketKey BucketKey) {
 	inProgress := t.inProgress[bucketKey]
 	pending, ok := t.pending[bucketKey]
 	for ok && pending.Len() > 0 && t.parallelism > len(inProgress) {
-		key := pending.pop().key
-		inProgress[key] = true
-		t.queue(key)
+		next := pending.pop()
+		inProgress[next.key] = next
+		t.queue(next.key)
+	}
+	for ok && pending.Len() > 0 && t.preemptible != nil {
+		next := pending.peek()
+		// items that were preempted wait for another item to complete, rather than preempting in turn
+		if next.preempted || !t.preemptible(next.key) {
+			return
+		}
+		victim := inProgress.lowestPriority(t.preemptible)
+		if victim == nil || victim.priority >= next.priority {
+			return
+		}
+		delete(inProgress, victim.key)
+		pending.pop()
+		inProgress[next.key] = next
+		victim.preempted = true
+		pending.push(victim)
+		t.queue(next.key)
+		t.queue(victim.key)
 	}
 }
 
+// lowestPriority returns the preemptible item with the lowest priority, and of those the newest, or nil
+func (b bucket) lowestPriority(preemptible PreemptibleFunc) *item {
+	var lowest *item
+	for _, x := range b {
+		if !preemptible(x.key) {
+			continue
+		}
+		if lowest == nil || x.priority < lowest.priority || (x.priority == lowest.priority && x.creationTime.After(lowest.creationTime)) {
+			lowest = x
+		}
+	}
+	return lowest
+}
+
 type item struct {
 	key          string
 	creationTime time.Time
 	priority     int32
 	index        int
+	// queueTime is when the item was added to the queue
+	queueTime time.Time
+	// weight is the number of units of a semaphore the item is waiting for, zero until it is known
+	weight int64
+	// preempted is whether the item was preempted by a higher-priority item
+	preempted bool
+}
+
+func (i *item) getWeight() int64 {
+	if i.weight < 1 {
+		return 1
+	}
+	return i.weight
+}
+
+// effectivePriority is the priority of the item raised by one for every priorityAgingInterval it has been queued
+func (i *item) effectivePriority() int64 {
+	priority := int64(i.priority)
+	if priorityAgingInterval > 0 && !i.queueTime.IsZero() {
+		priority += int64(time.Since(i.queueTime) / priorityAgingInterval)
+	}
+	return priority
+}
+
+// before returns whether the item should acquire a lock before the other one
+func (i *item) before(other *item) bool {
+	if p, q := i.effectivePriority(), other.effectivePriority(); p != q {
+		return p > q
+	}
+	return i.creationTime.Before(other.creationTime)
 }
 
 type priorityQueue struct {
@@ -166,10 +251,16 @@ func (pq *priorityQueue) add(key Key, priority int32, creationTime time.Time) {
 			heap.Fix(pq, res.index)
 		}
 	} else {
-		heap.Push(pq, &item{key: key, priority: priority, creationTime: creationTime})
+		heap.Push(pq, &item{key: key, priority: priority, creationTime: creationTime, queueTime: time.Now()})
 	}
 }
 
+// push adds the item, which was in progress, back to the queue
+func (pq *priorityQueue) push(x *item) {
+	x.queueTime = time.Now()
+	heap.Push(pq, x)
+}
+
 func (pq *priorityQueue) remove(key Key) {
 	if item, ok := pq.itemByKey[key]; ok {
 		heap.Remove(pq, item.index)
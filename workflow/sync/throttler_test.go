@@ -76,6 +76,51 @@ func TestWithParallelismLimitAndPriority(t *testing.T) {
 	assert.Equal(t, "c", queuedKey)
 }
 
+func TestPreemption(t *testing.T) {
+	var queued []string
+	throttler := NewPreemptingThrottler(2, SingleBucket, func(key string) { queued = append(queued, key) }, func(key Key) bool {
+		return NamespaceBucket(key) != "not-preemptible"
+	})
+	now := time.Now()
+	throttler.Add("ns/low", 1, now)
+	throttler.Add("not-preemptible/lowest", 0, now)
+	assert.True(t, throttler.Admit("ns/low"))
+	assert.True(t, throttler.Admit("not-preemptible/lowest"))
+	queued = nil
+
+	throttler.Add("ns/same", 1, now.Add(time.Second))
+	assert.False(t, throttler.Admit("ns/same"), "does not preempt a workflow of the same priority")
+	assert.Empty(t, queued)
+
+	throttler.Add("ns/high", 2, now.Add(time.Second))
+	assert.True(t, throttler.Admit("ns/high"), "preempts the lowest-priority preemptible workflow")
+	assert.False(t, throttler.Admit("ns/low"), "is preempted")
+	assert.True(t, throttler.Admit("not-preemptible/lowest"), "is not preemptible")
+	assert.Equal(t, []string{"ns/high", "ns/low"}, queued)
+	queued = nil
+
+	throttler.Add("ns/low", 1, now)
+	assert.False(t, throttler.Admit("ns/low"), "an update does not resume a preempted workflow")
+	assert.Empty(t, queued)
+
+	throttler.Add("ns/higher", 3, now.Add(2*time.Second))
+	assert.True(t, throttler.Admit("ns/higher"))
+	assert.False(t, throttler.Admit("ns/high"), "is preempted in turn")
+	assert.Equal(t, []string{"ns/higher", "ns/high"}, queued)
+	queued = nil
+
+	throttler.Remove("ns/higher")
+	assert.True(t, throttler.Admit("ns/high"), "is resumed first, as it has the highest priority")
+	assert.False(t, throttler.Admit("ns/low"))
+	assert.Equal(t, []string{"ns/high"}, queued)
+	queued = nil
+
+	throttler.Remove("not-preemptible/lowest")
+	assert.True(t, throttler.Admit("ns/low"), "is resumed, rather than the workflow of the same priority submitted later")
+	assert.False(t, throttler.Admit("ns/same"))
+	assert.Equal(t, []string{"ns/low"}, queued)
+}
+
 func TestInitWithWorkflows(t *testing.T) {
 	queuedKey := ""
 	throttler := NewThrottler(1, SingleBucket, func(key string) { queuedKey = key })
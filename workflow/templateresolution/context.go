@@ -7,11 +7,15 @@ import (
 	log "github.com/sirupsen/logrus"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/argoproj/argo-workflows/v3/errors"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	typed "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/templaterevision"
 )
 
 // maxResolveDepth is the limit of template reference resolution.
@@ -66,6 +70,113 @@ func (wrapper *clusterWorkflowTemplateInterfaceWrapper) Get(name string) (*wfv1.
 	return wrapper.clientset.Get(ctx, name, metav1.GetOptions{})
 }
 
+// WorkflowTemplateRevisionGetter is implemented by WorkflowTemplateNamespacedGetters that can get the saved revisions
+// of WorkflowTemplates.
+type WorkflowTemplateRevisionGetter interface {
+	// GetRevision retrieves a revision of the latest revision of a WorkflowTemplate.
+	GetRevision(latest *wfv1.WorkflowTemplate, revision int64) (*wfv1.WorkflowTemplate, error)
+}
+
+// ClusterWorkflowTemplateRevisionGetter is implemented by ClusterWorkflowTemplateGetters that can get the saved
+// revisions of ClusterWorkflowTemplates.
+type ClusterWorkflowTemplateRevisionGetter interface {
+	// GetRevision retrieves a revision of the latest revision of a ClusterWorkflowTemplate.
+	GetRevision(latest *wfv1.ClusterWorkflowTemplate, revision int64) (*wfv1.ClusterWorkflowTemplate, error)
+}
+
+type workflowTemplateRevisionGetter struct {
+	WorkflowTemplateNamespacedGetter
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+// WithWorkflowTemplateRevisions returns a getter that can also get the saved revisions of the WorkflowTemplates in the
+// namespace.
+func WithWorkflowTemplateRevisions(getter WorkflowTemplateNamespacedGetter, kubeClient kubernetes.Interface, namespace string) WorkflowTemplateNamespacedGetter {
+	return &workflowTemplateRevisionGetter{getter, kubeClient, namespace}
+}
+
+func (g *workflowTemplateRevisionGetter) GetRevision(latest *wfv1.WorkflowTemplate, revision int64) (*wfv1.WorkflowTemplate, error) {
+	return templaterevision.GetWorkflowTemplate(context.TODO(), g.kubeClient, latest, revision)
+}
+
+type clusterWorkflowTemplateRevisionGetter struct {
+	ClusterWorkflowTemplateGetter
+	kubeClient kubernetes.Interface
+	namespace  string
+}
+
+// WithClusterWorkflowTemplateRevisions returns a getter that can also get the saved revisions of ClusterWorkflowTemplates,
+// which are saved in the namespace of the controller.
+func WithClusterWorkflowTemplateRevisions(getter ClusterWorkflowTemplateGetter, kubeClient kubernetes.Interface, namespace string) ClusterWorkflowTemplateGetter {
+	return &clusterWorkflowTemplateRevisionGetter{getter, kubeClient, namespace}
+}
+
+func (g *clusterWorkflowTemplateRevisionGetter) GetRevision(latest *wfv1.ClusterWorkflowTemplate, revision int64) (*wfv1.ClusterWorkflowTemplate, error) {
+	return templaterevision.GetClusterWorkflowTemplate(context.TODO(), g.kubeClient, g.namespace, latest, revision)
+}
+
+type namespaceRestrictedClusterWorkflowTemplateGetter struct {
+	ClusterWorkflowTemplateGetter
+	namespace string
+}
+
+// WithNamespaceRestrictions returns a getter that forbids getting the ClusterWorkflowTemplates whose namespace
+// restrictions do not allow the namespace.
+func WithNamespaceRestrictions(getter ClusterWorkflowTemplateGetter, namespace string) ClusterWorkflowTemplateGetter {
+	return &namespaceRestrictedClusterWorkflowTemplateGetter{getter, namespace}
+}
+
+func (g *namespaceRestrictedClusterWorkflowTemplateGetter) Get(name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	cwftmpl, err := g.ClusterWorkflowTemplateGetter.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if !cwftmpl.Spec.NamespaceRestrictions.Allows(g.namespace) {
+		return nil, apierr.NewForbidden(schema.GroupResource{Group: workflow.Group, Resource: workflow.ClusterWorkflowTemplatePlural}, name, fmt.Errorf("it may not be referenced from namespace %s", g.namespace))
+	}
+	return cwftmpl, nil
+}
+
+// GetRevision checks the restrictions of the latest revision, so that a namespace that is no longer allowed cannot use
+// an older revision that allowed it.
+func (g *namespaceRestrictedClusterWorkflowTemplateGetter) GetRevision(latest *wfv1.ClusterWorkflowTemplate, revision int64) (*wfv1.ClusterWorkflowTemplate, error) {
+	if !latest.Spec.NamespaceRestrictions.Allows(g.namespace) {
+		return nil, apierr.NewForbidden(schema.GroupResource{Group: workflow.Group, Resource: workflow.ClusterWorkflowTemplatePlural}, latest.Name, fmt.Errorf("it may not be referenced from namespace %s", g.namespace))
+	}
+	if revisionGetter, ok := g.ClusterWorkflowTemplateGetter.(ClusterWorkflowTemplateRevisionGetter); ok {
+		return revisionGetter.GetRevision(latest, revision)
+	}
+	return latest, nil
+}
+
+// GetWorkflowTemplate retrieves a revision of the WorkflowTemplate of a given name, or the latest revision if the
+// revision is zero. Getters that cannot get revisions, e.g. when linting, always retrieve the latest revision.
+func GetWorkflowTemplate(getter WorkflowTemplateNamespacedGetter, name string, revision int64) (*wfv1.WorkflowTemplate, error) {
+	wftmpl, err := getter.Get(name)
+	if err != nil || revision == 0 || wftmpl.Generation == revision {
+		return wftmpl, err
+	}
+	if revisionGetter, ok := getter.(WorkflowTemplateRevisionGetter); ok {
+		return revisionGetter.GetRevision(wftmpl, revision)
+	}
+	return wftmpl, nil
+}
+
+// GetClusterWorkflowTemplate retrieves a revision of the ClusterWorkflowTemplate of a given name, or the latest
+// revision if the revision is zero. Getters that cannot get revisions, e.g. when linting, always retrieve the latest
+// revision.
+func GetClusterWorkflowTemplate(getter ClusterWorkflowTemplateGetter, name string, revision int64) (*wfv1.ClusterWorkflowTemplate, error) {
+	cwftmpl, err := getter.Get(name)
+	if err != nil || revision == 0 || cwftmpl.Generation == revision {
+		return cwftmpl, err
+	}
+	if revisionGetter, ok := getter.(ClusterWorkflowTemplateRevisionGetter); ok {
+		return revisionGetter.GetRevision(cwftmpl, revision)
+	}
+	return cwftmpl, nil
+}
+
 // Context is a context of template search.
 type Context struct {
 	// wftmplGetter is an interface to get WorkflowTemplates.
@@ -108,16 +219,79 @@ func (ctx *Context) GetTemplateByName(name string) (*wfv1.Template, error) {
 
 	tmpl := ctx.tmplBase.GetTemplateByName(name)
 	if tmpl == nil {
+		tmplRef, err := ctx.getImportedTemplateRef(name)
+		if err != nil {
+			return nil, err
+		}
+		if tmplRef != nil {
+			return ctx.GetTemplateFromRef(tmplRef)
+		}
 		return nil, errors.Errorf(errors.CodeNotFound, "template %s not found", name)
 	}
 	return tmpl.DeepCopy(), nil
 }
 
+// getImportedTemplateRef returns a reference to the template if it is not defined in the template base, but imported
+// by it, otherwise nil. Imports are searched in order.
+func (ctx *Context) getImportedTemplateRef(name string) (*wfv1.TemplateRef, error) {
+	if hasTemplate(ctx.tmplBase, name) {
+		return nil, nil
+	}
+	for _, templateImport := range getTemplateImports(ctx.tmplBase) {
+		if !templateImport.Imports(name) {
+			continue
+		}
+		tmplRef := templateImport.ToTemplateRef(name)
+		tmplBase, err := ctx.GetTemplateGetterFromRef(tmplRef)
+		if err != nil {
+			if apierr.IsNotFound(err) {
+				return nil, errors.Errorf(errors.CodeNotFound, "imported workflow template %s not found", templateImport.Name)
+			}
+			return nil, err
+		}
+		if tmplBase.GetTemplateByName(name) != nil {
+			return tmplRef, nil
+		}
+	}
+	return nil, nil
+}
+
+// hasTemplate returns true if the template is defined by the template base. Unlike GetTemplateByName, the templates
+// stored in the status of a workflow are not considered, as they include the templates imported by it.
+func hasTemplate(tmplBase wfv1.TemplateHolder, name string) bool {
+	wf, ok := tmplBase.(*wfv1.Workflow)
+	if !ok {
+		return tmplBase.GetTemplateByName(name) != nil
+	}
+	specs := []*wfv1.WorkflowSpec{&wf.Spec}
+	if wf.Status.StoredWorkflowSpec != nil {
+		specs = append(specs, wf.Status.StoredWorkflowSpec)
+	}
+	for _, spec := range specs {
+		for _, t := range spec.Templates {
+			if t.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func getTemplateImports(tmplBase wfv1.TemplateHolder) []wfv1.TemplateImport {
+	switch x := tmplBase.(type) {
+	case *wfv1.Workflow:
+		return x.Spec.TemplateImports
+	case wfv1.WorkflowSpecHolder:
+		return x.GetWorkflowSpec().TemplateImports
+	}
+	return nil
+}
+
 func (ctx *Context) GetTemplateGetterFromRef(tmplRef *wfv1.TemplateRef) (wfv1.TemplateHolder, error) {
 	if tmplRef.ClusterScope {
-		return ctx.cwftmplGetter.Get(tmplRef.Name)
+		return GetClusterWorkflowTemplate(ctx.cwftmplGetter, tmplRef.Name, tmplRef.Revision)
 	}
-	return ctx.wftmplGetter.Get(tmplRef.Name)
+	return GetWorkflowTemplate(ctx.wftmplGetter, tmplRef.Name, tmplRef.Revision)
 }
 
 // GetTemplateFromRef returns a template found by a given template ref.
@@ -127,9 +301,9 @@ func (ctx *Context) GetTemplateFromRef(tmplRef *wfv1.TemplateRef) (*wfv1.Templat
 	var wftmpl wfv1.TemplateHolder
 	var err error
 	if tmplRef.ClusterScope {
-		wftmpl, err = ctx.cwftmplGetter.Get(tmplRef.Name)
+		wftmpl, err = GetClusterWorkflowTemplate(ctx.cwftmplGetter, tmplRef.Name, tmplRef.Revision)
 	} else {
-		wftmpl, err = ctx.wftmplGetter.Get(tmplRef.Name)
+		wftmpl, err = GetWorkflowTemplate(ctx.wftmplGetter, tmplRef.Name, tmplRef.Revision)
 	}
 
 	if err != nil {
@@ -192,6 +366,18 @@ func (ctx *Context) resolveTemplateImpl(tmplHolder wfv1.TemplateReferenceHolder,
 
 	ctx.log.Debug("Resolving the template")
 
+	// Imported templates are resolved as references, so that they are stored, and the templates they call are
+	// searched for, in the workflow template that defines them.
+	if tmplHolder.GetTemplate() == nil && tmplHolder.GetTemplateRef() == nil && tmplHolder.GetTemplateName() != "" {
+		tmplRef, err := ctx.getImportedTemplateRef(tmplHolder.GetTemplateName())
+		if err != nil {
+			return nil, nil, false, err
+		}
+		if tmplRef != nil {
+			tmplHolder = &wfv1.WorkflowStep{Name: tmplHolder.GetName(), TemplateRef: tmplRef}
+		}
+	}
+
 	templateStored := false
 	var tmpl *wfv1.Template
 	if ctx.workflow != nil {
@@ -241,12 +427,11 @@ func (ctx *Context) resolveTemplateImpl(tmplHolder wfv1.TemplateReferenceHolder,
 func (ctx *Context) WithTemplateHolder(tmplHolder wfv1.TemplateReferenceHolder) (*Context, error) {
 	tmplRef := tmplHolder.GetTemplateRef()
 	if tmplRef != nil {
-		tmplName := tmplRef.Name
-		if tmplRef.ClusterScope {
-			return ctx.WithClusterWorkflowTemplate(tmplName)
-		} else {
-			return ctx.WithWorkflowTemplate(tmplName)
+		tmplBase, err := ctx.GetTemplateGetterFromRef(tmplRef)
+		if err != nil {
+			return nil, err
 		}
+		return ctx.WithTemplateBase(tmplBase), nil
 	}
 	return ctx.WithTemplateBase(ctx.tmplBase), nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -386,3 +387,25 @@ func TestOnWorkflowTemplate(t *testing.T) {
 	tmpl := newCtx.tmplBase.GetTemplateByName("whalesay")
 	assert.NotNil(t, tmpl)
 }
+
+func TestWithNamespaceRestrictions(t *testing.T) {
+	wfClientset := fakewfclientset.NewSimpleClientset(&wfv1.ClusterWorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cwftmpl"},
+		Spec: wfv1.WorkflowSpec{
+			NamespaceRestrictions: &wfv1.NamespaceRestrictions{Allow: []string{"team-*"}},
+			Templates:             []wfv1.Template{{Name: "whalesay", Container: &apiv1.Container{Image: "docker/whalesay"}}},
+		},
+	})
+	getter := WrapClusterWorkflowTemplateInterface(wfClientset.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	tmplRef := &wfv1.TemplateRef{Name: "my-cwftmpl", Template: "whalesay", ClusterScope: true}
+	t.Run("Allowed", func(t *testing.T) {
+		ctx := NewContext(nil, WithNamespaceRestrictions(getter, "team-a"), unmarshalWftmpl(baseWorkflowTemplateYaml), nil)
+		_, err := ctx.GetTemplateFromRef(tmplRef)
+		assert.NoError(t, err)
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		ctx := NewContext(nil, WithNamespaceRestrictions(getter, "default"), unmarshalWftmpl(baseWorkflowTemplateYaml), nil)
+		_, err := ctx.GetTemplateFromRef(tmplRef)
+		assert.True(t, apierr.IsForbidden(err))
+	})
+}
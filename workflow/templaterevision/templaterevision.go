@@ -0,0 +1,220 @@
+// Package templaterevision saves immutable revisions of WorkflowTemplates and ClusterWorkflowTemplates, so that
+// workflows can keep using the revision they started with, or pin one, when the template is later updated.
+//
+// Each revision is a config map labelled with the name of the template and its revision, which is the
+// metadata.generation of the template when it was saved. The config map is owned by the template, so revisions are
+// deleted with it, and only config maps owned by the template are its revisions. Revisions of ClusterWorkflowTemplates
+// are saved in the controller's namespace.
+//
+// Revisions are saved by the controller as it observes changes to the templates, not when the template is written, so
+// generations that are superseded before the controller observes them are not saved, and revisions can have gaps.
+package templaterevision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// dataKey is the key of the config map data that holds the template
+const dataKey = "template"
+
+// SaveWorkflowTemplate saves the current revision of the WorkflowTemplate, unless it has already been saved, and
+// deletes the oldest revisions so that no more than historyLimit are kept.
+func SaveWorkflowTemplate(ctx context.Context, kubeClient kubernetes.Interface, wftmpl *wfv1.WorkflowTemplate, historyLimit int) error {
+	return save(ctx, kubeClient, wftmpl.Namespace, common.LabelKeyWorkflowTemplate, workflow.WorkflowTemplateKind, wftmpl, historyLimit)
+}
+
+// SaveClusterWorkflowTemplate saves the current revision of the ClusterWorkflowTemplate in the namespace, unless it has
+// already been saved, and deletes the oldest revisions so that no more than historyLimit are kept.
+func SaveClusterWorkflowTemplate(ctx context.Context, kubeClient kubernetes.Interface, namespace string, cwftmpl *wfv1.ClusterWorkflowTemplate, historyLimit int) error {
+	return save(ctx, kubeClient, namespace, common.LabelKeyClusterWorkflowTemplate, workflow.ClusterWorkflowTemplateKind, cwftmpl, historyLimit)
+}
+
+// GetWorkflowTemplate returns a revision of the WorkflowTemplate.
+func GetWorkflowTemplate(ctx context.Context, kubeClient kubernetes.Interface, wftmpl *wfv1.WorkflowTemplate, revision int64) (*wfv1.WorkflowTemplate, error) {
+	obj := &wfv1.WorkflowTemplate{}
+	return obj, get(ctx, kubeClient, wftmpl.Namespace, common.LabelKeyWorkflowTemplate, workflow.WorkflowTemplatePlural, wftmpl, revision, obj)
+}
+
+// GetClusterWorkflowTemplate returns a revision of the ClusterWorkflowTemplate, saved in the namespace.
+func GetClusterWorkflowTemplate(ctx context.Context, kubeClient kubernetes.Interface, namespace string, cwftmpl *wfv1.ClusterWorkflowTemplate, revision int64) (*wfv1.ClusterWorkflowTemplate, error) {
+	obj := &wfv1.ClusterWorkflowTemplate{}
+	return obj, get(ctx, kubeClient, namespace, common.LabelKeyClusterWorkflowTemplate, workflow.ClusterWorkflowTemplatePlural, cwftmpl, revision, obj)
+}
+
+// ListWorkflowTemplate returns the saved revisions of the WorkflowTemplate, oldest first.
+func ListWorkflowTemplate(ctx context.Context, kubeClient kubernetes.Interface, wftmpl *wfv1.WorkflowTemplate) ([]wfv1.WorkflowTemplate, error) {
+	cms, err := listOwned(ctx, kubeClient, wftmpl.Namespace, common.LabelKeyWorkflowTemplate, wftmpl)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]wfv1.WorkflowTemplate, len(cms))
+	for i, cm := range cms {
+		if err := unmarshal(cm, &items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// ListClusterWorkflowTemplate returns the saved revisions of the ClusterWorkflowTemplate, saved in the namespace, oldest
+// first.
+func ListClusterWorkflowTemplate(ctx context.Context, kubeClient kubernetes.Interface, namespace string, cwftmpl *wfv1.ClusterWorkflowTemplate) ([]wfv1.ClusterWorkflowTemplate, error) {
+	cms, err := listOwned(ctx, kubeClient, namespace, common.LabelKeyClusterWorkflowTemplate, cwftmpl)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]wfv1.ClusterWorkflowTemplate, len(cms))
+	for i, cm := range cms {
+		if err := unmarshal(cm, &items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+func save(ctx context.Context, kubeClient kubernetes.Interface, namespace, labelKey, kind string, obj metav1.Object, historyLimit int) error {
+	// the API server sets the generation of every template it creates, so only templates that were never created are 0
+	if obj.GetGeneration() == 0 {
+		return nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	revision := strconv.FormatInt(obj.GetGeneration(), 10)
+	immutable := true
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("%s-%s-%s", kindPrefix(kind), obj.GetName(), revision),
+			Labels: map[string]string{
+				common.LabelKeyConfigMapType:    common.LabelValueTypeConfigMapTemplateRevision,
+				labelKey:                        obj.GetName(),
+				common.LabelKeyTemplateRevision: revision,
+			},
+		},
+		Immutable: &immutable,
+		Data:      map[string]string{dataKey: string(data)},
+	}
+	if obj.GetUID() != "" {
+		cm.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(obj, schema.GroupVersionKind{Group: workflow.Group, Version: workflow.Version, Kind: kind})}
+	}
+	_, err = kubeClient.CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{})
+	if apierr.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.WithFields(log.Fields{"namespace": namespace, "name": obj.GetName(), "kind": kind, "revision": revision}).Info("Saved template revision")
+	cms, err := list(ctx, kubeClient, namespace, labelKey, obj.GetName())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(cms)-historyLimit; i++ {
+		err := kubeClient.CoreV1().ConfigMaps(cms[i].Namespace).Delete(ctx, cms[i].Name, metav1.DeleteOptions{})
+		if err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func kindPrefix(kind string) string {
+	if kind == workflow.ClusterWorkflowTemplateKind {
+		return "clusterworkflowtemplate"
+	}
+	return "workflowtemplate"
+}
+
+func get(ctx context.Context, kubeClient kubernetes.Interface, namespace, labelKey, resource string, owner metav1.Object, revision int64, obj metav1.Object) error {
+	selector := labels.Set{
+		common.LabelKeyConfigMapType:    common.LabelValueTypeConfigMapTemplateRevision,
+		labelKey:                        owner.GetName(),
+		common.LabelKeyTemplateRevision: strconv.FormatInt(revision, 10),
+	}
+	cms, err := kubeClient.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	for i := range cms.Items {
+		if ownedBy(&cms.Items[i], owner) {
+			return unmarshal(&cms.Items[i], obj)
+		}
+	}
+	return apierr.NewNotFound(schema.GroupResource{Group: workflow.Group, Resource: resource}, fmt.Sprintf("%s@%d", owner.GetName(), revision))
+}
+
+// ownedBy returns whether the template owns the config map, so that config maps labelled by anyone who can create
+// config maps are not used as revisions, nor are the revisions of a deleted template with the same name
+func ownedBy(cm *apiv1.ConfigMap, owner metav1.Object) bool {
+	ref := metav1.GetControllerOf(cm)
+	return ref != nil && owner.GetUID() != "" && ref.UID == owner.GetUID()
+}
+
+// listOwned returns the revisions of the template that it owns, oldest first
+func listOwned(ctx context.Context, kubeClient kubernetes.Interface, namespace, labelKey string, owner metav1.Object) ([]*apiv1.ConfigMap, error) {
+	cms, err := list(ctx, kubeClient, namespace, labelKey, owner.GetName())
+	if err != nil {
+		return nil, err
+	}
+	var owned []*apiv1.ConfigMap
+	for _, cm := range cms {
+		if ownedBy(cm, owner) {
+			owned = append(owned, cm)
+		}
+	}
+	return owned, nil
+}
+
+// list returns the revisions of the template, oldest first
+func list(ctx context.Context, kubeClient kubernetes.Interface, namespace, labelKey, name string) ([]*apiv1.ConfigMap, error) {
+	selector := labels.Set{
+		common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapTemplateRevision,
+		labelKey:                     name,
+	}
+	cms, err := kubeClient.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*apiv1.ConfigMap, len(cms.Items))
+	for i := range cms.Items {
+		items[i] = &cms.Items[i]
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return revisionOf(items[i]) < revisionOf(items[j])
+	})
+	return items, nil
+}
+
+func revisionOf(cm *apiv1.ConfigMap) int64 {
+	revision, _ := strconv.ParseInt(cm.Labels[common.LabelKeyTemplateRevision], 10, 64)
+	return revision
+}
+
+// unmarshal the template from the config map, such that its generation is the revision and its creation timestamp is
+// when the revision was saved
+func unmarshal(cm *apiv1.ConfigMap, obj metav1.Object) error {
+	if err := json.Unmarshal([]byte(cm.Data[dataKey]), obj); err != nil {
+		return fmt.Errorf("malformed template revision %s/%s: %w", cm.Namespace, cm.Name, err)
+	}
+	obj.SetGeneration(revisionOf(cm))
+	obj.SetCreationTimestamp(cm.CreationTimestamp)
+	obj.SetResourceVersion("")
+	return nil
+}
@@ -0,0 +1,99 @@
+package templaterevision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestWorkflowTemplate(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset()
+	wftmpl := &wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "my-ns", Name: "my-wftmpl", UID: "my-uid"},
+		Spec:       wfv1.WorkflowSpec{Entrypoint: "main"},
+	}
+	t.Run("NotCreated", func(t *testing.T) {
+		assert.NoError(t, SaveWorkflowTemplate(ctx, kubeClient, wftmpl, 2))
+		revisions, err := ListWorkflowTemplate(ctx, kubeClient, wftmpl)
+		if assert.NoError(t, err) {
+			assert.Empty(t, revisions)
+		}
+	})
+	for i, entrypoint := range []string{"main", "a", "b"} {
+		wftmpl.Generation = int64(i + 1)
+		wftmpl.Spec.Entrypoint = entrypoint
+		assert.NoError(t, SaveWorkflowTemplate(ctx, kubeClient, wftmpl, 2))
+	}
+	t.Run("Saved", func(t *testing.T) {
+		cm, err := kubeClient.CoreV1().ConfigMaps("my-ns").Get(ctx, "workflowtemplate-my-wftmpl-3", metav1.GetOptions{})
+		if assert.NoError(t, err) {
+			assert.True(t, *cm.Immutable)
+			if assert.Len(t, cm.OwnerReferences, 1) {
+				assert.Equal(t, "WorkflowTemplate", cm.OwnerReferences[0].Kind)
+				assert.Equal(t, "my-uid", string(cm.OwnerReferences[0].UID))
+			}
+		}
+	})
+	t.Run("AlreadySaved", func(t *testing.T) {
+		assert.NoError(t, SaveWorkflowTemplate(ctx, kubeClient, wftmpl, 2))
+	})
+	t.Run("List", func(t *testing.T) {
+		revisions, err := ListWorkflowTemplate(ctx, kubeClient, wftmpl)
+		if assert.NoError(t, err) && assert.Len(t, revisions, 2) {
+			assert.Equal(t, int64(2), revisions[0].Generation)
+			assert.Equal(t, "a", revisions[0].Spec.Entrypoint)
+			assert.Equal(t, int64(3), revisions[1].Generation)
+			assert.Equal(t, "b", revisions[1].Spec.Entrypoint)
+		}
+	})
+	t.Run("Get", func(t *testing.T) {
+		revision, err := GetWorkflowTemplate(ctx, kubeClient, wftmpl, 2)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "my-wftmpl", revision.Name)
+			assert.Equal(t, "a", revision.Spec.Entrypoint)
+		}
+		_, err = GetWorkflowTemplate(ctx, kubeClient, wftmpl, 1)
+		assert.True(t, apierr.IsNotFound(err), "the oldest revision is deleted")
+	})
+	t.Run("NotOwned", func(t *testing.T) {
+		recreated := wftmpl.DeepCopy()
+		recreated.UID = "my-other-uid"
+		_, err := GetWorkflowTemplate(ctx, kubeClient, recreated, 2)
+		assert.True(t, apierr.IsNotFound(err), "the revisions of a deleted template with the same name are not used")
+		revisions, err := ListWorkflowTemplate(ctx, kubeClient, recreated)
+		if assert.NoError(t, err) {
+			assert.Empty(t, revisions)
+		}
+	})
+}
+
+func TestClusterWorkflowTemplate(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewSimpleClientset()
+	cwftmpl := &wfv1.ClusterWorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cwftmpl", Generation: 1, UID: "my-uid"},
+		Spec:       wfv1.WorkflowSpec{Entrypoint: "main"},
+	}
+	assert.NoError(t, SaveClusterWorkflowTemplate(ctx, kubeClient, "argo", cwftmpl, 10))
+	revision, err := GetClusterWorkflowTemplate(ctx, kubeClient, "argo", cwftmpl, 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "main", revision.Spec.Entrypoint)
+	}
+	revisions, err := ListClusterWorkflowTemplate(ctx, kubeClient, "argo", cwftmpl)
+	if assert.NoError(t, err) {
+		assert.Len(t, revisions, 1)
+	}
+	// revisions are only read from the controller's namespace
+	_, err = GetClusterWorkflowTemplate(ctx, kubeClient, "my-ns", cwftmpl, 1)
+	assert.True(t, apierr.IsNotFound(err))
+	// revisions of ClusterWorkflowTemplates are not revisions of WorkflowTemplates with the same name
+	_, err = GetWorkflowTemplate(ctx, kubeClient, &wfv1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{Namespace: "argo", Name: "my-cwftmpl", UID: "my-uid"}}, 1)
+	assert.True(t, apierr.IsNotFound(err))
+}
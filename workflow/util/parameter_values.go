@@ -0,0 +1,199 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	cmdutil "github.com/argoproj/argo-workflows/v3/util/cmd"
+)
+
+// ParameterValues are the values of a workflow's parameters, gathered Helm-style from parameter files, `--parameter`,
+// `--set` and `--set-string`. A value may be structured (e.g. a map or a list), in which case the parameter's value
+// is its JSON.
+type ParameterValues map[string]interface{}
+
+// ReadFile deep-merges the YAML or JSON parameter file, which may be a URL, into the values.
+func (v ParameterValues) ReadFile(file string) error {
+	var body []byte
+	var err error
+	if cmdutil.IsURL(file) {
+		body, err = ReadFromUrl(file)
+	} else {
+		body, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return err
+	}
+	data, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep numbers as they were written, e.g. not 8.1861780812e+10
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return fmt.Errorf("parameter file %s must contain a map of parameter names to values: %w", file, err)
+	}
+	deepMerge(v, values)
+	return nil
+}
+
+// SetParameter sets the value of a parameter to a string, e.g. "message=hello".
+func (v ParameterValues) SetParameter(expr string) error {
+	parts := strings.SplitN(expr, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("expected parameter of the form: NAME=VALUE. Received: %s", expr)
+	}
+	v[parts[0]] = parts[1]
+	return nil
+}
+
+// Set sets a value at a dot-separated path, e.g. "config.replicas=3". Integers, booleans and null are typed, and
+// "{a,b}" is a list.
+func (v ParameterValues) Set(expr string) error {
+	return v.set(expr, true)
+}
+
+// SetString sets a string value at a dot-separated path, e.g. "config.version=1.10".
+func (v ParameterValues) SetString(expr string) error {
+	return v.set(expr, false)
+}
+
+func (v ParameterValues) set(expr string, typed bool) error {
+	parts := strings.SplitN(expr, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("expected value of the form: PATH=VALUE, e.g. config.replicas=3. Received: %s", expr)
+	}
+	path := splitPath(parts[0])
+	var value interface{} = parts[1]
+	if typed {
+		value = parseValue(parts[1])
+	}
+	values := map[string]interface{}(v)
+	for i, key := range path {
+		if key == "" {
+			return fmt.Errorf("path %s must not have empty keys", parts[0])
+		}
+		if i == len(path)-1 {
+			values[key] = value
+			break
+		}
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			values[key] = next
+		}
+		values = next
+	}
+	return nil
+}
+
+// Parameters returns the values as "NAME=VALUE" parameters. A parameter whose value is a map is deep-merged into the
+// parameter's value in params, if that is a JSON object.
+func (v ParameterValues) Parameters(params []wfv1.Parameter) ([]string, error) {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parameters []string
+	for _, name := range names {
+		value := v[name]
+		if values, ok := value.(map[string]interface{}); ok {
+			for _, param := range params {
+				if param.Name != name || param.Value == nil {
+					continue
+				}
+				base := map[string]interface{}{}
+				decoder := json.NewDecoder(strings.NewReader(param.Value.String()))
+				decoder.UseNumber()
+				if decoder.Decode(&base) == nil {
+					deepMerge(base, values)
+					value = base
+				}
+			}
+		}
+		parameter, err := parameterValueString(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value of parameter %s: %w", name, err)
+		}
+		parameters = append(parameters, fmt.Sprintf("%s=%s", name, parameter))
+	}
+	return parameters, nil
+}
+
+func parameterValueString(value interface{}) (string, error) {
+	switch x := value.(type) {
+	case string:
+		return x, nil
+	case json.Number:
+		return x.String(), nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// deepMerge merges from into to, maps are merged recursively, and any other value replaces the existing one
+func deepMerge(to, from map[string]interface{}) {
+	for key, value := range from {
+		fromMap, ok := value.(map[string]interface{})
+		toMap, ok2 := to[key].(map[string]interface{})
+		if ok && ok2 {
+			deepMerge(toMap, fromMap)
+		} else {
+			to[key] = value
+		}
+	}
+}
+
+// splitPath splits the path on ".", unless escaped as "\."
+func splitPath(path string) []string {
+	var keys []string
+	var key strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			key.WriteByte('.')
+			i++
+		case path[i] == '.':
+			keys = append(keys, key.String())
+			key.Reset()
+		default:
+			key.WriteByte(path[i])
+		}
+	}
+	return append(keys, key.String())
+}
+
+func parseValue(value string) interface{} {
+	if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") {
+		items := []interface{}{}
+		if inner := value[1 : len(value)-1]; inner != "" {
+			for _, item := range strings.Split(inner, ",") {
+				items = append(items, parseValue(item))
+			}
+		}
+		return items
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	return value
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestParameterValues(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "values.yaml")
+	err := os.WriteFile(file, []byte(`
+message: hello
+count: 81861780812
+config:
+  image: argo:v1
+  resources:
+    cpu: 1
+`), 0o600)
+	assert.NoError(t, err)
+
+	values := ParameterValues{}
+	assert.NoError(t, values.ReadFile(file))
+	assert.NoError(t, values.SetParameter("message=hello world"))
+	assert.NoError(t, values.Set("config.resources.memory=1Gi"))
+	assert.NoError(t, values.Set("config.replicas=3"))
+	assert.NoError(t, values.Set("config.debug=true"))
+	assert.NoError(t, values.Set("config.regions={us,eu}"))
+	assert.NoError(t, values.SetString("config.version=10"))
+	assert.NoError(t, values.SetString(`annotations.example\.com/team=ml`))
+
+	parameters, err := values.Parameters([]wfv1.Parameter{
+		{Name: "config", Value: wfv1.AnyStringPtr(`{"image": "argo:v0", "pullPolicy": "Always"}`)},
+		{Name: "message", Value: wfv1.AnyStringPtr("bye")},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{
+			`annotations={"example.com/team":"ml"}`,
+			`config={"debug":true,"image":"argo:v1","pullPolicy":"Always","regions":["us","eu"],"replicas":3,"resources":{"cpu":1,"memory":"1Gi"},"version":"10"}`,
+			"count=81861780812",
+			"message=hello world",
+		}, parameters)
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		values := ParameterValues{}
+		assert.EqualError(t, values.SetParameter("message"), "expected parameter of the form: NAME=VALUE. Received: message")
+		assert.EqualError(t, values.Set("config.replicas"), "expected value of the form: PATH=VALUE, e.g. config.replicas=3. Received: config.replicas")
+		assert.EqualError(t, values.SetString("config..replicas=3"), "path config..replicas must not have empty keys")
+	})
+	t.Run("ReplaceNonObject", func(t *testing.T) {
+		values := ParameterValues{}
+		assert.NoError(t, values.Set("config.replicas=3"))
+		parameters, err := values.Parameters([]wfv1.Parameter{{Name: "config", Value: wfv1.AnyStringPtr("not-json")}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{`config={"replicas":3}`}, parameters)
+		}
+	})
+}
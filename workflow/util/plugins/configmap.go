@@ -12,6 +12,14 @@ import (
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
+// configMapSuffix returns the suffix of the names of the configmaps of plugins of the kind
+func configMapSuffix(kind string) string {
+	if kind == common.LabelValueTypeConfigMapArtifactDriverPlugin {
+		return "-artifact-driver-plugin"
+	}
+	return "-executor-plugin"
+}
+
 func ToConfigMap(p *spec.Plugin) (*apiv1.ConfigMap, error) {
 	if err := p.Validate(); err != nil {
 		return nil, err
@@ -26,7 +34,7 @@ func ToConfigMap(p *spec.Plugin) (*apiv1.ConfigMap, error) {
 			Kind:       "ConfigMap",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        fmt.Sprintf("%s-executor-plugin", p.Name),
+			Name:        p.Name + configMapSuffix(p.Kind),
 			Annotations: map[string]string{},
 			Labels: map[string]string{
 				common.LabelKeyConfigMapType: p.Kind,
@@ -53,7 +61,7 @@ func FromConfigMap(cm *apiv1.ConfigMap) (*spec.Plugin, error) {
 			Kind: cm.Labels[common.LabelKeyConfigMapType],
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        strings.TrimSuffix(cm.Name, "-executor-plugin"),
+			Name:        strings.TrimSuffix(cm.Name, configMapSuffix(cm.Labels[common.LabelKeyConfigMapType])),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		},
@@ -99,4 +99,19 @@ func TestFromConfigMap(t *testing.T) {
 			}, p.Spec.Sidecar.Container)
 		}
 	})
+	t.Run("ArtifactDriverPlugin", func(t *testing.T) {
+		p, err := FromConfigMap(&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "my-plug-artifact-driver-plugin",
+				Labels: map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapArtifactDriverPlugin},
+			},
+			Data: map[string]string{
+				"sidecar.container": "{'name': 'my-name', 'ports': [{}], 'resources': {'requests': {}, 'limits': {}}, 'securityContext': {}}",
+			},
+		})
+		if assert.NoError(t, err) {
+			assert.Equal(t, "ArtifactDriverPlugin", p.Kind)
+			assert.Equal(t, "my-plug", p.Name)
+		}
+	})
 }
@@ -37,6 +37,11 @@ func New(address, token string, timeout time.Duration, backoff wait.Backoff) Cli
 	}
 }
 
+// Implements returns whether the plugin implements the method, which it does not once calling it has returned 404
+func (p *Client) Implements(method string) bool {
+	return !p.invalid[method]
+}
+
 func (p *Client) Call(ctx context.Context, method string, args interface{}, reply interface{}) error {
 	if p.invalid[method] {
 		return nil
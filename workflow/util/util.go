@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	nruntime "runtime"
@@ -18,6 +19,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -175,7 +177,7 @@ func SubmitWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, wfClie
 		return nil, err
 	}
 	wftmplGetter := templateresolution.WrapWorkflowTemplateInterface(wfClientset.ArgoprojV1alpha1().WorkflowTemplates(namespace))
-	cwftmplGetter := templateresolution.WrapClusterWorkflowTemplateInterface(wfClientset.ArgoprojV1alpha1().ClusterWorkflowTemplates())
+	cwftmplGetter := templateresolution.WithNamespaceRestrictions(templateresolution.WrapClusterWorkflowTemplateInterface(wfClientset.ArgoprojV1alpha1().ClusterWorkflowTemplates()), namespace)
 
 	err = validate.ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, validate.ValidateOpts{Submit: true})
 	if err != nil {
@@ -245,6 +247,9 @@ func ApplySubmitOpts(wf *wfv1.Workflow, opts *wfv1.SubmitOpts) error {
 	if opts.Priority != nil {
 		wf.Spec.Priority = opts.Priority
 	}
+	if opts.Suspend {
+		wf.Spec.Suspend = pointer.BoolPtr(true)
+	}
 
 	wfLabels := wf.GetLabels()
 	if wfLabels == nil {
@@ -295,12 +300,20 @@ func overrideParameters(wf *wfv1.Workflow, parameters []string) error {
 	if len(parameters) > 0 {
 		newParams := make([]wfv1.Parameter, 0)
 		passedParams := make(map[string]bool)
+		existingParams := make(map[string]wfv1.Parameter)
+		for _, param := range wf.Spec.Arguments.Parameters {
+			existingParams[param.Name] = param
+		}
 		for _, paramStr := range parameters {
 			parts := strings.SplitN(paramStr, "=", 2)
 			if len(parts) != 2 {
 				return fmt.Errorf("expected parameter of the form: NAME=VALUE. Received: %s", paramStr)
 			}
 			param := wfv1.Parameter{Name: parts[0], Value: wfv1.AnyStringPtr(parts[1])}
+			// keep the schema and enum, so the value is validated against them
+			if existing, ok := existingParams[param.Name]; ok {
+				param.Schema, param.Enum = existing.Schema, existing.Enum
+			}
 			newParams = append(newParams, param)
 			passedParams[param.Name] = true
 		}
@@ -374,9 +387,9 @@ func SuspendWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, workf
 
 // ResumeWorkflow resumes a workflow by setting spec.suspend to nil and any suspended nodes to Successful.
 // Retries conflict errors
-func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName string, nodeFieldSelector string) error {
+func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrator hydrator.Interface, workflowName string, nodeFieldSelector string, approver *Approver) error {
 	if len(nodeFieldSelector) > 0 {
-		return updateSuspendedNode(ctx, wfIf, hydrator, workflowName, nodeFieldSelector, SetOperationValues{Phase: wfv1.NodeSucceeded})
+		return updateSuspendedNode(ctx, wfIf, hydrator, workflowName, nodeFieldSelector, SetOperationValues{Phase: wfv1.NodeSucceeded, Approver: approver})
 	} else {
 		err := waitutil.Backoff(retry.DefaultRetry, func() (bool, error) {
 			wf, err := wfIf.Get(ctx, workflowName, metav1.GetOptions{})
@@ -384,7 +397,7 @@ func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrat
 				return !errorsutil.IsTransientErr(err), err
 			}
 
-			err = hydrator.Hydrate(wf)
+			err = hydrator.Hydrate(ctx, wf)
 			if err != nil {
 				return true, err
 			}
@@ -398,17 +411,17 @@ func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrat
 			// To resume a workflow with a suspended node we simply mark the node as Successful
 			for nodeID, node := range wf.Status.Nodes {
 				if node.IsActiveSuspendNode() {
-					if node.Outputs != nil {
-						for i, param := range node.Outputs.Parameters {
-							if param.ValueFrom != nil && param.ValueFrom.Supplied != nil {
-								if param.ValueFrom.Default != nil {
-									node.Outputs.Parameters[i].Value = param.ValueFrom.Default
-									node.Outputs.Parameters[i].ValueFrom = nil
-								} else {
-									return false, fmt.Errorf("raw output parameter '%s' has not been set and does not have a default value", param.Name)
-								}
-							}
-						}
+					approved, err := approveNode(&node, approver)
+					if err != nil {
+						return true, err
+					}
+					if !approved {
+						wf.Status.Nodes[nodeID] = node
+						workflowUpdated = true
+						continue
+					}
+					if err := completeSuppliedOutputs(wf, &node); err != nil {
+						return false, err
 					}
 					node.Phase = wfv1.NodeSucceeded
 					node.FinishedAt = metav1.Time{Time: time.Now().UTC()}
@@ -418,7 +431,7 @@ func ResumeWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, hydrat
 			}
 
 			if workflowUpdated {
-				err := hydrator.Dehydrate(wf)
+				err := hydrator.Dehydrate(ctx, wf)
 				if err != nil {
 					return false, fmt.Errorf("unable to compress or offload workflow nodes: %s", err)
 				}
@@ -462,6 +475,72 @@ type SetOperationValues struct {
 	Phase            wfv1.NodePhase
 	Message          string
 	OutputParameters map[string]string
+	OutputArtifacts  map[string]wfv1.ArtifactLocation
+	// Approver is the user that set the node, which approves it if it requires approval
+	Approver *Approver
+}
+
+// Approver is an authenticated user that resumes suspend nodes, approving them if they require approval
+type Approver struct {
+	wfv1.Approver
+	// Groups are the groups the user is in, which must include one of the approver groups of a node
+	Groups []string
+}
+
+// approveNode records the approval of a suspend node that requires approval by the approver, and returns whether the
+// node has been approved by enough users to be resumed
+func approveNode(node *wfv1.NodeStatus, approver *Approver) (bool, error) {
+	if node.Approval == nil {
+		return true, nil
+	}
+	if approver == nil || approver.Subject == "" {
+		return false, errors.Errorf(errors.CodeForbidden, "node '%s' requires approval by an authenticated user", node.DisplayName)
+	}
+	if !node.Approval.IsApprover(approver.Groups) {
+		return false, errors.Errorf(errors.CodeForbidden, "user '%s' cannot approve node '%s' because they are not in any of the approver groups %v", approver.Subject, node.DisplayName, node.Approval.Groups)
+	}
+	if node.Approval.GetApprover(approver.Subject) != nil {
+		return false, errors.Errorf(errors.CodeBadRequest, "user '%s' has already approved node '%s'", approver.Subject, node.DisplayName)
+	}
+	a := approver.Approver
+	a.ApprovedAt = metav1.Time{Time: time.Now().UTC()}
+	node.Approval.Approvers = append(node.Approval.Approvers, a)
+	if !node.Approval.Approved() {
+		node.Message = fmt.Sprintf("%d/%d approvals", len(node.Approval.Approvers), node.Approval.GetMinApprovals())
+		return false, nil
+	}
+	return true, nil
+}
+
+// completeSuppliedOutputs defaults the output parameters of a suspend node that have not been supplied, and drops the
+// optional output artifacts that have not been supplied, so that the node can succeed
+func completeSuppliedOutputs(wf *wfv1.Workflow, node *wfv1.NodeStatus) error {
+	if node.Outputs == nil {
+		return nil
+	}
+	for i, param := range node.Outputs.Parameters {
+		if param.ValueFrom != nil && param.ValueFrom.Supplied != nil {
+			if param.ValueFrom.Default != nil {
+				node.Outputs.Parameters[i].Value = param.ValueFrom.Default
+				node.Outputs.Parameters[i].ValueFrom = nil
+				AddParamToGlobalScope(wf, log.NewEntry(log.StandardLogger()), node.Outputs.Parameters[i])
+			} else {
+				return fmt.Errorf("raw output parameter '%s' has not been set and does not have a default value", param.Name)
+			}
+		}
+	}
+	var artifacts wfv1.Artifacts
+	for _, art := range node.Outputs.Artifacts {
+		if !art.HasLocationOrKey() {
+			if !art.Optional {
+				return fmt.Errorf("output artifact '%s' has not been set and is not optional", art.Name)
+			}
+			continue
+		}
+		artifacts = append(artifacts, art)
+	}
+	node.Outputs.Artifacts = artifacts
+	return nil
 }
 
 func AddParamToGlobalScope(wf *wfv1.Workflow, log *log.Entry, param wfv1.Parameter) bool {
@@ -508,7 +587,7 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 			return !errorsutil.IsTransientErr(err), err
 		}
 
-		err = hydrator.Hydrate(wf)
+		err = hydrator.Hydrate(ctx, wf)
 		if err != nil {
 			return false, err
 		}
@@ -518,15 +597,6 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 			if node.IsActiveSuspendNode() {
 				if SelectorMatchesNode(selector, node) {
 
-					// Update phase
-					if values.Phase != "" {
-						node.Phase = values.Phase
-						if values.Phase.Fulfilled() {
-							node.FinishedAt = metav1.Time{Time: time.Now().UTC()}
-						}
-						nodeUpdated = true
-					}
-
 					// Update message
 					if values.Message != "" {
 						node.Message = values.Message
@@ -545,6 +615,9 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 									if param.ValueFrom == nil || param.ValueFrom.Supplied == nil {
 										return true, fmt.Errorf("cannot set output parameter '%s' because it does not use valueFrom.raw or it was already set", param.Name)
 									}
+									if len(param.Enum) > 0 && !slices.Contains(param.Enum, wfv1.AnyString(val)) {
+										return true, fmt.Errorf("cannot set output parameter '%s' to '%s' because it is not one of the allowed values %v", param.Name, val, param.Enum)
+									}
 									node.Outputs.Parameters[i].Value = wfv1.AnyStringPtr(val)
 									node.Outputs.Parameters[i].ValueFrom = nil
 									nodeUpdated = true
@@ -559,6 +632,52 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 						}
 					}
 
+					// Update output artifacts
+					for name, location := range values.OutputArtifacts {
+						var art *wfv1.Artifact
+						if node.Outputs != nil {
+							for i := range node.Outputs.Artifacts {
+								if node.Outputs.Artifacts[i].Name == name {
+									art = &node.Outputs.Artifacts[i]
+								}
+							}
+						}
+						if art == nil {
+							return true, fmt.Errorf("node is not expecting output artifact '%s'", name)
+						}
+						if art.HasLocationOrKey() {
+							return true, fmt.Errorf("cannot set output artifact '%s' because it was already set", name)
+						}
+						if !location.HasLocationOrKey() {
+							return true, fmt.Errorf("cannot set output artifact '%s' because it does not have a location or key", name)
+						}
+						art.ArtifactLocation = location
+						nodeUpdated = true
+					}
+
+					// Update phase, once the outputs have been set
+					if values.Phase != "" {
+						if values.Phase == wfv1.NodeSucceeded {
+							approved, err := approveNode(&node, values.Approver)
+							if err != nil {
+								return true, err
+							}
+							if !approved {
+								wf.Status.Nodes[nodeID] = node
+								nodeUpdated = true
+								continue
+							}
+							if err := completeSuppliedOutputs(wf, &node); err != nil {
+								return true, err
+							}
+						}
+						node.Phase = values.Phase
+						if values.Phase.Fulfilled() {
+							node.FinishedAt = metav1.Time{Time: time.Now().UTC()}
+						}
+						nodeUpdated = true
+					}
+
 					wf.Status.Nodes[nodeID] = node
 				}
 			}
@@ -568,7 +687,7 @@ func updateSuspendedNode(ctx context.Context, wfIf v1alpha1.WorkflowInterface, h
 			return true, fmt.Errorf("currently, set only targets suspend nodes: no suspend nodes matching nodeFieldSelector: %s", nodeFieldSelector)
 		}
 
-		err = hydrator.Dehydrate(wf)
+		err = hydrator.Dehydrate(ctx, wf)
 		if err != nil {
 			return true, fmt.Errorf("unable to compress or offload workflow nodes: %s", err)
 		}
@@ -607,8 +726,31 @@ func RandSuffix() string {
 	return randString(5)
 }
 
+// MetadataCarryOver is which labels and annotations of a workflow carry over to its resubmitted workflow, as globs of
+// their keys, e.g. "example.com/*". All of them carry over if there are no globs.
+type MetadataCarryOver struct {
+	Labels      []string
+	Annotations []string
+}
+
+func carriesOver(globs []string, key string) (bool, error) {
+	if len(globs) == 0 {
+		return true, nil
+	}
+	for _, glob := range globs {
+		ok, err := path.Match(glob, key)
+		if err != nil {
+			return false, errors.Errorf(errors.CodeBadRequest, "invalid glob '%s': %v", glob, err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // FormulateResubmitWorkflow formulate a new workflow from a previous workflow, optionally re-using successful nodes
-func FormulateResubmitWorkflow(wf *wfv1.Workflow, memoized bool, parameters []string) (*wfv1.Workflow, error) {
+func FormulateResubmitWorkflow(wf *wfv1.Workflow, memoized bool, parameters []string, carryOver MetadataCarryOver) (*wfv1.Workflow, error) {
 	newWF := wfv1.Workflow{}
 	newWF.TypeMeta = wf.TypeMeta
 
@@ -649,7 +791,11 @@ func FormulateResubmitWorkflow(wf *wfv1.Workflow, memoized bool, parameters []st
 		case common.LabelKeyCreator, common.LabelKeyPhase, common.LabelKeyCompleted, common.LabelKeyWorkflowArchivingStatus:
 			// ignore
 		default:
-			newWF.ObjectMeta.Labels[key] = val
+			if ok, err := carriesOver(carryOver.Labels, key); err != nil {
+				return nil, err
+			} else if ok {
+				newWF.ObjectMeta.Labels[key] = val
+			}
 		}
 	}
 	// Append an additional label so it's easy for user to see the
@@ -659,7 +805,11 @@ func FormulateResubmitWorkflow(wf *wfv1.Workflow, memoized bool, parameters []st
 		newWF.ObjectMeta.Annotations = make(map[string]string)
 	}
 	for key, val := range wf.ObjectMeta.Annotations {
-		newWF.ObjectMeta.Annotations[key] = val
+		if ok, err := carriesOver(carryOver.Annotations, key); err != nil {
+			return nil, err
+		} else if ok {
+			newWF.ObjectMeta.Annotations[key] = val
+		}
 	}
 
 	// Setting OwnerReference from original Workflow
@@ -822,6 +972,9 @@ func FormulateRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, restartSucce
 	newWF.Status.Message = ""
 	newWF.Status.StartedAt = metav1.Time{Time: time.Now().UTC()}
 	newWF.Status.FinishedAt = metav1.Time{}
+	// the retained PVCs are reused, and kept or resized, when they are created again
+	newWF.Status.PersistentVolumeClaims = nil
+	newWF.Status.Conditions.RemoveCondition(wfv1.ConditionTypeVolumeClaimsRetained)
 	newWF.Spec.Shutdown = ""
 	if newWF.Spec.ActiveDeadlineSeconds != nil && *newWF.Spec.ActiveDeadlineSeconds == 0 {
 		// if it was terminated, unset the deadline
@@ -1066,6 +1219,12 @@ func StopWorkflow(ctx context.Context, wfClient v1alpha1.WorkflowInterface, hydr
 	return patchShutdownStrategy(ctx, wfClient, name, wfv1.ShutdownStrategyStop)
 }
 
+// DrainWorkflow drains a workflow by setting its spec.shutdown to ShutdownStrategyDrain, so that it does not start new
+// nodes but lets running pods finish
+func DrainWorkflow(ctx context.Context, wfClient v1alpha1.WorkflowInterface, name string) error {
+	return patchShutdownStrategy(ctx, wfClient, name, wfv1.ShutdownStrategyDrain)
+}
+
 // patchShutdownStrategy patches the shutdown strategy to a workflow.
 func patchShutdownStrategy(ctx context.Context, wfClient v1alpha1.WorkflowInterface, name string, strategy wfv1.ShutdownStrategy) error {
 	patchObj := map[string]interface{}{
@@ -9,15 +9,18 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/yaml"
 
+	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	argofake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	hydratorfake "github.com/argoproj/argo-workflows/v3/workflow/hydrator/fake"
 )
@@ -67,7 +70,7 @@ func TestResubmitWorkflowWithOnExit(t *testing.T) {
 		Name:  onExitName,
 		Phase: wfv1.NodeSucceeded,
 	}
-	newWF, err := FormulateResubmitWorkflow(&wf, true, nil)
+	newWF, err := FormulateResubmitWorkflow(&wf, true, nil, MetadataCarryOver{})
 	assert.NoError(t, err)
 	newWFOnExitName := newWF.ObjectMeta.Name + ".onExit"
 	newWFOneExitID := newWF.NodeID(newWFOnExitName)
@@ -270,7 +273,7 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 	assert.NoError(t, err)
 
 	// will return error as displayName does not match any nodes
-	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=nonexistant")
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=nonexistant", nil)
 	assert.Error(t, err)
 
 	// displayName didn't match suspend node so should still be running
@@ -278,7 +281,7 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes.FindByDisplayName("approve").Phase)
 
-	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve")
+	err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", nil)
 	assert.NoError(t, err)
 
 	// displayName matched node so has succeeded
@@ -288,6 +291,83 @@ func TestResumeWorkflowByNodeName(t *testing.T) {
 	}
 }
 
+func TestResumeWorkflowApproval(t *testing.T) {
+	ctx := context.Background()
+	approver := func(subject string, groups ...string) *Approver {
+		return &Approver{Approver: wfv1.Approver{Subject: subject}, Groups: groups}
+	}
+	newWfIf := func(t *testing.T) v1alpha1.WorkflowInterface {
+		wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+		wf := wfv1.MustUnmarshalWorkflow(suspendedWf)
+		node := wf.Status.Nodes.FindByDisplayName("approve")
+		node.Approval = &wfv1.NodeApproval{Approval: wfv1.Approval{Groups: []string{"approvers"}, MinApprovals: 2}}
+		wf.Status.Nodes[node.ID] = *node
+		_, err := wfIf.Create(ctx, wf, metav1.CreateOptions{})
+		require.NoError(t, err)
+		return wfIf
+	}
+	getNode := func(t *testing.T, wfIf v1alpha1.WorkflowInterface) *wfv1.NodeStatus {
+		wf, err := wfIf.Get(ctx, "suspend", metav1.GetOptions{})
+		require.NoError(t, err)
+		return wf.Status.Nodes.FindByDisplayName("approve")
+	}
+
+	t.Run("ByNodeName", func(t *testing.T) {
+		wfIf := newWfIf(t)
+
+		err := ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", nil)
+		assert.True(t, errors.IsCode(errors.CodeForbidden, err))
+		assert.EqualError(t, err, "node 'approve' requires approval by an authenticated user")
+
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", approver("bob", "developers"))
+		assert.True(t, errors.IsCode(errors.CodeForbidden, err))
+		assert.EqualError(t, err, "user 'bob' cannot approve node 'approve' because they are not in any of the approver groups [approvers]")
+
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", approver("alice", "developers", "approvers"))
+		assert.NoError(t, err)
+		node := getNode(t, wfIf)
+		assert.Equal(t, wfv1.NodeRunning, node.Phase)
+		assert.Equal(t, "1/2 approvals", node.Message)
+		if assert.Len(t, node.Approval.Approvers, 1) {
+			assert.Equal(t, "alice", node.Approval.Approvers[0].Subject)
+			assert.False(t, node.Approval.Approvers[0].ApprovedAt.IsZero())
+		}
+
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", approver("alice", "approvers"))
+		assert.EqualError(t, err, "user 'alice' has already approved node 'approve'")
+
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", approver("carol", "approvers"))
+		assert.NoError(t, err)
+		node = getNode(t, wfIf)
+		assert.Equal(t, wfv1.NodeSucceeded, node.Phase)
+		assert.Len(t, node.Approval.Approvers, 2)
+	})
+
+	t.Run("AllNodes", func(t *testing.T) {
+		wfIf := newWfIf(t)
+
+		err := ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "", approver("bob"))
+		assert.True(t, errors.IsCode(errors.CodeForbidden, err))
+		assert.Equal(t, wfv1.NodeRunning, getNode(t, wfIf).Phase)
+
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "", approver("alice", "approvers"))
+		assert.NoError(t, err)
+		assert.Equal(t, wfv1.NodeRunning, getNode(t, wfIf).Phase)
+
+		err = ResumeWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "", approver("carol", "approvers"))
+		assert.NoError(t, err)
+		assert.Equal(t, wfv1.NodeSucceeded, getNode(t, wfIf).Phase)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		wfIf := newWfIf(t)
+
+		err := StopWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend", "displayName=approve", "rejected")
+		assert.NoError(t, err)
+		assert.Equal(t, wfv1.NodeFailed, getNode(t, wfIf).Phase)
+	})
+}
+
 func TestStopWorkflowByNodeName(t *testing.T) {
 	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
 	origWf := wfv1.MustUnmarshalWorkflow(suspendedWf)
@@ -476,6 +556,79 @@ func TestUpdateSuspendedNode(t *testing.T) {
 	}
 }
 
+var susWorkflowWithApprovalOutputs = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: suspend-template-approval
+spec:
+  entrypoint: approve
+  templates:
+  - name: approve
+    suspend: {}
+status:
+  nodes:
+    suspend-template-approval:
+      displayName: approve
+      id: suspend-template-approval
+      name: suspend-template-approval
+      outputs:
+        parameters:
+        - name: decision
+          enum: [approved, rejected]
+          valueFrom:
+            supplied: {}
+        - name: comment
+          globalName: approval-comment
+          valueFrom:
+            default: none
+            supplied: {}
+        artifacts:
+        - name: report
+        - name: attachment
+          optional: true
+      phase: Running
+      startedAt: "2020-06-25T18:01:56Z"
+      templateName: approve
+      type: Suspend
+  phase: Running
+  startedAt: "2020-06-25T18:01:56Z"
+`
+
+func TestUpdateSuspendedNodeApprovalOutputs(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	ctx := context.Background()
+	_, err := wfIf.Create(ctx, wfv1.MustUnmarshalWorkflow(susWorkflowWithApprovalOutputs), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	update := func(values SetOperationValues) error {
+		return updateSuspendedNode(ctx, wfIf, hydratorfake.Noop, "suspend-template-approval", "displayName=approve", values)
+	}
+	report := wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "reports/report.pdf"}}
+
+	err = update(SetOperationValues{OutputParameters: map[string]string{"decision": "maybe"}})
+	assert.EqualError(t, err, "cannot set output parameter 'decision' to 'maybe' because it is not one of the allowed values [approved rejected]")
+	err = update(SetOperationValues{OutputArtifacts: map[string]wfv1.ArtifactLocation{"does-not-exist": report}})
+	assert.EqualError(t, err, "node is not expecting output artifact 'does-not-exist'")
+	err = update(SetOperationValues{OutputArtifacts: map[string]wfv1.ArtifactLocation{"report": {}}})
+	assert.EqualError(t, err, "cannot set output artifact 'report' because it does not have a location or key")
+	err = update(SetOperationValues{OutputParameters: map[string]string{"decision": "approved"}, Phase: wfv1.NodeSucceeded})
+	assert.EqualError(t, err, "output artifact 'report' has not been set and is not optional")
+
+	err = update(SetOperationValues{OutputParameters: map[string]string{"decision": "approved"}, OutputArtifacts: map[string]wfv1.ArtifactLocation{"report": report}, Phase: wfv1.NodeSucceeded})
+	if assert.NoError(t, err) {
+		wf, err := wfIf.Get(ctx, "suspend-template-approval", metav1.GetOptions{})
+		assert.NoError(t, err)
+		node := wf.Status.Nodes["suspend-template-approval"]
+		assert.Equal(t, wfv1.NodeSucceeded, node.Phase)
+		assert.Equal(t, "approved", node.Outputs.Parameters[0].Value.String())
+		assert.Equal(t, "none", node.Outputs.Parameters[1].Value.String())
+		assert.Equal(t, wfv1.Artifacts{{Name: "report", ArtifactLocation: report}}, node.Outputs.Artifacts)
+		if assert.NotNil(t, wf.Status.Outputs) {
+			assert.Equal(t, []wfv1.Parameter{{Name: "approval-comment", Value: wfv1.AnyStringPtr("none")}}, wf.Status.Outputs.Parameters)
+		}
+	}
+}
+
 func TestSelectorMatchesNode(t *testing.T) {
 	tests := map[string]struct {
 		selector string
@@ -569,6 +722,15 @@ func TestApplySubmitOpts(t *testing.T) {
 			assert.Equal(t, "0", wf.GetLabels()["b"])
 		}
 	})
+	t.Run("Suspend", func(t *testing.T) {
+		wf := &wfv1.Workflow{}
+		err := ApplySubmitOpts(wf, &wfv1.SubmitOpts{Suspend: true})
+		assert.NoError(t, err)
+		if assert.NotNil(t, wf.Spec.Suspend) {
+			assert.True(t, *wf.Spec.Suspend)
+		}
+		assert.True(t, IsWorkflowSuspended(wf))
+	})
 	t.Run("InvalidParameters", func(t *testing.T) {
 		assert.Error(t, ApplySubmitOpts(&wfv1.Workflow{}, &wfv1.SubmitOpts{Parameters: []string{"a"}}))
 	})
@@ -588,6 +750,23 @@ func TestApplySubmitOpts(t *testing.T) {
 			assert.Equal(t, "81861780812", parameters[0].Value.String())
 		}
 	})
+	t.Run("ParameterSchema", func(t *testing.T) {
+		schema := &wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt}
+		wf := &wfv1.Workflow{
+			Spec: wfv1.WorkflowSpec{
+				Arguments: wfv1.Arguments{
+					Parameters: []wfv1.Parameter{{Name: "a", Value: wfv1.AnyStringPtr("0"), Schema: schema}},
+				},
+			},
+		}
+		err := ApplySubmitOpts(wf, &wfv1.SubmitOpts{Parameters: []string{"a=1"}})
+		assert.NoError(t, err)
+		parameters := wf.Spec.Arguments.Parameters
+		if assert.Len(t, parameters, 1) {
+			assert.Equal(t, "1", parameters[0].Value.String())
+			assert.Equal(t, schema, parameters[0].Schema)
+		}
+	})
 	t.Run("PodPriorityClassName", func(t *testing.T) {
 		wf := &wfv1.Workflow{}
 		err := ApplySubmitOpts(wf, &wfv1.SubmitOpts{PodPriorityClassName: "abc"})
@@ -633,7 +812,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 				},
 			},
 		}
-		wf, err := FormulateResubmitWorkflow(wf, false, nil)
+		wf, err := FormulateResubmitWorkflow(wf, false, nil, MetadataCarryOver{})
 		if assert.NoError(t, err) {
 			assert.Contains(t, wf.GetLabels(), common.LabelKeyControllerInstanceID)
 			assert.Contains(t, wf.GetLabels(), common.LabelKeyClusterWorkflowTemplate)
@@ -649,6 +828,22 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 			assert.Equal(t, "testObj", wf.OwnerReferences[0].Name)
 		}
 	})
+	t.Run("CarryOver", func(t *testing.T) {
+		wf := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "nightly",
+				Labels:      map[string]string{"example.com/team": "a", "example.com/run": "1", "tier": "b", common.LabelKeyPhase: "Failed"},
+				Annotations: map[string]string{"example.com/ticket": "c", "note": "d"},
+			},
+		}
+		newWF, err := FormulateResubmitWorkflow(wf, false, nil, MetadataCarryOver{Labels: []string{"example.com/team", "tier"}, Annotations: []string{"example.com/*"}})
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{"example.com/team": "a", "tier": "b", common.LabelKeyPreviousWorkflowName: "nightly"}, newWF.Labels)
+			assert.Equal(t, map[string]string{"example.com/ticket": "c"}, newWF.Annotations)
+		}
+		_, err = FormulateResubmitWorkflow(wf, false, nil, MetadataCarryOver{Labels: []string{"["}})
+		assert.EqualError(t, err, "invalid glob '[': syntax error in pattern")
+	})
 	t.Run("OverrideParams", func(t *testing.T) {
 		wf := &wfv1.Workflow{
 			Spec: wfv1.WorkflowSpec{Arguments: wfv1.Arguments{
@@ -657,7 +852,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 				},
 			}},
 		}
-		wf, err := FormulateResubmitWorkflow(wf, false, []string{"message=modified"})
+		wf, err := FormulateResubmitWorkflow(wf, false, []string{"message=modified"}, MetadataCarryOver{})
 		if assert.NoError(t, err) {
 			assert.Equal(t, "modified", wf.Spec.Arguments.Parameters[0].Value.String())
 		}
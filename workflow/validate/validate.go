@@ -3,6 +3,8 @@ package validate
 import (
 	"encoding/json"
 	"fmt"
+	"mime"
+	"path"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -11,8 +13,11 @@ import (
 
 	"golang.org/x/exp/maps"
 
+	"github.com/antonmedv/expr"
 	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
+	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	apivalidation "k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/yaml"
@@ -25,6 +30,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/template"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifacts/hdfs"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/data"
 	"github.com/argoproj/argo-workflows/v3/workflow/metrics"
 	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
 )
@@ -60,6 +66,8 @@ type templateValidationCtx struct {
 	// wf is the Workflow resource which is used to validate templates.
 	// It will be omitted in WorkflowTemplate validation.
 	wf *wfv1.Workflow
+	// strict indicates that tags and expressions must only reference defined variables
+	strict bool
 }
 
 func newTemplateValidationCtx(wf *wfv1.Workflow, opts ValidateOpts) *templateValidationCtx {
@@ -126,10 +134,11 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 		if err != nil {
 			return err
 		}
-		if wf.Spec.WorkflowTemplateRef.ClusterScope {
-			wfSpecHolder, err = cwftmplGetter.Get(wf.Spec.WorkflowTemplateRef.Name)
+		ref := wf.Spec.WorkflowTemplateRef
+		if ref.ClusterScope {
+			wfSpecHolder, err = templateresolution.GetClusterWorkflowTemplate(cwftmplGetter, ref.Name, ref.Revision)
 		} else {
-			wfSpecHolder, err = wftmplGetter.Get(wf.Spec.WorkflowTemplateRef.Name)
+			wfSpecHolder, err = templateresolution.GetWorkflowTemplate(wftmplGetter, ref.Name, ref.Revision)
 		}
 		if err != nil {
 			return err
@@ -144,12 +153,50 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	wfArgs := wf.Spec.Arguments
 
 	if wf.Spec.WorkflowTemplateRef != nil {
-		wfArgs.Parameters = util.MergeParameters(wfArgs.Parameters, wfSpecHolder.GetWorkflowSpec().Arguments.Parameters)
+		wfArgs.Parameters = withParameterSchemas(util.MergeParameters(wfArgs.Parameters, wfSpecHolder.GetWorkflowSpec().Arguments.Parameters), wfSpecHolder.GetWorkflowSpec().Arguments.Parameters)
 		wfArgs.Artifacts = util.MergeArtifacts(wfArgs.Artifacts, wfSpecHolder.GetWorkflowSpec().Arguments.Artifacts)
 	}
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "spec.templates%s", err.Error())
 	}
+	strictness := wf.Spec.TemplateResolutionStrictness
+	if strictness == "" && hasWorkflowTemplateRef {
+		strictness = wfSpecHolder.GetWorkflowSpec().TemplateResolutionStrictness
+	}
+	switch strictness {
+	case "", wfv1.TemplateResolutionLenient, wfv1.TemplateResolutionStrict:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "spec.templateResolutionStrictness must be Lenient or Strict")
+	}
+	ctx.strict = strictness == wfv1.TemplateResolutionStrict
+	if ref := wf.Spec.ArtifactRepositoryRef; ref != nil && ref.Name != "" && (ref.ConfigMap != "" || ref.Key != "") {
+		return errors.Errorf(errors.CodeBadRequest, "spec.artifactRepositoryRef.name cannot be combined with configMap or key")
+	}
+	if limit := wf.Spec.PodLimit; limit != nil {
+		if limit.Max <= 0 {
+			return errors.Errorf(errors.CodeBadRequest, "spec.podLimit.max must be greater than zero")
+		}
+		switch limit.Action {
+		case "", wfv1.PodLimitActionFail, wfv1.PodLimitActionSuspend:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "spec.podLimit.action must be Fail or Suspend")
+		}
+	}
+	if gc := wf.Spec.VolumeClaimGC; gc != nil {
+		switch gc.Strategy {
+		case "", wfv1.VolumeClaimGCOnCompletion, wfv1.VolumeClaimGCOnSuccess, wfv1.VolumeClaimGCOnDeletion:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "spec.volumeClaimGC.strategy must be OnWorkflowCompletion, OnWorkflowSuccess or OnWorkflowDeletion")
+		}
+		if gc.OlderThan != nil && gc.OlderThan.Duration < 0 {
+			return errors.Errorf(errors.CodeBadRequest, "spec.volumeClaimGC.olderThan must not be negative")
+		}
+	}
+	for i, templateImport := range wf.Spec.TemplateImports {
+		if err := validateTemplateImport(tmplCtx, templateImport); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.templateImports[%d] %s", i, err.Error())
+		}
+	}
 
 	// if we are linting, we don't care if spec.arguments.parameters.XXX doesn't have an
 	// explicit value. Workflow templates without a default value are also a desired use
@@ -222,11 +269,18 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	}
 	if wf.Spec.OnExit != "" {
 		ctx.globalParams[common.GlobalVarWorkflowFailures] = placeholderGenerator.NextPlaceholder()
+		ctx.globalParams[common.GlobalVarWorkflowFailedNodes] = placeholderGenerator.NextPlaceholder()
 		_, err = ctx.validateTemplateHolder(&wfv1.WorkflowStep{Template: wf.Spec.OnExit}, tmplCtx, &wf.Spec.Arguments)
 		if err != nil {
 			return err
 		}
 	}
+	if err := ctx.validateNotifications(wf.Spec.Notifications); err != nil {
+		return err
+	}
+	if m := wf.Spec.ServiceMesh; m != nil && m.Type != wfv1.ServiceMeshIstio && m.Type != wfv1.ServiceMeshLinkerd {
+		return errors.Errorf(errors.CodeBadRequest, "serviceMesh.type unknown type '%s', must be istio or linkerd", m.Type)
+	}
 
 	if !wf.Spec.PodGC.GetStrategy().IsValid() {
 		return errors.Errorf(errors.CodeBadRequest, "podGC.strategy unknown strategy '%s'", wf.Spec.PodGC.Strategy)
@@ -234,6 +288,39 @@ func ValidateWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespaced
 	if _, err := wf.Spec.PodGC.GetLabelSelector(); err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "podGC.labelSelector invalid: %v", err)
 	}
+	if podGC := wf.Spec.PodGC; podGC != nil {
+		if podGC.OlderThan != nil && podGC.OlderThan.Duration < 0 {
+			return errors.Errorf(errors.CodeBadRequest, "podGC.olderThan must not be negative")
+		}
+		for i, rule := range podGC.Rules {
+			for _, phase := range rule.Phases {
+				if phase != apiv1.PodSucceeded && phase != apiv1.PodFailed {
+					return errors.Errorf(errors.CodeBadRequest, "podGC.rules[%d].phases unknown phase '%s', must be Succeeded or Failed", i, phase)
+				}
+			}
+			if rule.LabelSelector != nil {
+				if _, err := v1.LabelSelectorAsSelector(rule.LabelSelector); err != nil {
+					return errors.Errorf(errors.CodeBadRequest, "podGC.rules[%d].labelSelector invalid: %v", i, err)
+				}
+			}
+			if rule.OlderThan != nil && rule.OlderThan.Duration < 0 {
+				return errors.Errorf(errors.CodeBadRequest, "podGC.rules[%d].olderThan must not be negative", i)
+			}
+		}
+	}
+	podEnvNames := make(map[string]bool)
+	for i, env := range wf.Spec.PodEnv {
+		if env.Name == "" {
+			return errors.Errorf(errors.CodeBadRequest, "podEnv[%d].name is required", i)
+		}
+		if podEnvNames[env.Name] {
+			return errors.Errorf(errors.CodeBadRequest, "podEnv[%d].name '%s' is not unique", i, env.Name)
+		}
+		podEnvNames[env.Name] = true
+	}
+	if err := validatePodSpecPatch("spec", wf.Spec.PodSpecPatch); err != nil {
+		return err
+	}
 
 	// Check if all templates can be resolved.
 	for _, template := range wf.Spec.Templates {
@@ -269,6 +356,10 @@ func ValidateWorkflowTemplate(wftmplGetter templateresolution.WorkflowTemplateNa
 		return fmt.Errorf("workflow template name %q must not be more than 63 characters long (currently %d)", wftmpl.Name, len(wftmpl.Name))
 	}
 
+	if wftmpl.Spec.NamespaceRestrictions != nil {
+		return errors.New(errors.CodeBadRequest, "spec.namespaceRestrictions is only valid for cluster workflow templates")
+	}
+
 	wf := &wfv1.Workflow{
 		ObjectMeta: v1.ObjectMeta{
 			Labels:      wftmpl.ObjectMeta.Labels,
@@ -287,6 +378,10 @@ func ValidateClusterWorkflowTemplate(wftmplGetter templateresolution.WorkflowTem
 		return fmt.Errorf("cluster workflow template name %q must not be more than 63 characters long (currently %d)", cwftmpl.Name, len(cwftmpl.Name))
 	}
 
+	if err := validateNamespaceRestrictions(cwftmpl.Spec.NamespaceRestrictions); err != nil {
+		return err
+	}
+
 	wf := &wfv1.Workflow{
 		ObjectMeta: v1.ObjectMeta{
 			Labels:      cwftmpl.ObjectMeta.Labels,
@@ -299,6 +394,26 @@ func ValidateClusterWorkflowTemplate(wftmplGetter templateresolution.WorkflowTem
 	return ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, opts)
 }
 
+// validateNamespaceRestrictions validates that the namespaces are valid glob patterns
+func validateNamespaceRestrictions(restrictions *wfv1.NamespaceRestrictions) error {
+	if restrictions == nil {
+		return nil
+	}
+	if err := validateNamespacePatterns("spec.namespaceRestrictions.allow", restrictions.Allow); err != nil {
+		return err
+	}
+	return validateNamespacePatterns("spec.namespaceRestrictions.deny", restrictions.Deny)
+}
+
+func validateNamespacePatterns(prefix string, namespaces []string) error {
+	for i, namespace := range namespaces {
+		if _, err := path.Match(namespace, ""); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s[%d] '%s' is not a valid pattern: %v", prefix, i, namespace, err)
+		}
+	}
+	return nil
+}
+
 // ValidateCronWorkflow validates a CronWorkflow
 func ValidateCronWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, cronWf *wfv1.CronWorkflow) error {
 	// CronWorkflows have fewer max chars allowed in their name because when workflows are created from them, they
@@ -308,17 +423,62 @@ func ValidateCronWorkflow(wftmplGetter templateresolution.WorkflowTemplateNamesp
 		return fmt.Errorf("cron workflow name %q must not be more than 52 characters long (currently %d)", cronWf.Name, len(cronWf.Name))
 	}
 
-	if _, err := cron.ParseStandard(cronWf.Spec.Schedule); err != nil {
-		return errors.Errorf(errors.CodeBadRequest, "cron schedule is malformed: %s", err)
+	schedules := cronWf.Spec.GetSchedules()
+	if len(schedules) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "cron schedule must be specified")
+	}
+	for _, schedule := range schedules {
+		if _, err := cron.ParseStandard(schedule); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "cron schedule is malformed: %s", err)
+		}
+	}
+
+	for i, exclusion := range cronWf.Spec.Exclusions {
+		if (exclusion.Schedule == "") == (exclusion.Calendar == nil) {
+			return errors.Errorf(errors.CodeBadRequest, "exclusions[%d] must specify exactly one of schedule or calendar", i)
+		}
+		if exclusion.Schedule != "" {
+			if _, err := cron.ParseStandard(exclusion.Schedule); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "exclusions[%d].schedule is malformed: %s", i, err)
+			}
+			if d, err := time.ParseDuration(exclusion.Duration); err != nil || d <= 0 {
+				return errors.Errorf(errors.CodeBadRequest, "exclusions[%d].duration must be a positive duration, e.g. \"2h\"", i)
+			}
+		}
+		if exclusion.Calendar != nil && (exclusion.Calendar.Name == "" || exclusion.Calendar.Key == "") {
+			return errors.Errorf(errors.CodeBadRequest, "exclusions[%d].calendar must specify a config map name and key", i)
+		}
 	}
 
 	switch cronWf.Spec.ConcurrencyPolicy {
 	case wfv1.AllowConcurrent, wfv1.ForbidConcurrent, wfv1.ReplaceConcurrent, "":
 		// Do nothing
+	case wfv1.ForbidIfPreviousFailedConcurrent:
+		// the failed workflow must be kept, otherwise the next run would not know about it
+		if cronWf.Spec.FailedJobsHistoryLimit != nil && *cronWf.Spec.FailedJobsHistoryLimit == 0 {
+			return errors.Errorf(errors.CodeBadRequest, "concurrencyPolicy '%s' requires failedJobsHistoryLimit to be at least 1", cronWf.Spec.ConcurrencyPolicy)
+		}
 	default:
 		return errors.Errorf(errors.CodeBadRequest, "'%s' is not a valid concurrencyPolicy", cronWf.Spec.ConcurrencyPolicy)
 	}
 
+	if cronWf.Spec.ScheduleJitter != "" {
+		if d, err := time.ParseDuration(cronWf.Spec.ScheduleJitter); err != nil || d < 0 {
+			return errors.Errorf(errors.CodeBadRequest, "scheduleJitter must be a non-negative duration, e.g. \"5m\"")
+		}
+	}
+
+	if cronWf.Spec.Catchup != nil && cronWf.Spec.Catchup.Limit != nil && *cronWf.Spec.Catchup.Limit < 1 {
+		return errors.Errorf(errors.CodeBadRequest, "catchup.limit must be at least 1")
+	}
+
+	switch cronWf.Spec.DSTPolicy {
+	case wfv1.DSTPolicySkip, wfv1.DSTPolicyRunOnce, wfv1.DSTPolicyRunTwice, "":
+		// Do nothing
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "'%s' is not a valid dstPolicy", cronWf.Spec.DSTPolicy)
+	}
+
 	if cronWf.Spec.StartingDeadlineSeconds != nil && *cronWf.Spec.StartingDeadlineSeconds < 0 {
 		return errors.Errorf(errors.CodeBadRequest, "startingDeadlineSeconds must be positive")
 	}
@@ -355,6 +515,27 @@ func (ctx *templateValidationCtx) validateTemplate(tmpl *wfv1.Template, tmplCtx
 		return err
 	}
 
+	for _, sidecar := range tmpl.Sidecars {
+		if sidecar.GetCaptureOutputs() {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.sidecars.%s.captureOutputs is only valid for init containers", tmpl.Name, sidecar.Name)
+		}
+	}
+
+	if err := validatePodSpecPatch("templates."+tmpl.Name, tmpl.PodSpecPatch); err != nil {
+		return err
+	}
+
+	if ctx.strict {
+		// the arguments are substituted below, which evaluates the expressions that can be
+		tmplBytes, err := json.Marshal(tmpl)
+		if err != nil {
+			return errors.InternalWrapError(err)
+		}
+		if err := template.ValidateSprigFree(string(tmplBytes)); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s: %s", tmpl.Name, err.Error())
+		}
+	}
+
 	localParams := make(map[string]string)
 	if tmpl.IsPodType() {
 		localParams[common.LocalVarPodName] = placeholderGenerator.NextPlaceholder()
@@ -422,7 +603,7 @@ func (ctx *templateValidationCtx) validateTemplate(tmpl *wfv1.Template, tmplCtx
 	if err != nil {
 		return err
 	}
-	err = validateOutputs(scope, ctx.globalParams, newTmpl)
+	err = validateOutputs(scope, ctx.globalParams, newTmpl, ctx.strict)
 	if err != nil {
 		return err
 	}
@@ -452,6 +633,43 @@ func (ctx *templateValidationCtx) validateTemplate(tmpl *wfv1.Template, tmplCtx
 	return nil
 }
 
+// validatePodSpecPatch checks that the patch can be applied to a pod spec, as the controller does when it creates the pod
+func validatePodSpecPatch(prefix, patch string) error {
+	if patch == "" || strings.Contains(patch, "{{") {
+		return nil // the variables are not known until runtime
+	}
+	data, err := yaml.YAMLToJSON([]byte(patch))
+	if err == nil {
+		err = json.Unmarshal(data, &apiv1.PodSpec{})
+	}
+	if err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "%s.podSpecPatch is invalid: %v", prefix, err)
+	}
+	return nil
+}
+
+// validateTemplateResources checks the quantities of the template's resources that are known once the workflow's
+// parameters are substituted, while those of the template's inputs are only known at runtime
+func validateTemplateResources(tmpl *wfv1.Template) error {
+	switch tmpl.GetType() {
+	case wfv1.TemplateTypeContainer, wfv1.TemplateTypeContainerSet, wfv1.TemplateTypeScript:
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.resources are only supported by container, containerSet and script templates", tmpl.Name)
+	}
+	resources := tmpl.Resources.DeepCopy()
+	for _, quantities := range []map[apiv1.ResourceName]string{resources.Limits, resources.Requests} {
+		for name, value := range quantities {
+			if strings.Contains(value, "{{") || placeholderGenerator.IsPlaceholder(value) {
+				delete(quantities, name)
+			}
+		}
+	}
+	if _, err := resources.ResourceRequirements(); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s", tmpl.Name, err)
+	}
+	return nil
+}
+
 // validateTemplateHolder validates a template holder and returns the validated template.
 func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.TemplateReferenceHolder, tmplCtx *templateresolution.Context, args wfv1.ArgumentsProvider) (*wfv1.Template, error) {
 	tmplRef := tmplHolder.GetTemplateRef()
@@ -496,15 +714,88 @@ func (ctx *templateValidationCtx) validateTemplateHolder(tmplHolder wfv1.Templat
 		default:
 			return nil, fmt.Errorf("%s is not a valid RetryPolicy", resolvedTmpl.RetryStrategy.RetryPolicy)
 		}
+		if _, err := resolvedTmpl.RetryStrategy.Backoff.GetJitter(); err != nil {
+			return nil, fmt.Errorf("templates.%s.retryStrategy.backoff: %w", resolvedTmpl.Name, err)
+		}
 	}
 
 	return resolvedTmpl, ctx.validateTemplate(resolvedTmpl, tmplCtx, args)
 }
 
+// validateTemplateImport validates that the imported workflow template, and the templates imported from it, exist
+func validateTemplateImport(tmplCtx *templateresolution.Context, templateImport wfv1.TemplateImport) error {
+	if templateImport.Name == "" {
+		return errors.New(errors.CodeBadRequest, "name is required")
+	}
+	tmplBase, err := tmplCtx.GetTemplateGetterFromRef(templateImport.ToTemplateRef(""))
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return errors.Errorf(errors.CodeBadRequest, "workflow template %s not found", templateImport.Name)
+		}
+		return err
+	}
+	for _, name := range templateImport.Templates {
+		if tmplBase.GetTemplateByName(name) == nil {
+			return errors.Errorf(errors.CodeBadRequest, "template %s not found in workflow template %s", name, templateImport.Name)
+		}
+	}
+	return nil
+}
+
+// validateNotifications validates the notifications of the workflow, which can use the global variables available
+// to exit handlers
+func (ctx *templateValidationCtx) validateNotifications(notifications []wfv1.WorkflowNotification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	names := make(map[string]bool)
+	for i, n := range notifications {
+		if err := n.Validate(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "spec.notifications[%d].%s", i, err.Error())
+		}
+		if names[n.Name] {
+			return errors.Errorf(errors.CodeBadRequest, "spec.notifications[%d].name '%s' is not unique", i, n.Name)
+		}
+		names[n.Name] = true
+	}
+	ctx.globalParams[common.GlobalVarWorkflowFailures] = placeholderGenerator.NextPlaceholder()
+	ctx.globalParams[common.GlobalVarWorkflowFailedNodes] = placeholderGenerator.NextPlaceholder()
+	notificationBytes, err := json.Marshal(notifications)
+	if err != nil {
+		return errors.InternalWrapError(err)
+	}
+	if err := resolveAllVariables(map[string]interface{}{}, ctx.globalParams, string(notificationBytes), ctx.strict); err != nil {
+		return errors.Errorf(errors.CodeBadRequest, "spec.notifications %s", err.Error())
+	}
+	return nil
+}
+
+// validateApproval validates the approval required to resume a suspend template
+func validateApproval(tmpl *wfv1.Template) error {
+	approval := tmpl.Suspend.Approval
+	if tmpl.Suspend.Duration != "" {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.approval cannot be used with a duration, use approval.expiry instead", tmpl.Name)
+	}
+	if len(approval.Groups) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.approval.groups must not be empty", tmpl.Name)
+	}
+	if approval.MinApprovals < 0 {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.approval.minApprovals must not be negative", tmpl.Name)
+	}
+	if expiry := approval.Expiry; expiry != "" && !strings.Contains(expiry, "{{") && !placeholderGenerator.IsPlaceholder(expiry) {
+		if _, err := strconv.Atoi(expiry); err != nil {
+			if _, err := time.ParseDuration(expiry); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.suspend.approval.expiry '%s' is not a valid duration", tmpl.Name, expiry)
+			}
+		}
+	}
+	return nil
+}
+
 // validateTemplateType validates that only one template type is defined
 func validateTemplateType(tmpl *wfv1.Template) error {
 	numTypes := 0
-	for _, tmplType := range []interface{}{tmpl.Container, tmpl.ContainerSet, tmpl.Steps, tmpl.Script, tmpl.Resource, tmpl.DAG, tmpl.Suspend, tmpl.Data, tmpl.HTTP, tmpl.Plugin} {
+	for _, tmplType := range []interface{}{tmpl.Container, tmpl.ContainerSet, tmpl.Steps, tmpl.Script, tmpl.Resource, tmpl.DAG, tmpl.Suspend, tmpl.Data, tmpl.HTTP, tmpl.Plugin, tmpl.SQLQuery, tmpl.Notification} {
 		if !reflect.ValueOf(tmplType).IsNil() {
 			numTypes++
 		}
@@ -540,6 +831,7 @@ func validateInputs(tmpl *wfv1.Template) (map[string]interface{}, error) {
 	for _, art := range tmpl.Inputs.Artifacts {
 		artRef := fmt.Sprintf("inputs.artifacts.%s", art.Name)
 		scope[artRef] = true
+		scope[fmt.Sprintf("inputs.artifacts.%s.digest", art.Name)] = true
 		if tmpl.IsLeaf() {
 			err = art.CleanPath()
 			if err != nil {
@@ -559,10 +851,40 @@ func validateInputs(tmpl *wfv1.Template) (map[string]interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+		if art.FromWorkflow != nil {
+			err = validateArtifactFromWorkflow(art)
+			if err != nil {
+				return nil, errors.Errorf(errors.CodeBadRequest, "%s.fromWorkflow %s", errPrefix, err.Error())
+			}
+		}
+		if art.Validation != nil {
+			err = validateArtifactValidation(errPrefix, tmpl, art)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	return scope, nil
 }
 
+func validateArtifactValidation(errPrefix string, tmpl *wfv1.Template, art wfv1.Artifact) error {
+	if !tmpl.IsPodType() {
+		return errors.Errorf(errors.CodeBadRequest, "%s.validation only valid in container/script templates", errPrefix)
+	}
+	if art.Optional && art.Validation.Required {
+		return errors.Errorf(errors.CodeBadRequest, "%s.validation.required cannot be used with optional artifacts", errPrefix)
+	}
+	if v := art.Validation.MinSizeBytes; v != nil && *v < 0 {
+		return errors.Errorf(errors.CodeBadRequest, "%s.validation.minSizeBytes must not be negative", errPrefix)
+	}
+	if v := art.Validation.MediaType; v != "" {
+		if _, _, err := mime.ParseMediaType(v); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "%s.validation.mediaType %q is invalid: %v", errPrefix, v, err)
+		}
+	}
+	return nil
+}
+
 func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error {
 	if art.Git != nil {
 		if art.Git.Repo == "" {
@@ -579,42 +901,80 @@ func validateArtifactLocation(errPrefix string, art wfv1.ArtifactLocation) error
 	return nil
 }
 
-// resolveAllVariables is a helper to ensure all {{variables}} are resolvable from current scope
-func resolveAllVariables(scope map[string]interface{}, globalParams map[string]string, tmplStr string) error {
-	_, allowAllItemRefs := scope[anyItemMagicValue] // 'item.*' is a magic placeholder value set by addItemsToScope
-	_, allowAllWorkflowOutputParameterRefs := scope[anyWorkflowOutputParameterMagicValue]
-	_, allowAllWorkflowOutputArtifactRefs := scope[anyWorkflowOutputArtifactMagicValue]
-	return template.Validate(tmplStr, func(tag string) error {
+// resolveAllVariables is a helper to ensure all {{variables}} are resolvable from current scope. If strict, tags of
+// unknown variables are not skipped, and the variables of expressions are also resolved.
+func resolveAllVariables(scope map[string]interface{}, globalParams map[string]string, tmplStr string, strict bool) error {
+	validator := func(tag string) error {
 		// Skip the custom variable references
-		if !checkValidWorkflowVariablePrefix(tag) {
+		if !strict && !checkValidWorkflowVariablePrefix(tag) {
 			return nil
 		}
-		_, ok := scope[tag]
-		_, isGlobal := globalParams[tag]
-		if !ok && !isGlobal {
-			if (tag == "item" || strings.HasPrefix(tag, "item.")) && allowAllItemRefs {
-				// we are *probably* referencing a undetermined item using withParam
-				// NOTE: this is far from foolproof.
-			} else if strings.HasPrefix(tag, "workflow.outputs.parameters.") && allowAllWorkflowOutputParameterRefs {
-				// Allow runtime resolution of workflow output parameter names
-			} else if strings.HasPrefix(tag, "workflow.outputs.artifacts.") && allowAllWorkflowOutputArtifactRefs {
-				// Allow runtime resolution of workflow output artifact names
-			} else if strings.HasPrefix(tag, "outputs.") {
-				// We are self referencing for metric emission, allow it.
-			} else if strings.HasPrefix(tag, common.GlobalVarWorkflowCreationTimestamp) {
-			} else if strings.HasPrefix(tag, common.GlobalVarWorkflowCronScheduleTime) {
-				// Allow runtime resolution for "scheduledTime" which will pass from CronWorkflow
-			} else if strings.HasPrefix(tag, common.GlobalVarWorkflowDuration) {
-			} else if strings.HasPrefix(tag, "tasks.name") {
-			} else if strings.HasPrefix(tag, "steps.name") {
-			} else {
-				return fmt.Errorf("failed to resolve {{%s}}", tag)
+		return resolveVariable(scope, globalParams, tag)
+	}
+	if !strict {
+		return template.Validate(tmplStr, validator)
+	}
+	return template.ValidateStrict(tmplStr, validator, func(variable string) error {
+		// expressions may also use maps of variables, e.g. inputs.parameters
+		for k := range scope {
+			if isVariablePrefix(variable, k) {
+				return nil
 			}
 		}
-		return nil
+		for k := range globalParams {
+			if isVariablePrefix(variable, k) {
+				return nil
+			}
+		}
+		return resolveVariable(scope, globalParams, variable)
 	})
 }
 
+// isMetricVariable returns whether the variable is only resolved when metrics are emitted
+func isMetricVariable(tag string) bool {
+	switch tag {
+	case common.LocalVarDuration, common.LocalVarStatus, common.LocalVarResourcesDuration, common.LocalVarCost, common.LocalVarExitCode:
+		return true
+	}
+	return strings.HasPrefix(tag, common.LocalVarResourcesDuration+".")
+}
+
+// isVariablePrefix returns whether the variable is the prefix of the other variable, e.g. inputs of inputs.parameters
+func isVariablePrefix(prefix, variable string) bool {
+	return strings.HasPrefix(variable, prefix+".")
+}
+
+func resolveVariable(scope map[string]interface{}, globalParams map[string]string, tag string) error {
+	_, allowAllItemRefs := scope[anyItemMagicValue] // 'item.*' is a magic placeholder value set by addItemsToScope
+	_, allowAllWorkflowOutputParameterRefs := scope[anyWorkflowOutputParameterMagicValue]
+	_, allowAllWorkflowOutputArtifactRefs := scope[anyWorkflowOutputArtifactMagicValue]
+	_, ok := scope[tag]
+	_, isGlobal := globalParams[tag]
+	if !ok && !isGlobal {
+		if (tag == "item" || strings.HasPrefix(tag, "item.")) && allowAllItemRefs {
+			// we are *probably* referencing a undetermined item using withParam
+			// NOTE: this is far from foolproof.
+		} else if strings.HasPrefix(tag, "workflow.outputs.parameters.") && allowAllWorkflowOutputParameterRefs {
+			// Allow runtime resolution of workflow output parameter names
+		} else if strings.HasPrefix(tag, "workflow.outputs.artifacts.") && allowAllWorkflowOutputArtifactRefs {
+			// Allow runtime resolution of workflow output artifact names
+		} else if strings.HasPrefix(tag, "outputs.") {
+			// We are self referencing for metric emission, allow it.
+		} else if strings.HasPrefix(tag, common.GlobalVarWorkflowCreationTimestamp) {
+		} else if strings.HasPrefix(tag, common.GlobalVarWorkflowCronScheduleTime) {
+			// Allow runtime resolution for "scheduledTime" which will pass from CronWorkflow
+		} else if strings.HasPrefix(tag, common.GlobalVarWorkflowDuration) {
+		} else if strings.HasPrefix(tag, "tasks.name") {
+		} else if strings.HasPrefix(tag, "steps.name") {
+		} else if isMetricVariable(tag) {
+			// Allow runtime resolution of the variables of metric emission
+		} else {
+			return fmt.Errorf("failed to resolve {{%s}}", tag)
+		}
+	}
+	return nil
+}
+
 // checkValidWorkflowVariablePrefix is a helper methood check variable starts workflow root elements
 func checkValidWorkflowVariablePrefix(tag string) bool {
 	for _, rootTag := range common.GlobalVarValidWorkflowVariablePrefix {
@@ -637,7 +997,7 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	err = resolveAllVariables(scope, ctx.globalParams, string(tmplBytes))
+	err = resolveAllVariables(scope, ctx.globalParams, string(tmplBytes), ctx.strict)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s: %s", tmpl.Name, err.Error())
 	}
@@ -709,12 +1069,123 @@ func (ctx *templateValidationCtx) validateLeaf(scope map[string]interface{}, tmp
 				}
 			}
 		}
+		if tmpl.Resource.ServerSideApply && tmpl.Resource.Action != "apply" && !placeholderGenerator.IsPlaceholder(tmpl.Resource.Action) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource.serverSideApply is only valid with the apply action", tmpl.Name)
+		}
+		if tmpl.Resource.FieldManager != "" && !tmpl.Resource.ServerSideApply {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource.fieldManager is only valid with serverSideApply", tmpl.Name)
+		}
+		if tmpl.Resource.SuccessCondition != "" && tmpl.Resource.SuccessExpression != "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource cannot have both successCondition and successExpression", tmpl.Name)
+		}
+		if tmpl.Resource.FailureCondition != "" && tmpl.Resource.FailureExpression != "" {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource cannot have both failureCondition and failureExpression", tmpl.Name)
+		}
+		for _, x := range []struct{ field, expression string }{
+			{"successExpression", tmpl.Resource.SuccessExpression},
+			{"failureExpression", tmpl.Resource.FailureExpression},
+		} {
+			if x.expression == "" || placeholderGenerator.IsPlaceholder(x.expression) {
+				continue
+			}
+			if _, err := expr.Compile(x.expression); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource.%s is invalid: %v", tmpl.Name, x.field, err)
+			}
+		}
+		if tmpl.Resource.WaitTimeout != "" && !placeholderGenerator.IsPlaceholder(tmpl.Resource.WaitTimeout) {
+			if _, err := time.ParseDuration(tmpl.Resource.WaitTimeout); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.resource.waitTimeout is invalid: %v", tmpl.Name, err)
+			}
+		}
 	}
 	if tmpl.Script != nil {
 		if tmpl.Script.Image == "" {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.script.image may not be empty", tmpl.Name)
 		}
 	}
+	if tmpl.HTTP != nil {
+		if err := tmpl.HTTP.ValidateRetryOn(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.http.retryOn %s", tmpl.Name, err.Error())
+		}
+		for _, art := range tmpl.Outputs.Artifacts {
+			if art.Name != tmpl.HTTP.OutputArtifact {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs.artifacts.%s must be the http.outputArtifact", tmpl.Name, art.Name)
+			}
+		}
+		if tmpl.HTTP.OutputArtifact != "" && tmpl.Outputs.GetArtifactByName(tmpl.HTTP.OutputArtifact) == nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.http.outputArtifact %s is not an output artifact", tmpl.Name, tmpl.HTTP.OutputArtifact)
+		}
+	}
+	if tmpl.Data != nil {
+		if err := data.Validate(tmpl.Data); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.data.%s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.SQLQuery != nil {
+		if err := tmpl.SQLQuery.Validate(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.sqlQuery.%s", tmpl.Name, err.Error())
+		}
+		for _, art := range tmpl.Outputs.Artifacts {
+			if art.Name != tmpl.SQLQuery.OutputArtifact {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs.artifacts.%s must be the sqlQuery.outputArtifact", tmpl.Name, art.Name)
+			}
+		}
+		if tmpl.SQLQuery.OutputArtifact != "" && tmpl.Outputs.GetArtifactByName(tmpl.SQLQuery.OutputArtifact) == nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.sqlQuery.outputArtifact %s is not an output artifact", tmpl.Name, tmpl.SQLQuery.OutputArtifact)
+		}
+	}
+	if tmpl.Notification != nil {
+		if err := tmpl.Notification.Validate(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.notification.%s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.GPU != nil {
+		if tmpl.Container == nil && tmpl.Script == nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.gpu is only supported by container and script templates", tmpl.Name)
+		}
+		if err := tmpl.GPU.Validate(); err != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.gpu.%s", tmpl.Name, err.Error())
+		}
+	}
+	if tmpl.PodDisruptionBudget != nil && !tmpl.IsPodType() {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.podDisruptionBudget is only supported by templates that run pods", tmpl.Name)
+	}
+	if tmpl.DisruptionSensitive != nil && !tmpl.IsPodType() {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.disruptionSensitive is only supported by templates that run pods", tmpl.Name)
+	}
+	if len(tmpl.ImagePullSecrets) > 0 && !tmpl.IsPodType() {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.imagePullSecrets is only supported by templates that run pods", tmpl.Name)
+	}
+	if tmpl.Cluster != "" && !tmpl.IsPodType() {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.cluster is only supported by templates that run pods", tmpl.Name)
+	}
+	if tmpl.WorkloadClass != "" && !tmpl.IsPodType() {
+		return errors.Errorf(errors.CodeBadRequest, "templates.%s.workloadClass is only supported by templates that run pods", tmpl.Name)
+	}
+	if tmpl.WorkingDirOverride != "" || tmpl.RunAsUserOverride != nil {
+		switch tmpl.GetType() {
+		case wfv1.TemplateTypeContainer, wfv1.TemplateTypeContainerSet, wfv1.TemplateTypeScript:
+		default:
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.workingDirOverride and runAsUserOverride are only supported by container, containerSet and script templates", tmpl.Name)
+		}
+		// the directory may not be known until runtime
+		if tmpl.WorkingDirOverride != "" && !strings.HasPrefix(tmpl.WorkingDirOverride, "{{") && !path.IsAbs(tmpl.WorkingDirOverride) {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.workingDirOverride '%s' must be an absolute path", tmpl.Name, tmpl.WorkingDirOverride)
+		}
+		if tmpl.RunAsUserOverride != nil && *tmpl.RunAsUserOverride < 0 {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.runAsUserOverride must not be negative", tmpl.Name)
+		}
+	}
+	if tmpl.Resources != nil {
+		if err := validateTemplateResources(tmpl); err != nil {
+			return err
+		}
+	}
+	if tmpl.Suspend != nil && tmpl.Suspend.Approval != nil {
+		if err := validateApproval(tmpl); err != nil {
+			return err
+		}
+	}
 	// we don't validate tmpl.Plugin, because this is done by Plugin.UnmarshallJSON
 	if tmpl.ActiveDeadlineSeconds != nil {
 		if !intstr.IsValidIntOrArgoVariable(tmpl.ActiveDeadlineSeconds) && !placeholderGenerator.IsPlaceholder(tmpl.ActiveDeadlineSeconds.StrVal) {
@@ -778,18 +1249,108 @@ func validateArgumentsValues(prefix string, arguments wfv1.Arguments, allowEmpty
 				return errors.Errorf(errors.CodeBadRequest, "%s%s.value should be present in %s%s.enum list", prefix, param.Name, prefix, param.Name)
 			}
 		}
+		if param.Schema != nil {
+			if err := validateParameterSchema(param.Schema); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "%s%s.schema %s", prefix, param.Name, err)
+			}
+			if param.Value != nil {
+				if err := validateParameterValue(param.Schema, param.Value.String()); err != nil {
+					return errors.Errorf(errors.CodeBadRequest, "%s%s.value %s", prefix, param.Name, err)
+				}
+			}
+		}
 	}
 	for _, art := range arguments.Artifacts {
-		if art.From == "" && !art.HasLocationOrKey() {
+		if art.From == "" && !art.HasLocationOrKey() && art.FromWorkflow == nil {
 			return errors.Errorf(errors.CodeBadRequest, "%s%s.from, artifact location, or key is required", prefix, art.Name)
 		}
 		if art.From != "" && art.FromExpression != "" {
 			return errors.Errorf(errors.CodeBadRequest, "%s%s shouldn't have both `from` and `fromExpression` in Artifact", prefix, art.Name)
 		}
+		if art.FromWorkflow != nil {
+			if err := validateArtifactFromWorkflow(art); err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "%s%s.fromWorkflow %s", prefix, art.Name, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func validateParameterSchema(schema *wfv1.ParameterSchema) error {
+	switch schema.GetType() {
+	case wfv1.ParameterTypeString, wfv1.ParameterTypeInt, wfv1.ParameterTypeBool, wfv1.ParameterTypeJSON:
+	default:
+		return fmt.Errorf("type '%s' is not one of: string, int, bool or json", schema.Type)
+	}
+	if _, err := regexp.Compile(schema.Pattern); err != nil {
+		return fmt.Errorf("pattern is not a valid regular expression: %w", err)
+	}
+	if (schema.Minimum != nil || schema.Maximum != nil) && schema.GetType() != wfv1.ParameterTypeInt {
+		return fmt.Errorf("minimum and maximum are only valid for type int")
+	}
+	if schema.Minimum != nil && schema.Maximum != nil && *schema.Minimum > *schema.Maximum {
+		return fmt.Errorf("minimum must not be greater than maximum")
+	}
+	return nil
+}
+
+// validateParameterValue returns an error if the value does not match the schema. Values with variables, e.g.
+// "{{workflow.name}}", are not known until the workflow runs, so they always match.
+func validateParameterValue(schema *wfv1.ParameterSchema, value string) error {
+	if strings.Contains(value, "{{") {
+		return nil
+	}
+	switch schema.GetType() {
+	case wfv1.ParameterTypeInt:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not an int", value)
+		}
+		if schema.Minimum != nil && i < *schema.Minimum {
+			return fmt.Errorf("%d is less than the minimum %d", i, *schema.Minimum)
+		}
+		if schema.Maximum != nil && i > *schema.Maximum {
+			return fmt.Errorf("%d is greater than the maximum %d", i, *schema.Maximum)
+		}
+	case wfv1.ParameterTypeBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("'%s' is not a bool, it must be true or false", value)
+		}
+	case wfv1.ParameterTypeJSON:
+		if !json.Valid([]byte(value)) {
+			return fmt.Errorf("'%s' is not JSON", value)
+		}
+	}
+	if schema.Pattern != "" {
+		if matched, _ := regexp.MatchString(schema.Pattern, value); !matched {
+			return fmt.Errorf("'%s' does not match the pattern '%s'", value, schema.Pattern)
+		}
 	}
 	return nil
 }
 
+// withParameterSchemas returns the parameters, such that those without a schema or enum have those of the template's
+// parameter of the same name, so that the values passed to a WorkflowTemplate match them
+func withParameterSchemas(params []wfv1.Parameter, templateParams []wfv1.Parameter) []wfv1.Parameter {
+	templateParamsByName := make(map[string]wfv1.Parameter)
+	for _, param := range templateParams {
+		templateParamsByName[param.Name] = param
+	}
+	result := make([]wfv1.Parameter, len(params))
+	for i, param := range params {
+		if templateParam, ok := templateParamsByName[param.Name]; ok {
+			if param.Schema == nil {
+				param.Schema = templateParam.Schema
+			}
+			if param.Enum == nil && param.Value != nil {
+				param.Enum = templateParam.Enum
+			}
+		}
+		result[i] = param
+	}
+	return result
+}
+
 func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tmplCtx *templateresolution.Context, tmpl *wfv1.Template) error {
 	err := validateNonLeaf(tmpl)
 	if err != nil {
@@ -839,7 +1400,7 @@ func (ctx *templateValidationCtx) validateSteps(scope map[string]interface{}, tm
 		if err != nil {
 			return errors.InternalWrapError(err)
 		}
-		err = resolveAllVariables(scope, ctx.globalParams, string(stepBytes))
+		err = resolveAllVariables(scope, ctx.globalParams, string(stepBytes), ctx.strict)
 		if err != nil {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.steps %s", tmpl.Name, err.Error())
 		}
@@ -916,6 +1477,9 @@ func (ctx *templateValidationCtx) addOutputsToScope(tmpl *wfv1.Template, prefix
 		scope[fmt.Sprintf("%s.outputs.result", prefix)] = true
 		scope[fmt.Sprintf("%s.exitCode", prefix)] = true
 	}
+	if tmpl.SQLQuery != nil {
+		scope[fmt.Sprintf("%s.outputs.parameters.%s", prefix, wfv1.SQLRowCountParameter)] = true
+	}
 	for _, param := range tmpl.Outputs.Parameters {
 		scope[fmt.Sprintf("%s.outputs.parameters.%s", prefix, param.Name)] = true
 		if param.GlobalName != "" {
@@ -959,7 +1523,51 @@ func (ctx *templateValidationCtx) addOutputsToScope(tmpl *wfv1.Template, prefix
 	}
 }
 
-func validateOutputs(scope map[string]interface{}, globalParams map[string]string, tmpl *wfv1.Template) error {
+// validatePipeArtifact validates that a pipe artifact is written by a container to a mount of its volume
+func validatePipeArtifact(tmpl *wfv1.Template, art wfv1.Artifact) error {
+	switch tmpl.GetType() {
+	case wfv1.TemplateTypeContainer, wfv1.TemplateTypeScript:
+	default:
+		return fmt.Errorf("only valid in container and script templates")
+	}
+	if art.Pipe.Volume == "" {
+		return fmt.Errorf("volume is required")
+	}
+	if art.HasLocation() {
+		return fmt.Errorf("cannot be used with an artifact location")
+	}
+	if strings.Contains(art.Path, "{{") {
+		// the path is only known once its parameters are resolved
+		return nil
+	}
+	_, err := common.PipeArtifactSubPath(tmpl, art)
+	return err
+}
+
+// validateArtifactFromWorkflow validates that an artifact taken from another workflow selects the workflows and names
+// the artifact, and has no other source
+func validateArtifactFromWorkflow(art wfv1.Artifact) error {
+	if art.From != "" || art.FromExpression != "" || art.HasLocation() {
+		return fmt.Errorf("cannot be used with from, fromExpression or an artifact location")
+	}
+	if art.FromWorkflow.Selector == nil {
+		return fmt.Errorf("selector is required")
+	}
+	if _, err := v1.LabelSelectorAsSelector(art.FromWorkflow.Selector); err != nil {
+		return fmt.Errorf("selector is invalid: %w", err)
+	}
+	if art.FromWorkflow.ArtifactName == "" {
+		return fmt.Errorf("artifactName is required")
+	}
+	switch art.FromWorkflow.Strategy {
+	case "", wfv1.FromWorkflowStrategyLatest:
+	default:
+		return fmt.Errorf("strategy %q is not one of: %s", art.FromWorkflow.Strategy, wfv1.FromWorkflowStrategyLatest)
+	}
+	return nil
+}
+
+func validateOutputs(scope map[string]interface{}, globalParams map[string]string, tmpl *wfv1.Template, strict bool) error {
 	err := validateWorkflowFieldNames(tmpl.Outputs.Parameters)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs.parameters %s", tmpl.Name, err.Error())
@@ -972,14 +1580,24 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 	if err != nil {
 		return errors.InternalWrapError(err)
 	}
-	err = resolveAllVariables(scope, globalParams, string(outputBytes))
+	err = resolveAllVariables(scope, globalParams, string(outputBytes), strict)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.outputs %s", tmpl.Name, err.Error())
 	}
 
 	for _, art := range tmpl.Outputs.Artifacts {
 		artRef := fmt.Sprintf("outputs.artifacts.%s", art.Name)
-		if tmpl.IsLeaf() {
+		if tmpl.HTTP != nil {
+			// the HTTP response body is streamed to the artifact, so it has no path
+			if art.Path != "" {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.path not valid in http templates", tmpl.Name, artRef)
+			}
+		} else if tmpl.SQLQuery != nil {
+			// the rows are saved to the artifact, so it has no path
+			if art.Path != "" {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.path not valid in sqlQuery templates", tmpl.Name, artRef)
+			}
+		} else if tmpl.IsLeaf() {
 			err = art.CleanPath()
 			if err != nil {
 				return errors.Errorf(errors.CodeBadRequest, "error in templates.%s.%s: %s", tmpl.Name, artRef, err.Error())
@@ -989,6 +1607,15 @@ func validateOutputs(scope map[string]interface{}, globalParams map[string]strin
 				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.path only valid in container/script templates", tmpl.Name, artRef)
 			}
 		}
+		if art.Validation != nil {
+			return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.validation only valid in inputs", tmpl.Name, artRef)
+		}
+		if art.Pipe != nil {
+			err = validatePipeArtifact(tmpl, art)
+			if err != nil {
+				return errors.Errorf(errors.CodeBadRequest, "templates.%s.%s.pipe %s", tmpl.Name, artRef, err.Error())
+			}
+		}
 		if art.GlobalName != "" && !isParameter(art.GlobalName) {
 			errs := isValidParamOrArtifactName(art.GlobalName)
 			if len(errs) > 0 {
@@ -1239,7 +1866,7 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 	if err = verifyNoCycles(tmpl, dagValidationCtx); err != nil {
 		return err
 	}
-	err = resolveAllVariables(scope, ctx.globalParams, tmpl.DAG.Target)
+	err = resolveAllVariables(scope, ctx.globalParams, tmpl.DAG.Target, ctx.strict)
 	if err != nil {
 		return errors.Errorf(errors.CodeBadRequest, "templates.%s.targets %s", tmpl.Name, err.Error())
 	}
@@ -1285,7 +1912,7 @@ func (ctx *templateValidationCtx) validateDAG(scope map[string]interface{}, tmpl
 		if err != nil {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
 		}
-		err = resolveAllVariables(taskScope, ctx.globalParams, string(taskBytes))
+		err = resolveAllVariables(taskScope, ctx.globalParams, string(taskBytes), ctx.strict)
 		if err != nil {
 			return errors.Errorf(errors.CodeBadRequest, "templates.%s.tasks.%s %s", tmpl.Name, task.Name, err.Error())
 		}
@@ -2,10 +2,12 @@ package validate
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -1500,6 +1502,78 @@ func TestCustomTemplatVariable(t *testing.T) {
 	assert.Equal(t, err, nil)
 }
 
+var strictTemplateResolution = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: strict-
+spec:
+  entrypoint: main
+  templateResolutionStrictness: Strict
+  templates:
+  - name: main
+    steps:
+    - - name: a
+        template: echo
+        arguments:
+          parameters:
+          - name: message
+            value: hello
+    - - name: b
+        template: echo
+        arguments:
+          parameters:
+          - name: message
+            value: "{{=steps.a.outputs.result}}"
+  - name: echo
+    inputs:
+      parameters:
+      - name: message
+    container:
+      image: alpine:latest
+      command: [echo, "{{=inputs.parameters.message}}"]
+`
+
+// TestStrictTemplateResolution verifies tags and expressions must only reference defined variables in strict mode
+func TestStrictTemplateResolution(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		wf := unmarshalWf(strictTemplateResolution)
+		assert.NoError(t, ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{}))
+	})
+	t.Run("CustomVariable", func(t *testing.T) {
+		wf := unmarshalWf(customVariableInput)
+		wf.Spec.TemplateResolutionStrictness = wfv1.TemplateResolutionStrict
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Lint: true})
+		assert.EqualError(t, err, "templates.whalesay: failed to resolve {{user.username}}")
+	})
+	t.Run("UndefinedInput", func(t *testing.T) {
+		wf := unmarshalWf(strings.ReplaceAll(strictTemplateResolution, "{{=inputs.parameters.message}}", "{{=inputs.parameters.mesage}}"))
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.ErrorContains(t, err, "failed to resolve {{inputs.parameters.mesage}}")
+	})
+	t.Run("UndefinedOutput", func(t *testing.T) {
+		wf := unmarshalWf(strings.ReplaceAll(strictTemplateResolution, "{{=steps.a.outputs.result}}", "{{=steps['a'].outputs.parameters.message}}"))
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.EqualError(t, err, "templates.main.steps failed to resolve {{steps.a.outputs.parameters.message}}")
+	})
+	t.Run("Lenient", func(t *testing.T) {
+		wf := unmarshalWf(strings.ReplaceAll(strictTemplateResolution, "{{=steps.a.outputs.result}}", "{{=steps['a'].outputs.parameters.message}}"))
+		wf.Spec.TemplateResolutionStrictness = wfv1.TemplateResolutionLenient
+		assert.NoError(t, ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{}))
+	})
+	t.Run("Sprig", func(t *testing.T) {
+		wf := unmarshalWf(strings.ReplaceAll(strictTemplateResolution, "{{=inputs.parameters.message}}", "{{=sprig.trim(inputs.parameters.message)}}"))
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.ErrorContains(t, err, "templates.echo: expression {{=sprig.trim(inputs.parameters.message)}} cannot use Sprig functions")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		wf := unmarshalWf(strictTemplateResolution)
+		wf.Spec.TemplateResolutionStrictness = "Pedantic"
+		err := ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		assert.EqualError(t, err, "spec.templateResolutionStrictness must be Lenient or Strict")
+	})
+}
+
 var templateRefTarget = `
 apiVersion: argoproj.io/v1alpha1
 kind: WorkflowTemplate
@@ -1581,6 +1655,69 @@ func TestUndefinedTemplateRef(t *testing.T) {
 	}
 }
 
+var templateImportsLibrary = `
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: template-imports-library
+spec:
+  templates:
+  - name: hello
+    steps:
+      - - name: echo
+          template: echo
+  - name: echo
+    container:
+      image: alpine:latest
+`
+
+var templateImports = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: template-imports-
+spec:
+  entrypoint: main
+  templateImports:
+  - name: template-imports-library
+    templates:
+    - hello
+  templates:
+  - name: main
+    steps:
+      - - name: hello
+          template: hello
+`
+
+func TestTemplateImports(t *testing.T) {
+	err := createWorkflowTemplateFromSpec(templateImportsLibrary)
+	assert.NoError(t, err)
+	defer func() { _ = deleteWorkflowTemplate("template-imports-library") }()
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(templateImports)
+		assert.NoError(t, err)
+	})
+	t.Run("NotImported", func(t *testing.T) {
+		err := validate(strings.Replace(templateImports, "template: hello", "template: echo", 1))
+		assert.EqualError(t, err, "templates.main.steps[0].hello template name 'echo' undefined")
+	})
+	t.Run("UndefinedTemplate", func(t *testing.T) {
+		err := validate(strings.Replace(templateImports, "- hello", "- goodbye", 1))
+		assert.EqualError(t, err, "spec.templateImports[0] template goodbye not found in workflow template template-imports-library")
+	})
+	t.Run("UndefinedWorkflowTemplate", func(t *testing.T) {
+		err := validate(strings.Replace(templateImports, "name: template-imports-library", "name: foo", 1))
+		assert.EqualError(t, err, "spec.templateImports[0] workflow template foo not found")
+	})
+	t.Run("LocalTemplatesTakePrecedence", func(t *testing.T) {
+		err := validate(templateImports + `
+  - name: hello
+    suspend: {}
+`)
+		assert.NoError(t, err)
+	})
+}
+
 var validResourceWorkflow = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -2624,6 +2761,58 @@ func TestMaxLengthName(t *testing.T) {
 	assert.EqualError(t, err, "cron workflow name \"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\" must not be more than 52 characters long (currently 60)")
 }
 
+func TestValidateCronWorkflowSchedules(t *testing.T) {
+	validate := func(spec wfv1.CronWorkflowSpec) error {
+		spec.WorkflowSpec = wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Container: &apiv1.Container{Image: "alpine"}}}}
+		return ValidateCronWorkflow(wftmplGetter, cwftmplGetter, &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf"}, Spec: spec})
+	}
+	assert.NoError(t, validate(wfv1.CronWorkflowSpec{Schedules: []string{"0 * * * *", "30 2 * * *"}}))
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{}), "cron schedule must be specified")
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Schedules: []string{"invalid"}}), "cron schedule is malformed: expected exactly 5 fields, found 1: [invalid]")
+	assert.NoError(t, validate(wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Exclusions: []wfv1.CronExclusion{
+		{Schedule: "0 2 * * 0", Duration: "2h"},
+		{Calendar: &apiv1.ConfigMapKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "holidays"}, Key: "dates"}},
+	}}))
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Exclusions: []wfv1.CronExclusion{{}}}), "exclusions[0] must specify exactly one of schedule or calendar")
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Exclusions: []wfv1.CronExclusion{{Schedule: "0 2 * * 0"}}}), "exclusions[0].duration must be a positive duration, e.g. \"2h\"")
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Exclusions: []wfv1.CronExclusion{{Schedule: "invalid", Duration: "2h"}}}), "exclusions[0].schedule is malformed: expected exactly 5 fields, found 1: [invalid]")
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Exclusions: []wfv1.CronExclusion{{Calendar: &apiv1.ConfigMapKeySelector{}}}}), "exclusions[0].calendar must specify a config map name and key")
+}
+
+func TestValidateCronWorkflowJitterAndDSTPolicy(t *testing.T) {
+	validate := func(spec wfv1.CronWorkflowSpec) error {
+		spec.Schedule = "0 * * * *"
+		spec.WorkflowSpec = wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Container: &apiv1.Container{Image: "alpine"}}}}
+		return ValidateCronWorkflow(wftmplGetter, cwftmplGetter, &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf"}, Spec: spec})
+	}
+	assert.NoError(t, validate(wfv1.CronWorkflowSpec{ScheduleJitter: "5m", DSTPolicy: wfv1.DSTPolicyRunOnce}))
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{ScheduleJitter: "-5m"}), "scheduleJitter must be a non-negative duration, e.g. \"5m\"")
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{DSTPolicy: "sometimes"}), "'sometimes' is not a valid dstPolicy")
+}
+
+func TestValidateCronWorkflowCatchup(t *testing.T) {
+	validate := func(catchup *wfv1.CronCatchup) error {
+		spec := wfv1.CronWorkflowSpec{Schedule: "0 * * * *", Catchup: catchup}
+		spec.WorkflowSpec = wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Container: &apiv1.Container{Image: "alpine"}}}}
+		return ValidateCronWorkflow(wftmplGetter, cwftmplGetter, &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf"}, Spec: spec})
+	}
+	assert.NoError(t, validate(&wfv1.CronCatchup{Enabled: true}))
+	zero := int32(0)
+	assert.EqualError(t, validate(&wfv1.CronCatchup{Enabled: true, Limit: &zero}), "catchup.limit must be at least 1")
+}
+
+func TestValidateCronWorkflowForbidIfPreviousFailed(t *testing.T) {
+	validate := func(spec wfv1.CronWorkflowSpec) error {
+		spec.Schedule = "0 * * * *"
+		spec.ConcurrencyPolicy = wfv1.ForbidIfPreviousFailedConcurrent
+		spec.WorkflowSpec = wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Container: &apiv1.Container{Image: "alpine"}}}}
+		return ValidateCronWorkflow(wftmplGetter, cwftmplGetter, &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "my-cwf"}, Spec: spec})
+	}
+	assert.NoError(t, validate(wfv1.CronWorkflowSpec{}))
+	zero := int32(0)
+	assert.EqualError(t, validate(wfv1.CronWorkflowSpec{FailedJobsHistoryLimit: &zero}), "concurrencyPolicy 'ForbidIfPreviousFailed' requires failedJobsHistoryLimit to be at least 1")
+}
+
 var invalidContainerSetDependencyNotFound = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -3001,6 +3190,39 @@ func TestInitContainerHasName(t *testing.T) {
 	assert.EqualError(t, err, "templates.main.tasks.spurious initContainers must all have container name")
 }
 
+func TestInitContainerCaptureOutputs(t *testing.T) {
+	wf := func(containers string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: capture-outputs-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+` + containers + `
+    container:
+      image: alpine:latest
+      command: [cat, /tmp/token]
+    outputs:
+      parameters:
+      - name: token
+        valueFrom:
+          path: /tmp/token
+`
+	}
+	assert.NoError(t, validate(wf(`    initContainers:
+    - name: fetch-token
+      image: alpine:latest
+      command: [sh, -c, "echo my-token > /tmp/token"]
+      captureOutputs: true`)))
+	assert.EqualError(t, validate(wf(`    sidecars:
+    - name: fetch-token
+      image: alpine:latest
+      captureOutputs: true`)), "templates.main.sidecars.fetch-token.captureOutputs is only valid for init containers")
+}
+
 func TestSubstituteGlobalVariablesLabelsAnnotations(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -3063,3 +3285,990 @@ func TestSubstituteGlobalVariablesLabelsAnnotations(t *testing.T) {
 		})
 	}
 }
+
+var sqlQueryTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: sql-query-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: query
+        template: query
+    - - name: print
+        template: print
+        arguments:
+          parameters:
+          - name: count
+            value: "{{steps.query.outputs.parameters.rowCount}}"
+  - name: query
+    sqlQuery:
+      driver: %s
+      connectionSecret:
+        name: db
+        key: dsn
+      query: SELECT 1
+  - name: print
+    inputs:
+      parameters:
+      - name: count
+    container:
+      image: alpine
+      command: [echo, "{{inputs.parameters.count}}"]
+`
+
+func TestSQLQueryTemplate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(sqlQueryTemplate, "postgres"))
+		assert.NoError(t, err)
+	})
+	t.Run("InvalidDriver", func(t *testing.T) {
+		err := validate(fmt.Sprintf(sqlQueryTemplate, "oracle"))
+		assert.EqualError(t, err, "templates.main.steps[0].query templates.query.sqlQuery.driver must be one of: postgres, mysql")
+	})
+}
+
+var sqlQueryOutputArtifact = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: sql-query-output-artifact-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    outputs:
+      artifacts:
+      - name: %s
+    sqlQuery:
+      driver: postgres
+      connectionSecret:
+        name: db
+        key: dsn
+      query: SELECT 1
+      outputArtifact: %s
+`
+
+func TestSQLQueryOutputArtifact(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(sqlQueryOutputArtifact, "rows", "rows"))
+		assert.NoError(t, err)
+	})
+	t.Run("NotOutputArtifact", func(t *testing.T) {
+		err := validate(fmt.Sprintf(sqlQueryOutputArtifact, "other", "rows"))
+		assert.EqualError(t, err, "templates.main.outputs.artifacts.other must be the sqlQuery.outputArtifact")
+	})
+}
+
+var notificationsWorkflow = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: notifications-
+spec:
+  entrypoint: main
+  notifications:
+  - name: slack
+    events: [onFailure]
+    slack:
+      webhookURLSecret:
+        name: slack
+        key: url
+      text: "{{workflow.name}} failed: {{workflow.failures}}"
+  - name: %s
+    http:
+      url: http://example.com
+      body: "%s"
+  templates:
+  - name: main
+    container:
+      image: alpine
+      command: [echo, hello]
+`
+
+func TestNotifications(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(notificationsWorkflow, "webhook", "{{workflow.status}}"))
+		assert.NoError(t, err)
+	})
+	t.Run("DuplicateName", func(t *testing.T) {
+		err := validate(fmt.Sprintf(notificationsWorkflow, "slack", "{{workflow.status}}"))
+		assert.EqualError(t, err, "spec.notifications[1].name 'slack' is not unique")
+	})
+	t.Run("UnresolvedVariable", func(t *testing.T) {
+		err := validate(fmt.Sprintf(notificationsWorkflow, "webhook", "{{steps.main.outputs.result}}"))
+		assert.EqualError(t, err, "spec.notifications failed to resolve {{steps.main.outputs.result}}")
+	})
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: notification-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    notification:
+      http: {}
+`)
+		assert.EqualError(t, err, "templates.main.notification.http.url must be specified")
+	})
+}
+
+var gpuTemplate = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: gpu-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    gpu:
+%s
+    container:
+      image: nvidia/cuda
+      command: [nvidia-smi]
+`
+
+func TestGPUTemplate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(gpuTemplate, "      count: 2"))
+		assert.NoError(t, err)
+	})
+	t.Run("MIGProfile", func(t *testing.T) {
+		err := validate(fmt.Sprintf(gpuTemplate, "      count: 1\n      vendor: amd\n      migProfile: 1g.5gb"))
+		assert.EqualError(t, err, "templates.main.gpu.migProfile is only supported by the nvidia vendor")
+	})
+	t.Run("NotAContainer", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: gpu-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    gpu:
+      count: 1
+    suspend: {}
+`)
+		assert.EqualError(t, err, "templates.main.gpu is only supported by container and script templates")
+	})
+}
+
+func TestTemplateDisruption(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: disruption-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    podDisruptionBudget:
+      maxUnavailable: 0
+    disruptionSensitive: true
+    container:
+      image: alpine
+      command: [sleep, "3600"]
+`)
+		assert.NoError(t, err)
+	})
+	t.Run("PodDisruptionBudgetNotAPod", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: disruption-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    podDisruptionBudget:
+      maxUnavailable: 0
+    suspend: {}
+`)
+		assert.EqualError(t, err, "templates.main.podDisruptionBudget is only supported by templates that run pods")
+	})
+	t.Run("DisruptionSensitiveNotAPod", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: disruption-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    disruptionSensitive: true
+    http:
+      url: http://example.com
+`)
+		assert.EqualError(t, err, "templates.main.disruptionSensitive is only supported by templates that run pods")
+	})
+}
+
+var httpOutputArtifact = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: http-output-artifact-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    outputs:
+      artifacts:
+      - name: %s
+    http:
+      url: http://my-url
+      outputArtifact: body
+      retryOn: [5xx, "429"]
+`
+
+func TestHTTPOutputArtifact(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(httpOutputArtifact, "body"))
+		assert.NoError(t, err)
+	})
+	t.Run("NotOutputArtifact", func(t *testing.T) {
+		err := validate(fmt.Sprintf(httpOutputArtifact, "other"))
+		assert.EqualError(t, err, "templates.main.outputs.artifacts.other must be the http.outputArtifact")
+	})
+}
+
+var inputArtifactValidation = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: input-artifact-validation-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    inputs:
+      artifacts:
+      - name: data
+        path: /tmp/data
+        optional: %v
+        raw:
+          data: hello
+        validation:
+          required: true
+          minSizeBytes: %d
+          mediaType: text/plain
+    container:
+      image: argoproj/argosay:v2
+`
+
+func TestInputArtifactValidation(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(inputArtifactValidation, false, 1))
+		assert.NoError(t, err)
+	})
+	t.Run("Optional", func(t *testing.T) {
+		err := validate(fmt.Sprintf(inputArtifactValidation, true, 1))
+		assert.EqualError(t, err, "templates.main.inputs.artifacts.data.validation.required cannot be used with optional artifacts")
+	})
+	t.Run("NegativeMinSizeBytes", func(t *testing.T) {
+		err := validate(fmt.Sprintf(inputArtifactValidation, false, -1))
+		assert.EqualError(t, err, "templates.main.inputs.artifacts.data.validation.minSizeBytes must not be negative")
+	})
+}
+
+var resourceServerSideApply = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: resource-server-side-apply-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    resource:
+      action: %s
+      serverSideApply: true
+      successExpression: %s
+      waitTimeout: 10m
+      manifest: |
+        apiVersion: v1
+        kind: ConfigMap
+        metadata:
+          name: my-config-map
+`
+
+func TestResourceServerSideApply(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(fmt.Sprintf(resourceServerSideApply, "apply", `"hasCondition('Ready', 'True')"`))
+		assert.NoError(t, err)
+	})
+	t.Run("NotApply", func(t *testing.T) {
+		err := validate(fmt.Sprintf(resourceServerSideApply, "create", `"hasCondition('Ready', 'True')"`))
+		assert.EqualError(t, err, "templates.main.resource.serverSideApply is only valid with the apply action")
+	})
+	t.Run("InvalidExpression", func(t *testing.T) {
+		err := validate(fmt.Sprintf(resourceServerSideApply, "apply", `"resource.status.phase =="`))
+		assert.ErrorContains(t, err, "templates.main.resource.successExpression is invalid")
+	})
+}
+
+var dataTemplateWithMultipleOperations = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: data-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    data:
+      source:
+        parameter: '["a", "b"]'
+      transformation:
+      - map: "item + '.txt'"
+        unique: true
+`
+
+func TestDataTemplateTransformationValidation(t *testing.T) {
+	err := validate(dataTemplateWithMultipleOperations)
+	assert.EqualError(t, err, "templates.main.data.transformation[0] must have only one of expression, map, flatten, unique, sort, reduce or join")
+	err = validate(strings.Replace(dataTemplateWithMultipleOperations, "        unique: true\n", "      - {}\n", 1))
+	assert.EqualError(t, err, "templates.main.data.transformation[1] must have one of expression, map, flatten, unique, sort, reduce or join")
+}
+
+var workflowWithDuplicatePodEnv = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pod-env-
+spec:
+  entrypoint: main
+  podEnv:
+  - name: FOO
+    value: foo
+  - name: FOO
+    value: bar
+  templates:
+  - name: main
+    container:
+      image: alpine
+`
+
+func TestPodEnvValidation(t *testing.T) {
+	err := validate(workflowWithDuplicatePodEnv)
+	assert.EqualError(t, err, "podEnv[1].name 'FOO' is not unique")
+}
+
+var workflowWithInvalidPodSpecPatch = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pod-spec-patch-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    podSpecPatch: |
+      containers:
+        name: main
+    container:
+      image: alpine
+`
+
+var workflowWithParameterizedPodSpecPatch = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pod-spec-patch-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+    - name: cpu
+      value: "1"
+  podSpecPatch: '{"terminationGracePeriodSeconds": 10}'
+  templates:
+  - name: main
+    podSpecPatch: '{"containers":[{"name":"main", "resources":{"limits":{"cpu": "{{workflow.parameters.cpu}}"}}}]}'
+    container:
+      image: alpine
+`
+
+func TestPodSpecPatchValidation(t *testing.T) {
+	err := validate(workflowWithInvalidPodSpecPatch)
+	assert.EqualError(t, err, "templates.main.podSpecPatch is invalid: json: cannot unmarshal object into Go struct field PodSpec.containers of type []v1.Container")
+	err = validate(workflowWithParameterizedPodSpecPatch)
+	assert.NoError(t, err)
+}
+
+var workflowWithInvalidExpression = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: invalid-expression-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: alpine
+      args: ["{{=sprig.upper('hello'}}"]
+`
+
+func TestExpressionSyntaxValidation(t *testing.T) {
+	err := validate(workflowWithInvalidExpression)
+	assert.ErrorContains(t, err, "invalid expression {{=sprig.upper('hello'}}: unexpected token EOF")
+}
+
+var wftmplWithParameterSchema = `
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: parameter-schema
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: replicas
+        value: "1"
+        schema:
+          type: int
+          minimum: 1
+          maximum: 5
+      - name: env
+        value: dev
+        enum: [dev, prod]
+        schema:
+          pattern: "^[a-z]+$"
+  templates:
+    - name: main
+      container:
+        image: alpine
+`
+
+func TestParameterSchema(t *testing.T) {
+	t.Run("Schema", func(t *testing.T) {
+		validate := func(schema wfv1.ParameterSchema, value string) error {
+			wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{
+				Entrypoint: "main",
+				Templates:  []wfv1.Template{{Name: "main", Container: &apiv1.Container{Image: "alpine"}}},
+				Arguments:  wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "p", Value: wfv1.AnyStringPtr(value), Schema: &schema}}},
+			}}
+			return ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{})
+		}
+		one, two := int64(1), int64(2)
+		assert.NoError(t, validate(wfv1.ParameterSchema{}, "foo"))
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: "float"}, "1"), "spec.arguments.p.schema type 'float' is not one of: string, int, bool or json")
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Pattern: "("}, "1"), "spec.arguments.p.schema pattern is not a valid regular expression: error parsing regexp: missing closing ): `(`")
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Minimum: &one}, "1"), "spec.arguments.p.schema minimum and maximum are only valid for type int")
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt, Minimum: &two, Maximum: &one}, "1"), "spec.arguments.p.schema minimum must not be greater than maximum")
+		assert.NoError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt, Minimum: &one, Maximum: &two}, "2"))
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt}, "1.5"), "spec.arguments.p.value '1.5' is not an int")
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt, Minimum: &two}, "1"), "spec.arguments.p.value 1 is less than the minimum 2")
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt, Maximum: &one}, "2"), "spec.arguments.p.value 2 is greater than the maximum 1")
+		assert.NoError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeBool}, "true"))
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeBool}, "yes"), "spec.arguments.p.value 'yes' is not a bool, it must be true or false")
+		assert.NoError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeJSON}, `{"a": 1}`))
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeJSON}, `{"a": }`), `spec.arguments.p.value '{"a": }' is not JSON`)
+		assert.EqualError(t, validate(wfv1.ParameterSchema{Pattern: "^[a-z]+$"}, "Foo"), "spec.arguments.p.value 'Foo' does not match the pattern '^[a-z]+$'")
+		assert.NoError(t, validate(wfv1.ParameterSchema{Type: wfv1.ParameterTypeInt}, "{{workflow.name}}"), "variables are not validated")
+	})
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		assert.NoError(t, validateWorkflowTemplate(wftmplWithParameterSchema, ValidateOpts{}))
+		assert.NoError(t, createWorkflowTemplateFromSpec(wftmplWithParameterSchema))
+		defer func() { _ = deleteWorkflowTemplate("parameter-schema") }()
+		validate := func(params ...wfv1.Parameter) error {
+			wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{
+				WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "parameter-schema"},
+				Arguments:           wfv1.Arguments{Parameters: params},
+			}}
+			return ValidateWorkflow(wftmplGetter, cwftmplGetter, wf, ValidateOpts{Submit: true})
+		}
+		assert.NoError(t, validate())
+		assert.NoError(t, validate(wfv1.Parameter{Name: "replicas", Value: wfv1.AnyStringPtr("5")}, wfv1.Parameter{Name: "env", Value: wfv1.AnyStringPtr("prod")}))
+		assert.EqualError(t, validate(wfv1.Parameter{Name: "replicas", Value: wfv1.AnyStringPtr("6")}), "spec.arguments.replicas.value 6 is greater than the maximum 5")
+		assert.EqualError(t, validate(wfv1.Parameter{Name: "env", Value: wfv1.AnyStringPtr("test")}), "spec.arguments.env.value should be present in spec.arguments.env.enum list")
+	})
+}
+
+func TestNamespaceRestrictions(t *testing.T) {
+	t.Run("ClusterWorkflowTemplate", func(t *testing.T) {
+		cwftmpl := &wfv1.ClusterWorkflowTemplate{Spec: wfv1.WorkflowSpec{NamespaceRestrictions: &wfv1.NamespaceRestrictions{Allow: []string{"team-*"}}}}
+		assert.NoError(t, ValidateClusterWorkflowTemplate(wftmplGetter, cwftmplGetter, cwftmpl, ValidateOpts{}))
+		cwftmpl.Spec.NamespaceRestrictions.Deny = []string{"team-["}
+		err := ValidateClusterWorkflowTemplate(wftmplGetter, cwftmplGetter, cwftmpl, ValidateOpts{})
+		assert.EqualError(t, err, "spec.namespaceRestrictions.deny[0] 'team-[' is not a valid pattern: syntax error in pattern")
+	})
+	t.Run("WorkflowTemplate", func(t *testing.T) {
+		wftmpl := &wfv1.WorkflowTemplate{Spec: wfv1.WorkflowSpec{NamespaceRestrictions: &wfv1.NamespaceRestrictions{}}}
+		err := ValidateWorkflowTemplate(wftmplGetter, cwftmplGetter, wftmpl, ValidateOpts{})
+		assert.EqualError(t, err, "spec.namespaceRestrictions is only valid for cluster workflow templates")
+	})
+}
+
+func TestTemplateImagePullSecrets(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: image-pull-secrets-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    imagePullSecrets:
+    - name: my-secret
+    container:
+      image: ghcr.io/my-org/my-image
+`)
+		assert.NoError(t, err)
+	})
+	t.Run("NotAPod", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: image-pull-secrets-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    imagePullSecrets:
+    - name: my-secret
+    suspend: {}
+`)
+		assert.EqualError(t, err, "templates.main.imagePullSecrets is only supported by templates that run pods")
+	})
+}
+
+func TestTemplateCluster(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: cluster-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    cluster: my-cluster
+    container:
+      image: argoproj/argosay:v2
+`)
+		assert.NoError(t, err)
+	})
+	t.Run("NotAPod", func(t *testing.T) {
+		err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: cluster-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    cluster: my-cluster
+    suspend: {}
+`)
+		assert.EqualError(t, err, "templates.main.cluster is only supported by templates that run pods")
+	})
+}
+
+func TestTemplateWorkloadClass(t *testing.T) {
+	err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: workload-class-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    workloadClass: gpu
+    steps: []
+`)
+	assert.EqualError(t, err, "templates.main.workloadClass is only supported by templates that run pods")
+}
+
+func TestServiceMesh(t *testing.T) {
+	wf := func(meshType string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: service-mesh-
+spec:
+  entrypoint: main
+  serviceMesh:
+    type: ` + meshType + `
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+	}
+	assert.NoError(t, validate(wf("istio")))
+	assert.NoError(t, validate(wf("linkerd")))
+	assert.EqualError(t, validate(wf("consul")), "serviceMesh.type unknown type 'consul', must be istio or linkerd")
+}
+
+func TestTemplateResources(t *testing.T) {
+	wf := func(memory, tmplType string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: template-resources-
+spec:
+  entrypoint: main
+  arguments:
+    parameters:
+      - name: memory
+        value: ` + memory + `
+  templates:
+  - name: main
+    steps:
+    - - name: work
+        template: work
+        arguments:
+          parameters:
+            - name: cpu
+              value: "{{workflow.name}}"
+  - name: work
+    inputs:
+      parameters:
+        - name: cpu
+    resources:
+      limits:
+        memory: "{{workflow.parameters.memory}}"
+      requests:
+        cpu: "{{inputs.parameters.cpu}}"
+` + tmplType
+	}
+	container := `
+    container:
+      image: my-image
+`
+	assert.NoError(t, validate(wf("2Gi", container)))
+	assert.EqualError(t, validate(wf("lots", container)), `templates.main.steps[0].work templates.work.resources.limits.memory "lots" is not a valid quantity: quantities must match the regular expression '^([+-]?[0-9.]+)([eEinumkKMGTP]*[-+]?[0-9]*)$'`)
+	assert.EqualError(t, validate(wf("2Gi", `
+    suspend: {}
+`)), "templates.main.steps[0].work templates.work.resources are only supported by container, containerSet and script templates")
+}
+
+func TestTemplateOverrides(t *testing.T) {
+	wf := func(fields, tmplType string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: overrides-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+` + fields + tmplType
+	}
+	container := `
+    container:
+      image: vendor/image
+`
+	t.Run("Valid", func(t *testing.T) {
+		err := validate(wf(`
+    workingDirOverride: /mnt/work/out
+    runAsUserOverride: 1000`, container))
+		assert.NoError(t, err)
+	})
+	t.Run("RelativeWorkingDir", func(t *testing.T) {
+		err := validate(wf(`
+    workingDirOverride: work`, container))
+		assert.EqualError(t, err, "templates.main.workingDirOverride 'work' must be an absolute path")
+	})
+	t.Run("NegativeRunAsUser", func(t *testing.T) {
+		err := validate(wf(`
+    runAsUserOverride: -1`, container))
+		assert.EqualError(t, err, "templates.main.runAsUserOverride must not be negative")
+	})
+	t.Run("NotAContainer", func(t *testing.T) {
+		err := validate(wf(`
+    runAsUserOverride: 1000`, `
+    suspend: {}
+`))
+		assert.EqualError(t, err, "templates.main.workingDirOverride and runAsUserOverride are only supported by container, containerSet and script templates")
+	})
+}
+
+func TestRetryStrategyBackoffJitter(t *testing.T) {
+	wf := func(jitter string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: retry-backoff-jitter-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    retryStrategy:
+      limit: 3
+      backoff:
+        duration: 10s
+        jitter: ` + jitter + `
+    container:
+      image: argoproj/argosay:v2
+`
+	}
+	assert.NoError(t, validate(wf("0.2")))
+	assert.EqualError(t, validate(wf("1.5")), "templates.main.retryStrategy.backoff: jitter 1.5 must be between 0 and 1")
+}
+
+func TestOnExitFailedNodes(t *testing.T) {
+	err := validate(`
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: exit-handler-
+spec:
+  entrypoint: main
+  onExit: exit-handler
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+  - name: exit-handler
+    dag:
+      tasks:
+      - name: report
+        template: report
+        arguments:
+          parameters:
+          - name: message
+            value: "{{item.name}}: {{item.message}}"
+        withParam: "{{workflow.failedNodes}}"
+  - name: report
+    inputs:
+      parameters:
+      - name: message
+    container:
+      image: argoproj/argosay:v2
+      args: [echo, "{{inputs.parameters.message}}"]
+`)
+	assert.NoError(t, err)
+}
+
+func TestPipeArtifacts(t *testing.T) {
+	wf := func(mountPath, pipe string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pipe-artifacts-
+spec:
+  entrypoint: main
+  volumes:
+  - name: workdir
+    emptyDir: {}
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: produce
+        template: produce
+      - name: consume
+        template: consume
+        depends: produce.Streaming
+        arguments:
+          artifacts:
+          - name: in
+            from: "{{tasks.produce.outputs.artifacts.out}}"
+  - name: produce
+    container:
+      image: my-image
+      volumeMounts:
+      - name: workdir
+        mountPath: ` + mountPath + `
+    outputs:
+      artifacts:
+      - name: out
+        path: /work/out
+        pipe:
+` + pipe + `
+  - name: consume
+    inputs:
+      artifacts:
+      - name: in
+        path: /in
+    container:
+      image: my-image
+`
+	}
+	assert.NoError(t, validate(wf("/work", "          volume: workdir")))
+	assert.EqualError(t, validate(wf("/other", "          volume: workdir")), "templates.main.tasks.produce templates.produce.outputs.artifacts.out.pipe path '/work/out' is not within a mount of volume 'workdir'")
+	assert.EqualError(t, validate(wf("/work", "          subPath: out")), "templates.main.tasks.produce templates.produce.outputs.artifacts.out.pipe volume is required")
+}
+
+func TestSuspendApproval(t *testing.T) {
+	wf := func(suspend string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: suspend-approval-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    suspend:
+` + suspend
+	}
+	assert.NoError(t, validate(wf(`
+      approval:
+        groups: [approvers]
+        minApprovals: 2
+        expiry: 24h
+`)))
+	assert.EqualError(t, validate(wf(`
+      approval:
+        groups: []
+`)), "templates.main.suspend.approval.groups must not be empty")
+	assert.EqualError(t, validate(wf(`
+      approval:
+        groups: [approvers]
+        minApprovals: -1
+`)), "templates.main.suspend.approval.minApprovals must not be negative")
+	assert.EqualError(t, validate(wf(`
+      approval:
+        groups: [approvers]
+        expiry: tomorrow
+`)), "templates.main.suspend.approval.expiry 'tomorrow' is not a valid duration")
+	assert.EqualError(t, validate(wf(`
+      duration: 10s
+      approval:
+        groups: [approvers]
+`)), "templates.main.suspend.approval cannot be used with a duration, use approval.expiry instead")
+}
+
+func TestArtifactRepositoryRefName(t *testing.T) {
+	wf := func(ref string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: artifact-repository-ref-
+spec:
+  entrypoint: main
+  artifactRepositoryRef:
+` + ref + `
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+	}
+	assert.NoError(t, validate(wf(`    name: my-repo`)))
+	assert.NoError(t, validate(wf(`    name: my-repo
+    clusterScope: true`)))
+	assert.EqualError(t, validate(wf(`    name: my-repo
+    configMap: my-config-map`)), "spec.artifactRepositoryRef.name cannot be combined with configMap or key")
+}
+
+func TestArtifactFromWorkflow(t *testing.T) {
+	wf := func(fromWorkflow string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: from-workflow-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    inputs:
+      artifacts:
+      - name: report
+        path: /tmp/report
+        fromWorkflow:
+` + fromWorkflow + `
+    container:
+      image: argoproj/argosay:v2
+`
+	}
+	assert.NoError(t, validate(wf(`
+          selector:
+            matchLabels:
+              pipeline: daily
+          artifactName: report
+          strategy: Latest`)))
+	assert.EqualError(t, validate(wf(`
+          artifactName: report`)), "templates.main.inputs.artifacts.report.fromWorkflow selector is required")
+	assert.EqualError(t, validate(wf(`
+          selector:
+            matchLabels:
+              pipeline: daily`)), "templates.main.inputs.artifacts.report.fromWorkflow artifactName is required")
+	assert.EqualError(t, validate(wf(`
+          selector:
+            matchLabels:
+              pipeline: daily
+          artifactName: report
+          strategy: Oldest`)), `templates.main.inputs.artifacts.report.fromWorkflow strategy "Oldest" is not one of: Latest`)
+}
+
+func TestPodLimit(t *testing.T) {
+	wf := func(podLimit string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: pod-limit-
+spec:
+  entrypoint: main
+  podLimit:
+` + podLimit + `
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+	}
+	assert.NoError(t, validate(wf(`    max: 100`)))
+	assert.NoError(t, validate(wf(`    max: 100
+    action: Suspend`)))
+	assert.EqualError(t, validate(wf(`    max: 0`)), "spec.podLimit.max must be greater than zero")
+	assert.EqualError(t, validate(wf(`    max: 100
+    action: Ignore`)), "spec.podLimit.action must be Fail or Suspend")
+}
+
+func TestVolumeClaimGC(t *testing.T) {
+	wf := func(volumeClaimGC string) string {
+		return `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: volume-claim-gc-
+spec:
+  entrypoint: main
+  volumeClaimGC:
+` + volumeClaimGC + `
+  templates:
+  - name: main
+    container:
+      image: argoproj/argosay:v2
+`
+	}
+	assert.NoError(t, validate(wf(`    strategy: OnWorkflowDeletion`)))
+	assert.NoError(t, validate(wf(`    strategy: OnWorkflowCompletion
+    olderThan: 1h
+    resizeOnRetry: true`)))
+	assert.EqualError(t, validate(wf(`    strategy: Never`)), "spec.volumeClaimGC.strategy must be OnWorkflowCompletion, OnWorkflowSuccess or OnWorkflowDeletion")
+	assert.EqualError(t, validate(wf(`    olderThan: -1h`)), "spec.volumeClaimGC.olderThan must not be negative")
+}